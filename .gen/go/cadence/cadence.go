@@ -74,6 +74,15 @@ type WorkflowService interface {  //WorkflowService API is exposed to provide su
   // Parameters:
   //  - DeprecateRequest
   DeprecateDomain(deprecateRequest *shared.DeprecateDomainRequest) (err error)
+  // DrainDomain is used to update status of a registered domain to DRAINING.  A draining domain rejects new workflow
+  // executions while the existing ones run to completion, including the child executions and runs continued as new
+  // they start, so the domain can be decommissioned or migrated once it has no open executions left.  Setting cancel
+  // on the request puts a draining domain back to REGISTERED.
+  // 
+  // 
+  // Parameters:
+  //  - DrainRequest
+  DrainDomain(drainRequest *shared.DrainDomainRequest) (err error)
   // StartWorkflowExecution starts a new long running workflow instance.  It will create the instance with
   // 'WorkflowExecutionStarted' event in history and also schedule the first DecisionTask for the worker to make the
   // first decision for this instance.  It will return 'WorkflowExecutionAlreadyStartedError', if an instance already
@@ -104,12 +113,13 @@ type WorkflowService interface {  //WorkflowService API is exposed to provide su
   // 'PollForDecisionTask' API call.  Completing a DecisionTask will result in new events for the workflow execution and
   // potentially new ActivityTask being created for corresponding decisions.  It will also create a DecisionTaskCompleted
   // event in the history for that session.  Use the 'taskToken' provided as response of PollForDecisionTask API call
-  // for completing the DecisionTask.
+  // for completing the DecisionTask.  Activities scheduled with 'requestLocalDispatch' in the same domain are started
+  // right away and returned in the response, for the worker to run them without polling.
   // 
   // 
   // Parameters:
   //  - CompleteRequest
-  RespondDecisionTaskCompleted(completeRequest *shared.RespondDecisionTaskCompletedRequest) (err error)
+  RespondDecisionTaskCompleted(completeRequest *shared.RespondDecisionTaskCompletedRequest) (r *shared.RespondDecisionTaskCompletedResponse, err error)
   // PollForActivityTask is called by application worker to process ActivityTask from a specific taskList.  ActivityTask
   // is dispatched to callers whenever a ScheduleTask decision is made for a workflow execution.
   // Application is expected to call 'RespondActivityTaskCompleted' or 'RespondActivityTaskFailed' once it is done
@@ -162,6 +172,31 @@ type WorkflowService interface {  //WorkflowService API is exposed to provide su
   // Parameters:
   //  - CanceledRequest
   RespondActivityTaskCanceled(canceledRequest *shared.RespondActivityTaskCanceledRequest) (err error)
+  // RespondActivityTaskCompletedByID is called by application to complete an ActivityTask it doesn't hold the
+  // 'taskToken' of, for instance when the work is done asynchronously by an external system.  The activity is located
+  // by the domain, workflowID, runID and activityID instead.  An empty runID completes the activity of the current run
+  // of the workflow.  It fails with 'EntityNotExistsError' if the activity is not pending or not started.
+  // 
+  // 
+  // Parameters:
+  //  - CompleteRequest
+  RespondActivityTaskCompletedByID(completeRequest *shared.RespondActivityTaskCompletedByIDRequest) (err error)
+  // RespondActivityTaskFailedByID is called by application to fail an ActivityTask located by the domain, workflowID,
+  // runID and activityID instead of the 'taskToken'.  It fails with 'EntityNotExistsError' if the activity is not
+  // pending or not started.
+  // 
+  // 
+  // Parameters:
+  //  - FailedRequest
+  RespondActivityTaskFailedByID(failedRequest *shared.RespondActivityTaskFailedByIDRequest) (err error)
+  // RespondActivityTaskCanceledByID is called by application to cancel an ActivityTask located by the domain,
+  // workflowID, runID and activityID instead of the 'taskToken'.  It fails with 'EntityNotExistsError' if the activity
+  // is not pending or not started.
+  // 
+  // 
+  // Parameters:
+  //  - CanceledRequest
+  RespondActivityTaskCanceledByID(canceledRequest *shared.RespondActivityTaskCanceledByIDRequest) (err error)
   // RequestCancelWorkflowExecution is called by application worker when it wants to request cancellation of a workflow instance.
   // It will result in a new 'WorkflowExecutionCancelRequested' event being written to the workflow history and a new DecisionTask
   // created for the workflow instance so new decisions could be made. It fails with 'EntityNotExistsError' if the workflow is not valid
@@ -173,30 +208,102 @@ type WorkflowService interface {  //WorkflowService API is exposed to provide su
   RequestCancelWorkflowExecution(cancelRequest *shared.RequestCancelWorkflowExecutionRequest) (err error)
   // SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
   // WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
+  // The response is the receipt of the signal: the run which recorded it and the ID of its event.  The event ID is
+  // not set while the signal is held back behind a decision task in flight, it is assigned once the decision
+  // completes.  Signals carrying a 'requestId' are recorded at most once per run, retrying one which was already
+  // recorded returns its original receipt.
   // 
   // 
   // Parameters:
   //  - SignalRequest
-  SignalWorkflowExecution(signalRequest *shared.SignalWorkflowExecutionRequest) (err error)
-  // TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
-  // in the history and immediately terminating the execution instance.
+  SignalWorkflowExecution(signalRequest *shared.SignalWorkflowExecutionRequest) (r *shared.SignalWorkflowExecutionResponse, err error)
+  // GetSignalReceipt tells whether the signal sent with the 'requestId' was recorded by the workflow execution, and
+  // returns its receipt if it was.  Senders which lost the response of SignalWorkflowExecution check it before
+  // retrying the signal to deliver it exactly once.  Receipts are kept by the run which recorded the signal, the
+  // current run is checked when the execution has no 'runId'.
+  // 
+  // 
+  // Parameters:
+  //  - ReceiptRequest
+  GetSignalReceipt(receiptRequest *shared.GetSignalReceiptRequest) (r *shared.GetSignalReceiptResponse, err error)
+  // QueryWorkflow is used to query the state of a running workflow execution.  The query is handed to the worker along
+  // with the next decision task of the execution in the 'queries' of PollForDecisionTaskResponse, and the worker answers
+  // it in the 'queryResults' of RespondDecisionTaskCompleted.  Without a decision task waiting for a poller, the query
+  // comes with a decision task of its own which has no new events, its completion can't carry decisions.  It fails with 'QueryFailedError' if the worker failed the
+  // query or didn't answer it.
+  // 
+  // 
+  // Parameters:
+  //  - QueryRequest
+  QueryWorkflow(queryRequest *shared.QueryWorkflowRequest) (r *shared.QueryWorkflowResponse, err error)
+  // DescribeWorkflowExecution returns information about the specified workflow execution, including the activities
+  // still pending on it.  Started activities are waiting to be completed, either by the worker which polled them or
+  // externally with the activity ID based APIs, until their 'expirationTimestamp'.  Activities past it without a
+  // completion have lost it and will time out.
+  // 
+  // 
+  // Parameters:
+  //  - DescribeRequest
+  DescribeWorkflowExecution(describeRequest *shared.DescribeWorkflowExecutionRequest) (r *shared.DescribeWorkflowExecutionResponse, err error)
+  // TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminatedByOperator
+  // event in the history and immediately terminating the execution instance.  Running child executions are left
+  // alone, unless the request sets a 'childPolicy' to terminate or cancel them.  All the executions affected are
+  // returned.
   // 
   // 
   // Parameters:
   //  - TerminateRequest
-  TerminateWorkflowExecution(terminateRequest *shared.TerminateWorkflowExecutionRequest) (err error)
+  TerminateWorkflowExecution(terminateRequest *shared.TerminateWorkflowExecutionRequest) (r *shared.TerminateWorkflowExecutionResponse, err error)
   // ListOpenWorkflowExecutions is a visibility API to list the open executions in a specific domain.
   // 
   // 
   // Parameters:
   //  - ListRequest
   ListOpenWorkflowExecutions(listRequest *shared.ListOpenWorkflowExecutionsRequest) (r *shared.ListOpenWorkflowExecutionsResponse, err error)
-  // ListClosedWorkflowExecutions is a visibility API to list the closed executions in a specific domain.
+  // ListClosedWorkflowExecutions is a visibility API to list the closed executions in a specific domain.  Executions
+  // are listed by start time range, or by close time range when CloseTimeFilter is set on the request.
   // 
   // 
   // Parameters:
   //  - ListRequest
   ListClosedWorkflowExecutions(listRequest *shared.ListClosedWorkflowExecutionsRequest) (r *shared.ListClosedWorkflowExecutionsResponse, err error)
+  // ListClosedWorkflowExecutionsSince is a visibility API to tail the executions closed in a specific domain.  It lists
+  // the executions closed after the ones already returned, in close time order, starting at 'earliestCloseTime' on the
+  // first call.  Pass the 'closedSinceToken' of the response to the next call to continue, the token is returned even
+  // when no execution closed since the last call.
+  // 
+  // 
+  // Parameters:
+  //  - ListRequest
+  ListClosedWorkflowExecutionsSince(listRequest *shared.ListClosedWorkflowExecutionsSinceRequest) (r *shared.ListClosedWorkflowExecutionsSinceResponse, err error)
+  // DescribeCluster returns the metadata of the cluster: the server version, the number of history shards, the client
+  // features it supports and the type of its persistence store.  SDKs and tools use it to negotiate capabilities.
+  // 
+  DescribeCluster() (r *shared.DescribeClusterResponse, err error)
+  // RefreshWorkflowTasks is an admin API to re-generate the transfer and timer tasks of a running workflow execution
+  // from its mutable state.  It recovers executions stuck because one of their tasks was lost or dropped.
+  // 
+  // 
+  // Parameters:
+  //  - RefreshRequest
+  RefreshWorkflowTasks(refreshRequest *shared.RefreshWorkflowTasksRequest) (err error)
+  // MigrateWorkflowExecution is an admin API to move a closed workflow execution, its history and its visibility
+  // record, to another domain of the cluster, to support reorganizing tenants.  The workflow ID must not be in use
+  // in the target domain.  Running executions and moves to another cluster are not supported.
+  // 
+  // 
+  // Parameters:
+  //  - MigrateRequest
+  MigrateWorkflowExecution(migrateRequest *shared.MigrateWorkflowExecutionRequest) (err error)
+  // VerifyHistory is an admin API to run structural validation over a serialized workflow history: event id
+  // continuity, the ordering of the history versions and the pairing of every started, completed, failed or timed out
+  // event with the event that initiated it.  Every violation found is returned, instead of the engine panicking on the
+  // first one while replaying the history.
+  // 
+  // 
+  // Parameters:
+  //  - VerifyRequest
+  VerifyHistory(verifyRequest *shared.VerifyHistoryRequest) (r *shared.VerifyHistoryResponse, err error)
 }
 
 //WorkflowService API is exposed to provide support for long running applications.  Application is expected to call
@@ -591,6 +698,97 @@ func (p *WorkflowServiceClient) recvDeprecateDomain() (err error) {
   return
 }
 
+// DrainDomain is used to update status of a registered domain to DRAINING.  A draining domain rejects new workflow
+// executions while the existing ones run to completion, including the child executions and runs continued as new
+// they start, so the domain can be decommissioned or migrated once it has no open executions left.  Setting cancel
+// on the request puts a draining domain back to REGISTERED.
+// 
+// 
+// Parameters:
+//  - DrainRequest
+func (p *WorkflowServiceClient) DrainDomain(drainRequest *shared.DrainDomainRequest) (err error) {
+  if err = p.sendDrainDomain(drainRequest); err != nil { return }
+  return p.recvDrainDomain()
+}
+
+func (p *WorkflowServiceClient) sendDrainDomain(drainRequest *shared.DrainDomainRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("DrainDomain", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := WorkflowServiceDrainDomainArgs{
+  DrainRequest : drainRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *WorkflowServiceClient) recvDrainDomain() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "DrainDomain" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "DrainDomain failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "DrainDomain failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error8 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error9 error
+    error9, err = error8.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error9
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "DrainDomain failed: invalid message type")
+    return
+  }
+  result := WorkflowServiceDrainDomainResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  }
+  return
+}
+
 // StartWorkflowExecution starts a new long running workflow instance.  It will create the instance with
 // 'WorkflowExecutionStarted' event in history and also schedule the first DecisionTask for the worker to make the
 // first decision for this instance.  It will return 'WorkflowExecutionAlreadyStartedError', if an instance already
@@ -646,16 +844,16 @@ func (p *WorkflowServiceClient) recvStartWorkflowExecution() (value *shared.Star
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error8 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error9 error
-    error9, err = error8.Read(iprot)
+    error10 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error11 error
+    error11, err = error10.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error9
+    err = error11
     return
   }
   if mTypeId != thrift.REPLY {
@@ -678,6 +876,12 @@ func (p *WorkflowServiceClient) recvStartWorkflowExecution() (value *shared.Star
   } else   if result.SessionAlreadyExistError != nil {
     err = result.SessionAlreadyExistError
     return 
+  } else   if result.LimitExceededError != nil {
+    err = result.LimitExceededError
+    return 
+  } else   if result.DomainNotActiveError != nil {
+    err = result.DomainNotActiveError
+    return 
   }
   value = result.GetSuccess()
   return
@@ -736,16 +940,16 @@ func (p *WorkflowServiceClient) recvGetWorkflowExecutionHistory() (value *shared
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error10 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error11 error
-    error11, err = error10.Read(iprot)
+    error12 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error13 error
+    error13, err = error12.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error11
+    err = error13
     return
   }
   if mTypeId != thrift.REPLY {
@@ -829,16 +1033,16 @@ func (p *WorkflowServiceClient) recvPollForDecisionTask() (value *shared.PollFor
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error12 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error13 error
-    error13, err = error12.Read(iprot)
+    error14 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error15 error
+    error15, err = error14.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error13
+    err = error15
     return
   }
   if mTypeId != thrift.REPLY {
@@ -858,6 +1062,9 @@ func (p *WorkflowServiceClient) recvPollForDecisionTask() (value *shared.PollFor
   } else   if result.InternalServiceError != nil {
     err = result.InternalServiceError
     return 
+  } else   if result.ServiceBusyError != nil {
+    err = result.ServiceBusyError
+    return 
   }
   value = result.GetSuccess()
   return
@@ -867,12 +1074,13 @@ func (p *WorkflowServiceClient) recvPollForDecisionTask() (value *shared.PollFor
 // 'PollForDecisionTask' API call.  Completing a DecisionTask will result in new events for the workflow execution and
 // potentially new ActivityTask being created for corresponding decisions.  It will also create a DecisionTaskCompleted
 // event in the history for that session.  Use the 'taskToken' provided as response of PollForDecisionTask API call
-// for completing the DecisionTask.
+// for completing the DecisionTask.  Activities scheduled with 'requestLocalDispatch' in the same domain are started
+// right away and returned in the response, for the worker to run them without polling.
 // 
 // 
 // Parameters:
 //  - CompleteRequest
-func (p *WorkflowServiceClient) RespondDecisionTaskCompleted(completeRequest *shared.RespondDecisionTaskCompletedRequest) (err error) {
+func (p *WorkflowServiceClient) RespondDecisionTaskCompleted(completeRequest *shared.RespondDecisionTaskCompletedRequest) (r *shared.RespondDecisionTaskCompletedResponse, err error) {
   if err = p.sendRespondDecisionTaskCompleted(completeRequest); err != nil { return }
   return p.recvRespondDecisionTaskCompleted()
 }
@@ -900,7 +1108,7 @@ func (p *WorkflowServiceClient) sendRespondDecisionTaskCompleted(completeRequest
 }
 
 
-func (p *WorkflowServiceClient) recvRespondDecisionTaskCompleted() (err error) {
+func (p *WorkflowServiceClient) recvRespondDecisionTaskCompleted() (value *shared.RespondDecisionTaskCompletedResponse, err error) {
   iprot := p.InputProtocol
   if iprot == nil {
     iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -919,16 +1127,16 @@ func (p *WorkflowServiceClient) recvRespondDecisionTaskCompleted() (err error) {
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error14 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error15 error
-    error15, err = error14.Read(iprot)
+    error16 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error17 error
+    error17, err = error16.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error15
+    err = error17
     return
   }
   if mTypeId != thrift.REPLY {
@@ -952,6 +1160,7 @@ func (p *WorkflowServiceClient) recvRespondDecisionTaskCompleted() (err error) {
     err = result.EntityNotExistError
     return 
   }
+  value = result.GetSuccess()
   return
 }
 
@@ -1013,16 +1222,16 @@ func (p *WorkflowServiceClient) recvPollForActivityTask() (value *shared.PollFor
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error16 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error17 error
-    error17, err = error16.Read(iprot)
+    error18 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error19 error
+    error19, err = error18.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error17
+    err = error19
     return
   }
   if mTypeId != thrift.REPLY {
@@ -1042,6 +1251,9 @@ func (p *WorkflowServiceClient) recvPollForActivityTask() (value *shared.PollFor
   } else   if result.InternalServiceError != nil {
     err = result.InternalServiceError
     return 
+  } else   if result.ServiceBusyError != nil {
+    err = result.ServiceBusyError
+    return 
   }
   value = result.GetSuccess()
   return
@@ -1103,16 +1315,16 @@ func (p *WorkflowServiceClient) recvRecordActivityTaskHeartbeat() (value *shared
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error18 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error19 error
-    error19, err = error18.Read(iprot)
+    error20 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error21 error
+    error21, err = error20.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error19
+    err = error21
     return
   }
   if mTypeId != thrift.REPLY {
@@ -1135,6 +1347,9 @@ func (p *WorkflowServiceClient) recvRecordActivityTaskHeartbeat() (value *shared
   } else   if result.EntityNotExistError != nil {
     err = result.EntityNotExistError
     return 
+  } else   if result.ServiceBusyError != nil {
+    err = result.ServiceBusyError
+    return 
   }
   value = result.GetSuccess()
   return
@@ -1196,16 +1411,16 @@ func (p *WorkflowServiceClient) recvRespondActivityTaskCompleted() (err error) {
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error20 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error21 error
-    error21, err = error20.Read(iprot)
+    error22 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error23 error
+    error23, err = error22.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error21
+    err = error23
     return
   }
   if mTypeId != thrift.REPLY {
@@ -1288,16 +1503,16 @@ func (p *WorkflowServiceClient) recvRespondActivityTaskFailed() (err error) {
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error22 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error23 error
-    error23, err = error22.Read(iprot)
+    error24 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error25 error
+    error25, err = error24.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error23
+    err = error25
     return
   }
   if mTypeId != thrift.REPLY {
@@ -1380,16 +1595,16 @@ func (p *WorkflowServiceClient) recvRespondActivityTaskCanceled() (err error) {
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error24 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error25 error
-    error25, err = error24.Read(iprot)
+    error26 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error27 error
+    error27, err = error26.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error25
+    err = error27
     return
   }
   if mTypeId != thrift.REPLY {
@@ -1416,31 +1631,31 @@ func (p *WorkflowServiceClient) recvRespondActivityTaskCanceled() (err error) {
   return
 }
 
-// RequestCancelWorkflowExecution is called by application worker when it wants to request cancellation of a workflow instance.
-// It will result in a new 'WorkflowExecutionCancelRequested' event being written to the workflow history and a new DecisionTask
-// created for the workflow instance so new decisions could be made. It fails with 'EntityNotExistsError' if the workflow is not valid
-// anymore due to completion or doesn't exist.
+// RespondActivityTaskCompletedByID is called by application to complete an ActivityTask it doesn't hold the
+// 'taskToken' of, for instance when the work is done asynchronously by an external system.  The activity is located
+// by the domain, workflowID, runID and activityID instead.  An empty runID completes the activity of the current run
+// of the workflow.  It fails with 'EntityNotExistsError' if the activity is not pending or not started.
 // 
 // 
 // Parameters:
-//  - CancelRequest
-func (p *WorkflowServiceClient) RequestCancelWorkflowExecution(cancelRequest *shared.RequestCancelWorkflowExecutionRequest) (err error) {
-  if err = p.sendRequestCancelWorkflowExecution(cancelRequest); err != nil { return }
-  return p.recvRequestCancelWorkflowExecution()
+//  - CompleteRequest
+func (p *WorkflowServiceClient) RespondActivityTaskCompletedByID(completeRequest *shared.RespondActivityTaskCompletedByIDRequest) (err error) {
+  if err = p.sendRespondActivityTaskCompletedByID(completeRequest); err != nil { return }
+  return p.recvRespondActivityTaskCompletedByID()
 }
 
-func (p *WorkflowServiceClient) sendRequestCancelWorkflowExecution(cancelRequest *shared.RequestCancelWorkflowExecutionRequest)(err error) {
+func (p *WorkflowServiceClient) sendRespondActivityTaskCompletedByID(completeRequest *shared.RespondActivityTaskCompletedByIDRequest)(err error) {
   oprot := p.OutputProtocol
   if oprot == nil {
     oprot = p.ProtocolFactory.GetProtocol(p.Transport)
     p.OutputProtocol = oprot
   }
   p.SeqId++
-  if err = oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+  if err = oprot.WriteMessageBegin("RespondActivityTaskCompletedByID", thrift.CALL, p.SeqId); err != nil {
       return
   }
-  args := WorkflowServiceRequestCancelWorkflowExecutionArgs{
-  CancelRequest : cancelRequest,
+  args := WorkflowServiceRespondActivityTaskCompletedByIDArgs{
+  CompleteRequest : completeRequest,
   }
   if err = args.Write(oprot); err != nil {
       return
@@ -1452,7 +1667,7 @@ func (p *WorkflowServiceClient) sendRequestCancelWorkflowExecution(cancelRequest
 }
 
 
-func (p *WorkflowServiceClient) recvRequestCancelWorkflowExecution() (err error) {
+func (p *WorkflowServiceClient) recvRespondActivityTaskCompletedByID() (err error) {
   iprot := p.InputProtocol
   if iprot == nil {
     iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -1462,32 +1677,32 @@ func (p *WorkflowServiceClient) recvRequestCancelWorkflowExecution() (err error)
   if err != nil {
     return
   }
-  if method != "RequestCancelWorkflowExecution" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RequestCancelWorkflowExecution failed: wrong method name")
+  if method != "RespondActivityTaskCompletedByID" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondActivityTaskCompletedByID failed: wrong method name")
     return
   }
   if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RequestCancelWorkflowExecution failed: out of sequence response")
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondActivityTaskCompletedByID failed: out of sequence response")
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error26 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error27 error
-    error27, err = error26.Read(iprot)
+    error28 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error29 error
+    error29, err = error28.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error27
+    err = error29
     return
   }
   if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RequestCancelWorkflowExecution failed: invalid message type")
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondActivityTaskCompletedByID failed: invalid message type")
     return
   }
-  result := WorkflowServiceRequestCancelWorkflowExecutionResult{}
+  result := WorkflowServiceRespondActivityTaskCompletedByIDResult{}
   if err = result.Read(iprot); err != nil {
     return
   }
@@ -1507,29 +1722,30 @@ func (p *WorkflowServiceClient) recvRequestCancelWorkflowExecution() (err error)
   return
 }
 
-// SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
-// WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
+// RespondActivityTaskFailedByID is called by application to fail an ActivityTask located by the domain, workflowID,
+// runID and activityID instead of the 'taskToken'.  It fails with 'EntityNotExistsError' if the activity is not
+// pending or not started.
 // 
 // 
 // Parameters:
-//  - SignalRequest
-func (p *WorkflowServiceClient) SignalWorkflowExecution(signalRequest *shared.SignalWorkflowExecutionRequest) (err error) {
-  if err = p.sendSignalWorkflowExecution(signalRequest); err != nil { return }
-  return p.recvSignalWorkflowExecution()
+//  - FailedRequest
+func (p *WorkflowServiceClient) RespondActivityTaskFailedByID(failedRequest *shared.RespondActivityTaskFailedByIDRequest) (err error) {
+  if err = p.sendRespondActivityTaskFailedByID(failedRequest); err != nil { return }
+  return p.recvRespondActivityTaskFailedByID()
 }
 
-func (p *WorkflowServiceClient) sendSignalWorkflowExecution(signalRequest *shared.SignalWorkflowExecutionRequest)(err error) {
+func (p *WorkflowServiceClient) sendRespondActivityTaskFailedByID(failedRequest *shared.RespondActivityTaskFailedByIDRequest)(err error) {
   oprot := p.OutputProtocol
   if oprot == nil {
     oprot = p.ProtocolFactory.GetProtocol(p.Transport)
     p.OutputProtocol = oprot
   }
   p.SeqId++
-  if err = oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+  if err = oprot.WriteMessageBegin("RespondActivityTaskFailedByID", thrift.CALL, p.SeqId); err != nil {
       return
   }
-  args := WorkflowServiceSignalWorkflowExecutionArgs{
-  SignalRequest : signalRequest,
+  args := WorkflowServiceRespondActivityTaskFailedByIDArgs{
+  FailedRequest : failedRequest,
   }
   if err = args.Write(oprot); err != nil {
       return
@@ -1541,7 +1757,7 @@ func (p *WorkflowServiceClient) sendSignalWorkflowExecution(signalRequest *share
 }
 
 
-func (p *WorkflowServiceClient) recvSignalWorkflowExecution() (err error) {
+func (p *WorkflowServiceClient) recvRespondActivityTaskFailedByID() (err error) {
   iprot := p.InputProtocol
   if iprot == nil {
     iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -1551,32 +1767,32 @@ func (p *WorkflowServiceClient) recvSignalWorkflowExecution() (err error) {
   if err != nil {
     return
   }
-  if method != "SignalWorkflowExecution" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "SignalWorkflowExecution failed: wrong method name")
+  if method != "RespondActivityTaskFailedByID" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondActivityTaskFailedByID failed: wrong method name")
     return
   }
   if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "SignalWorkflowExecution failed: out of sequence response")
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondActivityTaskFailedByID failed: out of sequence response")
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error28 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error29 error
-    error29, err = error28.Read(iprot)
+    error30 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error31 error
+    error31, err = error30.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error29
+    err = error31
     return
   }
   if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "SignalWorkflowExecution failed: invalid message type")
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondActivityTaskFailedByID failed: invalid message type")
     return
   }
-  result := WorkflowServiceSignalWorkflowExecutionResult{}
+  result := WorkflowServiceRespondActivityTaskFailedByIDResult{}
   if err = result.Read(iprot); err != nil {
     return
   }
@@ -1596,29 +1812,30 @@ func (p *WorkflowServiceClient) recvSignalWorkflowExecution() (err error) {
   return
 }
 
-// TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
-// in the history and immediately terminating the execution instance.
+// RespondActivityTaskCanceledByID is called by application to cancel an ActivityTask located by the domain,
+// workflowID, runID and activityID instead of the 'taskToken'.  It fails with 'EntityNotExistsError' if the activity
+// is not pending or not started.
 // 
 // 
 // Parameters:
-//  - TerminateRequest
-func (p *WorkflowServiceClient) TerminateWorkflowExecution(terminateRequest *shared.TerminateWorkflowExecutionRequest) (err error) {
-  if err = p.sendTerminateWorkflowExecution(terminateRequest); err != nil { return }
-  return p.recvTerminateWorkflowExecution()
+//  - CanceledRequest
+func (p *WorkflowServiceClient) RespondActivityTaskCanceledByID(canceledRequest *shared.RespondActivityTaskCanceledByIDRequest) (err error) {
+  if err = p.sendRespondActivityTaskCanceledByID(canceledRequest); err != nil { return }
+  return p.recvRespondActivityTaskCanceledByID()
 }
 
-func (p *WorkflowServiceClient) sendTerminateWorkflowExecution(terminateRequest *shared.TerminateWorkflowExecutionRequest)(err error) {
+func (p *WorkflowServiceClient) sendRespondActivityTaskCanceledByID(canceledRequest *shared.RespondActivityTaskCanceledByIDRequest)(err error) {
   oprot := p.OutputProtocol
   if oprot == nil {
     oprot = p.ProtocolFactory.GetProtocol(p.Transport)
     p.OutputProtocol = oprot
   }
   p.SeqId++
-  if err = oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+  if err = oprot.WriteMessageBegin("RespondActivityTaskCanceledByID", thrift.CALL, p.SeqId); err != nil {
       return
   }
-  args := WorkflowServiceTerminateWorkflowExecutionArgs{
-  TerminateRequest : terminateRequest,
+  args := WorkflowServiceRespondActivityTaskCanceledByIDArgs{
+  CanceledRequest : canceledRequest,
   }
   if err = args.Write(oprot); err != nil {
       return
@@ -1630,7 +1847,7 @@ func (p *WorkflowServiceClient) sendTerminateWorkflowExecution(terminateRequest
 }
 
 
-func (p *WorkflowServiceClient) recvTerminateWorkflowExecution() (err error) {
+func (p *WorkflowServiceClient) recvRespondActivityTaskCanceledByID() (err error) {
   iprot := p.InputProtocol
   if iprot == nil {
     iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -1640,32 +1857,32 @@ func (p *WorkflowServiceClient) recvTerminateWorkflowExecution() (err error) {
   if err != nil {
     return
   }
-  if method != "TerminateWorkflowExecution" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "TerminateWorkflowExecution failed: wrong method name")
+  if method != "RespondActivityTaskCanceledByID" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondActivityTaskCanceledByID failed: wrong method name")
     return
   }
   if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "TerminateWorkflowExecution failed: out of sequence response")
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondActivityTaskCanceledByID failed: out of sequence response")
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error30 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error31 error
-    error31, err = error30.Read(iprot)
-    if err != nil {
-      return
+    error32 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error33 error
+    error33, err = error32.Read(iprot)
+    if err != nil {
+      return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error31
+    err = error33
     return
   }
   if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "TerminateWorkflowExecution failed: invalid message type")
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondActivityTaskCanceledByID failed: invalid message type")
     return
   }
-  result := WorkflowServiceTerminateWorkflowExecutionResult{}
+  result := WorkflowServiceRespondActivityTaskCanceledByIDResult{}
   if err = result.Read(iprot); err != nil {
     return
   }
@@ -1685,28 +1902,31 @@ func (p *WorkflowServiceClient) recvTerminateWorkflowExecution() (err error) {
   return
 }
 
-// ListOpenWorkflowExecutions is a visibility API to list the open executions in a specific domain.
+// RequestCancelWorkflowExecution is called by application worker when it wants to request cancellation of a workflow instance.
+// It will result in a new 'WorkflowExecutionCancelRequested' event being written to the workflow history and a new DecisionTask
+// created for the workflow instance so new decisions could be made. It fails with 'EntityNotExistsError' if the workflow is not valid
+// anymore due to completion or doesn't exist.
 // 
 // 
 // Parameters:
-//  - ListRequest
-func (p *WorkflowServiceClient) ListOpenWorkflowExecutions(listRequest *shared.ListOpenWorkflowExecutionsRequest) (r *shared.ListOpenWorkflowExecutionsResponse, err error) {
-  if err = p.sendListOpenWorkflowExecutions(listRequest); err != nil { return }
-  return p.recvListOpenWorkflowExecutions()
+//  - CancelRequest
+func (p *WorkflowServiceClient) RequestCancelWorkflowExecution(cancelRequest *shared.RequestCancelWorkflowExecutionRequest) (err error) {
+  if err = p.sendRequestCancelWorkflowExecution(cancelRequest); err != nil { return }
+  return p.recvRequestCancelWorkflowExecution()
 }
 
-func (p *WorkflowServiceClient) sendListOpenWorkflowExecutions(listRequest *shared.ListOpenWorkflowExecutionsRequest)(err error) {
+func (p *WorkflowServiceClient) sendRequestCancelWorkflowExecution(cancelRequest *shared.RequestCancelWorkflowExecutionRequest)(err error) {
   oprot := p.OutputProtocol
   if oprot == nil {
     oprot = p.ProtocolFactory.GetProtocol(p.Transport)
     p.OutputProtocol = oprot
   }
   p.SeqId++
-  if err = oprot.WriteMessageBegin("ListOpenWorkflowExecutions", thrift.CALL, p.SeqId); err != nil {
+  if err = oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
       return
   }
-  args := WorkflowServiceListOpenWorkflowExecutionsArgs{
-  ListRequest : listRequest,
+  args := WorkflowServiceRequestCancelWorkflowExecutionArgs{
+  CancelRequest : cancelRequest,
   }
   if err = args.Write(oprot); err != nil {
       return
@@ -1718,7 +1938,7 @@ func (p *WorkflowServiceClient) sendListOpenWorkflowExecutions(listRequest *shar
 }
 
 
-func (p *WorkflowServiceClient) recvListOpenWorkflowExecutions() (value *shared.ListOpenWorkflowExecutionsResponse, err error) {
+func (p *WorkflowServiceClient) recvRequestCancelWorkflowExecution() (err error) {
   iprot := p.InputProtocol
   if iprot == nil {
     iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -1728,32 +1948,32 @@ func (p *WorkflowServiceClient) recvListOpenWorkflowExecutions() (value *shared.
   if err != nil {
     return
   }
-  if method != "ListOpenWorkflowExecutions" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ListOpenWorkflowExecutions failed: wrong method name")
+  if method != "RequestCancelWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RequestCancelWorkflowExecution failed: wrong method name")
     return
   }
   if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ListOpenWorkflowExecutions failed: out of sequence response")
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RequestCancelWorkflowExecution failed: out of sequence response")
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error32 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error33 error
-    error33, err = error32.Read(iprot)
+    error34 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error35 error
+    error35, err = error34.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error33
+    err = error35
     return
   }
   if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ListOpenWorkflowExecutions failed: invalid message type")
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RequestCancelWorkflowExecution failed: invalid message type")
     return
   }
-  result := WorkflowServiceListOpenWorkflowExecutionsResult{}
+  result := WorkflowServiceRequestCancelWorkflowExecutionResult{}
   if err = result.Read(iprot); err != nil {
     return
   }
@@ -1770,32 +1990,36 @@ func (p *WorkflowServiceClient) recvListOpenWorkflowExecutions() (value *shared.
     err = result.EntityNotExistError
     return 
   }
-  value = result.GetSuccess()
   return
 }
 
-// ListClosedWorkflowExecutions is a visibility API to list the closed executions in a specific domain.
+// SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
+// WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
+// The response is the receipt of the signal: the run which recorded it and the ID of its event.  The event ID is
+// not set while the signal is held back behind a decision task in flight, it is assigned once the decision
+// completes.  Signals carrying a 'requestId' are recorded at most once per run, retrying one which was already
+// recorded returns its original receipt.
 // 
 // 
 // Parameters:
-//  - ListRequest
-func (p *WorkflowServiceClient) ListClosedWorkflowExecutions(listRequest *shared.ListClosedWorkflowExecutionsRequest) (r *shared.ListClosedWorkflowExecutionsResponse, err error) {
-  if err = p.sendListClosedWorkflowExecutions(listRequest); err != nil { return }
-  return p.recvListClosedWorkflowExecutions()
+//  - SignalRequest
+func (p *WorkflowServiceClient) SignalWorkflowExecution(signalRequest *shared.SignalWorkflowExecutionRequest) (r *shared.SignalWorkflowExecutionResponse, err error) {
+  if err = p.sendSignalWorkflowExecution(signalRequest); err != nil { return }
+  return p.recvSignalWorkflowExecution()
 }
 
-func (p *WorkflowServiceClient) sendListClosedWorkflowExecutions(listRequest *shared.ListClosedWorkflowExecutionsRequest)(err error) {
+func (p *WorkflowServiceClient) sendSignalWorkflowExecution(signalRequest *shared.SignalWorkflowExecutionRequest)(err error) {
   oprot := p.OutputProtocol
   if oprot == nil {
     oprot = p.ProtocolFactory.GetProtocol(p.Transport)
     p.OutputProtocol = oprot
   }
   p.SeqId++
-  if err = oprot.WriteMessageBegin("ListClosedWorkflowExecutions", thrift.CALL, p.SeqId); err != nil {
+  if err = oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
       return
   }
-  args := WorkflowServiceListClosedWorkflowExecutionsArgs{
-  ListRequest : listRequest,
+  args := WorkflowServiceSignalWorkflowExecutionArgs{
+  SignalRequest : signalRequest,
   }
   if err = args.Write(oprot); err != nil {
       return
@@ -1807,7 +2031,7 @@ func (p *WorkflowServiceClient) sendListClosedWorkflowExecutions(listRequest *sh
 }
 
 
-func (p *WorkflowServiceClient) recvListClosedWorkflowExecutions() (value *shared.ListClosedWorkflowExecutionsResponse, err error) {
+func (p *WorkflowServiceClient) recvSignalWorkflowExecution() (value *shared.SignalWorkflowExecutionResponse, err error) {
   iprot := p.InputProtocol
   if iprot == nil {
     iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -1817,32 +2041,32 @@ func (p *WorkflowServiceClient) recvListClosedWorkflowExecutions() (value *share
   if err != nil {
     return
   }
-  if method != "ListClosedWorkflowExecutions" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ListClosedWorkflowExecutions failed: wrong method name")
+  if method != "SignalWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "SignalWorkflowExecution failed: wrong method name")
     return
   }
   if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ListClosedWorkflowExecutions failed: out of sequence response")
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "SignalWorkflowExecution failed: out of sequence response")
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error34 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error35 error
-    error35, err = error34.Read(iprot)
+    error36 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error37 error
+    error37, err = error36.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error35
+    err = error37
     return
   }
   if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ListClosedWorkflowExecutions failed: invalid message type")
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "SignalWorkflowExecution failed: invalid message type")
     return
   }
-  result := WorkflowServiceListClosedWorkflowExecutionsResult{}
+  result := WorkflowServiceSignalWorkflowExecutionResult{}
   if err = result.Read(iprot); err != nil {
     return
   }
@@ -1863,903 +2087,1082 @@ func (p *WorkflowServiceClient) recvListClosedWorkflowExecutions() (value *share
   return
 }
 
-
-type WorkflowServiceProcessor struct {
-  processorMap map[string]thrift.TProcessorFunction
-  handler WorkflowService
-}
-
-func (p *WorkflowServiceProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
-  p.processorMap[key] = processor
-}
-
-func (p *WorkflowServiceProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
-  processor, ok = p.processorMap[key]
-  return processor, ok
-}
-
-func (p *WorkflowServiceProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
-  return p.processorMap
-}
-
-func NewWorkflowServiceProcessor(handler WorkflowService) *WorkflowServiceProcessor {
-
-  self36 := &WorkflowServiceProcessor{handler:handler, processorMap:make(map[string]thrift.TProcessorFunction)}
-  self36.processorMap["RegisterDomain"] = &workflowServiceProcessorRegisterDomain{handler:handler}
-  self36.processorMap["DescribeDomain"] = &workflowServiceProcessorDescribeDomain{handler:handler}
-  self36.processorMap["UpdateDomain"] = &workflowServiceProcessorUpdateDomain{handler:handler}
-  self36.processorMap["DeprecateDomain"] = &workflowServiceProcessorDeprecateDomain{handler:handler}
-  self36.processorMap["StartWorkflowExecution"] = &workflowServiceProcessorStartWorkflowExecution{handler:handler}
-  self36.processorMap["GetWorkflowExecutionHistory"] = &workflowServiceProcessorGetWorkflowExecutionHistory{handler:handler}
-  self36.processorMap["PollForDecisionTask"] = &workflowServiceProcessorPollForDecisionTask{handler:handler}
-  self36.processorMap["RespondDecisionTaskCompleted"] = &workflowServiceProcessorRespondDecisionTaskCompleted{handler:handler}
-  self36.processorMap["PollForActivityTask"] = &workflowServiceProcessorPollForActivityTask{handler:handler}
-  self36.processorMap["RecordActivityTaskHeartbeat"] = &workflowServiceProcessorRecordActivityTaskHeartbeat{handler:handler}
-  self36.processorMap["RespondActivityTaskCompleted"] = &workflowServiceProcessorRespondActivityTaskCompleted{handler:handler}
-  self36.processorMap["RespondActivityTaskFailed"] = &workflowServiceProcessorRespondActivityTaskFailed{handler:handler}
-  self36.processorMap["RespondActivityTaskCanceled"] = &workflowServiceProcessorRespondActivityTaskCanceled{handler:handler}
-  self36.processorMap["RequestCancelWorkflowExecution"] = &workflowServiceProcessorRequestCancelWorkflowExecution{handler:handler}
-  self36.processorMap["SignalWorkflowExecution"] = &workflowServiceProcessorSignalWorkflowExecution{handler:handler}
-  self36.processorMap["TerminateWorkflowExecution"] = &workflowServiceProcessorTerminateWorkflowExecution{handler:handler}
-  self36.processorMap["ListOpenWorkflowExecutions"] = &workflowServiceProcessorListOpenWorkflowExecutions{handler:handler}
-  self36.processorMap["ListClosedWorkflowExecutions"] = &workflowServiceProcessorListClosedWorkflowExecutions{handler:handler}
-return self36
+// GetSignalReceipt tells whether the signal sent with the 'requestId' was recorded by the workflow execution, and
+// returns its receipt if it was.  Senders which lost the response of SignalWorkflowExecution check it before
+// retrying the signal to deliver it exactly once.  Receipts are kept by the run which recorded the signal, the
+// current run is checked when the execution has no 'runId'.
+// 
+// 
+// Parameters:
+//  - ReceiptRequest
+func (p *WorkflowServiceClient) GetSignalReceipt(receiptRequest *shared.GetSignalReceiptRequest) (r *shared.GetSignalReceiptResponse, err error) {
+  if err = p.sendGetSignalReceipt(receiptRequest); err != nil { return }
+  return p.recvGetSignalReceipt()
 }
 
-func (p *WorkflowServiceProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  name, _, seqId, err := iprot.ReadMessageBegin()
-  if err != nil { return false, err }
-  if processor, ok := p.GetProcessorFunction(name); ok {
-    return processor.Process(seqId, iprot, oprot)
+func (p *WorkflowServiceClient) sendGetSignalReceipt(receiptRequest *shared.GetSignalReceiptRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
   }
-  iprot.Skip(thrift.STRUCT)
-  iprot.ReadMessageEnd()
-  x37 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function " + name)
-  oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-  x37.Write(oprot)
-  oprot.WriteMessageEnd()
-  oprot.Flush()
-  return false, x37
-
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("GetSignalReceipt", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := WorkflowServiceGetSignalReceiptArgs{
+  ReceiptRequest : receiptRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
 }
 
-type workflowServiceProcessorRegisterDomain struct {
-  handler WorkflowService
-}
 
-func (p *workflowServiceProcessorRegisterDomain) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceRegisterDomainArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RegisterDomain", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) recvGetSignalReceipt() (value *shared.GetSignalReceiptResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
   }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceRegisterDomainResult{}
-  var err2 error
-  if err2 = p.handler.RegisterDomain(args.RegisterRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.DomainAlreadyExistsError:
-  result.DomainExistsError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RegisterDomain: " + err2.Error())
-    oprot.WriteMessageBegin("RegisterDomain", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
   }
+  if method != "GetSignalReceipt" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetSignalReceipt failed: wrong method name")
+    return
   }
-  if err2 = oprot.WriteMessageBegin("RegisterDomain", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetSignalReceipt failed: out of sequence response")
+    return
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  if mTypeId == thrift.EXCEPTION {
+    error38 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error39 error
+    error39, err = error38.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error39
+    return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetSignalReceipt failed: invalid message type")
+    return
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  result := WorkflowServiceGetSignalReceiptResult{}
+  if err = result.Read(iprot); err != nil {
+    return
   }
-  if err != nil {
+  if err = iprot.ReadMessageEnd(); err != nil {
     return
   }
-  return true, err
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  }
+  value = result.GetSuccess()
+  return
 }
 
-type workflowServiceProcessorDescribeDomain struct {
-  handler WorkflowService
+// QueryWorkflow is used to query the state of a running workflow execution.  The query is handed to the worker along
+// with the next decision task of the execution in the 'queries' of PollForDecisionTaskResponse, and the worker answers
+// it in the 'queryResults' of RespondDecisionTaskCompleted.  Without a decision task waiting for a poller, the query
+// comes with a decision task of its own which has no new events, its completion can't carry decisions.  It fails with 'QueryFailedError' if the worker failed the
+// query or didn't answer it.
+// 
+// 
+// Parameters:
+//  - QueryRequest
+func (p *WorkflowServiceClient) QueryWorkflow(queryRequest *shared.QueryWorkflowRequest) (r *shared.QueryWorkflowResponse, err error) {
+  if err = p.sendQueryWorkflow(queryRequest); err != nil { return }
+  return p.recvQueryWorkflow()
 }
 
-func (p *workflowServiceProcessorDescribeDomain) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceDescribeDomainArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("DescribeDomain", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) sendQueryWorkflow(queryRequest *shared.QueryWorkflowRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
   }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceDescribeDomainResult{}
-var retval *shared.DescribeDomainResponse
-  var err2 error
-  if retval, err2 = p.handler.DescribeDomain(args.DescribeRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DescribeDomain: " + err2.Error())
-    oprot.WriteMessageBegin("DescribeDomain", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("QueryWorkflow", thrift.CALL, p.SeqId); err != nil {
+      return
   }
-  } else {
-    result.Success = retval
-}
-  if err2 = oprot.WriteMessageBegin("DescribeDomain", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  args := WorkflowServiceQueryWorkflowArgs{
+  QueryRequest : queryRequest,
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
-  }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
-  }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if err = args.Write(oprot); err != nil {
+      return
   }
-  if err != nil {
-    return
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
   }
-  return true, err
+  return oprot.Flush()
 }
 
-type workflowServiceProcessorUpdateDomain struct {
-  handler WorkflowService
-}
 
-func (p *workflowServiceProcessorUpdateDomain) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceUpdateDomainArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("UpdateDomain", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) recvQueryWorkflow() (value *shared.QueryWorkflowResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
   }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceUpdateDomainResult{}
-var retval *shared.UpdateDomainResponse
-  var err2 error
-  if retval, err2 = p.handler.UpdateDomain(args.UpdateRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UpdateDomain: " + err2.Error())
-    oprot.WriteMessageBegin("UpdateDomain", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
   }
-  } else {
-    result.Success = retval
-}
-  if err2 = oprot.WriteMessageBegin("UpdateDomain", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if method != "QueryWorkflow" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "QueryWorkflow failed: wrong method name")
+    return
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "QueryWorkflow failed: out of sequence response")
+    return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId == thrift.EXCEPTION {
+    error40 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error41 error
+    error41, err = error40.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error41
+    return
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "QueryWorkflow failed: invalid message type")
+    return
   }
-  if err != nil {
+  result := WorkflowServiceQueryWorkflowResult{}
+  if err = result.Read(iprot); err != nil {
     return
   }
-  return true, err
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.QueryFailedError != nil {
+    err = result.QueryFailedError
+    return 
+  }
+  value = result.GetSuccess()
+  return
 }
 
-type workflowServiceProcessorDeprecateDomain struct {
-  handler WorkflowService
+// DescribeWorkflowExecution returns information about the specified workflow execution, including the activities
+// still pending on it.  Started activities are waiting to be completed, either by the worker which polled them or
+// externally with the activity ID based APIs, until their 'expirationTimestamp'.  Activities past it without a
+// completion have lost it and will time out.
+// 
+// 
+// Parameters:
+//  - DescribeRequest
+func (p *WorkflowServiceClient) DescribeWorkflowExecution(describeRequest *shared.DescribeWorkflowExecutionRequest) (r *shared.DescribeWorkflowExecutionResponse, err error) {
+  if err = p.sendDescribeWorkflowExecution(describeRequest); err != nil { return }
+  return p.recvDescribeWorkflowExecution()
 }
 
-func (p *workflowServiceProcessorDeprecateDomain) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceDeprecateDomainArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("DeprecateDomain", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
-  }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceDeprecateDomainResult{}
-  var err2 error
-  if err2 = p.handler.DeprecateDomain(args.DeprecateRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DeprecateDomain: " + err2.Error())
-    oprot.WriteMessageBegin("DeprecateDomain", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
-  }
-  if err2 = oprot.WriteMessageBegin("DeprecateDomain", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+func (p *WorkflowServiceClient) sendDescribeWorkflowExecution(describeRequest *shared.DescribeWorkflowExecutionRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("DescribeWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+      return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  args := WorkflowServiceDescribeWorkflowExecutionArgs{
+  DescribeRequest : describeRequest,
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if err = args.Write(oprot); err != nil {
+      return
   }
-  if err != nil {
-    return
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
   }
-  return true, err
+  return oprot.Flush()
 }
 
-type workflowServiceProcessorStartWorkflowExecution struct {
-  handler WorkflowService
-}
 
-func (p *workflowServiceProcessorStartWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceStartWorkflowExecutionArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("StartWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) recvDescribeWorkflowExecution() (value *shared.DescribeWorkflowExecutionResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
   }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceStartWorkflowExecutionResult{}
-var retval *shared.StartWorkflowExecutionResponse
-  var err2 error
-  if retval, err2 = p.handler.StartWorkflowExecution(args.StartRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.WorkflowExecutionAlreadyStartedError:
-  result.SessionAlreadyExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing StartWorkflowExecution: " + err2.Error())
-    oprot.WriteMessageBegin("StartWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
   }
-  } else {
-    result.Success = retval
-}
-  if err2 = oprot.WriteMessageBegin("StartWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if method != "DescribeWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "DescribeWorkflowExecution failed: wrong method name")
+    return
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "DescribeWorkflowExecution failed: out of sequence response")
+    return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId == thrift.EXCEPTION {
+    error42 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error43 error
+    error43, err = error42.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error43
+    return
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "DescribeWorkflowExecution failed: invalid message type")
+    return
   }
-  if err != nil {
+  result := WorkflowServiceDescribeWorkflowExecutionResult{}
+  if err = result.Read(iprot); err != nil {
     return
   }
-  return true, err
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  }
+  value = result.GetSuccess()
+  return
 }
 
-type workflowServiceProcessorGetWorkflowExecutionHistory struct {
-  handler WorkflowService
+// TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminatedByOperator
+// event in the history and immediately terminating the execution instance.  Running child executions are left
+// alone, unless the request sets a 'childPolicy' to terminate or cancel them.  All the executions affected are
+// returned.
+// 
+// 
+// Parameters:
+//  - TerminateRequest
+func (p *WorkflowServiceClient) TerminateWorkflowExecution(terminateRequest *shared.TerminateWorkflowExecutionRequest) (r *shared.TerminateWorkflowExecutionResponse, err error) {
+  if err = p.sendTerminateWorkflowExecution(terminateRequest); err != nil { return }
+  return p.recvTerminateWorkflowExecution()
 }
 
-func (p *workflowServiceProcessorGetWorkflowExecutionHistory) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceGetWorkflowExecutionHistoryArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("GetWorkflowExecutionHistory", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
-  }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceGetWorkflowExecutionHistoryResult{}
-var retval *shared.GetWorkflowExecutionHistoryResponse
-  var err2 error
-  if retval, err2 = p.handler.GetWorkflowExecutionHistory(args.GetRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetWorkflowExecutionHistory: " + err2.Error())
-    oprot.WriteMessageBegin("GetWorkflowExecutionHistory", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
-  } else {
-    result.Success = retval
-}
-  if err2 = oprot.WriteMessageBegin("GetWorkflowExecutionHistory", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+func (p *WorkflowServiceClient) sendTerminateWorkflowExecution(terminateRequest *shared.TerminateWorkflowExecutionRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+      return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  args := WorkflowServiceTerminateWorkflowExecutionArgs{
+  TerminateRequest : terminateRequest,
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if err = args.Write(oprot); err != nil {
+      return
   }
-  if err != nil {
-    return
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
   }
-  return true, err
+  return oprot.Flush()
 }
 
-type workflowServiceProcessorPollForDecisionTask struct {
-  handler WorkflowService
-}
 
-func (p *workflowServiceProcessorPollForDecisionTask) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServicePollForDecisionTaskArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("PollForDecisionTask", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) recvTerminateWorkflowExecution() (value *shared.TerminateWorkflowExecutionResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
   }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServicePollForDecisionTaskResult{}
-var retval *shared.PollForDecisionTaskResponse
-  var err2 error
-  if retval, err2 = p.handler.PollForDecisionTask(args.PollRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing PollForDecisionTask: " + err2.Error())
-    oprot.WriteMessageBegin("PollForDecisionTask", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
   }
-  } else {
-    result.Success = retval
-}
-  if err2 = oprot.WriteMessageBegin("PollForDecisionTask", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if method != "TerminateWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "TerminateWorkflowExecution failed: wrong method name")
+    return
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "TerminateWorkflowExecution failed: out of sequence response")
+    return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId == thrift.EXCEPTION {
+    error44 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error45 error
+    error45, err = error44.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error45
+    return
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "TerminateWorkflowExecution failed: invalid message type")
+    return
   }
-  if err != nil {
+  result := WorkflowServiceTerminateWorkflowExecutionResult{}
+  if err = result.Read(iprot); err != nil {
     return
   }
-  return true, err
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  }
+  value = result.GetSuccess()
+  return
 }
 
-type workflowServiceProcessorRespondDecisionTaskCompleted struct {
-  handler WorkflowService
+// ListOpenWorkflowExecutions is a visibility API to list the open executions in a specific domain.
+// 
+// 
+// Parameters:
+//  - ListRequest
+func (p *WorkflowServiceClient) ListOpenWorkflowExecutions(listRequest *shared.ListOpenWorkflowExecutionsRequest) (r *shared.ListOpenWorkflowExecutionsResponse, err error) {
+  if err = p.sendListOpenWorkflowExecutions(listRequest); err != nil { return }
+  return p.recvListOpenWorkflowExecutions()
 }
 
-func (p *workflowServiceProcessorRespondDecisionTaskCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceRespondDecisionTaskCompletedArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) sendListOpenWorkflowExecutions(listRequest *shared.ListOpenWorkflowExecutionsRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("ListOpenWorkflowExecutions", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := WorkflowServiceListOpenWorkflowExecutionsArgs{
+  ListRequest : listRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
   }
+  return oprot.Flush()
+}
 
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceRespondDecisionTaskCompletedResult{}
-  var err2 error
-  if err2 = p.handler.RespondDecisionTaskCompleted(args.CompleteRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondDecisionTaskCompleted: " + err2.Error())
-    oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+
+func (p *WorkflowServiceClient) recvListOpenWorkflowExecutions() (value *shared.ListOpenWorkflowExecutionsResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
   }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
   }
-  if err2 = oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if method != "ListOpenWorkflowExecutions" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ListOpenWorkflowExecutions failed: wrong method name")
+    return
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ListOpenWorkflowExecutions failed: out of sequence response")
+    return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId == thrift.EXCEPTION {
+    error46 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error47 error
+    error47, err = error46.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error47
+    return
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ListOpenWorkflowExecutions failed: invalid message type")
+    return
   }
-  if err != nil {
+  result := WorkflowServiceListOpenWorkflowExecutionsResult{}
+  if err = result.Read(iprot); err != nil {
     return
   }
-  return true, err
-}
-
-type workflowServiceProcessorPollForActivityTask struct {
-  handler WorkflowService
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  }
+  value = result.GetSuccess()
+  return
 }
 
-func (p *workflowServiceProcessorPollForActivityTask) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServicePollForActivityTaskArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("PollForActivityTask", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
-  }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServicePollForActivityTaskResult{}
-var retval *shared.PollForActivityTaskResponse
-  var err2 error
-  if retval, err2 = p.handler.PollForActivityTask(args.PollRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing PollForActivityTask: " + err2.Error())
-    oprot.WriteMessageBegin("PollForActivityTask", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
-  } else {
-    result.Success = retval
+// ListClosedWorkflowExecutions is a visibility API to list the closed executions in a specific domain.  Executions
+// are listed by start time range, or by close time range when CloseTimeFilter is set on the request.
+// 
+// 
+// Parameters:
+//  - ListRequest
+func (p *WorkflowServiceClient) ListClosedWorkflowExecutions(listRequest *shared.ListClosedWorkflowExecutionsRequest) (r *shared.ListClosedWorkflowExecutionsResponse, err error) {
+  if err = p.sendListClosedWorkflowExecutions(listRequest); err != nil { return }
+  return p.recvListClosedWorkflowExecutions()
 }
-  if err2 = oprot.WriteMessageBegin("PollForActivityTask", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+
+func (p *WorkflowServiceClient) sendListClosedWorkflowExecutions(listRequest *shared.ListClosedWorkflowExecutionsRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("ListClosedWorkflowExecutions", thrift.CALL, p.SeqId); err != nil {
+      return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  args := WorkflowServiceListClosedWorkflowExecutionsArgs{
+  ListRequest : listRequest,
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if err = args.Write(oprot); err != nil {
+      return
   }
-  if err != nil {
-    return
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
   }
-  return true, err
+  return oprot.Flush()
 }
 
-type workflowServiceProcessorRecordActivityTaskHeartbeat struct {
-  handler WorkflowService
-}
 
-func (p *workflowServiceProcessorRecordActivityTaskHeartbeat) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceRecordActivityTaskHeartbeatArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) recvListClosedWorkflowExecutions() (value *shared.ListClosedWorkflowExecutionsResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
   }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceRecordActivityTaskHeartbeatResult{}
-var retval *shared.RecordActivityTaskHeartbeatResponse
-  var err2 error
-  if retval, err2 = p.handler.RecordActivityTaskHeartbeat(args.HeartbeatRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordActivityTaskHeartbeat: " + err2.Error())
-    oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
   }
-  } else {
-    result.Success = retval
-}
-  if err2 = oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if method != "ListClosedWorkflowExecutions" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ListClosedWorkflowExecutions failed: wrong method name")
+    return
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ListClosedWorkflowExecutions failed: out of sequence response")
+    return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId == thrift.EXCEPTION {
+    error48 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error49 error
+    error49, err = error48.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error49
+    return
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ListClosedWorkflowExecutions failed: invalid message type")
+    return
   }
-  if err != nil {
+  result := WorkflowServiceListClosedWorkflowExecutionsResult{}
+  if err = result.Read(iprot); err != nil {
     return
   }
-  return true, err
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  }
+  value = result.GetSuccess()
+  return
 }
 
-type workflowServiceProcessorRespondActivityTaskCompleted struct {
-  handler WorkflowService
+// ListClosedWorkflowExecutionsSince is a visibility API to tail the executions closed in a specific domain.  It lists
+// the executions closed after the ones already returned, in close time order, starting at 'earliestCloseTime' on the
+// first call.  Pass the 'closedSinceToken' of the response to the next call to continue, the token is returned even
+// when no execution closed since the last call.
+// 
+// 
+// Parameters:
+//  - ListRequest
+func (p *WorkflowServiceClient) ListClosedWorkflowExecutionsSince(listRequest *shared.ListClosedWorkflowExecutionsSinceRequest) (r *shared.ListClosedWorkflowExecutionsSinceResponse, err error) {
+  if err = p.sendListClosedWorkflowExecutionsSince(listRequest); err != nil { return }
+  return p.recvListClosedWorkflowExecutionsSince()
 }
 
-func (p *workflowServiceProcessorRespondActivityTaskCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceRespondActivityTaskCompletedArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) sendListClosedWorkflowExecutionsSince(listRequest *shared.ListClosedWorkflowExecutionsSinceRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("ListClosedWorkflowExecutionsSince", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := WorkflowServiceListClosedWorkflowExecutionsSinceArgs{
+  ListRequest : listRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
   }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
 
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceRespondActivityTaskCompletedResult{}
-  var err2 error
-  if err2 = p.handler.RespondActivityTaskCompleted(args.CompleteRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCompleted: " + err2.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+
+func (p *WorkflowServiceClient) recvListClosedWorkflowExecutionsSince() (value *shared.ListClosedWorkflowExecutionsSinceResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
   }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
   }
-  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if method != "ListClosedWorkflowExecutionsSince" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ListClosedWorkflowExecutionsSince failed: wrong method name")
+    return
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ListClosedWorkflowExecutionsSince failed: out of sequence response")
+    return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId == thrift.EXCEPTION {
+    error50 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error51 error
+    error51, err = error50.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error51
+    return
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ListClosedWorkflowExecutionsSince failed: invalid message type")
+    return
   }
-  if err != nil {
+  result := WorkflowServiceListClosedWorkflowExecutionsSinceResult{}
+  if err = result.Read(iprot); err != nil {
     return
   }
-  return true, err
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  }
+  value = result.GetSuccess()
+  return
 }
 
-type workflowServiceProcessorRespondActivityTaskFailed struct {
-  handler WorkflowService
+// DescribeCluster returns the metadata of the cluster: the server version, the number of history shards, the client
+// features it supports and the type of its persistence store.  SDKs and tools use it to negotiate capabilities.
+// 
+func (p *WorkflowServiceClient) DescribeCluster() (r *shared.DescribeClusterResponse, err error) {
+  if err = p.sendDescribeCluster(); err != nil { return }
+  return p.recvDescribeCluster()
 }
 
-func (p *workflowServiceProcessorRespondActivityTaskFailed) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceRespondActivityTaskFailedArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
-  }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceRespondActivityTaskFailedResult{}
-  var err2 error
-  if err2 = p.handler.RespondActivityTaskFailed(args.FailRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskFailed: " + err2.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
-  }
-  if err2 = oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+func (p *WorkflowServiceClient) sendDescribeCluster()(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("DescribeCluster", thrift.CALL, p.SeqId); err != nil {
+      return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  args := WorkflowServiceDescribeClusterArgs{
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if err = args.Write(oprot); err != nil {
+      return
   }
-  if err != nil {
-    return
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
   }
-  return true, err
+  return oprot.Flush()
 }
 
-type workflowServiceProcessorRespondActivityTaskCanceled struct {
-  handler WorkflowService
-}
 
-func (p *workflowServiceProcessorRespondActivityTaskCanceled) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceRespondActivityTaskCanceledArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) recvDescribeCluster() (value *shared.DescribeClusterResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
   }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceRespondActivityTaskCanceledResult{}
-  var err2 error
-  if err2 = p.handler.RespondActivityTaskCanceled(args.CanceledRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCanceled: " + err2.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
   }
+  if method != "DescribeCluster" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "DescribeCluster failed: wrong method name")
+    return
   }
-  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "DescribeCluster failed: out of sequence response")
+    return
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  if mTypeId == thrift.EXCEPTION {
+    error52 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error53 error
+    error53, err = error52.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error53
+    return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "DescribeCluster failed: invalid message type")
+    return
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  result := WorkflowServiceDescribeClusterResult{}
+  if err = result.Read(iprot); err != nil {
+    return
   }
-  if err != nil {
+  if err = iprot.ReadMessageEnd(); err != nil {
     return
   }
-  return true, err
+  if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  }
+  value = result.GetSuccess()
+  return
 }
 
-type workflowServiceProcessorRequestCancelWorkflowExecution struct {
-  handler WorkflowService
+// RefreshWorkflowTasks is an admin API to re-generate the transfer and timer tasks of a running workflow execution
+// from its mutable state.  It recovers executions stuck because one of their tasks was lost or dropped.
+// 
+// 
+// Parameters:
+//  - RefreshRequest
+func (p *WorkflowServiceClient) RefreshWorkflowTasks(refreshRequest *shared.RefreshWorkflowTasksRequest) (err error) {
+  if err = p.sendRefreshWorkflowTasks(refreshRequest); err != nil { return }
+  return p.recvRefreshWorkflowTasks()
 }
 
-func (p *workflowServiceProcessorRequestCancelWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceRequestCancelWorkflowExecutionArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
-  }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceRequestCancelWorkflowExecutionResult{}
-  var err2 error
-  if err2 = p.handler.RequestCancelWorkflowExecution(args.CancelRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RequestCancelWorkflowExecution: " + err2.Error())
-    oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
-  }
-  if err2 = oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+func (p *WorkflowServiceClient) sendRefreshWorkflowTasks(refreshRequest *shared.RefreshWorkflowTasksRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RefreshWorkflowTasks", thrift.CALL, p.SeqId); err != nil {
+      return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  args := WorkflowServiceRefreshWorkflowTasksArgs{
+  RefreshRequest : refreshRequest,
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if err = args.Write(oprot); err != nil {
+      return
   }
-  if err != nil {
-    return
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
   }
-  return true, err
+  return oprot.Flush()
 }
 
-type workflowServiceProcessorSignalWorkflowExecution struct {
-  handler WorkflowService
-}
 
-func (p *workflowServiceProcessorSignalWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceSignalWorkflowExecutionArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *WorkflowServiceClient) recvRefreshWorkflowTasks() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
   }
-
-  iprot.ReadMessageEnd()
-  result := WorkflowServiceSignalWorkflowExecutionResult{}
-  var err2 error
-  if err2 = p.handler.SignalWorkflowExecution(args.SignalRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SignalWorkflowExecution: " + err2.Error())
-    oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
   }
+  if method != "RefreshWorkflowTasks" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RefreshWorkflowTasks failed: wrong method name")
+    return
   }
-  if err2 = oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RefreshWorkflowTasks failed: out of sequence response")
+    return
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  if mTypeId == thrift.EXCEPTION {
+    error54 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error55 error
+    error55, err = error54.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error55
+    return
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RefreshWorkflowTasks failed: invalid message type")
+    return
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  result := WorkflowServiceRefreshWorkflowTasksResult{}
+  if err = result.Read(iprot); err != nil {
+    return
   }
-  if err != nil {
+  if err = iprot.ReadMessageEnd(); err != nil {
     return
   }
-  return true, err
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  }
+  return
 }
 
-type workflowServiceProcessorTerminateWorkflowExecution struct {
+// MigrateWorkflowExecution is an admin API to move a closed workflow execution, its history and its visibility
+// record, to another domain of the cluster, to support reorganizing tenants.  The workflow ID must not be in use
+// in the target domain.  Running executions and moves to another cluster are not supported.
+// 
+// 
+// Parameters:
+//  - MigrateRequest
+func (p *WorkflowServiceClient) MigrateWorkflowExecution(migrateRequest *shared.MigrateWorkflowExecutionRequest) (err error) {
+  if err = p.sendMigrateWorkflowExecution(migrateRequest); err != nil { return }
+  return p.recvMigrateWorkflowExecution()
+}
+
+func (p *WorkflowServiceClient) sendMigrateWorkflowExecution(migrateRequest *shared.MigrateWorkflowExecutionRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("MigrateWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := WorkflowServiceMigrateWorkflowExecutionArgs{
+  MigrateRequest : migrateRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *WorkflowServiceClient) recvMigrateWorkflowExecution() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "MigrateWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "MigrateWorkflowExecution failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "MigrateWorkflowExecution failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error56 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error57 error
+    error57, err = error56.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error57
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "MigrateWorkflowExecution failed: invalid message type")
+    return
+  }
+  result := WorkflowServiceMigrateWorkflowExecutionResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.WorkflowAlreadyStartedError != nil {
+    err = result.WorkflowAlreadyStartedError
+    return 
+  }
+  return
+}
+
+// VerifyHistory is an admin API to run structural validation over a serialized workflow history: event id
+// continuity, the ordering of the history versions and the pairing of every started, completed, failed or timed out
+// event with the event that initiated it.  Every violation found is returned, instead of the engine panicking on the
+// first one while replaying the history.
+// 
+// 
+// Parameters:
+//  - VerifyRequest
+func (p *WorkflowServiceClient) VerifyHistory(verifyRequest *shared.VerifyHistoryRequest) (r *shared.VerifyHistoryResponse, err error) {
+  if err = p.sendVerifyHistory(verifyRequest); err != nil { return }
+  return p.recvVerifyHistory()
+}
+
+func (p *WorkflowServiceClient) sendVerifyHistory(verifyRequest *shared.VerifyHistoryRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("VerifyHistory", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := WorkflowServiceVerifyHistoryArgs{
+  VerifyRequest : verifyRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *WorkflowServiceClient) recvVerifyHistory() (value *shared.VerifyHistoryResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "VerifyHistory" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "VerifyHistory failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "VerifyHistory failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error58 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error59 error
+    error59, err = error58.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error59
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "VerifyHistory failed: invalid message type")
+    return
+  }
+  result := WorkflowServiceVerifyHistoryResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+
+type WorkflowServiceProcessor struct {
+  processorMap map[string]thrift.TProcessorFunction
   handler WorkflowService
 }
 
-func (p *workflowServiceProcessorTerminateWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceTerminateWorkflowExecutionArgs{}
+func (p *WorkflowServiceProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
+  p.processorMap[key] = processor
+}
+
+func (p *WorkflowServiceProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
+  processor, ok = p.processorMap[key]
+  return processor, ok
+}
+
+func (p *WorkflowServiceProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
+  return p.processorMap
+}
+
+func NewWorkflowServiceProcessor(handler WorkflowService) *WorkflowServiceProcessor {
+
+  self60 := &WorkflowServiceProcessor{handler:handler, processorMap:make(map[string]thrift.TProcessorFunction)}
+  self60.processorMap["RegisterDomain"] = &workflowServiceProcessorRegisterDomain{handler:handler}
+  self60.processorMap["DescribeDomain"] = &workflowServiceProcessorDescribeDomain{handler:handler}
+  self60.processorMap["UpdateDomain"] = &workflowServiceProcessorUpdateDomain{handler:handler}
+  self60.processorMap["DeprecateDomain"] = &workflowServiceProcessorDeprecateDomain{handler:handler}
+  self60.processorMap["DrainDomain"] = &workflowServiceProcessorDrainDomain{handler:handler}
+  self60.processorMap["StartWorkflowExecution"] = &workflowServiceProcessorStartWorkflowExecution{handler:handler}
+  self60.processorMap["GetWorkflowExecutionHistory"] = &workflowServiceProcessorGetWorkflowExecutionHistory{handler:handler}
+  self60.processorMap["PollForDecisionTask"] = &workflowServiceProcessorPollForDecisionTask{handler:handler}
+  self60.processorMap["RespondDecisionTaskCompleted"] = &workflowServiceProcessorRespondDecisionTaskCompleted{handler:handler}
+  self60.processorMap["PollForActivityTask"] = &workflowServiceProcessorPollForActivityTask{handler:handler}
+  self60.processorMap["RecordActivityTaskHeartbeat"] = &workflowServiceProcessorRecordActivityTaskHeartbeat{handler:handler}
+  self60.processorMap["RespondActivityTaskCompleted"] = &workflowServiceProcessorRespondActivityTaskCompleted{handler:handler}
+  self60.processorMap["RespondActivityTaskFailed"] = &workflowServiceProcessorRespondActivityTaskFailed{handler:handler}
+  self60.processorMap["RespondActivityTaskCanceled"] = &workflowServiceProcessorRespondActivityTaskCanceled{handler:handler}
+  self60.processorMap["RespondActivityTaskCompletedByID"] = &workflowServiceProcessorRespondActivityTaskCompletedByID{handler:handler}
+  self60.processorMap["RespondActivityTaskFailedByID"] = &workflowServiceProcessorRespondActivityTaskFailedByID{handler:handler}
+  self60.processorMap["RespondActivityTaskCanceledByID"] = &workflowServiceProcessorRespondActivityTaskCanceledByID{handler:handler}
+  self60.processorMap["RequestCancelWorkflowExecution"] = &workflowServiceProcessorRequestCancelWorkflowExecution{handler:handler}
+  self60.processorMap["SignalWorkflowExecution"] = &workflowServiceProcessorSignalWorkflowExecution{handler:handler}
+  self60.processorMap["GetSignalReceipt"] = &workflowServiceProcessorGetSignalReceipt{handler:handler}
+  self60.processorMap["QueryWorkflow"] = &workflowServiceProcessorQueryWorkflow{handler:handler}
+  self60.processorMap["DescribeWorkflowExecution"] = &workflowServiceProcessorDescribeWorkflowExecution{handler:handler}
+  self60.processorMap["TerminateWorkflowExecution"] = &workflowServiceProcessorTerminateWorkflowExecution{handler:handler}
+  self60.processorMap["ListOpenWorkflowExecutions"] = &workflowServiceProcessorListOpenWorkflowExecutions{handler:handler}
+  self60.processorMap["ListClosedWorkflowExecutions"] = &workflowServiceProcessorListClosedWorkflowExecutions{handler:handler}
+  self60.processorMap["ListClosedWorkflowExecutionsSince"] = &workflowServiceProcessorListClosedWorkflowExecutionsSince{handler:handler}
+  self60.processorMap["DescribeCluster"] = &workflowServiceProcessorDescribeCluster{handler:handler}
+  self60.processorMap["RefreshWorkflowTasks"] = &workflowServiceProcessorRefreshWorkflowTasks{handler:handler}
+  self60.processorMap["MigrateWorkflowExecution"] = &workflowServiceProcessorMigrateWorkflowExecution{handler:handler}
+  self60.processorMap["VerifyHistory"] = &workflowServiceProcessorVerifyHistory{handler:handler}
+return self60
+}
+
+func (p *WorkflowServiceProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  name, _, seqId, err := iprot.ReadMessageBegin()
+  if err != nil { return false, err }
+  if processor, ok := p.GetProcessorFunction(name); ok {
+    return processor.Process(seqId, iprot, oprot)
+  }
+  iprot.Skip(thrift.STRUCT)
+  iprot.ReadMessageEnd()
+  x61 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function " + name)
+  oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
+  x61.Write(oprot)
+  oprot.WriteMessageEnd()
+  oprot.Flush()
+  return false, x61
+
+}
+
+type workflowServiceProcessorRegisterDomain struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRegisterDomain) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRegisterDomainArgs{}
   if err = args.Read(iprot); err != nil {
     iprot.ReadMessageEnd()
     x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.EXCEPTION, seqId)
+    oprot.WriteMessageBegin("RegisterDomain", thrift.EXCEPTION, seqId)
     x.Write(oprot)
     oprot.WriteMessageEnd()
     oprot.Flush()
@@ -2767,26 +3170,26 @@ func (p *workflowServiceProcessorTerminateWorkflowExecution) Process(seqId int32
   }
 
   iprot.ReadMessageEnd()
-  result := WorkflowServiceTerminateWorkflowExecutionResult{}
+  result := WorkflowServiceRegisterDomainResult{}
   var err2 error
-  if err2 = p.handler.TerminateWorkflowExecution(args.TerminateRequest); err2 != nil {
+  if err2 = p.handler.RegisterDomain(args.RegisterRequest); err2 != nil {
   switch v := err2.(type) {
     case *shared.BadRequestError:
   result.BadRequestError = v
     case *shared.InternalServiceError:
   result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
+    case *shared.DomainAlreadyExistsError:
+  result.DomainExistsError = v
     default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing TerminateWorkflowExecution: " + err2.Error())
-    oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.EXCEPTION, seqId)
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RegisterDomain: " + err2.Error())
+    oprot.WriteMessageBegin("RegisterDomain", thrift.EXCEPTION, seqId)
     x.Write(oprot)
     oprot.WriteMessageEnd()
     oprot.Flush()
     return true, err2
   }
   }
-  if err2 = oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+  if err2 = oprot.WriteMessageBegin("RegisterDomain", thrift.REPLY, seqId); err2 != nil {
     err = err2
   }
   if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -2804,16 +3207,16 @@ func (p *workflowServiceProcessorTerminateWorkflowExecution) Process(seqId int32
   return true, err
 }
 
-type workflowServiceProcessorListOpenWorkflowExecutions struct {
+type workflowServiceProcessorDescribeDomain struct {
   handler WorkflowService
 }
 
-func (p *workflowServiceProcessorListOpenWorkflowExecutions) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceListOpenWorkflowExecutionsArgs{}
+func (p *workflowServiceProcessorDescribeDomain) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceDescribeDomainArgs{}
   if err = args.Read(iprot); err != nil {
     iprot.ReadMessageEnd()
     x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("ListOpenWorkflowExecutions", thrift.EXCEPTION, seqId)
+    oprot.WriteMessageBegin("DescribeDomain", thrift.EXCEPTION, seqId)
     x.Write(oprot)
     oprot.WriteMessageEnd()
     oprot.Flush()
@@ -2821,10 +3224,10 @@ func (p *workflowServiceProcessorListOpenWorkflowExecutions) Process(seqId int32
   }
 
   iprot.ReadMessageEnd()
-  result := WorkflowServiceListOpenWorkflowExecutionsResult{}
-var retval *shared.ListOpenWorkflowExecutionsResponse
+  result := WorkflowServiceDescribeDomainResult{}
+var retval *shared.DescribeDomainResponse
   var err2 error
-  if retval, err2 = p.handler.ListOpenWorkflowExecutions(args.ListRequest); err2 != nil {
+  if retval, err2 = p.handler.DescribeDomain(args.DescribeRequest); err2 != nil {
   switch v := err2.(type) {
     case *shared.BadRequestError:
   result.BadRequestError = v
@@ -2833,8 +3236,8 @@ var retval *shared.ListOpenWorkflowExecutionsResponse
     case *shared.EntityNotExistsError:
   result.EntityNotExistError = v
     default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListOpenWorkflowExecutions: " + err2.Error())
-    oprot.WriteMessageBegin("ListOpenWorkflowExecutions", thrift.EXCEPTION, seqId)
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DescribeDomain: " + err2.Error())
+    oprot.WriteMessageBegin("DescribeDomain", thrift.EXCEPTION, seqId)
     x.Write(oprot)
     oprot.WriteMessageEnd()
     oprot.Flush()
@@ -2843,7 +3246,7 @@ var retval *shared.ListOpenWorkflowExecutionsResponse
   } else {
     result.Success = retval
 }
-  if err2 = oprot.WriteMessageBegin("ListOpenWorkflowExecutions", thrift.REPLY, seqId); err2 != nil {
+  if err2 = oprot.WriteMessageBegin("DescribeDomain", thrift.REPLY, seqId); err2 != nil {
     err = err2
   }
   if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -2861,16 +3264,16 @@ var retval *shared.ListOpenWorkflowExecutionsResponse
   return true, err
 }
 
-type workflowServiceProcessorListClosedWorkflowExecutions struct {
+type workflowServiceProcessorUpdateDomain struct {
   handler WorkflowService
 }
 
-func (p *workflowServiceProcessorListClosedWorkflowExecutions) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := WorkflowServiceListClosedWorkflowExecutionsArgs{}
+func (p *workflowServiceProcessorUpdateDomain) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceUpdateDomainArgs{}
   if err = args.Read(iprot); err != nil {
     iprot.ReadMessageEnd()
     x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("ListClosedWorkflowExecutions", thrift.EXCEPTION, seqId)
+    oprot.WriteMessageBegin("UpdateDomain", thrift.EXCEPTION, seqId)
     x.Write(oprot)
     oprot.WriteMessageEnd()
     oprot.Flush()
@@ -2878,10 +3281,10 @@ func (p *workflowServiceProcessorListClosedWorkflowExecutions) Process(seqId int
   }
 
   iprot.ReadMessageEnd()
-  result := WorkflowServiceListClosedWorkflowExecutionsResult{}
-var retval *shared.ListClosedWorkflowExecutionsResponse
+  result := WorkflowServiceUpdateDomainResult{}
+var retval *shared.UpdateDomainResponse
   var err2 error
-  if retval, err2 = p.handler.ListClosedWorkflowExecutions(args.ListRequest); err2 != nil {
+  if retval, err2 = p.handler.UpdateDomain(args.UpdateRequest); err2 != nil {
   switch v := err2.(type) {
     case *shared.BadRequestError:
   result.BadRequestError = v
@@ -2890,8 +3293,8 @@ var retval *shared.ListClosedWorkflowExecutionsResponse
     case *shared.EntityNotExistsError:
   result.EntityNotExistError = v
     default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListClosedWorkflowExecutions: " + err2.Error())
-    oprot.WriteMessageBegin("ListClosedWorkflowExecutions", thrift.EXCEPTION, seqId)
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UpdateDomain: " + err2.Error())
+    oprot.WriteMessageBegin("UpdateDomain", thrift.EXCEPTION, seqId)
     x.Write(oprot)
     oprot.WriteMessageEnd()
     oprot.Flush()
@@ -2900,7 +3303,7 @@ var retval *shared.ListClosedWorkflowExecutionsResponse
   } else {
     result.Success = retval
 }
-  if err2 = oprot.WriteMessageBegin("ListClosedWorkflowExecutions", thrift.REPLY, seqId); err2 != nil {
+  if err2 = oprot.WriteMessageBegin("UpdateDomain", thrift.REPLY, seqId); err2 != nil {
     err = err2
   }
   if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -2918,31 +3321,5138 @@ var retval *shared.ListClosedWorkflowExecutionsResponse
   return true, err
 }
 
-
-// HELPER FUNCTIONS AND STRUCTURES
+type workflowServiceProcessorDeprecateDomain struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorDeprecateDomain) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceDeprecateDomainArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("DeprecateDomain", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceDeprecateDomainResult{}
+  var err2 error
+  if err2 = p.handler.DeprecateDomain(args.DeprecateRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DeprecateDomain: " + err2.Error())
+    oprot.WriteMessageBegin("DeprecateDomain", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("DeprecateDomain", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorDrainDomain struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorDrainDomain) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceDrainDomainArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("DrainDomain", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceDrainDomainResult{}
+  var err2 error
+  if err2 = p.handler.DrainDomain(args.DrainRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DrainDomain: " + err2.Error())
+    oprot.WriteMessageBegin("DrainDomain", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("DrainDomain", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorStartWorkflowExecution struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorStartWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceStartWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("StartWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceStartWorkflowExecutionResult{}
+var retval *shared.StartWorkflowExecutionResponse
+  var err2 error
+  if retval, err2 = p.handler.StartWorkflowExecution(args.StartRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.WorkflowExecutionAlreadyStartedError:
+  result.SessionAlreadyExistError = v
+    case *shared.LimitExceededError:
+  result.LimitExceededError = v
+    case *shared.DomainNotActiveError:
+  result.DomainNotActiveError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing StartWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("StartWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("StartWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorGetWorkflowExecutionHistory struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorGetWorkflowExecutionHistory) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceGetWorkflowExecutionHistoryArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("GetWorkflowExecutionHistory", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceGetWorkflowExecutionHistoryResult{}
+var retval *shared.GetWorkflowExecutionHistoryResponse
+  var err2 error
+  if retval, err2 = p.handler.GetWorkflowExecutionHistory(args.GetRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetWorkflowExecutionHistory: " + err2.Error())
+    oprot.WriteMessageBegin("GetWorkflowExecutionHistory", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("GetWorkflowExecutionHistory", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorPollForDecisionTask struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorPollForDecisionTask) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServicePollForDecisionTaskArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("PollForDecisionTask", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServicePollForDecisionTaskResult{}
+var retval *shared.PollForDecisionTaskResponse
+  var err2 error
+  if retval, err2 = p.handler.PollForDecisionTask(args.PollRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.ServiceBusyError:
+  result.ServiceBusyError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing PollForDecisionTask: " + err2.Error())
+    oprot.WriteMessageBegin("PollForDecisionTask", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("PollForDecisionTask", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRespondDecisionTaskCompleted struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRespondDecisionTaskCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRespondDecisionTaskCompletedArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRespondDecisionTaskCompletedResult{}
+var retval *shared.RespondDecisionTaskCompletedResponse
+  var err2 error
+  if retval, err2 = p.handler.RespondDecisionTaskCompleted(args.CompleteRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondDecisionTaskCompleted: " + err2.Error())
+    oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorPollForActivityTask struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorPollForActivityTask) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServicePollForActivityTaskArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("PollForActivityTask", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServicePollForActivityTaskResult{}
+var retval *shared.PollForActivityTaskResponse
+  var err2 error
+  if retval, err2 = p.handler.PollForActivityTask(args.PollRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.ServiceBusyError:
+  result.ServiceBusyError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing PollForActivityTask: " + err2.Error())
+    oprot.WriteMessageBegin("PollForActivityTask", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("PollForActivityTask", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRecordActivityTaskHeartbeat struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRecordActivityTaskHeartbeat) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRecordActivityTaskHeartbeatArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRecordActivityTaskHeartbeatResult{}
+var retval *shared.RecordActivityTaskHeartbeatResponse
+  var err2 error
+  if retval, err2 = p.handler.RecordActivityTaskHeartbeat(args.HeartbeatRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *shared.ServiceBusyError:
+  result.ServiceBusyError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordActivityTaskHeartbeat: " + err2.Error())
+    oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRespondActivityTaskCompleted struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRespondActivityTaskCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRespondActivityTaskCompletedArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRespondActivityTaskCompletedResult{}
+  var err2 error
+  if err2 = p.handler.RespondActivityTaskCompleted(args.CompleteRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCompleted: " + err2.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRespondActivityTaskFailed struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRespondActivityTaskFailed) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRespondActivityTaskFailedArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRespondActivityTaskFailedResult{}
+  var err2 error
+  if err2 = p.handler.RespondActivityTaskFailed(args.FailRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskFailed: " + err2.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRespondActivityTaskCanceled struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRespondActivityTaskCanceled) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRespondActivityTaskCanceledArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRespondActivityTaskCanceledResult{}
+  var err2 error
+  if err2 = p.handler.RespondActivityTaskCanceled(args.CanceledRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCanceled: " + err2.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRespondActivityTaskCompletedByID struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRespondActivityTaskCompletedByID) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRespondActivityTaskCompletedByIDArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCompletedByID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRespondActivityTaskCompletedByIDResult{}
+  var err2 error
+  if err2 = p.handler.RespondActivityTaskCompletedByID(args.CompleteRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCompletedByID: " + err2.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCompletedByID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCompletedByID", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRespondActivityTaskFailedByID struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRespondActivityTaskFailedByID) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRespondActivityTaskFailedByIDArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskFailedByID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRespondActivityTaskFailedByIDResult{}
+  var err2 error
+  if err2 = p.handler.RespondActivityTaskFailedByID(args.FailedRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskFailedByID: " + err2.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskFailedByID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RespondActivityTaskFailedByID", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRespondActivityTaskCanceledByID struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRespondActivityTaskCanceledByID) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRespondActivityTaskCanceledByIDArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCanceledByID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRespondActivityTaskCanceledByIDResult{}
+  var err2 error
+  if err2 = p.handler.RespondActivityTaskCanceledByID(args.CanceledRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCanceledByID: " + err2.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCanceledByID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCanceledByID", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRequestCancelWorkflowExecution struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRequestCancelWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRequestCancelWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRequestCancelWorkflowExecutionResult{}
+  var err2 error
+  if err2 = p.handler.RequestCancelWorkflowExecution(args.CancelRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RequestCancelWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorSignalWorkflowExecution struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorSignalWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceSignalWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceSignalWorkflowExecutionResult{}
+var retval *shared.SignalWorkflowExecutionResponse
+  var err2 error
+  if retval, err2 = p.handler.SignalWorkflowExecution(args.SignalRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SignalWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorGetSignalReceipt struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorGetSignalReceipt) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceGetSignalReceiptArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("GetSignalReceipt", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceGetSignalReceiptResult{}
+var retval *shared.GetSignalReceiptResponse
+  var err2 error
+  if retval, err2 = p.handler.GetSignalReceipt(args.ReceiptRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetSignalReceipt: " + err2.Error())
+    oprot.WriteMessageBegin("GetSignalReceipt", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("GetSignalReceipt", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorQueryWorkflow struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorQueryWorkflow) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceQueryWorkflowArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("QueryWorkflow", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceQueryWorkflowResult{}
+var retval *shared.QueryWorkflowResponse
+  var err2 error
+  if retval, err2 = p.handler.QueryWorkflow(args.QueryRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *shared.QueryFailedError:
+  result.QueryFailedError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing QueryWorkflow: " + err2.Error())
+    oprot.WriteMessageBegin("QueryWorkflow", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("QueryWorkflow", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorDescribeWorkflowExecution struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorDescribeWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceDescribeWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("DescribeWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceDescribeWorkflowExecutionResult{}
+var retval *shared.DescribeWorkflowExecutionResponse
+  var err2 error
+  if retval, err2 = p.handler.DescribeWorkflowExecution(args.DescribeRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DescribeWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("DescribeWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("DescribeWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorTerminateWorkflowExecution struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorTerminateWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceTerminateWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceTerminateWorkflowExecutionResult{}
+var retval *shared.TerminateWorkflowExecutionResponse
+  var err2 error
+  if retval, err2 = p.handler.TerminateWorkflowExecution(args.TerminateRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing TerminateWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorListOpenWorkflowExecutions struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorListOpenWorkflowExecutions) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceListOpenWorkflowExecutionsArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ListOpenWorkflowExecutions", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceListOpenWorkflowExecutionsResult{}
+var retval *shared.ListOpenWorkflowExecutionsResponse
+  var err2 error
+  if retval, err2 = p.handler.ListOpenWorkflowExecutions(args.ListRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListOpenWorkflowExecutions: " + err2.Error())
+    oprot.WriteMessageBegin("ListOpenWorkflowExecutions", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ListOpenWorkflowExecutions", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorListClosedWorkflowExecutions struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorListClosedWorkflowExecutions) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceListClosedWorkflowExecutionsArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ListClosedWorkflowExecutions", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceListClosedWorkflowExecutionsResult{}
+var retval *shared.ListClosedWorkflowExecutionsResponse
+  var err2 error
+  if retval, err2 = p.handler.ListClosedWorkflowExecutions(args.ListRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListClosedWorkflowExecutions: " + err2.Error())
+    oprot.WriteMessageBegin("ListClosedWorkflowExecutions", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ListClosedWorkflowExecutions", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorListClosedWorkflowExecutionsSince struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorListClosedWorkflowExecutionsSince) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceListClosedWorkflowExecutionsSinceArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ListClosedWorkflowExecutionsSince", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceListClosedWorkflowExecutionsSinceResult{}
+var retval *shared.ListClosedWorkflowExecutionsSinceResponse
+  var err2 error
+  if retval, err2 = p.handler.ListClosedWorkflowExecutionsSince(args.ListRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListClosedWorkflowExecutionsSince: " + err2.Error())
+    oprot.WriteMessageBegin("ListClosedWorkflowExecutionsSince", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ListClosedWorkflowExecutionsSince", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorDescribeCluster struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorDescribeCluster) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceDescribeClusterArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("DescribeCluster", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceDescribeClusterResult{}
+var retval *shared.DescribeClusterResponse
+  var err2 error
+  if retval, err2 = p.handler.DescribeCluster(); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DescribeCluster: " + err2.Error())
+    oprot.WriteMessageBegin("DescribeCluster", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("DescribeCluster", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorRefreshWorkflowTasks struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorRefreshWorkflowTasks) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceRefreshWorkflowTasksArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RefreshWorkflowTasks", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceRefreshWorkflowTasksResult{}
+  var err2 error
+  if err2 = p.handler.RefreshWorkflowTasks(args.RefreshRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RefreshWorkflowTasks: " + err2.Error())
+    oprot.WriteMessageBegin("RefreshWorkflowTasks", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RefreshWorkflowTasks", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorMigrateWorkflowExecution struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorMigrateWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceMigrateWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("MigrateWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceMigrateWorkflowExecutionResult{}
+  var err2 error
+  if err2 = p.handler.MigrateWorkflowExecution(args.MigrateRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *shared.WorkflowExecutionAlreadyStartedError:
+  result.WorkflowAlreadyStartedError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing MigrateWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("MigrateWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("MigrateWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type workflowServiceProcessorVerifyHistory struct {
+  handler WorkflowService
+}
+
+func (p *workflowServiceProcessorVerifyHistory) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := WorkflowServiceVerifyHistoryArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("VerifyHistory", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := WorkflowServiceVerifyHistoryResult{}
+var retval *shared.VerifyHistoryResponse
+  var err2 error
+  if retval, err2 = p.handler.VerifyHistory(args.VerifyRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing VerifyHistory: " + err2.Error())
+    oprot.WriteMessageBegin("VerifyHistory", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("VerifyHistory", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+// Attributes:
+//  - RegisterRequest
+type WorkflowServiceRegisterDomainArgs struct {
+  RegisterRequest *shared.RegisterDomainRequest `thrift:"registerRequest,1" db:"registerRequest" json:"registerRequest"`
+}
+
+func NewWorkflowServiceRegisterDomainArgs() *WorkflowServiceRegisterDomainArgs {
+  return &WorkflowServiceRegisterDomainArgs{}
+}
+
+var WorkflowServiceRegisterDomainArgs_RegisterRequest_DEFAULT *shared.RegisterDomainRequest
+func (p *WorkflowServiceRegisterDomainArgs) GetRegisterRequest() *shared.RegisterDomainRequest {
+  if !p.IsSetRegisterRequest() {
+    return WorkflowServiceRegisterDomainArgs_RegisterRequest_DEFAULT
+  }
+return p.RegisterRequest
+}
+func (p *WorkflowServiceRegisterDomainArgs) IsSetRegisterRequest() bool {
+  return p.RegisterRequest != nil
+}
+
+func (p *WorkflowServiceRegisterDomainArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRegisterDomainArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.RegisterRequest = &shared.RegisterDomainRequest{}
+  if err := p.RegisterRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.RegisterRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRegisterDomainArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RegisterDomain_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceRegisterDomainArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("registerRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:registerRequest: ", p), err) }
+  if err := p.RegisterRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.RegisterRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:registerRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceRegisterDomainArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceRegisterDomainArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - BadRequestError
+//  - InternalServiceError
+//  - DomainExistsError
+type WorkflowServiceRegisterDomainResult struct {
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  DomainExistsError *shared.DomainAlreadyExistsError `thrift:"domainExistsError,3" db:"domainExistsError" json:"domainExistsError,omitempty"`
+}
+
+func NewWorkflowServiceRegisterDomainResult() *WorkflowServiceRegisterDomainResult {
+  return &WorkflowServiceRegisterDomainResult{}
+}
+
+var WorkflowServiceRegisterDomainResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRegisterDomainResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceRegisterDomainResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceRegisterDomainResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRegisterDomainResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceRegisterDomainResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceRegisterDomainResult_DomainExistsError_DEFAULT *shared.DomainAlreadyExistsError
+func (p *WorkflowServiceRegisterDomainResult) GetDomainExistsError() *shared.DomainAlreadyExistsError {
+  if !p.IsSetDomainExistsError() {
+    return WorkflowServiceRegisterDomainResult_DomainExistsError_DEFAULT
+  }
+return p.DomainExistsError
+}
+func (p *WorkflowServiceRegisterDomainResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceRegisterDomainResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceRegisterDomainResult) IsSetDomainExistsError() bool {
+  return p.DomainExistsError != nil
+}
+
+func (p *WorkflowServiceRegisterDomainResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRegisterDomainResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRegisterDomainResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRegisterDomainResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.DomainExistsError = &shared.DomainAlreadyExistsError{}
+  if err := p.DomainExistsError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DomainExistsError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRegisterDomainResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RegisterDomain_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceRegisterDomainResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRegisterDomainResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRegisterDomainResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainExistsError() {
+    if err := oprot.WriteFieldBegin("domainExistsError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:domainExistsError: ", p), err) }
+    if err := p.DomainExistsError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DomainExistsError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:domainExistsError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRegisterDomainResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceRegisterDomainResult(%+v)", *p)
+}
+
+// Attributes:
+//  - DescribeRequest
+type WorkflowServiceDescribeDomainArgs struct {
+  DescribeRequest *shared.DescribeDomainRequest `thrift:"describeRequest,1" db:"describeRequest" json:"describeRequest"`
+}
+
+func NewWorkflowServiceDescribeDomainArgs() *WorkflowServiceDescribeDomainArgs {
+  return &WorkflowServiceDescribeDomainArgs{}
+}
+
+var WorkflowServiceDescribeDomainArgs_DescribeRequest_DEFAULT *shared.DescribeDomainRequest
+func (p *WorkflowServiceDescribeDomainArgs) GetDescribeRequest() *shared.DescribeDomainRequest {
+  if !p.IsSetDescribeRequest() {
+    return WorkflowServiceDescribeDomainArgs_DescribeRequest_DEFAULT
+  }
+return p.DescribeRequest
+}
+func (p *WorkflowServiceDescribeDomainArgs) IsSetDescribeRequest() bool {
+  return p.DescribeRequest != nil
+}
+
+func (p *WorkflowServiceDescribeDomainArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDescribeDomainArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.DescribeRequest = &shared.DescribeDomainRequest{}
+  if err := p.DescribeRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DescribeRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDescribeDomainArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeDomain_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceDescribeDomainArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("describeRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:describeRequest: ", p), err) }
+  if err := p.DescribeRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DescribeRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:describeRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceDescribeDomainArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceDescribeDomainArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+type WorkflowServiceDescribeDomainResult struct {
+  Success *shared.DescribeDomainResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+}
+
+func NewWorkflowServiceDescribeDomainResult() *WorkflowServiceDescribeDomainResult {
+  return &WorkflowServiceDescribeDomainResult{}
+}
+
+var WorkflowServiceDescribeDomainResult_Success_DEFAULT *shared.DescribeDomainResponse
+func (p *WorkflowServiceDescribeDomainResult) GetSuccess() *shared.DescribeDomainResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceDescribeDomainResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceDescribeDomainResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceDescribeDomainResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceDescribeDomainResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceDescribeDomainResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceDescribeDomainResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceDescribeDomainResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceDescribeDomainResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceDescribeDomainResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceDescribeDomainResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+func (p *WorkflowServiceDescribeDomainResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.DescribeDomainResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeDomain_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceDescribeDomainResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDescribeDomainResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDescribeDomainResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDescribeDomainResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDescribeDomainResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceDescribeDomainResult(%+v)", *p)
+}
+
+// Attributes:
+//  - UpdateRequest
+type WorkflowServiceUpdateDomainArgs struct {
+  UpdateRequest *shared.UpdateDomainRequest `thrift:"updateRequest,1" db:"updateRequest" json:"updateRequest"`
+}
+
+func NewWorkflowServiceUpdateDomainArgs() *WorkflowServiceUpdateDomainArgs {
+  return &WorkflowServiceUpdateDomainArgs{}
+}
+
+var WorkflowServiceUpdateDomainArgs_UpdateRequest_DEFAULT *shared.UpdateDomainRequest
+func (p *WorkflowServiceUpdateDomainArgs) GetUpdateRequest() *shared.UpdateDomainRequest {
+  if !p.IsSetUpdateRequest() {
+    return WorkflowServiceUpdateDomainArgs_UpdateRequest_DEFAULT
+  }
+return p.UpdateRequest
+}
+func (p *WorkflowServiceUpdateDomainArgs) IsSetUpdateRequest() bool {
+  return p.UpdateRequest != nil
+}
+
+func (p *WorkflowServiceUpdateDomainArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceUpdateDomainArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.UpdateRequest = &shared.UpdateDomainRequest{}
+  if err := p.UpdateRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.UpdateRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceUpdateDomainArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("UpdateDomain_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceUpdateDomainArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("updateRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:updateRequest: ", p), err) }
+  if err := p.UpdateRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.UpdateRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:updateRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceUpdateDomainArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceUpdateDomainArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+type WorkflowServiceUpdateDomainResult struct {
+  Success *shared.UpdateDomainResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+}
+
+func NewWorkflowServiceUpdateDomainResult() *WorkflowServiceUpdateDomainResult {
+  return &WorkflowServiceUpdateDomainResult{}
+}
+
+var WorkflowServiceUpdateDomainResult_Success_DEFAULT *shared.UpdateDomainResponse
+func (p *WorkflowServiceUpdateDomainResult) GetSuccess() *shared.UpdateDomainResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceUpdateDomainResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceUpdateDomainResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceUpdateDomainResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceUpdateDomainResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceUpdateDomainResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceUpdateDomainResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceUpdateDomainResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceUpdateDomainResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceUpdateDomainResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceUpdateDomainResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+func (p *WorkflowServiceUpdateDomainResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.UpdateDomainResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("UpdateDomain_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceUpdateDomainResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceUpdateDomainResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceUpdateDomainResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceUpdateDomainResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceUpdateDomainResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceUpdateDomainResult(%+v)", *p)
+}
+
+// Attributes:
+//  - DeprecateRequest
+type WorkflowServiceDeprecateDomainArgs struct {
+  DeprecateRequest *shared.DeprecateDomainRequest `thrift:"deprecateRequest,1" db:"deprecateRequest" json:"deprecateRequest"`
+}
+
+func NewWorkflowServiceDeprecateDomainArgs() *WorkflowServiceDeprecateDomainArgs {
+  return &WorkflowServiceDeprecateDomainArgs{}
+}
+
+var WorkflowServiceDeprecateDomainArgs_DeprecateRequest_DEFAULT *shared.DeprecateDomainRequest
+func (p *WorkflowServiceDeprecateDomainArgs) GetDeprecateRequest() *shared.DeprecateDomainRequest {
+  if !p.IsSetDeprecateRequest() {
+    return WorkflowServiceDeprecateDomainArgs_DeprecateRequest_DEFAULT
+  }
+return p.DeprecateRequest
+}
+func (p *WorkflowServiceDeprecateDomainArgs) IsSetDeprecateRequest() bool {
+  return p.DeprecateRequest != nil
+}
+
+func (p *WorkflowServiceDeprecateDomainArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDeprecateDomainArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.DeprecateRequest = &shared.DeprecateDomainRequest{}
+  if err := p.DeprecateRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DeprecateRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDeprecateDomainArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DeprecateDomain_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceDeprecateDomainArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("deprecateRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:deprecateRequest: ", p), err) }
+  if err := p.DeprecateRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DeprecateRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:deprecateRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceDeprecateDomainArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceDeprecateDomainArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+type WorkflowServiceDeprecateDomainResult struct {
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+}
+
+func NewWorkflowServiceDeprecateDomainResult() *WorkflowServiceDeprecateDomainResult {
+  return &WorkflowServiceDeprecateDomainResult{}
+}
+
+var WorkflowServiceDeprecateDomainResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceDeprecateDomainResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceDeprecateDomainResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceDeprecateDomainResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceDeprecateDomainResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceDeprecateDomainResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceDeprecateDomainResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceDeprecateDomainResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceDeprecateDomainResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+func (p *WorkflowServiceDeprecateDomainResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceDeprecateDomainResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceDeprecateDomainResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceDeprecateDomainResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDeprecateDomainResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDeprecateDomainResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDeprecateDomainResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDeprecateDomainResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DeprecateDomain_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceDeprecateDomainResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDeprecateDomainResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDeprecateDomainResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDeprecateDomainResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceDeprecateDomainResult(%+v)", *p)
+}
+
+// Attributes:
+//  - DrainRequest
+type WorkflowServiceDrainDomainArgs struct {
+  DrainRequest *shared.DrainDomainRequest `thrift:"drainRequest,1" db:"drainRequest" json:"drainRequest"`
+}
+
+func NewWorkflowServiceDrainDomainArgs() *WorkflowServiceDrainDomainArgs {
+  return &WorkflowServiceDrainDomainArgs{}
+}
+
+var WorkflowServiceDrainDomainArgs_DrainRequest_DEFAULT *shared.DrainDomainRequest
+func (p *WorkflowServiceDrainDomainArgs) GetDrainRequest() *shared.DrainDomainRequest {
+  if !p.IsSetDrainRequest() {
+    return WorkflowServiceDrainDomainArgs_DrainRequest_DEFAULT
+  }
+return p.DrainRequest
+}
+func (p *WorkflowServiceDrainDomainArgs) IsSetDrainRequest() bool {
+  return p.DrainRequest != nil
+}
+
+func (p *WorkflowServiceDrainDomainArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDrainDomainArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.DrainRequest = &shared.DrainDomainRequest{}
+  if err := p.DrainRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DrainRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDrainDomainArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DrainDomain_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceDrainDomainArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("drainRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:drainRequest: ", p), err) }
+  if err := p.DrainRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DrainRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:drainRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceDrainDomainArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceDrainDomainArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+type WorkflowServiceDrainDomainResult struct {
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+}
+
+func NewWorkflowServiceDrainDomainResult() *WorkflowServiceDrainDomainResult {
+  return &WorkflowServiceDrainDomainResult{}
+}
+
+var WorkflowServiceDrainDomainResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceDrainDomainResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceDrainDomainResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceDrainDomainResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceDrainDomainResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceDrainDomainResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceDrainDomainResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceDrainDomainResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceDrainDomainResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+func (p *WorkflowServiceDrainDomainResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceDrainDomainResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceDrainDomainResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceDrainDomainResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDrainDomainResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDrainDomainResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDrainDomainResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceDrainDomainResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DrainDomain_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceDrainDomainResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDrainDomainResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDrainDomainResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceDrainDomainResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceDrainDomainResult(%+v)", *p)
+}
+
+// Attributes:
+//  - StartRequest
+type WorkflowServiceStartWorkflowExecutionArgs struct {
+  StartRequest *shared.StartWorkflowExecutionRequest `thrift:"startRequest,1" db:"startRequest" json:"startRequest"`
+}
+
+func NewWorkflowServiceStartWorkflowExecutionArgs() *WorkflowServiceStartWorkflowExecutionArgs {
+  return &WorkflowServiceStartWorkflowExecutionArgs{}
+}
+
+var WorkflowServiceStartWorkflowExecutionArgs_StartRequest_DEFAULT *shared.StartWorkflowExecutionRequest
+func (p *WorkflowServiceStartWorkflowExecutionArgs) GetStartRequest() *shared.StartWorkflowExecutionRequest {
+  if !p.IsSetStartRequest() {
+    return WorkflowServiceStartWorkflowExecutionArgs_StartRequest_DEFAULT
+  }
+return p.StartRequest
+}
+func (p *WorkflowServiceStartWorkflowExecutionArgs) IsSetStartRequest() bool {
+  return p.StartRequest != nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.StartRequest = &shared.StartWorkflowExecutionRequest{}
+  if err := p.StartRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.StartRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("StartWorkflowExecution_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("startRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:startRequest: ", p), err) }
+  if err := p.StartRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.StartRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:startRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceStartWorkflowExecutionArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - SessionAlreadyExistError
+//  - LimitExceededError
+//  - DomainNotActiveError
+type WorkflowServiceStartWorkflowExecutionResult struct {
+  Success *shared.StartWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  SessionAlreadyExistError *shared.WorkflowExecutionAlreadyStartedError `thrift:"sessionAlreadyExistError,3" db:"sessionAlreadyExistError" json:"sessionAlreadyExistError,omitempty"`
+  LimitExceededError *shared.LimitExceededError `thrift:"limitExceededError,4" db:"limitExceededError" json:"limitExceededError,omitempty"`
+  DomainNotActiveError *shared.DomainNotActiveError `thrift:"domainNotActiveError,5" db:"domainNotActiveError" json:"domainNotActiveError,omitempty"`
+}
+
+func NewWorkflowServiceStartWorkflowExecutionResult() *WorkflowServiceStartWorkflowExecutionResult {
+  return &WorkflowServiceStartWorkflowExecutionResult{}
+}
+
+var WorkflowServiceStartWorkflowExecutionResult_Success_DEFAULT *shared.StartWorkflowExecutionResponse
+func (p *WorkflowServiceStartWorkflowExecutionResult) GetSuccess() *shared.StartWorkflowExecutionResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceStartWorkflowExecutionResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceStartWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceStartWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceStartWorkflowExecutionResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceStartWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceStartWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceStartWorkflowExecutionResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceStartWorkflowExecutionResult_SessionAlreadyExistError_DEFAULT *shared.WorkflowExecutionAlreadyStartedError
+func (p *WorkflowServiceStartWorkflowExecutionResult) GetSessionAlreadyExistError() *shared.WorkflowExecutionAlreadyStartedError {
+  if !p.IsSetSessionAlreadyExistError() {
+    return WorkflowServiceStartWorkflowExecutionResult_SessionAlreadyExistError_DEFAULT
+  }
+return p.SessionAlreadyExistError
+}
+var WorkflowServiceStartWorkflowExecutionResult_LimitExceededError_DEFAULT *shared.LimitExceededError
+func (p *WorkflowServiceStartWorkflowExecutionResult) GetLimitExceededError() *shared.LimitExceededError {
+  if !p.IsSetLimitExceededError() {
+    return WorkflowServiceStartWorkflowExecutionResult_LimitExceededError_DEFAULT
+  }
+return p.LimitExceededError
+}
+var WorkflowServiceStartWorkflowExecutionResult_DomainNotActiveError_DEFAULT *shared.DomainNotActiveError
+func (p *WorkflowServiceStartWorkflowExecutionResult) GetDomainNotActiveError() *shared.DomainNotActiveError {
+  if !p.IsSetDomainNotActiveError() {
+    return WorkflowServiceStartWorkflowExecutionResult_DomainNotActiveError_DEFAULT
+  }
+return p.DomainNotActiveError
+}
+func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetSessionAlreadyExistError() bool {
+  return p.SessionAlreadyExistError != nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetLimitExceededError() bool {
+  return p.LimitExceededError != nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetDomainNotActiveError() bool {
+  return p.DomainNotActiveError != nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
+    case 5:
+      if err := p.ReadField5(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.StartWorkflowExecutionResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.SessionAlreadyExistError = &shared.WorkflowExecutionAlreadyStartedError{}
+  if err := p.SessionAlreadyExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SessionAlreadyExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.LimitExceededError = &shared.LimitExceededError{}
+  if err := p.LimitExceededError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.LimitExceededError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField5(iprot thrift.TProtocol) error {
+  p.DomainNotActiveError = &shared.DomainNotActiveError{}
+  if err := p.DomainNotActiveError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DomainNotActiveError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("StartWorkflowExecution_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSessionAlreadyExistError() {
+    if err := oprot.WriteFieldBegin("sessionAlreadyExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:sessionAlreadyExistError: ", p), err) }
+    if err := p.SessionAlreadyExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SessionAlreadyExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:sessionAlreadyExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetLimitExceededError() {
+    if err := oprot.WriteFieldBegin("limitExceededError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:limitExceededError: ", p), err) }
+    if err := p.LimitExceededError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.LimitExceededError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:limitExceededError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) writeField5(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainNotActiveError() {
+    if err := oprot.WriteFieldBegin("domainNotActiveError", thrift.STRUCT, 5); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:domainNotActiveError: ", p), err) }
+    if err := p.DomainNotActiveError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DomainNotActiveError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 5:domainNotActiveError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceStartWorkflowExecutionResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceStartWorkflowExecutionResult(%+v)", *p)
+}
+
+// Attributes:
+//  - GetRequest
+type WorkflowServiceGetWorkflowExecutionHistoryArgs struct {
+  GetRequest *shared.GetWorkflowExecutionHistoryRequest `thrift:"getRequest,1" db:"getRequest" json:"getRequest"`
+}
+
+func NewWorkflowServiceGetWorkflowExecutionHistoryArgs() *WorkflowServiceGetWorkflowExecutionHistoryArgs {
+  return &WorkflowServiceGetWorkflowExecutionHistoryArgs{}
+}
+
+var WorkflowServiceGetWorkflowExecutionHistoryArgs_GetRequest_DEFAULT *shared.GetWorkflowExecutionHistoryRequest
+func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) GetGetRequest() *shared.GetWorkflowExecutionHistoryRequest {
+  if !p.IsSetGetRequest() {
+    return WorkflowServiceGetWorkflowExecutionHistoryArgs_GetRequest_DEFAULT
+  }
+return p.GetRequest
+}
+func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) IsSetGetRequest() bool {
+  return p.GetRequest != nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.GetRequest = &shared.GetWorkflowExecutionHistoryRequest{}
+  if err := p.GetRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.GetRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetWorkflowExecutionHistory_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("getRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:getRequest: ", p), err) }
+  if err := p.GetRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.GetRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:getRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceGetWorkflowExecutionHistoryArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+type WorkflowServiceGetWorkflowExecutionHistoryResult struct {
+  Success *shared.GetWorkflowExecutionHistoryResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+}
+
+func NewWorkflowServiceGetWorkflowExecutionHistoryResult() *WorkflowServiceGetWorkflowExecutionHistoryResult {
+  return &WorkflowServiceGetWorkflowExecutionHistoryResult{}
+}
+
+var WorkflowServiceGetWorkflowExecutionHistoryResult_Success_DEFAULT *shared.GetWorkflowExecutionHistoryResponse
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) GetSuccess() *shared.GetWorkflowExecutionHistoryResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceGetWorkflowExecutionHistoryResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceGetWorkflowExecutionHistoryResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceGetWorkflowExecutionHistoryResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceGetWorkflowExecutionHistoryResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceGetWorkflowExecutionHistoryResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceGetWorkflowExecutionHistoryResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceGetWorkflowExecutionHistoryResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.GetWorkflowExecutionHistoryResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetWorkflowExecutionHistory_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceGetWorkflowExecutionHistoryResult(%+v)", *p)
+}
+
+// Attributes:
+//  - PollRequest
+type WorkflowServicePollForDecisionTaskArgs struct {
+  PollRequest *shared.PollForDecisionTaskRequest `thrift:"pollRequest,1" db:"pollRequest" json:"pollRequest"`
+}
+
+func NewWorkflowServicePollForDecisionTaskArgs() *WorkflowServicePollForDecisionTaskArgs {
+  return &WorkflowServicePollForDecisionTaskArgs{}
+}
+
+var WorkflowServicePollForDecisionTaskArgs_PollRequest_DEFAULT *shared.PollForDecisionTaskRequest
+func (p *WorkflowServicePollForDecisionTaskArgs) GetPollRequest() *shared.PollForDecisionTaskRequest {
+  if !p.IsSetPollRequest() {
+    return WorkflowServicePollForDecisionTaskArgs_PollRequest_DEFAULT
+  }
+return p.PollRequest
+}
+func (p *WorkflowServicePollForDecisionTaskArgs) IsSetPollRequest() bool {
+  return p.PollRequest != nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.PollRequest = &shared.PollForDecisionTaskRequest{}
+  if err := p.PollRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PollRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("PollForDecisionTask_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("pollRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:pollRequest: ", p), err) }
+  if err := p.PollRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PollRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:pollRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServicePollForDecisionTaskArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServicePollForDecisionTaskArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - ServiceBusyError
+type WorkflowServicePollForDecisionTaskResult struct {
+  Success *shared.PollForDecisionTaskResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  ServiceBusyError *shared.ServiceBusyError `thrift:"serviceBusyError,3" db:"serviceBusyError" json:"serviceBusyError,omitempty"`
+}
+
+func NewWorkflowServicePollForDecisionTaskResult() *WorkflowServicePollForDecisionTaskResult {
+  return &WorkflowServicePollForDecisionTaskResult{}
+}
+
+var WorkflowServicePollForDecisionTaskResult_Success_DEFAULT *shared.PollForDecisionTaskResponse
+func (p *WorkflowServicePollForDecisionTaskResult) GetSuccess() *shared.PollForDecisionTaskResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServicePollForDecisionTaskResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServicePollForDecisionTaskResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServicePollForDecisionTaskResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServicePollForDecisionTaskResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServicePollForDecisionTaskResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServicePollForDecisionTaskResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServicePollForDecisionTaskResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServicePollForDecisionTaskResult_ServiceBusyError_DEFAULT *shared.ServiceBusyError
+func (p *WorkflowServicePollForDecisionTaskResult) GetServiceBusyError() *shared.ServiceBusyError {
+  if !p.IsSetServiceBusyError() {
+    return WorkflowServicePollForDecisionTaskResult_ServiceBusyError_DEFAULT
+  }
+return p.ServiceBusyError
+}
+func (p *WorkflowServicePollForDecisionTaskResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) IsSetServiceBusyError() bool {
+  return p.ServiceBusyError != nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.PollForDecisionTaskResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.ServiceBusyError = &shared.ServiceBusyError{}
+  if err := p.ServiceBusyError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ServiceBusyError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("PollForDecisionTask_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetServiceBusyError() {
+    if err := oprot.WriteFieldBegin("serviceBusyError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:serviceBusyError: ", p), err) }
+    if err := p.ServiceBusyError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ServiceBusyError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:serviceBusyError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServicePollForDecisionTaskResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServicePollForDecisionTaskResult(%+v)", *p)
+}
+
+// Attributes:
+//  - CompleteRequest
+type WorkflowServiceRespondDecisionTaskCompletedArgs struct {
+  CompleteRequest *shared.RespondDecisionTaskCompletedRequest `thrift:"completeRequest,1" db:"completeRequest" json:"completeRequest"`
+}
+
+func NewWorkflowServiceRespondDecisionTaskCompletedArgs() *WorkflowServiceRespondDecisionTaskCompletedArgs {
+  return &WorkflowServiceRespondDecisionTaskCompletedArgs{}
+}
+
+var WorkflowServiceRespondDecisionTaskCompletedArgs_CompleteRequest_DEFAULT *shared.RespondDecisionTaskCompletedRequest
+func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) GetCompleteRequest() *shared.RespondDecisionTaskCompletedRequest {
+  if !p.IsSetCompleteRequest() {
+    return WorkflowServiceRespondDecisionTaskCompletedArgs_CompleteRequest_DEFAULT
+  }
+return p.CompleteRequest
+}
+func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) IsSetCompleteRequest() bool {
+  return p.CompleteRequest != nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CompleteRequest = &shared.RespondDecisionTaskCompletedRequest{}
+  if err := p.CompleteRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondDecisionTaskCompleted_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completeRequest: ", p), err) }
+  if err := p.CompleteRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completeRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceRespondDecisionTaskCompletedArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+type WorkflowServiceRespondDecisionTaskCompletedResult struct {
+  Success *shared.RespondDecisionTaskCompletedResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+}
+
+func NewWorkflowServiceRespondDecisionTaskCompletedResult() *WorkflowServiceRespondDecisionTaskCompletedResult {
+  return &WorkflowServiceRespondDecisionTaskCompletedResult{}
+}
+
+var WorkflowServiceRespondDecisionTaskCompletedResult_Success_DEFAULT *shared.RespondDecisionTaskCompletedResponse
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) GetSuccess() *shared.RespondDecisionTaskCompletedResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceRespondDecisionTaskCompletedResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceRespondDecisionTaskCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceRespondDecisionTaskCompletedResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceRespondDecisionTaskCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceRespondDecisionTaskCompletedResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceRespondDecisionTaskCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceRespondDecisionTaskCompletedResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.RespondDecisionTaskCompletedResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondDecisionTaskCompleted_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRespondDecisionTaskCompletedResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceRespondDecisionTaskCompletedResult(%+v)", *p)
+}
+
+// Attributes:
+//  - PollRequest
+type WorkflowServicePollForActivityTaskArgs struct {
+  PollRequest *shared.PollForActivityTaskRequest `thrift:"pollRequest,1" db:"pollRequest" json:"pollRequest"`
+}
+
+func NewWorkflowServicePollForActivityTaskArgs() *WorkflowServicePollForActivityTaskArgs {
+  return &WorkflowServicePollForActivityTaskArgs{}
+}
+
+var WorkflowServicePollForActivityTaskArgs_PollRequest_DEFAULT *shared.PollForActivityTaskRequest
+func (p *WorkflowServicePollForActivityTaskArgs) GetPollRequest() *shared.PollForActivityTaskRequest {
+  if !p.IsSetPollRequest() {
+    return WorkflowServicePollForActivityTaskArgs_PollRequest_DEFAULT
+  }
+return p.PollRequest
+}
+func (p *WorkflowServicePollForActivityTaskArgs) IsSetPollRequest() bool {
+  return p.PollRequest != nil
+}
+
+func (p *WorkflowServicePollForActivityTaskArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForActivityTaskArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.PollRequest = &shared.PollForActivityTaskRequest{}
+  if err := p.PollRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PollRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForActivityTaskArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("PollForActivityTask_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServicePollForActivityTaskArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("pollRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:pollRequest: ", p), err) }
+  if err := p.PollRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PollRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:pollRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServicePollForActivityTaskArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServicePollForActivityTaskArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - ServiceBusyError
+type WorkflowServicePollForActivityTaskResult struct {
+  Success *shared.PollForActivityTaskResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  ServiceBusyError *shared.ServiceBusyError `thrift:"serviceBusyError,3" db:"serviceBusyError" json:"serviceBusyError,omitempty"`
+}
+
+func NewWorkflowServicePollForActivityTaskResult() *WorkflowServicePollForActivityTaskResult {
+  return &WorkflowServicePollForActivityTaskResult{}
+}
+
+var WorkflowServicePollForActivityTaskResult_Success_DEFAULT *shared.PollForActivityTaskResponse
+func (p *WorkflowServicePollForActivityTaskResult) GetSuccess() *shared.PollForActivityTaskResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServicePollForActivityTaskResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServicePollForActivityTaskResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServicePollForActivityTaskResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServicePollForActivityTaskResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServicePollForActivityTaskResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServicePollForActivityTaskResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServicePollForActivityTaskResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServicePollForActivityTaskResult_ServiceBusyError_DEFAULT *shared.ServiceBusyError
+func (p *WorkflowServicePollForActivityTaskResult) GetServiceBusyError() *shared.ServiceBusyError {
+  if !p.IsSetServiceBusyError() {
+    return WorkflowServicePollForActivityTaskResult_ServiceBusyError_DEFAULT
+  }
+return p.ServiceBusyError
+}
+func (p *WorkflowServicePollForActivityTaskResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) IsSetServiceBusyError() bool {
+  return p.ServiceBusyError != nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.PollForActivityTaskResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.ServiceBusyError = &shared.ServiceBusyError{}
+  if err := p.ServiceBusyError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ServiceBusyError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("PollForActivityTask_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetServiceBusyError() {
+    if err := oprot.WriteFieldBegin("serviceBusyError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:serviceBusyError: ", p), err) }
+    if err := p.ServiceBusyError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ServiceBusyError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:serviceBusyError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServicePollForActivityTaskResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServicePollForActivityTaskResult(%+v)", *p)
+}
+
+// Attributes:
+//  - HeartbeatRequest
+type WorkflowServiceRecordActivityTaskHeartbeatArgs struct {
+  HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `thrift:"heartbeatRequest,1" db:"heartbeatRequest" json:"heartbeatRequest"`
+}
+
+func NewWorkflowServiceRecordActivityTaskHeartbeatArgs() *WorkflowServiceRecordActivityTaskHeartbeatArgs {
+  return &WorkflowServiceRecordActivityTaskHeartbeatArgs{}
+}
+
+var WorkflowServiceRecordActivityTaskHeartbeatArgs_HeartbeatRequest_DEFAULT *shared.RecordActivityTaskHeartbeatRequest
+func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) GetHeartbeatRequest() *shared.RecordActivityTaskHeartbeatRequest {
+  if !p.IsSetHeartbeatRequest() {
+    return WorkflowServiceRecordActivityTaskHeartbeatArgs_HeartbeatRequest_DEFAULT
+  }
+return p.HeartbeatRequest
+}
+func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) IsSetHeartbeatRequest() bool {
+  return p.HeartbeatRequest != nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.HeartbeatRequest = &shared.RecordActivityTaskHeartbeatRequest{}
+  if err := p.HeartbeatRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.HeartbeatRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeat_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("heartbeatRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:heartbeatRequest: ", p), err) }
+  if err := p.HeartbeatRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.HeartbeatRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:heartbeatRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceRecordActivityTaskHeartbeatArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+//  - ServiceBusyError
+type WorkflowServiceRecordActivityTaskHeartbeatResult struct {
+  Success *shared.RecordActivityTaskHeartbeatResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ServiceBusyError *shared.ServiceBusyError `thrift:"serviceBusyError,4" db:"serviceBusyError" json:"serviceBusyError,omitempty"`
+}
+
+func NewWorkflowServiceRecordActivityTaskHeartbeatResult() *WorkflowServiceRecordActivityTaskHeartbeatResult {
+  return &WorkflowServiceRecordActivityTaskHeartbeatResult{}
+}
+
+var WorkflowServiceRecordActivityTaskHeartbeatResult_Success_DEFAULT *shared.RecordActivityTaskHeartbeatResponse
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) GetSuccess() *shared.RecordActivityTaskHeartbeatResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceRecordActivityTaskHeartbeatResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceRecordActivityTaskHeartbeatResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceRecordActivityTaskHeartbeatResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceRecordActivityTaskHeartbeatResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceRecordActivityTaskHeartbeatResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceRecordActivityTaskHeartbeatResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceRecordActivityTaskHeartbeatResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+var WorkflowServiceRecordActivityTaskHeartbeatResult_ServiceBusyError_DEFAULT *shared.ServiceBusyError
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) GetServiceBusyError() *shared.ServiceBusyError {
+  if !p.IsSetServiceBusyError() {
+    return WorkflowServiceRecordActivityTaskHeartbeatResult_ServiceBusyError_DEFAULT
+  }
+return p.ServiceBusyError
+}
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) IsSetServiceBusyError() bool {
+  return p.ServiceBusyError != nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.RecordActivityTaskHeartbeatResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.ServiceBusyError = &shared.ServiceBusyError{}
+  if err := p.ServiceBusyError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ServiceBusyError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeat_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetServiceBusyError() {
+    if err := oprot.WriteFieldBegin("serviceBusyError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:serviceBusyError: ", p), err) }
+    if err := p.ServiceBusyError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ServiceBusyError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:serviceBusyError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceRecordActivityTaskHeartbeatResult(%+v)", *p)
+}
+
+// Attributes:
+//  - CompleteRequest
+type WorkflowServiceRespondActivityTaskCompletedArgs struct {
+  CompleteRequest *shared.RespondActivityTaskCompletedRequest `thrift:"completeRequest,1" db:"completeRequest" json:"completeRequest"`
+}
+
+func NewWorkflowServiceRespondActivityTaskCompletedArgs() *WorkflowServiceRespondActivityTaskCompletedArgs {
+  return &WorkflowServiceRespondActivityTaskCompletedArgs{}
+}
+
+var WorkflowServiceRespondActivityTaskCompletedArgs_CompleteRequest_DEFAULT *shared.RespondActivityTaskCompletedRequest
+func (p *WorkflowServiceRespondActivityTaskCompletedArgs) GetCompleteRequest() *shared.RespondActivityTaskCompletedRequest {
+  if !p.IsSetCompleteRequest() {
+    return WorkflowServiceRespondActivityTaskCompletedArgs_CompleteRequest_DEFAULT
+  }
+return p.CompleteRequest
+}
+func (p *WorkflowServiceRespondActivityTaskCompletedArgs) IsSetCompleteRequest() bool {
+  return p.CompleteRequest != nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CompleteRequest = &shared.RespondActivityTaskCompletedRequest{}
+  if err := p.CompleteRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCompleted_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completeRequest: ", p), err) }
+  if err := p.CompleteRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completeRequest: ", p), err) }
+  return err
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCompletedArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+type WorkflowServiceRespondActivityTaskCompletedResult struct {
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+}
+
+func NewWorkflowServiceRespondActivityTaskCompletedResult() *WorkflowServiceRespondActivityTaskCompletedResult {
+  return &WorkflowServiceRespondActivityTaskCompletedResult{}
+}
+
+var WorkflowServiceRespondActivityTaskCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return WorkflowServiceRespondActivityTaskCompletedResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var WorkflowServiceRespondActivityTaskCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return WorkflowServiceRespondActivityTaskCompletedResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var WorkflowServiceRespondActivityTaskCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceRespondActivityTaskCompletedResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCompleted_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceRespondActivityTaskCompletedResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCompletedResult(%+v)", *p)
+}
 
 // Attributes:
-//  - RegisterRequest
-type WorkflowServiceRegisterDomainArgs struct {
-  RegisterRequest *shared.RegisterDomainRequest `thrift:"registerRequest,1" db:"registerRequest" json:"registerRequest"`
+//  - FailRequest
+type WorkflowServiceRespondActivityTaskFailedArgs struct {
+  FailRequest *shared.RespondActivityTaskFailedRequest `thrift:"failRequest,1" db:"failRequest" json:"failRequest"`
 }
 
-func NewWorkflowServiceRegisterDomainArgs() *WorkflowServiceRegisterDomainArgs {
-  return &WorkflowServiceRegisterDomainArgs{}
+func NewWorkflowServiceRespondActivityTaskFailedArgs() *WorkflowServiceRespondActivityTaskFailedArgs {
+  return &WorkflowServiceRespondActivityTaskFailedArgs{}
 }
 
-var WorkflowServiceRegisterDomainArgs_RegisterRequest_DEFAULT *shared.RegisterDomainRequest
-func (p *WorkflowServiceRegisterDomainArgs) GetRegisterRequest() *shared.RegisterDomainRequest {
-  if !p.IsSetRegisterRequest() {
-    return WorkflowServiceRegisterDomainArgs_RegisterRequest_DEFAULT
+var WorkflowServiceRespondActivityTaskFailedArgs_FailRequest_DEFAULT *shared.RespondActivityTaskFailedRequest
+func (p *WorkflowServiceRespondActivityTaskFailedArgs) GetFailRequest() *shared.RespondActivityTaskFailedRequest {
+  if !p.IsSetFailRequest() {
+    return WorkflowServiceRespondActivityTaskFailedArgs_FailRequest_DEFAULT
   }
-return p.RegisterRequest
+return p.FailRequest
 }
-func (p *WorkflowServiceRegisterDomainArgs) IsSetRegisterRequest() bool {
-  return p.RegisterRequest != nil
+func (p *WorkflowServiceRespondActivityTaskFailedArgs) IsSetFailRequest() bool {
+  return p.FailRequest != nil
 }
 
-func (p *WorkflowServiceRegisterDomainArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskFailedArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -2974,16 +8484,16 @@ func (p *WorkflowServiceRegisterDomainArgs) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *WorkflowServiceRegisterDomainArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.RegisterRequest = &shared.RegisterDomainRequest{}
-  if err := p.RegisterRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.RegisterRequest), err)
+func (p *WorkflowServiceRespondActivityTaskFailedArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.FailRequest = &shared.RespondActivityTaskFailedRequest{}
+  if err := p.FailRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.FailRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceRegisterDomainArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RegisterDomain_args"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskFailedArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskFailed_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -2995,72 +8505,72 @@ func (p *WorkflowServiceRegisterDomainArgs) Write(oprot thrift.TProtocol) error
   return nil
 }
 
-func (p *WorkflowServiceRegisterDomainArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("registerRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:registerRequest: ", p), err) }
-  if err := p.RegisterRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.RegisterRequest), err)
+func (p *WorkflowServiceRespondActivityTaskFailedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("failRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:failRequest: ", p), err) }
+  if err := p.FailRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.FailRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:registerRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:failRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceRegisterDomainArgs) String() string {
+func (p *WorkflowServiceRespondActivityTaskFailedArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRegisterDomainArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskFailedArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - BadRequestError
 //  - InternalServiceError
-//  - DomainExistsError
-type WorkflowServiceRegisterDomainResult struct {
+//  - EntityNotExistError
+type WorkflowServiceRespondActivityTaskFailedResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  DomainExistsError *shared.DomainAlreadyExistsError `thrift:"domainExistsError,3" db:"domainExistsError" json:"domainExistsError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceRegisterDomainResult() *WorkflowServiceRegisterDomainResult {
-  return &WorkflowServiceRegisterDomainResult{}
+func NewWorkflowServiceRespondActivityTaskFailedResult() *WorkflowServiceRespondActivityTaskFailedResult {
+  return &WorkflowServiceRespondActivityTaskFailedResult{}
 }
 
-var WorkflowServiceRegisterDomainResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceRegisterDomainResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceRespondActivityTaskFailedResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRespondActivityTaskFailedResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceRegisterDomainResult_BadRequestError_DEFAULT
+    return WorkflowServiceRespondActivityTaskFailedResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceRegisterDomainResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceRegisterDomainResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceRespondActivityTaskFailedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRespondActivityTaskFailedResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceRegisterDomainResult_InternalServiceError_DEFAULT
+    return WorkflowServiceRespondActivityTaskFailedResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceRegisterDomainResult_DomainExistsError_DEFAULT *shared.DomainAlreadyExistsError
-func (p *WorkflowServiceRegisterDomainResult) GetDomainExistsError() *shared.DomainAlreadyExistsError {
-  if !p.IsSetDomainExistsError() {
-    return WorkflowServiceRegisterDomainResult_DomainExistsError_DEFAULT
+var WorkflowServiceRespondActivityTaskFailedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRespondActivityTaskFailedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceRespondActivityTaskFailedResult_EntityNotExistError_DEFAULT
   }
-return p.DomainExistsError
+return p.EntityNotExistError
 }
-func (p *WorkflowServiceRegisterDomainResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceRespondActivityTaskFailedResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceRegisterDomainResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceRespondActivityTaskFailedResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceRegisterDomainResult) IsSetDomainExistsError() bool {
-  return p.DomainExistsError != nil
+func (p *WorkflowServiceRespondActivityTaskFailedResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceRegisterDomainResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskFailedResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -3100,7 +8610,7 @@ func (p *WorkflowServiceRegisterDomainResult) Read(iprot thrift.TProtocol) error
   return nil
 }
 
-func (p *WorkflowServiceRegisterDomainResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskFailedResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -3108,7 +8618,7 @@ func (p *WorkflowServiceRegisterDomainResult)  ReadField1(iprot thrift.TProtocol
   return nil
 }
 
-func (p *WorkflowServiceRegisterDomainResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskFailedResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -3116,16 +8626,16 @@ func (p *WorkflowServiceRegisterDomainResult)  ReadField2(iprot thrift.TProtocol
   return nil
 }
 
-func (p *WorkflowServiceRegisterDomainResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.DomainExistsError = &shared.DomainAlreadyExistsError{}
-  if err := p.DomainExistsError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DomainExistsError), err)
+func (p *WorkflowServiceRespondActivityTaskFailedResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceRegisterDomainResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RegisterDomain_result"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskFailedResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskFailed_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -3139,7 +8649,7 @@ func (p *WorkflowServiceRegisterDomainResult) Write(oprot thrift.TProtocol) erro
   return nil
 }
 
-func (p *WorkflowServiceRegisterDomainResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskFailedResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -3152,7 +8662,7 @@ func (p *WorkflowServiceRegisterDomainResult) writeField1(oprot thrift.TProtocol
   return err
 }
 
-func (p *WorkflowServiceRegisterDomainResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskFailedResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -3165,48 +8675,48 @@ func (p *WorkflowServiceRegisterDomainResult) writeField2(oprot thrift.TProtocol
   return err
 }
 
-func (p *WorkflowServiceRegisterDomainResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetDomainExistsError() {
-    if err := oprot.WriteFieldBegin("domainExistsError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:domainExistsError: ", p), err) }
-    if err := p.DomainExistsError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DomainExistsError), err)
+func (p *WorkflowServiceRespondActivityTaskFailedResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:domainExistsError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
   return err
 }
 
-func (p *WorkflowServiceRegisterDomainResult) String() string {
+func (p *WorkflowServiceRespondActivityTaskFailedResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRegisterDomainResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskFailedResult(%+v)", *p)
 }
 
 // Attributes:
-//  - DescribeRequest
-type WorkflowServiceDescribeDomainArgs struct {
-  DescribeRequest *shared.DescribeDomainRequest `thrift:"describeRequest,1" db:"describeRequest" json:"describeRequest"`
+//  - CanceledRequest
+type WorkflowServiceRespondActivityTaskCanceledArgs struct {
+  CanceledRequest *shared.RespondActivityTaskCanceledRequest `thrift:"canceledRequest,1" db:"canceledRequest" json:"canceledRequest"`
 }
 
-func NewWorkflowServiceDescribeDomainArgs() *WorkflowServiceDescribeDomainArgs {
-  return &WorkflowServiceDescribeDomainArgs{}
+func NewWorkflowServiceRespondActivityTaskCanceledArgs() *WorkflowServiceRespondActivityTaskCanceledArgs {
+  return &WorkflowServiceRespondActivityTaskCanceledArgs{}
 }
 
-var WorkflowServiceDescribeDomainArgs_DescribeRequest_DEFAULT *shared.DescribeDomainRequest
-func (p *WorkflowServiceDescribeDomainArgs) GetDescribeRequest() *shared.DescribeDomainRequest {
-  if !p.IsSetDescribeRequest() {
-    return WorkflowServiceDescribeDomainArgs_DescribeRequest_DEFAULT
+var WorkflowServiceRespondActivityTaskCanceledArgs_CanceledRequest_DEFAULT *shared.RespondActivityTaskCanceledRequest
+func (p *WorkflowServiceRespondActivityTaskCanceledArgs) GetCanceledRequest() *shared.RespondActivityTaskCanceledRequest {
+  if !p.IsSetCanceledRequest() {
+    return WorkflowServiceRespondActivityTaskCanceledArgs_CanceledRequest_DEFAULT
   }
-return p.DescribeRequest
+return p.CanceledRequest
 }
-func (p *WorkflowServiceDescribeDomainArgs) IsSetDescribeRequest() bool {
-  return p.DescribeRequest != nil
+func (p *WorkflowServiceRespondActivityTaskCanceledArgs) IsSetCanceledRequest() bool {
+  return p.CanceledRequest != nil
 }
 
-func (p *WorkflowServiceDescribeDomainArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCanceledArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -3238,16 +8748,16 @@ func (p *WorkflowServiceDescribeDomainArgs) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *WorkflowServiceDescribeDomainArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.DescribeRequest = &shared.DescribeDomainRequest{}
-  if err := p.DescribeRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DescribeRequest), err)
+func (p *WorkflowServiceRespondActivityTaskCanceledArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CanceledRequest = &shared.RespondActivityTaskCanceledRequest{}
+  if err := p.CanceledRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CanceledRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceDescribeDomainArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("DescribeDomain_args"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskCanceledArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCanceled_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -3259,85 +8769,72 @@ func (p *WorkflowServiceDescribeDomainArgs) Write(oprot thrift.TProtocol) error
   return nil
 }
 
-func (p *WorkflowServiceDescribeDomainArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("describeRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:describeRequest: ", p), err) }
-  if err := p.DescribeRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DescribeRequest), err)
+func (p *WorkflowServiceRespondActivityTaskCanceledArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("canceledRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:canceledRequest: ", p), err) }
+  if err := p.CanceledRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CanceledRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:describeRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:canceledRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceDescribeDomainArgs) String() string {
+func (p *WorkflowServiceRespondActivityTaskCanceledArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceDescribeDomainArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCanceledArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceDescribeDomainResult struct {
-  Success *shared.DescribeDomainResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type WorkflowServiceRespondActivityTaskCanceledResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceDescribeDomainResult() *WorkflowServiceDescribeDomainResult {
-  return &WorkflowServiceDescribeDomainResult{}
+func NewWorkflowServiceRespondActivityTaskCanceledResult() *WorkflowServiceRespondActivityTaskCanceledResult {
+  return &WorkflowServiceRespondActivityTaskCanceledResult{}
 }
 
-var WorkflowServiceDescribeDomainResult_Success_DEFAULT *shared.DescribeDomainResponse
-func (p *WorkflowServiceDescribeDomainResult) GetSuccess() *shared.DescribeDomainResponse {
-  if !p.IsSetSuccess() {
-    return WorkflowServiceDescribeDomainResult_Success_DEFAULT
-  }
-return p.Success
-}
-var WorkflowServiceDescribeDomainResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceDescribeDomainResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceRespondActivityTaskCanceledResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceDescribeDomainResult_BadRequestError_DEFAULT
+    return WorkflowServiceRespondActivityTaskCanceledResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceDescribeDomainResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceDescribeDomainResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceRespondActivityTaskCanceledResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceDescribeDomainResult_InternalServiceError_DEFAULT
+    return WorkflowServiceRespondActivityTaskCanceledResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceDescribeDomainResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceDescribeDomainResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceRespondActivityTaskCanceledResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceDescribeDomainResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceRespondActivityTaskCanceledResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceDescribeDomainResult) IsSetSuccess() bool {
-  return p.Success != nil
-}
-
-func (p *WorkflowServiceDescribeDomainResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceDescribeDomainResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceDescribeDomainResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceDescribeDomainResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -3350,10 +8847,6 @@ func (p *WorkflowServiceDescribeDomainResult) Read(iprot thrift.TProtocol) error
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
-    case 0:
-      if err := p.ReadField0(iprot); err != nil {
-        return err
-      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -3381,15 +8874,7 @@ func (p *WorkflowServiceDescribeDomainResult) Read(iprot thrift.TProtocol) error
   return nil
 }
 
-func (p *WorkflowServiceDescribeDomainResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.DescribeDomainResponse{}
-  if err := p.Success.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-  }
-  return nil
-}
-
-func (p *WorkflowServiceDescribeDomainResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -3397,7 +8882,7 @@ func (p *WorkflowServiceDescribeDomainResult)  ReadField1(iprot thrift.TProtocol
   return nil
 }
 
-func (p *WorkflowServiceDescribeDomainResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -3405,7 +8890,7 @@ func (p *WorkflowServiceDescribeDomainResult)  ReadField2(iprot thrift.TProtocol
   return nil
 }
 
-func (p *WorkflowServiceDescribeDomainResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -3413,11 +8898,10 @@ func (p *WorkflowServiceDescribeDomainResult)  ReadField3(iprot thrift.TProtocol
   return nil
 }
 
-func (p *WorkflowServiceDescribeDomainResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("DescribeDomain_result"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCanceled_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
-    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -3429,20 +8913,7 @@ func (p *WorkflowServiceDescribeDomainResult) Write(oprot thrift.TProtocol) erro
   return nil
 }
 
-func (p *WorkflowServiceDescribeDomainResult) writeField0(oprot thrift.TProtocol) (err error) {
-  if p.IsSetSuccess() {
-    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
-    if err := p.Success.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
-  }
-  return err
-}
-
-func (p *WorkflowServiceDescribeDomainResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -3455,7 +8926,7 @@ func (p *WorkflowServiceDescribeDomainResult) writeField1(oprot thrift.TProtocol
   return err
 }
 
-func (p *WorkflowServiceDescribeDomainResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -3468,7 +8939,7 @@ func (p *WorkflowServiceDescribeDomainResult) writeField2(oprot thrift.TProtocol
   return err
 }
 
-func (p *WorkflowServiceDescribeDomainResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -3481,35 +8952,35 @@ func (p *WorkflowServiceDescribeDomainResult) writeField3(oprot thrift.TProtocol
   return err
 }
 
-func (p *WorkflowServiceDescribeDomainResult) String() string {
+func (p *WorkflowServiceRespondActivityTaskCanceledResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceDescribeDomainResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCanceledResult(%+v)", *p)
 }
 
 // Attributes:
-//  - UpdateRequest
-type WorkflowServiceUpdateDomainArgs struct {
-  UpdateRequest *shared.UpdateDomainRequest `thrift:"updateRequest,1" db:"updateRequest" json:"updateRequest"`
+//  - CompleteRequest
+type WorkflowServiceRespondActivityTaskCompletedByIDArgs struct {
+  CompleteRequest *shared.RespondActivityTaskCompletedByIDRequest `thrift:"completeRequest,1" db:"completeRequest" json:"completeRequest"`
 }
 
-func NewWorkflowServiceUpdateDomainArgs() *WorkflowServiceUpdateDomainArgs {
-  return &WorkflowServiceUpdateDomainArgs{}
+func NewWorkflowServiceRespondActivityTaskCompletedByIDArgs() *WorkflowServiceRespondActivityTaskCompletedByIDArgs {
+  return &WorkflowServiceRespondActivityTaskCompletedByIDArgs{}
 }
 
-var WorkflowServiceUpdateDomainArgs_UpdateRequest_DEFAULT *shared.UpdateDomainRequest
-func (p *WorkflowServiceUpdateDomainArgs) GetUpdateRequest() *shared.UpdateDomainRequest {
-  if !p.IsSetUpdateRequest() {
-    return WorkflowServiceUpdateDomainArgs_UpdateRequest_DEFAULT
+var WorkflowServiceRespondActivityTaskCompletedByIDArgs_CompleteRequest_DEFAULT *shared.RespondActivityTaskCompletedByIDRequest
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDArgs) GetCompleteRequest() *shared.RespondActivityTaskCompletedByIDRequest {
+  if !p.IsSetCompleteRequest() {
+    return WorkflowServiceRespondActivityTaskCompletedByIDArgs_CompleteRequest_DEFAULT
   }
-return p.UpdateRequest
+return p.CompleteRequest
 }
-func (p *WorkflowServiceUpdateDomainArgs) IsSetUpdateRequest() bool {
-  return p.UpdateRequest != nil
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDArgs) IsSetCompleteRequest() bool {
+  return p.CompleteRequest != nil
 }
 
-func (p *WorkflowServiceUpdateDomainArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -3541,16 +9012,16 @@ func (p *WorkflowServiceUpdateDomainArgs) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *WorkflowServiceUpdateDomainArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.UpdateRequest = &shared.UpdateDomainRequest{}
-  if err := p.UpdateRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.UpdateRequest), err)
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CompleteRequest = &shared.RespondActivityTaskCompletedByIDRequest{}
+  if err := p.CompleteRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceUpdateDomainArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("UpdateDomain_args"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCompletedByID_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -3562,85 +9033,72 @@ func (p *WorkflowServiceUpdateDomainArgs) Write(oprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *WorkflowServiceUpdateDomainArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("updateRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:updateRequest: ", p), err) }
-  if err := p.UpdateRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.UpdateRequest), err)
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completeRequest: ", p), err) }
+  if err := p.CompleteRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:updateRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completeRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceUpdateDomainArgs) String() string {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceUpdateDomainArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCompletedByIDArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceUpdateDomainResult struct {
-  Success *shared.UpdateDomainResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type WorkflowServiceRespondActivityTaskCompletedByIDResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceUpdateDomainResult() *WorkflowServiceUpdateDomainResult {
-  return &WorkflowServiceUpdateDomainResult{}
+func NewWorkflowServiceRespondActivityTaskCompletedByIDResult() *WorkflowServiceRespondActivityTaskCompletedByIDResult {
+  return &WorkflowServiceRespondActivityTaskCompletedByIDResult{}
 }
 
-var WorkflowServiceUpdateDomainResult_Success_DEFAULT *shared.UpdateDomainResponse
-func (p *WorkflowServiceUpdateDomainResult) GetSuccess() *shared.UpdateDomainResponse {
-  if !p.IsSetSuccess() {
-    return WorkflowServiceUpdateDomainResult_Success_DEFAULT
-  }
-return p.Success
-}
-var WorkflowServiceUpdateDomainResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceUpdateDomainResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceRespondActivityTaskCompletedByIDResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceUpdateDomainResult_BadRequestError_DEFAULT
+    return WorkflowServiceRespondActivityTaskCompletedByIDResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceUpdateDomainResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceUpdateDomainResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceRespondActivityTaskCompletedByIDResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceUpdateDomainResult_InternalServiceError_DEFAULT
+    return WorkflowServiceRespondActivityTaskCompletedByIDResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceUpdateDomainResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceUpdateDomainResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceRespondActivityTaskCompletedByIDResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceUpdateDomainResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceRespondActivityTaskCompletedByIDResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceUpdateDomainResult) IsSetSuccess() bool {
-  return p.Success != nil
-}
-
-func (p *WorkflowServiceUpdateDomainResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceUpdateDomainResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceUpdateDomainResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceUpdateDomainResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -3653,10 +9111,6 @@ func (p *WorkflowServiceUpdateDomainResult) Read(iprot thrift.TProtocol) error {
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
-    case 0:
-      if err := p.ReadField0(iprot); err != nil {
-        return err
-      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -3684,15 +9138,7 @@ func (p *WorkflowServiceUpdateDomainResult) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *WorkflowServiceUpdateDomainResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.UpdateDomainResponse{}
-  if err := p.Success.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-  }
-  return nil
-}
-
-func (p *WorkflowServiceUpdateDomainResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -3700,7 +9146,7 @@ func (p *WorkflowServiceUpdateDomainResult)  ReadField1(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *WorkflowServiceUpdateDomainResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -3708,7 +9154,7 @@ func (p *WorkflowServiceUpdateDomainResult)  ReadField2(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *WorkflowServiceUpdateDomainResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -3716,11 +9162,10 @@ func (p *WorkflowServiceUpdateDomainResult)  ReadField3(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *WorkflowServiceUpdateDomainResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("UpdateDomain_result"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCompletedByID_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
-    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -3732,20 +9177,7 @@ func (p *WorkflowServiceUpdateDomainResult) Write(oprot thrift.TProtocol) error
   return nil
 }
 
-func (p *WorkflowServiceUpdateDomainResult) writeField0(oprot thrift.TProtocol) (err error) {
-  if p.IsSetSuccess() {
-    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
-    if err := p.Success.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
-  }
-  return err
-}
-
-func (p *WorkflowServiceUpdateDomainResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -3758,7 +9190,7 @@ func (p *WorkflowServiceUpdateDomainResult) writeField1(oprot thrift.TProtocol)
   return err
 }
 
-func (p *WorkflowServiceUpdateDomainResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -3771,7 +9203,7 @@ func (p *WorkflowServiceUpdateDomainResult) writeField2(oprot thrift.TProtocol)
   return err
 }
 
-func (p *WorkflowServiceUpdateDomainResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -3784,35 +9216,35 @@ func (p *WorkflowServiceUpdateDomainResult) writeField3(oprot thrift.TProtocol)
   return err
 }
 
-func (p *WorkflowServiceUpdateDomainResult) String() string {
+func (p *WorkflowServiceRespondActivityTaskCompletedByIDResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceUpdateDomainResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCompletedByIDResult(%+v)", *p)
 }
 
 // Attributes:
-//  - DeprecateRequest
-type WorkflowServiceDeprecateDomainArgs struct {
-  DeprecateRequest *shared.DeprecateDomainRequest `thrift:"deprecateRequest,1" db:"deprecateRequest" json:"deprecateRequest"`
+//  - FailedRequest
+type WorkflowServiceRespondActivityTaskFailedByIDArgs struct {
+  FailedRequest *shared.RespondActivityTaskFailedByIDRequest `thrift:"failedRequest,1" db:"failedRequest" json:"failedRequest"`
 }
 
-func NewWorkflowServiceDeprecateDomainArgs() *WorkflowServiceDeprecateDomainArgs {
-  return &WorkflowServiceDeprecateDomainArgs{}
+func NewWorkflowServiceRespondActivityTaskFailedByIDArgs() *WorkflowServiceRespondActivityTaskFailedByIDArgs {
+  return &WorkflowServiceRespondActivityTaskFailedByIDArgs{}
 }
 
-var WorkflowServiceDeprecateDomainArgs_DeprecateRequest_DEFAULT *shared.DeprecateDomainRequest
-func (p *WorkflowServiceDeprecateDomainArgs) GetDeprecateRequest() *shared.DeprecateDomainRequest {
-  if !p.IsSetDeprecateRequest() {
-    return WorkflowServiceDeprecateDomainArgs_DeprecateRequest_DEFAULT
+var WorkflowServiceRespondActivityTaskFailedByIDArgs_FailedRequest_DEFAULT *shared.RespondActivityTaskFailedByIDRequest
+func (p *WorkflowServiceRespondActivityTaskFailedByIDArgs) GetFailedRequest() *shared.RespondActivityTaskFailedByIDRequest {
+  if !p.IsSetFailedRequest() {
+    return WorkflowServiceRespondActivityTaskFailedByIDArgs_FailedRequest_DEFAULT
   }
-return p.DeprecateRequest
+return p.FailedRequest
 }
-func (p *WorkflowServiceDeprecateDomainArgs) IsSetDeprecateRequest() bool {
-  return p.DeprecateRequest != nil
+func (p *WorkflowServiceRespondActivityTaskFailedByIDArgs) IsSetFailedRequest() bool {
+  return p.FailedRequest != nil
 }
 
-func (p *WorkflowServiceDeprecateDomainArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -3844,16 +9276,16 @@ func (p *WorkflowServiceDeprecateDomainArgs) Read(iprot thrift.TProtocol) error
   return nil
 }
 
-func (p *WorkflowServiceDeprecateDomainArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.DeprecateRequest = &shared.DeprecateDomainRequest{}
-  if err := p.DeprecateRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DeprecateRequest), err)
+func (p *WorkflowServiceRespondActivityTaskFailedByIDArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.FailedRequest = &shared.RespondActivityTaskFailedByIDRequest{}
+  if err := p.FailedRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.FailedRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceDeprecateDomainArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("DeprecateDomain_args"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskFailedByID_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -3865,72 +9297,72 @@ func (p *WorkflowServiceDeprecateDomainArgs) Write(oprot thrift.TProtocol) error
   return nil
 }
 
-func (p *WorkflowServiceDeprecateDomainArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("deprecateRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:deprecateRequest: ", p), err) }
-  if err := p.DeprecateRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DeprecateRequest), err)
+func (p *WorkflowServiceRespondActivityTaskFailedByIDArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("failedRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:failedRequest: ", p), err) }
+  if err := p.FailedRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.FailedRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:deprecateRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:failedRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceDeprecateDomainArgs) String() string {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceDeprecateDomainArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskFailedByIDArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceDeprecateDomainResult struct {
+type WorkflowServiceRespondActivityTaskFailedByIDResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceDeprecateDomainResult() *WorkflowServiceDeprecateDomainResult {
-  return &WorkflowServiceDeprecateDomainResult{}
+func NewWorkflowServiceRespondActivityTaskFailedByIDResult() *WorkflowServiceRespondActivityTaskFailedByIDResult {
+  return &WorkflowServiceRespondActivityTaskFailedByIDResult{}
 }
 
-var WorkflowServiceDeprecateDomainResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceDeprecateDomainResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceRespondActivityTaskFailedByIDResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceDeprecateDomainResult_BadRequestError_DEFAULT
+    return WorkflowServiceRespondActivityTaskFailedByIDResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceDeprecateDomainResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceDeprecateDomainResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceRespondActivityTaskFailedByIDResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceDeprecateDomainResult_InternalServiceError_DEFAULT
+    return WorkflowServiceRespondActivityTaskFailedByIDResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceDeprecateDomainResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceDeprecateDomainResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceRespondActivityTaskFailedByIDResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceDeprecateDomainResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceRespondActivityTaskFailedByIDResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceDeprecateDomainResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceDeprecateDomainResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceDeprecateDomainResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceDeprecateDomainResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -3970,7 +9402,7 @@ func (p *WorkflowServiceDeprecateDomainResult) Read(iprot thrift.TProtocol) erro
   return nil
 }
 
-func (p *WorkflowServiceDeprecateDomainResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -3978,7 +9410,7 @@ func (p *WorkflowServiceDeprecateDomainResult)  ReadField1(iprot thrift.TProtoco
   return nil
 }
 
-func (p *WorkflowServiceDeprecateDomainResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -3986,7 +9418,7 @@ func (p *WorkflowServiceDeprecateDomainResult)  ReadField2(iprot thrift.TProtoco
   return nil
 }
 
-func (p *WorkflowServiceDeprecateDomainResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -3994,8 +9426,8 @@ func (p *WorkflowServiceDeprecateDomainResult)  ReadField3(iprot thrift.TProtoco
   return nil
 }
 
-func (p *WorkflowServiceDeprecateDomainResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("DeprecateDomain_result"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskFailedByID_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -4009,7 +9441,7 @@ func (p *WorkflowServiceDeprecateDomainResult) Write(oprot thrift.TProtocol) err
   return nil
 }
 
-func (p *WorkflowServiceDeprecateDomainResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -4022,7 +9454,7 @@ func (p *WorkflowServiceDeprecateDomainResult) writeField1(oprot thrift.TProtoco
   return err
 }
 
-func (p *WorkflowServiceDeprecateDomainResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -4035,7 +9467,7 @@ func (p *WorkflowServiceDeprecateDomainResult) writeField2(oprot thrift.TProtoco
   return err
 }
 
-func (p *WorkflowServiceDeprecateDomainResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -4048,35 +9480,35 @@ func (p *WorkflowServiceDeprecateDomainResult) writeField3(oprot thrift.TProtoco
   return err
 }
 
-func (p *WorkflowServiceDeprecateDomainResult) String() string {
+func (p *WorkflowServiceRespondActivityTaskFailedByIDResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceDeprecateDomainResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskFailedByIDResult(%+v)", *p)
 }
 
 // Attributes:
-//  - StartRequest
-type WorkflowServiceStartWorkflowExecutionArgs struct {
-  StartRequest *shared.StartWorkflowExecutionRequest `thrift:"startRequest,1" db:"startRequest" json:"startRequest"`
+//  - CanceledRequest
+type WorkflowServiceRespondActivityTaskCanceledByIDArgs struct {
+  CanceledRequest *shared.RespondActivityTaskCanceledByIDRequest `thrift:"canceledRequest,1" db:"canceledRequest" json:"canceledRequest"`
 }
 
-func NewWorkflowServiceStartWorkflowExecutionArgs() *WorkflowServiceStartWorkflowExecutionArgs {
-  return &WorkflowServiceStartWorkflowExecutionArgs{}
+func NewWorkflowServiceRespondActivityTaskCanceledByIDArgs() *WorkflowServiceRespondActivityTaskCanceledByIDArgs {
+  return &WorkflowServiceRespondActivityTaskCanceledByIDArgs{}
 }
 
-var WorkflowServiceStartWorkflowExecutionArgs_StartRequest_DEFAULT *shared.StartWorkflowExecutionRequest
-func (p *WorkflowServiceStartWorkflowExecutionArgs) GetStartRequest() *shared.StartWorkflowExecutionRequest {
-  if !p.IsSetStartRequest() {
-    return WorkflowServiceStartWorkflowExecutionArgs_StartRequest_DEFAULT
+var WorkflowServiceRespondActivityTaskCanceledByIDArgs_CanceledRequest_DEFAULT *shared.RespondActivityTaskCanceledByIDRequest
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDArgs) GetCanceledRequest() *shared.RespondActivityTaskCanceledByIDRequest {
+  if !p.IsSetCanceledRequest() {
+    return WorkflowServiceRespondActivityTaskCanceledByIDArgs_CanceledRequest_DEFAULT
   }
-return p.StartRequest
+return p.CanceledRequest
 }
-func (p *WorkflowServiceStartWorkflowExecutionArgs) IsSetStartRequest() bool {
-  return p.StartRequest != nil
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDArgs) IsSetCanceledRequest() bool {
+  return p.CanceledRequest != nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -4108,16 +9540,16 @@ func (p *WorkflowServiceStartWorkflowExecutionArgs) Read(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.StartRequest = &shared.StartWorkflowExecutionRequest{}
-  if err := p.StartRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.StartRequest), err)
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CanceledRequest = &shared.RespondActivityTaskCanceledByIDRequest{}
+  if err := p.CanceledRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CanceledRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("StartWorkflowExecution_args"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCanceledByID_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -4129,85 +9561,72 @@ func (p *WorkflowServiceStartWorkflowExecutionArgs) Write(oprot thrift.TProtocol
   return nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("startRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:startRequest: ", p), err) }
-  if err := p.StartRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.StartRequest), err)
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("canceledRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:canceledRequest: ", p), err) }
+  if err := p.CanceledRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CanceledRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:startRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:canceledRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionArgs) String() string {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceStartWorkflowExecutionArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCanceledByIDArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - BadRequestError
 //  - InternalServiceError
-//  - SessionAlreadyExistError
-type WorkflowServiceStartWorkflowExecutionResult struct {
-  Success *shared.StartWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+//  - EntityNotExistError
+type WorkflowServiceRespondActivityTaskCanceledByIDResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  SessionAlreadyExistError *shared.WorkflowExecutionAlreadyStartedError `thrift:"sessionAlreadyExistError,3" db:"sessionAlreadyExistError" json:"sessionAlreadyExistError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceStartWorkflowExecutionResult() *WorkflowServiceStartWorkflowExecutionResult {
-  return &WorkflowServiceStartWorkflowExecutionResult{}
+func NewWorkflowServiceRespondActivityTaskCanceledByIDResult() *WorkflowServiceRespondActivityTaskCanceledByIDResult {
+  return &WorkflowServiceRespondActivityTaskCanceledByIDResult{}
 }
 
-var WorkflowServiceStartWorkflowExecutionResult_Success_DEFAULT *shared.StartWorkflowExecutionResponse
-func (p *WorkflowServiceStartWorkflowExecutionResult) GetSuccess() *shared.StartWorkflowExecutionResponse {
-  if !p.IsSetSuccess() {
-    return WorkflowServiceStartWorkflowExecutionResult_Success_DEFAULT
-  }
-return p.Success
-}
-var WorkflowServiceStartWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceStartWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceRespondActivityTaskCanceledByIDResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceStartWorkflowExecutionResult_BadRequestError_DEFAULT
+    return WorkflowServiceRespondActivityTaskCanceledByIDResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceStartWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceStartWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceRespondActivityTaskCanceledByIDResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceStartWorkflowExecutionResult_InternalServiceError_DEFAULT
+    return WorkflowServiceRespondActivityTaskCanceledByIDResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceStartWorkflowExecutionResult_SessionAlreadyExistError_DEFAULT *shared.WorkflowExecutionAlreadyStartedError
-func (p *WorkflowServiceStartWorkflowExecutionResult) GetSessionAlreadyExistError() *shared.WorkflowExecutionAlreadyStartedError {
-  if !p.IsSetSessionAlreadyExistError() {
-    return WorkflowServiceStartWorkflowExecutionResult_SessionAlreadyExistError_DEFAULT
+var WorkflowServiceRespondActivityTaskCanceledByIDResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceRespondActivityTaskCanceledByIDResult_EntityNotExistError_DEFAULT
   }
-return p.SessionAlreadyExistError
-}
-func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetSuccess() bool {
-  return p.Success != nil
+return p.EntityNotExistError
 }
-
-func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult) IsSetSessionAlreadyExistError() bool {
-  return p.SessionAlreadyExistError != nil
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -4220,10 +9639,6 @@ func (p *WorkflowServiceStartWorkflowExecutionResult) Read(iprot thrift.TProtoco
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
-    case 0:
-      if err := p.ReadField0(iprot); err != nil {
-        return err
-      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -4251,15 +9666,7 @@ func (p *WorkflowServiceStartWorkflowExecutionResult) Read(iprot thrift.TProtoco
   return nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.StartWorkflowExecutionResponse{}
-  if err := p.Success.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-  }
-  return nil
-}
-
-func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -4267,7 +9674,7 @@ func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField1(iprot thrift.T
   return nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -4275,19 +9682,18 @@ func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField2(iprot thrift.T
   return nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.SessionAlreadyExistError = &shared.WorkflowExecutionAlreadyStartedError{}
-  if err := p.SessionAlreadyExistError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SessionAlreadyExistError), err)
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("StartWorkflowExecution_result"); err != nil {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCanceledByID_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
-    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -4299,20 +9705,7 @@ func (p *WorkflowServiceStartWorkflowExecutionResult) Write(oprot thrift.TProtoc
   return nil
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
-  if p.IsSetSuccess() {
-    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
-    if err := p.Success.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
-  }
-  return err
-}
-
-func (p *WorkflowServiceStartWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -4325,7 +9718,7 @@ func (p *WorkflowServiceStartWorkflowExecutionResult) writeField1(oprot thrift.T
   return err
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -4338,48 +9731,48 @@ func (p *WorkflowServiceStartWorkflowExecutionResult) writeField2(oprot thrift.T
   return err
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetSessionAlreadyExistError() {
-    if err := oprot.WriteFieldBegin("sessionAlreadyExistError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:sessionAlreadyExistError: ", p), err) }
-    if err := p.SessionAlreadyExistError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SessionAlreadyExistError), err)
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:sessionAlreadyExistError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
   return err
 }
 
-func (p *WorkflowServiceStartWorkflowExecutionResult) String() string {
+func (p *WorkflowServiceRespondActivityTaskCanceledByIDResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceStartWorkflowExecutionResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCanceledByIDResult(%+v)", *p)
 }
 
 // Attributes:
-//  - GetRequest
-type WorkflowServiceGetWorkflowExecutionHistoryArgs struct {
-  GetRequest *shared.GetWorkflowExecutionHistoryRequest `thrift:"getRequest,1" db:"getRequest" json:"getRequest"`
+//  - CancelRequest
+type WorkflowServiceRequestCancelWorkflowExecutionArgs struct {
+  CancelRequest *shared.RequestCancelWorkflowExecutionRequest `thrift:"cancelRequest,1" db:"cancelRequest" json:"cancelRequest"`
 }
 
-func NewWorkflowServiceGetWorkflowExecutionHistoryArgs() *WorkflowServiceGetWorkflowExecutionHistoryArgs {
-  return &WorkflowServiceGetWorkflowExecutionHistoryArgs{}
+func NewWorkflowServiceRequestCancelWorkflowExecutionArgs() *WorkflowServiceRequestCancelWorkflowExecutionArgs {
+  return &WorkflowServiceRequestCancelWorkflowExecutionArgs{}
 }
 
-var WorkflowServiceGetWorkflowExecutionHistoryArgs_GetRequest_DEFAULT *shared.GetWorkflowExecutionHistoryRequest
-func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) GetGetRequest() *shared.GetWorkflowExecutionHistoryRequest {
-  if !p.IsSetGetRequest() {
-    return WorkflowServiceGetWorkflowExecutionHistoryArgs_GetRequest_DEFAULT
+var WorkflowServiceRequestCancelWorkflowExecutionArgs_CancelRequest_DEFAULT *shared.RequestCancelWorkflowExecutionRequest
+func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) GetCancelRequest() *shared.RequestCancelWorkflowExecutionRequest {
+  if !p.IsSetCancelRequest() {
+    return WorkflowServiceRequestCancelWorkflowExecutionArgs_CancelRequest_DEFAULT
   }
-return p.GetRequest
+return p.CancelRequest
 }
-func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) IsSetGetRequest() bool {
-  return p.GetRequest != nil
+func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) IsSetCancelRequest() bool {
+  return p.CancelRequest != nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -4411,16 +9804,16 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) Read(iprot thrift.TProt
   return nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.GetRequest = &shared.GetWorkflowExecutionHistoryRequest{}
-  if err := p.GetRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.GetRequest), err)
+func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CancelRequest = &shared.RequestCancelWorkflowExecutionRequest{}
+  if err := p.CancelRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CancelRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("GetWorkflowExecutionHistory_args"); err != nil {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -4432,85 +9825,72 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) Write(oprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("getRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:getRequest: ", p), err) }
-  if err := p.GetRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.GetRequest), err)
+func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("cancelRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:cancelRequest: ", p), err) }
+  if err := p.CancelRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CancelRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:getRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:cancelRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryArgs) String() string {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceGetWorkflowExecutionHistoryArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRequestCancelWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceGetWorkflowExecutionHistoryResult struct {
-  Success *shared.GetWorkflowExecutionHistoryResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type WorkflowServiceRequestCancelWorkflowExecutionResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceGetWorkflowExecutionHistoryResult() *WorkflowServiceGetWorkflowExecutionHistoryResult {
-  return &WorkflowServiceGetWorkflowExecutionHistoryResult{}
+func NewWorkflowServiceRequestCancelWorkflowExecutionResult() *WorkflowServiceRequestCancelWorkflowExecutionResult {
+  return &WorkflowServiceRequestCancelWorkflowExecutionResult{}
 }
 
-var WorkflowServiceGetWorkflowExecutionHistoryResult_Success_DEFAULT *shared.GetWorkflowExecutionHistoryResponse
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) GetSuccess() *shared.GetWorkflowExecutionHistoryResponse {
-  if !p.IsSetSuccess() {
-    return WorkflowServiceGetWorkflowExecutionHistoryResult_Success_DEFAULT
-  }
-return p.Success
-}
-var WorkflowServiceGetWorkflowExecutionHistoryResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceRequestCancelWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceGetWorkflowExecutionHistoryResult_BadRequestError_DEFAULT
+    return WorkflowServiceRequestCancelWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceGetWorkflowExecutionHistoryResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceRequestCancelWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceGetWorkflowExecutionHistoryResult_InternalServiceError_DEFAULT
+    return WorkflowServiceRequestCancelWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceGetWorkflowExecutionHistoryResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceRequestCancelWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceGetWorkflowExecutionHistoryResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceRequestCancelWorkflowExecutionResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) IsSetSuccess() bool {
-  return p.Success != nil
-}
-
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -4523,10 +9903,6 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) Read(iprot thrift.TPr
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
-    case 0:
-      if err := p.ReadField0(iprot); err != nil {
-        return err
-      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -4554,15 +9930,7 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) Read(iprot thrift.TPr
   return nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.GetWorkflowExecutionHistoryResponse{}
-  if err := p.Success.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-  }
-  return nil
-}
-
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -4570,7 +9938,7 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField1(iprot thr
   return nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -4578,7 +9946,7 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField2(iprot thr
   return nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -4586,11 +9954,10 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryResult)  ReadField3(iprot thr
   return nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("GetWorkflowExecutionHistory_result"); err != nil {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
-    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -4602,20 +9969,7 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) Write(oprot thrift.TP
   return nil
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField0(oprot thrift.TProtocol) (err error) {
-  if p.IsSetSuccess() {
-    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
-    if err := p.Success.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
-  }
-  return err
-}
-
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -4628,7 +9982,7 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField1(oprot thr
   return err
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -4641,7 +9995,7 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField2(oprot thr
   return err
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -4654,35 +10008,35 @@ func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) writeField3(oprot thr
   return err
 }
 
-func (p *WorkflowServiceGetWorkflowExecutionHistoryResult) String() string {
+func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceGetWorkflowExecutionHistoryResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRequestCancelWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - PollRequest
-type WorkflowServicePollForDecisionTaskArgs struct {
-  PollRequest *shared.PollForDecisionTaskRequest `thrift:"pollRequest,1" db:"pollRequest" json:"pollRequest"`
+//  - SignalRequest
+type WorkflowServiceSignalWorkflowExecutionArgs struct {
+  SignalRequest *shared.SignalWorkflowExecutionRequest `thrift:"signalRequest,1" db:"signalRequest" json:"signalRequest"`
 }
 
-func NewWorkflowServicePollForDecisionTaskArgs() *WorkflowServicePollForDecisionTaskArgs {
-  return &WorkflowServicePollForDecisionTaskArgs{}
+func NewWorkflowServiceSignalWorkflowExecutionArgs() *WorkflowServiceSignalWorkflowExecutionArgs {
+  return &WorkflowServiceSignalWorkflowExecutionArgs{}
 }
 
-var WorkflowServicePollForDecisionTaskArgs_PollRequest_DEFAULT *shared.PollForDecisionTaskRequest
-func (p *WorkflowServicePollForDecisionTaskArgs) GetPollRequest() *shared.PollForDecisionTaskRequest {
-  if !p.IsSetPollRequest() {
-    return WorkflowServicePollForDecisionTaskArgs_PollRequest_DEFAULT
+var WorkflowServiceSignalWorkflowExecutionArgs_SignalRequest_DEFAULT *shared.SignalWorkflowExecutionRequest
+func (p *WorkflowServiceSignalWorkflowExecutionArgs) GetSignalRequest() *shared.SignalWorkflowExecutionRequest {
+  if !p.IsSetSignalRequest() {
+    return WorkflowServiceSignalWorkflowExecutionArgs_SignalRequest_DEFAULT
   }
-return p.PollRequest
+return p.SignalRequest
 }
-func (p *WorkflowServicePollForDecisionTaskArgs) IsSetPollRequest() bool {
-  return p.PollRequest != nil
+func (p *WorkflowServiceSignalWorkflowExecutionArgs) IsSetSignalRequest() bool {
+  return p.SignalRequest != nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceSignalWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -4714,16 +10068,16 @@ func (p *WorkflowServicePollForDecisionTaskArgs) Read(iprot thrift.TProtocol) er
   return nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.PollRequest = &shared.PollForDecisionTaskRequest{}
-  if err := p.PollRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PollRequest), err)
+func (p *WorkflowServiceSignalWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.SignalRequest = &shared.SignalWorkflowExecutionRequest{}
+  if err := p.SignalRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SignalRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("PollForDecisionTask_args"); err != nil {
+func (p *WorkflowServiceSignalWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SignalWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -4735,72 +10089,85 @@ func (p *WorkflowServicePollForDecisionTaskArgs) Write(oprot thrift.TProtocol) e
   return nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("pollRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:pollRequest: ", p), err) }
-  if err := p.PollRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PollRequest), err)
+func (p *WorkflowServiceSignalWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("signalRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:signalRequest: ", p), err) }
+  if err := p.SignalRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SignalRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:pollRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:signalRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServicePollForDecisionTaskArgs) String() string {
+func (p *WorkflowServiceSignalWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServicePollForDecisionTaskArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceSignalWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - BadRequestError
 //  - InternalServiceError
-type WorkflowServicePollForDecisionTaskResult struct {
-  Success *shared.PollForDecisionTaskResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+//  - EntityNotExistError
+type WorkflowServiceSignalWorkflowExecutionResult struct {
+  Success *shared.SignalWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServicePollForDecisionTaskResult() *WorkflowServicePollForDecisionTaskResult {
-  return &WorkflowServicePollForDecisionTaskResult{}
+func NewWorkflowServiceSignalWorkflowExecutionResult() *WorkflowServiceSignalWorkflowExecutionResult {
+  return &WorkflowServiceSignalWorkflowExecutionResult{}
 }
 
-var WorkflowServicePollForDecisionTaskResult_Success_DEFAULT *shared.PollForDecisionTaskResponse
-func (p *WorkflowServicePollForDecisionTaskResult) GetSuccess() *shared.PollForDecisionTaskResponse {
+var WorkflowServiceSignalWorkflowExecutionResult_Success_DEFAULT *shared.SignalWorkflowExecutionResponse
+func (p *WorkflowServiceSignalWorkflowExecutionResult) GetSuccess() *shared.SignalWorkflowExecutionResponse {
   if !p.IsSetSuccess() {
-    return WorkflowServicePollForDecisionTaskResult_Success_DEFAULT
+    return WorkflowServiceSignalWorkflowExecutionResult_Success_DEFAULT
   }
 return p.Success
 }
-var WorkflowServicePollForDecisionTaskResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServicePollForDecisionTaskResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceSignalWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceSignalWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServicePollForDecisionTaskResult_BadRequestError_DEFAULT
+    return WorkflowServiceSignalWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServicePollForDecisionTaskResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServicePollForDecisionTaskResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceSignalWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceSignalWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServicePollForDecisionTaskResult_InternalServiceError_DEFAULT
+    return WorkflowServiceSignalWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-func (p *WorkflowServicePollForDecisionTaskResult) IsSetSuccess() bool {
+var WorkflowServiceSignalWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceSignalWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceSignalWorkflowExecutionResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+func (p *WorkflowServiceSignalWorkflowExecutionResult) IsSetSuccess() bool {
   return p.Success != nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceSignalWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceSignalWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceSignalWorkflowExecutionResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceSignalWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -4825,6 +10192,10 @@ func (p *WorkflowServicePollForDecisionTaskResult) Read(iprot thrift.TProtocol)
       if err := p.ReadField2(iprot); err != nil {
         return err
       }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -4840,15 +10211,15 @@ func (p *WorkflowServicePollForDecisionTaskResult) Read(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.PollForDecisionTaskResponse{}
+func (p *WorkflowServiceSignalWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.SignalWorkflowExecutionResponse{}
   if err := p.Success.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
   return nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceSignalWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -4856,7 +10227,7 @@ func (p *WorkflowServicePollForDecisionTaskResult)  ReadField1(iprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceSignalWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -4864,13 +10235,22 @@ func (p *WorkflowServicePollForDecisionTaskResult)  ReadField2(iprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("PollForDecisionTask_result"); err != nil {
+func (p *WorkflowServiceSignalWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceSignalWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SignalWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -4879,7 +10259,7 @@ func (p *WorkflowServicePollForDecisionTaskResult) Write(oprot thrift.TProtocol)
   return nil
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceSignalWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
   if p.IsSetSuccess() {
     if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
@@ -4892,7 +10272,7 @@ func (p *WorkflowServicePollForDecisionTaskResult) writeField0(oprot thrift.TPro
   return err
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceSignalWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -4905,7 +10285,7 @@ func (p *WorkflowServicePollForDecisionTaskResult) writeField1(oprot thrift.TPro
   return err
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceSignalWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -4918,35 +10298,48 @@ func (p *WorkflowServicePollForDecisionTaskResult) writeField2(oprot thrift.TPro
   return err
 }
 
-func (p *WorkflowServicePollForDecisionTaskResult) String() string {
+func (p *WorkflowServiceSignalWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceSignalWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServicePollForDecisionTaskResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceSignalWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - CompleteRequest
-type WorkflowServiceRespondDecisionTaskCompletedArgs struct {
-  CompleteRequest *shared.RespondDecisionTaskCompletedRequest `thrift:"completeRequest,1" db:"completeRequest" json:"completeRequest"`
+//  - ReceiptRequest
+type WorkflowServiceGetSignalReceiptArgs struct {
+  ReceiptRequest *shared.GetSignalReceiptRequest `thrift:"receiptRequest,1" db:"receiptRequest" json:"receiptRequest"`
 }
 
-func NewWorkflowServiceRespondDecisionTaskCompletedArgs() *WorkflowServiceRespondDecisionTaskCompletedArgs {
-  return &WorkflowServiceRespondDecisionTaskCompletedArgs{}
+func NewWorkflowServiceGetSignalReceiptArgs() *WorkflowServiceGetSignalReceiptArgs {
+  return &WorkflowServiceGetSignalReceiptArgs{}
 }
 
-var WorkflowServiceRespondDecisionTaskCompletedArgs_CompleteRequest_DEFAULT *shared.RespondDecisionTaskCompletedRequest
-func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) GetCompleteRequest() *shared.RespondDecisionTaskCompletedRequest {
-  if !p.IsSetCompleteRequest() {
-    return WorkflowServiceRespondDecisionTaskCompletedArgs_CompleteRequest_DEFAULT
+var WorkflowServiceGetSignalReceiptArgs_ReceiptRequest_DEFAULT *shared.GetSignalReceiptRequest
+func (p *WorkflowServiceGetSignalReceiptArgs) GetReceiptRequest() *shared.GetSignalReceiptRequest {
+  if !p.IsSetReceiptRequest() {
+    return WorkflowServiceGetSignalReceiptArgs_ReceiptRequest_DEFAULT
   }
-return p.CompleteRequest
+return p.ReceiptRequest
 }
-func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) IsSetCompleteRequest() bool {
-  return p.CompleteRequest != nil
+func (p *WorkflowServiceGetSignalReceiptArgs) IsSetReceiptRequest() bool {
+  return p.ReceiptRequest != nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceGetSignalReceiptArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -4978,16 +10371,16 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) Read(iprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.CompleteRequest = &shared.RespondDecisionTaskCompletedRequest{}
-  if err := p.CompleteRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
+func (p *WorkflowServiceGetSignalReceiptArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.ReceiptRequest = &shared.GetSignalReceiptRequest{}
+  if err := p.ReceiptRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ReceiptRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondDecisionTaskCompleted_args"); err != nil {
+func (p *WorkflowServiceGetSignalReceiptArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetSignalReceipt_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -4999,72 +10392,85 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) Write(oprot thrift.TPr
   return nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completeRequest: ", p), err) }
-  if err := p.CompleteRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
+func (p *WorkflowServiceGetSignalReceiptArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("receiptRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:receiptRequest: ", p), err) }
+  if err := p.ReceiptRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ReceiptRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completeRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:receiptRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedArgs) String() string {
+func (p *WorkflowServiceGetSignalReceiptArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRespondDecisionTaskCompletedArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceGetSignalReceiptArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceRespondDecisionTaskCompletedResult struct {
+type WorkflowServiceGetSignalReceiptResult struct {
+  Success *shared.GetSignalReceiptResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceRespondDecisionTaskCompletedResult() *WorkflowServiceRespondDecisionTaskCompletedResult {
-  return &WorkflowServiceRespondDecisionTaskCompletedResult{}
+func NewWorkflowServiceGetSignalReceiptResult() *WorkflowServiceGetSignalReceiptResult {
+  return &WorkflowServiceGetSignalReceiptResult{}
 }
 
-var WorkflowServiceRespondDecisionTaskCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceGetSignalReceiptResult_Success_DEFAULT *shared.GetSignalReceiptResponse
+func (p *WorkflowServiceGetSignalReceiptResult) GetSuccess() *shared.GetSignalReceiptResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceGetSignalReceiptResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceGetSignalReceiptResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceGetSignalReceiptResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceRespondDecisionTaskCompletedResult_BadRequestError_DEFAULT
+    return WorkflowServiceGetSignalReceiptResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceRespondDecisionTaskCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceGetSignalReceiptResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceGetSignalReceiptResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceRespondDecisionTaskCompletedResult_InternalServiceError_DEFAULT
+    return WorkflowServiceGetSignalReceiptResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceRespondDecisionTaskCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceGetSignalReceiptResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceGetSignalReceiptResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceRespondDecisionTaskCompletedResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceGetSignalReceiptResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceGetSignalReceiptResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceGetSignalReceiptResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceGetSignalReceiptResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceGetSignalReceiptResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceGetSignalReceiptResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -5077,6 +10483,10 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedResult) Read(iprot thrift.TP
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -5104,7 +10514,15 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedResult) Read(iprot thrift.TP
   return nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceGetSignalReceiptResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.GetSignalReceiptResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceGetSignalReceiptResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -5112,7 +10530,7 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField1(iprot th
   return nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceGetSignalReceiptResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -5120,7 +10538,7 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField2(iprot th
   return nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceGetSignalReceiptResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -5128,10 +10546,11 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedResult)  ReadField3(iprot th
   return nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondDecisionTaskCompleted_result"); err != nil {
+func (p *WorkflowServiceGetSignalReceiptResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetSignalReceipt_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -5143,7 +10562,20 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedResult) Write(oprot thrift.T
   return nil
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceGetSignalReceiptResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceGetSignalReceiptResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -5156,7 +10588,7 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField1(oprot th
   return err
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceGetSignalReceiptResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -5169,7 +10601,7 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField2(oprot th
   return err
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceGetSignalReceiptResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -5182,35 +10614,35 @@ func (p *WorkflowServiceRespondDecisionTaskCompletedResult) writeField3(oprot th
   return err
 }
 
-func (p *WorkflowServiceRespondDecisionTaskCompletedResult) String() string {
+func (p *WorkflowServiceGetSignalReceiptResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRespondDecisionTaskCompletedResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceGetSignalReceiptResult(%+v)", *p)
 }
 
 // Attributes:
-//  - PollRequest
-type WorkflowServicePollForActivityTaskArgs struct {
-  PollRequest *shared.PollForActivityTaskRequest `thrift:"pollRequest,1" db:"pollRequest" json:"pollRequest"`
+//  - QueryRequest
+type WorkflowServiceQueryWorkflowArgs struct {
+  QueryRequest *shared.QueryWorkflowRequest `thrift:"queryRequest,1" db:"queryRequest" json:"queryRequest"`
 }
 
-func NewWorkflowServicePollForActivityTaskArgs() *WorkflowServicePollForActivityTaskArgs {
-  return &WorkflowServicePollForActivityTaskArgs{}
+func NewWorkflowServiceQueryWorkflowArgs() *WorkflowServiceQueryWorkflowArgs {
+  return &WorkflowServiceQueryWorkflowArgs{}
 }
 
-var WorkflowServicePollForActivityTaskArgs_PollRequest_DEFAULT *shared.PollForActivityTaskRequest
-func (p *WorkflowServicePollForActivityTaskArgs) GetPollRequest() *shared.PollForActivityTaskRequest {
-  if !p.IsSetPollRequest() {
-    return WorkflowServicePollForActivityTaskArgs_PollRequest_DEFAULT
+var WorkflowServiceQueryWorkflowArgs_QueryRequest_DEFAULT *shared.QueryWorkflowRequest
+func (p *WorkflowServiceQueryWorkflowArgs) GetQueryRequest() *shared.QueryWorkflowRequest {
+  if !p.IsSetQueryRequest() {
+    return WorkflowServiceQueryWorkflowArgs_QueryRequest_DEFAULT
   }
-return p.PollRequest
+return p.QueryRequest
 }
-func (p *WorkflowServicePollForActivityTaskArgs) IsSetPollRequest() bool {
-  return p.PollRequest != nil
+func (p *WorkflowServiceQueryWorkflowArgs) IsSetQueryRequest() bool {
+  return p.QueryRequest != nil
 }
 
-func (p *WorkflowServicePollForActivityTaskArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceQueryWorkflowArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -5242,16 +10674,16 @@ func (p *WorkflowServicePollForActivityTaskArgs) Read(iprot thrift.TProtocol) er
   return nil
 }
 
-func (p *WorkflowServicePollForActivityTaskArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.PollRequest = &shared.PollForActivityTaskRequest{}
-  if err := p.PollRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PollRequest), err)
+func (p *WorkflowServiceQueryWorkflowArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.QueryRequest = &shared.QueryWorkflowRequest{}
+  if err := p.QueryRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.QueryRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServicePollForActivityTaskArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("PollForActivityTask_args"); err != nil {
+func (p *WorkflowServiceQueryWorkflowArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("QueryWorkflow_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -5263,72 +10695,98 @@ func (p *WorkflowServicePollForActivityTaskArgs) Write(oprot thrift.TProtocol) e
   return nil
 }
 
-func (p *WorkflowServicePollForActivityTaskArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("pollRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:pollRequest: ", p), err) }
-  if err := p.PollRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PollRequest), err)
+func (p *WorkflowServiceQueryWorkflowArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("queryRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queryRequest: ", p), err) }
+  if err := p.QueryRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.QueryRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:pollRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queryRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServicePollForActivityTaskArgs) String() string {
+func (p *WorkflowServiceQueryWorkflowArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServicePollForActivityTaskArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceQueryWorkflowArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - BadRequestError
 //  - InternalServiceError
-type WorkflowServicePollForActivityTaskResult struct {
-  Success *shared.PollForActivityTaskResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+//  - EntityNotExistError
+//  - QueryFailedError
+type WorkflowServiceQueryWorkflowResult struct {
+  Success *shared.QueryWorkflowResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  QueryFailedError *shared.QueryFailedError `thrift:"queryFailedError,4" db:"queryFailedError" json:"queryFailedError,omitempty"`
 }
 
-func NewWorkflowServicePollForActivityTaskResult() *WorkflowServicePollForActivityTaskResult {
-  return &WorkflowServicePollForActivityTaskResult{}
+func NewWorkflowServiceQueryWorkflowResult() *WorkflowServiceQueryWorkflowResult {
+  return &WorkflowServiceQueryWorkflowResult{}
 }
 
-var WorkflowServicePollForActivityTaskResult_Success_DEFAULT *shared.PollForActivityTaskResponse
-func (p *WorkflowServicePollForActivityTaskResult) GetSuccess() *shared.PollForActivityTaskResponse {
+var WorkflowServiceQueryWorkflowResult_Success_DEFAULT *shared.QueryWorkflowResponse
+func (p *WorkflowServiceQueryWorkflowResult) GetSuccess() *shared.QueryWorkflowResponse {
   if !p.IsSetSuccess() {
-    return WorkflowServicePollForActivityTaskResult_Success_DEFAULT
+    return WorkflowServiceQueryWorkflowResult_Success_DEFAULT
   }
 return p.Success
 }
-var WorkflowServicePollForActivityTaskResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServicePollForActivityTaskResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceQueryWorkflowResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceQueryWorkflowResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServicePollForActivityTaskResult_BadRequestError_DEFAULT
+    return WorkflowServiceQueryWorkflowResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServicePollForActivityTaskResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServicePollForActivityTaskResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceQueryWorkflowResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceQueryWorkflowResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServicePollForActivityTaskResult_InternalServiceError_DEFAULT
+    return WorkflowServiceQueryWorkflowResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-func (p *WorkflowServicePollForActivityTaskResult) IsSetSuccess() bool {
+var WorkflowServiceQueryWorkflowResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceQueryWorkflowResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return WorkflowServiceQueryWorkflowResult_EntityNotExistError_DEFAULT
+  }
+return p.EntityNotExistError
+}
+var WorkflowServiceQueryWorkflowResult_QueryFailedError_DEFAULT *shared.QueryFailedError
+func (p *WorkflowServiceQueryWorkflowResult) GetQueryFailedError() *shared.QueryFailedError {
+  if !p.IsSetQueryFailedError() {
+    return WorkflowServiceQueryWorkflowResult_QueryFailedError_DEFAULT
+  }
+return p.QueryFailedError
+}
+func (p *WorkflowServiceQueryWorkflowResult) IsSetSuccess() bool {
   return p.Success != nil
 }
 
-func (p *WorkflowServicePollForActivityTaskResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceQueryWorkflowResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServicePollForActivityTaskResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceQueryWorkflowResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServicePollForActivityTaskResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceQueryWorkflowResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *WorkflowServiceQueryWorkflowResult) IsSetQueryFailedError() bool {
+  return p.QueryFailedError != nil
+}
+
+func (p *WorkflowServiceQueryWorkflowResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -5353,6 +10811,14 @@ func (p *WorkflowServicePollForActivityTaskResult) Read(iprot thrift.TProtocol)
       if err := p.ReadField2(iprot); err != nil {
         return err
       }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -5368,15 +10834,15 @@ func (p *WorkflowServicePollForActivityTaskResult) Read(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *WorkflowServicePollForActivityTaskResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.PollForActivityTaskResponse{}
+func (p *WorkflowServiceQueryWorkflowResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.QueryWorkflowResponse{}
   if err := p.Success.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
   return nil
 }
 
-func (p *WorkflowServicePollForActivityTaskResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceQueryWorkflowResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -5384,7 +10850,7 @@ func (p *WorkflowServicePollForActivityTaskResult)  ReadField1(iprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServicePollForActivityTaskResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceQueryWorkflowResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -5392,13 +10858,31 @@ func (p *WorkflowServicePollForActivityTaskResult)  ReadField2(iprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServicePollForActivityTaskResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("PollForActivityTask_result"); err != nil {
+func (p *WorkflowServiceQueryWorkflowResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceQueryWorkflowResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.QueryFailedError = &shared.QueryFailedError{}
+  if err := p.QueryFailedError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.QueryFailedError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceQueryWorkflowResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("QueryWorkflow_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -5407,7 +10891,7 @@ func (p *WorkflowServicePollForActivityTaskResult) Write(oprot thrift.TProtocol)
   return nil
 }
 
-func (p *WorkflowServicePollForActivityTaskResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceQueryWorkflowResult) writeField0(oprot thrift.TProtocol) (err error) {
   if p.IsSetSuccess() {
     if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
@@ -5420,7 +10904,7 @@ func (p *WorkflowServicePollForActivityTaskResult) writeField0(oprot thrift.TPro
   return err
 }
 
-func (p *WorkflowServicePollForActivityTaskResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceQueryWorkflowResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -5433,7 +10917,7 @@ func (p *WorkflowServicePollForActivityTaskResult) writeField1(oprot thrift.TPro
   return err
 }
 
-func (p *WorkflowServicePollForActivityTaskResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceQueryWorkflowResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -5446,35 +10930,61 @@ func (p *WorkflowServicePollForActivityTaskResult) writeField2(oprot thrift.TPro
   return err
 }
 
-func (p *WorkflowServicePollForActivityTaskResult) String() string {
+func (p *WorkflowServiceQueryWorkflowResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceQueryWorkflowResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetQueryFailedError() {
+    if err := oprot.WriteFieldBegin("queryFailedError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:queryFailedError: ", p), err) }
+    if err := p.QueryFailedError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.QueryFailedError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:queryFailedError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceQueryWorkflowResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServicePollForActivityTaskResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceQueryWorkflowResult(%+v)", *p)
 }
 
 // Attributes:
-//  - HeartbeatRequest
-type WorkflowServiceRecordActivityTaskHeartbeatArgs struct {
-  HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `thrift:"heartbeatRequest,1" db:"heartbeatRequest" json:"heartbeatRequest"`
+//  - DescribeRequest
+type WorkflowServiceDescribeWorkflowExecutionArgs struct {
+  DescribeRequest *shared.DescribeWorkflowExecutionRequest `thrift:"describeRequest,1" db:"describeRequest" json:"describeRequest"`
 }
 
-func NewWorkflowServiceRecordActivityTaskHeartbeatArgs() *WorkflowServiceRecordActivityTaskHeartbeatArgs {
-  return &WorkflowServiceRecordActivityTaskHeartbeatArgs{}
+func NewWorkflowServiceDescribeWorkflowExecutionArgs() *WorkflowServiceDescribeWorkflowExecutionArgs {
+  return &WorkflowServiceDescribeWorkflowExecutionArgs{}
 }
 
-var WorkflowServiceRecordActivityTaskHeartbeatArgs_HeartbeatRequest_DEFAULT *shared.RecordActivityTaskHeartbeatRequest
-func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) GetHeartbeatRequest() *shared.RecordActivityTaskHeartbeatRequest {
-  if !p.IsSetHeartbeatRequest() {
-    return WorkflowServiceRecordActivityTaskHeartbeatArgs_HeartbeatRequest_DEFAULT
+var WorkflowServiceDescribeWorkflowExecutionArgs_DescribeRequest_DEFAULT *shared.DescribeWorkflowExecutionRequest
+func (p *WorkflowServiceDescribeWorkflowExecutionArgs) GetDescribeRequest() *shared.DescribeWorkflowExecutionRequest {
+  if !p.IsSetDescribeRequest() {
+    return WorkflowServiceDescribeWorkflowExecutionArgs_DescribeRequest_DEFAULT
   }
-return p.HeartbeatRequest
+return p.DescribeRequest
 }
-func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) IsSetHeartbeatRequest() bool {
-  return p.HeartbeatRequest != nil
+func (p *WorkflowServiceDescribeWorkflowExecutionArgs) IsSetDescribeRequest() bool {
+  return p.DescribeRequest != nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceDescribeWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -5506,16 +11016,16 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) Read(iprot thrift.TProt
   return nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.HeartbeatRequest = &shared.RecordActivityTaskHeartbeatRequest{}
-  if err := p.HeartbeatRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.HeartbeatRequest), err)
+func (p *WorkflowServiceDescribeWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.DescribeRequest = &shared.DescribeWorkflowExecutionRequest{}
+  if err := p.DescribeRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DescribeRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeat_args"); err != nil {
+func (p *WorkflowServiceDescribeWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -5527,22 +11037,22 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) Write(oprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("heartbeatRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:heartbeatRequest: ", p), err) }
-  if err := p.HeartbeatRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.HeartbeatRequest), err)
+func (p *WorkflowServiceDescribeWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("describeRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:describeRequest: ", p), err) }
+  if err := p.DescribeRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DescribeRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:heartbeatRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:describeRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) String() string {
+func (p *WorkflowServiceDescribeWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRecordActivityTaskHeartbeatArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceDescribeWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
@@ -5550,62 +11060,62 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatArgs) String() string {
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceRecordActivityTaskHeartbeatResult struct {
-  Success *shared.RecordActivityTaskHeartbeatResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type WorkflowServiceDescribeWorkflowExecutionResult struct {
+  Success *shared.DescribeWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceRecordActivityTaskHeartbeatResult() *WorkflowServiceRecordActivityTaskHeartbeatResult {
-  return &WorkflowServiceRecordActivityTaskHeartbeatResult{}
+func NewWorkflowServiceDescribeWorkflowExecutionResult() *WorkflowServiceDescribeWorkflowExecutionResult {
+  return &WorkflowServiceDescribeWorkflowExecutionResult{}
 }
 
-var WorkflowServiceRecordActivityTaskHeartbeatResult_Success_DEFAULT *shared.RecordActivityTaskHeartbeatResponse
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) GetSuccess() *shared.RecordActivityTaskHeartbeatResponse {
+var WorkflowServiceDescribeWorkflowExecutionResult_Success_DEFAULT *shared.DescribeWorkflowExecutionResponse
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) GetSuccess() *shared.DescribeWorkflowExecutionResponse {
   if !p.IsSetSuccess() {
-    return WorkflowServiceRecordActivityTaskHeartbeatResult_Success_DEFAULT
+    return WorkflowServiceDescribeWorkflowExecutionResult_Success_DEFAULT
   }
 return p.Success
 }
-var WorkflowServiceRecordActivityTaskHeartbeatResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceDescribeWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceRecordActivityTaskHeartbeatResult_BadRequestError_DEFAULT
+    return WorkflowServiceDescribeWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceRecordActivityTaskHeartbeatResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceDescribeWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceRecordActivityTaskHeartbeatResult_InternalServiceError_DEFAULT
+    return WorkflowServiceDescribeWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceRecordActivityTaskHeartbeatResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceDescribeWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceRecordActivityTaskHeartbeatResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceDescribeWorkflowExecutionResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) IsSetSuccess() bool {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) IsSetSuccess() bool {
   return p.Success != nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -5649,15 +11159,15 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) Read(iprot thrift.TPr
   return nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.RecordActivityTaskHeartbeatResponse{}
+func (p *WorkflowServiceDescribeWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.DescribeWorkflowExecutionResponse{}
   if err := p.Success.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -5665,7 +11175,7 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField1(iprot thr
   return nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -5673,7 +11183,7 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField2(iprot thr
   return nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -5681,8 +11191,8 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatResult)  ReadField3(iprot thr
   return nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeat_result"); err != nil {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField0(oprot); err != nil { return err }
@@ -5697,7 +11207,7 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) Write(oprot thrift.TP
   return nil
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
   if p.IsSetSuccess() {
     if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
@@ -5710,7 +11220,7 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField0(oprot thr
   return err
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -5723,7 +11233,7 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField1(oprot thr
   return err
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -5736,7 +11246,7 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField2(oprot thr
   return err
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -5749,35 +11259,35 @@ func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) writeField3(oprot thr
   return err
 }
 
-func (p *WorkflowServiceRecordActivityTaskHeartbeatResult) String() string {
+func (p *WorkflowServiceDescribeWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRecordActivityTaskHeartbeatResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceDescribeWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - CompleteRequest
-type WorkflowServiceRespondActivityTaskCompletedArgs struct {
-  CompleteRequest *shared.RespondActivityTaskCompletedRequest `thrift:"completeRequest,1" db:"completeRequest" json:"completeRequest"`
+//  - TerminateRequest
+type WorkflowServiceTerminateWorkflowExecutionArgs struct {
+  TerminateRequest *shared.TerminateWorkflowExecutionRequest `thrift:"terminateRequest,1" db:"terminateRequest" json:"terminateRequest"`
 }
 
-func NewWorkflowServiceRespondActivityTaskCompletedArgs() *WorkflowServiceRespondActivityTaskCompletedArgs {
-  return &WorkflowServiceRespondActivityTaskCompletedArgs{}
+func NewWorkflowServiceTerminateWorkflowExecutionArgs() *WorkflowServiceTerminateWorkflowExecutionArgs {
+  return &WorkflowServiceTerminateWorkflowExecutionArgs{}
 }
 
-var WorkflowServiceRespondActivityTaskCompletedArgs_CompleteRequest_DEFAULT *shared.RespondActivityTaskCompletedRequest
-func (p *WorkflowServiceRespondActivityTaskCompletedArgs) GetCompleteRequest() *shared.RespondActivityTaskCompletedRequest {
-  if !p.IsSetCompleteRequest() {
-    return WorkflowServiceRespondActivityTaskCompletedArgs_CompleteRequest_DEFAULT
+var WorkflowServiceTerminateWorkflowExecutionArgs_TerminateRequest_DEFAULT *shared.TerminateWorkflowExecutionRequest
+func (p *WorkflowServiceTerminateWorkflowExecutionArgs) GetTerminateRequest() *shared.TerminateWorkflowExecutionRequest {
+  if !p.IsSetTerminateRequest() {
+    return WorkflowServiceTerminateWorkflowExecutionArgs_TerminateRequest_DEFAULT
   }
-return p.CompleteRequest
+return p.TerminateRequest
 }
-func (p *WorkflowServiceRespondActivityTaskCompletedArgs) IsSetCompleteRequest() bool {
-  return p.CompleteRequest != nil
+func (p *WorkflowServiceTerminateWorkflowExecutionArgs) IsSetTerminateRequest() bool {
+  return p.TerminateRequest != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceTerminateWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -5809,16 +11319,16 @@ func (p *WorkflowServiceRespondActivityTaskCompletedArgs) Read(iprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.CompleteRequest = &shared.RespondActivityTaskCompletedRequest{}
-  if err := p.CompleteRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
+func (p *WorkflowServiceTerminateWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.TerminateRequest = &shared.TerminateWorkflowExecutionRequest{}
+  if err := p.TerminateRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.TerminateRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCompleted_args"); err != nil {
+func (p *WorkflowServiceTerminateWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("TerminateWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -5830,72 +11340,85 @@ func (p *WorkflowServiceRespondActivityTaskCompletedArgs) Write(oprot thrift.TPr
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completeRequest: ", p), err) }
-  if err := p.CompleteRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
+func (p *WorkflowServiceTerminateWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("terminateRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:terminateRequest: ", p), err) }
+  if err := p.TerminateRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.TerminateRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completeRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:terminateRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedArgs) String() string {
+func (p *WorkflowServiceTerminateWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCompletedArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceTerminateWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceRespondActivityTaskCompletedResult struct {
+type WorkflowServiceTerminateWorkflowExecutionResult struct {
+  Success *shared.TerminateWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceRespondActivityTaskCompletedResult() *WorkflowServiceRespondActivityTaskCompletedResult {
-  return &WorkflowServiceRespondActivityTaskCompletedResult{}
+func NewWorkflowServiceTerminateWorkflowExecutionResult() *WorkflowServiceTerminateWorkflowExecutionResult {
+  return &WorkflowServiceTerminateWorkflowExecutionResult{}
 }
 
-var WorkflowServiceRespondActivityTaskCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceTerminateWorkflowExecutionResult_Success_DEFAULT *shared.TerminateWorkflowExecutionResponse
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) GetSuccess() *shared.TerminateWorkflowExecutionResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceTerminateWorkflowExecutionResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceTerminateWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceRespondActivityTaskCompletedResult_BadRequestError_DEFAULT
+    return WorkflowServiceTerminateWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceRespondActivityTaskCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceTerminateWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceRespondActivityTaskCompletedResult_InternalServiceError_DEFAULT
+    return WorkflowServiceTerminateWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceRespondActivityTaskCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceTerminateWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceRespondActivityTaskCompletedResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceTerminateWorkflowExecutionResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -5908,6 +11431,10 @@ func (p *WorkflowServiceRespondActivityTaskCompletedResult) Read(iprot thrift.TP
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -5935,7 +11462,15 @@ func (p *WorkflowServiceRespondActivityTaskCompletedResult) Read(iprot thrift.TP
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.TerminateWorkflowExecutionResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -5943,7 +11478,7 @@ func (p *WorkflowServiceRespondActivityTaskCompletedResult)  ReadField1(iprot th
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -5951,7 +11486,7 @@ func (p *WorkflowServiceRespondActivityTaskCompletedResult)  ReadField2(iprot th
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -5959,10 +11494,11 @@ func (p *WorkflowServiceRespondActivityTaskCompletedResult)  ReadField3(iprot th
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCompleted_result"); err != nil {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("TerminateWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -5974,7 +11510,20 @@ func (p *WorkflowServiceRespondActivityTaskCompletedResult) Write(oprot thrift.T
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -5987,7 +11536,7 @@ func (p *WorkflowServiceRespondActivityTaskCompletedResult) writeField1(oprot th
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -6000,7 +11549,7 @@ func (p *WorkflowServiceRespondActivityTaskCompletedResult) writeField2(oprot th
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -6013,35 +11562,35 @@ func (p *WorkflowServiceRespondActivityTaskCompletedResult) writeField3(oprot th
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskCompletedResult) String() string {
+func (p *WorkflowServiceTerminateWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCompletedResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceTerminateWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - FailRequest
-type WorkflowServiceRespondActivityTaskFailedArgs struct {
-  FailRequest *shared.RespondActivityTaskFailedRequest `thrift:"failRequest,1" db:"failRequest" json:"failRequest"`
+//  - ListRequest
+type WorkflowServiceListOpenWorkflowExecutionsArgs struct {
+  ListRequest *shared.ListOpenWorkflowExecutionsRequest `thrift:"listRequest,1" db:"listRequest" json:"listRequest"`
 }
 
-func NewWorkflowServiceRespondActivityTaskFailedArgs() *WorkflowServiceRespondActivityTaskFailedArgs {
-  return &WorkflowServiceRespondActivityTaskFailedArgs{}
+func NewWorkflowServiceListOpenWorkflowExecutionsArgs() *WorkflowServiceListOpenWorkflowExecutionsArgs {
+  return &WorkflowServiceListOpenWorkflowExecutionsArgs{}
 }
 
-var WorkflowServiceRespondActivityTaskFailedArgs_FailRequest_DEFAULT *shared.RespondActivityTaskFailedRequest
-func (p *WorkflowServiceRespondActivityTaskFailedArgs) GetFailRequest() *shared.RespondActivityTaskFailedRequest {
-  if !p.IsSetFailRequest() {
-    return WorkflowServiceRespondActivityTaskFailedArgs_FailRequest_DEFAULT
+var WorkflowServiceListOpenWorkflowExecutionsArgs_ListRequest_DEFAULT *shared.ListOpenWorkflowExecutionsRequest
+func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) GetListRequest() *shared.ListOpenWorkflowExecutionsRequest {
+  if !p.IsSetListRequest() {
+    return WorkflowServiceListOpenWorkflowExecutionsArgs_ListRequest_DEFAULT
   }
-return p.FailRequest
-}
-func (p *WorkflowServiceRespondActivityTaskFailedArgs) IsSetFailRequest() bool {
-  return p.FailRequest != nil
+return p.ListRequest
+}
+func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) IsSetListRequest() bool {
+  return p.ListRequest != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6073,16 +11622,16 @@ func (p *WorkflowServiceRespondActivityTaskFailedArgs) Read(iprot thrift.TProtoc
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.FailRequest = &shared.RespondActivityTaskFailedRequest{}
-  if err := p.FailRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.FailRequest), err)
+func (p *WorkflowServiceListOpenWorkflowExecutionsArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.ListRequest = &shared.ListOpenWorkflowExecutionsRequest{}
+  if err := p.ListRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ListRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskFailed_args"); err != nil {
+func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListOpenWorkflowExecutions_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -6094,72 +11643,85 @@ func (p *WorkflowServiceRespondActivityTaskFailedArgs) Write(oprot thrift.TProto
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("failRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:failRequest: ", p), err) }
-  if err := p.FailRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.FailRequest), err)
+func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("listRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:listRequest: ", p), err) }
+  if err := p.ListRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ListRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:failRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:listRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedArgs) String() string {
+func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRespondActivityTaskFailedArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceListOpenWorkflowExecutionsArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceRespondActivityTaskFailedResult struct {
+type WorkflowServiceListOpenWorkflowExecutionsResult struct {
+  Success *shared.ListOpenWorkflowExecutionsResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceRespondActivityTaskFailedResult() *WorkflowServiceRespondActivityTaskFailedResult {
-  return &WorkflowServiceRespondActivityTaskFailedResult{}
+func NewWorkflowServiceListOpenWorkflowExecutionsResult() *WorkflowServiceListOpenWorkflowExecutionsResult {
+  return &WorkflowServiceListOpenWorkflowExecutionsResult{}
 }
 
-var WorkflowServiceRespondActivityTaskFailedResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceRespondActivityTaskFailedResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceListOpenWorkflowExecutionsResult_Success_DEFAULT *shared.ListOpenWorkflowExecutionsResponse
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) GetSuccess() *shared.ListOpenWorkflowExecutionsResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceListOpenWorkflowExecutionsResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceListOpenWorkflowExecutionsResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceRespondActivityTaskFailedResult_BadRequestError_DEFAULT
+    return WorkflowServiceListOpenWorkflowExecutionsResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceRespondActivityTaskFailedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceRespondActivityTaskFailedResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceListOpenWorkflowExecutionsResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceRespondActivityTaskFailedResult_InternalServiceError_DEFAULT
+    return WorkflowServiceListOpenWorkflowExecutionsResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceRespondActivityTaskFailedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceRespondActivityTaskFailedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceListOpenWorkflowExecutionsResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceRespondActivityTaskFailedResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceListOpenWorkflowExecutionsResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceRespondActivityTaskFailedResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6172,6 +11734,10 @@ func (p *WorkflowServiceRespondActivityTaskFailedResult) Read(iprot thrift.TProt
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -6199,7 +11765,15 @@ func (p *WorkflowServiceRespondActivityTaskFailedResult) Read(iprot thrift.TProt
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.ListOpenWorkflowExecutionsResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -6207,7 +11781,7 @@ func (p *WorkflowServiceRespondActivityTaskFailedResult)  ReadField1(iprot thrif
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -6215,7 +11789,7 @@ func (p *WorkflowServiceRespondActivityTaskFailedResult)  ReadField2(iprot thrif
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -6223,10 +11797,11 @@ func (p *WorkflowServiceRespondActivityTaskFailedResult)  ReadField3(iprot thrif
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskFailed_result"); err != nil {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListOpenWorkflowExecutions_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -6238,7 +11813,20 @@ func (p *WorkflowServiceRespondActivityTaskFailedResult) Write(oprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -6251,7 +11839,7 @@ func (p *WorkflowServiceRespondActivityTaskFailedResult) writeField1(oprot thrif
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -6264,7 +11852,7 @@ func (p *WorkflowServiceRespondActivityTaskFailedResult) writeField2(oprot thrif
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -6277,35 +11865,35 @@ func (p *WorkflowServiceRespondActivityTaskFailedResult) writeField3(oprot thrif
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskFailedResult) String() string {
+func (p *WorkflowServiceListOpenWorkflowExecutionsResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRespondActivityTaskFailedResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceListOpenWorkflowExecutionsResult(%+v)", *p)
 }
 
 // Attributes:
-//  - CanceledRequest
-type WorkflowServiceRespondActivityTaskCanceledArgs struct {
-  CanceledRequest *shared.RespondActivityTaskCanceledRequest `thrift:"canceledRequest,1" db:"canceledRequest" json:"canceledRequest"`
+//  - ListRequest
+type WorkflowServiceListClosedWorkflowExecutionsArgs struct {
+  ListRequest *shared.ListClosedWorkflowExecutionsRequest `thrift:"listRequest,1" db:"listRequest" json:"listRequest"`
 }
 
-func NewWorkflowServiceRespondActivityTaskCanceledArgs() *WorkflowServiceRespondActivityTaskCanceledArgs {
-  return &WorkflowServiceRespondActivityTaskCanceledArgs{}
+func NewWorkflowServiceListClosedWorkflowExecutionsArgs() *WorkflowServiceListClosedWorkflowExecutionsArgs {
+  return &WorkflowServiceListClosedWorkflowExecutionsArgs{}
 }
 
-var WorkflowServiceRespondActivityTaskCanceledArgs_CanceledRequest_DEFAULT *shared.RespondActivityTaskCanceledRequest
-func (p *WorkflowServiceRespondActivityTaskCanceledArgs) GetCanceledRequest() *shared.RespondActivityTaskCanceledRequest {
-  if !p.IsSetCanceledRequest() {
-    return WorkflowServiceRespondActivityTaskCanceledArgs_CanceledRequest_DEFAULT
+var WorkflowServiceListClosedWorkflowExecutionsArgs_ListRequest_DEFAULT *shared.ListClosedWorkflowExecutionsRequest
+func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) GetListRequest() *shared.ListClosedWorkflowExecutionsRequest {
+  if !p.IsSetListRequest() {
+    return WorkflowServiceListClosedWorkflowExecutionsArgs_ListRequest_DEFAULT
   }
-return p.CanceledRequest
+return p.ListRequest
 }
-func (p *WorkflowServiceRespondActivityTaskCanceledArgs) IsSetCanceledRequest() bool {
-  return p.CanceledRequest != nil
+func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) IsSetListRequest() bool {
+  return p.ListRequest != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6337,16 +11925,16 @@ func (p *WorkflowServiceRespondActivityTaskCanceledArgs) Read(iprot thrift.TProt
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.CanceledRequest = &shared.RespondActivityTaskCanceledRequest{}
-  if err := p.CanceledRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CanceledRequest), err)
+func (p *WorkflowServiceListClosedWorkflowExecutionsArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.ListRequest = &shared.ListClosedWorkflowExecutionsRequest{}
+  if err := p.ListRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ListRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCanceled_args"); err != nil {
+func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListClosedWorkflowExecutions_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -6358,72 +11946,85 @@ func (p *WorkflowServiceRespondActivityTaskCanceledArgs) Write(oprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("canceledRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:canceledRequest: ", p), err) }
-  if err := p.CanceledRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CanceledRequest), err)
+func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("listRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:listRequest: ", p), err) }
+  if err := p.ListRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ListRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:canceledRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:listRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledArgs) String() string {
+func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCanceledArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceListClosedWorkflowExecutionsArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceRespondActivityTaskCanceledResult struct {
+type WorkflowServiceListClosedWorkflowExecutionsResult struct {
+  Success *shared.ListClosedWorkflowExecutionsResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceRespondActivityTaskCanceledResult() *WorkflowServiceRespondActivityTaskCanceledResult {
-  return &WorkflowServiceRespondActivityTaskCanceledResult{}
+func NewWorkflowServiceListClosedWorkflowExecutionsResult() *WorkflowServiceListClosedWorkflowExecutionsResult {
+  return &WorkflowServiceListClosedWorkflowExecutionsResult{}
 }
 
-var WorkflowServiceRespondActivityTaskCanceledResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceListClosedWorkflowExecutionsResult_Success_DEFAULT *shared.ListClosedWorkflowExecutionsResponse
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) GetSuccess() *shared.ListClosedWorkflowExecutionsResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceListClosedWorkflowExecutionsResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceListClosedWorkflowExecutionsResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceRespondActivityTaskCanceledResult_BadRequestError_DEFAULT
+    return WorkflowServiceListClosedWorkflowExecutionsResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceRespondActivityTaskCanceledResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceListClosedWorkflowExecutionsResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceRespondActivityTaskCanceledResult_InternalServiceError_DEFAULT
+    return WorkflowServiceListClosedWorkflowExecutionsResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceRespondActivityTaskCanceledResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceListClosedWorkflowExecutionsResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceRespondActivityTaskCanceledResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceListClosedWorkflowExecutionsResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6436,6 +12037,10 @@ func (p *WorkflowServiceRespondActivityTaskCanceledResult) Read(iprot thrift.TPr
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -6463,7 +12068,15 @@ func (p *WorkflowServiceRespondActivityTaskCanceledResult) Read(iprot thrift.TPr
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.ListClosedWorkflowExecutionsResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -6471,7 +12084,7 @@ func (p *WorkflowServiceRespondActivityTaskCanceledResult)  ReadField1(iprot thr
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -6479,7 +12092,7 @@ func (p *WorkflowServiceRespondActivityTaskCanceledResult)  ReadField2(iprot thr
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -6487,10 +12100,11 @@ func (p *WorkflowServiceRespondActivityTaskCanceledResult)  ReadField3(iprot thr
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCanceled_result"); err != nil {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListClosedWorkflowExecutions_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -6502,7 +12116,20 @@ func (p *WorkflowServiceRespondActivityTaskCanceledResult) Write(oprot thrift.TP
   return nil
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -6515,7 +12142,7 @@ func (p *WorkflowServiceRespondActivityTaskCanceledResult) writeField1(oprot thr
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -6528,7 +12155,7 @@ func (p *WorkflowServiceRespondActivityTaskCanceledResult) writeField2(oprot thr
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -6541,35 +12168,35 @@ func (p *WorkflowServiceRespondActivityTaskCanceledResult) writeField3(oprot thr
   return err
 }
 
-func (p *WorkflowServiceRespondActivityTaskCanceledResult) String() string {
+func (p *WorkflowServiceListClosedWorkflowExecutionsResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRespondActivityTaskCanceledResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceListClosedWorkflowExecutionsResult(%+v)", *p)
 }
 
 // Attributes:
-//  - CancelRequest
-type WorkflowServiceRequestCancelWorkflowExecutionArgs struct {
-  CancelRequest *shared.RequestCancelWorkflowExecutionRequest `thrift:"cancelRequest,1" db:"cancelRequest" json:"cancelRequest"`
+//  - ListRequest
+type WorkflowServiceListClosedWorkflowExecutionsSinceArgs struct {
+  ListRequest *shared.ListClosedWorkflowExecutionsSinceRequest `thrift:"listRequest,1" db:"listRequest" json:"listRequest"`
 }
 
-func NewWorkflowServiceRequestCancelWorkflowExecutionArgs() *WorkflowServiceRequestCancelWorkflowExecutionArgs {
-  return &WorkflowServiceRequestCancelWorkflowExecutionArgs{}
+func NewWorkflowServiceListClosedWorkflowExecutionsSinceArgs() *WorkflowServiceListClosedWorkflowExecutionsSinceArgs {
+  return &WorkflowServiceListClosedWorkflowExecutionsSinceArgs{}
 }
 
-var WorkflowServiceRequestCancelWorkflowExecutionArgs_CancelRequest_DEFAULT *shared.RequestCancelWorkflowExecutionRequest
-func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) GetCancelRequest() *shared.RequestCancelWorkflowExecutionRequest {
-  if !p.IsSetCancelRequest() {
-    return WorkflowServiceRequestCancelWorkflowExecutionArgs_CancelRequest_DEFAULT
+var WorkflowServiceListClosedWorkflowExecutionsSinceArgs_ListRequest_DEFAULT *shared.ListClosedWorkflowExecutionsSinceRequest
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceArgs) GetListRequest() *shared.ListClosedWorkflowExecutionsSinceRequest {
+  if !p.IsSetListRequest() {
+    return WorkflowServiceListClosedWorkflowExecutionsSinceArgs_ListRequest_DEFAULT
   }
-return p.CancelRequest
+return p.ListRequest
 }
-func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) IsSetCancelRequest() bool {
-  return p.CancelRequest != nil
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceArgs) IsSetListRequest() bool {
+  return p.ListRequest != nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6601,16 +12228,16 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) Read(iprot thrift.TP
   return nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.CancelRequest = &shared.RequestCancelWorkflowExecutionRequest{}
-  if err := p.CancelRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CancelRequest), err)
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.ListRequest = &shared.ListClosedWorkflowExecutionsSinceRequest{}
+  if err := p.ListRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ListRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecution_args"); err != nil {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListClosedWorkflowExecutionsSince_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -6622,72 +12249,85 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) Write(oprot thrift.T
   return nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("cancelRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:cancelRequest: ", p), err) }
-  if err := p.CancelRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CancelRequest), err)
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("listRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:listRequest: ", p), err) }
+  if err := p.ListRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ListRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:cancelRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:listRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionArgs) String() string {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRequestCancelWorkflowExecutionArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceListClosedWorkflowExecutionsSinceArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceRequestCancelWorkflowExecutionResult struct {
+type WorkflowServiceListClosedWorkflowExecutionsSinceResult struct {
+  Success *shared.ListClosedWorkflowExecutionsSinceResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceRequestCancelWorkflowExecutionResult() *WorkflowServiceRequestCancelWorkflowExecutionResult {
-  return &WorkflowServiceRequestCancelWorkflowExecutionResult{}
+func NewWorkflowServiceListClosedWorkflowExecutionsSinceResult() *WorkflowServiceListClosedWorkflowExecutionsSinceResult {
+  return &WorkflowServiceListClosedWorkflowExecutionsSinceResult{}
 }
 
-var WorkflowServiceRequestCancelWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceListClosedWorkflowExecutionsSinceResult_Success_DEFAULT *shared.ListClosedWorkflowExecutionsSinceResponse
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) GetSuccess() *shared.ListClosedWorkflowExecutionsSinceResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceListClosedWorkflowExecutionsSinceResult_Success_DEFAULT
+  }
+return p.Success
+}
+var WorkflowServiceListClosedWorkflowExecutionsSinceResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceRequestCancelWorkflowExecutionResult_BadRequestError_DEFAULT
+    return WorkflowServiceListClosedWorkflowExecutionsSinceResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceRequestCancelWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceListClosedWorkflowExecutionsSinceResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceRequestCancelWorkflowExecutionResult_InternalServiceError_DEFAULT
+    return WorkflowServiceListClosedWorkflowExecutionsSinceResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceRequestCancelWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceListClosedWorkflowExecutionsSinceResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceRequestCancelWorkflowExecutionResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceListClosedWorkflowExecutionsSinceResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6700,6 +12340,10 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) Read(iprot thrift.
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -6727,7 +12371,15 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) Read(iprot thrift.
   return nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.ListClosedWorkflowExecutionsSinceResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -6735,7 +12387,7 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionResult)  ReadField1(iprot
   return nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -6743,7 +12395,7 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionResult)  ReadField2(iprot
   return nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -6751,10 +12403,11 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionResult)  ReadField3(iprot
   return nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecution_result"); err != nil {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListClosedWorkflowExecutionsSince_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -6766,7 +12419,20 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) Write(oprot thrift
   return nil
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -6779,7 +12445,7 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) writeField1(oprot
   return err
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -6792,7 +12458,7 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) writeField2(oprot
   return err
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -6805,35 +12471,21 @@ func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) writeField3(oprot
   return err
 }
 
-func (p *WorkflowServiceRequestCancelWorkflowExecutionResult) String() string {
+func (p *WorkflowServiceListClosedWorkflowExecutionsSinceResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceRequestCancelWorkflowExecutionResult(%+v)", *p)
-}
-
-// Attributes:
-//  - SignalRequest
-type WorkflowServiceSignalWorkflowExecutionArgs struct {
-  SignalRequest *shared.SignalWorkflowExecutionRequest `thrift:"signalRequest,1" db:"signalRequest" json:"signalRequest"`
+  return fmt.Sprintf("WorkflowServiceListClosedWorkflowExecutionsSinceResult(%+v)", *p)
 }
 
-func NewWorkflowServiceSignalWorkflowExecutionArgs() *WorkflowServiceSignalWorkflowExecutionArgs {
-  return &WorkflowServiceSignalWorkflowExecutionArgs{}
+type WorkflowServiceDescribeClusterArgs struct {
 }
 
-var WorkflowServiceSignalWorkflowExecutionArgs_SignalRequest_DEFAULT *shared.SignalWorkflowExecutionRequest
-func (p *WorkflowServiceSignalWorkflowExecutionArgs) GetSignalRequest() *shared.SignalWorkflowExecutionRequest {
-  if !p.IsSetSignalRequest() {
-    return WorkflowServiceSignalWorkflowExecutionArgs_SignalRequest_DEFAULT
-  }
-return p.SignalRequest
-}
-func (p *WorkflowServiceSignalWorkflowExecutionArgs) IsSetSignalRequest() bool {
-  return p.SignalRequest != nil
+func NewWorkflowServiceDescribeClusterArgs() *WorkflowServiceDescribeClusterArgs {
+  return &WorkflowServiceDescribeClusterArgs{}
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceDescribeClusterArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6845,15 +12497,8 @@ func (p *WorkflowServiceSignalWorkflowExecutionArgs) Read(iprot thrift.TProtocol
       return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
     if fieldTypeId == thrift.STOP { break; }
-    switch fieldId {
-    case 1:
-      if err := p.ReadField1(iprot); err != nil {
-        return err
-      }
-    default:
-      if err := iprot.Skip(fieldTypeId); err != nil {
-        return err
-      }
+    if err := iprot.Skip(fieldTypeId); err != nil {
+      return err
     }
     if err := iprot.ReadFieldEnd(); err != nil {
       return err
@@ -6865,19 +12510,10 @@ func (p *WorkflowServiceSignalWorkflowExecutionArgs) Read(iprot thrift.TProtocol
   return nil
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.SignalRequest = &shared.SignalWorkflowExecutionRequest{}
-  if err := p.SignalRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SignalRequest), err)
-  }
-  return nil
-}
-
-func (p *WorkflowServiceSignalWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("SignalWorkflowExecution_args"); err != nil {
+func (p *WorkflowServiceDescribeClusterArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeCluster_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
-    if err := p.writeField1(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -6886,72 +12522,48 @@ func (p *WorkflowServiceSignalWorkflowExecutionArgs) Write(oprot thrift.TProtoco
   return nil
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("signalRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:signalRequest: ", p), err) }
-  if err := p.SignalRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SignalRequest), err)
-  }
-  if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:signalRequest: ", p), err) }
-  return err
-}
-
-func (p *WorkflowServiceSignalWorkflowExecutionArgs) String() string {
+func (p *WorkflowServiceDescribeClusterArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceSignalWorkflowExecutionArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceDescribeClusterArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - BadRequestError
+//  - Success
 //  - InternalServiceError
-//  - EntityNotExistError
-type WorkflowServiceSignalWorkflowExecutionResult struct {
-  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
-  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+type WorkflowServiceDescribeClusterResult struct {
+  Success *shared.DescribeClusterResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,1" db:"internalServiceError" json:"internalServiceError,omitempty"`
 }
 
-func NewWorkflowServiceSignalWorkflowExecutionResult() *WorkflowServiceSignalWorkflowExecutionResult {
-  return &WorkflowServiceSignalWorkflowExecutionResult{}
+func NewWorkflowServiceDescribeClusterResult() *WorkflowServiceDescribeClusterResult {
+  return &WorkflowServiceDescribeClusterResult{}
 }
 
-var WorkflowServiceSignalWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceSignalWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
-  if !p.IsSetBadRequestError() {
-    return WorkflowServiceSignalWorkflowExecutionResult_BadRequestError_DEFAULT
+var WorkflowServiceDescribeClusterResult_Success_DEFAULT *shared.DescribeClusterResponse
+func (p *WorkflowServiceDescribeClusterResult) GetSuccess() *shared.DescribeClusterResponse {
+  if !p.IsSetSuccess() {
+    return WorkflowServiceDescribeClusterResult_Success_DEFAULT
   }
-return p.BadRequestError
+return p.Success
 }
-var WorkflowServiceSignalWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceSignalWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceDescribeClusterResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceDescribeClusterResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceSignalWorkflowExecutionResult_InternalServiceError_DEFAULT
+    return WorkflowServiceDescribeClusterResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceSignalWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceSignalWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
-  if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceSignalWorkflowExecutionResult_EntityNotExistError_DEFAULT
-  }
-return p.EntityNotExistError
-}
-func (p *WorkflowServiceSignalWorkflowExecutionResult) IsSetBadRequestError() bool {
-  return p.BadRequestError != nil
+func (p *WorkflowServiceDescribeClusterResult) IsSetSuccess() bool {
+  return p.Success != nil
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceDescribeClusterResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionResult) IsSetEntityNotExistError() bool {
-  return p.EntityNotExistError != nil
-}
-
-func (p *WorkflowServiceSignalWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceDescribeClusterResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6964,16 +12576,12 @@ func (p *WorkflowServiceSignalWorkflowExecutionResult) Read(iprot thrift.TProtoc
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
-    case 1:
-      if err := p.ReadField1(iprot); err != nil {
-        return err
-      }
-    case 2:
-      if err := p.ReadField2(iprot); err != nil {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
         return err
       }
-    case 3:
-      if err := p.ReadField3(iprot); err != nil {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
         return err
       }
     default:
@@ -6991,15 +12599,15 @@ func (p *WorkflowServiceSignalWorkflowExecutionResult) Read(iprot thrift.TProtoc
   return nil
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
-  p.BadRequestError = &shared.BadRequestError{}
-  if err := p.BadRequestError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+func (p *WorkflowServiceDescribeClusterResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.DescribeClusterResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceDescribeClusterResult)  ReadField1(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -7007,21 +12615,12 @@ func (p *WorkflowServiceSignalWorkflowExecutionResult)  ReadField2(iprot thrift.
   return nil
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.EntityNotExistError = &shared.EntityNotExistsError{}
-  if err := p.EntityNotExistError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
-  }
-  return nil
-}
-
-func (p *WorkflowServiceSignalWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("SignalWorkflowExecution_result"); err != nil {
+func (p *WorkflowServiceDescribeClusterResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeCluster_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
-    if err := p.writeField2(oprot); err != nil { return err }
-    if err := p.writeField3(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -7030,74 +12629,61 @@ func (p *WorkflowServiceSignalWorkflowExecutionResult) Write(oprot thrift.TProto
   return nil
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
-  if p.IsSetBadRequestError() {
-    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
-    if err := p.BadRequestError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+func (p *WorkflowServiceDescribeClusterResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
   }
   return err
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceDescribeClusterResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
-    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:internalServiceError: ", p), err) }
     if err := p.InternalServiceError.Write(oprot); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
-  }
-  return err
-}
-
-func (p *WorkflowServiceSignalWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetEntityNotExistError() {
-    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
-    if err := p.EntityNotExistError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:internalServiceError: ", p), err) }
   }
   return err
 }
 
-func (p *WorkflowServiceSignalWorkflowExecutionResult) String() string {
+func (p *WorkflowServiceDescribeClusterResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceSignalWorkflowExecutionResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceDescribeClusterResult(%+v)", *p)
 }
 
 // Attributes:
-//  - TerminateRequest
-type WorkflowServiceTerminateWorkflowExecutionArgs struct {
-  TerminateRequest *shared.TerminateWorkflowExecutionRequest `thrift:"terminateRequest,1" db:"terminateRequest" json:"terminateRequest"`
+//  - RefreshRequest
+type WorkflowServiceRefreshWorkflowTasksArgs struct {
+  RefreshRequest *shared.RefreshWorkflowTasksRequest `thrift:"refreshRequest,1" db:"refreshRequest" json:"refreshRequest"`
 }
 
-func NewWorkflowServiceTerminateWorkflowExecutionArgs() *WorkflowServiceTerminateWorkflowExecutionArgs {
-  return &WorkflowServiceTerminateWorkflowExecutionArgs{}
+func NewWorkflowServiceRefreshWorkflowTasksArgs() *WorkflowServiceRefreshWorkflowTasksArgs {
+  return &WorkflowServiceRefreshWorkflowTasksArgs{}
 }
 
-var WorkflowServiceTerminateWorkflowExecutionArgs_TerminateRequest_DEFAULT *shared.TerminateWorkflowExecutionRequest
-func (p *WorkflowServiceTerminateWorkflowExecutionArgs) GetTerminateRequest() *shared.TerminateWorkflowExecutionRequest {
-  if !p.IsSetTerminateRequest() {
-    return WorkflowServiceTerminateWorkflowExecutionArgs_TerminateRequest_DEFAULT
+var WorkflowServiceRefreshWorkflowTasksArgs_RefreshRequest_DEFAULT *shared.RefreshWorkflowTasksRequest
+func (p *WorkflowServiceRefreshWorkflowTasksArgs) GetRefreshRequest() *shared.RefreshWorkflowTasksRequest {
+  if !p.IsSetRefreshRequest() {
+    return WorkflowServiceRefreshWorkflowTasksArgs_RefreshRequest_DEFAULT
   }
-return p.TerminateRequest
+return p.RefreshRequest
 }
-func (p *WorkflowServiceTerminateWorkflowExecutionArgs) IsSetTerminateRequest() bool {
-  return p.TerminateRequest != nil
+func (p *WorkflowServiceRefreshWorkflowTasksArgs) IsSetRefreshRequest() bool {
+  return p.RefreshRequest != nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRefreshWorkflowTasksArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7129,16 +12715,16 @@ func (p *WorkflowServiceTerminateWorkflowExecutionArgs) Read(iprot thrift.TProto
   return nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.TerminateRequest = &shared.TerminateWorkflowExecutionRequest{}
-  if err := p.TerminateRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.TerminateRequest), err)
+func (p *WorkflowServiceRefreshWorkflowTasksArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.RefreshRequest = &shared.RefreshWorkflowTasksRequest{}
+  if err := p.RefreshRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.RefreshRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("TerminateWorkflowExecution_args"); err != nil {
+func (p *WorkflowServiceRefreshWorkflowTasksArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RefreshWorkflowTasks_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -7150,72 +12736,72 @@ func (p *WorkflowServiceTerminateWorkflowExecutionArgs) Write(oprot thrift.TProt
   return nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("terminateRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:terminateRequest: ", p), err) }
-  if err := p.TerminateRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.TerminateRequest), err)
+func (p *WorkflowServiceRefreshWorkflowTasksArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("refreshRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:refreshRequest: ", p), err) }
+  if err := p.RefreshRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.RefreshRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:terminateRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:refreshRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionArgs) String() string {
+func (p *WorkflowServiceRefreshWorkflowTasksArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceTerminateWorkflowExecutionArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRefreshWorkflowTasksArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceTerminateWorkflowExecutionResult struct {
+type WorkflowServiceRefreshWorkflowTasksResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceTerminateWorkflowExecutionResult() *WorkflowServiceTerminateWorkflowExecutionResult {
-  return &WorkflowServiceTerminateWorkflowExecutionResult{}
+func NewWorkflowServiceRefreshWorkflowTasksResult() *WorkflowServiceRefreshWorkflowTasksResult {
+  return &WorkflowServiceRefreshWorkflowTasksResult{}
 }
 
-var WorkflowServiceTerminateWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceRefreshWorkflowTasksResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceRefreshWorkflowTasksResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceTerminateWorkflowExecutionResult_BadRequestError_DEFAULT
+    return WorkflowServiceRefreshWorkflowTasksResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceTerminateWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceRefreshWorkflowTasksResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceRefreshWorkflowTasksResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceTerminateWorkflowExecutionResult_InternalServiceError_DEFAULT
+    return WorkflowServiceRefreshWorkflowTasksResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceTerminateWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceRefreshWorkflowTasksResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceRefreshWorkflowTasksResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceTerminateWorkflowExecutionResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceRefreshWorkflowTasksResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceRefreshWorkflowTasksResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceRefreshWorkflowTasksResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceRefreshWorkflowTasksResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRefreshWorkflowTasksResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7255,7 +12841,7 @@ func (p *WorkflowServiceTerminateWorkflowExecutionResult) Read(iprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRefreshWorkflowTasksResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -7263,7 +12849,7 @@ func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField1(iprot thri
   return nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRefreshWorkflowTasksResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -7271,7 +12857,7 @@ func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField2(iprot thri
   return nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceRefreshWorkflowTasksResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -7279,8 +12865,8 @@ func (p *WorkflowServiceTerminateWorkflowExecutionResult)  ReadField3(iprot thri
   return nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("TerminateWorkflowExecution_result"); err != nil {
+func (p *WorkflowServiceRefreshWorkflowTasksResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RefreshWorkflowTasks_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -7294,7 +12880,7 @@ func (p *WorkflowServiceTerminateWorkflowExecutionResult) Write(oprot thrift.TPr
   return nil
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRefreshWorkflowTasksResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -7307,7 +12893,7 @@ func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField1(oprot thri
   return err
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRefreshWorkflowTasksResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -7320,7 +12906,7 @@ func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField2(oprot thri
   return err
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceRefreshWorkflowTasksResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -7333,35 +12919,35 @@ func (p *WorkflowServiceTerminateWorkflowExecutionResult) writeField3(oprot thri
   return err
 }
 
-func (p *WorkflowServiceTerminateWorkflowExecutionResult) String() string {
+func (p *WorkflowServiceRefreshWorkflowTasksResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceTerminateWorkflowExecutionResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceRefreshWorkflowTasksResult(%+v)", *p)
 }
 
 // Attributes:
-//  - ListRequest
-type WorkflowServiceListOpenWorkflowExecutionsArgs struct {
-  ListRequest *shared.ListOpenWorkflowExecutionsRequest `thrift:"listRequest,1" db:"listRequest" json:"listRequest"`
+//  - MigrateRequest
+type WorkflowServiceMigrateWorkflowExecutionArgs struct {
+  MigrateRequest *shared.MigrateWorkflowExecutionRequest `thrift:"migrateRequest,1" db:"migrateRequest" json:"migrateRequest"`
 }
 
-func NewWorkflowServiceListOpenWorkflowExecutionsArgs() *WorkflowServiceListOpenWorkflowExecutionsArgs {
-  return &WorkflowServiceListOpenWorkflowExecutionsArgs{}
+func NewWorkflowServiceMigrateWorkflowExecutionArgs() *WorkflowServiceMigrateWorkflowExecutionArgs {
+  return &WorkflowServiceMigrateWorkflowExecutionArgs{}
 }
 
-var WorkflowServiceListOpenWorkflowExecutionsArgs_ListRequest_DEFAULT *shared.ListOpenWorkflowExecutionsRequest
-func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) GetListRequest() *shared.ListOpenWorkflowExecutionsRequest {
-  if !p.IsSetListRequest() {
-    return WorkflowServiceListOpenWorkflowExecutionsArgs_ListRequest_DEFAULT
+var WorkflowServiceMigrateWorkflowExecutionArgs_MigrateRequest_DEFAULT *shared.MigrateWorkflowExecutionRequest
+func (p *WorkflowServiceMigrateWorkflowExecutionArgs) GetMigrateRequest() *shared.MigrateWorkflowExecutionRequest {
+  if !p.IsSetMigrateRequest() {
+    return WorkflowServiceMigrateWorkflowExecutionArgs_MigrateRequest_DEFAULT
   }
-return p.ListRequest
+return p.MigrateRequest
 }
-func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) IsSetListRequest() bool {
-  return p.ListRequest != nil
+func (p *WorkflowServiceMigrateWorkflowExecutionArgs) IsSetMigrateRequest() bool {
+  return p.MigrateRequest != nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceMigrateWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7393,16 +12979,16 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) Read(iprot thrift.TProto
   return nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.ListRequest = &shared.ListOpenWorkflowExecutionsRequest{}
-  if err := p.ListRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ListRequest), err)
+func (p *WorkflowServiceMigrateWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.MigrateRequest = &shared.MigrateWorkflowExecutionRequest{}
+  if err := p.MigrateRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.MigrateRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("ListOpenWorkflowExecutions_args"); err != nil {
+func (p *WorkflowServiceMigrateWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("MigrateWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -7414,85 +13000,85 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) Write(oprot thrift.TProt
   return nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("listRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:listRequest: ", p), err) }
-  if err := p.ListRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ListRequest), err)
+func (p *WorkflowServiceMigrateWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("migrateRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:migrateRequest: ", p), err) }
+  if err := p.MigrateRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.MigrateRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:listRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:migrateRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsArgs) String() string {
+func (p *WorkflowServiceMigrateWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceListOpenWorkflowExecutionsArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceMigrateWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
-type WorkflowServiceListOpenWorkflowExecutionsResult struct {
-  Success *shared.ListOpenWorkflowExecutionsResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+//  - WorkflowAlreadyStartedError
+type WorkflowServiceMigrateWorkflowExecutionResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  WorkflowAlreadyStartedError *shared.WorkflowExecutionAlreadyStartedError `thrift:"workflowAlreadyStartedError,4" db:"workflowAlreadyStartedError" json:"workflowAlreadyStartedError,omitempty"`
 }
 
-func NewWorkflowServiceListOpenWorkflowExecutionsResult() *WorkflowServiceListOpenWorkflowExecutionsResult {
-  return &WorkflowServiceListOpenWorkflowExecutionsResult{}
+func NewWorkflowServiceMigrateWorkflowExecutionResult() *WorkflowServiceMigrateWorkflowExecutionResult {
+  return &WorkflowServiceMigrateWorkflowExecutionResult{}
 }
 
-var WorkflowServiceListOpenWorkflowExecutionsResult_Success_DEFAULT *shared.ListOpenWorkflowExecutionsResponse
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) GetSuccess() *shared.ListOpenWorkflowExecutionsResponse {
-  if !p.IsSetSuccess() {
-    return WorkflowServiceListOpenWorkflowExecutionsResult_Success_DEFAULT
-  }
-return p.Success
-}
-var WorkflowServiceListOpenWorkflowExecutionsResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceMigrateWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceListOpenWorkflowExecutionsResult_BadRequestError_DEFAULT
+    return WorkflowServiceMigrateWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceListOpenWorkflowExecutionsResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceMigrateWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceListOpenWorkflowExecutionsResult_InternalServiceError_DEFAULT
+    return WorkflowServiceMigrateWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceListOpenWorkflowExecutionsResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var WorkflowServiceMigrateWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceListOpenWorkflowExecutionsResult_EntityNotExistError_DEFAULT
+    return WorkflowServiceMigrateWorkflowExecutionResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) IsSetSuccess() bool {
-  return p.Success != nil
+var WorkflowServiceMigrateWorkflowExecutionResult_WorkflowAlreadyStartedError_DEFAULT *shared.WorkflowExecutionAlreadyStartedError
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) GetWorkflowAlreadyStartedError() *shared.WorkflowExecutionAlreadyStartedError {
+  if !p.IsSetWorkflowAlreadyStartedError() {
+    return WorkflowServiceMigrateWorkflowExecutionResult_WorkflowAlreadyStartedError_DEFAULT
+  }
+return p.WorkflowAlreadyStartedError
 }
-
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) IsSetEntityNotExistError() bool {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) IsSetWorkflowAlreadyStartedError() bool {
+  return p.WorkflowAlreadyStartedError != nil
+}
+
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7505,10 +13091,6 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult) Read(iprot thrift.TPro
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
-    case 0:
-      if err := p.ReadField0(iprot); err != nil {
-        return err
-      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -7521,6 +13103,10 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult) Read(iprot thrift.TPro
       if err := p.ReadField3(iprot); err != nil {
         return err
       }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -7536,15 +13122,7 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult) Read(iprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.ListOpenWorkflowExecutionsResponse{}
-  if err := p.Success.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-  }
-  return nil
-}
-
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -7552,7 +13130,7 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField1(iprot thri
   return nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -7560,7 +13138,7 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField2(iprot thri
   return nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -7568,14 +13146,22 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult)  ReadField3(iprot thri
   return nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("ListOpenWorkflowExecutions_result"); err != nil {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.WorkflowAlreadyStartedError = &shared.WorkflowExecutionAlreadyStartedError{}
+  if err := p.WorkflowAlreadyStartedError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowAlreadyStartedError), err)
+  }
+  return nil
+}
+
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("MigrateWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
-    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -7584,20 +13170,7 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult) Write(oprot thrift.TPr
   return nil
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField0(oprot thrift.TProtocol) (err error) {
-  if p.IsSetSuccess() {
-    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
-    if err := p.Success.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
-  }
-  return err
-}
-
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -7610,7 +13183,7 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField1(oprot thri
   return err
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -7623,7 +13196,7 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField2(oprot thri
   return err
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -7636,35 +13209,48 @@ func (p *WorkflowServiceListOpenWorkflowExecutionsResult) writeField3(oprot thri
   return err
 }
 
-func (p *WorkflowServiceListOpenWorkflowExecutionsResult) String() string {
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetWorkflowAlreadyStartedError() {
+    if err := oprot.WriteFieldBegin("workflowAlreadyStartedError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:workflowAlreadyStartedError: ", p), err) }
+    if err := p.WorkflowAlreadyStartedError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowAlreadyStartedError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:workflowAlreadyStartedError: ", p), err) }
+  }
+  return err
+}
+
+func (p *WorkflowServiceMigrateWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceListOpenWorkflowExecutionsResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceMigrateWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - ListRequest
-type WorkflowServiceListClosedWorkflowExecutionsArgs struct {
-  ListRequest *shared.ListClosedWorkflowExecutionsRequest `thrift:"listRequest,1" db:"listRequest" json:"listRequest"`
+//  - VerifyRequest
+type WorkflowServiceVerifyHistoryArgs struct {
+  VerifyRequest *shared.VerifyHistoryRequest `thrift:"verifyRequest,1" db:"verifyRequest" json:"verifyRequest"`
 }
 
-func NewWorkflowServiceListClosedWorkflowExecutionsArgs() *WorkflowServiceListClosedWorkflowExecutionsArgs {
-  return &WorkflowServiceListClosedWorkflowExecutionsArgs{}
+func NewWorkflowServiceVerifyHistoryArgs() *WorkflowServiceVerifyHistoryArgs {
+  return &WorkflowServiceVerifyHistoryArgs{}
 }
 
-var WorkflowServiceListClosedWorkflowExecutionsArgs_ListRequest_DEFAULT *shared.ListClosedWorkflowExecutionsRequest
-func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) GetListRequest() *shared.ListClosedWorkflowExecutionsRequest {
-  if !p.IsSetListRequest() {
-    return WorkflowServiceListClosedWorkflowExecutionsArgs_ListRequest_DEFAULT
+var WorkflowServiceVerifyHistoryArgs_VerifyRequest_DEFAULT *shared.VerifyHistoryRequest
+func (p *WorkflowServiceVerifyHistoryArgs) GetVerifyRequest() *shared.VerifyHistoryRequest {
+  if !p.IsSetVerifyRequest() {
+    return WorkflowServiceVerifyHistoryArgs_VerifyRequest_DEFAULT
   }
-return p.ListRequest
+return p.VerifyRequest
 }
-func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) IsSetListRequest() bool {
-  return p.ListRequest != nil
+func (p *WorkflowServiceVerifyHistoryArgs) IsSetVerifyRequest() bool {
+  return p.VerifyRequest != nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceVerifyHistoryArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7696,16 +13282,16 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) Read(iprot thrift.TPro
   return nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.ListRequest = &shared.ListClosedWorkflowExecutionsRequest{}
-  if err := p.ListRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ListRequest), err)
+func (p *WorkflowServiceVerifyHistoryArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.VerifyRequest = &shared.VerifyHistoryRequest{}
+  if err := p.VerifyRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.VerifyRequest), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("ListClosedWorkflowExecutions_args"); err != nil {
+func (p *WorkflowServiceVerifyHistoryArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("VerifyHistory_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -7717,85 +13303,72 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) Write(oprot thrift.TPr
   return nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("listRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:listRequest: ", p), err) }
-  if err := p.ListRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ListRequest), err)
+func (p *WorkflowServiceVerifyHistoryArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("verifyRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:verifyRequest: ", p), err) }
+  if err := p.VerifyRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.VerifyRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:listRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:verifyRequest: ", p), err) }
   return err
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsArgs) String() string {
+func (p *WorkflowServiceVerifyHistoryArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceListClosedWorkflowExecutionsArgs(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceVerifyHistoryArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - BadRequestError
 //  - InternalServiceError
-//  - EntityNotExistError
-type WorkflowServiceListClosedWorkflowExecutionsResult struct {
-  Success *shared.ListClosedWorkflowExecutionsResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type WorkflowServiceVerifyHistoryResult struct {
+  Success *shared.VerifyHistoryResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
 }
 
-func NewWorkflowServiceListClosedWorkflowExecutionsResult() *WorkflowServiceListClosedWorkflowExecutionsResult {
-  return &WorkflowServiceListClosedWorkflowExecutionsResult{}
+func NewWorkflowServiceVerifyHistoryResult() *WorkflowServiceVerifyHistoryResult {
+  return &WorkflowServiceVerifyHistoryResult{}
 }
 
-var WorkflowServiceListClosedWorkflowExecutionsResult_Success_DEFAULT *shared.ListClosedWorkflowExecutionsResponse
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) GetSuccess() *shared.ListClosedWorkflowExecutionsResponse {
+var WorkflowServiceVerifyHistoryResult_Success_DEFAULT *shared.VerifyHistoryResponse
+func (p *WorkflowServiceVerifyHistoryResult) GetSuccess() *shared.VerifyHistoryResponse {
   if !p.IsSetSuccess() {
-    return WorkflowServiceListClosedWorkflowExecutionsResult_Success_DEFAULT
+    return WorkflowServiceVerifyHistoryResult_Success_DEFAULT
   }
 return p.Success
 }
-var WorkflowServiceListClosedWorkflowExecutionsResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) GetBadRequestError() *shared.BadRequestError {
+var WorkflowServiceVerifyHistoryResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *WorkflowServiceVerifyHistoryResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return WorkflowServiceListClosedWorkflowExecutionsResult_BadRequestError_DEFAULT
+    return WorkflowServiceVerifyHistoryResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var WorkflowServiceListClosedWorkflowExecutionsResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) GetInternalServiceError() *shared.InternalServiceError {
+var WorkflowServiceVerifyHistoryResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *WorkflowServiceVerifyHistoryResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return WorkflowServiceListClosedWorkflowExecutionsResult_InternalServiceError_DEFAULT
+    return WorkflowServiceVerifyHistoryResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var WorkflowServiceListClosedWorkflowExecutionsResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) GetEntityNotExistError() *shared.EntityNotExistsError {
-  if !p.IsSetEntityNotExistError() {
-    return WorkflowServiceListClosedWorkflowExecutionsResult_EntityNotExistError_DEFAULT
-  }
-return p.EntityNotExistError
-}
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) IsSetSuccess() bool {
+func (p *WorkflowServiceVerifyHistoryResult) IsSetSuccess() bool {
   return p.Success != nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) IsSetBadRequestError() bool {
+func (p *WorkflowServiceVerifyHistoryResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) IsSetInternalServiceError() bool {
+func (p *WorkflowServiceVerifyHistoryResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) IsSetEntityNotExistError() bool {
-  return p.EntityNotExistError != nil
-}
-
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) Read(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceVerifyHistoryResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7820,10 +13393,6 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsResult) Read(iprot thrift.TP
       if err := p.ReadField2(iprot); err != nil {
         return err
       }
-    case 3:
-      if err := p.ReadField3(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -7839,15 +13408,15 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsResult) Read(iprot thrift.TP
   return nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.ListClosedWorkflowExecutionsResponse{}
+func (p *WorkflowServiceVerifyHistoryResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.VerifyHistoryResponse{}
   if err := p.Success.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
   return nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceVerifyHistoryResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -7855,7 +13424,7 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField1(iprot th
   return nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *WorkflowServiceVerifyHistoryResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -7863,22 +13432,13 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField2(iprot th
   return nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.EntityNotExistError = &shared.EntityNotExistsError{}
-  if err := p.EntityNotExistError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
-  }
-  return nil
-}
-
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("ListClosedWorkflowExecutions_result"); err != nil {
+func (p *WorkflowServiceVerifyHistoryResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("VerifyHistory_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
-    if err := p.writeField3(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -7887,7 +13447,7 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsResult) Write(oprot thrift.T
   return nil
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceVerifyHistoryResult) writeField0(oprot thrift.TProtocol) (err error) {
   if p.IsSetSuccess() {
     if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
@@ -7900,7 +13460,7 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField0(oprot th
   return err
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceVerifyHistoryResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -7913,7 +13473,7 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField1(oprot th
   return err
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *WorkflowServiceVerifyHistoryResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -7926,24 +13486,11 @@ func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField2(oprot th
   return err
 }
 
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetEntityNotExistError() {
-    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
-    if err := p.EntityNotExistError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
-  }
-  return err
-}
-
-func (p *WorkflowServiceListClosedWorkflowExecutionsResult) String() string {
+func (p *WorkflowServiceVerifyHistoryResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("WorkflowServiceListClosedWorkflowExecutionsResult(%+v)", *p)
+  return fmt.Sprintf("WorkflowServiceVerifyHistoryResult(%+v)", *p)
 }
 
 
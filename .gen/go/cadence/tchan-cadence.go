@@ -39,23 +39,35 @@ var _ = shared.GoUnusedProtection__
 // TChanWorkflowService is the interface that defines the server handler and client interface.
 type TChanWorkflowService interface {
 	DeprecateDomain(ctx thrift.Context, deprecateRequest *shared.DeprecateDomainRequest) error
+	DescribeCluster(ctx thrift.Context) (*shared.DescribeClusterResponse, error)
 	DescribeDomain(ctx thrift.Context, describeRequest *shared.DescribeDomainRequest) (*shared.DescribeDomainResponse, error)
+	DescribeWorkflowExecution(ctx thrift.Context, describeRequest *shared.DescribeWorkflowExecutionRequest) (*shared.DescribeWorkflowExecutionResponse, error)
+	DrainDomain(ctx thrift.Context, drainRequest *shared.DrainDomainRequest) error
+	GetSignalReceipt(ctx thrift.Context, receiptRequest *shared.GetSignalReceiptRequest) (*shared.GetSignalReceiptResponse, error)
 	GetWorkflowExecutionHistory(ctx thrift.Context, getRequest *shared.GetWorkflowExecutionHistoryRequest) (*shared.GetWorkflowExecutionHistoryResponse, error)
 	ListClosedWorkflowExecutions(ctx thrift.Context, listRequest *shared.ListClosedWorkflowExecutionsRequest) (*shared.ListClosedWorkflowExecutionsResponse, error)
+	ListClosedWorkflowExecutionsSince(ctx thrift.Context, listRequest *shared.ListClosedWorkflowExecutionsSinceRequest) (*shared.ListClosedWorkflowExecutionsSinceResponse, error)
 	ListOpenWorkflowExecutions(ctx thrift.Context, listRequest *shared.ListOpenWorkflowExecutionsRequest) (*shared.ListOpenWorkflowExecutionsResponse, error)
+	MigrateWorkflowExecution(ctx thrift.Context, migrateRequest *shared.MigrateWorkflowExecutionRequest) error
 	PollForActivityTask(ctx thrift.Context, pollRequest *shared.PollForActivityTaskRequest) (*shared.PollForActivityTaskResponse, error)
 	PollForDecisionTask(ctx thrift.Context, pollRequest *shared.PollForDecisionTaskRequest) (*shared.PollForDecisionTaskResponse, error)
+	QueryWorkflow(ctx thrift.Context, queryRequest *shared.QueryWorkflowRequest) (*shared.QueryWorkflowResponse, error)
 	RecordActivityTaskHeartbeat(ctx thrift.Context, heartbeatRequest *shared.RecordActivityTaskHeartbeatRequest) (*shared.RecordActivityTaskHeartbeatResponse, error)
+	RefreshWorkflowTasks(ctx thrift.Context, refreshRequest *shared.RefreshWorkflowTasksRequest) error
 	RegisterDomain(ctx thrift.Context, registerRequest *shared.RegisterDomainRequest) error
 	RequestCancelWorkflowExecution(ctx thrift.Context, cancelRequest *shared.RequestCancelWorkflowExecutionRequest) error
 	RespondActivityTaskCanceled(ctx thrift.Context, canceledRequest *shared.RespondActivityTaskCanceledRequest) error
+	RespondActivityTaskCanceledByID(ctx thrift.Context, canceledRequest *shared.RespondActivityTaskCanceledByIDRequest) error
 	RespondActivityTaskCompleted(ctx thrift.Context, completeRequest *shared.RespondActivityTaskCompletedRequest) error
+	RespondActivityTaskCompletedByID(ctx thrift.Context, completeRequest *shared.RespondActivityTaskCompletedByIDRequest) error
 	RespondActivityTaskFailed(ctx thrift.Context, failRequest *shared.RespondActivityTaskFailedRequest) error
-	RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *shared.RespondDecisionTaskCompletedRequest) error
-	SignalWorkflowExecution(ctx thrift.Context, signalRequest *shared.SignalWorkflowExecutionRequest) error
+	RespondActivityTaskFailedByID(ctx thrift.Context, failedRequest *shared.RespondActivityTaskFailedByIDRequest) error
+	RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *shared.RespondDecisionTaskCompletedRequest) (*shared.RespondDecisionTaskCompletedResponse, error)
+	SignalWorkflowExecution(ctx thrift.Context, signalRequest *shared.SignalWorkflowExecutionRequest) (*shared.SignalWorkflowExecutionResponse, error)
 	StartWorkflowExecution(ctx thrift.Context, startRequest *shared.StartWorkflowExecutionRequest) (*shared.StartWorkflowExecutionResponse, error)
-	TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *shared.TerminateWorkflowExecutionRequest) error
+	TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *shared.TerminateWorkflowExecutionRequest) (*shared.TerminateWorkflowExecutionResponse, error)
 	UpdateDomain(ctx thrift.Context, updateRequest *shared.UpdateDomainRequest) (*shared.UpdateDomainResponse, error)
+	VerifyHistory(ctx thrift.Context, verifyRequest *shared.VerifyHistoryRequest) (*shared.VerifyHistoryResponse, error)
 }
 
 // Implementation of a client and service handler.
@@ -99,6 +111,22 @@ func (c *tchanWorkflowServiceClient) DeprecateDomain(ctx thrift.Context, depreca
 	return err
 }
 
+func (c *tchanWorkflowServiceClient) DescribeCluster(ctx thrift.Context) (*shared.DescribeClusterResponse, error) {
+	var resp WorkflowServiceDescribeClusterResult
+	args := WorkflowServiceDescribeClusterArgs{}
+	success, err := c.client.Call(ctx, c.thriftService, "DescribeCluster", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for DescribeCluster")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanWorkflowServiceClient) DescribeDomain(ctx thrift.Context, describeRequest *shared.DescribeDomainRequest) (*shared.DescribeDomainResponse, error) {
 	var resp WorkflowServiceDescribeDomainResult
 	args := WorkflowServiceDescribeDomainArgs{
@@ -121,6 +149,72 @@ func (c *tchanWorkflowServiceClient) DescribeDomain(ctx thrift.Context, describe
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanWorkflowServiceClient) DescribeWorkflowExecution(ctx thrift.Context, describeRequest *shared.DescribeWorkflowExecutionRequest) (*shared.DescribeWorkflowExecutionResponse, error) {
+	var resp WorkflowServiceDescribeWorkflowExecutionResult
+	args := WorkflowServiceDescribeWorkflowExecutionArgs{
+		DescribeRequest: describeRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "DescribeWorkflowExecution", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for DescribeWorkflowExecution")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
+func (c *tchanWorkflowServiceClient) DrainDomain(ctx thrift.Context, drainRequest *shared.DrainDomainRequest) error {
+	var resp WorkflowServiceDrainDomainResult
+	args := WorkflowServiceDrainDomainArgs{
+		DrainRequest: drainRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "DrainDomain", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for DrainDomain")
+		}
+	}
+
+	return err
+}
+
+func (c *tchanWorkflowServiceClient) GetSignalReceipt(ctx thrift.Context, receiptRequest *shared.GetSignalReceiptRequest) (*shared.GetSignalReceiptResponse, error) {
+	var resp WorkflowServiceGetSignalReceiptResult
+	args := WorkflowServiceGetSignalReceiptArgs{
+		ReceiptRequest: receiptRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "GetSignalReceipt", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for GetSignalReceipt")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanWorkflowServiceClient) GetWorkflowExecutionHistory(ctx thrift.Context, getRequest *shared.GetWorkflowExecutionHistoryRequest) (*shared.GetWorkflowExecutionHistoryResponse, error) {
 	var resp WorkflowServiceGetWorkflowExecutionHistoryResult
 	args := WorkflowServiceGetWorkflowExecutionHistoryArgs{
@@ -165,6 +259,28 @@ func (c *tchanWorkflowServiceClient) ListClosedWorkflowExecutions(ctx thrift.Con
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanWorkflowServiceClient) ListClosedWorkflowExecutionsSince(ctx thrift.Context, listRequest *shared.ListClosedWorkflowExecutionsSinceRequest) (*shared.ListClosedWorkflowExecutionsSinceResponse, error) {
+	var resp WorkflowServiceListClosedWorkflowExecutionsSinceResult
+	args := WorkflowServiceListClosedWorkflowExecutionsSinceArgs{
+		ListRequest: listRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "ListClosedWorkflowExecutionsSince", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for ListClosedWorkflowExecutionsSince")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanWorkflowServiceClient) ListOpenWorkflowExecutions(ctx thrift.Context, listRequest *shared.ListOpenWorkflowExecutionsRequest) (*shared.ListOpenWorkflowExecutionsResponse, error) {
 	var resp WorkflowServiceListOpenWorkflowExecutionsResult
 	args := WorkflowServiceListOpenWorkflowExecutionsArgs{
@@ -187,6 +303,30 @@ func (c *tchanWorkflowServiceClient) ListOpenWorkflowExecutions(ctx thrift.Conte
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanWorkflowServiceClient) MigrateWorkflowExecution(ctx thrift.Context, migrateRequest *shared.MigrateWorkflowExecutionRequest) error {
+	var resp WorkflowServiceMigrateWorkflowExecutionResult
+	args := WorkflowServiceMigrateWorkflowExecutionArgs{
+		MigrateRequest: migrateRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "MigrateWorkflowExecution", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		case resp.WorkflowAlreadyStartedError != nil:
+			err = resp.WorkflowAlreadyStartedError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for MigrateWorkflowExecution")
+		}
+	}
+
+	return err
+}
+
 func (c *tchanWorkflowServiceClient) PollForActivityTask(ctx thrift.Context, pollRequest *shared.PollForActivityTaskRequest) (*shared.PollForActivityTaskResponse, error) {
 	var resp WorkflowServicePollForActivityTaskResult
 	args := WorkflowServicePollForActivityTaskArgs{
@@ -199,6 +339,8 @@ func (c *tchanWorkflowServiceClient) PollForActivityTask(ctx thrift.Context, pol
 			err = resp.BadRequestError
 		case resp.InternalServiceError != nil:
 			err = resp.InternalServiceError
+		case resp.ServiceBusyError != nil:
+			err = resp.ServiceBusyError
 		default:
 			err = fmt.Errorf("received no result or unknown exception for PollForActivityTask")
 		}
@@ -219,6 +361,8 @@ func (c *tchanWorkflowServiceClient) PollForDecisionTask(ctx thrift.Context, pol
 			err = resp.BadRequestError
 		case resp.InternalServiceError != nil:
 			err = resp.InternalServiceError
+		case resp.ServiceBusyError != nil:
+			err = resp.ServiceBusyError
 		default:
 			err = fmt.Errorf("received no result or unknown exception for PollForDecisionTask")
 		}
@@ -227,6 +371,30 @@ func (c *tchanWorkflowServiceClient) PollForDecisionTask(ctx thrift.Context, pol
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanWorkflowServiceClient) QueryWorkflow(ctx thrift.Context, queryRequest *shared.QueryWorkflowRequest) (*shared.QueryWorkflowResponse, error) {
+	var resp WorkflowServiceQueryWorkflowResult
+	args := WorkflowServiceQueryWorkflowArgs{
+		QueryRequest: queryRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "QueryWorkflow", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		case resp.QueryFailedError != nil:
+			err = resp.QueryFailedError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for QueryWorkflow")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanWorkflowServiceClient) RecordActivityTaskHeartbeat(ctx thrift.Context, heartbeatRequest *shared.RecordActivityTaskHeartbeatRequest) (*shared.RecordActivityTaskHeartbeatResponse, error) {
 	var resp WorkflowServiceRecordActivityTaskHeartbeatResult
 	args := WorkflowServiceRecordActivityTaskHeartbeatArgs{
@@ -241,6 +409,8 @@ func (c *tchanWorkflowServiceClient) RecordActivityTaskHeartbeat(ctx thrift.Cont
 			err = resp.InternalServiceError
 		case resp.EntityNotExistError != nil:
 			err = resp.EntityNotExistError
+		case resp.ServiceBusyError != nil:
+			err = resp.ServiceBusyError
 		default:
 			err = fmt.Errorf("received no result or unknown exception for RecordActivityTaskHeartbeat")
 		}
@@ -249,6 +419,28 @@ func (c *tchanWorkflowServiceClient) RecordActivityTaskHeartbeat(ctx thrift.Cont
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanWorkflowServiceClient) RefreshWorkflowTasks(ctx thrift.Context, refreshRequest *shared.RefreshWorkflowTasksRequest) error {
+	var resp WorkflowServiceRefreshWorkflowTasksResult
+	args := WorkflowServiceRefreshWorkflowTasksArgs{
+		RefreshRequest: refreshRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "RefreshWorkflowTasks", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for RefreshWorkflowTasks")
+		}
+	}
+
+	return err
+}
+
 func (c *tchanWorkflowServiceClient) RegisterDomain(ctx thrift.Context, registerRequest *shared.RegisterDomainRequest) error {
 	var resp WorkflowServiceRegisterDomainResult
 	args := WorkflowServiceRegisterDomainArgs{
@@ -315,6 +507,28 @@ func (c *tchanWorkflowServiceClient) RespondActivityTaskCanceled(ctx thrift.Cont
 	return err
 }
 
+func (c *tchanWorkflowServiceClient) RespondActivityTaskCanceledByID(ctx thrift.Context, canceledRequest *shared.RespondActivityTaskCanceledByIDRequest) error {
+	var resp WorkflowServiceRespondActivityTaskCanceledByIDResult
+	args := WorkflowServiceRespondActivityTaskCanceledByIDArgs{
+		CanceledRequest: canceledRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "RespondActivityTaskCanceledByID", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for RespondActivityTaskCanceledByID")
+		}
+	}
+
+	return err
+}
+
 func (c *tchanWorkflowServiceClient) RespondActivityTaskCompleted(ctx thrift.Context, completeRequest *shared.RespondActivityTaskCompletedRequest) error {
 	var resp WorkflowServiceRespondActivityTaskCompletedResult
 	args := WorkflowServiceRespondActivityTaskCompletedArgs{
@@ -337,6 +551,28 @@ func (c *tchanWorkflowServiceClient) RespondActivityTaskCompleted(ctx thrift.Con
 	return err
 }
 
+func (c *tchanWorkflowServiceClient) RespondActivityTaskCompletedByID(ctx thrift.Context, completeRequest *shared.RespondActivityTaskCompletedByIDRequest) error {
+	var resp WorkflowServiceRespondActivityTaskCompletedByIDResult
+	args := WorkflowServiceRespondActivityTaskCompletedByIDArgs{
+		CompleteRequest: completeRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "RespondActivityTaskCompletedByID", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for RespondActivityTaskCompletedByID")
+		}
+	}
+
+	return err
+}
+
 func (c *tchanWorkflowServiceClient) RespondActivityTaskFailed(ctx thrift.Context, failRequest *shared.RespondActivityTaskFailedRequest) error {
 	var resp WorkflowServiceRespondActivityTaskFailedResult
 	args := WorkflowServiceRespondActivityTaskFailedArgs{
@@ -359,7 +595,29 @@ func (c *tchanWorkflowServiceClient) RespondActivityTaskFailed(ctx thrift.Contex
 	return err
 }
 
-func (c *tchanWorkflowServiceClient) RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *shared.RespondDecisionTaskCompletedRequest) error {
+func (c *tchanWorkflowServiceClient) RespondActivityTaskFailedByID(ctx thrift.Context, failedRequest *shared.RespondActivityTaskFailedByIDRequest) error {
+	var resp WorkflowServiceRespondActivityTaskFailedByIDResult
+	args := WorkflowServiceRespondActivityTaskFailedByIDArgs{
+		FailedRequest: failedRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "RespondActivityTaskFailedByID", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for RespondActivityTaskFailedByID")
+		}
+	}
+
+	return err
+}
+
+func (c *tchanWorkflowServiceClient) RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *shared.RespondDecisionTaskCompletedRequest) (*shared.RespondDecisionTaskCompletedResponse, error) {
 	var resp WorkflowServiceRespondDecisionTaskCompletedResult
 	args := WorkflowServiceRespondDecisionTaskCompletedArgs{
 		CompleteRequest: completeRequest,
@@ -378,10 +636,10 @@ func (c *tchanWorkflowServiceClient) RespondDecisionTaskCompleted(ctx thrift.Con
 		}
 	}
 
-	return err
+	return resp.GetSuccess(), err
 }
 
-func (c *tchanWorkflowServiceClient) SignalWorkflowExecution(ctx thrift.Context, signalRequest *shared.SignalWorkflowExecutionRequest) error {
+func (c *tchanWorkflowServiceClient) SignalWorkflowExecution(ctx thrift.Context, signalRequest *shared.SignalWorkflowExecutionRequest) (*shared.SignalWorkflowExecutionResponse, error) {
 	var resp WorkflowServiceSignalWorkflowExecutionResult
 	args := WorkflowServiceSignalWorkflowExecutionArgs{
 		SignalRequest: signalRequest,
@@ -400,7 +658,7 @@ func (c *tchanWorkflowServiceClient) SignalWorkflowExecution(ctx thrift.Context,
 		}
 	}
 
-	return err
+	return resp.GetSuccess(), err
 }
 
 func (c *tchanWorkflowServiceClient) StartWorkflowExecution(ctx thrift.Context, startRequest *shared.StartWorkflowExecutionRequest) (*shared.StartWorkflowExecutionResponse, error) {
@@ -417,6 +675,10 @@ func (c *tchanWorkflowServiceClient) StartWorkflowExecution(ctx thrift.Context,
 			err = resp.InternalServiceError
 		case resp.SessionAlreadyExistError != nil:
 			err = resp.SessionAlreadyExistError
+		case resp.LimitExceededError != nil:
+			err = resp.LimitExceededError
+		case resp.DomainNotActiveError != nil:
+			err = resp.DomainNotActiveError
 		default:
 			err = fmt.Errorf("received no result or unknown exception for StartWorkflowExecution")
 		}
@@ -425,7 +687,7 @@ func (c *tchanWorkflowServiceClient) StartWorkflowExecution(ctx thrift.Context,
 	return resp.GetSuccess(), err
 }
 
-func (c *tchanWorkflowServiceClient) TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *shared.TerminateWorkflowExecutionRequest) error {
+func (c *tchanWorkflowServiceClient) TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *shared.TerminateWorkflowExecutionRequest) (*shared.TerminateWorkflowExecutionResponse, error) {
 	var resp WorkflowServiceTerminateWorkflowExecutionResult
 	args := WorkflowServiceTerminateWorkflowExecutionArgs{
 		TerminateRequest: terminateRequest,
@@ -444,7 +706,7 @@ func (c *tchanWorkflowServiceClient) TerminateWorkflowExecution(ctx thrift.Conte
 		}
 	}
 
-	return err
+	return resp.GetSuccess(), err
 }
 
 func (c *tchanWorkflowServiceClient) UpdateDomain(ctx thrift.Context, updateRequest *shared.UpdateDomainRequest) (*shared.UpdateDomainResponse, error) {
@@ -469,6 +731,26 @@ func (c *tchanWorkflowServiceClient) UpdateDomain(ctx thrift.Context, updateRequ
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanWorkflowServiceClient) VerifyHistory(ctx thrift.Context, verifyRequest *shared.VerifyHistoryRequest) (*shared.VerifyHistoryResponse, error) {
+	var resp WorkflowServiceVerifyHistoryResult
+	args := WorkflowServiceVerifyHistoryArgs{
+		VerifyRequest: verifyRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "VerifyHistory", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for VerifyHistory")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 type tchanWorkflowServiceServer struct {
 	handler TChanWorkflowService
 }
@@ -488,23 +770,35 @@ func (s *tchanWorkflowServiceServer) Service() string {
 func (s *tchanWorkflowServiceServer) Methods() []string {
 	return []string{
 		"DeprecateDomain",
+		"DescribeCluster",
 		"DescribeDomain",
+		"DescribeWorkflowExecution",
+		"DrainDomain",
+		"GetSignalReceipt",
 		"GetWorkflowExecutionHistory",
 		"ListClosedWorkflowExecutions",
+		"ListClosedWorkflowExecutionsSince",
 		"ListOpenWorkflowExecutions",
+		"MigrateWorkflowExecution",
 		"PollForActivityTask",
 		"PollForDecisionTask",
+		"QueryWorkflow",
 		"RecordActivityTaskHeartbeat",
+		"RefreshWorkflowTasks",
 		"RegisterDomain",
 		"RequestCancelWorkflowExecution",
 		"RespondActivityTaskCanceled",
+		"RespondActivityTaskCanceledByID",
 		"RespondActivityTaskCompleted",
+		"RespondActivityTaskCompletedByID",
 		"RespondActivityTaskFailed",
+		"RespondActivityTaskFailedByID",
 		"RespondDecisionTaskCompleted",
 		"SignalWorkflowExecution",
 		"StartWorkflowExecution",
 		"TerminateWorkflowExecution",
 		"UpdateDomain",
+		"VerifyHistory",
 	}
 }
 
@@ -512,30 +806,52 @@ func (s *tchanWorkflowServiceServer) Handle(ctx thrift.Context, methodName strin
 	switch methodName {
 	case "DeprecateDomain":
 		return s.handleDeprecateDomain(ctx, protocol)
+	case "DescribeCluster":
+		return s.handleDescribeCluster(ctx, protocol)
 	case "DescribeDomain":
 		return s.handleDescribeDomain(ctx, protocol)
+	case "DescribeWorkflowExecution":
+		return s.handleDescribeWorkflowExecution(ctx, protocol)
+	case "DrainDomain":
+		return s.handleDrainDomain(ctx, protocol)
+	case "GetSignalReceipt":
+		return s.handleGetSignalReceipt(ctx, protocol)
 	case "GetWorkflowExecutionHistory":
 		return s.handleGetWorkflowExecutionHistory(ctx, protocol)
 	case "ListClosedWorkflowExecutions":
 		return s.handleListClosedWorkflowExecutions(ctx, protocol)
+	case "ListClosedWorkflowExecutionsSince":
+		return s.handleListClosedWorkflowExecutionsSince(ctx, protocol)
 	case "ListOpenWorkflowExecutions":
 		return s.handleListOpenWorkflowExecutions(ctx, protocol)
+	case "MigrateWorkflowExecution":
+		return s.handleMigrateWorkflowExecution(ctx, protocol)
 	case "PollForActivityTask":
 		return s.handlePollForActivityTask(ctx, protocol)
 	case "PollForDecisionTask":
 		return s.handlePollForDecisionTask(ctx, protocol)
+	case "QueryWorkflow":
+		return s.handleQueryWorkflow(ctx, protocol)
 	case "RecordActivityTaskHeartbeat":
 		return s.handleRecordActivityTaskHeartbeat(ctx, protocol)
+	case "RefreshWorkflowTasks":
+		return s.handleRefreshWorkflowTasks(ctx, protocol)
 	case "RegisterDomain":
 		return s.handleRegisterDomain(ctx, protocol)
 	case "RequestCancelWorkflowExecution":
 		return s.handleRequestCancelWorkflowExecution(ctx, protocol)
 	case "RespondActivityTaskCanceled":
 		return s.handleRespondActivityTaskCanceled(ctx, protocol)
+	case "RespondActivityTaskCanceledByID":
+		return s.handleRespondActivityTaskCanceledByID(ctx, protocol)
 	case "RespondActivityTaskCompleted":
 		return s.handleRespondActivityTaskCompleted(ctx, protocol)
+	case "RespondActivityTaskCompletedByID":
+		return s.handleRespondActivityTaskCompletedByID(ctx, protocol)
 	case "RespondActivityTaskFailed":
 		return s.handleRespondActivityTaskFailed(ctx, protocol)
+	case "RespondActivityTaskFailedByID":
+		return s.handleRespondActivityTaskFailedByID(ctx, protocol)
 	case "RespondDecisionTaskCompleted":
 		return s.handleRespondDecisionTaskCompleted(ctx, protocol)
 	case "SignalWorkflowExecution":
@@ -546,6 +862,8 @@ func (s *tchanWorkflowServiceServer) Handle(ctx thrift.Context, methodName strin
 		return s.handleTerminateWorkflowExecution(ctx, protocol)
 	case "UpdateDomain":
 		return s.handleUpdateDomain(ctx, protocol)
+	case "VerifyHistory":
+		return s.handleVerifyHistory(ctx, protocol)
 
 	default:
 		return false, nil, fmt.Errorf("method %v not found in service %v", methodName, s.Service())
@@ -589,34 +907,24 @@ func (s *tchanWorkflowServiceServer) handleDeprecateDomain(ctx thrift.Context, p
 	return err == nil, &res, nil
 }
 
-func (s *tchanWorkflowServiceServer) handleDescribeDomain(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req WorkflowServiceDescribeDomainArgs
-	var res WorkflowServiceDescribeDomainResult
+func (s *tchanWorkflowServiceServer) handleDescribeCluster(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceDescribeClusterArgs
+	var res WorkflowServiceDescribeClusterResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	r, err :=
-		s.handler.DescribeDomain(ctx, req.DescribeRequest)
+		s.handler.DescribeCluster(ctx)
 
 	if err != nil {
 		switch v := err.(type) {
-		case *shared.BadRequestError:
-			if v == nil {
-				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
-			}
-			res.BadRequestError = v
 		case *shared.InternalServiceError:
 			if v == nil {
 				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
 			}
 			res.InternalServiceError = v
-		case *shared.EntityNotExistsError:
-			if v == nil {
-				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
-			}
-			res.EntityNotExistError = v
 		default:
 			return false, nil, err
 		}
@@ -627,16 +935,16 @@ func (s *tchanWorkflowServiceServer) handleDescribeDomain(ctx thrift.Context, pr
 	return err == nil, &res, nil
 }
 
-func (s *tchanWorkflowServiceServer) handleGetWorkflowExecutionHistory(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req WorkflowServiceGetWorkflowExecutionHistoryArgs
-	var res WorkflowServiceGetWorkflowExecutionHistoryResult
+func (s *tchanWorkflowServiceServer) handleDescribeDomain(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceDescribeDomainArgs
+	var res WorkflowServiceDescribeDomainResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	r, err :=
-		s.handler.GetWorkflowExecutionHistory(ctx, req.GetRequest)
+		s.handler.DescribeDomain(ctx, req.DescribeRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -665,16 +973,16 @@ func (s *tchanWorkflowServiceServer) handleGetWorkflowExecutionHistory(ctx thrif
 	return err == nil, &res, nil
 }
 
-func (s *tchanWorkflowServiceServer) handleListClosedWorkflowExecutions(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req WorkflowServiceListClosedWorkflowExecutionsArgs
-	var res WorkflowServiceListClosedWorkflowExecutionsResult
+func (s *tchanWorkflowServiceServer) handleDescribeWorkflowExecution(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceDescribeWorkflowExecutionArgs
+	var res WorkflowServiceDescribeWorkflowExecutionResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	r, err :=
-		s.handler.ListClosedWorkflowExecutions(ctx, req.ListRequest)
+		s.handler.DescribeWorkflowExecution(ctx, req.DescribeRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -703,16 +1011,16 @@ func (s *tchanWorkflowServiceServer) handleListClosedWorkflowExecutions(ctx thri
 	return err == nil, &res, nil
 }
 
-func (s *tchanWorkflowServiceServer) handleListOpenWorkflowExecutions(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req WorkflowServiceListOpenWorkflowExecutionsArgs
-	var res WorkflowServiceListOpenWorkflowExecutionsResult
+func (s *tchanWorkflowServiceServer) handleDrainDomain(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceDrainDomainArgs
+	var res WorkflowServiceDrainDomainResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
-	r, err :=
-		s.handler.ListOpenWorkflowExecutions(ctx, req.ListRequest)
+	err :=
+		s.handler.DrainDomain(ctx, req.DrainRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -735,22 +1043,21 @@ func (s *tchanWorkflowServiceServer) handleListOpenWorkflowExecutions(ctx thrift
 			return false, nil, err
 		}
 	} else {
-		res.Success = r
 	}
 
 	return err == nil, &res, nil
 }
 
-func (s *tchanWorkflowServiceServer) handlePollForActivityTask(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req WorkflowServicePollForActivityTaskArgs
-	var res WorkflowServicePollForActivityTaskResult
+func (s *tchanWorkflowServiceServer) handleGetSignalReceipt(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceGetSignalReceiptArgs
+	var res WorkflowServiceGetSignalReceiptResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	r, err :=
-		s.handler.PollForActivityTask(ctx, req.PollRequest)
+		s.handler.GetSignalReceipt(ctx, req.ReceiptRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -764,6 +1071,11 @@ func (s *tchanWorkflowServiceServer) handlePollForActivityTask(ctx thrift.Contex
 				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
 			}
 			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
 		default:
 			return false, nil, err
 		}
@@ -774,16 +1086,16 @@ func (s *tchanWorkflowServiceServer) handlePollForActivityTask(ctx thrift.Contex
 	return err == nil, &res, nil
 }
 
-func (s *tchanWorkflowServiceServer) handlePollForDecisionTask(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req WorkflowServicePollForDecisionTaskArgs
-	var res WorkflowServicePollForDecisionTaskResult
+func (s *tchanWorkflowServiceServer) handleGetWorkflowExecutionHistory(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceGetWorkflowExecutionHistoryArgs
+	var res WorkflowServiceGetWorkflowExecutionHistoryResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	r, err :=
-		s.handler.PollForDecisionTask(ctx, req.PollRequest)
+		s.handler.GetWorkflowExecutionHistory(ctx, req.GetRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -797,6 +1109,11 @@ func (s *tchanWorkflowServiceServer) handlePollForDecisionTask(ctx thrift.Contex
 				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
 			}
 			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
 		default:
 			return false, nil, err
 		}
@@ -807,9 +1124,284 @@ func (s *tchanWorkflowServiceServer) handlePollForDecisionTask(ctx thrift.Contex
 	return err == nil, &res, nil
 }
 
-func (s *tchanWorkflowServiceServer) handleRecordActivityTaskHeartbeat(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req WorkflowServiceRecordActivityTaskHeartbeatArgs
-	var res WorkflowServiceRecordActivityTaskHeartbeatResult
+func (s *tchanWorkflowServiceServer) handleListClosedWorkflowExecutions(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceListClosedWorkflowExecutionsArgs
+	var res WorkflowServiceListClosedWorkflowExecutionsResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.ListClosedWorkflowExecutions(ctx, req.ListRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanWorkflowServiceServer) handleListClosedWorkflowExecutionsSince(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceListClosedWorkflowExecutionsSinceArgs
+	var res WorkflowServiceListClosedWorkflowExecutionsSinceResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.ListClosedWorkflowExecutionsSince(ctx, req.ListRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanWorkflowServiceServer) handleListOpenWorkflowExecutions(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceListOpenWorkflowExecutionsArgs
+	var res WorkflowServiceListOpenWorkflowExecutionsResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.ListOpenWorkflowExecutions(ctx, req.ListRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanWorkflowServiceServer) handleMigrateWorkflowExecution(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceMigrateWorkflowExecutionArgs
+	var res WorkflowServiceMigrateWorkflowExecutionResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.MigrateWorkflowExecution(ctx, req.MigrateRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *shared.WorkflowExecutionAlreadyStartedError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for workflowAlreadyStartedError returned non-nil error type *shared.WorkflowExecutionAlreadyStartedError but nil value")
+			}
+			res.WorkflowAlreadyStartedError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanWorkflowServiceServer) handlePollForActivityTask(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServicePollForActivityTaskArgs
+	var res WorkflowServicePollForActivityTaskResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.PollForActivityTask(ctx, req.PollRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.ServiceBusyError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for serviceBusyError returned non-nil error type *shared.ServiceBusyError but nil value")
+			}
+			res.ServiceBusyError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanWorkflowServiceServer) handlePollForDecisionTask(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServicePollForDecisionTaskArgs
+	var res WorkflowServicePollForDecisionTaskResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.PollForDecisionTask(ctx, req.PollRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.ServiceBusyError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for serviceBusyError returned non-nil error type *shared.ServiceBusyError but nil value")
+			}
+			res.ServiceBusyError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanWorkflowServiceServer) handleQueryWorkflow(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceQueryWorkflowArgs
+	var res WorkflowServiceQueryWorkflowResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.QueryWorkflow(ctx, req.QueryRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *shared.QueryFailedError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for queryFailedError returned non-nil error type *shared.QueryFailedError but nil value")
+			}
+			res.QueryFailedError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanWorkflowServiceServer) handleRecordActivityTaskHeartbeat(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceRecordActivityTaskHeartbeatArgs
+	var res WorkflowServiceRecordActivityTaskHeartbeatResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
@@ -835,6 +1427,11 @@ func (s *tchanWorkflowServiceServer) handleRecordActivityTaskHeartbeat(ctx thrif
 				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
 			}
 			res.EntityNotExistError = v
+		case *shared.ServiceBusyError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for serviceBusyError returned non-nil error type *shared.ServiceBusyError but nil value")
+			}
+			res.ServiceBusyError = v
 		default:
 			return false, nil, err
 		}
@@ -845,6 +1442,43 @@ func (s *tchanWorkflowServiceServer) handleRecordActivityTaskHeartbeat(ctx thrif
 	return err == nil, &res, nil
 }
 
+func (s *tchanWorkflowServiceServer) handleRefreshWorkflowTasks(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceRefreshWorkflowTasksArgs
+	var res WorkflowServiceRefreshWorkflowTasksResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.RefreshWorkflowTasks(ctx, req.RefreshRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanWorkflowServiceServer) handleRegisterDomain(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req WorkflowServiceRegisterDomainArgs
 	var res WorkflowServiceRegisterDomainResult
@@ -956,6 +1590,43 @@ func (s *tchanWorkflowServiceServer) handleRespondActivityTaskCanceled(ctx thrif
 	return err == nil, &res, nil
 }
 
+func (s *tchanWorkflowServiceServer) handleRespondActivityTaskCanceledByID(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceRespondActivityTaskCanceledByIDArgs
+	var res WorkflowServiceRespondActivityTaskCanceledByIDResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.RespondActivityTaskCanceledByID(ctx, req.CanceledRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanWorkflowServiceServer) handleRespondActivityTaskCompleted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req WorkflowServiceRespondActivityTaskCompletedArgs
 	var res WorkflowServiceRespondActivityTaskCompletedResult
@@ -993,6 +1664,43 @@ func (s *tchanWorkflowServiceServer) handleRespondActivityTaskCompleted(ctx thri
 	return err == nil, &res, nil
 }
 
+func (s *tchanWorkflowServiceServer) handleRespondActivityTaskCompletedByID(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceRespondActivityTaskCompletedByIDArgs
+	var res WorkflowServiceRespondActivityTaskCompletedByIDResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.RespondActivityTaskCompletedByID(ctx, req.CompleteRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanWorkflowServiceServer) handleRespondActivityTaskFailed(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req WorkflowServiceRespondActivityTaskFailedArgs
 	var res WorkflowServiceRespondActivityTaskFailedResult
@@ -1030,6 +1738,43 @@ func (s *tchanWorkflowServiceServer) handleRespondActivityTaskFailed(ctx thrift.
 	return err == nil, &res, nil
 }
 
+func (s *tchanWorkflowServiceServer) handleRespondActivityTaskFailedByID(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceRespondActivityTaskFailedByIDArgs
+	var res WorkflowServiceRespondActivityTaskFailedByIDResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.RespondActivityTaskFailedByID(ctx, req.FailedRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanWorkflowServiceServer) handleRespondDecisionTaskCompleted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req WorkflowServiceRespondDecisionTaskCompletedArgs
 	var res WorkflowServiceRespondDecisionTaskCompletedResult
@@ -1038,7 +1783,7 @@ func (s *tchanWorkflowServiceServer) handleRespondDecisionTaskCompleted(ctx thri
 		return false, nil, err
 	}
 
-	err :=
+	r, err :=
 		s.handler.RespondDecisionTaskCompleted(ctx, req.CompleteRequest)
 
 	if err != nil {
@@ -1062,6 +1807,7 @@ func (s *tchanWorkflowServiceServer) handleRespondDecisionTaskCompleted(ctx thri
 			return false, nil, err
 		}
 	} else {
+		res.Success = r
 	}
 
 	return err == nil, &res, nil
@@ -1075,7 +1821,7 @@ func (s *tchanWorkflowServiceServer) handleSignalWorkflowExecution(ctx thrift.Co
 		return false, nil, err
 	}
 
-	err :=
+	r, err :=
 		s.handler.SignalWorkflowExecution(ctx, req.SignalRequest)
 
 	if err != nil {
@@ -1099,6 +1845,7 @@ func (s *tchanWorkflowServiceServer) handleSignalWorkflowExecution(ctx thrift.Co
 			return false, nil, err
 		}
 	} else {
+		res.Success = r
 	}
 
 	return err == nil, &res, nil
@@ -1132,6 +1879,16 @@ func (s *tchanWorkflowServiceServer) handleStartWorkflowExecution(ctx thrift.Con
 				return false, nil, fmt.Errorf("Handler for sessionAlreadyExistError returned non-nil error type *shared.WorkflowExecutionAlreadyStartedError but nil value")
 			}
 			res.SessionAlreadyExistError = v
+		case *shared.LimitExceededError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for limitExceededError returned non-nil error type *shared.LimitExceededError but nil value")
+			}
+			res.LimitExceededError = v
+		case *shared.DomainNotActiveError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for domainNotActiveError returned non-nil error type *shared.DomainNotActiveError but nil value")
+			}
+			res.DomainNotActiveError = v
 		default:
 			return false, nil, err
 		}
@@ -1150,7 +1907,7 @@ func (s *tchanWorkflowServiceServer) handleTerminateWorkflowExecution(ctx thrift
 		return false, nil, err
 	}
 
-	err :=
+	r, err :=
 		s.handler.TerminateWorkflowExecution(ctx, req.TerminateRequest)
 
 	if err != nil {
@@ -1174,6 +1931,7 @@ func (s *tchanWorkflowServiceServer) handleTerminateWorkflowExecution(ctx thrift
 			return false, nil, err
 		}
 	} else {
+		res.Success = r
 	}
 
 	return err == nil, &res, nil
@@ -1216,3 +1974,36 @@ func (s *tchanWorkflowServiceServer) handleUpdateDomain(ctx thrift.Context, prot
 
 	return err == nil, &res, nil
 }
+
+func (s *tchanWorkflowServiceServer) handleVerifyHistory(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req WorkflowServiceVerifyHistoryArgs
+	var res WorkflowServiceVerifyHistoryResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.VerifyHistory(ctx, req.VerifyRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
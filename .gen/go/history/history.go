@@ -25,6 +25,8 @@ package history
 
 import (
 	"bytes"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/uber/cadence/.gen/go/shared"
@@ -37,6 +39,112 @@ var _ = fmt.Printf
 var _ = bytes.Equal
 
 var _ = shared.GoUnusedProtection__
+type QueueType int64
+const (
+  QueueType_TRANSFER QueueType = 0
+  QueueType_TIMER QueueType = 1
+)
+
+func (p QueueType) String() string {
+  switch p {
+  case QueueType_TRANSFER: return "TRANSFER"
+  case QueueType_TIMER: return "TIMER"
+  }
+  return "<UNSET>"
+}
+
+func QueueTypeFromString(s string) (QueueType, error) {
+  switch s {
+  case "TRANSFER": return QueueType_TRANSFER, nil 
+  case "TIMER": return QueueType_TIMER, nil 
+  }
+  return QueueType(0), fmt.Errorf("not a valid QueueType string")
+}
+
+
+func QueueTypePtr(v QueueType) *QueueType { return &v }
+
+func (p QueueType) MarshalText() ([]byte, error) {
+return []byte(p.String()), nil
+}
+
+func (p *QueueType) UnmarshalText(text []byte) error {
+q, err := QueueTypeFromString(string(text))
+if (err != nil) {
+return err
+}
+*p = q
+return nil
+}
+
+func (p *QueueType) Scan(value interface{}) error {
+v, ok := value.(int64)
+if !ok {
+return errors.New("Scan value is not int64")
+}
+*p = QueueType(v)
+return nil
+}
+
+func (p * QueueType) Value() (driver.Value, error) {
+  if p == nil {
+    return nil, nil
+  }
+return int64(*p), nil
+}
+type StaleExecutionReason int64
+const (
+  StaleExecutionReason_DECISION_PENDING StaleExecutionReason = 0
+  StaleExecutionReason_TIMER_OVERDUE StaleExecutionReason = 1
+)
+
+func (p StaleExecutionReason) String() string {
+  switch p {
+  case StaleExecutionReason_DECISION_PENDING: return "DECISION_PENDING"
+  case StaleExecutionReason_TIMER_OVERDUE: return "TIMER_OVERDUE"
+  }
+  return "<UNSET>"
+}
+
+func StaleExecutionReasonFromString(s string) (StaleExecutionReason, error) {
+  switch s {
+  case "DECISION_PENDING": return StaleExecutionReason_DECISION_PENDING, nil 
+  case "TIMER_OVERDUE": return StaleExecutionReason_TIMER_OVERDUE, nil 
+  }
+  return StaleExecutionReason(0), fmt.Errorf("not a valid StaleExecutionReason string")
+}
+
+
+func StaleExecutionReasonPtr(v StaleExecutionReason) *StaleExecutionReason { return &v }
+
+func (p StaleExecutionReason) MarshalText() ([]byte, error) {
+return []byte(p.String()), nil
+}
+
+func (p *StaleExecutionReason) UnmarshalText(text []byte) error {
+q, err := StaleExecutionReasonFromString(string(text))
+if (err != nil) {
+return err
+}
+*p = q
+return nil
+}
+
+func (p *StaleExecutionReason) Scan(value interface{}) error {
+v, ok := value.(int64)
+if !ok {
+return errors.New("Scan value is not int64")
+}
+*p = StaleExecutionReason(v)
+return nil
+}
+
+func (p * StaleExecutionReason) Value() (driver.Value, error) {
+  if p == nil {
+    return nil, nil
+  }
+return int64(*p), nil
+}
 // Attributes:
 //  - Message
 type EventAlreadyStartedError struct {
@@ -891,42 +999,26 @@ func (p *GetWorkflowExecutionNextEventIDResponse) String() string {
 }
 
 // Attributes:
-//  - DomainUUID
-//  - CompleteRequest
-type RespondDecisionTaskCompletedRequest struct {
+//  - Requests
+type BatchGetWorkflowExecutionNextEventIDRequest struct {
   // unused fields # 1 to 9
-  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
-  // unused fields # 11 to 19
-  CompleteRequest *shared.RespondDecisionTaskCompletedRequest `thrift:"completeRequest,20" db:"completeRequest" json:"completeRequest,omitempty"`
+  Requests []*GetWorkflowExecutionNextEventIDRequest `thrift:"requests,10" db:"requests" json:"requests,omitempty"`
 }
 
-func NewRespondDecisionTaskCompletedRequest() *RespondDecisionTaskCompletedRequest {
-  return &RespondDecisionTaskCompletedRequest{}
+func NewBatchGetWorkflowExecutionNextEventIDRequest() *BatchGetWorkflowExecutionNextEventIDRequest {
+  return &BatchGetWorkflowExecutionNextEventIDRequest{}
 }
 
-var RespondDecisionTaskCompletedRequest_DomainUUID_DEFAULT string
-func (p *RespondDecisionTaskCompletedRequest) GetDomainUUID() string {
-  if !p.IsSetDomainUUID() {
-    return RespondDecisionTaskCompletedRequest_DomainUUID_DEFAULT
-  }
-return *p.DomainUUID
-}
-var RespondDecisionTaskCompletedRequest_CompleteRequest_DEFAULT *shared.RespondDecisionTaskCompletedRequest
-func (p *RespondDecisionTaskCompletedRequest) GetCompleteRequest() *shared.RespondDecisionTaskCompletedRequest {
-  if !p.IsSetCompleteRequest() {
-    return RespondDecisionTaskCompletedRequest_CompleteRequest_DEFAULT
-  }
-return p.CompleteRequest
-}
-func (p *RespondDecisionTaskCompletedRequest) IsSetDomainUUID() bool {
-  return p.DomainUUID != nil
-}
+var BatchGetWorkflowExecutionNextEventIDRequest_Requests_DEFAULT []*GetWorkflowExecutionNextEventIDRequest
 
-func (p *RespondDecisionTaskCompletedRequest) IsSetCompleteRequest() bool {
-  return p.CompleteRequest != nil
+func (p *BatchGetWorkflowExecutionNextEventIDRequest) GetRequests() []*GetWorkflowExecutionNextEventIDRequest {
+  return p.Requests
+}
+func (p *BatchGetWorkflowExecutionNextEventIDRequest) IsSetRequests() bool {
+  return p.Requests != nil
 }
 
-func (p *RespondDecisionTaskCompletedRequest) Read(iprot thrift.TProtocol) error {
+func (p *BatchGetWorkflowExecutionNextEventIDRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -943,10 +1035,6 @@ func (p *RespondDecisionTaskCompletedRequest) Read(iprot thrift.TProtocol) error
       if err := p.ReadField10(iprot); err != nil {
         return err
       }
-    case 20:
-      if err := p.ReadField20(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -962,29 +1050,31 @@ func (p *RespondDecisionTaskCompletedRequest) Read(iprot thrift.TProtocol) error
   return nil
 }
 
-func (p *RespondDecisionTaskCompletedRequest)  ReadField10(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadString(); err != nil {
-  return thrift.PrependError("error reading field 10: ", err)
-} else {
-  p.DomainUUID = &v
-}
-  return nil
-}
-
-func (p *RespondDecisionTaskCompletedRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.CompleteRequest = &shared.RespondDecisionTaskCompletedRequest{}
-  if err := p.CompleteRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
+func (p *BatchGetWorkflowExecutionNextEventIDRequest)  ReadField10(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*GetWorkflowExecutionNextEventIDRequest, 0, size)
+  p.Requests =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem0 := &GetWorkflowExecutionNextEventIDRequest{}
+    if err := _elem0.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem0), err)
+    }
+    p.Requests = append(p.Requests, _elem0)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
   }
   return nil
 }
 
-func (p *RespondDecisionTaskCompletedRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondDecisionTaskCompletedRequest"); err != nil {
+func (p *BatchGetWorkflowExecutionNextEventIDRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("BatchGetWorkflowExecutionNextEventIDRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
-    if err := p.writeField20(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -993,75 +1083,127 @@ func (p *RespondDecisionTaskCompletedRequest) Write(oprot thrift.TProtocol) erro
   return nil
 }
 
-func (p *RespondDecisionTaskCompletedRequest) writeField10(oprot thrift.TProtocol) (err error) {
-  if p.IsSetDomainUUID() {
-    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
-    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
-  }
-  return err
-}
-
-func (p *RespondDecisionTaskCompletedRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetCompleteRequest() {
-    if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:completeRequest: ", p), err) }
-    if err := p.CompleteRequest.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
+func (p *BatchGetWorkflowExecutionNextEventIDRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetRequests() {
+    if err := oprot.WriteFieldBegin("requests", thrift.LIST, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:requests: ", p), err) }
+    if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Requests)); err != nil {
+      return thrift.PrependError("error writing list begin: ", err)
+    }
+    for _, v := range p.Requests {
+      if err := v.Write(oprot); err != nil {
+        return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+      }
+    }
+    if err := oprot.WriteListEnd(); err != nil {
+      return thrift.PrependError("error writing list end: ", err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:completeRequest: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:requests: ", p), err) }
   }
   return err
 }
 
-func (p *RespondDecisionTaskCompletedRequest) String() string {
+func (p *BatchGetWorkflowExecutionNextEventIDRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RespondDecisionTaskCompletedRequest(%+v)", *p)
+  return fmt.Sprintf("BatchGetWorkflowExecutionNextEventIDRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - DomainUUID
-//  - HeartbeatRequest
-type RecordActivityTaskHeartbeatRequest struct {
+//  - Response
+//  - EntityNotExistError
+//  - ShardOwnershipLostError
+//  - InternalServiceError
+//  - BadRequestError
+//  - ServiceBusyError
+type GetWorkflowExecutionNextEventIDOutcome struct {
   // unused fields # 1 to 9
-  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  Response *GetWorkflowExecutionNextEventIDResponse `thrift:"response,10" db:"response" json:"response,omitempty"`
   // unused fields # 11 to 19
-  HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `thrift:"heartbeatRequest,20" db:"heartbeatRequest" json:"heartbeatRequest,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,20" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  // unused fields # 21 to 29
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,30" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  // unused fields # 31 to 39
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,40" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  // unused fields # 41 to 49
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,50" db:"badRequestError" json:"badRequestError,omitempty"`
+  // unused fields # 51 to 59
+  ServiceBusyError *shared.ServiceBusyError `thrift:"serviceBusyError,60" db:"serviceBusyError" json:"serviceBusyError,omitempty"`
 }
 
-func NewRecordActivityTaskHeartbeatRequest() *RecordActivityTaskHeartbeatRequest {
-  return &RecordActivityTaskHeartbeatRequest{}
+func NewGetWorkflowExecutionNextEventIDOutcome() *GetWorkflowExecutionNextEventIDOutcome {
+  return &GetWorkflowExecutionNextEventIDOutcome{}
 }
 
-var RecordActivityTaskHeartbeatRequest_DomainUUID_DEFAULT string
-func (p *RecordActivityTaskHeartbeatRequest) GetDomainUUID() string {
-  if !p.IsSetDomainUUID() {
-    return RecordActivityTaskHeartbeatRequest_DomainUUID_DEFAULT
+var GetWorkflowExecutionNextEventIDOutcome_Response_DEFAULT *GetWorkflowExecutionNextEventIDResponse
+func (p *GetWorkflowExecutionNextEventIDOutcome) GetResponse() *GetWorkflowExecutionNextEventIDResponse {
+  if !p.IsSetResponse() {
+    return GetWorkflowExecutionNextEventIDOutcome_Response_DEFAULT
   }
-return *p.DomainUUID
+return p.Response
 }
-var RecordActivityTaskHeartbeatRequest_HeartbeatRequest_DEFAULT *shared.RecordActivityTaskHeartbeatRequest
-func (p *RecordActivityTaskHeartbeatRequest) GetHeartbeatRequest() *shared.RecordActivityTaskHeartbeatRequest {
-  if !p.IsSetHeartbeatRequest() {
-    return RecordActivityTaskHeartbeatRequest_HeartbeatRequest_DEFAULT
+var GetWorkflowExecutionNextEventIDOutcome_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *GetWorkflowExecutionNextEventIDOutcome) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return GetWorkflowExecutionNextEventIDOutcome_EntityNotExistError_DEFAULT
   }
-return p.HeartbeatRequest
+return p.EntityNotExistError
 }
-func (p *RecordActivityTaskHeartbeatRequest) IsSetDomainUUID() bool {
-  return p.DomainUUID != nil
+var GetWorkflowExecutionNextEventIDOutcome_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *GetWorkflowExecutionNextEventIDOutcome) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return GetWorkflowExecutionNextEventIDOutcome_ShardOwnershipLostError_DEFAULT
+  }
+return p.ShardOwnershipLostError
+}
+var GetWorkflowExecutionNextEventIDOutcome_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *GetWorkflowExecutionNextEventIDOutcome) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return GetWorkflowExecutionNextEventIDOutcome_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var GetWorkflowExecutionNextEventIDOutcome_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *GetWorkflowExecutionNextEventIDOutcome) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return GetWorkflowExecutionNextEventIDOutcome_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var GetWorkflowExecutionNextEventIDOutcome_ServiceBusyError_DEFAULT *shared.ServiceBusyError
+func (p *GetWorkflowExecutionNextEventIDOutcome) GetServiceBusyError() *shared.ServiceBusyError {
+  if !p.IsSetServiceBusyError() {
+    return GetWorkflowExecutionNextEventIDOutcome_ServiceBusyError_DEFAULT
+  }
+return p.ServiceBusyError
+}
+func (p *GetWorkflowExecutionNextEventIDOutcome) IsSetResponse() bool {
+  return p.Response != nil
 }
 
-func (p *RecordActivityTaskHeartbeatRequest) IsSetHeartbeatRequest() bool {
-  return p.HeartbeatRequest != nil
+func (p *GetWorkflowExecutionNextEventIDOutcome) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
 }
 
-func (p *RecordActivityTaskHeartbeatRequest) Read(iprot thrift.TProtocol) error {
+func (p *GetWorkflowExecutionNextEventIDOutcome) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
+
+func (p *GetWorkflowExecutionNextEventIDOutcome) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *GetWorkflowExecutionNextEventIDOutcome) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *GetWorkflowExecutionNextEventIDOutcome) IsSetServiceBusyError() bool {
+  return p.ServiceBusyError != nil
+}
+
+func (p *GetWorkflowExecutionNextEventIDOutcome) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -1082,6 +1224,22 @@ func (p *RecordActivityTaskHeartbeatRequest) Read(iprot thrift.TProtocol) error
       if err := p.ReadField20(iprot); err != nil {
         return err
       }
+    case 30:
+      if err := p.ReadField30(iprot); err != nil {
+        return err
+      }
+    case 40:
+      if err := p.ReadField40(iprot); err != nil {
+        return err
+      }
+    case 50:
+      if err := p.ReadField50(iprot); err != nil {
+        return err
+      }
+    case 60:
+      if err := p.ReadField60(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -1097,29 +1255,64 @@ func (p *RecordActivityTaskHeartbeatRequest) Read(iprot thrift.TProtocol) error
   return nil
 }
 
-func (p *RecordActivityTaskHeartbeatRequest)  ReadField10(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadString(); err != nil {
-  return thrift.PrependError("error reading field 10: ", err)
-} else {
-  p.DomainUUID = &v
+func (p *GetWorkflowExecutionNextEventIDOutcome)  ReadField10(iprot thrift.TProtocol) error {
+  p.Response = &GetWorkflowExecutionNextEventIDResponse{}
+  if err := p.Response.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Response), err)
+  }
+  return nil
 }
+
+func (p *GetWorkflowExecutionNextEventIDOutcome)  ReadField20(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
   return nil
 }
 
-func (p *RecordActivityTaskHeartbeatRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.HeartbeatRequest = &shared.RecordActivityTaskHeartbeatRequest{}
-  if err := p.HeartbeatRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.HeartbeatRequest), err)
+func (p *GetWorkflowExecutionNextEventIDOutcome)  ReadField30(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
   }
   return nil
 }
 
-func (p *RecordActivityTaskHeartbeatRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeatRequest"); err != nil {
+func (p *GetWorkflowExecutionNextEventIDOutcome)  ReadField40(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *GetWorkflowExecutionNextEventIDOutcome)  ReadField50(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *GetWorkflowExecutionNextEventIDOutcome)  ReadField60(iprot thrift.TProtocol) error {
+  p.ServiceBusyError = &shared.ServiceBusyError{}
+  if err := p.ServiceBusyError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ServiceBusyError), err)
+  }
+  return nil
+}
+
+func (p *GetWorkflowExecutionNextEventIDOutcome) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetWorkflowExecutionNextEventIDOutcome"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
     if err := p.writeField20(oprot); err != nil { return err }
+    if err := p.writeField30(oprot); err != nil { return err }
+    if err := p.writeField40(oprot); err != nil { return err }
+    if err := p.writeField50(oprot); err != nil { return err }
+    if err := p.writeField60(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -1128,75 +1321,112 @@ func (p *RecordActivityTaskHeartbeatRequest) Write(oprot thrift.TProtocol) error
   return nil
 }
 
-func (p *RecordActivityTaskHeartbeatRequest) writeField10(oprot thrift.TProtocol) (err error) {
-  if p.IsSetDomainUUID() {
-    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
-    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+func (p *GetWorkflowExecutionNextEventIDOutcome) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetResponse() {
+    if err := oprot.WriteFieldBegin("response", thrift.STRUCT, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:response: ", p), err) }
+    if err := p.Response.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Response), err)
+    }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:response: ", p), err) }
   }
   return err
 }
 
-func (p *RecordActivityTaskHeartbeatRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetHeartbeatRequest() {
-    if err := oprot.WriteFieldBegin("heartbeatRequest", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:heartbeatRequest: ", p), err) }
-    if err := p.HeartbeatRequest.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.HeartbeatRequest), err)
+func (p *GetWorkflowExecutionNextEventIDOutcome) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:heartbeatRequest: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:entityNotExistError: ", p), err) }
   }
   return err
 }
 
-func (p *RecordActivityTaskHeartbeatRequest) String() string {
-  if p == nil {
-    return "<nil>"
-  }
-  return fmt.Sprintf("RecordActivityTaskHeartbeatRequest(%+v)", *p)
-}
+func (p *GetWorkflowExecutionNextEventIDOutcome) writeField30(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 30); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:shardOwnershipLostError: ", p), err) }
+  }
+  return err
+}
 
-// Attributes:
-//  - DomainUUID
-//  - CompleteRequest
-type RespondActivityTaskCompletedRequest struct {
-  // unused fields # 1 to 9
-  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
-  // unused fields # 11 to 19
-  CompleteRequest *shared.RespondActivityTaskCompletedRequest `thrift:"completeRequest,20" db:"completeRequest" json:"completeRequest,omitempty"`
+func (p *GetWorkflowExecutionNextEventIDOutcome) writeField40(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 40); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 40:internalServiceError: ", p), err) }
+  }
+  return err
 }
 
-func NewRespondActivityTaskCompletedRequest() *RespondActivityTaskCompletedRequest {
-  return &RespondActivityTaskCompletedRequest{}
+func (p *GetWorkflowExecutionNextEventIDOutcome) writeField50(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 50); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 50:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 50:badRequestError: ", p), err) }
+  }
+  return err
 }
 
-var RespondActivityTaskCompletedRequest_DomainUUID_DEFAULT string
-func (p *RespondActivityTaskCompletedRequest) GetDomainUUID() string {
-  if !p.IsSetDomainUUID() {
-    return RespondActivityTaskCompletedRequest_DomainUUID_DEFAULT
+func (p *GetWorkflowExecutionNextEventIDOutcome) writeField60(oprot thrift.TProtocol) (err error) {
+  if p.IsSetServiceBusyError() {
+    if err := oprot.WriteFieldBegin("serviceBusyError", thrift.STRUCT, 60); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 60:serviceBusyError: ", p), err) }
+    if err := p.ServiceBusyError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ServiceBusyError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 60:serviceBusyError: ", p), err) }
   }
-return *p.DomainUUID
+  return err
 }
-var RespondActivityTaskCompletedRequest_CompleteRequest_DEFAULT *shared.RespondActivityTaskCompletedRequest
-func (p *RespondActivityTaskCompletedRequest) GetCompleteRequest() *shared.RespondActivityTaskCompletedRequest {
-  if !p.IsSetCompleteRequest() {
-    return RespondActivityTaskCompletedRequest_CompleteRequest_DEFAULT
+
+func (p *GetWorkflowExecutionNextEventIDOutcome) String() string {
+  if p == nil {
+    return "<nil>"
   }
-return p.CompleteRequest
+  return fmt.Sprintf("GetWorkflowExecutionNextEventIDOutcome(%+v)", *p)
 }
-func (p *RespondActivityTaskCompletedRequest) IsSetDomainUUID() bool {
-  return p.DomainUUID != nil
+
+// Attributes:
+//  - Results
+type BatchGetWorkflowExecutionNextEventIDResponse struct {
+  // unused fields # 1 to 9
+  Results []*GetWorkflowExecutionNextEventIDOutcome `thrift:"results,10" db:"results" json:"results,omitempty"`
 }
 
-func (p *RespondActivityTaskCompletedRequest) IsSetCompleteRequest() bool {
-  return p.CompleteRequest != nil
+func NewBatchGetWorkflowExecutionNextEventIDResponse() *BatchGetWorkflowExecutionNextEventIDResponse {
+  return &BatchGetWorkflowExecutionNextEventIDResponse{}
 }
 
-func (p *RespondActivityTaskCompletedRequest) Read(iprot thrift.TProtocol) error {
+var BatchGetWorkflowExecutionNextEventIDResponse_Results_DEFAULT []*GetWorkflowExecutionNextEventIDOutcome
+
+func (p *BatchGetWorkflowExecutionNextEventIDResponse) GetResults() []*GetWorkflowExecutionNextEventIDOutcome {
+  return p.Results
+}
+func (p *BatchGetWorkflowExecutionNextEventIDResponse) IsSetResults() bool {
+  return p.Results != nil
+}
+
+func (p *BatchGetWorkflowExecutionNextEventIDResponse) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -1213,10 +1443,6 @@ func (p *RespondActivityTaskCompletedRequest) Read(iprot thrift.TProtocol) error
       if err := p.ReadField10(iprot); err != nil {
         return err
       }
-    case 20:
-      if err := p.ReadField20(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -1232,29 +1458,31 @@ func (p *RespondActivityTaskCompletedRequest) Read(iprot thrift.TProtocol) error
   return nil
 }
 
-func (p *RespondActivityTaskCompletedRequest)  ReadField10(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadString(); err != nil {
-  return thrift.PrependError("error reading field 10: ", err)
-} else {
-  p.DomainUUID = &v
-}
-  return nil
-}
-
-func (p *RespondActivityTaskCompletedRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.CompleteRequest = &shared.RespondActivityTaskCompletedRequest{}
-  if err := p.CompleteRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
+func (p *BatchGetWorkflowExecutionNextEventIDResponse)  ReadField10(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*GetWorkflowExecutionNextEventIDOutcome, 0, size)
+  p.Results =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem1 := &GetWorkflowExecutionNextEventIDOutcome{}
+    if err := _elem1.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem1), err)
+    }
+    p.Results = append(p.Results, _elem1)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
   }
   return nil
 }
 
-func (p *RespondActivityTaskCompletedRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCompletedRequest"); err != nil {
+func (p *BatchGetWorkflowExecutionNextEventIDResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("BatchGetWorkflowExecutionNextEventIDResponse"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
-    if err := p.writeField20(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -1263,75 +1491,71 @@ func (p *RespondActivityTaskCompletedRequest) Write(oprot thrift.TProtocol) erro
   return nil
 }
 
-func (p *RespondActivityTaskCompletedRequest) writeField10(oprot thrift.TProtocol) (err error) {
-  if p.IsSetDomainUUID() {
-    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
-    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
-  }
-  return err
-}
-
-func (p *RespondActivityTaskCompletedRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetCompleteRequest() {
-    if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:completeRequest: ", p), err) }
-    if err := p.CompleteRequest.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
+func (p *BatchGetWorkflowExecutionNextEventIDResponse) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetResults() {
+    if err := oprot.WriteFieldBegin("results", thrift.LIST, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:results: ", p), err) }
+    if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Results)); err != nil {
+      return thrift.PrependError("error writing list begin: ", err)
+    }
+    for _, v := range p.Results {
+      if err := v.Write(oprot); err != nil {
+        return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+      }
+    }
+    if err := oprot.WriteListEnd(); err != nil {
+      return thrift.PrependError("error writing list end: ", err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:completeRequest: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:results: ", p), err) }
   }
   return err
 }
 
-func (p *RespondActivityTaskCompletedRequest) String() string {
+func (p *BatchGetWorkflowExecutionNextEventIDResponse) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RespondActivityTaskCompletedRequest(%+v)", *p)
+  return fmt.Sprintf("BatchGetWorkflowExecutionNextEventIDResponse(%+v)", *p)
 }
 
 // Attributes:
 //  - DomainUUID
-//  - FailedRequest
-type RespondActivityTaskFailedRequest struct {
+//  - CompleteRequest
+type RespondDecisionTaskCompletedRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
   // unused fields # 11 to 19
-  FailedRequest *shared.RespondActivityTaskFailedRequest `thrift:"failedRequest,20" db:"failedRequest" json:"failedRequest,omitempty"`
+  CompleteRequest *shared.RespondDecisionTaskCompletedRequest `thrift:"completeRequest,20" db:"completeRequest" json:"completeRequest,omitempty"`
 }
 
-func NewRespondActivityTaskFailedRequest() *RespondActivityTaskFailedRequest {
-  return &RespondActivityTaskFailedRequest{}
+func NewRespondDecisionTaskCompletedRequest() *RespondDecisionTaskCompletedRequest {
+  return &RespondDecisionTaskCompletedRequest{}
 }
 
-var RespondActivityTaskFailedRequest_DomainUUID_DEFAULT string
-func (p *RespondActivityTaskFailedRequest) GetDomainUUID() string {
+var RespondDecisionTaskCompletedRequest_DomainUUID_DEFAULT string
+func (p *RespondDecisionTaskCompletedRequest) GetDomainUUID() string {
   if !p.IsSetDomainUUID() {
-    return RespondActivityTaskFailedRequest_DomainUUID_DEFAULT
+    return RespondDecisionTaskCompletedRequest_DomainUUID_DEFAULT
   }
 return *p.DomainUUID
 }
-var RespondActivityTaskFailedRequest_FailedRequest_DEFAULT *shared.RespondActivityTaskFailedRequest
-func (p *RespondActivityTaskFailedRequest) GetFailedRequest() *shared.RespondActivityTaskFailedRequest {
-  if !p.IsSetFailedRequest() {
-    return RespondActivityTaskFailedRequest_FailedRequest_DEFAULT
+var RespondDecisionTaskCompletedRequest_CompleteRequest_DEFAULT *shared.RespondDecisionTaskCompletedRequest
+func (p *RespondDecisionTaskCompletedRequest) GetCompleteRequest() *shared.RespondDecisionTaskCompletedRequest {
+  if !p.IsSetCompleteRequest() {
+    return RespondDecisionTaskCompletedRequest_CompleteRequest_DEFAULT
   }
-return p.FailedRequest
+return p.CompleteRequest
 }
-func (p *RespondActivityTaskFailedRequest) IsSetDomainUUID() bool {
+func (p *RespondDecisionTaskCompletedRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
 
-func (p *RespondActivityTaskFailedRequest) IsSetFailedRequest() bool {
-  return p.FailedRequest != nil
+func (p *RespondDecisionTaskCompletedRequest) IsSetCompleteRequest() bool {
+  return p.CompleteRequest != nil
 }
 
-func (p *RespondActivityTaskFailedRequest) Read(iprot thrift.TProtocol) error {
+func (p *RespondDecisionTaskCompletedRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -1367,7 +1591,7 @@ func (p *RespondActivityTaskFailedRequest) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *RespondActivityTaskFailedRequest)  ReadField10(iprot thrift.TProtocol) error {
+func (p *RespondDecisionTaskCompletedRequest)  ReadField10(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadString(); err != nil {
   return thrift.PrependError("error reading field 10: ", err)
 } else {
@@ -1376,16 +1600,16 @@ func (p *RespondActivityTaskFailedRequest)  ReadField10(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *RespondActivityTaskFailedRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.FailedRequest = &shared.RespondActivityTaskFailedRequest{}
-  if err := p.FailedRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.FailedRequest), err)
+func (p *RespondDecisionTaskCompletedRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.CompleteRequest = &shared.RespondDecisionTaskCompletedRequest{}
+  if err := p.CompleteRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
   }
   return nil
 }
 
-func (p *RespondActivityTaskFailedRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskFailedRequest"); err != nil {
+func (p *RespondDecisionTaskCompletedRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondDecisionTaskCompletedRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
@@ -1398,7 +1622,7 @@ func (p *RespondActivityTaskFailedRequest) Write(oprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *RespondActivityTaskFailedRequest) writeField10(oprot thrift.TProtocol) (err error) {
+func (p *RespondDecisionTaskCompletedRequest) writeField10(oprot thrift.TProtocol) (err error) {
   if p.IsSetDomainUUID() {
     if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
@@ -1410,63 +1634,63 @@ func (p *RespondActivityTaskFailedRequest) writeField10(oprot thrift.TProtocol)
   return err
 }
 
-func (p *RespondActivityTaskFailedRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetFailedRequest() {
-    if err := oprot.WriteFieldBegin("failedRequest", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:failedRequest: ", p), err) }
-    if err := p.FailedRequest.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.FailedRequest), err)
+func (p *RespondDecisionTaskCompletedRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetCompleteRequest() {
+    if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:completeRequest: ", p), err) }
+    if err := p.CompleteRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:failedRequest: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:completeRequest: ", p), err) }
   }
   return err
 }
 
-func (p *RespondActivityTaskFailedRequest) String() string {
+func (p *RespondDecisionTaskCompletedRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RespondActivityTaskFailedRequest(%+v)", *p)
+  return fmt.Sprintf("RespondDecisionTaskCompletedRequest(%+v)", *p)
 }
 
 // Attributes:
 //  - DomainUUID
-//  - CancelRequest
-type RespondActivityTaskCanceledRequest struct {
+//  - HeartbeatRequest
+type RecordActivityTaskHeartbeatRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
   // unused fields # 11 to 19
-  CancelRequest *shared.RespondActivityTaskCanceledRequest `thrift:"cancelRequest,20" db:"cancelRequest" json:"cancelRequest,omitempty"`
+  HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `thrift:"heartbeatRequest,20" db:"heartbeatRequest" json:"heartbeatRequest,omitempty"`
 }
 
-func NewRespondActivityTaskCanceledRequest() *RespondActivityTaskCanceledRequest {
-  return &RespondActivityTaskCanceledRequest{}
+func NewRecordActivityTaskHeartbeatRequest() *RecordActivityTaskHeartbeatRequest {
+  return &RecordActivityTaskHeartbeatRequest{}
 }
 
-var RespondActivityTaskCanceledRequest_DomainUUID_DEFAULT string
-func (p *RespondActivityTaskCanceledRequest) GetDomainUUID() string {
+var RecordActivityTaskHeartbeatRequest_DomainUUID_DEFAULT string
+func (p *RecordActivityTaskHeartbeatRequest) GetDomainUUID() string {
   if !p.IsSetDomainUUID() {
-    return RespondActivityTaskCanceledRequest_DomainUUID_DEFAULT
+    return RecordActivityTaskHeartbeatRequest_DomainUUID_DEFAULT
   }
 return *p.DomainUUID
 }
-var RespondActivityTaskCanceledRequest_CancelRequest_DEFAULT *shared.RespondActivityTaskCanceledRequest
-func (p *RespondActivityTaskCanceledRequest) GetCancelRequest() *shared.RespondActivityTaskCanceledRequest {
-  if !p.IsSetCancelRequest() {
-    return RespondActivityTaskCanceledRequest_CancelRequest_DEFAULT
+var RecordActivityTaskHeartbeatRequest_HeartbeatRequest_DEFAULT *shared.RecordActivityTaskHeartbeatRequest
+func (p *RecordActivityTaskHeartbeatRequest) GetHeartbeatRequest() *shared.RecordActivityTaskHeartbeatRequest {
+  if !p.IsSetHeartbeatRequest() {
+    return RecordActivityTaskHeartbeatRequest_HeartbeatRequest_DEFAULT
   }
-return p.CancelRequest
+return p.HeartbeatRequest
 }
-func (p *RespondActivityTaskCanceledRequest) IsSetDomainUUID() bool {
+func (p *RecordActivityTaskHeartbeatRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
 
-func (p *RespondActivityTaskCanceledRequest) IsSetCancelRequest() bool {
-  return p.CancelRequest != nil
+func (p *RecordActivityTaskHeartbeatRequest) IsSetHeartbeatRequest() bool {
+  return p.HeartbeatRequest != nil
 }
 
-func (p *RespondActivityTaskCanceledRequest) Read(iprot thrift.TProtocol) error {
+func (p *RecordActivityTaskHeartbeatRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -1502,7 +1726,7 @@ func (p *RespondActivityTaskCanceledRequest) Read(iprot thrift.TProtocol) error
   return nil
 }
 
-func (p *RespondActivityTaskCanceledRequest)  ReadField10(iprot thrift.TProtocol) error {
+func (p *RecordActivityTaskHeartbeatRequest)  ReadField10(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadString(); err != nil {
   return thrift.PrependError("error reading field 10: ", err)
 } else {
@@ -1511,16 +1735,16 @@ func (p *RespondActivityTaskCanceledRequest)  ReadField10(iprot thrift.TProtocol
   return nil
 }
 
-func (p *RespondActivityTaskCanceledRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.CancelRequest = &shared.RespondActivityTaskCanceledRequest{}
-  if err := p.CancelRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CancelRequest), err)
+func (p *RecordActivityTaskHeartbeatRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.HeartbeatRequest = &shared.RecordActivityTaskHeartbeatRequest{}
+  if err := p.HeartbeatRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.HeartbeatRequest), err)
   }
   return nil
 }
 
-func (p *RespondActivityTaskCanceledRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCanceledRequest"); err != nil {
+func (p *RecordActivityTaskHeartbeatRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeatRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
@@ -1533,7 +1757,7 @@ func (p *RespondActivityTaskCanceledRequest) Write(oprot thrift.TProtocol) error
   return nil
 }
 
-func (p *RespondActivityTaskCanceledRequest) writeField10(oprot thrift.TProtocol) (err error) {
+func (p *RecordActivityTaskHeartbeatRequest) writeField10(oprot thrift.TProtocol) (err error) {
   if p.IsSetDomainUUID() {
     if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
@@ -1545,119 +1769,63 @@ func (p *RespondActivityTaskCanceledRequest) writeField10(oprot thrift.TProtocol
   return err
 }
 
-func (p *RespondActivityTaskCanceledRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetCancelRequest() {
-    if err := oprot.WriteFieldBegin("cancelRequest", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:cancelRequest: ", p), err) }
-    if err := p.CancelRequest.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CancelRequest), err)
+func (p *RecordActivityTaskHeartbeatRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetHeartbeatRequest() {
+    if err := oprot.WriteFieldBegin("heartbeatRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:heartbeatRequest: ", p), err) }
+    if err := p.HeartbeatRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.HeartbeatRequest), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:cancelRequest: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:heartbeatRequest: ", p), err) }
   }
   return err
 }
 
-func (p *RespondActivityTaskCanceledRequest) String() string {
+func (p *RecordActivityTaskHeartbeatRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RespondActivityTaskCanceledRequest(%+v)", *p)
+  return fmt.Sprintf("RecordActivityTaskHeartbeatRequest(%+v)", *p)
 }
 
 // Attributes:
 //  - DomainUUID
-//  - WorkflowExecution
-//  - ScheduleId
-//  - TaskId
-//  - RequestId
-//  - PollRequest
-type RecordActivityTaskStartedRequest struct {
+//  - CompleteRequest
+type RespondActivityTaskCompletedRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
   // unused fields # 11 to 19
-  WorkflowExecution *shared.WorkflowExecution `thrift:"workflowExecution,20" db:"workflowExecution" json:"workflowExecution,omitempty"`
-  // unused fields # 21 to 29
-  ScheduleId *int64 `thrift:"scheduleId,30" db:"scheduleId" json:"scheduleId,omitempty"`
-  // unused fields # 31 to 39
-  TaskId *int64 `thrift:"taskId,40" db:"taskId" json:"taskId,omitempty"`
-  // unused fields # 41 to 44
-  RequestId *string `thrift:"requestId,45" db:"requestId" json:"requestId,omitempty"`
-  // unused fields # 46 to 49
-  PollRequest *shared.PollForActivityTaskRequest `thrift:"pollRequest,50" db:"pollRequest" json:"pollRequest,omitempty"`
+  CompleteRequest *shared.RespondActivityTaskCompletedRequest `thrift:"completeRequest,20" db:"completeRequest" json:"completeRequest,omitempty"`
 }
 
-func NewRecordActivityTaskStartedRequest() *RecordActivityTaskStartedRequest {
-  return &RecordActivityTaskStartedRequest{}
+func NewRespondActivityTaskCompletedRequest() *RespondActivityTaskCompletedRequest {
+  return &RespondActivityTaskCompletedRequest{}
 }
 
-var RecordActivityTaskStartedRequest_DomainUUID_DEFAULT string
-func (p *RecordActivityTaskStartedRequest) GetDomainUUID() string {
+var RespondActivityTaskCompletedRequest_DomainUUID_DEFAULT string
+func (p *RespondActivityTaskCompletedRequest) GetDomainUUID() string {
   if !p.IsSetDomainUUID() {
-    return RecordActivityTaskStartedRequest_DomainUUID_DEFAULT
+    return RespondActivityTaskCompletedRequest_DomainUUID_DEFAULT
   }
 return *p.DomainUUID
 }
-var RecordActivityTaskStartedRequest_WorkflowExecution_DEFAULT *shared.WorkflowExecution
-func (p *RecordActivityTaskStartedRequest) GetWorkflowExecution() *shared.WorkflowExecution {
-  if !p.IsSetWorkflowExecution() {
-    return RecordActivityTaskStartedRequest_WorkflowExecution_DEFAULT
-  }
-return p.WorkflowExecution
-}
-var RecordActivityTaskStartedRequest_ScheduleId_DEFAULT int64
-func (p *RecordActivityTaskStartedRequest) GetScheduleId() int64 {
-  if !p.IsSetScheduleId() {
-    return RecordActivityTaskStartedRequest_ScheduleId_DEFAULT
-  }
-return *p.ScheduleId
-}
-var RecordActivityTaskStartedRequest_TaskId_DEFAULT int64
-func (p *RecordActivityTaskStartedRequest) GetTaskId() int64 {
-  if !p.IsSetTaskId() {
-    return RecordActivityTaskStartedRequest_TaskId_DEFAULT
-  }
-return *p.TaskId
-}
-var RecordActivityTaskStartedRequest_RequestId_DEFAULT string
-func (p *RecordActivityTaskStartedRequest) GetRequestId() string {
-  if !p.IsSetRequestId() {
-    return RecordActivityTaskStartedRequest_RequestId_DEFAULT
-  }
-return *p.RequestId
-}
-var RecordActivityTaskStartedRequest_PollRequest_DEFAULT *shared.PollForActivityTaskRequest
-func (p *RecordActivityTaskStartedRequest) GetPollRequest() *shared.PollForActivityTaskRequest {
-  if !p.IsSetPollRequest() {
-    return RecordActivityTaskStartedRequest_PollRequest_DEFAULT
+var RespondActivityTaskCompletedRequest_CompleteRequest_DEFAULT *shared.RespondActivityTaskCompletedRequest
+func (p *RespondActivityTaskCompletedRequest) GetCompleteRequest() *shared.RespondActivityTaskCompletedRequest {
+  if !p.IsSetCompleteRequest() {
+    return RespondActivityTaskCompletedRequest_CompleteRequest_DEFAULT
   }
-return p.PollRequest
+return p.CompleteRequest
 }
-func (p *RecordActivityTaskStartedRequest) IsSetDomainUUID() bool {
+func (p *RespondActivityTaskCompletedRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
 
-func (p *RecordActivityTaskStartedRequest) IsSetWorkflowExecution() bool {
-  return p.WorkflowExecution != nil
-}
-
-func (p *RecordActivityTaskStartedRequest) IsSetScheduleId() bool {
-  return p.ScheduleId != nil
-}
-
-func (p *RecordActivityTaskStartedRequest) IsSetTaskId() bool {
-  return p.TaskId != nil
-}
-
-func (p *RecordActivityTaskStartedRequest) IsSetRequestId() bool {
-  return p.RequestId != nil
-}
-
-func (p *RecordActivityTaskStartedRequest) IsSetPollRequest() bool {
-  return p.PollRequest != nil
+func (p *RespondActivityTaskCompletedRequest) IsSetCompleteRequest() bool {
+  return p.CompleteRequest != nil
 }
 
-func (p *RecordActivityTaskStartedRequest) Read(iprot thrift.TProtocol) error {
+func (p *RespondActivityTaskCompletedRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -1678,22 +1846,6 @@ func (p *RecordActivityTaskStartedRequest) Read(iprot thrift.TProtocol) error {
       if err := p.ReadField20(iprot); err != nil {
         return err
       }
-    case 30:
-      if err := p.ReadField30(iprot); err != nil {
-        return err
-      }
-    case 40:
-      if err := p.ReadField40(iprot); err != nil {
-        return err
-      }
-    case 45:
-      if err := p.ReadField45(iprot); err != nil {
-        return err
-      }
-    case 50:
-      if err := p.ReadField50(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -1709,7 +1861,7 @@ func (p *RecordActivityTaskStartedRequest) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *RecordActivityTaskStartedRequest)  ReadField10(iprot thrift.TProtocol) error {
+func (p *RespondActivityTaskCompletedRequest)  ReadField10(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadString(); err != nil {
   return thrift.PrependError("error reading field 10: ", err)
 } else {
@@ -1718,59 +1870,20 @@ func (p *RecordActivityTaskStartedRequest)  ReadField10(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *RecordActivityTaskStartedRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.WorkflowExecution = &shared.WorkflowExecution{}
-  if err := p.WorkflowExecution.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowExecution), err)
-  }
-  return nil
-}
-
-func (p *RecordActivityTaskStartedRequest)  ReadField30(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadI64(); err != nil {
-  return thrift.PrependError("error reading field 30: ", err)
-} else {
-  p.ScheduleId = &v
-}
-  return nil
-}
-
-func (p *RecordActivityTaskStartedRequest)  ReadField40(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadI64(); err != nil {
-  return thrift.PrependError("error reading field 40: ", err)
-} else {
-  p.TaskId = &v
-}
-  return nil
-}
-
-func (p *RecordActivityTaskStartedRequest)  ReadField45(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadString(); err != nil {
-  return thrift.PrependError("error reading field 45: ", err)
-} else {
-  p.RequestId = &v
-}
-  return nil
-}
-
-func (p *RecordActivityTaskStartedRequest)  ReadField50(iprot thrift.TProtocol) error {
-  p.PollRequest = &shared.PollForActivityTaskRequest{}
-  if err := p.PollRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PollRequest), err)
+func (p *RespondActivityTaskCompletedRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.CompleteRequest = &shared.RespondActivityTaskCompletedRequest{}
+  if err := p.CompleteRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
   }
   return nil
 }
 
-func (p *RecordActivityTaskStartedRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordActivityTaskStartedRequest"); err != nil {
+func (p *RespondActivityTaskCompletedRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCompletedRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
     if err := p.writeField20(oprot); err != nil { return err }
-    if err := p.writeField30(oprot); err != nil { return err }
-    if err := p.writeField40(oprot); err != nil { return err }
-    if err := p.writeField45(oprot); err != nil { return err }
-    if err := p.writeField50(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -1779,7 +1892,7 @@ func (p *RecordActivityTaskStartedRequest) Write(oprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *RecordActivityTaskStartedRequest) writeField10(oprot thrift.TProtocol) (err error) {
+func (p *RespondActivityTaskCompletedRequest) writeField10(oprot thrift.TProtocol) (err error) {
   if p.IsSetDomainUUID() {
     if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
@@ -1791,112 +1904,63 @@ func (p *RecordActivityTaskStartedRequest) writeField10(oprot thrift.TProtocol)
   return err
 }
 
-func (p *RecordActivityTaskStartedRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetWorkflowExecution() {
-    if err := oprot.WriteFieldBegin("workflowExecution", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:workflowExecution: ", p), err) }
-    if err := p.WorkflowExecution.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowExecution), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:workflowExecution: ", p), err) }
-  }
-  return err
-}
-
-func (p *RecordActivityTaskStartedRequest) writeField30(oprot thrift.TProtocol) (err error) {
-  if p.IsSetScheduleId() {
-    if err := oprot.WriteFieldBegin("scheduleId", thrift.I64, 30); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:scheduleId: ", p), err) }
-    if err := oprot.WriteI64(int64(*p.ScheduleId)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.scheduleId (30) field write error: ", p), err) }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:scheduleId: ", p), err) }
-  }
-  return err
-}
-
-func (p *RecordActivityTaskStartedRequest) writeField40(oprot thrift.TProtocol) (err error) {
-  if p.IsSetTaskId() {
-    if err := oprot.WriteFieldBegin("taskId", thrift.I64, 40); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:taskId: ", p), err) }
-    if err := oprot.WriteI64(int64(*p.TaskId)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.taskId (40) field write error: ", p), err) }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 40:taskId: ", p), err) }
-  }
-  return err
-}
-
-func (p *RecordActivityTaskStartedRequest) writeField45(oprot thrift.TProtocol) (err error) {
-  if p.IsSetRequestId() {
-    if err := oprot.WriteFieldBegin("requestId", thrift.STRING, 45); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 45:requestId: ", p), err) }
-    if err := oprot.WriteString(string(*p.RequestId)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.requestId (45) field write error: ", p), err) }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 45:requestId: ", p), err) }
-  }
-  return err
-}
-
-func (p *RecordActivityTaskStartedRequest) writeField50(oprot thrift.TProtocol) (err error) {
-  if p.IsSetPollRequest() {
-    if err := oprot.WriteFieldBegin("pollRequest", thrift.STRUCT, 50); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 50:pollRequest: ", p), err) }
-    if err := p.PollRequest.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PollRequest), err)
+func (p *RespondActivityTaskCompletedRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetCompleteRequest() {
+    if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:completeRequest: ", p), err) }
+    if err := p.CompleteRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 50:pollRequest: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:completeRequest: ", p), err) }
   }
   return err
 }
 
-func (p *RecordActivityTaskStartedRequest) String() string {
+func (p *RespondActivityTaskCompletedRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RecordActivityTaskStartedRequest(%+v)", *p)
+  return fmt.Sprintf("RespondActivityTaskCompletedRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - StartedEvent
-//  - ScheduledEvent
-type RecordActivityTaskStartedResponse struct {
+//  - DomainUUID
+//  - FailedRequest
+type RespondActivityTaskFailedRequest struct {
   // unused fields # 1 to 9
-  StartedEvent *shared.HistoryEvent `thrift:"startedEvent,10" db:"startedEvent" json:"startedEvent,omitempty"`
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
   // unused fields # 11 to 19
-  ScheduledEvent *shared.HistoryEvent `thrift:"scheduledEvent,20" db:"scheduledEvent" json:"scheduledEvent,omitempty"`
+  FailedRequest *shared.RespondActivityTaskFailedRequest `thrift:"failedRequest,20" db:"failedRequest" json:"failedRequest,omitempty"`
 }
 
-func NewRecordActivityTaskStartedResponse() *RecordActivityTaskStartedResponse {
-  return &RecordActivityTaskStartedResponse{}
+func NewRespondActivityTaskFailedRequest() *RespondActivityTaskFailedRequest {
+  return &RespondActivityTaskFailedRequest{}
 }
 
-var RecordActivityTaskStartedResponse_StartedEvent_DEFAULT *shared.HistoryEvent
-func (p *RecordActivityTaskStartedResponse) GetStartedEvent() *shared.HistoryEvent {
-  if !p.IsSetStartedEvent() {
-    return RecordActivityTaskStartedResponse_StartedEvent_DEFAULT
+var RespondActivityTaskFailedRequest_DomainUUID_DEFAULT string
+func (p *RespondActivityTaskFailedRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return RespondActivityTaskFailedRequest_DomainUUID_DEFAULT
   }
-return p.StartedEvent
+return *p.DomainUUID
 }
-var RecordActivityTaskStartedResponse_ScheduledEvent_DEFAULT *shared.HistoryEvent
-func (p *RecordActivityTaskStartedResponse) GetScheduledEvent() *shared.HistoryEvent {
-  if !p.IsSetScheduledEvent() {
-    return RecordActivityTaskStartedResponse_ScheduledEvent_DEFAULT
+var RespondActivityTaskFailedRequest_FailedRequest_DEFAULT *shared.RespondActivityTaskFailedRequest
+func (p *RespondActivityTaskFailedRequest) GetFailedRequest() *shared.RespondActivityTaskFailedRequest {
+  if !p.IsSetFailedRequest() {
+    return RespondActivityTaskFailedRequest_FailedRequest_DEFAULT
   }
-return p.ScheduledEvent
+return p.FailedRequest
 }
-func (p *RecordActivityTaskStartedResponse) IsSetStartedEvent() bool {
-  return p.StartedEvent != nil
+func (p *RespondActivityTaskFailedRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
 }
 
-func (p *RecordActivityTaskStartedResponse) IsSetScheduledEvent() bool {
-  return p.ScheduledEvent != nil
+func (p *RespondActivityTaskFailedRequest) IsSetFailedRequest() bool {
+  return p.FailedRequest != nil
 }
 
-func (p *RecordActivityTaskStartedResponse) Read(iprot thrift.TProtocol) error {
+func (p *RespondActivityTaskFailedRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -1932,24 +1996,25 @@ func (p *RecordActivityTaskStartedResponse) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *RecordActivityTaskStartedResponse)  ReadField10(iprot thrift.TProtocol) error {
-  p.StartedEvent = &shared.HistoryEvent{}
-  if err := p.StartedEvent.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.StartedEvent), err)
-  }
+func (p *RespondActivityTaskFailedRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
   return nil
 }
 
-func (p *RecordActivityTaskStartedResponse)  ReadField20(iprot thrift.TProtocol) error {
-  p.ScheduledEvent = &shared.HistoryEvent{}
-  if err := p.ScheduledEvent.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ScheduledEvent), err)
+func (p *RespondActivityTaskFailedRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.FailedRequest = &shared.RespondActivityTaskFailedRequest{}
+  if err := p.FailedRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.FailedRequest), err)
   }
   return nil
 }
 
-func (p *RecordActivityTaskStartedResponse) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordActivityTaskStartedResponse"); err != nil {
+func (p *RespondActivityTaskFailedRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskFailedRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
@@ -1962,37 +2027,171 @@ func (p *RecordActivityTaskStartedResponse) Write(oprot thrift.TProtocol) error
   return nil
 }
 
-func (p *RecordActivityTaskStartedResponse) writeField10(oprot thrift.TProtocol) (err error) {
-  if p.IsSetStartedEvent() {
-    if err := oprot.WriteFieldBegin("startedEvent", thrift.STRUCT, 10); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:startedEvent: ", p), err) }
-    if err := p.StartedEvent.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.StartedEvent), err)
+func (p *RespondActivityTaskFailedRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *RespondActivityTaskFailedRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetFailedRequest() {
+    if err := oprot.WriteFieldBegin("failedRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:failedRequest: ", p), err) }
+    if err := p.FailedRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.FailedRequest), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:startedEvent: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:failedRequest: ", p), err) }
   }
   return err
 }
 
-func (p *RecordActivityTaskStartedResponse) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetScheduledEvent() {
-    if err := oprot.WriteFieldBegin("scheduledEvent", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:scheduledEvent: ", p), err) }
-    if err := p.ScheduledEvent.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ScheduledEvent), err)
+func (p *RespondActivityTaskFailedRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("RespondActivityTaskFailedRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - DomainUUID
+//  - CancelRequest
+type RespondActivityTaskCanceledRequest struct {
+  // unused fields # 1 to 9
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 11 to 19
+  CancelRequest *shared.RespondActivityTaskCanceledRequest `thrift:"cancelRequest,20" db:"cancelRequest" json:"cancelRequest,omitempty"`
+}
+
+func NewRespondActivityTaskCanceledRequest() *RespondActivityTaskCanceledRequest {
+  return &RespondActivityTaskCanceledRequest{}
+}
+
+var RespondActivityTaskCanceledRequest_DomainUUID_DEFAULT string
+func (p *RespondActivityTaskCanceledRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return RespondActivityTaskCanceledRequest_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var RespondActivityTaskCanceledRequest_CancelRequest_DEFAULT *shared.RespondActivityTaskCanceledRequest
+func (p *RespondActivityTaskCanceledRequest) GetCancelRequest() *shared.RespondActivityTaskCanceledRequest {
+  if !p.IsSetCancelRequest() {
+    return RespondActivityTaskCanceledRequest_CancelRequest_DEFAULT
+  }
+return p.CancelRequest
+}
+func (p *RespondActivityTaskCanceledRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *RespondActivityTaskCanceledRequest) IsSetCancelRequest() bool {
+  return p.CancelRequest != nil
+}
+
+func (p *RespondActivityTaskCanceledRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *RespondActivityTaskCanceledRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *RespondActivityTaskCanceledRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.CancelRequest = &shared.RespondActivityTaskCanceledRequest{}
+  if err := p.CancelRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CancelRequest), err)
+  }
+  return nil
+}
+
+func (p *RespondActivityTaskCanceledRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCanceledRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *RespondActivityTaskCanceledRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *RespondActivityTaskCanceledRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetCancelRequest() {
+    if err := oprot.WriteFieldBegin("cancelRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:cancelRequest: ", p), err) }
+    if err := p.CancelRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CancelRequest), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:scheduledEvent: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:cancelRequest: ", p), err) }
   }
   return err
 }
 
-func (p *RecordActivityTaskStartedResponse) String() string {
+func (p *RespondActivityTaskCanceledRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RecordActivityTaskStartedResponse(%+v)", *p)
+  return fmt.Sprintf("RespondActivityTaskCanceledRequest(%+v)", *p)
 }
 
 // Attributes:
@@ -2002,7 +2201,7 @@ func (p *RecordActivityTaskStartedResponse) String() string {
 //  - TaskId
 //  - RequestId
 //  - PollRequest
-type RecordDecisionTaskStartedRequest struct {
+type RecordActivityTaskStartedRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
   // unused fields # 11 to 19
@@ -2014,80 +2213,80 @@ type RecordDecisionTaskStartedRequest struct {
   // unused fields # 41 to 44
   RequestId *string `thrift:"requestId,45" db:"requestId" json:"requestId,omitempty"`
   // unused fields # 46 to 49
-  PollRequest *shared.PollForDecisionTaskRequest `thrift:"pollRequest,50" db:"pollRequest" json:"pollRequest,omitempty"`
+  PollRequest *shared.PollForActivityTaskRequest `thrift:"pollRequest,50" db:"pollRequest" json:"pollRequest,omitempty"`
 }
 
-func NewRecordDecisionTaskStartedRequest() *RecordDecisionTaskStartedRequest {
-  return &RecordDecisionTaskStartedRequest{}
+func NewRecordActivityTaskStartedRequest() *RecordActivityTaskStartedRequest {
+  return &RecordActivityTaskStartedRequest{}
 }
 
-var RecordDecisionTaskStartedRequest_DomainUUID_DEFAULT string
-func (p *RecordDecisionTaskStartedRequest) GetDomainUUID() string {
+var RecordActivityTaskStartedRequest_DomainUUID_DEFAULT string
+func (p *RecordActivityTaskStartedRequest) GetDomainUUID() string {
   if !p.IsSetDomainUUID() {
-    return RecordDecisionTaskStartedRequest_DomainUUID_DEFAULT
+    return RecordActivityTaskStartedRequest_DomainUUID_DEFAULT
   }
 return *p.DomainUUID
 }
-var RecordDecisionTaskStartedRequest_WorkflowExecution_DEFAULT *shared.WorkflowExecution
-func (p *RecordDecisionTaskStartedRequest) GetWorkflowExecution() *shared.WorkflowExecution {
+var RecordActivityTaskStartedRequest_WorkflowExecution_DEFAULT *shared.WorkflowExecution
+func (p *RecordActivityTaskStartedRequest) GetWorkflowExecution() *shared.WorkflowExecution {
   if !p.IsSetWorkflowExecution() {
-    return RecordDecisionTaskStartedRequest_WorkflowExecution_DEFAULT
+    return RecordActivityTaskStartedRequest_WorkflowExecution_DEFAULT
   }
 return p.WorkflowExecution
 }
-var RecordDecisionTaskStartedRequest_ScheduleId_DEFAULT int64
-func (p *RecordDecisionTaskStartedRequest) GetScheduleId() int64 {
+var RecordActivityTaskStartedRequest_ScheduleId_DEFAULT int64
+func (p *RecordActivityTaskStartedRequest) GetScheduleId() int64 {
   if !p.IsSetScheduleId() {
-    return RecordDecisionTaskStartedRequest_ScheduleId_DEFAULT
+    return RecordActivityTaskStartedRequest_ScheduleId_DEFAULT
   }
 return *p.ScheduleId
 }
-var RecordDecisionTaskStartedRequest_TaskId_DEFAULT int64
-func (p *RecordDecisionTaskStartedRequest) GetTaskId() int64 {
+var RecordActivityTaskStartedRequest_TaskId_DEFAULT int64
+func (p *RecordActivityTaskStartedRequest) GetTaskId() int64 {
   if !p.IsSetTaskId() {
-    return RecordDecisionTaskStartedRequest_TaskId_DEFAULT
+    return RecordActivityTaskStartedRequest_TaskId_DEFAULT
   }
 return *p.TaskId
 }
-var RecordDecisionTaskStartedRequest_RequestId_DEFAULT string
-func (p *RecordDecisionTaskStartedRequest) GetRequestId() string {
+var RecordActivityTaskStartedRequest_RequestId_DEFAULT string
+func (p *RecordActivityTaskStartedRequest) GetRequestId() string {
   if !p.IsSetRequestId() {
-    return RecordDecisionTaskStartedRequest_RequestId_DEFAULT
+    return RecordActivityTaskStartedRequest_RequestId_DEFAULT
   }
 return *p.RequestId
 }
-var RecordDecisionTaskStartedRequest_PollRequest_DEFAULT *shared.PollForDecisionTaskRequest
-func (p *RecordDecisionTaskStartedRequest) GetPollRequest() *shared.PollForDecisionTaskRequest {
+var RecordActivityTaskStartedRequest_PollRequest_DEFAULT *shared.PollForActivityTaskRequest
+func (p *RecordActivityTaskStartedRequest) GetPollRequest() *shared.PollForActivityTaskRequest {
   if !p.IsSetPollRequest() {
-    return RecordDecisionTaskStartedRequest_PollRequest_DEFAULT
+    return RecordActivityTaskStartedRequest_PollRequest_DEFAULT
   }
 return p.PollRequest
 }
-func (p *RecordDecisionTaskStartedRequest) IsSetDomainUUID() bool {
+func (p *RecordActivityTaskStartedRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
 
-func (p *RecordDecisionTaskStartedRequest) IsSetWorkflowExecution() bool {
+func (p *RecordActivityTaskStartedRequest) IsSetWorkflowExecution() bool {
   return p.WorkflowExecution != nil
 }
 
-func (p *RecordDecisionTaskStartedRequest) IsSetScheduleId() bool {
+func (p *RecordActivityTaskStartedRequest) IsSetScheduleId() bool {
   return p.ScheduleId != nil
 }
 
-func (p *RecordDecisionTaskStartedRequest) IsSetTaskId() bool {
+func (p *RecordActivityTaskStartedRequest) IsSetTaskId() bool {
   return p.TaskId != nil
 }
 
-func (p *RecordDecisionTaskStartedRequest) IsSetRequestId() bool {
+func (p *RecordActivityTaskStartedRequest) IsSetRequestId() bool {
   return p.RequestId != nil
 }
 
-func (p *RecordDecisionTaskStartedRequest) IsSetPollRequest() bool {
+func (p *RecordActivityTaskStartedRequest) IsSetPollRequest() bool {
   return p.PollRequest != nil
 }
 
-func (p *RecordDecisionTaskStartedRequest) Read(iprot thrift.TProtocol) error {
+func (p *RecordActivityTaskStartedRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -2139,7 +2338,7 @@ func (p *RecordDecisionTaskStartedRequest) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *RecordDecisionTaskStartedRequest)  ReadField10(iprot thrift.TProtocol) error {
+func (p *RecordActivityTaskStartedRequest)  ReadField10(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadString(); err != nil {
   return thrift.PrependError("error reading field 10: ", err)
 } else {
@@ -2148,7 +2347,7 @@ func (p *RecordDecisionTaskStartedRequest)  ReadField10(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *RecordDecisionTaskStartedRequest)  ReadField20(iprot thrift.TProtocol) error {
+func (p *RecordActivityTaskStartedRequest)  ReadField20(iprot thrift.TProtocol) error {
   p.WorkflowExecution = &shared.WorkflowExecution{}
   if err := p.WorkflowExecution.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowExecution), err)
@@ -2156,7 +2355,7 @@ func (p *RecordDecisionTaskStartedRequest)  ReadField20(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *RecordDecisionTaskStartedRequest)  ReadField30(iprot thrift.TProtocol) error {
+func (p *RecordActivityTaskStartedRequest)  ReadField30(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadI64(); err != nil {
   return thrift.PrependError("error reading field 30: ", err)
 } else {
@@ -2165,7 +2364,7 @@ func (p *RecordDecisionTaskStartedRequest)  ReadField30(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *RecordDecisionTaskStartedRequest)  ReadField40(iprot thrift.TProtocol) error {
+func (p *RecordActivityTaskStartedRequest)  ReadField40(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadI64(); err != nil {
   return thrift.PrependError("error reading field 40: ", err)
 } else {
@@ -2174,7 +2373,7 @@ func (p *RecordDecisionTaskStartedRequest)  ReadField40(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *RecordDecisionTaskStartedRequest)  ReadField45(iprot thrift.TProtocol) error {
+func (p *RecordActivityTaskStartedRequest)  ReadField45(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadString(); err != nil {
   return thrift.PrependError("error reading field 45: ", err)
 } else {
@@ -2183,16 +2382,16 @@ func (p *RecordDecisionTaskStartedRequest)  ReadField45(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *RecordDecisionTaskStartedRequest)  ReadField50(iprot thrift.TProtocol) error {
-  p.PollRequest = &shared.PollForDecisionTaskRequest{}
+func (p *RecordActivityTaskStartedRequest)  ReadField50(iprot thrift.TProtocol) error {
+  p.PollRequest = &shared.PollForActivityTaskRequest{}
   if err := p.PollRequest.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PollRequest), err)
   }
   return nil
 }
 
-func (p *RecordDecisionTaskStartedRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordDecisionTaskStartedRequest"); err != nil {
+func (p *RecordActivityTaskStartedRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordActivityTaskStartedRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
@@ -2209,7 +2408,7 @@ func (p *RecordDecisionTaskStartedRequest) Write(oprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *RecordDecisionTaskStartedRequest) writeField10(oprot thrift.TProtocol) (err error) {
+func (p *RecordActivityTaskStartedRequest) writeField10(oprot thrift.TProtocol) (err error) {
   if p.IsSetDomainUUID() {
     if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
@@ -2221,7 +2420,7 @@ func (p *RecordDecisionTaskStartedRequest) writeField10(oprot thrift.TProtocol)
   return err
 }
 
-func (p *RecordDecisionTaskStartedRequest) writeField20(oprot thrift.TProtocol) (err error) {
+func (p *RecordActivityTaskStartedRequest) writeField20(oprot thrift.TProtocol) (err error) {
   if p.IsSetWorkflowExecution() {
     if err := oprot.WriteFieldBegin("workflowExecution", thrift.STRUCT, 20); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:workflowExecution: ", p), err) }
@@ -2234,7 +2433,7 @@ func (p *RecordDecisionTaskStartedRequest) writeField20(oprot thrift.TProtocol)
   return err
 }
 
-func (p *RecordDecisionTaskStartedRequest) writeField30(oprot thrift.TProtocol) (err error) {
+func (p *RecordActivityTaskStartedRequest) writeField30(oprot thrift.TProtocol) (err error) {
   if p.IsSetScheduleId() {
     if err := oprot.WriteFieldBegin("scheduleId", thrift.I64, 30); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:scheduleId: ", p), err) }
@@ -2246,7 +2445,7 @@ func (p *RecordDecisionTaskStartedRequest) writeField30(oprot thrift.TProtocol)
   return err
 }
 
-func (p *RecordDecisionTaskStartedRequest) writeField40(oprot thrift.TProtocol) (err error) {
+func (p *RecordActivityTaskStartedRequest) writeField40(oprot thrift.TProtocol) (err error) {
   if p.IsSetTaskId() {
     if err := oprot.WriteFieldBegin("taskId", thrift.I64, 40); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:taskId: ", p), err) }
@@ -2258,7 +2457,7 @@ func (p *RecordDecisionTaskStartedRequest) writeField40(oprot thrift.TProtocol)
   return err
 }
 
-func (p *RecordDecisionTaskStartedRequest) writeField45(oprot thrift.TProtocol) (err error) {
+func (p *RecordActivityTaskStartedRequest) writeField45(oprot thrift.TProtocol) (err error) {
   if p.IsSetRequestId() {
     if err := oprot.WriteFieldBegin("requestId", thrift.STRING, 45); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 45:requestId: ", p), err) }
@@ -2270,7 +2469,7 @@ func (p *RecordDecisionTaskStartedRequest) writeField45(oprot thrift.TProtocol)
   return err
 }
 
-func (p *RecordDecisionTaskStartedRequest) writeField50(oprot thrift.TProtocol) (err error) {
+func (p *RecordActivityTaskStartedRequest) writeField50(oprot thrift.TProtocol) (err error) {
   if p.IsSetPollRequest() {
     if err := oprot.WriteFieldBegin("pollRequest", thrift.STRUCT, 50); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 50:pollRequest: ", p), err) }
@@ -2283,64 +2482,50 @@ func (p *RecordDecisionTaskStartedRequest) writeField50(oprot thrift.TProtocol)
   return err
 }
 
-func (p *RecordDecisionTaskStartedRequest) String() string {
+func (p *RecordActivityTaskStartedRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RecordDecisionTaskStartedRequest(%+v)", *p)
+  return fmt.Sprintf("RecordActivityTaskStartedRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - WorkflowType
-//  - PreviousStartedEventId
-//  - StartedEventId
-type RecordDecisionTaskStartedResponse struct {
+//  - StartedEvent
+//  - ScheduledEvent
+type RecordActivityTaskStartedResponse struct {
   // unused fields # 1 to 9
-  WorkflowType *shared.WorkflowType `thrift:"workflowType,10" db:"workflowType" json:"workflowType,omitempty"`
+  StartedEvent *shared.HistoryEvent `thrift:"startedEvent,10" db:"startedEvent" json:"startedEvent,omitempty"`
   // unused fields # 11 to 19
-  PreviousStartedEventId *int64 `thrift:"previousStartedEventId,20" db:"previousStartedEventId" json:"previousStartedEventId,omitempty"`
-  // unused fields # 21 to 29
-  StartedEventId *int64 `thrift:"startedEventId,30" db:"startedEventId" json:"startedEventId,omitempty"`
+  ScheduledEvent *shared.HistoryEvent `thrift:"scheduledEvent,20" db:"scheduledEvent" json:"scheduledEvent,omitempty"`
 }
 
-func NewRecordDecisionTaskStartedResponse() *RecordDecisionTaskStartedResponse {
-  return &RecordDecisionTaskStartedResponse{}
+func NewRecordActivityTaskStartedResponse() *RecordActivityTaskStartedResponse {
+  return &RecordActivityTaskStartedResponse{}
 }
 
-var RecordDecisionTaskStartedResponse_WorkflowType_DEFAULT *shared.WorkflowType
-func (p *RecordDecisionTaskStartedResponse) GetWorkflowType() *shared.WorkflowType {
-  if !p.IsSetWorkflowType() {
-    return RecordDecisionTaskStartedResponse_WorkflowType_DEFAULT
-  }
-return p.WorkflowType
-}
-var RecordDecisionTaskStartedResponse_PreviousStartedEventId_DEFAULT int64
-func (p *RecordDecisionTaskStartedResponse) GetPreviousStartedEventId() int64 {
-  if !p.IsSetPreviousStartedEventId() {
-    return RecordDecisionTaskStartedResponse_PreviousStartedEventId_DEFAULT
+var RecordActivityTaskStartedResponse_StartedEvent_DEFAULT *shared.HistoryEvent
+func (p *RecordActivityTaskStartedResponse) GetStartedEvent() *shared.HistoryEvent {
+  if !p.IsSetStartedEvent() {
+    return RecordActivityTaskStartedResponse_StartedEvent_DEFAULT
   }
-return *p.PreviousStartedEventId
+return p.StartedEvent
 }
-var RecordDecisionTaskStartedResponse_StartedEventId_DEFAULT int64
-func (p *RecordDecisionTaskStartedResponse) GetStartedEventId() int64 {
-  if !p.IsSetStartedEventId() {
-    return RecordDecisionTaskStartedResponse_StartedEventId_DEFAULT
+var RecordActivityTaskStartedResponse_ScheduledEvent_DEFAULT *shared.HistoryEvent
+func (p *RecordActivityTaskStartedResponse) GetScheduledEvent() *shared.HistoryEvent {
+  if !p.IsSetScheduledEvent() {
+    return RecordActivityTaskStartedResponse_ScheduledEvent_DEFAULT
   }
-return *p.StartedEventId
-}
-func (p *RecordDecisionTaskStartedResponse) IsSetWorkflowType() bool {
-  return p.WorkflowType != nil
+return p.ScheduledEvent
 }
-
-func (p *RecordDecisionTaskStartedResponse) IsSetPreviousStartedEventId() bool {
-  return p.PreviousStartedEventId != nil
+func (p *RecordActivityTaskStartedResponse) IsSetStartedEvent() bool {
+  return p.StartedEvent != nil
 }
 
-func (p *RecordDecisionTaskStartedResponse) IsSetStartedEventId() bool {
-  return p.StartedEventId != nil
+func (p *RecordActivityTaskStartedResponse) IsSetScheduledEvent() bool {
+  return p.ScheduledEvent != nil
 }
 
-func (p *RecordDecisionTaskStartedResponse) Read(iprot thrift.TProtocol) error {
+func (p *RecordActivityTaskStartedResponse) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -2361,10 +2546,6 @@ func (p *RecordDecisionTaskStartedResponse) Read(iprot thrift.TProtocol) error {
       if err := p.ReadField20(iprot); err != nil {
         return err
       }
-    case 30:
-      if err := p.ReadField30(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -2380,39 +2561,28 @@ func (p *RecordDecisionTaskStartedResponse) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *RecordDecisionTaskStartedResponse)  ReadField10(iprot thrift.TProtocol) error {
-  p.WorkflowType = &shared.WorkflowType{}
-  if err := p.WorkflowType.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowType), err)
+func (p *RecordActivityTaskStartedResponse)  ReadField10(iprot thrift.TProtocol) error {
+  p.StartedEvent = &shared.HistoryEvent{}
+  if err := p.StartedEvent.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.StartedEvent), err)
   }
   return nil
 }
 
-func (p *RecordDecisionTaskStartedResponse)  ReadField20(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadI64(); err != nil {
-  return thrift.PrependError("error reading field 20: ", err)
-} else {
-  p.PreviousStartedEventId = &v
-}
-  return nil
-}
-
-func (p *RecordDecisionTaskStartedResponse)  ReadField30(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadI64(); err != nil {
-  return thrift.PrependError("error reading field 30: ", err)
-} else {
-  p.StartedEventId = &v
-}
+func (p *RecordActivityTaskStartedResponse)  ReadField20(iprot thrift.TProtocol) error {
+  p.ScheduledEvent = &shared.HistoryEvent{}
+  if err := p.ScheduledEvent.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ScheduledEvent), err)
+  }
   return nil
 }
 
-func (p *RecordDecisionTaskStartedResponse) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordDecisionTaskStartedResponse"); err != nil {
+func (p *RecordActivityTaskStartedResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordActivityTaskStartedResponse"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
     if err := p.writeField20(oprot); err != nil { return err }
-    if err := p.writeField30(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -2421,95 +2591,154 @@ func (p *RecordDecisionTaskStartedResponse) Write(oprot thrift.TProtocol) error
   return nil
 }
 
-func (p *RecordDecisionTaskStartedResponse) writeField10(oprot thrift.TProtocol) (err error) {
-  if p.IsSetWorkflowType() {
-    if err := oprot.WriteFieldBegin("workflowType", thrift.STRUCT, 10); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:workflowType: ", p), err) }
-    if err := p.WorkflowType.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowType), err)
+func (p *RecordActivityTaskStartedResponse) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetStartedEvent() {
+    if err := oprot.WriteFieldBegin("startedEvent", thrift.STRUCT, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:startedEvent: ", p), err) }
+    if err := p.StartedEvent.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.StartedEvent), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:workflowType: ", p), err) }
-  }
-  return err
-}
-
-func (p *RecordDecisionTaskStartedResponse) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetPreviousStartedEventId() {
-    if err := oprot.WriteFieldBegin("previousStartedEventId", thrift.I64, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:previousStartedEventId: ", p), err) }
-    if err := oprot.WriteI64(int64(*p.PreviousStartedEventId)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.previousStartedEventId (20) field write error: ", p), err) }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:previousStartedEventId: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:startedEvent: ", p), err) }
   }
   return err
 }
 
-func (p *RecordDecisionTaskStartedResponse) writeField30(oprot thrift.TProtocol) (err error) {
-  if p.IsSetStartedEventId() {
-    if err := oprot.WriteFieldBegin("startedEventId", thrift.I64, 30); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:startedEventId: ", p), err) }
-    if err := oprot.WriteI64(int64(*p.StartedEventId)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.startedEventId (30) field write error: ", p), err) }
+func (p *RecordActivityTaskStartedResponse) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetScheduledEvent() {
+    if err := oprot.WriteFieldBegin("scheduledEvent", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:scheduledEvent: ", p), err) }
+    if err := p.ScheduledEvent.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ScheduledEvent), err)
+    }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:startedEventId: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:scheduledEvent: ", p), err) }
   }
   return err
 }
 
-func (p *RecordDecisionTaskStartedResponse) String() string {
+func (p *RecordActivityTaskStartedResponse) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RecordDecisionTaskStartedResponse(%+v)", *p)
+  return fmt.Sprintf("RecordActivityTaskStartedResponse(%+v)", *p)
 }
 
 // Attributes:
 //  - DomainUUID
-//  - SignalRequest
-type SignalWorkflowExecutionRequest struct {
+//  - WorkflowExecution
+//  - ScheduleId
+//  - TaskId
+//  - RequestId
+//  - PollRequest
+//  - QueryId
+type RecordDecisionTaskStartedRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
   // unused fields # 11 to 19
-  SignalRequest *shared.SignalWorkflowExecutionRequest `thrift:"signalRequest,20" db:"signalRequest" json:"signalRequest,omitempty"`
+  WorkflowExecution *shared.WorkflowExecution `thrift:"workflowExecution,20" db:"workflowExecution" json:"workflowExecution,omitempty"`
+  // unused fields # 21 to 29
+  ScheduleId *int64 `thrift:"scheduleId,30" db:"scheduleId" json:"scheduleId,omitempty"`
+  // unused fields # 31 to 39
+  TaskId *int64 `thrift:"taskId,40" db:"taskId" json:"taskId,omitempty"`
+  // unused fields # 41 to 44
+  RequestId *string `thrift:"requestId,45" db:"requestId" json:"requestId,omitempty"`
+  // unused fields # 46 to 49
+  PollRequest *shared.PollForDecisionTaskRequest `thrift:"pollRequest,50" db:"pollRequest" json:"pollRequest,omitempty"`
+  // unused fields # 51 to 59
+  QueryId *string `thrift:"queryId,60" db:"queryId" json:"queryId,omitempty"`
 }
 
-func NewSignalWorkflowExecutionRequest() *SignalWorkflowExecutionRequest {
-  return &SignalWorkflowExecutionRequest{}
+func NewRecordDecisionTaskStartedRequest() *RecordDecisionTaskStartedRequest {
+  return &RecordDecisionTaskStartedRequest{}
 }
 
-var SignalWorkflowExecutionRequest_DomainUUID_DEFAULT string
-func (p *SignalWorkflowExecutionRequest) GetDomainUUID() string {
+var RecordDecisionTaskStartedRequest_DomainUUID_DEFAULT string
+func (p *RecordDecisionTaskStartedRequest) GetDomainUUID() string {
   if !p.IsSetDomainUUID() {
-    return SignalWorkflowExecutionRequest_DomainUUID_DEFAULT
+    return RecordDecisionTaskStartedRequest_DomainUUID_DEFAULT
   }
 return *p.DomainUUID
 }
-var SignalWorkflowExecutionRequest_SignalRequest_DEFAULT *shared.SignalWorkflowExecutionRequest
-func (p *SignalWorkflowExecutionRequest) GetSignalRequest() *shared.SignalWorkflowExecutionRequest {
-  if !p.IsSetSignalRequest() {
-    return SignalWorkflowExecutionRequest_SignalRequest_DEFAULT
+var RecordDecisionTaskStartedRequest_WorkflowExecution_DEFAULT *shared.WorkflowExecution
+func (p *RecordDecisionTaskStartedRequest) GetWorkflowExecution() *shared.WorkflowExecution {
+  if !p.IsSetWorkflowExecution() {
+    return RecordDecisionTaskStartedRequest_WorkflowExecution_DEFAULT
   }
-return p.SignalRequest
+return p.WorkflowExecution
 }
-func (p *SignalWorkflowExecutionRequest) IsSetDomainUUID() bool {
+var RecordDecisionTaskStartedRequest_ScheduleId_DEFAULT int64
+func (p *RecordDecisionTaskStartedRequest) GetScheduleId() int64 {
+  if !p.IsSetScheduleId() {
+    return RecordDecisionTaskStartedRequest_ScheduleId_DEFAULT
+  }
+return *p.ScheduleId
+}
+var RecordDecisionTaskStartedRequest_TaskId_DEFAULT int64
+func (p *RecordDecisionTaskStartedRequest) GetTaskId() int64 {
+  if !p.IsSetTaskId() {
+    return RecordDecisionTaskStartedRequest_TaskId_DEFAULT
+  }
+return *p.TaskId
+}
+var RecordDecisionTaskStartedRequest_RequestId_DEFAULT string
+func (p *RecordDecisionTaskStartedRequest) GetRequestId() string {
+  if !p.IsSetRequestId() {
+    return RecordDecisionTaskStartedRequest_RequestId_DEFAULT
+  }
+return *p.RequestId
+}
+var RecordDecisionTaskStartedRequest_PollRequest_DEFAULT *shared.PollForDecisionTaskRequest
+func (p *RecordDecisionTaskStartedRequest) GetPollRequest() *shared.PollForDecisionTaskRequest {
+  if !p.IsSetPollRequest() {
+    return RecordDecisionTaskStartedRequest_PollRequest_DEFAULT
+  }
+return p.PollRequest
+}
+var RecordDecisionTaskStartedRequest_QueryId_DEFAULT string
+func (p *RecordDecisionTaskStartedRequest) GetQueryId() string {
+  if !p.IsSetQueryId() {
+    return RecordDecisionTaskStartedRequest_QueryId_DEFAULT
+  }
+return *p.QueryId
+}
+func (p *RecordDecisionTaskStartedRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
 
-func (p *SignalWorkflowExecutionRequest) IsSetSignalRequest() bool {
-  return p.SignalRequest != nil
+func (p *RecordDecisionTaskStartedRequest) IsSetWorkflowExecution() bool {
+  return p.WorkflowExecution != nil
 }
 
-func (p *SignalWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
-  if _, err := iprot.ReadStructBegin(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
-  }
+func (p *RecordDecisionTaskStartedRequest) IsSetScheduleId() bool {
+  return p.ScheduleId != nil
+}
 
+func (p *RecordDecisionTaskStartedRequest) IsSetTaskId() bool {
+  return p.TaskId != nil
+}
 
-  for {
-    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
-    if err != nil {
+func (p *RecordDecisionTaskStartedRequest) IsSetRequestId() bool {
+  return p.RequestId != nil
+}
+
+func (p *RecordDecisionTaskStartedRequest) IsSetPollRequest() bool {
+  return p.PollRequest != nil
+}
+
+func (p *RecordDecisionTaskStartedRequest) IsSetQueryId() bool {
+  return p.QueryId != nil
+}
+
+func (p *RecordDecisionTaskStartedRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
       return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
     if fieldTypeId == thrift.STOP { break; }
@@ -2522,6 +2751,26 @@ func (p *SignalWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
       if err := p.ReadField20(iprot); err != nil {
         return err
       }
+    case 30:
+      if err := p.ReadField30(iprot); err != nil {
+        return err
+      }
+    case 40:
+      if err := p.ReadField40(iprot); err != nil {
+        return err
+      }
+    case 45:
+      if err := p.ReadField45(iprot); err != nil {
+        return err
+      }
+    case 50:
+      if err := p.ReadField50(iprot); err != nil {
+        return err
+      }
+    case 60:
+      if err := p.ReadField60(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -2537,7 +2786,7 @@ func (p *SignalWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *SignalWorkflowExecutionRequest)  ReadField10(iprot thrift.TProtocol) error {
+func (p *RecordDecisionTaskStartedRequest)  ReadField10(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadString(); err != nil {
   return thrift.PrependError("error reading field 10: ", err)
 } else {
@@ -2546,20 +2795,69 @@ func (p *SignalWorkflowExecutionRequest)  ReadField10(iprot thrift.TProtocol) er
   return nil
 }
 
-func (p *SignalWorkflowExecutionRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.SignalRequest = &shared.SignalWorkflowExecutionRequest{}
-  if err := p.SignalRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SignalRequest), err)
+func (p *RecordDecisionTaskStartedRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.WorkflowExecution = &shared.WorkflowExecution{}
+  if err := p.WorkflowExecution.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowExecution), err)
   }
   return nil
 }
 
-func (p *SignalWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("SignalWorkflowExecutionRequest"); err != nil {
+func (p *RecordDecisionTaskStartedRequest)  ReadField30(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 30: ", err)
+} else {
+  p.ScheduleId = &v
+}
+  return nil
+}
+
+func (p *RecordDecisionTaskStartedRequest)  ReadField40(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 40: ", err)
+} else {
+  p.TaskId = &v
+}
+  return nil
+}
+
+func (p *RecordDecisionTaskStartedRequest)  ReadField45(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 45: ", err)
+} else {
+  p.RequestId = &v
+}
+  return nil
+}
+
+func (p *RecordDecisionTaskStartedRequest)  ReadField50(iprot thrift.TProtocol) error {
+  p.PollRequest = &shared.PollForDecisionTaskRequest{}
+  if err := p.PollRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.PollRequest), err)
+  }
+  return nil
+}
+
+func (p *RecordDecisionTaskStartedRequest)  ReadField60(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 60: ", err)
+} else {
+  p.QueryId = &v
+}
+  return nil
+}
+
+func (p *RecordDecisionTaskStartedRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordDecisionTaskStartedRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
     if err := p.writeField20(oprot); err != nil { return err }
+    if err := p.writeField30(oprot); err != nil { return err }
+    if err := p.writeField40(oprot); err != nil { return err }
+    if err := p.writeField45(oprot); err != nil { return err }
+    if err := p.writeField50(oprot); err != nil { return err }
+    if err := p.writeField60(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -2568,7 +2866,7 @@ func (p *SignalWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *SignalWorkflowExecutionRequest) writeField10(oprot thrift.TProtocol) (err error) {
+func (p *RecordDecisionTaskStartedRequest) writeField10(oprot thrift.TProtocol) (err error) {
   if p.IsSetDomainUUID() {
     if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
@@ -2580,63 +2878,150 @@ func (p *SignalWorkflowExecutionRequest) writeField10(oprot thrift.TProtocol) (e
   return err
 }
 
-func (p *SignalWorkflowExecutionRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetSignalRequest() {
-    if err := oprot.WriteFieldBegin("signalRequest", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:signalRequest: ", p), err) }
-    if err := p.SignalRequest.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SignalRequest), err)
+func (p *RecordDecisionTaskStartedRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetWorkflowExecution() {
+    if err := oprot.WriteFieldBegin("workflowExecution", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:workflowExecution: ", p), err) }
+    if err := p.WorkflowExecution.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowExecution), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:signalRequest: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:workflowExecution: ", p), err) }
   }
   return err
 }
 
-func (p *SignalWorkflowExecutionRequest) String() string {
+func (p *RecordDecisionTaskStartedRequest) writeField30(oprot thrift.TProtocol) (err error) {
+  if p.IsSetScheduleId() {
+    if err := oprot.WriteFieldBegin("scheduleId", thrift.I64, 30); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:scheduleId: ", p), err) }
+    if err := oprot.WriteI64(int64(*p.ScheduleId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.scheduleId (30) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:scheduleId: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordDecisionTaskStartedRequest) writeField40(oprot thrift.TProtocol) (err error) {
+  if p.IsSetTaskId() {
+    if err := oprot.WriteFieldBegin("taskId", thrift.I64, 40); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:taskId: ", p), err) }
+    if err := oprot.WriteI64(int64(*p.TaskId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.taskId (40) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 40:taskId: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordDecisionTaskStartedRequest) writeField45(oprot thrift.TProtocol) (err error) {
+  if p.IsSetRequestId() {
+    if err := oprot.WriteFieldBegin("requestId", thrift.STRING, 45); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 45:requestId: ", p), err) }
+    if err := oprot.WriteString(string(*p.RequestId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.requestId (45) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 45:requestId: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordDecisionTaskStartedRequest) writeField50(oprot thrift.TProtocol) (err error) {
+  if p.IsSetPollRequest() {
+    if err := oprot.WriteFieldBegin("pollRequest", thrift.STRUCT, 50); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 50:pollRequest: ", p), err) }
+    if err := p.PollRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.PollRequest), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 50:pollRequest: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordDecisionTaskStartedRequest) writeField60(oprot thrift.TProtocol) (err error) {
+  if p.IsSetQueryId() {
+    if err := oprot.WriteFieldBegin("queryId", thrift.STRING, 60); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 60:queryId: ", p), err) }
+    if err := oprot.WriteString(string(*p.QueryId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.queryId (60) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 60:queryId: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordDecisionTaskStartedRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("SignalWorkflowExecutionRequest(%+v)", *p)
+  return fmt.Sprintf("RecordDecisionTaskStartedRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - DomainUUID
-//  - TerminateRequest
-type TerminateWorkflowExecutionRequest struct {
+//  - WorkflowType
+//  - PreviousStartedEventId
+//  - StartedEventId
+//  - Queries
+type RecordDecisionTaskStartedResponse struct {
   // unused fields # 1 to 9
-  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  WorkflowType *shared.WorkflowType `thrift:"workflowType,10" db:"workflowType" json:"workflowType,omitempty"`
   // unused fields # 11 to 19
-  TerminateRequest *shared.TerminateWorkflowExecutionRequest `thrift:"terminateRequest,20" db:"terminateRequest" json:"terminateRequest,omitempty"`
+  PreviousStartedEventId *int64 `thrift:"previousStartedEventId,20" db:"previousStartedEventId" json:"previousStartedEventId,omitempty"`
+  // unused fields # 21 to 29
+  StartedEventId *int64 `thrift:"startedEventId,30" db:"startedEventId" json:"startedEventId,omitempty"`
+  // unused fields # 31 to 39
+  Queries map[string]*shared.WorkflowQuery `thrift:"queries,40" db:"queries" json:"queries,omitempty"`
 }
 
-func NewTerminateWorkflowExecutionRequest() *TerminateWorkflowExecutionRequest {
-  return &TerminateWorkflowExecutionRequest{}
+func NewRecordDecisionTaskStartedResponse() *RecordDecisionTaskStartedResponse {
+  return &RecordDecisionTaskStartedResponse{}
 }
 
-var TerminateWorkflowExecutionRequest_DomainUUID_DEFAULT string
-func (p *TerminateWorkflowExecutionRequest) GetDomainUUID() string {
-  if !p.IsSetDomainUUID() {
-    return TerminateWorkflowExecutionRequest_DomainUUID_DEFAULT
+var RecordDecisionTaskStartedResponse_WorkflowType_DEFAULT *shared.WorkflowType
+func (p *RecordDecisionTaskStartedResponse) GetWorkflowType() *shared.WorkflowType {
+  if !p.IsSetWorkflowType() {
+    return RecordDecisionTaskStartedResponse_WorkflowType_DEFAULT
   }
-return *p.DomainUUID
+return p.WorkflowType
 }
-var TerminateWorkflowExecutionRequest_TerminateRequest_DEFAULT *shared.TerminateWorkflowExecutionRequest
-func (p *TerminateWorkflowExecutionRequest) GetTerminateRequest() *shared.TerminateWorkflowExecutionRequest {
-  if !p.IsSetTerminateRequest() {
-    return TerminateWorkflowExecutionRequest_TerminateRequest_DEFAULT
+var RecordDecisionTaskStartedResponse_PreviousStartedEventId_DEFAULT int64
+func (p *RecordDecisionTaskStartedResponse) GetPreviousStartedEventId() int64 {
+  if !p.IsSetPreviousStartedEventId() {
+    return RecordDecisionTaskStartedResponse_PreviousStartedEventId_DEFAULT
   }
-return p.TerminateRequest
+return *p.PreviousStartedEventId
 }
-func (p *TerminateWorkflowExecutionRequest) IsSetDomainUUID() bool {
-  return p.DomainUUID != nil
+var RecordDecisionTaskStartedResponse_StartedEventId_DEFAULT int64
+func (p *RecordDecisionTaskStartedResponse) GetStartedEventId() int64 {
+  if !p.IsSetStartedEventId() {
+    return RecordDecisionTaskStartedResponse_StartedEventId_DEFAULT
+  }
+return *p.StartedEventId
 }
+var RecordDecisionTaskStartedResponse_Queries_DEFAULT map[string]*shared.WorkflowQuery
 
-func (p *TerminateWorkflowExecutionRequest) IsSetTerminateRequest() bool {
-  return p.TerminateRequest != nil
+func (p *RecordDecisionTaskStartedResponse) GetQueries() map[string]*shared.WorkflowQuery {
+  return p.Queries
+}
+func (p *RecordDecisionTaskStartedResponse) IsSetWorkflowType() bool {
+  return p.WorkflowType != nil
 }
 
-func (p *TerminateWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
+func (p *RecordDecisionTaskStartedResponse) IsSetPreviousStartedEventId() bool {
+  return p.PreviousStartedEventId != nil
+}
+
+func (p *RecordDecisionTaskStartedResponse) IsSetStartedEventId() bool {
+  return p.StartedEventId != nil
+}
+
+func (p *RecordDecisionTaskStartedResponse) IsSetQueries() bool {
+  return p.Queries != nil
+}
+
+func (p *RecordDecisionTaskStartedResponse) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -2657,6 +3042,14 @@ func (p *TerminateWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
       if err := p.ReadField20(iprot); err != nil {
         return err
       }
+    case 30:
+      if err := p.ReadField30(iprot); err != nil {
+        return err
+      }
+    case 40:
+      if err := p.ReadField40(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -2672,29 +3065,66 @@ func (p *TerminateWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *TerminateWorkflowExecutionRequest)  ReadField10(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadString(); err != nil {
-  return thrift.PrependError("error reading field 10: ", err)
-} else {
-  p.DomainUUID = &v
-}
+func (p *RecordDecisionTaskStartedResponse)  ReadField10(iprot thrift.TProtocol) error {
+  p.WorkflowType = &shared.WorkflowType{}
+  if err := p.WorkflowType.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowType), err)
+  }
   return nil
 }
 
-func (p *TerminateWorkflowExecutionRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.TerminateRequest = &shared.TerminateWorkflowExecutionRequest{}
-  if err := p.TerminateRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.TerminateRequest), err)
+func (p *RecordDecisionTaskStartedResponse)  ReadField20(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 20: ", err)
+} else {
+  p.PreviousStartedEventId = &v
+}
+  return nil
+}
+
+func (p *RecordDecisionTaskStartedResponse)  ReadField30(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 30: ", err)
+} else {
+  p.StartedEventId = &v
+}
+  return nil
+}
+
+func (p *RecordDecisionTaskStartedResponse)  ReadField40(iprot thrift.TProtocol) error {
+  _, _, size, err := iprot.ReadMapBegin()
+  if err != nil {
+    return thrift.PrependError("error reading map begin: ", err)
+  }
+  tMap := make(map[string]*shared.WorkflowQuery, size)
+  p.Queries =  tMap
+  for i := 0; i < size; i ++ {
+var _key2 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _key2 = v
+}
+    _val3 := &shared.WorkflowQuery{}
+    if err := _val3.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val3), err)
+    }
+    p.Queries[_key2] = _val3
+  }
+  if err := iprot.ReadMapEnd(); err != nil {
+    return thrift.PrependError("error reading map end: ", err)
   }
   return nil
 }
 
-func (p *TerminateWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("TerminateWorkflowExecutionRequest"); err != nil {
+func (p *RecordDecisionTaskStartedResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordDecisionTaskStartedResponse"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
     if err := p.writeField20(oprot); err != nil { return err }
+    if err := p.writeField30(oprot); err != nil { return err }
+    if err := p.writeField40(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -2703,103 +3133,110 @@ func (p *TerminateWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error
   return nil
 }
 
-func (p *TerminateWorkflowExecutionRequest) writeField10(oprot thrift.TProtocol) (err error) {
-  if p.IsSetDomainUUID() {
-    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
-    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+func (p *RecordDecisionTaskStartedResponse) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetWorkflowType() {
+    if err := oprot.WriteFieldBegin("workflowType", thrift.STRUCT, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:workflowType: ", p), err) }
+    if err := p.WorkflowType.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowType), err)
+    }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:workflowType: ", p), err) }
   }
   return err
 }
 
-func (p *TerminateWorkflowExecutionRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetTerminateRequest() {
-    if err := oprot.WriteFieldBegin("terminateRequest", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:terminateRequest: ", p), err) }
-    if err := p.TerminateRequest.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.TerminateRequest), err)
+func (p *RecordDecisionTaskStartedResponse) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetPreviousStartedEventId() {
+    if err := oprot.WriteFieldBegin("previousStartedEventId", thrift.I64, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:previousStartedEventId: ", p), err) }
+    if err := oprot.WriteI64(int64(*p.PreviousStartedEventId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.previousStartedEventId (20) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:previousStartedEventId: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordDecisionTaskStartedResponse) writeField30(oprot thrift.TProtocol) (err error) {
+  if p.IsSetStartedEventId() {
+    if err := oprot.WriteFieldBegin("startedEventId", thrift.I64, 30); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:startedEventId: ", p), err) }
+    if err := oprot.WriteI64(int64(*p.StartedEventId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.startedEventId (30) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:startedEventId: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordDecisionTaskStartedResponse) writeField40(oprot thrift.TProtocol) (err error) {
+  if p.IsSetQueries() {
+    if err := oprot.WriteFieldBegin("queries", thrift.MAP, 40); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:queries: ", p), err) }
+    if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.Queries)); err != nil {
+      return thrift.PrependError("error writing map begin: ", err)
+    }
+    for k, v := range p.Queries {
+      if err := oprot.WriteString(string(k)); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+      if err := v.Write(oprot); err != nil {
+        return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+      }
+    }
+    if err := oprot.WriteMapEnd(); err != nil {
+      return thrift.PrependError("error writing map end: ", err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:terminateRequest: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 40:queries: ", p), err) }
   }
   return err
 }
 
-func (p *TerminateWorkflowExecutionRequest) String() string {
+func (p *RecordDecisionTaskStartedResponse) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("TerminateWorkflowExecutionRequest(%+v)", *p)
+  return fmt.Sprintf("RecordDecisionTaskStartedResponse(%+v)", *p)
 }
 
 // Attributes:
 //  - DomainUUID
-//  - CancelRequest
-//  - ExternalInitiatedEventId
-//  - ExternalWorkflowExecution
-type RequestCancelWorkflowExecutionRequest struct {
+//  - QueryRequest
+type QueryWorkflowRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
   // unused fields # 11 to 19
-  CancelRequest *shared.RequestCancelWorkflowExecutionRequest `thrift:"cancelRequest,20" db:"cancelRequest" json:"cancelRequest,omitempty"`
-  // unused fields # 21 to 29
-  ExternalInitiatedEventId *int64 `thrift:"externalInitiatedEventId,30" db:"externalInitiatedEventId" json:"externalInitiatedEventId,omitempty"`
-  // unused fields # 31 to 39
-  ExternalWorkflowExecution *shared.WorkflowExecution `thrift:"externalWorkflowExecution,40" db:"externalWorkflowExecution" json:"externalWorkflowExecution,omitempty"`
+  QueryRequest *shared.QueryWorkflowRequest `thrift:"queryRequest,20" db:"queryRequest" json:"queryRequest,omitempty"`
 }
 
-func NewRequestCancelWorkflowExecutionRequest() *RequestCancelWorkflowExecutionRequest {
-  return &RequestCancelWorkflowExecutionRequest{}
+func NewQueryWorkflowRequest() *QueryWorkflowRequest {
+  return &QueryWorkflowRequest{}
 }
 
-var RequestCancelWorkflowExecutionRequest_DomainUUID_DEFAULT string
-func (p *RequestCancelWorkflowExecutionRequest) GetDomainUUID() string {
+var QueryWorkflowRequest_DomainUUID_DEFAULT string
+func (p *QueryWorkflowRequest) GetDomainUUID() string {
   if !p.IsSetDomainUUID() {
-    return RequestCancelWorkflowExecutionRequest_DomainUUID_DEFAULT
+    return QueryWorkflowRequest_DomainUUID_DEFAULT
   }
 return *p.DomainUUID
 }
-var RequestCancelWorkflowExecutionRequest_CancelRequest_DEFAULT *shared.RequestCancelWorkflowExecutionRequest
-func (p *RequestCancelWorkflowExecutionRequest) GetCancelRequest() *shared.RequestCancelWorkflowExecutionRequest {
-  if !p.IsSetCancelRequest() {
-    return RequestCancelWorkflowExecutionRequest_CancelRequest_DEFAULT
-  }
-return p.CancelRequest
-}
-var RequestCancelWorkflowExecutionRequest_ExternalInitiatedEventId_DEFAULT int64
-func (p *RequestCancelWorkflowExecutionRequest) GetExternalInitiatedEventId() int64 {
-  if !p.IsSetExternalInitiatedEventId() {
-    return RequestCancelWorkflowExecutionRequest_ExternalInitiatedEventId_DEFAULT
-  }
-return *p.ExternalInitiatedEventId
-}
-var RequestCancelWorkflowExecutionRequest_ExternalWorkflowExecution_DEFAULT *shared.WorkflowExecution
-func (p *RequestCancelWorkflowExecutionRequest) GetExternalWorkflowExecution() *shared.WorkflowExecution {
-  if !p.IsSetExternalWorkflowExecution() {
-    return RequestCancelWorkflowExecutionRequest_ExternalWorkflowExecution_DEFAULT
+var QueryWorkflowRequest_QueryRequest_DEFAULT *shared.QueryWorkflowRequest
+func (p *QueryWorkflowRequest) GetQueryRequest() *shared.QueryWorkflowRequest {
+  if !p.IsSetQueryRequest() {
+    return QueryWorkflowRequest_QueryRequest_DEFAULT
   }
-return p.ExternalWorkflowExecution
+return p.QueryRequest
 }
-func (p *RequestCancelWorkflowExecutionRequest) IsSetDomainUUID() bool {
+func (p *QueryWorkflowRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
 
-func (p *RequestCancelWorkflowExecutionRequest) IsSetCancelRequest() bool {
-  return p.CancelRequest != nil
-}
-
-func (p *RequestCancelWorkflowExecutionRequest) IsSetExternalInitiatedEventId() bool {
-  return p.ExternalInitiatedEventId != nil
-}
-
-func (p *RequestCancelWorkflowExecutionRequest) IsSetExternalWorkflowExecution() bool {
-  return p.ExternalWorkflowExecution != nil
+func (p *QueryWorkflowRequest) IsSetQueryRequest() bool {
+  return p.QueryRequest != nil
 }
 
-func (p *RequestCancelWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
+func (p *QueryWorkflowRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -2820,14 +3257,6 @@ func (p *RequestCancelWorkflowExecutionRequest) Read(iprot thrift.TProtocol) err
       if err := p.ReadField20(iprot); err != nil {
         return err
       }
-    case 30:
-      if err := p.ReadField30(iprot); err != nil {
-        return err
-      }
-    case 40:
-      if err := p.ReadField40(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -2843,7 +3272,7 @@ func (p *RequestCancelWorkflowExecutionRequest) Read(iprot thrift.TProtocol) err
   return nil
 }
 
-func (p *RequestCancelWorkflowExecutionRequest)  ReadField10(iprot thrift.TProtocol) error {
+func (p *QueryWorkflowRequest)  ReadField10(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadString(); err != nil {
   return thrift.PrependError("error reading field 10: ", err)
 } else {
@@ -2852,39 +3281,20 @@ func (p *RequestCancelWorkflowExecutionRequest)  ReadField10(iprot thrift.TProto
   return nil
 }
 
-func (p *RequestCancelWorkflowExecutionRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.CancelRequest = &shared.RequestCancelWorkflowExecutionRequest{}
-  if err := p.CancelRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CancelRequest), err)
-  }
-  return nil
-}
-
-func (p *RequestCancelWorkflowExecutionRequest)  ReadField30(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadI64(); err != nil {
-  return thrift.PrependError("error reading field 30: ", err)
-} else {
-  p.ExternalInitiatedEventId = &v
-}
-  return nil
-}
-
-func (p *RequestCancelWorkflowExecutionRequest)  ReadField40(iprot thrift.TProtocol) error {
-  p.ExternalWorkflowExecution = &shared.WorkflowExecution{}
-  if err := p.ExternalWorkflowExecution.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ExternalWorkflowExecution), err)
+func (p *QueryWorkflowRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.QueryRequest = &shared.QueryWorkflowRequest{}
+  if err := p.QueryRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.QueryRequest), err)
   }
   return nil
 }
 
-func (p *RequestCancelWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecutionRequest"); err != nil {
+func (p *QueryWorkflowRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("QueryWorkflowRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
     if err := p.writeField20(oprot); err != nil { return err }
-    if err := p.writeField30(oprot); err != nil { return err }
-    if err := p.writeField40(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -2893,7 +3303,7 @@ func (p *RequestCancelWorkflowExecutionRequest) Write(oprot thrift.TProtocol) er
   return nil
 }
 
-func (p *RequestCancelWorkflowExecutionRequest) writeField10(oprot thrift.TProtocol) (err error) {
+func (p *QueryWorkflowRequest) writeField10(oprot thrift.TProtocol) (err error) {
   if p.IsSetDomainUUID() {
     if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
@@ -2905,88 +3315,63 @@ func (p *RequestCancelWorkflowExecutionRequest) writeField10(oprot thrift.TProto
   return err
 }
 
-func (p *RequestCancelWorkflowExecutionRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetCancelRequest() {
-    if err := oprot.WriteFieldBegin("cancelRequest", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:cancelRequest: ", p), err) }
-    if err := p.CancelRequest.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CancelRequest), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:cancelRequest: ", p), err) }
-  }
-  return err
-}
-
-func (p *RequestCancelWorkflowExecutionRequest) writeField30(oprot thrift.TProtocol) (err error) {
-  if p.IsSetExternalInitiatedEventId() {
-    if err := oprot.WriteFieldBegin("externalInitiatedEventId", thrift.I64, 30); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:externalInitiatedEventId: ", p), err) }
-    if err := oprot.WriteI64(int64(*p.ExternalInitiatedEventId)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.externalInitiatedEventId (30) field write error: ", p), err) }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:externalInitiatedEventId: ", p), err) }
-  }
-  return err
-}
-
-func (p *RequestCancelWorkflowExecutionRequest) writeField40(oprot thrift.TProtocol) (err error) {
-  if p.IsSetExternalWorkflowExecution() {
-    if err := oprot.WriteFieldBegin("externalWorkflowExecution", thrift.STRUCT, 40); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:externalWorkflowExecution: ", p), err) }
-    if err := p.ExternalWorkflowExecution.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ExternalWorkflowExecution), err)
+func (p *QueryWorkflowRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetQueryRequest() {
+    if err := oprot.WriteFieldBegin("queryRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:queryRequest: ", p), err) }
+    if err := p.QueryRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.QueryRequest), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 40:externalWorkflowExecution: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:queryRequest: ", p), err) }
   }
   return err
 }
 
-func (p *RequestCancelWorkflowExecutionRequest) String() string {
+func (p *QueryWorkflowRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RequestCancelWorkflowExecutionRequest(%+v)", *p)
+  return fmt.Sprintf("QueryWorkflowRequest(%+v)", *p)
 }
 
 // Attributes:
 //  - DomainUUID
-//  - WorkflowExecution
-type ScheduleDecisionTaskRequest struct {
+//  - Request
+type DescribeWorkflowExecutionRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
   // unused fields # 11 to 19
-  WorkflowExecution *shared.WorkflowExecution `thrift:"workflowExecution,20" db:"workflowExecution" json:"workflowExecution,omitempty"`
+  Request *shared.DescribeWorkflowExecutionRequest `thrift:"request,20" db:"request" json:"request,omitempty"`
 }
 
-func NewScheduleDecisionTaskRequest() *ScheduleDecisionTaskRequest {
-  return &ScheduleDecisionTaskRequest{}
+func NewDescribeWorkflowExecutionRequest() *DescribeWorkflowExecutionRequest {
+  return &DescribeWorkflowExecutionRequest{}
 }
 
-var ScheduleDecisionTaskRequest_DomainUUID_DEFAULT string
-func (p *ScheduleDecisionTaskRequest) GetDomainUUID() string {
+var DescribeWorkflowExecutionRequest_DomainUUID_DEFAULT string
+func (p *DescribeWorkflowExecutionRequest) GetDomainUUID() string {
   if !p.IsSetDomainUUID() {
-    return ScheduleDecisionTaskRequest_DomainUUID_DEFAULT
+    return DescribeWorkflowExecutionRequest_DomainUUID_DEFAULT
   }
 return *p.DomainUUID
 }
-var ScheduleDecisionTaskRequest_WorkflowExecution_DEFAULT *shared.WorkflowExecution
-func (p *ScheduleDecisionTaskRequest) GetWorkflowExecution() *shared.WorkflowExecution {
-  if !p.IsSetWorkflowExecution() {
-    return ScheduleDecisionTaskRequest_WorkflowExecution_DEFAULT
+var DescribeWorkflowExecutionRequest_Request_DEFAULT *shared.DescribeWorkflowExecutionRequest
+func (p *DescribeWorkflowExecutionRequest) GetRequest() *shared.DescribeWorkflowExecutionRequest {
+  if !p.IsSetRequest() {
+    return DescribeWorkflowExecutionRequest_Request_DEFAULT
   }
-return p.WorkflowExecution
+return p.Request
 }
-func (p *ScheduleDecisionTaskRequest) IsSetDomainUUID() bool {
+func (p *DescribeWorkflowExecutionRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
 
-func (p *ScheduleDecisionTaskRequest) IsSetWorkflowExecution() bool {
-  return p.WorkflowExecution != nil
+func (p *DescribeWorkflowExecutionRequest) IsSetRequest() bool {
+  return p.Request != nil
 }
 
-func (p *ScheduleDecisionTaskRequest) Read(iprot thrift.TProtocol) error {
+func (p *DescribeWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -3022,7 +3407,7 @@ func (p *ScheduleDecisionTaskRequest) Read(iprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *ScheduleDecisionTaskRequest)  ReadField10(iprot thrift.TProtocol) error {
+func (p *DescribeWorkflowExecutionRequest)  ReadField10(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadString(); err != nil {
   return thrift.PrependError("error reading field 10: ", err)
 } else {
@@ -3031,16 +3416,16 @@ func (p *ScheduleDecisionTaskRequest)  ReadField10(iprot thrift.TProtocol) error
   return nil
 }
 
-func (p *ScheduleDecisionTaskRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.WorkflowExecution = &shared.WorkflowExecution{}
-  if err := p.WorkflowExecution.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowExecution), err)
+func (p *DescribeWorkflowExecutionRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.Request = &shared.DescribeWorkflowExecutionRequest{}
+  if err := p.Request.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
   }
   return nil
 }
 
-func (p *ScheduleDecisionTaskRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("ScheduleDecisionTaskRequest"); err != nil {
+func (p *DescribeWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeWorkflowExecutionRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
@@ -3053,7 +3438,7 @@ func (p *ScheduleDecisionTaskRequest) Write(oprot thrift.TProtocol) error {
   return nil
 }
 
-func (p *ScheduleDecisionTaskRequest) writeField10(oprot thrift.TProtocol) (err error) {
+func (p *DescribeWorkflowExecutionRequest) writeField10(oprot thrift.TProtocol) (err error) {
   if p.IsSetDomainUUID() {
     if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
@@ -3065,112 +3450,63 @@ func (p *ScheduleDecisionTaskRequest) writeField10(oprot thrift.TProtocol) (err
   return err
 }
 
-func (p *ScheduleDecisionTaskRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetWorkflowExecution() {
-    if err := oprot.WriteFieldBegin("workflowExecution", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:workflowExecution: ", p), err) }
-    if err := p.WorkflowExecution.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowExecution), err)
+func (p *DescribeWorkflowExecutionRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetRequest() {
+    if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:request: ", p), err) }
+    if err := p.Request.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:workflowExecution: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:request: ", p), err) }
   }
   return err
 }
 
-func (p *ScheduleDecisionTaskRequest) String() string {
+func (p *DescribeWorkflowExecutionRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("ScheduleDecisionTaskRequest(%+v)", *p)
+  return fmt.Sprintf("DescribeWorkflowExecutionRequest(%+v)", *p)
 }
 
-// RecordChildExecutionCompletedRequest is used for reporting the completion of child execution to parent workflow
-// execution which started it.  When a child execution is completed it creates this request and calls the
-// RecordChildExecutionCompleted API with the workflowExecution of parent.  It also sets the completedExecution of the
-// child as it could potentially be different than the ChildExecutionStartedEvent of parent in the situation when
-// child creates multiple runs through ContinueAsNew before finally completing.
-// 
-// 
 // Attributes:
 //  - DomainUUID
-//  - WorkflowExecution
-//  - InitiatedId
-//  - CompletedExecution
-//  - CompletionEvent
-type RecordChildExecutionCompletedRequest struct {
+//  - SignalRequest
+type SignalWorkflowExecutionRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
   // unused fields # 11 to 19
-  WorkflowExecution *shared.WorkflowExecution `thrift:"workflowExecution,20" db:"workflowExecution" json:"workflowExecution,omitempty"`
-  // unused fields # 21 to 29
-  InitiatedId *int64 `thrift:"initiatedId,30" db:"initiatedId" json:"initiatedId,omitempty"`
-  // unused fields # 31 to 39
-  CompletedExecution *shared.WorkflowExecution `thrift:"completedExecution,40" db:"completedExecution" json:"completedExecution,omitempty"`
-  // unused fields # 41 to 49
-  CompletionEvent *shared.HistoryEvent `thrift:"completionEvent,50" db:"completionEvent" json:"completionEvent,omitempty"`
+  SignalRequest *shared.SignalWorkflowExecutionRequest `thrift:"signalRequest,20" db:"signalRequest" json:"signalRequest,omitempty"`
 }
 
-func NewRecordChildExecutionCompletedRequest() *RecordChildExecutionCompletedRequest {
-  return &RecordChildExecutionCompletedRequest{}
+func NewSignalWorkflowExecutionRequest() *SignalWorkflowExecutionRequest {
+  return &SignalWorkflowExecutionRequest{}
 }
 
-var RecordChildExecutionCompletedRequest_DomainUUID_DEFAULT string
-func (p *RecordChildExecutionCompletedRequest) GetDomainUUID() string {
+var SignalWorkflowExecutionRequest_DomainUUID_DEFAULT string
+func (p *SignalWorkflowExecutionRequest) GetDomainUUID() string {
   if !p.IsSetDomainUUID() {
-    return RecordChildExecutionCompletedRequest_DomainUUID_DEFAULT
+    return SignalWorkflowExecutionRequest_DomainUUID_DEFAULT
   }
 return *p.DomainUUID
 }
-var RecordChildExecutionCompletedRequest_WorkflowExecution_DEFAULT *shared.WorkflowExecution
-func (p *RecordChildExecutionCompletedRequest) GetWorkflowExecution() *shared.WorkflowExecution {
-  if !p.IsSetWorkflowExecution() {
-    return RecordChildExecutionCompletedRequest_WorkflowExecution_DEFAULT
-  }
-return p.WorkflowExecution
-}
-var RecordChildExecutionCompletedRequest_InitiatedId_DEFAULT int64
-func (p *RecordChildExecutionCompletedRequest) GetInitiatedId() int64 {
-  if !p.IsSetInitiatedId() {
-    return RecordChildExecutionCompletedRequest_InitiatedId_DEFAULT
-  }
-return *p.InitiatedId
-}
-var RecordChildExecutionCompletedRequest_CompletedExecution_DEFAULT *shared.WorkflowExecution
-func (p *RecordChildExecutionCompletedRequest) GetCompletedExecution() *shared.WorkflowExecution {
-  if !p.IsSetCompletedExecution() {
-    return RecordChildExecutionCompletedRequest_CompletedExecution_DEFAULT
-  }
-return p.CompletedExecution
-}
-var RecordChildExecutionCompletedRequest_CompletionEvent_DEFAULT *shared.HistoryEvent
-func (p *RecordChildExecutionCompletedRequest) GetCompletionEvent() *shared.HistoryEvent {
-  if !p.IsSetCompletionEvent() {
-    return RecordChildExecutionCompletedRequest_CompletionEvent_DEFAULT
+var SignalWorkflowExecutionRequest_SignalRequest_DEFAULT *shared.SignalWorkflowExecutionRequest
+func (p *SignalWorkflowExecutionRequest) GetSignalRequest() *shared.SignalWorkflowExecutionRequest {
+  if !p.IsSetSignalRequest() {
+    return SignalWorkflowExecutionRequest_SignalRequest_DEFAULT
   }
-return p.CompletionEvent
+return p.SignalRequest
 }
-func (p *RecordChildExecutionCompletedRequest) IsSetDomainUUID() bool {
+func (p *SignalWorkflowExecutionRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
 
-func (p *RecordChildExecutionCompletedRequest) IsSetWorkflowExecution() bool {
-  return p.WorkflowExecution != nil
-}
-
-func (p *RecordChildExecutionCompletedRequest) IsSetInitiatedId() bool {
-  return p.InitiatedId != nil
-}
-
-func (p *RecordChildExecutionCompletedRequest) IsSetCompletedExecution() bool {
-  return p.CompletedExecution != nil
-}
-
-func (p *RecordChildExecutionCompletedRequest) IsSetCompletionEvent() bool {
-  return p.CompletionEvent != nil
+func (p *SignalWorkflowExecutionRequest) IsSetSignalRequest() bool {
+  return p.SignalRequest != nil
 }
 
-func (p *RecordChildExecutionCompletedRequest) Read(iprot thrift.TProtocol) error {
+func (p *SignalWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -3191,18 +3527,6 @@ func (p *RecordChildExecutionCompletedRequest) Read(iprot thrift.TProtocol) erro
       if err := p.ReadField20(iprot); err != nil {
         return err
       }
-    case 30:
-      if err := p.ReadField30(iprot); err != nil {
-        return err
-      }
-    case 40:
-      if err := p.ReadField40(iprot); err != nil {
-        return err
-      }
-    case 50:
-      if err := p.ReadField50(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -3218,7 +3542,7 @@ func (p *RecordChildExecutionCompletedRequest) Read(iprot thrift.TProtocol) erro
   return nil
 }
 
-func (p *RecordChildExecutionCompletedRequest)  ReadField10(iprot thrift.TProtocol) error {
+func (p *SignalWorkflowExecutionRequest)  ReadField10(iprot thrift.TProtocol) error {
   if v, err := iprot.ReadString(); err != nil {
   return thrift.PrependError("error reading field 10: ", err)
 } else {
@@ -3227,48 +3551,20 @@ func (p *RecordChildExecutionCompletedRequest)  ReadField10(iprot thrift.TProtoc
   return nil
 }
 
-func (p *RecordChildExecutionCompletedRequest)  ReadField20(iprot thrift.TProtocol) error {
-  p.WorkflowExecution = &shared.WorkflowExecution{}
-  if err := p.WorkflowExecution.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowExecution), err)
-  }
-  return nil
-}
-
-func (p *RecordChildExecutionCompletedRequest)  ReadField30(iprot thrift.TProtocol) error {
-  if v, err := iprot.ReadI64(); err != nil {
-  return thrift.PrependError("error reading field 30: ", err)
-} else {
-  p.InitiatedId = &v
-}
-  return nil
-}
-
-func (p *RecordChildExecutionCompletedRequest)  ReadField40(iprot thrift.TProtocol) error {
-  p.CompletedExecution = &shared.WorkflowExecution{}
-  if err := p.CompletedExecution.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompletedExecution), err)
-  }
-  return nil
-}
-
-func (p *RecordChildExecutionCompletedRequest)  ReadField50(iprot thrift.TProtocol) error {
-  p.CompletionEvent = &shared.HistoryEvent{}
-  if err := p.CompletionEvent.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompletionEvent), err)
+func (p *SignalWorkflowExecutionRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.SignalRequest = &shared.SignalWorkflowExecutionRequest{}
+  if err := p.SignalRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SignalRequest), err)
   }
   return nil
 }
 
-func (p *RecordChildExecutionCompletedRequest) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordChildExecutionCompletedRequest"); err != nil {
+func (p *SignalWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SignalWorkflowExecutionRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField10(oprot); err != nil { return err }
     if err := p.writeField20(oprot); err != nil { return err }
-    if err := p.writeField30(oprot); err != nil { return err }
-    if err := p.writeField40(oprot); err != nil { return err }
-    if err := p.writeField50(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -3277,7 +3573,7 @@ func (p *RecordChildExecutionCompletedRequest) Write(oprot thrift.TProtocol) err
   return nil
 }
 
-func (p *RecordChildExecutionCompletedRequest) writeField10(oprot thrift.TProtocol) (err error) {
+func (p *SignalWorkflowExecutionRequest) writeField10(oprot thrift.TProtocol) (err error) {
   if p.IsSetDomainUUID() {
     if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
@@ -3289,72 +3585,2326 @@ func (p *RecordChildExecutionCompletedRequest) writeField10(oprot thrift.TProtoc
   return err
 }
 
-func (p *RecordChildExecutionCompletedRequest) writeField20(oprot thrift.TProtocol) (err error) {
-  if p.IsSetWorkflowExecution() {
-    if err := oprot.WriteFieldBegin("workflowExecution", thrift.STRUCT, 20); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:workflowExecution: ", p), err) }
-    if err := p.WorkflowExecution.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowExecution), err)
+func (p *SignalWorkflowExecutionRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSignalRequest() {
+    if err := oprot.WriteFieldBegin("signalRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:signalRequest: ", p), err) }
+    if err := p.SignalRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SignalRequest), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:workflowExecution: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:signalRequest: ", p), err) }
   }
   return err
 }
 
-func (p *RecordChildExecutionCompletedRequest) writeField30(oprot thrift.TProtocol) (err error) {
-  if p.IsSetInitiatedId() {
-    if err := oprot.WriteFieldBegin("initiatedId", thrift.I64, 30); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:initiatedId: ", p), err) }
-    if err := oprot.WriteI64(int64(*p.InitiatedId)); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T.initiatedId (30) field write error: ", p), err) }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:initiatedId: ", p), err) }
+func (p *SignalWorkflowExecutionRequest) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return err
+  return fmt.Sprintf("SignalWorkflowExecutionRequest(%+v)", *p)
 }
 
-func (p *RecordChildExecutionCompletedRequest) writeField40(oprot thrift.TProtocol) (err error) {
-  if p.IsSetCompletedExecution() {
-    if err := oprot.WriteFieldBegin("completedExecution", thrift.STRUCT, 40); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:completedExecution: ", p), err) }
-    if err := p.CompletedExecution.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompletedExecution), err)
+// Attributes:
+//  - DomainUUID
+//  - ReceiptRequest
+type GetSignalReceiptRequest struct {
+  // unused fields # 1 to 9
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 11 to 19
+  ReceiptRequest *shared.GetSignalReceiptRequest `thrift:"receiptRequest,20" db:"receiptRequest" json:"receiptRequest,omitempty"`
+}
+
+func NewGetSignalReceiptRequest() *GetSignalReceiptRequest {
+  return &GetSignalReceiptRequest{}
+}
+
+var GetSignalReceiptRequest_DomainUUID_DEFAULT string
+func (p *GetSignalReceiptRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return GetSignalReceiptRequest_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var GetSignalReceiptRequest_ReceiptRequest_DEFAULT *shared.GetSignalReceiptRequest
+func (p *GetSignalReceiptRequest) GetReceiptRequest() *shared.GetSignalReceiptRequest {
+  if !p.IsSetReceiptRequest() {
+    return GetSignalReceiptRequest_ReceiptRequest_DEFAULT
+  }
+return p.ReceiptRequest
+}
+func (p *GetSignalReceiptRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *GetSignalReceiptRequest) IsSetReceiptRequest() bool {
+  return p.ReceiptRequest != nil
+}
+
+func (p *GetSignalReceiptRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *GetSignalReceiptRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *GetSignalReceiptRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.ReceiptRequest = &shared.GetSignalReceiptRequest{}
+  if err := p.ReceiptRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ReceiptRequest), err)
+  }
+  return nil
+}
+
+func (p *GetSignalReceiptRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetSignalReceiptRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *GetSignalReceiptRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 40:completedExecution: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
   }
   return err
 }
 
-func (p *RecordChildExecutionCompletedRequest) writeField50(oprot thrift.TProtocol) (err error) {
-  if p.IsSetCompletionEvent() {
-    if err := oprot.WriteFieldBegin("completionEvent", thrift.STRUCT, 50); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 50:completionEvent: ", p), err) }
-    if err := p.CompletionEvent.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompletionEvent), err)
+func (p *GetSignalReceiptRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetReceiptRequest() {
+    if err := oprot.WriteFieldBegin("receiptRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:receiptRequest: ", p), err) }
+    if err := p.ReceiptRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ReceiptRequest), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 50:completionEvent: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:receiptRequest: ", p), err) }
   }
   return err
 }
 
-func (p *RecordChildExecutionCompletedRequest) String() string {
+func (p *GetSignalReceiptRequest) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("RecordChildExecutionCompletedRequest(%+v)", *p)
+  return fmt.Sprintf("GetSignalReceiptRequest(%+v)", *p)
 }
 
-type HistoryService interface {  //HistoryService provides API to start a new long running workflow instance, as well as query and update the history
-  //of workflow instances already created.
-  //
+// Attributes:
+//  - DomainUUID
+//  - TerminateRequest
+type TerminateWorkflowExecutionRequest struct {
+  // unused fields # 1 to 9
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 11 to 19
+  TerminateRequest *shared.TerminateWorkflowExecutionRequest `thrift:"terminateRequest,20" db:"terminateRequest" json:"terminateRequest,omitempty"`
+}
 
-  // StartWorkflowExecution starts a new long running workflow instance.  It will create the instance with
-  // 'WorkflowExecutionStarted' event in history and also schedule the first DecisionTask for the worker to make the
-  // first decision for this instance.  It will return 'WorkflowExecutionAlreadyStartedError', if an instance already
-  // exists with same workflowId.
+func NewTerminateWorkflowExecutionRequest() *TerminateWorkflowExecutionRequest {
+  return &TerminateWorkflowExecutionRequest{}
+}
+
+var TerminateWorkflowExecutionRequest_DomainUUID_DEFAULT string
+func (p *TerminateWorkflowExecutionRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return TerminateWorkflowExecutionRequest_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var TerminateWorkflowExecutionRequest_TerminateRequest_DEFAULT *shared.TerminateWorkflowExecutionRequest
+func (p *TerminateWorkflowExecutionRequest) GetTerminateRequest() *shared.TerminateWorkflowExecutionRequest {
+  if !p.IsSetTerminateRequest() {
+    return TerminateWorkflowExecutionRequest_TerminateRequest_DEFAULT
+  }
+return p.TerminateRequest
+}
+func (p *TerminateWorkflowExecutionRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *TerminateWorkflowExecutionRequest) IsSetTerminateRequest() bool {
+  return p.TerminateRequest != nil
+}
+
+func (p *TerminateWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *TerminateWorkflowExecutionRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *TerminateWorkflowExecutionRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.TerminateRequest = &shared.TerminateWorkflowExecutionRequest{}
+  if err := p.TerminateRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.TerminateRequest), err)
+  }
+  return nil
+}
+
+func (p *TerminateWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("TerminateWorkflowExecutionRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *TerminateWorkflowExecutionRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *TerminateWorkflowExecutionRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetTerminateRequest() {
+    if err := oprot.WriteFieldBegin("terminateRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:terminateRequest: ", p), err) }
+    if err := p.TerminateRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.TerminateRequest), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:terminateRequest: ", p), err) }
+  }
+  return err
+}
+
+func (p *TerminateWorkflowExecutionRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("TerminateWorkflowExecutionRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - DomainUUID
+//  - CancelRequest
+//  - ExternalInitiatedEventId
+//  - ExternalWorkflowExecution
+type RequestCancelWorkflowExecutionRequest struct {
+  // unused fields # 1 to 9
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 11 to 19
+  CancelRequest *shared.RequestCancelWorkflowExecutionRequest `thrift:"cancelRequest,20" db:"cancelRequest" json:"cancelRequest,omitempty"`
+  // unused fields # 21 to 29
+  ExternalInitiatedEventId *int64 `thrift:"externalInitiatedEventId,30" db:"externalInitiatedEventId" json:"externalInitiatedEventId,omitempty"`
+  // unused fields # 31 to 39
+  ExternalWorkflowExecution *shared.WorkflowExecution `thrift:"externalWorkflowExecution,40" db:"externalWorkflowExecution" json:"externalWorkflowExecution,omitempty"`
+}
+
+func NewRequestCancelWorkflowExecutionRequest() *RequestCancelWorkflowExecutionRequest {
+  return &RequestCancelWorkflowExecutionRequest{}
+}
+
+var RequestCancelWorkflowExecutionRequest_DomainUUID_DEFAULT string
+func (p *RequestCancelWorkflowExecutionRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return RequestCancelWorkflowExecutionRequest_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var RequestCancelWorkflowExecutionRequest_CancelRequest_DEFAULT *shared.RequestCancelWorkflowExecutionRequest
+func (p *RequestCancelWorkflowExecutionRequest) GetCancelRequest() *shared.RequestCancelWorkflowExecutionRequest {
+  if !p.IsSetCancelRequest() {
+    return RequestCancelWorkflowExecutionRequest_CancelRequest_DEFAULT
+  }
+return p.CancelRequest
+}
+var RequestCancelWorkflowExecutionRequest_ExternalInitiatedEventId_DEFAULT int64
+func (p *RequestCancelWorkflowExecutionRequest) GetExternalInitiatedEventId() int64 {
+  if !p.IsSetExternalInitiatedEventId() {
+    return RequestCancelWorkflowExecutionRequest_ExternalInitiatedEventId_DEFAULT
+  }
+return *p.ExternalInitiatedEventId
+}
+var RequestCancelWorkflowExecutionRequest_ExternalWorkflowExecution_DEFAULT *shared.WorkflowExecution
+func (p *RequestCancelWorkflowExecutionRequest) GetExternalWorkflowExecution() *shared.WorkflowExecution {
+  if !p.IsSetExternalWorkflowExecution() {
+    return RequestCancelWorkflowExecutionRequest_ExternalWorkflowExecution_DEFAULT
+  }
+return p.ExternalWorkflowExecution
+}
+func (p *RequestCancelWorkflowExecutionRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) IsSetCancelRequest() bool {
+  return p.CancelRequest != nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) IsSetExternalInitiatedEventId() bool {
+  return p.ExternalInitiatedEventId != nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) IsSetExternalWorkflowExecution() bool {
+  return p.ExternalWorkflowExecution != nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    case 30:
+      if err := p.ReadField30(iprot); err != nil {
+        return err
+      }
+    case 40:
+      if err := p.ReadField40(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.CancelRequest = &shared.RequestCancelWorkflowExecutionRequest{}
+  if err := p.CancelRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CancelRequest), err)
+  }
+  return nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest)  ReadField30(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 30: ", err)
+} else {
+  p.ExternalInitiatedEventId = &v
+}
+  return nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest)  ReadField40(iprot thrift.TProtocol) error {
+  p.ExternalWorkflowExecution = &shared.WorkflowExecution{}
+  if err := p.ExternalWorkflowExecution.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ExternalWorkflowExecution), err)
+  }
+  return nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecutionRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+    if err := p.writeField30(oprot); err != nil { return err }
+    if err := p.writeField40(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetCancelRequest() {
+    if err := oprot.WriteFieldBegin("cancelRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:cancelRequest: ", p), err) }
+    if err := p.CancelRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CancelRequest), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:cancelRequest: ", p), err) }
+  }
+  return err
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) writeField30(oprot thrift.TProtocol) (err error) {
+  if p.IsSetExternalInitiatedEventId() {
+    if err := oprot.WriteFieldBegin("externalInitiatedEventId", thrift.I64, 30); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:externalInitiatedEventId: ", p), err) }
+    if err := oprot.WriteI64(int64(*p.ExternalInitiatedEventId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.externalInitiatedEventId (30) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:externalInitiatedEventId: ", p), err) }
+  }
+  return err
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) writeField40(oprot thrift.TProtocol) (err error) {
+  if p.IsSetExternalWorkflowExecution() {
+    if err := oprot.WriteFieldBegin("externalWorkflowExecution", thrift.STRUCT, 40); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:externalWorkflowExecution: ", p), err) }
+    if err := p.ExternalWorkflowExecution.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ExternalWorkflowExecution), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 40:externalWorkflowExecution: ", p), err) }
+  }
+  return err
+}
+
+func (p *RequestCancelWorkflowExecutionRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("RequestCancelWorkflowExecutionRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - DomainUUID
+//  - WorkflowExecution
+type ScheduleDecisionTaskRequest struct {
+  // unused fields # 1 to 9
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 11 to 19
+  WorkflowExecution *shared.WorkflowExecution `thrift:"workflowExecution,20" db:"workflowExecution" json:"workflowExecution,omitempty"`
+}
+
+func NewScheduleDecisionTaskRequest() *ScheduleDecisionTaskRequest {
+  return &ScheduleDecisionTaskRequest{}
+}
+
+var ScheduleDecisionTaskRequest_DomainUUID_DEFAULT string
+func (p *ScheduleDecisionTaskRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return ScheduleDecisionTaskRequest_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var ScheduleDecisionTaskRequest_WorkflowExecution_DEFAULT *shared.WorkflowExecution
+func (p *ScheduleDecisionTaskRequest) GetWorkflowExecution() *shared.WorkflowExecution {
+  if !p.IsSetWorkflowExecution() {
+    return ScheduleDecisionTaskRequest_WorkflowExecution_DEFAULT
+  }
+return p.WorkflowExecution
+}
+func (p *ScheduleDecisionTaskRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *ScheduleDecisionTaskRequest) IsSetWorkflowExecution() bool {
+  return p.WorkflowExecution != nil
+}
+
+func (p *ScheduleDecisionTaskRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ScheduleDecisionTaskRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *ScheduleDecisionTaskRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.WorkflowExecution = &shared.WorkflowExecution{}
+  if err := p.WorkflowExecution.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowExecution), err)
+  }
+  return nil
+}
+
+func (p *ScheduleDecisionTaskRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ScheduleDecisionTaskRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ScheduleDecisionTaskRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *ScheduleDecisionTaskRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetWorkflowExecution() {
+    if err := oprot.WriteFieldBegin("workflowExecution", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:workflowExecution: ", p), err) }
+    if err := p.WorkflowExecution.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowExecution), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:workflowExecution: ", p), err) }
+  }
+  return err
+}
+
+func (p *ScheduleDecisionTaskRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ScheduleDecisionTaskRequest(%+v)", *p)
+}
+
+// RecordChildExecutionCompletedRequest is used for reporting the completion of child execution to parent workflow
+// execution which started it.  When a child execution is completed it creates this request and calls the
+// RecordChildExecutionCompleted API with the workflowExecution of parent.  It also sets the completedExecution of the
+// child as it could potentially be different than the ChildExecutionStartedEvent of parent in the situation when
+// child creates multiple runs through ContinueAsNew before finally completing.
+// 
+// 
+// Attributes:
+//  - DomainUUID
+//  - WorkflowExecution
+//  - InitiatedId
+//  - CompletedExecution
+//  - CompletionEvent
+type RecordChildExecutionCompletedRequest struct {
+  // unused fields # 1 to 9
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 11 to 19
+  WorkflowExecution *shared.WorkflowExecution `thrift:"workflowExecution,20" db:"workflowExecution" json:"workflowExecution,omitempty"`
+  // unused fields # 21 to 29
+  InitiatedId *int64 `thrift:"initiatedId,30" db:"initiatedId" json:"initiatedId,omitempty"`
+  // unused fields # 31 to 39
+  CompletedExecution *shared.WorkflowExecution `thrift:"completedExecution,40" db:"completedExecution" json:"completedExecution,omitempty"`
+  // unused fields # 41 to 49
+  CompletionEvent *shared.HistoryEvent `thrift:"completionEvent,50" db:"completionEvent" json:"completionEvent,omitempty"`
+}
+
+func NewRecordChildExecutionCompletedRequest() *RecordChildExecutionCompletedRequest {
+  return &RecordChildExecutionCompletedRequest{}
+}
+
+var RecordChildExecutionCompletedRequest_DomainUUID_DEFAULT string
+func (p *RecordChildExecutionCompletedRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return RecordChildExecutionCompletedRequest_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var RecordChildExecutionCompletedRequest_WorkflowExecution_DEFAULT *shared.WorkflowExecution
+func (p *RecordChildExecutionCompletedRequest) GetWorkflowExecution() *shared.WorkflowExecution {
+  if !p.IsSetWorkflowExecution() {
+    return RecordChildExecutionCompletedRequest_WorkflowExecution_DEFAULT
+  }
+return p.WorkflowExecution
+}
+var RecordChildExecutionCompletedRequest_InitiatedId_DEFAULT int64
+func (p *RecordChildExecutionCompletedRequest) GetInitiatedId() int64 {
+  if !p.IsSetInitiatedId() {
+    return RecordChildExecutionCompletedRequest_InitiatedId_DEFAULT
+  }
+return *p.InitiatedId
+}
+var RecordChildExecutionCompletedRequest_CompletedExecution_DEFAULT *shared.WorkflowExecution
+func (p *RecordChildExecutionCompletedRequest) GetCompletedExecution() *shared.WorkflowExecution {
+  if !p.IsSetCompletedExecution() {
+    return RecordChildExecutionCompletedRequest_CompletedExecution_DEFAULT
+  }
+return p.CompletedExecution
+}
+var RecordChildExecutionCompletedRequest_CompletionEvent_DEFAULT *shared.HistoryEvent
+func (p *RecordChildExecutionCompletedRequest) GetCompletionEvent() *shared.HistoryEvent {
+  if !p.IsSetCompletionEvent() {
+    return RecordChildExecutionCompletedRequest_CompletionEvent_DEFAULT
+  }
+return p.CompletionEvent
+}
+func (p *RecordChildExecutionCompletedRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *RecordChildExecutionCompletedRequest) IsSetWorkflowExecution() bool {
+  return p.WorkflowExecution != nil
+}
+
+func (p *RecordChildExecutionCompletedRequest) IsSetInitiatedId() bool {
+  return p.InitiatedId != nil
+}
+
+func (p *RecordChildExecutionCompletedRequest) IsSetCompletedExecution() bool {
+  return p.CompletedExecution != nil
+}
+
+func (p *RecordChildExecutionCompletedRequest) IsSetCompletionEvent() bool {
+  return p.CompletionEvent != nil
+}
+
+func (p *RecordChildExecutionCompletedRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    case 30:
+      if err := p.ReadField30(iprot); err != nil {
+        return err
+      }
+    case 40:
+      if err := p.ReadField40(iprot); err != nil {
+        return err
+      }
+    case 50:
+      if err := p.ReadField50(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *RecordChildExecutionCompletedRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *RecordChildExecutionCompletedRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.WorkflowExecution = &shared.WorkflowExecution{}
+  if err := p.WorkflowExecution.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowExecution), err)
+  }
+  return nil
+}
+
+func (p *RecordChildExecutionCompletedRequest)  ReadField30(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 30: ", err)
+} else {
+  p.InitiatedId = &v
+}
+  return nil
+}
+
+func (p *RecordChildExecutionCompletedRequest)  ReadField40(iprot thrift.TProtocol) error {
+  p.CompletedExecution = &shared.WorkflowExecution{}
+  if err := p.CompletedExecution.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompletedExecution), err)
+  }
+  return nil
+}
+
+func (p *RecordChildExecutionCompletedRequest)  ReadField50(iprot thrift.TProtocol) error {
+  p.CompletionEvent = &shared.HistoryEvent{}
+  if err := p.CompletionEvent.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompletionEvent), err)
+  }
+  return nil
+}
+
+func (p *RecordChildExecutionCompletedRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordChildExecutionCompletedRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+    if err := p.writeField30(oprot); err != nil { return err }
+    if err := p.writeField40(oprot); err != nil { return err }
+    if err := p.writeField50(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *RecordChildExecutionCompletedRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordChildExecutionCompletedRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetWorkflowExecution() {
+    if err := oprot.WriteFieldBegin("workflowExecution", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:workflowExecution: ", p), err) }
+    if err := p.WorkflowExecution.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowExecution), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:workflowExecution: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordChildExecutionCompletedRequest) writeField30(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInitiatedId() {
+    if err := oprot.WriteFieldBegin("initiatedId", thrift.I64, 30); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:initiatedId: ", p), err) }
+    if err := oprot.WriteI64(int64(*p.InitiatedId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.initiatedId (30) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:initiatedId: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordChildExecutionCompletedRequest) writeField40(oprot thrift.TProtocol) (err error) {
+  if p.IsSetCompletedExecution() {
+    if err := oprot.WriteFieldBegin("completedExecution", thrift.STRUCT, 40); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:completedExecution: ", p), err) }
+    if err := p.CompletedExecution.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompletedExecution), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 40:completedExecution: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordChildExecutionCompletedRequest) writeField50(oprot thrift.TProtocol) (err error) {
+  if p.IsSetCompletionEvent() {
+    if err := oprot.WriteFieldBegin("completionEvent", thrift.STRUCT, 50); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 50:completionEvent: ", p), err) }
+    if err := p.CompletionEvent.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompletionEvent), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 50:completionEvent: ", p), err) }
+  }
+  return err
+}
+
+func (p *RecordChildExecutionCompletedRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("RecordChildExecutionCompletedRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - ShardId
+//  - QueueType
+//  - Paused
+type UpdateQueueProcessingRequest struct {
+  // unused fields # 1 to 9
+  ShardId *int32 `thrift:"shardId,10" db:"shardId" json:"shardId,omitempty"`
+  // unused fields # 11 to 19
+  QueueType *QueueType `thrift:"queueType,20" db:"queueType" json:"queueType,omitempty"`
+  // unused fields # 21 to 29
+  Paused *bool `thrift:"paused,30" db:"paused" json:"paused,omitempty"`
+}
+
+func NewUpdateQueueProcessingRequest() *UpdateQueueProcessingRequest {
+  return &UpdateQueueProcessingRequest{}
+}
+
+var UpdateQueueProcessingRequest_ShardId_DEFAULT int32
+func (p *UpdateQueueProcessingRequest) GetShardId() int32 {
+  if !p.IsSetShardId() {
+    return UpdateQueueProcessingRequest_ShardId_DEFAULT
+  }
+return *p.ShardId
+}
+var UpdateQueueProcessingRequest_QueueType_DEFAULT QueueType
+func (p *UpdateQueueProcessingRequest) GetQueueType() QueueType {
+  if !p.IsSetQueueType() {
+    return UpdateQueueProcessingRequest_QueueType_DEFAULT
+  }
+return *p.QueueType
+}
+var UpdateQueueProcessingRequest_Paused_DEFAULT bool
+func (p *UpdateQueueProcessingRequest) GetPaused() bool {
+  if !p.IsSetPaused() {
+    return UpdateQueueProcessingRequest_Paused_DEFAULT
+  }
+return *p.Paused
+}
+func (p *UpdateQueueProcessingRequest) IsSetShardId() bool {
+  return p.ShardId != nil
+}
+
+func (p *UpdateQueueProcessingRequest) IsSetQueueType() bool {
+  return p.QueueType != nil
+}
+
+func (p *UpdateQueueProcessingRequest) IsSetPaused() bool {
+  return p.Paused != nil
+}
+
+func (p *UpdateQueueProcessingRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    case 30:
+      if err := p.ReadField30(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *UpdateQueueProcessingRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.ShardId = &v
+}
+  return nil
+}
+
+func (p *UpdateQueueProcessingRequest)  ReadField20(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 20: ", err)
+} else {
+  temp := QueueType(v)
+  p.QueueType = &temp
+}
+  return nil
+}
+
+func (p *UpdateQueueProcessingRequest)  ReadField30(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadBool(); err != nil {
+  return thrift.PrependError("error reading field 30: ", err)
+} else {
+  p.Paused = &v
+}
+  return nil
+}
+
+func (p *UpdateQueueProcessingRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("UpdateQueueProcessingRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+    if err := p.writeField30(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *UpdateQueueProcessingRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardId() {
+    if err := oprot.WriteFieldBegin("shardId", thrift.I32, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:shardId: ", p), err) }
+    if err := oprot.WriteI32(int32(*p.ShardId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.shardId (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:shardId: ", p), err) }
+  }
+  return err
+}
+
+func (p *UpdateQueueProcessingRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetQueueType() {
+    if err := oprot.WriteFieldBegin("queueType", thrift.I32, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:queueType: ", p), err) }
+    if err := oprot.WriteI32(int32(*p.QueueType)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.queueType (20) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:queueType: ", p), err) }
+  }
+  return err
+}
+
+func (p *UpdateQueueProcessingRequest) writeField30(oprot thrift.TProtocol) (err error) {
+  if p.IsSetPaused() {
+    if err := oprot.WriteFieldBegin("paused", thrift.BOOL, 30); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:paused: ", p), err) }
+    if err := oprot.WriteBool(bool(*p.Paused)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.paused (30) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:paused: ", p), err) }
+  }
+  return err
+}
+
+func (p *UpdateQueueProcessingRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("UpdateQueueProcessingRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - DomainUUID
+//  - Execution
+type DescribeMutableStateRequest struct {
+  // unused fields # 1 to 9
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 11 to 19
+  Execution *shared.WorkflowExecution `thrift:"execution,20" db:"execution" json:"execution,omitempty"`
+}
+
+func NewDescribeMutableStateRequest() *DescribeMutableStateRequest {
+  return &DescribeMutableStateRequest{}
+}
+
+var DescribeMutableStateRequest_DomainUUID_DEFAULT string
+func (p *DescribeMutableStateRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return DescribeMutableStateRequest_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var DescribeMutableStateRequest_Execution_DEFAULT *shared.WorkflowExecution
+func (p *DescribeMutableStateRequest) GetExecution() *shared.WorkflowExecution {
+  if !p.IsSetExecution() {
+    return DescribeMutableStateRequest_Execution_DEFAULT
+  }
+return p.Execution
+}
+func (p *DescribeMutableStateRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *DescribeMutableStateRequest) IsSetExecution() bool {
+  return p.Execution != nil
+}
+
+func (p *DescribeMutableStateRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *DescribeMutableStateRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *DescribeMutableStateRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.Execution = &shared.WorkflowExecution{}
+  if err := p.Execution.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Execution), err)
+  }
+  return nil
+}
+
+func (p *DescribeMutableStateRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeMutableStateRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *DescribeMutableStateRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *DescribeMutableStateRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetExecution() {
+    if err := oprot.WriteFieldBegin("execution", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:execution: ", p), err) }
+    if err := p.Execution.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Execution), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:execution: ", p), err) }
+  }
+  return err
+}
+
+func (p *DescribeMutableStateRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("DescribeMutableStateRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - MutableStateInJson
+type DescribeMutableStateResponse struct {
+  // unused fields # 1 to 9
+  MutableStateInJson *string `thrift:"mutableStateInJson,10" db:"mutableStateInJson" json:"mutableStateInJson,omitempty"`
+}
+
+func NewDescribeMutableStateResponse() *DescribeMutableStateResponse {
+  return &DescribeMutableStateResponse{}
+}
+
+var DescribeMutableStateResponse_MutableStateInJson_DEFAULT string
+func (p *DescribeMutableStateResponse) GetMutableStateInJson() string {
+  if !p.IsSetMutableStateInJson() {
+    return DescribeMutableStateResponse_MutableStateInJson_DEFAULT
+  }
+return *p.MutableStateInJson
+}
+func (p *DescribeMutableStateResponse) IsSetMutableStateInJson() bool {
+  return p.MutableStateInJson != nil
+}
+
+func (p *DescribeMutableStateResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *DescribeMutableStateResponse)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.MutableStateInJson = &v
+}
+  return nil
+}
+
+func (p *DescribeMutableStateResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeMutableStateResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *DescribeMutableStateResponse) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetMutableStateInJson() {
+    if err := oprot.WriteFieldBegin("mutableStateInJson", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:mutableStateInJson: ", p), err) }
+    if err := oprot.WriteString(string(*p.MutableStateInJson)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.mutableStateInJson (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:mutableStateInJson: ", p), err) }
+  }
+  return err
+}
+
+func (p *DescribeMutableStateResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("DescribeMutableStateResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - DomainUUID
+//  - RefreshRequest
+type RefreshWorkflowTasksRequest struct {
+  // unused fields # 1 to 9
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 11 to 19
+  RefreshRequest *shared.RefreshWorkflowTasksRequest `thrift:"refreshRequest,20" db:"refreshRequest" json:"refreshRequest,omitempty"`
+}
+
+func NewRefreshWorkflowTasksRequest() *RefreshWorkflowTasksRequest {
+  return &RefreshWorkflowTasksRequest{}
+}
+
+var RefreshWorkflowTasksRequest_DomainUUID_DEFAULT string
+func (p *RefreshWorkflowTasksRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return RefreshWorkflowTasksRequest_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var RefreshWorkflowTasksRequest_RefreshRequest_DEFAULT *shared.RefreshWorkflowTasksRequest
+func (p *RefreshWorkflowTasksRequest) GetRefreshRequest() *shared.RefreshWorkflowTasksRequest {
+  if !p.IsSetRefreshRequest() {
+    return RefreshWorkflowTasksRequest_RefreshRequest_DEFAULT
+  }
+return p.RefreshRequest
+}
+func (p *RefreshWorkflowTasksRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *RefreshWorkflowTasksRequest) IsSetRefreshRequest() bool {
+  return p.RefreshRequest != nil
+}
+
+func (p *RefreshWorkflowTasksRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *RefreshWorkflowTasksRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *RefreshWorkflowTasksRequest)  ReadField20(iprot thrift.TProtocol) error {
+  p.RefreshRequest = &shared.RefreshWorkflowTasksRequest{}
+  if err := p.RefreshRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.RefreshRequest), err)
+  }
+  return nil
+}
+
+func (p *RefreshWorkflowTasksRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RefreshWorkflowTasksRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *RefreshWorkflowTasksRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *RefreshWorkflowTasksRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetRefreshRequest() {
+    if err := oprot.WriteFieldBegin("refreshRequest", thrift.STRUCT, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:refreshRequest: ", p), err) }
+    if err := p.RefreshRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.RefreshRequest), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:refreshRequest: ", p), err) }
+  }
+  return err
+}
+
+func (p *RefreshWorkflowTasksRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("RefreshWorkflowTasksRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - DomainUUID
+//  - TargetDomainUUID
+//  - MigrateRequest
+type MigrateWorkflowExecutionRequest struct {
+  // unused fields # 1 to 9
+  DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 11 to 19
+  TargetDomainUUID *string `thrift:"targetDomainUUID,20" db:"targetDomainUUID" json:"targetDomainUUID,omitempty"`
+  // unused fields # 21 to 29
+  MigrateRequest *shared.MigrateWorkflowExecutionRequest `thrift:"migrateRequest,30" db:"migrateRequest" json:"migrateRequest,omitempty"`
+}
+
+func NewMigrateWorkflowExecutionRequest() *MigrateWorkflowExecutionRequest {
+  return &MigrateWorkflowExecutionRequest{}
+}
+
+var MigrateWorkflowExecutionRequest_DomainUUID_DEFAULT string
+func (p *MigrateWorkflowExecutionRequest) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return MigrateWorkflowExecutionRequest_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var MigrateWorkflowExecutionRequest_TargetDomainUUID_DEFAULT string
+func (p *MigrateWorkflowExecutionRequest) GetTargetDomainUUID() string {
+  if !p.IsSetTargetDomainUUID() {
+    return MigrateWorkflowExecutionRequest_TargetDomainUUID_DEFAULT
+  }
+return *p.TargetDomainUUID
+}
+var MigrateWorkflowExecutionRequest_MigrateRequest_DEFAULT *shared.MigrateWorkflowExecutionRequest
+func (p *MigrateWorkflowExecutionRequest) GetMigrateRequest() *shared.MigrateWorkflowExecutionRequest {
+  if !p.IsSetMigrateRequest() {
+    return MigrateWorkflowExecutionRequest_MigrateRequest_DEFAULT
+  }
+return p.MigrateRequest
+}
+func (p *MigrateWorkflowExecutionRequest) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *MigrateWorkflowExecutionRequest) IsSetTargetDomainUUID() bool {
+  return p.TargetDomainUUID != nil
+}
+
+func (p *MigrateWorkflowExecutionRequest) IsSetMigrateRequest() bool {
+  return p.MigrateRequest != nil
+}
+
+func (p *MigrateWorkflowExecutionRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    case 30:
+      if err := p.ReadField30(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *MigrateWorkflowExecutionRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *MigrateWorkflowExecutionRequest)  ReadField20(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 20: ", err)
+} else {
+  p.TargetDomainUUID = &v
+}
+  return nil
+}
+
+func (p *MigrateWorkflowExecutionRequest)  ReadField30(iprot thrift.TProtocol) error {
+  p.MigrateRequest = &shared.MigrateWorkflowExecutionRequest{}
+  if err := p.MigrateRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.MigrateRequest), err)
+  }
+  return nil
+}
+
+func (p *MigrateWorkflowExecutionRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("MigrateWorkflowExecutionRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+    if err := p.writeField30(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *MigrateWorkflowExecutionRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *MigrateWorkflowExecutionRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetTargetDomainUUID() {
+    if err := oprot.WriteFieldBegin("targetDomainUUID", thrift.STRING, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:targetDomainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.TargetDomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.targetDomainUUID (20) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:targetDomainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *MigrateWorkflowExecutionRequest) writeField30(oprot thrift.TProtocol) (err error) {
+  if p.IsSetMigrateRequest() {
+    if err := oprot.WriteFieldBegin("migrateRequest", thrift.STRUCT, 30); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:migrateRequest: ", p), err) }
+    if err := p.MigrateRequest.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.MigrateRequest), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:migrateRequest: ", p), err) }
+  }
+  return err
+}
+
+func (p *MigrateWorkflowExecutionRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("MigrateWorkflowExecutionRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - ShardId
+//  - DomainUUID
+//  - Execution
+//  - Reason
+//  - StaleSinceTimestamp
+type StaleExecution struct {
+  // unused fields # 1 to 9
+  ShardId *int32 `thrift:"shardId,10" db:"shardId" json:"shardId,omitempty"`
+  // unused fields # 11 to 19
+  DomainUUID *string `thrift:"domainUUID,20" db:"domainUUID" json:"domainUUID,omitempty"`
+  // unused fields # 21 to 29
+  Execution *shared.WorkflowExecution `thrift:"execution,30" db:"execution" json:"execution,omitempty"`
+  // unused fields # 31 to 39
+  Reason *StaleExecutionReason `thrift:"reason,40" db:"reason" json:"reason,omitempty"`
+  // unused fields # 41 to 49
+  StaleSinceTimestamp *int64 `thrift:"staleSinceTimestamp,50" db:"staleSinceTimestamp" json:"staleSinceTimestamp,omitempty"`
+}
+
+func NewStaleExecution() *StaleExecution {
+  return &StaleExecution{}
+}
+
+var StaleExecution_ShardId_DEFAULT int32
+func (p *StaleExecution) GetShardId() int32 {
+  if !p.IsSetShardId() {
+    return StaleExecution_ShardId_DEFAULT
+  }
+return *p.ShardId
+}
+var StaleExecution_DomainUUID_DEFAULT string
+func (p *StaleExecution) GetDomainUUID() string {
+  if !p.IsSetDomainUUID() {
+    return StaleExecution_DomainUUID_DEFAULT
+  }
+return *p.DomainUUID
+}
+var StaleExecution_Execution_DEFAULT *shared.WorkflowExecution
+func (p *StaleExecution) GetExecution() *shared.WorkflowExecution {
+  if !p.IsSetExecution() {
+    return StaleExecution_Execution_DEFAULT
+  }
+return p.Execution
+}
+var StaleExecution_Reason_DEFAULT StaleExecutionReason
+func (p *StaleExecution) GetReason() StaleExecutionReason {
+  if !p.IsSetReason() {
+    return StaleExecution_Reason_DEFAULT
+  }
+return *p.Reason
+}
+var StaleExecution_StaleSinceTimestamp_DEFAULT int64
+func (p *StaleExecution) GetStaleSinceTimestamp() int64 {
+  if !p.IsSetStaleSinceTimestamp() {
+    return StaleExecution_StaleSinceTimestamp_DEFAULT
+  }
+return *p.StaleSinceTimestamp
+}
+func (p *StaleExecution) IsSetShardId() bool {
+  return p.ShardId != nil
+}
+
+func (p *StaleExecution) IsSetDomainUUID() bool {
+  return p.DomainUUID != nil
+}
+
+func (p *StaleExecution) IsSetExecution() bool {
+  return p.Execution != nil
+}
+
+func (p *StaleExecution) IsSetReason() bool {
+  return p.Reason != nil
+}
+
+func (p *StaleExecution) IsSetStaleSinceTimestamp() bool {
+  return p.StaleSinceTimestamp != nil
+}
+
+func (p *StaleExecution) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    case 30:
+      if err := p.ReadField30(iprot); err != nil {
+        return err
+      }
+    case 40:
+      if err := p.ReadField40(iprot); err != nil {
+        return err
+      }
+    case 50:
+      if err := p.ReadField50(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *StaleExecution)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.ShardId = &v
+}
+  return nil
+}
+
+func (p *StaleExecution)  ReadField20(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 20: ", err)
+} else {
+  p.DomainUUID = &v
+}
+  return nil
+}
+
+func (p *StaleExecution)  ReadField30(iprot thrift.TProtocol) error {
+  p.Execution = &shared.WorkflowExecution{}
+  if err := p.Execution.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Execution), err)
+  }
+  return nil
+}
+
+func (p *StaleExecution)  ReadField40(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 40: ", err)
+} else {
+  temp := StaleExecutionReason(v)
+  p.Reason = &temp
+}
+  return nil
+}
+
+func (p *StaleExecution)  ReadField50(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI64(); err != nil {
+  return thrift.PrependError("error reading field 50: ", err)
+} else {
+  p.StaleSinceTimestamp = &v
+}
+  return nil
+}
+
+func (p *StaleExecution) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("StaleExecution"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+    if err := p.writeField30(oprot); err != nil { return err }
+    if err := p.writeField40(oprot); err != nil { return err }
+    if err := p.writeField50(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *StaleExecution) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardId() {
+    if err := oprot.WriteFieldBegin("shardId", thrift.I32, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:shardId: ", p), err) }
+    if err := oprot.WriteI32(int32(*p.ShardId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.shardId (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:shardId: ", p), err) }
+  }
+  return err
+}
+
+func (p *StaleExecution) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainUUID() {
+    if err := oprot.WriteFieldBegin("domainUUID", thrift.STRING, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:domainUUID: ", p), err) }
+    if err := oprot.WriteString(string(*p.DomainUUID)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.domainUUID (20) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:domainUUID: ", p), err) }
+  }
+  return err
+}
+
+func (p *StaleExecution) writeField30(oprot thrift.TProtocol) (err error) {
+  if p.IsSetExecution() {
+    if err := oprot.WriteFieldBegin("execution", thrift.STRUCT, 30); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 30:execution: ", p), err) }
+    if err := p.Execution.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Execution), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 30:execution: ", p), err) }
+  }
+  return err
+}
+
+func (p *StaleExecution) writeField40(oprot thrift.TProtocol) (err error) {
+  if p.IsSetReason() {
+    if err := oprot.WriteFieldBegin("reason", thrift.I32, 40); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 40:reason: ", p), err) }
+    if err := oprot.WriteI32(int32(*p.Reason)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.reason (40) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 40:reason: ", p), err) }
+  }
+  return err
+}
+
+func (p *StaleExecution) writeField50(oprot thrift.TProtocol) (err error) {
+  if p.IsSetStaleSinceTimestamp() {
+    if err := oprot.WriteFieldBegin("staleSinceTimestamp", thrift.I64, 50); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 50:staleSinceTimestamp: ", p), err) }
+    if err := oprot.WriteI64(int64(*p.StaleSinceTimestamp)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.staleSinceTimestamp (50) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 50:staleSinceTimestamp: ", p), err) }
+  }
+  return err
+}
+
+func (p *StaleExecution) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("StaleExecution(%+v)", *p)
+}
+
+// Attributes:
+//  - ShardId
+type ListStaleExecutionsRequest struct {
+  // unused fields # 1 to 9
+  ShardId *int32 `thrift:"shardId,10" db:"shardId" json:"shardId,omitempty"`
+}
+
+func NewListStaleExecutionsRequest() *ListStaleExecutionsRequest {
+  return &ListStaleExecutionsRequest{}
+}
+
+var ListStaleExecutionsRequest_ShardId_DEFAULT int32
+func (p *ListStaleExecutionsRequest) GetShardId() int32 {
+  if !p.IsSetShardId() {
+    return ListStaleExecutionsRequest_ShardId_DEFAULT
+  }
+return *p.ShardId
+}
+func (p *ListStaleExecutionsRequest) IsSetShardId() bool {
+  return p.ShardId != nil
+}
+
+func (p *ListStaleExecutionsRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ListStaleExecutionsRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.ShardId = &v
+}
+  return nil
+}
+
+func (p *ListStaleExecutionsRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListStaleExecutionsRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ListStaleExecutionsRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardId() {
+    if err := oprot.WriteFieldBegin("shardId", thrift.I32, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:shardId: ", p), err) }
+    if err := oprot.WriteI32(int32(*p.ShardId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.shardId (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:shardId: ", p), err) }
+  }
+  return err
+}
+
+func (p *ListStaleExecutionsRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ListStaleExecutionsRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - Executions
+type ListStaleExecutionsResponse struct {
+  // unused fields # 1 to 9
+  Executions []*StaleExecution `thrift:"executions,10" db:"executions" json:"executions,omitempty"`
+}
+
+func NewListStaleExecutionsResponse() *ListStaleExecutionsResponse {
+  return &ListStaleExecutionsResponse{}
+}
+
+var ListStaleExecutionsResponse_Executions_DEFAULT []*StaleExecution
+
+func (p *ListStaleExecutionsResponse) GetExecutions() []*StaleExecution {
+  return p.Executions
+}
+func (p *ListStaleExecutionsResponse) IsSetExecutions() bool {
+  return p.Executions != nil
+}
+
+func (p *ListStaleExecutionsResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *ListStaleExecutionsResponse)  ReadField10(iprot thrift.TProtocol) error {
+  _, size, err := iprot.ReadListBegin()
+  if err != nil {
+    return thrift.PrependError("error reading list begin: ", err)
+  }
+  tSlice := make([]*StaleExecution, 0, size)
+  p.Executions =  tSlice
+  for i := 0; i < size; i ++ {
+    _elem4 := &StaleExecution{}
+    if err := _elem4.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem4), err)
+    }
+    p.Executions = append(p.Executions, _elem4)
+  }
+  if err := iprot.ReadListEnd(); err != nil {
+    return thrift.PrependError("error reading list end: ", err)
+  }
+  return nil
+}
+
+func (p *ListStaleExecutionsResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListStaleExecutionsResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *ListStaleExecutionsResponse) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetExecutions() {
+    if err := oprot.WriteFieldBegin("executions", thrift.LIST, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:executions: ", p), err) }
+    if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Executions)); err != nil {
+      return thrift.PrependError("error writing list begin: ", err)
+    }
+    for _, v := range p.Executions {
+      if err := v.Write(oprot); err != nil {
+        return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+      }
+    }
+    if err := oprot.WriteListEnd(); err != nil {
+      return thrift.PrependError("error writing list end: ", err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:executions: ", p), err) }
+  }
+  return err
+}
+
+func (p *ListStaleExecutionsResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("ListStaleExecutionsResponse(%+v)", *p)
+}
+
+// Attributes:
+//  - ShardId
+//  - MaximumTaskCount
+type RedriveTransferDLQTasksRequest struct {
+  // unused fields # 1 to 9
+  ShardId *int32 `thrift:"shardId,10" db:"shardId" json:"shardId,omitempty"`
+  // unused fields # 11 to 19
+  MaximumTaskCount *int32 `thrift:"maximumTaskCount,20" db:"maximumTaskCount" json:"maximumTaskCount,omitempty"`
+}
+
+func NewRedriveTransferDLQTasksRequest() *RedriveTransferDLQTasksRequest {
+  return &RedriveTransferDLQTasksRequest{}
+}
+
+var RedriveTransferDLQTasksRequest_ShardId_DEFAULT int32
+func (p *RedriveTransferDLQTasksRequest) GetShardId() int32 {
+  if !p.IsSetShardId() {
+    return RedriveTransferDLQTasksRequest_ShardId_DEFAULT
+  }
+return *p.ShardId
+}
+var RedriveTransferDLQTasksRequest_MaximumTaskCount_DEFAULT int32
+func (p *RedriveTransferDLQTasksRequest) GetMaximumTaskCount() int32 {
+  if !p.IsSetMaximumTaskCount() {
+    return RedriveTransferDLQTasksRequest_MaximumTaskCount_DEFAULT
+  }
+return *p.MaximumTaskCount
+}
+func (p *RedriveTransferDLQTasksRequest) IsSetShardId() bool {
+  return p.ShardId != nil
+}
+
+func (p *RedriveTransferDLQTasksRequest) IsSetMaximumTaskCount() bool {
+  return p.MaximumTaskCount != nil
+}
+
+func (p *RedriveTransferDLQTasksRequest) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    case 20:
+      if err := p.ReadField20(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *RedriveTransferDLQTasksRequest)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.ShardId = &v
+}
+  return nil
+}
+
+func (p *RedriveTransferDLQTasksRequest)  ReadField20(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 20: ", err)
+} else {
+  p.MaximumTaskCount = &v
+}
+  return nil
+}
+
+func (p *RedriveTransferDLQTasksRequest) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RedriveTransferDLQTasksRequest"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+    if err := p.writeField20(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *RedriveTransferDLQTasksRequest) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardId() {
+    if err := oprot.WriteFieldBegin("shardId", thrift.I32, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:shardId: ", p), err) }
+    if err := oprot.WriteI32(int32(*p.ShardId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.shardId (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:shardId: ", p), err) }
+  }
+  return err
+}
+
+func (p *RedriveTransferDLQTasksRequest) writeField20(oprot thrift.TProtocol) (err error) {
+  if p.IsSetMaximumTaskCount() {
+    if err := oprot.WriteFieldBegin("maximumTaskCount", thrift.I32, 20); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 20:maximumTaskCount: ", p), err) }
+    if err := oprot.WriteI32(int32(*p.MaximumTaskCount)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.maximumTaskCount (20) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 20:maximumTaskCount: ", p), err) }
+  }
+  return err
+}
+
+func (p *RedriveTransferDLQTasksRequest) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("RedriveTransferDLQTasksRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - RedrivenTaskCount
+type RedriveTransferDLQTasksResponse struct {
+  // unused fields # 1 to 9
+  RedrivenTaskCount *int32 `thrift:"redrivenTaskCount,10" db:"redrivenTaskCount" json:"redrivenTaskCount,omitempty"`
+}
+
+func NewRedriveTransferDLQTasksResponse() *RedriveTransferDLQTasksResponse {
+  return &RedriveTransferDLQTasksResponse{}
+}
+
+var RedriveTransferDLQTasksResponse_RedrivenTaskCount_DEFAULT int32
+func (p *RedriveTransferDLQTasksResponse) GetRedrivenTaskCount() int32 {
+  if !p.IsSetRedrivenTaskCount() {
+    return RedriveTransferDLQTasksResponse_RedrivenTaskCount_DEFAULT
+  }
+return *p.RedrivenTaskCount
+}
+func (p *RedriveTransferDLQTasksResponse) IsSetRedrivenTaskCount() bool {
+  return p.RedrivenTaskCount != nil
+}
+
+func (p *RedriveTransferDLQTasksResponse) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 10:
+      if err := p.ReadField10(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *RedriveTransferDLQTasksResponse)  ReadField10(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 10: ", err)
+} else {
+  p.RedrivenTaskCount = &v
+}
+  return nil
+}
+
+func (p *RedriveTransferDLQTasksResponse) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RedriveTransferDLQTasksResponse"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField10(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *RedriveTransferDLQTasksResponse) writeField10(oprot thrift.TProtocol) (err error) {
+  if p.IsSetRedrivenTaskCount() {
+    if err := oprot.WriteFieldBegin("redrivenTaskCount", thrift.I32, 10); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:redrivenTaskCount: ", p), err) }
+    if err := oprot.WriteI32(int32(*p.RedrivenTaskCount)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.redrivenTaskCount (10) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 10:redrivenTaskCount: ", p), err) }
+  }
+  return err
+}
+
+func (p *RedriveTransferDLQTasksResponse) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("RedriveTransferDLQTasksResponse(%+v)", *p)
+}
+
+type HistoryService interface {  //HistoryService provides API to start a new long running workflow instance, as well as query and update the history
+  //of workflow instances already created.
+  //
+
+  // StartWorkflowExecution starts a new long running workflow instance.  It will create the instance with
+  // 'WorkflowExecutionStarted' event in history and also schedule the first DecisionTask for the worker to make the
+  // first decision for this instance.  It will return 'WorkflowExecutionAlreadyStartedError', if an instance already
+  // exists with same workflowId.
   // 
   // 
   // Parameters:
@@ -3368,6 +5918,14 @@ type HistoryService interface {  //HistoryService provides API to start a new lo
   // Parameters:
   //  - GetRequest
   GetWorkflowExecutionNextEventID(getRequest *GetWorkflowExecutionNextEventIDRequest) (r *GetWorkflowExecutionNextEventIDResponse, err error)
+  // BatchGetWorkflowExecutionNextEventID returns the nextEventID of the history of each of the workflow executions,
+  // in the order of the requests.  The outcome of each execution is reported by its own result, the call only fails
+  // when the batch itself is invalid.  Callers validating many executions send one batch per history host.
+  // 
+  // 
+  // Parameters:
+  //  - GetRequest
+  BatchGetWorkflowExecutionNextEventID(getRequest *BatchGetWorkflowExecutionNextEventIDRequest) (r *BatchGetWorkflowExecutionNextEventIDResponse, err error)
   // RecordDecisionTaskStarted is called by the Matchingservice before it hands a decision task to the application worker in response to
   // a PollForDecisionTask call. It records in the history the event that the decision task has started. It will return 'EventAlreadyStartedError',
   // if the workflow's execution history already includes a record of the event starting.
@@ -3388,12 +5946,13 @@ type HistoryService interface {  //HistoryService provides API to start a new lo
   // 'PollForDecisionTask' API call.  Completing a DecisionTask will result in new events for the workflow execution and
   // potentially new ActivityTask being created for corresponding decisions.  It will also create a DecisionTaskCompleted
   // event in the history for that session.  Use the 'taskToken' provided as response of PollForDecisionTask API call
-  // for completing the DecisionTask.
+  // for completing the DecisionTask.  Activities scheduled with 'requestLocalDispatch' in the same domain are started
+  // right away and returned in the response, for the worker to run them without polling.
   // 
   // 
   // Parameters:
   //  - CompleteRequest
-  RespondDecisionTaskCompleted(completeRequest *RespondDecisionTaskCompletedRequest) (err error)
+  RespondDecisionTaskCompleted(completeRequest *RespondDecisionTaskCompletedRequest) (r *shared.RespondDecisionTaskCompletedResponse, err error)
   // RecordActivityTaskHeartbeat is called by application worker while it is processing an ActivityTask.  If worker fails
   // to heartbeat within 'heartbeatTimeoutSeconds' interval for the ActivityTask, then it will be marked as timedout and
   // 'ActivityTaskTimedOut' event will be written to the workflow history.  Calling 'RecordActivityTaskHeartbeat' will
@@ -3436,18 +5995,43 @@ type HistoryService interface {  //HistoryService provides API to start a new lo
   RespondActivityTaskCanceled(canceledRequest *RespondActivityTaskCanceledRequest) (err error)
   // SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
   // WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
+  // It returns the receipt of the signal.
   // 
   // 
   // Parameters:
   //  - SignalRequest
-  SignalWorkflowExecution(signalRequest *SignalWorkflowExecutionRequest) (err error)
-  // TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
-  // in the history and immediately terminating the execution instance.
+  SignalWorkflowExecution(signalRequest *SignalWorkflowExecutionRequest) (r *shared.SignalWorkflowExecutionResponse, err error)
+  // GetSignalReceipt tells whether the signal sent with the request ID was recorded by the workflow execution.
+  // 
+  // 
+  // Parameters:
+  //  - ReceiptRequest
+  GetSignalReceipt(receiptRequest *GetSignalReceiptRequest) (r *shared.GetSignalReceiptResponse, err error)
+  // QueryWorkflow queues a query to a running workflow execution and waits for the answer.  Queued queries are handed
+  // to the worker with the decision task of the execution waiting for a poller, or else with a query task of their own
+  // which doesn't change the history.  It fails with 'QueryFailedError' if the worker failed the query or didn't answer
+  // it.
+  // 
+  // 
+  // Parameters:
+  //  - QueryRequest
+  QueryWorkflow(queryRequest *QueryWorkflowRequest) (r *shared.QueryWorkflowResponse, err error)
+  // DescribeWorkflowExecution returns information about the specified workflow execution, including the activities
+  // still pending on it.
+  // 
+  // 
+  // Parameters:
+  //  - DescribeRequest
+  DescribeWorkflowExecution(describeRequest *DescribeWorkflowExecutionRequest) (r *shared.DescribeWorkflowExecutionResponse, err error)
+  // TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminatedByOperator
+  // event in the history and immediately terminating the execution instance.  Running child executions are left
+  // alone, unless the request sets a 'childPolicy' to terminate or cancel them.  All the executions affected are
+  // returned.
   // 
   // 
   // Parameters:
   //  - TerminateRequest
-  TerminateWorkflowExecution(terminateRequest *TerminateWorkflowExecutionRequest) (err error)
+  TerminateWorkflowExecution(terminateRequest *TerminateWorkflowExecutionRequest) (r *shared.TerminateWorkflowExecutionResponse, err error)
   // RequestCancelWorkflowExecution is called by application worker when it wants to request cancellation of a workflow instance.
   // It will result in a new 'WorkflowExecutionCancelRequested' event being written to the workflow history and a new DecisionTask
   // created for the workflow instance so new decisions could be made. It fails with 'EntityNotExistsError' if the workflow is not valid
@@ -3473,2244 +6057,7346 @@ type HistoryService interface {  //HistoryService provides API to start a new lo
   // Parameters:
   //  - CompletionRequest
   RecordChildExecutionCompleted(completionRequest *RecordChildExecutionCompletedRequest) (err error)
+  // UpdateQueueProcessing is an admin API to pause or resume the processing of the transfer or timer queue of a shard.
+  // It is meant for controlled draining of shards during incident mitigation.  Tasks keep accumulating in the queue
+  // while processing is paused and are processed once it is resumed.  The paused state is persisted on the shard and
+  // kept when the shard moves to another host, it overrides the paused state the host is configured to start queues in.
+  // 
+  // 
+  // Parameters:
+  //  - UpdateRequest
+  UpdateQueueProcessing(updateRequest *UpdateQueueProcessingRequest) (err error)
+  // DescribeMutableState is an admin API to dump the mutable state of a workflow execution as JSON, including its
+  // pending activities, timers, child executions and decision, along with the transfer queue ack levels of the shard.
+  // It is meant for debugging mismatches between the history and the mutable state of an execution, and never updates
+  // the execution.
+  // 
+  // 
+  // Parameters:
+  //  - Request
+  DescribeMutableState(request *DescribeMutableStateRequest) (r *DescribeMutableStateResponse, err error)
+  // RefreshWorkflowTasks re-generates the transfer and timer tasks of a running workflow execution from its mutable
+  // state.  It is meant to recover executions stuck because one of their tasks was lost or dropped, tasks which were
+  // not lost are processed twice and dropped as duplicates.
+  // 
+  // 
+  // Parameters:
+  //  - RefreshRequest
+  RefreshWorkflowTasks(refreshRequest *RefreshWorkflowTasksRequest) (err error)
+  // ListStaleExecutions is an admin API listing the stale executions found by the last scan of the shard, or of all
+  // shards when no shard is given: running executions whose pending decision has not moved for many times its
+  // timeout, and executions with timers long past due.  Both are symptoms of a stuck shard or of lost tasks.
+  // 
+  // 
+  // Parameters:
+  //  - ListRequest
+  ListStaleExecutions(listRequest *ListStaleExecutionsRequest) (r *ListStaleExecutionsResponse, err error)
+  // MigrateWorkflowExecution moves a closed workflow execution to another domain: its history, mutable state and
+  // visibility record are copied under the ID of the target domain, which then owns its retention, and deleted from
+  // the source domain.  Every step is idempotent, a failed migration is completed by retrying it.
+  // 
+  // 
+  // Parameters:
+  //  - MigrateRequest
+  MigrateWorkflowExecution(migrateRequest *MigrateWorkflowExecutionRequest) (err error)
+  // RedriveTransferDLQTasks is an admin API moving the transfer tasks parked in the dead letter queue of a shard, after
+  // they failed to be added to matching too many times, back to its transfer queue under new task IDs.  At most
+  // maximumTaskCount tasks are moved when it is set, the oldest first.  It returns the number of tasks moved.
+  // 
+  // 
+  // Parameters:
+  //  - RedriveRequest
+  RedriveTransferDLQTasks(redriveRequest *RedriveTransferDLQTasksRequest) (r *RedriveTransferDLQTasksResponse, err error)
+}
+
+//HistoryService provides API to start a new long running workflow instance, as well as query and update the history
+//of workflow instances already created.
+//
+type HistoryServiceClient struct {
+  Transport thrift.TTransport
+  ProtocolFactory thrift.TProtocolFactory
+  InputProtocol thrift.TProtocol
+  OutputProtocol thrift.TProtocol
+  SeqId int32
+}
+
+func NewHistoryServiceClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *HistoryServiceClient {
+  return &HistoryServiceClient{Transport: t,
+    ProtocolFactory: f,
+    InputProtocol: f.GetProtocol(t),
+    OutputProtocol: f.GetProtocol(t),
+    SeqId: 0,
+  }
+}
+
+func NewHistoryServiceClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *HistoryServiceClient {
+  return &HistoryServiceClient{Transport: t,
+    ProtocolFactory: nil,
+    InputProtocol: iprot,
+    OutputProtocol: oprot,
+    SeqId: 0,
+  }
+}
+
+// StartWorkflowExecution starts a new long running workflow instance.  It will create the instance with
+// 'WorkflowExecutionStarted' event in history and also schedule the first DecisionTask for the worker to make the
+// first decision for this instance.  It will return 'WorkflowExecutionAlreadyStartedError', if an instance already
+// exists with same workflowId.
+// 
+// 
+// Parameters:
+//  - StartRequest
+func (p *HistoryServiceClient) StartWorkflowExecution(startRequest *StartWorkflowExecutionRequest) (r *shared.StartWorkflowExecutionResponse, err error) {
+  if err = p.sendStartWorkflowExecution(startRequest); err != nil { return }
+  return p.recvStartWorkflowExecution()
+}
+
+func (p *HistoryServiceClient) sendStartWorkflowExecution(startRequest *StartWorkflowExecutionRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("StartWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceStartWorkflowExecutionArgs{
+  StartRequest : startRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvStartWorkflowExecution() (value *shared.StartWorkflowExecutionResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "StartWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "StartWorkflowExecution failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "StartWorkflowExecution failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error5 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error6 error
+    error6, err = error5.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error6
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "StartWorkflowExecution failed: invalid message type")
+    return
+  }
+  result := HistoryServiceStartWorkflowExecutionResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.SessionAlreadyExistError != nil {
+    err = result.SessionAlreadyExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  } else   if result.DomainNotActiveError != nil {
+    err = result.DomainNotActiveError
+    return 
+  } else   if result.LimitExceededError != nil {
+    err = result.LimitExceededError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// Returns the nextEventID of the history of workflow execution. Only events in the history with Ids below the returned Id are
+// guaranteed to be valid, so the first step of reading an execution's history is to retrieve this event Id.
+// It fails with 'EntityNotExistError' if specified workflow execution in unknown to the service.
+// 
+// 
+// Parameters:
+//  - GetRequest
+func (p *HistoryServiceClient) GetWorkflowExecutionNextEventID(getRequest *GetWorkflowExecutionNextEventIDRequest) (r *GetWorkflowExecutionNextEventIDResponse, err error) {
+  if err = p.sendGetWorkflowExecutionNextEventID(getRequest); err != nil { return }
+  return p.recvGetWorkflowExecutionNextEventID()
+}
+
+func (p *HistoryServiceClient) sendGetWorkflowExecutionNextEventID(getRequest *GetWorkflowExecutionNextEventIDRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("GetWorkflowExecutionNextEventID", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceGetWorkflowExecutionNextEventIDArgs{
+  GetRequest : getRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvGetWorkflowExecutionNextEventID() (value *GetWorkflowExecutionNextEventIDResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "GetWorkflowExecutionNextEventID" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetWorkflowExecutionNextEventID failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetWorkflowExecutionNextEventID failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error7 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error8 error
+    error8, err = error7.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error8
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetWorkflowExecutionNextEventID failed: invalid message type")
+    return
+  }
+  result := HistoryServiceGetWorkflowExecutionNextEventIDResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// BatchGetWorkflowExecutionNextEventID returns the nextEventID of the history of each of the workflow executions,
+// in the order of the requests.  The outcome of each execution is reported by its own result, the call only fails
+// when the batch itself is invalid.  Callers validating many executions send one batch per history host.
+// 
+// 
+// Parameters:
+//  - GetRequest
+func (p *HistoryServiceClient) BatchGetWorkflowExecutionNextEventID(getRequest *BatchGetWorkflowExecutionNextEventIDRequest) (r *BatchGetWorkflowExecutionNextEventIDResponse, err error) {
+  if err = p.sendBatchGetWorkflowExecutionNextEventID(getRequest); err != nil { return }
+  return p.recvBatchGetWorkflowExecutionNextEventID()
+}
+
+func (p *HistoryServiceClient) sendBatchGetWorkflowExecutionNextEventID(getRequest *BatchGetWorkflowExecutionNextEventIDRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("BatchGetWorkflowExecutionNextEventID", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs{
+  GetRequest : getRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvBatchGetWorkflowExecutionNextEventID() (value *BatchGetWorkflowExecutionNextEventIDResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "BatchGetWorkflowExecutionNextEventID" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "BatchGetWorkflowExecutionNextEventID failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "BatchGetWorkflowExecutionNextEventID failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error9 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error10 error
+    error10, err = error9.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error10
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "BatchGetWorkflowExecutionNextEventID failed: invalid message type")
+    return
+  }
+  result := HistoryServiceBatchGetWorkflowExecutionNextEventIDResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// RecordDecisionTaskStarted is called by the Matchingservice before it hands a decision task to the application worker in response to
+// a PollForDecisionTask call. It records in the history the event that the decision task has started. It will return 'EventAlreadyStartedError',
+// if the workflow's execution history already includes a record of the event starting.
+// 
+// 
+// Parameters:
+//  - AddRequest
+func (p *HistoryServiceClient) RecordDecisionTaskStarted(addRequest *RecordDecisionTaskStartedRequest) (r *RecordDecisionTaskStartedResponse, err error) {
+  if err = p.sendRecordDecisionTaskStarted(addRequest); err != nil { return }
+  return p.recvRecordDecisionTaskStarted()
+}
+
+func (p *HistoryServiceClient) sendRecordDecisionTaskStarted(addRequest *RecordDecisionTaskStartedRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RecordDecisionTaskStarted", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRecordDecisionTaskStartedArgs{
+  AddRequest : addRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRecordDecisionTaskStarted() (value *RecordDecisionTaskStartedResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RecordDecisionTaskStarted" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RecordDecisionTaskStarted failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RecordDecisionTaskStarted failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error11 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error12 error
+    error12, err = error11.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error12
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RecordDecisionTaskStarted failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRecordDecisionTaskStartedResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EventAlreadyStartedError != nil {
+    err = result.EventAlreadyStartedError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  } else   if result.ServiceBusyError != nil {
+    err = result.ServiceBusyError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// RecordActivityTaskStarted is called by the Matchingservice before it hands a decision task to the application worker in response to
+// a PollForActivityTask call. It records in the history the event that the decision task has started. It will return 'EventAlreadyStartedError',
+// if the workflow's execution history already includes a record of the event starting.
+// 
+// 
+// Parameters:
+//  - AddRequest
+func (p *HistoryServiceClient) RecordActivityTaskStarted(addRequest *RecordActivityTaskStartedRequest) (r *RecordActivityTaskStartedResponse, err error) {
+  if err = p.sendRecordActivityTaskStarted(addRequest); err != nil { return }
+  return p.recvRecordActivityTaskStarted()
+}
+
+func (p *HistoryServiceClient) sendRecordActivityTaskStarted(addRequest *RecordActivityTaskStartedRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RecordActivityTaskStarted", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRecordActivityTaskStartedArgs{
+  AddRequest : addRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRecordActivityTaskStarted() (value *RecordActivityTaskStartedResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RecordActivityTaskStarted" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RecordActivityTaskStarted failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RecordActivityTaskStarted failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error13 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error14 error
+    error14, err = error13.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error14
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RecordActivityTaskStarted failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRecordActivityTaskStartedResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EventAlreadyStartedError != nil {
+    err = result.EventAlreadyStartedError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  } else   if result.ServiceBusyError != nil {
+    err = result.ServiceBusyError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// RespondDecisionTaskCompleted is called by application worker to complete a DecisionTask handed as a result of
+// 'PollForDecisionTask' API call.  Completing a DecisionTask will result in new events for the workflow execution and
+// potentially new ActivityTask being created for corresponding decisions.  It will also create a DecisionTaskCompleted
+// event in the history for that session.  Use the 'taskToken' provided as response of PollForDecisionTask API call
+// for completing the DecisionTask.  Activities scheduled with 'requestLocalDispatch' in the same domain are started
+// right away and returned in the response, for the worker to run them without polling.
+// 
+// 
+// Parameters:
+//  - CompleteRequest
+func (p *HistoryServiceClient) RespondDecisionTaskCompleted(completeRequest *RespondDecisionTaskCompletedRequest) (r *shared.RespondDecisionTaskCompletedResponse, err error) {
+  if err = p.sendRespondDecisionTaskCompleted(completeRequest); err != nil { return }
+  return p.recvRespondDecisionTaskCompleted()
+}
+
+func (p *HistoryServiceClient) sendRespondDecisionTaskCompleted(completeRequest *RespondDecisionTaskCompletedRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRespondDecisionTaskCompletedArgs{
+  CompleteRequest : completeRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRespondDecisionTaskCompleted() (value *shared.RespondDecisionTaskCompletedResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RespondDecisionTaskCompleted" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondDecisionTaskCompleted failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondDecisionTaskCompleted failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error15 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error16 error
+    error16, err = error15.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error16
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondDecisionTaskCompleted failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRespondDecisionTaskCompletedResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// RecordActivityTaskHeartbeat is called by application worker while it is processing an ActivityTask.  If worker fails
+// to heartbeat within 'heartbeatTimeoutSeconds' interval for the ActivityTask, then it will be marked as timedout and
+// 'ActivityTaskTimedOut' event will be written to the workflow history.  Calling 'RecordActivityTaskHeartbeat' will
+// fail with 'EntityNotExistsError' in such situations.  Use the 'taskToken' provided as response of
+// PollForActivityTask API call for heartbeating.
+// 
+// 
+// Parameters:
+//  - HeartbeatRequest
+func (p *HistoryServiceClient) RecordActivityTaskHeartbeat(heartbeatRequest *RecordActivityTaskHeartbeatRequest) (r *shared.RecordActivityTaskHeartbeatResponse, err error) {
+  if err = p.sendRecordActivityTaskHeartbeat(heartbeatRequest); err != nil { return }
+  return p.recvRecordActivityTaskHeartbeat()
+}
+
+func (p *HistoryServiceClient) sendRecordActivityTaskHeartbeat(heartbeatRequest *RecordActivityTaskHeartbeatRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRecordActivityTaskHeartbeatArgs{
+  HeartbeatRequest : heartbeatRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRecordActivityTaskHeartbeat() (value *shared.RecordActivityTaskHeartbeatResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RecordActivityTaskHeartbeat" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RecordActivityTaskHeartbeat failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RecordActivityTaskHeartbeat failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error17 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error18 error
+    error18, err = error17.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error18
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RecordActivityTaskHeartbeat failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRecordActivityTaskHeartbeatResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  } else   if result.ServiceBusyError != nil {
+    err = result.ServiceBusyError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// RespondActivityTaskCompleted is called by application worker when it is done processing an ActivityTask.  It will
+// result in a new 'ActivityTaskCompleted' event being written to the workflow history and a new DecisionTask
+// created for the workflow so new decisions could be made.  Use the 'taskToken' provided as response of
+// PollForActivityTask API call for completion. It fails with 'EntityNotExistsError' if the taskToken is not valid
+// anymore due to activity timeout.
+// 
+// 
+// Parameters:
+//  - CompleteRequest
+func (p *HistoryServiceClient) RespondActivityTaskCompleted(completeRequest *RespondActivityTaskCompletedRequest) (err error) {
+  if err = p.sendRespondActivityTaskCompleted(completeRequest); err != nil { return }
+  return p.recvRespondActivityTaskCompleted()
+}
+
+func (p *HistoryServiceClient) sendRespondActivityTaskCompleted(completeRequest *RespondActivityTaskCompletedRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRespondActivityTaskCompletedArgs{
+  CompleteRequest : completeRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRespondActivityTaskCompleted() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RespondActivityTaskCompleted" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondActivityTaskCompleted failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondActivityTaskCompleted failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error19 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error20 error
+    error20, err = error19.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error20
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondActivityTaskCompleted failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRespondActivityTaskCompletedResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  return
+}
+
+// RespondActivityTaskFailed is called by application worker when it is done processing an ActivityTask.  It will
+// result in a new 'ActivityTaskFailed' event being written to the workflow history and a new DecisionTask
+// created for the workflow instance so new decisions could be made.  Use the 'taskToken' provided as response of
+// PollForActivityTask API call for completion. It fails with 'EntityNotExistsError' if the taskToken is not valid
+// anymore due to activity timeout.
+// 
+// 
+// Parameters:
+//  - FailRequest
+func (p *HistoryServiceClient) RespondActivityTaskFailed(failRequest *RespondActivityTaskFailedRequest) (err error) {
+  if err = p.sendRespondActivityTaskFailed(failRequest); err != nil { return }
+  return p.recvRespondActivityTaskFailed()
+}
+
+func (p *HistoryServiceClient) sendRespondActivityTaskFailed(failRequest *RespondActivityTaskFailedRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRespondActivityTaskFailedArgs{
+  FailRequest : failRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRespondActivityTaskFailed() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RespondActivityTaskFailed" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondActivityTaskFailed failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondActivityTaskFailed failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error21 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error22 error
+    error22, err = error21.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error22
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondActivityTaskFailed failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRespondActivityTaskFailedResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  return
+}
+
+// RespondActivityTaskCanceled is called by application worker when it is successfully canceled an ActivityTask.  It will
+// result in a new 'ActivityTaskCanceled' event being written to the workflow history and a new DecisionTask
+// created for the workflow instance so new decisions could be made.  Use the 'taskToken' provided as response of
+// PollForActivityTask API call for completion. It fails with 'EntityNotExistsError' if the taskToken is not valid
+// anymore due to activity timeout.
+// 
+// 
+// Parameters:
+//  - CanceledRequest
+func (p *HistoryServiceClient) RespondActivityTaskCanceled(canceledRequest *RespondActivityTaskCanceledRequest) (err error) {
+  if err = p.sendRespondActivityTaskCanceled(canceledRequest); err != nil { return }
+  return p.recvRespondActivityTaskCanceled()
+}
+
+func (p *HistoryServiceClient) sendRespondActivityTaskCanceled(canceledRequest *RespondActivityTaskCanceledRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRespondActivityTaskCanceledArgs{
+  CanceledRequest : canceledRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRespondActivityTaskCanceled() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RespondActivityTaskCanceled" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondActivityTaskCanceled failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondActivityTaskCanceled failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error23 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error24 error
+    error24, err = error23.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error24
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondActivityTaskCanceled failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRespondActivityTaskCanceledResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  return
+}
+
+// SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
+// WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
+// It returns the receipt of the signal.
+// 
+// 
+// Parameters:
+//  - SignalRequest
+func (p *HistoryServiceClient) SignalWorkflowExecution(signalRequest *SignalWorkflowExecutionRequest) (r *shared.SignalWorkflowExecutionResponse, err error) {
+  if err = p.sendSignalWorkflowExecution(signalRequest); err != nil { return }
+  return p.recvSignalWorkflowExecution()
+}
+
+func (p *HistoryServiceClient) sendSignalWorkflowExecution(signalRequest *SignalWorkflowExecutionRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceSignalWorkflowExecutionArgs{
+  SignalRequest : signalRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvSignalWorkflowExecution() (value *shared.SignalWorkflowExecutionResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "SignalWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "SignalWorkflowExecution failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "SignalWorkflowExecution failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error25 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error26 error
+    error26, err = error25.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error26
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "SignalWorkflowExecution failed: invalid message type")
+    return
+  }
+  result := HistoryServiceSignalWorkflowExecutionResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// GetSignalReceipt tells whether the signal sent with the request ID was recorded by the workflow execution.
+// 
+// 
+// Parameters:
+//  - ReceiptRequest
+func (p *HistoryServiceClient) GetSignalReceipt(receiptRequest *GetSignalReceiptRequest) (r *shared.GetSignalReceiptResponse, err error) {
+  if err = p.sendGetSignalReceipt(receiptRequest); err != nil { return }
+  return p.recvGetSignalReceipt()
+}
+
+func (p *HistoryServiceClient) sendGetSignalReceipt(receiptRequest *GetSignalReceiptRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("GetSignalReceipt", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceGetSignalReceiptArgs{
+  ReceiptRequest : receiptRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvGetSignalReceipt() (value *shared.GetSignalReceiptResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "GetSignalReceipt" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetSignalReceipt failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetSignalReceipt failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error27 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error28 error
+    error28, err = error27.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error28
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetSignalReceipt failed: invalid message type")
+    return
+  }
+  result := HistoryServiceGetSignalReceiptResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// QueryWorkflow queues a query to a running workflow execution and waits for the answer.  Queued queries are handed
+// to the worker with the decision task of the execution waiting for a poller, or else with a query task of their own
+// which doesn't change the history.  It fails with 'QueryFailedError' if the worker failed the query or didn't answer
+// it.
+// 
+// 
+// Parameters:
+//  - QueryRequest
+func (p *HistoryServiceClient) QueryWorkflow(queryRequest *QueryWorkflowRequest) (r *shared.QueryWorkflowResponse, err error) {
+  if err = p.sendQueryWorkflow(queryRequest); err != nil { return }
+  return p.recvQueryWorkflow()
+}
+
+func (p *HistoryServiceClient) sendQueryWorkflow(queryRequest *QueryWorkflowRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("QueryWorkflow", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceQueryWorkflowArgs{
+  QueryRequest : queryRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvQueryWorkflow() (value *shared.QueryWorkflowResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "QueryWorkflow" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "QueryWorkflow failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "QueryWorkflow failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error29 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error30 error
+    error30, err = error29.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error30
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "QueryWorkflow failed: invalid message type")
+    return
+  }
+  result := HistoryServiceQueryWorkflowResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.QueryFailedError != nil {
+    err = result.QueryFailedError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// DescribeWorkflowExecution returns information about the specified workflow execution, including the activities
+// still pending on it.
+// 
+// 
+// Parameters:
+//  - DescribeRequest
+func (p *HistoryServiceClient) DescribeWorkflowExecution(describeRequest *DescribeWorkflowExecutionRequest) (r *shared.DescribeWorkflowExecutionResponse, err error) {
+  if err = p.sendDescribeWorkflowExecution(describeRequest); err != nil { return }
+  return p.recvDescribeWorkflowExecution()
+}
+
+func (p *HistoryServiceClient) sendDescribeWorkflowExecution(describeRequest *DescribeWorkflowExecutionRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("DescribeWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceDescribeWorkflowExecutionArgs{
+  DescribeRequest : describeRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvDescribeWorkflowExecution() (value *shared.DescribeWorkflowExecutionResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "DescribeWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "DescribeWorkflowExecution failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "DescribeWorkflowExecution failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error31 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error32 error
+    error32, err = error31.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error32
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "DescribeWorkflowExecution failed: invalid message type")
+    return
+  }
+  result := HistoryServiceDescribeWorkflowExecutionResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminatedByOperator
+// event in the history and immediately terminating the execution instance.  Running child executions are left
+// alone, unless the request sets a 'childPolicy' to terminate or cancel them.  All the executions affected are
+// returned.
+// 
+// 
+// Parameters:
+//  - TerminateRequest
+func (p *HistoryServiceClient) TerminateWorkflowExecution(terminateRequest *TerminateWorkflowExecutionRequest) (r *shared.TerminateWorkflowExecutionResponse, err error) {
+  if err = p.sendTerminateWorkflowExecution(terminateRequest); err != nil { return }
+  return p.recvTerminateWorkflowExecution()
+}
+
+func (p *HistoryServiceClient) sendTerminateWorkflowExecution(terminateRequest *TerminateWorkflowExecutionRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceTerminateWorkflowExecutionArgs{
+  TerminateRequest : terminateRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvTerminateWorkflowExecution() (value *shared.TerminateWorkflowExecutionResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "TerminateWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "TerminateWorkflowExecution failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "TerminateWorkflowExecution failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error33 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error34 error
+    error34, err = error33.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error34
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "TerminateWorkflowExecution failed: invalid message type")
+    return
+  }
+  result := HistoryServiceTerminateWorkflowExecutionResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// RequestCancelWorkflowExecution is called by application worker when it wants to request cancellation of a workflow instance.
+// It will result in a new 'WorkflowExecutionCancelRequested' event being written to the workflow history and a new DecisionTask
+// created for the workflow instance so new decisions could be made. It fails with 'EntityNotExistsError' if the workflow is not valid
+// anymore due to completion or doesn't exist.
+// 
+// 
+// Parameters:
+//  - CancelRequest
+func (p *HistoryServiceClient) RequestCancelWorkflowExecution(cancelRequest *RequestCancelWorkflowExecutionRequest) (err error) {
+  if err = p.sendRequestCancelWorkflowExecution(cancelRequest); err != nil { return }
+  return p.recvRequestCancelWorkflowExecution()
+}
+
+func (p *HistoryServiceClient) sendRequestCancelWorkflowExecution(cancelRequest *RequestCancelWorkflowExecutionRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRequestCancelWorkflowExecutionArgs{
+  CancelRequest : cancelRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRequestCancelWorkflowExecution() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RequestCancelWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RequestCancelWorkflowExecution failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RequestCancelWorkflowExecution failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error35 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error36 error
+    error36, err = error35.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error36
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RequestCancelWorkflowExecution failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRequestCancelWorkflowExecutionResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  return
+}
+
+// ScheduleDecisionTask is used for creating a decision task for already started workflow execution.  This is mainly
+// used by transfer queue processor during the processing of StartChildWorkflowExecution task, where it first starts
+// child execution without creating the decision task and then calls this API after updating the mutable state of
+// parent execution.
+// 
+// 
+// Parameters:
+//  - ScheduleRequest
+func (p *HistoryServiceClient) ScheduleDecisionTask(scheduleRequest *ScheduleDecisionTaskRequest) (err error) {
+  if err = p.sendScheduleDecisionTask(scheduleRequest); err != nil { return }
+  return p.recvScheduleDecisionTask()
+}
+
+func (p *HistoryServiceClient) sendScheduleDecisionTask(scheduleRequest *ScheduleDecisionTaskRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("ScheduleDecisionTask", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceScheduleDecisionTaskArgs{
+  ScheduleRequest : scheduleRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvScheduleDecisionTask() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "ScheduleDecisionTask" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ScheduleDecisionTask failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ScheduleDecisionTask failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error37 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error38 error
+    error38, err = error37.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error38
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ScheduleDecisionTask failed: invalid message type")
+    return
+  }
+  result := HistoryServiceScheduleDecisionTaskResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  return
+}
+
+// RecordChildExecutionCompleted is used for reporting the completion of child workflow execution to parent.
+// This is mainly called by transfer queue processor during the processing of DeleteExecution task.
+// 
+// 
+// Parameters:
+//  - CompletionRequest
+func (p *HistoryServiceClient) RecordChildExecutionCompleted(completionRequest *RecordChildExecutionCompletedRequest) (err error) {
+  if err = p.sendRecordChildExecutionCompleted(completionRequest); err != nil { return }
+  return p.recvRecordChildExecutionCompleted()
+}
+
+func (p *HistoryServiceClient) sendRecordChildExecutionCompleted(completionRequest *RecordChildExecutionCompletedRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RecordChildExecutionCompleted", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRecordChildExecutionCompletedArgs{
+  CompletionRequest : completionRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRecordChildExecutionCompleted() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RecordChildExecutionCompleted" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RecordChildExecutionCompleted failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RecordChildExecutionCompleted failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error39 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error40 error
+    error40, err = error39.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error40
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RecordChildExecutionCompleted failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRecordChildExecutionCompletedResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  return
+}
+
+// UpdateQueueProcessing is an admin API to pause or resume the processing of the transfer or timer queue of a shard.
+// It is meant for controlled draining of shards during incident mitigation.  Tasks keep accumulating in the queue
+// while processing is paused and are processed once it is resumed.  The paused state is persisted on the shard and
+// kept when the shard moves to another host, it overrides the paused state the host is configured to start queues in.
+// 
+// 
+// Parameters:
+//  - UpdateRequest
+func (p *HistoryServiceClient) UpdateQueueProcessing(updateRequest *UpdateQueueProcessingRequest) (err error) {
+  if err = p.sendUpdateQueueProcessing(updateRequest); err != nil { return }
+  return p.recvUpdateQueueProcessing()
+}
+
+func (p *HistoryServiceClient) sendUpdateQueueProcessing(updateRequest *UpdateQueueProcessingRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("UpdateQueueProcessing", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceUpdateQueueProcessingArgs{
+  UpdateRequest : updateRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvUpdateQueueProcessing() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "UpdateQueueProcessing" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "UpdateQueueProcessing failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "UpdateQueueProcessing failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error41 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error42 error
+    error42, err = error41.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error42
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "UpdateQueueProcessing failed: invalid message type")
+    return
+  }
+  result := HistoryServiceUpdateQueueProcessingResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  return
+}
+
+// DescribeMutableState is an admin API to dump the mutable state of a workflow execution as JSON, including its
+// pending activities, timers, child executions and decision, along with the transfer queue ack levels of the shard.
+// It is meant for debugging mismatches between the history and the mutable state of an execution, and never updates
+// the execution.
+// 
+// 
+// Parameters:
+//  - Request
+func (p *HistoryServiceClient) DescribeMutableState(request *DescribeMutableStateRequest) (r *DescribeMutableStateResponse, err error) {
+  if err = p.sendDescribeMutableState(request); err != nil { return }
+  return p.recvDescribeMutableState()
+}
+
+func (p *HistoryServiceClient) sendDescribeMutableState(request *DescribeMutableStateRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("DescribeMutableState", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceDescribeMutableStateArgs{
+  Request : request,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvDescribeMutableState() (value *DescribeMutableStateResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "DescribeMutableState" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "DescribeMutableState failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "DescribeMutableState failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error43 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error44 error
+    error44, err = error43.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error44
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "DescribeMutableState failed: invalid message type")
+    return
+  }
+  result := HistoryServiceDescribeMutableStateResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// RefreshWorkflowTasks re-generates the transfer and timer tasks of a running workflow execution from its mutable
+// state.  It is meant to recover executions stuck because one of their tasks was lost or dropped, tasks which were
+// not lost are processed twice and dropped as duplicates.
+// 
+// 
+// Parameters:
+//  - RefreshRequest
+func (p *HistoryServiceClient) RefreshWorkflowTasks(refreshRequest *RefreshWorkflowTasksRequest) (err error) {
+  if err = p.sendRefreshWorkflowTasks(refreshRequest); err != nil { return }
+  return p.recvRefreshWorkflowTasks()
+}
+
+func (p *HistoryServiceClient) sendRefreshWorkflowTasks(refreshRequest *RefreshWorkflowTasksRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RefreshWorkflowTasks", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRefreshWorkflowTasksArgs{
+  RefreshRequest : refreshRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRefreshWorkflowTasks() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RefreshWorkflowTasks" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RefreshWorkflowTasks failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RefreshWorkflowTasks failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error45 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error46 error
+    error46, err = error45.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error46
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RefreshWorkflowTasks failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRefreshWorkflowTasksResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  return
+}
+
+// ListStaleExecutions is an admin API listing the stale executions found by the last scan of the shard, or of all
+// shards when no shard is given: running executions whose pending decision has not moved for many times its
+// timeout, and executions with timers long past due.  Both are symptoms of a stuck shard or of lost tasks.
+// 
+// 
+// Parameters:
+//  - ListRequest
+func (p *HistoryServiceClient) ListStaleExecutions(listRequest *ListStaleExecutionsRequest) (r *ListStaleExecutionsResponse, err error) {
+  if err = p.sendListStaleExecutions(listRequest); err != nil { return }
+  return p.recvListStaleExecutions()
+}
+
+func (p *HistoryServiceClient) sendListStaleExecutions(listRequest *ListStaleExecutionsRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("ListStaleExecutions", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceListStaleExecutionsArgs{
+  ListRequest : listRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvListStaleExecutions() (value *ListStaleExecutionsResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "ListStaleExecutions" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ListStaleExecutions failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ListStaleExecutions failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error47 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error48 error
+    error48, err = error47.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error48
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ListStaleExecutions failed: invalid message type")
+    return
+  }
+  result := HistoryServiceListStaleExecutionsResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+// MigrateWorkflowExecution moves a closed workflow execution to another domain: its history, mutable state and
+// visibility record are copied under the ID of the target domain, which then owns its retention, and deleted from
+// the source domain.  Every step is idempotent, a failed migration is completed by retrying it.
+// 
+// 
+// Parameters:
+//  - MigrateRequest
+func (p *HistoryServiceClient) MigrateWorkflowExecution(migrateRequest *MigrateWorkflowExecutionRequest) (err error) {
+  if err = p.sendMigrateWorkflowExecution(migrateRequest); err != nil { return }
+  return p.recvMigrateWorkflowExecution()
+}
+
+func (p *HistoryServiceClient) sendMigrateWorkflowExecution(migrateRequest *MigrateWorkflowExecutionRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("MigrateWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceMigrateWorkflowExecutionArgs{
+  MigrateRequest : migrateRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvMigrateWorkflowExecution() (err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "MigrateWorkflowExecution" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "MigrateWorkflowExecution failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "MigrateWorkflowExecution failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error49 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error50 error
+    error50, err = error49.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error50
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "MigrateWorkflowExecution failed: invalid message type")
+    return
+  }
+  result := HistoryServiceMigrateWorkflowExecutionResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.EntityNotExistError != nil {
+    err = result.EntityNotExistError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  } else   if result.WorkflowAlreadyStartedError != nil {
+    err = result.WorkflowAlreadyStartedError
+    return 
+  }
+  return
+}
+
+// RedriveTransferDLQTasks is an admin API moving the transfer tasks parked in the dead letter queue of a shard, after
+// they failed to be added to matching too many times, back to its transfer queue under new task IDs.  At most
+// maximumTaskCount tasks are moved when it is set, the oldest first.  It returns the number of tasks moved.
+// 
+// 
+// Parameters:
+//  - RedriveRequest
+func (p *HistoryServiceClient) RedriveTransferDLQTasks(redriveRequest *RedriveTransferDLQTasksRequest) (r *RedriveTransferDLQTasksResponse, err error) {
+  if err = p.sendRedriveTransferDLQTasks(redriveRequest); err != nil { return }
+  return p.recvRedriveTransferDLQTasks()
+}
+
+func (p *HistoryServiceClient) sendRedriveTransferDLQTasks(redriveRequest *RedriveTransferDLQTasksRequest)(err error) {
+  oprot := p.OutputProtocol
+  if oprot == nil {
+    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.OutputProtocol = oprot
+  }
+  p.SeqId++
+  if err = oprot.WriteMessageBegin("RedriveTransferDLQTasks", thrift.CALL, p.SeqId); err != nil {
+      return
+  }
+  args := HistoryServiceRedriveTransferDLQTasksArgs{
+  RedriveRequest : redriveRequest,
+  }
+  if err = args.Write(oprot); err != nil {
+      return
+  }
+  if err = oprot.WriteMessageEnd(); err != nil {
+      return
+  }
+  return oprot.Flush()
+}
+
+
+func (p *HistoryServiceClient) recvRedriveTransferDLQTasks() (value *RedriveTransferDLQTasksResponse, err error) {
+  iprot := p.InputProtocol
+  if iprot == nil {
+    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+    p.InputProtocol = iprot
+  }
+  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+  if err != nil {
+    return
+  }
+  if method != "RedriveTransferDLQTasks" {
+    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RedriveTransferDLQTasks failed: wrong method name")
+    return
+  }
+  if p.SeqId != seqId {
+    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RedriveTransferDLQTasks failed: out of sequence response")
+    return
+  }
+  if mTypeId == thrift.EXCEPTION {
+    error51 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error52 error
+    error52, err = error51.Read(iprot)
+    if err != nil {
+      return
+    }
+    if err = iprot.ReadMessageEnd(); err != nil {
+      return
+    }
+    err = error52
+    return
+  }
+  if mTypeId != thrift.REPLY {
+    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RedriveTransferDLQTasks failed: invalid message type")
+    return
+  }
+  result := HistoryServiceRedriveTransferDLQTasksResult{}
+  if err = result.Read(iprot); err != nil {
+    return
+  }
+  if err = iprot.ReadMessageEnd(); err != nil {
+    return
+  }
+  if result.BadRequestError != nil {
+    err = result.BadRequestError
+    return 
+  } else   if result.InternalServiceError != nil {
+    err = result.InternalServiceError
+    return 
+  } else   if result.ShardOwnershipLostError != nil {
+    err = result.ShardOwnershipLostError
+    return 
+  }
+  value = result.GetSuccess()
+  return
+}
+
+
+type HistoryServiceProcessor struct {
+  processorMap map[string]thrift.TProcessorFunction
+  handler HistoryService
+}
+
+func (p *HistoryServiceProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
+  p.processorMap[key] = processor
+}
+
+func (p *HistoryServiceProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
+  processor, ok = p.processorMap[key]
+  return processor, ok
+}
+
+func (p *HistoryServiceProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
+  return p.processorMap
+}
+
+func NewHistoryServiceProcessor(handler HistoryService) *HistoryServiceProcessor {
+
+  self53 := &HistoryServiceProcessor{handler:handler, processorMap:make(map[string]thrift.TProcessorFunction)}
+  self53.processorMap["StartWorkflowExecution"] = &historyServiceProcessorStartWorkflowExecution{handler:handler}
+  self53.processorMap["GetWorkflowExecutionNextEventID"] = &historyServiceProcessorGetWorkflowExecutionNextEventID{handler:handler}
+  self53.processorMap["BatchGetWorkflowExecutionNextEventID"] = &historyServiceProcessorBatchGetWorkflowExecutionNextEventID{handler:handler}
+  self53.processorMap["RecordDecisionTaskStarted"] = &historyServiceProcessorRecordDecisionTaskStarted{handler:handler}
+  self53.processorMap["RecordActivityTaskStarted"] = &historyServiceProcessorRecordActivityTaskStarted{handler:handler}
+  self53.processorMap["RespondDecisionTaskCompleted"] = &historyServiceProcessorRespondDecisionTaskCompleted{handler:handler}
+  self53.processorMap["RecordActivityTaskHeartbeat"] = &historyServiceProcessorRecordActivityTaskHeartbeat{handler:handler}
+  self53.processorMap["RespondActivityTaskCompleted"] = &historyServiceProcessorRespondActivityTaskCompleted{handler:handler}
+  self53.processorMap["RespondActivityTaskFailed"] = &historyServiceProcessorRespondActivityTaskFailed{handler:handler}
+  self53.processorMap["RespondActivityTaskCanceled"] = &historyServiceProcessorRespondActivityTaskCanceled{handler:handler}
+  self53.processorMap["SignalWorkflowExecution"] = &historyServiceProcessorSignalWorkflowExecution{handler:handler}
+  self53.processorMap["GetSignalReceipt"] = &historyServiceProcessorGetSignalReceipt{handler:handler}
+  self53.processorMap["QueryWorkflow"] = &historyServiceProcessorQueryWorkflow{handler:handler}
+  self53.processorMap["DescribeWorkflowExecution"] = &historyServiceProcessorDescribeWorkflowExecution{handler:handler}
+  self53.processorMap["TerminateWorkflowExecution"] = &historyServiceProcessorTerminateWorkflowExecution{handler:handler}
+  self53.processorMap["RequestCancelWorkflowExecution"] = &historyServiceProcessorRequestCancelWorkflowExecution{handler:handler}
+  self53.processorMap["ScheduleDecisionTask"] = &historyServiceProcessorScheduleDecisionTask{handler:handler}
+  self53.processorMap["RecordChildExecutionCompleted"] = &historyServiceProcessorRecordChildExecutionCompleted{handler:handler}
+  self53.processorMap["UpdateQueueProcessing"] = &historyServiceProcessorUpdateQueueProcessing{handler:handler}
+  self53.processorMap["DescribeMutableState"] = &historyServiceProcessorDescribeMutableState{handler:handler}
+  self53.processorMap["RefreshWorkflowTasks"] = &historyServiceProcessorRefreshWorkflowTasks{handler:handler}
+  self53.processorMap["ListStaleExecutions"] = &historyServiceProcessorListStaleExecutions{handler:handler}
+  self53.processorMap["MigrateWorkflowExecution"] = &historyServiceProcessorMigrateWorkflowExecution{handler:handler}
+  self53.processorMap["RedriveTransferDLQTasks"] = &historyServiceProcessorRedriveTransferDLQTasks{handler:handler}
+return self53
+}
+
+func (p *HistoryServiceProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  name, _, seqId, err := iprot.ReadMessageBegin()
+  if err != nil { return false, err }
+  if processor, ok := p.GetProcessorFunction(name); ok {
+    return processor.Process(seqId, iprot, oprot)
+  }
+  iprot.Skip(thrift.STRUCT)
+  iprot.ReadMessageEnd()
+  x54 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function " + name)
+  oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
+  x54.Write(oprot)
+  oprot.WriteMessageEnd()
+  oprot.Flush()
+  return false, x54
+
+}
+
+type historyServiceProcessorStartWorkflowExecution struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorStartWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceStartWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("StartWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceStartWorkflowExecutionResult{}
+var retval *shared.StartWorkflowExecutionResponse
+  var err2 error
+  if retval, err2 = p.handler.StartWorkflowExecution(args.StartRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.WorkflowExecutionAlreadyStartedError:
+  result.SessionAlreadyExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    case *shared.DomainNotActiveError:
+  result.DomainNotActiveError = v
+    case *shared.LimitExceededError:
+  result.LimitExceededError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing StartWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("StartWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("StartWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorGetWorkflowExecutionNextEventID struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorGetWorkflowExecutionNextEventID) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceGetWorkflowExecutionNextEventIDArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("GetWorkflowExecutionNextEventID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceGetWorkflowExecutionNextEventIDResult{}
+var retval *GetWorkflowExecutionNextEventIDResponse
+  var err2 error
+  if retval, err2 = p.handler.GetWorkflowExecutionNextEventID(args.GetRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetWorkflowExecutionNextEventID: " + err2.Error())
+    oprot.WriteMessageBegin("GetWorkflowExecutionNextEventID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("GetWorkflowExecutionNextEventID", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorBatchGetWorkflowExecutionNextEventID struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorBatchGetWorkflowExecutionNextEventID) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("BatchGetWorkflowExecutionNextEventID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceBatchGetWorkflowExecutionNextEventIDResult{}
+var retval *BatchGetWorkflowExecutionNextEventIDResponse
+  var err2 error
+  if retval, err2 = p.handler.BatchGetWorkflowExecutionNextEventID(args.GetRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing BatchGetWorkflowExecutionNextEventID: " + err2.Error())
+    oprot.WriteMessageBegin("BatchGetWorkflowExecutionNextEventID", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("BatchGetWorkflowExecutionNextEventID", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRecordDecisionTaskStarted struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRecordDecisionTaskStarted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRecordDecisionTaskStartedArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RecordDecisionTaskStarted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRecordDecisionTaskStartedResult{}
+var retval *RecordDecisionTaskStartedResponse
+  var err2 error
+  if retval, err2 = p.handler.RecordDecisionTaskStarted(args.AddRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *EventAlreadyStartedError:
+  result.EventAlreadyStartedError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    case *shared.ServiceBusyError:
+  result.ServiceBusyError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordDecisionTaskStarted: " + err2.Error())
+    oprot.WriteMessageBegin("RecordDecisionTaskStarted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("RecordDecisionTaskStarted", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRecordActivityTaskStarted struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRecordActivityTaskStarted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRecordActivityTaskStartedArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RecordActivityTaskStarted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRecordActivityTaskStartedResult{}
+var retval *RecordActivityTaskStartedResponse
+  var err2 error
+  if retval, err2 = p.handler.RecordActivityTaskStarted(args.AddRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *EventAlreadyStartedError:
+  result.EventAlreadyStartedError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    case *shared.ServiceBusyError:
+  result.ServiceBusyError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordActivityTaskStarted: " + err2.Error())
+    oprot.WriteMessageBegin("RecordActivityTaskStarted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("RecordActivityTaskStarted", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRespondDecisionTaskCompleted struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRespondDecisionTaskCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRespondDecisionTaskCompletedArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRespondDecisionTaskCompletedResult{}
+var retval *shared.RespondDecisionTaskCompletedResponse
+  var err2 error
+  if retval, err2 = p.handler.RespondDecisionTaskCompleted(args.CompleteRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondDecisionTaskCompleted: " + err2.Error())
+    oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRecordActivityTaskHeartbeat struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRecordActivityTaskHeartbeat) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRecordActivityTaskHeartbeatArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRecordActivityTaskHeartbeatResult{}
+var retval *shared.RecordActivityTaskHeartbeatResponse
+  var err2 error
+  if retval, err2 = p.handler.RecordActivityTaskHeartbeat(args.HeartbeatRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    case *shared.ServiceBusyError:
+  result.ServiceBusyError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordActivityTaskHeartbeat: " + err2.Error())
+    oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRespondActivityTaskCompleted struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRespondActivityTaskCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRespondActivityTaskCompletedArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRespondActivityTaskCompletedResult{}
+  var err2 error
+  if err2 = p.handler.RespondActivityTaskCompleted(args.CompleteRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCompleted: " + err2.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRespondActivityTaskFailed struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRespondActivityTaskFailed) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRespondActivityTaskFailedArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRespondActivityTaskFailedResult{}
+  var err2 error
+  if err2 = p.handler.RespondActivityTaskFailed(args.FailRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskFailed: " + err2.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRespondActivityTaskCanceled struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRespondActivityTaskCanceled) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRespondActivityTaskCanceledArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRespondActivityTaskCanceledResult{}
+  var err2 error
+  if err2 = p.handler.RespondActivityTaskCanceled(args.CanceledRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCanceled: " + err2.Error())
+    oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorSignalWorkflowExecution struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorSignalWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceSignalWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceSignalWorkflowExecutionResult{}
+var retval *shared.SignalWorkflowExecutionResponse
+  var err2 error
+  if retval, err2 = p.handler.SignalWorkflowExecution(args.SignalRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SignalWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorGetSignalReceipt struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorGetSignalReceipt) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceGetSignalReceiptArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("GetSignalReceipt", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceGetSignalReceiptResult{}
+var retval *shared.GetSignalReceiptResponse
+  var err2 error
+  if retval, err2 = p.handler.GetSignalReceipt(args.ReceiptRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetSignalReceipt: " + err2.Error())
+    oprot.WriteMessageBegin("GetSignalReceipt", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("GetSignalReceipt", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorQueryWorkflow struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorQueryWorkflow) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceQueryWorkflowArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("QueryWorkflow", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceQueryWorkflowResult{}
+var retval *shared.QueryWorkflowResponse
+  var err2 error
+  if retval, err2 = p.handler.QueryWorkflow(args.QueryRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *shared.QueryFailedError:
+  result.QueryFailedError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing QueryWorkflow: " + err2.Error())
+    oprot.WriteMessageBegin("QueryWorkflow", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("QueryWorkflow", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorDescribeWorkflowExecution struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorDescribeWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceDescribeWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("DescribeWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceDescribeWorkflowExecutionResult{}
+var retval *shared.DescribeWorkflowExecutionResponse
+  var err2 error
+  if retval, err2 = p.handler.DescribeWorkflowExecution(args.DescribeRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DescribeWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("DescribeWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("DescribeWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorTerminateWorkflowExecution struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorTerminateWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceTerminateWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceTerminateWorkflowExecutionResult{}
+var retval *shared.TerminateWorkflowExecutionResponse
+  var err2 error
+  if retval, err2 = p.handler.TerminateWorkflowExecution(args.TerminateRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing TerminateWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRequestCancelWorkflowExecution struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRequestCancelWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRequestCancelWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRequestCancelWorkflowExecutionResult{}
+  var err2 error
+  if err2 = p.handler.RequestCancelWorkflowExecution(args.CancelRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RequestCancelWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorScheduleDecisionTask struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorScheduleDecisionTask) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceScheduleDecisionTaskArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ScheduleDecisionTask", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceScheduleDecisionTaskResult{}
+  var err2 error
+  if err2 = p.handler.ScheduleDecisionTask(args.ScheduleRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ScheduleDecisionTask: " + err2.Error())
+    oprot.WriteMessageBegin("ScheduleDecisionTask", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("ScheduleDecisionTask", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRecordChildExecutionCompleted struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRecordChildExecutionCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRecordChildExecutionCompletedArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RecordChildExecutionCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRecordChildExecutionCompletedResult{}
+  var err2 error
+  if err2 = p.handler.RecordChildExecutionCompleted(args.CompletionRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordChildExecutionCompleted: " + err2.Error())
+    oprot.WriteMessageBegin("RecordChildExecutionCompleted", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RecordChildExecutionCompleted", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorUpdateQueueProcessing struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorUpdateQueueProcessing) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceUpdateQueueProcessingArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("UpdateQueueProcessing", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceUpdateQueueProcessingResult{}
+  var err2 error
+  if err2 = p.handler.UpdateQueueProcessing(args.UpdateRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing UpdateQueueProcessing: " + err2.Error())
+    oprot.WriteMessageBegin("UpdateQueueProcessing", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("UpdateQueueProcessing", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorDescribeMutableState struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorDescribeMutableState) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceDescribeMutableStateArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("DescribeMutableState", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceDescribeMutableStateResult{}
+var retval *DescribeMutableStateResponse
+  var err2 error
+  if retval, err2 = p.handler.DescribeMutableState(args.Request); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing DescribeMutableState: " + err2.Error())
+    oprot.WriteMessageBegin("DescribeMutableState", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("DescribeMutableState", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRefreshWorkflowTasks struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRefreshWorkflowTasks) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRefreshWorkflowTasksArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RefreshWorkflowTasks", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRefreshWorkflowTasksResult{}
+  var err2 error
+  if err2 = p.handler.RefreshWorkflowTasks(args.RefreshRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RefreshWorkflowTasks: " + err2.Error())
+    oprot.WriteMessageBegin("RefreshWorkflowTasks", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("RefreshWorkflowTasks", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorListStaleExecutions struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorListStaleExecutions) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceListStaleExecutionsArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("ListStaleExecutions", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceListStaleExecutionsResult{}
+var retval *ListStaleExecutionsResponse
+  var err2 error
+  if retval, err2 = p.handler.ListStaleExecutions(args.ListRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ListStaleExecutions: " + err2.Error())
+    oprot.WriteMessageBegin("ListStaleExecutions", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("ListStaleExecutions", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorMigrateWorkflowExecution struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorMigrateWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceMigrateWorkflowExecutionArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("MigrateWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceMigrateWorkflowExecutionResult{}
+  var err2 error
+  if err2 = p.handler.MigrateWorkflowExecution(args.MigrateRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *shared.EntityNotExistsError:
+  result.EntityNotExistError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    case *shared.WorkflowExecutionAlreadyStartedError:
+  result.WorkflowAlreadyStartedError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing MigrateWorkflowExecution: " + err2.Error())
+    oprot.WriteMessageBegin("MigrateWorkflowExecution", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  }
+  if err2 = oprot.WriteMessageBegin("MigrateWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+type historyServiceProcessorRedriveTransferDLQTasks struct {
+  handler HistoryService
+}
+
+func (p *historyServiceProcessorRedriveTransferDLQTasks) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+  args := HistoryServiceRedriveTransferDLQTasksArgs{}
+  if err = args.Read(iprot); err != nil {
+    iprot.ReadMessageEnd()
+    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+    oprot.WriteMessageBegin("RedriveTransferDLQTasks", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return false, err
+  }
+
+  iprot.ReadMessageEnd()
+  result := HistoryServiceRedriveTransferDLQTasksResult{}
+var retval *RedriveTransferDLQTasksResponse
+  var err2 error
+  if retval, err2 = p.handler.RedriveTransferDLQTasks(args.RedriveRequest); err2 != nil {
+  switch v := err2.(type) {
+    case *shared.BadRequestError:
+  result.BadRequestError = v
+    case *shared.InternalServiceError:
+  result.InternalServiceError = v
+    case *ShardOwnershipLostError:
+  result.ShardOwnershipLostError = v
+    default:
+    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RedriveTransferDLQTasks: " + err2.Error())
+    oprot.WriteMessageBegin("RedriveTransferDLQTasks", thrift.EXCEPTION, seqId)
+    x.Write(oprot)
+    oprot.WriteMessageEnd()
+    oprot.Flush()
+    return true, err2
+  }
+  } else {
+    result.Success = retval
+}
+  if err2 = oprot.WriteMessageBegin("RedriveTransferDLQTasks", thrift.REPLY, seqId); err2 != nil {
+    err = err2
+  }
+  if err2 = result.Write(oprot); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err2 = oprot.Flush(); err == nil && err2 != nil {
+    err = err2
+  }
+  if err != nil {
+    return
+  }
+  return true, err
+}
+
+
+// HELPER FUNCTIONS AND STRUCTURES
+
+// Attributes:
+//  - StartRequest
+type HistoryServiceStartWorkflowExecutionArgs struct {
+  StartRequest *StartWorkflowExecutionRequest `thrift:"startRequest,1" db:"startRequest" json:"startRequest"`
+}
+
+func NewHistoryServiceStartWorkflowExecutionArgs() *HistoryServiceStartWorkflowExecutionArgs {
+  return &HistoryServiceStartWorkflowExecutionArgs{}
+}
+
+var HistoryServiceStartWorkflowExecutionArgs_StartRequest_DEFAULT *StartWorkflowExecutionRequest
+func (p *HistoryServiceStartWorkflowExecutionArgs) GetStartRequest() *StartWorkflowExecutionRequest {
+  if !p.IsSetStartRequest() {
+    return HistoryServiceStartWorkflowExecutionArgs_StartRequest_DEFAULT
+  }
+return p.StartRequest
+}
+func (p *HistoryServiceStartWorkflowExecutionArgs) IsSetStartRequest() bool {
+  return p.StartRequest != nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.StartRequest = &StartWorkflowExecutionRequest{}
+  if err := p.StartRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.StartRequest), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("StartWorkflowExecution_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("startRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:startRequest: ", p), err) }
+  if err := p.StartRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.StartRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:startRequest: ", p), err) }
+  return err
+}
+
+func (p *HistoryServiceStartWorkflowExecutionArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("HistoryServiceStartWorkflowExecutionArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - SessionAlreadyExistError
+//  - ShardOwnershipLostError
+//  - DomainNotActiveError
+//  - LimitExceededError
+type HistoryServiceStartWorkflowExecutionResult struct {
+  Success *shared.StartWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  SessionAlreadyExistError *shared.WorkflowExecutionAlreadyStartedError `thrift:"sessionAlreadyExistError,3" db:"sessionAlreadyExistError" json:"sessionAlreadyExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  DomainNotActiveError *shared.DomainNotActiveError `thrift:"domainNotActiveError,5" db:"domainNotActiveError" json:"domainNotActiveError,omitempty"`
+  LimitExceededError *shared.LimitExceededError `thrift:"limitExceededError,6" db:"limitExceededError" json:"limitExceededError,omitempty"`
+}
+
+func NewHistoryServiceStartWorkflowExecutionResult() *HistoryServiceStartWorkflowExecutionResult {
+  return &HistoryServiceStartWorkflowExecutionResult{}
+}
+
+var HistoryServiceStartWorkflowExecutionResult_Success_DEFAULT *shared.StartWorkflowExecutionResponse
+func (p *HistoryServiceStartWorkflowExecutionResult) GetSuccess() *shared.StartWorkflowExecutionResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceStartWorkflowExecutionResult_Success_DEFAULT
+  }
+return p.Success
+}
+var HistoryServiceStartWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceStartWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceStartWorkflowExecutionResult_BadRequestError_DEFAULT
+  }
+return p.BadRequestError
+}
+var HistoryServiceStartWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceStartWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceStartWorkflowExecutionResult_InternalServiceError_DEFAULT
+  }
+return p.InternalServiceError
+}
+var HistoryServiceStartWorkflowExecutionResult_SessionAlreadyExistError_DEFAULT *shared.WorkflowExecutionAlreadyStartedError
+func (p *HistoryServiceStartWorkflowExecutionResult) GetSessionAlreadyExistError() *shared.WorkflowExecutionAlreadyStartedError {
+  if !p.IsSetSessionAlreadyExistError() {
+    return HistoryServiceStartWorkflowExecutionResult_SessionAlreadyExistError_DEFAULT
+  }
+return p.SessionAlreadyExistError
+}
+var HistoryServiceStartWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceStartWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return HistoryServiceStartWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
+  }
+return p.ShardOwnershipLostError
+}
+var HistoryServiceStartWorkflowExecutionResult_DomainNotActiveError_DEFAULT *shared.DomainNotActiveError
+func (p *HistoryServiceStartWorkflowExecutionResult) GetDomainNotActiveError() *shared.DomainNotActiveError {
+  if !p.IsSetDomainNotActiveError() {
+    return HistoryServiceStartWorkflowExecutionResult_DomainNotActiveError_DEFAULT
+  }
+return p.DomainNotActiveError
+}
+var HistoryServiceStartWorkflowExecutionResult_LimitExceededError_DEFAULT *shared.LimitExceededError
+func (p *HistoryServiceStartWorkflowExecutionResult) GetLimitExceededError() *shared.LimitExceededError {
+  if !p.IsSetLimitExceededError() {
+    return HistoryServiceStartWorkflowExecutionResult_LimitExceededError_DEFAULT
+  }
+return p.LimitExceededError
+}
+func (p *HistoryServiceStartWorkflowExecutionResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) IsSetSessionAlreadyExistError() bool {
+  return p.SessionAlreadyExistError != nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) IsSetDomainNotActiveError() bool {
+  return p.DomainNotActiveError != nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) IsSetLimitExceededError() bool {
+  return p.LimitExceededError != nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
+    case 5:
+      if err := p.ReadField5(iprot); err != nil {
+        return err
+      }
+    case 6:
+      if err := p.ReadField6(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.StartWorkflowExecutionResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.SessionAlreadyExistError = &shared.WorkflowExecutionAlreadyStartedError{}
+  if err := p.SessionAlreadyExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SessionAlreadyExistError), err)
+  }
+  return nil
 }
 
-//HistoryService provides API to start a new long running workflow instance, as well as query and update the history
-//of workflow instances already created.
-//
-type HistoryServiceClient struct {
-  Transport thrift.TTransport
-  ProtocolFactory thrift.TProtocolFactory
-  InputProtocol thrift.TProtocol
-  OutputProtocol thrift.TProtocol
-  SeqId int32
+func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
+  }
+  return nil
 }
 
-func NewHistoryServiceClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *HistoryServiceClient {
-  return &HistoryServiceClient{Transport: t,
-    ProtocolFactory: f,
-    InputProtocol: f.GetProtocol(t),
-    OutputProtocol: f.GetProtocol(t),
-    SeqId: 0,
+func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField5(iprot thrift.TProtocol) error {
+  p.DomainNotActiveError = &shared.DomainNotActiveError{}
+  if err := p.DomainNotActiveError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DomainNotActiveError), err)
   }
+  return nil
 }
 
-func NewHistoryServiceClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *HistoryServiceClient {
-  return &HistoryServiceClient{Transport: t,
-    ProtocolFactory: nil,
-    InputProtocol: iprot,
-    OutputProtocol: oprot,
-    SeqId: 0,
+func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField6(iprot thrift.TProtocol) error {
+  p.LimitExceededError = &shared.LimitExceededError{}
+  if err := p.LimitExceededError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.LimitExceededError), err)
   }
+  return nil
 }
 
-// StartWorkflowExecution starts a new long running workflow instance.  It will create the instance with
-// 'WorkflowExecutionStarted' event in history and also schedule the first DecisionTask for the worker to make the
-// first decision for this instance.  It will return 'WorkflowExecutionAlreadyStartedError', if an instance already
-// exists with same workflowId.
-// 
-// 
-// Parameters:
-//  - StartRequest
-func (p *HistoryServiceClient) StartWorkflowExecution(startRequest *StartWorkflowExecutionRequest) (r *shared.StartWorkflowExecutionResponse, err error) {
-  if err = p.sendStartWorkflowExecution(startRequest); err != nil { return }
-  return p.recvStartWorkflowExecution()
+func (p *HistoryServiceStartWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("StartWorkflowExecution_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+    if err := p.writeField6(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
 }
 
-func (p *HistoryServiceClient) sendStartWorkflowExecution(startRequest *StartWorkflowExecutionRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
+func (p *HistoryServiceStartWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
   }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("StartWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
   }
-  args := HistoryServiceStartWorkflowExecutionArgs{
-  StartRequest : startRequest,
+  return err
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSessionAlreadyExistError() {
+    if err := oprot.WriteFieldBegin("sessionAlreadyExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:sessionAlreadyExistError: ", p), err) }
+    if err := p.SessionAlreadyExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SessionAlreadyExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:sessionAlreadyExistError: ", p), err) }
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
   }
-  return oprot.Flush()
+  return err
 }
 
+func (p *HistoryServiceStartWorkflowExecutionResult) writeField5(oprot thrift.TProtocol) (err error) {
+  if p.IsSetDomainNotActiveError() {
+    if err := oprot.WriteFieldBegin("domainNotActiveError", thrift.STRUCT, 5); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:domainNotActiveError: ", p), err) }
+    if err := p.DomainNotActiveError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DomainNotActiveError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 5:domainNotActiveError: ", p), err) }
+  }
+  return err
+}
 
-func (p *HistoryServiceClient) recvStartWorkflowExecution() (value *shared.StartWorkflowExecutionResponse, err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
+func (p *HistoryServiceStartWorkflowExecutionResult) writeField6(oprot thrift.TProtocol) (err error) {
+  if p.IsSetLimitExceededError() {
+    if err := oprot.WriteFieldBegin("limitExceededError", thrift.STRUCT, 6); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:limitExceededError: ", p), err) }
+    if err := p.LimitExceededError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.LimitExceededError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 6:limitExceededError: ", p), err) }
   }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
+  return err
+}
+
+func (p *HistoryServiceStartWorkflowExecutionResult) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  if method != "StartWorkflowExecution" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "StartWorkflowExecution failed: wrong method name")
-    return
+  return fmt.Sprintf("HistoryServiceStartWorkflowExecutionResult(%+v)", *p)
+}
+
+// Attributes:
+//  - GetRequest
+type HistoryServiceGetWorkflowExecutionNextEventIDArgs struct {
+  GetRequest *GetWorkflowExecutionNextEventIDRequest `thrift:"getRequest,1" db:"getRequest" json:"getRequest"`
+}
+
+func NewHistoryServiceGetWorkflowExecutionNextEventIDArgs() *HistoryServiceGetWorkflowExecutionNextEventIDArgs {
+  return &HistoryServiceGetWorkflowExecutionNextEventIDArgs{}
+}
+
+var HistoryServiceGetWorkflowExecutionNextEventIDArgs_GetRequest_DEFAULT *GetWorkflowExecutionNextEventIDRequest
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) GetGetRequest() *GetWorkflowExecutionNextEventIDRequest {
+  if !p.IsSetGetRequest() {
+    return HistoryServiceGetWorkflowExecutionNextEventIDArgs_GetRequest_DEFAULT
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "StartWorkflowExecution failed: out of sequence response")
-    return
+return p.GetRequest
+}
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) IsSetGetRequest() bool {
+  return p.GetRequest != nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if mTypeId == thrift.EXCEPTION {
-    error0 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error1 error
-    error1, err = error0.Read(iprot)
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error1
-    return
   }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "StartWorkflowExecution failed: invalid message type")
-    return
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  result := HistoryServiceStartWorkflowExecutionResult{}
-  if err = result.Read(iprot); err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.GetRequest = &GetWorkflowExecutionNextEventIDRequest{}
+  if err := p.GetRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.GetRequest), err)
   }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetWorkflowExecutionNextEventID_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.SessionAlreadyExistError != nil {
-    err = result.SessionAlreadyExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("getRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:getRequest: ", p), err) }
+  if err := p.GetRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.GetRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:getRequest: ", p), err) }
+  return err
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  value = result.GetSuccess()
-  return
+  return fmt.Sprintf("HistoryServiceGetWorkflowExecutionNextEventIDArgs(%+v)", *p)
 }
 
-// Returns the nextEventID of the history of workflow execution. Only events in the history with Ids below the returned Id are
-// guaranteed to be valid, so the first step of reading an execution's history is to retrieve this event Id.
-// It fails with 'EntityNotExistError' if specified workflow execution in unknown to the service.
-// 
-// 
-// Parameters:
-//  - GetRequest
-func (p *HistoryServiceClient) GetWorkflowExecutionNextEventID(getRequest *GetWorkflowExecutionNextEventIDRequest) (r *GetWorkflowExecutionNextEventIDResponse, err error) {
-  if err = p.sendGetWorkflowExecutionNextEventID(getRequest); err != nil { return }
-  return p.recvGetWorkflowExecutionNextEventID()
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+//  - ShardOwnershipLostError
+type HistoryServiceGetWorkflowExecutionNextEventIDResult struct {
+  Success *GetWorkflowExecutionNextEventIDResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func (p *HistoryServiceClient) sendGetWorkflowExecutionNextEventID(getRequest *GetWorkflowExecutionNextEventIDRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
+func NewHistoryServiceGetWorkflowExecutionNextEventIDResult() *HistoryServiceGetWorkflowExecutionNextEventIDResult {
+  return &HistoryServiceGetWorkflowExecutionNextEventIDResult{}
+}
+
+var HistoryServiceGetWorkflowExecutionNextEventIDResult_Success_DEFAULT *GetWorkflowExecutionNextEventIDResponse
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetSuccess() *GetWorkflowExecutionNextEventIDResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceGetWorkflowExecutionNextEventIDResult_Success_DEFAULT
   }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("GetWorkflowExecutionNextEventID", thrift.CALL, p.SeqId); err != nil {
-      return
+return p.Success
+}
+var HistoryServiceGetWorkflowExecutionNextEventIDResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceGetWorkflowExecutionNextEventIDResult_BadRequestError_DEFAULT
   }
-  args := HistoryServiceGetWorkflowExecutionNextEventIDArgs{
-  GetRequest : getRequest,
+return p.BadRequestError
+}
+var HistoryServiceGetWorkflowExecutionNextEventIDResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceGetWorkflowExecutionNextEventIDResult_InternalServiceError_DEFAULT
   }
-  if err = args.Write(oprot); err != nil {
-      return
+return p.InternalServiceError
+}
+var HistoryServiceGetWorkflowExecutionNextEventIDResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return HistoryServiceGetWorkflowExecutionNextEventIDResult_EntityNotExistError_DEFAULT
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+return p.EntityNotExistError
+}
+var HistoryServiceGetWorkflowExecutionNextEventIDResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return HistoryServiceGetWorkflowExecutionNextEventIDResult_ShardOwnershipLostError_DEFAULT
   }
-  return oprot.Flush()
+return p.ShardOwnershipLostError
+}
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetSuccess() bool {
+  return p.Success != nil
 }
 
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
 
-func (p *HistoryServiceClient) recvGetWorkflowExecutionNextEventID() (value *GetWorkflowExecutionNextEventIDResponse, err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
-  }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
-  }
-  if method != "GetWorkflowExecutionNextEventID" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetWorkflowExecutionNextEventID failed: wrong method name")
-    return
-  }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetWorkflowExecutionNextEventID failed: out of sequence response")
-    return
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if mTypeId == thrift.EXCEPTION {
-    error2 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error3 error
-    error3, err = error2.Read(iprot)
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error3
-    return
   }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetWorkflowExecutionNextEventID failed: invalid message type")
-    return
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  result := HistoryServiceGetWorkflowExecutionNextEventIDResult{}
-  if err = result.Read(iprot); err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &GetWorkflowExecutionNextEventIDResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  return nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
   }
-  value = result.GetSuccess()
-  return
+  return nil
 }
 
-// RecordDecisionTaskStarted is called by the Matchingservice before it hands a decision task to the application worker in response to
-// a PollForDecisionTask call. It records in the history the event that the decision task has started. It will return 'EventAlreadyStartedError',
-// if the workflow's execution history already includes a record of the event starting.
-// 
-// 
-// Parameters:
-//  - AddRequest
-func (p *HistoryServiceClient) RecordDecisionTaskStarted(addRequest *RecordDecisionTaskStartedRequest) (r *RecordDecisionTaskStartedResponse, err error) {
-  if err = p.sendRecordDecisionTaskStarted(addRequest); err != nil { return }
-  return p.recvRecordDecisionTaskStarted()
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetWorkflowExecutionNextEventID_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
 }
 
-func (p *HistoryServiceClient) sendRecordDecisionTaskStarted(addRequest *RecordDecisionTaskStartedRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
   }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("RecordDecisionTaskStarted", thrift.CALL, p.SeqId); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
   }
-  args := HistoryServiceRecordDecisionTaskStartedArgs{
-  AddRequest : addRequest,
+  return err
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return oprot.Flush()
+  return fmt.Sprintf("HistoryServiceGetWorkflowExecutionNextEventIDResult(%+v)", *p)
 }
 
+// Attributes:
+//  - GetRequest
+type HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs struct {
+  GetRequest *BatchGetWorkflowExecutionNextEventIDRequest `thrift:"getRequest,1" db:"getRequest" json:"getRequest"`
+}
 
-func (p *HistoryServiceClient) recvRecordDecisionTaskStarted() (value *RecordDecisionTaskStartedResponse, err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
-  }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
-  }
-  if method != "RecordDecisionTaskStarted" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RecordDecisionTaskStarted failed: wrong method name")
-    return
+func NewHistoryServiceBatchGetWorkflowExecutionNextEventIDArgs() *HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs {
+  return &HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs{}
+}
+
+var HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs_GetRequest_DEFAULT *BatchGetWorkflowExecutionNextEventIDRequest
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs) GetGetRequest() *BatchGetWorkflowExecutionNextEventIDRequest {
+  if !p.IsSetGetRequest() {
+    return HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs_GetRequest_DEFAULT
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RecordDecisionTaskStarted failed: out of sequence response")
-    return
+return p.GetRequest
+}
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs) IsSetGetRequest() bool {
+  return p.GetRequest != nil
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if mTypeId == thrift.EXCEPTION {
-    error4 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error5 error
-    error5, err = error4.Read(iprot)
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error5
-    return
-  }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RecordDecisionTaskStarted failed: invalid message type")
-    return
-  }
-  result := HistoryServiceRecordDecisionTaskStartedResult{}
-  if err = result.Read(iprot); err != nil {
-    return
-  }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EventAlreadyStartedError != nil {
-    err = result.EventAlreadyStartedError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  value = result.GetSuccess()
-  return
+  return nil
 }
 
-// RecordActivityTaskStarted is called by the Matchingservice before it hands a decision task to the application worker in response to
-// a PollForActivityTask call. It records in the history the event that the decision task has started. It will return 'EventAlreadyStartedError',
-// if the workflow's execution history already includes a record of the event starting.
-// 
-// 
-// Parameters:
-//  - AddRequest
-func (p *HistoryServiceClient) RecordActivityTaskStarted(addRequest *RecordActivityTaskStartedRequest) (r *RecordActivityTaskStartedResponse, err error) {
-  if err = p.sendRecordActivityTaskStarted(addRequest); err != nil { return }
-  return p.recvRecordActivityTaskStarted()
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.GetRequest = &BatchGetWorkflowExecutionNextEventIDRequest{}
+  if err := p.GetRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.GetRequest), err)
+  }
+  return nil
 }
 
-func (p *HistoryServiceClient) sendRecordActivityTaskStarted(addRequest *RecordActivityTaskStartedRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
-  }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("RecordActivityTaskStarted", thrift.CALL, p.SeqId); err != nil {
-      return
-  }
-  args := HistoryServiceRecordActivityTaskStartedArgs{
-  AddRequest : addRequest,
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("BatchGetWorkflowExecutionNextEventID_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("getRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:getRequest: ", p), err) }
+  if err := p.GetRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.GetRequest), err)
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:getRequest: ", p), err) }
+  return err
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return oprot.Flush()
+  return fmt.Sprintf("HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+type HistoryServiceBatchGetWorkflowExecutionNextEventIDResult struct {
+  Success *BatchGetWorkflowExecutionNextEventIDResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
 }
 
+func NewHistoryServiceBatchGetWorkflowExecutionNextEventIDResult() *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult {
+  return &HistoryServiceBatchGetWorkflowExecutionNextEventIDResult{}
+}
 
-func (p *HistoryServiceClient) recvRecordActivityTaskStarted() (value *RecordActivityTaskStartedResponse, err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
+var HistoryServiceBatchGetWorkflowExecutionNextEventIDResult_Success_DEFAULT *BatchGetWorkflowExecutionNextEventIDResponse
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) GetSuccess() *BatchGetWorkflowExecutionNextEventIDResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceBatchGetWorkflowExecutionNextEventIDResult_Success_DEFAULT
   }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
+return p.Success
+}
+var HistoryServiceBatchGetWorkflowExecutionNextEventIDResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceBatchGetWorkflowExecutionNextEventIDResult_BadRequestError_DEFAULT
   }
-  if method != "RecordActivityTaskStarted" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RecordActivityTaskStarted failed: wrong method name")
-    return
+return p.BadRequestError
+}
+var HistoryServiceBatchGetWorkflowExecutionNextEventIDResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceBatchGetWorkflowExecutionNextEventIDResult_InternalServiceError_DEFAULT
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RecordActivityTaskStarted failed: out of sequence response")
-    return
+return p.InternalServiceError
+}
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if mTypeId == thrift.EXCEPTION {
-    error6 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error7 error
-    error7, err = error6.Read(iprot)
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error7
-    return
-  }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RecordActivityTaskStarted failed: invalid message type")
-    return
   }
-  result := HistoryServiceRecordActivityTaskStartedResult{}
-  if err = result.Read(iprot); err != nil {
-    return
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &BatchGetWorkflowExecutionNextEventIDResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EventAlreadyStartedError != nil {
-    err = result.EventAlreadyStartedError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  return nil
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
   }
-  value = result.GetSuccess()
-  return
+  return nil
 }
 
-// RespondDecisionTaskCompleted is called by application worker to complete a DecisionTask handed as a result of
-// 'PollForDecisionTask' API call.  Completing a DecisionTask will result in new events for the workflow execution and
-// potentially new ActivityTask being created for corresponding decisions.  It will also create a DecisionTaskCompleted
-// event in the history for that session.  Use the 'taskToken' provided as response of PollForDecisionTask API call
-// for completing the DecisionTask.
-// 
-// 
-// Parameters:
-//  - CompleteRequest
-func (p *HistoryServiceClient) RespondDecisionTaskCompleted(completeRequest *RespondDecisionTaskCompletedRequest) (err error) {
-  if err = p.sendRespondDecisionTaskCompleted(completeRequest); err != nil { return }
-  return p.recvRespondDecisionTaskCompleted()
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
 }
 
-func (p *HistoryServiceClient) sendRespondDecisionTaskCompleted(completeRequest *RespondDecisionTaskCompletedRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("BatchGetWorkflowExecutionNextEventID_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
   }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.CALL, p.SeqId); err != nil {
-      return
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
   }
-  args := HistoryServiceRespondDecisionTaskCompletedArgs{
-  CompleteRequest : completeRequest,
+  return err
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceBatchGetWorkflowExecutionNextEventIDResult) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return oprot.Flush()
+  return fmt.Sprintf("HistoryServiceBatchGetWorkflowExecutionNextEventIDResult(%+v)", *p)
 }
 
+// Attributes:
+//  - AddRequest
+type HistoryServiceRecordDecisionTaskStartedArgs struct {
+  AddRequest *RecordDecisionTaskStartedRequest `thrift:"addRequest,1" db:"addRequest" json:"addRequest"`
+}
 
-func (p *HistoryServiceClient) recvRespondDecisionTaskCompleted() (err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
-  }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
-  }
-  if method != "RespondDecisionTaskCompleted" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondDecisionTaskCompleted failed: wrong method name")
-    return
+func NewHistoryServiceRecordDecisionTaskStartedArgs() *HistoryServiceRecordDecisionTaskStartedArgs {
+  return &HistoryServiceRecordDecisionTaskStartedArgs{}
+}
+
+var HistoryServiceRecordDecisionTaskStartedArgs_AddRequest_DEFAULT *RecordDecisionTaskStartedRequest
+func (p *HistoryServiceRecordDecisionTaskStartedArgs) GetAddRequest() *RecordDecisionTaskStartedRequest {
+  if !p.IsSetAddRequest() {
+    return HistoryServiceRecordDecisionTaskStartedArgs_AddRequest_DEFAULT
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondDecisionTaskCompleted failed: out of sequence response")
-    return
+return p.AddRequest
+}
+func (p *HistoryServiceRecordDecisionTaskStartedArgs) IsSetAddRequest() bool {
+  return p.AddRequest != nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if mTypeId == thrift.EXCEPTION {
-    error8 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error9 error
-    error9, err = error8.Read(iprot)
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error9
-    return
   }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondDecisionTaskCompleted failed: invalid message type")
-    return
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  result := HistoryServiceRespondDecisionTaskCompletedResult{}
-  if err = result.Read(iprot); err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.AddRequest = &RecordDecisionTaskStartedRequest{}
+  if err := p.AddRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.AddRequest), err)
   }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordDecisionTaskStarted_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("addRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:addRequest: ", p), err) }
+  if err := p.AddRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.AddRequest), err)
+  }
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:addRequest: ", p), err) }
+  return err
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedArgs) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return
+  return fmt.Sprintf("HistoryServiceRecordDecisionTaskStartedArgs(%+v)", *p)
 }
 
-// RecordActivityTaskHeartbeat is called by application worker while it is processing an ActivityTask.  If worker fails
-// to heartbeat within 'heartbeatTimeoutSeconds' interval for the ActivityTask, then it will be marked as timedout and
-// 'ActivityTaskTimedOut' event will be written to the workflow history.  Calling 'RecordActivityTaskHeartbeat' will
-// fail with 'EntityNotExistsError' in such situations.  Use the 'taskToken' provided as response of
-// PollForActivityTask API call for heartbeating.
-// 
-// 
-// Parameters:
-//  - HeartbeatRequest
-func (p *HistoryServiceClient) RecordActivityTaskHeartbeat(heartbeatRequest *RecordActivityTaskHeartbeatRequest) (r *shared.RecordActivityTaskHeartbeatResponse, err error) {
-  if err = p.sendRecordActivityTaskHeartbeat(heartbeatRequest); err != nil { return }
-  return p.recvRecordActivityTaskHeartbeat()
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EventAlreadyStartedError
+//  - EntityNotExistError
+//  - ShardOwnershipLostError
+//  - ServiceBusyError
+type HistoryServiceRecordDecisionTaskStartedResult struct {
+  Success *RecordDecisionTaskStartedResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EventAlreadyStartedError *EventAlreadyStartedError `thrift:"eventAlreadyStartedError,3" db:"eventAlreadyStartedError" json:"eventAlreadyStartedError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,4" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,5" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  ServiceBusyError *shared.ServiceBusyError `thrift:"serviceBusyError,6" db:"serviceBusyError" json:"serviceBusyError,omitempty"`
 }
 
-func (p *HistoryServiceClient) sendRecordActivityTaskHeartbeat(heartbeatRequest *RecordActivityTaskHeartbeatRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
-  }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.CALL, p.SeqId); err != nil {
-      return
+func NewHistoryServiceRecordDecisionTaskStartedResult() *HistoryServiceRecordDecisionTaskStartedResult {
+  return &HistoryServiceRecordDecisionTaskStartedResult{}
+}
+
+var HistoryServiceRecordDecisionTaskStartedResult_Success_DEFAULT *RecordDecisionTaskStartedResponse
+func (p *HistoryServiceRecordDecisionTaskStartedResult) GetSuccess() *RecordDecisionTaskStartedResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceRecordDecisionTaskStartedResult_Success_DEFAULT
   }
-  args := HistoryServiceRecordActivityTaskHeartbeatArgs{
-  HeartbeatRequest : heartbeatRequest,
+return p.Success
+}
+var HistoryServiceRecordDecisionTaskStartedResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRecordDecisionTaskStartedResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceRecordDecisionTaskStartedResult_BadRequestError_DEFAULT
   }
-  if err = args.Write(oprot); err != nil {
-      return
+return p.BadRequestError
+}
+var HistoryServiceRecordDecisionTaskStartedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRecordDecisionTaskStartedResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceRecordDecisionTaskStartedResult_InternalServiceError_DEFAULT
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+return p.InternalServiceError
+}
+var HistoryServiceRecordDecisionTaskStartedResult_EventAlreadyStartedError_DEFAULT *EventAlreadyStartedError
+func (p *HistoryServiceRecordDecisionTaskStartedResult) GetEventAlreadyStartedError() *EventAlreadyStartedError {
+  if !p.IsSetEventAlreadyStartedError() {
+    return HistoryServiceRecordDecisionTaskStartedResult_EventAlreadyStartedError_DEFAULT
   }
-  return oprot.Flush()
+return p.EventAlreadyStartedError
 }
-
-
-func (p *HistoryServiceClient) recvRecordActivityTaskHeartbeat() (value *shared.RecordActivityTaskHeartbeatResponse, err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
+var HistoryServiceRecordDecisionTaskStartedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRecordDecisionTaskStartedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return HistoryServiceRecordDecisionTaskStartedResult_EntityNotExistError_DEFAULT
   }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
+return p.EntityNotExistError
+}
+var HistoryServiceRecordDecisionTaskStartedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRecordDecisionTaskStartedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return HistoryServiceRecordDecisionTaskStartedResult_ShardOwnershipLostError_DEFAULT
   }
-  if method != "RecordActivityTaskHeartbeat" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RecordActivityTaskHeartbeat failed: wrong method name")
-    return
+return p.ShardOwnershipLostError
+}
+var HistoryServiceRecordDecisionTaskStartedResult_ServiceBusyError_DEFAULT *shared.ServiceBusyError
+func (p *HistoryServiceRecordDecisionTaskStartedResult) GetServiceBusyError() *shared.ServiceBusyError {
+  if !p.IsSetServiceBusyError() {
+    return HistoryServiceRecordDecisionTaskStartedResult_ServiceBusyError_DEFAULT
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RecordActivityTaskHeartbeat failed: out of sequence response")
-    return
+return p.ServiceBusyError
+}
+func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetEventAlreadyStartedError() bool {
+  return p.EventAlreadyStartedError != nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetServiceBusyError() bool {
+  return p.ServiceBusyError != nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if mTypeId == thrift.EXCEPTION {
-    error10 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error11 error
-    error11, err = error10.Read(iprot)
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
+    case 5:
+      if err := p.ReadField5(iprot); err != nil {
+        return err
+      }
+    case 6:
+      if err := p.ReadField6(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error11
-    return
-  }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RecordActivityTaskHeartbeat failed: invalid message type")
-    return
-  }
-  result := HistoryServiceRecordActivityTaskHeartbeatResult{}
-  if err = result.Read(iprot); err != nil {
-    return
-  }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  value = result.GetSuccess()
-  return
+  return nil
 }
 
-// RespondActivityTaskCompleted is called by application worker when it is done processing an ActivityTask.  It will
-// result in a new 'ActivityTaskCompleted' event being written to the workflow history and a new DecisionTask
-// created for the workflow so new decisions could be made.  Use the 'taskToken' provided as response of
-// PollForActivityTask API call for completion. It fails with 'EntityNotExistsError' if the taskToken is not valid
-// anymore due to activity timeout.
-// 
-// 
-// Parameters:
-//  - CompleteRequest
-func (p *HistoryServiceClient) RespondActivityTaskCompleted(completeRequest *RespondActivityTaskCompletedRequest) (err error) {
-  if err = p.sendRespondActivityTaskCompleted(completeRequest); err != nil { return }
-  return p.recvRespondActivityTaskCompleted()
+func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &RecordDecisionTaskStartedResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
 }
 
-func (p *HistoryServiceClient) sendRespondActivityTaskCompleted(completeRequest *RespondActivityTaskCompletedRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
-  }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.CALL, p.SeqId); err != nil {
-      return
-  }
-  args := HistoryServiceRespondActivityTaskCompletedArgs{
-  CompleteRequest : completeRequest,
+func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  return nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  return nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EventAlreadyStartedError = &EventAlreadyStartedError{}
+  if err := p.EventAlreadyStartedError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EventAlreadyStartedError), err)
   }
-  return oprot.Flush()
+  return nil
 }
 
-
-func (p *HistoryServiceClient) recvRespondActivityTaskCompleted() (err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
+func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField5(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
   }
-  if method != "RespondActivityTaskCompleted" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondActivityTaskCompleted failed: wrong method name")
-    return
+  return nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField6(iprot thrift.TProtocol) error {
+  p.ServiceBusyError = &shared.ServiceBusyError{}
+  if err := p.ServiceBusyError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ServiceBusyError), err)
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondActivityTaskCompleted failed: out of sequence response")
-    return
+  return nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordDecisionTaskStarted_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+    if err := p.writeField6(oprot); err != nil { return err }
   }
-  if mTypeId == thrift.EXCEPTION {
-    error12 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error13 error
-    error13, err = error12.Read(iprot)
-    if err != nil {
-      return
-    }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
     }
-    err = error13
-    return
-  }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondActivityTaskCompleted failed: invalid message type")
-    return
-  }
-  result := HistoryServiceRespondActivityTaskCompletedResult{}
-  if err = result.Read(iprot); err != nil {
-    return
-  }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  return err
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
   }
-  return
+  return err
 }
 
-// RespondActivityTaskFailed is called by application worker when it is done processing an ActivityTask.  It will
-// result in a new 'ActivityTaskFailed' event being written to the workflow history and a new DecisionTask
-// created for the workflow instance so new decisions could be made.  Use the 'taskToken' provided as response of
-// PollForActivityTask API call for completion. It fails with 'EntityNotExistsError' if the taskToken is not valid
-// anymore due to activity timeout.
-// 
-// 
-// Parameters:
-//  - FailRequest
-func (p *HistoryServiceClient) RespondActivityTaskFailed(failRequest *RespondActivityTaskFailedRequest) (err error) {
-  if err = p.sendRespondActivityTaskFailed(failRequest); err != nil { return }
-  return p.recvRespondActivityTaskFailed()
+func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
 }
 
-func (p *HistoryServiceClient) sendRespondActivityTaskFailed(failRequest *RespondActivityTaskFailedRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
+func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEventAlreadyStartedError() {
+    if err := oprot.WriteFieldBegin("eventAlreadyStartedError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:eventAlreadyStartedError: ", p), err) }
+    if err := p.EventAlreadyStartedError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EventAlreadyStartedError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:eventAlreadyStartedError: ", p), err) }
   }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.CALL, p.SeqId); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:entityNotExistError: ", p), err) }
   }
-  args := HistoryServiceRespondActivityTaskFailedArgs{
-  FailRequest : failRequest,
+  return err
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField5(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 5); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 5:shardOwnershipLostError: ", p), err) }
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField6(oprot thrift.TProtocol) (err error) {
+  if p.IsSetServiceBusyError() {
+    if err := oprot.WriteFieldBegin("serviceBusyError", thrift.STRUCT, 6); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:serviceBusyError: ", p), err) }
+    if err := p.ServiceBusyError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ServiceBusyError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 6:serviceBusyError: ", p), err) }
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceRecordDecisionTaskStartedResult) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return oprot.Flush()
+  return fmt.Sprintf("HistoryServiceRecordDecisionTaskStartedResult(%+v)", *p)
 }
 
+// Attributes:
+//  - AddRequest
+type HistoryServiceRecordActivityTaskStartedArgs struct {
+  AddRequest *RecordActivityTaskStartedRequest `thrift:"addRequest,1" db:"addRequest" json:"addRequest"`
+}
 
-func (p *HistoryServiceClient) recvRespondActivityTaskFailed() (err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
-  }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
-  }
-  if method != "RespondActivityTaskFailed" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondActivityTaskFailed failed: wrong method name")
-    return
+func NewHistoryServiceRecordActivityTaskStartedArgs() *HistoryServiceRecordActivityTaskStartedArgs {
+  return &HistoryServiceRecordActivityTaskStartedArgs{}
+}
+
+var HistoryServiceRecordActivityTaskStartedArgs_AddRequest_DEFAULT *RecordActivityTaskStartedRequest
+func (p *HistoryServiceRecordActivityTaskStartedArgs) GetAddRequest() *RecordActivityTaskStartedRequest {
+  if !p.IsSetAddRequest() {
+    return HistoryServiceRecordActivityTaskStartedArgs_AddRequest_DEFAULT
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondActivityTaskFailed failed: out of sequence response")
-    return
+return p.AddRequest
+}
+func (p *HistoryServiceRecordActivityTaskStartedArgs) IsSetAddRequest() bool {
+  return p.AddRequest != nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if mTypeId == thrift.EXCEPTION {
-    error14 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error15 error
-    error15, err = error14.Read(iprot)
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error15
-    return
-  }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondActivityTaskFailed failed: invalid message type")
-    return
-  }
-  result := HistoryServiceRespondActivityTaskFailedResult{}
-  if err = result.Read(iprot); err != nil {
-    return
-  }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  return
+  return nil
 }
 
-// RespondActivityTaskCanceled is called by application worker when it is successfully canceled an ActivityTask.  It will
-// result in a new 'ActivityTaskCanceled' event being written to the workflow history and a new DecisionTask
-// created for the workflow instance so new decisions could be made.  Use the 'taskToken' provided as response of
-// PollForActivityTask API call for completion. It fails with 'EntityNotExistsError' if the taskToken is not valid
-// anymore due to activity timeout.
-// 
-// 
-// Parameters:
-//  - CanceledRequest
-func (p *HistoryServiceClient) RespondActivityTaskCanceled(canceledRequest *RespondActivityTaskCanceledRequest) (err error) {
-  if err = p.sendRespondActivityTaskCanceled(canceledRequest); err != nil { return }
-  return p.recvRespondActivityTaskCanceled()
+func (p *HistoryServiceRecordActivityTaskStartedArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.AddRequest = &RecordActivityTaskStartedRequest{}
+  if err := p.AddRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.AddRequest), err)
+  }
+  return nil
 }
 
-func (p *HistoryServiceClient) sendRespondActivityTaskCanceled(canceledRequest *RespondActivityTaskCanceledRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
-  }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.CALL, p.SeqId); err != nil {
-      return
-  }
-  args := HistoryServiceRespondActivityTaskCanceledArgs{
-  CanceledRequest : canceledRequest,
+func (p *HistoryServiceRecordActivityTaskStartedArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordActivityTaskStarted_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("addRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:addRequest: ", p), err) }
+  if err := p.AddRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.AddRequest), err)
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:addRequest: ", p), err) }
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedArgs) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return oprot.Flush()
+  return fmt.Sprintf("HistoryServiceRecordActivityTaskStartedArgs(%+v)", *p)
 }
 
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EventAlreadyStartedError
+//  - EntityNotExistError
+//  - ShardOwnershipLostError
+//  - ServiceBusyError
+type HistoryServiceRecordActivityTaskStartedResult struct {
+  Success *RecordActivityTaskStartedResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EventAlreadyStartedError *EventAlreadyStartedError `thrift:"eventAlreadyStartedError,3" db:"eventAlreadyStartedError" json:"eventAlreadyStartedError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,4" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,5" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  ServiceBusyError *shared.ServiceBusyError `thrift:"serviceBusyError,6" db:"serviceBusyError" json:"serviceBusyError,omitempty"`
+}
 
-func (p *HistoryServiceClient) recvRespondActivityTaskCanceled() (err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
-  }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
-  }
-  if method != "RespondActivityTaskCanceled" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RespondActivityTaskCanceled failed: wrong method name")
-    return
+func NewHistoryServiceRecordActivityTaskStartedResult() *HistoryServiceRecordActivityTaskStartedResult {
+  return &HistoryServiceRecordActivityTaskStartedResult{}
+}
+
+var HistoryServiceRecordActivityTaskStartedResult_Success_DEFAULT *RecordActivityTaskStartedResponse
+func (p *HistoryServiceRecordActivityTaskStartedResult) GetSuccess() *RecordActivityTaskStartedResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceRecordActivityTaskStartedResult_Success_DEFAULT
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RespondActivityTaskCanceled failed: out of sequence response")
-    return
+return p.Success
+}
+var HistoryServiceRecordActivityTaskStartedResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRecordActivityTaskStartedResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceRecordActivityTaskStartedResult_BadRequestError_DEFAULT
   }
-  if mTypeId == thrift.EXCEPTION {
-    error16 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error17 error
-    error17, err = error16.Read(iprot)
-    if err != nil {
-      return
-    }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
-    }
-    err = error17
-    return
+return p.BadRequestError
+}
+var HistoryServiceRecordActivityTaskStartedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRecordActivityTaskStartedResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceRecordActivityTaskStartedResult_InternalServiceError_DEFAULT
   }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RespondActivityTaskCanceled failed: invalid message type")
-    return
+return p.InternalServiceError
+}
+var HistoryServiceRecordActivityTaskStartedResult_EventAlreadyStartedError_DEFAULT *EventAlreadyStartedError
+func (p *HistoryServiceRecordActivityTaskStartedResult) GetEventAlreadyStartedError() *EventAlreadyStartedError {
+  if !p.IsSetEventAlreadyStartedError() {
+    return HistoryServiceRecordActivityTaskStartedResult_EventAlreadyStartedError_DEFAULT
   }
-  result := HistoryServiceRespondActivityTaskCanceledResult{}
-  if err = result.Read(iprot); err != nil {
-    return
+return p.EventAlreadyStartedError
+}
+var HistoryServiceRecordActivityTaskStartedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRecordActivityTaskStartedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return HistoryServiceRecordActivityTaskStartedResult_EntityNotExistError_DEFAULT
   }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+return p.EntityNotExistError
+}
+var HistoryServiceRecordActivityTaskStartedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRecordActivityTaskStartedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return HistoryServiceRecordActivityTaskStartedResult_ShardOwnershipLostError_DEFAULT
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+return p.ShardOwnershipLostError
+}
+var HistoryServiceRecordActivityTaskStartedResult_ServiceBusyError_DEFAULT *shared.ServiceBusyError
+func (p *HistoryServiceRecordActivityTaskStartedResult) GetServiceBusyError() *shared.ServiceBusyError {
+  if !p.IsSetServiceBusyError() {
+    return HistoryServiceRecordActivityTaskStartedResult_ServiceBusyError_DEFAULT
   }
-  return
+return p.ServiceBusyError
+}
+func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetSuccess() bool {
+  return p.Success != nil
 }
 
-// SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
-// WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
-// 
-// 
-// Parameters:
-//  - SignalRequest
-func (p *HistoryServiceClient) SignalWorkflowExecution(signalRequest *SignalWorkflowExecutionRequest) (err error) {
-  if err = p.sendSignalWorkflowExecution(signalRequest); err != nil { return }
-  return p.recvSignalWorkflowExecution()
+func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceClient) sendSignalWorkflowExecution(signalRequest *SignalWorkflowExecutionRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
+func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetEventAlreadyStartedError() bool {
+  return p.EventAlreadyStartedError != nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetServiceBusyError() bool {
+  return p.ServiceBusyError != nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
+    case 5:
+      if err := p.ReadField5(iprot); err != nil {
+        return err
+      }
+    case 6:
+      if err := p.ReadField6(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
   }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
-      return
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  args := HistoryServiceSignalWorkflowExecutionArgs{
-  SignalRequest : signalRequest,
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &RecordActivityTaskStartedResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
   }
-  return oprot.Flush()
+  return nil
 }
 
+func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EventAlreadyStartedError = &EventAlreadyStartedError{}
+  if err := p.EventAlreadyStartedError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EventAlreadyStartedError), err)
+  }
+  return nil
+}
 
-func (p *HistoryServiceClient) recvSignalWorkflowExecution() (err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
+func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField5(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
   }
-  if method != "SignalWorkflowExecution" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "SignalWorkflowExecution failed: wrong method name")
-    return
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField6(iprot thrift.TProtocol) error {
+  p.ServiceBusyError = &shared.ServiceBusyError{}
+  if err := p.ServiceBusyError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ServiceBusyError), err)
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "SignalWorkflowExecution failed: out of sequence response")
-    return
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordActivityTaskStarted_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+    if err := p.writeField6(oprot); err != nil { return err }
   }
-  if mTypeId == thrift.EXCEPTION {
-    error18 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error19 error
-    error19, err = error18.Read(iprot)
-    if err != nil {
-      return
-    }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
     }
-    err = error19
-    return
-  }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "SignalWorkflowExecution failed: invalid message type")
-    return
-  }
-  result := HistoryServiceSignalWorkflowExecutionResult{}
-  if err = result.Read(iprot); err != nil {
-    return
-  }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
   }
-  return
+  return err
 }
 
-// TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
-// in the history and immediately terminating the execution instance.
-// 
-// 
-// Parameters:
-//  - TerminateRequest
-func (p *HistoryServiceClient) TerminateWorkflowExecution(terminateRequest *TerminateWorkflowExecutionRequest) (err error) {
-  if err = p.sendTerminateWorkflowExecution(terminateRequest); err != nil { return }
-  return p.recvTerminateWorkflowExecution()
+func (p *HistoryServiceRecordActivityTaskStartedResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
 }
 
-func (p *HistoryServiceClient) sendTerminateWorkflowExecution(terminateRequest *TerminateWorkflowExecutionRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
+func (p *HistoryServiceRecordActivityTaskStartedResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEventAlreadyStartedError() {
+    if err := oprot.WriteFieldBegin("eventAlreadyStartedError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:eventAlreadyStartedError: ", p), err) }
+    if err := p.EventAlreadyStartedError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EventAlreadyStartedError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:eventAlreadyStartedError: ", p), err) }
   }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:entityNotExistError: ", p), err) }
   }
-  args := HistoryServiceTerminateWorkflowExecutionArgs{
-  TerminateRequest : terminateRequest,
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) writeField5(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 5); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 5:shardOwnershipLostError: ", p), err) }
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) writeField6(oprot thrift.TProtocol) (err error) {
+  if p.IsSetServiceBusyError() {
+    if err := oprot.WriteFieldBegin("serviceBusyError", thrift.STRUCT, 6); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:serviceBusyError: ", p), err) }
+    if err := p.ServiceBusyError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ServiceBusyError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 6:serviceBusyError: ", p), err) }
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskStartedResult) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return oprot.Flush()
+  return fmt.Sprintf("HistoryServiceRecordActivityTaskStartedResult(%+v)", *p)
+}
+
+// Attributes:
+//  - CompleteRequest
+type HistoryServiceRespondDecisionTaskCompletedArgs struct {
+  CompleteRequest *RespondDecisionTaskCompletedRequest `thrift:"completeRequest,1" db:"completeRequest" json:"completeRequest"`
 }
 
+func NewHistoryServiceRespondDecisionTaskCompletedArgs() *HistoryServiceRespondDecisionTaskCompletedArgs {
+  return &HistoryServiceRespondDecisionTaskCompletedArgs{}
+}
 
-func (p *HistoryServiceClient) recvTerminateWorkflowExecution() (err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
-  }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
-  }
-  if method != "TerminateWorkflowExecution" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "TerminateWorkflowExecution failed: wrong method name")
-    return
+var HistoryServiceRespondDecisionTaskCompletedArgs_CompleteRequest_DEFAULT *RespondDecisionTaskCompletedRequest
+func (p *HistoryServiceRespondDecisionTaskCompletedArgs) GetCompleteRequest() *RespondDecisionTaskCompletedRequest {
+  if !p.IsSetCompleteRequest() {
+    return HistoryServiceRespondDecisionTaskCompletedArgs_CompleteRequest_DEFAULT
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "TerminateWorkflowExecution failed: out of sequence response")
-    return
+return p.CompleteRequest
+}
+func (p *HistoryServiceRespondDecisionTaskCompletedArgs) IsSetCompleteRequest() bool {
+  return p.CompleteRequest != nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if mTypeId == thrift.EXCEPTION {
-    error20 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error21 error
-    error21, err = error20.Read(iprot)
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error21
-    return
   }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "TerminateWorkflowExecution failed: invalid message type")
-    return
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  result := HistoryServiceTerminateWorkflowExecutionResult{}
-  if err = result.Read(iprot); err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CompleteRequest = &RespondDecisionTaskCompletedRequest{}
+  if err := p.CompleteRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
   }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondDecisionTaskCompleted_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completeRequest: ", p), err) }
+  if err := p.CompleteRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
   }
-  return
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completeRequest: ", p), err) }
+  return err
 }
 
-// RequestCancelWorkflowExecution is called by application worker when it wants to request cancellation of a workflow instance.
-// It will result in a new 'WorkflowExecutionCancelRequested' event being written to the workflow history and a new DecisionTask
-// created for the workflow instance so new decisions could be made. It fails with 'EntityNotExistsError' if the workflow is not valid
-// anymore due to completion or doesn't exist.
-// 
-// 
-// Parameters:
-//  - CancelRequest
-func (p *HistoryServiceClient) RequestCancelWorkflowExecution(cancelRequest *RequestCancelWorkflowExecutionRequest) (err error) {
-  if err = p.sendRequestCancelWorkflowExecution(cancelRequest); err != nil { return }
-  return p.recvRequestCancelWorkflowExecution()
+func (p *HistoryServiceRespondDecisionTaskCompletedArgs) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("HistoryServiceRespondDecisionTaskCompletedArgs(%+v)", *p)
 }
 
-func (p *HistoryServiceClient) sendRequestCancelWorkflowExecution(cancelRequest *RequestCancelWorkflowExecutionRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+//  - ShardOwnershipLostError
+type HistoryServiceRespondDecisionTaskCompletedResult struct {
+  Success *shared.RespondDecisionTaskCompletedResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+}
+
+func NewHistoryServiceRespondDecisionTaskCompletedResult() *HistoryServiceRespondDecisionTaskCompletedResult {
+  return &HistoryServiceRespondDecisionTaskCompletedResult{}
+}
+
+var HistoryServiceRespondDecisionTaskCompletedResult_Success_DEFAULT *shared.RespondDecisionTaskCompletedResponse
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) GetSuccess() *shared.RespondDecisionTaskCompletedResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceRespondDecisionTaskCompletedResult_Success_DEFAULT
   }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.CALL, p.SeqId); err != nil {
-      return
+return p.Success
+}
+var HistoryServiceRespondDecisionTaskCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceRespondDecisionTaskCompletedResult_BadRequestError_DEFAULT
   }
-  args := HistoryServiceRequestCancelWorkflowExecutionArgs{
-  CancelRequest : cancelRequest,
+return p.BadRequestError
+}
+var HistoryServiceRespondDecisionTaskCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceRespondDecisionTaskCompletedResult_InternalServiceError_DEFAULT
   }
-  if err = args.Write(oprot); err != nil {
-      return
+return p.InternalServiceError
+}
+var HistoryServiceRespondDecisionTaskCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return HistoryServiceRespondDecisionTaskCompletedResult_EntityNotExistError_DEFAULT
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+return p.EntityNotExistError
+}
+var HistoryServiceRespondDecisionTaskCompletedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return HistoryServiceRespondDecisionTaskCompletedResult_ShardOwnershipLostError_DEFAULT
   }
-  return oprot.Flush()
+return p.ShardOwnershipLostError
+}
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) IsSetSuccess() bool {
+  return p.Success != nil
 }
 
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
 
-func (p *HistoryServiceClient) recvRequestCancelWorkflowExecution() (err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
-  }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
-  }
-  if method != "RequestCancelWorkflowExecution" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RequestCancelWorkflowExecution failed: wrong method name")
-    return
-  }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RequestCancelWorkflowExecution failed: out of sequence response")
-    return
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if mTypeId == thrift.EXCEPTION {
-    error22 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error23 error
-    error23, err = error22.Read(iprot)
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error23
-    return
-  }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RequestCancelWorkflowExecution failed: invalid message type")
-    return
-  }
-  result := HistoryServiceRequestCancelWorkflowExecutionResult{}
-  if err = result.Read(iprot); err != nil {
-    return
   }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  return nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.RespondDecisionTaskCompletedResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
-  return
+  return nil
 }
 
-// ScheduleDecisionTask is used for creating a decision task for already started workflow execution.  This is mainly
-// used by transfer queue processor during the processing of StartChildWorkflowExecution task, where it first starts
-// child execution without creating the decision task and then calls this API after updating the mutable state of
-// parent execution.
-// 
-// 
-// Parameters:
-//  - ScheduleRequest
-func (p *HistoryServiceClient) ScheduleDecisionTask(scheduleRequest *ScheduleDecisionTaskRequest) (err error) {
-  if err = p.sendScheduleDecisionTask(scheduleRequest); err != nil { return }
-  return p.recvScheduleDecisionTask()
+func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
 }
 
-func (p *HistoryServiceClient) sendScheduleDecisionTask(scheduleRequest *ScheduleDecisionTaskRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
+func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
   }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("ScheduleDecisionTask", thrift.CALL, p.SeqId); err != nil {
-      return
+  return nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
-  args := HistoryServiceScheduleDecisionTaskArgs{
-  ScheduleRequest : scheduleRequest,
+  return nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  return nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondDecisionTaskCompleted_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
   }
-  return oprot.Flush()
+  return err
 }
 
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
+  }
+  return err
+}
 
-func (p *HistoryServiceClient) recvScheduleDecisionTask() (err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
   }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
+  return err
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
-  if method != "ScheduleDecisionTask" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "ScheduleDecisionTask failed: wrong method name")
-    return
+  return err
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
   }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "ScheduleDecisionTask failed: out of sequence response")
-    return
+  return err
+}
+
+func (p *HistoryServiceRespondDecisionTaskCompletedResult) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  if mTypeId == thrift.EXCEPTION {
-    error24 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error25 error
-    error25, err = error24.Read(iprot)
+  return fmt.Sprintf("HistoryServiceRespondDecisionTaskCompletedResult(%+v)", *p)
+}
+
+// Attributes:
+//  - HeartbeatRequest
+type HistoryServiceRecordActivityTaskHeartbeatArgs struct {
+  HeartbeatRequest *RecordActivityTaskHeartbeatRequest `thrift:"heartbeatRequest,1" db:"heartbeatRequest" json:"heartbeatRequest"`
+}
+
+func NewHistoryServiceRecordActivityTaskHeartbeatArgs() *HistoryServiceRecordActivityTaskHeartbeatArgs {
+  return &HistoryServiceRecordActivityTaskHeartbeatArgs{}
+}
+
+var HistoryServiceRecordActivityTaskHeartbeatArgs_HeartbeatRequest_DEFAULT *RecordActivityTaskHeartbeatRequest
+func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) GetHeartbeatRequest() *RecordActivityTaskHeartbeatRequest {
+  if !p.IsSetHeartbeatRequest() {
+    return HistoryServiceRecordActivityTaskHeartbeatArgs_HeartbeatRequest_DEFAULT
+  }
+return p.HeartbeatRequest
+}
+func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) IsSetHeartbeatRequest() bool {
+  return p.HeartbeatRequest != nil
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
     if err != nil {
-      return
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
     }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
     }
-    err = error25
-    return
-  }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "ScheduleDecisionTask failed: invalid message type")
-    return
-  }
-  result := HistoryServiceScheduleDecisionTaskResult{}
-  if err = result.Read(iprot); err != nil {
-    return
-  }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  return
+  return nil
 }
 
-// RecordChildExecutionCompleted is used for reporting the completion of child workflow execution to parent.
-// This is mainly called by transfer queue processor during the processing of DeleteExecution task.
-// 
-// 
-// Parameters:
-//  - CompletionRequest
-func (p *HistoryServiceClient) RecordChildExecutionCompleted(completionRequest *RecordChildExecutionCompletedRequest) (err error) {
-  if err = p.sendRecordChildExecutionCompleted(completionRequest); err != nil { return }
-  return p.recvRecordChildExecutionCompleted()
+func (p *HistoryServiceRecordActivityTaskHeartbeatArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.HeartbeatRequest = &RecordActivityTaskHeartbeatRequest{}
+  if err := p.HeartbeatRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.HeartbeatRequest), err)
+  }
+  return nil
 }
 
-func (p *HistoryServiceClient) sendRecordChildExecutionCompleted(completionRequest *RecordChildExecutionCompletedRequest)(err error) {
-  oprot := p.OutputProtocol
-  if oprot == nil {
-    oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.OutputProtocol = oprot
-  }
-  p.SeqId++
-  if err = oprot.WriteMessageBegin("RecordChildExecutionCompleted", thrift.CALL, p.SeqId); err != nil {
-      return
-  }
-  args := HistoryServiceRecordChildExecutionCompletedArgs{
-  CompletionRequest : completionRequest,
+func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeat_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
   }
-  if err = args.Write(oprot); err != nil {
-      return
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("heartbeatRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:heartbeatRequest: ", p), err) }
+  if err := p.HeartbeatRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.HeartbeatRequest), err)
   }
-  if err = oprot.WriteMessageEnd(); err != nil {
-      return
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:heartbeatRequest: ", p), err) }
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return oprot.Flush()
+  return fmt.Sprintf("HistoryServiceRecordActivityTaskHeartbeatArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+//  - ShardOwnershipLostError
+//  - ServiceBusyError
+type HistoryServiceRecordActivityTaskHeartbeatResult struct {
+  Success *shared.RecordActivityTaskHeartbeatResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  ServiceBusyError *shared.ServiceBusyError `thrift:"serviceBusyError,5" db:"serviceBusyError" json:"serviceBusyError,omitempty"`
 }
 
+func NewHistoryServiceRecordActivityTaskHeartbeatResult() *HistoryServiceRecordActivityTaskHeartbeatResult {
+  return &HistoryServiceRecordActivityTaskHeartbeatResult{}
+}
 
-func (p *HistoryServiceClient) recvRecordChildExecutionCompleted() (err error) {
-  iprot := p.InputProtocol
-  if iprot == nil {
-    iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-    p.InputProtocol = iprot
-  }
-  method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-  if err != nil {
-    return
-  }
-  if method != "RecordChildExecutionCompleted" {
-    err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "RecordChildExecutionCompleted failed: wrong method name")
-    return
-  }
-  if p.SeqId != seqId {
-    err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "RecordChildExecutionCompleted failed: out of sequence response")
-    return
+var HistoryServiceRecordActivityTaskHeartbeatResult_Success_DEFAULT *shared.RecordActivityTaskHeartbeatResponse
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetSuccess() *shared.RecordActivityTaskHeartbeatResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceRecordActivityTaskHeartbeatResult_Success_DEFAULT
   }
-  if mTypeId == thrift.EXCEPTION {
-    error26 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error27 error
-    error27, err = error26.Read(iprot)
-    if err != nil {
-      return
-    }
-    if err = iprot.ReadMessageEnd(); err != nil {
-      return
-    }
-    err = error27
-    return
+return p.Success
+}
+var HistoryServiceRecordActivityTaskHeartbeatResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceRecordActivityTaskHeartbeatResult_BadRequestError_DEFAULT
   }
-  if mTypeId != thrift.REPLY {
-    err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "RecordChildExecutionCompleted failed: invalid message type")
-    return
+return p.BadRequestError
+}
+var HistoryServiceRecordActivityTaskHeartbeatResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceRecordActivityTaskHeartbeatResult_InternalServiceError_DEFAULT
   }
-  result := HistoryServiceRecordChildExecutionCompletedResult{}
-  if err = result.Read(iprot); err != nil {
-    return
+return p.InternalServiceError
+}
+var HistoryServiceRecordActivityTaskHeartbeatResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return HistoryServiceRecordActivityTaskHeartbeatResult_EntityNotExistError_DEFAULT
   }
-  if err = iprot.ReadMessageEnd(); err != nil {
-    return
+return p.EntityNotExistError
+}
+var HistoryServiceRecordActivityTaskHeartbeatResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return HistoryServiceRecordActivityTaskHeartbeatResult_ShardOwnershipLostError_DEFAULT
   }
-  if result.BadRequestError != nil {
-    err = result.BadRequestError
-    return 
-  } else   if result.InternalServiceError != nil {
-    err = result.InternalServiceError
-    return 
-  } else   if result.EntityNotExistError != nil {
-    err = result.EntityNotExistError
-    return 
-  } else   if result.ShardOwnershipLostError != nil {
-    err = result.ShardOwnershipLostError
-    return 
+return p.ShardOwnershipLostError
+}
+var HistoryServiceRecordActivityTaskHeartbeatResult_ServiceBusyError_DEFAULT *shared.ServiceBusyError
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetServiceBusyError() *shared.ServiceBusyError {
+  if !p.IsSetServiceBusyError() {
+    return HistoryServiceRecordActivityTaskHeartbeatResult_ServiceBusyError_DEFAULT
   }
-  return
+return p.ServiceBusyError
 }
-
-
-type HistoryServiceProcessor struct {
-  processorMap map[string]thrift.TProcessorFunction
-  handler HistoryService
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetSuccess() bool {
+  return p.Success != nil
 }
 
-func (p *HistoryServiceProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
-  p.processorMap[key] = processor
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
-  processor, ok = p.processorMap[key]
-  return processor, ok
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
-  return p.processorMap
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
 }
 
-func NewHistoryServiceProcessor(handler HistoryService) *HistoryServiceProcessor {
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
 
-  self28 := &HistoryServiceProcessor{handler:handler, processorMap:make(map[string]thrift.TProcessorFunction)}
-  self28.processorMap["StartWorkflowExecution"] = &historyServiceProcessorStartWorkflowExecution{handler:handler}
-  self28.processorMap["GetWorkflowExecutionNextEventID"] = &historyServiceProcessorGetWorkflowExecutionNextEventID{handler:handler}
-  self28.processorMap["RecordDecisionTaskStarted"] = &historyServiceProcessorRecordDecisionTaskStarted{handler:handler}
-  self28.processorMap["RecordActivityTaskStarted"] = &historyServiceProcessorRecordActivityTaskStarted{handler:handler}
-  self28.processorMap["RespondDecisionTaskCompleted"] = &historyServiceProcessorRespondDecisionTaskCompleted{handler:handler}
-  self28.processorMap["RecordActivityTaskHeartbeat"] = &historyServiceProcessorRecordActivityTaskHeartbeat{handler:handler}
-  self28.processorMap["RespondActivityTaskCompleted"] = &historyServiceProcessorRespondActivityTaskCompleted{handler:handler}
-  self28.processorMap["RespondActivityTaskFailed"] = &historyServiceProcessorRespondActivityTaskFailed{handler:handler}
-  self28.processorMap["RespondActivityTaskCanceled"] = &historyServiceProcessorRespondActivityTaskCanceled{handler:handler}
-  self28.processorMap["SignalWorkflowExecution"] = &historyServiceProcessorSignalWorkflowExecution{handler:handler}
-  self28.processorMap["TerminateWorkflowExecution"] = &historyServiceProcessorTerminateWorkflowExecution{handler:handler}
-  self28.processorMap["RequestCancelWorkflowExecution"] = &historyServiceProcessorRequestCancelWorkflowExecution{handler:handler}
-  self28.processorMap["ScheduleDecisionTask"] = &historyServiceProcessorScheduleDecisionTask{handler:handler}
-  self28.processorMap["RecordChildExecutionCompleted"] = &historyServiceProcessorRecordChildExecutionCompleted{handler:handler}
-return self28
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetServiceBusyError() bool {
+  return p.ServiceBusyError != nil
 }
 
-func (p *HistoryServiceProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  name, _, seqId, err := iprot.ReadMessageBegin()
-  if err != nil { return false, err }
-  if processor, ok := p.GetProcessorFunction(name); ok {
-    return processor.Process(seqId, iprot, oprot)
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  iprot.Skip(thrift.STRUCT)
-  iprot.ReadMessageEnd()
-  x29 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function " + name)
-  oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-  x29.Write(oprot)
-  oprot.WriteMessageEnd()
-  oprot.Flush()
-  return false, x29
 
-}
 
-type historyServiceProcessorStartWorkflowExecution struct {
-  handler HistoryService
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
+    case 5:
+      if err := p.ReadField5(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
+  }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+  }
+  return nil
 }
 
-func (p *historyServiceProcessorStartWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceStartWorkflowExecutionArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("StartWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.RecordActivityTaskHeartbeatResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
+  return nil
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceStartWorkflowExecutionResult{}
-var retval *shared.StartWorkflowExecutionResponse
-  var err2 error
-  if retval, err2 = p.handler.StartWorkflowExecution(args.StartRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.WorkflowExecutionAlreadyStartedError:
-  result.SessionAlreadyExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing StartWorkflowExecution: " + err2.Error())
-    oprot.WriteMessageBegin("StartWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
   }
-  } else {
-    result.Success = retval
+  return nil
 }
-  if err2 = oprot.WriteMessageBegin("StartWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
-    err = err2
-  }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
   }
-  if err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField5(iprot thrift.TProtocol) error {
+  p.ServiceBusyError = &shared.ServiceBusyError{}
+  if err := p.ServiceBusyError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ServiceBusyError), err)
   }
-  return true, err
+  return nil
 }
 
-type historyServiceProcessorGetWorkflowExecutionNextEventID struct {
-  handler HistoryService
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeat_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
 }
 
-func (p *historyServiceProcessorGetWorkflowExecutionNextEventID) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceGetWorkflowExecutionNextEventIDArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("GetWorkflowExecutionNextEventID", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
   }
+  return err
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceGetWorkflowExecutionNextEventIDResult{}
-var retval *GetWorkflowExecutionNextEventIDResponse
-  var err2 error
-  if retval, err2 = p.handler.GetWorkflowExecutionNextEventID(args.GetRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetWorkflowExecutionNextEventID: " + err2.Error())
-    oprot.WriteMessageBegin("GetWorkflowExecutionNextEventID", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
   }
-  } else {
-    result.Success = retval
+  return err
 }
-  if err2 = oprot.WriteMessageBegin("GetWorkflowExecutionNextEventID", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField5(oprot thrift.TProtocol) (err error) {
+  if p.IsSetServiceBusyError() {
+    if err := oprot.WriteFieldBegin("serviceBusyError", thrift.STRUCT, 5); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:serviceBusyError: ", p), err) }
+    if err := p.ServiceBusyError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ServiceBusyError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 5:serviceBusyError: ", p), err) }
   }
-  if err != nil {
-    return
+  return err
+}
+
+func (p *HistoryServiceRecordActivityTaskHeartbeatResult) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return true, err
+  return fmt.Sprintf("HistoryServiceRecordActivityTaskHeartbeatResult(%+v)", *p)
 }
 
-type historyServiceProcessorRecordDecisionTaskStarted struct {
-  handler HistoryService
+// Attributes:
+//  - CompleteRequest
+type HistoryServiceRespondActivityTaskCompletedArgs struct {
+  CompleteRequest *RespondActivityTaskCompletedRequest `thrift:"completeRequest,1" db:"completeRequest" json:"completeRequest"`
 }
 
-func (p *historyServiceProcessorRecordDecisionTaskStarted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceRecordDecisionTaskStartedArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RecordDecisionTaskStarted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func NewHistoryServiceRespondActivityTaskCompletedArgs() *HistoryServiceRespondActivityTaskCompletedArgs {
+  return &HistoryServiceRespondActivityTaskCompletedArgs{}
+}
+
+var HistoryServiceRespondActivityTaskCompletedArgs_CompleteRequest_DEFAULT *RespondActivityTaskCompletedRequest
+func (p *HistoryServiceRespondActivityTaskCompletedArgs) GetCompleteRequest() *RespondActivityTaskCompletedRequest {
+  if !p.IsSetCompleteRequest() {
+    return HistoryServiceRespondActivityTaskCompletedArgs_CompleteRequest_DEFAULT
   }
+return p.CompleteRequest
+}
+func (p *HistoryServiceRespondActivityTaskCompletedArgs) IsSetCompleteRequest() bool {
+  return p.CompleteRequest != nil
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceRecordDecisionTaskStartedResult{}
-var retval *RecordDecisionTaskStartedResponse
-  var err2 error
-  if retval, err2 = p.handler.RecordDecisionTaskStarted(args.AddRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *EventAlreadyStartedError:
-  result.EventAlreadyStartedError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
+func (p *HistoryServiceRespondActivityTaskCompletedArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+  }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
     default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordDecisionTaskStarted: " + err2.Error())
-    oprot.WriteMessageBegin("RecordDecisionTaskStarted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
   }
-  } else {
-    result.Success = retval
-}
-  if err2 = oprot.WriteMessageBegin("RecordDecisionTaskStarted", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CompleteRequest = &RespondActivityTaskCompletedRequest{}
+  if err := p.CompleteRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCompleted_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completeRequest: ", p), err) }
+  if err := p.CompleteRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
   }
-  if err != nil {
-    return
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completeRequest: ", p), err) }
+  return err
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedArgs) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return true, err
+  return fmt.Sprintf("HistoryServiceRespondActivityTaskCompletedArgs(%+v)", *p)
 }
 
-type historyServiceProcessorRecordActivityTaskStarted struct {
-  handler HistoryService
+// Attributes:
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+//  - ShardOwnershipLostError
+type HistoryServiceRespondActivityTaskCompletedResult struct {
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func (p *historyServiceProcessorRecordActivityTaskStarted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceRecordActivityTaskStartedArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RecordActivityTaskStarted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
-  }
+func NewHistoryServiceRespondActivityTaskCompletedResult() *HistoryServiceRespondActivityTaskCompletedResult {
+  return &HistoryServiceRespondActivityTaskCompletedResult{}
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceRecordActivityTaskStartedResult{}
-var retval *RecordActivityTaskStartedResponse
-  var err2 error
-  if retval, err2 = p.handler.RecordActivityTaskStarted(args.AddRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *EventAlreadyStartedError:
-  result.EventAlreadyStartedError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordActivityTaskStarted: " + err2.Error())
-    oprot.WriteMessageBegin("RecordActivityTaskStarted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+var HistoryServiceRespondActivityTaskCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRespondActivityTaskCompletedResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceRespondActivityTaskCompletedResult_BadRequestError_DEFAULT
   }
-  } else {
-    result.Success = retval
+return p.BadRequestError
 }
-  if err2 = oprot.WriteMessageBegin("RecordActivityTaskStarted", thrift.REPLY, seqId); err2 != nil {
-    err = err2
-  }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
-  }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+var HistoryServiceRespondActivityTaskCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRespondActivityTaskCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceRespondActivityTaskCompletedResult_InternalServiceError_DEFAULT
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+return p.InternalServiceError
+}
+var HistoryServiceRespondActivityTaskCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRespondActivityTaskCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return HistoryServiceRespondActivityTaskCompletedResult_EntityNotExistError_DEFAULT
   }
-  if err != nil {
-    return
+return p.EntityNotExistError
+}
+var HistoryServiceRespondActivityTaskCompletedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRespondActivityTaskCompletedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return HistoryServiceRespondActivityTaskCompletedResult_ShardOwnershipLostError_DEFAULT
   }
-  return true, err
+return p.ShardOwnershipLostError
+}
+func (p *HistoryServiceRespondActivityTaskCompletedResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
 }
 
-type historyServiceProcessorRespondDecisionTaskCompleted struct {
-  handler HistoryService
+func (p *HistoryServiceRespondActivityTaskCompletedResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
 }
 
-func (p *historyServiceProcessorRespondDecisionTaskCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceRespondDecisionTaskCompletedArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *HistoryServiceRespondActivityTaskCompletedResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedResult) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceRespondDecisionTaskCompletedResult{}
-  var err2 error
-  if err2 = p.handler.RespondDecisionTaskCompleted(args.CompleteRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
     default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondDecisionTaskCompleted: " + err2.Error())
-    oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
   }
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  if err2 = oprot.WriteMessageBegin("RespondDecisionTaskCompleted", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
   }
-  if err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCompleted_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
+  }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
   }
-  return true, err
+  return err
 }
 
-type historyServiceProcessorRecordActivityTaskHeartbeat struct {
-  handler HistoryService
+func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
+  }
+  return err
 }
 
-func (p *historyServiceProcessorRecordActivityTaskHeartbeat) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceRecordActivityTaskHeartbeatArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
+  return err
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceRecordActivityTaskHeartbeatResult{}
-var retval *shared.RecordActivityTaskHeartbeatResponse
-  var err2 error
-  if retval, err2 = p.handler.RecordActivityTaskHeartbeat(args.HeartbeatRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordActivityTaskHeartbeat: " + err2.Error())
-    oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
   }
-  } else {
-    result.Success = retval
+  return err
 }
-  if err2 = oprot.WriteMessageBegin("RecordActivityTaskHeartbeat", thrift.REPLY, seqId); err2 != nil {
-    err = err2
-  }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
-  }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
-  }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
-  }
-  if err != nil {
-    return
+
+func (p *HistoryServiceRespondActivityTaskCompletedResult) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return true, err
+  return fmt.Sprintf("HistoryServiceRespondActivityTaskCompletedResult(%+v)", *p)
 }
 
-type historyServiceProcessorRespondActivityTaskCompleted struct {
-  handler HistoryService
+// Attributes:
+//  - FailRequest
+type HistoryServiceRespondActivityTaskFailedArgs struct {
+  FailRequest *RespondActivityTaskFailedRequest `thrift:"failRequest,1" db:"failRequest" json:"failRequest"`
 }
 
-func (p *historyServiceProcessorRespondActivityTaskCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceRespondActivityTaskCompletedArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
-  }
+func NewHistoryServiceRespondActivityTaskFailedArgs() *HistoryServiceRespondActivityTaskFailedArgs {
+  return &HistoryServiceRespondActivityTaskFailedArgs{}
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceRespondActivityTaskCompletedResult{}
-  var err2 error
-  if err2 = p.handler.RespondActivityTaskCompleted(args.CompleteRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCompleted: " + err2.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
-  }
-  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCompleted", thrift.REPLY, seqId); err2 != nil {
-    err = err2
-  }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+var HistoryServiceRespondActivityTaskFailedArgs_FailRequest_DEFAULT *RespondActivityTaskFailedRequest
+func (p *HistoryServiceRespondActivityTaskFailedArgs) GetFailRequest() *RespondActivityTaskFailedRequest {
+  if !p.IsSetFailRequest() {
+    return HistoryServiceRespondActivityTaskFailedArgs_FailRequest_DEFAULT
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+return p.FailRequest
+}
+func (p *HistoryServiceRespondActivityTaskFailedArgs) IsSetFailRequest() bool {
+  return p.FailRequest != nil
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
   }
-  if err != nil {
-    return
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  return true, err
+  return nil
 }
 
-type historyServiceProcessorRespondActivityTaskFailed struct {
-  handler HistoryService
+func (p *HistoryServiceRespondActivityTaskFailedArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.FailRequest = &RespondActivityTaskFailedRequest{}
+  if err := p.FailRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.FailRequest), err)
+  }
+  return nil
 }
 
-func (p *historyServiceProcessorRespondActivityTaskFailed) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceRespondActivityTaskFailedArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *HistoryServiceRespondActivityTaskFailedArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskFailed_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
   }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceRespondActivityTaskFailedResult{}
-  var err2 error
-  if err2 = p.handler.RespondActivityTaskFailed(args.FailRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskFailed: " + err2.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
+func (p *HistoryServiceRespondActivityTaskFailedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("failRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:failRequest: ", p), err) }
+  if err := p.FailRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.FailRequest), err)
   }
-  if err2 = oprot.WriteMessageBegin("RespondActivityTaskFailed", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:failRequest: ", p), err) }
+  return err
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedArgs) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  return fmt.Sprintf("HistoryServiceRespondActivityTaskFailedArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+//  - ShardOwnershipLostError
+type HistoryServiceRespondActivityTaskFailedResult struct {
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+}
+
+func NewHistoryServiceRespondActivityTaskFailedResult() *HistoryServiceRespondActivityTaskFailedResult {
+  return &HistoryServiceRespondActivityTaskFailedResult{}
+}
+
+var HistoryServiceRespondActivityTaskFailedResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRespondActivityTaskFailedResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceRespondActivityTaskFailedResult_BadRequestError_DEFAULT
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+return p.BadRequestError
+}
+var HistoryServiceRespondActivityTaskFailedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRespondActivityTaskFailedResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceRespondActivityTaskFailedResult_InternalServiceError_DEFAULT
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+return p.InternalServiceError
+}
+var HistoryServiceRespondActivityTaskFailedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRespondActivityTaskFailedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return HistoryServiceRespondActivityTaskFailedResult_EntityNotExistError_DEFAULT
   }
-  if err != nil {
-    return
+return p.EntityNotExistError
+}
+var HistoryServiceRespondActivityTaskFailedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRespondActivityTaskFailedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return HistoryServiceRespondActivityTaskFailedResult_ShardOwnershipLostError_DEFAULT
   }
-  return true, err
+return p.ShardOwnershipLostError
+}
+func (p *HistoryServiceRespondActivityTaskFailedResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
 }
 
-type historyServiceProcessorRespondActivityTaskCanceled struct {
-  handler HistoryService
+func (p *HistoryServiceRespondActivityTaskFailedResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
 }
 
-func (p *historyServiceProcessorRespondActivityTaskCanceled) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceRespondActivityTaskCanceledArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *HistoryServiceRespondActivityTaskFailedResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedResult) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceRespondActivityTaskCanceledResult{}
-  var err2 error
-  if err2 = p.handler.RespondActivityTaskCanceled(args.CanceledRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
     default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RespondActivityTaskCanceled: " + err2.Error())
-    oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
-  }
-  if err2 = oprot.WriteMessageBegin("RespondActivityTaskCanceled", thrift.REPLY, seqId); err2 != nil {
-    err = err2
-  }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
-  }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  if err != nil {
-    return
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
   }
-  return true, err
+  return nil
 }
 
-type historyServiceProcessorSignalWorkflowExecution struct {
-  handler HistoryService
+func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
+  }
+  return nil
 }
 
-func (p *historyServiceProcessorSignalWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceSignalWorkflowExecutionArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
+  return nil
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceSignalWorkflowExecutionResult{}
-  var err2 error
-  if err2 = p.handler.SignalWorkflowExecution(args.SignalRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing SignalWorkflowExecution: " + err2.Error())
-    oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
   }
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskFailed_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
   }
-  if err2 = oprot.WriteMessageBegin("SignalWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  return err
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  return err
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  return err
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
   }
-  if err != nil {
-    return
+  return err
+}
+
+func (p *HistoryServiceRespondActivityTaskFailedResult) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return true, err
+  return fmt.Sprintf("HistoryServiceRespondActivityTaskFailedResult(%+v)", *p)
 }
 
-type historyServiceProcessorTerminateWorkflowExecution struct {
-  handler HistoryService
+// Attributes:
+//  - CanceledRequest
+type HistoryServiceRespondActivityTaskCanceledArgs struct {
+  CanceledRequest *RespondActivityTaskCanceledRequest `thrift:"canceledRequest,1" db:"canceledRequest" json:"canceledRequest"`
 }
 
-func (p *historyServiceProcessorTerminateWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceTerminateWorkflowExecutionArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func NewHistoryServiceRespondActivityTaskCanceledArgs() *HistoryServiceRespondActivityTaskCanceledArgs {
+  return &HistoryServiceRespondActivityTaskCanceledArgs{}
+}
+
+var HistoryServiceRespondActivityTaskCanceledArgs_CanceledRequest_DEFAULT *RespondActivityTaskCanceledRequest
+func (p *HistoryServiceRespondActivityTaskCanceledArgs) GetCanceledRequest() *RespondActivityTaskCanceledRequest {
+  if !p.IsSetCanceledRequest() {
+    return HistoryServiceRespondActivityTaskCanceledArgs_CanceledRequest_DEFAULT
   }
+return p.CanceledRequest
+}
+func (p *HistoryServiceRespondActivityTaskCanceledArgs) IsSetCanceledRequest() bool {
+  return p.CanceledRequest != nil
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceTerminateWorkflowExecutionResult{}
-  var err2 error
-  if err2 = p.handler.TerminateWorkflowExecution(args.TerminateRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing TerminateWorkflowExecution: " + err2.Error())
-    oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+func (p *HistoryServiceRespondActivityTaskCanceledArgs) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
   }
-  if err2 = oprot.WriteMessageBegin("TerminateWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CanceledRequest = &RespondActivityTaskCanceledRequest{}
+  if err := p.CanceledRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CanceledRequest), err)
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCanceled_args"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("canceledRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:canceledRequest: ", p), err) }
+  if err := p.CanceledRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CanceledRequest), err)
   }
-  if err != nil {
-    return
+  if err := oprot.WriteFieldEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:canceledRequest: ", p), err) }
+  return err
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledArgs) String() string {
+  if p == nil {
+    return "<nil>"
   }
-  return true, err
+  return fmt.Sprintf("HistoryServiceRespondActivityTaskCanceledArgs(%+v)", *p)
 }
 
-type historyServiceProcessorRequestCancelWorkflowExecution struct {
-  handler HistoryService
+// Attributes:
+//  - BadRequestError
+//  - InternalServiceError
+//  - EntityNotExistError
+//  - ShardOwnershipLostError
+type HistoryServiceRespondActivityTaskCanceledResult struct {
+  BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
+  InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func (p *historyServiceProcessorRequestCancelWorkflowExecution) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceRequestCancelWorkflowExecutionArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
-  }
+func NewHistoryServiceRespondActivityTaskCanceledResult() *HistoryServiceRespondActivityTaskCanceledResult {
+  return &HistoryServiceRespondActivityTaskCanceledResult{}
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceRequestCancelWorkflowExecutionResult{}
-  var err2 error
-  if err2 = p.handler.RequestCancelWorkflowExecution(args.CancelRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RequestCancelWorkflowExecution: " + err2.Error())
-    oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
+var HistoryServiceRespondActivityTaskCanceledResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRespondActivityTaskCanceledResult) GetBadRequestError() *shared.BadRequestError {
+  if !p.IsSetBadRequestError() {
+    return HistoryServiceRespondActivityTaskCanceledResult_BadRequestError_DEFAULT
   }
+return p.BadRequestError
+}
+var HistoryServiceRespondActivityTaskCanceledResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRespondActivityTaskCanceledResult) GetInternalServiceError() *shared.InternalServiceError {
+  if !p.IsSetInternalServiceError() {
+    return HistoryServiceRespondActivityTaskCanceledResult_InternalServiceError_DEFAULT
   }
-  if err2 = oprot.WriteMessageBegin("RequestCancelWorkflowExecution", thrift.REPLY, seqId); err2 != nil {
-    err = err2
+return p.InternalServiceError
+}
+var HistoryServiceRespondActivityTaskCanceledResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRespondActivityTaskCanceledResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return HistoryServiceRespondActivityTaskCanceledResult_EntityNotExistError_DEFAULT
   }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
+return p.EntityNotExistError
+}
+var HistoryServiceRespondActivityTaskCanceledResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRespondActivityTaskCanceledResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+  if !p.IsSetShardOwnershipLostError() {
+    return HistoryServiceRespondActivityTaskCanceledResult_ShardOwnershipLostError_DEFAULT
   }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+return p.ShardOwnershipLostError
+}
+func (p *HistoryServiceRespondActivityTaskCanceledResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledResult) IsSetShardOwnershipLostError() bool {
+  return p.ShardOwnershipLostError != nil
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledResult) Read(iprot thrift.TProtocol) error {
+  if _, err := iprot.ReadStructBegin(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+
+
+  for {
+    _, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+    if err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+    }
+    if fieldTypeId == thrift.STOP { break; }
+    switch fieldId {
+    case 1:
+      if err := p.ReadField1(iprot); err != nil {
+        return err
+      }
+    case 2:
+      if err := p.ReadField2(iprot); err != nil {
+        return err
+      }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
+    case 4:
+      if err := p.ReadField4(iprot); err != nil {
+        return err
+      }
+    default:
+      if err := iprot.Skip(fieldTypeId); err != nil {
+        return err
+      }
+    }
+    if err := iprot.ReadFieldEnd(); err != nil {
+      return err
+    }
   }
-  if err != nil {
-    return
+  if err := iprot.ReadStructEnd(); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
   }
-  return true, err
+  return nil
 }
 
-type historyServiceProcessorScheduleDecisionTask struct {
-  handler HistoryService
+func (p *HistoryServiceRespondActivityTaskCanceledResult)  ReadField1(iprot thrift.TProtocol) error {
+  p.BadRequestError = &shared.BadRequestError{}
+  if err := p.BadRequestError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
+  }
+  return nil
 }
 
-func (p *historyServiceProcessorScheduleDecisionTask) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceScheduleDecisionTaskArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("ScheduleDecisionTask", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *HistoryServiceRespondActivityTaskCanceledResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
   }
+  return nil
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceScheduleDecisionTaskResult{}
-  var err2 error
-  if err2 = p.handler.ScheduleDecisionTask(args.ScheduleRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing ScheduleDecisionTask: " + err2.Error())
-    oprot.WriteMessageBegin("ScheduleDecisionTask", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
-  }
-  if err2 = oprot.WriteMessageBegin("ScheduleDecisionTask", thrift.REPLY, seqId); err2 != nil {
-    err = err2
-  }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
-  }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
-  }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
-  }
-  if err != nil {
-    return
+func (p *HistoryServiceRespondActivityTaskCanceledResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
-  return true, err
+  return nil
 }
 
-type historyServiceProcessorRecordChildExecutionCompleted struct {
-  handler HistoryService
+func (p *HistoryServiceRespondActivityTaskCanceledResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.ShardOwnershipLostError = &ShardOwnershipLostError{}
+  if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
+  }
+  return nil
 }
 
-func (p *historyServiceProcessorRecordChildExecutionCompleted) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-  args := HistoryServiceRecordChildExecutionCompletedArgs{}
-  if err = args.Read(iprot); err != nil {
-    iprot.ReadMessageEnd()
-    x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-    oprot.WriteMessageBegin("RecordChildExecutionCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return false, err
+func (p *HistoryServiceRespondActivityTaskCanceledResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RespondActivityTaskCanceled_result"); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
+  if p != nil {
+    if err := p.writeField1(oprot); err != nil { return err }
+    if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
+    if err := p.writeField4(oprot); err != nil { return err }
   }
+  if err := oprot.WriteFieldStop(); err != nil {
+    return thrift.PrependError("write field stop error: ", err) }
+  if err := oprot.WriteStructEnd(); err != nil {
+    return thrift.PrependError("write struct stop error: ", err) }
+  return nil
+}
 
-  iprot.ReadMessageEnd()
-  result := HistoryServiceRecordChildExecutionCompletedResult{}
-  var err2 error
-  if err2 = p.handler.RecordChildExecutionCompleted(args.CompletionRequest); err2 != nil {
-  switch v := err2.(type) {
-    case *shared.BadRequestError:
-  result.BadRequestError = v
-    case *shared.InternalServiceError:
-  result.InternalServiceError = v
-    case *shared.EntityNotExistsError:
-  result.EntityNotExistError = v
-    case *ShardOwnershipLostError:
-  result.ShardOwnershipLostError = v
-    default:
-    x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing RecordChildExecutionCompleted: " + err2.Error())
-    oprot.WriteMessageBegin("RecordChildExecutionCompleted", thrift.EXCEPTION, seqId)
-    x.Write(oprot)
-    oprot.WriteMessageEnd()
-    oprot.Flush()
-    return true, err2
-  }
-  }
-  if err2 = oprot.WriteMessageBegin("RecordChildExecutionCompleted", thrift.REPLY, seqId); err2 != nil {
-    err = err2
-  }
-  if err2 = result.Write(oprot); err == nil && err2 != nil {
-    err = err2
-  }
-  if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-    err = err2
+func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField1(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBadRequestError() {
+    if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
+    if err := p.BadRequestError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.BadRequestError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 1:badRequestError: ", p), err) }
   }
-  if err2 = oprot.Flush(); err == nil && err2 != nil {
-    err = err2
+  return err
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField2(oprot thrift.TProtocol) (err error) {
+  if p.IsSetInternalServiceError() {
+    if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
+    if err := p.InternalServiceError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.InternalServiceError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 2:internalServiceError: ", p), err) }
   }
-  if err != nil {
-    return
+  return err
+}
+
+func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
-  return true, err
+  return err
 }
 
+func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetShardOwnershipLostError() {
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
+  }
+  return err
+}
 
-// HELPER FUNCTIONS AND STRUCTURES
+func (p *HistoryServiceRespondActivityTaskCanceledResult) String() string {
+  if p == nil {
+    return "<nil>"
+  }
+  return fmt.Sprintf("HistoryServiceRespondActivityTaskCanceledResult(%+v)", *p)
+}
 
 // Attributes:
-//  - StartRequest
-type HistoryServiceStartWorkflowExecutionArgs struct {
-  StartRequest *StartWorkflowExecutionRequest `thrift:"startRequest,1" db:"startRequest" json:"startRequest"`
+//  - SignalRequest
+type HistoryServiceSignalWorkflowExecutionArgs struct {
+  SignalRequest *SignalWorkflowExecutionRequest `thrift:"signalRequest,1" db:"signalRequest" json:"signalRequest"`
 }
 
-func NewHistoryServiceStartWorkflowExecutionArgs() *HistoryServiceStartWorkflowExecutionArgs {
-  return &HistoryServiceStartWorkflowExecutionArgs{}
+func NewHistoryServiceSignalWorkflowExecutionArgs() *HistoryServiceSignalWorkflowExecutionArgs {
+  return &HistoryServiceSignalWorkflowExecutionArgs{}
 }
 
-var HistoryServiceStartWorkflowExecutionArgs_StartRequest_DEFAULT *StartWorkflowExecutionRequest
-func (p *HistoryServiceStartWorkflowExecutionArgs) GetStartRequest() *StartWorkflowExecutionRequest {
-  if !p.IsSetStartRequest() {
-    return HistoryServiceStartWorkflowExecutionArgs_StartRequest_DEFAULT
+var HistoryServiceSignalWorkflowExecutionArgs_SignalRequest_DEFAULT *SignalWorkflowExecutionRequest
+func (p *HistoryServiceSignalWorkflowExecutionArgs) GetSignalRequest() *SignalWorkflowExecutionRequest {
+  if !p.IsSetSignalRequest() {
+    return HistoryServiceSignalWorkflowExecutionArgs_SignalRequest_DEFAULT
   }
-return p.StartRequest
+return p.SignalRequest
 }
-func (p *HistoryServiceStartWorkflowExecutionArgs) IsSetStartRequest() bool {
-  return p.StartRequest != nil
+func (p *HistoryServiceSignalWorkflowExecutionArgs) IsSetSignalRequest() bool {
+  return p.SignalRequest != nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceSignalWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -5742,16 +13428,16 @@ func (p *HistoryServiceStartWorkflowExecutionArgs) Read(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.StartRequest = &StartWorkflowExecutionRequest{}
-  if err := p.StartRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.StartRequest), err)
+func (p *HistoryServiceSignalWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.SignalRequest = &SignalWorkflowExecutionRequest{}
+  if err := p.SignalRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SignalRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("StartWorkflowExecution_args"); err != nil {
+func (p *HistoryServiceSignalWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SignalWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -5763,98 +13449,98 @@ func (p *HistoryServiceStartWorkflowExecutionArgs) Write(oprot thrift.TProtocol)
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("startRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:startRequest: ", p), err) }
-  if err := p.StartRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.StartRequest), err)
+func (p *HistoryServiceSignalWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("signalRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:signalRequest: ", p), err) }
+  if err := p.SignalRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SignalRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:startRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:signalRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceStartWorkflowExecutionArgs) String() string {
+func (p *HistoryServiceSignalWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceStartWorkflowExecutionArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceSignalWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - BadRequestError
 //  - InternalServiceError
-//  - SessionAlreadyExistError
+//  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceStartWorkflowExecutionResult struct {
-  Success *shared.StartWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type HistoryServiceSignalWorkflowExecutionResult struct {
+  Success *shared.SignalWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  SessionAlreadyExistError *shared.WorkflowExecutionAlreadyStartedError `thrift:"sessionAlreadyExistError,3" db:"sessionAlreadyExistError" json:"sessionAlreadyExistError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceStartWorkflowExecutionResult() *HistoryServiceStartWorkflowExecutionResult {
-  return &HistoryServiceStartWorkflowExecutionResult{}
+func NewHistoryServiceSignalWorkflowExecutionResult() *HistoryServiceSignalWorkflowExecutionResult {
+  return &HistoryServiceSignalWorkflowExecutionResult{}
 }
 
-var HistoryServiceStartWorkflowExecutionResult_Success_DEFAULT *shared.StartWorkflowExecutionResponse
-func (p *HistoryServiceStartWorkflowExecutionResult) GetSuccess() *shared.StartWorkflowExecutionResponse {
+var HistoryServiceSignalWorkflowExecutionResult_Success_DEFAULT *shared.SignalWorkflowExecutionResponse
+func (p *HistoryServiceSignalWorkflowExecutionResult) GetSuccess() *shared.SignalWorkflowExecutionResponse {
   if !p.IsSetSuccess() {
-    return HistoryServiceStartWorkflowExecutionResult_Success_DEFAULT
+    return HistoryServiceSignalWorkflowExecutionResult_Success_DEFAULT
   }
 return p.Success
 }
-var HistoryServiceStartWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceStartWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceSignalWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceSignalWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceStartWorkflowExecutionResult_BadRequestError_DEFAULT
+    return HistoryServiceSignalWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceStartWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceStartWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceSignalWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceSignalWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceStartWorkflowExecutionResult_InternalServiceError_DEFAULT
+    return HistoryServiceSignalWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceStartWorkflowExecutionResult_SessionAlreadyExistError_DEFAULT *shared.WorkflowExecutionAlreadyStartedError
-func (p *HistoryServiceStartWorkflowExecutionResult) GetSessionAlreadyExistError() *shared.WorkflowExecutionAlreadyStartedError {
-  if !p.IsSetSessionAlreadyExistError() {
-    return HistoryServiceStartWorkflowExecutionResult_SessionAlreadyExistError_DEFAULT
+var HistoryServiceSignalWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceSignalWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+  if !p.IsSetEntityNotExistError() {
+    return HistoryServiceSignalWorkflowExecutionResult_EntityNotExistError_DEFAULT
   }
-return p.SessionAlreadyExistError
+return p.EntityNotExistError
 }
-var HistoryServiceStartWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceStartWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceSignalWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceSignalWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceStartWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceSignalWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceStartWorkflowExecutionResult) IsSetSuccess() bool {
+func (p *HistoryServiceSignalWorkflowExecutionResult) IsSetSuccess() bool {
   return p.Success != nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceSignalWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceSignalWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) IsSetSessionAlreadyExistError() bool {
-  return p.SessionAlreadyExistError != nil
+func (p *HistoryServiceSignalWorkflowExecutionResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceSignalWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceSignalWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -5902,15 +13588,15 @@ func (p *HistoryServiceStartWorkflowExecutionResult) Read(iprot thrift.TProtocol
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.StartWorkflowExecutionResponse{}
+func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.SignalWorkflowExecutionResponse{}
   if err := p.Success.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -5918,7 +13604,7 @@ func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField1(iprot thrift.TP
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -5926,15 +13612,15 @@ func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField2(iprot thrift.TP
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.SessionAlreadyExistError = &shared.WorkflowExecutionAlreadyStartedError{}
-  if err := p.SessionAlreadyExistError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SessionAlreadyExistError), err)
+func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -5942,8 +13628,8 @@ func (p *HistoryServiceStartWorkflowExecutionResult)  ReadField4(iprot thrift.TP
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("StartWorkflowExecution_result"); err != nil {
+func (p *HistoryServiceSignalWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("SignalWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField0(oprot); err != nil { return err }
@@ -5959,7 +13645,7 @@ func (p *HistoryServiceStartWorkflowExecutionResult) Write(oprot thrift.TProtoco
   return nil
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceSignalWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
   if p.IsSetSuccess() {
     if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
@@ -5972,7 +13658,7 @@ func (p *HistoryServiceStartWorkflowExecutionResult) writeField0(oprot thrift.TP
   return err
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceSignalWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -5985,7 +13671,7 @@ func (p *HistoryServiceStartWorkflowExecutionResult) writeField1(oprot thrift.TP
   return err
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceSignalWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -5998,20 +13684,20 @@ func (p *HistoryServiceStartWorkflowExecutionResult) writeField2(oprot thrift.TP
   return err
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetSessionAlreadyExistError() {
-    if err := oprot.WriteFieldBegin("sessionAlreadyExistError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:sessionAlreadyExistError: ", p), err) }
-    if err := p.SessionAlreadyExistError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SessionAlreadyExistError), err)
+func (p *HistoryServiceSignalWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:sessionAlreadyExistError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
   return err
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceSignalWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
@@ -6024,35 +13710,35 @@ func (p *HistoryServiceStartWorkflowExecutionResult) writeField4(oprot thrift.TP
   return err
 }
 
-func (p *HistoryServiceStartWorkflowExecutionResult) String() string {
+func (p *HistoryServiceSignalWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceStartWorkflowExecutionResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceSignalWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - GetRequest
-type HistoryServiceGetWorkflowExecutionNextEventIDArgs struct {
-  GetRequest *GetWorkflowExecutionNextEventIDRequest `thrift:"getRequest,1" db:"getRequest" json:"getRequest"`
+//  - ReceiptRequest
+type HistoryServiceGetSignalReceiptArgs struct {
+  ReceiptRequest *GetSignalReceiptRequest `thrift:"receiptRequest,1" db:"receiptRequest" json:"receiptRequest"`
 }
 
-func NewHistoryServiceGetWorkflowExecutionNextEventIDArgs() *HistoryServiceGetWorkflowExecutionNextEventIDArgs {
-  return &HistoryServiceGetWorkflowExecutionNextEventIDArgs{}
+func NewHistoryServiceGetSignalReceiptArgs() *HistoryServiceGetSignalReceiptArgs {
+  return &HistoryServiceGetSignalReceiptArgs{}
 }
 
-var HistoryServiceGetWorkflowExecutionNextEventIDArgs_GetRequest_DEFAULT *GetWorkflowExecutionNextEventIDRequest
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) GetGetRequest() *GetWorkflowExecutionNextEventIDRequest {
-  if !p.IsSetGetRequest() {
-    return HistoryServiceGetWorkflowExecutionNextEventIDArgs_GetRequest_DEFAULT
+var HistoryServiceGetSignalReceiptArgs_ReceiptRequest_DEFAULT *GetSignalReceiptRequest
+func (p *HistoryServiceGetSignalReceiptArgs) GetReceiptRequest() *GetSignalReceiptRequest {
+  if !p.IsSetReceiptRequest() {
+    return HistoryServiceGetSignalReceiptArgs_ReceiptRequest_DEFAULT
   }
-return p.GetRequest
+return p.ReceiptRequest
 }
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) IsSetGetRequest() bool {
-  return p.GetRequest != nil
+func (p *HistoryServiceGetSignalReceiptArgs) IsSetReceiptRequest() bool {
+  return p.ReceiptRequest != nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceGetSignalReceiptArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6084,16 +13770,16 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) Read(iprot thrift.TP
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.GetRequest = &GetWorkflowExecutionNextEventIDRequest{}
-  if err := p.GetRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.GetRequest), err)
+func (p *HistoryServiceGetSignalReceiptArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.ReceiptRequest = &GetSignalReceiptRequest{}
+  if err := p.ReceiptRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ReceiptRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("GetWorkflowExecutionNextEventID_args"); err != nil {
+func (p *HistoryServiceGetSignalReceiptArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetSignalReceipt_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -6105,22 +13791,22 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) Write(oprot thrift.T
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("getRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:getRequest: ", p), err) }
-  if err := p.GetRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.GetRequest), err)
+func (p *HistoryServiceGetSignalReceiptArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("receiptRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:receiptRequest: ", p), err) }
+  if err := p.ReceiptRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ReceiptRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:getRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:receiptRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) String() string {
+func (p *HistoryServiceGetSignalReceiptArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceGetWorkflowExecutionNextEventIDArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceGetSignalReceiptArgs(%+v)", *p)
 }
 
 // Attributes:
@@ -6129,74 +13815,74 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDArgs) String() string {
 //  - InternalServiceError
 //  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceGetWorkflowExecutionNextEventIDResult struct {
-  Success *GetWorkflowExecutionNextEventIDResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type HistoryServiceGetSignalReceiptResult struct {
+  Success *shared.GetSignalReceiptResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceGetWorkflowExecutionNextEventIDResult() *HistoryServiceGetWorkflowExecutionNextEventIDResult {
-  return &HistoryServiceGetWorkflowExecutionNextEventIDResult{}
+func NewHistoryServiceGetSignalReceiptResult() *HistoryServiceGetSignalReceiptResult {
+  return &HistoryServiceGetSignalReceiptResult{}
 }
 
-var HistoryServiceGetWorkflowExecutionNextEventIDResult_Success_DEFAULT *GetWorkflowExecutionNextEventIDResponse
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetSuccess() *GetWorkflowExecutionNextEventIDResponse {
+var HistoryServiceGetSignalReceiptResult_Success_DEFAULT *shared.GetSignalReceiptResponse
+func (p *HistoryServiceGetSignalReceiptResult) GetSuccess() *shared.GetSignalReceiptResponse {
   if !p.IsSetSuccess() {
-    return HistoryServiceGetWorkflowExecutionNextEventIDResult_Success_DEFAULT
+    return HistoryServiceGetSignalReceiptResult_Success_DEFAULT
   }
 return p.Success
 }
-var HistoryServiceGetWorkflowExecutionNextEventIDResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceGetSignalReceiptResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceGetSignalReceiptResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceGetWorkflowExecutionNextEventIDResult_BadRequestError_DEFAULT
+    return HistoryServiceGetSignalReceiptResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceGetWorkflowExecutionNextEventIDResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceGetSignalReceiptResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceGetSignalReceiptResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceGetWorkflowExecutionNextEventIDResult_InternalServiceError_DEFAULT
+    return HistoryServiceGetSignalReceiptResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceGetWorkflowExecutionNextEventIDResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceGetSignalReceiptResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceGetSignalReceiptResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceGetWorkflowExecutionNextEventIDResult_EntityNotExistError_DEFAULT
+    return HistoryServiceGetSignalReceiptResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceGetWorkflowExecutionNextEventIDResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceGetSignalReceiptResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceGetSignalReceiptResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceGetWorkflowExecutionNextEventIDResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceGetSignalReceiptResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetSuccess() bool {
+func (p *HistoryServiceGetSignalReceiptResult) IsSetSuccess() bool {
   return p.Success != nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceGetSignalReceiptResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceGetSignalReceiptResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetEntityNotExistError() bool {
+func (p *HistoryServiceGetSignalReceiptResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceGetSignalReceiptResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceGetSignalReceiptResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6244,15 +13930,15 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) Read(iprot thrift.
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &GetWorkflowExecutionNextEventIDResponse{}
+func (p *HistoryServiceGetSignalReceiptResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.GetSignalReceiptResponse{}
   if err := p.Success.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceGetSignalReceiptResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -6260,7 +13946,7 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField1(iprot
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceGetSignalReceiptResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -6268,7 +13954,7 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField2(iprot
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *HistoryServiceGetSignalReceiptResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -6276,7 +13962,7 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField3(iprot
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceGetSignalReceiptResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -6284,8 +13970,8 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult)  ReadField4(iprot
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("GetWorkflowExecutionNextEventID_result"); err != nil {
+func (p *HistoryServiceGetSignalReceiptResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("GetSignalReceipt_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField0(oprot); err != nil { return err }
@@ -6301,7 +13987,7 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) Write(oprot thrift
   return nil
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceGetSignalReceiptResult) writeField0(oprot thrift.TProtocol) (err error) {
   if p.IsSetSuccess() {
     if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
@@ -6314,7 +14000,7 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField0(oprot
   return err
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceGetSignalReceiptResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -6327,7 +14013,7 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField1(oprot
   return err
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceGetSignalReceiptResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -6340,7 +14026,7 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField2(oprot
   return err
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceGetSignalReceiptResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -6353,7 +14039,7 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField3(oprot
   return err
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceGetSignalReceiptResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
@@ -6366,35 +14052,35 @@ func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) writeField4(oprot
   return err
 }
 
-func (p *HistoryServiceGetWorkflowExecutionNextEventIDResult) String() string {
+func (p *HistoryServiceGetSignalReceiptResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceGetWorkflowExecutionNextEventIDResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceGetSignalReceiptResult(%+v)", *p)
 }
 
 // Attributes:
-//  - AddRequest
-type HistoryServiceRecordDecisionTaskStartedArgs struct {
-  AddRequest *RecordDecisionTaskStartedRequest `thrift:"addRequest,1" db:"addRequest" json:"addRequest"`
+//  - QueryRequest
+type HistoryServiceQueryWorkflowArgs struct {
+  QueryRequest *QueryWorkflowRequest `thrift:"queryRequest,1" db:"queryRequest" json:"queryRequest"`
 }
 
-func NewHistoryServiceRecordDecisionTaskStartedArgs() *HistoryServiceRecordDecisionTaskStartedArgs {
-  return &HistoryServiceRecordDecisionTaskStartedArgs{}
+func NewHistoryServiceQueryWorkflowArgs() *HistoryServiceQueryWorkflowArgs {
+  return &HistoryServiceQueryWorkflowArgs{}
 }
 
-var HistoryServiceRecordDecisionTaskStartedArgs_AddRequest_DEFAULT *RecordDecisionTaskStartedRequest
-func (p *HistoryServiceRecordDecisionTaskStartedArgs) GetAddRequest() *RecordDecisionTaskStartedRequest {
-  if !p.IsSetAddRequest() {
-    return HistoryServiceRecordDecisionTaskStartedArgs_AddRequest_DEFAULT
+var HistoryServiceQueryWorkflowArgs_QueryRequest_DEFAULT *QueryWorkflowRequest
+func (p *HistoryServiceQueryWorkflowArgs) GetQueryRequest() *QueryWorkflowRequest {
+  if !p.IsSetQueryRequest() {
+    return HistoryServiceQueryWorkflowArgs_QueryRequest_DEFAULT
   }
-return p.AddRequest
+return p.QueryRequest
 }
-func (p *HistoryServiceRecordDecisionTaskStartedArgs) IsSetAddRequest() bool {
-  return p.AddRequest != nil
+func (p *HistoryServiceQueryWorkflowArgs) IsSetQueryRequest() bool {
+  return p.QueryRequest != nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceQueryWorkflowArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6426,16 +14112,16 @@ func (p *HistoryServiceRecordDecisionTaskStartedArgs) Read(iprot thrift.TProtoco
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.AddRequest = &RecordDecisionTaskStartedRequest{}
-  if err := p.AddRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.AddRequest), err)
+func (p *HistoryServiceQueryWorkflowArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.QueryRequest = &QueryWorkflowRequest{}
+  if err := p.QueryRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.QueryRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordDecisionTaskStarted_args"); err != nil {
+func (p *HistoryServiceQueryWorkflowArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("QueryWorkflow_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -6447,111 +14133,111 @@ func (p *HistoryServiceRecordDecisionTaskStartedArgs) Write(oprot thrift.TProtoc
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("addRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:addRequest: ", p), err) }
-  if err := p.AddRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.AddRequest), err)
+func (p *HistoryServiceQueryWorkflowArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("queryRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queryRequest: ", p), err) }
+  if err := p.QueryRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.QueryRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:addRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queryRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedArgs) String() string {
+func (p *HistoryServiceQueryWorkflowArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRecordDecisionTaskStartedArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceQueryWorkflowArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - BadRequestError
 //  - InternalServiceError
-//  - EventAlreadyStartedError
 //  - EntityNotExistError
+//  - QueryFailedError
 //  - ShardOwnershipLostError
-type HistoryServiceRecordDecisionTaskStartedResult struct {
-  Success *RecordDecisionTaskStartedResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type HistoryServiceQueryWorkflowResult struct {
+  Success *shared.QueryWorkflowResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  EventAlreadyStartedError *EventAlreadyStartedError `thrift:"eventAlreadyStartedError,3" db:"eventAlreadyStartedError" json:"eventAlreadyStartedError,omitempty"`
-  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,4" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  QueryFailedError *shared.QueryFailedError `thrift:"queryFailedError,4" db:"queryFailedError" json:"queryFailedError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,5" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceRecordDecisionTaskStartedResult() *HistoryServiceRecordDecisionTaskStartedResult {
-  return &HistoryServiceRecordDecisionTaskStartedResult{}
+func NewHistoryServiceQueryWorkflowResult() *HistoryServiceQueryWorkflowResult {
+  return &HistoryServiceQueryWorkflowResult{}
 }
 
-var HistoryServiceRecordDecisionTaskStartedResult_Success_DEFAULT *RecordDecisionTaskStartedResponse
-func (p *HistoryServiceRecordDecisionTaskStartedResult) GetSuccess() *RecordDecisionTaskStartedResponse {
+var HistoryServiceQueryWorkflowResult_Success_DEFAULT *shared.QueryWorkflowResponse
+func (p *HistoryServiceQueryWorkflowResult) GetSuccess() *shared.QueryWorkflowResponse {
   if !p.IsSetSuccess() {
-    return HistoryServiceRecordDecisionTaskStartedResult_Success_DEFAULT
+    return HistoryServiceQueryWorkflowResult_Success_DEFAULT
   }
 return p.Success
 }
-var HistoryServiceRecordDecisionTaskStartedResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceRecordDecisionTaskStartedResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceQueryWorkflowResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceQueryWorkflowResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceRecordDecisionTaskStartedResult_BadRequestError_DEFAULT
+    return HistoryServiceQueryWorkflowResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceRecordDecisionTaskStartedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceRecordDecisionTaskStartedResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceQueryWorkflowResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceQueryWorkflowResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceRecordDecisionTaskStartedResult_InternalServiceError_DEFAULT
+    return HistoryServiceQueryWorkflowResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceRecordDecisionTaskStartedResult_EventAlreadyStartedError_DEFAULT *EventAlreadyStartedError
-func (p *HistoryServiceRecordDecisionTaskStartedResult) GetEventAlreadyStartedError() *EventAlreadyStartedError {
-  if !p.IsSetEventAlreadyStartedError() {
-    return HistoryServiceRecordDecisionTaskStartedResult_EventAlreadyStartedError_DEFAULT
-  }
-return p.EventAlreadyStartedError
-}
-var HistoryServiceRecordDecisionTaskStartedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceRecordDecisionTaskStartedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceQueryWorkflowResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceQueryWorkflowResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceRecordDecisionTaskStartedResult_EntityNotExistError_DEFAULT
+    return HistoryServiceQueryWorkflowResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceRecordDecisionTaskStartedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceRecordDecisionTaskStartedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceQueryWorkflowResult_QueryFailedError_DEFAULT *shared.QueryFailedError
+func (p *HistoryServiceQueryWorkflowResult) GetQueryFailedError() *shared.QueryFailedError {
+  if !p.IsSetQueryFailedError() {
+    return HistoryServiceQueryWorkflowResult_QueryFailedError_DEFAULT
+  }
+return p.QueryFailedError
+}
+var HistoryServiceQueryWorkflowResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceQueryWorkflowResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceRecordDecisionTaskStartedResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceQueryWorkflowResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetSuccess() bool {
+func (p *HistoryServiceQueryWorkflowResult) IsSetSuccess() bool {
   return p.Success != nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceQueryWorkflowResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceQueryWorkflowResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetEventAlreadyStartedError() bool {
-  return p.EventAlreadyStartedError != nil
+func (p *HistoryServiceQueryWorkflowResult) IsSetEntityNotExistError() bool {
+  return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetEntityNotExistError() bool {
-  return p.EntityNotExistError != nil
+func (p *HistoryServiceQueryWorkflowResult) IsSetQueryFailedError() bool {
+  return p.QueryFailedError != nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceQueryWorkflowResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceQueryWorkflowResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6603,15 +14289,15 @@ func (p *HistoryServiceRecordDecisionTaskStartedResult) Read(iprot thrift.TProto
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &RecordDecisionTaskStartedResponse{}
+func (p *HistoryServiceQueryWorkflowResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.QueryWorkflowResponse{}
   if err := p.Success.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceQueryWorkflowResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -6619,7 +14305,7 @@ func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField1(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceQueryWorkflowResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -6627,23 +14313,23 @@ func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField2(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.EventAlreadyStartedError = &EventAlreadyStartedError{}
-  if err := p.EventAlreadyStartedError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EventAlreadyStartedError), err)
+func (p *HistoryServiceQueryWorkflowResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.EntityNotExistError = &shared.EntityNotExistsError{}
+  if err := p.EntityNotExistError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField4(iprot thrift.TProtocol) error {
-  p.EntityNotExistError = &shared.EntityNotExistsError{}
-  if err := p.EntityNotExistError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+func (p *HistoryServiceQueryWorkflowResult)  ReadField4(iprot thrift.TProtocol) error {
+  p.QueryFailedError = &shared.QueryFailedError{}
+  if err := p.QueryFailedError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.QueryFailedError), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField5(iprot thrift.TProtocol) error {
+func (p *HistoryServiceQueryWorkflowResult)  ReadField5(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -6651,8 +14337,8 @@ func (p *HistoryServiceRecordDecisionTaskStartedResult)  ReadField5(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordDecisionTaskStarted_result"); err != nil {
+func (p *HistoryServiceQueryWorkflowResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("QueryWorkflow_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField0(oprot); err != nil { return err }
@@ -6669,7 +14355,7 @@ func (p *HistoryServiceRecordDecisionTaskStartedResult) Write(oprot thrift.TProt
   return nil
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceQueryWorkflowResult) writeField0(oprot thrift.TProtocol) (err error) {
   if p.IsSetSuccess() {
     if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
@@ -6682,7 +14368,7 @@ func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField0(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceQueryWorkflowResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -6695,7 +14381,7 @@ func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField1(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceQueryWorkflowResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -6708,33 +14394,33 @@ func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField2(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetEventAlreadyStartedError() {
-    if err := oprot.WriteFieldBegin("eventAlreadyStartedError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:eventAlreadyStartedError: ", p), err) }
-    if err := p.EventAlreadyStartedError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EventAlreadyStartedError), err)
+func (p *HistoryServiceQueryWorkflowResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetEntityNotExistError() {
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
+    if err := p.EntityNotExistError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:eventAlreadyStartedError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
   return err
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField4(oprot thrift.TProtocol) (err error) {
-  if p.IsSetEntityNotExistError() {
-    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 4); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:entityNotExistError: ", p), err) }
-    if err := p.EntityNotExistError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
+func (p *HistoryServiceQueryWorkflowResult) writeField4(oprot thrift.TProtocol) (err error) {
+  if p.IsSetQueryFailedError() {
+    if err := oprot.WriteFieldBegin("queryFailedError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:queryFailedError: ", p), err) }
+    if err := p.QueryFailedError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.QueryFailedError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:entityNotExistError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:queryFailedError: ", p), err) }
   }
   return err
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField5(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceQueryWorkflowResult) writeField5(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 5); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:shardOwnershipLostError: ", p), err) }
@@ -6747,35 +14433,35 @@ func (p *HistoryServiceRecordDecisionTaskStartedResult) writeField5(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRecordDecisionTaskStartedResult) String() string {
+func (p *HistoryServiceQueryWorkflowResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRecordDecisionTaskStartedResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceQueryWorkflowResult(%+v)", *p)
 }
 
 // Attributes:
-//  - AddRequest
-type HistoryServiceRecordActivityTaskStartedArgs struct {
-  AddRequest *RecordActivityTaskStartedRequest `thrift:"addRequest,1" db:"addRequest" json:"addRequest"`
+//  - DescribeRequest
+type HistoryServiceDescribeWorkflowExecutionArgs struct {
+  DescribeRequest *DescribeWorkflowExecutionRequest `thrift:"describeRequest,1" db:"describeRequest" json:"describeRequest"`
 }
 
-func NewHistoryServiceRecordActivityTaskStartedArgs() *HistoryServiceRecordActivityTaskStartedArgs {
-  return &HistoryServiceRecordActivityTaskStartedArgs{}
+func NewHistoryServiceDescribeWorkflowExecutionArgs() *HistoryServiceDescribeWorkflowExecutionArgs {
+  return &HistoryServiceDescribeWorkflowExecutionArgs{}
 }
 
-var HistoryServiceRecordActivityTaskStartedArgs_AddRequest_DEFAULT *RecordActivityTaskStartedRequest
-func (p *HistoryServiceRecordActivityTaskStartedArgs) GetAddRequest() *RecordActivityTaskStartedRequest {
-  if !p.IsSetAddRequest() {
-    return HistoryServiceRecordActivityTaskStartedArgs_AddRequest_DEFAULT
+var HistoryServiceDescribeWorkflowExecutionArgs_DescribeRequest_DEFAULT *DescribeWorkflowExecutionRequest
+func (p *HistoryServiceDescribeWorkflowExecutionArgs) GetDescribeRequest() *DescribeWorkflowExecutionRequest {
+  if !p.IsSetDescribeRequest() {
+    return HistoryServiceDescribeWorkflowExecutionArgs_DescribeRequest_DEFAULT
   }
-return p.AddRequest
+return p.DescribeRequest
 }
-func (p *HistoryServiceRecordActivityTaskStartedArgs) IsSetAddRequest() bool {
-  return p.AddRequest != nil
+func (p *HistoryServiceDescribeWorkflowExecutionArgs) IsSetDescribeRequest() bool {
+  return p.DescribeRequest != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6807,16 +14493,16 @@ func (p *HistoryServiceRecordActivityTaskStartedArgs) Read(iprot thrift.TProtoco
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.AddRequest = &RecordActivityTaskStartedRequest{}
-  if err := p.AddRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.AddRequest), err)
+func (p *HistoryServiceDescribeWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.DescribeRequest = &DescribeWorkflowExecutionRequest{}
+  if err := p.DescribeRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.DescribeRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordActivityTaskStarted_args"); err != nil {
+func (p *HistoryServiceDescribeWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -6828,111 +14514,98 @@ func (p *HistoryServiceRecordActivityTaskStartedArgs) Write(oprot thrift.TProtoc
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("addRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:addRequest: ", p), err) }
-  if err := p.AddRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.AddRequest), err)
+func (p *HistoryServiceDescribeWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("describeRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:describeRequest: ", p), err) }
+  if err := p.DescribeRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.DescribeRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:addRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:describeRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedArgs) String() string {
+func (p *HistoryServiceDescribeWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRecordActivityTaskStartedArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceDescribeWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - BadRequestError
 //  - InternalServiceError
-//  - EventAlreadyStartedError
 //  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceRecordActivityTaskStartedResult struct {
-  Success *RecordActivityTaskStartedResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type HistoryServiceDescribeWorkflowExecutionResult struct {
+  Success *shared.DescribeWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  EventAlreadyStartedError *EventAlreadyStartedError `thrift:"eventAlreadyStartedError,3" db:"eventAlreadyStartedError" json:"eventAlreadyStartedError,omitempty"`
-  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,4" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
-  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,5" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceRecordActivityTaskStartedResult() *HistoryServiceRecordActivityTaskStartedResult {
-  return &HistoryServiceRecordActivityTaskStartedResult{}
+func NewHistoryServiceDescribeWorkflowExecutionResult() *HistoryServiceDescribeWorkflowExecutionResult {
+  return &HistoryServiceDescribeWorkflowExecutionResult{}
 }
 
-var HistoryServiceRecordActivityTaskStartedResult_Success_DEFAULT *RecordActivityTaskStartedResponse
-func (p *HistoryServiceRecordActivityTaskStartedResult) GetSuccess() *RecordActivityTaskStartedResponse {
+var HistoryServiceDescribeWorkflowExecutionResult_Success_DEFAULT *shared.DescribeWorkflowExecutionResponse
+func (p *HistoryServiceDescribeWorkflowExecutionResult) GetSuccess() *shared.DescribeWorkflowExecutionResponse {
   if !p.IsSetSuccess() {
-    return HistoryServiceRecordActivityTaskStartedResult_Success_DEFAULT
+    return HistoryServiceDescribeWorkflowExecutionResult_Success_DEFAULT
   }
 return p.Success
 }
-var HistoryServiceRecordActivityTaskStartedResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceRecordActivityTaskStartedResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceDescribeWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceDescribeWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceRecordActivityTaskStartedResult_BadRequestError_DEFAULT
+    return HistoryServiceDescribeWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceRecordActivityTaskStartedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceRecordActivityTaskStartedResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceDescribeWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceDescribeWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceRecordActivityTaskStartedResult_InternalServiceError_DEFAULT
+    return HistoryServiceDescribeWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceRecordActivityTaskStartedResult_EventAlreadyStartedError_DEFAULT *EventAlreadyStartedError
-func (p *HistoryServiceRecordActivityTaskStartedResult) GetEventAlreadyStartedError() *EventAlreadyStartedError {
-  if !p.IsSetEventAlreadyStartedError() {
-    return HistoryServiceRecordActivityTaskStartedResult_EventAlreadyStartedError_DEFAULT
-  }
-return p.EventAlreadyStartedError
-}
-var HistoryServiceRecordActivityTaskStartedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceRecordActivityTaskStartedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceDescribeWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceDescribeWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceRecordActivityTaskStartedResult_EntityNotExistError_DEFAULT
+    return HistoryServiceDescribeWorkflowExecutionResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceRecordActivityTaskStartedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceRecordActivityTaskStartedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceDescribeWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceDescribeWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceRecordActivityTaskStartedResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceDescribeWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetSuccess() bool {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) IsSetSuccess() bool {
   return p.Success != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetEventAlreadyStartedError() bool {
-  return p.EventAlreadyStartedError != nil
-}
-
-func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetEntityNotExistError() bool {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -6965,10 +14638,6 @@ func (p *HistoryServiceRecordActivityTaskStartedResult) Read(iprot thrift.TProto
       if err := p.ReadField4(iprot); err != nil {
         return err
       }
-    case 5:
-      if err := p.ReadField5(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -6984,15 +14653,15 @@ func (p *HistoryServiceRecordActivityTaskStartedResult) Read(iprot thrift.TProto
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &RecordActivityTaskStartedResponse{}
+func (p *HistoryServiceDescribeWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.DescribeWorkflowExecutionResponse{}
   if err := p.Success.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -7000,7 +14669,7 @@ func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField1(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -7008,15 +14677,7 @@ func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField2(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.EventAlreadyStartedError = &EventAlreadyStartedError{}
-  if err := p.EventAlreadyStartedError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EventAlreadyStartedError), err)
-  }
-  return nil
-}
-
-func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -7024,7 +14685,7 @@ func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField4(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField5(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -7032,8 +14693,8 @@ func (p *HistoryServiceRecordActivityTaskStartedResult)  ReadField5(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordActivityTaskStarted_result"); err != nil {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField0(oprot); err != nil { return err }
@@ -7041,7 +14702,6 @@ func (p *HistoryServiceRecordActivityTaskStartedResult) Write(oprot thrift.TProt
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
     if err := p.writeField4(oprot); err != nil { return err }
-    if err := p.writeField5(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -7050,7 +14710,7 @@ func (p *HistoryServiceRecordActivityTaskStartedResult) Write(oprot thrift.TProt
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
   if p.IsSetSuccess() {
     if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
@@ -7063,7 +14723,7 @@ func (p *HistoryServiceRecordActivityTaskStartedResult) writeField0(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -7076,7 +14736,7 @@ func (p *HistoryServiceRecordActivityTaskStartedResult) writeField1(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -7089,74 +14749,61 @@ func (p *HistoryServiceRecordActivityTaskStartedResult) writeField2(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetEventAlreadyStartedError() {
-    if err := oprot.WriteFieldBegin("eventAlreadyStartedError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:eventAlreadyStartedError: ", p), err) }
-    if err := p.EventAlreadyStartedError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EventAlreadyStartedError), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:eventAlreadyStartedError: ", p), err) }
-  }
-  return err
-}
-
-func (p *HistoryServiceRecordActivityTaskStartedResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
-    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 4); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:entityNotExistError: ", p), err) }
+    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
     if err := p.EntityNotExistError.Write(oprot); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:entityNotExistError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
   }
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) writeField5(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
-    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 5); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:shardOwnershipLostError: ", p), err) }
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
     if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 5:shardOwnershipLostError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
   }
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskStartedResult) String() string {
+func (p *HistoryServiceDescribeWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRecordActivityTaskStartedResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceDescribeWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - CompleteRequest
-type HistoryServiceRespondDecisionTaskCompletedArgs struct {
-  CompleteRequest *RespondDecisionTaskCompletedRequest `thrift:"completeRequest,1" db:"completeRequest" json:"completeRequest"`
+//  - TerminateRequest
+type HistoryServiceTerminateWorkflowExecutionArgs struct {
+  TerminateRequest *TerminateWorkflowExecutionRequest `thrift:"terminateRequest,1" db:"terminateRequest" json:"terminateRequest"`
 }
 
-func NewHistoryServiceRespondDecisionTaskCompletedArgs() *HistoryServiceRespondDecisionTaskCompletedArgs {
-  return &HistoryServiceRespondDecisionTaskCompletedArgs{}
+func NewHistoryServiceTerminateWorkflowExecutionArgs() *HistoryServiceTerminateWorkflowExecutionArgs {
+  return &HistoryServiceTerminateWorkflowExecutionArgs{}
 }
 
-var HistoryServiceRespondDecisionTaskCompletedArgs_CompleteRequest_DEFAULT *RespondDecisionTaskCompletedRequest
-func (p *HistoryServiceRespondDecisionTaskCompletedArgs) GetCompleteRequest() *RespondDecisionTaskCompletedRequest {
-  if !p.IsSetCompleteRequest() {
-    return HistoryServiceRespondDecisionTaskCompletedArgs_CompleteRequest_DEFAULT
+var HistoryServiceTerminateWorkflowExecutionArgs_TerminateRequest_DEFAULT *TerminateWorkflowExecutionRequest
+func (p *HistoryServiceTerminateWorkflowExecutionArgs) GetTerminateRequest() *TerminateWorkflowExecutionRequest {
+  if !p.IsSetTerminateRequest() {
+    return HistoryServiceTerminateWorkflowExecutionArgs_TerminateRequest_DEFAULT
   }
-return p.CompleteRequest
+return p.TerminateRequest
 }
-func (p *HistoryServiceRespondDecisionTaskCompletedArgs) IsSetCompleteRequest() bool {
-  return p.CompleteRequest != nil
+func (p *HistoryServiceTerminateWorkflowExecutionArgs) IsSetTerminateRequest() bool {
+  return p.TerminateRequest != nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceTerminateWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7188,16 +14835,16 @@ func (p *HistoryServiceRespondDecisionTaskCompletedArgs) Read(iprot thrift.TProt
   return nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.CompleteRequest = &RespondDecisionTaskCompletedRequest{}
-  if err := p.CompleteRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
+func (p *HistoryServiceTerminateWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.TerminateRequest = &TerminateWorkflowExecutionRequest{}
+  if err := p.TerminateRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.TerminateRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondDecisionTaskCompleted_args"); err != nil {
+func (p *HistoryServiceTerminateWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("TerminateWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -7209,85 +14856,98 @@ func (p *HistoryServiceRespondDecisionTaskCompletedArgs) Write(oprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completeRequest: ", p), err) }
-  if err := p.CompleteRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
+func (p *HistoryServiceTerminateWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("terminateRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:terminateRequest: ", p), err) }
+  if err := p.TerminateRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.TerminateRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completeRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:terminateRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedArgs) String() string {
+func (p *HistoryServiceTerminateWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRespondDecisionTaskCompletedArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceTerminateWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceRespondDecisionTaskCompletedResult struct {
+type HistoryServiceTerminateWorkflowExecutionResult struct {
+  Success *shared.TerminateWorkflowExecutionResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceRespondDecisionTaskCompletedResult() *HistoryServiceRespondDecisionTaskCompletedResult {
-  return &HistoryServiceRespondDecisionTaskCompletedResult{}
+func NewHistoryServiceTerminateWorkflowExecutionResult() *HistoryServiceTerminateWorkflowExecutionResult {
+  return &HistoryServiceTerminateWorkflowExecutionResult{}
 }
 
-var HistoryServiceRespondDecisionTaskCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceTerminateWorkflowExecutionResult_Success_DEFAULT *shared.TerminateWorkflowExecutionResponse
+func (p *HistoryServiceTerminateWorkflowExecutionResult) GetSuccess() *shared.TerminateWorkflowExecutionResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceTerminateWorkflowExecutionResult_Success_DEFAULT
+  }
+return p.Success
+}
+var HistoryServiceTerminateWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceTerminateWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceRespondDecisionTaskCompletedResult_BadRequestError_DEFAULT
+    return HistoryServiceTerminateWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceRespondDecisionTaskCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceTerminateWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceTerminateWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceRespondDecisionTaskCompletedResult_InternalServiceError_DEFAULT
+    return HistoryServiceTerminateWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceRespondDecisionTaskCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceTerminateWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceTerminateWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceRespondDecisionTaskCompletedResult_EntityNotExistError_DEFAULT
+    return HistoryServiceTerminateWorkflowExecutionResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceRespondDecisionTaskCompletedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceTerminateWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceTerminateWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceRespondDecisionTaskCompletedResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceTerminateWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *HistoryServiceTerminateWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) IsSetEntityNotExistError() bool {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7300,6 +14960,10 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult) Read(iprot thrift.TPr
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -7331,7 +14995,15 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult) Read(iprot thrift.TPr
   return nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &shared.TerminateWorkflowExecutionResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -7339,7 +15011,7 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField1(iprot thr
   return nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -7347,7 +15019,7 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField2(iprot thr
   return nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -7355,7 +15027,7 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField3(iprot thr
   return nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -7363,10 +15035,11 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult)  ReadField4(iprot thr
   return nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondDecisionTaskCompleted_result"); err != nil {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("TerminateWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -7379,7 +15052,20 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult) Write(oprot thrift.TP
   return nil
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -7392,7 +15078,7 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField1(oprot thr
   return err
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -7405,7 +15091,7 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField2(oprot thr
   return err
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -7418,7 +15104,7 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField3(oprot thr
   return err
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
@@ -7431,35 +15117,35 @@ func (p *HistoryServiceRespondDecisionTaskCompletedResult) writeField4(oprot thr
   return err
 }
 
-func (p *HistoryServiceRespondDecisionTaskCompletedResult) String() string {
+func (p *HistoryServiceTerminateWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRespondDecisionTaskCompletedResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceTerminateWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - HeartbeatRequest
-type HistoryServiceRecordActivityTaskHeartbeatArgs struct {
-  HeartbeatRequest *RecordActivityTaskHeartbeatRequest `thrift:"heartbeatRequest,1" db:"heartbeatRequest" json:"heartbeatRequest"`
+//  - CancelRequest
+type HistoryServiceRequestCancelWorkflowExecutionArgs struct {
+  CancelRequest *RequestCancelWorkflowExecutionRequest `thrift:"cancelRequest,1" db:"cancelRequest" json:"cancelRequest"`
 }
 
-func NewHistoryServiceRecordActivityTaskHeartbeatArgs() *HistoryServiceRecordActivityTaskHeartbeatArgs {
-  return &HistoryServiceRecordActivityTaskHeartbeatArgs{}
+func NewHistoryServiceRequestCancelWorkflowExecutionArgs() *HistoryServiceRequestCancelWorkflowExecutionArgs {
+  return &HistoryServiceRequestCancelWorkflowExecutionArgs{}
 }
 
-var HistoryServiceRecordActivityTaskHeartbeatArgs_HeartbeatRequest_DEFAULT *RecordActivityTaskHeartbeatRequest
-func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) GetHeartbeatRequest() *RecordActivityTaskHeartbeatRequest {
-  if !p.IsSetHeartbeatRequest() {
-    return HistoryServiceRecordActivityTaskHeartbeatArgs_HeartbeatRequest_DEFAULT
+var HistoryServiceRequestCancelWorkflowExecutionArgs_CancelRequest_DEFAULT *RequestCancelWorkflowExecutionRequest
+func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) GetCancelRequest() *RequestCancelWorkflowExecutionRequest {
+  if !p.IsSetCancelRequest() {
+    return HistoryServiceRequestCancelWorkflowExecutionArgs_CancelRequest_DEFAULT
   }
-return p.HeartbeatRequest
+return p.CancelRequest
 }
-func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) IsSetHeartbeatRequest() bool {
-  return p.HeartbeatRequest != nil
+func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) IsSetCancelRequest() bool {
+  return p.CancelRequest != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7491,16 +15177,16 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) Read(iprot thrift.TProto
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.HeartbeatRequest = &RecordActivityTaskHeartbeatRequest{}
-  if err := p.HeartbeatRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.HeartbeatRequest), err)
+func (p *HistoryServiceRequestCancelWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CancelRequest = &RequestCancelWorkflowExecutionRequest{}
+  if err := p.CancelRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CancelRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeat_args"); err != nil {
+func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -7512,98 +15198,85 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) Write(oprot thrift.TProt
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("heartbeatRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:heartbeatRequest: ", p), err) }
-  if err := p.HeartbeatRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.HeartbeatRequest), err)
+func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("cancelRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:cancelRequest: ", p), err) }
+  if err := p.CancelRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CancelRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:heartbeatRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:cancelRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatArgs) String() string {
+func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRecordActivityTaskHeartbeatArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceRequestCancelWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceRecordActivityTaskHeartbeatResult struct {
-  Success *shared.RecordActivityTaskHeartbeatResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
+type HistoryServiceRequestCancelWorkflowExecutionResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceRecordActivityTaskHeartbeatResult() *HistoryServiceRecordActivityTaskHeartbeatResult {
-  return &HistoryServiceRecordActivityTaskHeartbeatResult{}
+func NewHistoryServiceRequestCancelWorkflowExecutionResult() *HistoryServiceRequestCancelWorkflowExecutionResult {
+  return &HistoryServiceRequestCancelWorkflowExecutionResult{}
 }
 
-var HistoryServiceRecordActivityTaskHeartbeatResult_Success_DEFAULT *shared.RecordActivityTaskHeartbeatResponse
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetSuccess() *shared.RecordActivityTaskHeartbeatResponse {
-  if !p.IsSetSuccess() {
-    return HistoryServiceRecordActivityTaskHeartbeatResult_Success_DEFAULT
-  }
-return p.Success
-}
-var HistoryServiceRecordActivityTaskHeartbeatResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceRequestCancelWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceRecordActivityTaskHeartbeatResult_BadRequestError_DEFAULT
+    return HistoryServiceRequestCancelWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceRecordActivityTaskHeartbeatResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceRequestCancelWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceRecordActivityTaskHeartbeatResult_InternalServiceError_DEFAULT
+    return HistoryServiceRequestCancelWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceRecordActivityTaskHeartbeatResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceRequestCancelWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceRecordActivityTaskHeartbeatResult_EntityNotExistError_DEFAULT
+    return HistoryServiceRequestCancelWorkflowExecutionResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceRecordActivityTaskHeartbeatResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceRequestCancelWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceRecordActivityTaskHeartbeatResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceRequestCancelWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetSuccess() bool {
-  return p.Success != nil
-}
-
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetEntityNotExistError() bool {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7616,10 +15289,6 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult) Read(iprot thrift.TPro
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
-    case 0:
-      if err := p.ReadField0(iprot); err != nil {
-        return err
-      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -7651,15 +15320,7 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult) Read(iprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField0(iprot thrift.TProtocol) error {
-  p.Success = &shared.RecordActivityTaskHeartbeatResponse{}
-  if err := p.Success.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-  }
-  return nil
-}
-
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -7667,7 +15328,7 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField1(iprot thri
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -7675,7 +15336,7 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField2(iprot thri
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -7683,7 +15344,7 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField3(iprot thri
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -7691,11 +15352,10 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult)  ReadField4(iprot thri
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordActivityTaskHeartbeat_result"); err != nil {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
-    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -7708,20 +15368,7 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult) Write(oprot thrift.TPr
   return nil
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField0(oprot thrift.TProtocol) (err error) {
-  if p.IsSetSuccess() {
-    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
-    if err := p.Success.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
-  }
-  return err
-}
-
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -7734,7 +15381,7 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField1(oprot thri
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -7747,7 +15394,7 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField2(oprot thri
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -7760,7 +15407,7 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField3(oprot thri
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
@@ -7773,35 +15420,35 @@ func (p *HistoryServiceRecordActivityTaskHeartbeatResult) writeField4(oprot thri
   return err
 }
 
-func (p *HistoryServiceRecordActivityTaskHeartbeatResult) String() string {
+func (p *HistoryServiceRequestCancelWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRecordActivityTaskHeartbeatResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceRequestCancelWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - CompleteRequest
-type HistoryServiceRespondActivityTaskCompletedArgs struct {
-  CompleteRequest *RespondActivityTaskCompletedRequest `thrift:"completeRequest,1" db:"completeRequest" json:"completeRequest"`
+//  - ScheduleRequest
+type HistoryServiceScheduleDecisionTaskArgs struct {
+  ScheduleRequest *ScheduleDecisionTaskRequest `thrift:"scheduleRequest,1" db:"scheduleRequest" json:"scheduleRequest"`
 }
 
-func NewHistoryServiceRespondActivityTaskCompletedArgs() *HistoryServiceRespondActivityTaskCompletedArgs {
-  return &HistoryServiceRespondActivityTaskCompletedArgs{}
+func NewHistoryServiceScheduleDecisionTaskArgs() *HistoryServiceScheduleDecisionTaskArgs {
+  return &HistoryServiceScheduleDecisionTaskArgs{}
 }
 
-var HistoryServiceRespondActivityTaskCompletedArgs_CompleteRequest_DEFAULT *RespondActivityTaskCompletedRequest
-func (p *HistoryServiceRespondActivityTaskCompletedArgs) GetCompleteRequest() *RespondActivityTaskCompletedRequest {
-  if !p.IsSetCompleteRequest() {
-    return HistoryServiceRespondActivityTaskCompletedArgs_CompleteRequest_DEFAULT
+var HistoryServiceScheduleDecisionTaskArgs_ScheduleRequest_DEFAULT *ScheduleDecisionTaskRequest
+func (p *HistoryServiceScheduleDecisionTaskArgs) GetScheduleRequest() *ScheduleDecisionTaskRequest {
+  if !p.IsSetScheduleRequest() {
+    return HistoryServiceScheduleDecisionTaskArgs_ScheduleRequest_DEFAULT
   }
-return p.CompleteRequest
+return p.ScheduleRequest
 }
-func (p *HistoryServiceRespondActivityTaskCompletedArgs) IsSetCompleteRequest() bool {
-  return p.CompleteRequest != nil
+func (p *HistoryServiceScheduleDecisionTaskArgs) IsSetScheduleRequest() bool {
+  return p.ScheduleRequest != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceScheduleDecisionTaskArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7833,16 +15480,16 @@ func (p *HistoryServiceRespondActivityTaskCompletedArgs) Read(iprot thrift.TProt
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.CompleteRequest = &RespondActivityTaskCompletedRequest{}
-  if err := p.CompleteRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompleteRequest), err)
+func (p *HistoryServiceScheduleDecisionTaskArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.ScheduleRequest = &ScheduleDecisionTaskRequest{}
+  if err := p.ScheduleRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ScheduleRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCompleted_args"); err != nil {
+func (p *HistoryServiceScheduleDecisionTaskArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ScheduleDecisionTask_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -7854,22 +15501,22 @@ func (p *HistoryServiceRespondActivityTaskCompletedArgs) Write(oprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("completeRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completeRequest: ", p), err) }
-  if err := p.CompleteRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompleteRequest), err)
+func (p *HistoryServiceScheduleDecisionTaskArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("scheduleRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:scheduleRequest: ", p), err) }
+  if err := p.ScheduleRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ScheduleRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completeRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:scheduleRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedArgs) String() string {
+func (p *HistoryServiceScheduleDecisionTaskArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRespondActivityTaskCompletedArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceScheduleDecisionTaskArgs(%+v)", *p)
 }
 
 // Attributes:
@@ -7877,62 +15524,62 @@ func (p *HistoryServiceRespondActivityTaskCompletedArgs) String() string {
 //  - InternalServiceError
 //  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceRespondActivityTaskCompletedResult struct {
+type HistoryServiceScheduleDecisionTaskResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
-
-func NewHistoryServiceRespondActivityTaskCompletedResult() *HistoryServiceRespondActivityTaskCompletedResult {
-  return &HistoryServiceRespondActivityTaskCompletedResult{}
+
+func NewHistoryServiceScheduleDecisionTaskResult() *HistoryServiceScheduleDecisionTaskResult {
+  return &HistoryServiceScheduleDecisionTaskResult{}
 }
 
-var HistoryServiceRespondActivityTaskCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceRespondActivityTaskCompletedResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceScheduleDecisionTaskResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceScheduleDecisionTaskResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceRespondActivityTaskCompletedResult_BadRequestError_DEFAULT
+    return HistoryServiceScheduleDecisionTaskResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceRespondActivityTaskCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceRespondActivityTaskCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceScheduleDecisionTaskResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceScheduleDecisionTaskResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceRespondActivityTaskCompletedResult_InternalServiceError_DEFAULT
+    return HistoryServiceScheduleDecisionTaskResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceRespondActivityTaskCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceRespondActivityTaskCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceScheduleDecisionTaskResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceScheduleDecisionTaskResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceRespondActivityTaskCompletedResult_EntityNotExistError_DEFAULT
+    return HistoryServiceScheduleDecisionTaskResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceRespondActivityTaskCompletedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceRespondActivityTaskCompletedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceScheduleDecisionTaskResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceScheduleDecisionTaskResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceRespondActivityTaskCompletedResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceScheduleDecisionTaskResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceRespondActivityTaskCompletedResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceScheduleDecisionTaskResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceScheduleDecisionTaskResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) IsSetEntityNotExistError() bool {
+func (p *HistoryServiceScheduleDecisionTaskResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceScheduleDecisionTaskResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceScheduleDecisionTaskResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -7976,7 +15623,7 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult) Read(iprot thrift.TPr
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -7984,7 +15631,7 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField1(iprot thr
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -7992,7 +15639,7 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField2(iprot thr
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -8000,7 +15647,7 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField3(iprot thr
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -8008,8 +15655,8 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult)  ReadField4(iprot thr
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCompleted_result"); err != nil {
+func (p *HistoryServiceScheduleDecisionTaskResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ScheduleDecisionTask_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -8024,7 +15671,7 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult) Write(oprot thrift.TP
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceScheduleDecisionTaskResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -8037,7 +15684,7 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField1(oprot thr
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceScheduleDecisionTaskResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -8050,7 +15697,7 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField2(oprot thr
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceScheduleDecisionTaskResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -8063,7 +15710,7 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField3(oprot thr
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceScheduleDecisionTaskResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
@@ -8076,35 +15723,35 @@ func (p *HistoryServiceRespondActivityTaskCompletedResult) writeField4(oprot thr
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskCompletedResult) String() string {
+func (p *HistoryServiceScheduleDecisionTaskResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRespondActivityTaskCompletedResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceScheduleDecisionTaskResult(%+v)", *p)
 }
 
 // Attributes:
-//  - FailRequest
-type HistoryServiceRespondActivityTaskFailedArgs struct {
-  FailRequest *RespondActivityTaskFailedRequest `thrift:"failRequest,1" db:"failRequest" json:"failRequest"`
+//  - CompletionRequest
+type HistoryServiceRecordChildExecutionCompletedArgs struct {
+  CompletionRequest *RecordChildExecutionCompletedRequest `thrift:"completionRequest,1" db:"completionRequest" json:"completionRequest"`
 }
 
-func NewHistoryServiceRespondActivityTaskFailedArgs() *HistoryServiceRespondActivityTaskFailedArgs {
-  return &HistoryServiceRespondActivityTaskFailedArgs{}
+func NewHistoryServiceRecordChildExecutionCompletedArgs() *HistoryServiceRecordChildExecutionCompletedArgs {
+  return &HistoryServiceRecordChildExecutionCompletedArgs{}
 }
 
-var HistoryServiceRespondActivityTaskFailedArgs_FailRequest_DEFAULT *RespondActivityTaskFailedRequest
-func (p *HistoryServiceRespondActivityTaskFailedArgs) GetFailRequest() *RespondActivityTaskFailedRequest {
-  if !p.IsSetFailRequest() {
-    return HistoryServiceRespondActivityTaskFailedArgs_FailRequest_DEFAULT
+var HistoryServiceRecordChildExecutionCompletedArgs_CompletionRequest_DEFAULT *RecordChildExecutionCompletedRequest
+func (p *HistoryServiceRecordChildExecutionCompletedArgs) GetCompletionRequest() *RecordChildExecutionCompletedRequest {
+  if !p.IsSetCompletionRequest() {
+    return HistoryServiceRecordChildExecutionCompletedArgs_CompletionRequest_DEFAULT
   }
-return p.FailRequest
+return p.CompletionRequest
 }
-func (p *HistoryServiceRespondActivityTaskFailedArgs) IsSetFailRequest() bool {
-  return p.FailRequest != nil
+func (p *HistoryServiceRecordChildExecutionCompletedArgs) IsSetCompletionRequest() bool {
+  return p.CompletionRequest != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRecordChildExecutionCompletedArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -8136,16 +15783,16 @@ func (p *HistoryServiceRespondActivityTaskFailedArgs) Read(iprot thrift.TProtoco
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.FailRequest = &RespondActivityTaskFailedRequest{}
-  if err := p.FailRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.FailRequest), err)
+func (p *HistoryServiceRecordChildExecutionCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.CompletionRequest = &RecordChildExecutionCompletedRequest{}
+  if err := p.CompletionRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompletionRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskFailed_args"); err != nil {
+func (p *HistoryServiceRecordChildExecutionCompletedArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordChildExecutionCompleted_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -8157,22 +15804,22 @@ func (p *HistoryServiceRespondActivityTaskFailedArgs) Write(oprot thrift.TProtoc
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("failRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:failRequest: ", p), err) }
-  if err := p.FailRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.FailRequest), err)
+func (p *HistoryServiceRecordChildExecutionCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("completionRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completionRequest: ", p), err) }
+  if err := p.CompletionRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompletionRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:failRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completionRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedArgs) String() string {
+func (p *HistoryServiceRecordChildExecutionCompletedArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRespondActivityTaskFailedArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceRecordChildExecutionCompletedArgs(%+v)", *p)
 }
 
 // Attributes:
@@ -8180,62 +15827,62 @@ func (p *HistoryServiceRespondActivityTaskFailedArgs) String() string {
 //  - InternalServiceError
 //  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceRespondActivityTaskFailedResult struct {
+type HistoryServiceRecordChildExecutionCompletedResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceRespondActivityTaskFailedResult() *HistoryServiceRespondActivityTaskFailedResult {
-  return &HistoryServiceRespondActivityTaskFailedResult{}
+func NewHistoryServiceRecordChildExecutionCompletedResult() *HistoryServiceRecordChildExecutionCompletedResult {
+  return &HistoryServiceRecordChildExecutionCompletedResult{}
 }
 
-var HistoryServiceRespondActivityTaskFailedResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceRespondActivityTaskFailedResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceRecordChildExecutionCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRecordChildExecutionCompletedResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceRespondActivityTaskFailedResult_BadRequestError_DEFAULT
+    return HistoryServiceRecordChildExecutionCompletedResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceRespondActivityTaskFailedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceRespondActivityTaskFailedResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceRecordChildExecutionCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRecordChildExecutionCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceRespondActivityTaskFailedResult_InternalServiceError_DEFAULT
+    return HistoryServiceRecordChildExecutionCompletedResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceRespondActivityTaskFailedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceRespondActivityTaskFailedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceRecordChildExecutionCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRecordChildExecutionCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceRespondActivityTaskFailedResult_EntityNotExistError_DEFAULT
+    return HistoryServiceRecordChildExecutionCompletedResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceRespondActivityTaskFailedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceRespondActivityTaskFailedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceRecordChildExecutionCompletedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRecordChildExecutionCompletedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceRespondActivityTaskFailedResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceRecordChildExecutionCompletedResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceRespondActivityTaskFailedResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) IsSetEntityNotExistError() bool {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -8279,7 +15926,7 @@ func (p *HistoryServiceRespondActivityTaskFailedResult) Read(iprot thrift.TProto
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -8287,7 +15934,7 @@ func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField1(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -8295,7 +15942,7 @@ func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField2(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -8303,7 +15950,7 @@ func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField3(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -8311,8 +15958,8 @@ func (p *HistoryServiceRespondActivityTaskFailedResult)  ReadField4(iprot thrift
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskFailed_result"); err != nil {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RecordChildExecutionCompleted_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -8327,7 +15974,7 @@ func (p *HistoryServiceRespondActivityTaskFailedResult) Write(oprot thrift.TProt
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -8340,7 +15987,7 @@ func (p *HistoryServiceRespondActivityTaskFailedResult) writeField1(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -8353,7 +16000,7 @@ func (p *HistoryServiceRespondActivityTaskFailedResult) writeField2(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -8366,7 +16013,7 @@ func (p *HistoryServiceRespondActivityTaskFailedResult) writeField3(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
@@ -8379,35 +16026,35 @@ func (p *HistoryServiceRespondActivityTaskFailedResult) writeField4(oprot thrift
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskFailedResult) String() string {
+func (p *HistoryServiceRecordChildExecutionCompletedResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRespondActivityTaskFailedResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceRecordChildExecutionCompletedResult(%+v)", *p)
 }
 
 // Attributes:
-//  - CanceledRequest
-type HistoryServiceRespondActivityTaskCanceledArgs struct {
-  CanceledRequest *RespondActivityTaskCanceledRequest `thrift:"canceledRequest,1" db:"canceledRequest" json:"canceledRequest"`
+//  - UpdateRequest
+type HistoryServiceUpdateQueueProcessingArgs struct {
+  UpdateRequest *UpdateQueueProcessingRequest `thrift:"updateRequest,1" db:"updateRequest" json:"updateRequest"`
 }
 
-func NewHistoryServiceRespondActivityTaskCanceledArgs() *HistoryServiceRespondActivityTaskCanceledArgs {
-  return &HistoryServiceRespondActivityTaskCanceledArgs{}
+func NewHistoryServiceUpdateQueueProcessingArgs() *HistoryServiceUpdateQueueProcessingArgs {
+  return &HistoryServiceUpdateQueueProcessingArgs{}
 }
 
-var HistoryServiceRespondActivityTaskCanceledArgs_CanceledRequest_DEFAULT *RespondActivityTaskCanceledRequest
-func (p *HistoryServiceRespondActivityTaskCanceledArgs) GetCanceledRequest() *RespondActivityTaskCanceledRequest {
-  if !p.IsSetCanceledRequest() {
-    return HistoryServiceRespondActivityTaskCanceledArgs_CanceledRequest_DEFAULT
+var HistoryServiceUpdateQueueProcessingArgs_UpdateRequest_DEFAULT *UpdateQueueProcessingRequest
+func (p *HistoryServiceUpdateQueueProcessingArgs) GetUpdateRequest() *UpdateQueueProcessingRequest {
+  if !p.IsSetUpdateRequest() {
+    return HistoryServiceUpdateQueueProcessingArgs_UpdateRequest_DEFAULT
   }
-return p.CanceledRequest
+return p.UpdateRequest
 }
-func (p *HistoryServiceRespondActivityTaskCanceledArgs) IsSetCanceledRequest() bool {
-  return p.CanceledRequest != nil
+func (p *HistoryServiceUpdateQueueProcessingArgs) IsSetUpdateRequest() bool {
+  return p.UpdateRequest != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceUpdateQueueProcessingArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -8439,16 +16086,16 @@ func (p *HistoryServiceRespondActivityTaskCanceledArgs) Read(iprot thrift.TProto
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.CanceledRequest = &RespondActivityTaskCanceledRequest{}
-  if err := p.CanceledRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CanceledRequest), err)
+func (p *HistoryServiceUpdateQueueProcessingArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.UpdateRequest = &UpdateQueueProcessingRequest{}
+  if err := p.UpdateRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.UpdateRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCanceled_args"); err != nil {
+func (p *HistoryServiceUpdateQueueProcessingArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("UpdateQueueProcessing_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -8460,85 +16107,72 @@ func (p *HistoryServiceRespondActivityTaskCanceledArgs) Write(oprot thrift.TProt
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("canceledRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:canceledRequest: ", p), err) }
-  if err := p.CanceledRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CanceledRequest), err)
+func (p *HistoryServiceUpdateQueueProcessingArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("updateRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:updateRequest: ", p), err) }
+  if err := p.UpdateRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.UpdateRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:canceledRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:updateRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledArgs) String() string {
+func (p *HistoryServiceUpdateQueueProcessingArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRespondActivityTaskCanceledArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceUpdateQueueProcessingArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - BadRequestError
 //  - InternalServiceError
-//  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceRespondActivityTaskCanceledResult struct {
+type HistoryServiceUpdateQueueProcessingResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
-  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,3" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceRespondActivityTaskCanceledResult() *HistoryServiceRespondActivityTaskCanceledResult {
-  return &HistoryServiceRespondActivityTaskCanceledResult{}
+func NewHistoryServiceUpdateQueueProcessingResult() *HistoryServiceUpdateQueueProcessingResult {
+  return &HistoryServiceUpdateQueueProcessingResult{}
 }
 
-var HistoryServiceRespondActivityTaskCanceledResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceRespondActivityTaskCanceledResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceUpdateQueueProcessingResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceUpdateQueueProcessingResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceRespondActivityTaskCanceledResult_BadRequestError_DEFAULT
+    return HistoryServiceUpdateQueueProcessingResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceRespondActivityTaskCanceledResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceRespondActivityTaskCanceledResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceUpdateQueueProcessingResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceUpdateQueueProcessingResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceRespondActivityTaskCanceledResult_InternalServiceError_DEFAULT
+    return HistoryServiceUpdateQueueProcessingResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceRespondActivityTaskCanceledResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceRespondActivityTaskCanceledResult) GetEntityNotExistError() *shared.EntityNotExistsError {
-  if !p.IsSetEntityNotExistError() {
-    return HistoryServiceRespondActivityTaskCanceledResult_EntityNotExistError_DEFAULT
-  }
-return p.EntityNotExistError
-}
-var HistoryServiceRespondActivityTaskCanceledResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceRespondActivityTaskCanceledResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceUpdateQueueProcessingResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceUpdateQueueProcessingResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceRespondActivityTaskCanceledResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceUpdateQueueProcessingResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceRespondActivityTaskCanceledResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceUpdateQueueProcessingResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceUpdateQueueProcessingResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult) IsSetEntityNotExistError() bool {
-  return p.EntityNotExistError != nil
-}
-
-func (p *HistoryServiceRespondActivityTaskCanceledResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceUpdateQueueProcessingResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceUpdateQueueProcessingResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -8563,10 +16197,6 @@ func (p *HistoryServiceRespondActivityTaskCanceledResult) Read(iprot thrift.TPro
       if err := p.ReadField3(iprot); err != nil {
         return err
       }
-    case 4:
-      if err := p.ReadField4(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -8582,31 +16212,23 @@ func (p *HistoryServiceRespondActivityTaskCanceledResult) Read(iprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceUpdateQueueProcessingResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
   }
-  return nil
-}
-
-func (p *HistoryServiceRespondActivityTaskCanceledResult)  ReadField2(iprot thrift.TProtocol) error {
-  p.InternalServiceError = &shared.InternalServiceError{}
-  if err := p.InternalServiceError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
-  }
-  return nil
-}
-
-func (p *HistoryServiceRespondActivityTaskCanceledResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.EntityNotExistError = &shared.EntityNotExistsError{}
-  if err := p.EntityNotExistError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
+  return nil
+}
+
+func (p *HistoryServiceUpdateQueueProcessingResult)  ReadField2(iprot thrift.TProtocol) error {
+  p.InternalServiceError = &shared.InternalServiceError{}
+  if err := p.InternalServiceError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceUpdateQueueProcessingResult)  ReadField3(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -8614,14 +16236,13 @@ func (p *HistoryServiceRespondActivityTaskCanceledResult)  ReadField4(iprot thri
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RespondActivityTaskCanceled_result"); err != nil {
+func (p *HistoryServiceUpdateQueueProcessingResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("UpdateQueueProcessing_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
-    if err := p.writeField4(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -8630,7 +16251,7 @@ func (p *HistoryServiceRespondActivityTaskCanceledResult) Write(oprot thrift.TPr
   return nil
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceUpdateQueueProcessingResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -8643,7 +16264,7 @@ func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField1(oprot thri
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceUpdateQueueProcessingResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -8656,61 +16277,48 @@ func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField2(oprot thri
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetEntityNotExistError() {
-    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
-    if err := p.EntityNotExistError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
-  }
-  return err
-}
-
-func (p *HistoryServiceRespondActivityTaskCanceledResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceUpdateQueueProcessingResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
-    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:shardOwnershipLostError: ", p), err) }
     if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:shardOwnershipLostError: ", p), err) }
   }
   return err
 }
 
-func (p *HistoryServiceRespondActivityTaskCanceledResult) String() string {
+func (p *HistoryServiceUpdateQueueProcessingResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRespondActivityTaskCanceledResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceUpdateQueueProcessingResult(%+v)", *p)
 }
 
 // Attributes:
-//  - SignalRequest
-type HistoryServiceSignalWorkflowExecutionArgs struct {
-  SignalRequest *SignalWorkflowExecutionRequest `thrift:"signalRequest,1" db:"signalRequest" json:"signalRequest"`
+//  - Request
+type HistoryServiceDescribeMutableStateArgs struct {
+  Request *DescribeMutableStateRequest `thrift:"request,1" db:"request" json:"request"`
 }
 
-func NewHistoryServiceSignalWorkflowExecutionArgs() *HistoryServiceSignalWorkflowExecutionArgs {
-  return &HistoryServiceSignalWorkflowExecutionArgs{}
+func NewHistoryServiceDescribeMutableStateArgs() *HistoryServiceDescribeMutableStateArgs {
+  return &HistoryServiceDescribeMutableStateArgs{}
 }
 
-var HistoryServiceSignalWorkflowExecutionArgs_SignalRequest_DEFAULT *SignalWorkflowExecutionRequest
-func (p *HistoryServiceSignalWorkflowExecutionArgs) GetSignalRequest() *SignalWorkflowExecutionRequest {
-  if !p.IsSetSignalRequest() {
-    return HistoryServiceSignalWorkflowExecutionArgs_SignalRequest_DEFAULT
+var HistoryServiceDescribeMutableStateArgs_Request_DEFAULT *DescribeMutableStateRequest
+func (p *HistoryServiceDescribeMutableStateArgs) GetRequest() *DescribeMutableStateRequest {
+  if !p.IsSetRequest() {
+    return HistoryServiceDescribeMutableStateArgs_Request_DEFAULT
   }
-return p.SignalRequest
+return p.Request
 }
-func (p *HistoryServiceSignalWorkflowExecutionArgs) IsSetSignalRequest() bool {
-  return p.SignalRequest != nil
+func (p *HistoryServiceDescribeMutableStateArgs) IsSetRequest() bool {
+  return p.Request != nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeMutableStateArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -8742,16 +16350,16 @@ func (p *HistoryServiceSignalWorkflowExecutionArgs) Read(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.SignalRequest = &SignalWorkflowExecutionRequest{}
-  if err := p.SignalRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.SignalRequest), err)
+func (p *HistoryServiceDescribeMutableStateArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.Request = &DescribeMutableStateRequest{}
+  if err := p.Request.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Request), err)
   }
   return nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("SignalWorkflowExecution_args"); err != nil {
+func (p *HistoryServiceDescribeMutableStateArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeMutableState_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -8763,85 +16371,98 @@ func (p *HistoryServiceSignalWorkflowExecutionArgs) Write(oprot thrift.TProtocol
   return nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("signalRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:signalRequest: ", p), err) }
-  if err := p.SignalRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.SignalRequest), err)
+func (p *HistoryServiceDescribeMutableStateArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("request", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:request: ", p), err) }
+  if err := p.Request.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Request), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:signalRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:request: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionArgs) String() string {
+func (p *HistoryServiceDescribeMutableStateArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceSignalWorkflowExecutionArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceDescribeMutableStateArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - BadRequestError
 //  - InternalServiceError
 //  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceSignalWorkflowExecutionResult struct {
+type HistoryServiceDescribeMutableStateResult struct {
+  Success *DescribeMutableStateResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceSignalWorkflowExecutionResult() *HistoryServiceSignalWorkflowExecutionResult {
-  return &HistoryServiceSignalWorkflowExecutionResult{}
+func NewHistoryServiceDescribeMutableStateResult() *HistoryServiceDescribeMutableStateResult {
+  return &HistoryServiceDescribeMutableStateResult{}
 }
 
-var HistoryServiceSignalWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceSignalWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceDescribeMutableStateResult_Success_DEFAULT *DescribeMutableStateResponse
+func (p *HistoryServiceDescribeMutableStateResult) GetSuccess() *DescribeMutableStateResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceDescribeMutableStateResult_Success_DEFAULT
+  }
+return p.Success
+}
+var HistoryServiceDescribeMutableStateResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceDescribeMutableStateResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceSignalWorkflowExecutionResult_BadRequestError_DEFAULT
+    return HistoryServiceDescribeMutableStateResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceSignalWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceSignalWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceDescribeMutableStateResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceDescribeMutableStateResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceSignalWorkflowExecutionResult_InternalServiceError_DEFAULT
+    return HistoryServiceDescribeMutableStateResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceSignalWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceSignalWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceDescribeMutableStateResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceDescribeMutableStateResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceSignalWorkflowExecutionResult_EntityNotExistError_DEFAULT
+    return HistoryServiceDescribeMutableStateResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceSignalWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceSignalWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceDescribeMutableStateResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceDescribeMutableStateResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceSignalWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceDescribeMutableStateResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceSignalWorkflowExecutionResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceDescribeMutableStateResult) IsSetSuccess() bool {
+  return p.Success != nil
+}
+
+func (p *HistoryServiceDescribeMutableStateResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceDescribeMutableStateResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) IsSetEntityNotExistError() bool {
+func (p *HistoryServiceDescribeMutableStateResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceDescribeMutableStateResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeMutableStateResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -8854,6 +16475,10 @@ func (p *HistoryServiceSignalWorkflowExecutionResult) Read(iprot thrift.TProtoco
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -8885,7 +16510,15 @@ func (p *HistoryServiceSignalWorkflowExecutionResult) Read(iprot thrift.TProtoco
   return nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeMutableStateResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &DescribeMutableStateResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceDescribeMutableStateResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -8893,7 +16526,7 @@ func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField1(iprot thrift.T
   return nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeMutableStateResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -8901,7 +16534,7 @@ func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField2(iprot thrift.T
   return nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeMutableStateResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -8909,7 +16542,7 @@ func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField3(iprot thrift.T
   return nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceDescribeMutableStateResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -8917,10 +16550,11 @@ func (p *HistoryServiceSignalWorkflowExecutionResult)  ReadField4(iprot thrift.T
   return nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("SignalWorkflowExecution_result"); err != nil {
+func (p *HistoryServiceDescribeMutableStateResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("DescribeMutableState_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
@@ -8933,7 +16567,20 @@ func (p *HistoryServiceSignalWorkflowExecutionResult) Write(oprot thrift.TProtoc
   return nil
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceDescribeMutableStateResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *HistoryServiceDescribeMutableStateResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -8946,7 +16593,7 @@ func (p *HistoryServiceSignalWorkflowExecutionResult) writeField1(oprot thrift.T
   return err
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceDescribeMutableStateResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -8959,7 +16606,7 @@ func (p *HistoryServiceSignalWorkflowExecutionResult) writeField2(oprot thrift.T
   return err
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceDescribeMutableStateResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -8972,7 +16619,7 @@ func (p *HistoryServiceSignalWorkflowExecutionResult) writeField3(oprot thrift.T
   return err
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceDescribeMutableStateResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
@@ -8985,35 +16632,35 @@ func (p *HistoryServiceSignalWorkflowExecutionResult) writeField4(oprot thrift.T
   return err
 }
 
-func (p *HistoryServiceSignalWorkflowExecutionResult) String() string {
+func (p *HistoryServiceDescribeMutableStateResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceSignalWorkflowExecutionResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceDescribeMutableStateResult(%+v)", *p)
 }
 
 // Attributes:
-//  - TerminateRequest
-type HistoryServiceTerminateWorkflowExecutionArgs struct {
-  TerminateRequest *TerminateWorkflowExecutionRequest `thrift:"terminateRequest,1" db:"terminateRequest" json:"terminateRequest"`
+//  - RefreshRequest
+type HistoryServiceRefreshWorkflowTasksArgs struct {
+  RefreshRequest *RefreshWorkflowTasksRequest `thrift:"refreshRequest,1" db:"refreshRequest" json:"refreshRequest"`
 }
 
-func NewHistoryServiceTerminateWorkflowExecutionArgs() *HistoryServiceTerminateWorkflowExecutionArgs {
-  return &HistoryServiceTerminateWorkflowExecutionArgs{}
+func NewHistoryServiceRefreshWorkflowTasksArgs() *HistoryServiceRefreshWorkflowTasksArgs {
+  return &HistoryServiceRefreshWorkflowTasksArgs{}
 }
 
-var HistoryServiceTerminateWorkflowExecutionArgs_TerminateRequest_DEFAULT *TerminateWorkflowExecutionRequest
-func (p *HistoryServiceTerminateWorkflowExecutionArgs) GetTerminateRequest() *TerminateWorkflowExecutionRequest {
-  if !p.IsSetTerminateRequest() {
-    return HistoryServiceTerminateWorkflowExecutionArgs_TerminateRequest_DEFAULT
+var HistoryServiceRefreshWorkflowTasksArgs_RefreshRequest_DEFAULT *RefreshWorkflowTasksRequest
+func (p *HistoryServiceRefreshWorkflowTasksArgs) GetRefreshRequest() *RefreshWorkflowTasksRequest {
+  if !p.IsSetRefreshRequest() {
+    return HistoryServiceRefreshWorkflowTasksArgs_RefreshRequest_DEFAULT
   }
-return p.TerminateRequest
+return p.RefreshRequest
 }
-func (p *HistoryServiceTerminateWorkflowExecutionArgs) IsSetTerminateRequest() bool {
-  return p.TerminateRequest != nil
+func (p *HistoryServiceRefreshWorkflowTasksArgs) IsSetRefreshRequest() bool {
+  return p.RefreshRequest != nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRefreshWorkflowTasksArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -9045,16 +16692,16 @@ func (p *HistoryServiceTerminateWorkflowExecutionArgs) Read(iprot thrift.TProtoc
   return nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.TerminateRequest = &TerminateWorkflowExecutionRequest{}
-  if err := p.TerminateRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.TerminateRequest), err)
+func (p *HistoryServiceRefreshWorkflowTasksArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.RefreshRequest = &RefreshWorkflowTasksRequest{}
+  if err := p.RefreshRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.RefreshRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("TerminateWorkflowExecution_args"); err != nil {
+func (p *HistoryServiceRefreshWorkflowTasksArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RefreshWorkflowTasks_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -9066,22 +16713,22 @@ func (p *HistoryServiceTerminateWorkflowExecutionArgs) Write(oprot thrift.TProto
   return nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("terminateRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:terminateRequest: ", p), err) }
-  if err := p.TerminateRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.TerminateRequest), err)
+func (p *HistoryServiceRefreshWorkflowTasksArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("refreshRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:refreshRequest: ", p), err) }
+  if err := p.RefreshRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.RefreshRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:terminateRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:refreshRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionArgs) String() string {
+func (p *HistoryServiceRefreshWorkflowTasksArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceTerminateWorkflowExecutionArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceRefreshWorkflowTasksArgs(%+v)", *p)
 }
 
 // Attributes:
@@ -9089,62 +16736,62 @@ func (p *HistoryServiceTerminateWorkflowExecutionArgs) String() string {
 //  - InternalServiceError
 //  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceTerminateWorkflowExecutionResult struct {
+type HistoryServiceRefreshWorkflowTasksResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceTerminateWorkflowExecutionResult() *HistoryServiceTerminateWorkflowExecutionResult {
-  return &HistoryServiceTerminateWorkflowExecutionResult{}
+func NewHistoryServiceRefreshWorkflowTasksResult() *HistoryServiceRefreshWorkflowTasksResult {
+  return &HistoryServiceRefreshWorkflowTasksResult{}
 }
 
-var HistoryServiceTerminateWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceTerminateWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceRefreshWorkflowTasksResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRefreshWorkflowTasksResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceTerminateWorkflowExecutionResult_BadRequestError_DEFAULT
+    return HistoryServiceRefreshWorkflowTasksResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceTerminateWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceTerminateWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceRefreshWorkflowTasksResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRefreshWorkflowTasksResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceTerminateWorkflowExecutionResult_InternalServiceError_DEFAULT
+    return HistoryServiceRefreshWorkflowTasksResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceTerminateWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceTerminateWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceRefreshWorkflowTasksResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceRefreshWorkflowTasksResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceTerminateWorkflowExecutionResult_EntityNotExistError_DEFAULT
+    return HistoryServiceRefreshWorkflowTasksResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceTerminateWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceTerminateWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceRefreshWorkflowTasksResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRefreshWorkflowTasksResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceTerminateWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceRefreshWorkflowTasksResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceTerminateWorkflowExecutionResult) IsSetBadRequestError() bool {
+func (p *HistoryServiceRefreshWorkflowTasksResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceRefreshWorkflowTasksResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) IsSetEntityNotExistError() bool {
+func (p *HistoryServiceRefreshWorkflowTasksResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceRefreshWorkflowTasksResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRefreshWorkflowTasksResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -9188,7 +16835,7 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult) Read(iprot thrift.TProt
   return nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRefreshWorkflowTasksResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -9196,7 +16843,7 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField1(iprot thrif
   return nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRefreshWorkflowTasksResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -9204,7 +16851,7 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField2(iprot thrif
   return nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRefreshWorkflowTasksResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -9212,7 +16859,7 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField3(iprot thrif
   return nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRefreshWorkflowTasksResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -9220,8 +16867,8 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult)  ReadField4(iprot thrif
   return nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("TerminateWorkflowExecution_result"); err != nil {
+func (p *HistoryServiceRefreshWorkflowTasksResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RefreshWorkflowTasks_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -9236,7 +16883,7 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult) Write(oprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRefreshWorkflowTasksResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -9249,7 +16896,7 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField1(oprot thrif
   return err
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRefreshWorkflowTasksResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -9262,7 +16909,7 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField2(oprot thrif
   return err
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRefreshWorkflowTasksResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -9275,7 +16922,7 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField3(oprot thrif
   return err
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRefreshWorkflowTasksResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
@@ -9288,35 +16935,35 @@ func (p *HistoryServiceTerminateWorkflowExecutionResult) writeField4(oprot thrif
   return err
 }
 
-func (p *HistoryServiceTerminateWorkflowExecutionResult) String() string {
+func (p *HistoryServiceRefreshWorkflowTasksResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceTerminateWorkflowExecutionResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceRefreshWorkflowTasksResult(%+v)", *p)
 }
 
 // Attributes:
-//  - CancelRequest
-type HistoryServiceRequestCancelWorkflowExecutionArgs struct {
-  CancelRequest *RequestCancelWorkflowExecutionRequest `thrift:"cancelRequest,1" db:"cancelRequest" json:"cancelRequest"`
+//  - ListRequest
+type HistoryServiceListStaleExecutionsArgs struct {
+  ListRequest *ListStaleExecutionsRequest `thrift:"listRequest,1" db:"listRequest" json:"listRequest"`
 }
 
-func NewHistoryServiceRequestCancelWorkflowExecutionArgs() *HistoryServiceRequestCancelWorkflowExecutionArgs {
-  return &HistoryServiceRequestCancelWorkflowExecutionArgs{}
+func NewHistoryServiceListStaleExecutionsArgs() *HistoryServiceListStaleExecutionsArgs {
+  return &HistoryServiceListStaleExecutionsArgs{}
 }
 
-var HistoryServiceRequestCancelWorkflowExecutionArgs_CancelRequest_DEFAULT *RequestCancelWorkflowExecutionRequest
-func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) GetCancelRequest() *RequestCancelWorkflowExecutionRequest {
-  if !p.IsSetCancelRequest() {
-    return HistoryServiceRequestCancelWorkflowExecutionArgs_CancelRequest_DEFAULT
+var HistoryServiceListStaleExecutionsArgs_ListRequest_DEFAULT *ListStaleExecutionsRequest
+func (p *HistoryServiceListStaleExecutionsArgs) GetListRequest() *ListStaleExecutionsRequest {
+  if !p.IsSetListRequest() {
+    return HistoryServiceListStaleExecutionsArgs_ListRequest_DEFAULT
   }
-return p.CancelRequest
+return p.ListRequest
 }
-func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) IsSetCancelRequest() bool {
-  return p.CancelRequest != nil
+func (p *HistoryServiceListStaleExecutionsArgs) IsSetListRequest() bool {
+  return p.ListRequest != nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceListStaleExecutionsArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -9348,16 +16995,16 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) Read(iprot thrift.TPr
   return nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.CancelRequest = &RequestCancelWorkflowExecutionRequest{}
-  if err := p.CancelRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CancelRequest), err)
+func (p *HistoryServiceListStaleExecutionsArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.ListRequest = &ListStaleExecutionsRequest{}
+  if err := p.ListRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ListRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecution_args"); err != nil {
+func (p *HistoryServiceListStaleExecutionsArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListStaleExecutions_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -9369,85 +17016,85 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) Write(oprot thrift.TP
   return nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("cancelRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:cancelRequest: ", p), err) }
-  if err := p.CancelRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CancelRequest), err)
+func (p *HistoryServiceListStaleExecutionsArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("listRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:listRequest: ", p), err) }
+  if err := p.ListRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ListRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:cancelRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:listRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionArgs) String() string {
+func (p *HistoryServiceListStaleExecutionsArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRequestCancelWorkflowExecutionArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceListStaleExecutionsArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - BadRequestError
 //  - InternalServiceError
-//  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceRequestCancelWorkflowExecutionResult struct {
+type HistoryServiceListStaleExecutionsResult struct {
+  Success *ListStaleExecutionsResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
-  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,3" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceRequestCancelWorkflowExecutionResult() *HistoryServiceRequestCancelWorkflowExecutionResult {
-  return &HistoryServiceRequestCancelWorkflowExecutionResult{}
+func NewHistoryServiceListStaleExecutionsResult() *HistoryServiceListStaleExecutionsResult {
+  return &HistoryServiceListStaleExecutionsResult{}
 }
 
-var HistoryServiceRequestCancelWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceListStaleExecutionsResult_Success_DEFAULT *ListStaleExecutionsResponse
+func (p *HistoryServiceListStaleExecutionsResult) GetSuccess() *ListStaleExecutionsResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceListStaleExecutionsResult_Success_DEFAULT
+  }
+return p.Success
+}
+var HistoryServiceListStaleExecutionsResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceListStaleExecutionsResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceRequestCancelWorkflowExecutionResult_BadRequestError_DEFAULT
+    return HistoryServiceListStaleExecutionsResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceRequestCancelWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceListStaleExecutionsResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceListStaleExecutionsResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceRequestCancelWorkflowExecutionResult_InternalServiceError_DEFAULT
+    return HistoryServiceListStaleExecutionsResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceRequestCancelWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
-  if !p.IsSetEntityNotExistError() {
-    return HistoryServiceRequestCancelWorkflowExecutionResult_EntityNotExistError_DEFAULT
-  }
-return p.EntityNotExistError
-}
-var HistoryServiceRequestCancelWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceListStaleExecutionsResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceListStaleExecutionsResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceRequestCancelWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceListStaleExecutionsResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) IsSetBadRequestError() bool {
-  return p.BadRequestError != nil
+func (p *HistoryServiceListStaleExecutionsResult) IsSetSuccess() bool {
+  return p.Success != nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) IsSetInternalServiceError() bool {
-  return p.InternalServiceError != nil
+func (p *HistoryServiceListStaleExecutionsResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) IsSetEntityNotExistError() bool {
-  return p.EntityNotExistError != nil
+func (p *HistoryServiceListStaleExecutionsResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceListStaleExecutionsResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceListStaleExecutionsResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -9460,6 +17107,10 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionResult) Read(iprot thrift.T
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -9472,10 +17123,6 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionResult) Read(iprot thrift.T
       if err := p.ReadField3(iprot); err != nil {
         return err
       }
-    case 4:
-      if err := p.ReadField4(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -9491,7 +17138,15 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionResult) Read(iprot thrift.T
   return nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceListStaleExecutionsResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &ListStaleExecutionsResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceListStaleExecutionsResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -9499,7 +17154,7 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField1(iprot t
   return nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceListStaleExecutionsResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -9507,15 +17162,7 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField2(iprot t
   return nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.EntityNotExistError = &shared.EntityNotExistsError{}
-  if err := p.EntityNotExistError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
-  }
-  return nil
-}
-
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceListStaleExecutionsResult)  ReadField3(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -9523,14 +17170,14 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionResult)  ReadField4(iprot t
   return nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RequestCancelWorkflowExecution_result"); err != nil {
+func (p *HistoryServiceListStaleExecutionsResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("ListStaleExecutions_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
-    if err := p.writeField4(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -9539,7 +17186,20 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionResult) Write(oprot thrift.
   return nil
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceListStaleExecutionsResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *HistoryServiceListStaleExecutionsResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -9552,7 +17212,7 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField1(oprot t
   return err
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceListStaleExecutionsResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -9565,61 +17225,48 @@ func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField2(oprot t
   return err
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetEntityNotExistError() {
-    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
-    if err := p.EntityNotExistError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
-  }
-  return err
-}
-
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceListStaleExecutionsResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
-    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:shardOwnershipLostError: ", p), err) }
     if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:shardOwnershipLostError: ", p), err) }
   }
   return err
 }
 
-func (p *HistoryServiceRequestCancelWorkflowExecutionResult) String() string {
+func (p *HistoryServiceListStaleExecutionsResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRequestCancelWorkflowExecutionResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceListStaleExecutionsResult(%+v)", *p)
 }
 
 // Attributes:
-//  - ScheduleRequest
-type HistoryServiceScheduleDecisionTaskArgs struct {
-  ScheduleRequest *ScheduleDecisionTaskRequest `thrift:"scheduleRequest,1" db:"scheduleRequest" json:"scheduleRequest"`
+//  - MigrateRequest
+type HistoryServiceMigrateWorkflowExecutionArgs struct {
+  MigrateRequest *MigrateWorkflowExecutionRequest `thrift:"migrateRequest,1" db:"migrateRequest" json:"migrateRequest"`
 }
 
-func NewHistoryServiceScheduleDecisionTaskArgs() *HistoryServiceScheduleDecisionTaskArgs {
-  return &HistoryServiceScheduleDecisionTaskArgs{}
+func NewHistoryServiceMigrateWorkflowExecutionArgs() *HistoryServiceMigrateWorkflowExecutionArgs {
+  return &HistoryServiceMigrateWorkflowExecutionArgs{}
 }
 
-var HistoryServiceScheduleDecisionTaskArgs_ScheduleRequest_DEFAULT *ScheduleDecisionTaskRequest
-func (p *HistoryServiceScheduleDecisionTaskArgs) GetScheduleRequest() *ScheduleDecisionTaskRequest {
-  if !p.IsSetScheduleRequest() {
-    return HistoryServiceScheduleDecisionTaskArgs_ScheduleRequest_DEFAULT
+var HistoryServiceMigrateWorkflowExecutionArgs_MigrateRequest_DEFAULT *MigrateWorkflowExecutionRequest
+func (p *HistoryServiceMigrateWorkflowExecutionArgs) GetMigrateRequest() *MigrateWorkflowExecutionRequest {
+  if !p.IsSetMigrateRequest() {
+    return HistoryServiceMigrateWorkflowExecutionArgs_MigrateRequest_DEFAULT
   }
-return p.ScheduleRequest
+return p.MigrateRequest
 }
-func (p *HistoryServiceScheduleDecisionTaskArgs) IsSetScheduleRequest() bool {
-  return p.ScheduleRequest != nil
+func (p *HistoryServiceMigrateWorkflowExecutionArgs) IsSetMigrateRequest() bool {
+  return p.MigrateRequest != nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceMigrateWorkflowExecutionArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -9651,16 +17298,16 @@ func (p *HistoryServiceScheduleDecisionTaskArgs) Read(iprot thrift.TProtocol) er
   return nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.ScheduleRequest = &ScheduleDecisionTaskRequest{}
-  if err := p.ScheduleRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ScheduleRequest), err)
+func (p *HistoryServiceMigrateWorkflowExecutionArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.MigrateRequest = &MigrateWorkflowExecutionRequest{}
+  if err := p.MigrateRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.MigrateRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("ScheduleDecisionTask_args"); err != nil {
+func (p *HistoryServiceMigrateWorkflowExecutionArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("MigrateWorkflowExecution_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -9672,22 +17319,22 @@ func (p *HistoryServiceScheduleDecisionTaskArgs) Write(oprot thrift.TProtocol) e
   return nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("scheduleRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:scheduleRequest: ", p), err) }
-  if err := p.ScheduleRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ScheduleRequest), err)
+func (p *HistoryServiceMigrateWorkflowExecutionArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("migrateRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:migrateRequest: ", p), err) }
+  if err := p.MigrateRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.MigrateRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:scheduleRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:migrateRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceScheduleDecisionTaskArgs) String() string {
+func (p *HistoryServiceMigrateWorkflowExecutionArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceScheduleDecisionTaskArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceMigrateWorkflowExecutionArgs(%+v)", *p)
 }
 
 // Attributes:
@@ -9695,62 +17342,75 @@ func (p *HistoryServiceScheduleDecisionTaskArgs) String() string {
 //  - InternalServiceError
 //  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceScheduleDecisionTaskResult struct {
+//  - WorkflowAlreadyStartedError
+type HistoryServiceMigrateWorkflowExecutionResult struct {
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
   EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
   ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  WorkflowAlreadyStartedError *shared.WorkflowExecutionAlreadyStartedError `thrift:"workflowAlreadyStartedError,5" db:"workflowAlreadyStartedError" json:"workflowAlreadyStartedError,omitempty"`
 }
 
-func NewHistoryServiceScheduleDecisionTaskResult() *HistoryServiceScheduleDecisionTaskResult {
-  return &HistoryServiceScheduleDecisionTaskResult{}
+func NewHistoryServiceMigrateWorkflowExecutionResult() *HistoryServiceMigrateWorkflowExecutionResult {
+  return &HistoryServiceMigrateWorkflowExecutionResult{}
 }
 
-var HistoryServiceScheduleDecisionTaskResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceScheduleDecisionTaskResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceMigrateWorkflowExecutionResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceMigrateWorkflowExecutionResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceScheduleDecisionTaskResult_BadRequestError_DEFAULT
+    return HistoryServiceMigrateWorkflowExecutionResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceScheduleDecisionTaskResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceScheduleDecisionTaskResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceMigrateWorkflowExecutionResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceMigrateWorkflowExecutionResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceScheduleDecisionTaskResult_InternalServiceError_DEFAULT
+    return HistoryServiceMigrateWorkflowExecutionResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceScheduleDecisionTaskResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceScheduleDecisionTaskResult) GetEntityNotExistError() *shared.EntityNotExistsError {
+var HistoryServiceMigrateWorkflowExecutionResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
+func (p *HistoryServiceMigrateWorkflowExecutionResult) GetEntityNotExistError() *shared.EntityNotExistsError {
   if !p.IsSetEntityNotExistError() {
-    return HistoryServiceScheduleDecisionTaskResult_EntityNotExistError_DEFAULT
+    return HistoryServiceMigrateWorkflowExecutionResult_EntityNotExistError_DEFAULT
   }
 return p.EntityNotExistError
 }
-var HistoryServiceScheduleDecisionTaskResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceScheduleDecisionTaskResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceMigrateWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceMigrateWorkflowExecutionResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceScheduleDecisionTaskResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceMigrateWorkflowExecutionResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceScheduleDecisionTaskResult) IsSetBadRequestError() bool {
+var HistoryServiceMigrateWorkflowExecutionResult_WorkflowAlreadyStartedError_DEFAULT *shared.WorkflowExecutionAlreadyStartedError
+func (p *HistoryServiceMigrateWorkflowExecutionResult) GetWorkflowAlreadyStartedError() *shared.WorkflowExecutionAlreadyStartedError {
+  if !p.IsSetWorkflowAlreadyStartedError() {
+    return HistoryServiceMigrateWorkflowExecutionResult_WorkflowAlreadyStartedError_DEFAULT
+  }
+return p.WorkflowAlreadyStartedError
+}
+func (p *HistoryServiceMigrateWorkflowExecutionResult) IsSetBadRequestError() bool {
   return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) IsSetInternalServiceError() bool {
+func (p *HistoryServiceMigrateWorkflowExecutionResult) IsSetInternalServiceError() bool {
   return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) IsSetEntityNotExistError() bool {
+func (p *HistoryServiceMigrateWorkflowExecutionResult) IsSetEntityNotExistError() bool {
   return p.EntityNotExistError != nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceMigrateWorkflowExecutionResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceMigrateWorkflowExecutionResult) IsSetWorkflowAlreadyStartedError() bool {
+  return p.WorkflowAlreadyStartedError != nil
+}
+
+func (p *HistoryServiceMigrateWorkflowExecutionResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -9779,6 +17439,10 @@ func (p *HistoryServiceScheduleDecisionTaskResult) Read(iprot thrift.TProtocol)
       if err := p.ReadField4(iprot); err != nil {
         return err
       }
+    case 5:
+      if err := p.ReadField5(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -9794,7 +17458,7 @@ func (p *HistoryServiceScheduleDecisionTaskResult) Read(iprot thrift.TProtocol)
   return nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceMigrateWorkflowExecutionResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -9802,7 +17466,7 @@ func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField1(iprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceMigrateWorkflowExecutionResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -9810,7 +17474,7 @@ func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField2(iprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField3(iprot thrift.TProtocol) error {
+func (p *HistoryServiceMigrateWorkflowExecutionResult)  ReadField3(iprot thrift.TProtocol) error {
   p.EntityNotExistError = &shared.EntityNotExistsError{}
   if err := p.EntityNotExistError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
@@ -9818,7 +17482,7 @@ func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField3(iprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceMigrateWorkflowExecutionResult)  ReadField4(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -9826,14 +17490,23 @@ func (p *HistoryServiceScheduleDecisionTaskResult)  ReadField4(iprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("ScheduleDecisionTask_result"); err != nil {
+func (p *HistoryServiceMigrateWorkflowExecutionResult)  ReadField5(iprot thrift.TProtocol) error {
+  p.WorkflowAlreadyStartedError = &shared.WorkflowExecutionAlreadyStartedError{}
+  if err := p.WorkflowAlreadyStartedError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.WorkflowAlreadyStartedError), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceMigrateWorkflowExecutionResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("MigrateWorkflowExecution_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
     if err := p.writeField4(oprot); err != nil { return err }
+    if err := p.writeField5(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -9842,7 +17515,7 @@ func (p *HistoryServiceScheduleDecisionTaskResult) Write(oprot thrift.TProtocol)
   return nil
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceMigrateWorkflowExecutionResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -9855,7 +17528,7 @@ func (p *HistoryServiceScheduleDecisionTaskResult) writeField1(oprot thrift.TPro
   return err
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceMigrateWorkflowExecutionResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -9868,7 +17541,7 @@ func (p *HistoryServiceScheduleDecisionTaskResult) writeField2(oprot thrift.TPro
   return err
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceMigrateWorkflowExecutionResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetEntityNotExistError() {
     if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
@@ -9881,7 +17554,7 @@ func (p *HistoryServiceScheduleDecisionTaskResult) writeField3(oprot thrift.TPro
   return err
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceMigrateWorkflowExecutionResult) writeField4(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
     if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
@@ -9894,35 +17567,48 @@ func (p *HistoryServiceScheduleDecisionTaskResult) writeField4(oprot thrift.TPro
   return err
 }
 
-func (p *HistoryServiceScheduleDecisionTaskResult) String() string {
+func (p *HistoryServiceMigrateWorkflowExecutionResult) writeField5(oprot thrift.TProtocol) (err error) {
+  if p.IsSetWorkflowAlreadyStartedError() {
+    if err := oprot.WriteFieldBegin("workflowAlreadyStartedError", thrift.STRUCT, 5); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:workflowAlreadyStartedError: ", p), err) }
+    if err := p.WorkflowAlreadyStartedError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.WorkflowAlreadyStartedError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 5:workflowAlreadyStartedError: ", p), err) }
+  }
+  return err
+}
+
+func (p *HistoryServiceMigrateWorkflowExecutionResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceScheduleDecisionTaskResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceMigrateWorkflowExecutionResult(%+v)", *p)
 }
 
 // Attributes:
-//  - CompletionRequest
-type HistoryServiceRecordChildExecutionCompletedArgs struct {
-  CompletionRequest *RecordChildExecutionCompletedRequest `thrift:"completionRequest,1" db:"completionRequest" json:"completionRequest"`
+//  - RedriveRequest
+type HistoryServiceRedriveTransferDLQTasksArgs struct {
+  RedriveRequest *RedriveTransferDLQTasksRequest `thrift:"redriveRequest,1" db:"redriveRequest" json:"redriveRequest"`
 }
 
-func NewHistoryServiceRecordChildExecutionCompletedArgs() *HistoryServiceRecordChildExecutionCompletedArgs {
-  return &HistoryServiceRecordChildExecutionCompletedArgs{}
+func NewHistoryServiceRedriveTransferDLQTasksArgs() *HistoryServiceRedriveTransferDLQTasksArgs {
+  return &HistoryServiceRedriveTransferDLQTasksArgs{}
 }
 
-var HistoryServiceRecordChildExecutionCompletedArgs_CompletionRequest_DEFAULT *RecordChildExecutionCompletedRequest
-func (p *HistoryServiceRecordChildExecutionCompletedArgs) GetCompletionRequest() *RecordChildExecutionCompletedRequest {
-  if !p.IsSetCompletionRequest() {
-    return HistoryServiceRecordChildExecutionCompletedArgs_CompletionRequest_DEFAULT
+var HistoryServiceRedriveTransferDLQTasksArgs_RedriveRequest_DEFAULT *RedriveTransferDLQTasksRequest
+func (p *HistoryServiceRedriveTransferDLQTasksArgs) GetRedriveRequest() *RedriveTransferDLQTasksRequest {
+  if !p.IsSetRedriveRequest() {
+    return HistoryServiceRedriveTransferDLQTasksArgs_RedriveRequest_DEFAULT
   }
-return p.CompletionRequest
+return p.RedriveRequest
 }
-func (p *HistoryServiceRecordChildExecutionCompletedArgs) IsSetCompletionRequest() bool {
-  return p.CompletionRequest != nil
+func (p *HistoryServiceRedriveTransferDLQTasksArgs) IsSetRedriveRequest() bool {
+  return p.RedriveRequest != nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedArgs) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRedriveTransferDLQTasksArgs) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -9954,16 +17640,16 @@ func (p *HistoryServiceRecordChildExecutionCompletedArgs) Read(iprot thrift.TPro
   return nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedArgs)  ReadField1(iprot thrift.TProtocol) error {
-  p.CompletionRequest = &RecordChildExecutionCompletedRequest{}
-  if err := p.CompletionRequest.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.CompletionRequest), err)
+func (p *HistoryServiceRedriveTransferDLQTasksArgs)  ReadField1(iprot thrift.TProtocol) error {
+  p.RedriveRequest = &RedriveTransferDLQTasksRequest{}
+  if err := p.RedriveRequest.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.RedriveRequest), err)
   }
   return nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedArgs) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordChildExecutionCompleted_args"); err != nil {
+func (p *HistoryServiceRedriveTransferDLQTasksArgs) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RedriveTransferDLQTasks_args"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
     if err := p.writeField1(oprot); err != nil { return err }
@@ -9975,85 +17661,85 @@ func (p *HistoryServiceRecordChildExecutionCompletedArgs) Write(oprot thrift.TPr
   return nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedArgs) writeField1(oprot thrift.TProtocol) (err error) {
-  if err := oprot.WriteFieldBegin("completionRequest", thrift.STRUCT, 1); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:completionRequest: ", p), err) }
-  if err := p.CompletionRequest.Write(oprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.CompletionRequest), err)
+func (p *HistoryServiceRedriveTransferDLQTasksArgs) writeField1(oprot thrift.TProtocol) (err error) {
+  if err := oprot.WriteFieldBegin("redriveRequest", thrift.STRUCT, 1); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:redriveRequest: ", p), err) }
+  if err := p.RedriveRequest.Write(oprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.RedriveRequest), err)
   }
   if err := oprot.WriteFieldEnd(); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:completionRequest: ", p), err) }
+    return thrift.PrependError(fmt.Sprintf("%T write field end error 1:redriveRequest: ", p), err) }
   return err
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedArgs) String() string {
+func (p *HistoryServiceRedriveTransferDLQTasksArgs) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRecordChildExecutionCompletedArgs(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceRedriveTransferDLQTasksArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - BadRequestError
 //  - InternalServiceError
-//  - EntityNotExistError
 //  - ShardOwnershipLostError
-type HistoryServiceRecordChildExecutionCompletedResult struct {
+type HistoryServiceRedriveTransferDLQTasksResult struct {
+  Success *RedriveTransferDLQTasksResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
-  EntityNotExistError *shared.EntityNotExistsError `thrift:"entityNotExistError,3" db:"entityNotExistError" json:"entityNotExistError,omitempty"`
-  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,4" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
+  ShardOwnershipLostError *ShardOwnershipLostError `thrift:"shardOwnershipLostError,3" db:"shardOwnershipLostError" json:"shardOwnershipLostError,omitempty"`
 }
 
-func NewHistoryServiceRecordChildExecutionCompletedResult() *HistoryServiceRecordChildExecutionCompletedResult {
-  return &HistoryServiceRecordChildExecutionCompletedResult{}
+func NewHistoryServiceRedriveTransferDLQTasksResult() *HistoryServiceRedriveTransferDLQTasksResult {
+  return &HistoryServiceRedriveTransferDLQTasksResult{}
 }
 
-var HistoryServiceRecordChildExecutionCompletedResult_BadRequestError_DEFAULT *shared.BadRequestError
-func (p *HistoryServiceRecordChildExecutionCompletedResult) GetBadRequestError() *shared.BadRequestError {
+var HistoryServiceRedriveTransferDLQTasksResult_Success_DEFAULT *RedriveTransferDLQTasksResponse
+func (p *HistoryServiceRedriveTransferDLQTasksResult) GetSuccess() *RedriveTransferDLQTasksResponse {
+  if !p.IsSetSuccess() {
+    return HistoryServiceRedriveTransferDLQTasksResult_Success_DEFAULT
+  }
+return p.Success
+}
+var HistoryServiceRedriveTransferDLQTasksResult_BadRequestError_DEFAULT *shared.BadRequestError
+func (p *HistoryServiceRedriveTransferDLQTasksResult) GetBadRequestError() *shared.BadRequestError {
   if !p.IsSetBadRequestError() {
-    return HistoryServiceRecordChildExecutionCompletedResult_BadRequestError_DEFAULT
+    return HistoryServiceRedriveTransferDLQTasksResult_BadRequestError_DEFAULT
   }
 return p.BadRequestError
 }
-var HistoryServiceRecordChildExecutionCompletedResult_InternalServiceError_DEFAULT *shared.InternalServiceError
-func (p *HistoryServiceRecordChildExecutionCompletedResult) GetInternalServiceError() *shared.InternalServiceError {
+var HistoryServiceRedriveTransferDLQTasksResult_InternalServiceError_DEFAULT *shared.InternalServiceError
+func (p *HistoryServiceRedriveTransferDLQTasksResult) GetInternalServiceError() *shared.InternalServiceError {
   if !p.IsSetInternalServiceError() {
-    return HistoryServiceRecordChildExecutionCompletedResult_InternalServiceError_DEFAULT
+    return HistoryServiceRedriveTransferDLQTasksResult_InternalServiceError_DEFAULT
   }
 return p.InternalServiceError
 }
-var HistoryServiceRecordChildExecutionCompletedResult_EntityNotExistError_DEFAULT *shared.EntityNotExistsError
-func (p *HistoryServiceRecordChildExecutionCompletedResult) GetEntityNotExistError() *shared.EntityNotExistsError {
-  if !p.IsSetEntityNotExistError() {
-    return HistoryServiceRecordChildExecutionCompletedResult_EntityNotExistError_DEFAULT
-  }
-return p.EntityNotExistError
-}
-var HistoryServiceRecordChildExecutionCompletedResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
-func (p *HistoryServiceRecordChildExecutionCompletedResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
+var HistoryServiceRedriveTransferDLQTasksResult_ShardOwnershipLostError_DEFAULT *ShardOwnershipLostError
+func (p *HistoryServiceRedriveTransferDLQTasksResult) GetShardOwnershipLostError() *ShardOwnershipLostError {
   if !p.IsSetShardOwnershipLostError() {
-    return HistoryServiceRecordChildExecutionCompletedResult_ShardOwnershipLostError_DEFAULT
+    return HistoryServiceRedriveTransferDLQTasksResult_ShardOwnershipLostError_DEFAULT
   }
 return p.ShardOwnershipLostError
 }
-func (p *HistoryServiceRecordChildExecutionCompletedResult) IsSetBadRequestError() bool {
-  return p.BadRequestError != nil
+func (p *HistoryServiceRedriveTransferDLQTasksResult) IsSetSuccess() bool {
+  return p.Success != nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult) IsSetInternalServiceError() bool {
-  return p.InternalServiceError != nil
+func (p *HistoryServiceRedriveTransferDLQTasksResult) IsSetBadRequestError() bool {
+  return p.BadRequestError != nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult) IsSetEntityNotExistError() bool {
-  return p.EntityNotExistError != nil
+func (p *HistoryServiceRedriveTransferDLQTasksResult) IsSetInternalServiceError() bool {
+  return p.InternalServiceError != nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult) IsSetShardOwnershipLostError() bool {
+func (p *HistoryServiceRedriveTransferDLQTasksResult) IsSetShardOwnershipLostError() bool {
   return p.ShardOwnershipLostError != nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult) Read(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRedriveTransferDLQTasksResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
   }
@@ -10066,6 +17752,10 @@ func (p *HistoryServiceRecordChildExecutionCompletedResult) Read(iprot thrift.TP
     }
     if fieldTypeId == thrift.STOP { break; }
     switch fieldId {
+    case 0:
+      if err := p.ReadField0(iprot); err != nil {
+        return err
+      }
     case 1:
       if err := p.ReadField1(iprot); err != nil {
         return err
@@ -10078,10 +17768,6 @@ func (p *HistoryServiceRecordChildExecutionCompletedResult) Read(iprot thrift.TP
       if err := p.ReadField3(iprot); err != nil {
         return err
       }
-    case 4:
-      if err := p.ReadField4(iprot); err != nil {
-        return err
-      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -10097,7 +17783,15 @@ func (p *HistoryServiceRecordChildExecutionCompletedResult) Read(iprot thrift.TP
   return nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField1(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRedriveTransferDLQTasksResult)  ReadField0(iprot thrift.TProtocol) error {
+  p.Success = &RedriveTransferDLQTasksResponse{}
+  if err := p.Success.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+  }
+  return nil
+}
+
+func (p *HistoryServiceRedriveTransferDLQTasksResult)  ReadField1(iprot thrift.TProtocol) error {
   p.BadRequestError = &shared.BadRequestError{}
   if err := p.BadRequestError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.BadRequestError), err)
@@ -10105,7 +17799,7 @@ func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField1(iprot th
   return nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField2(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRedriveTransferDLQTasksResult)  ReadField2(iprot thrift.TProtocol) error {
   p.InternalServiceError = &shared.InternalServiceError{}
   if err := p.InternalServiceError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.InternalServiceError), err)
@@ -10113,15 +17807,7 @@ func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField2(iprot th
   return nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField3(iprot thrift.TProtocol) error {
-  p.EntityNotExistError = &shared.EntityNotExistsError{}
-  if err := p.EntityNotExistError.Read(iprot); err != nil {
-    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.EntityNotExistError), err)
-  }
-  return nil
-}
-
-func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField4(iprot thrift.TProtocol) error {
+func (p *HistoryServiceRedriveTransferDLQTasksResult)  ReadField3(iprot thrift.TProtocol) error {
   p.ShardOwnershipLostError = &ShardOwnershipLostError{}
   if err := p.ShardOwnershipLostError.Read(iprot); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ShardOwnershipLostError), err)
@@ -10129,14 +17815,14 @@ func (p *HistoryServiceRecordChildExecutionCompletedResult)  ReadField4(iprot th
   return nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult) Write(oprot thrift.TProtocol) error {
-  if err := oprot.WriteStructBegin("RecordChildExecutionCompleted_result"); err != nil {
+func (p *HistoryServiceRedriveTransferDLQTasksResult) Write(oprot thrift.TProtocol) error {
+  if err := oprot.WriteStructBegin("RedriveTransferDLQTasks_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
   if p != nil {
+    if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
     if err := p.writeField3(oprot); err != nil { return err }
-    if err := p.writeField4(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -10145,7 +17831,20 @@ func (p *HistoryServiceRecordChildExecutionCompletedResult) Write(oprot thrift.T
   return nil
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRedriveTransferDLQTasksResult) writeField0(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSuccess() {
+    if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err) }
+    if err := p.Success.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err) }
+  }
+  return err
+}
+
+func (p *HistoryServiceRedriveTransferDLQTasksResult) writeField1(oprot thrift.TProtocol) (err error) {
   if p.IsSetBadRequestError() {
     if err := oprot.WriteFieldBegin("badRequestError", thrift.STRUCT, 1); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:badRequestError: ", p), err) }
@@ -10158,7 +17857,7 @@ func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField1(oprot th
   return err
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRedriveTransferDLQTasksResult) writeField2(oprot thrift.TProtocol) (err error) {
   if p.IsSetInternalServiceError() {
     if err := oprot.WriteFieldBegin("internalServiceError", thrift.STRUCT, 2); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:internalServiceError: ", p), err) }
@@ -10171,37 +17870,24 @@ func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField2(oprot th
   return err
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField3(oprot thrift.TProtocol) (err error) {
-  if p.IsSetEntityNotExistError() {
-    if err := oprot.WriteFieldBegin("entityNotExistError", thrift.STRUCT, 3); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:entityNotExistError: ", p), err) }
-    if err := p.EntityNotExistError.Write(oprot); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.EntityNotExistError), err)
-    }
-    if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:entityNotExistError: ", p), err) }
-  }
-  return err
-}
-
-func (p *HistoryServiceRecordChildExecutionCompletedResult) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *HistoryServiceRedriveTransferDLQTasksResult) writeField3(oprot thrift.TProtocol) (err error) {
   if p.IsSetShardOwnershipLostError() {
-    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 4); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:shardOwnershipLostError: ", p), err) }
+    if err := oprot.WriteFieldBegin("shardOwnershipLostError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:shardOwnershipLostError: ", p), err) }
     if err := p.ShardOwnershipLostError.Write(oprot); err != nil {
       return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ShardOwnershipLostError), err)
     }
     if err := oprot.WriteFieldEnd(); err != nil {
-      return thrift.PrependError(fmt.Sprintf("%T write field end error 4:shardOwnershipLostError: ", p), err) }
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:shardOwnershipLostError: ", p), err) }
   }
   return err
 }
 
-func (p *HistoryServiceRecordChildExecutionCompletedResult) String() string {
+func (p *HistoryServiceRedriveTransferDLQTasksResult) String() string {
   if p == nil {
     return "<nil>"
   }
-  return fmt.Sprintf("HistoryServiceRecordChildExecutionCompletedResult(%+v)", *p)
+  return fmt.Sprintf("HistoryServiceRedriveTransferDLQTasksResult(%+v)", *p)
 }
 
 
@@ -38,20 +38,30 @@ var _ = shared.GoUnusedProtection__
 
 // TChanHistoryService is the interface that defines the server handler and client interface.
 type TChanHistoryService interface {
+	BatchGetWorkflowExecutionNextEventID(ctx thrift.Context, getRequest *BatchGetWorkflowExecutionNextEventIDRequest) (*BatchGetWorkflowExecutionNextEventIDResponse, error)
+	DescribeMutableState(ctx thrift.Context, request *DescribeMutableStateRequest) (*DescribeMutableStateResponse, error)
+	DescribeWorkflowExecution(ctx thrift.Context, describeRequest *DescribeWorkflowExecutionRequest) (*shared.DescribeWorkflowExecutionResponse, error)
+	GetSignalReceipt(ctx thrift.Context, receiptRequest *GetSignalReceiptRequest) (*shared.GetSignalReceiptResponse, error)
 	GetWorkflowExecutionNextEventID(ctx thrift.Context, getRequest *GetWorkflowExecutionNextEventIDRequest) (*GetWorkflowExecutionNextEventIDResponse, error)
+	ListStaleExecutions(ctx thrift.Context, listRequest *ListStaleExecutionsRequest) (*ListStaleExecutionsResponse, error)
+	MigrateWorkflowExecution(ctx thrift.Context, migrateRequest *MigrateWorkflowExecutionRequest) error
+	QueryWorkflow(ctx thrift.Context, queryRequest *QueryWorkflowRequest) (*shared.QueryWorkflowResponse, error)
 	RecordActivityTaskHeartbeat(ctx thrift.Context, heartbeatRequest *RecordActivityTaskHeartbeatRequest) (*shared.RecordActivityTaskHeartbeatResponse, error)
 	RecordActivityTaskStarted(ctx thrift.Context, addRequest *RecordActivityTaskStartedRequest) (*RecordActivityTaskStartedResponse, error)
 	RecordChildExecutionCompleted(ctx thrift.Context, completionRequest *RecordChildExecutionCompletedRequest) error
 	RecordDecisionTaskStarted(ctx thrift.Context, addRequest *RecordDecisionTaskStartedRequest) (*RecordDecisionTaskStartedResponse, error)
+	RedriveTransferDLQTasks(ctx thrift.Context, redriveRequest *RedriveTransferDLQTasksRequest) (*RedriveTransferDLQTasksResponse, error)
+	RefreshWorkflowTasks(ctx thrift.Context, refreshRequest *RefreshWorkflowTasksRequest) error
 	RequestCancelWorkflowExecution(ctx thrift.Context, cancelRequest *RequestCancelWorkflowExecutionRequest) error
 	RespondActivityTaskCanceled(ctx thrift.Context, canceledRequest *RespondActivityTaskCanceledRequest) error
 	RespondActivityTaskCompleted(ctx thrift.Context, completeRequest *RespondActivityTaskCompletedRequest) error
 	RespondActivityTaskFailed(ctx thrift.Context, failRequest *RespondActivityTaskFailedRequest) error
-	RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *RespondDecisionTaskCompletedRequest) error
+	RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *RespondDecisionTaskCompletedRequest) (*shared.RespondDecisionTaskCompletedResponse, error)
 	ScheduleDecisionTask(ctx thrift.Context, scheduleRequest *ScheduleDecisionTaskRequest) error
-	SignalWorkflowExecution(ctx thrift.Context, signalRequest *SignalWorkflowExecutionRequest) error
+	SignalWorkflowExecution(ctx thrift.Context, signalRequest *SignalWorkflowExecutionRequest) (*shared.SignalWorkflowExecutionResponse, error)
 	StartWorkflowExecution(ctx thrift.Context, startRequest *StartWorkflowExecutionRequest) (*shared.StartWorkflowExecutionResponse, error)
-	TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *TerminateWorkflowExecutionRequest) error
+	TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *TerminateWorkflowExecutionRequest) (*shared.TerminateWorkflowExecutionResponse, error)
+	UpdateQueueProcessing(ctx thrift.Context, updateRequest *UpdateQueueProcessingRequest) error
 }
 
 // Implementation of a client and service handler.
@@ -73,6 +83,98 @@ func NewTChanHistoryServiceClient(client thrift.TChanClient) TChanHistoryService
 	return NewTChanHistoryServiceInheritedClient("HistoryService", client)
 }
 
+func (c *tchanHistoryServiceClient) BatchGetWorkflowExecutionNextEventID(ctx thrift.Context, getRequest *BatchGetWorkflowExecutionNextEventIDRequest) (*BatchGetWorkflowExecutionNextEventIDResponse, error) {
+	var resp HistoryServiceBatchGetWorkflowExecutionNextEventIDResult
+	args := HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs{
+		GetRequest: getRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "BatchGetWorkflowExecutionNextEventID", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for BatchGetWorkflowExecutionNextEventID")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
+func (c *tchanHistoryServiceClient) DescribeMutableState(ctx thrift.Context, request *DescribeMutableStateRequest) (*DescribeMutableStateResponse, error) {
+	var resp HistoryServiceDescribeMutableStateResult
+	args := HistoryServiceDescribeMutableStateArgs{
+		Request: request,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "DescribeMutableState", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		case resp.ShardOwnershipLostError != nil:
+			err = resp.ShardOwnershipLostError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for DescribeMutableState")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
+func (c *tchanHistoryServiceClient) DescribeWorkflowExecution(ctx thrift.Context, describeRequest *DescribeWorkflowExecutionRequest) (*shared.DescribeWorkflowExecutionResponse, error) {
+	var resp HistoryServiceDescribeWorkflowExecutionResult
+	args := HistoryServiceDescribeWorkflowExecutionArgs{
+		DescribeRequest: describeRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "DescribeWorkflowExecution", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		case resp.ShardOwnershipLostError != nil:
+			err = resp.ShardOwnershipLostError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for DescribeWorkflowExecution")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
+func (c *tchanHistoryServiceClient) GetSignalReceipt(ctx thrift.Context, receiptRequest *GetSignalReceiptRequest) (*shared.GetSignalReceiptResponse, error) {
+	var resp HistoryServiceGetSignalReceiptResult
+	args := HistoryServiceGetSignalReceiptArgs{
+		ReceiptRequest: receiptRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "GetSignalReceipt", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		case resp.ShardOwnershipLostError != nil:
+			err = resp.ShardOwnershipLostError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for GetSignalReceipt")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanHistoryServiceClient) GetWorkflowExecutionNextEventID(ctx thrift.Context, getRequest *GetWorkflowExecutionNextEventIDRequest) (*GetWorkflowExecutionNextEventIDResponse, error) {
 	var resp HistoryServiceGetWorkflowExecutionNextEventIDResult
 	args := HistoryServiceGetWorkflowExecutionNextEventIDArgs{
@@ -97,6 +199,80 @@ func (c *tchanHistoryServiceClient) GetWorkflowExecutionNextEventID(ctx thrift.C
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanHistoryServiceClient) ListStaleExecutions(ctx thrift.Context, listRequest *ListStaleExecutionsRequest) (*ListStaleExecutionsResponse, error) {
+	var resp HistoryServiceListStaleExecutionsResult
+	args := HistoryServiceListStaleExecutionsArgs{
+		ListRequest: listRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "ListStaleExecutions", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.ShardOwnershipLostError != nil:
+			err = resp.ShardOwnershipLostError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for ListStaleExecutions")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
+func (c *tchanHistoryServiceClient) MigrateWorkflowExecution(ctx thrift.Context, migrateRequest *MigrateWorkflowExecutionRequest) error {
+	var resp HistoryServiceMigrateWorkflowExecutionResult
+	args := HistoryServiceMigrateWorkflowExecutionArgs{
+		MigrateRequest: migrateRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "MigrateWorkflowExecution", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		case resp.ShardOwnershipLostError != nil:
+			err = resp.ShardOwnershipLostError
+		case resp.WorkflowAlreadyStartedError != nil:
+			err = resp.WorkflowAlreadyStartedError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for MigrateWorkflowExecution")
+		}
+	}
+
+	return err
+}
+
+func (c *tchanHistoryServiceClient) QueryWorkflow(ctx thrift.Context, queryRequest *QueryWorkflowRequest) (*shared.QueryWorkflowResponse, error) {
+	var resp HistoryServiceQueryWorkflowResult
+	args := HistoryServiceQueryWorkflowArgs{
+		QueryRequest: queryRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "QueryWorkflow", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		case resp.QueryFailedError != nil:
+			err = resp.QueryFailedError
+		case resp.ShardOwnershipLostError != nil:
+			err = resp.ShardOwnershipLostError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for QueryWorkflow")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanHistoryServiceClient) RecordActivityTaskHeartbeat(ctx thrift.Context, heartbeatRequest *RecordActivityTaskHeartbeatRequest) (*shared.RecordActivityTaskHeartbeatResponse, error) {
 	var resp HistoryServiceRecordActivityTaskHeartbeatResult
 	args := HistoryServiceRecordActivityTaskHeartbeatArgs{
@@ -113,6 +289,8 @@ func (c *tchanHistoryServiceClient) RecordActivityTaskHeartbeat(ctx thrift.Conte
 			err = resp.EntityNotExistError
 		case resp.ShardOwnershipLostError != nil:
 			err = resp.ShardOwnershipLostError
+		case resp.ServiceBusyError != nil:
+			err = resp.ServiceBusyError
 		default:
 			err = fmt.Errorf("received no result or unknown exception for RecordActivityTaskHeartbeat")
 		}
@@ -139,6 +317,8 @@ func (c *tchanHistoryServiceClient) RecordActivityTaskStarted(ctx thrift.Context
 			err = resp.EntityNotExistError
 		case resp.ShardOwnershipLostError != nil:
 			err = resp.ShardOwnershipLostError
+		case resp.ServiceBusyError != nil:
+			err = resp.ServiceBusyError
 		default:
 			err = fmt.Errorf("received no result or unknown exception for RecordActivityTaskStarted")
 		}
@@ -189,6 +369,8 @@ func (c *tchanHistoryServiceClient) RecordDecisionTaskStarted(ctx thrift.Context
 			err = resp.EntityNotExistError
 		case resp.ShardOwnershipLostError != nil:
 			err = resp.ShardOwnershipLostError
+		case resp.ServiceBusyError != nil:
+			err = resp.ServiceBusyError
 		default:
 			err = fmt.Errorf("received no result or unknown exception for RecordDecisionTaskStarted")
 		}
@@ -197,6 +379,52 @@ func (c *tchanHistoryServiceClient) RecordDecisionTaskStarted(ctx thrift.Context
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanHistoryServiceClient) RedriveTransferDLQTasks(ctx thrift.Context, redriveRequest *RedriveTransferDLQTasksRequest) (*RedriveTransferDLQTasksResponse, error) {
+	var resp HistoryServiceRedriveTransferDLQTasksResult
+	args := HistoryServiceRedriveTransferDLQTasksArgs{
+		RedriveRequest: redriveRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "RedriveTransferDLQTasks", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.ShardOwnershipLostError != nil:
+			err = resp.ShardOwnershipLostError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for RedriveTransferDLQTasks")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
+func (c *tchanHistoryServiceClient) RefreshWorkflowTasks(ctx thrift.Context, refreshRequest *RefreshWorkflowTasksRequest) error {
+	var resp HistoryServiceRefreshWorkflowTasksResult
+	args := HistoryServiceRefreshWorkflowTasksArgs{
+		RefreshRequest: refreshRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "RefreshWorkflowTasks", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.EntityNotExistError != nil:
+			err = resp.EntityNotExistError
+		case resp.ShardOwnershipLostError != nil:
+			err = resp.ShardOwnershipLostError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for RefreshWorkflowTasks")
+		}
+	}
+
+	return err
+}
+
 func (c *tchanHistoryServiceClient) RequestCancelWorkflowExecution(ctx thrift.Context, cancelRequest *RequestCancelWorkflowExecutionRequest) error {
 	var resp HistoryServiceRequestCancelWorkflowExecutionResult
 	args := HistoryServiceRequestCancelWorkflowExecutionArgs{
@@ -293,7 +521,7 @@ func (c *tchanHistoryServiceClient) RespondActivityTaskFailed(ctx thrift.Context
 	return err
 }
 
-func (c *tchanHistoryServiceClient) RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *RespondDecisionTaskCompletedRequest) error {
+func (c *tchanHistoryServiceClient) RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *RespondDecisionTaskCompletedRequest) (*shared.RespondDecisionTaskCompletedResponse, error) {
 	var resp HistoryServiceRespondDecisionTaskCompletedResult
 	args := HistoryServiceRespondDecisionTaskCompletedArgs{
 		CompleteRequest: completeRequest,
@@ -314,7 +542,7 @@ func (c *tchanHistoryServiceClient) RespondDecisionTaskCompleted(ctx thrift.Cont
 		}
 	}
 
-	return err
+	return resp.GetSuccess(), err
 }
 
 func (c *tchanHistoryServiceClient) ScheduleDecisionTask(ctx thrift.Context, scheduleRequest *ScheduleDecisionTaskRequest) error {
@@ -341,7 +569,7 @@ func (c *tchanHistoryServiceClient) ScheduleDecisionTask(ctx thrift.Context, sch
 	return err
 }
 
-func (c *tchanHistoryServiceClient) SignalWorkflowExecution(ctx thrift.Context, signalRequest *SignalWorkflowExecutionRequest) error {
+func (c *tchanHistoryServiceClient) SignalWorkflowExecution(ctx thrift.Context, signalRequest *SignalWorkflowExecutionRequest) (*shared.SignalWorkflowExecutionResponse, error) {
 	var resp HistoryServiceSignalWorkflowExecutionResult
 	args := HistoryServiceSignalWorkflowExecutionArgs{
 		SignalRequest: signalRequest,
@@ -362,7 +590,7 @@ func (c *tchanHistoryServiceClient) SignalWorkflowExecution(ctx thrift.Context,
 		}
 	}
 
-	return err
+	return resp.GetSuccess(), err
 }
 
 func (c *tchanHistoryServiceClient) StartWorkflowExecution(ctx thrift.Context, startRequest *StartWorkflowExecutionRequest) (*shared.StartWorkflowExecutionResponse, error) {
@@ -381,6 +609,10 @@ func (c *tchanHistoryServiceClient) StartWorkflowExecution(ctx thrift.Context, s
 			err = resp.SessionAlreadyExistError
 		case resp.ShardOwnershipLostError != nil:
 			err = resp.ShardOwnershipLostError
+		case resp.DomainNotActiveError != nil:
+			err = resp.DomainNotActiveError
+		case resp.LimitExceededError != nil:
+			err = resp.LimitExceededError
 		default:
 			err = fmt.Errorf("received no result or unknown exception for StartWorkflowExecution")
 		}
@@ -389,7 +621,7 @@ func (c *tchanHistoryServiceClient) StartWorkflowExecution(ctx thrift.Context, s
 	return resp.GetSuccess(), err
 }
 
-func (c *tchanHistoryServiceClient) TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *TerminateWorkflowExecutionRequest) error {
+func (c *tchanHistoryServiceClient) TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *TerminateWorkflowExecutionRequest) (*shared.TerminateWorkflowExecutionResponse, error) {
 	var resp HistoryServiceTerminateWorkflowExecutionResult
 	args := HistoryServiceTerminateWorkflowExecutionArgs{
 		TerminateRequest: terminateRequest,
@@ -410,6 +642,28 @@ func (c *tchanHistoryServiceClient) TerminateWorkflowExecution(ctx thrift.Contex
 		}
 	}
 
+	return resp.GetSuccess(), err
+}
+
+func (c *tchanHistoryServiceClient) UpdateQueueProcessing(ctx thrift.Context, updateRequest *UpdateQueueProcessingRequest) error {
+	var resp HistoryServiceUpdateQueueProcessingResult
+	args := HistoryServiceUpdateQueueProcessingArgs{
+		UpdateRequest: updateRequest,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "UpdateQueueProcessing", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.BadRequestError != nil:
+			err = resp.BadRequestError
+		case resp.InternalServiceError != nil:
+			err = resp.InternalServiceError
+		case resp.ShardOwnershipLostError != nil:
+			err = resp.ShardOwnershipLostError
+		default:
+			err = fmt.Errorf("received no result or unknown exception for UpdateQueueProcessing")
+		}
+	}
+
 	return err
 }
 
@@ -431,11 +685,20 @@ func (s *tchanHistoryServiceServer) Service() string {
 
 func (s *tchanHistoryServiceServer) Methods() []string {
 	return []string{
+		"BatchGetWorkflowExecutionNextEventID",
+		"DescribeMutableState",
+		"DescribeWorkflowExecution",
+		"GetSignalReceipt",
 		"GetWorkflowExecutionNextEventID",
+		"ListStaleExecutions",
+		"MigrateWorkflowExecution",
+		"QueryWorkflow",
 		"RecordActivityTaskHeartbeat",
 		"RecordActivityTaskStarted",
 		"RecordChildExecutionCompleted",
 		"RecordDecisionTaskStarted",
+		"RedriveTransferDLQTasks",
+		"RefreshWorkflowTasks",
 		"RequestCancelWorkflowExecution",
 		"RespondActivityTaskCanceled",
 		"RespondActivityTaskCompleted",
@@ -445,13 +708,28 @@ func (s *tchanHistoryServiceServer) Methods() []string {
 		"SignalWorkflowExecution",
 		"StartWorkflowExecution",
 		"TerminateWorkflowExecution",
+		"UpdateQueueProcessing",
 	}
 }
 
 func (s *tchanHistoryServiceServer) Handle(ctx thrift.Context, methodName string, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	switch methodName {
+	case "BatchGetWorkflowExecutionNextEventID":
+		return s.handleBatchGetWorkflowExecutionNextEventID(ctx, protocol)
+	case "DescribeMutableState":
+		return s.handleDescribeMutableState(ctx, protocol)
+	case "DescribeWorkflowExecution":
+		return s.handleDescribeWorkflowExecution(ctx, protocol)
+	case "GetSignalReceipt":
+		return s.handleGetSignalReceipt(ctx, protocol)
 	case "GetWorkflowExecutionNextEventID":
 		return s.handleGetWorkflowExecutionNextEventID(ctx, protocol)
+	case "ListStaleExecutions":
+		return s.handleListStaleExecutions(ctx, protocol)
+	case "MigrateWorkflowExecution":
+		return s.handleMigrateWorkflowExecution(ctx, protocol)
+	case "QueryWorkflow":
+		return s.handleQueryWorkflow(ctx, protocol)
 	case "RecordActivityTaskHeartbeat":
 		return s.handleRecordActivityTaskHeartbeat(ctx, protocol)
 	case "RecordActivityTaskStarted":
@@ -460,6 +738,10 @@ func (s *tchanHistoryServiceServer) Handle(ctx thrift.Context, methodName string
 		return s.handleRecordChildExecutionCompleted(ctx, protocol)
 	case "RecordDecisionTaskStarted":
 		return s.handleRecordDecisionTaskStarted(ctx, protocol)
+	case "RedriveTransferDLQTasks":
+		return s.handleRedriveTransferDLQTasks(ctx, protocol)
+	case "RefreshWorkflowTasks":
+		return s.handleRefreshWorkflowTasks(ctx, protocol)
 	case "RequestCancelWorkflowExecution":
 		return s.handleRequestCancelWorkflowExecution(ctx, protocol)
 	case "RespondActivityTaskCanceled":
@@ -478,22 +760,24 @@ func (s *tchanHistoryServiceServer) Handle(ctx thrift.Context, methodName string
 		return s.handleStartWorkflowExecution(ctx, protocol)
 	case "TerminateWorkflowExecution":
 		return s.handleTerminateWorkflowExecution(ctx, protocol)
+	case "UpdateQueueProcessing":
+		return s.handleUpdateQueueProcessing(ctx, protocol)
 
 	default:
 		return false, nil, fmt.Errorf("method %v not found in service %v", methodName, s.Service())
 	}
 }
 
-func (s *tchanHistoryServiceServer) handleGetWorkflowExecutionNextEventID(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req HistoryServiceGetWorkflowExecutionNextEventIDArgs
-	var res HistoryServiceGetWorkflowExecutionNextEventIDResult
+func (s *tchanHistoryServiceServer) handleBatchGetWorkflowExecutionNextEventID(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceBatchGetWorkflowExecutionNextEventIDArgs
+	var res HistoryServiceBatchGetWorkflowExecutionNextEventIDResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	r, err :=
-		s.handler.GetWorkflowExecutionNextEventID(ctx, req.GetRequest)
+		s.handler.BatchGetWorkflowExecutionNextEventID(ctx, req.GetRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -507,16 +791,6 @@ func (s *tchanHistoryServiceServer) handleGetWorkflowExecutionNextEventID(ctx th
 				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
 			}
 			res.InternalServiceError = v
-		case *shared.EntityNotExistsError:
-			if v == nil {
-				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
-			}
-			res.EntityNotExistError = v
-		case *ShardOwnershipLostError:
-			if v == nil {
-				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
-			}
-			res.ShardOwnershipLostError = v
 		default:
 			return false, nil, err
 		}
@@ -527,16 +801,16 @@ func (s *tchanHistoryServiceServer) handleGetWorkflowExecutionNextEventID(ctx th
 	return err == nil, &res, nil
 }
 
-func (s *tchanHistoryServiceServer) handleRecordActivityTaskHeartbeat(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req HistoryServiceRecordActivityTaskHeartbeatArgs
-	var res HistoryServiceRecordActivityTaskHeartbeatResult
+func (s *tchanHistoryServiceServer) handleDescribeMutableState(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceDescribeMutableStateArgs
+	var res HistoryServiceDescribeMutableStateResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	r, err :=
-		s.handler.RecordActivityTaskHeartbeat(ctx, req.HeartbeatRequest)
+		s.handler.DescribeMutableState(ctx, req.Request)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -570,16 +844,16 @@ func (s *tchanHistoryServiceServer) handleRecordActivityTaskHeartbeat(ctx thrift
 	return err == nil, &res, nil
 }
 
-func (s *tchanHistoryServiceServer) handleRecordActivityTaskStarted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req HistoryServiceRecordActivityTaskStartedArgs
-	var res HistoryServiceRecordActivityTaskStartedResult
+func (s *tchanHistoryServiceServer) handleDescribeWorkflowExecution(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceDescribeWorkflowExecutionArgs
+	var res HistoryServiceDescribeWorkflowExecutionResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	r, err :=
-		s.handler.RecordActivityTaskStarted(ctx, req.AddRequest)
+		s.handler.DescribeWorkflowExecution(ctx, req.DescribeRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -593,11 +867,6 @@ func (s *tchanHistoryServiceServer) handleRecordActivityTaskStarted(ctx thrift.C
 				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
 			}
 			res.InternalServiceError = v
-		case *EventAlreadyStartedError:
-			if v == nil {
-				return false, nil, fmt.Errorf("Handler for eventAlreadyStartedError returned non-nil error type *EventAlreadyStartedError but nil value")
-			}
-			res.EventAlreadyStartedError = v
 		case *shared.EntityNotExistsError:
 			if v == nil {
 				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
@@ -618,16 +887,16 @@ func (s *tchanHistoryServiceServer) handleRecordActivityTaskStarted(ctx thrift.C
 	return err == nil, &res, nil
 }
 
-func (s *tchanHistoryServiceServer) handleRecordChildExecutionCompleted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req HistoryServiceRecordChildExecutionCompletedArgs
-	var res HistoryServiceRecordChildExecutionCompletedResult
+func (s *tchanHistoryServiceServer) handleGetSignalReceipt(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceGetSignalReceiptArgs
+	var res HistoryServiceGetSignalReceiptResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
-	err :=
-		s.handler.RecordChildExecutionCompleted(ctx, req.CompletionRequest)
+	r, err :=
+		s.handler.GetSignalReceipt(ctx, req.ReceiptRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -655,21 +924,22 @@ func (s *tchanHistoryServiceServer) handleRecordChildExecutionCompleted(ctx thri
 			return false, nil, err
 		}
 	} else {
+		res.Success = r
 	}
 
 	return err == nil, &res, nil
 }
 
-func (s *tchanHistoryServiceServer) handleRecordDecisionTaskStarted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req HistoryServiceRecordDecisionTaskStartedArgs
-	var res HistoryServiceRecordDecisionTaskStartedResult
+func (s *tchanHistoryServiceServer) handleGetWorkflowExecutionNextEventID(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceGetWorkflowExecutionNextEventIDArgs
+	var res HistoryServiceGetWorkflowExecutionNextEventIDResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	r, err :=
-		s.handler.RecordDecisionTaskStarted(ctx, req.AddRequest)
+		s.handler.GetWorkflowExecutionNextEventID(ctx, req.GetRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -683,11 +953,6 @@ func (s *tchanHistoryServiceServer) handleRecordDecisionTaskStarted(ctx thrift.C
 				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
 			}
 			res.InternalServiceError = v
-		case *EventAlreadyStartedError:
-			if v == nil {
-				return false, nil, fmt.Errorf("Handler for eventAlreadyStartedError returned non-nil error type *EventAlreadyStartedError but nil value")
-			}
-			res.EventAlreadyStartedError = v
 		case *shared.EntityNotExistsError:
 			if v == nil {
 				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
@@ -708,16 +973,16 @@ func (s *tchanHistoryServiceServer) handleRecordDecisionTaskStarted(ctx thrift.C
 	return err == nil, &res, nil
 }
 
-func (s *tchanHistoryServiceServer) handleRequestCancelWorkflowExecution(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req HistoryServiceRequestCancelWorkflowExecutionArgs
-	var res HistoryServiceRequestCancelWorkflowExecutionResult
+func (s *tchanHistoryServiceServer) handleListStaleExecutions(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceListStaleExecutionsArgs
+	var res HistoryServiceListStaleExecutionsResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
-	err :=
-		s.handler.RequestCancelWorkflowExecution(ctx, req.CancelRequest)
+	r, err :=
+		s.handler.ListStaleExecutions(ctx, req.ListRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -731,11 +996,6 @@ func (s *tchanHistoryServiceServer) handleRequestCancelWorkflowExecution(ctx thr
 				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
 			}
 			res.InternalServiceError = v
-		case *shared.EntityNotExistsError:
-			if v == nil {
-				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
-			}
-			res.EntityNotExistError = v
 		case *ShardOwnershipLostError:
 			if v == nil {
 				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
@@ -745,21 +1005,22 @@ func (s *tchanHistoryServiceServer) handleRequestCancelWorkflowExecution(ctx thr
 			return false, nil, err
 		}
 	} else {
+		res.Success = r
 	}
 
 	return err == nil, &res, nil
 }
 
-func (s *tchanHistoryServiceServer) handleRespondActivityTaskCanceled(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req HistoryServiceRespondActivityTaskCanceledArgs
-	var res HistoryServiceRespondActivityTaskCanceledResult
+func (s *tchanHistoryServiceServer) handleMigrateWorkflowExecution(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceMigrateWorkflowExecutionArgs
+	var res HistoryServiceMigrateWorkflowExecutionResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
 	err :=
-		s.handler.RespondActivityTaskCanceled(ctx, req.CanceledRequest)
+		s.handler.MigrateWorkflowExecution(ctx, req.MigrateRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -783,6 +1044,11 @@ func (s *tchanHistoryServiceServer) handleRespondActivityTaskCanceled(ctx thrift
 				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
 			}
 			res.ShardOwnershipLostError = v
+		case *shared.WorkflowExecutionAlreadyStartedError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for workflowAlreadyStartedError returned non-nil error type *shared.WorkflowExecutionAlreadyStartedError but nil value")
+			}
+			res.WorkflowAlreadyStartedError = v
 		default:
 			return false, nil, err
 		}
@@ -792,16 +1058,16 @@ func (s *tchanHistoryServiceServer) handleRespondActivityTaskCanceled(ctx thrift
 	return err == nil, &res, nil
 }
 
-func (s *tchanHistoryServiceServer) handleRespondActivityTaskCompleted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req HistoryServiceRespondActivityTaskCompletedArgs
-	var res HistoryServiceRespondActivityTaskCompletedResult
+func (s *tchanHistoryServiceServer) handleQueryWorkflow(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceQueryWorkflowArgs
+	var res HistoryServiceQueryWorkflowResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
 	}
 
-	err :=
-		s.handler.RespondActivityTaskCompleted(ctx, req.CompleteRequest)
+	r, err :=
+		s.handler.QueryWorkflow(ctx, req.QueryRequest)
 
 	if err != nil {
 		switch v := err.(type) {
@@ -820,6 +1086,11 @@ func (s *tchanHistoryServiceServer) handleRespondActivityTaskCompleted(ctx thrif
 				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
 			}
 			res.EntityNotExistError = v
+		case *shared.QueryFailedError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for queryFailedError returned non-nil error type *shared.QueryFailedError but nil value")
+			}
+			res.QueryFailedError = v
 		case *ShardOwnershipLostError:
 			if v == nil {
 				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
@@ -829,14 +1100,417 @@ func (s *tchanHistoryServiceServer) handleRespondActivityTaskCompleted(ctx thrif
 			return false, nil, err
 		}
 	} else {
+		res.Success = r
 	}
 
 	return err == nil, &res, nil
 }
 
-func (s *tchanHistoryServiceServer) handleRespondActivityTaskFailed(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
-	var req HistoryServiceRespondActivityTaskFailedArgs
-	var res HistoryServiceRespondActivityTaskFailedResult
+func (s *tchanHistoryServiceServer) handleRecordActivityTaskHeartbeat(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRecordActivityTaskHeartbeatArgs
+	var res HistoryServiceRecordActivityTaskHeartbeatResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.RecordActivityTaskHeartbeat(ctx, req.HeartbeatRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		case *shared.ServiceBusyError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for serviceBusyError returned non-nil error type *shared.ServiceBusyError but nil value")
+			}
+			res.ServiceBusyError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleRecordActivityTaskStarted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRecordActivityTaskStartedArgs
+	var res HistoryServiceRecordActivityTaskStartedResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.RecordActivityTaskStarted(ctx, req.AddRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *EventAlreadyStartedError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for eventAlreadyStartedError returned non-nil error type *EventAlreadyStartedError but nil value")
+			}
+			res.EventAlreadyStartedError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		case *shared.ServiceBusyError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for serviceBusyError returned non-nil error type *shared.ServiceBusyError but nil value")
+			}
+			res.ServiceBusyError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleRecordChildExecutionCompleted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRecordChildExecutionCompletedArgs
+	var res HistoryServiceRecordChildExecutionCompletedResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.RecordChildExecutionCompleted(ctx, req.CompletionRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleRecordDecisionTaskStarted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRecordDecisionTaskStartedArgs
+	var res HistoryServiceRecordDecisionTaskStartedResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.RecordDecisionTaskStarted(ctx, req.AddRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *EventAlreadyStartedError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for eventAlreadyStartedError returned non-nil error type *EventAlreadyStartedError but nil value")
+			}
+			res.EventAlreadyStartedError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		case *shared.ServiceBusyError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for serviceBusyError returned non-nil error type *shared.ServiceBusyError but nil value")
+			}
+			res.ServiceBusyError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleRedriveTransferDLQTasks(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRedriveTransferDLQTasksArgs
+	var res HistoryServiceRedriveTransferDLQTasksResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.RedriveTransferDLQTasks(ctx, req.RedriveRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleRefreshWorkflowTasks(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRefreshWorkflowTasksArgs
+	var res HistoryServiceRefreshWorkflowTasksResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.RefreshWorkflowTasks(ctx, req.RefreshRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleRequestCancelWorkflowExecution(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRequestCancelWorkflowExecutionArgs
+	var res HistoryServiceRequestCancelWorkflowExecutionResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.RequestCancelWorkflowExecution(ctx, req.CancelRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleRespondActivityTaskCanceled(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRespondActivityTaskCanceledArgs
+	var res HistoryServiceRespondActivityTaskCanceledResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.RespondActivityTaskCanceled(ctx, req.CanceledRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleRespondActivityTaskCompleted(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRespondActivityTaskCompletedArgs
+	var res HistoryServiceRespondActivityTaskCompletedResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.RespondActivityTaskCompleted(ctx, req.CompleteRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *shared.EntityNotExistsError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for entityNotExistError returned non-nil error type *shared.EntityNotExistsError but nil value")
+			}
+			res.EntityNotExistError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		default:
+			return false, nil, err
+		}
+	} else {
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleRespondActivityTaskFailed(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceRespondActivityTaskFailedArgs
+	var res HistoryServiceRespondActivityTaskFailedResult
 
 	if err := req.Read(protocol); err != nil {
 		return false, nil, err
@@ -884,7 +1558,7 @@ func (s *tchanHistoryServiceServer) handleRespondDecisionTaskCompleted(ctx thrif
 		return false, nil, err
 	}
 
-	err :=
+	r, err :=
 		s.handler.RespondDecisionTaskCompleted(ctx, req.CompleteRequest)
 
 	if err != nil {
@@ -913,6 +1587,7 @@ func (s *tchanHistoryServiceServer) handleRespondDecisionTaskCompleted(ctx thrif
 			return false, nil, err
 		}
 	} else {
+		res.Success = r
 	}
 
 	return err == nil, &res, nil
@@ -968,7 +1643,7 @@ func (s *tchanHistoryServiceServer) handleSignalWorkflowExecution(ctx thrift.Con
 		return false, nil, err
 	}
 
-	err :=
+	r, err :=
 		s.handler.SignalWorkflowExecution(ctx, req.SignalRequest)
 
 	if err != nil {
@@ -997,6 +1672,7 @@ func (s *tchanHistoryServiceServer) handleSignalWorkflowExecution(ctx thrift.Con
 			return false, nil, err
 		}
 	} else {
+		res.Success = r
 	}
 
 	return err == nil, &res, nil
@@ -1035,6 +1711,16 @@ func (s *tchanHistoryServiceServer) handleStartWorkflowExecution(ctx thrift.Cont
 				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
 			}
 			res.ShardOwnershipLostError = v
+		case *shared.DomainNotActiveError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for domainNotActiveError returned non-nil error type *shared.DomainNotActiveError but nil value")
+			}
+			res.DomainNotActiveError = v
+		case *shared.LimitExceededError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for limitExceededError returned non-nil error type *shared.LimitExceededError but nil value")
+			}
+			res.LimitExceededError = v
 		default:
 			return false, nil, err
 		}
@@ -1053,7 +1739,7 @@ func (s *tchanHistoryServiceServer) handleTerminateWorkflowExecution(ctx thrift.
 		return false, nil, err
 	}
 
-	err :=
+	r, err :=
 		s.handler.TerminateWorkflowExecution(ctx, req.TerminateRequest)
 
 	if err != nil {
@@ -1081,6 +1767,44 @@ func (s *tchanHistoryServiceServer) handleTerminateWorkflowExecution(ctx thrift.
 		default:
 			return false, nil, err
 		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
+func (s *tchanHistoryServiceServer) handleUpdateQueueProcessing(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req HistoryServiceUpdateQueueProcessingArgs
+	var res HistoryServiceUpdateQueueProcessingResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	err :=
+		s.handler.UpdateQueueProcessing(ctx, req.UpdateRequest)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *shared.BadRequestError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for badRequestError returned non-nil error type *shared.BadRequestError but nil value")
+			}
+			res.BadRequestError = v
+		case *shared.InternalServiceError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for internalServiceError returned non-nil error type *shared.InternalServiceError but nil value")
+			}
+			res.InternalServiceError = v
+		case *ShardOwnershipLostError:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for shardOwnershipLostError returned non-nil error type *ShardOwnershipLostError but nil value")
+			}
+			res.ShardOwnershipLostError = v
+		default:
+			return false, nil, err
+		}
 	} else {
 	}
 
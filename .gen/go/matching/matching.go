@@ -178,6 +178,7 @@ func (p *PollForDecisionTaskRequest) String() string {
 //  - WorkflowType
 //  - PreviousStartedEventId
 //  - StartedEventId
+//  - Queries
 type PollForDecisionTaskResponse struct {
   // unused fields # 1 to 9
   TaskToken []byte `thrift:"taskToken,10" db:"taskToken" json:"taskToken,omitempty"`
@@ -189,6 +190,8 @@ type PollForDecisionTaskResponse struct {
   PreviousStartedEventId *int64 `thrift:"previousStartedEventId,40" db:"previousStartedEventId" json:"previousStartedEventId,omitempty"`
   // unused fields # 41 to 49
   StartedEventId *int64 `thrift:"startedEventId,50" db:"startedEventId" json:"startedEventId,omitempty"`
+  // unused fields # 51 to 59
+  Queries map[string]*shared.WorkflowQuery `thrift:"queries,60" db:"queries" json:"queries,omitempty"`
 }
 
 func NewPollForDecisionTaskResponse() *PollForDecisionTaskResponse {
@@ -228,6 +231,11 @@ func (p *PollForDecisionTaskResponse) GetStartedEventId() int64 {
   }
 return *p.StartedEventId
 }
+var PollForDecisionTaskResponse_Queries_DEFAULT map[string]*shared.WorkflowQuery
+
+func (p *PollForDecisionTaskResponse) GetQueries() map[string]*shared.WorkflowQuery {
+  return p.Queries
+}
 func (p *PollForDecisionTaskResponse) IsSetTaskToken() bool {
   return p.TaskToken != nil
 }
@@ -248,6 +256,10 @@ func (p *PollForDecisionTaskResponse) IsSetStartedEventId() bool {
   return p.StartedEventId != nil
 }
 
+func (p *PollForDecisionTaskResponse) IsSetQueries() bool {
+  return p.Queries != nil
+}
+
 func (p *PollForDecisionTaskResponse) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -281,6 +293,10 @@ func (p *PollForDecisionTaskResponse) Read(iprot thrift.TProtocol) error {
       if err := p.ReadField50(iprot); err != nil {
         return err
       }
+    case 60:
+      if err := p.ReadField60(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -339,6 +355,32 @@ func (p *PollForDecisionTaskResponse)  ReadField50(iprot thrift.TProtocol) error
   return nil
 }
 
+func (p *PollForDecisionTaskResponse)  ReadField60(iprot thrift.TProtocol) error {
+  _, _, size, err := iprot.ReadMapBegin()
+  if err != nil {
+    return thrift.PrependError("error reading map begin: ", err)
+  }
+  tMap := make(map[string]*shared.WorkflowQuery, size)
+  p.Queries =  tMap
+  for i := 0; i < size; i ++ {
+var _key0 string
+    if v, err := iprot.ReadString(); err != nil {
+    return thrift.PrependError("error reading field 0: ", err)
+} else {
+    _key0 = v
+}
+    _val1 := &shared.WorkflowQuery{}
+    if err := _val1.Read(iprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val1), err)
+    }
+    p.Queries[_key0] = _val1
+  }
+  if err := iprot.ReadMapEnd(); err != nil {
+    return thrift.PrependError("error reading map end: ", err)
+  }
+  return nil
+}
+
 func (p *PollForDecisionTaskResponse) Write(oprot thrift.TProtocol) error {
   if err := oprot.WriteStructBegin("PollForDecisionTaskResponse"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
@@ -348,6 +390,7 @@ func (p *PollForDecisionTaskResponse) Write(oprot thrift.TProtocol) error {
     if err := p.writeField30(oprot); err != nil { return err }
     if err := p.writeField40(oprot); err != nil { return err }
     if err := p.writeField50(oprot); err != nil { return err }
+    if err := p.writeField60(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -418,6 +461,29 @@ func (p *PollForDecisionTaskResponse) writeField50(oprot thrift.TProtocol) (err
   return err
 }
 
+func (p *PollForDecisionTaskResponse) writeField60(oprot thrift.TProtocol) (err error) {
+  if p.IsSetQueries() {
+    if err := oprot.WriteFieldBegin("queries", thrift.MAP, 60); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 60:queries: ", p), err) }
+    if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.Queries)); err != nil {
+      return thrift.PrependError("error writing map begin: ", err)
+    }
+    for k, v := range p.Queries {
+      if err := oprot.WriteString(string(k)); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err) }
+      if err := v.Write(oprot); err != nil {
+        return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+      }
+    }
+    if err := oprot.WriteMapEnd(); err != nil {
+      return thrift.PrependError("error writing map end: ", err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 60:queries: ", p), err) }
+  }
+  return err
+}
+
 func (p *PollForDecisionTaskResponse) String() string {
   if p == nil {
     return "<nil>"
@@ -565,6 +631,8 @@ func (p *PollForActivityTaskRequest) String() string {
 //  - Execution
 //  - TaskList
 //  - ScheduleId
+//  - BuildId
+//  - QueryId
 type AddDecisionTaskRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
@@ -574,6 +642,10 @@ type AddDecisionTaskRequest struct {
   TaskList *shared.TaskList `thrift:"taskList,30" db:"taskList" json:"taskList,omitempty"`
   // unused fields # 31 to 39
   ScheduleId *int64 `thrift:"scheduleId,40" db:"scheduleId" json:"scheduleId,omitempty"`
+  // unused fields # 41 to 49
+  BuildId *string `thrift:"buildId,50" db:"buildId" json:"buildId,omitempty"`
+  // unused fields # 51 to 59
+  QueryId *string `thrift:"queryId,60" db:"queryId" json:"queryId,omitempty"`
 }
 
 func NewAddDecisionTaskRequest() *AddDecisionTaskRequest {
@@ -608,6 +680,20 @@ func (p *AddDecisionTaskRequest) GetScheduleId() int64 {
   }
 return *p.ScheduleId
 }
+var AddDecisionTaskRequest_BuildId_DEFAULT string
+func (p *AddDecisionTaskRequest) GetBuildId() string {
+  if !p.IsSetBuildId() {
+    return AddDecisionTaskRequest_BuildId_DEFAULT
+  }
+return *p.BuildId
+}
+var AddDecisionTaskRequest_QueryId_DEFAULT string
+func (p *AddDecisionTaskRequest) GetQueryId() string {
+  if !p.IsSetQueryId() {
+    return AddDecisionTaskRequest_QueryId_DEFAULT
+  }
+return *p.QueryId
+}
 func (p *AddDecisionTaskRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
@@ -624,6 +710,14 @@ func (p *AddDecisionTaskRequest) IsSetScheduleId() bool {
   return p.ScheduleId != nil
 }
 
+func (p *AddDecisionTaskRequest) IsSetBuildId() bool {
+  return p.BuildId != nil
+}
+
+func (p *AddDecisionTaskRequest) IsSetQueryId() bool {
+  return p.QueryId != nil
+}
+
 func (p *AddDecisionTaskRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -653,6 +747,14 @@ func (p *AddDecisionTaskRequest) Read(iprot thrift.TProtocol) error {
       if err := p.ReadField40(iprot); err != nil {
         return err
       }
+    case 50:
+      if err := p.ReadField50(iprot); err != nil {
+        return err
+      }
+    case 60:
+      if err := p.ReadField60(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -702,6 +804,24 @@ func (p *AddDecisionTaskRequest)  ReadField40(iprot thrift.TProtocol) error {
   return nil
 }
 
+func (p *AddDecisionTaskRequest)  ReadField50(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 50: ", err)
+} else {
+  p.BuildId = &v
+}
+  return nil
+}
+
+func (p *AddDecisionTaskRequest)  ReadField60(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 60: ", err)
+} else {
+  p.QueryId = &v
+}
+  return nil
+}
+
 func (p *AddDecisionTaskRequest) Write(oprot thrift.TProtocol) error {
   if err := oprot.WriteStructBegin("AddDecisionTaskRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
@@ -710,6 +830,8 @@ func (p *AddDecisionTaskRequest) Write(oprot thrift.TProtocol) error {
     if err := p.writeField20(oprot); err != nil { return err }
     if err := p.writeField30(oprot); err != nil { return err }
     if err := p.writeField40(oprot); err != nil { return err }
+    if err := p.writeField50(oprot); err != nil { return err }
+    if err := p.writeField60(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -768,6 +890,30 @@ func (p *AddDecisionTaskRequest) writeField40(oprot thrift.TProtocol) (err error
   return err
 }
 
+func (p *AddDecisionTaskRequest) writeField50(oprot thrift.TProtocol) (err error) {
+  if p.IsSetBuildId() {
+    if err := oprot.WriteFieldBegin("buildId", thrift.STRING, 50); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 50:buildId: ", p), err) }
+    if err := oprot.WriteString(string(*p.BuildId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.buildId (50) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 50:buildId: ", p), err) }
+  }
+  return err
+}
+
+func (p *AddDecisionTaskRequest) writeField60(oprot thrift.TProtocol) (err error) {
+  if p.IsSetQueryId() {
+    if err := oprot.WriteFieldBegin("queryId", thrift.STRING, 60); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 60:queryId: ", p), err) }
+    if err := oprot.WriteString(string(*p.QueryId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.queryId (60) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 60:queryId: ", p), err) }
+  }
+  return err
+}
+
 func (p *AddDecisionTaskRequest) String() string {
   if p == nil {
     return "<nil>"
@@ -782,6 +928,8 @@ func (p *AddDecisionTaskRequest) String() string {
 //  - TaskList
 //  - ScheduleId
 //  - ScheduleToStartTimeoutSeconds
+//  - Priority
+//  - SessionId
 type AddActivityTaskRequest struct {
   // unused fields # 1 to 9
   DomainUUID *string `thrift:"domainUUID,10" db:"domainUUID" json:"domainUUID,omitempty"`
@@ -795,6 +943,10 @@ type AddActivityTaskRequest struct {
   ScheduleId *int64 `thrift:"scheduleId,50" db:"scheduleId" json:"scheduleId,omitempty"`
   // unused fields # 51 to 59
   ScheduleToStartTimeoutSeconds *int32 `thrift:"scheduleToStartTimeoutSeconds,60" db:"scheduleToStartTimeoutSeconds" json:"scheduleToStartTimeoutSeconds,omitempty"`
+  // unused fields # 61 to 69
+  Priority *int32 `thrift:"priority,70" db:"priority" json:"priority,omitempty"`
+  // unused fields # 71 to 79
+  SessionId *string `thrift:"sessionId,80" db:"sessionId" json:"sessionId,omitempty"`
 }
 
 func NewAddActivityTaskRequest() *AddActivityTaskRequest {
@@ -843,6 +995,20 @@ func (p *AddActivityTaskRequest) GetScheduleToStartTimeoutSeconds() int32 {
   }
 return *p.ScheduleToStartTimeoutSeconds
 }
+var AddActivityTaskRequest_Priority_DEFAULT int32
+func (p *AddActivityTaskRequest) GetPriority() int32 {
+  if !p.IsSetPriority() {
+    return AddActivityTaskRequest_Priority_DEFAULT
+  }
+return *p.Priority
+}
+var AddActivityTaskRequest_SessionId_DEFAULT string
+func (p *AddActivityTaskRequest) GetSessionId() string {
+  if !p.IsSetSessionId() {
+    return AddActivityTaskRequest_SessionId_DEFAULT
+  }
+return *p.SessionId
+}
 func (p *AddActivityTaskRequest) IsSetDomainUUID() bool {
   return p.DomainUUID != nil
 }
@@ -867,6 +1033,14 @@ func (p *AddActivityTaskRequest) IsSetScheduleToStartTimeoutSeconds() bool {
   return p.ScheduleToStartTimeoutSeconds != nil
 }
 
+func (p *AddActivityTaskRequest) IsSetPriority() bool {
+  return p.Priority != nil
+}
+
+func (p *AddActivityTaskRequest) IsSetSessionId() bool {
+  return p.SessionId != nil
+}
+
 func (p *AddActivityTaskRequest) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -904,6 +1078,14 @@ func (p *AddActivityTaskRequest) Read(iprot thrift.TProtocol) error {
       if err := p.ReadField60(iprot); err != nil {
         return err
       }
+    case 70:
+      if err := p.ReadField70(iprot); err != nil {
+        return err
+      }
+    case 80:
+      if err := p.ReadField80(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -971,6 +1153,24 @@ func (p *AddActivityTaskRequest)  ReadField60(iprot thrift.TProtocol) error {
   return nil
 }
 
+func (p *AddActivityTaskRequest)  ReadField70(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadI32(); err != nil {
+  return thrift.PrependError("error reading field 70: ", err)
+} else {
+  p.Priority = &v
+}
+  return nil
+}
+
+func (p *AddActivityTaskRequest)  ReadField80(iprot thrift.TProtocol) error {
+  if v, err := iprot.ReadString(); err != nil {
+  return thrift.PrependError("error reading field 80: ", err)
+} else {
+  p.SessionId = &v
+}
+  return nil
+}
+
 func (p *AddActivityTaskRequest) Write(oprot thrift.TProtocol) error {
   if err := oprot.WriteStructBegin("AddActivityTaskRequest"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
@@ -981,6 +1181,8 @@ func (p *AddActivityTaskRequest) Write(oprot thrift.TProtocol) error {
     if err := p.writeField40(oprot); err != nil { return err }
     if err := p.writeField50(oprot); err != nil { return err }
     if err := p.writeField60(oprot); err != nil { return err }
+    if err := p.writeField70(oprot); err != nil { return err }
+    if err := p.writeField80(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -1063,6 +1265,30 @@ func (p *AddActivityTaskRequest) writeField60(oprot thrift.TProtocol) (err error
   return err
 }
 
+func (p *AddActivityTaskRequest) writeField70(oprot thrift.TProtocol) (err error) {
+  if p.IsSetPriority() {
+    if err := oprot.WriteFieldBegin("priority", thrift.I32, 70); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 70:priority: ", p), err) }
+    if err := oprot.WriteI32(int32(*p.Priority)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.priority (70) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 70:priority: ", p), err) }
+  }
+  return err
+}
+
+func (p *AddActivityTaskRequest) writeField80(oprot thrift.TProtocol) (err error) {
+  if p.IsSetSessionId() {
+    if err := oprot.WriteFieldBegin("sessionId", thrift.STRING, 80); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 80:sessionId: ", p), err) }
+    if err := oprot.WriteString(string(*p.SessionId)); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T.sessionId (80) field write error: ", p), err) }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 80:sessionId: ", p), err) }
+  }
+  return err
+}
+
 func (p *AddActivityTaskRequest) String() string {
   if p == nil {
     return "<nil>"
@@ -1092,7 +1318,7 @@ type MatchingService interface {  //MatchingService API is exposed to provide su
   //  - PollRequest
   PollForActivityTask(pollRequest *PollForActivityTaskRequest) (r *shared.PollForActivityTaskResponse, err error)
   // AddDecisionTask is called by the history service when a decision task is scheduled, so that it can be dispatched
-  // by the MatchingEngine.
+  // by the MatchingEngine.  Query tasks are only matched to pollers, the call blocks until a poller takes the task.
   // 
   // 
   // Parameters:
@@ -1192,16 +1418,16 @@ func (p *MatchingServiceClient) recvPollForDecisionTask() (value *PollForDecisio
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error0 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error1 error
-    error1, err = error0.Read(iprot)
+    error2 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error3 error
+    error3, err = error2.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error1
+    err = error3
     return
   }
   if mTypeId != thrift.REPLY {
@@ -1221,6 +1447,9 @@ func (p *MatchingServiceClient) recvPollForDecisionTask() (value *PollForDecisio
   } else   if result.InternalServiceError != nil {
     err = result.InternalServiceError
     return 
+  } else   if result.ServiceBusyError != nil {
+    err = result.ServiceBusyError
+    return 
   }
   value = result.GetSuccess()
   return
@@ -1279,16 +1508,16 @@ func (p *MatchingServiceClient) recvPollForActivityTask() (value *shared.PollFor
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error2 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error3 error
-    error3, err = error2.Read(iprot)
+    error4 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error5 error
+    error5, err = error4.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error3
+    err = error5
     return
   }
   if mTypeId != thrift.REPLY {
@@ -1308,13 +1537,16 @@ func (p *MatchingServiceClient) recvPollForActivityTask() (value *shared.PollFor
   } else   if result.InternalServiceError != nil {
     err = result.InternalServiceError
     return 
+  } else   if result.ServiceBusyError != nil {
+    err = result.ServiceBusyError
+    return 
   }
   value = result.GetSuccess()
   return
 }
 
 // AddDecisionTask is called by the history service when a decision task is scheduled, so that it can be dispatched
-// by the MatchingEngine.
+// by the MatchingEngine.  Query tasks are only matched to pollers, the call blocks until a poller takes the task.
 // 
 // 
 // Parameters:
@@ -1366,16 +1598,16 @@ func (p *MatchingServiceClient) recvAddDecisionTask() (err error) {
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error4 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error5 error
-    error5, err = error4.Read(iprot)
+    error6 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error7 error
+    error7, err = error6.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error5
+    err = error7
     return
   }
   if mTypeId != thrift.REPLY {
@@ -1455,16 +1687,16 @@ func (p *MatchingServiceClient) recvAddActivityTask() (err error) {
     return
   }
   if mTypeId == thrift.EXCEPTION {
-    error6 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-    var error7 error
-    error7, err = error6.Read(iprot)
+    error8 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+    var error9 error
+    error9, err = error8.Read(iprot)
     if err != nil {
       return
     }
     if err = iprot.ReadMessageEnd(); err != nil {
       return
     }
-    err = error7
+    err = error9
     return
   }
   if mTypeId != thrift.REPLY {
@@ -1512,12 +1744,12 @@ func (p *MatchingServiceProcessor) ProcessorMap() map[string]thrift.TProcessorFu
 
 func NewMatchingServiceProcessor(handler MatchingService) *MatchingServiceProcessor {
 
-  self8 := &MatchingServiceProcessor{handler:handler, processorMap:make(map[string]thrift.TProcessorFunction)}
-  self8.processorMap["PollForDecisionTask"] = &matchingServiceProcessorPollForDecisionTask{handler:handler}
-  self8.processorMap["PollForActivityTask"] = &matchingServiceProcessorPollForActivityTask{handler:handler}
-  self8.processorMap["AddDecisionTask"] = &matchingServiceProcessorAddDecisionTask{handler:handler}
-  self8.processorMap["AddActivityTask"] = &matchingServiceProcessorAddActivityTask{handler:handler}
-return self8
+  self10 := &MatchingServiceProcessor{handler:handler, processorMap:make(map[string]thrift.TProcessorFunction)}
+  self10.processorMap["PollForDecisionTask"] = &matchingServiceProcessorPollForDecisionTask{handler:handler}
+  self10.processorMap["PollForActivityTask"] = &matchingServiceProcessorPollForActivityTask{handler:handler}
+  self10.processorMap["AddDecisionTask"] = &matchingServiceProcessorAddDecisionTask{handler:handler}
+  self10.processorMap["AddActivityTask"] = &matchingServiceProcessorAddActivityTask{handler:handler}
+return self10
 }
 
 func (p *MatchingServiceProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
@@ -1528,12 +1760,12 @@ func (p *MatchingServiceProcessor) Process(iprot, oprot thrift.TProtocol) (succe
   }
   iprot.Skip(thrift.STRUCT)
   iprot.ReadMessageEnd()
-  x9 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function " + name)
+  x11 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function " + name)
   oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-  x9.Write(oprot)
+  x11.Write(oprot)
   oprot.WriteMessageEnd()
   oprot.Flush()
-  return false, x9
+  return false, x11
 
 }
 
@@ -1563,6 +1795,8 @@ var retval *PollForDecisionTaskResponse
   result.BadRequestError = v
     case *shared.InternalServiceError:
   result.InternalServiceError = v
+    case *shared.ServiceBusyError:
+  result.ServiceBusyError = v
     default:
     x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing PollForDecisionTask: " + err2.Error())
     oprot.WriteMessageBegin("PollForDecisionTask", thrift.EXCEPTION, seqId)
@@ -1618,6 +1852,8 @@ var retval *shared.PollForActivityTaskResponse
   result.BadRequestError = v
     case *shared.InternalServiceError:
   result.InternalServiceError = v
+    case *shared.ServiceBusyError:
+  result.ServiceBusyError = v
     default:
     x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing PollForActivityTask: " + err2.Error())
     oprot.WriteMessageBegin("PollForActivityTask", thrift.EXCEPTION, seqId)
@@ -1854,10 +2090,12 @@ func (p *MatchingServicePollForDecisionTaskArgs) String() string {
 //  - Success
 //  - BadRequestError
 //  - InternalServiceError
+//  - ServiceBusyError
 type MatchingServicePollForDecisionTaskResult struct {
   Success *PollForDecisionTaskResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  ServiceBusyError *shared.ServiceBusyError `thrift:"serviceBusyError,3" db:"serviceBusyError" json:"serviceBusyError,omitempty"`
 }
 
 func NewMatchingServicePollForDecisionTaskResult() *MatchingServicePollForDecisionTaskResult {
@@ -1885,6 +2123,13 @@ func (p *MatchingServicePollForDecisionTaskResult) GetInternalServiceError() *sh
   }
 return p.InternalServiceError
 }
+var MatchingServicePollForDecisionTaskResult_ServiceBusyError_DEFAULT *shared.ServiceBusyError
+func (p *MatchingServicePollForDecisionTaskResult) GetServiceBusyError() *shared.ServiceBusyError {
+  if !p.IsSetServiceBusyError() {
+    return MatchingServicePollForDecisionTaskResult_ServiceBusyError_DEFAULT
+  }
+return p.ServiceBusyError
+}
 func (p *MatchingServicePollForDecisionTaskResult) IsSetSuccess() bool {
   return p.Success != nil
 }
@@ -1897,6 +2142,10 @@ func (p *MatchingServicePollForDecisionTaskResult) IsSetInternalServiceError() b
   return p.InternalServiceError != nil
 }
 
+func (p *MatchingServicePollForDecisionTaskResult) IsSetServiceBusyError() bool {
+  return p.ServiceBusyError != nil
+}
+
 func (p *MatchingServicePollForDecisionTaskResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -1922,6 +2171,10 @@ func (p *MatchingServicePollForDecisionTaskResult) Read(iprot thrift.TProtocol)
       if err := p.ReadField2(iprot); err != nil {
         return err
       }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -1961,6 +2214,14 @@ func (p *MatchingServicePollForDecisionTaskResult)  ReadField2(iprot thrift.TPro
   return nil
 }
 
+func (p *MatchingServicePollForDecisionTaskResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.ServiceBusyError = &shared.ServiceBusyError{}
+  if err := p.ServiceBusyError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ServiceBusyError), err)
+  }
+  return nil
+}
+
 func (p *MatchingServicePollForDecisionTaskResult) Write(oprot thrift.TProtocol) error {
   if err := oprot.WriteStructBegin("PollForDecisionTask_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
@@ -1968,6 +2229,7 @@ func (p *MatchingServicePollForDecisionTaskResult) Write(oprot thrift.TProtocol)
     if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -2015,6 +2277,19 @@ func (p *MatchingServicePollForDecisionTaskResult) writeField2(oprot thrift.TPro
   return err
 }
 
+func (p *MatchingServicePollForDecisionTaskResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetServiceBusyError() {
+    if err := oprot.WriteFieldBegin("serviceBusyError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:serviceBusyError: ", p), err) }
+    if err := p.ServiceBusyError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ServiceBusyError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:serviceBusyError: ", p), err) }
+  }
+  return err
+}
+
 func (p *MatchingServicePollForDecisionTaskResult) String() string {
   if p == nil {
     return "<nil>"
@@ -2118,10 +2393,12 @@ func (p *MatchingServicePollForActivityTaskArgs) String() string {
 //  - Success
 //  - BadRequestError
 //  - InternalServiceError
+//  - ServiceBusyError
 type MatchingServicePollForActivityTaskResult struct {
   Success *shared.PollForActivityTaskResponse `thrift:"success,0" db:"success" json:"success,omitempty"`
   BadRequestError *shared.BadRequestError `thrift:"badRequestError,1" db:"badRequestError" json:"badRequestError,omitempty"`
   InternalServiceError *shared.InternalServiceError `thrift:"internalServiceError,2" db:"internalServiceError" json:"internalServiceError,omitempty"`
+  ServiceBusyError *shared.ServiceBusyError `thrift:"serviceBusyError,3" db:"serviceBusyError" json:"serviceBusyError,omitempty"`
 }
 
 func NewMatchingServicePollForActivityTaskResult() *MatchingServicePollForActivityTaskResult {
@@ -2149,6 +2426,13 @@ func (p *MatchingServicePollForActivityTaskResult) GetInternalServiceError() *sh
   }
 return p.InternalServiceError
 }
+var MatchingServicePollForActivityTaskResult_ServiceBusyError_DEFAULT *shared.ServiceBusyError
+func (p *MatchingServicePollForActivityTaskResult) GetServiceBusyError() *shared.ServiceBusyError {
+  if !p.IsSetServiceBusyError() {
+    return MatchingServicePollForActivityTaskResult_ServiceBusyError_DEFAULT
+  }
+return p.ServiceBusyError
+}
 func (p *MatchingServicePollForActivityTaskResult) IsSetSuccess() bool {
   return p.Success != nil
 }
@@ -2161,6 +2445,10 @@ func (p *MatchingServicePollForActivityTaskResult) IsSetInternalServiceError() b
   return p.InternalServiceError != nil
 }
 
+func (p *MatchingServicePollForActivityTaskResult) IsSetServiceBusyError() bool {
+  return p.ServiceBusyError != nil
+}
+
 func (p *MatchingServicePollForActivityTaskResult) Read(iprot thrift.TProtocol) error {
   if _, err := iprot.ReadStructBegin(); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -2186,6 +2474,10 @@ func (p *MatchingServicePollForActivityTaskResult) Read(iprot thrift.TProtocol)
       if err := p.ReadField2(iprot); err != nil {
         return err
       }
+    case 3:
+      if err := p.ReadField3(iprot); err != nil {
+        return err
+      }
     default:
       if err := iprot.Skip(fieldTypeId); err != nil {
         return err
@@ -2225,6 +2517,14 @@ func (p *MatchingServicePollForActivityTaskResult)  ReadField2(iprot thrift.TPro
   return nil
 }
 
+func (p *MatchingServicePollForActivityTaskResult)  ReadField3(iprot thrift.TProtocol) error {
+  p.ServiceBusyError = &shared.ServiceBusyError{}
+  if err := p.ServiceBusyError.Read(iprot); err != nil {
+    return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ServiceBusyError), err)
+  }
+  return nil
+}
+
 func (p *MatchingServicePollForActivityTaskResult) Write(oprot thrift.TProtocol) error {
   if err := oprot.WriteStructBegin("PollForActivityTask_result"); err != nil {
     return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err) }
@@ -2232,6 +2532,7 @@ func (p *MatchingServicePollForActivityTaskResult) Write(oprot thrift.TProtocol)
     if err := p.writeField0(oprot); err != nil { return err }
     if err := p.writeField1(oprot); err != nil { return err }
     if err := p.writeField2(oprot); err != nil { return err }
+    if err := p.writeField3(oprot); err != nil { return err }
   }
   if err := oprot.WriteFieldStop(); err != nil {
     return thrift.PrependError("write field stop error: ", err) }
@@ -2279,6 +2580,19 @@ func (p *MatchingServicePollForActivityTaskResult) writeField2(oprot thrift.TPro
   return err
 }
 
+func (p *MatchingServicePollForActivityTaskResult) writeField3(oprot thrift.TProtocol) (err error) {
+  if p.IsSetServiceBusyError() {
+    if err := oprot.WriteFieldBegin("serviceBusyError", thrift.STRUCT, 3); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:serviceBusyError: ", p), err) }
+    if err := p.ServiceBusyError.Write(oprot); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ServiceBusyError), err)
+    }
+    if err := oprot.WriteFieldEnd(); err != nil {
+      return thrift.PrependError(fmt.Sprintf("%T write field end error 3:serviceBusyError: ", p), err) }
+  }
+  return err
+}
+
 func (p *MatchingServicePollForActivityTaskResult) String() string {
   if p == nil {
     return "<nil>"
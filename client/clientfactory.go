@@ -23,6 +23,7 @@ package client
 import (
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
 	tchannel "github.com/uber/tchannel-go"
@@ -39,21 +40,24 @@ type tchannelClientFactory struct {
 	monitor               membership.Monitor
 	metricsClient         metrics.Client
 	numberOfHistoryShards int
+	tokenSerializer       common.TaskTokenSerializer
 }
 
 // NewTChannelClientFactory creates an instance of client factory using tchannel
 func NewTChannelClientFactory(ch *tchannel.Channel,
-	monitor membership.Monitor, metricsClient metrics.Client, numberOfHistoryShards int) Factory {
+	monitor membership.Monitor, metricsClient metrics.Client, numberOfHistoryShards int,
+	tokenSerializer common.TaskTokenSerializer) Factory {
 	return &tchannelClientFactory{
 		ch:                    ch,
 		monitor:               monitor,
 		metricsClient:         metricsClient,
 		numberOfHistoryShards: numberOfHistoryShards,
+		tokenSerializer:       tokenSerializer,
 	}
 }
 
 func (cf *tchannelClientFactory) NewHistoryClient() (history.Client, error) {
-	client, err := history.NewClient(cf.ch, cf.monitor, cf.numberOfHistoryShards)
+	client, err := history.NewClient(cf.ch, cf.monitor, cf.metricsClient, cf.numberOfHistoryShards, cf.tokenSerializer)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +68,7 @@ func (cf *tchannelClientFactory) NewHistoryClient() (history.Client, error) {
 }
 
 func (cf *tchannelClientFactory) NewMatchingClient() (matching.Client, error) {
-	client, err := matching.NewClient(cf.ch, cf.monitor)
+	client, err := matching.NewClient(cf.ch, cf.monitor, cf.metricsClient)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +87,12 @@ func (c *clientImpl) DeprecateDomain(deprecateRequest *workflow.DeprecateDomainR
 	return c.client.DeprecateDomain(ctx, deprecateRequest)
 }
 
+func (c *clientImpl) DrainDomain(drainRequest *workflow.DrainDomainRequest) error {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.DrainDomain(ctx, drainRequest)
+}
+
 func (c *clientImpl) StartWorkflowExecution(request *workflow.StartWorkflowExecutionRequest) (*workflow.StartWorkflowExecutionResponse, error) {
 	ctx, cancel := c.createContext()
 	defer cancel()
@@ -118,7 +124,7 @@ func (c *clientImpl) RecordActivityTaskHeartbeat(heartbeatRequest *workflow.Reco
 	return c.client.RecordActivityTaskHeartbeat(ctx, heartbeatRequest)
 }
 
-func (c *clientImpl) RespondDecisionTaskCompleted(request *workflow.RespondDecisionTaskCompletedRequest) error {
+func (c *clientImpl) RespondDecisionTaskCompleted(request *workflow.RespondDecisionTaskCompletedRequest) (*workflow.RespondDecisionTaskCompletedResponse, error) {
 	ctx, cancel := c.createContext()
 	defer cancel()
 	return c.client.RespondDecisionTaskCompleted(ctx, request)
@@ -142,24 +148,84 @@ func (c *clientImpl) RespondActivityTaskCanceled(request *workflow.RespondActivi
 	return c.client.RespondActivityTaskCanceled(ctx, request)
 }
 
+func (c *clientImpl) RespondActivityTaskCompletedByID(request *workflow.RespondActivityTaskCompletedByIDRequest) error {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.RespondActivityTaskCompletedByID(ctx, request)
+}
+
+func (c *clientImpl) RespondActivityTaskFailedByID(request *workflow.RespondActivityTaskFailedByIDRequest) error {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.RespondActivityTaskFailedByID(ctx, request)
+}
+
+func (c *clientImpl) RespondActivityTaskCanceledByID(request *workflow.RespondActivityTaskCanceledByIDRequest) error {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.RespondActivityTaskCanceledByID(ctx, request)
+}
+
 func (c *clientImpl) RequestCancelWorkflowExecution(cancelRequest *workflow.RequestCancelWorkflowExecutionRequest) error {
 	ctx, cancel := c.createContext()
 	defer cancel()
 	return c.client.RequestCancelWorkflowExecution(ctx, cancelRequest)
 }
 
-func (c *clientImpl) SignalWorkflowExecution(request *workflow.SignalWorkflowExecutionRequest) error {
+func (c *clientImpl) SignalWorkflowExecution(request *workflow.SignalWorkflowExecutionRequest) (*workflow.SignalWorkflowExecutionResponse, error) {
 	ctx, cancel := c.createContext()
 	defer cancel()
 	return c.client.SignalWorkflowExecution(ctx, request)
 }
 
-func (c *clientImpl) TerminateWorkflowExecution(request *workflow.TerminateWorkflowExecutionRequest) error {
+func (c *clientImpl) GetSignalReceipt(request *workflow.GetSignalReceiptRequest) (*workflow.GetSignalReceiptResponse, error) {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.GetSignalReceipt(ctx, request)
+}
+
+func (c *clientImpl) QueryWorkflow(queryRequest *workflow.QueryWorkflowRequest) (*workflow.QueryWorkflowResponse, error) {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.QueryWorkflow(ctx, queryRequest)
+}
+
+func (c *clientImpl) DescribeWorkflowExecution(request *workflow.DescribeWorkflowExecutionRequest) (*workflow.DescribeWorkflowExecutionResponse, error) {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.DescribeWorkflowExecution(ctx, request)
+}
+
+func (c *clientImpl) TerminateWorkflowExecution(request *workflow.TerminateWorkflowExecutionRequest) (*workflow.TerminateWorkflowExecutionResponse, error) {
 	ctx, cancel := c.createContext()
 	defer cancel()
 	return c.client.TerminateWorkflowExecution(ctx, request)
 }
 
+func (c *clientImpl) RefreshWorkflowTasks(request *workflow.RefreshWorkflowTasksRequest) error {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.RefreshWorkflowTasks(ctx, request)
+}
+
+func (c *clientImpl) MigrateWorkflowExecution(request *workflow.MigrateWorkflowExecutionRequest) error {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.MigrateWorkflowExecution(ctx, request)
+}
+
+func (c *clientImpl) DescribeCluster() (*workflow.DescribeClusterResponse, error) {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.DescribeCluster(ctx)
+}
+
+func (c *clientImpl) VerifyHistory(request *workflow.VerifyHistoryRequest) (*workflow.VerifyHistoryResponse, error) {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.VerifyHistory(ctx, request)
+}
+
 func (c *clientImpl) ListOpenWorkflowExecutions(
 	listRequest *workflow.ListOpenWorkflowExecutionsRequest) (*workflow.ListOpenWorkflowExecutionsResponse, error) {
 	ctx, cancel := c.createContext()
@@ -173,3 +239,10 @@ func (c *clientImpl) ListClosedWorkflowExecutions(
 	defer cancel()
 	return c.client.ListClosedWorkflowExecutions(ctx, listRequest)
 }
+
+func (c *clientImpl) ListClosedWorkflowExecutionsSince(
+	listRequest *workflow.ListClosedWorkflowExecutionsSinceRequest) (*workflow.ListClosedWorkflowExecutionsSinceResponse, error) {
+	ctx, cancel := c.createContext()
+	defer cancel()
+	return c.client.ListClosedWorkflowExecutionsSince(ctx, listRequest)
+}
@@ -30,6 +30,7 @@ type Client interface {
 	DescribeDomain(describeRequest *shared.DescribeDomainRequest) (*shared.DescribeDomainResponse, error)
 	UpdateDomain(updateRequest *shared.UpdateDomainRequest) (*shared.UpdateDomainResponse, error)
 	DeprecateDomain(deprecateRequest *shared.DeprecateDomainRequest) error
+	DrainDomain(drainRequest *shared.DrainDomainRequest) error
 	GetWorkflowExecutionHistory(getRequest *shared.GetWorkflowExecutionHistoryRequest) (*shared.GetWorkflowExecutionHistoryResponse, error)
 	PollForActivityTask(pollRequest *shared.PollForActivityTaskRequest) (*shared.PollForActivityTaskResponse, error)
 	PollForDecisionTask(pollRequest *shared.PollForDecisionTaskRequest) (*shared.PollForDecisionTaskResponse, error)
@@ -37,11 +38,22 @@ type Client interface {
 	RespondActivityTaskCompleted(completeRequest *shared.RespondActivityTaskCompletedRequest) error
 	RespondActivityTaskFailed(failRequest *shared.RespondActivityTaskFailedRequest) error
 	RespondActivityTaskCanceled(cancelRequest *shared.RespondActivityTaskCanceledRequest) error
-	RespondDecisionTaskCompleted(completeRequest *shared.RespondDecisionTaskCompletedRequest) error
+	RespondActivityTaskCompletedByID(completeRequest *shared.RespondActivityTaskCompletedByIDRequest) error
+	RespondActivityTaskFailedByID(failRequest *shared.RespondActivityTaskFailedByIDRequest) error
+	RespondActivityTaskCanceledByID(cancelRequest *shared.RespondActivityTaskCanceledByIDRequest) error
+	RespondDecisionTaskCompleted(completeRequest *shared.RespondDecisionTaskCompletedRequest) (*shared.RespondDecisionTaskCompletedResponse, error)
 	StartWorkflowExecution(startRequest *shared.StartWorkflowExecutionRequest) (*shared.StartWorkflowExecutionResponse, error)
 	RequestCancelWorkflowExecution(cancelRequest *shared.RequestCancelWorkflowExecutionRequest) error
-	SignalWorkflowExecution(request *shared.SignalWorkflowExecutionRequest) error
-	TerminateWorkflowExecution(terminateRequest *shared.TerminateWorkflowExecutionRequest) error
+	SignalWorkflowExecution(request *shared.SignalWorkflowExecutionRequest) (*shared.SignalWorkflowExecutionResponse, error)
+	GetSignalReceipt(request *shared.GetSignalReceiptRequest) (*shared.GetSignalReceiptResponse, error)
+	QueryWorkflow(queryRequest *shared.QueryWorkflowRequest) (*shared.QueryWorkflowResponse, error)
+	DescribeWorkflowExecution(request *shared.DescribeWorkflowExecutionRequest) (*shared.DescribeWorkflowExecutionResponse, error)
+	TerminateWorkflowExecution(terminateRequest *shared.TerminateWorkflowExecutionRequest) (*shared.TerminateWorkflowExecutionResponse, error)
+	RefreshWorkflowTasks(refreshRequest *shared.RefreshWorkflowTasksRequest) error
+	MigrateWorkflowExecution(migrateRequest *shared.MigrateWorkflowExecutionRequest) error
+	DescribeCluster() (*shared.DescribeClusterResponse, error)
+	VerifyHistory(verifyRequest *shared.VerifyHistoryRequest) (*shared.VerifyHistoryResponse, error)
 	ListOpenWorkflowExecutions(listRequest *shared.ListOpenWorkflowExecutionsRequest) (*shared.ListOpenWorkflowExecutionsResponse, error)
 	ListClosedWorkflowExecutions(listRequest *shared.ListClosedWorkflowExecutionsRequest) (*shared.ListClosedWorkflowExecutionsResponse, error)
+	ListClosedWorkflowExecutionsSince(listRequest *shared.ListClosedWorkflowExecutionsSinceRequest) (*shared.ListClosedWorkflowExecutionsSinceResponse, error)
 }
@@ -0,0 +1,311 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	h "github.com/uber/cadence/.gen/go/history"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+var _ h.TChanHistoryService = (*circuitBreakerClient)(nil)
+
+var errCircuitBreakerOpen = &workflow.ServiceBusyError{Message: "History host is unavailable, circuit breaker is open."}
+
+// circuitBreakerClient fronts the thrift client for a single history host. It stops sending
+// requests to the host after consecutive host failures, so callers fail fast instead of
+// piling up on timeouts while the host is sick.
+type circuitBreakerClient struct {
+	client        h.TChanHistoryService
+	breaker       *backoff.CircuitBreaker
+	metricsClient metrics.Client
+}
+
+func newCircuitBreakerClient(client h.TChanHistoryService, hostPort string, metricsClient metrics.Client) h.TChanHistoryService {
+	c := &circuitBreakerClient{
+		client:  client,
+		breaker: backoff.NewCircuitBreaker(backoff.SystemClock),
+	}
+	if metricsClient != nil {
+		c.metricsClient = metricsClient.Tagged(map[string]string{metrics.TargetHostTagName: hostPort})
+		c.breaker.SetStateChangeHandler(c.onStateChange)
+	}
+	return c
+}
+
+func (c *circuitBreakerClient) GetWorkflowExecutionNextEventID(context thrift.Context,
+	getRequest *h.GetWorkflowExecutionNextEventIDRequest) (*h.GetWorkflowExecutionNextEventIDResponse, error) {
+	var resp *h.GetWorkflowExecutionNextEventIDResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.GetWorkflowExecutionNextEventID(context, getRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) BatchGetWorkflowExecutionNextEventID(context thrift.Context,
+	getRequest *h.BatchGetWorkflowExecutionNextEventIDRequest) (*h.BatchGetWorkflowExecutionNextEventIDResponse, error) {
+	var resp *h.BatchGetWorkflowExecutionNextEventIDResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.BatchGetWorkflowExecutionNextEventID(context, getRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) RecordActivityTaskHeartbeat(context thrift.Context,
+	heartbeatRequest *h.RecordActivityTaskHeartbeatRequest) (*workflow.RecordActivityTaskHeartbeatResponse, error) {
+	var resp *workflow.RecordActivityTaskHeartbeatResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.RecordActivityTaskHeartbeat(context, heartbeatRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) RecordActivityTaskStarted(context thrift.Context,
+	addRequest *h.RecordActivityTaskStartedRequest) (*h.RecordActivityTaskStartedResponse, error) {
+	var resp *h.RecordActivityTaskStartedResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.RecordActivityTaskStarted(context, addRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) RecordChildExecutionCompleted(context thrift.Context,
+	completionRequest *h.RecordChildExecutionCompletedRequest) error {
+	return c.execute(func() error {
+		return c.client.RecordChildExecutionCompleted(context, completionRequest)
+	})
+}
+
+func (c *circuitBreakerClient) UpdateQueueProcessing(context thrift.Context,
+	updateRequest *h.UpdateQueueProcessingRequest) error {
+	return c.execute(func() error {
+		return c.client.UpdateQueueProcessing(context, updateRequest)
+	})
+}
+
+func (c *circuitBreakerClient) DescribeMutableState(context thrift.Context,
+	request *h.DescribeMutableStateRequest) (*h.DescribeMutableStateResponse, error) {
+	var resp *h.DescribeMutableStateResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.DescribeMutableState(context, request)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) RefreshWorkflowTasks(context thrift.Context,
+	request *h.RefreshWorkflowTasksRequest) error {
+	return c.execute(func() error {
+		return c.client.RefreshWorkflowTasks(context, request)
+	})
+}
+
+func (c *circuitBreakerClient) ListStaleExecutions(context thrift.Context,
+	request *h.ListStaleExecutionsRequest) (*h.ListStaleExecutionsResponse, error) {
+	var resp *h.ListStaleExecutionsResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.ListStaleExecutions(context, request)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) MigrateWorkflowExecution(context thrift.Context,
+	request *h.MigrateWorkflowExecutionRequest) error {
+	return c.execute(func() error {
+		return c.client.MigrateWorkflowExecution(context, request)
+	})
+}
+
+func (c *circuitBreakerClient) RedriveTransferDLQTasks(context thrift.Context,
+	request *h.RedriveTransferDLQTasksRequest) (*h.RedriveTransferDLQTasksResponse, error) {
+	var resp *h.RedriveTransferDLQTasksResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.RedriveTransferDLQTasks(context, request)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) RecordDecisionTaskStarted(context thrift.Context,
+	addRequest *h.RecordDecisionTaskStartedRequest) (*h.RecordDecisionTaskStartedResponse, error) {
+	var resp *h.RecordDecisionTaskStartedResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.RecordDecisionTaskStarted(context, addRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) RequestCancelWorkflowExecution(context thrift.Context,
+	cancelRequest *h.RequestCancelWorkflowExecutionRequest) error {
+	return c.execute(func() error {
+		return c.client.RequestCancelWorkflowExecution(context, cancelRequest)
+	})
+}
+
+func (c *circuitBreakerClient) RespondActivityTaskCanceled(context thrift.Context,
+	canceledRequest *h.RespondActivityTaskCanceledRequest) error {
+	return c.execute(func() error {
+		return c.client.RespondActivityTaskCanceled(context, canceledRequest)
+	})
+}
+
+func (c *circuitBreakerClient) RespondActivityTaskCompleted(context thrift.Context,
+	completeRequest *h.RespondActivityTaskCompletedRequest) error {
+	return c.execute(func() error {
+		return c.client.RespondActivityTaskCompleted(context, completeRequest)
+	})
+}
+
+func (c *circuitBreakerClient) RespondActivityTaskFailed(context thrift.Context,
+	failRequest *h.RespondActivityTaskFailedRequest) error {
+	return c.execute(func() error {
+		return c.client.RespondActivityTaskFailed(context, failRequest)
+	})
+}
+
+func (c *circuitBreakerClient) RespondDecisionTaskCompleted(context thrift.Context,
+	completeRequest *h.RespondDecisionTaskCompletedRequest) (*workflow.RespondDecisionTaskCompletedResponse, error) {
+	var resp *workflow.RespondDecisionTaskCompletedResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.RespondDecisionTaskCompleted(context, completeRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) ScheduleDecisionTask(context thrift.Context,
+	scheduleRequest *h.ScheduleDecisionTaskRequest) error {
+	return c.execute(func() error {
+		return c.client.ScheduleDecisionTask(context, scheduleRequest)
+	})
+}
+
+func (c *circuitBreakerClient) SignalWorkflowExecution(context thrift.Context,
+	signalRequest *h.SignalWorkflowExecutionRequest) (*workflow.SignalWorkflowExecutionResponse, error) {
+	var resp *workflow.SignalWorkflowExecutionResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.SignalWorkflowExecution(context, signalRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) GetSignalReceipt(context thrift.Context,
+	receiptRequest *h.GetSignalReceiptRequest) (*workflow.GetSignalReceiptResponse, error) {
+	var resp *workflow.GetSignalReceiptResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.GetSignalReceipt(context, receiptRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) StartWorkflowExecution(context thrift.Context,
+	startRequest *h.StartWorkflowExecutionRequest) (*workflow.StartWorkflowExecutionResponse, error) {
+	var resp *workflow.StartWorkflowExecutionResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.StartWorkflowExecution(context, startRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) QueryWorkflow(context thrift.Context,
+	queryRequest *h.QueryWorkflowRequest) (*workflow.QueryWorkflowResponse, error) {
+	var resp *workflow.QueryWorkflowResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.QueryWorkflow(context, queryRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) DescribeWorkflowExecution(context thrift.Context,
+	describeRequest *h.DescribeWorkflowExecutionRequest) (*workflow.DescribeWorkflowExecutionResponse, error) {
+	var resp *workflow.DescribeWorkflowExecutionResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.DescribeWorkflowExecution(context, describeRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) TerminateWorkflowExecution(context thrift.Context,
+	terminateRequest *h.TerminateWorkflowExecutionRequest) (*workflow.TerminateWorkflowExecutionResponse, error) {
+	var resp *workflow.TerminateWorkflowExecutionResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.TerminateWorkflowExecution(context, terminateRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) execute(op backoff.Operation) error {
+	if !c.breaker.Allow() {
+		if c.metricsClient != nil {
+			c.metricsClient.IncCounter(metrics.HistoryClientCircuitBreakerScope, metrics.CircuitBreakerRejectedCounter)
+		}
+		return errCircuitBreakerOpen
+	}
+
+	err := op()
+	if common.IsServiceHostFailureError(err) {
+		c.breaker.Failed()
+	} else {
+		c.breaker.Succeeded()
+	}
+	return err
+}
+
+func (c *circuitBreakerClient) onStateChange(from, to backoff.CircuitBreakerState) {
+	switch to {
+	case backoff.CircuitBreakerOpen:
+		c.metricsClient.IncCounter(metrics.HistoryClientCircuitBreakerScope, metrics.CircuitBreakerOpenedCounter)
+	case backoff.CircuitBreakerHalfOpen:
+		c.metricsClient.IncCounter(metrics.HistoryClientCircuitBreakerScope, metrics.CircuitBreakerHalfOpenedCounter)
+	case backoff.CircuitBreakerClosed:
+		c.metricsClient.IncCounter(metrics.HistoryClientCircuitBreakerScope, metrics.CircuitBreakerClosedCounter)
+	}
+}
@@ -21,6 +21,7 @@
 package history
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
 	tchannel "github.com/uber/tchannel-go"
 	"github.com/uber/tchannel-go/thrift"
 )
@@ -41,13 +43,15 @@ type clientImpl struct {
 	resolver        membership.ServiceResolver
 	tokenSerializer common.TaskTokenSerializer
 	numberOfShards  int
+	metricsClient   metrics.Client
 	// TODO: consider refactor thriftCache into a separate struct
 	thriftCacheLock sync.RWMutex
 	thriftCache     map[string]h.TChanHistoryService
 }
 
 // NewClient creates a new history service TChannel client
-func NewClient(ch *tchannel.Channel, monitor membership.Monitor, numberOfShards int) (Client, error) {
+func NewClient(ch *tchannel.Channel, monitor membership.Monitor, metricsClient metrics.Client,
+	numberOfShards int, tokenSerializer common.TaskTokenSerializer) (Client, error) {
 	sResolver, err := monitor.GetResolver(common.HistoryServiceName)
 	if err != nil {
 		return nil, err
@@ -56,8 +60,9 @@ func NewClient(ch *tchannel.Channel, monitor membership.Monitor, numberOfShards
 	client := &clientImpl{
 		connection:      ch,
 		resolver:        sResolver,
-		tokenSerializer: common.NewJSONTaskTokenSerializer(),
+		tokenSerializer: tokenSerializer,
 		numberOfShards:  numberOfShards,
+		metricsClient:   metricsClient,
 		thriftCache:     make(map[string]h.TChanHistoryService),
 	}
 	return client, nil
@@ -105,6 +110,102 @@ func (c *clientImpl) GetWorkflowExecutionNextEventID(context thrift.Context,
 	return response, nil
 }
 
+// BatchGetWorkflowExecutionNextEventID groups the requests by the history host owning their shard and sends one
+// batch to each host, the batches are sent concurrently.  Executions whose shard moved to another host while the batch
+// was in flight are retried one by one, following the redirect to their new owner.
+func (c *clientImpl) BatchGetWorkflowExecutionNextEventID(context thrift.Context,
+	request *h.BatchGetWorkflowExecutionNextEventIDRequest) (*h.BatchGetWorkflowExecutionNextEventIDResponse, error) {
+	requests := request.GetRequests()
+	results := make([]*h.GetWorkflowExecutionNextEventIDOutcome, len(requests))
+
+	// Indexes of the requests by the address of the host owning the shard of their execution
+	batches := make(map[string][]int)
+	for i, r := range requests {
+		shardID := common.WorkflowIDToHistoryShard(r.GetExecution().GetWorkflowId(), c.numberOfShards)
+		host, err := c.lookupShardOwner(shardID)
+		if err != nil {
+			return nil, err
+		}
+		batches[host.GetAddress()] = append(batches[host.GetAddress()], i)
+	}
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(batches))
+	for address, indexes := range batches {
+		wg.Add(1)
+		go func(address string, indexes []int) {
+			defer wg.Done()
+			if err := c.batchGetNextEventIDFromHost(context, address, requests, indexes, results); err != nil {
+				errCh <- err
+			}
+		}(address, indexes)
+	}
+	wg.Wait()
+	close(errCh)
+
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	return &h.BatchGetWorkflowExecutionNextEventIDResponse{Results: results}, nil
+}
+
+// batchGetNextEventIDFromHost sends the requests at the given indexes to the host as a single batch and stores their
+// results at the same indexes
+func (c *clientImpl) batchGetNextEventIDFromHost(context thrift.Context, address string,
+	requests []*h.GetWorkflowExecutionNextEventIDRequest, indexes []int,
+	results []*h.GetWorkflowExecutionNextEventIDOutcome) error {
+	batch := make([]*h.GetWorkflowExecutionNextEventIDRequest, len(indexes))
+	for i, index := range indexes {
+		batch[i] = requests[index]
+	}
+
+	ctx, cancel := c.createContext(context)
+	defer cancel()
+	response, err := c.getThriftClient(address).BatchGetWorkflowExecutionNextEventID(ctx,
+		&h.BatchGetWorkflowExecutionNextEventIDRequest{Requests: batch})
+	if err != nil {
+		return err
+	}
+	if len(response.GetResults()) != len(batch) {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("History host %v returned %v results for a batch of %v executions.", address,
+				len(response.GetResults()), len(batch)),
+		}
+	}
+
+	for i, outcome := range response.GetResults() {
+		if outcome.IsSetShardOwnershipLostError() {
+			response, err := c.GetWorkflowExecutionNextEventID(context, batch[i])
+			outcome = newNextEventIDOutcome(response, err)
+		}
+		results[indexes[i]] = outcome
+	}
+	return nil
+}
+
+// newNextEventIDOutcome reports the failure of an execution in the field of its type, other errors are reported as
+// InternalServiceError
+func newNextEventIDOutcome(response *h.GetWorkflowExecutionNextEventIDResponse,
+	err error) *h.GetWorkflowExecutionNextEventIDOutcome {
+	outcome := &h.GetWorkflowExecutionNextEventIDOutcome{Response: response}
+	switch err := err.(type) {
+	case nil:
+	case *workflow.EntityNotExistsError:
+		outcome.EntityNotExistError = err
+	case *h.ShardOwnershipLostError:
+		outcome.ShardOwnershipLostError = err
+	case *workflow.InternalServiceError:
+		outcome.InternalServiceError = err
+	case *workflow.BadRequestError:
+		outcome.BadRequestError = err
+	case *workflow.ServiceBusyError:
+		outcome.ServiceBusyError = err
+	default:
+		outcome.InternalServiceError = &workflow.InternalServiceError{Message: err.Error()}
+	}
+	return outcome
+}
+
 func (c *clientImpl) RecordDecisionTaskStarted(context thrift.Context,
 	request *h.RecordDecisionTaskStartedRequest) (*h.RecordDecisionTaskStartedResponse, error) {
 	client, err := c.getHostForRequest(request.WorkflowExecution.GetWorkflowId())
@@ -148,22 +249,28 @@ func (c *clientImpl) RecordActivityTaskStarted(context thrift.Context,
 }
 
 func (c *clientImpl) RespondDecisionTaskCompleted(context thrift.Context,
-	request *h.RespondDecisionTaskCompletedRequest) error {
+	request *h.RespondDecisionTaskCompletedRequest) (*workflow.RespondDecisionTaskCompletedResponse, error) {
 	taskToken, err := c.tokenSerializer.Deserialize(request.GetCompleteRequest().TaskToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	client, err := c.getHostForRequest(taskToken.WorkflowID)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	var response *workflow.RespondDecisionTaskCompletedResponse
 	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		var err error
 		ctx, cancel := c.createContext(context)
 		defer cancel()
-		return client.RespondDecisionTaskCompleted(ctx, request)
+		response, err = client.RespondDecisionTaskCompleted(ctx, request)
+		return err
 	}
 	err = c.executeWithRedirect(context, client, op)
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
 func (c *clientImpl) RespondActivityTaskCompleted(context thrift.Context,
@@ -263,34 +370,108 @@ func (c *clientImpl) RequestCancelWorkflowExecution(context thrift.Context,
 }
 
 func (c *clientImpl) SignalWorkflowExecution(context thrift.Context,
-	request *h.SignalWorkflowExecutionRequest) error {
+	request *h.SignalWorkflowExecutionRequest) (*workflow.SignalWorkflowExecutionResponse, error) {
 	client, err := c.getHostForRequest(request.GetSignalRequest().GetWorkflowExecution().GetWorkflowId())
 	if err != nil {
+		return nil, err
+	}
+	var response *workflow.SignalWorkflowExecutionResponse
+	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		var err error
+		ctx, cancel := c.createContext(context)
+		defer cancel()
+		response, err = client.SignalWorkflowExecution(ctx, request)
 		return err
 	}
+	err = c.executeWithRedirect(context, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) GetSignalReceipt(context thrift.Context,
+	request *h.GetSignalReceiptRequest) (*workflow.GetSignalReceiptResponse, error) {
+	client, err := c.getHostForRequest(request.GetReceiptRequest().GetWorkflowExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *workflow.GetSignalReceiptResponse
 	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		var err error
 		ctx, cancel := c.createContext(context)
 		defer cancel()
-		return client.SignalWorkflowExecution(ctx, request)
+		response, err = client.GetSignalReceipt(ctx, request)
+		return err
 	}
 	err = c.executeWithRedirect(context, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
 
-	return err
+func (c *clientImpl) QueryWorkflow(context thrift.Context,
+	request *h.QueryWorkflowRequest) (*workflow.QueryWorkflowResponse, error) {
+	client, err := c.getHostForRequest(request.GetQueryRequest().GetExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *workflow.QueryWorkflowResponse
+	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		var err error
+		ctx, cancel := c.createContext(context)
+		defer cancel()
+		response, err = client.QueryWorkflow(ctx, request)
+		return err
+	}
+	err = c.executeWithRedirect(context, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) DescribeWorkflowExecution(context thrift.Context,
+	request *h.DescribeWorkflowExecutionRequest) (*workflow.DescribeWorkflowExecutionResponse, error) {
+	client, err := c.getHostForRequest(request.GetRequest().GetExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *workflow.DescribeWorkflowExecutionResponse
+	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		var err error
+		ctx, cancel := c.createContext(context)
+		defer cancel()
+		response, err = client.DescribeWorkflowExecution(ctx, request)
+		return err
+	}
+	err = c.executeWithRedirect(context, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
 func (c *clientImpl) TerminateWorkflowExecution(context thrift.Context,
-	request *h.TerminateWorkflowExecutionRequest) error {
+	request *h.TerminateWorkflowExecutionRequest) (*workflow.TerminateWorkflowExecutionResponse, error) {
 	client, err := c.getHostForRequest(request.GetTerminateRequest().GetWorkflowExecution().GetWorkflowId())
 	if err != nil {
-		return err
+		return nil, err
 	}
+	var response *workflow.TerminateWorkflowExecutionResponse
 	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		var err error
 		ctx, cancel := c.createContext(context)
 		defer cancel()
-		return client.TerminateWorkflowExecution(ctx, request)
+		response, err = client.TerminateWorkflowExecution(ctx, request)
+		return err
 	}
 	err = c.executeWithRedirect(context, client, op)
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
 func (c *clientImpl) ScheduleDecisionTask(context thrift.Context, request *h.ScheduleDecisionTaskRequest) error {
@@ -321,9 +502,182 @@ func (c *clientImpl) RecordChildExecutionCompleted(context thrift.Context, reque
 	return err
 }
 
+// UpdateQueueProcessing pauses or resumes queue processing on the shard set on the request, or on every shard when
+// the request has no shard.
+func (c *clientImpl) UpdateQueueProcessing(context thrift.Context, request *h.UpdateQueueProcessingRequest) error {
+	if request.IsSetShardId() {
+		return c.updateQueueProcessingForShard(context, request)
+	}
+
+	for shardID := 0; shardID < c.numberOfShards; shardID++ {
+		err := c.updateQueueProcessingForShard(context, &h.UpdateQueueProcessingRequest{
+			ShardId:   common.Int32Ptr(int32(shardID)),
+			QueueType: request.QueueType,
+			Paused:    request.Paused,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *clientImpl) updateQueueProcessingForShard(context thrift.Context, request *h.UpdateQueueProcessingRequest) error {
+	client, err := c.getHostForShard(int(request.GetShardId()))
+	if err != nil {
+		return err
+	}
+	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		ctx, cancel := c.createContext(context)
+		defer cancel()
+		return client.UpdateQueueProcessing(ctx, request)
+	}
+	err = c.executeWithRedirect(context, client, op)
+	return err
+}
+
+func (c *clientImpl) DescribeMutableState(context thrift.Context,
+	request *h.DescribeMutableStateRequest) (*h.DescribeMutableStateResponse, error) {
+	client, err := c.getHostForRequest(request.GetExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *h.DescribeMutableStateResponse
+	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		var err error
+		ctx, cancel := c.createContext(context)
+		defer cancel()
+		response, err = client.DescribeMutableState(ctx, request)
+		return err
+	}
+	err = c.executeWithRedirect(context, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) RefreshWorkflowTasks(context thrift.Context, request *h.RefreshWorkflowTasksRequest) error {
+	client, err := c.getHostForRequest(request.GetRefreshRequest().GetWorkflowExecution().GetWorkflowId())
+	if err != nil {
+		return err
+	}
+	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		ctx, cancel := c.createContext(context)
+		defer cancel()
+		return client.RefreshWorkflowTasks(ctx, request)
+	}
+	return c.executeWithRedirect(context, client, op)
+}
+
+func (c *clientImpl) MigrateWorkflowExecution(context thrift.Context,
+	request *h.MigrateWorkflowExecutionRequest) error {
+	client, err := c.getHostForRequest(request.GetMigrateRequest().GetWorkflowExecution().GetWorkflowId())
+	if err != nil {
+		return err
+	}
+	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		ctx, cancel := c.createContext(context)
+		defer cancel()
+		return client.MigrateWorkflowExecution(ctx, request)
+	}
+	return c.executeWithRedirect(context, client, op)
+}
+
+func (c *clientImpl) ListStaleExecutions(context thrift.Context,
+	request *h.ListStaleExecutionsRequest) (*h.ListStaleExecutionsResponse, error) {
+	if request.IsSetShardId() {
+		return c.listStaleExecutionsForShard(context, request)
+	}
+
+	response := &h.ListStaleExecutionsResponse{Executions: []*h.StaleExecution{}}
+	for shardID := 0; shardID < c.numberOfShards; shardID++ {
+		shardResponse, err := c.listStaleExecutionsForShard(context, &h.ListStaleExecutionsRequest{
+			ShardId: common.Int32Ptr(int32(shardID)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.Executions = append(response.Executions, shardResponse.Executions...)
+	}
+	return response, nil
+}
+
+func (c *clientImpl) listStaleExecutionsForShard(context thrift.Context,
+	request *h.ListStaleExecutionsRequest) (*h.ListStaleExecutionsResponse, error) {
+	client, err := c.getHostForShard(int(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+	var response *h.ListStaleExecutionsResponse
+	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		var err error
+		ctx, cancel := c.createContext(context)
+		defer cancel()
+		response, err = client.ListStaleExecutions(ctx, request)
+		return err
+	}
+	err = c.executeWithRedirect(context, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RedriveTransferDLQTasks redrives the transfer dead letter queue of the shard set on the request, or of every shard
+// when the request has no shard.  The maximum task count, if set, bounds the tasks redriven across all shards.
+func (c *clientImpl) RedriveTransferDLQTasks(context thrift.Context,
+	request *h.RedriveTransferDLQTasksRequest) (*h.RedriveTransferDLQTasksResponse, error) {
+	if request.IsSetShardId() {
+		return c.redriveTransferDLQTasksForShard(context, request)
+	}
+
+	redriven := int32(0)
+	for shardID := 0; shardID < c.numberOfShards; shardID++ {
+		shardRequest := &h.RedriveTransferDLQTasksRequest{ShardId: common.Int32Ptr(int32(shardID))}
+		if request.GetMaximumTaskCount() > 0 {
+			if redriven >= request.GetMaximumTaskCount() {
+				break
+			}
+			shardRequest.MaximumTaskCount = common.Int32Ptr(request.GetMaximumTaskCount() - redriven)
+		}
+		shardResponse, err := c.redriveTransferDLQTasksForShard(context, shardRequest)
+		if err != nil {
+			return nil, err
+		}
+		redriven += shardResponse.GetRedrivenTaskCount()
+	}
+	return &h.RedriveTransferDLQTasksResponse{RedrivenTaskCount: common.Int32Ptr(redriven)}, nil
+}
+
+func (c *clientImpl) redriveTransferDLQTasksForShard(context thrift.Context,
+	request *h.RedriveTransferDLQTasksRequest) (*h.RedriveTransferDLQTasksResponse, error) {
+	client, err := c.getHostForShard(int(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+	var response *h.RedriveTransferDLQTasksResponse
+	op := func(context thrift.Context, client h.TChanHistoryService) error {
+		var err error
+		ctx, cancel := c.createContext(context)
+		defer cancel()
+		response, err = client.RedriveTransferDLQTasks(ctx, request)
+		return err
+	}
+	err = c.executeWithRedirect(context, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) getHostForRequest(workflowID string) (h.TChanHistoryService, error) {
 	key := common.WorkflowIDToHistoryShard(workflowID, c.numberOfShards)
-	host, err := c.resolver.Lookup(string(key))
+	return c.getHostForShard(key)
+}
+
+func (c *clientImpl) getHostForShard(shardID int) (h.TChanHistoryService, error) {
+	host, err := c.lookupShardOwner(shardID)
 	if err != nil {
 		return nil, err
 	}
@@ -331,6 +685,10 @@ func (c *clientImpl) getHostForRequest(workflowID string) (h.TChanHistoryService
 	return c.getThriftClient(host.GetAddress()), nil
 }
 
+func (c *clientImpl) lookupShardOwner(shardID int) (*membership.HostInfo, error) {
+	return c.resolver.Lookup(string(shardID))
+}
+
 func (c *clientImpl) createContext(parent thrift.Context) (thrift.Context, context.CancelFunc) {
 	timeout := time.Second * 30
 	if parent == nil {
@@ -361,7 +719,7 @@ func (c *clientImpl) getThriftClient(hostPort string) h.TChanHistoryService {
 			HostPort: hostPort,
 		})
 
-		client = h.NewTChanHistoryServiceClient(tClient)
+		client = newCircuitBreakerClient(h.NewTChanHistoryServiceClient(tClient), hostPort, c.metricsClient)
 		c.thriftCache[hostPort] = client
 	}
 	return client
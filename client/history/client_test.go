@@ -0,0 +1,247 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	h "github.com/uber/cadence/.gen/go/history"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+type (
+	historyClientSuite struct {
+		suite.Suite
+		*require.Assertions
+		resolver *mocks.ServiceResolver
+		hosts    map[string]*testHistoryHost
+		client   *clientImpl
+	}
+
+	// testHistoryHost answers next event ID requests like a history host, recording the batches it receives
+	testHistoryHost struct {
+		h.TChanHistoryService
+		sync.Mutex
+		nextEventIDs map[string]int64
+		errors       map[string]error
+		batches      [][]string
+		// when set, the host waits for the batches of the other hosts to arrive before answering
+		barrier    *sync.WaitGroup
+		concurrent bool
+	}
+)
+
+const testNumberOfShards = 8
+
+func TestHistoryClientSuite(t *testing.T) {
+	suite.Run(t, new(historyClientSuite))
+}
+
+func (s *historyClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.resolver = &mocks.ServiceResolver{}
+	// even shards are owned by hostA, odd shards by hostB
+	s.resolver.On("Lookup", mock.Anything).Return(func(key string) *membership.HostInfo {
+		return membership.NewHostInfo(s.ownerOf(int([]rune(key)[0])), nil)
+	}, nil)
+	s.hosts = map[string]*testHistoryHost{
+		"hostA": newTestHistoryHost(),
+		"hostB": newTestHistoryHost(),
+	}
+	s.client = &clientImpl{
+		resolver:       s.resolver,
+		numberOfShards: testNumberOfShards,
+		thriftCache:    make(map[string]h.TChanHistoryService),
+	}
+	for address, host := range s.hosts {
+		s.client.thriftCache[address] = host
+	}
+}
+
+func (s *historyClientSuite) ownerOf(shardID int) string {
+	if shardID%2 == 0 {
+		return "hostA"
+	}
+	return "hostB"
+}
+
+func (s *historyClientSuite) ownerOfWorkflow(workflowID string) string {
+	return s.ownerOf(common.WorkflowIDToHistoryShard(workflowID, testNumberOfShards))
+}
+
+func (s *historyClientSuite) TestBatchGetNextEventID() {
+	var barrier sync.WaitGroup
+	barrier.Add(len(s.hosts))
+	for _, host := range s.hosts {
+		host.barrier = &barrier
+	}
+
+	var workflowIDs []string
+	owners := make(map[string][]string)
+	for i := 0; i < 16; i++ {
+		workflowID := fmt.Sprintf("workflow%v", i)
+		workflowIDs = append(workflowIDs, workflowID)
+		owner := s.ownerOfWorkflow(workflowID)
+		owners[owner] = append(owners[owner], workflowID)
+		s.hosts[owner].nextEventIDs[workflowID] = int64(100 + i)
+	}
+	s.Len(owners, 2, "the workflows must be spread over both hosts")
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+	response, err := s.client.BatchGetWorkflowExecutionNextEventID(ctx, newBatchRequest(workflowIDs))
+	s.NoError(err)
+
+	// results are in the order of the requests
+	s.Len(response.GetResults(), len(workflowIDs))
+	for i, outcome := range response.GetResults() {
+		s.EqualValues(100+i, outcome.GetResponse().GetEventId())
+	}
+	// each host received a single batch with the executions of its shards, all the batches were in flight at once
+	for address, host := range s.hosts {
+		s.Equal([][]string{owners[address]}, host.batches)
+		s.True(host.concurrent)
+	}
+}
+
+func (s *historyClientSuite) TestBatchGetNextEventIDShardOwnershipLost() {
+	workflowID := "workflow0"
+	owner := s.ownerOfWorkflow(workflowID)
+	newOwner := "hostA"
+	if owner == newOwner {
+		newOwner = "hostB"
+	}
+	s.hosts[owner].errors[workflowID] = &h.ShardOwnershipLostError{Owner: common.StringPtr(newOwner)}
+	s.hosts[newOwner].nextEventIDs[workflowID] = 42
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+	response, err := s.client.BatchGetWorkflowExecutionNextEventID(ctx, newBatchRequest([]string{workflowID}))
+	s.NoError(err)
+	s.Len(response.GetResults(), 1)
+	outcome := response.GetResults()[0]
+	s.False(outcome.IsSetShardOwnershipLostError())
+	s.EqualValues(42, outcome.GetResponse().GetEventId())
+	// the execution was retried alone and redirected to the new owner
+	s.Len(s.hosts[owner].batches, 1)
+	s.Empty(s.hosts[newOwner].batches)
+}
+
+func (s *historyClientSuite) TestBatchGetNextEventIDErrors() {
+	errs := []error{
+		&workflow.EntityNotExistsError{Message: "Workflow execution not found."},
+		&workflow.BadRequestError{Message: "Invalid WorkflowID."},
+		&workflow.ServiceBusyError{Message: "Shard overloaded."},
+		&workflow.InternalServiceError{Message: "Persistence failure."},
+		errors.New("unexpected failure"),
+	}
+	var workflowIDs []string
+	for i, err := range errs {
+		workflowID := fmt.Sprintf("workflow%v", i)
+		workflowIDs = append(workflowIDs, workflowID)
+		s.hosts[s.ownerOfWorkflow(workflowID)].errors[workflowID] = err
+	}
+
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+	response, err := s.client.BatchGetWorkflowExecutionNextEventID(ctx, newBatchRequest(workflowIDs))
+	s.NoError(err)
+	results := response.GetResults()
+	s.Len(results, len(errs))
+	s.Equal(errs[0], results[0].GetEntityNotExistError())
+	s.Equal(errs[1], results[1].GetBadRequestError())
+	s.Equal(errs[2], results[2].GetServiceBusyError())
+	s.Equal(errs[3], results[3].GetInternalServiceError())
+	s.Equal("unexpected failure", results[4].GetInternalServiceError().GetMessage())
+	for _, outcome := range results {
+		s.False(outcome.IsSetResponse())
+	}
+}
+
+func newBatchRequest(workflowIDs []string) *h.BatchGetWorkflowExecutionNextEventIDRequest {
+	request := &h.BatchGetWorkflowExecutionNextEventIDRequest{}
+	for _, workflowID := range workflowIDs {
+		request.Requests = append(request.Requests, &h.GetWorkflowExecutionNextEventIDRequest{
+			DomainUUID: common.StringPtr("domain"),
+			Execution:  &workflow.WorkflowExecution{WorkflowId: common.StringPtr(workflowID)},
+		})
+	}
+	return request
+}
+
+func newTestHistoryHost() *testHistoryHost {
+	return &testHistoryHost{
+		nextEventIDs: make(map[string]int64),
+		errors:       make(map[string]error),
+	}
+}
+
+func (t *testHistoryHost) GetWorkflowExecutionNextEventID(ctx thrift.Context,
+	request *h.GetWorkflowExecutionNextEventIDRequest) (*h.GetWorkflowExecutionNextEventIDResponse, error) {
+	workflowID := request.GetExecution().GetWorkflowId()
+	if err, ok := t.errors[workflowID]; ok {
+		return nil, err
+	}
+	return &h.GetWorkflowExecutionNextEventIDResponse{
+		EventId: common.Int64Ptr(t.nextEventIDs[workflowID]),
+	}, nil
+}
+
+func (t *testHistoryHost) BatchGetWorkflowExecutionNextEventID(ctx thrift.Context,
+	request *h.BatchGetWorkflowExecutionNextEventIDRequest) (*h.BatchGetWorkflowExecutionNextEventIDResponse, error) {
+	if t.barrier != nil {
+		t.barrier.Done()
+		done := make(chan struct{})
+		go func() {
+			t.barrier.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			t.concurrent = true
+		case <-time.After(time.Second):
+		}
+	}
+
+	var workflowIDs []string
+	response := &h.BatchGetWorkflowExecutionNextEventIDResponse{}
+	for _, r := range request.GetRequests() {
+		workflowIDs = append(workflowIDs, r.GetExecution().GetWorkflowId())
+		resp, err := t.GetWorkflowExecutionNextEventID(ctx, r)
+		response.Results = append(response.Results, newNextEventIDOutcome(resp, err))
+	}
+	t.Lock()
+	t.batches = append(t.batches, workflowIDs)
+	t.Unlock()
+	return response, nil
+}
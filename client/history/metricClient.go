@@ -72,6 +72,21 @@ func (c *metricClient) GetWorkflowExecutionNextEventID(context thrift.Context,
 	return resp, err
 }
 
+func (c *metricClient) BatchGetWorkflowExecutionNextEventID(context thrift.Context,
+	request *h.BatchGetWorkflowExecutionNextEventIDRequest) (*h.BatchGetWorkflowExecutionNextEventIDResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientBatchGetNextEventIDScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientBatchGetNextEventIDScope, metrics.CadenceLatency)
+	resp, err := c.client.BatchGetWorkflowExecutionNextEventID(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientBatchGetNextEventIDScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) RecordDecisionTaskStarted(context thrift.Context,
 	request *h.RecordDecisionTaskStartedRequest) (*h.RecordDecisionTaskStartedResponse, error) {
 	c.metricsClient.IncCounter(metrics.HistoryClientRecordDecisionTaskStartedScope, metrics.CadenceRequests)
@@ -103,18 +118,18 @@ func (c *metricClient) RecordActivityTaskStarted(context thrift.Context,
 }
 
 func (c *metricClient) RespondDecisionTaskCompleted(context thrift.Context,
-	request *h.RespondDecisionTaskCompletedRequest) error {
+	request *h.RespondDecisionTaskCompletedRequest) (*workflow.RespondDecisionTaskCompletedResponse, error) {
 	c.metricsClient.IncCounter(metrics.HistoryClientRespondDecisionTaskCompletedScope, metrics.CadenceRequests)
 
 	sw := c.metricsClient.StartTimer(metrics.HistoryClientRespondDecisionTaskCompletedScope, metrics.CadenceLatency)
-	err := c.client.RespondDecisionTaskCompleted(context, request)
+	resp, err := c.client.RespondDecisionTaskCompleted(context, request)
 	sw.Stop()
 
 	if err != nil {
 		c.metricsClient.IncCounter(metrics.HistoryClientRespondDecisionTaskCompletedScope, metrics.CadenceFailures)
 	}
 
-	return err
+	return resp, err
 }
 
 func (c *metricClient) RespondActivityTaskCompleted(context thrift.Context,
@@ -193,33 +208,78 @@ func (c *metricClient) RequestCancelWorkflowExecution(context thrift.Context,
 }
 
 func (c *metricClient) SignalWorkflowExecution(context thrift.Context,
-	request *h.SignalWorkflowExecutionRequest) error {
+	request *h.SignalWorkflowExecutionRequest) (*workflow.SignalWorkflowExecutionResponse, error) {
 	c.metricsClient.IncCounter(metrics.HistoryClientSignalWorkflowExecutionScope, metrics.CadenceRequests)
 
 	sw := c.metricsClient.StartTimer(metrics.HistoryClientSignalWorkflowExecutionScope, metrics.CadenceLatency)
-	err := c.client.SignalWorkflowExecution(context, request)
+	resp, err := c.client.SignalWorkflowExecution(context, request)
 	sw.Stop()
 
 	if err != nil {
 		c.metricsClient.IncCounter(metrics.HistoryClientSignalWorkflowExecutionScope, metrics.CadenceFailures)
 	}
 
-	return err
+	return resp, err
+}
+
+func (c *metricClient) GetSignalReceipt(context thrift.Context,
+	request *h.GetSignalReceiptRequest) (*workflow.GetSignalReceiptResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientGetSignalReceiptScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientGetSignalReceiptScope, metrics.CadenceLatency)
+	resp, err := c.client.GetSignalReceipt(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientGetSignalReceiptScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) QueryWorkflow(context thrift.Context,
+	request *h.QueryWorkflowRequest) (*workflow.QueryWorkflowResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientQueryWorkflowScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientQueryWorkflowScope, metrics.CadenceLatency)
+	resp, err := c.client.QueryWorkflow(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientQueryWorkflowScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) DescribeWorkflowExecution(context thrift.Context,
+	request *h.DescribeWorkflowExecutionRequest) (*workflow.DescribeWorkflowExecutionResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientDescribeWorkflowExecutionScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDescribeWorkflowExecutionScope, metrics.CadenceLatency)
+	resp, err := c.client.DescribeWorkflowExecution(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDescribeWorkflowExecutionScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
 }
 
 func (c *metricClient) TerminateWorkflowExecution(context thrift.Context,
-	request *h.TerminateWorkflowExecutionRequest) error {
+	request *h.TerminateWorkflowExecutionRequest) (*workflow.TerminateWorkflowExecutionResponse, error) {
 	c.metricsClient.IncCounter(metrics.HistoryClientTerminateWorkflowExecutionScope, metrics.CadenceRequests)
 
 	sw := c.metricsClient.StartTimer(metrics.HistoryClientTerminateWorkflowExecutionScope, metrics.CadenceLatency)
-	err := c.client.TerminateWorkflowExecution(context, request)
+	resp, err := c.client.TerminateWorkflowExecution(context, request)
 	sw.Stop()
 
 	if err != nil {
 		c.metricsClient.IncCounter(metrics.HistoryClientTerminateWorkflowExecutionScope, metrics.CadenceFailures)
 	}
 
-	return err
+	return resp, err
 }
 
 func (c *metricClient) ScheduleDecisionTask(context thrift.Context,
@@ -237,6 +297,96 @@ func (c *metricClient) ScheduleDecisionTask(context thrift.Context,
 	return err
 }
 
+func (c *metricClient) UpdateQueueProcessing(context thrift.Context,
+	request *h.UpdateQueueProcessingRequest) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientUpdateQueueProcessingScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpdateQueueProcessingScope, metrics.CadenceLatency)
+	err := c.client.UpdateQueueProcessing(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpdateQueueProcessingScope, metrics.CadenceFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) DescribeMutableState(context thrift.Context,
+	request *h.DescribeMutableStateRequest) (*h.DescribeMutableStateResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientDescribeMutableStateScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDescribeMutableStateScope, metrics.CadenceLatency)
+	resp, err := c.client.DescribeMutableState(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDescribeMutableStateScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) RefreshWorkflowTasks(context thrift.Context,
+	request *h.RefreshWorkflowTasksRequest) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientRefreshWorkflowTasksScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientRefreshWorkflowTasksScope, metrics.CadenceLatency)
+	err := c.client.RefreshWorkflowTasks(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientRefreshWorkflowTasksScope, metrics.CadenceFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) ListStaleExecutions(context thrift.Context,
+	request *h.ListStaleExecutionsRequest) (*h.ListStaleExecutionsResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientListStaleExecutionsScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientListStaleExecutionsScope, metrics.CadenceLatency)
+	resp, err := c.client.ListStaleExecutions(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientListStaleExecutionsScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) MigrateWorkflowExecution(context thrift.Context,
+	request *h.MigrateWorkflowExecutionRequest) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientMigrateWorkflowExecutionScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientMigrateWorkflowExecutionScope, metrics.CadenceLatency)
+	err := c.client.MigrateWorkflowExecution(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientMigrateWorkflowExecutionScope, metrics.CadenceFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) RedriveTransferDLQTasks(context thrift.Context,
+	request *h.RedriveTransferDLQTasksRequest) (*h.RedriveTransferDLQTasksResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientRedriveTransferDLQTasksScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientRedriveTransferDLQTasksScope, metrics.CadenceLatency)
+	resp, err := c.client.RedriveTransferDLQTasks(context, request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientRedriveTransferDLQTasksScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) RecordChildExecutionCompleted(context thrift.Context,
 	request *h.RecordChildExecutionCompletedRequest) error {
 	c.metricsClient.IncCounter(metrics.HistoryClientRecordChildExecutionCompletedScope, metrics.CadenceRequests)
@@ -0,0 +1,119 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	m "github.com/uber/cadence/.gen/go/matching"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+var _ m.TChanMatchingService = (*circuitBreakerClient)(nil)
+
+var errCircuitBreakerOpen = &workflow.ServiceBusyError{Message: "Matching host is unavailable, circuit breaker is open."}
+
+// circuitBreakerClient fronts the thrift client for a single matching host. It stops sending
+// requests to the host after consecutive host failures, so callers fail fast instead of
+// piling up on timeouts while the host is sick.
+type circuitBreakerClient struct {
+	client        m.TChanMatchingService
+	breaker       *backoff.CircuitBreaker
+	metricsClient metrics.Client
+}
+
+func newCircuitBreakerClient(client m.TChanMatchingService, hostPort string, metricsClient metrics.Client) m.TChanMatchingService {
+	c := &circuitBreakerClient{
+		client:  client,
+		breaker: backoff.NewCircuitBreaker(backoff.SystemClock),
+	}
+	if metricsClient != nil {
+		c.metricsClient = metricsClient.Tagged(map[string]string{metrics.TargetHostTagName: hostPort})
+		c.breaker.SetStateChangeHandler(c.onStateChange)
+	}
+	return c
+}
+
+func (c *circuitBreakerClient) AddActivityTask(context thrift.Context,
+	addRequest *m.AddActivityTaskRequest) error {
+	return c.execute(func() error {
+		return c.client.AddActivityTask(context, addRequest)
+	})
+}
+
+func (c *circuitBreakerClient) AddDecisionTask(context thrift.Context,
+	addRequest *m.AddDecisionTaskRequest) error {
+	return c.execute(func() error {
+		return c.client.AddDecisionTask(context, addRequest)
+	})
+}
+
+func (c *circuitBreakerClient) PollForActivityTask(context thrift.Context,
+	pollRequest *m.PollForActivityTaskRequest) (*workflow.PollForActivityTaskResponse, error) {
+	var resp *workflow.PollForActivityTaskResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.PollForActivityTask(context, pollRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) PollForDecisionTask(context thrift.Context,
+	pollRequest *m.PollForDecisionTaskRequest) (*m.PollForDecisionTaskResponse, error) {
+	var resp *m.PollForDecisionTaskResponse
+	err := c.execute(func() error {
+		var err error
+		resp, err = c.client.PollForDecisionTask(context, pollRequest)
+		return err
+	})
+	return resp, err
+}
+
+func (c *circuitBreakerClient) execute(op backoff.Operation) error {
+	if !c.breaker.Allow() {
+		if c.metricsClient != nil {
+			c.metricsClient.IncCounter(metrics.MatchingClientCircuitBreakerScope, metrics.CircuitBreakerRejectedCounter)
+		}
+		return errCircuitBreakerOpen
+	}
+
+	err := op()
+	if common.IsServiceHostFailureError(err) {
+		c.breaker.Failed()
+	} else {
+		c.breaker.Succeeded()
+	}
+	return err
+}
+
+func (c *circuitBreakerClient) onStateChange(from, to backoff.CircuitBreakerState) {
+	switch to {
+	case backoff.CircuitBreakerOpen:
+		c.metricsClient.IncCounter(metrics.MatchingClientCircuitBreakerScope, metrics.CircuitBreakerOpenedCounter)
+	case backoff.CircuitBreakerHalfOpen:
+		c.metricsClient.IncCounter(metrics.MatchingClientCircuitBreakerScope, metrics.CircuitBreakerHalfOpenedCounter)
+	case backoff.CircuitBreakerClosed:
+		c.metricsClient.IncCounter(metrics.MatchingClientCircuitBreakerScope, metrics.CircuitBreakerClosedCounter)
+	}
+}
@@ -30,6 +30,7 @@ import (
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
 	tchannel "github.com/uber/tchannel-go"
 	"github.com/uber/tchannel-go/thrift"
 )
@@ -39,21 +40,23 @@ var _ Client = (*clientImpl)(nil)
 type clientImpl struct {
 	connection      *tchannel.Channel
 	resolver        membership.ServiceResolver
+	metricsClient   metrics.Client
 	thriftCacheLock sync.RWMutex
 	thriftCache     map[string]m.TChanMatchingService
 }
 
 // NewClient creates a new history service TChannel client
-func NewClient(ch *tchannel.Channel, monitor membership.Monitor) (Client, error) {
+func NewClient(ch *tchannel.Channel, monitor membership.Monitor, metricsClient metrics.Client) (Client, error) {
 	sResolver, err := monitor.GetResolver(common.MatchingServiceName)
 	if err != nil {
 		return nil, err
 	}
 
 	client := &clientImpl{
-		connection:  ch,
-		resolver:    sResolver,
-		thriftCache: make(map[string]m.TChanMatchingService),
+		connection:    ch,
+		resolver:      sResolver,
+		metricsClient: metricsClient,
+		thriftCache:   make(map[string]m.TChanMatchingService),
 	}
 	return client, nil
 }
@@ -151,7 +154,7 @@ func (c *clientImpl) getThriftClient(hostPort string) m.TChanMatchingService {
 			HostPort: hostPort,
 		})
 
-		client = m.NewTChanMatchingServiceClient(tClient)
+		client = newCircuitBreakerClient(m.NewTChanMatchingServiceClient(tClient), hostPort, c.metricsClient)
 		c.thriftCache[hostPort] = client
 	}
 	return client
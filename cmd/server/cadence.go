@@ -21,6 +21,11 @@
 package main
 
 import (
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/urfave/cli"
 	"log"
@@ -49,6 +54,14 @@ func startHandler(c *cli.Context) {
 	config.Load(env, configDir, zone, &cfg)
 	log.Printf("config=\n%v\n", cfg.String())
 
+	tracer, _, err := cfg.Tracing.NewTracer("cadence")
+	if err != nil {
+		log.Fatalf("error creating tracer: %v", err)
+	}
+	if tracer != nil {
+		opentracing.SetGlobalTracer(tracer)
+	}
+
 	for _, svc := range getServices(c) {
 		if _, ok := cfg.Services[svc]; !ok {
 			log.Fatalf("`%v` service missing config", svc)
@@ -60,6 +73,38 @@ func startHandler(c *cli.Context) {
 	select {}
 }
 
+// reshardHandler is the handler for the cli reshard command. It moves all workflow
+// executions and their tasks from the old number of history shards to the one
+// configured in cluster.numHistoryShards. All cadence hosts must be stopped and the
+// keyspace backed up before running it. A run which failed while copying the rows is
+// started over with drop-staging.
+func reshardHandler(c *cli.Context) {
+	env := getEnvironment(c)
+	zone := getZone(c)
+	configDir := getConfigDir(c)
+
+	log.Printf("Loading config; env=%v,zone=%v,configDir=%v\n", env, zone, configDir)
+
+	var cfg config.Config
+	config.Load(env, configDir, zone, &cfg)
+
+	toShards, err := cfg.GetNumHistoryShards()
+	if err != nil {
+		log.Fatalf("invalid cluster config: %v", err)
+	}
+	fromShards := c.Int("from-shards")
+	dropStaging := c.Bool("drop-staging")
+
+	log.Printf("Resharding history; from=%v,to=%v,dropStaging=%v\n", fromShards, toShards, dropStaging)
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	err = persistence.ReshardExecutions(cfg.Cassandra, fromShards, toShards, dropStaging, metricsClient,
+		cfg.Log.NewBarkLogger())
+	if err != nil {
+		log.Fatalf("reshard failed: %v", err)
+	}
+	log.Printf("Reshard complete\n")
+}
+
 func getEnvironment(c *cli.Context) string {
 	return strings.TrimSpace(c.GlobalString("env"))
 }
@@ -122,7 +167,7 @@ func buildCLI() *cli.App {
 	app := cli.NewApp()
 	app.Name = "cadence"
 	app.Usage = "Cadence server"
-	app.Version = "0.0.1"
+	app.Version = common.ServerVersion
 
 	app.Flags = []cli.Flag{
 		cli.StringFlag{
@@ -167,6 +212,23 @@ func buildCLI() *cli.App {
 				startHandler(c)
 			},
 		},
+		{
+			Name:  "reshard",
+			Usage: "offline move of workflow executions to cluster.numHistoryShards shards, all hosts must be stopped",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "from-shards, f",
+					Usage: "number of history shards the cluster currently runs with",
+				},
+				cli.BoolFlag{
+					Name:  "drop-staging",
+					Usage: "drop the staging shards left by a resharding which failed while copying executions",
+				},
+			},
+			Action: func(c *cli.Context) {
+				reshardHandler(c)
+			},
+		},
 	}
 
 	return app
@@ -92,6 +92,18 @@ func (s *server) startService() common.Daemon {
 	params.Name = "cadence-" + s.name
 	params.Logger = s.cfg.Log.NewBarkLogger()
 	params.CassandraConfig = s.cfg.Cassandra
+	params.ClusterName = s.cfg.Ringpop.Name
+	params.NumHistoryShards, err = s.cfg.GetNumHistoryShards()
+	if err != nil {
+		log.Fatalf("invalid cluster config: %v", err)
+	}
+	params.TaskToken = s.cfg.TaskToken
+	params.Audit = s.cfg.Audit
+	params.PageToken = s.cfg.PageToken
+	params.ClientVersions = s.cfg.ClientVersions
+	params.PropagatedHeaders = s.cfg.PropagatedHeaders
+	params.Blobstore = s.cfg.Blobstore
+	params.LargePayloads = s.cfg.LargePayloads
 
 	params.RingpopFactory, err = s.cfg.Ringpop.NewFactory()
 	if err != nil {
@@ -99,8 +111,13 @@ func (s *server) startService() common.Daemon {
 	}
 
 	svcCfg := s.cfg.Services[s.name]
+	if svcCfg.CassandraRouting != nil {
+		params.CassandraConfig.Routing = *svcCfg.CassandraRouting
+	}
 
 	params.MetricScope = svcCfg.Metrics.NewScope()
+	params.RuntimeMetricsInterval = svcCfg.Metrics.RuntimeReportInterval
+	params.PProf = svcCfg.PProf
 	params.TChannelFactory = svcCfg.TChannel.NewFactory()
 
 	var daemon common.Daemon
@@ -0,0 +1,142 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"time"
+
+	"github.com/uber-common/bark"
+)
+
+// Operations recorded by the audit log
+const (
+	OperationRegisterDomain                 = "RegisterDomain"
+	OperationUpdateDomain                   = "UpdateDomain"
+	OperationDeprecateDomain                = "DeprecateDomain"
+	OperationDrainDomain                    = "DrainDomain"
+	OperationStartWorkflowExecution         = "StartWorkflowExecution"
+	OperationSignalWorkflowExecution        = "SignalWorkflowExecution"
+	OperationTerminateWorkflowExecution     = "TerminateWorkflowExecution"
+	OperationRequestCancelWorkflowExecution = "RequestCancelWorkflowExecution"
+	OperationRefreshWorkflowTasks           = "RefreshWorkflowTasks"
+	OperationMigrateWorkflowExecution       = "MigrateWorkflowExecution"
+)
+
+// Outcomes recorded by the audit log
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+type (
+	// Record describes a single mutating call made against the cadence frontend
+	Record struct {
+		// Timestamp is the UTC time the call completed at
+		Timestamp time.Time `json:"timestamp"`
+		// Operation is the name of the call, one of the Operation constants
+		Operation string `json:"operation"`
+		// Identity is the identity the caller passed in the request, if any
+		Identity string `json:"identity,omitempty"`
+		// Domain is the name of the domain the call was made against
+		Domain string `json:"domain,omitempty"`
+		// WorkflowID is the ID of the workflow execution the call was made against, if any
+		WorkflowID string `json:"workflowId,omitempty"`
+		// RunID is the run ID of the workflow execution the call was made against, if any
+		RunID string `json:"runId,omitempty"`
+		// Outcome is OutcomeSuccess or OutcomeFailure
+		Outcome string `json:"outcome"`
+		// Error is the error the call failed with, empty for successful calls
+		Error string `json:"error,omitempty"`
+	}
+
+	// Sink is the destination audit records are written to.  Implementations must be safe for concurrent use.
+	Sink interface {
+		// Write writes a record to the sink
+		Write(record *Record) error
+		// Close flushes and releases the sink, no record can be written after it
+		Close() error
+	}
+
+	// Logger records mutating calls to a sink
+	Logger interface {
+		// Log records the outcome of an operation, err being nil for successful calls
+		Log(operation, identity, domain, workflowID, runID string, err error)
+		// Close closes the sink of the logger
+		Close() error
+	}
+
+	loggerImpl struct {
+		sink   Sink
+		logger bark.Logger
+	}
+
+	noopSink struct{}
+)
+
+// NewLogger creates an audit logger writing to the given sink.  Failures to write a record are logged and never
+// fail the call being audited.
+func NewLogger(sink Sink, logger bark.Logger) Logger {
+	return &loggerImpl{
+		sink:   sink,
+		logger: logger,
+	}
+}
+
+// NewNoopSink creates a sink which drops all records
+func NewNoopSink() Sink {
+	return noopSink{}
+}
+
+// Log writes the record of a call to the sink of the logger
+func (l *loggerImpl) Log(operation, identity, domain, workflowID, runID string, err error) {
+	record := &Record{
+		Timestamp:  time.Now().UTC(),
+		Operation:  operation,
+		Identity:   identity,
+		Domain:     domain,
+		WorkflowID: workflowID,
+		RunID:      runID,
+		Outcome:    OutcomeSuccess,
+	}
+	if err != nil {
+		record.Outcome = OutcomeFailure
+		record.Error = err.Error()
+	}
+
+	if err := l.sink.Write(record); err != nil {
+		l.logger.Warnf("Failed to write audit record for %v on domain %v: %v", operation, domain, err)
+	}
+}
+
+// Close closes the sink of the logger
+func (l *loggerImpl) Close() error {
+	return l.sink.Close()
+}
+
+// Write drops the record
+func (noopSink) Write(record *Record) error {
+	return nil
+}
+
+// Close is a no-op
+func (noopSink) Close() error {
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+)
+
+type (
+	auditLoggerSuite struct {
+		*require.Assertions
+		suite.Suite
+		dir string
+	}
+)
+
+func TestAuditLoggerSuite(t *testing.T) {
+	suite.Run(t, new(auditLoggerSuite))
+}
+
+func (s *auditLoggerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	dir, err := ioutil.TempDir("", "audit")
+	s.NoError(err)
+	s.dir = dir
+}
+
+func (s *auditLoggerSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *auditLoggerSuite) TestFileSink() {
+	path := filepath.Join(s.dir, "audit.log")
+	sink, err := NewFileSink(path)
+	s.NoError(err)
+	logger := NewLogger(sink, bark.NewLoggerFromLogrus(log.New()))
+
+	logger.Log(OperationStartWorkflowExecution, "worker1", "domain1", "wId", "rId", nil)
+	logger.Log(OperationTerminateWorkflowExecution, "operator", "domain1", "wId", "", errors.New("not found"))
+	s.NoError(logger.Close())
+
+	file, err := os.Open(path)
+	s.NoError(err)
+	defer file.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := &Record{}
+		s.NoError(json.Unmarshal(scanner.Bytes(), record))
+		records = append(records, record)
+	}
+	s.NoError(scanner.Err())
+	s.Equal(2, len(records))
+
+	s.Equal(OperationStartWorkflowExecution, records[0].Operation)
+	s.Equal("worker1", records[0].Identity)
+	s.Equal("domain1", records[0].Domain)
+	s.Equal("wId", records[0].WorkflowID)
+	s.Equal("rId", records[0].RunID)
+	s.Equal(OutcomeSuccess, records[0].Outcome)
+	s.Empty(records[0].Error)
+	s.False(records[0].Timestamp.IsZero())
+
+	s.Equal(OperationTerminateWorkflowExecution, records[1].Operation)
+	s.Equal(OutcomeFailure, records[1].Outcome)
+	s.Equal("not found", records[1].Error)
+}
+
+func (s *auditLoggerSuite) TestFileSinkAppends() {
+	path := filepath.Join(s.dir, "audit.log")
+	for i := 0; i < 2; i++ {
+		sink, err := NewFileSink(path)
+		s.NoError(err)
+		s.NoError(sink.Write(&Record{Operation: OperationRegisterDomain, Outcome: OutcomeSuccess}))
+		s.NoError(sink.Close())
+	}
+
+	data, err := ioutil.ReadFile(path)
+	s.NoError(err)
+	lines := 0
+	for _, b := range data {
+		if b == '\n' {
+			lines++
+		}
+	}
+	s.Equal(2, lines)
+}
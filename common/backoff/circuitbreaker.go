@@ -0,0 +1,171 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backoff
+
+import (
+	"sync"
+	"time"
+)
+
+// Circuit breaker states
+const (
+	// CircuitBreakerClosed means requests flow through to the target
+	CircuitBreakerClosed CircuitBreakerState = iota
+	// CircuitBreakerOpen means requests are rejected without reaching the target
+	CircuitBreakerOpen
+	// CircuitBreakerHalfOpen means a single trial request is allowed through to probe the target
+	CircuitBreakerHalfOpen
+)
+
+const (
+	defaultCircuitBreakerFailureThreshold = 5
+	defaultCircuitBreakerResetTimeout     = 10 * time.Second
+)
+
+type (
+	// CircuitBreakerState is the state of a CircuitBreaker
+	CircuitBreakerState int
+
+	// CircuitBreakerStateChangeHandler is invoked every time a CircuitBreaker transitions to a new state
+	CircuitBreakerStateChangeHandler func(from, to CircuitBreakerState)
+
+	// CircuitBreaker is used to fail fast against a sick target. It opens after a number of consecutive
+	// failures, rejects all requests while open and lets a single trial request through once the reset
+	// timeout has elapsed. The trial request either closes the breaker again or re-opens it.
+	CircuitBreaker struct {
+		sync.Mutex
+		clock               Clock
+		failureThreshold    int
+		resetTimeout        time.Duration
+		onStateChange       CircuitBreakerStateChangeHandler
+		state               CircuitBreakerState
+		consecutiveFailures int
+		openedTime          time.Time
+		trialInFlight       bool
+	}
+)
+
+// NewCircuitBreaker returns an instance of CircuitBreaker in the closed state
+func NewCircuitBreaker(clock Clock) *CircuitBreaker {
+	return &CircuitBreaker{
+		clock:            clock,
+		failureThreshold: defaultCircuitBreakerFailureThreshold,
+		resetTimeout:     defaultCircuitBreakerResetTimeout,
+		state:            CircuitBreakerClosed,
+	}
+}
+
+// SetFailureThreshold sets the number of consecutive failures after which the breaker opens
+func (cb *CircuitBreaker) SetFailureThreshold(failureThreshold int) {
+	cb.failureThreshold = failureThreshold
+}
+
+// SetResetTimeout sets the time the breaker stays open before letting a trial request through
+func (cb *CircuitBreaker) SetResetTimeout(resetTimeout time.Duration) {
+	cb.resetTimeout = resetTimeout
+}
+
+// SetStateChangeHandler sets the handler invoked on every state transition
+func (cb *CircuitBreaker) SetStateChangeHandler(handler CircuitBreakerStateChangeHandler) {
+	cb.onStateChange = handler
+}
+
+// State returns the current state of the breaker
+func (cb *CircuitBreaker) State() CircuitBreakerState {
+	cb.Lock()
+	defer cb.Unlock()
+	return cb.state
+}
+
+// Allow returns true if a request can be sent to the target. Every allowed request
+// must be followed by a call to either Succeeded or Failed.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.Lock()
+	from := cb.state
+	allowed := true
+	switch cb.state {
+	case CircuitBreakerOpen:
+		if cb.clock.Now().Sub(cb.openedTime) < cb.resetTimeout {
+			allowed = false
+			break
+		}
+		cb.state = CircuitBreakerHalfOpen
+		cb.trialInFlight = true
+	case CircuitBreakerHalfOpen:
+		if cb.trialInFlight {
+			allowed = false
+			break
+		}
+		cb.trialInFlight = true
+	}
+	to := cb.state
+	cb.Unlock()
+
+	cb.notify(from, to)
+	return allowed
+}
+
+// Succeeded marks a request to the target as succeeded
+func (cb *CircuitBreaker) Succeeded() {
+	cb.Lock()
+	from := cb.state
+	cb.consecutiveFailures = 0
+	cb.trialInFlight = false
+	cb.state = CircuitBreakerClosed
+	cb.Unlock()
+
+	cb.notify(from, CircuitBreakerClosed)
+}
+
+// Failed marks a request to the target as failed
+func (cb *CircuitBreaker) Failed() {
+	cb.Lock()
+	from := cb.state
+	cb.consecutiveFailures++
+	cb.trialInFlight = false
+	if cb.state == CircuitBreakerHalfOpen || cb.consecutiveFailures >= cb.failureThreshold {
+		cb.state = CircuitBreakerOpen
+		cb.openedTime = cb.clock.Now()
+	}
+	to := cb.state
+	cb.Unlock()
+
+	cb.notify(from, to)
+}
+
+func (cb *CircuitBreaker) notify(from, to CircuitBreakerState) {
+	if from != to && cb.onStateChange != nil {
+		cb.onStateChange(from, to)
+	}
+}
+
+// String returns the name of the state
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitBreakerClosed:
+		return "closed"
+	case CircuitBreakerOpen:
+		return "open"
+	case CircuitBreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	CircuitBreakerSuite struct {
+		*require.Assertions // override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test, not merely log an error
+		suite.Suite
+	}
+)
+
+func TestCircuitBreakerSuite(t *testing.T) {
+	suite.Run(t, new(CircuitBreakerSuite))
+}
+
+func (s *CircuitBreakerSuite) SetupTest() {
+	s.Assertions = require.New(s.T()) // Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+}
+
+func (s *CircuitBreakerSuite) TestOpensAfterConsecutiveFailures() {
+	cb, _ := createCircuitBreaker(3, time.Second)
+
+	for i := 0; i < 2; i++ {
+		s.True(cb.Allow())
+		cb.Failed()
+	}
+	s.Equal(CircuitBreakerClosed, cb.State())
+
+	// a success in between resets the failure count
+	s.True(cb.Allow())
+	cb.Succeeded()
+	for i := 0; i < 2; i++ {
+		s.True(cb.Allow())
+		cb.Failed()
+	}
+	s.Equal(CircuitBreakerClosed, cb.State())
+
+	s.True(cb.Allow())
+	cb.Failed()
+	s.Equal(CircuitBreakerOpen, cb.State())
+	s.False(cb.Allow())
+}
+
+func (s *CircuitBreakerSuite) TestHalfOpenTrialSucceeds() {
+	cb, clock := createCircuitBreaker(1, time.Second)
+	var transitions []CircuitBreakerState
+	cb.SetStateChangeHandler(func(from, to CircuitBreakerState) {
+		transitions = append(transitions, to)
+	})
+
+	s.True(cb.Allow())
+	cb.Failed()
+	s.False(cb.Allow())
+
+	clock.moveClock(time.Second)
+	s.True(cb.Allow())
+	s.Equal(CircuitBreakerHalfOpen, cb.State())
+	// only a single trial request is let through
+	s.False(cb.Allow())
+
+	cb.Succeeded()
+	s.Equal(CircuitBreakerClosed, cb.State())
+	s.True(cb.Allow())
+	s.Equal([]CircuitBreakerState{CircuitBreakerOpen, CircuitBreakerHalfOpen, CircuitBreakerClosed}, transitions)
+}
+
+func (s *CircuitBreakerSuite) TestHalfOpenTrialFails() {
+	cb, clock := createCircuitBreaker(2, time.Second)
+
+	for i := 0; i < 2; i++ {
+		s.True(cb.Allow())
+		cb.Failed()
+	}
+	clock.moveClock(time.Second)
+	s.True(cb.Allow())
+	cb.Failed()
+	s.Equal(CircuitBreakerOpen, cb.State())
+	s.False(cb.Allow())
+
+	clock.moveClock(500 * time.Millisecond)
+	s.False(cb.Allow())
+	clock.moveClock(500 * time.Millisecond)
+	s.True(cb.Allow())
+}
+
+func createCircuitBreaker(failureThreshold int, resetTimeout time.Duration) (*CircuitBreaker, *TestClock) {
+	clock := &TestClock{currentTime: time.Time{}}
+	cb := NewCircuitBreaker(clock)
+	cb.SetFailureThreshold(failureThreshold)
+	cb.SetResetTimeout(resetTimeout)
+	return cb, clock
+}
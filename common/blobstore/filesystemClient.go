@@ -0,0 +1,149 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/net/context"
+)
+
+type (
+	// filesystemClient stores each bucket as a directory under its root directory and each blob as a file of the
+	// bucket.  The file holds the tags of the blob as a JSON document on its first line, followed by the body.
+	filesystemClient struct {
+		rootDir string
+	}
+)
+
+var _ Client = (*filesystemClient)(nil)
+
+// NewFilesystemClient creates a client storing blobs under the root directory, which is created if missing.  Buckets
+// are directories of the root directory, created with their first blob.
+func NewFilesystemClient(rootDir string) (Client, error) {
+	if err := os.MkdirAll(rootDir, 0750); err != nil {
+		return nil, err
+	}
+	return &filesystemClient{rootDir: rootDir}, nil
+}
+
+func (c *filesystemClient) Put(ctx context.Context, bucket, key string, blob *Blob) error {
+	path, err := c.blobPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	tags, err := json.Marshal(normalizeTags(blob.Tags))
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	// Written to a temporary file renamed over the blob, so readers never see a partially written blob
+	file, err := ioutil.TempFile(dir, ".blob")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	writer := bufio.NewWriter(file)
+	writer.Write(tags)
+	writer.WriteByte('\n')
+	writer.Write(blob.Body)
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (c *filesystemClient) Get(ctx context.Context, bucket, key string) (*Blob, error) {
+	path, err := c.blobPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrBlobNotExists
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	tags, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	blob := &Blob{}
+	if err := json.Unmarshal(tags, &blob.Tags); err != nil {
+		return nil, err
+	}
+	if blob.Body, err = ioutil.ReadAll(reader); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+func (c *filesystemClient) Exists(ctx context.Context, bucket, key string) (bool, error) {
+	path, err := c.blobPath(bucket, key)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *filesystemClient) Delete(ctx context.Context, bucket, key string) error {
+	path, err := c.blobPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *filesystemClient) blobPath(bucket, key string) (string, error) {
+	if err := validateKey(bucket); err != nil || filepath.Base(bucket) != bucket {
+		return "", ErrInvalidKey
+	}
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(c.rootDir, bucket, filepath.FromSlash(key)), nil
+}
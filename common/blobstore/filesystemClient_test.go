@@ -0,0 +1,105 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
+)
+
+type (
+	filesystemClientSuite struct {
+		*require.Assertions
+		suite.Suite
+		dir    string
+		client Client
+	}
+)
+
+func TestFilesystemClientSuite(t *testing.T) {
+	suite.Run(t, new(filesystemClientSuite))
+}
+
+func (s *filesystemClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	dir, err := ioutil.TempDir("", "blobstore")
+	s.NoError(err)
+	s.dir = dir
+	s.client, err = NewFilesystemClient(dir)
+	s.NoError(err)
+}
+
+func (s *filesystemClientSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *filesystemClientSuite) TestPutGet() {
+	ctx := context.Background()
+	blob := &Blob{
+		Body: []byte("line1\nline2"),
+		Tags: map[string]string{"DomainID": "domain1", "encoding": "json"},
+	}
+	s.NoError(s.client.Put(ctx, "history", "domain1/wId/rId", blob))
+
+	exists, err := s.client.Exists(ctx, "history", "domain1/wId/rId")
+	s.NoError(err)
+	s.True(exists)
+
+	stored, err := s.client.Get(ctx, "history", "domain1/wId/rId")
+	s.NoError(err)
+	s.Equal(blob.Body, stored.Body)
+	s.Equal(map[string]string{"domainid": "domain1", "encoding": "json"}, stored.Tags)
+
+	// Putting the key again replaces the blob
+	s.NoError(s.client.Put(ctx, "history", "domain1/wId/rId", &Blob{Body: []byte("replaced")}))
+	stored, err = s.client.Get(ctx, "history", "domain1/wId/rId")
+	s.NoError(err)
+	s.Equal([]byte("replaced"), stored.Body)
+	s.Empty(stored.Tags)
+}
+
+func (s *filesystemClientSuite) TestDelete() {
+	ctx := context.Background()
+	s.NoError(s.client.Put(ctx, "history", "key", &Blob{Body: []byte("body")}))
+	s.NoError(s.client.Delete(ctx, "history", "key"))
+
+	exists, err := s.client.Exists(ctx, "history", "key")
+	s.NoError(err)
+	s.False(exists)
+	_, err = s.client.Get(ctx, "history", "key")
+	s.Equal(ErrBlobNotExists, err)
+
+	s.NoError(s.client.Delete(ctx, "history", "key"))
+}
+
+func (s *filesystemClientSuite) TestInvalidKeys() {
+	ctx := context.Background()
+	for _, key := range []string{"", "/abs", "a/../../b", "a//b", "a/./b"} {
+		s.Equal(ErrInvalidKey, s.client.Put(ctx, "history", key, &Blob{}), key)
+	}
+	s.Equal(ErrInvalidKey, s.client.Put(ctx, "../history", "key", &Blob{}))
+	s.Equal(ErrInvalidKey, s.client.Put(ctx, "a/b", "key", &Blob{}))
+}
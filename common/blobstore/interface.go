@@ -0,0 +1,82 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+type (
+	// Blob is a payload stored under a key, along with the tags describing it.  Tag names are case insensitive, blobs
+	// are returned with the names in lower case.
+	Blob struct {
+		Body []byte
+		Tags map[string]string
+	}
+
+	// Client stores blobs in buckets, for archived histories and visibility records or payloads too large to keep
+	// inline.  Keys are slash separated paths within the bucket.  Implementations must be safe for concurrent use.
+	Client interface {
+		// Put stores the blob under the key, replacing the blob already stored there
+		Put(ctx context.Context, bucket, key string, blob *Blob) error
+		// Get returns the blob stored under the key, ErrBlobNotExists when there is none
+		Get(ctx context.Context, bucket, key string) (*Blob, error)
+		// Exists tells whether a blob is stored under the key
+		Exists(ctx context.Context, bucket, key string) (bool, error)
+		// Delete removes the blob stored under the key, deleting a missing blob succeeds
+		Delete(ctx context.Context, bucket, key string) error
+	}
+)
+
+var (
+	// ErrBlobNotExists is returned when no blob is stored under the key
+	ErrBlobNotExists = errors.New("blob does not exist")
+	// ErrBucketNotExists is returned for calls against a bucket which does not exist
+	ErrBucketNotExists = errors.New("bucket does not exist")
+	// ErrInvalidKey is returned for keys which are empty, absolute or escape the bucket
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// validateKey rejects keys which don't name a blob within the bucket
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// normalizeTags returns the tags with their names in lower case, the way stores without case sensitive metadata
+// return them
+func normalizeTags(tags map[string]string) map[string]string {
+	normalized := make(map[string]string, len(tags))
+	for name, value := range tags {
+		normalized[strings.ToLower(name)] = value
+	}
+	return normalized
+}
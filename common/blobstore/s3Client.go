@@ -0,0 +1,131 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"golang.org/x/net/context"
+)
+
+type (
+	// s3Client stores blobs as objects of S3 buckets, with their tags as the user metadata of the objects
+	s3Client struct {
+		s3 s3iface.S3API
+	}
+)
+
+var _ Client = (*s3Client)(nil)
+
+// NewS3Client creates a client storing blobs in S3, or a store exposing its API, with the given AWS configuration.
+// Credentials are taken from the environment when the configuration has none.  Buckets have to exist.
+func NewS3Client(awsConfig *aws.Config) (Client, error) {
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Client{s3: s3.New(sess)}, nil
+}
+
+func (c *s3Client) Put(ctx context.Context, bucket, key string, blob *Blob) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	_, err := c.s3.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		Body:     bytes.NewReader(blob.Body),
+		Metadata: aws.StringMap(normalizeTags(blob.Tags)),
+	})
+	return convertS3Error(err)
+}
+
+func (c *s3Client) Get(ctx context.Context, bucket, key string) (*Blob, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	result, err := c.s3.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, convertS3Error(err)
+	}
+	defer result.Body.Close()
+
+	body, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+	// The SDK returns the metadata names in canonical header form
+	return &Blob{
+		Body: body,
+		Tags: normalizeTags(aws.StringValueMap(result.Metadata)),
+	}, nil
+}
+
+func (c *s3Client) Exists(ctx context.Context, bucket, key string) (bool, error) {
+	if err := validateKey(key); err != nil {
+		return false, err
+	}
+	_, err := c.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		// Responses to HEAD requests have no body, so a missing object only shows in the status code
+		if failure, ok := err.(awserr.RequestFailure); ok && failure.StatusCode() == http.StatusNotFound {
+			return false, nil
+		}
+		return false, convertS3Error(err)
+	}
+	return true, nil
+}
+
+func (c *s3Client) Delete(ctx context.Context, bucket, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	_, err := c.s3.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	return convertS3Error(err)
+}
+
+func convertS3Error(err error) error {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case s3.ErrCodeNoSuchKey:
+			return ErrBlobNotExists
+		case s3.ErrCodeNoSuchBucket:
+			return ErrBucketNotExists
+		}
+	}
+	return err
+}
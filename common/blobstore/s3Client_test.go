@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
+)
+
+type (
+	s3ClientSuite struct {
+		*require.Assertions
+		suite.Suite
+		s3     *fakeS3
+		client Client
+	}
+
+	// fakeS3 keeps the objects of a single bucket in memory, with their metadata names in canonical header form
+	// like the SDK returns them
+	fakeS3 struct {
+		s3iface.S3API
+		bucket  string
+		objects map[string]*s3.GetObjectOutput
+	}
+)
+
+func TestS3ClientSuite(t *testing.T) {
+	suite.Run(t, new(s3ClientSuite))
+}
+
+func (s *s3ClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.s3 = &fakeS3{bucket: "history", objects: make(map[string]*s3.GetObjectOutput)}
+	s.client = &s3Client{s3: s.s3}
+}
+
+func (s *s3ClientSuite) TestPutGet() {
+	ctx := context.Background()
+	blob := &Blob{
+		Body: []byte("body"),
+		Tags: map[string]string{"DomainID": "domain1"},
+	}
+	s.NoError(s.client.Put(ctx, "history", "domain1/wId/rId", blob))
+
+	exists, err := s.client.Exists(ctx, "history", "domain1/wId/rId")
+	s.NoError(err)
+	s.True(exists)
+
+	stored, err := s.client.Get(ctx, "history", "domain1/wId/rId")
+	s.NoError(err)
+	s.Equal(blob.Body, stored.Body)
+	s.Equal(map[string]string{"domainid": "domain1"}, stored.Tags)
+}
+
+func (s *s3ClientSuite) TestErrors() {
+	ctx := context.Background()
+	_, err := s.client.Get(ctx, "history", "missing")
+	s.Equal(ErrBlobNotExists, err)
+	exists, err := s.client.Exists(ctx, "history", "missing")
+	s.NoError(err)
+	s.False(exists)
+
+	s.Equal(ErrBucketNotExists, s.client.Put(ctx, "visibility", "key", &Blob{}))
+	s.Equal(ErrInvalidKey, s.client.Put(ctx, "history", "/key", &Blob{}))
+
+	s.NoError(s.client.Put(ctx, "history", "key", &Blob{}))
+	s.NoError(s.client.Delete(ctx, "history", "key"))
+	_, err = s.client.Get(ctx, "history", "key")
+	s.Equal(ErrBlobNotExists, err)
+}
+
+func (f *fakeS3) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput,
+	opts ...request.Option) (*s3.PutObjectOutput, error) {
+	if aws.StringValue(input.Bucket) != f.bucket {
+		return nil, awserr.New(s3.ErrCodeNoSuchBucket, "bucket not found", nil)
+	}
+	body, _ := ioutil.ReadAll(input.Body)
+	metadata := make(map[string]*string)
+	for name, value := range input.Metadata {
+		metadata[http.CanonicalHeaderKey(name)] = value
+	}
+	f.objects[aws.StringValue(input.Key)] = &s3.GetObjectOutput{
+		Body:     ioutil.NopCloser(bytes.NewReader(body)),
+		Metadata: metadata,
+	}
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput,
+	opts ...request.Option) (*s3.GetObjectOutput, error) {
+	object, ok := f.objects[aws.StringValue(input.Key)]
+	if !ok {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "key not found", nil)
+	}
+	return object, nil
+}
+
+func (f *fakeS3) HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput,
+	opts ...request.Option) (*s3.HeadObjectOutput, error) {
+	if _, ok := f.objects[aws.StringValue(input.Key)]; !ok {
+		return nil, awserr.NewRequestFailure(awserr.New("NotFound", "Not Found", nil), http.StatusNotFound, "")
+	}
+	return &s3.HeadObjectOutput{}, nil
+}
+
+func (f *fakeS3) DeleteObjectWithContext(ctx aws.Context, input *s3.DeleteObjectInput,
+	opts ...request.Option) (*s3.DeleteObjectOutput, error) {
+	delete(f.objects, aws.StringValue(input.Key))
+	return &s3.DeleteObjectOutput{}, nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 )
 
 const (
@@ -46,6 +47,7 @@ type (
 	DomainCache interface {
 		GetDomain(name string) (*persistence.DomainInfo, *persistence.DomainConfig, error)
 		GetDomainByID(id string) (*persistence.DomainInfo, *persistence.DomainConfig, error)
+		InvalidateDomain(name, id string)
 	}
 
 	domainCache struct {
@@ -95,6 +97,13 @@ func (c *domainCache) GetDomainByID(id string) (*persistence.DomainInfo, *persis
 	return c.getDomain(id, id, "", c.cacheByID)
 }
 
+// InvalidateDomain removes the entries of a domain from the cache after it is updated, so that the next lookups read
+// the update from metadata store instead of serving the previous entry until it expires
+func (c *domainCache) InvalidateDomain(name, id string) {
+	c.cacheByName.Delete(name)
+	c.cacheByID.Delete(id)
+}
+
 // GetDomain retrieves the information from the cache if it exists, otherwise retrieves the information from metadata
 // store and writes it to the cache with an expiry before returning back
 func (c *domainCache) getDomain(key, id, name string, cache Cache) (*persistence.DomainInfo, *persistence.DomainConfig, error) {
@@ -132,7 +141,8 @@ func (c *domainCache) getDomain(key, id, name string, cache Cache) (*persistence
 
 	// Check again under the lock to make sure someone else did not update the entry
 	if entry.expiry == 0 || now >= entry.expiry {
-		response, err := c.metadataMgr.GetDomain(&persistence.GetDomainRequest{
+		// The entry is shared by all callers, so the refresh is not tied to any single request
+		response, err := c.metadataMgr.GetDomain(context.Background(), &persistence.GetDomainRequest{
 			Name: name,
 			ID:   id,
 		})
@@ -34,23 +34,43 @@ import (
 	"github.com/uber/cadence/tools/cassandra"
 )
 
-// NewCassandraCluster creates a cassandra cluster given comma separated list of clusterHosts
-func NewCassandraCluster(clusterHosts string, dc string) *gocql.ClusterConfig {
-	var hosts []string
-	for _, h := range strings.Split(clusterHosts, ",") {
-		if host := strings.TrimSpace(h); len(host) > 0 {
-			hosts = append(hosts, host)
-		}
+// NewCassandraCluster creates a cassandra cluster given comma separated list of clusterHosts of the
+// datacenter dc. remoteDatacenters maps the name of a remote datacenter to a comma separated list of
+// its hosts; when it is not empty queries fall back to those datacenters while no host of dc is up
+func NewCassandraCluster(clusterHosts string, dc string, remoteDatacenters map[string]string) *gocql.ClusterConfig {
+	hosts := parseHosts(clusterHosts)
+	for _, remoteHosts := range remoteDatacenters {
+		hosts = append(hosts, parseHosts(remoteHosts)...)
 	}
 
 	cluster := gocql.NewCluster(hosts...)
 	cluster.ProtoVersion = 4
-	if dc != "" {
+	if dc == "" {
+		return cluster
+	}
+
+	if len(remoteDatacenters) == 0 {
 		cluster.HostFilter = gocql.DataCentreHostFilter(dc)
+		return cluster
 	}
+	cluster.HostFilter = gocql.HostFilterFunc(func(host *gocql.HostInfo) bool {
+		_, ok := remoteDatacenters[host.DataCenter()]
+		return ok || host.DataCenter() == dc
+	})
+	cluster.PoolConfig.HostSelectionPolicy = NewDCAwareHostPolicy(dc)
 	return cluster
 }
 
+func parseHosts(clusterHosts string) []string {
+	var hosts []string
+	for _, h := range strings.Split(clusterHosts, ",") {
+		if host := strings.TrimSpace(h); len(host) > 0 {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
 // CreateCassandraKeyspace creates the keyspace using this session for given replica count
 func CreateCassandraKeyspace(s *gocql.Session, keyspace string, replicas int, overwrite bool) (err error) {
 	// if overwrite flag is set, drop the keyspace and create a new one
@@ -0,0 +1,155 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/gocql/gocql"
+)
+
+type (
+	// DCAwareHostPolicy is a gocql host selection policy that prefers the hosts of the local
+	// datacenter. Hosts of remote datacenters are only picked after every local host, so they
+	// receive queries only while no local host is up.
+	DCAwareHostPolicy struct {
+		sync.RWMutex
+		localDC     string
+		localHosts  []*gocql.HostInfo
+		remoteHosts []*gocql.HostInfo
+		pos         uint32
+	}
+
+	selectedHost struct {
+		info *gocql.HostInfo
+	}
+)
+
+var _ gocql.HostSelectionPolicy = (*DCAwareHostPolicy)(nil)
+
+// NewDCAwareHostPolicy creates a host selection policy preferring the hosts of localDC
+func NewDCAwareHostPolicy(localDC string) *DCAwareHostPolicy {
+	return &DCAwareHostPolicy{localDC: localDC}
+}
+
+// LocalHostsUp returns true if at least one host of the local datacenter is up
+func (p *DCAwareHostPolicy) LocalHostsUp() bool {
+	p.RLock()
+	defer p.RUnlock()
+	for _, host := range p.localHosts {
+		if host.IsUp() {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPartitioner is a noop, routing does not depend on the partitioner
+func (p *DCAwareHostPolicy) SetPartitioner(partitioner string) {
+}
+
+// AddHost adds the host to the hosts of its datacenter
+func (p *DCAwareHostPolicy) AddHost(host *gocql.HostInfo) {
+	p.Lock()
+	defer p.Unlock()
+	if host.DataCenter() == p.localDC {
+		p.localHosts = addHost(p.localHosts, host)
+	} else {
+		p.remoteHosts = addHost(p.remoteHosts, host)
+	}
+}
+
+// RemoveHost removes the host from the hosts of its datacenter
+func (p *DCAwareHostPolicy) RemoveHost(host *gocql.HostInfo) {
+	p.Lock()
+	defer p.Unlock()
+	p.localHosts = removeHost(p.localHosts, host)
+	p.remoteHosts = removeHost(p.remoteHosts, host)
+}
+
+// HostUp adds the host back to the policy
+func (p *DCAwareHostPolicy) HostUp(host *gocql.HostInfo) {
+	p.AddHost(host)
+}
+
+// HostDown removes the host from the policy
+func (p *DCAwareHostPolicy) HostDown(host *gocql.HostInfo) {
+	p.RemoveHost(host)
+}
+
+// Pick returns an iterator over the local hosts followed by the remote hosts, each of them
+// starting at the next host in round robin order
+func (p *DCAwareHostPolicy) Pick(query gocql.ExecutableQuery) gocql.NextHost {
+	p.RLock()
+	localHosts := p.localHosts
+	remoteHosts := p.remoteHosts
+	p.RUnlock()
+
+	pos := int(atomic.AddUint32(&p.pos, 1) - 1)
+	total := len(localHosts) + len(remoteHosts)
+	i := 0
+	return func() gocql.SelectedHost {
+		if i >= total {
+			return nil
+		}
+		var host *gocql.HostInfo
+		if i < len(localHosts) {
+			host = localHosts[(pos+i)%len(localHosts)]
+		} else {
+			host = remoteHosts[(pos+i)%len(remoteHosts)]
+		}
+		i++
+		return &selectedHost{info: host}
+	}
+}
+
+// Info returns the selected host
+func (h *selectedHost) Info() *gocql.HostInfo {
+	return h.info
+}
+
+// Mark is a noop, host health is tracked through the host state notifications
+func (h *selectedHost) Mark(err error) {
+}
+
+// addHost returns a copy of hosts with the host appended, unless it is already there
+func addHost(hosts []*gocql.HostInfo, host *gocql.HostInfo) []*gocql.HostInfo {
+	for _, h := range hosts {
+		if h.Peer().Equal(host.Peer()) {
+			return hosts
+		}
+	}
+	result := make([]*gocql.HostInfo, 0, len(hosts)+1)
+	result = append(result, hosts...)
+	return append(result, host)
+}
+
+// removeHost returns a copy of hosts without the host
+func removeHost(hosts []*gocql.HostInfo, host *gocql.HostInfo) []*gocql.HostInfo {
+	result := make([]*gocql.HostInfo, 0, len(hosts))
+	for _, h := range hosts {
+		if !h.Peer().Equal(host.Peer()) {
+			result = append(result, h)
+		}
+	}
+	return result
+}
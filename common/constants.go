@@ -27,6 +27,9 @@ const (
 	EmptyEventID int64 = -23
 )
 
+// ServerVersion is the version of the cadence server
+const ServerVersion = "0.0.1"
+
 const (
 	// FrontendServiceName is the name of the frontend service
 	FrontendServiceName = "cadence-frontend"
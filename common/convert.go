@@ -86,3 +86,13 @@ func EventTypePtr(t s.EventType) *s.EventType {
 func WorkflowTypePtr(t s.WorkflowType) *s.WorkflowType {
 	return &t
 }
+
+// QueryResultTypePtr makes a copy and returns the pointer to a QueryResultType.
+func QueryResultTypePtr(t s.QueryResultType) *s.QueryResultType {
+	return &t
+}
+
+// PendingActivityStatePtr makes a copy and returns the pointer to a PendingActivityState.
+func PendingActivityStatePtr(t s.PendingActivityState) *s.PendingActivityState {
+	return &t
+}
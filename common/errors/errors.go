@@ -0,0 +1,133 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package errors holds the typed errors shared by the cadence services.  Errors returned to callers are always one
+// of the thrift exceptions, so clients can branch on the error type instead of matching messages.
+package errors
+
+import (
+	"fmt"
+
+	h "github.com/uber/cadence/.gen/go/history"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+// NewBadRequestError returns a BadRequestError with the formatted message
+func NewBadRequestError(format string, args ...interface{}) *gen.BadRequestError {
+	return &gen.BadRequestError{Message: fmt.Sprintf(format, args...)}
+}
+
+// NewInternalServiceError returns an InternalServiceError with the formatted message
+func NewInternalServiceError(format string, args ...interface{}) *gen.InternalServiceError {
+	return &gen.InternalServiceError{Message: fmt.Sprintf(format, args...)}
+}
+
+// NewEntityNotExistsError returns an EntityNotExistsError with the formatted message
+func NewEntityNotExistsError(format string, args ...interface{}) *gen.EntityNotExistsError {
+	return &gen.EntityNotExistsError{Message: fmt.Sprintf(format, args...)}
+}
+
+// NewServiceBusyError returns a ServiceBusyError with the formatted message
+func NewServiceBusyError(format string, args ...interface{}) *gen.ServiceBusyError {
+	return &gen.ServiceBusyError{Message: fmt.Sprintf(format, args...)}
+}
+
+// NewLimitExceededError returns a LimitExceededError with the formatted message
+func NewLimitExceededError(format string, args ...interface{}) *gen.LimitExceededError {
+	return &gen.LimitExceededError{Message: fmt.Sprintf(format, args...)}
+}
+
+// NewDomainNotActiveError returns a DomainNotActiveError for the named domain with the formatted message
+func NewDomainNotActiveError(domainName string, format string, args ...interface{}) *gen.DomainNotActiveError {
+	return &gen.DomainNotActiveError{Message: fmt.Sprintf(format, args...), DomainName: domainName}
+}
+
+// NewShardOwnershipLostError returns the error redirecting the caller of the history service from the current host
+// to the owner of the shard, if the owner is known
+func NewShardOwnershipLostError(currentHost, ownerHost string) *h.ShardOwnershipLostError {
+	return &h.ShardOwnershipLostError{
+		Message: common.StringPtr(fmt.Sprintf("Shard is not owned by host: %v", currentHost)),
+		Owner:   common.StringPtr(ownerHost),
+	}
+}
+
+// NewConditionFailedError returns the persistence error of a conditional update which lost a race
+func NewConditionFailedError(format string, args ...interface{}) *persistence.ConditionFailedError {
+	return &persistence.ConditionFailedError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// Wrap prefixes the message of err with the formatted context while keeping its type, so callers still see the
+// exception they can branch on.  Errors which are not typed are wrapped into a plain error.
+func Wrap(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	prefix := fmt.Sprintf(format, args...)
+	switch e := err.(type) {
+	case *gen.BadRequestError:
+		return NewBadRequestError("%v: %v", prefix, e.Message)
+	case *gen.InternalServiceError:
+		return NewInternalServiceError("%v: %v", prefix, e.Message)
+	case *gen.EntityNotExistsError:
+		return NewEntityNotExistsError("%v: %v", prefix, e.Message)
+	case *gen.ServiceBusyError:
+		return &gen.ServiceBusyError{Message: fmt.Sprintf("%v: %v", prefix, e.Message),
+			RetryAfterMillis: e.RetryAfterMillis}
+	case *gen.LimitExceededError:
+		return NewLimitExceededError("%v: %v", prefix, e.Message)
+	case *gen.DomainNotActiveError:
+		return NewDomainNotActiveError(e.DomainName, "%v: %v", prefix, e.Message)
+	case *persistence.ConditionFailedError:
+		return NewConditionFailedError("%v: %v", prefix, e.Msg)
+	}
+
+	return fmt.Errorf("%v: %v", prefix, err)
+}
+
+// ToThrift maps err onto the exceptions of the public API.  Those exceptions are returned as is, everything else is
+// reported to the caller as an InternalServiceError.
+func ToThrift(err error) error {
+	if err == nil || IsServiceError(err) {
+		return err
+	}
+
+	return NewInternalServiceError("%v", err)
+}
+
+// IsServiceError returns true if err is one of the exceptions of the public API
+func IsServiceError(err error) bool {
+	switch err.(type) {
+	case *gen.InternalServiceError,
+		*gen.BadRequestError,
+		*gen.EntityNotExistsError,
+		*gen.WorkflowExecutionAlreadyStartedError,
+		*gen.DomainAlreadyExistsError,
+		*gen.ServiceBusyError,
+		*gen.QueryFailedError,
+		*gen.LimitExceededError,
+		*gen.DomainNotActiveError:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestWrapKeepsType(t *testing.T) {
+	err := Wrap(NewEntityNotExistsError("Workflow %v not found", "wid"), "Describe failed")
+	assert.Equal(t, &gen.EntityNotExistsError{Message: "Describe failed: Workflow wid not found"}, err)
+
+	busy := &gen.ServiceBusyError{Message: "busy", RetryAfterMillis: common.Int64Ptr(100)}
+	assert.Equal(t, &gen.ServiceBusyError{Message: "shard 1: busy", RetryAfterMillis: common.Int64Ptr(100)},
+		Wrap(busy, "shard %v", 1))
+
+	err = Wrap(NewDomainNotActiveError("domain", "draining"), "Start failed")
+	assert.Equal(t, &gen.DomainNotActiveError{Message: "Start failed: draining", DomainName: "domain"}, err)
+
+	err = Wrap(NewConditionFailedError("range changed"), "update")
+	assert.Equal(t, &persistence.ConditionFailedError{Msg: "update: range changed"}, err)
+
+	assert.Equal(t, "context: failure", Wrap(fmt.Errorf("failure"), "context").Error())
+	assert.Nil(t, Wrap(nil, "context"))
+}
+
+func TestToThrift(t *testing.T) {
+	assert.Nil(t, ToThrift(nil))
+
+	limitErr := NewLimitExceededError("too many")
+	assert.Equal(t, limitErr, ToThrift(limitErr))
+
+	assert.Equal(t, &gen.InternalServiceError{Message: "Conditional update failed"},
+		ToThrift(NewConditionFailedError("Conditional update failed")))
+	assert.Equal(t, &gen.InternalServiceError{Message: "failure"}, ToThrift(fmt.Errorf("failure")))
+	assert.IsType(t, &gen.InternalServiceError{}, ToThrift(NewShardOwnershipLostError("host1", "host2")))
+}
@@ -61,8 +61,10 @@ const (
 	ShardEngineStopped              = 4023
 
 	// MutableSateBuilder events
-	InvalidMutableStateActionEventID = 4100
+	InvalidMutableStateActionEventID    = 4100
+	MutableStateChecksumMismatchEventID = 4101
 
 	// General purpose events
-	OperationFailed = 9000
+	OperationFailed       = 9000
+	PanicRecoveredEventID = 9001
 )
@@ -45,6 +45,13 @@ func LogOperationFailedEvent(logger bark.Logger, msg string, err error) {
 	}).Warnf("%v.  Error: %v", msg, err)
 }
 
+// LogPanicRecoveredEvent is used to log a panic recovered while serving a request, along with its stack trace.
+func LogPanicRecoveredEvent(logger bark.Logger, panicValue interface{}, stack []byte) {
+	logger.WithFields(bark.Fields{
+		TagWorkflowEventID: PanicRecoveredEventID,
+	}).Errorf("Recovered from panic while serving request: %v\n%s", panicValue, stack)
+}
+
 //
 // History service logging methods
 //
@@ -190,11 +197,13 @@ func LogRingMembershipChangedEvent(logger bark.Logger, host string, added, remov
 }
 
 // LogShardClosedEvent is used to log shard closed event
-func LogShardClosedEvent(logger bark.Logger, host string, shardID int) {
+func LogShardClosedEvent(logger bark.Logger, host string, shardID int, reason string, err error) {
 	logger.WithFields(bark.Fields{
 		TagWorkflowEventID: ShardClosedEvent,
 		TagHistoryShardID:  shardID,
-	}).Infof("ShardController on host '%v' received shard closed event for shardID: %v", host, shardID)
+		TagErr:             err,
+	}).Infof("ShardController on host '%v' received shard closed event for shardID: %v, reason: %v", host, shardID,
+		reason)
 }
 
 // LogShardItemCreatedEvent is used to log creation of a shard item
@@ -247,6 +256,13 @@ func LogMutableStateInvalidAction(logger bark.Logger, errorMsg string) {
 	}).Errorf("%v.  ", errorMsg)
 }
 
+// LogMutableStateChecksumMismatchEvent is used to log a loaded mutable state not matching its persisted checksum
+func LogMutableStateChecksumMismatchEvent(logger bark.Logger, expected, actual int64) {
+	logger.WithFields(bark.Fields{
+		TagWorkflowEventID: MutableStateChecksumMismatchEventID,
+	}).Errorf("Mutable state checksum mismatch.  Expected: %v, Actual: %v", expected, actual)
+}
+
 // LogMultipleCompletionDecisionsEvent is used to log multiple completion decisions for an execution
 func LogMultipleCompletionDecisionsEvent(lg bark.Logger, decisionType shared.DecisionType) {
 	lg.WithFields(bark.Fields{
@@ -38,6 +38,8 @@ const (
 	TagWorkflowRunID        = "run-id"
 	TagHistoryShardID       = "shard-id"
 	TagDecisionType         = "decision-type"
+	TagRequestID            = "request-id"
+	TagTimerQueue           = "timer-queue"
 
 	// workflow logging tag values
 	// TagWorkflowComponent Values
@@ -48,6 +50,11 @@ const (
 	TagValueTimerQueueComponent     = "timer-queue-processor"
 	TagValueShardController         = "shard-controller"
 	TagValueMatchingEngineComponent = "matching-engine"
+	TagValueCassandraSession        = "cassandra-session"
+	TagValueTaskListScavenger       = "tasklist-scavenger"
+	TagValueTaskListWarmer          = "tasklist-warmer"
+	TagValueExecutionScavenger      = "execution-scavenger"
+	TagValueStaleExecutionMonitor   = "stale-execution-monitor"
 
 	// TagHistoryBuilderAction values
 	TagValueActionWorkflowStarted                 = "add-workflowexecution-started-event"
@@ -81,6 +88,8 @@ const (
 	TagValueActionChildExecutionFailed            = "add-childexecution-failed-event"
 	TagValueActionChildExecutionCanceled          = "add-childexecution-canceled-event"
 	TagValueActionChildExecutionTerminated        = "add-childexecution-terminated-event"
+	TagValueActionExternalCancelRequested         = "add-external-cancel-requested-event"
+	TagValueActionRequestCancelExternalFailed     = "add-request-cancel-external-failed-event"
 
 	// TagStoreOperation values
 	TagValueStoreOperationGetTasks                = "get-tasks"
@@ -111,6 +111,13 @@ func (m *ClientImpl) UpdateGauge(scopeIdx int, gaugeIdx int, delta float64) {
 	m.childScopes[scopeIdx].Gauge(name).Update(delta)
 }
 
+// RecordHistogramValue records the value in the
+// histogram for the given metric name
+func (m *ClientImpl) RecordHistogramValue(scopeIdx int, histogramIdx int, value float64) {
+	def := m.metricDefs[histogramIdx]
+	m.childScopes[scopeIdx].Histogram(string(def.metricName), def.buckets).RecordValue(value)
+}
+
 // Tagged returns a client that adds the given tags to all metrics
 func (m *ClientImpl) Tagged(tags map[string]string) Client {
 	scope := m.parentScope.Tagged(tags)
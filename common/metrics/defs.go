@@ -20,6 +20,8 @@
 
 package metrics
 
+import "github.com/uber-go/tally"
+
 // types used/defined by the package
 type (
 	// MetricName is the name of the metric
@@ -30,8 +32,9 @@ type (
 
 	// metricDefinition contains the definition for a metric
 	metricDefinition struct {
-		metricType MetricType // metric type
-		metricName MetricName // metric name
+		metricType MetricType    // metric type
+		metricName MetricName    // metric name
+		buckets    tally.Buckets // buckets for histogram metrics
 	}
 
 	// scopeDefinition holds the tag definitions for a scope
@@ -49,6 +52,7 @@ const (
 	Counter MetricType = iota
 	Timer
 	Gauge
+	Histogram
 )
 
 // Service names for all services that emit metrics.
@@ -62,9 +66,16 @@ const (
 
 // Common tags for all services
 const (
-	HostnameTagName  = "hostname"
-	OperationTagName = "operation"
-	ShardTagName     = "shard"
+	HostnameTagName      = "hostname"
+	OperationTagName     = "operation"
+	ShardTagName         = "shard"
+	TargetHostTagName    = "target_host"
+	KeyspaceTagName      = "keyspace"
+	CassandraHostTagName = "cassandra_host"
+	DomainIDTagName      = "domain_id"
+	ClientNameTagName    = "client_name"
+	ClientVersionTagName = "client_version"
+	TaskTypeTagName      = "task_type"
 )
 
 // This package should hold all the metrics and tags for cadence
@@ -114,6 +125,8 @@ const (
 	PersistenceGetShardScope
 	// PersistenceUpdateShardScope tracks UpdateShard calls made by service to persistence layer
 	PersistenceUpdateShardScope
+	// PersistenceAllocateTaskIDsScope tracks AllocateTaskIDs calls made by service to persistence layer
+	PersistenceAllocateTaskIDsScope
 	// PersistenceCreateWorkflowExecutionScope tracks CreateWorkflowExecution calls made by service to persistence layer
 	PersistenceCreateWorkflowExecutionScope
 	// PersistenceGetWorkflowExecutionScope tracks GetWorkflowExecution calls made by service to persistence layer
@@ -128,10 +141,18 @@ const (
 	PersistenceGetTransferTasksScope
 	// PersistenceCompleteTransferTaskScope tracks CompleteTransferTasks calls made by service to persistence layer
 	PersistenceCompleteTransferTaskScope
+	// PersistencePutTransferDLQTaskScope tracks PutTransferDLQTask calls made by service to persistence layer
+	PersistencePutTransferDLQTaskScope
+	// PersistenceGetTransferDLQTasksScope tracks GetTransferDLQTasks calls made by service to persistence layer
+	PersistenceGetTransferDLQTasksScope
+	// PersistenceRedriveTransferDLQTaskScope tracks RedriveTransferDLQTask calls made by service to persistence layer
+	PersistenceRedriveTransferDLQTaskScope
 	// PersistenceGetTimerIndexTasksScope tracks GetTimerIndexTasks calls made by service to persistence layer
 	PersistenceGetTimerIndexTasksScope
 	// PersistenceCompleteTimerTaskScope tracks CompleteTimerTasks calls made by service to persistence layer
 	PersistenceCompleteTimerTaskScope
+	// PersistenceListExecutionsScope tracks ListExecutions calls made by service to persistence layer
+	PersistenceListExecutionsScope
 	// PersistenceCreateTaskScope tracks CreateTask calls made by service to persistence layer
 	PersistenceCreateTaskScope
 	// PersistenceGetTasksScope tracks GetTasks calls made by service to persistence layer
@@ -142,12 +163,18 @@ const (
 	PersistenceLeaseTaskListScope
 	// PersistenceUpdateTaskListScope tracks PersistenceUpdateTaskListScope calls made by service to persistence layer
 	PersistenceUpdateTaskListScope
+	// PersistenceListTaskListsScope tracks ListTaskLists calls made by service to persistence layer
+	PersistenceListTaskListsScope
+	// PersistenceDeleteTaskListScope tracks DeleteTaskList calls made by service to persistence layer
+	PersistenceDeleteTaskListScope
 	// PersistenceAppendHistoryEventsScope tracks AppendHistoryEvents calls made by service to persistence layer
 	PersistenceAppendHistoryEventsScope
 	// PersistenceGetWorkflowExecutionHistoryScope tracks GetWorkflowExecutionHistory calls made by service to persistence layer
 	PersistenceGetWorkflowExecutionHistoryScope
 	// PersistenceDeleteWorkflowExecutionHistoryScope tracks DeleteWorkflowExecutionHistory calls made by service to persistence layer
 	PersistenceDeleteWorkflowExecutionHistoryScope
+	// PersistenceListWorkflowExecutionHistoriesScope tracks ListWorkflowExecutionHistories calls made by service to persistence layer
+	PersistenceListWorkflowExecutionHistoriesScope
 	// PersistenceCreateDomainScope tracks CreateDomain calls made by service to persistence layer
 	PersistenceCreateDomainScope
 	// PersistenceGetDomainScope tracks GetDomain calls made by service to persistence layer
@@ -158,6 +185,10 @@ const (
 	PersistenceDeleteDomainScope
 	// PersistenceDeleteDomainByNameScope tracks DeleteDomainByName calls made by service to persistence layer
 	PersistenceDeleteDomainByNameScope
+	// PersistenceGetOpenExecutionCountScope tracks GetOpenExecutionCount calls made by service to persistence layer
+	PersistenceGetOpenExecutionCountScope
+	// PersistenceUpdateOpenExecutionCountScope tracks UpdateOpenExecutionCount calls made by service to persistence layer
+	PersistenceUpdateOpenExecutionCountScope
 	// HistoryClientStartWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientStartWorkflowExecutionScope
 	// HistoryClientRecordActivityTaskHeartbeatScope tracks RPC calls to history service
@@ -180,12 +211,32 @@ const (
 	HistoryClientRequestCancelWorkflowExecutionScope
 	// HistoryClientSignalWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientSignalWorkflowExecutionScope
+	// HistoryClientGetSignalReceiptScope tracks RPC calls to history service
+	HistoryClientGetSignalReceiptScope
+	// HistoryClientQueryWorkflowScope tracks RPC calls to history service
+	HistoryClientQueryWorkflowScope
+	// HistoryClientDescribeWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientDescribeWorkflowExecutionScope
 	// HistoryClientTerminateWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientTerminateWorkflowExecutionScope
 	// HistoryClientScheduleDecisionTaskScope tracks RPC calls to history service
 	HistoryClientScheduleDecisionTaskScope
 	// HistoryClientRecordChildExecutionCompletedScope tracks RPC calls to history service
 	HistoryClientRecordChildExecutionCompletedScope
+	// HistoryClientUpdateQueueProcessingScope tracks RPC calls to history service
+	HistoryClientUpdateQueueProcessingScope
+	// HistoryClientDescribeMutableStateScope tracks RPC calls to history service
+	HistoryClientDescribeMutableStateScope
+	// HistoryClientRefreshWorkflowTasksScope tracks RPC calls to history service
+	HistoryClientRefreshWorkflowTasksScope
+	// HistoryClientListStaleExecutionsScope tracks RPC calls to history service
+	HistoryClientListStaleExecutionsScope
+	// HistoryClientMigrateWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientMigrateWorkflowExecutionScope
+	// HistoryClientRedriveTransferDLQTasksScope tracks RPC calls to history service
+	HistoryClientRedriveTransferDLQTasksScope
+	// HistoryClientBatchGetNextEventIDScope tracks RPC calls to history service
+	HistoryClientBatchGetNextEventIDScope
 	// MatchingClientPollForDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientPollForDecisionTaskScope
 	// MatchingClientPollForActivityTaskScope tracks RPC calls to matching service
@@ -194,6 +245,14 @@ const (
 	MatchingClientAddActivityTaskScope
 	// MatchingClientAddDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientAddDecisionTaskScope
+	// HistoryClientCircuitBreakerScope tracks circuit breaker state changes for history hosts
+	HistoryClientCircuitBreakerScope
+	// MatchingClientCircuitBreakerScope tracks circuit breaker state changes for matching hosts
+	MatchingClientCircuitBreakerScope
+	// PersistenceSessionScope tracks the health of cassandra sessions
+	PersistenceSessionScope
+	// PersistenceHostScope tracks the health of cassandra hosts
+	PersistenceHostScope
 
 	NumCommonScopes
 )
@@ -216,6 +275,8 @@ const (
 	RespondActivityTaskFailedScope
 	// GetWorkflowExecutionHistoryScope tracks GetWorkflowExecutionHistory API calls received by service
 	GetWorkflowExecutionHistoryScope
+	// FrontendClientVersionScope tracks the client libraries calling the service
+	FrontendClientVersionScope
 
 	NumFrontendScopes
 )
@@ -242,18 +303,52 @@ const (
 	HistoryRecordActivityTaskStartedScope
 	// HistorySignalWorkflowExecutionScope tracks SignalWorkflowExecution API calls received by service
 	HistorySignalWorkflowExecutionScope
+	// HistoryGetSignalReceiptScope tracks GetSignalReceipt API calls received by service
+	HistoryGetSignalReceiptScope
+	// HistoryQueryWorkflowScope tracks QueryWorkflow API calls received by service
+	HistoryQueryWorkflowScope
+	// HistoryDescribeWorkflowExecutionScope tracks DescribeWorkflowExecution API calls received by service
+	HistoryDescribeWorkflowExecutionScope
 	// HistoryTerminateWorkflowExecutionScope tracks TerminateWorkflowExecution API calls received by service
 	HistoryTerminateWorkflowExecutionScope
 	// HistoryScheduleDecisionTaskScope tracks ScheduleDecisionTask API calls received by service
 	HistoryScheduleDecisionTaskScope
 	// HistoryRecordChildExecutionCompletedScope tracks CompleteChildExecution API calls received by service
 	HistoryRecordChildExecutionCompletedScope
+	// HistoryUpdateQueueProcessingScope tracks UpdateQueueProcessing API calls received by service
+	HistoryUpdateQueueProcessingScope
+	// HistoryDescribeMutableStateScope tracks DescribeMutableState API calls received by service
+	HistoryDescribeMutableStateScope
+	// HistoryRefreshWorkflowTasksScope tracks RefreshWorkflowTasks API calls received by service
+	HistoryRefreshWorkflowTasksScope
+	// HistoryListStaleExecutionsScope tracks ListStaleExecutions API calls received by service
+	HistoryListStaleExecutionsScope
+	// HistoryMigrateWorkflowExecutionScope tracks MigrateWorkflowExecution API calls received by service
+	HistoryMigrateWorkflowExecutionScope
+	// HistoryRedriveTransferDLQTasksScope tracks RedriveTransferDLQTasks API calls received by service
+	HistoryRedriveTransferDLQTasksScope
+	// HistoryBatchGetNextEventIDScope tracks BatchGetWorkflowExecutionNextEventID API calls received by service
+	HistoryBatchGetNextEventIDScope
 	// HistoryProcessTransferTasksScope tracks number of transfer tasks processed
 	HistoryProcessTransferTasksScope
 	// HistoryRequestCancelWorkflowExecutionScope tracks RequestCancelWorkflowExecution API calls received by service
 	HistoryRequestCancelWorkflowExecutionScope
 	// HistoryMultipleCompletionDecisionsScope tracks number of duplicate completion decisions for an execution
 	HistoryMultipleCompletionDecisionsScope
+	// HistoryProcessTimerTasksScope tracks number of timer tasks processed
+	HistoryProcessTimerTasksScope
+	// HistoryLoadMutableStateScope tracks loads of workflow execution mutable state from persistence
+	HistoryLoadMutableStateScope
+	// HistoryExecutionScavengerScope tracks garbage found by the background scans of executions
+	HistoryExecutionScavengerScope
+	// HistoryStaleExecutionMonitorScope tracks stale executions found by the background scans of executions
+	HistoryStaleExecutionMonitorScope
+	// HistoryExecutionStatsScope tracks the usage of workflow executions, reported when they close
+	HistoryExecutionStatsScope
+	// HistoryCacheScope tracks the workflow executions held in the history cache
+	HistoryCacheScope
+	// HistoryShardControllerScope tracks the shards of the host, their loads and unloads and their task ID ranges
+	HistoryShardControllerScope
 
 	NumHistoryScopes
 )
@@ -268,6 +363,8 @@ const (
 	MatchingAddActivityTaskScope
 	// MatchingAddDecisionTaskScope tracks AddDecisionTask API calls received by service
 	MatchingAddDecisionTaskScope
+	// MatchingTaskListMgrScope tracks the tasks loaded by the task list managers
+	MatchingTaskListMgrScope
 
 	NumMatchingScopes
 )
@@ -279,6 +376,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceCreateShardScope:                    {operation: "CreateShard"},
 		PersistenceGetShardScope:                       {operation: "GetShard"},
 		PersistenceUpdateShardScope:                    {operation: "UpdateShard"},
+		PersistenceAllocateTaskIDsScope:                {operation: "AllocateTaskIDs"},
 		PersistenceCreateWorkflowExecutionScope:        {operation: "CreateWorkflowExecution"},
 		PersistenceGetWorkflowExecutionScope:           {operation: "GetWorkflowExecution"},
 		PersistenceUpdateWorkflowExecutionScope:        {operation: "UpdateWorkflowExecution"},
@@ -286,21 +384,30 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceGetCurrentExecutionScope:            {operation: "GetCurrentExecution"},
 		PersistenceGetTransferTasksScope:               {operation: "GetTransferTasks"},
 		PersistenceCompleteTransferTaskScope:           {operation: "CompleteTransferTask"},
+		PersistencePutTransferDLQTaskScope:             {operation: "PutTransferDLQTask"},
+		PersistenceGetTransferDLQTasksScope:            {operation: "GetTransferDLQTasks"},
+		PersistenceRedriveTransferDLQTaskScope:         {operation: "RedriveTransferDLQTask"},
 		PersistenceGetTimerIndexTasksScope:             {operation: "GetTimerIndexTasks"},
 		PersistenceCompleteTimerTaskScope:              {operation: "CompleteTimerTask"},
+		PersistenceListExecutionsScope:                 {operation: "ListExecutions"},
 		PersistenceCreateTaskScope:                     {operation: "CreateTask"},
 		PersistenceGetTasksScope:                       {operation: "GetTasks"},
 		PersistenceCompleteTaskScope:                   {operation: "CompleteTask"},
 		PersistenceLeaseTaskListScope:                  {operation: "LeaseTaskList"},
 		PersistenceUpdateTaskListScope:                 {operation: "UpdateTaskList"},
+		PersistenceListTaskListsScope:                  {operation: "ListTaskLists"},
+		PersistenceDeleteTaskListScope:                 {operation: "DeleteTaskList"},
 		PersistenceAppendHistoryEventsScope:            {operation: "AppendHistoryEvents"},
 		PersistenceGetWorkflowExecutionHistoryScope:    {operation: "GetWorkflowExecutionHistory"},
 		PersistenceDeleteWorkflowExecutionHistoryScope: {operation: "DeleteWorkflowExecutionHistory"},
+		PersistenceListWorkflowExecutionHistoriesScope: {operation: "ListWorkflowExecutionHistories"},
 		PersistenceCreateDomainScope:                   {operation: "CreateDomain"},
 		PersistenceGetDomainScope:                      {operation: "GetDomain"},
 		PersistenceUpdateDomainScope:                   {operation: "UpdateDomain"},
 		PersistenceDeleteDomainScope:                   {operation: "DeleteDomain"},
 		PersistenceDeleteDomainByNameScope:             {operation: "DeleteDomainByName"},
+		PersistenceGetOpenExecutionCountScope:          {operation: "GetOpenExecutionCount"},
+		PersistenceUpdateOpenExecutionCountScope:       {operation: "UpdateOpenExecutionCount"},
 
 		HistoryClientStartWorkflowExecutionScope:          {operation: "HistoryClientStartWorkflowExecution"},
 		HistoryClientRecordActivityTaskHeartbeatScope:     {operation: "HistoryClientRecordActivityTaskHeartbeat"},
@@ -313,13 +420,27 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientRecordActivityTaskStartedScope:       {operation: "HistoryClientRecordActivityTaskStarted"},
 		HistoryClientRequestCancelWorkflowExecutionScope:  {operation: "HistoryClientRequestCancelWorkflowExecution"},
 		HistoryClientSignalWorkflowExecutionScope:         {operation: "HistoryClientSignalWorkflowExecution"},
+		HistoryClientGetSignalReceiptScope:                {operation: "HistoryClientGetSignalReceipt"},
+		HistoryClientQueryWorkflowScope:                   {operation: "HistoryClientQueryWorkflow"},
+		HistoryClientDescribeWorkflowExecutionScope:       {operation: "HistoryClientDescribeWorkflowExecution"},
 		HistoryClientTerminateWorkflowExecutionScope:      {operation: "HistoryClientTerminateWorkflowExecution"},
 		HistoryClientScheduleDecisionTaskScope:            {operation: "HistoryClientScheduleDecisionTask"},
 		HistoryClientRecordChildExecutionCompletedScope:   {operation: "HistoryClientRecordChildExecutionCompleted"},
+		HistoryClientUpdateQueueProcessingScope:           {operation: "HistoryClientUpdateQueueProcessing"},
+		HistoryClientDescribeMutableStateScope:            {operation: "HistoryClientDescribeMutableState"},
+		HistoryClientRefreshWorkflowTasksScope:            {operation: "HistoryClientRefreshWorkflowTasks"},
+		HistoryClientListStaleExecutionsScope:             {operation: "HistoryClientListStaleExecutions"},
+		HistoryClientMigrateWorkflowExecutionScope:        {operation: "HistoryClientMigrateWorkflowExecution"},
+		HistoryClientRedriveTransferDLQTasksScope:         {operation: "HistoryClientRedriveTransferDLQTasks"},
+		HistoryClientBatchGetNextEventIDScope:             {operation: "HistoryClientBatchGetWorkflowExecutionNextEventID"},
 		MatchingClientPollForDecisionTaskScope:            {operation: "MatchingClientPollForDecisionTask"},
 		MatchingClientPollForActivityTaskScope:            {operation: "MatchingClientPollForActivityTask"},
 		MatchingClientAddActivityTaskScope:                {operation: "MatchingClientAddActivityTask"},
 		MatchingClientAddDecisionTaskScope:                {operation: "MatchingClientAddDecisionTask"},
+		HistoryClientCircuitBreakerScope:                  {operation: "HistoryClientCircuitBreaker"},
+		MatchingClientCircuitBreakerScope:                 {operation: "MatchingClientCircuitBreaker"},
+		PersistenceSessionScope:                           {operation: "PersistenceSession"},
+		PersistenceHostScope:                              {operation: "PersistenceHost"},
 	},
 	// Frontend Scope Names
 	Frontend: {
@@ -331,6 +452,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		RespondActivityTaskCompletedScope: {operation: "RespondActivityTaskCompleted"},
 		RespondActivityTaskFailedScope:    {operation: "RespondActivityTaskFailed"},
 		GetWorkflowExecutionHistoryScope:  {operation: "GetWorkflowExecutionHistory"},
+		FrontendClientVersionScope:        {operation: "ClientVersion"},
 	},
 	// History Scope Names
 	History: {
@@ -344,12 +466,29 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRecordDecisionTaskStartedScope:       {operation: "RecordDecisionTaskStarted"},
 		HistoryRecordActivityTaskStartedScope:       {operation: "RecordActivityTaskStarted"},
 		HistorySignalWorkflowExecutionScope:         {operation: "SignalWorkflowExecution"},
+		HistoryGetSignalReceiptScope:                {operation: "GetSignalReceipt"},
+		HistoryQueryWorkflowScope:                   {operation: "QueryWorkflow"},
+		HistoryDescribeWorkflowExecutionScope:       {operation: "DescribeWorkflowExecution"},
 		HistoryTerminateWorkflowExecutionScope:      {operation: "TerminateWorkflowExecution"},
 		HistoryScheduleDecisionTaskScope:            {operation: "ScheduleDecisionTask"},
 		HistoryRecordChildExecutionCompletedScope:   {operation: "RecordChildExecutionCompleted"},
+		HistoryUpdateQueueProcessingScope:           {operation: "UpdateQueueProcessing"},
+		HistoryDescribeMutableStateScope:            {operation: "DescribeMutableState"},
+		HistoryRefreshWorkflowTasksScope:            {operation: "RefreshWorkflowTasks"},
+		HistoryListStaleExecutionsScope:             {operation: "ListStaleExecutions"},
+		HistoryMigrateWorkflowExecutionScope:        {operation: "MigrateWorkflowExecution"},
+		HistoryRedriveTransferDLQTasksScope:         {operation: "RedriveTransferDLQTasks"},
+		HistoryBatchGetNextEventIDScope:             {operation: "BatchGetWorkflowExecutionNextEventID"},
 		HistoryProcessTransferTasksScope:            {operation: "ProcessTransferTask"},
 		HistoryRequestCancelWorkflowExecutionScope:  {operation: "RequestCancelWorkflowExecution"},
 		HistoryMultipleCompletionDecisionsScope:     {operation: "MultipleCompletionDecisions"},
+		HistoryProcessTimerTasksScope:               {operation: "ProcessTimerTask"},
+		HistoryLoadMutableStateScope:                {operation: "LoadMutableState"},
+		HistoryExecutionScavengerScope:              {operation: "ExecutionScavenger"},
+		HistoryStaleExecutionMonitorScope:           {operation: "StaleExecutionMonitor"},
+		HistoryExecutionStatsScope:                  {operation: "ExecutionStats"},
+		HistoryCacheScope:                           {operation: "HistoryCache"},
+		HistoryShardControllerScope:                 {operation: "ShardController"},
 	},
 	// Matching Scope Names
 	Matching: {
@@ -357,6 +496,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingPollForActivityTaskScope: {operation: "PollForActivityTask"},
 		MatchingAddActivityTaskScope:     {operation: "AddActivityTask"},
 		MatchingAddDecisionTaskScope:     {operation: "AddDecisionTask"},
+		MatchingTaskListMgrScope:         {operation: "TaskListMgr"},
 	},
 }
 
@@ -369,6 +509,9 @@ const (
 	CadenceErrEntityNotExistsCounter
 	CadenceErrExecutionAlreadyStartedCounter
 	CadenceErrDomainAlreadyExistsCounter
+	CadenceErrServiceBusyCounter
+	CadenceErrLimitExceededCounter
+	CadenceErrPanicCounter
 	PersistenceRequests
 	PersistenceFailures
 	PersistenceLatency
@@ -376,10 +519,34 @@ const (
 	PersistenceErrShardOwnershipLostCounter
 	PersistenceErrConditionFailedCounter
 	PersistenceErrTimeoutCounter
+	CircuitBreakerOpenedCounter
+	CircuitBreakerHalfOpenedCounter
+	CircuitBreakerClosedCounter
+	CircuitBreakerRejectedCounter
+	PersistenceSessionConnectedGauge
+	PersistenceSessionCheckFailedCounter
+	PersistenceSessionReconnectCounter
+	PersistenceSessionReconnectFailedCounter
+	PersistenceSessionRemoteFallbackGauge
+	PersistenceQueryRetryCounter
+	PersistenceSlowQueryCounter
+	PersistenceHostUpGauge
+	PersistenceHostRequests
+	PersistenceHostFailures
+	PersistenceHostLatency
+	PersistenceHostTimeoutCounter
+	PersistenceHostUnavailableCounter
+	PersistenceHostRetryCounter
 
 	NumCommonMetrics
 )
 
+// Frontend Metrics enum
+const (
+	FrontendClientRequests = iota + NumCommonMetrics
+	FrontendClientVersionRejectedCounter
+)
+
 // History Metrics enum
 const (
 	TransferTasksProcessedCounter = iota + NumCommonMetrics
@@ -387,8 +554,59 @@ const (
 	FailedDecisionsCounter
 	CadenceErrEventAlreadyStartedCounter
 	CadenceErrShardOwnershipLostCounter
+	TransferTaskAckLevelLagGauge
+	TransferTasksThrottledCounter
+	TransferTasksMatchingFailedCounter
+	TransferTasksParkedCounter
+	DeduplicatedStartWorkflowExecutionCounter
+	DuplicateStartWorkflowExecutionAge
+	TimerTasksProcessedCounter
+	TimerTaskFireLatency
+	TimerAheadOfNowGauge
+	TimerTasksJitteredCounter
+	MutableStateChecksumMismatchCounter
+	ExecutionScavengerOrphanedExecutionsCounter
+	ExecutionScavengerOrphanedHistoriesCounter
+	ExecutionScavengerOrphanedTasksCounter
+	ExecutionScavengerDeletedCounter
+	StaleDecisionsCounter
+	StaleTimersCounter
+	StaleExecutionsGauge
+	ExecutionsClosedCounter
+	ExecutionEventsCounter
+	ExecutionSignalsCounter
+	ExecutionActivitiesCounter
+	ExecutionTimersCounter
+	ExecutionDecisionsCounter
+	MutableStateSizeHistogram
+	HistoryBlobSizeHistogram
+	HistoryCacheEntriesGauge
+	HistoryCacheBytesGauge
+	HistoryCacheAverageStateSizeGauge
+	ShardClosedLeaseLostCounter
+	ShardClosedErrorCounter
+	ShardClosedShutdownCounter
+	ShardRangeExhaustedCounter
+	TimerSequenceExhaustedCounter
+	StuckExecutionsTerminatedCounter
+	BatchedSignalsCounter
+	DuplicateSignalsCounter
+	TaskScheduleToStartLatency
+	TaskStartToCompleteLatency
+	TransferTaskQueueLatency
+	CompletedExecutionCacheHitCounter
+	DuplicateActivityCompletionCounter
+)
+
+// Matching Metrics enum
+const (
+	DeferredTasksCounter = iota + NumCommonMetrics
+	DeferredTasksLimitReachedCounter
 )
 
+// sizeBuckets are the histogram buckets for sizes in bytes, doubling from 1KB to 32MB
+var sizeBuckets = tally.MustMakeExponentialValueBuckets(1024, 2, 16)
+
 // MetricDefs record the metrics for all services
 var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 	Common: {
@@ -399,6 +617,9 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		CadenceErrEntityNotExistsCounter:         {metricName: "cadence.errors.entity-not-exists", metricType: Counter},
 		CadenceErrExecutionAlreadyStartedCounter: {metricName: "cadence.errors.execution-already-started", metricType: Counter},
 		CadenceErrDomainAlreadyExistsCounter:     {metricName: "cadence.errors.domain-already-exists", metricType: Counter},
+		CadenceErrServiceBusyCounter:             {metricName: "cadence.errors.service-busy", metricType: Counter},
+		CadenceErrLimitExceededCounter:           {metricName: "cadence.errors.limit-exceeded", metricType: Counter},
+		CadenceErrPanicCounter:                   {metricName: "cadence.errors.panic", metricType: Counter},
 		PersistenceRequests:                      {metricName: "persistence.requests", metricType: Counter},
 		PersistenceFailures:                      {metricName: "persistence.errors", metricType: Counter},
 		PersistenceLatency:                       {metricName: "persistence.latency", metricType: Timer},
@@ -406,16 +627,82 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		PersistenceErrShardOwnershipLostCounter:  {metricName: "persistence.errors.shard-ownership-lost", metricType: Counter},
 		PersistenceErrConditionFailedCounter:     {metricName: "persistence.errors.condition-failed", metricType: Counter},
 		PersistenceErrTimeoutCounter:             {metricName: "persistence.errors.timeout", metricType: Counter},
+		CircuitBreakerOpenedCounter:              {metricName: "circuit-breaker.opened", metricType: Counter},
+		CircuitBreakerHalfOpenedCounter:          {metricName: "circuit-breaker.half-opened", metricType: Counter},
+		CircuitBreakerClosedCounter:              {metricName: "circuit-breaker.closed", metricType: Counter},
+		CircuitBreakerRejectedCounter:            {metricName: "circuit-breaker.rejected", metricType: Counter},
+		PersistenceSessionConnectedGauge:         {metricName: "persistence.session.connected", metricType: Gauge},
+		PersistenceSessionCheckFailedCounter:     {metricName: "persistence.session.check-failed", metricType: Counter},
+		PersistenceSessionReconnectCounter:       {metricName: "persistence.session.reconnects", metricType: Counter},
+		PersistenceSessionReconnectFailedCounter: {metricName: "persistence.session.reconnect-failed", metricType: Counter},
+		PersistenceSessionRemoteFallbackGauge:    {metricName: "persistence.session.remote-fallback", metricType: Gauge},
+		PersistenceQueryRetryCounter:             {metricName: "persistence.session.query-retries", metricType: Counter},
+		PersistenceSlowQueryCounter:              {metricName: "persistence.session.slow-queries", metricType: Counter},
+		PersistenceHostUpGauge:                   {metricName: "persistence.host.up", metricType: Gauge},
+		PersistenceHostRequests:                  {metricName: "persistence.host.requests", metricType: Counter},
+		PersistenceHostFailures:                  {metricName: "persistence.host.errors", metricType: Counter},
+		PersistenceHostLatency:                   {metricName: "persistence.host.latency", metricType: Timer},
+		PersistenceHostTimeoutCounter:            {metricName: "persistence.host.errors.timeout", metricType: Counter},
+		PersistenceHostUnavailableCounter:        {metricName: "persistence.host.errors.unavailable", metricType: Counter},
+		PersistenceHostRetryCounter:              {metricName: "persistence.host.retries", metricType: Counter},
+	},
+	Frontend: {
+		FrontendClientRequests:               {metricName: "client.requests", metricType: Counter},
+		FrontendClientVersionRejectedCounter: {metricName: "client.version-rejected", metricType: Counter},
 	},
-	Frontend: {},
 	History: {
-		TransferTasksProcessedCounter:        {metricName: "transfer-tasks-processed", metricType: Counter},
-		MultipleCompletionDecisionsCounter:   {metricName: "multiple-completion-decisions", metricType: Counter},
-		FailedDecisionsCounter:               {metricName: "failed-decisions", metricType: Counter},
-		CadenceErrShardOwnershipLostCounter:  {metricName: "cadence.errors.shard-ownership-lost", metricType: Counter},
-		CadenceErrEventAlreadyStartedCounter: {metricName: "cadence.errors.event-already-started", metricType: Counter},
+		TransferTasksProcessedCounter:               {metricName: "transfer-tasks-processed", metricType: Counter},
+		MultipleCompletionDecisionsCounter:          {metricName: "multiple-completion-decisions", metricType: Counter},
+		FailedDecisionsCounter:                      {metricName: "failed-decisions", metricType: Counter},
+		CadenceErrShardOwnershipLostCounter:         {metricName: "cadence.errors.shard-ownership-lost", metricType: Counter},
+		CadenceErrEventAlreadyStartedCounter:        {metricName: "cadence.errors.event-already-started", metricType: Counter},
+		TransferTaskAckLevelLagGauge:                {metricName: "transfer-ack-level-lag", metricType: Gauge},
+		TransferTasksThrottledCounter:               {metricName: "transfer-tasks-throttled", metricType: Counter},
+		TransferTasksMatchingFailedCounter:          {metricName: "transfer-tasks-matching-failed", metricType: Counter},
+		TransferTasksParkedCounter:                  {metricName: "transfer-tasks-parked", metricType: Counter},
+		DeduplicatedStartWorkflowExecutionCounter:   {metricName: "start-workflow-deduplicated", metricType: Counter},
+		DuplicateStartWorkflowExecutionAge:          {metricName: "duplicate-start-execution-age", metricType: Timer},
+		TimerTasksProcessedCounter:                  {metricName: "timer-tasks-processed", metricType: Counter},
+		TimerTaskFireLatency:                        {metricName: "timer-fire-latency", metricType: Timer},
+		TimerAheadOfNowGauge:                        {metricName: "timer-ahead-of-now-ms", metricType: Gauge},
+		TimerTasksJitteredCounter:                   {metricName: "timer-tasks-jittered", metricType: Counter},
+		MutableStateChecksumMismatchCounter:         {metricName: "mutable-state-checksum-mismatch", metricType: Counter},
+		ExecutionScavengerOrphanedExecutionsCounter: {metricName: "scavenger-orphaned-executions", metricType: Counter},
+		ExecutionScavengerOrphanedHistoriesCounter:  {metricName: "scavenger-orphaned-histories", metricType: Counter},
+		ExecutionScavengerOrphanedTasksCounter:      {metricName: "scavenger-orphaned-tasks", metricType: Counter},
+		ExecutionScavengerDeletedCounter:            {metricName: "scavenger-deleted-garbage", metricType: Counter},
+		StaleDecisionsCounter:                       {metricName: "stale-decisions", metricType: Counter},
+		StaleTimersCounter:                          {metricName: "stale-timers", metricType: Counter},
+		StaleExecutionsGauge:                        {metricName: "stale-executions", metricType: Gauge},
+		ExecutionsClosedCounter:                     {metricName: "executions-closed", metricType: Counter},
+		ExecutionEventsCounter:                      {metricName: "execution-events", metricType: Counter},
+		ExecutionSignalsCounter:                     {metricName: "execution-signals", metricType: Counter},
+		ExecutionActivitiesCounter:                  {metricName: "execution-activities", metricType: Counter},
+		ExecutionTimersCounter:                      {metricName: "execution-timers", metricType: Counter},
+		ExecutionDecisionsCounter:                   {metricName: "execution-decisions", metricType: Counter},
+		MutableStateSizeHistogram:                   {metricName: "mutable-state-size", metricType: Histogram, buckets: sizeBuckets},
+		HistoryBlobSizeHistogram:                    {metricName: "history-blob-size", metricType: Histogram, buckets: sizeBuckets},
+		HistoryCacheEntriesGauge:                    {metricName: "history-cache-entries", metricType: Gauge},
+		HistoryCacheBytesGauge:                      {metricName: "history-cache-bytes", metricType: Gauge},
+		HistoryCacheAverageStateSizeGauge:           {metricName: "history-cache-average-state-size", metricType: Gauge},
+		ShardClosedLeaseLostCounter:                 {metricName: "shard-closed-lease-lost", metricType: Counter},
+		ShardClosedErrorCounter:                     {metricName: "shard-closed-error", metricType: Counter},
+		ShardClosedShutdownCounter:                  {metricName: "shard-closed-shutdown", metricType: Counter},
+		ShardRangeExhaustedCounter:                  {metricName: "shard-range-exhausted", metricType: Counter},
+		TimerSequenceExhaustedCounter:               {metricName: "timer-sequence-exhausted", metricType: Counter},
+		StuckExecutionsTerminatedCounter:            {metricName: "stuck-executions-terminated", metricType: Counter},
+		BatchedSignalsCounter:                       {metricName: "batched-signals", metricType: Counter},
+		DuplicateSignalsCounter:                     {metricName: "duplicate-signals", metricType: Counter},
+		TaskScheduleToStartLatency:                  {metricName: "task-schedule-to-start-latency", metricType: Timer},
+		TaskStartToCompleteLatency:                  {metricName: "task-start-to-complete-latency", metricType: Timer},
+		TransferTaskQueueLatency:                    {metricName: "transfer-task-queue-latency", metricType: Timer},
+		CompletedExecutionCacheHitCounter:           {metricName: "completed-execution-cache-hit", metricType: Counter},
+		DuplicateActivityCompletionCounter:          {metricName: "duplicate-activity-completion", metricType: Counter},
+	},
+	Matching: {
+		DeferredTasksCounter:             {metricName: "deferred-tasks", metricType: Counter},
+		DeferredTasksLimitReachedCounter: {metricName: "deferred-tasks-limit-reached", metricType: Counter},
 	},
-	Matching: {},
 }
 
 // ErrorClass is an enum to help with classifying SLA vs. non-SLA errors (SLA = "service level agreement")
@@ -41,6 +41,8 @@ type (
 		RecordTimer(scope int, timer int, d time.Duration)
 		// UpdateGauge reports Gauge type metric
 		UpdateGauge(scope int, gauge int, delta float64)
+		// RecordHistogramValue records a value in the buckets of a histogram metric
+		RecordHistogramValue(scope int, histogram int, value float64)
 		// Tagged returns a client that adds the given tags to all metrics
 		Tagged(tags map[string]string) Client
 	}
@@ -21,6 +21,7 @@
 package mocks
 
 import mock "github.com/stretchr/testify/mock"
+import "golang.org/x/net/context"
 import persistence "github.com/uber/cadence/common/persistence"
 
 // ExecutionManager is an autogenerated mock type for the ExecutionManager type
@@ -28,13 +29,18 @@ type ExecutionManager struct {
 	mock.Mock
 }
 
-// CompleteTimerTask provides a mock function with given fields: request
-func (_m *ExecutionManager) CompleteTimerTask(request *persistence.CompleteTimerTaskRequest) error {
-	ret := _m.Called(request)
+// Close provides a mock function with given fields:
+func (_m *ExecutionManager) Close() {
+	_m.Called()
+}
+
+// CompleteTimerTask provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) CompleteTimerTask(ctx context.Context, request *persistence.CompleteTimerTaskRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.CompleteTimerTaskRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.CompleteTimerTaskRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -42,13 +48,13 @@ func (_m *ExecutionManager) CompleteTimerTask(request *persistence.CompleteTimer
 	return r0
 }
 
-// CompleteTransferTask provides a mock function with given fields: request
-func (_m *ExecutionManager) CompleteTransferTask(request *persistence.CompleteTransferTaskRequest) error {
-	ret := _m.Called(request)
+// CompleteTransferTask provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) CompleteTransferTask(ctx context.Context, request *persistence.CompleteTransferTaskRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.CompleteTransferTaskRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.CompleteTransferTaskRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -56,13 +62,13 @@ func (_m *ExecutionManager) CompleteTransferTask(request *persistence.CompleteTr
 	return r0
 }
 
-// CreateWorkflowExecution provides a mock function with given fields: request
-func (_m *ExecutionManager) CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error) {
-	ret := _m.Called(request)
+// CreateWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.CreateWorkflowExecutionResponse
-	if rf, ok := ret.Get(0).(func(*persistence.CreateWorkflowExecutionRequest) *persistence.CreateWorkflowExecutionResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.CreateWorkflowExecutionRequest) *persistence.CreateWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.CreateWorkflowExecutionResponse)
@@ -70,8 +76,8 @@ func (_m *ExecutionManager) CreateWorkflowExecution(request *persistence.CreateW
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.CreateWorkflowExecutionRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.CreateWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -79,13 +85,13 @@ func (_m *ExecutionManager) CreateWorkflowExecution(request *persistence.CreateW
 	return r0, r1
 }
 
-// DeleteWorkflowExecution provides a mock function with given fields: request
-func (_m *ExecutionManager) DeleteWorkflowExecution(request *persistence.DeleteWorkflowExecutionRequest) error {
-	ret := _m.Called(request)
+// DeleteWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) DeleteWorkflowExecution(ctx context.Context, request *persistence.DeleteWorkflowExecutionRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.DeleteWorkflowExecutionRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.DeleteWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -93,13 +99,13 @@ func (_m *ExecutionManager) DeleteWorkflowExecution(request *persistence.DeleteW
 	return r0
 }
 
-// GetTimerIndexTasks provides a mock function with given fields: request
-func (_m *ExecutionManager) GetTimerIndexTasks(request *persistence.GetTimerIndexTasksRequest) (*persistence.GetTimerIndexTasksResponse, error) {
-	ret := _m.Called(request)
+// GetTimerIndexTasks provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) GetTimerIndexTasks(ctx context.Context, request *persistence.GetTimerIndexTasksRequest) (*persistence.GetTimerIndexTasksResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.GetTimerIndexTasksResponse
-	if rf, ok := ret.Get(0).(func(*persistence.GetTimerIndexTasksRequest) *persistence.GetTimerIndexTasksResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetTimerIndexTasksRequest) *persistence.GetTimerIndexTasksResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetTimerIndexTasksResponse)
@@ -107,8 +113,31 @@ func (_m *ExecutionManager) GetTimerIndexTasks(request *persistence.GetTimerInde
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.GetTimerIndexTasksRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetTimerIndexTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransferDLQTasks provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) GetTransferDLQTasks(ctx context.Context, request *persistence.GetTransferDLQTasksRequest) (*persistence.GetTransferDLQTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.GetTransferDLQTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetTransferDLQTasksRequest) *persistence.GetTransferDLQTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetTransferDLQTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetTransferDLQTasksRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -116,13 +145,13 @@ func (_m *ExecutionManager) GetTimerIndexTasks(request *persistence.GetTimerInde
 	return r0, r1
 }
 
-// GetTransferTasks provides a mock function with given fields: request
-func (_m *ExecutionManager) GetTransferTasks(request *persistence.GetTransferTasksRequest) (*persistence.GetTransferTasksResponse, error) {
-	ret := _m.Called(request)
+// GetTransferTasks provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) GetTransferTasks(ctx context.Context, request *persistence.GetTransferTasksRequest) (*persistence.GetTransferTasksResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.GetTransferTasksResponse
-	if rf, ok := ret.Get(0).(func(*persistence.GetTransferTasksRequest) *persistence.GetTransferTasksResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetTransferTasksRequest) *persistence.GetTransferTasksResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetTransferTasksResponse)
@@ -130,8 +159,8 @@ func (_m *ExecutionManager) GetTransferTasks(request *persistence.GetTransferTas
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.GetTransferTasksRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetTransferTasksRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -139,13 +168,13 @@ func (_m *ExecutionManager) GetTransferTasks(request *persistence.GetTransferTas
 	return r0, r1
 }
 
-// GetWorkflowExecution provides a mock function with given fields: request
-func (_m *ExecutionManager) GetWorkflowExecution(request *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
-	ret := _m.Called(request)
+// GetWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) GetWorkflowExecution(ctx context.Context, request *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.GetWorkflowExecutionResponse
-	if rf, ok := ret.Get(0).(func(*persistence.GetWorkflowExecutionRequest) *persistence.GetWorkflowExecutionResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetWorkflowExecutionRequest) *persistence.GetWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetWorkflowExecutionResponse)
@@ -153,8 +182,8 @@ func (_m *ExecutionManager) GetWorkflowExecution(request *persistence.GetWorkflo
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.GetWorkflowExecutionRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -162,13 +191,13 @@ func (_m *ExecutionManager) GetWorkflowExecution(request *persistence.GetWorkflo
 	return r0, r1
 }
 
-// GetWorkflowExecution provides a mock function with given fields: request
-func (_m *ExecutionManager) GetCurrentExecution(request *persistence.GetCurrentExecutionRequest) (*persistence.GetCurrentExecutionResponse, error) {
-	ret := _m.Called(request)
+// GetWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) GetCurrentExecution(ctx context.Context, request *persistence.GetCurrentExecutionRequest) (*persistence.GetCurrentExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.GetCurrentExecutionResponse
-	if rf, ok := ret.Get(0).(func(*persistence.GetCurrentExecutionRequest) *persistence.GetCurrentExecutionResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetCurrentExecutionRequest) *persistence.GetCurrentExecutionResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetCurrentExecutionResponse)
@@ -176,8 +205,31 @@ func (_m *ExecutionManager) GetCurrentExecution(request *persistence.GetCurrentE
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.GetCurrentExecutionRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetCurrentExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExecutions provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) ListExecutions(ctx context.Context, request *persistence.ListExecutionsRequest) (*persistence.ListExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.ListExecutionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListExecutionsRequest) *persistence.ListExecutionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListExecutionsRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -185,13 +237,41 @@ func (_m *ExecutionManager) GetCurrentExecution(request *persistence.GetCurrentE
 	return r0, r1
 }
 
-// UpdateWorkflowExecution provides a mock function with given fields: request
-func (_m *ExecutionManager) UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) error {
-	ret := _m.Called(request)
+// PutTransferDLQTask provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) PutTransferDLQTask(ctx context.Context, request *persistence.PutTransferDLQTaskRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.PutTransferDLQTaskRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RedriveTransferDLQTask provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) RedriveTransferDLQTask(ctx context.Context, request *persistence.RedriveTransferDLQTaskRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.RedriveTransferDLQTaskRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *ExecutionManager) UpdateWorkflowExecution(ctx context.Context, request *persistence.UpdateWorkflowExecutionRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.UpdateWorkflowExecutionRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.UpdateWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
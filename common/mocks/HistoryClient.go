@@ -53,6 +53,29 @@ func (_m *HistoryClient) GetWorkflowExecutionNextEventID(ctx thrift.Context, get
 	return r0, r1
 }
 
+// BatchGetWorkflowExecutionNextEventID provides a mock function with given fields: ctx, getRequest
+func (_m *HistoryClient) BatchGetWorkflowExecutionNextEventID(ctx thrift.Context, getRequest *history.BatchGetWorkflowExecutionNextEventIDRequest) (*history.BatchGetWorkflowExecutionNextEventIDResponse, error) {
+	ret := _m.Called(ctx, getRequest)
+
+	var r0 *history.BatchGetWorkflowExecutionNextEventIDResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.BatchGetWorkflowExecutionNextEventIDRequest) *history.BatchGetWorkflowExecutionNextEventIDResponse); ok {
+		r0 = rf(ctx, getRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.BatchGetWorkflowExecutionNextEventIDResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.BatchGetWorkflowExecutionNextEventIDRequest) error); ok {
+		r1 = rf(ctx, getRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RecordActivityTaskHeartbeat provides a mock function with given fields: ctx, heartbeatRequest
 func (_m *HistoryClient) RecordActivityTaskHeartbeat(ctx thrift.Context, heartbeatRequest *history.RecordActivityTaskHeartbeatRequest) (*shared.RecordActivityTaskHeartbeatResponse, error) {
 	ret := _m.Called(ctx, heartbeatRequest)
@@ -165,31 +188,118 @@ func (_m *HistoryClient) RespondActivityTaskFailed(ctx thrift.Context, failReque
 }
 
 // RespondDecisionTaskCompleted provides a mock function with given fields: ctx, completeRequest
-func (_m *HistoryClient) RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *history.RespondDecisionTaskCompletedRequest) error {
+func (_m *HistoryClient) RespondDecisionTaskCompleted(ctx thrift.Context, completeRequest *history.RespondDecisionTaskCompletedRequest) (*shared.RespondDecisionTaskCompletedResponse, error) {
 	ret := _m.Called(ctx, completeRequest)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(thrift.Context, *history.RespondDecisionTaskCompletedRequest) error); ok {
+	var r0 *shared.RespondDecisionTaskCompletedResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.RespondDecisionTaskCompletedRequest) *shared.RespondDecisionTaskCompletedResponse); ok {
 		r0 = rf(ctx, completeRequest)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.RespondDecisionTaskCompletedResponse)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.RespondDecisionTaskCompletedRequest) error); ok {
+		r1 = rf(ctx, completeRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // SignalWorkflowExecution provides a mock function with given fields: ctx, signalRequest
-func (_m *HistoryClient) SignalWorkflowExecution(ctx thrift.Context, signalRequest *history.SignalWorkflowExecutionRequest) error {
+func (_m *HistoryClient) SignalWorkflowExecution(ctx thrift.Context, signalRequest *history.SignalWorkflowExecutionRequest) (*shared.SignalWorkflowExecutionResponse, error) {
 	ret := _m.Called(ctx, signalRequest)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(thrift.Context, *history.SignalWorkflowExecutionRequest) error); ok {
+	var r0 *shared.SignalWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.SignalWorkflowExecutionRequest) *shared.SignalWorkflowExecutionResponse); ok {
 		r0 = rf(ctx, signalRequest)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.SignalWorkflowExecutionResponse)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.SignalWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, signalRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSignalReceipt provides a mock function with given fields: ctx, receiptRequest
+func (_m *HistoryClient) GetSignalReceipt(ctx thrift.Context, receiptRequest *history.GetSignalReceiptRequest) (*shared.GetSignalReceiptResponse, error) {
+	ret := _m.Called(ctx, receiptRequest)
+
+	var r0 *shared.GetSignalReceiptResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.GetSignalReceiptRequest) *shared.GetSignalReceiptResponse); ok {
+		r0 = rf(ctx, receiptRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetSignalReceiptResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.GetSignalReceiptRequest) error); ok {
+		r1 = rf(ctx, receiptRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryWorkflow provides a mock function with given fields: ctx, queryRequest
+func (_m *HistoryClient) QueryWorkflow(ctx thrift.Context, queryRequest *history.QueryWorkflowRequest) (*shared.QueryWorkflowResponse, error) {
+	ret := _m.Called(ctx, queryRequest)
+
+	var r0 *shared.QueryWorkflowResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.QueryWorkflowRequest) *shared.QueryWorkflowResponse); ok {
+		r0 = rf(ctx, queryRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.QueryWorkflowResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.QueryWorkflowRequest) error); ok {
+		r1 = rf(ctx, queryRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeWorkflowExecution provides a mock function with given fields: ctx, describeRequest
+func (_m *HistoryClient) DescribeWorkflowExecution(ctx thrift.Context, describeRequest *history.DescribeWorkflowExecutionRequest) (*shared.DescribeWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, describeRequest)
+
+	var r0 *shared.DescribeWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.DescribeWorkflowExecutionRequest) *shared.DescribeWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, describeRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.DescribeWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, describeRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // StartWorkflowExecution provides a mock function with given fields: ctx, startRequest
@@ -230,17 +340,26 @@ func (_m *HistoryClient) RequestCancelWorkflowExecution(ctx thrift.Context, requ
 }
 
 // TerminateWorkflowExecution provides a mock function with given fields: ctx, terminateRequest
-func (_m *HistoryClient) TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *history.TerminateWorkflowExecutionRequest) error {
+func (_m *HistoryClient) TerminateWorkflowExecution(ctx thrift.Context, terminateRequest *history.TerminateWorkflowExecutionRequest) (*shared.TerminateWorkflowExecutionResponse, error) {
 	ret := _m.Called(ctx, terminateRequest)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(thrift.Context, *history.TerminateWorkflowExecutionRequest) error); ok {
+	var r0 *shared.TerminateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.TerminateWorkflowExecutionRequest) *shared.TerminateWorkflowExecutionResponse); ok {
 		r0 = rf(ctx, terminateRequest)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.TerminateWorkflowExecutionResponse)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.TerminateWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, terminateRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // ScheduleDecisionTask provides a mock function with given fields: ctx, request
@@ -257,6 +376,117 @@ func (_m *HistoryClient) ScheduleDecisionTask(ctx thrift.Context, request *histo
 	return r0
 }
 
+// UpdateQueueProcessing provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) UpdateQueueProcessing(ctx thrift.Context, request *history.UpdateQueueProcessingRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.UpdateQueueProcessingRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeMutableState provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) DescribeMutableState(ctx thrift.Context, request *history.DescribeMutableStateRequest) (*history.DescribeMutableStateResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *history.DescribeMutableStateResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.DescribeMutableStateRequest) *history.DescribeMutableStateResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.DescribeMutableStateResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.DescribeMutableStateRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshWorkflowTasks provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) RefreshWorkflowTasks(ctx thrift.Context, request *history.RefreshWorkflowTasksRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.RefreshWorkflowTasksRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListStaleExecutions provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) ListStaleExecutions(ctx thrift.Context, request *history.ListStaleExecutionsRequest) (*history.ListStaleExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *history.ListStaleExecutionsResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.ListStaleExecutionsRequest) *history.ListStaleExecutionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.ListStaleExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.ListStaleExecutionsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) MigrateWorkflowExecution(ctx thrift.Context, request *history.MigrateWorkflowExecutionRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.MigrateWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RedriveTransferDLQTasks provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) RedriveTransferDLQTasks(ctx thrift.Context, request *history.RedriveTransferDLQTasksRequest) (*history.RedriveTransferDLQTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *history.RedriveTransferDLQTasksResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *history.RedriveTransferDLQTasksRequest) *history.RedriveTransferDLQTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.RedriveTransferDLQTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *history.RedriveTransferDLQTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RecordChildExecutionCompleted provides a mock function with given fields: ctx, request
 func (_m *HistoryClient) RecordChildExecutionCompleted(ctx thrift.Context, request *history.RecordChildExecutionCompletedRequest) error {
 	ret := _m.Called(ctx, request)
@@ -21,6 +21,7 @@
 package mocks
 
 import mock "github.com/stretchr/testify/mock"
+import "golang.org/x/net/context"
 import persistence "github.com/uber/cadence/common/persistence"
 
 // ExecutionManager is an autogenerated mock type for the ExecutionManager type
@@ -28,13 +29,13 @@ type HistoryManager struct {
 	mock.Mock
 }
 
-// AppendHistoryEvents provides a mock function with given fields: request
-func (_m *HistoryManager) AppendHistoryEvents(request *persistence.AppendHistoryEventsRequest) error {
-	ret := _m.Called(request)
+// AppendHistoryEvents provides a mock function with given fields: ctx, request
+func (_m *HistoryManager) AppendHistoryEvents(ctx context.Context, request *persistence.AppendHistoryEventsRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(eventsRequest *persistence.AppendHistoryEventsRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.AppendHistoryEventsRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -42,13 +43,13 @@ func (_m *HistoryManager) AppendHistoryEvents(request *persistence.AppendHistory
 	return r0
 }
 
-// DeleteWorkflowExecutionHistory provides a mock function with given fields: request
-func (_m *HistoryManager) DeleteWorkflowExecutionHistory(request *persistence.DeleteWorkflowExecutionHistoryRequest) error {
-	ret := _m.Called(request)
+// DeleteWorkflowExecutionHistory provides a mock function with given fields: ctx, request
+func (_m *HistoryManager) DeleteWorkflowExecutionHistory(ctx context.Context, request *persistence.DeleteWorkflowExecutionHistoryRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.DeleteWorkflowExecutionHistoryRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.DeleteWorkflowExecutionHistoryRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -56,14 +57,14 @@ func (_m *HistoryManager) DeleteWorkflowExecutionHistory(request *persistence.De
 	return r0
 }
 
-// GetWorkflowExecutionHistory provides a mock function with given fields: request
+// GetWorkflowExecutionHistory provides a mock function with given fields: ctx, request
 func (_m *HistoryManager) GetWorkflowExecutionHistory(
-	request *persistence.GetWorkflowExecutionHistoryRequest) (*persistence.GetWorkflowExecutionHistoryResponse, error) {
-	ret := _m.Called(request)
+	ctx context.Context, request *persistence.GetWorkflowExecutionHistoryRequest) (*persistence.GetWorkflowExecutionHistoryResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.GetWorkflowExecutionHistoryResponse
-	if rf, ok := ret.Get(0).(func(*persistence.GetWorkflowExecutionHistoryRequest) *persistence.GetWorkflowExecutionHistoryResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetWorkflowExecutionHistoryRequest) *persistence.GetWorkflowExecutionHistoryResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetWorkflowExecutionHistoryResponse)
@@ -71,8 +72,31 @@ func (_m *HistoryManager) GetWorkflowExecutionHistory(
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.GetWorkflowExecutionHistoryRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetWorkflowExecutionHistoryRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWorkflowExecutionHistories provides a mock function with given fields: ctx, request
+func (_m *HistoryManager) ListWorkflowExecutionHistories(ctx context.Context, request *persistence.ListWorkflowExecutionHistoriesRequest) (*persistence.ListWorkflowExecutionHistoriesResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.ListWorkflowExecutionHistoriesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionHistoriesRequest) *persistence.ListWorkflowExecutionHistoriesResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionHistoriesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionHistoriesRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
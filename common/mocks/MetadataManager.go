@@ -21,6 +21,7 @@
 package mocks
 
 import mock "github.com/stretchr/testify/mock"
+import "golang.org/x/net/context"
 import persistence "github.com/uber/cadence/common/persistence"
 
 // MetadataManager is an autogenerated mock type for the MetadataManager type
@@ -28,13 +29,13 @@ type MetadataManager struct {
 	mock.Mock
 }
 
-// CreateDomain provides a mock function with given fields: request
-func (_m *MetadataManager) CreateDomain(request *persistence.CreateDomainRequest) (*persistence.CreateDomainResponse, error) {
-	ret := _m.Called(request)
+// CreateDomain provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) CreateDomain(ctx context.Context, request *persistence.CreateDomainRequest) (*persistence.CreateDomainResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.CreateDomainResponse
-	if rf, ok := ret.Get(0).(func(*persistence.CreateDomainRequest) *persistence.CreateDomainResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.CreateDomainRequest) *persistence.CreateDomainResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.CreateDomainResponse)
@@ -42,8 +43,8 @@ func (_m *MetadataManager) CreateDomain(request *persistence.CreateDomainRequest
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.CreateDomainRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.CreateDomainRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,13 +52,13 @@ func (_m *MetadataManager) CreateDomain(request *persistence.CreateDomainRequest
 	return r0, r1
 }
 
-// DeleteDomain provides a mock function with given fields: request
-func (_m *MetadataManager) DeleteDomain(request *persistence.DeleteDomainRequest) error {
-	ret := _m.Called(request)
+// DeleteDomain provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) DeleteDomain(ctx context.Context, request *persistence.DeleteDomainRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.DeleteDomainRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.DeleteDomainRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -65,13 +66,13 @@ func (_m *MetadataManager) DeleteDomain(request *persistence.DeleteDomainRequest
 	return r0
 }
 
-// DeleteDomainByName provides a mock function with given fields: request
-func (_m *MetadataManager) DeleteDomainByName(request *persistence.DeleteDomainByNameRequest) error {
-	ret := _m.Called(request)
+// DeleteDomainByName provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) DeleteDomainByName(ctx context.Context, request *persistence.DeleteDomainByNameRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.DeleteDomainByNameRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.DeleteDomainByNameRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -79,13 +80,13 @@ func (_m *MetadataManager) DeleteDomainByName(request *persistence.DeleteDomainB
 	return r0
 }
 
-// GetDomain provides a mock function with given fields: request
-func (_m *MetadataManager) GetDomain(request *persistence.GetDomainRequest) (*persistence.GetDomainResponse, error) {
-	ret := _m.Called(request)
+// GetDomain provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) GetDomain(ctx context.Context, request *persistence.GetDomainRequest) (*persistence.GetDomainResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.GetDomainResponse
-	if rf, ok := ret.Get(0).(func(*persistence.GetDomainRequest) *persistence.GetDomainResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetDomainRequest) *persistence.GetDomainResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetDomainResponse)
@@ -93,8 +94,8 @@ func (_m *MetadataManager) GetDomain(request *persistence.GetDomainRequest) (*pe
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.GetDomainRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetDomainRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,13 +103,50 @@ func (_m *MetadataManager) GetDomain(request *persistence.GetDomainRequest) (*pe
 	return r0, r1
 }
 
-// UpdateDomain provides a mock function with given fields: request
-func (_m *MetadataManager) UpdateDomain(request *persistence.UpdateDomainRequest) error {
-	ret := _m.Called(request)
+// GetOpenExecutionCount provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) GetOpenExecutionCount(ctx context.Context, request *persistence.GetOpenExecutionCountRequest) (*persistence.GetOpenExecutionCountResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.GetOpenExecutionCountResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetOpenExecutionCountRequest) *persistence.GetOpenExecutionCountResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetOpenExecutionCountResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetOpenExecutionCountRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDomain provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) UpdateDomain(ctx context.Context, request *persistence.UpdateDomainRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.UpdateDomainRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOpenExecutionCount provides a mock function with given fields: ctx, request
+func (_m *MetadataManager) UpdateOpenExecutionCount(ctx context.Context, request *persistence.UpdateOpenExecutionCountRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.UpdateDomainRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.UpdateOpenExecutionCountRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -22,28 +22,29 @@ package mocks
 
 import "github.com/uber/cadence/common/persistence"
 import "github.com/stretchr/testify/mock"
+import "golang.org/x/net/context"
 
 // TaskManager is an autogenerated mock type for the TaskManager type
 type TaskManager struct {
 	mock.Mock
 }
 
-// LeaseTaskList provides a mock function with given fields: request
-func (_m *TaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error) {
-	ret := _m.Called(request)
+// LeaseTaskList provides a mock function with given fields: ctx, request
+func (_m *TaskManager) LeaseTaskList(ctx context.Context, request *persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.LeaseTaskListResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error)); ok {
-		return rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error)); ok {
+		return rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.LeaseTaskListResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*persistence.LeaseTaskListRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.LeaseTaskListRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,13 +52,13 @@ func (_m *TaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest)
 	return r0, r1
 }
 
-// UpdateTaskList provides a mock function with given fields: request
-func (_m *TaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequest) (*persistence.UpdateTaskListResponse, error) {
-	ret := _m.Called(request)
+// UpdateTaskList provides a mock function with given fields: ctx, request
+func (_m *TaskManager) UpdateTaskList(ctx context.Context, request *persistence.UpdateTaskListRequest) (*persistence.UpdateTaskListResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.UpdateTaskListResponse
-	if rf, ok := ret.Get(0).(func(*persistence.UpdateTaskListRequest) *persistence.UpdateTaskListResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.UpdateTaskListRequest) *persistence.UpdateTaskListResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.UpdateTaskListResponse)
@@ -65,8 +66,8 @@ func (_m *TaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequest
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.UpdateTaskListRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.UpdateTaskListRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -74,13 +75,13 @@ func (_m *TaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequest
 	return r0, r1
 }
 
-// CompleteTask provides a mock function with given fields: request
-func (_m *TaskManager) CompleteTask(request *persistence.CompleteTaskRequest) error {
-	ret := _m.Called(request)
+// CompleteTask provides a mock function with given fields: ctx, request
+func (_m *TaskManager) CompleteTask(ctx context.Context, request *persistence.CompleteTaskRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.CompleteTaskRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.CompleteTaskRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -88,13 +89,13 @@ func (_m *TaskManager) CompleteTask(request *persistence.CompleteTaskRequest) er
 	return r0
 }
 
-// CreateTasks provides a mock function with given fields: request
-func (_m *TaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*persistence.CreateTasksResponse, error) {
-	ret := _m.Called(request)
+// CreateTasks provides a mock function with given fields: ctx, request
+func (_m *TaskManager) CreateTasks(ctx context.Context, request *persistence.CreateTasksRequest) (*persistence.CreateTasksResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.CreateTasksResponse
-	if rf, ok := ret.Get(0).(func(*persistence.CreateTasksRequest) *persistence.CreateTasksResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.CreateTasksRequest) *persistence.CreateTasksResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.CreateTasksResponse)
@@ -102,8 +103,8 @@ func (_m *TaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*pe
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.CreateTasksRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.CreateTasksRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -111,13 +112,13 @@ func (_m *TaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*pe
 	return r0, r1
 }
 
-// GetTasks provides a mock function with given fields: request
-func (_m *TaskManager) GetTasks(request *persistence.GetTasksRequest) (*persistence.GetTasksResponse, error) {
-	ret := _m.Called(request)
+// GetTasks provides a mock function with given fields: ctx, request
+func (_m *TaskManager) GetTasks(ctx context.Context, request *persistence.GetTasksRequest) (*persistence.GetTasksResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.GetTasksResponse
-	if rf, ok := ret.Get(0).(func(*persistence.GetTasksRequest) (*persistence.GetTasksResponse, error)); ok {
-		return rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetTasksRequest) (*persistence.GetTasksResponse, error)); ok {
+		return rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.GetTasksResponse)
@@ -125,11 +126,48 @@ func (_m *TaskManager) GetTasks(request *persistence.GetTasksRequest) (*persiste
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.GetTasksRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetTasksRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
+
+// ListTaskLists provides a mock function with given fields: ctx, request
+func (_m *TaskManager) ListTaskLists(ctx context.Context, request *persistence.ListTaskListsRequest) (*persistence.ListTaskListsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.ListTaskListsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListTaskListsRequest) *persistence.ListTaskListsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListTaskListsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListTaskListsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTaskList provides a mock function with given fields: ctx, request
+func (_m *TaskManager) DeleteTaskList(ctx context.Context, request *persistence.DeleteTaskListRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.DeleteTaskListRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -22,19 +22,80 @@ package mocks
 
 import "github.com/uber/cadence/common/persistence"
 import "github.com/stretchr/testify/mock"
+import "golang.org/x/net/context"
 
 // VisibilityManager is an autogenerated mock type for the VisibilityManager type
 type VisibilityManager struct {
 	mock.Mock
 }
 
-// ListClosedWorkflowExecutions provides a mock function with given fields: request
-func (_m *VisibilityManager) ListClosedWorkflowExecutions(request *persistence.ListWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// DeleteClosedWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) DeleteClosedWorkflowExecution(ctx context.Context, request *persistence.DeleteClosedWorkflowExecutionRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.DeleteClosedWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListClosedWorkflowExecutions provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutions(ctx context.Context, request *persistence.ListWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.ListWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClosedWorkflowExecutionsByStatus provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *persistence.ListClosedWorkflowExecutionsByStatusRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.ListWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListClosedWorkflowExecutionsByStatusRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListClosedWorkflowExecutionsByStatusRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClosedWorkflowExecutionsByCloseTime provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsByCloseTime(ctx context.Context, request *persistence.ListClosedWorkflowExecutionsByCloseTimeRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListClosedWorkflowExecutionsByCloseTimeRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -42,8 +103,8 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutions(request *persistence.L
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListClosedWorkflowExecutionsByCloseTimeRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,13 +112,13 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutions(request *persistence.L
 	return r0, r1
 }
 
-// ListClosedWorkflowExecutionsByStatus provides a mock function with given fields: request
-func (_m *VisibilityManager) ListClosedWorkflowExecutionsByStatus(request *persistence.ListClosedWorkflowExecutionsByStatusRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListClosedWorkflowExecutionsSince provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsSince(ctx context.Context, request *persistence.ListClosedWorkflowExecutionsSinceRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListClosedWorkflowExecutionsByStatusRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListClosedWorkflowExecutionsSinceRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -65,8 +126,8 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByStatus(request *persi
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListClosedWorkflowExecutionsByStatusRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListClosedWorkflowExecutionsSinceRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -74,13 +135,13 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByStatus(request *persi
 	return r0, r1
 }
 
-// ListClosedWorkflowExecutionsByType provides a mock function with given fields: request
-func (_m *VisibilityManager) ListClosedWorkflowExecutionsByType(request *persistence.ListWorkflowExecutionsByTypeRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListClosedWorkflowExecutionsByType provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsByType(ctx context.Context, request *persistence.ListWorkflowExecutionsByTypeRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByTypeRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsByTypeRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -88,8 +149,8 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByType(request *persist
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByTypeRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsByTypeRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -97,13 +158,13 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByType(request *persist
 	return r0, r1
 }
 
-// ListClosedWorkflowExecutionsByWorkflowID provides a mock function with given fields: request
-func (_m *VisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListClosedWorkflowExecutionsByWorkflowID provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByWorkflowIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsByWorkflowIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -111,8 +172,8 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(request *p
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByWorkflowIDRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsByWorkflowIDRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -120,13 +181,13 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutionsByWorkflowID(request *p
 	return r0, r1
 }
 
-// ListOpenWorkflowExecutions provides a mock function with given fields: request
-func (_m *VisibilityManager) ListOpenWorkflowExecutions(request *persistence.ListWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListOpenWorkflowExecutions provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListOpenWorkflowExecutions(ctx context.Context, request *persistence.ListWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -134,8 +195,8 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutions(request *persistence.Lis
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -143,13 +204,13 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutions(request *persistence.Lis
 	return r0, r1
 }
 
-// ListOpenWorkflowExecutionsByType provides a mock function with given fields: request
-func (_m *VisibilityManager) ListOpenWorkflowExecutionsByType(request *persistence.ListWorkflowExecutionsByTypeRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListOpenWorkflowExecutionsByType provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListOpenWorkflowExecutionsByType(ctx context.Context, request *persistence.ListWorkflowExecutionsByTypeRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByTypeRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsByTypeRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -157,8 +218,8 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutionsByType(request *persisten
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByTypeRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsByTypeRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -166,13 +227,13 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutionsByType(request *persisten
 	return r0, r1
 }
 
-// ListOpenWorkflowExecutionsByWorkflowID provides a mock function with given fields: request
-func (_m *VisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
-	ret := _m.Called(request)
+// ListOpenWorkflowExecutionsByWorkflowID provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *persistence.ListWorkflowExecutionsResponse
-	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByWorkflowIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.ListWorkflowExecutionsByWorkflowIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
@@ -180,8 +241,8 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(request *per
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByWorkflowIDRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.ListWorkflowExecutionsByWorkflowIDRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -189,13 +250,13 @@ func (_m *VisibilityManager) ListOpenWorkflowExecutionsByWorkflowID(request *per
 	return r0, r1
 }
 
-// RecordWorkflowExecutionClosed provides a mock function with given fields: request
-func (_m *VisibilityManager) RecordWorkflowExecutionClosed(request *persistence.RecordWorkflowExecutionClosedRequest) error {
-	ret := _m.Called(request)
+// RecordWorkflowExecutionClosed provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) RecordWorkflowExecutionClosed(ctx context.Context, request *persistence.RecordWorkflowExecutionClosedRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.RecordWorkflowExecutionClosedRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.RecordWorkflowExecutionClosedRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -203,13 +264,13 @@ func (_m *VisibilityManager) RecordWorkflowExecutionClosed(request *persistence.
 	return r0
 }
 
-// RecordWorkflowExecutionStarted provides a mock function with given fields: request
-func (_m *VisibilityManager) RecordWorkflowExecutionStarted(request *persistence.RecordWorkflowExecutionStartedRequest) error {
-	ret := _m.Called(request)
+// RecordWorkflowExecutionStarted provides a mock function with given fields: ctx, request
+func (_m *VisibilityManager) RecordWorkflowExecutionStarted(ctx context.Context, request *persistence.RecordWorkflowExecutionStartedRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*persistence.RecordWorkflowExecutionStartedRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.RecordWorkflowExecutionStartedRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,98 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"strconv"
+
+	"github.com/uber-common/bark"
+
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	// cassandraFactory creates the Cassandra backed persistence managers, wrapped with the clients emitting their
+	// metrics
+	cassandraFactory struct {
+		cfg           config.Cassandra
+		metricsClient metrics.Client
+		logger        bark.Logger
+	}
+)
+
+var _ Factory = (*cassandraFactory)(nil)
+
+// NewCassandraFactory returns a Factory creating persistence managers backed by the given Cassandra cluster
+func NewCassandraFactory(cfg config.Cassandra, metricsClient metrics.Client, logger bark.Logger) Factory {
+	return &cassandraFactory{
+		cfg:           cfg,
+		metricsClient: metricsClient,
+		logger:        logger,
+	}
+}
+
+func (f *cassandraFactory) NewShardManager() (ShardManager, error) {
+	mgr, err := NewCassandraShardPersistence(f.cfg, f.metricsClient, f.logger)
+	if err != nil {
+		return nil, err
+	}
+	return NewShardPersistenceClient(mgr, f.metricsClient), nil
+}
+
+func (f *cassandraFactory) NewMetadataManager() (MetadataManager, error) {
+	mgr, err := NewCassandraMetadataPersistence(f.cfg, f.metricsClient, f.logger)
+	if err != nil {
+		return nil, err
+	}
+	return NewMetadataPersistenceClient(mgr, f.metricsClient), nil
+}
+
+func (f *cassandraFactory) NewVisibilityManager() (VisibilityManager, error) {
+	return NewCassandraVisibilityPersistence(f.cfg, f.metricsClient, f.logger)
+}
+
+func (f *cassandraFactory) NewHistoryManager() (HistoryManager, error) {
+	mgr, err := NewCassandraHistoryPersistence(f.cfg, f.metricsClient, f.logger)
+	if err != nil {
+		return nil, err
+	}
+	return NewHistoryPersistenceClient(mgr, f.metricsClient), nil
+}
+
+func (f *cassandraFactory) NewTaskManager() (TaskManager, error) {
+	mgr, err := NewCassandraTaskPersistence(f.cfg, f.metricsClient, f.logger)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaskPersistenceClient(mgr, f.metricsClient), nil
+}
+
+func (f *cassandraFactory) CreateExecutionManager(shardID int) (ExecutionManager, error) {
+	mgr, err := NewCassandraWorkflowExecutionPersistence(f.cfg, shardID, f.metricsClient, f.logger)
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]string{
+		metrics.ShardTagName: strconv.Itoa(shardID),
+	}
+	return NewWorkflowExecutionPersistenceClient(mgr, f.metricsClient.Tagged(tags)), nil
+}
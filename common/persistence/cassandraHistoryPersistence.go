@@ -22,12 +22,16 @@ package persistence
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/gocql/gocql"
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
 )
 
 const (
@@ -35,49 +39,50 @@ const (
 		`domain_id, workflow_id, run_id, first_event_id, range_id, tx_id, data, data_encoding, data_version) ` +
 		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) IF NOT EXISTS`
 
+	// A transaction may overwrite its own events, so that a write retried after a transient failure is idempotent
 	templateOverwriteHistoryEvents = `UPDATE events ` +
 		`SET range_id = ?, tx_id = ?, data = ?, data_encoding = ?, data_version = ? ` +
 		`WHERE domain_id = ? AND workflow_id = ? AND run_id = ? AND first_event_id = ? ` +
-		`IF range_id <= ? AND tx_id < ?`
+		`IF range_id <= ? AND tx_id <= ?`
 
 	templateGetWorkflowExecutionHistory = `SELECT first_event_id, data, data_encoding, data_version FROM events ` +
 		`WHERE domain_id = ? ` +
 		`AND workflow_id = ? ` +
 		`AND run_id = ? ` +
+		`AND first_event_id >= ? ` +
 		`AND first_event_id < ?`
 
 	templateDeleteWorkflowExecutionHistory = `DELETE FROM events ` +
 		`WHERE domain_id = ? ` +
 		`AND workflow_id = ? ` +
 		`AND run_id = ? `
+
+	templateListWorkflowExecutionHistories = `SELECT DISTINCT domain_id, workflow_id, run_id FROM events ` +
+		`WHERE token(domain_id, workflow_id, run_id) >= ? ` +
+		`AND token(domain_id, workflow_id, run_id) <= ?`
 )
 
 type (
 	cassandraHistoryPersistence struct {
-		session *gocql.Session
-		logger  bark.Logger
+		cassandraStore
 	}
 )
 
 // NewCassandraHistoryPersistence is used to create an instance of HistoryManager implementation
-func NewCassandraHistoryPersistence(hosts string, dc string, keyspace string, logger bark.Logger) (HistoryManager,
-	error) {
-	cluster := common.NewCassandraCluster(hosts, dc)
-	cluster.Keyspace = keyspace
-	cluster.ProtoVersion = cassandraProtoVersion
-	cluster.Consistency = gocql.LocalQuorum
-	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
-
-	session, err := cluster.CreateSession()
+func NewCassandraHistoryPersistence(cfg config.Cassandra, metricsClient metrics.Client,
+	logger bark.Logger) (HistoryManager, error) {
+	store, err := newCassandraStore(cfg, cfg.Keyspace, HistoryStoreName, metricsClient, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	return &cassandraHistoryPersistence{session: session, logger: logger}, nil
+	return &cassandraHistoryPersistence{cassandraStore: store}, nil
 }
 
-func (h *cassandraHistoryPersistence) AppendHistoryEvents(request *AppendHistoryEventsRequest) error {
+func (h *cassandraHistoryPersistence) AppendHistoryEvents(ctx context.Context, request *AppendHistoryEventsRequest) error {
+	ctx, cancel := h.timeouts.withTimeout(ctx, writeOperation, "AppendHistoryEvents")
+	defer cancel()
+
 	var query *gocql.Query
 	if request.Overwrite {
 		query = h.session.Query(templateOverwriteHistoryEvents,
@@ -91,7 +96,7 @@ func (h *cassandraHistoryPersistence) AppendHistoryEvents(request *AppendHistory
 			request.Execution.GetRunId(),
 			request.FirstEventID,
 			request.RangeID,
-			request.TransactionID)
+			request.TransactionID).WithContext(ctx)
 	} else {
 		query = h.session.Query(templateAppendHistoryEvents,
 			request.DomainID,
@@ -102,7 +107,7 @@ func (h *cassandraHistoryPersistence) AppendHistoryEvents(request *AppendHistory
 			request.TransactionID,
 			request.Events.Data,
 			request.Events.EncodingType,
-			request.Events.Version)
+			request.Events.Version).WithContext(ctx)
 	}
 
 	previous := make(map[string]interface{})
@@ -127,14 +132,18 @@ func (h *cassandraHistoryPersistence) AppendHistoryEvents(request *AppendHistory
 	return nil
 }
 
-func (h *cassandraHistoryPersistence) GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (
+func (h *cassandraHistoryPersistence) GetWorkflowExecutionHistory(ctx context.Context, request *GetWorkflowExecutionHistoryRequest) (
 	*GetWorkflowExecutionHistoryResponse, error) {
+	ctx, cancel := h.timeouts.withTimeout(ctx, rangeScanOperation, "GetWorkflowExecutionHistory")
+	defer cancel()
+
 	execution := request.Execution
 	query := h.session.Query(templateGetWorkflowExecutionHistory,
 		request.DomainID,
 		execution.GetWorkflowId(),
 		execution.GetRunId(),
-		request.NextEventID)
+		request.FirstEventID,
+		request.NextEventID).WithContext(ctx)
 
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
@@ -173,12 +182,15 @@ func (h *cassandraHistoryPersistence) GetWorkflowExecutionHistory(request *GetWo
 }
 
 func (h *cassandraHistoryPersistence) DeleteWorkflowExecutionHistory(
-	request *DeleteWorkflowExecutionHistoryRequest) error {
+	ctx context.Context, request *DeleteWorkflowExecutionHistoryRequest) error {
+	ctx, cancel := h.timeouts.withTimeout(ctx, writeOperation, "DeleteWorkflowExecutionHistory")
+	defer cancel()
+
 	execution := request.Execution
 	query := h.session.Query(templateDeleteWorkflowExecutionHistory,
 		request.DomainID,
 		execution.GetWorkflowId(),
-		execution.GetRunId())
+		execution.GetRunId()).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -189,3 +201,61 @@ func (h *cassandraHistoryPersistence) DeleteWorkflowExecutionHistory(
 
 	return nil
 }
+
+func (h *cassandraHistoryPersistence) ListWorkflowExecutionHistories(ctx context.Context,
+	request *ListWorkflowExecutionHistoriesRequest) (*ListWorkflowExecutionHistoriesResponse, error) {
+	ctx, cancel := h.timeouts.withTimeout(ctx, rangeScanOperation, "ListWorkflowExecutionHistories")
+	defer cancel()
+
+	if request.NumPartitions <= 0 || request.Partition < 0 || request.Partition >= request.NumPartitions {
+		return nil, &workflow.BadRequestError{
+			Message: fmt.Sprintf("Invalid history partition %v of %v.", request.Partition, request.NumPartitions),
+		}
+	}
+
+	minToken, maxToken := historyPartitionTokenRange(request.Partition, request.NumPartitions)
+	query := h.session.Query(templateListWorkflowExecutionHistories,
+		minToken,
+		maxToken).WithContext(ctx)
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListWorkflowExecutionHistories operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &ListWorkflowExecutionHistoriesResponse{}
+	var domainID, runID gocql.UUID
+	var workflowID string
+	for iter.Scan(&domainID, &workflowID, &runID) {
+		response.Histories = append(response.Histories, &WorkflowExecutionHistoryInfo{
+			DomainID: domainID.String(),
+			Execution: workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr(workflowID),
+				RunId:      common.StringPtr(runID.String()),
+			},
+		})
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListWorkflowExecutionHistories operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+// historyPartitionTokenRange splits the Murmur3 token ring of the events table into numPartitions ranges of equal
+// size and returns the inclusive bounds of the given partition
+func historyPartitionTokenRange(partition, numPartitions int) (int64, int64) {
+	step := math.MaxUint64 / uint64(numPartitions)
+	minToken := int64(uint64(partition)*step + 1<<63)
+	if partition == numPartitions-1 {
+		return minToken, math.MaxInt64
+	}
+	return minToken, int64(uint64(partition+1)*step+1<<63) - 1
+}
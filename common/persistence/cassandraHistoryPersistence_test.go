@@ -27,6 +27,7 @@ import (
 	log "github.com/Sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
 
 	"github.com/pborman/uuid"
 	gen "github.com/uber/cadence/.gen/go/shared"
@@ -90,6 +91,14 @@ func (s *historyPersistenceSuite) TestAppendHistoryEvents() {
 
 	err3 := s.AppendHistoryEvents(domainID, workflowExecution, 3, 1, 2, serializedHistory, true)
 	s.Nil(err3)
+
+	// a retried transaction can overwrite its own events
+	err4 := s.AppendHistoryEvents(domainID, workflowExecution, 3, 1, 2, serializedHistory, true)
+	s.Nil(err4)
+
+	err5 := s.AppendHistoryEvents(domainID, workflowExecution, 3, 1, 1, serializedHistory, true)
+	s.NotNil(err5)
+	s.IsType(&ConditionFailedError{}, err5)
 }
 
 func (s *historyPersistenceSuite) TestGetHistoryEvents() {
@@ -113,6 +122,38 @@ func (s *historyPersistenceSuite) TestGetHistoryEvents() {
 	s.Equal(events, history[0].Data)
 }
 
+func (s *historyPersistenceSuite) TestGetHistoryEventsFromFirstEventID() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("get-history-events-from-first-event-id-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+
+	events := []*SerializedHistoryEventBatch{
+		NewSerializedHistoryEventBatch([]byte("event1;event2"), common.EncodingTypeJSON, 1),
+		NewSerializedHistoryEventBatch([]byte("event3"), common.EncodingTypeJSON, 1),
+		NewSerializedHistoryEventBatch([]byte("event4;event5"), common.EncodingTypeJSON, 1),
+	}
+	firstEventIDs := []int64{1, 3, 4}
+	for i := range events {
+		err0 := s.AppendHistoryEvents(domainID, workflowExecution, firstEventIDs[i], 1, int64(i), events[i], false)
+		s.Nil(err0)
+	}
+
+	response, err1 := s.HistoryMgr.GetWorkflowExecutionHistory(context.Background(),
+		&GetWorkflowExecutionHistoryRequest{
+			DomainID:     domainID,
+			Execution:    workflowExecution,
+			FirstEventID: 3,
+			NextEventID:  6,
+			PageSize:     10,
+		})
+	s.Nil(err1)
+	s.Equal(2, len(response.Events))
+	s.Equal(events[1].Data, response.Events[0].Data)
+	s.Equal(events[2].Data, response.Events[1].Data)
+}
+
 func (s *historyPersistenceSuite) TestDeleteHistoryEvents() {
 	domainID := "373de9d6-e41e-42d4-bee9-9e06968e4d0d"
 	workflowExecution := gen.WorkflowExecution{
@@ -191,7 +232,7 @@ func (s *historyPersistenceSuite) TestAppendAndGet() {
 func (s *historyPersistenceSuite) AppendHistoryEvents(domainID string, workflowExecution gen.WorkflowExecution,
 	firstEventID, rangeID, txID int64, eventsBatch *SerializedHistoryEventBatch, overwrite bool) error {
 
-	return s.HistoryMgr.AppendHistoryEvents(&AppendHistoryEventsRequest{
+	return s.HistoryMgr.AppendHistoryEvents(context.Background(), &AppendHistoryEventsRequest{
 		DomainID:      domainID,
 		Execution:     workflowExecution,
 		FirstEventID:  firstEventID,
@@ -205,7 +246,7 @@ func (s *historyPersistenceSuite) AppendHistoryEvents(domainID string, workflowE
 func (s *historyPersistenceSuite) GetWorkflowExecutionHistory(domainID string, workflowExecution gen.WorkflowExecution,
 	nextEventID int64, pageSize int, token []byte) ([]SerializedHistoryEventBatch, []byte, error) {
 
-	response, err := s.HistoryMgr.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{
+	response, err := s.HistoryMgr.GetWorkflowExecutionHistory(context.Background(), &GetWorkflowExecutionHistoryRequest{
 		DomainID:      domainID,
 		Execution:     workflowExecution,
 		NextEventID:   nextEventID,
@@ -223,7 +264,7 @@ func (s *historyPersistenceSuite) GetWorkflowExecutionHistory(domainID string, w
 func (s *historyPersistenceSuite) DeleteWorkflowExecutionHistory(domainID string,
 	workflowExecution gen.WorkflowExecution) error {
 
-	return s.HistoryMgr.DeleteWorkflowExecutionHistory(&DeleteWorkflowExecutionHistoryRequest{
+	return s.HistoryMgr.DeleteWorkflowExecutionHistory(context.Background(), &DeleteWorkflowExecutionHistoryRequest{
 		DomainID:  domainID,
 		Execution: workflowExecution,
 	})
@@ -0,0 +1,167 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	// hostMetricsPolicy wraps the host selection policy of a cluster to report the health of each cassandra host as
+	// seen from this process: whether it is up, and the latency and outcome of every attempt it served. The vendored
+	// gocql does not expose its connection pools, so the connections per host are not reported
+	hostMetricsPolicy struct {
+		gocql.HostSelectionPolicy
+		metricsClient metrics.Client
+
+		sync.RWMutex
+		hostClients map[string]metrics.Client
+	}
+
+	// meteredHost reports the attempt of a query on a selected host once it is marked
+	meteredHost struct {
+		gocql.SelectedHost
+		metricsClient metrics.Client
+		retry         bool
+		startTime     time.Time
+	}
+)
+
+var _ gocql.HostSelectionPolicy = (*hostMetricsPolicy)(nil)
+
+func newHostMetricsPolicy(policy gocql.HostSelectionPolicy, metricsClient metrics.Client) *hostMetricsPolicy {
+	return &hostMetricsPolicy{
+		HostSelectionPolicy: policy,
+		metricsClient:       metricsClient,
+		hostClients:         make(map[string]metrics.Client),
+	}
+}
+
+// AddHost reports the host as up if it is, before adding it to the wrapped policy
+func (p *hostMetricsPolicy) AddHost(host *gocql.HostInfo) {
+	p.updateHostUpGauge(host, host.IsUp())
+	p.HostSelectionPolicy.AddHost(host)
+}
+
+// RemoveHost reports the host as down, before removing it from the wrapped policy
+func (p *hostMetricsPolicy) RemoveHost(host *gocql.HostInfo) {
+	p.updateHostUpGauge(host, false)
+	p.HostSelectionPolicy.RemoveHost(host)
+}
+
+// HostUp reports the host as up
+func (p *hostMetricsPolicy) HostUp(host *gocql.HostInfo) {
+	p.updateHostUpGauge(host, true)
+	p.HostSelectionPolicy.HostUp(host)
+}
+
+// HostDown reports the host as down
+func (p *hostMetricsPolicy) HostDown(host *gocql.HostInfo) {
+	p.updateHostUpGauge(host, false)
+	p.HostSelectionPolicy.HostDown(host)
+}
+
+// Pick returns the hosts of the wrapped policy, reporting the attempt made on each of them. Every host after the
+// first one serves a retry of the query
+func (p *hostMetricsPolicy) Pick(query gocql.ExecutableQuery) gocql.NextHost {
+	next := p.HostSelectionPolicy.Pick(query)
+	attempts := 0
+	return func() gocql.SelectedHost {
+		host := next()
+		if host == nil {
+			return nil
+		}
+		attempts++
+		return &meteredHost{
+			SelectedHost:  host,
+			metricsClient: p.hostClient(host.Info()),
+			retry:         attempts > 1,
+			startTime:     time.Now(),
+		}
+	}
+}
+
+func (p *hostMetricsPolicy) unwrap() gocql.HostSelectionPolicy {
+	return p.HostSelectionPolicy
+}
+
+// Mark reports the latency and outcome of the attempt
+func (h *meteredHost) Mark(err error) {
+	h.metricsClient.IncCounter(metrics.PersistenceHostScope, metrics.PersistenceHostRequests)
+	h.metricsClient.RecordTimer(metrics.PersistenceHostScope, metrics.PersistenceHostLatency, time.Since(h.startTime))
+	if h.retry {
+		h.metricsClient.IncCounter(metrics.PersistenceHostScope, metrics.PersistenceHostRetryCounter)
+	}
+	if err != nil {
+		h.metricsClient.IncCounter(metrics.PersistenceHostScope, hostErrorCounter(err))
+	}
+	h.SelectedHost.Mark(err)
+}
+
+func (p *hostMetricsPolicy) updateHostUpGauge(host *gocql.HostInfo, up bool) {
+	value := 0.0
+	if up {
+		value = 1.0
+	}
+	p.hostClient(host).UpdateGauge(metrics.PersistenceHostScope, metrics.PersistenceHostUpGauge, value)
+}
+
+// hostClient returns the metrics client tagged with the host
+func (p *hostMetricsPolicy) hostClient(host *gocql.HostInfo) metrics.Client {
+	address := "unknown"
+	if host != nil && host.Peer() != nil {
+		address = host.Peer().String()
+	}
+
+	p.RLock()
+	client, ok := p.hostClients[address]
+	p.RUnlock()
+	if ok {
+		return client
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if client, ok = p.hostClients[address]; !ok {
+		client = p.metricsClient.Tagged(map[string]string{metrics.CassandraHostTagName: address})
+		p.hostClients[address] = client
+	}
+	return client
+}
+
+// hostErrorCounter returns the counter of the error of an attempt
+func hostErrorCounter(err error) int {
+	switch err.(type) {
+	case *gocql.RequestErrReadTimeout, *gocql.RequestErrWriteTimeout:
+		return metrics.PersistenceHostTimeoutCounter
+	case *gocql.RequestErrUnavailable:
+		return metrics.PersistenceHostUnavailableCounter
+	}
+	if err == gocql.ErrTimeoutNoResponse {
+		return metrics.PersistenceHostTimeoutCounter
+	}
+	return metrics.PersistenceHostFailures
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	cassandraHostMetricsSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestCassandraHostMetricsSuite(t *testing.T) {
+	s := new(cassandraHostMetricsSuite)
+	suite.Run(t, s)
+}
+
+func (s *cassandraHostMetricsSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func (s *cassandraHostMetricsSuite) TestAttempts() {
+	scope := tally.NewTestScope("", nil)
+	inner := &testHostPolicy{DCAwareHostPolicy: common.NewDCAwareHostPolicy("dc1"), host: &testSelectedHost{}}
+	policy := newHostMetricsPolicy(inner, metrics.NewClient(scope, metrics.Common))
+	s.Equal(inner, unwrapHostPolicy(newSlowQueryTracingPolicy(policy, time.Hour, nil, bark.NewLoggerFromLogrus(log.New()))))
+
+	query := (&gocql.Session{}).Query(templateGetShardQuery, 1)
+	next := policy.Pick(query)
+	next().Mark(&gocql.RequestErrWriteTimeout{})
+	next().Mark(&gocql.RequestErrUnavailable{})
+	next().Mark(errors.New("some error"))
+	policy.Pick(query)().Mark(nil)
+	s.Equal(4, len(inner.host.marked))
+
+	snapshot := scope.Snapshot()
+	counter := func(name string) int64 {
+		c, ok := snapshot.Counters()[name+"+cassandra_host=unknown,operation=PersistenceHost"]
+		s.True(ok, name)
+		return c.Value()
+	}
+	s.Equal(int64(4), counter("persistence.host.requests"))
+	s.Equal(int64(2), counter("persistence.host.retries"))
+	s.Equal(int64(1), counter("persistence.host.errors.timeout"))
+	s.Equal(int64(1), counter("persistence.host.errors.unavailable"))
+	s.Equal(int64(1), counter("persistence.host.errors"))
+}
+
+func (s *cassandraHostMetricsSuite) TestHostUp() {
+	scope := tally.NewTestScope("", nil)
+	policy := newHostMetricsPolicy(common.NewDCAwareHostPolicy("dc1"), metrics.NewClient(scope, metrics.Common))
+	host := &gocql.HostInfo{}
+	gauge := func() float64 {
+		g, ok := scope.Snapshot().Gauges()["persistence.host.up+cassandra_host=unknown,operation=PersistenceHost"]
+		s.True(ok)
+		return g.Value()
+	}
+
+	policy.HostUp(host)
+	s.Equal(1.0, gauge())
+	policy.HostDown(host)
+	s.Equal(0.0, gauge())
+}
@@ -26,9 +26,11 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/pborman/uuid"
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
-	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
 )
 
 const (
@@ -42,7 +44,18 @@ const (
 
 	templateDomainConfigType = `{` +
 		`retention: ?, ` +
-		`emit_metric: ?` +
+		`emit_metric: ?, ` +
+		`bad_binaries: ?, ` +
+		`max_decision_attempts: ?, ` +
+		`activity_default_schedule_to_close: ?, ` +
+		`activity_default_schedule_to_start: ?, ` +
+		`activity_default_start_to_close: ?, ` +
+		`activity_default_heartbeat: ?, ` +
+		`activity_cap_schedule_to_close: ?, ` +
+		`activity_cap_schedule_to_start: ?, ` +
+		`activity_cap_start_to_close: ?, ` +
+		`activity_cap_heartbeat: ?, ` +
+		`max_open_executions: ?` +
 		`}`
 
 	templateCreateDomainQuery = `INSERT INTO domains (` +
@@ -54,12 +67,20 @@ const (
 		`VALUES(?, ` + templateDomainType + `, ` + templateDomainConfigType + `) IF NOT EXISTS`
 
 	templateGetDomainQuery = `SELECT domain.id, domain.name, domain.status, domain.description, domain.owner_email, ` +
-		`config.retention, config.emit_metric ` +
+		`config.retention, config.emit_metric, config.bad_binaries, config.max_decision_attempts, ` +
+		`config.activity_default_schedule_to_close, config.activity_default_schedule_to_start, ` +
+		`config.activity_default_start_to_close, config.activity_default_heartbeat, ` +
+		`config.activity_cap_schedule_to_close, config.activity_cap_schedule_to_start, ` +
+		`config.activity_cap_start_to_close, config.activity_cap_heartbeat, config.max_open_executions ` +
 		`FROM domains ` +
 		`WHERE id = ?`
 
 	templateGetDomainByNameQuery = `SELECT domain.id, domain.name, domain.status, domain.description, ` +
-		`domain.owner_email, config.retention, config.emit_metric ` +
+		`domain.owner_email, config.retention, config.emit_metric, config.bad_binaries, config.max_decision_attempts, ` +
+		`config.activity_default_schedule_to_close, config.activity_default_schedule_to_start, ` +
+		`config.activity_default_start_to_close, config.activity_default_heartbeat, ` +
+		`config.activity_cap_schedule_to_close, config.activity_cap_schedule_to_start, ` +
+		`config.activity_cap_start_to_close, config.activity_cap_heartbeat, config.max_open_executions ` +
 		`FROM domains_by_name ` +
 		`WHERE name = ?`
 
@@ -78,38 +99,41 @@ const (
 
 	templateDeleteDomainByNameQuery = `DELETE FROM domains_by_name ` +
 		`WHERE name = ?`
+
+	templateGetOpenExecutionCountQuery = `SELECT count ` +
+		`FROM open_execution_counts ` +
+		`WHERE domain_id = ?`
+
+	templateUpdateOpenExecutionCountQuery = `UPDATE open_execution_counts ` +
+		`SET count = count + ? ` +
+		`WHERE domain_id = ?`
 )
 
 type (
 	cassandraMetadataPersistence struct {
-		session *gocql.Session
-		logger  bark.Logger
+		cassandraStore
 	}
 )
 
 // NewCassandraMetadataPersistence is used to create an instance of HistoryManager implementation
-func NewCassandraMetadataPersistence(hosts string, dc string, keyspace string, logger bark.Logger) (MetadataManager,
-	error) {
-	cluster := common.NewCassandraCluster(hosts, dc)
-	cluster.Keyspace = keyspace
-	cluster.ProtoVersion = cassandraProtoVersion
-	cluster.Consistency = gocql.LocalQuorum
-	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
-
-	session, err := cluster.CreateSession()
+func NewCassandraMetadataPersistence(cfg config.Cassandra, metricsClient metrics.Client,
+	logger bark.Logger) (MetadataManager, error) {
+	store, err := newCassandraStore(cfg, cfg.Keyspace, MetadataStoreName, metricsClient, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	return &cassandraMetadataPersistence{session: session, logger: logger}, nil
+	return &cassandraMetadataPersistence{cassandraStore: store}, nil
 }
 
 // Cassandra does not support conditional updates across multiple tables.  For this reason we have to first insert into
 // 'Domains' table and then do a conditional insert into domains_by_name table.  If the conditional write fails we
 // delete the orphaned entry from domains table.  There is a chance delete entry could fail and we never delete the
 // orphaned entry from domains table.  We might need a background job to delete those orphaned record.
-func (m *cassandraMetadataPersistence) CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error) {
+func (m *cassandraMetadataPersistence) CreateDomain(ctx context.Context, request *CreateDomainRequest) (*CreateDomainResponse, error) {
+	ctx, cancel := m.timeouts.withTimeout(ctx, writeOperation, "CreateDomain")
+	defer cancel()
+
 	domainUUID := uuid.New()
 	if err := m.session.Query(templateCreateDomainQuery,
 		domainUUID,
@@ -119,7 +143,12 @@ func (m *cassandraMetadataPersistence) CreateDomain(request *CreateDomainRequest
 		request.Description,
 		request.OwnerEmail,
 		request.Retention,
-		request.EmitMetric).Exec(); err != nil {
+		request.EmitMetric,
+		[]string{},
+		0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0).WithContext(ctx).Exec(); err != nil {
 		return nil, &workflow.InternalServiceError{
 			Message: fmt.Sprintf("CreateDomain operation failed. Inserting into domains table. Error: %v", err),
 		}
@@ -133,7 +162,12 @@ func (m *cassandraMetadataPersistence) CreateDomain(request *CreateDomainRequest
 		request.Description,
 		request.OwnerEmail,
 		request.Retention,
-		request.EmitMetric)
+		request.EmitMetric,
+		[]string{},
+		0,
+		0, 0, 0, 0,
+		0, 0, 0, 0,
+		0).WithContext(ctx)
 
 	previous := make(map[string]interface{})
 	applied, err := query.MapScanCAS(previous)
@@ -145,7 +179,7 @@ func (m *cassandraMetadataPersistence) CreateDomain(request *CreateDomainRequest
 
 	if !applied {
 		// Domain already exist.  Delete orphan domain record before returning back to user
-		if err = m.session.Query(templateDeleteDomainQuery, domainUUID).Exec(); err != nil {
+		if err = m.session.Query(templateDeleteDomainQuery, domainUUID).WithContext(ctx).Exec(); err != nil {
 			m.logger.Warnf("Unable to delete orphan domain record. Error: %v", err)
 		}
 
@@ -164,7 +198,10 @@ func (m *cassandraMetadataPersistence) CreateDomain(request *CreateDomainRequest
 	return &CreateDomainResponse{ID: domainUUID}, nil
 }
 
-func (m *cassandraMetadataPersistence) GetDomain(request *GetDomainRequest) (*GetDomainResponse, error) {
+func (m *cassandraMetadataPersistence) GetDomain(ctx context.Context, request *GetDomainRequest) (*GetDomainResponse, error) {
+	ctx, cancel := m.timeouts.withTimeout(ctx, readOperation, "GetDomain")
+	defer cancel()
+
 	var query *gocql.Query
 	var err error
 	info := &DomainInfo{}
@@ -177,7 +214,7 @@ func (m *cassandraMetadataPersistence) GetDomain(request *GetDomainRequest) (*Ge
 		}
 
 		query = m.session.Query(templateGetDomainQuery,
-			request.ID)
+			request.ID).WithContext(ctx)
 		err = query.Scan(
 			&info.ID,
 			&info.Name,
@@ -185,10 +222,21 @@ func (m *cassandraMetadataPersistence) GetDomain(request *GetDomainRequest) (*Ge
 			&info.Description,
 			&info.OwnerEmail,
 			&config.Retention,
-			&config.EmitMetric)
+			&config.EmitMetric,
+			&config.BadBinaries,
+			&config.MaxDecisionAttempts,
+			&config.ActivityTimeoutDefaults.ScheduleToClose,
+			&config.ActivityTimeoutDefaults.ScheduleToStart,
+			&config.ActivityTimeoutDefaults.StartToClose,
+			&config.ActivityTimeoutDefaults.Heartbeat,
+			&config.ActivityTimeoutCaps.ScheduleToClose,
+			&config.ActivityTimeoutCaps.ScheduleToStart,
+			&config.ActivityTimeoutCaps.StartToClose,
+			&config.ActivityTimeoutCaps.Heartbeat,
+			&config.MaxOpenExecutions)
 	} else if len(request.Name) > 0 {
 		query = m.session.Query(templateGetDomainByNameQuery,
-			request.Name)
+			request.Name).WithContext(ctx)
 		err = query.Scan(
 			&info.ID,
 			&info.Name,
@@ -196,7 +244,18 @@ func (m *cassandraMetadataPersistence) GetDomain(request *GetDomainRequest) (*Ge
 			&info.Description,
 			&info.OwnerEmail,
 			&config.Retention,
-			&config.EmitMetric)
+			&config.EmitMetric,
+			&config.BadBinaries,
+			&config.MaxDecisionAttempts,
+			&config.ActivityTimeoutDefaults.ScheduleToClose,
+			&config.ActivityTimeoutDefaults.ScheduleToStart,
+			&config.ActivityTimeoutDefaults.StartToClose,
+			&config.ActivityTimeoutDefaults.Heartbeat,
+			&config.ActivityTimeoutCaps.ScheduleToClose,
+			&config.ActivityTimeoutCaps.ScheduleToStart,
+			&config.ActivityTimeoutCaps.StartToClose,
+			&config.ActivityTimeoutCaps.Heartbeat,
+			&config.MaxOpenExecutions)
 	} else {
 		return nil, &workflow.BadRequestError{
 			Message: "GetDomain operation failed.  Both ID and Name are empty.",
@@ -228,8 +287,11 @@ func (m *cassandraMetadataPersistence) GetDomain(request *GetDomainRequest) (*Ge
 	}, nil
 }
 
-func (m *cassandraMetadataPersistence) UpdateDomain(request *UpdateDomainRequest) error {
-	batch := m.session.NewBatch(gocql.LoggedBatch)
+func (m *cassandraMetadataPersistence) UpdateDomain(ctx context.Context, request *UpdateDomainRequest) error {
+	ctx, cancel := m.timeouts.withTimeout(ctx, writeOperation, "UpdateDomain")
+	defer cancel()
+
+	batch := m.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	batch.Query(templateUpdateDomainQuery,
 		request.Info.ID,
@@ -239,6 +301,17 @@ func (m *cassandraMetadataPersistence) UpdateDomain(request *UpdateDomainRequest
 		request.Info.OwnerEmail,
 		request.Config.Retention,
 		request.Config.EmitMetric,
+		request.Config.BadBinaries,
+		request.Config.MaxDecisionAttempts,
+		request.Config.ActivityTimeoutDefaults.ScheduleToClose,
+		request.Config.ActivityTimeoutDefaults.ScheduleToStart,
+		request.Config.ActivityTimeoutDefaults.StartToClose,
+		request.Config.ActivityTimeoutDefaults.Heartbeat,
+		request.Config.ActivityTimeoutCaps.ScheduleToClose,
+		request.Config.ActivityTimeoutCaps.ScheduleToStart,
+		request.Config.ActivityTimeoutCaps.StartToClose,
+		request.Config.ActivityTimeoutCaps.Heartbeat,
+		request.Config.MaxOpenExecutions,
 		request.Info.ID)
 
 	batch.Query(templateUpdateDomainByNameQuery,
@@ -249,6 +322,17 @@ func (m *cassandraMetadataPersistence) UpdateDomain(request *UpdateDomainRequest
 		request.Info.OwnerEmail,
 		request.Config.Retention,
 		request.Config.EmitMetric,
+		request.Config.BadBinaries,
+		request.Config.MaxDecisionAttempts,
+		request.Config.ActivityTimeoutDefaults.ScheduleToClose,
+		request.Config.ActivityTimeoutDefaults.ScheduleToStart,
+		request.Config.ActivityTimeoutDefaults.StartToClose,
+		request.Config.ActivityTimeoutDefaults.Heartbeat,
+		request.Config.ActivityTimeoutCaps.ScheduleToClose,
+		request.Config.ActivityTimeoutCaps.ScheduleToStart,
+		request.Config.ActivityTimeoutCaps.StartToClose,
+		request.Config.ActivityTimeoutCaps.Heartbeat,
+		request.Config.MaxOpenExecutions,
 		request.Info.Name)
 
 	if err := m.session.ExecuteBatch(batch); err != nil {
@@ -260,9 +344,12 @@ func (m *cassandraMetadataPersistence) UpdateDomain(request *UpdateDomainRequest
 	return nil
 }
 
-func (m *cassandraMetadataPersistence) DeleteDomain(request *DeleteDomainRequest) error {
+func (m *cassandraMetadataPersistence) DeleteDomain(ctx context.Context, request *DeleteDomainRequest) error {
+	ctx, cancel := m.timeouts.withTimeout(ctx, writeOperation, "DeleteDomain")
+	defer cancel()
+
 	query := m.session.Query(templateDeleteDomainQuery,
-		request.ID)
+		request.ID).WithContext(ctx)
 
 	if err := query.Exec(); err != nil {
 		return &workflow.InternalServiceError{
@@ -273,9 +360,12 @@ func (m *cassandraMetadataPersistence) DeleteDomain(request *DeleteDomainRequest
 	return nil
 }
 
-func (m *cassandraMetadataPersistence) DeleteDomainByName(request *DeleteDomainByNameRequest) error {
+func (m *cassandraMetadataPersistence) DeleteDomainByName(ctx context.Context, request *DeleteDomainByNameRequest) error {
+	ctx, cancel := m.timeouts.withTimeout(ctx, writeOperation, "DeleteDomainByName")
+	defer cancel()
+
 	query := m.session.Query(templateDeleteDomainByNameQuery,
-		request.Name)
+		request.Name).WithContext(ctx)
 
 	if err := query.Exec(); err != nil {
 		return &workflow.InternalServiceError{
@@ -285,3 +375,42 @@ func (m *cassandraMetadataPersistence) DeleteDomainByName(request *DeleteDomainB
 
 	return nil
 }
+
+func (m *cassandraMetadataPersistence) GetOpenExecutionCount(ctx context.Context,
+	request *GetOpenExecutionCountRequest) (*GetOpenExecutionCountResponse, error) {
+	ctx, cancel := m.timeouts.withTimeout(ctx, readOperation, "GetOpenExecutionCount")
+	defer cancel()
+
+	query := m.session.Query(templateGetOpenExecutionCountQuery,
+		request.DomainID).WithContext(ctx)
+
+	// The counter row of a domain only exists once one of its executions was started
+	var count int64
+	if err := query.Scan(&count); err != nil && err != gocql.ErrNotFound {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetOpenExecutionCount operation failed. Error %v", err),
+		}
+	}
+
+	return &GetOpenExecutionCountResponse{Count: count}, nil
+}
+
+// UpdateOpenExecutionCount adds the delta of the request to the count of the open executions of the domain.  Counter
+// updates are not idempotent, an update which timed out may or may not have been applied.
+func (m *cassandraMetadataPersistence) UpdateOpenExecutionCount(ctx context.Context,
+	request *UpdateOpenExecutionCountRequest) error {
+	ctx, cancel := m.timeouts.withTimeout(ctx, writeOperation, "UpdateOpenExecutionCount")
+	defer cancel()
+
+	query := m.session.Query(templateUpdateOpenExecutionCountQuery,
+		request.Delta,
+		request.DomainID).WithContext(ctx)
+
+	if err := query.Exec(); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateOpenExecutionCount operation failed. Error %v", err),
+		}
+	}
+
+	return nil
+}
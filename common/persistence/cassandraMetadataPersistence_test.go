@@ -25,8 +25,10 @@ import (
 	"testing"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
 
 	gen "github.com/uber/cadence/.gen/go/shared"
 	//"github.com/uber/cadence/common"
@@ -194,6 +196,11 @@ func (m *metadataPersistenceSuite) TestUpdateDomain() {
 	updatedOwner := "owner-updated"
 	updatedRetention := int32(20)
 	updatedEmitMetric := false
+	updatedBadBinaries := []string{"bad-binary-checksum"}
+	updatedMaxDecisionAttempts := int32(5)
+	updatedActivityTimeoutDefaults := ActivityTimeouts{ScheduleToClose: 600, ScheduleToStart: 60, StartToClose: 300}
+	updatedActivityTimeoutCaps := ActivityTimeouts{ScheduleToClose: 3600, Heartbeat: 120}
+	updatedMaxOpenExecutions := int32(1000)
 
 	err3 := m.UpdateDomain(
 		&DomainInfo{
@@ -204,8 +211,13 @@ func (m *metadataPersistenceSuite) TestUpdateDomain() {
 			OwnerEmail:  updatedOwner,
 		},
 		&DomainConfig{
-			Retention:  updatedRetention,
-			EmitMetric: updatedEmitMetric,
+			Retention:               updatedRetention,
+			EmitMetric:              updatedEmitMetric,
+			BadBinaries:             updatedBadBinaries,
+			MaxDecisionAttempts:     updatedMaxDecisionAttempts,
+			ActivityTimeoutDefaults: updatedActivityTimeoutDefaults,
+			ActivityTimeoutCaps:     updatedActivityTimeoutCaps,
+			MaxOpenExecutions:       updatedMaxOpenExecutions,
 		})
 
 	m.Nil(err3)
@@ -220,6 +232,11 @@ func (m *metadataPersistenceSuite) TestUpdateDomain() {
 	m.Equal(updatedOwner, resp4.Info.OwnerEmail)
 	m.Equal(updatedRetention, resp4.Config.Retention)
 	m.Equal(updatedEmitMetric, resp4.Config.EmitMetric)
+	m.Equal(updatedBadBinaries, resp4.Config.BadBinaries)
+	m.Equal(updatedMaxDecisionAttempts, resp4.Config.MaxDecisionAttempts)
+	m.Equal(updatedActivityTimeoutDefaults, resp4.Config.ActivityTimeoutDefaults)
+	m.Equal(updatedActivityTimeoutCaps, resp4.Config.ActivityTimeoutCaps)
+	m.Equal(updatedMaxOpenExecutions, resp4.Config.MaxOpenExecutions)
 
 	resp5, err5 := m.GetDomain("", name)
 	m.Nil(err5)
@@ -231,6 +248,11 @@ func (m *metadataPersistenceSuite) TestUpdateDomain() {
 	m.Equal(updatedOwner, resp5.Info.OwnerEmail)
 	m.Equal(updatedRetention, resp5.Config.Retention)
 	m.Equal(updatedEmitMetric, resp5.Config.EmitMetric)
+	m.Equal(updatedBadBinaries, resp5.Config.BadBinaries)
+	m.Equal(updatedMaxDecisionAttempts, resp5.Config.MaxDecisionAttempts)
+	m.Equal(updatedActivityTimeoutDefaults, resp5.Config.ActivityTimeoutDefaults)
+	m.Equal(updatedActivityTimeoutCaps, resp5.Config.ActivityTimeoutCaps)
+	m.Equal(updatedMaxOpenExecutions, resp5.Config.MaxOpenExecutions)
 }
 
 func (m *metadataPersistenceSuite) TestDeleteDomain() {
@@ -282,8 +304,27 @@ func (m *metadataPersistenceSuite) TestDeleteDomain() {
 	m.Nil(resp7)
 }
 
+func (m *metadataPersistenceSuite) TestOpenExecutionCount() {
+	domainID := uuid.New()
+
+	count0, err0 := m.GetOpenExecutionCount(domainID)
+	m.Nil(err0)
+	m.Equal(int64(0), count0)
+
+	m.Nil(m.UpdateOpenExecutionCount(domainID, 3))
+	m.Nil(m.UpdateOpenExecutionCount(domainID, -1))
+
+	count1, err1 := m.GetOpenExecutionCount(domainID)
+	m.Nil(err1)
+	m.Equal(int64(2), count1)
+
+	count2, err2 := m.GetOpenExecutionCount(uuid.New())
+	m.Nil(err2)
+	m.Equal(int64(0), count2)
+}
+
 func (m *metadataPersistenceSuite) CreateDomain(info *DomainInfo, config *DomainConfig) (*CreateDomainResponse, error) {
-	return m.MetadataManager.CreateDomain(&CreateDomainRequest{
+	return m.MetadataManager.CreateDomain(context.Background(), &CreateDomainRequest{
 		Name:        info.Name,
 		Status:      info.Status,
 		Description: info.Description,
@@ -294,14 +335,14 @@ func (m *metadataPersistenceSuite) CreateDomain(info *DomainInfo, config *Domain
 }
 
 func (m *metadataPersistenceSuite) GetDomain(id, name string) (*GetDomainResponse, error) {
-	return m.MetadataManager.GetDomain(&GetDomainRequest{
+	return m.MetadataManager.GetDomain(context.Background(), &GetDomainRequest{
 		ID:   id,
 		Name: name,
 	})
 }
 
 func (m *metadataPersistenceSuite) UpdateDomain(info *DomainInfo, config *DomainConfig) error {
-	return m.MetadataManager.UpdateDomain(&UpdateDomainRequest{
+	return m.MetadataManager.UpdateDomain(context.Background(), &UpdateDomainRequest{
 		Info:   info,
 		Config: config,
 	})
@@ -309,7 +350,23 @@ func (m *metadataPersistenceSuite) UpdateDomain(info *DomainInfo, config *Domain
 
 func (m *metadataPersistenceSuite) DeleteDomain(id, name string) error {
 	if len(id) > 0 {
-		return m.MetadataManager.DeleteDomain(&DeleteDomainRequest{ID: id})
+		return m.MetadataManager.DeleteDomain(context.Background(), &DeleteDomainRequest{ID: id})
+	}
+	return m.MetadataManager.DeleteDomainByName(context.Background(), &DeleteDomainByNameRequest{Name: name})
+}
+
+func (m *metadataPersistenceSuite) GetOpenExecutionCount(domainID string) (int64, error) {
+	response, err := m.MetadataManager.GetOpenExecutionCount(context.Background(),
+		&GetOpenExecutionCountRequest{DomainID: domainID})
+	if err != nil {
+		return 0, err
 	}
-	return m.MetadataManager.DeleteDomainByName(&DeleteDomainByNameRequest{Name: name})
+	return response.Count, nil
+}
+
+func (m *metadataPersistenceSuite) UpdateOpenExecutionCount(domainID string, delta int64) error {
+	return m.MetadataManager.UpdateOpenExecutionCount(context.Background(), &UpdateOpenExecutionCountRequest{
+		DomainID: domainID,
+		Delta:    delta,
+	})
 }
@@ -28,14 +28,16 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/pborman/uuid"
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
 )
 
 const (
 	cassandraProtoVersion                = 4
-	defaultSessionTimeout                = 10 * time.Second
 	rowTypeExecutionTaskID               = int64(77)
 	permanentRunID                       = "dcb940ac-0c63-ffa2-ffea-a6c305881d71"
 	emptyRunID                           = "2912faa8-274d-f70d-f96d-0ac8cf614799"
@@ -49,6 +51,10 @@ const (
 	rowTypeTimerDomainID                 = "2b2dc6d8-e465-fb94-f66c-a5f2f38e16f5"
 	rowTypeTimerWorkflowID               = "cd1f9688-d7ac-fc6b-f69e-8b44a3460a3d"
 	rowTypeTimerRunID                    = "c82b7881-892f-fd9e-feb3-a6d9f7b32f7f"
+	rowTypeCronTimerWorkflowID           = "6f0c2d47-95b3-f1e8-fa6d-3c8e7b2a194d"
+	rowTypeTransferDLQDomainID           = "e2e4e6a1-8cd5-f0a3-fb2e-1f5a0c7d9b43"
+	rowTypeTransferDLQWorkflowID         = "9d6b1f0e-3a27-f4c8-fd51-c08e2b7a61f5"
+	rowTypeTransferDLQRunID              = "47c3a9d2-b6e1-f85d-fa04-63d9e1c5b827"
 	transferTaskTransferTargetWorkflowID = "11111111-1a97-f929-fd00-b6fef701457d"
 	transferTaskTypeTransferTargetRunID  = "11111111-f1fa-fa16-f67b-4553d9859b8c"
 	rowTypeShardTaskID                   = int64(23)
@@ -62,12 +68,14 @@ const (
 	rowTypeExecution
 	rowTypeTransferTask
 	rowTypeTimerTask
+	rowTypeTransferDLQTask
 )
 
 const (
 	// Row types for table tasks
 	rowTypeTask = iota
 	rowTypeTaskList
+	rowTypeHighPriorityTask
 )
 
 const (
@@ -82,7 +90,10 @@ const (
 		`range_id: ?, ` +
 		`stolen_since_renew: ?, ` +
 		`updated_at: ?, ` +
-		`transfer_ack_level: ?` +
+		`transfer_ack_level: ?, ` +
+		`timer_ack_level: ?, ` +
+		`timer_ack_levels: ?, ` +
+		`queue_paused: ?` +
 		`}`
 
 	templateWorkflowExecutionType = `{` +
@@ -108,7 +119,15 @@ const (
 		`decision_schedule_id: ?, ` +
 		`decision_started_id: ?, ` +
 		`decision_request_id: ?, ` +
-		`decision_timeout: ?` +
+		`decision_timeout: ?, ` +
+		`decision_attempt: ?, ` +
+		`decision_scheduled_time: ?, ` +
+		`decision_started_time: ?, ` +
+		`build_id: ?, ` +
+		`signal_count: ?, ` +
+		`activity_count: ?, ` +
+		`timer_count: ?, ` +
+		`decision_count: ?` +
 		`}`
 
 	templateTransferTaskType = `{` +
@@ -121,7 +140,8 @@ const (
 		`target_run_id: ?, ` +
 		`task_list: ?, ` +
 		`type: ?, ` +
-		`schedule_id: ?` +
+		`schedule_id: ?, ` +
+		`visibility_ts: ?` +
 		`}`
 
 	templateTimerTaskType = `{` +
@@ -139,6 +159,7 @@ const (
 		`scheduled_event: ?, ` +
 		`started_id: ?, ` +
 		`started_event: ?, ` +
+		`started_time: ?, ` +
 		`activity_id: ?, ` +
 		`request_id: ?, ` +
 		`details: ?, ` +
@@ -148,7 +169,10 @@ const (
 		`heart_beat_timeout: ?, ` +
 		`cancel_requested: ?, ` +
 		`cancel_request_id: ?, ` +
-		`last_hb_updated_time: ?` +
+		`last_hb_updated_time: ?, ` +
+		`priority: ?, ` +
+		`session_id: ?, ` +
+		`dispatch_deferred: ?` +
 		`}`
 
 	templateTimerInfoType = `{` +
@@ -166,18 +190,36 @@ const (
 		`create_request_id: ?` +
 		`}`
 
+	templateRequestCancelInfoType = `{` +
+		`initiated_id: ?, ` +
+		`cancel_request_id: ?` +
+		`}`
+
+	templateSerializedEventBatchType = `{` +
+		`encoding_type: ?, ` +
+		`version: ?, ` +
+		`data: ?` +
+		`}`
+
 	templateTaskListType = `{` +
 		`domain_id: ?, ` +
 		`name: ?, ` +
 		`type: ?, ` +
-		`ack_level: ? ` +
+		`ack_level: ?, ` +
+		`last_updated: ?, ` +
+		`pollers: ?, ` +
+		`high_priority_ack_level: ? ` +
 		`}`
 
 	templateTaskType = `{` +
 		`domain_id: ?, ` +
 		`workflow_id: ?, ` +
 		`run_id: ?, ` +
-		`schedule_id: ?` +
+		`schedule_id: ?, ` +
+		`build_id: ?, ` +
+		`priority: ?, ` +
+		`session_id: ?, ` +
+		`payload: ?` +
 		`}`
 
 	templateCreateShardQuery = `INSERT INTO executions (` +
@@ -204,7 +246,7 @@ const (
 		`IF range_id = ?`
 
 	templateUpdateCurrentWorkflowExecutionQuery = `UPDATE executions ` +
-		`SET current_run_id = ?, execution = {run_id: ?, create_request_id: ?}` +
+		`SET current_run_id = ?, execution = {run_id: ?, create_request_id: ?, start_time: ?}` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
 		`and domain_id = ? ` +
@@ -214,7 +256,7 @@ const (
 
 	templateCreateWorkflowExecutionQuery = `INSERT INTO executions (` +
 		`shard_id, type, domain_id, workflow_id, run_id, task_id, current_run_id, execution) ` +
-		`VALUES(?, ?, ?, ?, ?, ?, ?, {run_id: ?, create_request_id: ?}) IF NOT EXISTS`
+		`VALUES(?, ?, ?, ?, ?, ?, ?, {run_id: ?, create_request_id: ?, start_time: ?}) IF NOT EXISTS`
 
 	templateCreateWorkflowExecutionQuery2 = `INSERT INTO executions (` +
 		`shard_id, domain_id, workflow_id, run_id, type, execution, next_event_id, task_id) ` +
@@ -233,7 +275,8 @@ const (
 		`WHERE shard_id = ? ` +
 		`IF range_id = ?`
 
-	templateGetWorkflowExecutionQuery = `SELECT execution, activity_map, timer_map, child_executions_map ` +
+	templateGetWorkflowExecutionQuery = `SELECT execution, activity_map, timer_map, child_executions_map, request_cancel_map, buffered_events_list, ` +
+		`signal_receipt_map, checksum ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -252,7 +295,7 @@ const (
 		`and task_id = ?`
 
 	templateUpdateWorkflowExecutionQuery = `UPDATE executions ` +
-		`SET execution = ` + templateWorkflowExecutionType + `, next_event_id = ? ` +
+		`SET execution = ` + templateWorkflowExecutionType + `, next_event_id = ?, checksum = ? ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
 		`and domain_id = ? ` +
@@ -291,6 +334,56 @@ const (
 		`and task_id = ? ` +
 		`IF next_event_id = ? and range_id = ?`
 
+	templateUpdateRequestCancelInfoQuery = `UPDATE executions ` +
+		`SET request_cancel_map[ ? ] =` + templateRequestCancelInfoType + ` ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ? and range_id = ?`
+
+	templateUpdateSignalReceiptQuery = `UPDATE executions ` +
+		`SET signal_receipt_map[ ? ] = ? ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ? and range_id = ?`
+
+	templateDeleteSignalReceiptQuery = `DELETE signal_receipt_map[ ? ] ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ? and range_id = ?`
+
+	templateAppendBufferedEventsQuery = `UPDATE executions ` +
+		`SET buffered_events_list = buffered_events_list + [ ` + templateSerializedEventBatchType + ` ] ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ? and range_id = ?`
+
+	templateDeleteBufferedEventsQuery = `UPDATE executions ` +
+		`SET buffered_events_list = [] ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ? and range_id = ?`
+
 	templateDeleteActivityInfoQuery = `DELETE activity_map[ ? ] ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -321,6 +414,16 @@ const (
 		`and task_id = ? ` +
 		`IF next_event_id = ? and range_id = ?`
 
+	templateDeleteRequestCancelInfoQuery = `DELETE request_cancel_map[ ? ] ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ? and range_id = ?`
+
 	templateDeleteWorkflowExecutionQuery = `DELETE FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -355,6 +458,15 @@ const (
 		`and run_id = ? ` +
 		`and task_id = ?`
 
+	templateGetTransferDLQTasksQuery = `SELECT transfer ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and task_id > ? LIMIT ?`
+
 	templateGetTimerTasksQuery = `SELECT timer ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -365,6 +477,20 @@ const (
 		`and task_id >= ?` +
 		`and task_id < ? LIMIT ?`
 
+	templateGetTimerTasksByIDQuery = `SELECT timer ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and task_id IN ?`
+
+	templateListExecutionsQuery = `SELECT run_id, execution ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ?`
+
 	templateCompleteTimerTaskQuery = `DELETE FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -433,68 +559,78 @@ const (
 		`and task_list_name = ? ` +
 		`and task_list_type = ? ` +
 		`IF range_id = ?`
+
+	templateInsertTaskListByDomainQuery = `INSERT INTO task_lists_by_domain (` +
+		`domain_id, ` +
+		`task_list_name, ` +
+		`task_list_type` +
+		`) VALUES (?, ?, ?)`
+
+	templateDeleteTaskListByDomainQuery = `DELETE FROM task_lists_by_domain ` +
+		`WHERE domain_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ?`
+
+	templateListTaskListsQuery = `SELECT ` +
+		`domain_id, ` +
+		`task_list_name, ` +
+		`task_list_type ` +
+		`FROM task_lists_by_domain`
+
+	templateListDomainTaskListsQuery = templateListTaskListsQuery + ` ` +
+		`WHERE domain_id = ?`
+
+	templateDeleteTaskListQuery = `DELETE FROM tasks ` +
+		`WHERE domain_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ? ` +
+		`IF range_id = ?`
 )
 
 type (
 	cassandraPersistence struct {
-		session      *gocql.Session
-		lowConslevel gocql.Consistency
-		shardID      int
-		logger       bark.Logger
+		cassandraStore
+		shardID int
 	}
 )
 
 // NewCassandraShardPersistence is used to create an instance of ShardManager implementation
-func NewCassandraShardPersistence(hosts string, dc string, keyspace string, logger bark.Logger) (ShardManager, error) {
-	cluster := common.NewCassandraCluster(hosts, dc)
-	cluster.Keyspace = keyspace
-	cluster.ProtoVersion = cassandraProtoVersion
-	cluster.Consistency = gocql.LocalQuorum
-	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
-
-	session, err := cluster.CreateSession()
+func NewCassandraShardPersistence(cfg config.Cassandra, metricsClient metrics.Client,
+	logger bark.Logger) (ShardManager, error) {
+	store, err := newCassandraStore(cfg, cfg.Keyspace, ShardStoreName, metricsClient, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	return &cassandraPersistence{shardID: -1, session: session, lowConslevel: gocql.One, logger: logger}, nil
+	return &cassandraPersistence{cassandraStore: store, shardID: -1}, nil
 }
 
 // NewCassandraWorkflowExecutionPersistence is used to create an instance of workflowExecutionManager implementation
-func NewCassandraWorkflowExecutionPersistence(hosts string, dc string, keyspace string, shardID int, logger bark.Logger) (ExecutionManager, error) {
-	cluster := common.NewCassandraCluster(hosts, dc)
-	cluster.Keyspace = keyspace
-	cluster.ProtoVersion = cassandraProtoVersion
-	cluster.Consistency = gocql.LocalQuorum
-	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
-
-	session, err := cluster.CreateSession()
+func NewCassandraWorkflowExecutionPersistence(cfg config.Cassandra, shardID int, metricsClient metrics.Client,
+	logger bark.Logger) (ExecutionManager, error) {
+	store, err := newCassandraStore(cfg, cfg.Keyspace, ExecutionStoreName, metricsClient, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	return &cassandraPersistence{shardID: shardID, session: session, lowConslevel: gocql.One, logger: logger}, nil
+	return &cassandraPersistence{cassandraStore: store, shardID: shardID}, nil
 }
 
 // NewCassandraTaskPersistence is used to create an instance of TaskManager implementation
-func NewCassandraTaskPersistence(hosts string, dc string, keyspace string, logger bark.Logger) (TaskManager, error) {
-	cluster := common.NewCassandraCluster(hosts, dc)
-	cluster.Keyspace = keyspace
-	cluster.ProtoVersion = cassandraProtoVersion
-	cluster.Consistency = gocql.LocalQuorum
-	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
-
-	session, err := cluster.CreateSession()
+func NewCassandraTaskPersistence(cfg config.Cassandra, metricsClient metrics.Client,
+	logger bark.Logger) (TaskManager, error) {
+	store, err := newCassandraStore(cfg, cfg.Keyspace, TaskStoreName, metricsClient, logger)
 	if err != nil {
 		return nil, err
 	}
-	return &cassandraPersistence{shardID: -1, session: session, lowConslevel: gocql.One, logger: logger}, nil
+
+	return &cassandraPersistence{cassandraStore: store, shardID: -1}, nil
 }
 
-func (d *cassandraPersistence) CreateShard(request *CreateShardRequest) error {
+func (d *cassandraPersistence) CreateShard(ctx context.Context, request *CreateShardRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "CreateShard")
+	defer cancel()
+
 	cqlNowTimestamp := common.UnixNanoToCQLTimestamp(time.Now().UnixNano())
 	shardInfo := request.ShardInfo
 	query := d.session.Query(templateCreateShardQuery,
@@ -510,7 +646,10 @@ func (d *cassandraPersistence) CreateShard(request *CreateShardRequest) error {
 		shardInfo.StolenSinceRenew,
 		cqlNowTimestamp,
 		shardInfo.TransferAckLevel,
-		shardInfo.RangeID)
+		shardInfo.TimerAckLevel,
+		shardInfo.TimerAckLevels,
+		shardInfo.QueuePaused,
+		shardInfo.RangeID).WithContext(ctx)
 
 	previous := make(map[string]interface{})
 	applied, err := query.MapScanCAS(previous)
@@ -531,7 +670,10 @@ func (d *cassandraPersistence) CreateShard(request *CreateShardRequest) error {
 	return nil
 }
 
-func (d *cassandraPersistence) GetShard(request *GetShardRequest) (*GetShardResponse, error) {
+func (d *cassandraPersistence) GetShard(ctx context.Context, request *GetShardRequest) (*GetShardResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, readOperation, "GetShard")
+	defer cancel()
+
 	shardID := request.ShardID
 	query := d.session.Query(templateGetShardQuery,
 		shardID,
@@ -539,7 +681,7 @@ func (d *cassandraPersistence) GetShard(request *GetShardRequest) (*GetShardResp
 		rowTypeShardDomainID,
 		rowTypeShardWorkflowID,
 		rowTypeShardRunID,
-		rowTypeShardTaskID).Consistency(d.lowConslevel)
+		rowTypeShardTaskID).WithContext(ctx).Consistency(d.getReadConsistency())
 
 	result := make(map[string]interface{})
 	if err := query.MapScan(result); err != nil {
@@ -559,7 +701,10 @@ func (d *cassandraPersistence) GetShard(request *GetShardRequest) (*GetShardResp
 	return &GetShardResponse{ShardInfo: info}, nil
 }
 
-func (d *cassandraPersistence) UpdateShard(request *UpdateShardRequest) error {
+func (d *cassandraPersistence) UpdateShard(ctx context.Context, request *UpdateShardRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "UpdateShard")
+	defer cancel()
+
 	cqlNowTimestamp := common.UnixNanoToCQLTimestamp(time.Now().UnixNano())
 	shardInfo := request.ShardInfo
 
@@ -570,6 +715,9 @@ func (d *cassandraPersistence) UpdateShard(request *UpdateShardRequest) error {
 		shardInfo.StolenSinceRenew,
 		cqlNowTimestamp,
 		shardInfo.TransferAckLevel,
+		shardInfo.TimerAckLevel,
+		shardInfo.TimerAckLevels,
+		shardInfo.QueuePaused,
 		shardInfo.RangeID,
 		shardInfo.ShardID,
 		rowTypeShard,
@@ -577,7 +725,7 @@ func (d *cassandraPersistence) UpdateShard(request *UpdateShardRequest) error {
 		rowTypeShardWorkflowID,
 		rowTypeShardRunID,
 		rowTypeShardTaskID,
-		request.PreviousRangeID)
+		request.PreviousRangeID).WithContext(ctx)
 
 	previous := make(map[string]interface{})
 	applied, err := query.MapScanCAS(previous)
@@ -603,11 +751,32 @@ func (d *cassandraPersistence) UpdateShard(request *UpdateShardRequest) error {
 	return nil
 }
 
-func (d *cassandraPersistence) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (
+func (d *cassandraPersistence) AllocateTaskIDs(ctx context.Context, request *AllocateTaskIDsRequest) (
+	*AllocateTaskIDsResponse, error) {
+	shardInfo := *request.ShardInfo
+	shardInfo.RangeID = request.ShardInfo.RangeID + 1
+	if err := d.UpdateShard(ctx, &UpdateShardRequest{
+		ShardInfo:       &shardInfo,
+		PreviousRangeID: request.ShardInfo.RangeID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &AllocateTaskIDsResponse{
+		RangeID:   shardInfo.RangeID,
+		MinTaskID: shardInfo.RangeID << request.RangeSizeBits,
+		MaxTaskID: (shardInfo.RangeID + 1) << request.RangeSizeBits,
+	}, nil
+}
+
+func (d *cassandraPersistence) CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (
 	*CreateWorkflowExecutionResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "CreateWorkflowExecution")
+	defer cancel()
+
 	transferTaskID := uuid.New()
 	cqlNowTimestamp := common.UnixNanoToCQLTimestamp(time.Now().UnixNano())
-	batch := d.session.NewBatch(gocql.LoggedBatch)
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	d.CreateWorkflowExecutionWithinBatch(request, batch, cqlNowTimestamp)
 
@@ -654,11 +823,16 @@ func (d *cassandraPersistence) CreateWorkflowExecution(request *CreateWorkflowEx
 			// CreateWorkflowExecution failed because it already exists
 			msg := fmt.Sprintf("Workflow execution already running. WorkflowId: %v, RunId: %v, rangeID: %v, columns: (%v)",
 				execution["workflow_id"], execution["run_id"], request.RangeID, strings.Join(columns, ","))
-			return nil, &workflow.WorkflowExecutionAlreadyStartedError{
+			alreadyStartedErr := &workflow.WorkflowExecutionAlreadyStartedError{
 				Message:        common.StringPtr(msg),
 				StartRequestId: common.StringPtr(fmt.Sprintf("%v", execution["create_request_id"])),
 				RunId:          common.StringPtr(fmt.Sprintf("%v", execution["run_id"])),
 			}
+			// Executions created before the start time was recorded on the current row don't report it
+			if startTime, ok := execution["start_time"].(time.Time); ok && !startTime.IsZero() {
+				alreadyStartedErr.StartTime = common.Int64Ptr(startTime.UnixNano())
+			}
+			return nil, alreadyStartedErr
 		}
 
 		return nil, &ConditionFailedError{
@@ -677,6 +851,7 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *Creat
 			request.Execution.GetRunId(),
 			request.Execution.GetRunId(),
 			request.RequestID,
+			cqlNowTimestamp,
 			d.shardID,
 			rowTypeExecution,
 			request.DomainID,
@@ -693,7 +868,8 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *Creat
 			rowTypeExecutionTaskID,
 			request.Execution.GetRunId(),
 			request.Execution.GetRunId(),
-			request.RequestID)
+			request.RequestID,
+			cqlNowTimestamp)
 	}
 
 	parentDomainID := emptyDomainID
@@ -736,12 +912,23 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *Creat
 		request.DecisionStartedID,
 		"", // Decision Start Request ID
 		request.DecisionStartToCloseTimeout,
+		int64(0),        // Decision attempt
+		cqlNowTimestamp, // Decision scheduled time
+		nil,             // Decision started time
+		"",              // Build ID
+		int64(0),        // Signal count
+		int64(0),        // Activity count
+		int64(0),        // Timer count
+		int64(0),        // Decision count
 		request.NextEventID,
 		rowTypeExecutionTaskID)
 }
 
-func (d *cassandraPersistence) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (
+func (d *cassandraPersistence) GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (
 	*GetWorkflowExecutionResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, readOperation, "GetWorkflowExecution")
+	defer cancel()
+
 	execution := request.Execution
 	query := d.session.Query(templateGetWorkflowExecutionQuery,
 		d.shardID,
@@ -749,7 +936,7 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *GetWorkflowExecutio
 		request.DomainID,
 		execution.GetWorkflowId(),
 		execution.GetRunId(),
-		rowTypeExecutionTaskID)
+		rowTypeExecutionTaskID).WithContext(ctx)
 
 	result := make(map[string]interface{})
 	if err := query.MapScan(result); err != nil {
@@ -793,14 +980,34 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *GetWorkflowExecutio
 	}
 	state.ChildExecutionInfos = childExecutionInfos
 
+	requestCancelInfos := make(map[int64]*RequestCancelInfo)
+	rMap := result["request_cancel_map"].(map[int64]map[string]interface{})
+	for key, value := range rMap {
+		info := createRequestCancelInfo(value)
+		requestCancelInfos[key] = info
+	}
+	state.RequestCancelInfos = requestCancelInfos
+
+	bufferedEvents := []*SerializedHistoryEventBatch{}
+	eList, _ := result["buffered_events_list"].([]map[string]interface{})
+	for _, value := range eList {
+		bufferedEvents = append(bufferedEvents, createSerializedHistoryEventBatch(value))
+	}
+	state.BufferedEvents = bufferedEvents
+	state.SignalReceipts, _ = result["signal_receipt_map"].(map[string]int64)
+	state.Checksum, _ = result["checksum"].(int64)
+
 	return &GetWorkflowExecutionResponse{State: state}, nil
 }
 
-func (d *cassandraPersistence) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) error {
+func (d *cassandraPersistence) UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "UpdateWorkflowExecution")
+	defer cancel()
+
 	executionInfo := request.ExecutionInfo
 	cqlNowTimestamp := common.UnixNanoToCQLTimestamp(time.Now().UnixNano())
 
-	batch := d.session.NewBatch(gocql.LoggedBatch)
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 	batch.Query(templateUpdateWorkflowExecutionQuery,
 		executionInfo.DomainID,
 		executionInfo.WorkflowID,
@@ -825,7 +1032,16 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *UpdateWorkflowEx
 		executionInfo.DecisionStartedID,
 		executionInfo.DecisionRequestID,
 		executionInfo.DecisionTimeout,
+		executionInfo.DecisionAttempt,
+		executionInfo.DecisionScheduledTime,
+		executionInfo.DecisionStartedTime,
+		executionInfo.BuildID,
+		executionInfo.SignalCount,
+		executionInfo.ActivityCount,
+		executionInfo.TimerCount,
+		executionInfo.DecisionCount,
 		executionInfo.NextEventID,
+		request.Checksum,
 		d.shardID,
 		rowTypeExecution,
 		executionInfo.DomainID,
@@ -850,11 +1066,22 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *UpdateWorkflowEx
 	d.updateChildExecutionInfos(batch, request.UpsertChildExecutionInfos, request.DeleteChildExecutionInfo,
 		executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, request.Condition, request.RangeID)
 
+	d.updateRequestCancelInfos(batch, request.UpsertRequestCancelInfos, request.DeleteRequestCancelInfo,
+		executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, request.Condition, request.RangeID)
+
+	d.updateBufferedEvents(batch, request.NewBufferedEvents, request.ClearBufferedEvents, executionInfo.DomainID,
+		executionInfo.WorkflowID, executionInfo.RunID, request.Condition, request.RangeID)
+
+	d.updateSignalReceipts(batch, request.UpsertSignalReceipts, request.DeleteSignalReceipts, executionInfo.DomainID,
+		executionInfo.WorkflowID, executionInfo.RunID, request.Condition, request.RangeID)
+
 	if request.ContinueAsNew != nil {
 		startReq := request.ContinueAsNew
 		d.CreateWorkflowExecutionWithinBatch(startReq, batch, cqlNowTimestamp)
 		d.createTransferTasks(batch, startReq.TransferTasks, startReq.DomainID, startReq.Execution.GetWorkflowId(),
 			startReq.Execution.GetRunId(), cqlNowTimestamp)
+		d.createTimerTasks(batch, startReq.TimerTasks, nil, startReq.DomainID, startReq.Execution.GetWorkflowId(),
+			startReq.Execution.GetRunId(), cqlNowTimestamp)
 	} else if request.CloseExecution {
 		// Delete WorkflowExecution row representing current execution
 		batch.Query(templateDeleteWorkflowExecutionQuery,
@@ -911,10 +1138,14 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *UpdateWorkflowEx
 	return nil
 }
 
-func (d *cassandraPersistence) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+func (d *cassandraPersistence) DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "DeleteWorkflowExecution")
+	defer cancel()
+
 	info := request.ExecutionInfo
 
-	query := d.session.Query(templateDeleteWorkflowExecutionMutableStateQuery,
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(templateDeleteWorkflowExecutionMutableStateQuery,
 		d.shardID,
 		rowTypeExecution,
 		info.DomainID,
@@ -922,25 +1153,21 @@ func (d *cassandraPersistence) DeleteWorkflowExecution(request *DeleteWorkflowEx
 		info.RunID,
 		rowTypeExecutionTaskID)
 
-	err := query.Exec()
-	if err != nil {
-		return &workflow.InternalServiceError{
-			Message: fmt.Sprintf("DeleteWorkflowExecution operation failed. Error: %v", err),
-		}
-	}
-
-	return nil
+	return d.executeBatchWithLease(batch, request.RangeID, "DeleteWorkflowExecution")
 }
 
-func (d *cassandraPersistence) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse,
+func (d *cassandraPersistence) GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse,
 	error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, readOperation, "GetCurrentExecution")
+	defer cancel()
+
 	query := d.session.Query(templateGetCurrentExecutionQuery,
 		d.shardID,
 		rowTypeExecution,
 		request.DomainID,
 		request.WorkflowID,
 		permanentRunID,
-		rowTypeExecutionTaskID)
+		rowTypeExecutionTaskID).WithContext(ctx)
 
 	var currentRunID string
 	if err := query.Scan(&currentRunID); err != nil {
@@ -959,7 +1186,9 @@ func (d *cassandraPersistence) GetCurrentExecution(request *GetCurrentExecutionR
 	return &GetCurrentExecutionResponse{RunID: currentRunID}, nil
 }
 
-func (d *cassandraPersistence) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+func (d *cassandraPersistence) GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, rangeScanOperation, "GetTransferTasks")
+	defer cancel()
 
 	// Reading transfer tasks need to be quorum level consistent, otherwise we could loose task
 	query := d.session.Query(templateGetTransferTasksQuery,
@@ -970,7 +1199,7 @@ func (d *cassandraPersistence) GetTransferTasks(request *GetTransferTasksRequest
 		rowTypeTransferRunID,
 		request.ReadLevel,
 		request.MaxReadLevel,
-		request.BatchSize)
+		request.BatchSize).WithContext(ctx)
 
 	iter := query.Iter()
 	if iter == nil {
@@ -998,14 +1227,17 @@ func (d *cassandraPersistence) GetTransferTasks(request *GetTransferTasksRequest
 	return response, nil
 }
 
-func (d *cassandraPersistence) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+func (d *cassandraPersistence) CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "CompleteTransferTask")
+	defer cancel()
+
 	query := d.session.Query(templateCompleteTransferTaskQuery,
 		d.shardID,
 		rowTypeTransferTask,
 		rowTypeTransferDomainID,
 		rowTypeTransferWorkflowID,
 		rowTypeTransferRunID,
-		request.TaskID)
+		request.TaskID).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -1017,14 +1249,123 @@ func (d *cassandraPersistence) CompleteTransferTask(request *CompleteTransferTas
 	return nil
 }
 
-func (d *cassandraPersistence) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+// PutTransferDLQTask parks the transfer task in the dead letter queue of the shard.  The task is copied as is, it still
+// needs to be completed in the transfer queue to let the ack level move past it.
+func (d *cassandraPersistence) PutTransferDLQTask(ctx context.Context, request *PutTransferDLQTaskRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "PutTransferDLQTask")
+	defer cancel()
+
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	d.copyTransferTask(batch, request.TaskInfo, request.TaskInfo.TaskID, rowTypeTransferDLQTask,
+		rowTypeTransferDLQDomainID, rowTypeTransferDLQWorkflowID, rowTypeTransferDLQRunID)
+
+	return d.executeBatchWithLease(batch, request.RangeID, "PutTransferDLQTask")
+}
+
+// RedriveTransferDLQTask moves the parked transfer task back to the transfer queue under the new task ID, which has to
+// be above the transfer read level of the shard for the task to be processed.
+func (d *cassandraPersistence) RedriveTransferDLQTask(ctx context.Context, request *RedriveTransferDLQTaskRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "RedriveTransferDLQTask")
+	defer cancel()
+
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	d.copyTransferTask(batch, request.TaskInfo, request.NewTaskID, rowTypeTransferTask,
+		rowTypeTransferDomainID, rowTypeTransferWorkflowID, rowTypeTransferRunID)
+	batch.Query(templateCompleteTransferTaskQuery,
+		d.shardID,
+		rowTypeTransferDLQTask,
+		rowTypeTransferDLQDomainID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		request.TaskInfo.TaskID)
+
+	return d.executeBatchWithLease(batch, request.RangeID, "RedriveTransferDLQTask")
+}
+
+// copyTransferTask adds the insert of the transfer task, under the task ID, in the rows of the given type to the batch
+func (d *cassandraPersistence) copyTransferTask(batch *gocql.Batch, task *TransferTaskInfo, taskID int64, rowType int,
+	rowDomainID, rowWorkflowID, rowRunID string) {
+	targetDomainID := task.TargetDomainID
+	if targetDomainID == "" {
+		targetDomainID = task.DomainID
+	}
+	targetWorkflowID := task.TargetWorkflowID
+	if targetWorkflowID == "" {
+		targetWorkflowID = transferTaskTransferTargetWorkflowID
+	}
+	targetRunID := task.TargetRunID
+	if targetRunID == "" {
+		targetRunID = transferTaskTypeTransferTargetRunID
+	}
+
+	batch.Query(templateCreateTransferTaskQuery,
+		d.shardID,
+		rowType,
+		rowDomainID,
+		rowWorkflowID,
+		rowRunID,
+		task.DomainID,
+		task.WorkflowID,
+		task.RunID,
+		taskID,
+		targetDomainID,
+		targetWorkflowID,
+		targetRunID,
+		task.TaskList,
+		task.TaskType,
+		task.ScheduleID,
+		task.VisibilityTimestamp,
+		taskID)
+}
+
+func (d *cassandraPersistence) GetTransferDLQTasks(ctx context.Context,
+	request *GetTransferDLQTasksRequest) (*GetTransferDLQTasksResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, rangeScanOperation, "GetTransferDLQTasks")
+	defer cancel()
+
+	query := d.session.Query(templateGetTransferDLQTasksQuery,
+		d.shardID,
+		rowTypeTransferDLQTask,
+		rowTypeTransferDLQDomainID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		request.ReadLevel,
+		request.BatchSize).WithContext(ctx)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetTransferDLQTasks operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &GetTransferDLQTasksResponse{}
+	task := make(map[string]interface{})
+	for iter.MapScan(task) {
+		response.Tasks = append(response.Tasks, createTransferTaskInfo(task["transfer"].(map[string]interface{})))
+		task = make(map[string]interface{})
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetTransferDLQTasks operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "CompleteTimerTask")
+	defer cancel()
+
 	query := d.session.Query(templateCompleteTimerTaskQuery,
 		d.shardID,
 		rowTypeTimerTask,
 		rowTypeTimerDomainID,
-		rowTypeTimerWorkflowID,
+		timerQueueWorkflowID(request.Queue),
 		rowTypeTimerRunID,
-		request.TaskID)
+		request.TaskID).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -1036,8 +1377,43 @@ func (d *cassandraPersistence) CompleteTimerTask(request *CompleteTimerTaskReque
 	return nil
 }
 
+// executeBatchWithLease applies the batch only if the shard is still owned at the given rangeID, so a host which lost
+// the shard can never delete rows on behalf of the new owner
+func (d *cassandraPersistence) executeBatchWithLease(batch *gocql.Batch, rangeID int64, operation string) error {
+	batch.Query(templateUpdateLeaseQuery,
+		rangeID,
+		d.shardID,
+		rangeID,
+	)
+
+	previous := make(map[string]interface{})
+	applied, _, err := d.session.MapExecuteBatchCAS(batch, previous)
+	if err != nil {
+		if isTimeoutError(err) {
+			return &TimeoutError{Msg: fmt.Sprintf("%v timed out. Error: %v", operation, err)}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+		}
+	}
+
+	if !applied {
+		actualRangeID, _ := previous["range_id"].(int64)
+		return &ShardOwnershipLostError{
+			ShardID: d.shardID,
+			Msg: fmt.Sprintf("%v failed.  Request RangeID: %v, Actual RangeID: %v",
+				operation, rangeID, actualRangeID),
+		}
+	}
+
+	return nil
+}
+
 // From TaskManager interface
-func (d *cassandraPersistence) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+func (d *cassandraPersistence) LeaseTaskList(ctx context.Context, request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "LeaseTaskList")
+	defer cancel()
+
 	if len(request.TaskList) == 0 {
 		return nil, &workflow.InternalServiceError{
 			Message: fmt.Sprintf("LeaseTaskList requires non empty task list"),
@@ -1049,8 +1425,9 @@ func (d *cassandraPersistence) LeaseTaskList(request *LeaseTaskListRequest) (*Le
 		request.TaskType,
 		rowTypeTaskList,
 		taskListTaskID,
-	)
-	var rangeID, ackLevel int64
+	).WithContext(ctx)
+	var rangeID, ackLevel, highPriorityAckLevel int64
+	var pollers map[string]time.Time
 	var tlDB map[string]interface{}
 	err := query.Scan(&rangeID, &tlDB)
 	if err != nil {
@@ -1065,7 +1442,10 @@ func (d *cassandraPersistence) LeaseTaskList(request *LeaseTaskListRequest) (*Le
 				request.DomainID,
 				request.TaskList,
 				request.TaskType,
-				0)
+				0,
+				time.Now(),
+				nil,
+				0).WithContext(ctx)
 		} else {
 			return nil, &workflow.InternalServiceError{
 				Message: fmt.Sprintf("LeaseTaskList operation failed. TaskList: %v, TaskType: %v, Error : %v",
@@ -1074,20 +1454,40 @@ func (d *cassandraPersistence) LeaseTaskList(request *LeaseTaskListRequest) (*Le
 		}
 	} else {
 		ackLevel = tlDB["ack_level"].(int64)
+		tli := createTaskListInfo(tlDB)
+		pollers = tli.Pollers
+		highPriorityAckLevel = tli.HighPriorityAckLevel
 		query = d.session.Query(templateUpdateTaskListQuery,
 			rangeID+1,
 			request.DomainID,
 			&request.TaskList,
 			request.TaskType,
 			ackLevel,
+			time.Now(),
+			pollers,
+			highPriorityAckLevel,
 			request.DomainID,
 			&request.TaskList,
 			request.TaskType,
 			rowTypeTaskList,
 			taskListTaskID,
 			rangeID,
-		)
+		).WithContext(ctx)
 	}
+
+	// Every lease registers the task list with its domain, which also registers the task lists created before
+	// task_lists_by_domain was added the next time they are loaded
+	err = d.session.Query(templateInsertTaskListByDomainQuery,
+		request.DomainID,
+		request.TaskList,
+		request.TaskType,
+	).WithContext(ctx).Exec()
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("LeaseTaskList operation failed. Error : %v", err),
+		}
+	}
+
 	previous := make(map[string]interface{})
 	applied, err := query.MapScanCAS(previous)
 	if err != nil {
@@ -1101,12 +1501,125 @@ func (d *cassandraPersistence) LeaseTaskList(request *LeaseTaskListRequest) (*Le
 			Msg: fmt.Sprintf("LeaseTaskList failed to apply. db rangeID %v", previousRangeID),
 		}
 	}
-	tli := &TaskListInfo{Name: request.TaskList, TaskType: request.TaskType, RangeID: rangeID + 1, AckLevel: ackLevel}
+	tli := &TaskListInfo{Name: request.TaskList, TaskType: request.TaskType, RangeID: rangeID + 1, AckLevel: ackLevel,
+		Pollers: pollers, HighPriorityAckLevel: highPriorityAckLevel}
 	return &LeaseTaskListResponse{TaskListInfo: tli}, nil
 }
 
 // From TaskManager interface
-func (d *cassandraPersistence) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
+func (d *cassandraPersistence) ListTaskLists(ctx context.Context, request *ListTaskListsRequest) (*ListTaskListsResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, rangeScanOperation, "ListTaskLists")
+	defer cancel()
+
+	var query *gocql.Query
+	if len(request.DomainID) > 0 {
+		query = d.session.Query(templateListDomainTaskListsQuery, request.DomainID)
+	} else {
+		query = d.session.Query(templateListTaskListsQuery)
+	}
+	query = query.WithContext(ctx).Consistency(d.getReadConsistency())
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListTaskLists operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	type taskListKey struct {
+		domainID string
+		name     string
+		taskType int
+	}
+	var keys []taskListKey
+	var domainID gocql.UUID
+	var name string
+	var taskType int
+	for iter.Scan(&domainID, &name, &taskType) {
+		keys = append(keys, taskListKey{domainID: domainID.String(), name: name, taskType: taskType})
+	}
+
+	response := &ListTaskListsResponse{}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListTaskLists operation failed. Error: %v", err),
+		}
+	}
+
+	for _, key := range keys {
+		var rangeID int64
+		var tlDB map[string]interface{}
+		err := d.session.Query(templateGetTaskList,
+			key.domainID,
+			key.name,
+			key.taskType,
+			rowTypeTaskList,
+			taskListTaskID,
+		).WithContext(ctx).Consistency(d.getReadConsistency()).Scan(&rangeID, &tlDB)
+		if err != nil {
+			if err == gocql.ErrNotFound {
+				// registered by a lease which did not apply, or left over by a delete which failed half way
+				continue
+			}
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListTaskLists operation failed. Error: %v", err),
+			}
+		}
+		tli := createTaskListInfo(tlDB)
+		tli.RangeID = rangeID
+		response.TaskLists = append(response.TaskLists, tli)
+	}
+
+	return response, nil
+}
+
+// From TaskManager interface
+func (d *cassandraPersistence) DeleteTaskList(ctx context.Context, request *DeleteTaskListRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "DeleteTaskList")
+	defer cancel()
+
+	query := d.session.Query(templateDeleteTaskListQuery,
+		request.DomainID,
+		request.TaskList,
+		request.TaskType,
+		request.RangeID,
+	).WithContext(ctx)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+		}
+	}
+	if !applied {
+		return &ConditionFailedError{
+			Msg: fmt.Sprintf("DeleteTaskList failed to apply. TaskList: %v, TaskType: %v, rangeID: %v, db rangeID: %v",
+				request.TaskList, request.TaskType, request.RangeID, previous["range_id"]),
+		}
+	}
+
+	err = d.session.Query(templateDeleteTaskListByDomainQuery,
+		request.DomainID,
+		request.TaskList,
+		request.TaskType,
+	).WithContext(ctx).Exec()
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+// From TaskManager interface
+func (d *cassandraPersistence) UpdateTaskList(ctx context.Context, request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "UpdateTaskList")
+	defer cancel()
+
 	tli := request.TaskListInfo
 
 	query := d.session.Query(templateUpdateTaskListQuery,
@@ -1115,13 +1628,16 @@ func (d *cassandraPersistence) UpdateTaskList(request *UpdateTaskListRequest) (*
 		&tli.Name,
 		tli.TaskType,
 		tli.AckLevel,
+		time.Now(),
+		tli.Pollers,
+		tli.HighPriorityAckLevel,
 		tli.DomainID,
 		&tli.Name,
 		tli.TaskType,
 		rowTypeTaskList,
 		taskListTaskID,
 		tli.RangeID,
-	)
+	).WithContext(ctx)
 
 	previous := make(map[string]interface{})
 	applied, err := query.MapScanCAS(previous)
@@ -1147,36 +1663,51 @@ func (d *cassandraPersistence) UpdateTaskList(request *UpdateTaskListRequest) (*
 }
 
 // From TaskManager interface
-func (d *cassandraPersistence) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
-	batch := d.session.NewBatch(gocql.LoggedBatch)
+func (d *cassandraPersistence) CreateTasks(ctx context.Context, request *CreateTasksRequest) (*CreateTasksResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "CreateTasks")
+	defer cancel()
+
+	batch := d.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 	domainID := request.DomainID
 	taskList := request.TaskList
 	taskListType := request.TaskListType
 
 	for _, task := range request.Tasks {
 		scheduleID := task.Data.ScheduleID
+		rowType := rowTypeTask
+		if task.Data.Priority > 0 {
+			rowType = rowTypeHighPriorityTask
+		}
 		if task.Data.ScheduleToStartTimeout == 0 {
 			batch.Query(templateCreateTaskQuery,
 				domainID,
 				taskList,
 				taskListType,
-				rowTypeTask,
+				rowType,
 				task.TaskID,
 				domainID,
 				task.Execution.GetWorkflowId(),
 				task.Execution.GetRunId(),
-				scheduleID)
+				scheduleID,
+				task.Data.BuildID,
+				task.Data.Priority,
+				task.Data.SessionID,
+				task.Data.Payload)
 		} else {
 			batch.Query(templateCreateTaskWithTTLQuery,
 				domainID,
 				taskList,
 				taskListType,
-				rowTypeTask,
+				rowType,
 				task.TaskID,
 				domainID,
 				task.Execution.GetWorkflowId(),
 				task.Execution.GetRunId(),
 				scheduleID,
+				task.Data.BuildID,
+				task.Data.Priority,
+				task.Data.SessionID,
+				task.Data.Payload,
 				task.Data.ScheduleToStartTimeout)
 		}
 	}
@@ -1208,20 +1739,27 @@ func (d *cassandraPersistence) CreateTasks(request *CreateTasksRequest) (*Create
 }
 
 // From TaskManager interface
-func (d *cassandraPersistence) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
+func (d *cassandraPersistence) GetTasks(ctx context.Context, request *GetTasksRequest) (*GetTasksResponse, error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, rangeScanOperation, "GetTasks")
+	defer cancel()
+
 	if request.ReadLevel > request.MaxReadLevel {
 		return &GetTasksResponse{}, nil
 	}
 
+	rowType := rowTypeTask
+	if request.HighPriority {
+		rowType = rowTypeHighPriorityTask
+	}
 	// Reading tasklist tasks need to be quorum level consistent, otherwise we could loose task
 	query := d.session.Query(templateGetTasksQuery,
 		request.DomainID,
 		request.TaskList,
 		request.TaskType,
-		rowTypeTask,
+		rowType,
 		request.ReadLevel,
 		request.MaxReadLevel,
-		request.BatchSize)
+		request.BatchSize).WithContext(ctx)
 
 	iter := query.Iter()
 	if iter == nil {
@@ -1257,14 +1795,21 @@ PopulateTasks:
 }
 
 // From TaskManager interface
-func (d *cassandraPersistence) CompleteTask(request *CompleteTaskRequest) error {
+func (d *cassandraPersistence) CompleteTask(ctx context.Context, request *CompleteTaskRequest) error {
+	ctx, cancel := d.timeouts.withTimeout(ctx, writeOperation, "CompleteTask")
+	defer cancel()
+
 	tli := request.TaskList
+	rowType := rowTypeTask
+	if request.HighPriority {
+		rowType = rowTypeHighPriorityTask
+	}
 	query := d.session.Query(templateCompleteTaskQuery,
 		tli.DomainID,
 		tli.Name,
 		tli.TaskType,
-		rowTypeTask,
-		request.TaskID)
+		rowType,
+		request.TaskID).WithContext(ctx)
 
 	err := query.Exec()
 	if err != nil {
@@ -1276,18 +1821,32 @@ func (d *cassandraPersistence) CompleteTask(request *CompleteTaskRequest) error
 	return nil
 }
 
-func (d *cassandraPersistence) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse,
+func (d *cassandraPersistence) GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse,
 	error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, rangeScanOperation, "GetTimerIndexTasks")
+	defer cancel()
+
 	// Reading timer tasks need to be quorum level consistent, otherwise we could loose task
-	query := d.session.Query(templateGetTimerTasksQuery,
-		d.shardID,
-		rowTypeTimerTask,
-		rowTypeTimerDomainID,
-		rowTypeTimerWorkflowID,
-		rowTypeTimerRunID,
-		request.MinKey,
-		request.MaxKey,
-		request.BatchSize)
+	var query *gocql.Query
+	if len(request.TaskIDs) > 0 {
+		query = d.session.Query(templateGetTimerTasksByIDQuery,
+			d.shardID,
+			rowTypeTimerTask,
+			rowTypeTimerDomainID,
+			timerQueueWorkflowID(request.Queue),
+			rowTypeTimerRunID,
+			request.TaskIDs).WithContext(ctx)
+	} else {
+		query = d.session.Query(templateGetTimerTasksQuery,
+			d.shardID,
+			rowTypeTimerTask,
+			rowTypeTimerDomainID,
+			timerQueueWorkflowID(request.Queue),
+			rowTypeTimerRunID,
+			request.MinKey,
+			request.MaxKey,
+			request.BatchSize).WithContext(ctx)
+	}
 
 	iter := query.Iter()
 	if iter == nil {
@@ -1315,6 +1874,44 @@ func (d *cassandraPersistence) GetTimerIndexTasks(request *GetTimerIndexTasksReq
 	return response, nil
 }
 
+func (d *cassandraPersistence) ListExecutions(ctx context.Context, request *ListExecutionsRequest) (*ListExecutionsResponse,
+	error) {
+	ctx, cancel := d.timeouts.withTimeout(ctx, rangeScanOperation, "ListExecutions")
+	defer cancel()
+
+	query := d.session.Query(templateListExecutionsQuery,
+		d.shardID,
+		rowTypeExecution).WithContext(ctx)
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListExecutions operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &ListExecutionsResponse{}
+	var runID gocql.UUID
+	var execution map[string]interface{}
+	for iter.Scan(&runID, &execution) {
+		// Rows pointing to the current run of a workflow don't hold any mutable state
+		if runID.String() != permanentRunID {
+			response.Executions = append(response.Executions, createWorkflowExecutionInfo(execution))
+		}
+		execution = nil
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListExecutions operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
 func (d *cassandraPersistence) createTransferTasks(batch *gocql.Batch, transferTasks []Task, domainID, workflowID,
 	runID string, cqlNowTimestamp int64) {
 	targetDomainID := domainID
@@ -1345,6 +1942,9 @@ func (d *cassandraPersistence) createTransferTasks(batch *gocql.Batch, transferT
 			targetDomainID = task.(*StartChildExecutionTask).TargetDomainID
 			targetWorkflowID = task.(*StartChildExecutionTask).TargetWorkflowID
 			scheduleID = task.(*StartChildExecutionTask).InitiatedID
+
+		case TransferTaskTypeActivityTaskContinuation:
+			scheduleID = task.(*ActivityTaskContinuationTask).ScheduleID
 		}
 
 		batch.Query(templateCreateTransferTaskQuery,
@@ -1363,6 +1963,7 @@ func (d *cassandraPersistence) createTransferTasks(batch *gocql.Batch, transferT
 			taskList,
 			task.GetType(),
 			scheduleID,
+			cqlNowTimestamp,
 			task.GetTaskID())
 	}
 }
@@ -1378,6 +1979,7 @@ func (d *cassandraPersistence) createTimerTasks(batch *gocql.Batch, timerTasks [
 		switch task.GetType() {
 		case TaskTypeDecisionTimeout:
 			eventID = task.(*DecisionTimeoutTask).EventID
+			timeoutType = task.(*DecisionTimeoutTask).TimeoutType
 
 		case TaskTypeActivityTimeout:
 			eventID = task.(*ActivityTimeoutTask).EventID
@@ -1385,13 +1987,19 @@ func (d *cassandraPersistence) createTimerTasks(batch *gocql.Batch, timerTasks [
 
 		case TaskTypeUserTimer:
 			eventID = task.(*UserTimerTask).EventID
+
+		case TaskTypeFirstDecisionBackoff:
+			eventID = task.(*FirstDecisionBackoffTask).EventID
+
+		case TaskTypeDecisionRetryBackoff:
+			eventID = task.(*DecisionRetryBackoffTask).EventID
 		}
 
 		batch.Query(templateCreateTimerTaskQuery,
 			d.shardID,
 			rowTypeTimerTask,
 			rowTypeTimerDomainID,
-			rowTypeTimerWorkflowID,
+			timerQueueWorkflowID(GetTimerQueue(task.GetType())),
 			rowTypeTimerRunID,
 			domainID,
 			workflowID,
@@ -1408,7 +2016,7 @@ func (d *cassandraPersistence) createTimerTasks(batch *gocql.Batch, timerTasks [
 			d.shardID,
 			rowTypeTimerTask,
 			rowTypeTimerDomainID,
-			rowTypeTimerWorkflowID,
+			timerQueueWorkflowID(GetTimerQueue(deleteTimerTask.GetType())),
 			rowTypeTimerRunID,
 			deleteTimerTask.GetTaskID())
 	}
@@ -1424,6 +2032,7 @@ func (d *cassandraPersistence) updateActivityInfos(batch *gocql.Batch, activityI
 			a.ScheduledEvent,
 			a.StartedID,
 			a.StartedEvent,
+			a.StartedTime,
 			a.ActivityID,
 			a.RequestID,
 			a.Details,
@@ -1434,6 +2043,9 @@ func (d *cassandraPersistence) updateActivityInfos(batch *gocql.Batch, activityI
 			a.CancelRequested,
 			a.CancelRequestID,
 			a.LastHeartBeatUpdatedTime,
+			a.Priority,
+			a.SessionID,
+			a.DispatchDeferred,
 			d.shardID,
 			rowTypeExecution,
 			domainID,
@@ -1528,6 +2140,99 @@ func (d *cassandraPersistence) updateChildExecutionInfos(batch *gocql.Batch, chi
 	}
 }
 
+func (d *cassandraPersistence) updateRequestCancelInfos(batch *gocql.Batch, requestCancelInfos []*RequestCancelInfo,
+	deleteInfo *int64, domainID, workflowID, runID string, condition int64, rangeID int64) {
+
+	for _, c := range requestCancelInfos {
+		batch.Query(templateUpdateRequestCancelInfoQuery,
+			c.InitiatedID,
+			c.InitiatedID,
+			c.CancelRequestID,
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			rowTypeExecutionTaskID,
+			condition,
+			rangeID)
+	}
+
+	// deleteInfo is the initiatedID for RequestCancelInfo being deleted
+	if deleteInfo != nil {
+		batch.Query(templateDeleteRequestCancelInfoQuery,
+			*deleteInfo,
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			rowTypeExecutionTaskID,
+			condition,
+			rangeID)
+	}
+}
+
+func (d *cassandraPersistence) updateBufferedEvents(batch *gocql.Batch, newBufferedEvents *SerializedHistoryEventBatch,
+	clearBufferedEvents bool, domainID, workflowID, runID string, condition int64, rangeID int64) {
+
+	if clearBufferedEvents {
+		batch.Query(templateDeleteBufferedEventsQuery,
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			rowTypeExecutionTaskID,
+			condition,
+			rangeID)
+	} else if newBufferedEvents != nil {
+		batch.Query(templateAppendBufferedEventsQuery,
+			string(newBufferedEvents.EncodingType),
+			newBufferedEvents.Version,
+			newBufferedEvents.Data,
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			rowTypeExecutionTaskID,
+			condition,
+			rangeID)
+	}
+}
+
+func (d *cassandraPersistence) updateSignalReceipts(batch *gocql.Batch, signalReceipts map[string]int64,
+	deleteSignalReceipts []string, domainID, workflowID, runID string, condition int64, rangeID int64) {
+
+	for requestID, eventID := range signalReceipts {
+		batch.Query(templateUpdateSignalReceiptQuery,
+			requestID,
+			eventID,
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			rowTypeExecutionTaskID,
+			condition,
+			rangeID)
+	}
+
+	for _, requestID := range deleteSignalReceipts {
+		batch.Query(templateDeleteSignalReceiptQuery,
+			requestID,
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			rowTypeExecutionTaskID,
+			condition,
+			rangeID)
+	}
+}
+
 func createShardInfo(result map[string]interface{}) *ShardInfo {
 	info := &ShardInfo{}
 	for k, v := range result {
@@ -1544,6 +2249,12 @@ func createShardInfo(result map[string]interface{}) *ShardInfo {
 			info.UpdatedAt = v.(time.Time)
 		case "transfer_ack_level":
 			info.TransferAckLevel = v.(int64)
+		case "timer_ack_level":
+			info.TimerAckLevel = v.(int64)
+		case "timer_ack_levels":
+			info.TimerAckLevels = v.(map[string]int64)
+		case "queue_paused":
+			info.QueuePaused = v.(map[string]bool)
 		}
 	}
 
@@ -1600,6 +2311,22 @@ func createWorkflowExecutionInfo(result map[string]interface{}) *WorkflowExecuti
 			info.DecisionRequestID = v.(string)
 		case "decision_timeout":
 			info.DecisionTimeout = int32(v.(int))
+		case "decision_attempt":
+			info.DecisionAttempt = v.(int64)
+		case "decision_scheduled_time":
+			info.DecisionScheduledTime = v.(time.Time)
+		case "decision_started_time":
+			info.DecisionStartedTime = v.(time.Time)
+		case "build_id":
+			info.BuildID = v.(string)
+		case "signal_count":
+			info.SignalCount = v.(int64)
+		case "activity_count":
+			info.ActivityCount = v.(int64)
+		case "timer_count":
+			info.TimerCount = v.(int64)
+		case "decision_count":
+			info.DecisionCount = v.(int64)
 		}
 	}
 
@@ -1630,6 +2357,8 @@ func createTransferTaskInfo(result map[string]interface{}) *TransferTaskInfo {
 			info.TaskType = v.(int)
 		case "schedule_id":
 			info.ScheduleID = v.(int64)
+		case "visibility_ts":
+			info.VisibilityTimestamp = v.(time.Time)
 		}
 	}
 
@@ -1648,6 +2377,8 @@ func createActivityInfo(result map[string]interface{}) *ActivityInfo {
 			info.StartedID = v.(int64)
 		case "started_event":
 			info.StartedEvent = v.([]byte)
+		case "started_time":
+			info.StartedTime = v.(time.Time)
 		case "activity_id":
 			info.ActivityID = v.(string)
 		case "request_id":
@@ -1668,6 +2399,12 @@ func createActivityInfo(result map[string]interface{}) *ActivityInfo {
 			info.CancelRequestID = v.(int64)
 		case "last_hb_updated_time":
 			info.LastHeartBeatUpdatedTime = v.(time.Time)
+		case "priority":
+			info.Priority = int32(v.(int))
+		case "session_id":
+			info.SessionID = v.(string)
+		case "dispatch_deferred":
+			info.DispatchDeferred = v.(bool)
 		}
 	}
 
@@ -1711,6 +2448,36 @@ func createChildExecutionInfo(result map[string]interface{}) *ChildExecutionInfo
 	return info
 }
 
+func createSerializedHistoryEventBatch(result map[string]interface{}) *SerializedHistoryEventBatch {
+	batch := &SerializedHistoryEventBatch{}
+	for k, v := range result {
+		switch k {
+		case "encoding_type":
+			batch.EncodingType = common.EncodingType(v.(string))
+		case "version":
+			batch.Version = v.(int)
+		case "data":
+			batch.Data = v.([]byte)
+		}
+	}
+
+	return batch
+}
+
+func createRequestCancelInfo(result map[string]interface{}) *RequestCancelInfo {
+	info := &RequestCancelInfo{}
+	for k, v := range result {
+		switch k {
+		case "initiated_id":
+			info.InitiatedID = v.(int64)
+		case "cancel_request_id":
+			info.CancelRequestID = v.(gocql.UUID).String()
+		}
+	}
+
+	return info
+}
+
 func createTaskInfo(result map[string]interface{}) *TaskInfo {
 	info := &TaskInfo{}
 	for k, v := range result {
@@ -1723,12 +2490,53 @@ func createTaskInfo(result map[string]interface{}) *TaskInfo {
 			info.RunID = v.(gocql.UUID).String()
 		case "schedule_id":
 			info.ScheduleID = v.(int64)
+		case "build_id":
+			info.BuildID = v.(string)
+		case "priority":
+			info.Priority = int32(v.(int))
+		case "session_id":
+			info.SessionID = v.(string)
+		case "payload":
+			info.Payload = v.([]byte)
 		}
 	}
 
 	return info
 }
 
+func createTaskListInfo(result map[string]interface{}) *TaskListInfo {
+	info := &TaskListInfo{}
+	for k, v := range result {
+		switch k {
+		case "domain_id":
+			info.DomainID = v.(gocql.UUID).String()
+		case "name":
+			info.Name = v.(string)
+		case "type":
+			info.TaskType = v.(int)
+		case "ack_level":
+			info.AckLevel = v.(int64)
+		case "last_updated":
+			info.LastUpdated = v.(time.Time)
+		case "pollers":
+			info.Pollers, _ = v.(map[string]time.Time)
+		case "high_priority_ack_level":
+			info.HighPriorityAckLevel, _ = v.(int64)
+		}
+	}
+
+	return info
+}
+
+// timerQueueWorkflowID returns the workflow_id of the timer task rows of the given timer queue.  Timers written before
+// the timer queues were split are all kept under the one of the active queue, which fires them whatever their type.
+func timerQueueWorkflowID(queue int) string {
+	if queue == TimerQueueCron {
+		return rowTypeCronTimerWorkflowID
+	}
+	return rowTypeTimerWorkflowID
+}
+
 func createTimerTaskInfo(result map[string]interface{}) *TimerTaskInfo {
 	info := &TimerTaskInfo{}
 	for k, v := range result {
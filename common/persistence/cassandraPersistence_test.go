@@ -30,6 +30,7 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
 
 	gen "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
@@ -84,9 +85,11 @@ func (s *cassandraPersistenceSuite) TestPersistenceStartWorkflow() {
 	startedErr, ok := err1.(*gen.WorkflowExecutionAlreadyStartedError)
 	s.True(ok)
 	s.Equal(workflowExecution.GetRunId(), startedErr.GetRunId(), startedErr.GetMessage())
+	s.True(startedErr.IsSetStartTime())
+	s.WithinDuration(time.Now(), time.Unix(0, startedErr.GetStartTime()), time.Minute)
 	s.Empty(task1, "Expected empty task identifier.")
 
-	response, err2 := s.WorkflowMgr.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{
+	response, err2 := s.WorkflowMgr.CreateWorkflowExecution(context.Background(), &CreateWorkflowExecutionRequest{
 		RequestID:            uuid.New(),
 		DomainID:             domainID,
 		Execution:            workflowExecution,
@@ -177,12 +180,18 @@ func (s *cassandraPersistenceSuite) TestUpdateWorkflow() {
 	s.Equal(int64(2), info0.DecisionScheduleID)
 	s.Equal(common.EmptyEventID, info0.DecisionStartedID)
 	s.Equal(int32(1), info0.DecisionTimeout)
+	s.Equal(int64(0), info0.SignalCount)
+	s.Equal(int64(0), info0.DecisionCount)
 
 	log.Infof("Workflow execution last updated: %v", info0.LastUpdatedTimestamp)
 
 	updatedInfo := copyWorkflowExecutionInfo(info0)
 	updatedInfo.NextEventID = int64(5)
 	updatedInfo.LastProcessedEvent = int64(2)
+	updatedInfo.SignalCount = int64(1)
+	updatedInfo.ActivityCount = int64(2)
+	updatedInfo.TimerCount = int64(3)
+	updatedInfo.DecisionCount = int64(4)
 	err2 := s.UpdateWorkflowExecution(updatedInfo, []int64{int64(4)}, nil, int64(3), nil, nil, nil, nil, nil, nil)
 	s.Nil(err2, "No error expected.")
 
@@ -204,6 +213,10 @@ func (s *cassandraPersistenceSuite) TestUpdateWorkflow() {
 	s.Equal(int64(2), info1.DecisionScheduleID)
 	s.Equal(common.EmptyEventID, info1.DecisionStartedID)
 	s.Equal(int32(1), info1.DecisionTimeout)
+	s.Equal(int64(1), info1.SignalCount)
+	s.Equal(int64(2), info1.ActivityCount)
+	s.Equal(int64(3), info1.TimerCount)
+	s.Equal(int64(4), info1.DecisionCount)
 
 	log.Infof("Workflow execution last updated: %v", info1.LastUpdatedTimestamp)
 
@@ -297,6 +310,13 @@ func (s *cassandraPersistenceSuite) TestDeleteWorkflow() {
 
 	log.Infof("Workflow execution last updated: %v", info0.LastUpdatedTimestamp)
 
+	err6 := s.DeleteWorkflowExecutionWithRangeID(info0, s.ShardContext.GetRangeID()-1)
+	s.NotNil(err6, "expected non nil error.")
+	s.IsType(&ShardOwnershipLostError{}, err6)
+
+	_, err7 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err7, "No error expected.")
+
 	err4 := s.DeleteWorkflowExecution(info0)
 	s.Nil(err4, "No error expected.")
 
@@ -381,6 +401,82 @@ func (s *cassandraPersistenceSuite) TestTransferTasks() {
 	s.Nil(err4)
 }
 
+func (s *cassandraPersistenceSuite) TestTransferDLQTasks() {
+	domainID := "0b7fd4f8-0a4e-4b8d-9b3c-3d2f1c9a7e55"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("transfer-dlq-tasks-test"),
+		RunId:      common.StringPtr("6e6f1c37-2f7a-4b0e-8a51-6a0c5d8e9f12"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 13, nil, 3, 0, 2, nil)
+	s.Nil(err0, "No error expected.")
+	s.NotEmpty(task0, "Expected non empty task identifier.")
+
+	tasks, err1 := s.GetTransferTasks(1)
+	s.Nil(err1, "No error expected.")
+	s.Equal(1, len(tasks), "Expected 1 decision task.")
+	task := tasks[0]
+
+	err2 := s.WorkflowMgr.PutTransferDLQTask(context.Background(), &PutTransferDLQTaskRequest{
+		TaskInfo: task,
+		RangeID:  s.ShardContext.GetRangeID() - 1,
+	})
+	s.IsType(&ShardOwnershipLostError{}, err2)
+
+	err3 := s.ShardContext.PutTransferDLQTask(context.Background(), &PutTransferDLQTaskRequest{TaskInfo: task})
+	s.Nil(err3)
+	s.Nil(s.CompleteTransferTask(task.TaskID))
+
+	response, err4 := s.WorkflowMgr.GetTransferDLQTasks(context.Background(), &GetTransferDLQTasksRequest{
+		ReadLevel: task.TaskID - 1,
+		BatchSize: 10,
+	})
+	s.Nil(err4)
+	s.Equal(1, len(response.Tasks))
+	parked := response.Tasks[0]
+	s.Equal(task.TaskID, parked.TaskID)
+	s.Equal(domainID, parked.DomainID)
+	s.Equal(workflowExecution.GetWorkflowId(), parked.WorkflowID)
+	s.Equal(workflowExecution.GetRunId(), parked.RunID)
+	s.Equal("queue1", parked.TaskList)
+	s.Equal(TransferTaskTypeDecisionTask, parked.TaskType)
+	s.Equal(int64(2), parked.ScheduleID)
+
+	// Parked tasks are not read from the transfer queue
+	tasks, err5 := s.GetTransferTasks(1)
+	s.Nil(err5)
+	s.Empty(tasks)
+
+	err6 := s.WorkflowMgr.RedriveTransferDLQTask(context.Background(), &RedriveTransferDLQTaskRequest{
+		TaskInfo:  parked,
+		NewTaskID: task.TaskID + 1,
+		RangeID:   s.ShardContext.GetRangeID() - 1,
+	})
+	s.IsType(&ShardOwnershipLostError{}, err6)
+
+	// Redriven tasks move back to the transfer queue under a new task ID
+	err7 := s.ShardContext.RedriveTransferDLQTask(context.Background(), &RedriveTransferDLQTaskRequest{TaskInfo: parked})
+	s.Nil(err7)
+	tasks, err8 := s.GetTransferTasks(1)
+	s.Nil(err8)
+	s.Equal(1, len(tasks))
+	redriven := tasks[0]
+	s.True(redriven.TaskID > task.TaskID)
+	s.Equal(domainID, redriven.DomainID)
+	s.Equal(workflowExecution.GetWorkflowId(), redriven.WorkflowID)
+	s.Equal(workflowExecution.GetRunId(), redriven.RunID)
+	s.Equal("queue1", redriven.TaskList)
+	s.Equal(TransferTaskTypeDecisionTask, redriven.TaskType)
+	s.Equal(int64(2), redriven.ScheduleID)
+
+	response, err9 := s.WorkflowMgr.GetTransferDLQTasks(context.Background(), &GetTransferDLQTasksRequest{
+		ReadLevel: task.TaskID - 1,
+		BatchSize: 10,
+	})
+	s.Nil(err9)
+	s.Empty(response.Tasks)
+}
+
 func (s *cassandraPersistenceSuite) TestTransferTasksThroughUpdate() {
 	domainID := "b785a8ba-bd7d-4760-bb05-41b115f3e10a"
 	workflowExecution := gen.WorkflowExecution{
@@ -598,10 +694,90 @@ func (s *cassandraPersistenceSuite) TestCompleteDecisionTask() {
 	}
 }
 
+func (s *cassandraPersistenceSuite) TestHighPriorityTasks() {
+	domainID := "8a4d4c3e-5c0f-4a4b-9a36-4b8b2b7f2a11"
+	workflowExecution := gen.WorkflowExecution{WorkflowId: common.StringPtr("high-priority-task-test"),
+		RunId: common.StringPtr("5c1b3f7a-2e8d-4a43-b7a6-0d4e5f6a7b8c")}
+	taskList := "0d4e5f6a7b8c"
+	response, err := s.TaskMgr.LeaseTaskList(context.Background(), &LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: TaskListTypeActivity,
+	})
+	s.NoError(err)
+	rangeID := response.TaskListInfo.RangeID
+
+	var tasks []*CreateTaskInfo
+	for priority := int32(0); priority < 2; priority++ {
+		taskID := s.GetNextSequenceNumber()
+		tasks = append(tasks, &CreateTaskInfo{
+			TaskID:    taskID,
+			Execution: workflowExecution,
+			Data: &TaskInfo{
+				DomainID:   domainID,
+				WorkflowID: workflowExecution.GetWorkflowId(),
+				RunID:      workflowExecution.GetRunId(),
+				TaskID:     taskID,
+				ScheduleID: int64(10 + priority),
+				Priority:   priority,
+			},
+		})
+	}
+	_, err = s.TaskMgr.CreateTasks(context.Background(), &CreateTasksRequest{
+		DomainID:     domainID,
+		TaskList:     taskList,
+		TaskListType: TaskListTypeActivity,
+		Tasks:        tasks,
+		RangeID:      rangeID,
+	})
+	s.NoError(err)
+
+	// high priority tasks are read apart from the others
+	for _, highPriority := range []bool{false, true} {
+		getResponse, err := s.TaskMgr.GetTasks(context.Background(), &GetTasksRequest{
+			DomainID:     domainID,
+			TaskList:     taskList,
+			TaskType:     TaskListTypeActivity,
+			BatchSize:    10,
+			RangeID:      rangeID,
+			MaxReadLevel: math.MaxInt64,
+			HighPriority: highPriority,
+		})
+		s.NoError(err)
+		s.Equal(1, len(getResponse.Tasks))
+		task := getResponse.Tasks[0]
+		s.Equal(highPriority, task.Priority > 0)
+
+		err = s.TaskMgr.CompleteTask(context.Background(), &CompleteTaskRequest{
+			TaskList: &TaskListInfo{
+				DomainID: domainID,
+				Name:     taskList,
+				TaskType: TaskListTypeActivity,
+				RangeID:  rangeID,
+			},
+			TaskID:       task.TaskID,
+			HighPriority: highPriority,
+		})
+		s.NoError(err)
+
+		getResponse, err = s.TaskMgr.GetTasks(context.Background(), &GetTasksRequest{
+			DomainID:     domainID,
+			TaskList:     taskList,
+			TaskType:     TaskListTypeActivity,
+			BatchSize:    10,
+			RangeID:      rangeID,
+			MaxReadLevel: math.MaxInt64,
+			HighPriority: highPriority,
+		})
+		s.NoError(err)
+		s.Empty(getResponse.Tasks)
+	}
+}
+
 func (s *cassandraPersistenceSuite) TestLeaseTaskList() {
 	domainID := "00136543-72ad-4615-b7e9-44bca9775b45"
 	taskList := "aaaaaaa"
-	response, err := s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+	response, err := s.TaskMgr.LeaseTaskList(context.Background(), &LeaseTaskListRequest{
 		DomainID: domainID,
 		TaskList: taskList,
 		TaskType: TaskListTypeActivity,
@@ -611,7 +787,7 @@ func (s *cassandraPersistenceSuite) TestLeaseTaskList() {
 	s.EqualValues(1, tli.RangeID)
 	s.EqualValues(0, tli.AckLevel)
 
-	response, err = s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+	response, err = s.TaskMgr.LeaseTaskList(context.Background(), &LeaseTaskListRequest{
 		DomainID: domainID,
 		TaskList: taskList,
 		TaskType: TaskListTypeActivity,
@@ -620,6 +796,100 @@ func (s *cassandraPersistenceSuite) TestLeaseTaskList() {
 	tli = response.TaskListInfo
 	s.EqualValues(2, tli.RangeID)
 	s.EqualValues(0, tli.AckLevel)
+	s.Empty(tli.Pollers)
+
+	lastPoll := time.Now().Truncate(time.Millisecond).UTC()
+	tli.DomainID = domainID
+	tli.Pollers = map[string]time.Time{"poller1": lastPoll}
+	tli.HighPriorityAckLevel = 5
+	_, err = s.TaskMgr.UpdateTaskList(context.Background(), &UpdateTaskListRequest{TaskListInfo: tli})
+	s.NoError(err)
+
+	response, err = s.TaskMgr.LeaseTaskList(context.Background(), &LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: TaskListTypeActivity,
+	})
+	s.NoError(err)
+	tli = response.TaskListInfo
+	s.EqualValues(3, tli.RangeID)
+	s.Equal(1, len(tli.Pollers))
+	s.True(lastPoll.Equal(tli.Pollers["poller1"]))
+	s.EqualValues(5, tli.HighPriorityAckLevel)
+}
+
+func (s *cassandraPersistenceSuite) TestListAndDeleteTaskList() {
+	domainID := "00136543-72ad-4615-b7e9-44bca9775b46"
+	taskList := "bbbbbbb"
+	response, err := s.TaskMgr.LeaseTaskList(context.Background(), &LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: TaskListTypeDecision,
+	})
+	s.NoError(err)
+	rangeID := response.TaskListInfo.RangeID
+
+	var found *TaskListInfo
+	var pageToken []byte
+	for found == nil {
+		listResponse, err := s.TaskMgr.ListTaskLists(context.Background(), &ListTaskListsRequest{
+			PageSize:      10,
+			NextPageToken: pageToken,
+		})
+		s.NoError(err)
+		for _, tli := range listResponse.TaskLists {
+			if tli.DomainID == domainID && tli.Name == taskList && tli.TaskType == TaskListTypeDecision {
+				found = tli
+			}
+		}
+		if len(listResponse.NextPageToken) == 0 {
+			break
+		}
+		pageToken = listResponse.NextPageToken
+	}
+	s.NotNil(found)
+	s.Equal(rangeID, found.RangeID)
+	s.True(time.Since(found.LastUpdated) < time.Minute)
+
+	listResponse, err := s.TaskMgr.ListTaskLists(context.Background(), &ListTaskListsRequest{
+		DomainID: domainID,
+		PageSize: 10,
+	})
+	s.NoError(err)
+	s.Equal(1, len(listResponse.TaskLists))
+	s.Equal(taskList, listResponse.TaskLists[0].Name)
+	s.Equal(rangeID, listResponse.TaskLists[0].RangeID)
+
+	err = s.TaskMgr.DeleteTaskList(context.Background(), &DeleteTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: TaskListTypeDecision,
+		RangeID:  rangeID - 1,
+	})
+	s.IsType(&ConditionFailedError{}, err)
+
+	err = s.TaskMgr.DeleteTaskList(context.Background(), &DeleteTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: TaskListTypeDecision,
+		RangeID:  rangeID,
+	})
+	s.NoError(err)
+
+	listResponse, err = s.TaskMgr.ListTaskLists(context.Background(), &ListTaskListsRequest{
+		DomainID: domainID,
+		PageSize: 10,
+	})
+	s.NoError(err)
+	s.Empty(listResponse.TaskLists)
+
+	response, err = s.TaskMgr.LeaseTaskList(context.Background(), &LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: TaskListTypeDecision,
+	})
+	s.NoError(err)
+	s.EqualValues(1, response.TaskListInfo.RangeID)
 }
 
 func (s *cassandraPersistenceSuite) TestTimerTasks() {
@@ -641,7 +911,7 @@ func (s *cassandraPersistenceSuite) TestTimerTasks() {
 	updatedInfo := copyWorkflowExecutionInfo(info0)
 	updatedInfo.NextEventID = int64(5)
 	updatedInfo.LastProcessedEvent = int64(2)
-	tasks := []Task{&DecisionTimeoutTask{1, 2}}
+	tasks := []Task{&DecisionTimeoutTask{TaskID: 1, EventID: 2}}
 	err2 := s.UpdateWorkflowExecution(updatedInfo, []int64{int64(4)}, nil, int64(3), tasks, nil, nil, nil, nil, nil)
 	s.Nil(err2, "No error expected.")
 
@@ -657,6 +927,109 @@ func (s *cassandraPersistenceSuite) TestTimerTasks() {
 	s.Empty(timerTasks2, "expected empty task list.")
 }
 
+func (s *cassandraPersistenceSuite) TestCompleteTimerTask() {
+	domainID := "0b4bdc8c-5f1d-4c32-8d5a-a2b1f3c5e6d7"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("complete-timer-task-test"),
+		RunId:      common.StringPtr("bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "taskList", "wType", 13, nil, 3, 0, 2, nil)
+	s.Nil(err0, "No error expected.")
+	s.NotEmpty(task0, "Expected non empty task identifier.")
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	info0 := state0.ExecutionInfo
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	updatedInfo.NextEventID = int64(5)
+	updatedInfo.LastProcessedEvent = int64(2)
+	tasks := []Task{&DecisionTimeoutTask{TaskID: 1, EventID: 2}}
+	err2 := s.UpdateWorkflowExecution(updatedInfo, []int64{int64(4)}, nil, int64(3), tasks, nil, nil, nil, nil, nil)
+	s.Nil(err2, "No error expected.")
+
+	getTimerTask := func() *TimerTaskInfo {
+		timerTasks, err := s.GetTimerIndexTasks(-1, math.MaxInt64)
+		s.Nil(err, "No error expected.")
+		for _, t := range timerTasks {
+			if t.WorkflowID == workflowExecution.GetWorkflowId() {
+				return t
+			}
+		}
+		return nil
+	}
+
+	timerTask := getTimerTask()
+	s.NotNil(timerTask, "expected valid timer task.")
+
+	err4 := s.CompleteTimerTask(timerTask.TaskID)
+	s.Nil(err4, "No error expected.")
+	s.Nil(getTimerTask(), "expected timer task to be completed.")
+}
+
+func (s *cassandraPersistenceSuite) TestTimerTasksOfQueues() {
+	domainID := "5c2e8a1f-3b4d-4e6f-9a7b-8c9d0e1f2a3b"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("timer-queues-test"),
+		RunId:      common.StringPtr("cccccccc-cccc-cccc-cccc-cccccccccccc"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "taskList", "wType", 13, nil, 3, 0, 2, nil)
+	s.Nil(err0, "No error expected.")
+	s.NotEmpty(task0, "Expected non empty task identifier.")
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	info0 := state0.ExecutionInfo
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	updatedInfo.NextEventID = int64(5)
+	updatedInfo.LastProcessedEvent = int64(2)
+	tasks := []Task{&DecisionTimeoutTask{TaskID: 1, EventID: 2}, &FirstDecisionBackoffTask{TaskID: 2, EventID: 1}}
+	err2 := s.UpdateWorkflowExecution(updatedInfo, []int64{int64(4)}, nil, int64(3), tasks, nil, nil, nil, nil, nil)
+	s.Nil(err2, "No error expected.")
+
+	getTimerTasks := func(queue int) []*TimerTaskInfo {
+		response, err := s.WorkflowMgr.GetTimerIndexTasks(context.Background(), &GetTimerIndexTasksRequest{
+			Queue: queue, MinKey: -1, MaxKey: math.MaxInt64, BatchSize: 10})
+		s.Nil(err, "No error expected.")
+		var timerTasks []*TimerTaskInfo
+		for _, t := range response.Timers {
+			if t.WorkflowID == workflowExecution.GetWorkflowId() {
+				timerTasks = append(timerTasks, t)
+			}
+		}
+		return timerTasks
+	}
+
+	// Each queue only reads the timer tasks of its own partition
+	activeTasks := getTimerTasks(TimerQueueActive)
+	s.Equal(1, len(activeTasks))
+	s.Equal(TaskTypeDecisionTimeout, activeTasks[0].TaskType)
+	cronTasks := getTimerTasks(TimerQueueCron)
+	s.Equal(1, len(cronTasks))
+	s.Equal(TaskTypeFirstDecisionBackoff, cronTasks[0].TaskType)
+
+	response, err3 := s.WorkflowMgr.GetTimerIndexTasks(context.Background(), &GetTimerIndexTasksRequest{
+		Queue: TimerQueueActive, TaskIDs: []int64{activeTasks[0].TaskID, cronTasks[0].TaskID}})
+	s.Nil(err3, "No error expected.")
+	s.Equal(1, len(response.Timers))
+	s.Equal(activeTasks[0].TaskID, response.Timers[0].TaskID)
+
+	err4 := s.WorkflowMgr.CompleteTimerTask(context.Background(), &CompleteTimerTaskRequest{
+		Queue: TimerQueueCron, TaskID: cronTasks[0].TaskID})
+	s.Nil(err4, "No error expected.")
+	s.Empty(getTimerTasks(TimerQueueCron), "expected empty task list.")
+	s.Equal(1, len(getTimerTasks(TimerQueueActive)))
+
+	// The timer tasks deleted along with an update are deleted from the partition of their queue
+	err5 := s.UpdateWorkflowExecution(updatedInfo, nil, nil, int64(5), nil,
+		&DecisionTimeoutTask{TaskID: activeTasks[0].TaskID}, nil, nil, nil, nil)
+	s.Nil(err5, "No error expected.")
+	s.Empty(getTimerTasks(TimerQueueActive), "expected empty task list.")
+}
+
 func (s *cassandraPersistenceSuite) TestWorkflowMutableState_Activities() {
 	domainID := "7fcf0aa9-e121-4292-bdad-0a75181b4aa3"
 	workflowExecution := gen.WorkflowExecution{
@@ -683,6 +1056,7 @@ func (s *cassandraPersistenceSuite) TestWorkflowMutableState_Activities() {
 			ScheduledEvent:           []byte("scheduled_event_1"),
 			StartedID:                2,
 			StartedEvent:             []byte("started_event_1"),
+			StartedTime:              currentTime,
 			ScheduleToCloseTimeout:   1,
 			ScheduleToStartTimeout:   2,
 			StartToCloseTimeout:      3,
@@ -703,6 +1077,7 @@ func (s *cassandraPersistenceSuite) TestWorkflowMutableState_Activities() {
 	s.Equal([]byte("scheduled_event_1"), ai.ScheduledEvent)
 	s.Equal(int64(2), ai.StartedID)
 	s.Equal([]byte("started_event_1"), ai.StartedEvent)
+	s.Equal(currentTime.Unix(), ai.StartedTime.Unix())
 	s.Equal(int32(1), ai.ScheduleToCloseTimeout)
 	s.Equal(int32(2), ai.ScheduleToStartTimeout)
 	s.Equal(int32(3), ai.StartToCloseTimeout)
@@ -824,6 +1199,137 @@ func (s *cassandraPersistenceSuite) TestWorkflowMutableState_ChildExecutions() {
 	s.Equal(0, len(state.ChildExecutionInfos))
 }
 
+func (s *cassandraPersistenceSuite) TestWorkflowMutableState_RequestCancel() {
+	domainID := "568b8d19-cf64-4604-9f7c-1aa1db8dd1b5"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-workflow-mutable-request-cancel-test"),
+		RunId:      common.StringPtr("87f96253-b925-426e-90db-aa4ee89b5aca"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "taskList", "wType", 13, nil, 3, 0, 2, nil)
+	s.Nil(err0, "No error expected.")
+	s.NotEmpty(task0, "Expected non empty task identifier.")
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	info0 := state0.ExecutionInfo
+	s.NotNil(info0, "Valid Workflow info expected.")
+	s.Equal(0, len(state0.RequestCancelInfos))
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	updatedInfo.NextEventID = int64(5)
+	updatedInfo.LastProcessedEvent = int64(2)
+	cancelRequestID := uuid.New()
+	requestCancelInfos := []*RequestCancelInfo{
+		{
+			InitiatedID:     1,
+			CancelRequestID: cancelRequestID,
+		}}
+	err2 := s.UpsertRequestCancelState(updatedInfo, int64(3), requestCancelInfos)
+	s.Nil(err2, "No error expected.")
+
+	state, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	s.NotNil(state, "expected valid state.")
+	s.Equal(1, len(state.RequestCancelInfos))
+	ri, ok := state.RequestCancelInfos[1]
+	s.True(ok)
+	s.NotNil(ri)
+	s.Equal(int64(1), ri.InitiatedID)
+	s.Equal(cancelRequestID, ri.CancelRequestID)
+
+	err2 = s.DeleteRequestCancelState(updatedInfo, int64(5), int64(1))
+	s.Nil(err2, "No error expected.")
+
+	state, err1 = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	s.NotNil(state, "expected valid state.")
+	s.Equal(0, len(state.RequestCancelInfos))
+}
+
+func (s *cassandraPersistenceSuite) TestWorkflowMutableState_BufferedEvents() {
+	domainID := "2d9c7f2e-7d9a-4a7e-a2bb-5c8d1e9b3f61"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-workflow-mutable-buffered-events-test"),
+		RunId:      common.StringPtr("4f6b1c2a-3e8d-4b7f-9a1e-6c5d2b8e7f30"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "taskList", "wType", 13, nil, 3, 0, 2, nil)
+	s.Nil(err0, "No error expected.")
+	s.NotEmpty(task0, "Expected non empty task identifier.")
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	info0 := state0.ExecutionInfo
+	s.NotNil(info0, "Valid Workflow info expected.")
+	s.Equal(0, len(state0.BufferedEvents))
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	for _, data := range []string{"batch1", "batch2"} {
+		batch := NewSerializedHistoryEventBatch([]byte(data), common.EncodingTypeJSON, 1)
+		err2 := s.UpdateBufferedEvents(updatedInfo, int64(3), batch, false)
+		s.Nil(err2, "No error expected.")
+	}
+
+	state, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	s.Equal(2, len(state.BufferedEvents))
+	s.Equal(common.EncodingTypeJSON, state.BufferedEvents[0].EncodingType)
+	s.Equal(1, state.BufferedEvents[0].Version)
+	s.Equal([]byte("batch1"), state.BufferedEvents[0].Data)
+	s.Equal([]byte("batch2"), state.BufferedEvents[1].Data)
+
+	updatedInfo.NextEventID = int64(5)
+	err2 := s.UpdateBufferedEvents(updatedInfo, int64(3), nil, true)
+	s.Nil(err2, "No error expected.")
+
+	state, err1 = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	s.Equal(0, len(state.BufferedEvents))
+}
+
+func (s *cassandraPersistenceSuite) TestWorkflowMutableState_SignalReceipts() {
+	domainID := "6a1e3c9b-52f4-4d0e-8b7a-91c2e4f5d603"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-workflow-mutable-signal-receipts-test"),
+		RunId:      common.StringPtr("b3d7e2a1-8c4f-4e69-a05b-2f71c9d8e4a2"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "taskList", "wType", 13, nil, 3, 0, 2, nil)
+	s.Nil(err0, "No error expected.")
+	s.NotEmpty(task0, "Expected non empty task identifier.")
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	info0 := state0.ExecutionInfo
+	s.NotNil(info0, "Valid Workflow info expected.")
+	s.Equal(0, len(state0.SignalReceipts))
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	err2 := s.UpdateSignalReceipts(updatedInfo, int64(3), map[string]int64{"signal-1": 3, "signal-2": 4}, nil)
+	s.Nil(err2, "No error expected.")
+
+	state, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	s.Equal(map[string]int64{"signal-1": 3, "signal-2": 4}, state.SignalReceipts)
+
+	updatedInfo.NextEventID = int64(6)
+	err2 = s.UpdateSignalReceipts(updatedInfo, int64(3), map[string]int64{"signal-3": 5}, nil)
+	s.Nil(err2, "No error expected.")
+
+	state, err1 = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	s.Equal(map[string]int64{"signal-1": 3, "signal-2": 4, "signal-3": 5}, state.SignalReceipts)
+
+	updatedInfo.NextEventID = int64(7)
+	err2 = s.UpdateSignalReceipts(updatedInfo, int64(6), map[string]int64{"signal-4": 6}, []string{"signal-1"})
+	s.Nil(err2, "No error expected.")
+
+	state, err1 = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	s.Equal(map[string]int64{"signal-2": 4, "signal-3": 5, "signal-4": 6}, state.SignalReceipts)
+}
+
 func (s *cassandraPersistenceSuite) TestWorkflowMutableStateInfo() {
 	domainID := "9ed8818b-3090-4160-9f21-c6b70e64d2dd"
 	workflowExecution := gen.WorkflowExecution{
@@ -923,5 +1429,9 @@ func copyWorkflowExecutionInfo(sourceInfo *WorkflowExecutionInfo) *WorkflowExecu
 		DecisionStartedID:    sourceInfo.DecisionStartedID,
 		DecisionRequestID:    sourceInfo.DecisionRequestID,
 		DecisionTimeout:      sourceInfo.DecisionTimeout,
+		SignalCount:          sourceInfo.SignalCount,
+		ActivityCount:        sourceInfo.ActivityCount,
+		TimerCount:           sourceInfo.TimerCount,
+		DecisionCount:        sourceInfo.DecisionCount,
 	}
 }
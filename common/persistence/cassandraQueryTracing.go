@@ -0,0 +1,153 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/uber-common/bark"
+
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	// slowQueryTracingPolicy wraps the host selection policy of a cluster to time every attempt of a query, and logs
+	// the attempts slower than the threshold. Bound values are never logged: the partition key is reported as a hash,
+	// so the slow queries hitting the same hot partition can still be grouped
+	slowQueryTracingPolicy struct {
+		gocql.HostSelectionPolicy
+		threshold     time.Duration
+		metricsClient metrics.Client
+		logger        bark.Logger
+	}
+
+	// tracedHost times the attempt of a query on a selected host, from its selection until it is marked
+	tracedHost struct {
+		gocql.SelectedHost
+		policy    *slowQueryTracingPolicy
+		query     gocql.ExecutableQuery
+		startTime time.Time
+	}
+)
+
+var _ gocql.HostSelectionPolicy = (*slowQueryTracingPolicy)(nil)
+
+func newSlowQueryTracingPolicy(policy gocql.HostSelectionPolicy, threshold time.Duration,
+	metricsClient metrics.Client, logger bark.Logger) *slowQueryTracingPolicy {
+	return &slowQueryTracingPolicy{
+		HostSelectionPolicy: policy,
+		threshold:           threshold,
+		metricsClient:       metricsClient,
+		logger:              logger.WithField(logging.TagWorkflowComponent, logging.TagValueCassandraSession),
+	}
+}
+
+// Pick returns the hosts of the wrapped policy, timing the attempt made on each of them
+func (p *slowQueryTracingPolicy) Pick(query gocql.ExecutableQuery) gocql.NextHost {
+	next := p.HostSelectionPolicy.Pick(query)
+	return func() gocql.SelectedHost {
+		host := next()
+		if host == nil {
+			return nil
+		}
+		return &tracedHost{SelectedHost: host, policy: p, query: query, startTime: time.Now()}
+	}
+}
+
+// Mark reports the attempt as slow if it took longer than the threshold
+func (h *tracedHost) Mark(err error) {
+	latency := time.Since(h.startTime)
+	if latency >= h.policy.threshold {
+		h.policy.traceSlowQuery(h.query, h.Info(), latency, err)
+	}
+	h.SelectedHost.Mark(err)
+}
+
+func (p *slowQueryTracingPolicy) traceSlowQuery(query gocql.ExecutableQuery, host *gocql.HostInfo,
+	latency time.Duration, err error) {
+	if p.metricsClient != nil {
+		p.metricsClient.IncCounter(metrics.PersistenceSessionScope, metrics.PersistenceSlowQueryCounter)
+	}
+
+	fields := bark.Fields{
+		"statement": queryStatement(query),
+		"partition": partitionHash(query),
+		"latency":   latency,
+	}
+	if host != nil {
+		fields["host"] = host.Peer()
+	}
+	if err != nil {
+		fields[logging.TagErr] = err
+	}
+	p.logger.WithFields(fields).Warn("Slow cassandra query")
+}
+
+// queryStatement returns the statement of a query, which only holds placeholders for the bound values
+func queryStatement(query gocql.ExecutableQuery) string {
+	switch q := query.(type) {
+	case *gocql.Query:
+		// the statement is only exposed through the string of the query, which also holds the values
+		var stmt string
+		if _, err := fmt.Sscanf(q.String(), "[query statement=%q", &stmt); err != nil {
+			return "unknown"
+		}
+		return stmt
+	case *gocql.Batch:
+		if len(q.Entries) == 0 {
+			return "empty batch"
+		}
+		return fmt.Sprintf("batch of %v statements, first: %v", len(q.Entries), q.Entries[0].Stmt)
+	}
+	return "unknown"
+}
+
+// partitionHash returns a hash of the partition key of a query, empty if it is unknown
+func partitionHash(query gocql.ExecutableQuery) string {
+	routingKey, err := query.GetRoutingKey()
+	if err != nil || len(routingKey) == 0 {
+		return ""
+	}
+	h := fnv.New64a()
+	h.Write(routingKey)
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func (p *slowQueryTracingPolicy) unwrap() gocql.HostSelectionPolicy {
+	return p.HostSelectionPolicy
+}
+
+// unwrapHostPolicy returns the host selection policy wrapped by the tracing and metrics policies, if any
+func unwrapHostPolicy(policy gocql.HostSelectionPolicy) gocql.HostSelectionPolicy {
+	for {
+		wrapper, ok := policy.(interface {
+			unwrap() gocql.HostSelectionPolicy
+		})
+		if !ok {
+			return policy
+		}
+		policy = wrapper.unwrap()
+	}
+}
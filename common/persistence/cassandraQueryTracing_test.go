@@ -0,0 +1,120 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	cassandraQueryTracingSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+
+	testHostPolicy struct {
+		*common.DCAwareHostPolicy
+		host *testSelectedHost
+	}
+
+	testSelectedHost struct {
+		marked []error
+	}
+)
+
+func TestCassandraQueryTracingSuite(t *testing.T) {
+	s := new(cassandraQueryTracingSuite)
+	suite.Run(t, s)
+}
+
+func (s *cassandraQueryTracingSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func (s *cassandraQueryTracingSuite) TestQueryStatement() {
+	query := (&gocql.Session{}).Query(templateGetWorkflowExecutionQuery, 1, "domain", "workflow", "run")
+	s.Equal(templateGetWorkflowExecutionQuery, queryStatement(query))
+
+	batch := gocql.NewBatch(gocql.LoggedBatch)
+	batch.Query(templateCompleteTransferTaskQuery, 1, 2)
+	batch.Query(templateUpdateLeaseQuery, 1, 2, 1)
+	s.Equal("batch of 2 statements, first: "+templateCompleteTransferTaskQuery, queryStatement(batch))
+}
+
+func (s *cassandraQueryTracingSuite) TestPartitionHash() {
+	query := (&gocql.Session{}).Query(templateGetWorkflowExecutionQuery, 1).RoutingKey([]byte("shard-1"))
+	hash := partitionHash(query)
+	s.Len(hash, 16)
+	s.NotContains(hash, "shard-1")
+	s.Equal(hash, partitionHash((&gocql.Session{}).Query(templateGetShardQuery, 1).RoutingKey([]byte("shard-1"))))
+	s.Equal("", partitionHash(gocql.NewBatch(gocql.LoggedBatch)))
+}
+
+func (s *cassandraQueryTracingSuite) TestSlowQueries() {
+	scope := tally.NewTestScope("", nil)
+	inner := &testHostPolicy{DCAwareHostPolicy: common.NewDCAwareHostPolicy("dc1"), host: &testSelectedHost{}}
+	policy := newSlowQueryTracingPolicy(inner, time.Hour, metrics.NewClient(scope, metrics.Common),
+		bark.NewLoggerFromLogrus(log.New()))
+	s.Equal(inner, unwrapHostPolicy(policy))
+
+	query := (&gocql.Session{}).Query(templateGetShardQuery, 1).RoutingKey([]byte("shard-1"))
+	host := policy.Pick(query)()
+	host.Mark(nil)
+	s.Equal([]error{nil}, inner.host.marked)
+	counter, ok := scope.Snapshot().Counters()["persistence.session.slow-queries+operation=PersistenceSession"]
+	s.False(ok && counter.Value() > 0)
+
+	policy.threshold = 0
+	host = policy.Pick(query)()
+	host.Mark(gocql.ErrTimeoutNoResponse)
+	s.Equal([]error{nil, gocql.ErrTimeoutNoResponse}, inner.host.marked)
+	counter, ok = scope.Snapshot().Counters()["persistence.session.slow-queries+operation=PersistenceSession"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+}
+
+func (p *testHostPolicy) Pick(query gocql.ExecutableQuery) gocql.NextHost {
+	return func() gocql.SelectedHost {
+		return p.host
+	}
+}
+
+func (h *testSelectedHost) Info() *gocql.HostInfo {
+	return nil
+}
+
+func (h *testSelectedHost) Mark(err error) {
+	h.marked = append(h.marked, err)
+}
@@ -0,0 +1,385 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/uber-common/bark"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	reshardPageSize = 100
+
+	templateReshardScanShardQuery = `SELECT type, domain_id, workflow_id, run_id, task_id, current_run_id, ` +
+		`execution, transfer, timer, next_event_id, activity_map, timer_map, child_executions_map, ` +
+		`request_cancel_map, buffered_events_list, signal_receipt_map, checksum ` +
+		`FROM executions ` +
+		`WHERE shard_id = ?`
+
+	templateReshardInsertRowQuery = `INSERT INTO executions (` +
+		`shard_id, type, domain_id, workflow_id, run_id, task_id, current_run_id, ` +
+		`execution, transfer, timer, next_event_id, activity_map, timer_map, child_executions_map, ` +
+		`request_cancel_map, buffered_events_list, signal_receipt_map, checksum) ` +
+		`VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	templateReshardShardExistsQuery = `SELECT shard_id ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? LIMIT 1`
+
+	templateReshardDeleteShardQuery = `DELETE FROM executions ` +
+		`WHERE shard_id = ?`
+)
+
+type (
+	// executionsResharder moves the rows of the executions table to a different number of history shards.  Rows are
+	// first copied to staging partitions placed after both the old and the new shard ranges, so that the old shards
+	// can be dropped before the staging partitions are moved into place.  A marker shard placed after the staging
+	// partitions is created once every row is copied, it tells a run which failed while copying, and left the old
+	// shards intact, from one which already dropped them.
+	executionsResharder struct {
+		session     *cassandraSessionManager
+		fromShards  int
+		toShards    int
+		stagingBase int
+		markerShard int
+		dropStaging bool
+		logger      bark.Logger
+
+		// Transfer task IDs are only unique within a shard, so the tasks moved to a shard are renumbered in order,
+		// along with the tasks parked in its dead letter queue
+		transferTaskCount []int64
+	}
+)
+
+// ReshardExecutions moves the workflow executions, transfer tasks and timer tasks of a cluster from fromShards to
+// toShards history shards, and recreates the shards with a range above every task ID they contain.  All history
+// hosts have to be stopped while it runs.  A run which fails while copying the rows leaves the old shards intact
+// next to its staging partitions, running it again with dropStaging drops them and starts over.  As the old shards
+// are dropped once the rows are copied, the keyspace has to be restored from a backup if it fails past that point.
+func ReshardExecutions(cfg config.Cassandra, fromShards, toShards int, dropStaging bool,
+	metricsClient metrics.Client, logger bark.Logger) error {
+	if fromShards <= 0 || toShards <= 0 || fromShards == toShards {
+		return fmt.Errorf("invalid number of shards, from: %v, to: %v", fromShards, toShards)
+	}
+
+	store, err := newCassandraStore(cfg, cfg.Keyspace, ExecutionStoreName, metricsClient, logger)
+	if err != nil {
+		return err
+	}
+	defer store.session.Close()
+
+	stagingBase := fromShards
+	if toShards > stagingBase {
+		stagingBase = toShards
+	}
+	r := &executionsResharder{
+		session:           store.session,
+		fromShards:        fromShards,
+		toShards:          toShards,
+		stagingBase:       stagingBase,
+		markerShard:       stagingBase + toShards,
+		dropStaging:       dropStaging,
+		logger:            logger,
+		transferTaskCount: make([]int64, toShards),
+	}
+	return r.reshard()
+}
+
+func (r *executionsResharder) reshard() error {
+	maxRangeID, err := r.verifyShards()
+	if err != nil {
+		return err
+	}
+
+	for shardID := 0; shardID < r.fromShards; shardID++ {
+		r.logger.Infof("Copying shard %v to staging.", shardID)
+		if err := r.copyShard(shardID, r.copyToStaging); err != nil {
+			return err
+		}
+	}
+
+	// Past this point the old shards are dropped, and a failed run can no longer be started over
+	if err := r.createShard(r.markerShard, 0); err != nil {
+		return err
+	}
+
+	for shardID := 0; shardID < r.fromShards; shardID++ {
+		if err := r.deleteShard(shardID); err != nil {
+			return err
+		}
+	}
+
+	for shardID := 0; shardID < r.toShards; shardID++ {
+		r.logger.Infof("Moving staging shard %v into place.", shardID)
+		targetShardID := shardID
+		copyRow := func(row map[string]interface{}) error {
+			return r.insertRow(targetShardID, row)
+		}
+		if err := r.copyShard(r.stagingBase+shardID, copyRow); err != nil {
+			return err
+		}
+		if err := r.deleteShard(r.stagingBase + shardID); err != nil {
+			return err
+		}
+
+		// Every task moved to the shard was given an ID below the range, as the history host acquiring the shard
+		// only hands out IDs above it
+		rangeID := maxRangeID
+		if r.transferTaskCount[shardID] > rangeID {
+			rangeID = r.transferTaskCount[shardID]
+		}
+		if err := r.createShard(shardID, rangeID+1); err != nil {
+			return err
+		}
+	}
+
+	if err := r.deleteShard(r.markerShard); err != nil {
+		return err
+	}
+	r.logger.Infof("Resharded executions from %v to %v shards.", r.fromShards, r.toShards)
+	return nil
+}
+
+// verifyShards checks the cluster has exactly fromShards shards and no leftover staging partitions, and returns the
+// highest range ID of the shards.  With dropStaging the staging partitions of a run which failed while copying the
+// rows are dropped.
+func (r *executionsResharder) verifyShards() (int64, error) {
+	maxRangeID := int64(0)
+	for shardID := 0; shardID < r.fromShards; shardID++ {
+		query := r.session.Query(templateGetShardQuery,
+			shardID,
+			rowTypeShard,
+			rowTypeShardDomainID,
+			rowTypeShardWorkflowID,
+			rowTypeShardRunID,
+			rowTypeShardTaskID)
+		result := make(map[string]interface{})
+		if err := query.MapScan(result); err != nil {
+			return 0, fmt.Errorf("unable to read shard %v: %v", shardID, err)
+		}
+		info := createShardInfo(result["shard"].(map[string]interface{}))
+		if info.RangeID > maxRangeID {
+			maxRangeID = info.RangeID
+		}
+	}
+
+	markerExists, err := r.shardExists(r.markerShard)
+	if err != nil {
+		return 0, err
+	}
+	if markerExists {
+		return 0, fmt.Errorf("a previous resharding failed after dropping the old shards, the keyspace has to be " +
+			"restored from a backup")
+	}
+
+	for shardID := r.fromShards; shardID < r.stagingBase; shardID++ {
+		exists, err := r.shardExists(shardID)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			return 0, fmt.Errorf("shard %v already exists, the cluster does not have %v shards", shardID,
+				r.fromShards)
+		}
+	}
+
+	for shardID := r.stagingBase; shardID < r.markerShard; shardID++ {
+		exists, err := r.shardExists(shardID)
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			continue
+		}
+		if !r.dropStaging {
+			return 0, fmt.Errorf("shard %v already exists, the cluster does not have %v shards or a previous "+
+				"resharding did not complete and its staging shards have to be dropped", shardID, r.fromShards)
+		}
+		if err := r.dropStagingShard(shardID); err != nil {
+			return 0, err
+		}
+	}
+
+	return maxRangeID, nil
+}
+
+// dropStagingShard drops a staging partition left by a run which failed while copying the rows.  Staging partitions
+// never hold a shard row, a partition which does belongs to a shard of the cluster and is kept.
+func (r *executionsResharder) dropStagingShard(shardID int) error {
+	query := r.session.Query(templateGetShardQuery,
+		shardID,
+		rowTypeShard,
+		rowTypeShardDomainID,
+		rowTypeShardWorkflowID,
+		rowTypeShardRunID,
+		rowTypeShardTaskID)
+	err := query.MapScan(make(map[string]interface{}))
+	if err == nil {
+		return fmt.Errorf("shard %v already exists, the cluster does not have %v shards", shardID, r.fromShards)
+	}
+	if err != gocql.ErrNotFound {
+		return fmt.Errorf("unable to read shard %v: %v", shardID, err)
+	}
+
+	r.logger.Infof("Dropping staging shard %v of a previous resharding.", shardID)
+	return r.deleteShard(shardID)
+}
+
+func (r *executionsResharder) shardExists(shardID int) (bool, error) {
+	var id int
+	err := r.session.Query(templateReshardShardExistsQuery, shardID).Scan(&id)
+	if err == gocql.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("unable to read shard %v: %v", shardID, err)
+	}
+	return true, nil
+}
+
+// copyShard calls copyRow for every row of the shard partition except the shard row itself
+func (r *executionsResharder) copyShard(shardID int, copyRow func(row map[string]interface{}) error) error {
+	iter := r.session.Query(templateReshardScanShardQuery, shardID).PageSize(reshardPageSize).Iter()
+	for {
+		row := make(map[string]interface{})
+		if !iter.MapScan(row) {
+			break
+		}
+		if row["type"].(int) == rowTypeShard {
+			continue
+		}
+		if err := copyRow(row); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("unable to read shard %v: %v", shardID, err)
+	}
+	return nil
+}
+
+// copyToStaging copies a row of an old shard to the staging partition of the shard now owning its workflow
+func (r *executionsResharder) copyToStaging(row map[string]interface{}) error {
+	switch row["type"].(int) {
+	case rowTypeExecution:
+		shardID := common.WorkflowIDToHistoryShard(row["workflow_id"].(string), r.toShards)
+		return r.insertRow(r.stagingBase+shardID, row)
+
+	case rowTypeTransferTask, rowTypeTransferDLQTask:
+		task := row["transfer"].(map[string]interface{})
+		shardID := common.WorkflowIDToHistoryShard(task["workflow_id"].(string), r.toShards)
+		r.transferTaskCount[shardID]++
+		row["task_id"] = r.transferTaskCount[shardID]
+		task["task_id"] = r.transferTaskCount[shardID]
+		return r.insertRow(r.stagingBase+shardID, row)
+
+	case rowTypeTimerTask:
+		// Timer task IDs embed the fire time, so they are kept and only moved past the timers of other shards
+		// which happen to share the same ID
+		task := row["timer"].(map[string]interface{})
+		shardID := common.WorkflowIDToHistoryShard(task["workflow_id"].(string), r.toShards)
+		for {
+			previous := make(map[string]interface{})
+			applied, err := r.session.Query(templateReshardInsertRowQuery+" IF NOT EXISTS",
+				insertRowArgs(r.stagingBase+shardID, row)...).MapScanCAS(previous)
+			if err != nil {
+				return fmt.Errorf("unable to copy timer task: %v", err)
+			}
+			if applied {
+				return nil
+			}
+			row["task_id"] = row["task_id"].(int64) + 1
+			task["task_id"] = row["task_id"]
+		}
+	}
+
+	return fmt.Errorf("unknown row type %v", row["type"])
+}
+
+func (r *executionsResharder) insertRow(shardID int, row map[string]interface{}) error {
+	if err := r.session.Query(templateReshardInsertRowQuery, insertRowArgs(shardID, row)...).Exec(); err != nil {
+		return fmt.Errorf("unable to copy row to shard %v: %v", shardID, err)
+	}
+	return nil
+}
+
+func insertRowArgs(shardID int, row map[string]interface{}) []interface{} {
+	return []interface{}{
+		shardID,
+		row["type"],
+		row["domain_id"],
+		row["workflow_id"],
+		row["run_id"],
+		row["task_id"],
+		row["current_run_id"],
+		row["execution"],
+		row["transfer"],
+		row["timer"],
+		row["next_event_id"],
+		row["activity_map"],
+		row["timer_map"],
+		row["child_executions_map"],
+		row["request_cancel_map"],
+		row["buffered_events_list"],
+		row["signal_receipt_map"],
+		row["checksum"],
+	}
+}
+
+func (r *executionsResharder) deleteShard(shardID int) error {
+	if err := r.session.Query(templateReshardDeleteShardQuery, shardID).Exec(); err != nil {
+		return fmt.Errorf("unable to delete shard %v: %v", shardID, err)
+	}
+	return nil
+}
+
+func (r *executionsResharder) createShard(shardID int, rangeID int64) error {
+	applied, err := r.session.Query(templateCreateShardQuery,
+		shardID,
+		rowTypeShard,
+		rowTypeShardDomainID,
+		rowTypeShardWorkflowID,
+		rowTypeShardRunID,
+		rowTypeShardTaskID,
+		shardID,
+		"",
+		rangeID,
+		0,
+		common.UnixNanoToCQLTimestamp(time.Now().UnixNano()),
+		0,
+		rangeID).MapScanCAS(make(map[string]interface{}))
+	if err != nil {
+		return fmt.Errorf("unable to create shard %v: %v", shardID, err)
+	}
+	if !applied {
+		return fmt.Errorf("unable to create shard %v: shard already exists", shardID)
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	reshardSuite struct {
+		suite.Suite
+		TestBase
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestReshardSuite(t *testing.T) {
+	s := new(reshardSuite)
+	suite.Run(t, s)
+}
+
+func (s *reshardSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *reshardSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	// Every test reshards a keyspace of its own, which starts with shard 0 only
+	s.SetupWorkflowStore()
+}
+
+func (s *reshardSuite) TearDownTest() {
+	s.TearDownWorkflowStore()
+}
+
+func (s *reshardSuite) TestReshardExecutions() {
+	s.populateShard(s.WorkflowMgr, 0)
+	before := s.readRows(0)
+
+	err := s.reshard(1, 4, false)
+	s.Nil(err)
+
+	s.assertResharded(before, 4)
+}
+
+func (s *reshardSuite) TestReshardExecutions_MergeShards() {
+	err0 := s.CreateShard(1, "test_reshard_merge", 0)
+	s.Nil(err0)
+	executionMgr, err1 := s.ExecutionMgrFactory.CreateExecutionManager(1)
+	s.Nil(err1)
+
+	// Both shards hand out the same transfer and timer task IDs
+	s.populateShard(s.WorkflowMgr, 0)
+	s.populateShard(executionMgr, 1)
+	before := append(s.readRows(0), s.readRows(1)...)
+
+	err2 := s.reshard(2, 1, false)
+	s.Nil(err2)
+
+	after := s.readRows(0)
+	s.Equal(len(before), len(after))
+	transferTaskIDs := make(map[int64]bool)
+	timerTaskIDs := make(map[int64]bool)
+	for _, row := range after {
+		switch row["type"].(int) {
+		case rowTypeTransferTask, rowTypeTransferDLQTask:
+			taskID := row["task_id"].(int64)
+			s.False(transferTaskIDs[taskID], "transfer task ID %v used twice", taskID)
+			transferTaskIDs[taskID] = true
+			s.Equal(taskID, row["transfer"].(map[string]interface{})["task_id"])
+		case rowTypeTimerTask:
+			taskID := row["task_id"].(int64)
+			s.False(timerTaskIDs[taskID], "timer task ID %v used twice", taskID)
+			timerTaskIDs[taskID] = true
+			s.Equal(taskID, row["timer"].(map[string]interface{})["task_id"])
+		}
+	}
+	// Transfer tasks are renumbered from 1 without gaps
+	for taskID := int64(1); taskID <= int64(len(transferTaskIDs)); taskID++ {
+		s.True(transferTaskIDs[taskID], "transfer task ID %v missing", taskID)
+	}
+
+	info, err3 := s.GetShard(0)
+	s.Nil(err3)
+	s.True(info.RangeID > int64(len(transferTaskIDs)))
+	_, err4 := s.GetShard(1)
+	s.NotNil(err4)
+}
+
+func (s *reshardSuite) TestReshardExecutions_DropStaging() {
+	s.populateShard(s.WorkflowMgr, 0)
+	before := s.readRows(0)
+
+	// A previous run failed while copying the rows to the staging partitions
+	stagingRow := before[0]
+	err0 := s.session.Query(fmt.Sprintf("INSERT INTO %v.executions (shard_id, type, domain_id, workflow_id, "+
+		"run_id, task_id) VALUES (?, ?, ?, ?, ?, ?)", s.keyspace),
+		5,
+		stagingRow["type"],
+		stagingRow["domain_id"],
+		stagingRow["workflow_id"],
+		stagingRow["run_id"],
+		stagingRow["task_id"]).Exec()
+	s.Nil(err0)
+
+	err1 := s.reshard(1, 4, false)
+	s.NotNil(err1)
+	s.Equal(before, s.readRows(0))
+
+	err2 := s.reshard(1, 4, true)
+	s.Nil(err2)
+
+	s.assertResharded(before, 4)
+}
+
+func (s *reshardSuite) TestReshardExecutions_StagingShardKept() {
+	s.populateShard(s.WorkflowMgr, 0)
+	before := s.readRows(0)
+
+	// A partition in the staging range holding a shard row belongs to the cluster, it is never dropped
+	err0 := s.CreateShard(5, "test_reshard_staging", 0)
+	s.Nil(err0)
+
+	err1 := s.reshard(1, 4, true)
+	s.NotNil(err1)
+	s.Equal(before, s.readRows(0))
+	_, err2 := s.GetShard(5)
+	s.Nil(err2)
+}
+
+func (s *reshardSuite) TestReshardExecutions_MarkerShard() {
+	s.populateShard(s.WorkflowMgr, 0)
+	before := s.readRows(0)
+
+	// A previous run failed after dropping the old shards
+	err0 := s.CreateShard(8, "", 0)
+	s.Nil(err0)
+
+	err1 := s.reshard(1, 4, true)
+	s.NotNil(err1)
+	s.Equal(before, s.readRows(0))
+}
+
+// assertResharded checks every row read from the old shards was moved to the shard owning its workflow, with every
+// column intact but the IDs of the transfer tasks, which are renumbered in order
+func (s *reshardSuite) assertResharded(before []map[string]interface{}, toShards int) {
+	expected := make([][]map[string]interface{}, toShards)
+	transferTaskCount := make([]int64, toShards)
+	for _, row := range before {
+		shardID := common.WorkflowIDToHistoryShard(rowWorkflowID(row), toShards)
+		switch row["type"].(int) {
+		case rowTypeTransferTask, rowTypeTransferDLQTask:
+			transferTaskCount[shardID]++
+			row["task_id"] = transferTaskCount[shardID]
+			row["transfer"].(map[string]interface{})["task_id"] = transferTaskCount[shardID]
+		}
+		expected[shardID] = append(expected[shardID], row)
+	}
+
+	for shardID := 0; shardID < toShards; shardID++ {
+		s.Equal(expected[shardID], s.readRows(shardID), "shard %v", shardID)
+
+		info, err := s.GetShard(shardID)
+		s.Nil(err)
+		s.True(info.RangeID > transferTaskCount[shardID])
+	}
+
+	// Neither the staging partitions nor the marker shard are left behind
+	stagingBase := toShards
+	for shardID := stagingBase; shardID < stagingBase+toShards; shardID++ {
+		s.Empty(s.readRows(shardID), "staging shard %v", shardID)
+	}
+	_, err := s.GetShard(stagingBase + toShards)
+	s.NotNil(err)
+}
+
+func (s *reshardSuite) reshard(fromShards, toShards int, dropStaging bool) error {
+	options := TestBaseOptions{
+		ClusterHost: testWorkflowClusterHosts,
+		Datacenter:  testDatacenter,
+	}
+	return ReshardExecutions(options.cassandraConfig(s.keyspace), fromShards, toShards, dropStaging, nil,
+		bark.NewLoggerFromLogrus(log.New()))
+}
+
+// readRows returns every row of the shard partition but the shard row, without the columns of the shard row
+func (s *reshardSuite) readRows(shardID int) []map[string]interface{} {
+	iter := s.session.Query(fmt.Sprintf("SELECT * FROM %v.executions WHERE shard_id = ?", s.keyspace),
+		shardID).Iter()
+	var rows []map[string]interface{}
+	for {
+		row := make(map[string]interface{})
+		if !iter.MapScan(row) {
+			break
+		}
+		if row["type"].(int) == rowTypeShard {
+			continue
+		}
+		delete(row, "shard_id")
+		delete(row, "shard")
+		delete(row, "range_id")
+		rows = append(rows, row)
+	}
+	s.Nil(iter.Close())
+	return rows
+}
+
+// populateShard creates workflow executions with every kind of mutable state, transfer and timer tasks, and a task
+// parked in the dead letter queue.  The workflows spread over the shards of a resharded cluster.
+func (s *reshardSuite) populateShard(executionMgr ExecutionManager, shardID int) {
+	domainID := "9e2b1c4d-7f3a-4e5b-8c6d-1a2b3c4d5e6f"
+	for i := 0; i < 8; i++ {
+		workflowExecution := gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(fmt.Sprintf("reshard-workflow-%v-%v", shardID, i)),
+			RunId:      common.StringPtr(uuid.New()),
+		}
+		_, err0 := executionMgr.CreateWorkflowExecution(context.Background(), &CreateWorkflowExecutionRequest{
+			RequestID:            uuid.New(),
+			DomainID:             domainID,
+			Execution:            workflowExecution,
+			TaskList:             "reshard-queue",
+			WorkflowTypeName:     "wType",
+			DecisionTimeoutValue: 13,
+			NextEventID:          3,
+			LastProcessedEvent:   0,
+			RangeID:              0,
+			TransferTasks: []Task{
+				&DecisionTask{TaskID: int64(2*i + 1), DomainID: domainID, TaskList: "reshard-queue", ScheduleID: 2},
+			},
+			TimerTasks:                  []Task{&UserTimerTask{TaskID: int64(i + 1), EventID: 2}},
+			DecisionScheduleID:          2,
+			DecisionStartedID:           common.EmptyEventID,
+			DecisionStartToCloseTimeout: 1,
+		})
+		s.Nil(err0)
+
+		state, err1 := executionMgr.GetWorkflowExecution(context.Background(), &GetWorkflowExecutionRequest{
+			DomainID:  domainID,
+			Execution: workflowExecution,
+		})
+		s.Nil(err1)
+		updatedInfo := copyWorkflowExecutionInfo(state.State.ExecutionInfo)
+		currentTime := time.Now().UTC()
+		err2 := executionMgr.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
+			ExecutionInfo: updatedInfo,
+			TransferTasks: []Task{
+				&ActivityTask{TaskID: int64(2*i + 2), DomainID: domainID, TaskList: "reshard-queue", ScheduleID: 5},
+			},
+			Condition: 3,
+			RangeID:   0,
+			UpsertActivityInfos: []*ActivityInfo{{
+				ScheduleID:             5,
+				ScheduledEvent:         []byte("scheduled_event_5"),
+				StartedID:              common.EmptyEventID,
+				ScheduleToCloseTimeout: 1,
+				ScheduleToStartTimeout: 2,
+				StartToCloseTimeout:    3,
+				HeartbeatTimeout:       4,
+			}},
+			UpserTimerInfos: []*TimerInfo{{TimerID: "timer-1", StartedID: 4, ExpiryTime: currentTime, TaskID: 6}},
+			UpsertChildExecutionInfos: []*ChildExecutionInfo{{
+				InitiatedID:     7,
+				InitiatedEvent:  []byte("initiated_event_7"),
+				StartedID:       common.EmptyEventID,
+				CreateRequestID: uuid.New(),
+			}},
+			UpsertRequestCancelInfos: []*RequestCancelInfo{{InitiatedID: 8, CancelRequestID: uuid.New()}},
+			NewBufferedEvents:        NewSerializedHistoryEventBatch([]byte("batch1"), common.EncodingTypeJSON, 1),
+			UpsertSignalReceipts:     map[string]int64{"signal-1": 9},
+		})
+		s.Nil(err2)
+	}
+
+	err3 := executionMgr.PutTransferDLQTask(context.Background(), &PutTransferDLQTaskRequest{
+		TaskInfo: &TransferTaskInfo{
+			DomainID:   domainID,
+			WorkflowID: fmt.Sprintf("reshard-workflow-%v-0", shardID),
+			RunID:      uuid.New(),
+			TaskID:     100,
+			TaskList:   "reshard-queue",
+			TaskType:   TransferTaskTypeActivityTask,
+			ScheduleID: 5,
+		},
+		RangeID: 0,
+	})
+	s.Nil(err3)
+}
+
+func rowWorkflowID(row map[string]interface{}) string {
+	switch row["type"].(int) {
+	case rowTypeTransferTask, rowTypeTransferDLQTask:
+		return row["transfer"].(map[string]interface{})["workflow_id"].(string)
+	case rowTypeTimerTask:
+		return row["timer"].(map[string]interface{})["workflow_id"].(string)
+	}
+	return row["workflow_id"].(string)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	defaultQueryMaxRetries           = 2
+	defaultQueryRetryInitialInterval = 10 * time.Millisecond
+	defaultQueryRetryMaxInterval     = 100 * time.Millisecond
+)
+
+// conditionalStatementPattern matches the lightweight transactions of the persistence templates, e.g. IF NOT EXISTS
+var conditionalStatementPattern = regexp.MustCompile(`\bIF\b`)
+
+type (
+	// queryRetryPolicies holds the retry policies of the queries of a store. The vendored gocql calls a retry policy
+	// after any failed attempt and moves the query to the next host, so the backoff happens within the policy
+	queryRetryPolicies struct {
+		read  gocql.RetryPolicy
+		write gocql.RetryPolicy
+	}
+
+	// queryRetryPolicy retries a query with an exponential backoff, optionally retrying it immediately the first time
+	queryRetryPolicy struct {
+		policy         backoff.RetryPolicy
+		immediateFirst bool
+		onRetry        func()
+		sleep          func(time.Duration)
+	}
+)
+
+var _ gocql.RetryPolicy = (*queryRetryPolicy)(nil)
+
+// newQueryRetryPolicies creates the retry policies of the configuration, onRetry is called before each retry.
+// Retries are disabled when the policies are nil
+func newQueryRetryPolicies(cfg config.CassandraRetry, onRetry func()) *queryRetryPolicies {
+	maxRetries := defaultQueryMaxRetries
+	if cfg.MaxRetries < 0 {
+		return nil
+	}
+	if cfg.MaxRetries > 0 {
+		maxRetries = cfg.MaxRetries
+	}
+	initialInterval := defaultQueryRetryInitialInterval
+	if cfg.InitialInterval > 0 {
+		initialInterval = cfg.InitialInterval
+	}
+	maxInterval := defaultQueryRetryMaxInterval
+	if cfg.MaxInterval > 0 {
+		maxInterval = cfg.MaxInterval
+	}
+
+	policy := backoff.NewExponentialRetryPolicy(initialInterval)
+	policy.SetMaximumInterval(maxInterval)
+	policy.SetMaximumAttempts(maxRetries)
+	policy.SetExpirationInterval(backoff.NoInterval)
+
+	return &queryRetryPolicies{
+		// reads are cheap and usually fail because of a single slow coordinator, so the first retry goes to the
+		// next host right away
+		read:  &queryRetryPolicy{policy: policy, immediateFirst: true, onRetry: onRetry, sleep: time.Sleep},
+		write: &queryRetryPolicy{policy: policy, onRetry: onRetry, sleep: time.Sleep},
+	}
+}
+
+// forStatement returns the retry policy of a statement, nil if it must not be retried. Conditional updates are not
+// idempotent: a retry of an update which was applied before its coordinator timed out would fail its condition
+func (p *queryRetryPolicies) forStatement(stmt string) gocql.RetryPolicy {
+	if p == nil || conditionalStatementPattern.MatchString(stmt) {
+		return nil
+	}
+	if isReadStatement(stmt) {
+		return p.read
+	}
+	return p.write
+}
+
+// forBatch returns the retry policy of a batch, nil if it must not be retried
+func (p *queryRetryPolicies) forBatch(conditional bool) gocql.RetryPolicy {
+	if p == nil || conditional {
+		return nil
+	}
+	return p.write
+}
+
+func (p *queryRetryPolicy) Attempt(query gocql.RetryableQuery) bool {
+	retries := query.Attempts() - 1
+	delay := p.policy.ComputeNextDelay(0, retries)
+	if delay < 0 {
+		return false
+	}
+
+	if p.onRetry != nil {
+		p.onRetry()
+	}
+	if retries > 0 || !p.immediateFirst {
+		p.sleep(delay)
+	}
+	return true
+}
+
+func isReadStatement(stmt string) bool {
+	stmt = strings.TrimSpace(stmt)
+	return len(stmt) >= len("SELECT") && strings.EqualFold(stmt[:len("SELECT")], "SELECT")
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	cassandraRetryPolicySuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+
+	testRetryableQuery struct {
+		attempts int
+	}
+)
+
+func TestCassandraRetryPolicySuite(t *testing.T) {
+	s := new(cassandraRetryPolicySuite)
+	suite.Run(t, s)
+}
+
+func (s *cassandraRetryPolicySuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func (s *cassandraRetryPolicySuite) TestForStatement() {
+	p := newQueryRetryPolicies(config.CassandraRetry{}, nil)
+	s.Equal(p.read, p.forStatement(templateGetWorkflowExecutionQuery))
+	s.Equal(p.write, p.forStatement(templateCompleteTransferTaskQuery))
+	s.Nil(p.forStatement(templateUpdateLeaseQuery))
+	s.Nil(p.forStatement(templateAppendHistoryEvents))
+	s.Equal(p.write, p.forBatch(false))
+	s.Nil(p.forBatch(true))
+}
+
+func (s *cassandraRetryPolicySuite) TestDisabled() {
+	p := newQueryRetryPolicies(config.CassandraRetry{MaxRetries: -1}, nil)
+	s.Nil(p)
+	s.Nil(p.forStatement(templateGetWorkflowExecutionQuery))
+	s.Nil(p.forBatch(false))
+}
+
+func (s *cassandraRetryPolicySuite) TestReadRetries() {
+	retries := 0
+	p := newQueryRetryPolicies(config.CassandraRetry{
+		MaxRetries:      3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     2 * time.Millisecond,
+	}, func() { retries++ })
+	policy := p.read.(*queryRetryPolicy)
+	var delays []time.Duration
+	policy.sleep = func(delay time.Duration) { delays = append(delays, delay) }
+
+	query := &testRetryableQuery{}
+	for query.attempts = 1; policy.Attempt(query); query.attempts++ {
+	}
+	s.Equal(4, query.attempts)
+	s.Equal(3, retries)
+	// the first retry of a read is immediate
+	s.Equal(2, len(delays))
+	for _, delay := range delays {
+		s.True(delay > 0 && delay <= 2*time.Millisecond)
+	}
+}
+
+func (s *cassandraRetryPolicySuite) TestWriteRetries() {
+	p := newQueryRetryPolicies(config.CassandraRetry{
+		MaxRetries:      2,
+		InitialInterval: time.Millisecond,
+	}, nil)
+	policy := p.write.(*queryRetryPolicy)
+	var delays []time.Duration
+	policy.sleep = func(delay time.Duration) { delays = append(delays, delay) }
+
+	query := &testRetryableQuery{}
+	for query.attempts = 1; policy.Attempt(query); query.attempts++ {
+	}
+	s.Equal(3, query.attempts)
+	s.Equal(2, len(delays))
+}
+
+func (q *testRetryableQuery) Attempts() int {
+	return q.attempts
+}
+
+func (q *testRetryableQuery) GetConsistency() gocql.Consistency {
+	return gocql.LocalQuorum
+}
@@ -0,0 +1,285 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	sessionHealthCheckInterval  = 10 * time.Second
+	sessionHealthCheckTimeout   = 5 * time.Second
+	sessionMaxConsecutiveFailed = 3
+
+	templateSessionHealthCheckQuery = `SELECT now() FROM system.local`
+)
+
+const (
+	sessionManagerStatusRunning = iota
+	sessionManagerStatusClosed
+)
+
+type (
+	// cassandraSessionManager owns the gocql session of a persistence manager, replacing it after
+	// consecutive failed probes. Non conditional queries falling back to a remote datacenter are
+	// downgraded to the fallback consistency, if one is configured.
+	cassandraSessionManager struct {
+		sync.RWMutex
+		cluster             *gocql.ClusterConfig
+		session             *gocql.Session
+		hostPolicy          *common.DCAwareHostPolicy
+		fallbackConsistency *gocql.Consistency
+		retryPolicies       *queryRetryPolicies
+		status              int32
+		failedChecks        int
+		remoteFallback      bool
+		metricsClient       metrics.Client
+		logger              bark.Logger
+		shutdownCh          chan struct{}
+		shutdownWG          sync.WaitGroup
+		createSession       func() (*gocql.Session, error)
+		probeSession        func(*gocql.Session) error
+	}
+)
+
+func newCassandraSessionManager(cluster *gocql.ClusterConfig, fallbackConsistency *gocql.Consistency,
+	retry config.CassandraRetry, metricsClient metrics.Client, logger bark.Logger) (*cassandraSessionManager, error) {
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &cassandraSessionManager{
+		cluster:             cluster,
+		session:             session,
+		fallbackConsistency: fallbackConsistency,
+		status:              sessionManagerStatusRunning,
+		logger:              logger.WithField(logging.TagWorkflowComponent, logging.TagValueCassandraSession),
+		shutdownCh:          make(chan struct{}),
+		createSession:       cluster.CreateSession,
+		probeSession:        probeSession,
+	}
+	m.hostPolicy, _ = unwrapHostPolicy(cluster.PoolConfig.HostSelectionPolicy).(*common.DCAwareHostPolicy)
+	if metricsClient != nil {
+		m.metricsClient = metricsClient.Tagged(map[string]string{metrics.KeyspaceTagName: cluster.Keyspace})
+	}
+	m.retryPolicies = newQueryRetryPolicies(retry, func() {
+		m.incCounter(metrics.PersistenceQueryRetryCounter)
+	})
+	m.updateConnectedGauge(true)
+
+	m.shutdownWG.Add(1)
+	go m.healthCheckLoop()
+	return m, nil
+}
+
+// Query creates a query on the current session
+func (m *cassandraSessionManager) Query(stmt string, values ...interface{}) *gocql.Query {
+	query := m.getSession().Query(stmt, values...).RetryPolicy(m.retryPolicies.forStatement(stmt))
+	if m.shouldDowngrade(conditionalStatementPattern.MatchString(stmt)) {
+		query.Consistency(*m.fallbackConsistency)
+	}
+	return query
+}
+
+// consistency returns cons, or the fallback consistency while queries are downgraded
+func (m *cassandraSessionManager) consistency(cons gocql.Consistency) gocql.Consistency {
+	if m.shouldDowngrade(false) {
+		return *m.fallbackConsistency
+	}
+	return cons
+}
+
+// NewBatch creates a batch on the current session, it is downgraded once executed
+func (m *cassandraSessionManager) NewBatch(typ gocql.BatchType) *gocql.Batch {
+	return m.getSession().NewBatch(typ)
+}
+
+// ExecuteBatch executes the batch on the current session
+func (m *cassandraSessionManager) ExecuteBatch(batch *gocql.Batch) error {
+	if m.shouldDowngrade(false) {
+		batch.Cons = *m.fallbackConsistency
+	}
+	return m.getSession().ExecuteBatch(batch.RetryPolicy(m.retryPolicies.forBatch(false)))
+}
+
+// MapExecuteBatchCAS executes the conditional batch on the current session
+func (m *cassandraSessionManager) MapExecuteBatchCAS(batch *gocql.Batch,
+	dest map[string]interface{}) (bool, *gocql.Iter, error) {
+	return m.getSession().MapExecuteBatchCAS(batch.RetryPolicy(m.retryPolicies.forBatch(true)), dest)
+}
+
+// prepare prepares the statements on one of the hosts, so the first queries using them do not pay for it. It goes
+// through the routing key lookup of gocql, as the vendored version does not expose preparing a statement. Bound
+// values are nil placeholders, a routing key is not computed from them
+func (m *cassandraSessionManager) prepare(stmts []string) {
+	for _, stmt := range stmts {
+		values := make([]interface{}, strings.Count(stmt, "?"))
+		for i := range values {
+			values[i] = (*string)(nil)
+		}
+		if _, err := m.getSession().Query(stmt, values...).WithContext(context.Background()).GetRoutingKey(); err != nil {
+			m.logger.WithFields(bark.Fields{
+				"statement":    stmt,
+				logging.TagErr: err,
+			}).Warn("Unable to prepare cassandra statement")
+		}
+	}
+}
+
+// Close stops the health checks and closes the session
+func (m *cassandraSessionManager) Close() {
+	if !atomic.CompareAndSwapInt32(&m.status, sessionManagerStatusRunning, sessionManagerStatusClosed) {
+		return
+	}
+	close(m.shutdownCh)
+	m.shutdownWG.Wait()
+	m.getSession().Close()
+}
+
+func (m *cassandraSessionManager) getSession() *gocql.Session {
+	m.RLock()
+	defer m.RUnlock()
+	return m.session
+}
+
+// shouldDowngrade returns true if non conditional queries have to be downgraded
+func (m *cassandraSessionManager) shouldDowngrade(conditional bool) bool {
+	return !conditional && m.fallbackConsistency != nil && m.hostPolicy != nil && !m.hostPolicy.LocalHostsUp()
+}
+
+func (m *cassandraSessionManager) healthCheckLoop() {
+	defer m.shutdownWG.Done()
+
+	ticker := time.NewTicker(sessionHealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-ticker.C:
+			m.checkHealth()
+		}
+	}
+}
+
+func (m *cassandraSessionManager) checkHealth() {
+	m.checkRemoteFallback()
+
+	session := m.getSession()
+	err := m.probeSession(session)
+	if err == nil {
+		m.failedChecks = 0
+		m.updateConnectedGauge(true)
+		return
+	}
+
+	m.failedChecks++
+	m.incCounter(metrics.PersistenceSessionCheckFailedCounter)
+	m.logger.WithField(logging.TagErr, err).Warnf("Cassandra session health check failed, consecutive failures: %v",
+		m.failedChecks)
+	if m.failedChecks < sessionMaxConsecutiveFailed && !session.Closed() {
+		return
+	}
+
+	m.updateConnectedGauge(false)
+	m.reconnect(session)
+}
+
+func (m *cassandraSessionManager) reconnect(old *gocql.Session) {
+	session, err := m.createSession()
+	if err != nil {
+		m.incCounter(metrics.PersistenceSessionReconnectFailedCounter)
+		m.logger.WithField(logging.TagErr, err).Error("Unable to recreate cassandra session")
+		return
+	}
+
+	m.Lock()
+	m.session = session
+	m.Unlock()
+	// Queries already running on the old session fail, callers retry them on the new one.
+	old.Close()
+
+	m.failedChecks = 0
+	m.incCounter(metrics.PersistenceSessionReconnectCounter)
+	m.updateConnectedGauge(true)
+	m.logger.Info("Recreated cassandra session")
+}
+
+func (m *cassandraSessionManager) checkRemoteFallback() {
+	if m.hostPolicy == nil {
+		return
+	}
+
+	remoteFallback := !m.hostPolicy.LocalHostsUp()
+	if remoteFallback != m.remoteFallback {
+		if remoteFallback {
+			m.logger.Warn("No cassandra host in the local datacenter is up, falling back to remote datacenters")
+		} else {
+			m.logger.Info("Cassandra hosts in the local datacenter are up again")
+		}
+		m.remoteFallback = remoteFallback
+	}
+
+	value := float64(0)
+	if remoteFallback {
+		value = 1
+	}
+	if m.metricsClient != nil {
+		m.metricsClient.UpdateGauge(metrics.PersistenceSessionScope, metrics.PersistenceSessionRemoteFallbackGauge, value)
+	}
+}
+
+func probeSession(session *gocql.Session) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sessionHealthCheckTimeout)
+	defer cancel()
+	return session.Query(templateSessionHealthCheckQuery).WithContext(ctx).Exec()
+}
+
+func (m *cassandraSessionManager) updateConnectedGauge(connected bool) {
+	if m.metricsClient == nil {
+		return
+	}
+	value := float64(0)
+	if connected {
+		value = 1
+	}
+	m.metricsClient.UpdateGauge(metrics.PersistenceSessionScope, metrics.PersistenceSessionConnectedGauge, value)
+}
+
+func (m *cassandraSessionManager) incCounter(counter int) {
+	if m.metricsClient != nil {
+		m.metricsClient.IncCounter(metrics.PersistenceSessionScope, counter)
+	}
+}
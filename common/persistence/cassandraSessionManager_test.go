@@ -0,0 +1,160 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"errors"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+
+	"github.com/uber/cadence/common"
+)
+
+type (
+	cassandraSessionManagerSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		probeErr  error
+		createErr error
+		created   []*gocql.Session
+		manager   *cassandraSessionManager
+	}
+)
+
+func TestCassandraSessionManagerSuite(t *testing.T) {
+	s := new(cassandraSessionManagerSuite)
+	suite.Run(t, s)
+}
+
+func (s *cassandraSessionManagerSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.probeErr = nil
+	s.createErr = nil
+	s.created = nil
+	// Sessions which were never connected can be swapped and closed like connected ones
+	s.manager = &cassandraSessionManager{
+		session:    &gocql.Session{},
+		status:     sessionManagerStatusRunning,
+		logger:     bark.NewLoggerFromLogrus(log.New()),
+		shutdownCh: make(chan struct{}),
+		createSession: func() (*gocql.Session, error) {
+			if s.createErr != nil {
+				return nil, s.createErr
+			}
+			session := &gocql.Session{}
+			s.created = append(s.created, session)
+			return session, nil
+		},
+		probeSession: func(*gocql.Session) error {
+			return s.probeErr
+		},
+	}
+}
+
+func (s *cassandraSessionManagerSuite) TestReconnect() {
+	old := s.manager.getSession()
+	s.probeErr = errors.New("no hosts available")
+	for i := 1; i < sessionMaxConsecutiveFailed; i++ {
+		s.manager.checkHealth()
+		s.Equal(old, s.manager.getSession())
+	}
+
+	s.manager.checkHealth()
+	s.Len(s.created, 1)
+	s.Equal(s.created[0], s.manager.getSession())
+	s.True(old.Closed())
+	s.False(s.created[0].Closed())
+	s.Equal(0, s.manager.failedChecks)
+
+	// A successful probe resets the count of failed ones
+	s.manager.checkHealth()
+	s.probeErr = nil
+	s.manager.checkHealth()
+	s.Equal(0, s.manager.failedChecks)
+	s.Len(s.created, 1)
+}
+
+func (s *cassandraSessionManagerSuite) TestReconnectFailed() {
+	old := s.manager.getSession()
+	s.probeErr = errors.New("no hosts available")
+	s.createErr = errors.New("unable to connect")
+	for i := 0; i < sessionMaxConsecutiveFailed; i++ {
+		s.manager.checkHealth()
+	}
+	// The session is kept until a new one is created, the next check tries again
+	s.Equal(old, s.manager.getSession())
+	s.False(old.Closed())
+
+	s.createErr = nil
+	s.manager.checkHealth()
+	s.Len(s.created, 1)
+	s.Equal(s.created[0], s.manager.getSession())
+	s.True(old.Closed())
+}
+
+func (s *cassandraSessionManagerSuite) TestReconnectClosedSession() {
+	old := s.manager.getSession()
+	old.Close()
+	s.probeErr = gocql.ErrSessionClosed
+	s.manager.checkHealth()
+	s.Len(s.created, 1)
+	s.Equal(s.created[0], s.manager.getSession())
+}
+
+func (s *cassandraSessionManagerSuite) TestClose() {
+	s.manager.shutdownWG.Add(1)
+	go s.manager.healthCheckLoop()
+
+	session := s.manager.getSession()
+	s.manager.Close()
+	s.True(session.Closed())
+	// the health check loop is stopped
+	s.manager.shutdownWG.Wait()
+	// closing again is a no-op
+	s.manager.Close()
+}
+
+func (s *cassandraSessionManagerSuite) TestDowngradeSkipsConditionalQueries() {
+	// No host of the local datacenter is up, queries fall back to a remote datacenter
+	fallbackConsistency := gocql.One
+	s.manager.hostPolicy = common.NewDCAwareHostPolicy("dc1")
+	s.manager.fallbackConsistency = &fallbackConsistency
+
+	s.Equal(gocql.One, s.manager.Query(`UPDATE executions SET shard = ? WHERE shard_id = ?`).GetConsistency())
+	s.NotEqual(gocql.One, s.manager.Query(`UPDATE executions SET shard = ? WHERE shard_id = ? IF range_id = ?`).
+		GetConsistency())
+	// Batches are downgraded once they are executed, conditional ones never are
+	s.NotEqual(gocql.One, s.manager.NewBatch(gocql.LoggedBatch).Cons)
+	s.True(s.manager.shouldDowngrade(false))
+	s.False(s.manager.shouldDowngrade(true))
+	// Reads setting their own consistency are downgraded too
+	s.Equal(gocql.One, s.manager.consistency(gocql.LocalQuorum))
+	s.manager.fallbackConsistency = nil
+	s.Equal(gocql.LocalQuorum, s.manager.consistency(gocql.LocalQuorum))
+}
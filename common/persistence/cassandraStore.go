@@ -0,0 +1,180 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/uber-common/bark"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+)
+
+// Names of the persistence stores, used as keys of the per-store cassandra configuration
+const (
+	ShardStoreName      = "shard"
+	ExecutionStoreName  = "execution"
+	TaskStoreName       = "task"
+	HistoryStoreName    = "history"
+	MetadataStoreName   = "metadata"
+	VisibilityStoreName = "visibility"
+)
+
+// hotStatements are the statements of each store prepared when its session is created
+var hotStatements = map[string][]string{
+	ShardStoreName: {
+		templateGetShardQuery,
+		templateUpdateShardQuery,
+	},
+	ExecutionStoreName: {
+		templateGetWorkflowExecutionQuery,
+		templateGetCurrentExecutionQuery,
+		templateUpdateWorkflowExecutionQuery,
+		templateUpdateLeaseQuery,
+		templateCreateTransferTaskQuery,
+		templateCreateTimerTaskQuery,
+		templateGetTransferTasksQuery,
+		templateCompleteTransferTaskQuery,
+		templateGetTimerTasksQuery,
+		templateCompleteTimerTaskQuery,
+	},
+	TaskStoreName: {
+		templateCreateTaskQuery,
+		templateGetTasksQuery,
+		templateCompleteTaskQuery,
+		templateUpdateTaskListQuery,
+	},
+	HistoryStoreName: {
+		templateAppendHistoryEvents,
+		templateOverwriteHistoryEvents,
+		templateGetWorkflowExecutionHistory,
+	},
+}
+
+const (
+	defaultReadConsistency  = gocql.One
+	defaultWriteConsistency = gocql.LocalQuorum
+)
+
+type (
+	// cassandraStore holds the session and the per-store settings shared by the cassandra
+	// persistence managers
+	cassandraStore struct {
+		session         *cassandraSessionManager
+		timeouts        *operationTimeouts
+		readConsistency gocql.Consistency
+		logger          bark.Logger
+	}
+)
+
+func newCassandraStore(cfg config.Cassandra, keyspace string, store string, metricsClient metrics.Client,
+	logger bark.Logger) (cassandraStore, error) {
+	readConsistency, writeConsistency, err := storeConsistency(cfg, store)
+	if err != nil {
+		return cassandraStore{}, err
+	}
+	fallbackConsistency, err := routingFallbackConsistency(cfg.Routing)
+	if err != nil {
+		return cassandraStore{}, err
+	}
+	timeouts := newOperationTimeouts(cfg.Timeouts)
+
+	cluster := common.NewCassandraCluster(cfg.Hosts, cfg.Datacenter, cfg.Routing.RemoteDatacenters)
+	cluster.Keyspace = keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = writeConsistency
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = timeouts.sessionTimeout()
+	var keyspaceMetricsClient metrics.Client
+	if metricsClient != nil {
+		keyspaceMetricsClient = metricsClient.Tagged(map[string]string{metrics.KeyspaceTagName: keyspace})
+		cluster.PoolConfig.HostSelectionPolicy = newHostMetricsPolicy(cluster.PoolConfig.HostSelectionPolicy,
+			keyspaceMetricsClient)
+	}
+	if cfg.SlowQueryThreshold > 0 {
+		cluster.PoolConfig.HostSelectionPolicy = newSlowQueryTracingPolicy(cluster.PoolConfig.HostSelectionPolicy,
+			cfg.SlowQueryThreshold, keyspaceMetricsClient, logger)
+	}
+
+	session, err := newCassandraSessionManager(cluster, fallbackConsistency, cfg.Retry, metricsClient, logger)
+	if err != nil {
+		return cassandraStore{}, err
+	}
+	session.prepare(hotStatements[store])
+
+	return cassandraStore{
+		session:         session,
+		timeouts:        timeouts,
+		readConsistency: readConsistency,
+		logger:          logger,
+	}, nil
+}
+
+// getReadConsistency returns the read consistency of the store, downgraded along with the queries
+func (s *cassandraStore) getReadConsistency() gocql.Consistency {
+	return s.session.consistency(s.readConsistency)
+}
+
+// Close closes the session of the store
+func (s *cassandraStore) Close() {
+	s.session.Close()
+}
+
+// storeConsistency returns the read and write consistency levels configured for the store,
+// falling back to the defaults for any level that is not set
+func storeConsistency(cfg config.Cassandra, store string) (gocql.Consistency, gocql.Consistency, error) {
+	readConsistency := defaultReadConsistency
+	writeConsistency := defaultWriteConsistency
+
+	consistency, ok := cfg.StoreConsistency[store]
+	if !ok {
+		return readConsistency, writeConsistency, nil
+	}
+
+	var err error
+	if consistency.Read != "" {
+		if readConsistency, err = gocql.ParseConsistencyWrapper(consistency.Read); err != nil {
+			return 0, 0, fmt.Errorf("invalid read consistency for %v store: %v", store, err)
+		}
+	}
+	if consistency.Write != "" {
+		if writeConsistency, err = gocql.ParseConsistencyWrapper(consistency.Write); err != nil {
+			return 0, 0, fmt.Errorf("invalid write consistency for %v store: %v", store, err)
+		}
+	}
+	return readConsistency, writeConsistency, nil
+}
+
+// routingFallbackConsistency returns the consistency level queries are downgraded to while they
+// fall back to a remote datacenter, nil if they are not downgraded
+func routingFallbackConsistency(routing config.CassandraRouting) (*gocql.Consistency, error) {
+	if routing.FallbackConsistency == "" {
+		return nil, nil
+	}
+	consistency, err := gocql.ParseConsistencyWrapper(routing.FallbackConsistency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fallback consistency: %v", err)
+	}
+	return &consistency, nil
+}
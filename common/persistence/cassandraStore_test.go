@@ -0,0 +1,104 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	cassandraStoreSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestCassandraStoreSuite(t *testing.T) {
+	s := new(cassandraStoreSuite)
+	suite.Run(t, s)
+}
+
+func (s *cassandraStoreSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func (s *cassandraStoreSuite) TestStoreConsistencyDefaults() {
+	read, write, err := storeConsistency(config.Cassandra{}, VisibilityStoreName)
+	s.Nil(err)
+	s.Equal(gocql.One, read)
+	s.Equal(gocql.LocalQuorum, write)
+}
+
+func (s *cassandraStoreSuite) TestStoreConsistencyOverride() {
+	cfg := config.Cassandra{
+		StoreConsistency: map[string]config.CassandraStoreConsistency{
+			VisibilityStoreName: {Read: "LOCAL_QUORUM"},
+			ShardStoreName:      {Read: "local_one", Write: "QUORUM"},
+		},
+	}
+
+	read, write, err := storeConsistency(cfg, VisibilityStoreName)
+	s.Nil(err)
+	s.Equal(gocql.LocalQuorum, read)
+	s.Equal(gocql.LocalQuorum, write)
+
+	read, write, err = storeConsistency(cfg, ShardStoreName)
+	s.Nil(err)
+	s.Equal(gocql.LocalOne, read)
+	s.Equal(gocql.Quorum, write)
+
+	read, write, err = storeConsistency(cfg, ExecutionStoreName)
+	s.Nil(err)
+	s.Equal(gocql.One, read)
+	s.Equal(gocql.LocalQuorum, write)
+}
+
+func (s *cassandraStoreSuite) TestStoreConsistencyInvalid() {
+	cfg := config.Cassandra{
+		StoreConsistency: map[string]config.CassandraStoreConsistency{
+			TaskStoreName: {Write: "MOSTLY"},
+		},
+	}
+	_, _, err := storeConsistency(cfg, TaskStoreName)
+	s.NotNil(err)
+}
+
+func (s *cassandraStoreSuite) TestRoutingFallbackConsistency() {
+	consistency, err := routingFallbackConsistency(config.CassandraRouting{})
+	s.Nil(err)
+	s.Nil(consistency)
+
+	consistency, err = routingFallbackConsistency(config.CassandraRouting{FallbackConsistency: "ONE"})
+	s.Nil(err)
+	s.Equal(gocql.One, *consistency)
+
+	_, err = routingFallbackConsistency(config.CassandraRouting{FallbackConsistency: "MOSTLY"})
+	s.NotNil(err)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	defaultReadTimeout      = 10 * time.Second
+	defaultWriteTimeout     = 10 * time.Second
+	defaultRangeScanTimeout = 10 * time.Second
+)
+
+const (
+	// Kinds of cassandra operations, each with its own default timeout
+	readOperation operationKind = iota
+	writeOperation
+	rangeScanOperation
+)
+
+type (
+	operationKind int
+
+	// operationTimeouts resolves the timeout of a cassandra operation from the per-kind
+	// defaults and the per-operation overrides
+	operationTimeouts struct {
+		read      time.Duration
+		write     time.Duration
+		rangeScan time.Duration
+		overrides map[string]time.Duration
+	}
+)
+
+func newOperationTimeouts(cfg config.CassandraTimeouts) *operationTimeouts {
+	t := &operationTimeouts{
+		read:      defaultReadTimeout,
+		write:     defaultWriteTimeout,
+		rangeScan: defaultRangeScanTimeout,
+		overrides: make(map[string]time.Duration),
+	}
+	if cfg.Read > 0 {
+		t.read = cfg.Read
+	}
+	if cfg.Write > 0 {
+		t.write = cfg.Write
+	}
+	if cfg.RangeScan > 0 {
+		t.rangeScan = cfg.RangeScan
+	}
+	for operation, timeout := range cfg.Overrides {
+		if timeout > 0 {
+			t.overrides[operation] = timeout
+		}
+	}
+	return t
+}
+
+func (t *operationTimeouts) timeout(kind operationKind, operation string) time.Duration {
+	if timeout, ok := t.overrides[operation]; ok {
+		return timeout
+	}
+	switch kind {
+	case writeOperation:
+		return t.write
+	case rangeScanOperation:
+		return t.rangeScan
+	default:
+		return t.read
+	}
+}
+
+// withTimeout bounds ctx by the timeout of the operation. A shorter deadline already set
+// on ctx by the caller still applies.
+func (t *operationTimeouts) withTimeout(ctx context.Context, kind operationKind,
+	operation string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, t.timeout(kind, operation))
+}
+
+// sessionTimeout is the gocql per-request timeout for the session. It must not be shorter
+// than any operation timeout, otherwise gocql gives up before the operation deadline.
+func (t *operationTimeouts) sessionTimeout() time.Duration {
+	max := t.read
+	for _, timeout := range []time.Duration{t.write, t.rangeScan} {
+		if timeout > max {
+			max = timeout
+		}
+	}
+	for _, timeout := range t.overrides {
+		if timeout > max {
+			max = timeout
+		}
+	}
+	return max
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
+
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	cassandraTimeoutsSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestCassandraTimeoutsSuite(t *testing.T) {
+	s := new(cassandraTimeoutsSuite)
+	suite.Run(t, s)
+}
+
+func (s *cassandraTimeoutsSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func (s *cassandraTimeoutsSuite) TestDefaults() {
+	t := newOperationTimeouts(config.CassandraTimeouts{})
+	s.Equal(defaultReadTimeout, t.timeout(readOperation, "GetShard"))
+	s.Equal(defaultWriteTimeout, t.timeout(writeOperation, "UpdateShard"))
+	s.Equal(defaultRangeScanTimeout, t.timeout(rangeScanOperation, "GetTimerIndexTasks"))
+	s.Equal(10*time.Second, t.sessionTimeout())
+}
+
+func (s *cassandraTimeoutsSuite) TestOverrides() {
+	t := newOperationTimeouts(config.CassandraTimeouts{
+		Read:      time.Second,
+		Write:     2 * time.Second,
+		RangeScan: 5 * time.Second,
+		Overrides: map[string]time.Duration{
+			"GetTimerIndexTasks": 30 * time.Second,
+			"GetShard":           0,
+		},
+	})
+	s.Equal(time.Second, t.timeout(readOperation, "GetShard"))
+	s.Equal(2*time.Second, t.timeout(writeOperation, "UpdateShard"))
+	s.Equal(5*time.Second, t.timeout(rangeScanOperation, "GetTransferTasks"))
+	s.Equal(30*time.Second, t.timeout(rangeScanOperation, "GetTimerIndexTasks"))
+	s.Equal(30*time.Second, t.sessionTimeout())
+}
+
+func (s *cassandraTimeoutsSuite) TestWithTimeoutKeepsEarlierDeadline() {
+	t := newOperationTimeouts(config.CassandraTimeouts{Read: time.Minute})
+
+	parent, parentCancel := context.WithTimeout(context.Background(), time.Second)
+	defer parentCancel()
+	ctx, cancel := t.withTimeout(parent, readOperation, "GetShard")
+	defer cancel()
+	parentDeadline, _ := parent.Deadline()
+	deadline, ok := ctx.Deadline()
+	s.True(ok)
+	s.Equal(parentDeadline, deadline)
+
+	ctx2, cancel2 := t.withTimeout(context.Background(), readOperation, "GetShard")
+	defer cancel2()
+	deadline2, ok := ctx2.Deadline()
+	s.True(ok)
+	s.True(deadline2.After(time.Now().Add(30 * time.Second)))
+}
@@ -26,9 +26,12 @@ import (
 
 	"github.com/gocql/gocql"
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
 )
 
 // Fixed domain values for now
@@ -49,8 +52,24 @@ const (
 		`AND run_id = ?`
 
 	templateCreateWorkflowExecutionClosed = `INSERT INTO closed_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, close_time, workflow_type_name, status) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+		`domain_id, domain_partition, workflow_id, run_id, start_time, close_time, workflow_type_name, status, close_reason) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+
+	templateCreateWorkflowExecutionClosedByCloseTime = `INSERT INTO closed_executions_by_close_time (` +
+		`domain_id, domain_partition, workflow_id, run_id, start_time, close_time, workflow_type_name, status, close_reason) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+
+	templateDeleteWorkflowExecutionClosed = `DELETE FROM closed_executions ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND start_time = ? ` +
+		`AND run_id = ?`
+
+	templateDeleteWorkflowExecutionClosedByCloseTime = `DELETE FROM closed_executions_by_close_time ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_time = ? ` +
+		`AND run_id = ?`
 
 	templateGetOpenWorkflowExecutions = `SELECT workflow_id, run_id, start_time, workflow_type_name ` +
 		`FROM open_executions ` +
@@ -59,7 +78,7 @@ const (
 		`AND start_time >= ? ` +
 		`AND start_time <= ? `
 
-	templateGetClosedWorkflowExecutions = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status ` +
+	templateGetClosedWorkflowExecutions = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, close_reason ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition IN (?) ` +
@@ -74,7 +93,7 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_type_name = ? `
 
-	templateGetClosedWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status ` +
+	templateGetClosedWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, close_reason ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -90,7 +109,7 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_id = ? `
 
-	templateGetClosedWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status ` +
+	templateGetClosedWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, close_reason ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -98,43 +117,51 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_id = ? `
 
-	templateGetClosedWorkflowExecutionsByStatus = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status ` +
+	templateGetClosedWorkflowExecutionsByStatus = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, close_reason ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
 		`AND start_time >= ? ` +
 		`AND start_time <= ? ` +
 		`AND status = ? `
+
+	templateGetClosedWorkflowExecutionsByCloseTime = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, close_reason ` +
+		`FROM closed_executions_by_close_time ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_time >= ? ` +
+		`AND close_time <= ? `
+
+	templateGetClosedWorkflowExecutionsByCloseTimeAndStatus = templateGetClosedWorkflowExecutionsByCloseTime +
+		`AND status = ? `
+
+	templateGetClosedWorkflowExecutionsSince = templateGetClosedWorkflowExecutionsByCloseTime +
+		`ORDER BY close_time ASC ` +
+		`LIMIT ?`
 )
 
 type (
 	cassandraVisibilityPersistence struct {
-		session      *gocql.Session
-		lowConslevel gocql.Consistency
-		logger       bark.Logger
+		cassandraStore
 	}
 )
 
 // NewCassandraVisibilityPersistence is used to create an instance of VisibilityManager implementation
-func NewCassandraVisibilityPersistence(
-	hosts string, dc string, keyspace string, logger bark.Logger) (VisibilityManager, error) {
-	cluster := common.NewCassandraCluster(hosts, dc)
-	cluster.Keyspace = keyspace
-	cluster.ProtoVersion = cassandraProtoVersion
-	cluster.Consistency = gocql.LocalQuorum
-	cluster.SerialConsistency = gocql.LocalSerial
-	cluster.Timeout = defaultSessionTimeout
-
-	session, err := cluster.CreateSession()
+func NewCassandraVisibilityPersistence(cfg config.Cassandra, metricsClient metrics.Client,
+	logger bark.Logger) (VisibilityManager, error) {
+	store, err := newCassandraStore(cfg, cfg.VisibilityKeyspace, VisibilityStoreName, metricsClient, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	return &cassandraVisibilityPersistence{session: session, lowConslevel: gocql.One, logger: logger}, nil
+	return &cassandraVisibilityPersistence{cassandraStore: store}, nil
 }
 
 func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
-	request *RecordWorkflowExecutionStartedRequest) error {
+	ctx context.Context, request *RecordWorkflowExecutionStartedRequest) error {
+	ctx, cancel := v.timeouts.withTimeout(ctx, writeOperation, "RecordWorkflowExecutionStarted")
+	defer cancel()
+
 	query := v.session.Query(templateCreateWorkflowExecutionStarted,
 		request.DomainUUID,
 		domainPartition,
@@ -142,7 +169,7 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
 		request.Execution.GetRunId(),
 		common.UnixNanoToCQLTimestamp(request.StartTimestamp),
 		request.WorkflowTypeName,
-	)
+	).WithContext(ctx)
 	query = query.WithTimestamp(common.UnixNanoToCQLTimestamp(request.StartTimestamp))
 	err := query.Exec()
 	if err != nil {
@@ -155,8 +182,11 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
 }
 
 func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
-	request *RecordWorkflowExecutionClosedRequest) error {
-	batch := v.session.NewBatch(gocql.LoggedBatch)
+	ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error {
+	ctx, cancel := v.timeouts.withTimeout(ctx, writeOperation, "RecordWorkflowExecutionClosed")
+	defer cancel()
+
+	batch := v.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 
 	// First, remove execution from the open table
 	batch.Query(templateDeleteWorkflowExecutionStarted,
@@ -183,6 +213,21 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 		common.UnixNanoToCQLTimestamp(request.CloseTimestamp),
 		request.WorkflowTypeName,
 		request.Status,
+		request.CloseReason,
+		retention,
+	)
+
+	// Finally, add a row in the table of closed executions by close time
+	batch.Query(templateCreateWorkflowExecutionClosedByCloseTime,
+		request.DomainUUID,
+		domainPartition,
+		request.Execution.GetWorkflowId(),
+		request.Execution.GetRunId(),
+		common.UnixNanoToCQLTimestamp(request.StartTimestamp),
+		common.UnixNanoToCQLTimestamp(request.CloseTimestamp),
+		request.WorkflowTypeName,
+		request.Status,
+		request.CloseReason,
 		retention,
 	)
 
@@ -196,13 +241,44 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 	return nil
 }
 
+func (v *cassandraVisibilityPersistence) DeleteClosedWorkflowExecution(
+	ctx context.Context, request *DeleteClosedWorkflowExecutionRequest) error {
+	ctx, cancel := v.timeouts.withTimeout(ctx, writeOperation, "DeleteClosedWorkflowExecution")
+	defer cancel()
+
+	batch := v.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	batch.Query(templateDeleteWorkflowExecutionClosed,
+		request.DomainUUID,
+		domainPartition,
+		common.UnixNanoToCQLTimestamp(request.StartTimestamp),
+		request.Execution.GetRunId(),
+	)
+	batch.Query(templateDeleteWorkflowExecutionClosedByCloseTime,
+		request.DomainUUID,
+		domainPartition,
+		common.UnixNanoToCQLTimestamp(request.CloseTimestamp),
+		request.Execution.GetRunId(),
+	)
+
+	err := v.session.ExecuteBatch(batch)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteClosedWorkflowExecution operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutions(
-	request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := v.timeouts.withTimeout(ctx, rangeScanOperation, "ListOpenWorkflowExecutions")
+	defer cancel()
+
 	query := v.session.Query(templateGetOpenWorkflowExecutions,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
-		common.UnixNanoToCQLTimestamp(request.LatestStartTime)).Consistency(v.lowConslevel)
+		common.UnixNanoToCQLTimestamp(request.LatestStartTime)).WithContext(ctx).Consistency(v.getReadConsistency())
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		// TODO: should return a bad request error if the token is invalid
@@ -232,12 +308,15 @@ func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutions(
 }
 
 func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutions(
-	request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := v.timeouts.withTimeout(ctx, rangeScanOperation, "ListClosedWorkflowExecutions")
+	defer cancel()
+
 	query := v.session.Query(templateGetClosedWorkflowExecutions,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
-		common.UnixNanoToCQLTimestamp(request.LatestStartTime)).Consistency(v.lowConslevel)
+		common.UnixNanoToCQLTimestamp(request.LatestStartTime)).WithContext(ctx).Consistency(v.getReadConsistency())
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		// TODO: should return a bad request error if the token is invalid
@@ -267,13 +346,16 @@ func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutions(
 }
 
 func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByType(
-	request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := v.timeouts.withTimeout(ctx, rangeScanOperation, "ListOpenWorkflowExecutionsByType")
+	defer cancel()
+
 	query := v.session.Query(templateGetOpenWorkflowExecutionsByType,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
 		common.UnixNanoToCQLTimestamp(request.LatestStartTime),
-		request.WorkflowTypeName).Consistency(v.lowConslevel)
+		request.WorkflowTypeName).WithContext(ctx).Consistency(v.getReadConsistency())
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		// TODO: should return a bad request error if the token is invalid
@@ -303,13 +385,16 @@ func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByType(
 }
 
 func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByType(
-	request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := v.timeouts.withTimeout(ctx, rangeScanOperation, "ListClosedWorkflowExecutionsByType")
+	defer cancel()
+
 	query := v.session.Query(templateGetClosedWorkflowExecutionsByType,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
 		common.UnixNanoToCQLTimestamp(request.LatestStartTime),
-		request.WorkflowTypeName).Consistency(v.lowConslevel)
+		request.WorkflowTypeName).WithContext(ctx).Consistency(v.getReadConsistency())
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		// TODO: should return a bad request error if the token is invalid
@@ -339,13 +424,16 @@ func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByType(
 }
 
 func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByWorkflowID(
-	request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := v.timeouts.withTimeout(ctx, rangeScanOperation, "ListOpenWorkflowExecutionsByWorkflowID")
+	defer cancel()
+
 	query := v.session.Query(templateGetOpenWorkflowExecutionsByID,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
 		common.UnixNanoToCQLTimestamp(request.LatestStartTime),
-		request.WorkflowID).Consistency(v.lowConslevel)
+		request.WorkflowID).WithContext(ctx).Consistency(v.getReadConsistency())
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		// TODO: should return a bad request error if the token is invalid
@@ -375,13 +463,16 @@ func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByWorkflowID(
 }
 
 func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByWorkflowID(
-	request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := v.timeouts.withTimeout(ctx, rangeScanOperation, "ListClosedWorkflowExecutionsByWorkflowID")
+	defer cancel()
+
 	query := v.session.Query(templateGetClosedWorkflowExecutionsByID,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
 		common.UnixNanoToCQLTimestamp(request.LatestStartTime),
-		request.WorkflowID).Consistency(v.lowConslevel)
+		request.WorkflowID).WithContext(ctx).Consistency(v.getReadConsistency())
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		// TODO: should return a bad request error if the token is invalid
@@ -411,13 +502,16 @@ func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByWorkflowI
 }
 
 func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByStatus(
-	request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx context.Context, request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := v.timeouts.withTimeout(ctx, rangeScanOperation, "ListClosedWorkflowExecutionsByStatus")
+	defer cancel()
+
 	query := v.session.Query(templateGetClosedWorkflowExecutionsByStatus,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
 		common.UnixNanoToCQLTimestamp(request.LatestStartTime),
-		request.Status).Consistency(v.lowConslevel)
+		request.Status).WithContext(ctx).Consistency(v.getReadConsistency())
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		// TODO: should return a bad request error if the token is invalid
@@ -446,6 +540,100 @@ func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByStatus(
 	return response, nil
 }
 
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByCloseTime(
+	ctx context.Context, request *ListClosedWorkflowExecutionsByCloseTimeRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := v.timeouts.withTimeout(ctx, rangeScanOperation, "ListClosedWorkflowExecutionsByCloseTime")
+	defer cancel()
+
+	var query *gocql.Query
+	if request.Status != nil {
+		query = v.session.Query(templateGetClosedWorkflowExecutionsByCloseTimeAndStatus,
+			request.DomainUUID,
+			domainPartition,
+			common.UnixNanoToCQLTimestamp(request.EarliestCloseTime),
+			common.UnixNanoToCQLTimestamp(request.LatestCloseTime),
+			*request.Status)
+	} else {
+		query = v.session.Query(templateGetClosedWorkflowExecutionsByCloseTime,
+			request.DomainUUID,
+			domainPartition,
+			common.UnixNanoToCQLTimestamp(request.EarliestCloseTime),
+			common.UnixNanoToCQLTimestamp(request.LatestCloseTime))
+	}
+	query = query.WithContext(ctx).Consistency(v.getReadConsistency())
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		// TODO: should return a bad request error if the token is invalid
+		return nil, &workflow.InternalServiceError{
+			Message: "ListClosedWorkflowExecutionsByCloseTime operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &ListWorkflowExecutionsResponse{}
+	response.Executions = make([]*workflow.WorkflowExecutionInfo, 0)
+	wfexecution, has := readClosedWorkflowExecutionRecord(iter)
+	for has {
+		response.Executions = append(response.Executions, wfexecution)
+		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByCloseTime operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsSince(
+	ctx context.Context, request *ListClosedWorkflowExecutionsSinceRequest) (*ListWorkflowExecutionsResponse, error) {
+	ctx, cancel := v.timeouts.withTimeout(ctx, rangeScanOperation, "ListClosedWorkflowExecutionsSince")
+	defer cancel()
+
+	excluded := make(map[string]struct{}, len(request.ExcludedRunIDs))
+	for _, runID := range request.ExcludedRunIDs {
+		excluded[runID] = struct{}{}
+	}
+
+	// excluded runs share the earliest close time, so they can only show up at the head of the scan
+	limit := request.PageSize + len(excluded)
+	query := v.session.Query(templateGetClosedWorkflowExecutionsSince,
+		request.DomainUUID,
+		domainPartition,
+		common.UnixNanoToCQLTimestamp(request.EarliestCloseTime),
+		common.UnixNanoToCQLTimestamp(request.LatestCloseTime),
+		limit).WithContext(ctx).Consistency(v.getReadConsistency())
+	iter := query.PageSize(limit).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListClosedWorkflowExecutionsSince operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &ListWorkflowExecutionsResponse{}
+	response.Executions = make([]*workflow.WorkflowExecutionInfo, 0)
+	wfexecution, has := readClosedWorkflowExecutionRecord(iter)
+	for has && len(response.Executions) < request.PageSize {
+		if _, ok := excluded[wfexecution.Execution.GetRunId()]; !ok ||
+			wfexecution.GetCloseTime() != request.EarliestCloseTime {
+			response.Executions = append(response.Executions, wfexecution)
+		}
+		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListClosedWorkflowExecutionsSince operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
 func readOpenWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExecutionInfo, bool) {
 	var workflowID string
 	var runID gocql.UUID
@@ -475,7 +663,8 @@ func readClosedWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExec
 	var startTime time.Time
 	var closeTime time.Time
 	var status workflow.WorkflowExecutionCloseStatus
-	if iter.Scan(&workflowID, &runID, &startTime, &closeTime, &typeName, &status) {
+	var closeReason string
+	if iter.Scan(&workflowID, &runID, &startTime, &closeTime, &typeName, &status, &closeReason) {
 		execution := workflow.NewWorkflowExecution()
 		execution.WorkflowId = common.StringPtr(workflowID)
 		execution.RunId = common.StringPtr(runID.String())
@@ -489,6 +678,9 @@ func readClosedWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExec
 		record.CloseTime = common.Int64Ptr(closeTime.UnixNano())
 		record.Type = wfType
 		record.CloseStatus = workflow.WorkflowExecutionCloseStatusPtr(status)
+		if closeReason != "" {
+			record.CloseReason = common.StringPtr(closeReason)
+		}
 		return record, true
 	}
 	return nil, false
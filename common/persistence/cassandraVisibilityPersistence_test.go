@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
 
 	gen "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
@@ -75,7 +77,7 @@ func (s *visibilityPersistenceSuite) TestBasicVisibility() {
 	}
 
 	startTime := time.Now().Add(time.Second * -5).UnixNano()
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution,
 		WorkflowTypeName: "visibility-workflow",
@@ -83,7 +85,7 @@ func (s *visibilityPersistenceSuite) TestBasicVisibility() {
 	})
 	s.Nil(err0)
 
-	resp, err1 := s.VisibilityMgr.ListOpenWorkflowExecutions(&ListWorkflowExecutionsRequest{
+	resp, err1 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime,
@@ -93,16 +95,18 @@ func (s *visibilityPersistenceSuite) TestBasicVisibility() {
 	s.Equal(1, len(resp.Executions))
 	s.Equal(workflowExecution.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
 
-	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution,
 		WorkflowTypeName: "visibility-workflow",
 		StartTimestamp:   startTime,
 		CloseTimestamp:   time.Now().UnixNano(),
+		Status:           gen.WorkflowExecutionCloseStatus_TERMINATED,
+		CloseReason:      "terminate-reason",
 	})
 	s.Nil(err2)
 
-	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(&ListWorkflowExecutionsRequest{
+	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime,
@@ -111,7 +115,7 @@ func (s *visibilityPersistenceSuite) TestBasicVisibility() {
 	s.Nil(err3)
 	s.Equal(0, len(resp.Executions))
 
-	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutions(&ListWorkflowExecutionsRequest{
+	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutions(context.Background(), &ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime,
@@ -119,6 +123,8 @@ func (s *visibilityPersistenceSuite) TestBasicVisibility() {
 	})
 	s.Nil(err4)
 	s.Equal(1, len(resp.Executions))
+	s.Equal(gen.WorkflowExecutionCloseStatus_TERMINATED, resp.Executions[0].GetCloseStatus())
+	s.Equal("terminate-reason", resp.Executions[0].GetCloseReason())
 }
 
 func (s *visibilityPersistenceSuite) TestVisibilityPagination() {
@@ -130,7 +136,7 @@ func (s *visibilityPersistenceSuite) TestVisibilityPagination() {
 		WorkflowId: common.StringPtr("visibility-pagination-test1"),
 		RunId:      common.StringPtr("fb15e4b5-356f-466d-8c6d-a29223e5c536"),
 	}
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow",
@@ -143,7 +149,7 @@ func (s *visibilityPersistenceSuite) TestVisibilityPagination() {
 		WorkflowId: common.StringPtr("visibility-pagination-test2"),
 		RunId:      common.StringPtr("843f6fc7-102a-4c63-a2d4-7c653b01bf52"),
 	}
-	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow",
@@ -152,7 +158,7 @@ func (s *visibilityPersistenceSuite) TestVisibilityPagination() {
 	s.Nil(err1)
 
 	// Get the first one
-	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutions(&ListWorkflowExecutionsRequest{
+	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime1.UnixNano(),
@@ -163,7 +169,7 @@ func (s *visibilityPersistenceSuite) TestVisibilityPagination() {
 	s.Equal(workflowExecution2.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
 
 	// Use token to get the second one
-	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(&ListWorkflowExecutionsRequest{
+	resp, err3 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime1.UnixNano(),
@@ -175,7 +181,7 @@ func (s *visibilityPersistenceSuite) TestVisibilityPagination() {
 	s.Equal(workflowExecution1.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
 
 	// Now should get empty result by using token
-	resp, err4 := s.VisibilityMgr.ListOpenWorkflowExecutions(&ListWorkflowExecutionsRequest{
+	resp, err4 := s.VisibilityMgr.ListOpenWorkflowExecutions(context.Background(), &ListWorkflowExecutionsRequest{
 		DomainUUID:        testDomainUUID,
 		PageSize:          1,
 		EarliestStartTime: startTime1.UnixNano(),
@@ -195,7 +201,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByType() {
 		WorkflowId: common.StringPtr("visibility-filtering-test1"),
 		RunId:      common.StringPtr("fb15e4b5-356f-466d-8c6d-a29223e5c536"),
 	}
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow-1",
@@ -207,7 +213,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByType() {
 		WorkflowId: common.StringPtr("visibility-filtering-test2"),
 		RunId:      common.StringPtr("843f6fc7-102a-4c63-a2d4-7c653b01bf52"),
 	}
-	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow-2",
@@ -216,7 +222,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByType() {
 	s.Nil(err1)
 
 	// List open with filtering
-	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutionsByType(&ListWorkflowExecutionsByTypeRequest{
+	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutionsByType(context.Background(), &ListWorkflowExecutionsByTypeRequest{
 		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -230,7 +236,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByType() {
 	s.Equal(workflowExecution1.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
 
 	// Close both executions
-	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow-1",
@@ -239,7 +245,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByType() {
 	})
 	s.Nil(err3)
 
-	err4 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+	err4 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow-2",
@@ -249,7 +255,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByType() {
 	s.Nil(err4)
 
 	// List closed with filtering
-	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByType(&ListWorkflowExecutionsByTypeRequest{
+	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByType(context.Background(), &ListWorkflowExecutionsByTypeRequest{
 		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -272,7 +278,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByWorkflowID() {
 		WorkflowId: common.StringPtr("visibility-filtering-test1"),
 		RunId:      common.StringPtr("fb15e4b5-356f-466d-8c6d-a29223e5c536"),
 	}
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow",
@@ -284,7 +290,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByWorkflowID() {
 		WorkflowId: common.StringPtr("visibility-filtering-test2"),
 		RunId:      common.StringPtr("843f6fc7-102a-4c63-a2d4-7c653b01bf52"),
 	}
-	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow",
@@ -293,7 +299,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByWorkflowID() {
 	s.Nil(err1)
 
 	// List open with filtering
-	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(&ListWorkflowExecutionsByWorkflowIDRequest{
+	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(context.Background(), &ListWorkflowExecutionsByWorkflowIDRequest{
 		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -307,7 +313,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByWorkflowID() {
 	s.Equal(workflowExecution1.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
 
 	// Close both executions
-	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow",
@@ -316,7 +322,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByWorkflowID() {
 	})
 	s.Nil(err3)
 
-	err4 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+	err4 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow",
@@ -326,7 +332,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByWorkflowID() {
 	s.Nil(err4)
 
 	// List closed with filtering
-	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(&ListWorkflowExecutionsByWorkflowIDRequest{
+	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(context.Background(), &ListWorkflowExecutionsByWorkflowIDRequest{
 		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -349,7 +355,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByCloseStatus() {
 		WorkflowId: common.StringPtr("visibility-filtering-test1"),
 		RunId:      common.StringPtr("fb15e4b5-356f-466d-8c6d-a29223e5c536"),
 	}
-	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow",
@@ -361,7 +367,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByCloseStatus() {
 		WorkflowId: common.StringPtr("visibility-filtering-test2"),
 		RunId:      common.StringPtr("843f6fc7-102a-4c63-a2d4-7c653b01bf52"),
 	}
-	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(context.Background(), &RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow",
@@ -370,7 +376,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByCloseStatus() {
 	s.Nil(err1)
 
 	// Close both executions with different status
-	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution1,
 		WorkflowTypeName: "visibility-workflow",
@@ -380,7 +386,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByCloseStatus() {
 	})
 	s.Nil(err2)
 
-	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution2,
 		WorkflowTypeName: "visibility-workflow",
@@ -391,7 +397,7 @@ func (s *visibilityPersistenceSuite) TestFilteringByCloseStatus() {
 	s.Nil(err3)
 
 	// List closed with filtering
-	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutionsByStatus(&ListClosedWorkflowExecutionsByStatusRequest{
+	resp, err4 := s.VisibilityMgr.ListClosedWorkflowExecutionsByStatus(context.Background(), &ListClosedWorkflowExecutionsByStatusRequest{
 		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
 			DomainUUID:        testDomainUUID,
 			PageSize:          2,
@@ -404,3 +410,118 @@ func (s *visibilityPersistenceSuite) TestFilteringByCloseStatus() {
 	s.Equal(1, len(resp.Executions))
 	s.Equal(workflowExecution2.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
 }
+
+func (s *visibilityPersistenceSuite) TestFilteringByCloseTime() {
+	testDomainUUID := uuid.New()
+	// The first execution starts earlier but closes later than the second one
+	startTime := time.Now().Add(-time.Hour).UnixNano()
+	closeTime := time.Now().UnixNano()
+
+	workflowExecution1 := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-close-time-test1"),
+		RunId:      common.StringPtr("5b1d0d5c-6a7b-4b2e-9c1a-0f43c6d0b0a1"),
+	}
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution1,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		CloseTimestamp:   closeTime,
+		Status:           gen.WorkflowExecutionCloseStatus_FAILED,
+	})
+	s.Nil(err0)
+
+	workflowExecution2 := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-close-time-test2"),
+		RunId:      common.StringPtr("8f6a2c1e-3d4b-4e5f-a6b7-c8d9e0f1a2b3"),
+	}
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution2,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime + int64(time.Minute),
+		CloseTimestamp:   startTime + int64(2*time.Minute),
+		Status:           gen.WorkflowExecutionCloseStatus_COMPLETED,
+	})
+	s.Nil(err1)
+
+	// Only the first execution closed in the last ten minutes
+	resp, err2 := s.VisibilityMgr.ListClosedWorkflowExecutionsByCloseTime(context.Background(),
+		&ListClosedWorkflowExecutionsByCloseTimeRequest{
+			DomainUUID:        testDomainUUID,
+			PageSize:          2,
+			EarliestCloseTime: closeTime - int64(10*time.Minute),
+			LatestCloseTime:   closeTime,
+		})
+	s.Nil(err2)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(workflowExecution1.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
+	s.Equal(startTime, resp.Executions[0].GetStartTime())
+
+	// Both executions closed in the last hour, only the second one completed
+	resp, err3 := s.VisibilityMgr.ListClosedWorkflowExecutionsByCloseTime(context.Background(),
+		&ListClosedWorkflowExecutionsByCloseTimeRequest{
+			DomainUUID:        testDomainUUID,
+			PageSize:          2,
+			EarliestCloseTime: startTime,
+			LatestCloseTime:   closeTime,
+			Status:            gen.WorkflowExecutionCloseStatusPtr(gen.WorkflowExecutionCloseStatus_COMPLETED),
+		})
+	s.Nil(err3)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(workflowExecution2.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
+}
+
+func (s *visibilityPersistenceSuite) TestListClosedSince() {
+	testDomainUUID := uuid.New()
+	closeTime := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UnixNano()
+
+	// The first two executions close at the same time, the third one a second later
+	closeTimes := []int64{closeTime, closeTime, closeTime + int64(time.Second)}
+	executions := make([]gen.WorkflowExecution, len(closeTimes))
+	for i, t := range closeTimes {
+		executions[i] = gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(fmt.Sprintf("visibility-closed-since-test%v", i)),
+			RunId:      common.StringPtr(uuid.New()),
+		}
+		err0 := s.VisibilityMgr.RecordWorkflowExecutionClosed(context.Background(), &RecordWorkflowExecutionClosedRequest{
+			DomainUUID:       testDomainUUID,
+			Execution:        executions[i],
+			WorkflowTypeName: "visibility-workflow",
+			StartTimestamp:   closeTime - int64(time.Minute),
+			CloseTimestamp:   t,
+			Status:           gen.WorkflowExecutionCloseStatus_COMPLETED,
+		})
+		s.Nil(err0)
+	}
+
+	request := &ListClosedWorkflowExecutionsSinceRequest{
+		DomainUUID:        testDomainUUID,
+		EarliestCloseTime: closeTime,
+		LatestCloseTime:   closeTime + int64(time.Minute),
+		PageSize:          1,
+	}
+	var runIDs []string
+	for i := 0; i < 2; i++ {
+		resp, err1 := s.VisibilityMgr.ListClosedWorkflowExecutionsSince(context.Background(), request)
+		s.Nil(err1)
+		s.Equal(1, len(resp.Executions))
+		s.Equal(closeTime, resp.Executions[0].GetCloseTime())
+		runIDs = append(runIDs, resp.Executions[0].Execution.GetRunId())
+		request.ExcludedRunIDs = runIDs
+	}
+	s.NotEqual(runIDs[0], runIDs[1])
+
+	// Both executions closed at the earliest close time are skipped
+	request.PageSize = 10
+	resp, err2 := s.VisibilityMgr.ListClosedWorkflowExecutionsSince(context.Background(), request)
+	s.Nil(err2)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(executions[2].GetRunId(), resp.Executions[0].Execution.GetRunId())
+
+	// Excluded runs only apply at the earliest close time
+	request.EarliestCloseTime = closeTime - int64(time.Second)
+	resp, err3 := s.VisibilityMgr.ListClosedWorkflowExecutionsSince(context.Background(), request)
+	s.Nil(err3)
+	s.Equal(3, len(resp.Executions))
+}
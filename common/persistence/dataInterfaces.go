@@ -24,15 +24,21 @@ import (
 	"fmt"
 	"time"
 
+	"golang.org/x/net/context"
+
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 )
 
+// StoreTypeCassandra is the type of the cassandra persistence store
+const StoreTypeCassandra = "cassandra"
+
 // Domain status
 const (
 	DomainStatusRegistered = iota
 	DomainStatusDeprecated
 	DomainStatusDeleted
+	DomainStatusDraining
 )
 
 // Workflow execution states
@@ -51,9 +57,10 @@ const (
 	WorkflowCloseStatusTerminated
 	WorkflowCloseStatusContinuedAsNew
 	WorkflowCloseStatusTimedOut
+	WorkflowCloseStatusTerminatedStuck
 )
 
-// Types of task lists
+// Types of task lists, a task list holds the tasks of a single type
 const (
 	TaskListTypeDecision = iota
 	TaskListTypeActivity
@@ -66,6 +73,8 @@ const (
 	TransferTaskTypeDeleteExecution
 	TransferTaskTypeCancelExecution
 	TransferTaskTypeStartChildExecution
+	TransferTaskTypeRecordWorkflowStarted
+	TransferTaskTypeActivityTaskContinuation
 )
 
 // Types of timers
@@ -73,8 +82,20 @@ const (
 	TaskTypeDecisionTimeout = iota
 	TaskTypeActivityTimeout
 	TaskTypeUserTimer
+	TaskTypeFirstDecisionBackoff
+	TaskTypeDecisionRetryBackoff
+	TaskTypeDeleteHistoryEvent
+)
+
+// Timer queues, the timer tasks of a shard are kept apart by the queue firing them
+const (
+	TimerQueueActive = iota
+	TimerQueueCron
 )
 
+// TimerQueues are all timer queues of a shard
+var TimerQueues = []int{TimerQueueActive, TimerQueueCron}
+
 type (
 	// ConditionFailedError represents a failed conditional put
 	ConditionFailedError struct {
@@ -105,6 +126,13 @@ type (
 		StolenSinceRenew int
 		UpdatedAt        time.Time
 		TransferAckLevel int64
+		// TimerAckLevel is the fire time, in UnixNano, up to which the timers of the shard were due and processed
+		TimerAckLevel int64
+		// TimerAckLevels holds the ack levels of the timer queues of the shard other than the active one, by name
+		TimerAckLevels map[string]int64
+		// QueuePaused holds the paused state of the queue processors of the shard set through the
+		// UpdateQueueProcessing admin API, by queue type.  Queues missing from it start in the configured state
+		QueuePaused map[string]bool
 	}
 
 	// WorkflowExecutionInfo describes a workflow execution
@@ -132,6 +160,16 @@ type (
 		DecisionStartedID    int64
 		DecisionRequestID    string
 		DecisionTimeout      int32
+		DecisionAttempt      int64
+		// DecisionScheduledTime and DecisionStartedTime are when the pending decision was scheduled and started
+		DecisionScheduledTime time.Time
+		DecisionStartedTime   time.Time
+		BuildID               string
+		// Counters of the signals, activities, timers and decisions of the execution, for metering
+		SignalCount   int64
+		ActivityCount int64
+		TimerCount    int64
+		DecisionCount int64
 	}
 
 	// TransferTaskInfo describes a transfer task
@@ -146,6 +184,9 @@ type (
 		TaskList         string
 		TaskType         int
 		ScheduleID       int64
+		// VisibilityTimestamp is when the task was written to the transfer queue, zero for tasks written before it
+		// was recorded
+		VisibilityTimestamp time.Time
 	}
 
 	// TimerTaskInfo describes a timer task.
@@ -161,11 +202,16 @@ type (
 
 	// TaskListInfo describes a state of a task list implementation.
 	TaskListInfo struct {
-		DomainID string
-		Name     string
-		TaskType int
-		RangeID  int64
-		AckLevel int64
+		DomainID    string
+		Name        string
+		TaskType    int
+		RangeID     int64
+		AckLevel    int64
+		LastUpdated time.Time
+		// Pollers are the identities of the pollers seen on the task list with the time of their last poll
+		Pollers map[string]time.Time
+		// HighPriorityAckLevel is the ack level of the tasks with a positive priority, which are stored apart
+		HighPriorityAckLevel int64
 	}
 
 	// TaskInfo describes either activity or decision task
@@ -176,6 +222,16 @@ type (
 		TaskID                 int64
 		ScheduleID             int64
 		ScheduleToStartTimeout int32
+		// BuildID is set on decision tasks which must only be dispatched to workers compatible with the build
+		BuildID string
+		// Priority of activity tasks, tasks with a positive priority are dispatched ahead of the others
+		Priority int32
+		// SessionID is set on activity tasks which must be dispatched to the poller owning the session
+		SessionID string
+		// Payload is the envelope of the data of task types which carry more than the scheduled event
+		Payload []byte
+		// QueryID is set on query tasks, they are only matched to pollers and never persisted
+		QueryID string
 	}
 
 	// Task is the generic interface for workflow tasks
@@ -208,8 +264,9 @@ type (
 
 	// DecisionTimeoutTask identifies a timeout task.
 	DecisionTimeoutTask struct {
-		TaskID  int64
-		EventID int64
+		TaskID      int64
+		TimeoutType int
+		EventID     int64
 	}
 
 	// CancelExecutionTask identifies a transfer task for cancel of execution
@@ -229,6 +286,19 @@ type (
 		InitiatedID      int64
 	}
 
+	// RecordWorkflowStartedTask identifies a transfer task for recording the start of an execution in visibility
+	RecordWorkflowStartedTask struct {
+		TaskID int64
+	}
+
+	// ActivityTaskContinuationTask identifies a transfer task for writing the activity tasks of the activities scheduled
+	// by a decision which did not fit in the update completing the decision, starting with the activity scheduled at
+	// ScheduleID.  Those activities are marked with DispatchDeferred until their tasks are written.
+	ActivityTaskContinuationTask struct {
+		TaskID     int64
+		ScheduleID int64
+	}
+
 	// ActivityTimeoutTask identifies a timeout task.
 	ActivityTimeoutTask struct {
 		TaskID      int64
@@ -242,12 +312,38 @@ type (
 		EventID int64
 	}
 
+	// FirstDecisionBackoffTask identifies a timer task dispatching the first decision of a delayed workflow execution.
+	FirstDecisionBackoffTask struct {
+		TaskID  int64
+		EventID int64
+	}
+
+	// DecisionRetryBackoffTask identifies a timer task dispatching a decision which is retried after failures.
+	DecisionRetryBackoffTask struct {
+		TaskID  int64
+		EventID int64
+	}
+
+	// DeleteHistoryEventTask identifies a timer task deleting the history and mutable state of a closed workflow
+	// execution once the retention period of its domain has passed.
+	DeleteHistoryEventTask struct {
+		TaskID int64
+	}
+
 	// WorkflowMutableState indicates workflow related state
 	WorkflowMutableState struct {
 		ActivitInfos        map[int64]*ActivityInfo
 		TimerInfos          map[string]*TimerInfo
 		ChildExecutionInfos map[int64]*ChildExecutionInfo
+		RequestCancelInfos  map[int64]*RequestCancelInfo
 		ExecutionInfo       *WorkflowExecutionInfo
+		// BufferedEvents are the batches of events received while a decision task was in flight, which are not part
+		// of the history yet
+		BufferedEvents []*SerializedHistoryEventBatch
+		// SignalReceipts maps the request IDs of the signals recorded by the execution to the IDs of their events
+		SignalReceipts map[string]int64
+		// Checksum is the checksum written by the last update of the execution, zero if none was written
+		Checksum int64
 	}
 
 	// ActivityInfo details.
@@ -256,6 +352,7 @@ type (
 		ScheduledEvent           []byte
 		StartedID                int64
 		StartedEvent             []byte
+		StartedTime              time.Time
 		ActivityID               string
 		RequestID                string
 		Details                  []byte
@@ -266,6 +363,10 @@ type (
 		CancelRequested          bool
 		CancelRequestID          int64
 		LastHeartBeatUpdatedTime time.Time
+		Priority                 int32
+		SessionID                string
+		// DispatchDeferred is set on activities whose tasks are written by an activity task continuation
+		DispatchDeferred bool
 	}
 
 	// TimerInfo details - metadata about user timer info.
@@ -285,6 +386,12 @@ type (
 		CreateRequestID string
 	}
 
+	// RequestCancelInfo has details for pending external workflow cancellations
+	RequestCancelInfo struct {
+		InitiatedID     int64
+		CancelRequestID string
+	}
+
 	// CreateShardRequest is used to create a shard in executions table
 	CreateShardRequest struct {
 		ShardInfo *ShardInfo
@@ -306,6 +413,22 @@ type (
 		PreviousRangeID int64
 	}
 
+	// AllocateTaskIDsRequest is used to allocate the next range of task IDs of a shard.  The shard is written with its
+	// range ID bumped, on condition that nobody else bumped it since ShardInfo was read.
+	AllocateTaskIDsRequest struct {
+		ShardInfo *ShardInfo
+		// RangeSizeBits is the number of bits of task IDs taken up by the sequence number within a range
+		RangeSizeBits uint
+	}
+
+	// AllocateTaskIDsResponse is the response to AllocateTaskIDs.  Task IDs from MinTaskID up to, but not including,
+	// MaxTaskID are owned by the caller, as long as the range ID of the shard stays RangeID.
+	AllocateTaskIDsResponse struct {
+		RangeID   int64
+		MinTaskID int64
+		MaxTaskID int64
+	}
+
 	// CreateWorkflowExecutionRequest is used to write a new workflow execution
 	CreateWorkflowExecutionRequest struct {
 		RequestID                   string
@@ -366,6 +489,9 @@ type (
 		RangeID         int64
 		ContinueAsNew   *CreateWorkflowExecutionRequest
 		CloseExecution  bool
+		// Checksum over the mutable state after the update, verified when the execution is loaded.  Zero skips the
+		// verification.
+		Checksum int64
 
 		// Mutable state
 		UpsertActivityInfos       []*ActivityInfo
@@ -374,11 +500,20 @@ type (
 		DeleteTimerInfos          []string
 		UpsertChildExecutionInfos []*ChildExecutionInfo
 		DeleteChildExecutionInfo  *int64
+		UpsertRequestCancelInfos  []*RequestCancelInfo
+		DeleteRequestCancelInfo   *int64
+		// NewBufferedEvents is appended to the buffered events of the execution, ClearBufferedEvents drops them once
+		// they are flushed to the history
+		NewBufferedEvents    *SerializedHistoryEventBatch
+		ClearBufferedEvents  bool
+		UpsertSignalReceipts map[string]int64
+		DeleteSignalReceipts []string
 	}
 
 	// DeleteWorkflowExecutionRequest is used to delete a workflow execution
 	DeleteWorkflowExecutionRequest struct {
 		ExecutionInfo *WorkflowExecutionInfo
+		RangeID       int64
 	}
 
 	// GetTransferTasksRequest is used to read tasks from the transfer task queue
@@ -398,11 +533,51 @@ type (
 		TaskID int64
 	}
 
+	// PutTransferDLQTaskRequest is used to park a transfer task which couldn't be processed in the dead letter queue
+	// of the shard
+	PutTransferDLQTaskRequest struct {
+		TaskInfo *TransferTaskInfo
+		RangeID  int64
+	}
+
+	// GetTransferDLQTasksRequest is used to read the transfer tasks parked in the dead letter queue of the shard
+	GetTransferDLQTasksRequest struct {
+		ReadLevel int64
+		BatchSize int
+	}
+
+	// GetTransferDLQTasksResponse is the response to GetTransferDLQTasksRequest
+	GetTransferDLQTasksResponse struct {
+		Tasks []*TransferTaskInfo
+	}
+
+	// RedriveTransferDLQTaskRequest is used to move a transfer task parked in the dead letter queue of the shard back
+	// to its transfer queue, under a new task ID
+	RedriveTransferDLQTaskRequest struct {
+		TaskInfo  *TransferTaskInfo
+		NewTaskID int64
+		RangeID   int64
+	}
+
 	// CompleteTimerTaskRequest is used to complete a task in the timer task queue
 	CompleteTimerTaskRequest struct {
+		// Queue is the timer queue the task was read from
+		Queue  int
 		TaskID int64
 	}
 
+	// ListExecutionsRequest is used to page through the mutable state of all workflow executions of a shard
+	ListExecutionsRequest struct {
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListExecutionsResponse is the response to ListExecutionsRequest
+	ListExecutionsResponse struct {
+		Executions    []*WorkflowExecutionInfo
+		NextPageToken []byte
+	}
+
 	// LeaseTaskListRequest is used to request lease of a task list
 	LeaseTaskListRequest struct {
 		DomainID string
@@ -424,6 +599,27 @@ type (
 	UpdateTaskListResponse struct {
 	}
 
+	// ListTaskListsRequest is used to page through the task lists of a domain, or of all domains if DomainID is empty
+	ListTaskListsRequest struct {
+		DomainID      string
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListTaskListsResponse is the response to ListTaskListsRequest
+	ListTaskListsResponse struct {
+		TaskLists     []*TaskListInfo
+		NextPageToken []byte
+	}
+
+	// DeleteTaskListRequest is used to delete a task list and all its tasks
+	DeleteTaskListRequest struct {
+		DomainID string
+		TaskList string
+		TaskType int
+		RangeID  int64
+	}
+
 	// CreateTasksRequest is used to create a new task for a workflow exectution
 	CreateTasksRequest struct {
 		DomainID     string
@@ -453,6 +649,10 @@ type (
 		MaxReadLevel int64 // inclusive
 		BatchSize    int
 		RangeID      int64
+		// HighPriority reads the tasks created with a positive priority, which are stored apart from the others so
+		// they are read ahead of a backlog of normal priority tasks.  Tasks created before they were stored apart
+		// are read with the others.
+		HighPriority bool
 	}
 
 	// GetTasksResponse is the response to GetTasksRequests
@@ -464,14 +664,21 @@ type (
 	CompleteTaskRequest struct {
 		TaskList *TaskListInfo
 		TaskID   int64
+		// HighPriority is set for tasks read with GetTasksRequest.HighPriority
+		HighPriority bool
 	}
 
 	// GetTimerIndexTasksRequest is the request for GetTimerIndexTasks
 	// TODO: replace this with an iterator that can configure min and max index.
 	GetTimerIndexTasksRequest struct {
+		// Queue is the timer queue to read, each queue only holds its own timer tasks
+		Queue     int
 		MinKey    int64
 		MaxKey    int64
 		BatchSize int
+		// TaskIDs, if set, reads the timer tasks with these IDs in a single round trip, instead of the timer tasks
+		// between MinKey and MaxKey.  BatchSize only limits the range reads
+		TaskIDs []int64
 	}
 
 	// GetTimerIndexTasksResponse is the response for GetTimerIndexTasks
@@ -507,6 +714,8 @@ type (
 	GetWorkflowExecutionHistoryRequest struct {
 		DomainID  string
 		Execution workflow.WorkflowExecution
+		// Get the history events from the batch starting at FirstEventID.  Inclusive, zero reads from the beginning.
+		FirstEventID int64
 		// Get the history events upto NextEventID.  Not Inclusive.
 		NextEventID int64
 		// Maximum number of history append transactions per page
@@ -530,6 +739,27 @@ type (
 		Execution workflow.WorkflowExecution
 	}
 
+	// ListWorkflowExecutionHistoriesRequest is used to page through the workflow execution histories.  Histories are
+	// split into NumPartitions disjoint partitions and only the ones in Partition are returned.
+	ListWorkflowExecutionHistoriesRequest struct {
+		Partition     int
+		NumPartitions int
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListWorkflowExecutionHistoriesResponse is the response to ListWorkflowExecutionHistoriesRequest
+	ListWorkflowExecutionHistoriesResponse struct {
+		Histories     []*WorkflowExecutionHistoryInfo
+		NextPageToken []byte
+	}
+
+	// WorkflowExecutionHistoryInfo identifies the history of a workflow execution
+	WorkflowExecutionHistoryInfo struct {
+		DomainID  string
+		Execution workflow.WorkflowExecution
+	}
+
 	// DomainInfo describes the domain entity
 	DomainInfo struct {
 		ID          string
@@ -543,6 +773,25 @@ type (
 	DomainConfig struct {
 		Retention  int32
 		EmitMetric bool
+		// BadBinaries are checksums of worker binaries whose decisions are failed by history
+		BadBinaries []string
+		// MaxDecisionAttempts is the number of consecutive failed decision attempts after which history terminates
+		// an execution as stuck, zero disables the policy
+		MaxDecisionAttempts int32
+		// ActivityTimeoutDefaults are applied to activities scheduled without the corresponding timeout
+		ActivityTimeoutDefaults ActivityTimeouts
+		// ActivityTimeoutCaps are the largest timeouts an activity can be scheduled with
+		ActivityTimeoutCaps ActivityTimeouts
+		// MaxOpenExecutions caps the executions of the domain open at the same time, zero disables the cap
+		MaxOpenExecutions int32
+	}
+
+	// ActivityTimeouts holds a value in seconds for each activity timeout, zero means not configured
+	ActivityTimeouts struct {
+		ScheduleToClose int32
+		ScheduleToStart int32
+		StartToClose    int32
+		Heartbeat       int32
 	}
 
 	// CreateDomainRequest is used to create the domain
@@ -588,26 +837,52 @@ type (
 		Name string
 	}
 
+	// GetOpenExecutionCountRequest is used to read the count of the open executions of a domain
+	GetOpenExecutionCountRequest struct {
+		DomainID string
+	}
+
+	// GetOpenExecutionCountResponse is the response to GetOpenExecutionCountRequest
+	GetOpenExecutionCountResponse struct {
+		Count int64
+	}
+
+	// UpdateOpenExecutionCountRequest is used to add Delta to the count of the open executions of a domain
+	UpdateOpenExecutionCountRequest struct {
+		DomainID string
+		Delta    int64
+	}
+
 	// ShardManager is used to manage all shards
 	ShardManager interface {
-		CreateShard(request *CreateShardRequest) error
-		GetShard(request *GetShardRequest) (*GetShardResponse, error)
-		UpdateShard(request *UpdateShardRequest) error
+		CreateShard(ctx context.Context, request *CreateShardRequest) error
+		GetShard(ctx context.Context, request *GetShardRequest) (*GetShardResponse, error)
+		UpdateShard(ctx context.Context, request *UpdateShardRequest) error
+		AllocateTaskIDs(ctx context.Context, request *AllocateTaskIDsRequest) (*AllocateTaskIDsResponse, error)
 	}
 
 	// ExecutionManager is used to manage workflow executions
 	ExecutionManager interface {
-		CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
-		GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error)
-		UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) error
-		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
-		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
-		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
-		CompleteTransferTask(request *CompleteTransferTaskRequest) error
+		CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
+		GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error)
+		UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) error
+		DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error
+		GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
+		GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
+		CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error
+		PutTransferDLQTask(ctx context.Context, request *PutTransferDLQTaskRequest) error
+		GetTransferDLQTasks(ctx context.Context, request *GetTransferDLQTasksRequest) (*GetTransferDLQTasksResponse, error)
+		RedriveTransferDLQTask(ctx context.Context, request *RedriveTransferDLQTaskRequest) error
 
 		// Timer related methods.
-		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
-		CompleteTimerTask(request *CompleteTimerTaskRequest) error
+		GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
+		CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error
+
+		// ListExecutions pages through the mutable state of all executions of the shard, for background scans
+		ListExecutions(ctx context.Context, request *ListExecutionsRequest) (*ListExecutionsResponse, error)
+
+		// Close releases the connections of the manager once the shard is unloaded
+		Close()
 	}
 
 	// ExecutionManagerFactory creates an instance of ExecutionManager for a given shard
@@ -615,34 +890,62 @@ type (
 		CreateExecutionManager(shardID int) (ExecutionManager, error)
 	}
 
+	// Factory creates the persistence managers used by the services, services get it from the resources of the
+	// service so they can be assembled on top of other implementations of the stores
+	Factory interface {
+		ExecutionManagerFactory
+		NewShardManager() (ShardManager, error)
+		NewMetadataManager() (MetadataManager, error)
+		NewVisibilityManager() (VisibilityManager, error)
+		NewHistoryManager() (HistoryManager, error)
+		NewTaskManager() (TaskManager, error)
+	}
+
 	// TaskManager is used to manage tasks
 	TaskManager interface {
-		LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error)
-		UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error)
-		CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error)
-		GetTasks(request *GetTasksRequest) (*GetTasksResponse, error)
-		CompleteTask(request *CompleteTaskRequest) error
+		LeaseTaskList(ctx context.Context, request *LeaseTaskListRequest) (*LeaseTaskListResponse, error)
+		UpdateTaskList(ctx context.Context, request *UpdateTaskListRequest) (*UpdateTaskListResponse, error)
+		ListTaskLists(ctx context.Context, request *ListTaskListsRequest) (*ListTaskListsResponse, error)
+		DeleteTaskList(ctx context.Context, request *DeleteTaskListRequest) error
+		CreateTasks(ctx context.Context, request *CreateTasksRequest) (*CreateTasksResponse, error)
+		GetTasks(ctx context.Context, request *GetTasksRequest) (*GetTasksResponse, error)
+		CompleteTask(ctx context.Context, request *CompleteTaskRequest) error
 	}
 
 	// HistoryManager is used to manage Workflow Execution HistoryEventBatch
 	HistoryManager interface {
-		AppendHistoryEvents(request *AppendHistoryEventsRequest) error
+		AppendHistoryEvents(ctx context.Context, request *AppendHistoryEventsRequest) error
 		// GetWorkflowExecutionHistory retrieves the paginated list of history events for given execution
-		GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse,
+		GetWorkflowExecutionHistory(ctx context.Context, request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse,
 			error)
-		DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error
+		DeleteWorkflowExecutionHistory(ctx context.Context, request *DeleteWorkflowExecutionHistoryRequest) error
+		// ListWorkflowExecutionHistories pages through the histories of one partition, for background scans
+		ListWorkflowExecutionHistories(ctx context.Context, request *ListWorkflowExecutionHistoriesRequest) (
+			*ListWorkflowExecutionHistoriesResponse, error)
 	}
 
 	// MetadataManager is used to manage metadata CRUD for various entities
 	MetadataManager interface {
-		CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error)
-		GetDomain(request *GetDomainRequest) (*GetDomainResponse, error)
-		UpdateDomain(request *UpdateDomainRequest) error
-		DeleteDomain(request *DeleteDomainRequest) error
-		DeleteDomainByName(request *DeleteDomainByNameRequest) error
+		CreateDomain(ctx context.Context, request *CreateDomainRequest) (*CreateDomainResponse, error)
+		GetDomain(ctx context.Context, request *GetDomainRequest) (*GetDomainResponse, error)
+		UpdateDomain(ctx context.Context, request *UpdateDomainRequest) error
+		DeleteDomain(ctx context.Context, request *DeleteDomainRequest) error
+		DeleteDomainByName(ctx context.Context, request *DeleteDomainByNameRequest) error
+		GetOpenExecutionCount(ctx context.Context, request *GetOpenExecutionCountRequest) (
+			*GetOpenExecutionCountResponse, error)
+		UpdateOpenExecutionCount(ctx context.Context, request *UpdateOpenExecutionCountRequest) error
 	}
 )
 
+// GetTimerQueue returns the timer queue firing timer tasks of the given type.  Executions scheduled to start at the
+// same time are kept in a queue of their own, so that mass starts don't delay the timeouts of running executions.
+func GetTimerQueue(taskType int) int {
+	if taskType == TaskTypeFirstDecisionBackoff {
+		return TimerQueueCron
+	}
+	return TimerQueueActive
+}
+
 func (e *ConditionFailedError) Error() string {
 	return e.Msg
 }
@@ -749,6 +1052,51 @@ func (u *UserTimerTask) SetTaskID(id int64) {
 	u.TaskID = id
 }
 
+// GetType returns the type of the timer task
+func (f *FirstDecisionBackoffTask) GetType() int {
+	return TaskTypeFirstDecisionBackoff
+}
+
+// GetTaskID returns the sequence ID of the timer task.
+func (f *FirstDecisionBackoffTask) GetTaskID() int64 {
+	return f.TaskID
+}
+
+// SetTaskID sets the sequence ID of the timer task.
+func (f *FirstDecisionBackoffTask) SetTaskID(id int64) {
+	f.TaskID = id
+}
+
+// GetType returns the type of the timer task
+func (d *DecisionRetryBackoffTask) GetType() int {
+	return TaskTypeDecisionRetryBackoff
+}
+
+// GetTaskID returns the sequence ID of the timer task.
+func (d *DecisionRetryBackoffTask) GetTaskID() int64 {
+	return d.TaskID
+}
+
+// SetTaskID sets the sequence ID of the timer task.
+func (d *DecisionRetryBackoffTask) SetTaskID(id int64) {
+	d.TaskID = id
+}
+
+// GetType returns the type of the timer task
+func (d *DeleteHistoryEventTask) GetType() int {
+	return TaskTypeDeleteHistoryEvent
+}
+
+// GetTaskID returns the sequence ID of the timer task.
+func (d *DeleteHistoryEventTask) GetTaskID() int64 {
+	return d.TaskID
+}
+
+// SetTaskID sets the sequence ID of the timer task.
+func (d *DeleteHistoryEventTask) SetTaskID(id int64) {
+	d.TaskID = id
+}
+
 // GetType returns the type of the cancel transfer task
 func (u *CancelExecutionTask) GetType() int {
 	return TransferTaskTypeCancelExecution
@@ -779,6 +1127,36 @@ func (u *StartChildExecutionTask) SetTaskID(id int64) {
 	u.TaskID = id
 }
 
+// GetType returns the type of the record workflow started transfer task
+func (r *RecordWorkflowStartedTask) GetType() int {
+	return TransferTaskTypeRecordWorkflowStarted
+}
+
+// GetTaskID returns the sequence ID of the record workflow started transfer task
+func (r *RecordWorkflowStartedTask) GetTaskID() int64 {
+	return r.TaskID
+}
+
+// SetTaskID sets the sequence ID of the record workflow started transfer task
+func (r *RecordWorkflowStartedTask) SetTaskID(id int64) {
+	r.TaskID = id
+}
+
+// GetType returns the type of the activity task continuation transfer task
+func (a *ActivityTaskContinuationTask) GetType() int {
+	return TransferTaskTypeActivityTaskContinuation
+}
+
+// GetTaskID returns the sequence ID of the activity task continuation transfer task
+func (a *ActivityTaskContinuationTask) GetTaskID() int64 {
+	return a.TaskID
+}
+
+// SetTaskID sets the sequence ID of the activity task continuation transfer task
+func (a *ActivityTaskContinuationTask) SetTaskID(id int64) {
+	a.TaskID = id
+}
+
 // NewHistoryEventBatch returns a new instance of HistoryEventBatch
 func NewHistoryEventBatch(version int, events []*workflow.HistoryEvent) *HistoryEventBatch {
 	return &HistoryEventBatch{
@@ -21,8 +21,11 @@
 package persistence
 
 import (
+	"golang.org/x/net/context"
+
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/tracing"
 )
 
 type (
@@ -98,12 +101,14 @@ func NewMetadataPersistenceClient(persistence MetadataManager, metricClient metr
 	}
 }
 
-func (p *shardPersistenceClient) CreateShard(request *CreateShardRequest) error {
+func (p *shardPersistenceClient) CreateShard(ctx context.Context, request *CreateShardRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCreateShardScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.CreateShard")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateShardScope, metrics.PersistenceLatency)
-	err := p.persistence.CreateShard(request)
+	err := p.persistence.CreateShard(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		if _, ok := err.(*ShardAlreadyExistError); ok {
@@ -117,12 +122,14 @@ func (p *shardPersistenceClient) CreateShard(request *CreateShardRequest) error
 }
 
 func (p *shardPersistenceClient) GetShard(
-	request *GetShardRequest) (*GetShardResponse, error) {
+	ctx context.Context, request *GetShardRequest) (*GetShardResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetShardScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetShard")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetShardScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetShard(request)
+	response, err := p.persistence.GetShard(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		switch err.(type) {
@@ -136,12 +143,14 @@ func (p *shardPersistenceClient) GetShard(
 	return response, err
 }
 
-func (p *shardPersistenceClient) UpdateShard(request *UpdateShardRequest) error {
+func (p *shardPersistenceClient) UpdateShard(ctx context.Context, request *UpdateShardRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateShardScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.UpdateShard")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateShardScope, metrics.PersistenceLatency)
-	err := p.persistence.UpdateShard(request)
+	err := p.persistence.UpdateShard(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		if _, ok := err.(*ShardOwnershipLostError); ok {
@@ -154,12 +163,36 @@ func (p *shardPersistenceClient) UpdateShard(request *UpdateShardRequest) error
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+func (p *shardPersistenceClient) AllocateTaskIDs(ctx context.Context, request *AllocateTaskIDsRequest) (
+	*AllocateTaskIDsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceAllocateTaskIDsScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.AllocateTaskIDs")
+	sw := p.metricClient.StartTimer(metrics.PersistenceAllocateTaskIDsScope, metrics.PersistenceLatency)
+	response, err := p.persistence.AllocateTaskIDs(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		if _, ok := err.(*ShardOwnershipLostError); ok {
+			p.metricClient.IncCounter(metrics.PersistenceAllocateTaskIDsScope,
+				metrics.PersistenceErrShardOwnershipLostCounter)
+		} else {
+			p.metricClient.IncCounter(metrics.PersistenceAllocateTaskIDsScope, metrics.PersistenceFailures)
+		}
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.CreateWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateWorkflowExecutionScope, metrics.PersistenceLatency)
-	response, err := p.persistence.CreateWorkflowExecution(request)
+	response, err := p.persistence.CreateWorkflowExecution(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCreateWorkflowExecutionScope, err)
@@ -168,12 +201,14 @@ func (p *workflowExecutionPersistenceClient) CreateWorkflowExecution(request *Cr
 	return response, err
 }
 
-func (p *workflowExecutionPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+func (p *workflowExecutionPersistenceClient) GetWorkflowExecution(ctx context.Context, request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetWorkflowExecutionScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetWorkflowExecution(request)
+	response, err := p.persistence.GetWorkflowExecution(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetWorkflowExecutionScope, err)
@@ -182,12 +217,14 @@ func (p *workflowExecutionPersistenceClient) GetWorkflowExecution(request *GetWo
 	return response, err
 }
 
-func (p *workflowExecutionPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) error {
+func (p *workflowExecutionPersistenceClient) UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.UpdateWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateWorkflowExecutionScope, metrics.PersistenceLatency)
-	err := p.persistence.UpdateWorkflowExecution(request)
+	err := p.persistence.UpdateWorkflowExecution(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpdateWorkflowExecutionScope, err)
@@ -196,12 +233,14 @@ func (p *workflowExecutionPersistenceClient) UpdateWorkflowExecution(request *Up
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+func (p *workflowExecutionPersistenceClient) DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.DeleteWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteWorkflowExecutionScope, metrics.PersistenceLatency)
-	err := p.persistence.DeleteWorkflowExecution(request)
+	err := p.persistence.DeleteWorkflowExecution(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteWorkflowExecutionScope, err)
@@ -210,12 +249,14 @@ func (p *workflowExecutionPersistenceClient) DeleteWorkflowExecution(request *De
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+func (p *workflowExecutionPersistenceClient) GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetCurrentExecutionScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetCurrentExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetCurrentExecutionScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetCurrentExecution(request)
+	response, err := p.persistence.GetCurrentExecution(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetCurrentExecutionScope, err)
@@ -224,12 +265,14 @@ func (p *workflowExecutionPersistenceClient) GetCurrentExecution(request *GetCur
 	return response, err
 }
 
-func (p *workflowExecutionPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+func (p *workflowExecutionPersistenceClient) GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTransferTasksScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetTransferTasks")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetTransferTasksScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetTransferTasks(request)
+	response, err := p.persistence.GetTransferTasks(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetTransferTasksScope, err)
@@ -238,12 +281,14 @@ func (p *workflowExecutionPersistenceClient) GetTransferTasks(request *GetTransf
 	return response, err
 }
 
-func (p *workflowExecutionPersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+func (p *workflowExecutionPersistenceClient) CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTransferTaskScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.CompleteTransferTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteTransferTaskScope, metrics.PersistenceLatency)
-	err := p.persistence.CompleteTransferTask(request)
+	err := p.persistence.CompleteTransferTask(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCompleteTransferTaskScope, err)
@@ -252,12 +297,64 @@ func (p *workflowExecutionPersistenceClient) CompleteTransferTask(request *Compl
 	return err
 }
 
-func (p *workflowExecutionPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+func (p *workflowExecutionPersistenceClient) PutTransferDLQTask(ctx context.Context, request *PutTransferDLQTaskRequest) error {
+	p.metricClient.IncCounter(metrics.PersistencePutTransferDLQTaskScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.PutTransferDLQTask")
+	sw := p.metricClient.StartTimer(metrics.PersistencePutTransferDLQTaskScope, metrics.PersistenceLatency)
+	err := p.persistence.PutTransferDLQTask(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePutTransferDLQTaskScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) GetTransferDLQTasks(ctx context.Context,
+	request *GetTransferDLQTasksRequest) (*GetTransferDLQTasksResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTransferDLQTasksScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetTransferDLQTasks")
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTransferDLQTasksScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetTransferDLQTasks(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTransferDLQTasksScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) RedriveTransferDLQTask(ctx context.Context,
+	request *RedriveTransferDLQTaskRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceRedriveTransferDLQTaskScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.RedriveTransferDLQTask")
+	sw := p.metricClient.StartTimer(metrics.PersistenceRedriveTransferDLQTaskScope, metrics.PersistenceLatency)
+	err := p.persistence.RedriveTransferDLQTask(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRedriveTransferDLQTaskScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) GetTimerIndexTasks(ctx context.Context, request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTimerIndexTasksScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetTimerIndexTasks")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetTimerIndexTasksScope, metrics.PersistenceLatency)
-	resonse, err := p.persistence.GetTimerIndexTasks(request)
+	resonse, err := p.persistence.GetTimerIndexTasks(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetTimerIndexTasksScope, err)
@@ -266,12 +363,14 @@ func (p *workflowExecutionPersistenceClient) GetTimerIndexTasks(request *GetTime
 	return resonse, err
 }
 
-func (p *workflowExecutionPersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+func (p *workflowExecutionPersistenceClient) CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTimerTaskScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.CompleteTimerTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteTimerTaskScope, metrics.PersistenceLatency)
-	err := p.persistence.CompleteTimerTask(request)
+	err := p.persistence.CompleteTimerTask(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCompleteTimerTaskScope, err)
@@ -280,6 +379,26 @@ func (p *workflowExecutionPersistenceClient) CompleteTimerTask(request *Complete
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) ListExecutions(ctx context.Context, request *ListExecutionsRequest) (*ListExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListExecutionsScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.ListExecutions")
+	sw := p.metricClient.StartTimer(metrics.PersistenceListExecutionsScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListExecutions(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListExecutionsScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
 func (p *workflowExecutionPersistenceClient) updateErrorMetric(scope int, err error) {
 	switch err.(type) {
 	case *workflow.WorkflowExecutionAlreadyStartedError:
@@ -296,12 +415,14 @@ func (p *workflowExecutionPersistenceClient) updateErrorMetric(scope int, err er
 	}
 }
 
-func (p *taskPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
+func (p *taskPersistenceClient) CreateTasks(ctx context.Context, request *CreateTasksRequest) (*CreateTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateTaskScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.CreateTasks")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateTaskScope, metrics.PersistenceLatency)
-	response, err := p.persistence.CreateTasks(request)
+	response, err := p.persistence.CreateTasks(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCreateTaskScope, err)
@@ -310,12 +431,14 @@ func (p *taskPersistenceClient) CreateTasks(request *CreateTasksRequest) (*Creat
 	return response, err
 }
 
-func (p *taskPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
+func (p *taskPersistenceClient) GetTasks(ctx context.Context, request *GetTasksRequest) (*GetTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTasksScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetTasks")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetTasksScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetTasks(request)
+	response, err := p.persistence.GetTasks(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetTasksScope, err)
@@ -324,12 +447,14 @@ func (p *taskPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksRes
 	return response, err
 }
 
-func (p *taskPersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
+func (p *taskPersistenceClient) CompleteTask(ctx context.Context, request *CompleteTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTaskScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.CompleteTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteTaskScope, metrics.PersistenceLatency)
-	err := p.persistence.CompleteTask(request)
+	err := p.persistence.CompleteTask(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCompleteTaskScope, err)
@@ -338,12 +463,14 @@ func (p *taskPersistenceClient) CompleteTask(request *CompleteTaskRequest) error
 	return err
 }
 
-func (p *taskPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+func (p *taskPersistenceClient) LeaseTaskList(ctx context.Context, request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceLeaseTaskListScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.LeaseTaskList")
 	sw := p.metricClient.StartTimer(metrics.PersistenceLeaseTaskListScope, metrics.PersistenceLatency)
-	response, err := p.persistence.LeaseTaskList(request)
+	response, err := p.persistence.LeaseTaskList(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceLeaseTaskListScope, err)
@@ -352,12 +479,14 @@ func (p *taskPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*L
 	return response, err
 }
 
-func (p *taskPersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
+func (p *taskPersistenceClient) UpdateTaskList(ctx context.Context, request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateTaskListScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.UpdateTaskList")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateTaskListScope, metrics.PersistenceLatency)
-	response, err := p.persistence.UpdateTaskList(request)
+	response, err := p.persistence.UpdateTaskList(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpdateTaskListScope, err)
@@ -366,6 +495,38 @@ func (p *taskPersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (
 	return response, err
 }
 
+func (p *taskPersistenceClient) ListTaskLists(ctx context.Context, request *ListTaskListsRequest) (*ListTaskListsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListTaskListsScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.ListTaskLists")
+	sw := p.metricClient.StartTimer(metrics.PersistenceListTaskListsScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListTaskLists(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListTaskListsScope, err)
+	}
+
+	return response, err
+}
+
+func (p *taskPersistenceClient) DeleteTaskList(ctx context.Context, request *DeleteTaskListRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteTaskListScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.DeleteTaskList")
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteTaskListScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteTaskList(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteTaskListScope, err)
+	}
+
+	return err
+}
+
 func (p *taskPersistenceClient) updateErrorMetric(scope int, err error) {
 	switch err.(type) {
 	case *ConditionFailedError:
@@ -378,12 +539,14 @@ func (p *taskPersistenceClient) updateErrorMetric(scope int, err error) {
 	}
 }
 
-func (p *historyPersistenceClient) AppendHistoryEvents(request *AppendHistoryEventsRequest) error {
+func (p *historyPersistenceClient) AppendHistoryEvents(ctx context.Context, request *AppendHistoryEventsRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceAppendHistoryEventsScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.AppendHistoryEvents")
 	sw := p.metricClient.StartTimer(metrics.PersistenceAppendHistoryEventsScope, metrics.PersistenceLatency)
-	err := p.persistence.AppendHistoryEvents(request)
+	err := p.persistence.AppendHistoryEvents(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceAppendHistoryEventsScope, err)
@@ -393,12 +556,14 @@ func (p *historyPersistenceClient) AppendHistoryEvents(request *AppendHistoryEve
 }
 
 func (p *historyPersistenceClient) GetWorkflowExecutionHistory(
-	request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	ctx context.Context, request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetWorkflowExecutionHistoryScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetWorkflowExecutionHistory")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetWorkflowExecutionHistoryScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetWorkflowExecutionHistory(request)
+	response, err := p.persistence.GetWorkflowExecutionHistory(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetWorkflowExecutionHistoryScope, err)
@@ -408,12 +573,14 @@ func (p *historyPersistenceClient) GetWorkflowExecutionHistory(
 }
 
 func (p *historyPersistenceClient) DeleteWorkflowExecutionHistory(
-	request *DeleteWorkflowExecutionHistoryRequest) error {
+	ctx context.Context, request *DeleteWorkflowExecutionHistoryRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteWorkflowExecutionHistoryScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.DeleteWorkflowExecutionHistory")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteWorkflowExecutionHistoryScope, metrics.PersistenceLatency)
-	err := p.persistence.DeleteWorkflowExecutionHistory(request)
+	err := p.persistence.DeleteWorkflowExecutionHistory(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteWorkflowExecutionHistoryScope, err)
@@ -422,6 +589,23 @@ func (p *historyPersistenceClient) DeleteWorkflowExecutionHistory(
 	return err
 }
 
+func (p *historyPersistenceClient) ListWorkflowExecutionHistories(
+	ctx context.Context, request *ListWorkflowExecutionHistoriesRequest) (*ListWorkflowExecutionHistoriesResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListWorkflowExecutionHistoriesScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.ListWorkflowExecutionHistories")
+	sw := p.metricClient.StartTimer(metrics.PersistenceListWorkflowExecutionHistoriesScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListWorkflowExecutionHistories(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListWorkflowExecutionHistoriesScope, err)
+	}
+
+	return response, err
+}
+
 func (p *historyPersistenceClient) updateErrorMetric(scope int, err error) {
 	switch err.(type) {
 	case *workflow.EntityNotExistsError:
@@ -436,12 +620,14 @@ func (p *historyPersistenceClient) updateErrorMetric(scope int, err error) {
 	}
 }
 
-func (p *metadataPersistenceClient) CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error) {
+func (p *metadataPersistenceClient) CreateDomain(ctx context.Context, request *CreateDomainRequest) (*CreateDomainResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateDomainScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.CreateDomain")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateDomainScope, metrics.PersistenceLatency)
-	response, err := p.persistence.CreateDomain(request)
+	response, err := p.persistence.CreateDomain(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCreateDomainScope, err)
@@ -450,12 +636,14 @@ func (p *metadataPersistenceClient) CreateDomain(request *CreateDomainRequest) (
 	return response, err
 }
 
-func (p *metadataPersistenceClient) GetDomain(request *GetDomainRequest) (*GetDomainResponse, error) {
+func (p *metadataPersistenceClient) GetDomain(ctx context.Context, request *GetDomainRequest) (*GetDomainResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetDomainScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetDomain")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetDomainScope, metrics.PersistenceLatency)
-	response, err := p.persistence.GetDomain(request)
+	response, err := p.persistence.GetDomain(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetDomainScope, err)
@@ -464,12 +652,14 @@ func (p *metadataPersistenceClient) GetDomain(request *GetDomainRequest) (*GetDo
 	return response, err
 }
 
-func (p *metadataPersistenceClient) UpdateDomain(request *UpdateDomainRequest) error {
+func (p *metadataPersistenceClient) UpdateDomain(ctx context.Context, request *UpdateDomainRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateDomainScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.UpdateDomain")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateDomainScope, metrics.PersistenceLatency)
-	err := p.persistence.UpdateDomain(request)
+	err := p.persistence.UpdateDomain(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpdateDomainScope, err)
@@ -478,12 +668,14 @@ func (p *metadataPersistenceClient) UpdateDomain(request *UpdateDomainRequest) e
 	return err
 }
 
-func (p *metadataPersistenceClient) DeleteDomain(request *DeleteDomainRequest) error {
+func (p *metadataPersistenceClient) DeleteDomain(ctx context.Context, request *DeleteDomainRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteDomainScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.DeleteDomain")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteDomainScope, metrics.PersistenceLatency)
-	err := p.persistence.DeleteDomain(request)
+	err := p.persistence.DeleteDomain(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteDomainScope, err)
@@ -492,12 +684,14 @@ func (p *metadataPersistenceClient) DeleteDomain(request *DeleteDomainRequest) e
 	return err
 }
 
-func (p *metadataPersistenceClient) DeleteDomainByName(request *DeleteDomainByNameRequest) error {
+func (p *metadataPersistenceClient) DeleteDomainByName(ctx context.Context, request *DeleteDomainByNameRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteDomainByNameScope, metrics.PersistenceRequests)
 
+	span, ctx := tracing.StartSpan(ctx, "persistence.DeleteDomainByName")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteDomainByNameScope, metrics.PersistenceLatency)
-	err := p.persistence.DeleteDomainByName(request)
+	err := p.persistence.DeleteDomainByName(ctx, request)
 	sw.Stop()
+	tracing.FinishSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteDomainByNameScope, err)
@@ -506,6 +700,40 @@ func (p *metadataPersistenceClient) DeleteDomainByName(request *DeleteDomainByNa
 	return err
 }
 
+func (p *metadataPersistenceClient) GetOpenExecutionCount(ctx context.Context,
+	request *GetOpenExecutionCountRequest) (*GetOpenExecutionCountResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetOpenExecutionCountScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.GetOpenExecutionCount")
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetOpenExecutionCountScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetOpenExecutionCount(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetOpenExecutionCountScope, err)
+	}
+
+	return response, err
+}
+
+func (p *metadataPersistenceClient) UpdateOpenExecutionCount(ctx context.Context,
+	request *UpdateOpenExecutionCountRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceUpdateOpenExecutionCountScope, metrics.PersistenceRequests)
+
+	span, ctx := tracing.StartSpan(ctx, "persistence.UpdateOpenExecutionCount")
+	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateOpenExecutionCountScope, metrics.PersistenceLatency)
+	err := p.persistence.UpdateOpenExecutionCount(ctx, request)
+	sw.Stop()
+	tracing.FinishSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceUpdateOpenExecutionCountScope, err)
+	}
+
+	return err
+}
+
 func (p *metadataPersistenceClient) updateErrorMetric(scope int, err error) {
 	switch err.(type) {
 	case *workflow.DomainAlreadyExistsError:
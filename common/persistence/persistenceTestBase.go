@@ -24,6 +24,7 @@ import (
 	"math"
 	"math/rand"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -31,12 +32,14 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/pborman/uuid"
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 
 	"github.com/uber-go/tally"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
 )
 
 const (
@@ -53,6 +56,7 @@ type (
 		Datacenter   string
 		DropKeySpace bool
 		SchemaDir    string
+		Timeouts     config.CassandraTimeouts
 	}
 
 	// TestBase wraps the base setup needed to create workflows over engine layer.
@@ -85,6 +89,8 @@ type (
 		executionMgr           ExecutionManager
 		logger                 bark.Logger
 		metricsClient          metrics.Client
+		sync.Mutex
+		timerAckLevels map[string]int64 // ack levels of the timer queues, by queue name
 	}
 
 	testExecutionMgrFactory struct {
@@ -103,9 +109,14 @@ func newTestShardContext(shardInfo *ShardInfo, transferSequenceNumber int64, his
 		executionMgr:           executionMgr,
 		logger:                 logger,
 		metricsClient:          metrics.NewClient(tally.NoopScope, metrics.History),
+		timerAckLevels:         make(map[string]int64),
 	}
 }
 
+func (s *testShardContext) GetShardID() int {
+	return s.shardInfo.ShardID
+}
+
 func (s *testShardContext) GetExecutionManager() ExecutionManager {
 	return s.executionMgr
 }
@@ -126,8 +137,42 @@ func (s *testShardContext) GetTransferAckLevel() int64 {
 	return atomic.LoadInt64(&s.shardInfo.TransferAckLevel)
 }
 
-func (s *testShardContext) GetTimerSequenceNumber() int64 {
-	return atomic.AddInt64(&s.timerSequeceNumber, 1)
+func (s *testShardContext) GetTimerAckLevel(queue string) int64 {
+	s.Lock()
+	defer s.Unlock()
+	return s.timerAckLevels[queue]
+}
+
+func (s *testShardContext) UpdateTimerAckLevel(queue string, ackLevel int64) error {
+	s.Lock()
+	defer s.Unlock()
+	s.timerAckLevels[queue] = ackLevel
+	return nil
+}
+
+func (s *testShardContext) GetQueuePaused(queueType string) (bool, bool) {
+	s.Lock()
+	defer s.Unlock()
+	paused, ok := s.shardInfo.QueuePaused[queueType]
+	return paused, ok
+}
+
+func (s *testShardContext) UpdateQueuePaused(queueType string, paused bool) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.shardInfo.QueuePaused == nil {
+		s.shardInfo.QueuePaused = make(map[string]bool)
+	}
+	s.shardInfo.QueuePaused[queueType] = paused
+	return nil
+}
+
+func (s *testShardContext) GetTimeSource() common.TimeSource {
+	return common.NewRealTimeSource()
+}
+
+func (s *testShardContext) GetTimerSequenceNumber() (int64, error) {
+	return atomic.AddInt64(&s.timerSequeceNumber, 1), nil
 }
 
 func (s *testShardContext) UpdateAckLevel(ackLevel int64) error {
@@ -139,17 +184,56 @@ func (s *testShardContext) GetTransferSequenceNumber() int64 {
 	return atomic.LoadInt64(&s.transferSequenceNumber)
 }
 
-func (s *testShardContext) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (
+func (s *testShardContext) GetTransferQueueDepth() int64 {
+	return 0
+}
+
+func (s *testShardContext) TransferTaskProcessed(taskID int64) {
+}
+
+func (s *testShardContext) GetPersistenceLatency() time.Duration {
+	return 0
+}
+
+func (s *testShardContext) CreateWorkflowExecution(ctx context.Context, request *CreateWorkflowExecutionRequest) (
 	*CreateWorkflowExecutionResponse, error) {
-	return s.executionMgr.CreateWorkflowExecution(request)
+	return s.executionMgr.CreateWorkflowExecution(ctx, request)
+}
+
+func (s *testShardContext) UpdateWorkflowExecution(ctx context.Context, request *UpdateWorkflowExecutionRequest) error {
+	return s.executionMgr.UpdateWorkflowExecution(ctx, request)
+}
+
+func (s *testShardContext) AppendHistoryEvents(ctx context.Context, request *AppendHistoryEventsRequest) error {
+	return s.historyMgr.AppendHistoryEvents(ctx, request)
+}
+
+func (s *testShardContext) DeleteWorkflowExecution(ctx context.Context, request *DeleteWorkflowExecutionRequest) error {
+	request.RangeID = s.GetRangeID()
+	return s.executionMgr.DeleteWorkflowExecution(ctx, request)
+}
+
+func (s *testShardContext) CompleteTransferTask(ctx context.Context, request *CompleteTransferTaskRequest) error {
+	return s.executionMgr.CompleteTransferTask(ctx, request)
+}
+
+func (s *testShardContext) PutTransferDLQTask(ctx context.Context, request *PutTransferDLQTaskRequest) error {
+	request.RangeID = s.GetRangeID()
+	return s.executionMgr.PutTransferDLQTask(ctx, request)
 }
 
-func (s *testShardContext) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) error {
-	return s.executionMgr.UpdateWorkflowExecution(request)
+func (s *testShardContext) RedriveTransferDLQTask(ctx context.Context, request *RedriveTransferDLQTaskRequest) error {
+	newTaskID, err := s.GetNextTransferTaskID()
+	if err != nil {
+		return err
+	}
+	request.NewTaskID = newTaskID
+	request.RangeID = s.GetRangeID()
+	return s.executionMgr.RedriveTransferDLQTask(ctx, request)
 }
 
-func (s *testShardContext) AppendHistoryEvents(request *AppendHistoryEventsRequest) error {
-	return s.historyMgr.AppendHistoryEvents(request)
+func (s *testShardContext) CompleteTimerTask(ctx context.Context, request *CompleteTimerTaskRequest) error {
+	return s.executionMgr.CompleteTimerTask(ctx, request)
 }
 
 func (s *testShardContext) GetLogger() bark.Logger {
@@ -160,9 +244,17 @@ func (s *testShardContext) GetMetricsClient() metrics.Client {
 	return s.metricsClient
 }
 
+func (s *testShardContext) IsClosed() bool {
+	return false
+}
+
 func (s *testShardContext) Reset() {
 	atomic.StoreInt64(&s.shardInfo.RangeID, 0)
 	atomic.StoreInt64(&s.shardInfo.TransferAckLevel, 0)
+	atomic.StoreInt64(&s.shardInfo.TimerAckLevel, 0)
+	s.Lock()
+	s.timerAckLevels = make(map[string]int64)
+	s.Unlock()
 }
 
 func (s *testShardContext) GetRangeID() int64 {
@@ -179,8 +271,19 @@ func newTestExecutionMgrFactory(options TestBaseOptions, cassandra CassandraTest
 }
 
 func (f *testExecutionMgrFactory) CreateExecutionManager(shardID int) (ExecutionManager, error) {
-	return NewCassandraWorkflowExecutionPersistence(f.options.ClusterHost, f.options.Datacenter, f.cassandra.keyspace,
-		shardID, f.logger)
+	return NewCassandraWorkflowExecutionPersistence(f.options.cassandraConfig(f.cassandra.keyspace), shardID, nil,
+		f.logger)
+}
+
+// cassandraConfig returns the cassandra configuration of the test cluster for the given keyspace
+func (options TestBaseOptions) cassandraConfig(keyspace string) config.Cassandra {
+	return config.Cassandra{
+		Hosts:              options.ClusterHost,
+		Keyspace:           keyspace,
+		VisibilityKeyspace: keyspace,
+		Datacenter:         options.Datacenter,
+		Timeouts:           options.Timeouts,
+	}
 }
 
 // SetupWorkflowStoreWithOptions to setup workflow test base
@@ -189,9 +292,9 @@ func (s *TestBase) SetupWorkflowStoreWithOptions(options TestBaseOptions) {
 	// Setup Workflow keyspace and deploy schema for tests
 	s.CassandraTestCluster.setupTestCluster(options.KeySpace, options.DropKeySpace, options.SchemaDir)
 	shardID := 0
+	cfg := options.cassandraConfig(s.CassandraTestCluster.keyspace)
 	var err error
-	s.ShardMgr, err = NewCassandraShardPersistence(options.ClusterHost, options.Datacenter,
-		s.CassandraTestCluster.keyspace, log)
+	s.ShardMgr, err = NewCassandraShardPersistence(cfg, nil, log)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -201,25 +304,22 @@ func (s *TestBase) SetupWorkflowStoreWithOptions(options TestBaseOptions) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	s.TaskMgr, err = NewCassandraTaskPersistence(options.ClusterHost, options.Datacenter, s.CassandraTestCluster.keyspace,
-		log)
+	s.TaskMgr, err = NewCassandraTaskPersistence(cfg, nil, log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	s.HistoryMgr, err = NewCassandraHistoryPersistence(options.ClusterHost, options.Datacenter,
-		s.CassandraTestCluster.keyspace, log)
+	s.HistoryMgr, err = NewCassandraHistoryPersistence(cfg, nil, log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	s.MetadataManager, err = NewCassandraMetadataPersistence(options.ClusterHost, options.Datacenter,
-		s.CassandraTestCluster.keyspace, log)
+	s.MetadataManager, err = NewCassandraMetadataPersistence(cfg, nil, log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	s.VisibilityMgr, err = NewCassandraVisibilityPersistence(options.ClusterHost, options.Datacenter, s.CassandraTestCluster.keyspace, log)
+	s.VisibilityMgr, err = NewCassandraVisibilityPersistence(cfg, nil, log)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -232,7 +332,7 @@ func (s *TestBase) SetupWorkflowStoreWithOptions(options TestBaseOptions) {
 		TransferAckLevel: 0,
 	}
 	s.ShardContext = newTestShardContext(s.ShardInfo, 0, s.HistoryMgr, s.WorkflowMgr, log)
-	err1 := s.ShardMgr.CreateShard(&CreateShardRequest{
+	err1 := s.ShardMgr.CreateShard(context.Background(), &CreateShardRequest{
 		ShardInfo: s.ShardInfo,
 	})
 	if err1 != nil {
@@ -248,14 +348,14 @@ func (s *TestBase) CreateShard(shardID int, owner string, rangeID int64) error {
 		RangeID: rangeID,
 	}
 
-	return s.ShardMgr.CreateShard(&CreateShardRequest{
+	return s.ShardMgr.CreateShard(context.Background(), &CreateShardRequest{
 		ShardInfo: info,
 	})
 }
 
 // GetShard is a utility method to get the shard using persistence layer
 func (s *TestBase) GetShard(shardID int) (*ShardInfo, error) {
-	response, err := s.ShardMgr.GetShard(&GetShardRequest{
+	response, err := s.ShardMgr.GetShard(context.Background(), &GetShardRequest{
 		ShardID: shardID,
 	})
 
@@ -268,7 +368,7 @@ func (s *TestBase) GetShard(shardID int) (*ShardInfo, error) {
 
 // UpdateShard is a utility method to update the shard using persistence layer
 func (s *TestBase) UpdateShard(updatedInfo *ShardInfo, previousRangeID int64) error {
-	return s.ShardMgr.UpdateShard(&UpdateShardRequest{
+	return s.ShardMgr.UpdateShard(context.Background(), &UpdateShardRequest{
 		ShardInfo:       updatedInfo,
 		PreviousRangeID: previousRangeID,
 	})
@@ -278,7 +378,7 @@ func (s *TestBase) UpdateShard(updatedInfo *ShardInfo, previousRangeID int64) er
 func (s *TestBase) CreateWorkflowExecution(domainID string, workflowExecution workflow.WorkflowExecution, taskList,
 	wType string, decisionTimeout int32, executionContext []byte, nextEventID int64, lastProcessedEventID int64,
 	decisionScheduleID int64, timerTasks []Task) (string, error) {
-	response, err := s.WorkflowMgr.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{
+	response, err := s.WorkflowMgr.CreateWorkflowExecution(context.Background(), &CreateWorkflowExecutionRequest{
 		RequestID:            uuid.New(),
 		DomainID:             domainID,
 		Execution:            workflowExecution,
@@ -336,7 +436,7 @@ func (s *TestBase) CreateWorkflowExecutionManyTasks(domainID string, workflowExe
 			})
 	}
 
-	response, err := s.WorkflowMgr.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{
+	response, err := s.WorkflowMgr.CreateWorkflowExecution(context.Background(), &CreateWorkflowExecutionRequest{
 		RequestID:                   uuid.New(),
 		DomainID:                    domainID,
 		Execution:                   workflowExecution,
@@ -363,7 +463,7 @@ func (s *TestBase) CreateChildWorkflowExecution(domainID string, workflowExecuti
 	parentDomainID string, parentExecution *workflow.WorkflowExecution, initiatedID int64, taskList, wType string,
 	decisionTimeout int32, executionContext []byte, nextEventID int64, lastProcessedEventID int64,
 	decisionScheduleID int64, timerTasks []Task) (string, error) {
-	response, err := s.WorkflowMgr.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{
+	response, err := s.WorkflowMgr.CreateWorkflowExecution(context.Background(), &CreateWorkflowExecutionRequest{
 		RequestID:            uuid.New(),
 		DomainID:             domainID,
 		Execution:            workflowExecution,
@@ -401,7 +501,7 @@ func (s *TestBase) CreateChildWorkflowExecution(domainID string, workflowExecuti
 // GetWorkflowExecutionInfo is a utility method to retrieve execution info
 func (s *TestBase) GetWorkflowExecutionInfo(domainID string, workflowExecution workflow.WorkflowExecution) (
 	*WorkflowMutableState, error) {
-	response, err := s.WorkflowMgr.GetWorkflowExecution(&GetWorkflowExecutionRequest{
+	response, err := s.WorkflowMgr.GetWorkflowExecution(context.Background(), &GetWorkflowExecutionRequest{
 		DomainID:  domainID,
 		Execution: workflowExecution,
 	})
@@ -414,7 +514,7 @@ func (s *TestBase) GetWorkflowExecutionInfo(domainID string, workflowExecution w
 
 // GetCurrentWorkflow returns the workflow state for the given params
 func (s *TestBase) GetCurrentWorkflow(domainID, workflowID string) (string, error) {
-	response, err := s.WorkflowMgr.GetCurrentExecution(&GetCurrentExecutionRequest{
+	response, err := s.WorkflowMgr.GetCurrentExecution(context.Background(), &GetCurrentExecutionRequest{
 		DomainID:   domainID,
 		WorkflowID: workflowID,
 	})
@@ -436,7 +536,7 @@ func (s *TestBase) ContinueAsNewExecution(updatedInfo *WorkflowExecutionInfo, co
 		ScheduleID: int64(decisionScheduleID),
 	}
 
-	return s.WorkflowMgr.UpdateWorkflowExecution(&UpdateWorkflowExecutionRequest{
+	return s.WorkflowMgr.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
 		ExecutionInfo:       updatedInfo,
 		TransferTasks:       []Task{newdecisionTask},
 		TimerTasks:          nil,
@@ -482,7 +582,7 @@ func (s *TestBase) UpdateWorkflowExecution(updatedInfo *WorkflowExecutionInfo, d
 func (s *TestBase) UpdateWorkflowExecutionAndDelete(updatedInfo *WorkflowExecutionInfo, condition int64) error {
 	transferTasks := []Task{}
 	transferTasks = append(transferTasks, &DeleteExecutionTask{TaskID: s.GetNextSequenceNumber()})
-	return s.WorkflowMgr.UpdateWorkflowExecution(&UpdateWorkflowExecutionRequest{
+	return s.WorkflowMgr.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
 		ExecutionInfo:       updatedInfo,
 		TransferTasks:       transferTasks,
 		TimerTasks:          nil,
@@ -513,6 +613,52 @@ func (s *TestBase) DeleteChildExecutionsState(updatedInfo *WorkflowExecutionInfo
 		nil, nil, nil, &deleteChildInfo)
 }
 
+// UpsertRequestCancelState is a utility method to update mutable state of workflow execution
+func (s *TestBase) UpsertRequestCancelState(updatedInfo *WorkflowExecutionInfo, condition int64,
+	upsertCancelInfos []*RequestCancelInfo) error {
+	return s.WorkflowMgr.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
+		ExecutionInfo:            updatedInfo,
+		Condition:                condition,
+		RangeID:                  s.ShardContext.GetRangeID(),
+		UpsertRequestCancelInfos: upsertCancelInfos,
+	})
+}
+
+// DeleteRequestCancelState is a utility method to delete request cancellation from mutable state
+func (s *TestBase) DeleteRequestCancelState(updatedInfo *WorkflowExecutionInfo, condition int64,
+	deleteCancelInfo int64) error {
+	return s.WorkflowMgr.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
+		ExecutionInfo:           updatedInfo,
+		Condition:               condition,
+		RangeID:                 s.ShardContext.GetRangeID(),
+		DeleteRequestCancelInfo: &deleteCancelInfo,
+	})
+}
+
+// UpdateBufferedEvents is a utility method to append to or clear the buffered events of mutable state
+func (s *TestBase) UpdateBufferedEvents(updatedInfo *WorkflowExecutionInfo, condition int64,
+	newBufferedEvents *SerializedHistoryEventBatch, clearBufferedEvents bool) error {
+	return s.WorkflowMgr.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
+		ExecutionInfo:       updatedInfo,
+		Condition:           condition,
+		RangeID:             s.ShardContext.GetRangeID(),
+		NewBufferedEvents:   newBufferedEvents,
+		ClearBufferedEvents: clearBufferedEvents,
+	})
+}
+
+// UpdateSignalReceipts is a utility method to record and delete signal receipts in mutable state
+func (s *TestBase) UpdateSignalReceipts(updatedInfo *WorkflowExecutionInfo, condition int64,
+	signalReceipts map[string]int64, deleteSignalReceipts []string) error {
+	return s.WorkflowMgr.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
+		ExecutionInfo:        updatedInfo,
+		Condition:            condition,
+		RangeID:              s.ShardContext.GetRangeID(),
+		UpsertSignalReceipts: signalReceipts,
+		DeleteSignalReceipts: deleteSignalReceipts,
+	})
+}
+
 // UpdateWorkflowExecutionWithRangeID is a utility method to update workflow execution
 func (s *TestBase) UpdateWorkflowExecutionWithRangeID(updatedInfo *WorkflowExecutionInfo, decisionScheduleIDs []int64,
 	activityScheduleIDs []int64, rangeID, condition int64, timerTasks []Task, deleteTimerTask Task,
@@ -535,7 +681,7 @@ func (s *TestBase) UpdateWorkflowExecutionWithRangeID(updatedInfo *WorkflowExecu
 			ScheduleID: int64(activityScheduleID)})
 	}
 
-	return s.WorkflowMgr.UpdateWorkflowExecution(&UpdateWorkflowExecutionRequest{
+	return s.WorkflowMgr.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
 		ExecutionInfo:             updatedInfo,
 		TransferTasks:             transferTasks,
 		TimerTasks:                timerTasks,
@@ -554,7 +700,7 @@ func (s *TestBase) UpdateWorkflowExecutionWithRangeID(updatedInfo *WorkflowExecu
 // UpdateWorkflowExecutionWithTransferTasks is a utility method to update workflow execution
 func (s *TestBase) UpdateWorkflowExecutionWithTransferTasks(
 	updatedInfo *WorkflowExecutionInfo, condition int64, transferTasks []Task, upsertActivityInfo []*ActivityInfo) error {
-	return s.WorkflowMgr.UpdateWorkflowExecution(&UpdateWorkflowExecutionRequest{
+	return s.WorkflowMgr.UpdateWorkflowExecution(context.Background(), &UpdateWorkflowExecutionRequest{
 		ExecutionInfo:       updatedInfo,
 		TransferTasks:       transferTasks,
 		Condition:           condition,
@@ -565,14 +711,20 @@ func (s *TestBase) UpdateWorkflowExecutionWithTransferTasks(
 
 // DeleteWorkflowExecution is a utility method to delete a workflow execution
 func (s *TestBase) DeleteWorkflowExecution(info *WorkflowExecutionInfo) error {
-	return s.WorkflowMgr.DeleteWorkflowExecution(&DeleteWorkflowExecutionRequest{
+	return s.DeleteWorkflowExecutionWithRangeID(info, s.ShardContext.GetRangeID())
+}
+
+// DeleteWorkflowExecutionWithRangeID is a utility method to delete a workflow execution
+func (s *TestBase) DeleteWorkflowExecutionWithRangeID(info *WorkflowExecutionInfo, rangeID int64) error {
+	return s.WorkflowMgr.DeleteWorkflowExecution(context.Background(), &DeleteWorkflowExecutionRequest{
 		ExecutionInfo: info,
+		RangeID:       rangeID,
 	})
 }
 
 // GetTransferTasks is a utility method to get tasks from transfer task queue
 func (s *TestBase) GetTransferTasks(batchSize int) ([]*TransferTaskInfo, error) {
-	response, err := s.WorkflowMgr.GetTransferTasks(&GetTransferTasksRequest{
+	response, err := s.WorkflowMgr.GetTransferTasks(context.Background(), &GetTransferTasksRequest{
 		ReadLevel:    s.GetReadLevel(),
 		MaxReadLevel: int64(math.MaxInt64),
 		BatchSize:    batchSize,
@@ -591,15 +743,21 @@ func (s *TestBase) GetTransferTasks(batchSize int) ([]*TransferTaskInfo, error)
 
 // CompleteTransferTask is a utility method to complete a transfer task
 func (s *TestBase) CompleteTransferTask(taskID int64) error {
+	return s.WorkflowMgr.CompleteTransferTask(context.Background(), &CompleteTransferTaskRequest{
+		TaskID: taskID,
+	})
+}
 
-	return s.WorkflowMgr.CompleteTransferTask(&CompleteTransferTaskRequest{
+// CompleteTimerTask is a utility method to complete a timer task
+func (s *TestBase) CompleteTimerTask(taskID int64) error {
+	return s.WorkflowMgr.CompleteTimerTask(context.Background(), &CompleteTimerTaskRequest{
 		TaskID: taskID,
 	})
 }
 
 // GetTimerIndexTasks is a utility method to get tasks from transfer task queue
 func (s *TestBase) GetTimerIndexTasks(minKey int64, maxKey int64) ([]*TimerTaskInfo, error) {
-	response, err := s.WorkflowMgr.GetTimerIndexTasks(&GetTimerIndexTasksRequest{
+	response, err := s.WorkflowMgr.GetTimerIndexTasks(context.Background(), &GetTimerIndexTasksRequest{
 		MinKey: minKey, MaxKey: maxKey, BatchSize: 10})
 
 	if err != nil {
@@ -612,7 +770,7 @@ func (s *TestBase) GetTimerIndexTasks(minKey int64, maxKey int64) ([]*TimerTaskI
 // CreateDecisionTask is a utility method to create a task
 func (s *TestBase) CreateDecisionTask(domainID string, workflowExecution workflow.WorkflowExecution, taskList string,
 	decisionScheduleID int64) (int64, error) {
-	leaseResponse, err := s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+	leaseResponse, err := s.TaskMgr.LeaseTaskList(context.Background(), &LeaseTaskListRequest{
 		DomainID: domainID,
 		TaskList: taskList,
 		TaskType: TaskListTypeDecision,
@@ -636,7 +794,7 @@ func (s *TestBase) CreateDecisionTask(domainID string, workflowExecution workflo
 		},
 	}
 
-	_, err = s.TaskMgr.CreateTasks(&CreateTasksRequest{
+	_, err = s.TaskMgr.CreateTasks(context.Background(), &CreateTasksRequest{
 		DomainID:     domainID,
 		TaskList:     taskList,
 		TaskListType: TaskListTypeDecision,
@@ -661,7 +819,7 @@ func (s *TestBase) CreateActivityTasks(domainID string, workflowExecution workfl
 	for activityScheduleID, taskList := range activities {
 
 		leaseResponse, err = s.TaskMgr.LeaseTaskList(
-			&LeaseTaskListRequest{DomainID: domainID, TaskList: taskList, TaskType: TaskListTypeActivity})
+			context.Background(), &LeaseTaskListRequest{DomainID: domainID, TaskList: taskList, TaskType: TaskListTypeActivity})
 		if err != nil {
 			return []int64{}, err
 		}
@@ -679,7 +837,7 @@ func (s *TestBase) CreateActivityTasks(domainID string, workflowExecution workfl
 				},
 			},
 		}
-		_, err := s.TaskMgr.CreateTasks(&CreateTasksRequest{
+		_, err := s.TaskMgr.CreateTasks(context.Background(), &CreateTasksRequest{
 			DomainID:     domainID,
 			TaskList:     taskList,
 			TaskListType: TaskListTypeActivity,
@@ -699,7 +857,7 @@ func (s *TestBase) CreateActivityTasks(domainID string, workflowExecution workfl
 
 // GetTasks is a utility method to get tasks from persistence
 func (s *TestBase) GetTasks(domainID, taskList string, taskType int, batchSize int) (*GetTasksResponse, error) {
-	leaseResponse, err := s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+	leaseResponse, err := s.TaskMgr.LeaseTaskList(context.Background(), &LeaseTaskListRequest{
 		DomainID: domainID,
 		TaskList: taskList,
 		TaskType: taskType,
@@ -708,7 +866,7 @@ func (s *TestBase) GetTasks(domainID, taskList string, taskType int, batchSize i
 		return nil, err
 	}
 
-	response, err := s.TaskMgr.GetTasks(&GetTasksRequest{
+	response, err := s.TaskMgr.GetTasks(context.Background(), &GetTasksRequest{
 		DomainID:     domainID,
 		TaskList:     taskList,
 		TaskType:     taskType,
@@ -726,7 +884,7 @@ func (s *TestBase) GetTasks(domainID, taskList string, taskType int, batchSize i
 
 // CompleteTask is a utility method to complete a task
 func (s *TestBase) CompleteTask(domainID, taskList string, taskType int, taskID int64, ackLevel int64) error {
-	leaseResponse, err := s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+	leaseResponse, err := s.TaskMgr.LeaseTaskList(context.Background(), &LeaseTaskListRequest{
 		DomainID: domainID,
 		TaskList: taskList,
 		TaskType: taskType,
@@ -735,7 +893,7 @@ func (s *TestBase) CompleteTask(domainID, taskList string, taskType int, taskID
 		return err
 	}
 
-	return s.TaskMgr.CompleteTask(&CompleteTaskRequest{
+	return s.TaskMgr.CompleteTask(context.Background(), &CompleteTaskRequest{
 		TaskList: &TaskListInfo{
 			DomainID: domainID,
 			AckLevel: ackLevel,
@@ -809,7 +967,7 @@ func (s *CassandraTestCluster) tearDownTestCluster() {
 }
 
 func (s *CassandraTestCluster) createCluster(clusterHosts string, dc string, cons gocql.Consistency, keyspace string) {
-	s.cluster = common.NewCassandraCluster(clusterHosts, dc)
+	s.cluster = common.NewCassandraCluster(clusterHosts, dc, nil)
 	s.cluster.Consistency = cons
 	s.cluster.Keyspace = "system"
 	s.cluster.Timeout = 40 * time.Second
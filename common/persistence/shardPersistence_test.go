@@ -23,12 +23,14 @@ package persistence
 import (
 	"os"
 	"testing"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	gen "github.com/uber/cadence/.gen/go/shared"
+	"golang.org/x/net/context"
 )
 
 type (
@@ -112,12 +114,15 @@ func (s *shardPersistenceSuite) TestUpdateShard() {
 	updatedOwner := "updatedOwner"
 	updatedRangeID := int64(142)
 	updatedTransferAckLevel := int64(1000)
+	updatedTimerAckLevel := time.Now().UnixNano()
 	updatedStolenSinceRenew := 10
 	updatedInfo := copyShardInfo(shardInfo)
 	updatedInfo.Owner = updatedOwner
 	updatedInfo.RangeID = updatedRangeID
 	updatedInfo.TransferAckLevel = updatedTransferAckLevel
+	updatedInfo.TimerAckLevel = updatedTimerAckLevel
 	updatedInfo.StolenSinceRenew = updatedStolenSinceRenew
+	updatedInfo.QueuePaused = map[string]bool{"TRANSFER": true, "TIMER": false}
 	err2 := s.UpdateShard(updatedInfo, shardInfo.RangeID)
 	s.Nil(err2)
 
@@ -127,7 +132,9 @@ func (s *shardPersistenceSuite) TestUpdateShard() {
 	s.Equal(updatedOwner, info1.Owner)
 	s.Equal(updatedRangeID, info1.RangeID)
 	s.Equal(updatedTransferAckLevel, info1.TransferAckLevel)
+	s.Equal(updatedTimerAckLevel, info1.TimerAckLevel)
 	s.Equal(updatedStolenSinceRenew, info1.StolenSinceRenew)
+	s.Equal(map[string]bool{"TRANSFER": true, "TIMER": false}, info1.QueuePaused)
 
 	failedUpdateInfo := copyShardInfo(shardInfo)
 	failedUpdateInfo.Owner = "failed_owner"
@@ -142,15 +149,48 @@ func (s *shardPersistenceSuite) TestUpdateShard() {
 	s.Equal(updatedOwner, info2.Owner)
 	s.Equal(updatedRangeID, info2.RangeID)
 	s.Equal(updatedTransferAckLevel, info2.TransferAckLevel)
+	s.Equal(updatedTimerAckLevel, info2.TimerAckLevel)
 	s.Equal(updatedStolenSinceRenew, info2.StolenSinceRenew)
 }
 
+func (s *shardPersistenceSuite) TestAllocateTaskIDs() {
+	shardID := 31
+	owner := "test_allocate_task_ids"
+	rangeID := int64(141)
+	err0 := s.CreateShard(shardID, owner, rangeID)
+	s.Nil(err0, "No error expected.")
+
+	shardInfo, err1 := s.GetShard(shardID)
+	s.Nil(err1)
+	response, err2 := s.ShardMgr.AllocateTaskIDs(context.Background(), &AllocateTaskIDsRequest{
+		ShardInfo:     shardInfo,
+		RangeSizeBits: 20,
+	})
+	s.Nil(err2)
+	s.Equal(rangeID+1, response.RangeID)
+	s.Equal((rangeID+1)<<20, response.MinTaskID)
+	s.Equal((rangeID+2)<<20, response.MaxTaskID)
+
+	info1, err3 := s.GetShard(shardID)
+	s.Nil(err3)
+	s.Equal(rangeID+1, info1.RangeID)
+	s.Equal(owner, info1.Owner)
+
+	// The range allocated with a stale shard belongs to whoever bumped the range ID since
+	_, err4 := s.ShardMgr.AllocateTaskIDs(context.Background(), &AllocateTaskIDsRequest{
+		ShardInfo:     shardInfo,
+		RangeSizeBits: 20,
+	})
+	s.IsType(&ShardOwnershipLostError{}, err4)
+}
+
 func copyShardInfo(sourceInfo *ShardInfo) *ShardInfo {
 	return &ShardInfo{
 		ShardID:          sourceInfo.ShardID,
 		Owner:            sourceInfo.Owner,
 		RangeID:          sourceInfo.RangeID,
 		TransferAckLevel: sourceInfo.TransferAckLevel,
+		TimerAckLevel:    sourceInfo.TimerAckLevel,
 		StolenSinceRenew: sourceInfo.StolenSinceRenew,
 	}
 }
@@ -20,7 +20,11 @@
 
 package persistence
 
-import s "github.com/uber/cadence/.gen/go/shared"
+import (
+	"golang.org/x/net/context"
+
+	s "github.com/uber/cadence/.gen/go/shared"
+)
 
 // Interfaces for the Visibility Store.
 // This is a secondary store that is eventually consistent with the main
@@ -47,9 +51,19 @@ type (
 		StartTimestamp   int64
 		CloseTimestamp   int64
 		Status           s.WorkflowExecutionCloseStatus
+		CloseReason      string
 		RetentionSeconds int64
 	}
 
+	// DeleteClosedWorkflowExecutionRequest is used to delete the record of a closed execution, the start and close
+	// times must be the ones it was recorded with
+	DeleteClosedWorkflowExecutionRequest struct {
+		DomainUUID     string
+		Execution      s.WorkflowExecution
+		StartTimestamp int64
+		CloseTimestamp int64
+	}
+
 	// ListWorkflowExecutionsRequest is used to list executions in a domain
 	ListWorkflowExecutionsRequest struct {
 		DomainUUID        string
@@ -91,16 +105,45 @@ type (
 		Status s.WorkflowExecutionCloseStatus
 	}
 
+	// ListClosedWorkflowExecutionsByCloseTimeRequest is used to list executions
+	// closed within a time range, optionally with a specific close status
+	ListClosedWorkflowExecutionsByCloseTimeRequest struct {
+		DomainUUID        string
+		EarliestCloseTime int64
+		LatestCloseTime   int64
+		Status            *s.WorkflowExecutionCloseStatus
+		// Maximum number of workflow executions per page
+		PageSize int
+		// Token to continue reading next page of workflow executions.
+		// Pass in empty slice for first page.
+		NextPageToken []byte
+	}
+
+	// ListClosedWorkflowExecutionsSinceRequest is used to list executions in
+	// ascending close time order, starting at EarliestCloseTime
+	ListClosedWorkflowExecutionsSinceRequest struct {
+		DomainUUID        string
+		EarliestCloseTime int64
+		LatestCloseTime   int64
+		// Runs closed exactly at EarliestCloseTime which were already listed
+		ExcludedRunIDs []string
+		// Maximum number of workflow executions to return
+		PageSize int
+	}
+
 	// VisibilityManager is used to manage the visibility store
 	VisibilityManager interface {
-		RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error
-		RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error
-		ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error)
-		ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error)
-		ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error)
-		ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error)
-		ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
-		ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
-		ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error)
+		RecordWorkflowExecutionStarted(ctx context.Context, request *RecordWorkflowExecutionStartedRequest) error
+		RecordWorkflowExecutionClosed(ctx context.Context, request *RecordWorkflowExecutionClosedRequest) error
+		DeleteClosedWorkflowExecution(ctx context.Context, request *DeleteClosedWorkflowExecutionRequest) error
+		ListOpenWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutions(ctx context.Context, request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error)
+		ListOpenWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsByType(ctx context.Context, request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error)
+		ListOpenWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsByWorkflowID(ctx context.Context, request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsByStatus(ctx context.Context, request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsByCloseTime(ctx context.Context, request *ListClosedWorkflowExecutionsByCloseTimeRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsSince(ctx context.Context, request *ListClosedWorkflowExecutionsSinceRequest) (*ListWorkflowExecutionsResponse, error)
 	}
 )
@@ -0,0 +1,49 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/uber/cadence/common/blobstore"
+)
+
+// NewClient creates a client of the configured blob store, nil when no store is configured
+func (c *Blobstore) NewClient() (blobstore.Client, error) {
+	if c.Filesystem != nil && c.S3 != nil {
+		return nil, errors.New("blobstore can't be configured with both filesystem and s3")
+	}
+
+	if c.Filesystem != nil {
+		return blobstore.NewFilesystemClient(c.Filesystem.RootDir)
+	}
+	if c.S3 != nil {
+		awsConfig := aws.NewConfig().
+			WithRegion(c.S3.Region).
+			WithS3ForcePathStyle(c.S3.ForcePathStyle)
+		if c.S3.Endpoint != "" {
+			awsConfig = awsConfig.WithEndpoint(c.S3.Endpoint)
+		}
+		return blobstore.NewS3Client(awsConfig)
+	}
+	return nil, nil
+}
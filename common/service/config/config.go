@@ -22,6 +22,8 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/uber-go/tally/m3"
 	"github.com/uber/ringpop-go/discovery"
 	"time"
@@ -30,6 +32,8 @@ import (
 type (
 	// Config contains the configuration for a set of cadence services
 	Config struct {
+		// Cluster is the configuration describing the cadence cluster
+		Cluster Cluster `yaml:"cluster"`
 		// Ringpop is the ringpop related configuration
 		Ringpop Ringpop `yaml:"ringpop"`
 		// Cassandra is the configuration for connecting to cassandra
@@ -38,6 +42,32 @@ type (
 		Log Logger `yaml:"log"`
 		// Services is a map of service name to service config items
 		Services map[string]Service `yaml:"services"`
+		// TaskToken is the configuration for the task tokens handed to workers
+		TaskToken TaskToken `yaml:"taskToken"`
+		// Audit is the configuration for the audit log of mutating frontend calls
+		Audit Audit `yaml:"audit"`
+		// PageToken is the configuration for the page tokens returned by the visibility list APIs
+		PageToken PageToken `yaml:"pageToken"`
+		// ClientVersions is the range of versions of each client library the frontend accepts calls from
+		ClientVersions map[string]ClientVersions `yaml:"clientVersions"`
+		// PropagatedHeaders are the request headers, such as trace and tenant IDs, the frontend records in the
+		// header of the workflows started by the request and hands back to the workers polling their tasks
+		PropagatedHeaders []string `yaml:"propagatedHeaders"`
+		// Blobstore is the configuration for the store of archived histories and visibility records, and of
+		// payloads too large to keep inline
+		Blobstore Blobstore `yaml:"blobstore"`
+		// LargePayloads is the configuration for offloading the payloads of history events to the blob store
+		LargePayloads LargePayloads `yaml:"largePayloads"`
+		// Tracing is the configuration for the Jaeger tracer the spans of the services are reported to
+		Tracing Tracing `yaml:"tracing"`
+	}
+
+	// Cluster contains the config items describing the cadence cluster
+	Cluster struct {
+		// NumHistoryShards is the number of history shards workflow executions are partitioned into. It has to
+		// be the same on every host, and can only be changed by resharding the stopped cluster with
+		// cadence-cassandra-tool
+		NumHistoryShards int `yaml:"numHistoryShards"`
 	}
 
 	// Service contains the service specific config items
@@ -46,6 +76,30 @@ type (
 		TChannel TChannel `yaml:"tchannel"`
 		// Metrics is the metrics subsystem configuration
 		Metrics Metrics `yaml:"metrics"`
+		// CassandraRouting overrides the cassandra datacenter routing for this service
+		CassandraRouting *CassandraRouting `yaml:"cassandraRouting"`
+		// PProf is the configuration of the pprof HTTP endpoints of this service
+		PProf PProf `yaml:"pprof"`
+	}
+
+	// PProf contains the config items for the pprof HTTP endpoints, used to profile a running service and dump
+	// the stacks of its goroutines
+	PProf struct {
+		// Port is the port the endpoints are served on, the endpoints are disabled when it is zero
+		Port int `yaml:"port"`
+		// BindOnLocalHost is true if localhost is the bind address
+		BindOnLocalHost bool `yaml:"bindOnLocalHost"`
+	}
+
+	// Tracing contains the config items for the Jaeger tracer. The services of a process share one tracer, which
+	// is registered as the opentracing global tracer and so is also the tracer of their tchannel channels
+	Tracing struct {
+		// AgentHostPort is the host:port of the jaeger-agent the spans are sent to, tracing is disabled when it
+		// is empty
+		AgentHostPort string `yaml:"agentHostPort"`
+		// SamplingRate is the fraction, between 0 and 1, of the traces started by the services which are
+		// sampled. The traces started by the callers are sampled as the callers decided
+		SamplingRate float64 `yaml:"samplingRate"`
 	}
 
 	// TChannel contains the tchannel config items
@@ -89,7 +143,129 @@ type (
 		// Datacenter is the data center filter arg for cassandra
 		Datacenter string `yaml:"datacenter"`
 		// NumHistoryShards is the desired number of history shards
-		NumHistoryShards int `yaml:"numHistoryShards" validate:"nonzero"`
+		// Deprecated: use cluster.numHistoryShards instead
+		NumHistoryShards int `yaml:"numHistoryShards"`
+		// Timeouts is the per-operation timeout configuration for cassandra calls
+		Timeouts CassandraTimeouts `yaml:"timeouts"`
+		// StoreConsistency overrides the consistency levels of a persistence store. It is keyed by
+		// store name: shard, execution, task, history, metadata or visibility
+		StoreConsistency map[string]CassandraStoreConsistency `yaml:"storeConsistency"`
+		// Routing is the datacenter routing configuration
+		Routing CassandraRouting `yaml:"routing"`
+		// Retry is the retry policy of the queries which fail on their coordinator
+		Retry CassandraRetry `yaml:"retry"`
+		// SlowQueryThreshold enables logging the queries slower than the threshold, with their bound values redacted
+		SlowQueryThreshold time.Duration `yaml:"slowQueryThreshold"`
+	}
+
+	// CassandraRetry contains the config items for retrying failed cassandra queries on the next host. Idempotent
+	// queries are retried with an exponential backoff, conditional updates are never retried. Zero values use defaults
+	CassandraRetry struct {
+		// MaxRetries is the maximum number of retries of a query, a negative value disables retries
+		MaxRetries int `yaml:"maxRetries"`
+		// InitialInterval is the backoff before the first retry. Reads are first retried immediately
+		InitialInterval time.Duration `yaml:"initialInterval"`
+		// MaxInterval caps the backoff between retries
+		MaxInterval time.Duration `yaml:"maxInterval"`
+	}
+
+	// CassandraRouting contains the datacenter routing config items. Queries always prefer the
+	// hosts of Datacenter and only go to a remote datacenter when no local host is up
+	CassandraRouting struct {
+		// RemoteDatacenters maps the name of a remote datacenter to a csv of its cassandra endpoints
+		RemoteDatacenters map[string]string `yaml:"remoteDatacenters"`
+		// FallbackConsistency is the consistency level queries are downgraded to while no local
+		// host is up, e.g. ONE. An empty value keeps the configured consistency levels
+		FallbackConsistency string `yaml:"fallbackConsistency"`
+	}
+
+	// CassandraStoreConsistency contains the consistency levels used by a persistence store,
+	// e.g. LOCAL_QUORUM. An empty value means the default for the store is used
+	CassandraStoreConsistency struct {
+		// Read is the consistency level for reads that tolerate stale data
+		Read string `yaml:"read"`
+		// Write is the consistency level for writes and all other reads
+		Write string `yaml:"write"`
+	}
+
+	// CassandraTimeouts contains the timeouts applied to individual cassandra operations.
+	// A zero value means the default for that kind of operation is used
+	CassandraTimeouts struct {
+		// Read is the timeout for single partition reads
+		Read time.Duration `yaml:"read"`
+		// Write is the timeout for writes, including conditional updates
+		Write time.Duration `yaml:"write"`
+		// RangeScan is the timeout for paginated reads over a range of rows
+		RangeScan time.Duration `yaml:"rangeScan"`
+		// Overrides maps an operation name, e.g. GetTimerIndexTasks, to its timeout
+		Overrides map[string]time.Duration `yaml:"overrides"`
+	}
+
+	// TaskToken contains the config items for task tokens. Tokens are signed and bound to the cluster,
+	// identified by the ringpop name, once a signing key is set
+	TaskToken struct {
+		// SigningKey is the HMAC key used to sign task tokens, tokens are unsigned JSON when it is empty
+		SigningKey string `yaml:"signingKey"`
+		// AcceptLegacyTokens keeps accepting unsigned JSON tokens, to be set while migrating to signed tokens
+		AcceptLegacyTokens bool `yaml:"acceptLegacyTokens"`
+	}
+
+	// PageToken contains the config items for visibility page tokens. Tokens are signed, bound to the query they
+	// were issued for, and expire after their TTL
+	PageToken struct {
+		// SigningKey is the HMAC key used to sign page tokens, it has to be the same on every frontend host.
+		// The frontend refuses to start without it
+		SigningKey string `yaml:"signingKey"`
+		// TTL is how long a page token can be used, defaults to an hour
+		TTL time.Duration `yaml:"ttl"`
+	}
+
+	// ClientVersions contains the range of versions of a client library the frontend accepts calls from.
+	// Versions are dotted numbers, an empty bound leaves the range open on that side
+	ClientVersions struct {
+		// MinVersion is the lowest supported version
+		MinVersion string `yaml:"minVersion"`
+		// MaxVersion is the highest supported version
+		MaxVersion string `yaml:"maxVersion"`
+	}
+
+	// Audit contains the config items for the audit log
+	Audit struct {
+		// FilePath is the file audit records are appended to, auditing is disabled when empty
+		FilePath string `yaml:"filePath"`
+	}
+
+	// Blobstore contains the config items for the blob store, at most one of the stores can be set
+	Blobstore struct {
+		// Filesystem keeps the blobs in files under a local directory, for development and tests
+		Filesystem *FilesystemBlobstore `yaml:"filesystem"`
+		// S3 keeps the blobs in S3 buckets
+		S3 *S3Blobstore `yaml:"s3"`
+	}
+
+	// FilesystemBlobstore contains the config items for a blob store on the local filesystem
+	FilesystemBlobstore struct {
+		// RootDir is the directory the buckets are created in
+		RootDir string `yaml:"rootDir" validate:"nonzero"`
+	}
+
+	// S3Blobstore contains the config items for a blob store in S3
+	S3Blobstore struct {
+		// Region is the AWS region of the buckets
+		Region string `yaml:"region" validate:"nonzero"`
+		// Endpoint overrides the endpoint of S3, to keep the blobs in another store exposing its API
+		Endpoint string `yaml:"endpoint"`
+		// ForcePathStyle addresses the buckets in the path of the URLs rather than their host name, which most
+		// stores exposing the S3 API require
+		ForcePathStyle bool `yaml:"forcePathStyle"`
+	}
+
+	// LargePayloads contains the config items for offloading large payloads to the blob store
+	LargePayloads struct {
+		// Bucket is the blob store bucket the payloads are kept in
+		Bucket string `yaml:"bucket"`
+		// Threshold is the size in bytes above which payloads are offloaded, offloading is disabled when zero
+		Threshold int `yaml:"threshold"`
 	}
 
 	// Logger contains the config items for logger
@@ -100,6 +276,11 @@ type (
 		Level string `yaml:"level"`
 		// OutputFile is the path to the log output file
 		OutputFile string `yaml:"outputFile"`
+		// Format is the format of the log entries, text or json. Defaults to text
+		Format string `yaml:"format"`
+		// TimestampKey overrides the key of the timestamp of json log entries, e.g. Timestamp or @timestamp.
+		// Defaults to time
+		TimestampKey string `yaml:"timestampKey"`
 	}
 
 	// Metrics contains the config items for metrics subsystem
@@ -111,6 +292,9 @@ type (
 		// Tags is the set of key-value pairs to be reported
 		// as part of every metric
 		Tags map[string]string `yaml:"tags"`
+		// RuntimeReportInterval is how often the Go runtime metrics, such as the number of goroutines, the heap
+		// size and the GC pauses, are reported. Defaults to a minute
+		RuntimeReportInterval time.Duration `yaml:"runtimeReportInterval"`
 	}
 
 	// Statsd contains the config items for statsd metrics reporter
@@ -132,6 +316,22 @@ type (
 	BootstrapMode int
 )
 
+// GetNumHistoryShards returns the number of history shards of the cluster.  The deprecated
+// cassandra.numHistoryShards is still honored when cluster.numHistoryShards is not set
+func (c *Config) GetNumHistoryShards() (int, error) {
+	numHistoryShards := c.Cluster.NumHistoryShards
+	if numHistoryShards == 0 {
+		numHistoryShards = c.Cassandra.NumHistoryShards
+	} else if c.Cassandra.NumHistoryShards != 0 && c.Cassandra.NumHistoryShards != numHistoryShards {
+		return 0, fmt.Errorf("cluster.numHistoryShards (%v) conflicts with cassandra.numHistoryShards (%v)",
+			numHistoryShards, c.Cassandra.NumHistoryShards)
+	}
+	if numHistoryShards <= 0 {
+		return 0, errors.New("cluster.numHistoryShards must be set to a positive number")
+	}
+	return numHistoryShards, nil
+}
+
 // String converts the config object into a string
 func (c *Config) String() string {
 	out, _ := json.MarshalIndent(c, "", "    ")
@@ -31,7 +31,12 @@ import (
 	"strings"
 )
 
-const fileMode = os.FileMode(0644)
+const (
+	fileMode = os.FileMode(0644)
+
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
 
 // NewBarkLogger builds and returns a new bark
 // logger for this logging configuration
@@ -40,7 +45,7 @@ func (cfg *Logger) NewBarkLogger() bark.Logger {
 	logger := logrus.New()
 	logger.Out = ioutil.Discard
 	logger.Level = parseLogrusLevel(cfg.Level)
-	logger.Formatter = getFormatter()
+	logger.Formatter = cfg.getFormatter()
 
 	if cfg.Stdout {
 		logger.Out = os.Stdout
@@ -57,10 +62,22 @@ func (cfg *Logger) NewBarkLogger() bark.Logger {
 	return bark.NewLoggerFromLogrus(logger)
 }
 
-func getFormatter() logrus.Formatter {
-	formatter := &logrus.TextFormatter{}
-	formatter.FullTimestamp = true
-	return formatter
+func (cfg *Logger) getFormatter() logrus.Formatter {
+	switch strings.ToLower(cfg.Format) {
+	case "", logFormatText:
+		formatter := &logrus.TextFormatter{}
+		formatter.FullTimestamp = true
+		return formatter
+	case logFormatJSON:
+		formatter := &logrus.JSONFormatter{}
+		if len(cfg.TimestampKey) > 0 {
+			formatter.FieldMap = logrus.FieldMap{logrus.FieldKeyTime: cfg.TimestampKey}
+		}
+		return formatter
+	default:
+		log.Fatalf("unknown log format %v, expected %v or %v", cfg.Format, logFormatText, logFormatJSON)
+		return nil
+	}
 }
 
 func createLogFile(path string) *os.File {
@@ -21,6 +21,7 @@
 package config
 
 import (
+	"encoding/json"
 	"github.com/Sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -35,7 +36,7 @@ type LogSuite struct {
 }
 
 func TestLogSuite(t *testing.T) {
-	suite.Run(t, new(LoaderSuite))
+	suite.Run(t, new(LogSuite))
 }
 
 func (s *LogSuite) SetupTest() {
@@ -68,3 +69,33 @@ func (s *LogSuite) TestNewLogger() {
 	_, err = os.Stat(dir + "/test.log")
 	s.Nil(err)
 }
+
+func (s *LogSuite) TestJSONFormat() {
+	dir, err := ioutil.TempDir("", "config.testJSONFormat")
+	s.Nil(err)
+	defer os.RemoveAll(dir)
+
+	config := &Logger{
+		Level:        "info",
+		OutputFile:   dir + "/test.log",
+		Format:       "json",
+		TimestampKey: "Timestamp",
+	}
+	config.NewBarkLogger().WithField("wf-id", "id").Info("message")
+
+	data, err := ioutil.ReadFile(dir + "/test.log")
+	s.Nil(err)
+	var entry map[string]interface{}
+	s.Nil(json.Unmarshal(data, &entry))
+	s.Equal("message", entry["msg"])
+	s.Equal("info", entry["level"])
+	s.Equal("id", entry["wf-id"])
+	s.NotEmpty(entry["Timestamp"])
+	s.Nil(entry["time"])
+}
+
+func (s *LogSuite) TestTextFormat() {
+	s.IsType(&logrus.TextFormatter{}, (&Logger{}).getFormatter())
+	s.IsType(&logrus.TextFormatter{}, (&Logger{Format: "text"}).getFormatter())
+	s.IsType(&logrus.JSONFormatter{}, (&Logger{Format: "JSON"}).getFormatter())
+}
@@ -22,6 +22,7 @@ package config
 
 import (
 	"fmt"
+	"github.com/uber/cadence/common/tracing"
 	"github.com/uber/tchannel-go"
 	"github.com/uber/tchannel-go/thrift"
 	"log"
@@ -65,6 +66,7 @@ func (factory *TChannelFactory) CreateChannel(sName string,
 	}
 
 	server := thrift.NewServer(ch)
+	server.SetContextFn(tracing.NewRequestContext)
 	for _, s := range thriftServices {
 		server.Register(s)
 	}
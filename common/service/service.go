@@ -22,6 +22,8 @@ package service
 
 import (
 	"math/rand"
+	"net"
+	"net/http"
 	"os"
 	"time"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service/config"
 
 	log "github.com/Sirupsen/logrus"
@@ -46,12 +49,28 @@ type (
 	// BootstrapParams holds the set of parameters
 	// needed to bootstrap a service
 	BootstrapParams struct {
-		Name            string
-		Logger          bark.Logger
-		MetricScope     tally.Scope
-		RingpopFactory  RingpopFactory
-		TChannelFactory TChannelFactory
-		CassandraConfig config.Cassandra
+		Name              string
+		Logger            bark.Logger
+		MetricScope       tally.Scope
+		RingpopFactory    RingpopFactory
+		TChannelFactory   TChannelFactory
+		CassandraConfig   config.Cassandra
+		ClusterName       string
+		NumHistoryShards  int
+		TaskToken         config.TaskToken
+		Audit             config.Audit
+		PageToken         config.PageToken
+		ClientVersions    map[string]config.ClientVersions
+		PropagatedHeaders []string
+		Blobstore         config.Blobstore
+		LargePayloads     config.LargePayloads
+		// PersistenceFactory creates the persistence managers of the service, services embedded in other processes
+		// or assembled by tests pass their own.  Nil creates them on the Cassandra cluster of CassandraConfig.
+		PersistenceFactory persistence.Factory
+		// PProf enables the pprof HTTP endpoints of the service
+		PProf config.PProf
+		// RuntimeMetricsInterval is how often the Go runtime metrics are reported, zero means every minute
+		RuntimeMetricsInterval time.Duration
 	}
 
 	// TChannelFactory creates a TChannel and Thrift server
@@ -85,9 +104,14 @@ type (
 		metricsScope           tally.Scope
 		runtimeMetricsReporter *metrics.RuntimeMetricsReporter
 		metricsClient          metrics.Client
+		taskTokenSerializer    common.TaskTokenSerializer
+		persistenceFactory     persistence.Factory
+		pprofServer            *http.Server
 	}
 )
 
+const defaultRuntimeMetricsInterval = time.Minute
+
 // New instantiates a Service Instance
 // TODO: have a better name for Service.
 func New(params *BootstrapParams) Service {
@@ -97,10 +121,26 @@ func New(params *BootstrapParams) Service {
 		tchannelFactory:       params.TChannelFactory,
 		rpFactory:             params.RingpopFactory,
 		metricsScope:          params.MetricScope,
-		numberOfHistoryShards: params.CassandraConfig.NumHistoryShards,
+		numberOfHistoryShards: params.NumHistoryShards,
+	}
+	runtimeMetricsInterval := params.RuntimeMetricsInterval
+	if runtimeMetricsInterval <= 0 {
+		runtimeMetricsInterval = defaultRuntimeMetricsInterval
 	}
-	sVice.runtimeMetricsReporter = metrics.NewRuntimeMetricsReporter(params.MetricScope, time.Minute, sVice.logger)
+	sVice.runtimeMetricsReporter = metrics.NewRuntimeMetricsReporter(params.MetricScope, runtimeMetricsInterval,
+		sVice.logger)
+	sVice.pprofServer = params.PProf.NewServer()
 	sVice.metricsClient = metrics.NewClient(params.MetricScope, getMetricsServiceIdx(params.Name, params.Logger))
+	sVice.taskTokenSerializer = common.NewJSONTaskTokenSerializer()
+	if params.TaskToken.SigningKey != "" {
+		sVice.taskTokenSerializer = common.NewSignedTaskTokenSerializer(params.ClusterName,
+			[]byte(params.TaskToken.SigningKey), params.TaskToken.AcceptLegacyTokens)
+	}
+	sVice.persistenceFactory = params.PersistenceFactory
+	if sVice.persistenceFactory == nil {
+		sVice.persistenceFactory = persistence.NewCassandraFactory(params.CassandraConfig, sVice.metricsClient,
+			sVice.logger)
+	}
 
 	// Get the host name and set it on the service.  This is used for emitting metric with a tag for hostname
 	if hostName, e := os.Hostname(); e != nil {
@@ -132,6 +172,7 @@ func (h *serviceImpl) Start(thriftServices []thrift.TChanServer) {
 
 	h.metricsScope.Counter(metrics.RestartCount).Inc(1)
 	h.runtimeMetricsReporter.Start()
+	h.startPProf()
 
 	h.ch, h.server = h.tchannelFactory.CreateChannel(h.sName, thriftServices)
 
@@ -164,7 +205,7 @@ func (h *serviceImpl) Start(thriftServices []thrift.TChanServer) {
 	h.hostInfo = hostInfo
 
 	h.clientFactory = client.NewTChannelClientFactory(h.ch, h.membershipMonitor, h.metricsClient,
-		h.numberOfHistoryShards)
+		h.numberOfHistoryShards, h.taskTokenSerializer)
 
 	// The service is now started up
 	h.logger.Info("service started")
@@ -188,6 +229,28 @@ func (h *serviceImpl) Stop() {
 	}
 
 	h.runtimeMetricsReporter.Stop()
+
+	if h.pprofServer != nil {
+		h.pprofServer.Close()
+	}
+}
+
+// startPProf starts serving the pprof endpoints, if they are enabled
+func (h *serviceImpl) startPProf() {
+	if h.pprofServer == nil {
+		return
+	}
+
+	listener, err := net.Listen("tcp", h.pprofServer.Addr)
+	if err != nil {
+		h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("pprof listen failed")
+	}
+	go func() {
+		if err := h.pprofServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			h.logger.WithFields(bark.Fields{logging.TagErr: err}).Error("pprof server failed")
+		}
+	}()
+	h.logger.Infof("pprof endpoints served on %v", listener.Addr())
 }
 
 // GetLogger returns the service logger
@@ -211,6 +274,18 @@ func (h *serviceImpl) GetHostInfo() *membership.HostInfo {
 	return h.hostInfo
 }
 
+func (h *serviceImpl) GetTaskTokenSerializer() common.TaskTokenSerializer {
+	return h.taskTokenSerializer
+}
+
+func (h *serviceImpl) GetNumberOfHistoryShards() int {
+	return h.numberOfHistoryShards
+}
+
+func (h *serviceImpl) GetPersistenceFactory() persistence.Factory {
+	return h.persistenceFactory
+}
+
 func getMetricsServiceIdx(serviceName string, logger bark.Logger) metrics.ServiceIdx {
 	switch serviceName {
 	case common.FrontendServiceName:
@@ -18,50 +18,33 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-package history
+package service
 
 import (
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 	"github.com/uber-common/bark"
-	"github.com/uber/cadence/common/metrics"
+	"github.com/uber-go/tally"
+
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/persistence"
-	"github.com/uber/cadence/common/service/config"
 )
 
-// executionMgrFactory is an implementation of
-// persistence.ExecutionManagerFactory interface
-type executionMgrFactory struct {
-	config        *config.Cassandra
-	logger        bark.Logger
-	metricsClient metrics.Client
+type testPersistenceFactory struct {
+	persistence.Factory
 }
 
-// NewExecutionManagerFactory builds and returns a factory object
-func NewExecutionManagerFactory(config *config.Cassandra,
-	logger bark.Logger, mClient metrics.Client) persistence.ExecutionManagerFactory {
-
-	return &executionMgrFactory{
-		config:        config,
-		logger:        logger,
-		metricsClient: mClient,
+func TestPersistenceFactory(t *testing.T) {
+	params := &BootstrapParams{
+		Name:        common.FrontendServiceName,
+		Logger:      bark.NewLoggerFromLogrus(log.New()),
+		MetricScope: tally.NoopScope,
 	}
-}
-
-// CreateExecutionManager implements ExecutionManagerFactory interface
-func (factory *executionMgrFactory) CreateExecutionManager(shardID int) (persistence.ExecutionManager, error) {
+	assert.NotNil(t, New(params).GetPersistenceFactory())
 
-	mgr, err := persistence.NewCassandraWorkflowExecutionPersistence(
-		factory.config.Hosts,
-		factory.config.Datacenter,
-		factory.config.Keyspace,
-		shardID,
-		factory.logger)
-
-	if err != nil {
-		return nil, err
-	}
-
-	tags := map[string]string{
-		metrics.ShardTagName: string(shardID),
-	}
-	return persistence.NewWorkflowExecutionPersistenceClient(mgr, factory.metricsClient.Tagged(tags)), nil
+	factory := &testPersistenceFactory{}
+	params.PersistenceFactory = factory
+	assert.Equal(t, factory, New(params).GetPersistenceFactory())
 }
@@ -25,12 +25,15 @@ import (
 	"github.com/uber/tchannel-go/thrift"
 
 	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
 )
 
 type (
-	// Service is the interface which must be implemented by all the services
+	// Service is the interface which must be implemented by all the services.  It holds the resources shared by the
+	// components of a service, which get them through its accessors instead of creating their own.
 	Service interface {
 		// GetHostName returns the name of host running the service
 		GetHostName() string
@@ -50,5 +53,13 @@ type (
 		GetMembershipMonitor() membership.Monitor
 
 		GetHostInfo() *membership.HostInfo
+
+		GetTaskTokenSerializer() common.TaskTokenSerializer
+
+		// GetNumberOfHistoryShards returns the number of history shards of the cluster
+		GetNumberOfHistoryShards() int
+
+		// GetPersistenceFactory returns the factory of the persistence managers of the service
+		GetPersistenceFactory() persistence.Factory
 	}
 )
@@ -0,0 +1,162 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	// taskTokenVersion1 is the first version of the signed binary task token format
+	taskTokenVersion1 byte = 1
+	// taskTokenVersion2 adds the activity ID after the schedule ID, tokens of version 1 are still accepted
+	taskTokenVersion2 byte = 2
+	// taskTokenVersion3 adds the query ID after the activity ID
+	taskTokenVersion3 byte = 3
+
+	// taskTokenSignatureSize is the size of the HMAC-SHA256 signature trailing the token
+	taskTokenSignatureSize = sha256.Size
+)
+
+var (
+	// ErrInvalidTaskToken is returned for task tokens which can't be decoded
+	ErrInvalidTaskToken = errors.New("invalid task token")
+	// ErrTaskTokenSignatureMismatch is returned for task tokens not signed with the signing key of the cluster
+	ErrTaskTokenSignatureMismatch = errors.New("task token signature mismatch")
+	// ErrTaskTokenClusterMismatch is returned for task tokens issued by another cluster
+	ErrTaskTokenClusterMismatch = errors.New("task token issued by another cluster")
+)
+
+type (
+	// signedTaskTokenSerializer encodes task tokens as a version byte, followed by the token fields and an
+	// HMAC-SHA256 signature of both. Tokens are bound to the cluster which issued them.
+	signedTaskTokenSerializer struct {
+		clusterName        string
+		signingKey         []byte
+		acceptLegacyTokens bool
+		legacySerializer   TaskTokenSerializer
+	}
+)
+
+// NewSignedTaskTokenSerializer creates a TaskTokenSerializer signing tokens with signingKey. When acceptLegacyTokens
+// is set, unsigned JSON tokens are accepted as well, to migrate from the JSON serializer.
+func NewSignedTaskTokenSerializer(clusterName string, signingKey []byte, acceptLegacyTokens bool) TaskTokenSerializer {
+	return &signedTaskTokenSerializer{
+		clusterName:        clusterName,
+		signingKey:         signingKey,
+		acceptLegacyTokens: acceptLegacyTokens,
+		legacySerializer:   NewJSONTaskTokenSerializer(),
+	}
+}
+
+func (s *signedTaskTokenSerializer) Serialize(token *TaskToken) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte(taskTokenVersion3)
+	writeTaskTokenString(buf, s.clusterName)
+	writeTaskTokenString(buf, token.DomainID)
+	writeTaskTokenString(buf, token.WorkflowID)
+	writeTaskTokenString(buf, token.RunID)
+	var scheduleID [binary.MaxVarintLen64]byte
+	buf.Write(scheduleID[:binary.PutVarint(scheduleID[:], token.ScheduleID)])
+	writeTaskTokenString(buf, token.ActivityID)
+	writeTaskTokenString(buf, token.QueryID)
+	buf.Write(s.sign(buf.Bytes()))
+	return buf.Bytes(), nil
+}
+
+func (s *signedTaskTokenSerializer) Deserialize(data []byte) (*TaskToken, error) {
+	if len(data) > 0 && data[0] == '{' {
+		if !s.acceptLegacyTokens {
+			return nil, ErrTaskTokenSignatureMismatch
+		}
+		return s.legacySerializer.Deserialize(data)
+	}
+	if len(data) <= taskTokenSignatureSize || data[0] < taskTokenVersion1 || data[0] > taskTokenVersion3 {
+		return nil, ErrInvalidTaskToken
+	}
+
+	payload := data[:len(data)-taskTokenSignatureSize]
+	if !hmac.Equal(data[len(data)-taskTokenSignatureSize:], s.sign(payload)) {
+		return nil, ErrTaskTokenSignatureMismatch
+	}
+
+	reader := bytes.NewReader(payload[1:])
+	clusterName, err := readTaskTokenString(reader)
+	if err != nil {
+		return nil, err
+	}
+	if clusterName != s.clusterName {
+		return nil, ErrTaskTokenClusterMismatch
+	}
+	token := &TaskToken{}
+	if token.DomainID, err = readTaskTokenString(reader); err != nil {
+		return nil, err
+	}
+	if token.WorkflowID, err = readTaskTokenString(reader); err != nil {
+		return nil, err
+	}
+	if token.RunID, err = readTaskTokenString(reader); err != nil {
+		return nil, err
+	}
+	if token.ScheduleID, err = binary.ReadVarint(reader); err != nil {
+		return nil, ErrInvalidTaskToken
+	}
+	if data[0] >= taskTokenVersion2 {
+		if token.ActivityID, err = readTaskTokenString(reader); err != nil {
+			return nil, err
+		}
+	}
+	if data[0] >= taskTokenVersion3 {
+		if token.QueryID, err = readTaskTokenString(reader); err != nil {
+			return nil, err
+		}
+	}
+	if reader.Len() != 0 {
+		return nil, ErrInvalidTaskToken
+	}
+	return token, nil
+}
+
+func (s *signedTaskTokenSerializer) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func writeTaskTokenString(buf *bytes.Buffer, value string) {
+	var length [binary.MaxVarintLen64]byte
+	buf.Write(length[:binary.PutUvarint(length[:], uint64(len(value)))])
+	buf.WriteString(value)
+}
+
+func readTaskTokenString(reader *bytes.Reader) (string, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil || length > uint64(reader.Len()) {
+		return "", ErrInvalidTaskToken
+	}
+	value := make([]byte, length)
+	reader.Read(value)
+	return string(value), nil
+}
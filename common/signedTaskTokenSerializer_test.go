@@ -0,0 +1,146 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	SignedTaskTokenSerializerSuite struct {
+		*require.Assertions
+		suite.Suite
+	}
+)
+
+func TestSignedTaskTokenSerializerSuite(t *testing.T) {
+	suite.Run(t, new(SignedTaskTokenSerializerSuite))
+}
+
+func (s *SignedTaskTokenSerializerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *SignedTaskTokenSerializerSuite) TestRoundTrip() {
+	serializer := NewSignedTaskTokenSerializer("cluster1", []byte("key"), false)
+	tokens := []*TaskToken{
+		{DomainID: "domainId", WorkflowID: "wId", RunID: "rId", ScheduleID: 42, ActivityID: "aId"},
+		{DomainID: "domainId", WorkflowID: "wId", RunID: "rId", ScheduleID: EmptyEventID, ActivityID: "aId"},
+		{DomainID: "domainId", WorkflowID: "wId", RunID: "rId", ScheduleID: EmptyEventID, QueryID: "qId"},
+		{},
+	}
+
+	for _, token := range tokens {
+		data, err := serializer.Serialize(token)
+		s.NoError(err)
+		s.Equal(taskTokenVersion3, data[0])
+		result, err := serializer.Deserialize(data)
+		s.NoError(err)
+		s.Equal(token, result)
+	}
+}
+
+func (s *SignedTaskTokenSerializerSuite) TestActivityIDSigned() {
+	serializer := NewSignedTaskTokenSerializer("cluster1", []byte("key"), false)
+	data, err := serializer.Serialize(&TaskToken{WorkflowID: "wId", RunID: "rId", ScheduleID: EmptyEventID,
+		ActivityID: "aId"})
+	s.NoError(err)
+
+	// the activity ID is followed by the empty query ID before the signature
+	data[len(data)-taskTokenSignatureSize-2]++
+	_, err = serializer.Deserialize(data)
+	s.Equal(ErrTaskTokenSignatureMismatch, err)
+}
+
+func (s *SignedTaskTokenSerializerSuite) TestVersion1Tokens() {
+	serializer := NewSignedTaskTokenSerializer("cluster1", []byte("key"), false).(*signedTaskTokenSerializer)
+	token := &TaskToken{DomainID: "domainId", WorkflowID: "wId", RunID: "rId", ScheduleID: 42}
+
+	// version 1 tokens end with the schedule ID
+	data, err := serializer.Serialize(token)
+	s.NoError(err)
+	payload := append([]byte{taskTokenVersion1}, data[1:len(data)-taskTokenSignatureSize-2]...)
+	data = append(payload, serializer.sign(payload)...)
+
+	result, err := serializer.Deserialize(data)
+	s.NoError(err)
+	s.Equal(token, result)
+}
+
+func (s *SignedTaskTokenSerializerSuite) TestVersion2Tokens() {
+	serializer := NewSignedTaskTokenSerializer("cluster1", []byte("key"), false).(*signedTaskTokenSerializer)
+	token := &TaskToken{DomainID: "domainId", WorkflowID: "wId", RunID: "rId", ScheduleID: EmptyEventID,
+		ActivityID: "aId"}
+
+	// version 2 tokens end with the activity ID
+	data, err := serializer.Serialize(token)
+	s.NoError(err)
+	payload := append([]byte{taskTokenVersion2}, data[1:len(data)-taskTokenSignatureSize-1]...)
+	data = append(payload, serializer.sign(payload)...)
+
+	result, err := serializer.Deserialize(data)
+	s.NoError(err)
+	s.Equal(token, result)
+}
+
+func (s *SignedTaskTokenSerializerSuite) TestForgedToken() {
+	serializer := NewSignedTaskTokenSerializer("cluster1", []byte("key"), false)
+	data, err := serializer.Serialize(&TaskToken{WorkflowID: "wId", RunID: "rId", ScheduleID: 42})
+	s.NoError(err)
+
+	data[len(data)-taskTokenSignatureSize-1]++
+	_, err = serializer.Deserialize(data)
+	s.Equal(ErrTaskTokenSignatureMismatch, err)
+
+	forged, err := NewSignedTaskTokenSerializer("cluster1", []byte("other"), false).Serialize(
+		&TaskToken{WorkflowID: "wId", RunID: "rId", ScheduleID: 42})
+	s.NoError(err)
+	_, err = serializer.Deserialize(forged)
+	s.Equal(ErrTaskTokenSignatureMismatch, err)
+
+	_, err = serializer.Deserialize([]byte{taskTokenVersion1})
+	s.Equal(ErrInvalidTaskToken, err)
+}
+
+func (s *SignedTaskTokenSerializerSuite) TestOtherCluster() {
+	data, err := NewSignedTaskTokenSerializer("cluster2", []byte("key"), false).Serialize(
+		&TaskToken{WorkflowID: "wId", RunID: "rId", ScheduleID: 42})
+	s.NoError(err)
+
+	_, err = NewSignedTaskTokenSerializer("cluster1", []byte("key"), false).Deserialize(data)
+	s.Equal(ErrTaskTokenClusterMismatch, err)
+}
+
+func (s *SignedTaskTokenSerializerSuite) TestLegacyTokens() {
+	token := &TaskToken{DomainID: "domainId", WorkflowID: "wId", RunID: "rId", ScheduleID: 42}
+	data, err := NewJSONTaskTokenSerializer().Serialize(token)
+	s.NoError(err)
+
+	_, err = NewSignedTaskTokenSerializer("cluster1", []byte("key"), false).Deserialize(data)
+	s.Equal(ErrTaskTokenSignatureMismatch, err)
+
+	result, err := NewSignedTaskTokenSerializer("cluster1", []byte("key"), true).Deserialize(data)
+	s.NoError(err)
+	s.Equal(token, result)
+}
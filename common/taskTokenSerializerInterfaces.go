@@ -33,5 +33,9 @@ type (
 		WorkflowID string `json:"workflowId"`
 		RunID      string `json:"runId"`
 		ScheduleID int64  `json:"scheduleId"`
+		// ActivityID locates the activity when ScheduleID is EmptyEventID, for tokens made up from the activity ID
+		ActivityID string `json:"activityId,omitempty"`
+		// QueryID is set for query tasks, which hand a query to the worker without a decision
+		QueryID string `json:"queryId,omitempty"`
 	}
 )
@@ -20,7 +20,10 @@
 
 package common
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 type (
 	// TimeSource is an interface for any
@@ -32,6 +35,13 @@ type (
 	}
 	// realTimeSource serves real wall-clock time
 	realTimeSource struct{}
+
+	// FakeTimeSource serves a time which only moves when
+	// updated, for tests of time dependent behavior
+	FakeTimeSource struct {
+		sync.RWMutex
+		now time.Time
+	}
 )
 
 // NewRealTimeSource returns a time source that servers
@@ -43,3 +53,30 @@ func NewRealTimeSource() TimeSource {
 func (ts *realTimeSource) Now() time.Time {
 	return time.Now()
 }
+
+// NewFakeTimeSource returns a time source that serves
+// the given time until updated
+func NewFakeTimeSource(now time.Time) *FakeTimeSource {
+	return &FakeTimeSource{now: now}
+}
+
+// Now returns the current fake time
+func (ts *FakeTimeSource) Now() time.Time {
+	ts.RLock()
+	defer ts.RUnlock()
+	return ts.now
+}
+
+// Update sets the current fake time
+func (ts *FakeTimeSource) Update(now time.Time) {
+	ts.Lock()
+	defer ts.Unlock()
+	ts.now = now
+}
+
+// Advance moves the current fake time forward by d
+func (ts *FakeTimeSource) Advance(d time.Duration) {
+	ts.Lock()
+	defer ts.Unlock()
+	ts.now = ts.now.Add(d)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"github.com/pborman/uuid"
+	"github.com/uber-common/bark"
+	"github.com/uber/tchannel-go/thrift"
+	"golang.org/x/net/context"
+
+	"github.com/uber/cadence/common/logging"
+)
+
+// HeaderRequestID is the tchannel header carrying the id of the request a call is made for.  The id is accepted from
+// the caller or generated by the first service the request reaches, returned to the caller as a response header, and
+// propagated with the headers of the calls made to other services on behalf of the request.
+const HeaderRequestID = "cadence-request-id"
+
+// NewRequestContext creates the context of an incoming call, making sure it carries a request id.  It is the context
+// function of the thrift servers of the cadence services.
+func NewRequestContext(ctx context.Context, method string, headers map[string]string) thrift.Context {
+	requestID, ok := headers[HeaderRequestID]
+	if !ok || requestID == "" {
+		requestID = uuid.New()
+		requestHeaders := make(map[string]string, len(headers)+1)
+		for k, v := range headers {
+			requestHeaders[k] = v
+		}
+		requestHeaders[HeaderRequestID] = requestID
+		headers = requestHeaders
+	}
+
+	callCtx := thrift.WithHeaders(ctx, headers)
+	callCtx.SetResponseHeaders(map[string]string{HeaderRequestID: requestID})
+	return callCtx
+}
+
+// RequestID returns the id of the request ctx is serving, or an empty string if ctx is not serving a request
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	return thrift.Wrap(ctx).Headers()[HeaderRequestID]
+}
+
+// WithRequestID returns the logger tagged with the id of the request ctx is serving
+func WithRequestID(logger bark.Logger, ctx context.Context) bark.Logger {
+	if requestID := RequestID(ctx); requestID != "" {
+		return logger.WithField(logging.TagRequestID, requestID)
+	}
+	return logger
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package tracing instruments cadence with opentracing spans.  Spans are reported to the opentracing global tracer,
+// which is also the tracer of the tchannel channels, so the spans started in a service are children of the span of
+// the call being served and parents of the calls made to other services.  The server registers the Jaeger tracer of
+// the tracing section of its config as the global tracer, nothing is reported when that section is not set.
+package tracing
+
+import (
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/uber/tchannel-go/thrift"
+	"golang.org/x/net/context"
+)
+
+// Tags set on the spans of the calls made for a workflow execution
+const (
+	TagDomain     = "cadence.domain"
+	TagWorkflowID = "cadence.workflowID"
+	TagRunID      = "cadence.runID"
+	TagRequestID  = "cadence.requestID"
+)
+
+// StartSpan starts a span child of the span carried by ctx, and returns it with a context carrying it
+func StartSpan(ctx context.Context, operationName string) (opentracing.Span, context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return opentracing.StartSpanFromContext(ctx, operationName)
+}
+
+// StartThriftSpan starts a span child of the span carried by the context of a tchannel call, and returns it with a
+// context carrying it and the headers of the call
+func StartThriftSpan(ctx thrift.Context, operationName string) (opentracing.Span, thrift.Context) {
+	if ctx == nil {
+		return opentracing.StartSpan(operationName), nil
+	}
+	span, spanCtx := opentracing.StartSpanFromContext(ctx, operationName)
+	if requestID := RequestID(ctx); requestID != "" {
+		span.SetTag(TagRequestID, requestID)
+	}
+	return span, thrift.WithHeaders(spanCtx, ctx.Headers())
+}
+
+// FinishSpan finishes the span, marking it as failed with the error when err is not nil
+func FinishSpan(span opentracing.Span, err error) {
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+	}
+	span.Finish()
+}
+
+// TagExecution tags the span carried by ctx with the workflow execution the call is made for
+func TagExecution(ctx context.Context, domain, workflowID, runID string) {
+	if ctx == nil {
+		return
+	}
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return
+	}
+	if domain != "" {
+		span.SetTag(TagDomain, domain)
+	}
+	if workflowID != "" {
+		span.SetTag(TagWorkflowID, workflowID)
+	}
+	if runID != "" {
+		span.SetTag(TagRunID, runID)
+	}
+}
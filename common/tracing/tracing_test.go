@@ -0,0 +1,121 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/tchannel-go/thrift"
+	"golang.org/x/net/context"
+)
+
+type (
+	tracingSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		tracer         *mocktracer.MockTracer
+		previousTracer opentracing.Tracer
+	}
+)
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(tracingSuite))
+}
+
+func (s *tracingSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.tracer = mocktracer.New()
+	s.previousTracer = opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(s.tracer)
+}
+
+func (s *tracingSuite) TearDownTest() {
+	opentracing.SetGlobalTracer(s.previousTracer)
+}
+
+func (s *tracingSuite) TestSpans() {
+	parent, ctx := StartSpan(context.Background(), "parent")
+	TagExecution(ctx, "domain", "workflowID", "")
+	child, _ := StartSpan(ctx, "child")
+	FinishSpan(child, errors.New("failed"))
+	FinishSpan(parent, nil)
+
+	spans := s.tracer.FinishedSpans()
+	s.Equal(2, len(spans))
+	s.Equal("child", spans[0].OperationName)
+	s.Equal(spans[1].SpanContext.SpanID, spans[0].ParentID)
+	s.Equal(true, spans[0].Tag("error"))
+	s.Equal(1, len(spans[0].Logs()))
+	s.Equal("parent", spans[1].OperationName)
+	s.Nil(spans[1].Tag("error"))
+	s.Equal("domain", spans[1].Tag(TagDomain))
+	s.Equal("workflowID", spans[1].Tag(TagWorkflowID))
+	s.Nil(spans[1].Tag(TagRunID))
+}
+
+func (s *tracingSuite) TestThriftSpans() {
+	parent, ctx := StartSpan(context.Background(), "call")
+	callCtx := thrift.WithHeaders(ctx, map[string]string{"header": "value"})
+	span, spanCtx := StartThriftSpan(callCtx, "engine")
+	s.Equal("value", spanCtx.Headers()["header"])
+	s.Equal(span, opentracing.SpanFromContext(spanCtx))
+	span.Finish()
+	parent.Finish()
+
+	spans := s.tracer.FinishedSpans()
+	s.Equal(2, len(spans))
+	s.Equal(spans[1].SpanContext.SpanID, spans[0].ParentID)
+
+	span, spanCtx = StartThriftSpan(nil, "engine")
+	s.NotNil(span)
+	s.Nil(spanCtx)
+	TagExecution(nil, "domain", "", "")
+}
+
+func (s *tracingSuite) TestRequestID() {
+	ctx := NewRequestContext(context.Background(), "method", map[string]string{"header": "value"})
+	requestID := RequestID(ctx)
+	s.NotEmpty(requestID)
+	s.Equal("value", ctx.Headers()["header"])
+	s.Equal(requestID, ctx.ResponseHeaders()[HeaderRequestID])
+
+	ctx = NewRequestContext(context.Background(), "method", map[string]string{HeaderRequestID: "request"})
+	s.Equal("request", RequestID(ctx))
+	s.Equal("request", ctx.ResponseHeaders()[HeaderRequestID])
+
+	parent, _ := StartSpan(context.Background(), "call")
+	span, spanCtx := StartThriftSpan(ctx, "engine")
+	s.Equal("request", RequestID(spanCtx))
+	span.Finish()
+	parent.Finish()
+	s.Equal("request", s.tracer.FinishedSpans()[0].Tag(TagRequestID))
+
+	s.Empty(RequestID(nil))
+	s.Empty(RequestID(context.Background()))
+}
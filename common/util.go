@@ -32,6 +32,7 @@ import (
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/backoff"
+	tchannel "github.com/uber/tchannel-go"
 	"github.com/uber/tchannel-go/thrift"
 )
 
@@ -126,12 +127,45 @@ func IsServiceNonRetryableError(err error) bool {
 	return false
 }
 
+// IsServiceHostFailureError checks if the error indicates an unhealthy remote host, as opposed
+// to an application error returned by a healthy host.
+func IsServiceHostFailureError(err error) bool {
+	switch err := err.(type) {
+	case tchannel.SystemError:
+		switch err.Code() {
+		case tchannel.ErrCodeBadRequest, tchannel.ErrCodeCancelled:
+			return false
+		}
+		return true
+	case *workflow.InternalServiceError:
+		return true
+	}
+
+	return err == context.DeadlineExceeded
+}
+
 // WorkflowIDToHistoryShard is used to map workflowID to a shardID
 func WorkflowIDToHistoryShard(workflowID string, numberOfShards int) int {
 	hash := farm.Fingerprint32([]byte(workflowID))
 	return int(hash % uint32(numberOfShards))
 }
 
+// IsWorkflowCloseEvent checks if the event type is one of the events closing a workflow execution, which are the last
+// events of its history
+func IsWorkflowCloseEvent(eventType workflow.EventType) bool {
+	switch eventType {
+	case workflow.EventType_WorkflowExecutionCompleted,
+		workflow.EventType_WorkflowExecutionFailed,
+		workflow.EventType_WorkflowExecutionTimedOut,
+		workflow.EventType_WorkflowExecutionCanceled,
+		workflow.EventType_WorkflowExecutionTerminated,
+		workflow.EventType_WorkflowExecutionTerminatedByOperator,
+		workflow.EventType_WorkflowExecutionContinuedAsNew:
+		return true
+	}
+	return false
+}
+
 // PrettyPrintHistory prints history in human readable format
 func PrettyPrintHistory(history *workflow.History, logger bark.Logger) {
 	data, err := json.MarshalIndent(history, "", "    ")
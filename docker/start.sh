@@ -71,6 +71,15 @@ init_env() {
     if [ -z "$NUM_HISTORY_SHARDS" ]; then
         export NUM_HISTORY_SHARDS=4
     fi
+
+    # every frontend host has to share the key, set it when running more than one container
+    if [ -z "$PAGE_TOKEN_SIGNING_KEY" ]; then
+        export PAGE_TOKEN_SIGNING_KEY=`head -c 32 /dev/urandom | base64`
+    fi
+
+    if [ -z "$JAEGER_SAMPLING_RATE" ]; then
+        export JAEGER_SAMPLING_RATE=0.001
+    fi
 }
 
 CADENCE_HOME=$1
@@ -33,6 +33,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	tchannel "github.com/uber/tchannel-go"
+	"golang.org/x/net/context"
 
 	"bytes"
 	"encoding/binary"
@@ -131,7 +132,7 @@ func (s *integrationSuite) SetupTest() {
 	s.host.Start()
 	s.engine, _ = frontend.NewClient(s.ch, s.host.FrontendAddress())
 	s.domainName = "integration-test-domain"
-	s.MetadataManager.CreateDomain(&persistence.CreateDomainRequest{
+	s.MetadataManager.CreateDomain(context.Background(), &persistence.CreateDomainRequest{
 		Name:        s.domainName,
 		Status:      persistence.DomainStatusRegistered,
 		Description: "Test domain for integration test",
@@ -139,7 +140,7 @@ func (s *integrationSuite) SetupTest() {
 		EmitMetric:  false,
 	})
 	s.foreignDomainName = "integration-foreign-test-domain"
-	s.MetadataManager.CreateDomain(&persistence.CreateDomainRequest{
+	s.MetadataManager.CreateDomain(context.Background(), &persistence.CreateDomainRequest{
 		Name:        s.foreignDomainName,
 		Status:      persistence.DomainStatusRegistered,
 		Description: "Test foreign domain for integration test",
@@ -289,7 +290,7 @@ func (s *integrationSuite) TestTerminateWorkflow() {
 
 	terminateReason := "terminate reason."
 	terminateDetails := []byte("terminate details.")
-	err = s.engine.TerminateWorkflowExecution(&workflow.TerminateWorkflowExecutionRequest{
+	_, err = s.engine.TerminateWorkflowExecution(&workflow.TerminateWorkflowExecutionRequest{
 		Domain: common.StringPtr(s.domainName),
 		WorkflowExecution: &workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(id),
@@ -316,13 +317,13 @@ GetHistoryLoop:
 		common.PrettyPrintHistory(history, s.logger)
 
 		lastEvent := history.GetEvents()[len(history.GetEvents())-1]
-		if lastEvent.GetEventType() != workflow.EventType_WorkflowExecutionTerminated {
+		if lastEvent.GetEventType() != workflow.EventType_WorkflowExecutionTerminatedByOperator {
 			s.logger.Warnf("Execution not terminated yet.")
 			time.Sleep(100 * time.Millisecond)
 			continue GetHistoryLoop
 		}
 
-		terminateEventAttributes := lastEvent.GetWorkflowExecutionTerminatedEventAttributes()
+		terminateEventAttributes := lastEvent.GetWorkflowExecutionTerminatedByOperatorEventAttributes()
 		s.Equal(terminateReason, terminateEventAttributes.GetReason())
 		s.Equal(terminateDetails, terminateEventAttributes.GetDetails())
 		s.Equal(identity, terminateEventAttributes.GetIdentity())
@@ -528,12 +529,13 @@ retry:
 		context, decisions := p.decisionHandler(response.GetWorkflowExecution(), response.GetWorkflowType(),
 			response.GetPreviousStartedEventId(), response.GetStartedEventId(), response.GetHistory())
 
-		return p.engine.RespondDecisionTaskCompleted(&workflow.RespondDecisionTaskCompletedRequest{
+		_, err := p.engine.RespondDecisionTaskCompleted(&workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        response.GetTaskToken(),
 			Identity:         common.StringPtr(p.identity),
 			ExecutionContext: context,
 			Decisions:        decisions,
 		})
+		return err
 	}
 
 	return matching.ErrNoTasks
@@ -1125,7 +1127,7 @@ func (s *integrationSuite) TestSignalWorkflow() {
 	taskList.Name = common.StringPtr(tl)
 
 	// Send a signal to non-existant workflow
-	err0 := s.engine.SignalWorkflowExecution(&workflow.SignalWorkflowExecutionRequest{
+	_, err0 := s.engine.SignalWorkflowExecution(&workflow.SignalWorkflowExecutionRequest{
 		Domain: common.StringPtr(s.domainName),
 		WorkflowExecution: &workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(id),
@@ -1225,7 +1227,7 @@ func (s *integrationSuite) TestSignalWorkflow() {
 	// Send first signal using RunID
 	signalName := "my signal"
 	signalInput := []byte("my signal input.")
-	err = s.engine.SignalWorkflowExecution(&workflow.SignalWorkflowExecutionRequest{
+	_, err = s.engine.SignalWorkflowExecution(&workflow.SignalWorkflowExecutionRequest{
 		Domain: common.StringPtr(s.domainName),
 		WorkflowExecution: &workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(id),
@@ -1251,7 +1253,7 @@ func (s *integrationSuite) TestSignalWorkflow() {
 	// Send another signal without RunID
 	signalName = "another signal"
 	signalInput = []byte("another signal input.")
-	err = s.engine.SignalWorkflowExecution(&workflow.SignalWorkflowExecutionRequest{
+	_, err = s.engine.SignalWorkflowExecution(&workflow.SignalWorkflowExecutionRequest{
 		Domain: common.StringPtr(s.domainName),
 		WorkflowExecution: &workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(id),
@@ -1274,7 +1276,7 @@ func (s *integrationSuite) TestSignalWorkflow() {
 	s.Equal(identity, signalEvent.GetWorkflowExecutionSignaledEventAttributes().GetIdentity())
 
 	// Terminate workflow execution
-	err = s.engine.TerminateWorkflowExecution(&workflow.TerminateWorkflowExecutionRequest{
+	_, err = s.engine.TerminateWorkflowExecution(&workflow.TerminateWorkflowExecutionRequest{
 		Domain: common.StringPtr(s.domainName),
 		WorkflowExecution: &workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(id),
@@ -1286,7 +1288,7 @@ func (s *integrationSuite) TestSignalWorkflow() {
 	s.Nil(err)
 
 	// Send signal to terminated workflow
-	err = s.engine.SignalWorkflowExecution(&workflow.SignalWorkflowExecutionRequest{
+	_, err = s.engine.SignalWorkflowExecution(&workflow.SignalWorkflowExecutionRequest{
 		Domain: common.StringPtr(s.domainName),
 		WorkflowExecution: &workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(id),
@@ -28,8 +28,11 @@ import (
 	"github.com/uber-common/bark"
 	"github.com/uber-go/tally"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/tracing"
 	"github.com/uber/cadence/service/frontend"
 	"github.com/uber/cadence/service/history"
 	"github.com/uber/cadence/service/matching"
@@ -151,11 +154,13 @@ func (c *cadenceImpl) startFrontend(logger bark.Logger, rpHosts []string, startW
 	params.TChannelFactory = newTChannelFactory(c.FrontendAddress(), logger)
 	params.MetricScope = tally.NewTestScope(common.FrontendServiceName, make(map[string]string))
 	params.RingpopFactory = newRingpopFactory(common.FrontendServiceName, rpHosts)
-	params.CassandraConfig.NumHistoryShards = c.numberOfHistoryShards
+	params.NumHistoryShards = c.numberOfHistoryShards
 	params.CassandraConfig.Hosts = "127.0.0.1"
 	service := service.New(params)
 	var thriftServices []thrift.TChanServer
-	c.frontendHandler, thriftServices = frontend.NewWorkflowHandler(service, c.metadataMgr, c.historyMgr, c.visibilityMgr)
+	c.frontendHandler, thriftServices = frontend.NewWorkflowHandler(service, c.metadataMgr, c.historyMgr, c.visibilityMgr,
+		audit.NewLogger(audit.NewNoopSink(), logger), config.PageToken{SigningKey: "onebox-page-token-key"}, nil, nil,
+		nil, config.LargePayloads{})
 	err := c.frontendHandler.Start(thriftServices)
 	if err != nil {
 		c.logger.WithField("error", err).Fatal("Failed to start frontend")
@@ -176,12 +181,12 @@ func (c *cadenceImpl) startHistory(logger bark.Logger, shardMgr persistence.Shar
 		params.TChannelFactory = newTChannelFactory(hostport, logger)
 		params.MetricScope = tally.NewTestScope(common.HistoryServiceName, make(map[string]string))
 		params.RingpopFactory = newRingpopFactory(common.FrontendServiceName, rpHosts)
-		params.CassandraConfig.NumHistoryShards = c.numberOfHistoryShards
+		params.NumHistoryShards = c.numberOfHistoryShards
 		service := service.New(params)
 		var thriftServices []thrift.TChanServer
 		var handler *history.Handler
 		handler, thriftServices = history.NewHandler(service, shardMgr, metadataMgr, visibilityMgr, historyMgr, executionMgrFactory,
-			c.numberOfHistoryShards)
+			nil, config.LargePayloads{}, c.numberOfHistoryShards, history.NewConfig())
 		handler.Start(thriftServices)
 		c.historyHandlers = append(c.historyHandlers, handler)
 	}
@@ -200,10 +205,10 @@ func (c *cadenceImpl) startMatching(logger bark.Logger, taskMgr persistence.Task
 	params.TChannelFactory = newTChannelFactory(c.MatchingServiceAddress(), logger)
 	params.MetricScope = tally.NewTestScope(common.MatchingServiceName, make(map[string]string))
 	params.RingpopFactory = newRingpopFactory(common.FrontendServiceName, rpHosts)
-	params.CassandraConfig.NumHistoryShards = c.numberOfHistoryShards
+	params.NumHistoryShards = c.numberOfHistoryShards
 	service := service.New(params)
 	var thriftServices []thrift.TChanServer
-	c.matchingHandler, thriftServices = matching.NewHandler(taskMgr, service)
+	c.matchingHandler, thriftServices = matching.NewHandler(taskMgr, matching.NewConfig(), service)
 	c.matchingHandler.Start(thriftServices)
 	startWG.Done()
 	<-c.shutdownCh
@@ -262,6 +267,7 @@ func (c *tchannelFactoryImpl) CreateChannel(sName string,
 		c.logger.WithField("error", err).Fatal("Failed to create TChannel")
 	}
 	server := thrift.NewServer(ch)
+	server.SetContextFn(tracing.NewRequestContext)
 	for _, thriftService := range thriftServices {
 		server.Register(thriftService)
 	}
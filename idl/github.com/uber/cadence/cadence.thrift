@@ -76,6 +76,19 @@ service WorkflowService {
       3: shared.EntityNotExistsError entityNotExistError,
     )
 
+  /**
+  * DrainDomain is used to update status of a registered domain to DRAINING.  A draining domain rejects new workflow
+  * executions while the existing ones run to completion, including the child executions and runs continued as new
+  * they start, so the domain can be decommissioned or migrated once it has no open executions left.  Setting cancel
+  * on the request puts a draining domain back to REGISTERED.
+  **/
+  void DrainDomain(1: shared.DrainDomainRequest drainRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+    )
+
   /**
   * StartWorkflowExecution starts a new long running workflow instance.  It will create the instance with
   * 'WorkflowExecutionStarted' event in history and also schedule the first DecisionTask for the worker to make the
@@ -87,6 +100,8 @@ service WorkflowService {
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
       3: shared.WorkflowExecutionAlreadyStartedError sessionAlreadyExistError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.DomainNotActiveError domainNotActiveError,
     )
 
   /**
@@ -111,6 +126,7 @@ service WorkflowService {
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
+      3: shared.ServiceBusyError serviceBusyError,
     )
 
   /**
@@ -118,9 +134,10 @@ service WorkflowService {
   * 'PollForDecisionTask' API call.  Completing a DecisionTask will result in new events for the workflow execution and
   * potentially new ActivityTask being created for corresponding decisions.  It will also create a DecisionTaskCompleted
   * event in the history for that session.  Use the 'taskToken' provided as response of PollForDecisionTask API call
-  * for completing the DecisionTask.
+  * for completing the DecisionTask.  Activities scheduled with 'requestLocalDispatch' in the same domain are started
+  * right away and returned in the response, for the worker to run them without polling.
   **/
-  void RespondDecisionTaskCompleted(1: shared.RespondDecisionTaskCompletedRequest completeRequest)
+  shared.RespondDecisionTaskCompletedResponse RespondDecisionTaskCompleted(1: shared.RespondDecisionTaskCompletedRequest completeRequest)
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
@@ -140,6 +157,7 @@ service WorkflowService {
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
+      3: shared.ServiceBusyError serviceBusyError,
     )
 
   /**
@@ -154,6 +172,7 @@ service WorkflowService {
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
       3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
     )
 
   /**
@@ -198,6 +217,43 @@ service WorkflowService {
       3: shared.EntityNotExistsError entityNotExistError,
     )
 
+  /**
+  * RespondActivityTaskCompletedByID is called by application to complete an ActivityTask it doesn't hold the
+  * 'taskToken' of, for instance when the work is done asynchronously by an external system.  The activity is located
+  * by the domain, workflowID, runID and activityID instead.  An empty runID completes the activity of the current run
+  * of the workflow.  It fails with 'EntityNotExistsError' if the activity is not pending or not started.
+  **/
+  void RespondActivityTaskCompletedByID(1: shared.RespondActivityTaskCompletedByIDRequest completeRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+    )
+
+  /**
+  * RespondActivityTaskFailedByID is called by application to fail an ActivityTask located by the domain, workflowID,
+  * runID and activityID instead of the 'taskToken'.  It fails with 'EntityNotExistsError' if the activity is not
+  * pending or not started.
+  **/
+  void RespondActivityTaskFailedByID(1: shared.RespondActivityTaskFailedByIDRequest failedRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+    )
+
+  /**
+  * RespondActivityTaskCanceledByID is called by application to cancel an ActivityTask located by the domain,
+  * workflowID, runID and activityID instead of the 'taskToken'.  It fails with 'EntityNotExistsError' if the activity
+  * is not pending or not started.
+  **/
+  void RespondActivityTaskCanceledByID(1: shared.RespondActivityTaskCanceledByIDRequest canceledRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+    )
+
   /**
   * RequestCancelWorkflowExecution is called by application worker when it wants to request cancellation of a workflow instance.
   * It will result in a new 'WorkflowExecutionCancelRequested' event being written to the workflow history and a new DecisionTask
@@ -214,8 +270,53 @@ service WorkflowService {
   /**
   * SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
   * WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
+  * The response is the receipt of the signal: the run which recorded it and the ID of its event.  The event ID is
+  * not set while the signal is held back behind a decision task in flight, it is assigned once the decision
+  * completes.  Signals carrying a 'requestId' are recorded at most once per run, retrying one which was already
+  * recorded returns its original receipt.
+  **/
+  shared.SignalWorkflowExecutionResponse SignalWorkflowExecution(1: shared.SignalWorkflowExecutionRequest signalRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+    )
+
+  /**
+  * GetSignalReceipt tells whether the signal sent with the 'requestId' was recorded by the workflow execution, and
+  * returns its receipt if it was.  Senders which lost the response of SignalWorkflowExecution check it before
+  * retrying the signal to deliver it exactly once.  Receipts are kept by the run which recorded the signal, the
+  * current run is checked when the execution has no 'runId'.
+  **/
+  shared.GetSignalReceiptResponse GetSignalReceipt(1: shared.GetSignalReceiptRequest receiptRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+    )
+
+  /**
+  * QueryWorkflow is used to query the state of a running workflow execution.  The query is handed to the worker along
+  * with the next decision task of the execution in the 'queries' of PollForDecisionTaskResponse, and the worker answers
+  * it in the 'queryResults' of RespondDecisionTaskCompleted.  Without a decision task waiting for a poller, the query
+  * comes with a decision task of its own which has no new events, its completion can't carry decisions.  It fails with 'QueryFailedError' if the worker failed the
+  * query or didn't answer it.
+  **/
+  shared.QueryWorkflowResponse QueryWorkflow(1: shared.QueryWorkflowRequest queryRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.QueryFailedError queryFailedError,
+    )
+
+  /**
+  * DescribeWorkflowExecution returns information about the specified workflow execution, including the activities
+  * still pending on it.  Started activities are waiting to be completed, either by the worker which polled them or
+  * externally with the activity ID based APIs, until their 'expirationTimestamp'.  Activities past it without a
+  * completion have lost it and will time out.
   **/
-  void SignalWorkflowExecution(1: shared.SignalWorkflowExecutionRequest signalRequest)
+  shared.DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: shared.DescribeWorkflowExecutionRequest describeRequest)
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
@@ -223,10 +324,12 @@ service WorkflowService {
     )
 
   /**
-  * TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
-  * in the history and immediately terminating the execution instance.
+  * TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminatedByOperator
+  * event in the history and immediately terminating the execution instance.  Running child executions are left
+  * alone, unless the request sets a 'childPolicy' to terminate or cancel them.  All the executions affected are
+  * returned.
   **/
-  void TerminateWorkflowExecution(1: shared.TerminateWorkflowExecutionRequest terminateRequest)
+  shared.TerminateWorkflowExecutionResponse TerminateWorkflowExecution(1: shared.TerminateWorkflowExecutionRequest terminateRequest)
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
@@ -244,7 +347,8 @@ service WorkflowService {
     )
 
   /**
-  * ListClosedWorkflowExecutions is a visibility API to list the closed executions in a specific domain.
+  * ListClosedWorkflowExecutions is a visibility API to list the closed executions in a specific domain.  Executions
+  * are listed by start time range, or by close time range when CloseTimeFilter is set on the request.
   **/
   shared.ListClosedWorkflowExecutionsResponse ListClosedWorkflowExecutions(1: shared.ListClosedWorkflowExecutionsRequest listRequest)
     throws (
@@ -252,4 +356,62 @@ service WorkflowService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.EntityNotExistsError entityNotExistError,
     )
-}
\ No newline at end of file
+
+  /**
+  * ListClosedWorkflowExecutionsSince is a visibility API to tail the executions closed in a specific domain.  It lists
+  * the executions closed after the ones already returned, in close time order, starting at 'earliestCloseTime' on the
+  * first call.  Pass the 'closedSinceToken' of the response to the next call to continue, the token is returned even
+  * when no execution closed since the last call.
+  **/
+  shared.ListClosedWorkflowExecutionsSinceResponse ListClosedWorkflowExecutionsSince(1: shared.ListClosedWorkflowExecutionsSinceRequest listRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+    )
+
+  /**
+  * DescribeCluster returns the metadata of the cluster: the server version, the number of history shards, the client
+  * features it supports and the type of its persistence store.  SDKs and tools use it to negotiate capabilities.
+  **/
+  shared.DescribeClusterResponse DescribeCluster()
+    throws (
+      1: shared.InternalServiceError internalServiceError,
+    )
+
+  /**
+  * RefreshWorkflowTasks is an admin API to re-generate the transfer and timer tasks of a running workflow execution
+  * from its mutable state.  It recovers executions stuck because one of their tasks was lost or dropped.
+  **/
+  void RefreshWorkflowTasks(1: shared.RefreshWorkflowTasksRequest refreshRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+    )
+
+  /**
+  * MigrateWorkflowExecution is an admin API to move a closed workflow execution, its history and its visibility
+  * record, to another domain of the cluster, to support reorganizing tenants.  The workflow ID must not be in use
+  * in the target domain.  Running executions and moves to another cluster are not supported.
+  **/
+  void MigrateWorkflowExecution(1: shared.MigrateWorkflowExecutionRequest migrateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.WorkflowExecutionAlreadyStartedError workflowAlreadyStartedError,
+    )
+
+  /**
+  * VerifyHistory is an admin API to run structural validation over a serialized workflow history: event id
+  * continuity, the ordering of the history versions and the pairing of every started, completed, failed or timed out
+  * event with the event that initiated it.  Every violation found is returned, instead of the engine panicking on the
+  * first one while replaying the history.
+  **/
+  shared.VerifyHistoryResponse VerifyHistory(1: shared.VerifyHistoryRequest verifyRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+    )
+}
@@ -31,6 +31,16 @@ exception ShardOwnershipLostError {
   20: optional string owner
 }
 
+enum QueueType {
+  TRANSFER,
+  TIMER,
+}
+
+enum StaleExecutionReason {
+  DECISION_PENDING,
+  TIMER_OVERDUE,
+}
+
 struct ParentExecutionInfo {
   10: optional string domainUUID
   20: optional shared.WorkflowExecution execution
@@ -53,6 +63,23 @@ struct GetWorkflowExecutionNextEventIDResponse {
   20: optional string runId
 }
 
+struct BatchGetWorkflowExecutionNextEventIDRequest {
+  10: optional list<GetWorkflowExecutionNextEventIDRequest> requests
+}
+
+struct GetWorkflowExecutionNextEventIDOutcome {
+  10: optional GetWorkflowExecutionNextEventIDResponse response
+  20: optional shared.EntityNotExistsError entityNotExistError
+  30: optional ShardOwnershipLostError shardOwnershipLostError
+  40: optional shared.InternalServiceError internalServiceError
+  50: optional shared.BadRequestError badRequestError
+  60: optional shared.ServiceBusyError serviceBusyError
+}
+
+struct BatchGetWorkflowExecutionNextEventIDResponse {
+  10: optional list<GetWorkflowExecutionNextEventIDOutcome> results
+}
+
 struct RespondDecisionTaskCompletedRequest {
   10: optional string domainUUID
   20: optional shared.RespondDecisionTaskCompletedRequest completeRequest
@@ -99,12 +126,24 @@ struct RecordDecisionTaskStartedRequest {
   40: optional i64 (js.type = "Long") taskId
   45: optional string requestId // Unique id of each poll request. Used to ensure at most once delivery of tasks.
   50: optional shared.PollForDecisionTaskRequest pollRequest
+  60: optional string queryId // Set for query tasks, which are handed to the worker without recording any event
 }
 
 struct RecordDecisionTaskStartedResponse {
   10: optional shared.WorkflowType workflowType
   20: optional i64 (js.type = "Long") previousStartedEventId
   30: optional i64 (js.type = "Long") startedEventId
+  40: optional map<string, shared.WorkflowQuery> queries
+}
+
+struct QueryWorkflowRequest {
+  10: optional string domainUUID
+  20: optional shared.QueryWorkflowRequest queryRequest
+}
+
+struct DescribeWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.DescribeWorkflowExecutionRequest request
 }
 
 struct SignalWorkflowExecutionRequest {
@@ -112,6 +151,11 @@ struct SignalWorkflowExecutionRequest {
   20: optional shared.SignalWorkflowExecutionRequest signalRequest
 }
 
+struct GetSignalReceiptRequest {
+  10: optional string domainUUID
+  20: optional shared.GetSignalReceiptRequest receiptRequest
+}
+
 struct TerminateWorkflowExecutionRequest {
   10: optional string domainUUID
   20: optional shared.TerminateWorkflowExecutionRequest terminateRequest
@@ -144,6 +188,57 @@ struct RecordChildExecutionCompletedRequest {
   50: optional shared.HistoryEvent completionEvent
 }
 
+struct UpdateQueueProcessingRequest {
+  10: optional i32 shardId
+  20: optional QueueType queueType
+  30: optional bool paused
+}
+
+struct DescribeMutableStateRequest {
+  10: optional string domainUUID
+  20: optional shared.WorkflowExecution execution
+}
+
+struct DescribeMutableStateResponse {
+  10: optional string mutableStateInJson
+}
+
+struct RefreshWorkflowTasksRequest {
+  10: optional string domainUUID
+  20: optional shared.RefreshWorkflowTasksRequest refreshRequest
+}
+
+struct MigrateWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional string targetDomainUUID
+  30: optional shared.MigrateWorkflowExecutionRequest migrateRequest
+}
+
+struct StaleExecution {
+  10: optional i32 shardId
+  20: optional string domainUUID
+  30: optional shared.WorkflowExecution execution
+  40: optional StaleExecutionReason reason
+  50: optional i64 (js.type = "Long") staleSinceTimestamp
+}
+
+struct ListStaleExecutionsRequest {
+  10: optional i32 shardId
+}
+
+struct ListStaleExecutionsResponse {
+  10: optional list<StaleExecution> executions
+}
+
+struct RedriveTransferDLQTasksRequest {
+  10: optional i32 shardId
+  20: optional i32 maximumTaskCount
+}
+
+struct RedriveTransferDLQTasksResponse {
+  10: optional i32 redrivenTaskCount
+}
+
 /**
 * HistoryService provides API to start a new long running workflow instance, as well as query and update the history
 * of workflow instances already created.
@@ -161,6 +256,8 @@ service HistoryService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.WorkflowExecutionAlreadyStartedError sessionAlreadyExistError,
       4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
     )
 
   /**
@@ -176,6 +273,17 @@ service HistoryService {
       4: ShardOwnershipLostError shardOwnershipLostError,
     )
 
+  /**
+  * BatchGetWorkflowExecutionNextEventID returns the nextEventID of the history of each of the workflow executions,
+  * in the order of the requests.  The outcome of each execution is reported by its own result, the call only fails
+  * when the batch itself is invalid.  Callers validating many executions send one batch per history host.
+  **/
+  BatchGetWorkflowExecutionNextEventIDResponse BatchGetWorkflowExecutionNextEventID(1: BatchGetWorkflowExecutionNextEventIDRequest getRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+    )
+
   /**
   * RecordDecisionTaskStarted is called by the Matchingservice before it hands a decision task to the application worker in response to
   * a PollForDecisionTask call. It records in the history the event that the decision task has started. It will return 'EventAlreadyStartedError',
@@ -188,6 +296,7 @@ service HistoryService {
       3: EventAlreadyStartedError eventAlreadyStartedError,
       4: shared.EntityNotExistsError entityNotExistError,
       5: ShardOwnershipLostError shardOwnershipLostError,
+      6: shared.ServiceBusyError serviceBusyError,
     )
 
   /**
@@ -202,6 +311,7 @@ service HistoryService {
       3: EventAlreadyStartedError eventAlreadyStartedError,
       4: shared.EntityNotExistsError entityNotExistError,
       5: ShardOwnershipLostError shardOwnershipLostError,
+      6: shared.ServiceBusyError serviceBusyError,
     )
 
   /**
@@ -209,9 +319,10 @@ service HistoryService {
   * 'PollForDecisionTask' API call.  Completing a DecisionTask will result in new events for the workflow execution and
   * potentially new ActivityTask being created for corresponding decisions.  It will also create a DecisionTaskCompleted
   * event in the history for that session.  Use the 'taskToken' provided as response of PollForDecisionTask API call
-  * for completing the DecisionTask.
+  * for completing the DecisionTask.  Activities scheduled with 'requestLocalDispatch' in the same domain are started
+  * right away and returned in the response, for the worker to run them without polling.
   **/
-  void RespondDecisionTaskCompleted(1: RespondDecisionTaskCompletedRequest completeRequest)
+  shared.RespondDecisionTaskCompletedResponse RespondDecisionTaskCompleted(1: RespondDecisionTaskCompletedRequest completeRequest)
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
@@ -232,6 +343,7 @@ service HistoryService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.EntityNotExistsError entityNotExistError,
       4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.ServiceBusyError serviceBusyError,
     )
 
   /**
@@ -282,8 +394,9 @@ service HistoryService {
   /**
   * SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
   * WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
+  * It returns the receipt of the signal.
   **/
-  void SignalWorkflowExecution(1: SignalWorkflowExecutionRequest signalRequest)
+  shared.SignalWorkflowExecutionResponse SignalWorkflowExecution(1: SignalWorkflowExecutionRequest signalRequest)
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
@@ -292,10 +405,50 @@ service HistoryService {
     )
 
   /**
-  * TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
-  * in the history and immediately terminating the execution instance.
+  * GetSignalReceipt tells whether the signal sent with the request ID was recorded by the workflow execution.
   **/
-  void TerminateWorkflowExecution(1: TerminateWorkflowExecutionRequest terminateRequest)
+  shared.GetSignalReceiptResponse GetSignalReceipt(1: GetSignalReceiptRequest receiptRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * QueryWorkflow queues a query to a running workflow execution and waits for the answer.  Queued queries are handed
+  * to the worker with the decision task of the execution waiting for a poller, or else with a query task of their own
+  * which doesn't change the history.  It fails with 'QueryFailedError' if the worker failed the query or didn't answer
+  * it.
+  **/
+  shared.QueryWorkflowResponse QueryWorkflow(1: QueryWorkflowRequest queryRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.QueryFailedError queryFailedError,
+      5: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * DescribeWorkflowExecution returns information about the specified workflow execution, including the activities
+  * still pending on it.
+  **/
+  shared.DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: DescribeWorkflowExecutionRequest describeRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminatedByOperator
+  * event in the history and immediately terminating the execution instance.  Running child executions are left
+  * alone, unless the request sets a 'childPolicy' to terminate or cancel them.  All the executions affected are
+  * returned.
+  **/
+  shared.TerminateWorkflowExecutionResponse TerminateWorkflowExecution(1: TerminateWorkflowExecutionRequest terminateRequest)
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
@@ -342,4 +495,82 @@ service HistoryService {
       3: shared.EntityNotExistsError entityNotExistError,
       4: ShardOwnershipLostError shardOwnershipLostError,
     )
+
+  /**
+  * UpdateQueueProcessing is an admin API to pause or resume the processing of the transfer or timer queue of a shard.
+  * It is meant for controlled draining of shards during incident mitigation.  Tasks keep accumulating in the queue
+  * while processing is paused and are processed once it is resumed.  The paused state is persisted on the shard and
+  * kept when the shard moves to another host, it overrides the paused state the host is configured to start queues in.
+  **/
+  void UpdateQueueProcessing(1: UpdateQueueProcessingRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * DescribeMutableState is an admin API to dump the mutable state of a workflow execution as JSON, including its
+  * pending activities, timers, child executions and decision, along with the transfer queue ack levels of the shard.
+  * It is meant for debugging mismatches between the history and the mutable state of an execution, and never updates
+  * the execution.
+  **/
+  DescribeMutableStateResponse DescribeMutableState(1: DescribeMutableStateRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * RefreshWorkflowTasks re-generates the transfer and timer tasks of a running workflow execution from its mutable
+  * state.  It is meant to recover executions stuck because one of their tasks was lost or dropped, tasks which were
+  * not lost are processed twice and dropped as duplicates.
+  **/
+  void RefreshWorkflowTasks(1: RefreshWorkflowTasksRequest refreshRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * ListStaleExecutions is an admin API listing the stale executions found by the last scan of the shard, or of all
+  * shards when no shard is given: running executions whose pending decision has not moved for many times its
+  * timeout, and executions with timers long past due.  Both are symptoms of a stuck shard or of lost tasks.
+  **/
+  ListStaleExecutionsResponse ListStaleExecutions(1: ListStaleExecutionsRequest listRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * MigrateWorkflowExecution moves a closed workflow execution to another domain: its history, mutable state and
+  * visibility record are copied under the ID of the target domain, which then owns its retention, and deleted from
+  * the source domain.  Every step is idempotent, a failed migration is completed by retrying it.
+  **/
+  void MigrateWorkflowExecution(1: MigrateWorkflowExecutionRequest migrateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.WorkflowExecutionAlreadyStartedError workflowAlreadyStartedError,
+    )
+
+  /**
+  * RedriveTransferDLQTasks is an admin API moving the transfer tasks parked in the dead letter queue of a shard, after
+  * they failed to be added to matching too many times, back to its transfer queue under new task IDs.  At most
+  * maximumTaskCount tasks are moved when it is set, the oldest first.  It returns the number of tasks moved.
+  **/
+  RedriveTransferDLQTasksResponse RedriveTransferDLQTasks(1: RedriveTransferDLQTasksRequest redriveRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: ShardOwnershipLostError shardOwnershipLostError,
+    )
 }
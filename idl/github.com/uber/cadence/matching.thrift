@@ -33,6 +33,7 @@ struct PollForDecisionTaskResponse {
   30: optional shared.WorkflowType workflowType
   40: optional i64 (js.type = "Long") previousStartedEventId
   50: optional i64 (js.type = "Long") startedEventId
+  60: optional map<string, shared.WorkflowQuery> queries
 }
 
 struct PollForActivityTaskRequest {
@@ -45,6 +46,9 @@ struct AddDecisionTaskRequest {
   20: optional shared.WorkflowExecution execution
   30: optional shared.TaskList taskList
   40: optional i64 (js.type = "Long") scheduleId
+  50: optional string buildId
+  // set for a query task, which hands a query to a worker without a decision task and is never persisted
+  60: optional string queryId
 }
 
 struct AddActivityTaskRequest {
@@ -54,6 +58,8 @@ struct AddActivityTaskRequest {
   40: optional shared.TaskList taskList
   50: optional i64 (js.type = "Long") scheduleId
   60: optional i32 scheduleToStartTimeoutSeconds
+  70: optional i32 priority
+  80: optional string sessionId
 }
 
 /**
@@ -72,6 +78,7 @@ service MatchingService {
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
+      3: shared.ServiceBusyError serviceBusyError,
     )
 
   /**
@@ -82,11 +89,12 @@ service MatchingService {
     throws (
       1: shared.BadRequestError badRequestError,
       2: shared.InternalServiceError internalServiceError,
+      3: shared.ServiceBusyError serviceBusyError,
     )
 
   /**
   * AddDecisionTask is called by the history service when a decision task is scheduled, so that it can be dispatched
-  * by the MatchingEngine.
+  * by the MatchingEngine.  Query tasks are only matched to pollers, the call blocks until a poller takes the task.
   **/
   void AddDecisionTask(1: AddDecisionTaskRequest addRequest)
     throws (
@@ -36,6 +36,7 @@ exception WorkflowExecutionAlreadyStartedError {
   10: optional string message
   20: optional string startRequestId
   30: optional string runId
+  40: optional i64 (js.type = "Long") startTime
 }
 
 exception EntityNotExistsError {
@@ -44,12 +45,29 @@ exception EntityNotExistsError {
 
 exception ServiceBusyError {
   1: required string message
+  // set when the caller should back off for at least this long before retrying
+  2: optional i64 (js.type = "Long") retryAfterMillis
+}
+
+exception QueryFailedError {
+  1: required string message
+}
+
+exception LimitExceededError {
+  1: required string message
+}
+
+// returned for requests a domain does not accept in its current status, e.g. new executions on a draining domain
+exception DomainNotActiveError {
+  1: required string message
+  2: required string domainName
 }
 
 enum DomainStatus {
   REGISTERED,
   DEPRECATED,
   DELETED,
+  DRAINING,
 }
 
 enum TimeoutType {
@@ -112,6 +130,7 @@ enum EventType {
   ChildWorkflowExecutionCanceled,
   ChildWorkflowExecutionTimedOut,
   ChildWorkflowExecutionTerminated,
+  WorkflowExecutionTerminatedByOperator,
 }
 
 enum DecisionTaskFailedCause {
@@ -126,6 +145,12 @@ enum DecisionTaskFailedCause {
   BAD_CANCEL_WORKFLOW_EXECUTION_ATTRIBUTES,
   BAD_REQUEST_CANCEL_EXTERNAL_WORKFLOW_EXECUTION_ATTRIBUTES,
   BAD_CONTINUE_AS_NEW_ATTRIBUTES,
+  BAD_BINARY,
+  BAD_START_CHILD_EXECUTION_ATTRIBUTES,
+  SCHEDULE_ACTIVITY_DUPLICATE_ID,
+  START_TIMER_DUPLICATE_ID,
+  TOO_MANY_DECISIONS,
+  TOO_MANY_PENDING_TIMERS,
 }
 
 enum CancelExternalWorkflowExecutionFailedCause {
@@ -134,6 +159,7 @@ enum CancelExternalWorkflowExecutionFailedCause {
 
 enum ChildWorkflowExecutionFailedCause {
   WORKFLOW_ALREADY_RUNNING,
+  OPEN_EXECUTION_LIMIT_EXCEEDED,
 }
 
 enum WorkflowExecutionCloseStatus {
@@ -143,6 +169,7 @@ enum WorkflowExecutionCloseStatus {
   TERMINATED,
   CONTINUED_AS_NEW,
   TIMED_OUT,
+  TERMINATED_STUCK,
 }
 
 enum ChildPolicy {
@@ -151,6 +178,44 @@ enum ChildPolicy {
   ABANDON,
 }
 
+enum QueryResultType {
+  ANSWERED,
+  FAILED,
+}
+
+enum PendingActivityState {
+  SCHEDULED,
+  STARTED,
+  CANCEL_REQUESTED,
+}
+
+/**
+* ExecutionInfoView selects the fields of the executions returned by the visibility list APIs.  MINIMAL only returns
+* the workflow and run IDs of the executions.
+**/
+enum ExecutionInfoView {
+  FULL,
+  MINIMAL,
+}
+
+/**
+* Header carries context such as trace and tenant IDs from a workflow starter to the workers, it is recorded in the
+* history and handed to the workers processing the tasks of the workflow
+**/
+struct Header {
+  10: optional map<string, binary> fields
+}
+
+/**
+* PayloadReference points at a payload the frontend offloaded to the blob store, the payload field it stands for is
+* left empty.  References are set by the server only and resolved before events and tasks are returned to callers.
+**/
+struct PayloadReference {
+  10: optional string key
+  20: optional string checksum
+  30: optional i32 size
+}
+
 struct WorkflowType {
   10: optional string name
 }
@@ -174,6 +239,7 @@ struct WorkflowExecutionInfo {
   30: optional i64 (js.type = "Long") startTime
   40: optional i64 (js.type = "Long") closeTime
   50: optional WorkflowExecutionCloseStatus closeStatus
+  60: optional string closeReason
 }
 
 struct ScheduleActivityTaskDecisionAttributes {
@@ -186,6 +252,10 @@ struct ScheduleActivityTaskDecisionAttributes {
   50: optional i32 scheduleToStartTimeoutSeconds
   55: optional i32 startToCloseTimeoutSeconds
   60: optional i32 heartbeatTimeoutSeconds
+  70: optional i32 priority
+  80: optional string sessionId
+  90: optional bool requestLocalDispatch
+  100: optional Header header
 }
 
 struct RequestCancelActivityTaskDecisionAttributes {
@@ -195,6 +265,8 @@ struct RequestCancelActivityTaskDecisionAttributes {
 struct StartTimerDecisionAttributes {
   10: optional string timerId
   20: optional i64 (js.type = "Long") startToFireTimeoutSeconds
+  // Takes precedence over startToFireTimeoutSeconds when set, for timers needing sub-second resolution
+  30: optional i64 (js.type = "Long") startToFireTimeoutMilliseconds
 }
 
 struct CompleteWorkflowExecutionDecisionAttributes {
@@ -232,6 +304,7 @@ struct ContinueAsNewWorkflowExecutionDecisionAttributes {
   30: optional binary input
   40: optional i32 executionStartToCloseTimeoutSeconds
   50: optional i32 taskStartToCloseTimeoutSeconds
+  60: optional Header header
 }
 
 struct StartChildWorkflowExecutionDecisionAttributes {
@@ -244,6 +317,7 @@ struct StartChildWorkflowExecutionDecisionAttributes {
   70: optional i32 taskStartToCloseTimeoutSeconds
   80: optional ChildPolicy childPolicy
   90: optional binary control
+  100: optional Header header
 }
 
 struct Decision {
@@ -259,6 +333,7 @@ struct Decision {
   80:  optional RecordMarkerDecisionAttributes recordMarkerDecisionAttributes
   90:  optional ContinueAsNewWorkflowExecutionDecisionAttributes continueAsNewWorkflowExecutionDecisionAttributes
   100: optional StartChildWorkflowExecutionDecisionAttributes startChildWorkflowExecutionDecisionAttributes
+  110: optional PayloadReference payloadReference
 }
 
 struct WorkflowExecutionStartedEventAttributes {
@@ -268,6 +343,8 @@ struct WorkflowExecutionStartedEventAttributes {
   40: optional i32 executionStartToCloseTimeoutSeconds
   50: optional i32 taskStartToCloseTimeoutSeconds
   60: optional string identity
+  70: optional i32 firstDecisionTaskBackoffSeconds
+  80: optional Header header
 }
 
 struct WorkflowExecutionCompletedEventAttributes {
@@ -298,6 +375,7 @@ struct WorkflowExecutionContinuedAsNewEventAttributes {
 struct DecisionTaskScheduledEventAttributes {
   10: optional TaskList taskList
   20: optional i32 startToCloseTimeoutSeconds
+  30: optional i64 (js.type = "Long") attempt
 }
 
 struct DecisionTaskStartedEventAttributes {
@@ -311,12 +389,14 @@ struct DecisionTaskCompletedEventAttributes {
   20: optional i64 (js.type = "Long") scheduledEventId
   30: optional i64 (js.type = "Long") startedEventId
   40: optional string identity
+  50: optional string binaryChecksum
 }
 
 struct DecisionTaskTimedOutEventAttributes {
   10: optional i64 (js.type = "Long") scheduledEventId
   20: optional i64 (js.type = "Long") startedEventId
   30: optional TimeoutType timeoutType
+  40: optional i64 (js.type = "Long") attempt
 }
 
 struct DecisionTaskFailedEventAttributes {
@@ -324,6 +404,7 @@ struct DecisionTaskFailedEventAttributes {
   20: optional i64 (js.type = "Long") startedEventId
   30: optional DecisionTaskFailedCause cause
   40: optional string identity
+  50: optional i64 (js.type = "Long") attempt
 }
 
 struct ActivityTaskScheduledEventAttributes {
@@ -336,7 +417,10 @@ struct ActivityTaskScheduledEventAttributes {
   50: optional i32 scheduleToStartTimeoutSeconds
   55: optional i32 startToCloseTimeoutSeconds
   60: optional i32 heartbeatTimeoutSeconds
+  70: optional i32 priority
+  80: optional string sessionId
   90: optional i64 (js.type = "Long") decisionTaskCompletedEventId
+  100: optional Header header
 }
 
 struct ActivityTaskStartedEventAttributes {
@@ -390,6 +474,7 @@ struct TimerStartedEventAttributes {
   10: optional string timerId
   20: optional i64 (js.type = "Long") startToFireTimeoutSeconds
   30: optional i64 (js.type = "Long") decisionTaskCompletedEventId
+  40: optional i64 (js.type = "Long") startToFireTimeoutMilliseconds
 }
 
 struct TimerFiredEventAttributes {
@@ -433,6 +518,7 @@ struct WorkflowExecutionSignaledEventAttributes {
   10: optional string signalName
   20: optional binary input
   30: optional string identity
+  40: optional string requestId
 }
 
 struct WorkflowExecutionTerminatedEventAttributes {
@@ -441,6 +527,13 @@ struct WorkflowExecutionTerminatedEventAttributes {
   30: optional string identity
 }
 
+struct WorkflowExecutionTerminatedByOperatorEventAttributes {
+  10: optional string reason
+  20: optional binary details
+  30: optional string identity
+  40: optional ChildPolicy childPolicy
+}
+
 struct RequestCancelExternalWorkflowExecutionInitiatedEventAttributes {
   10: optional i64 (js.type = "Long") decisionTaskCompletedEventId
   20: optional string domain
@@ -474,6 +567,7 @@ struct StartChildWorkflowExecutionInitiatedEventAttributes {
   80:  optional ChildPolicy childPolicy
   90:  optional binary control
   100: optional i64 (js.type = "Long") decisionTaskCompletedEventId
+  110: optional Header header
 }
 
 struct StartChildWorkflowExecutionFailedEventAttributes {
@@ -580,6 +674,8 @@ struct HistoryEvent {
   390: optional ChildWorkflowExecutionCanceledEventAttributes childWorkflowExecutionCanceledEventAttributes
   400: optional ChildWorkflowExecutionTimedOutEventAttributes childWorkflowExecutionTimedOutEventAttributes
   410: optional ChildWorkflowExecutionTerminatedEventAttributes childWorkflowExecutionTerminatedEventAttributes
+  420: optional WorkflowExecutionTerminatedByOperatorEventAttributes workflowExecutionTerminatedByOperatorEventAttributes
+  430: optional PayloadReference payloadReference
 }
 
 struct History {
@@ -599,6 +695,11 @@ struct StartTimeFilter {
   20: optional i64 (js.type = "Long") latestTime
 }
 
+struct CloseTimeFilter {
+  10: optional i64 (js.type = "Long") earliestTime
+  20: optional i64 (js.type = "Long") latestTime
+}
+
 struct DomainInfo {
   10: optional string name
   20: optional DomainStatus status
@@ -606,9 +707,22 @@ struct DomainInfo {
   40: optional string ownerEmail
 }
 
+struct ActivityTimeoutPolicy {
+  10: optional i32 scheduleToCloseTimeoutSeconds
+  20: optional i32 scheduleToStartTimeoutSeconds
+  30: optional i32 startToCloseTimeoutSeconds
+  40: optional i32 heartbeatTimeoutSeconds
+}
+
 struct DomainConfiguration {
   10: optional i32 workflowExecutionRetentionPeriodInDays
   20: optional bool emitMetric
+  30: optional list<string> badBinaryChecksums
+  40: optional i32 maxDecisionAttempts
+  50: optional ActivityTimeoutPolicy activityTimeoutDefaults
+  60: optional ActivityTimeoutPolicy activityTimeoutCaps
+  // cap on the executions of the domain open at the same time, enforced when executions are started
+  70: optional i32 maxOpenExecutions
 }
 
 struct UpdateDomainInfo {
@@ -648,6 +762,12 @@ struct DeprecateDomainRequest {
  10: optional string name
 }
 
+struct DrainDomainRequest {
+ 10: optional string name
+ // cancel puts a draining domain back to REGISTERED
+ 20: optional bool cancel
+}
+
 struct StartWorkflowExecutionRequest {
   10: optional string domain
   20: optional string workflowId
@@ -658,6 +778,9 @@ struct StartWorkflowExecutionRequest {
   70: optional i32 taskStartToCloseTimeoutSeconds
   80: optional string identity
   90: optional string requestId
+  100: optional i32 delayStartSeconds
+  110: optional Header header
+  120: optional PayloadReference payloadReference
 }
 
 struct StartWorkflowExecutionResponse {
@@ -668,6 +791,9 @@ struct PollForDecisionTaskRequest {
   10: optional string domain
   20: optional TaskList taskList
   30: optional string identity
+  40: optional list<string> compatibleBuildIds
+  // only return the history since the previous decision started, for pollers caching workflow state
+  50: optional bool partialHistory
 }
 
 struct PollForDecisionTaskResponse {
@@ -678,6 +804,11 @@ struct PollForDecisionTaskResponse {
   50: optional i64 (js.type = "Long") startedEventId
   60: optional History history
   70: optional binary nextPageToken
+  // queries to the workflow execution waiting to be answered with the completion of the decision, by query ID
+  80: optional map<string, WorkflowQuery> queries
+  // history starts after previousStartedEventId, pollers without the workflow state cached at
+  // previousStartedEventId need to get the full history
+  90: optional bool partialHistory
 }
 
 struct RespondDecisionTaskCompletedRequest {
@@ -685,6 +816,8 @@ struct RespondDecisionTaskCompletedRequest {
   20: optional list<Decision> decisions
   30: optional binary executionContext
   40: optional string identity
+  50: optional string binaryChecksum
+  60: optional map<string, WorkflowQueryAnswer> queryResults
 }
 
 struct PollForActivityTaskRequest {
@@ -705,6 +838,12 @@ struct PollForActivityTaskResponse {
   90:  optional i64 (js.type = "Long") startedTimestamp
   100: optional i32 startToCloseTimeoutSeconds
   110: optional i32 heartbeatTimeoutSeconds
+  120: optional Header header
+  130: optional PayloadReference payloadReference
+}
+
+struct RespondDecisionTaskCompletedResponse {
+  10: optional list<PollForActivityTaskResponse> activityTasks
 }
 
 struct RecordActivityTaskHeartbeatRequest {
@@ -721,6 +860,7 @@ struct RespondActivityTaskCompletedRequest {
   10: optional binary taskToken
   20: optional binary result
   30: optional string identity
+  40: optional PayloadReference payloadReference
 }
 
 struct RespondActivityTaskFailedRequest {
@@ -728,6 +868,7 @@ struct RespondActivityTaskFailedRequest {
   20: optional string reason
   30: optional binary details
   40: optional string identity
+  50: optional PayloadReference payloadReference
 }
 
 struct RespondActivityTaskCanceledRequest {
@@ -736,6 +877,34 @@ struct RespondActivityTaskCanceledRequest {
   30: optional string identity
 }
 
+struct RespondActivityTaskCompletedByIDRequest {
+  10: optional string domain
+  20: optional string workflowID
+  30: optional string runID
+  40: optional string activityID
+  50: optional binary result
+  60: optional string identity
+}
+
+struct RespondActivityTaskFailedByIDRequest {
+  10: optional string domain
+  20: optional string workflowID
+  30: optional string runID
+  40: optional string activityID
+  50: optional string reason
+  60: optional binary details
+  70: optional string identity
+}
+
+struct RespondActivityTaskCanceledByIDRequest {
+  10: optional string domain
+  20: optional string workflowID
+  30: optional string runID
+  40: optional string activityID
+  50: optional binary details
+  60: optional string identity
+}
+
 struct RequestCancelWorkflowExecutionRequest {
   10: optional string domain
   20: optional WorkflowExecution workflowExecution
@@ -760,6 +929,25 @@ struct SignalWorkflowExecutionRequest {
   30: optional string signalName
   40: optional binary input
   50: optional string identity
+  60: optional string requestId
+  70: optional PayloadReference payloadReference
+}
+
+struct SignalWorkflowExecutionResponse {
+  10: optional string runId
+  20: optional i64 (js.type = "Long") eventId
+}
+
+struct GetSignalReceiptRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  30: optional string requestId
+}
+
+struct GetSignalReceiptResponse {
+  10: optional bool recorded
+  20: optional string runId
+  30: optional i64 (js.type = "Long") eventId
 }
 
 struct TerminateWorkflowExecutionRequest {
@@ -768,6 +956,24 @@ struct TerminateWorkflowExecutionRequest {
   30: optional string reason
   40: optional binary details
   50: optional string identity
+  60: optional ChildPolicy childPolicy
+}
+
+struct TerminateWorkflowExecutionResponse {
+  10: optional list<WorkflowExecution> affectedExecutions
+}
+
+struct RefreshWorkflowTasksRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  30: optional string identity
+}
+
+struct MigrateWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  30: optional string targetDomain
+  40: optional string identity
 }
 
 struct ListOpenWorkflowExecutionsRequest {
@@ -777,6 +983,7 @@ struct ListOpenWorkflowExecutionsRequest {
   40: optional StartTimeFilter StartTimeFilter
   50: optional WorkflowExecutionFilter executionFilter
   60: optional WorkflowTypeFilter typeFilter
+  70: optional ExecutionInfoView view
 }
 
 struct ListOpenWorkflowExecutionsResponse {
@@ -792,9 +999,92 @@ struct ListClosedWorkflowExecutionsRequest {
   50: optional WorkflowExecutionFilter executionFilter
   60: optional WorkflowTypeFilter typeFilter
   70: optional WorkflowExecutionCloseStatus statusFilter
+  80: optional CloseTimeFilter closeTimeFilter
+  90: optional ExecutionInfoView view
 }
 
 struct ListClosedWorkflowExecutionsResponse {
   10: optional list<WorkflowExecutionInfo> executions
   20: optional binary nextPageToken
 }
+
+struct ListClosedWorkflowExecutionsSinceRequest {
+  10: optional string domain
+  20: optional i32 maximumPageSize
+  30: optional binary closedSinceToken
+  40: optional i64 (js.type = "Long") earliestCloseTime
+  50: optional ExecutionInfoView view
+}
+
+struct ListClosedWorkflowExecutionsSinceResponse {
+  10: optional list<WorkflowExecutionInfo> executions
+  20: optional binary closedSinceToken
+}
+
+struct WorkflowQuery {
+  10: optional string queryType
+  20: optional binary queryInput
+}
+
+struct WorkflowQueryAnswer {
+  10: optional QueryResultType resultType
+  20: optional binary answer
+  30: optional string errorMessage
+}
+
+struct QueryWorkflowRequest {
+  10: optional string domain
+  20: optional WorkflowExecution execution
+  30: optional WorkflowQuery query
+}
+
+struct QueryWorkflowResponse {
+  10: optional binary queryAnswer
+}
+
+struct DescribeWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional WorkflowExecution execution
+}
+
+struct PendingActivityInfo {
+  10: optional string activityID
+  20: optional PendingActivityState state
+  30: optional binary heartbeatDetails
+  40: optional i64 (js.type = "Long") lastHeartbeatTimestamp
+  50: optional i64 (js.type = "Long") lastStartedTimestamp
+  60: optional i64 (js.type = "Long") expirationTimestamp
+}
+
+struct DescribeWorkflowExecutionResponse {
+  10: optional WorkflowExecutionInfo workflowExecutionInfo
+  20: optional list<PendingActivityInfo> pendingActivities
+}
+
+struct DescribeClusterResponse {
+  10: optional string serverVersion
+  20: optional i32 numberOfHistoryShards
+  30: optional list<string> supportedClientFeatures
+  40: optional string persistenceStoreType
+}
+
+struct SerializedHistoryBatch {
+  10: optional string encodingType
+  20: optional i32 version
+  30: optional binary data
+}
+
+struct VerifyHistoryRequest {
+  10: optional list<SerializedHistoryBatch> batches
+}
+
+struct HistoryVerificationIssue {
+  10: optional i64 (js.type = "Long") eventId
+  20: optional string message
+}
+
+struct VerifyHistoryResponse {
+  10: optional bool valid
+  20: optional i64 (js.type = "Long") eventCount
+  30: optional list<HistoryVerificationIssue> issues
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+const (
+	// ClientNameHeaderName is the request header holding the name of the client library making the call
+	ClientNameHeaderName = "cadence-client-name"
+	// ClientVersionHeaderName is the request header holding the version of the client library making the call
+	ClientVersionHeaderName = "cadence-client-version"
+
+	unknownClientTagValue     = "unknown"
+	otherClientTagValue       = "other"
+	unsupportedClientTagValue = "unsupported"
+
+	// maxClientVersionTags bounds the number of distinct client version tag values
+	maxClientVersionTags = 100
+)
+
+type (
+	// clientVersionChecker rejects the calls of client libraries whose version is outside of the range configured
+	// for them.  Calls of libraries without a configured range, or which don't send their name, are accepted so
+	// older clients keep working.  Every call is counted by client name and version to track client rollouts, the
+	// tags are bounded: only configured client names are tagged and versions are bucketed by major.minor.
+	clientVersionChecker struct {
+		versions      map[string]clientVersionRange
+		metricsClient metrics.Client
+
+		sync.Mutex
+		versionTags map[string]struct{}
+	}
+
+	clientVersionRange struct {
+		min    clientVersion
+		max    clientVersion
+		config config.ClientVersions
+	}
+
+	// clientVersion is a parsed dotted version, nil for an open bound
+	clientVersion []int
+)
+
+func newClientVersionChecker(cfg map[string]config.ClientVersions,
+	metricsClient metrics.Client) (*clientVersionChecker, error) {
+	versions := make(map[string]clientVersionRange, len(cfg))
+	for name, c := range cfg {
+		r := clientVersionRange{config: c}
+		var err error
+		if c.MinVersion != "" {
+			if r.min, err = parseClientVersion(c.MinVersion); err != nil {
+				return nil, fmt.Errorf("invalid min version of client %v: %v", name, err)
+			}
+		}
+		if c.MaxVersion != "" {
+			if r.max, err = parseClientVersion(c.MaxVersion); err != nil {
+				return nil, fmt.Errorf("invalid max version of client %v: %v", name, err)
+			}
+		}
+		versions[name] = r
+	}
+	return &clientVersionChecker{
+		versions:      versions,
+		metricsClient: metricsClient,
+		versionTags:   make(map[string]struct{}),
+	}, nil
+}
+
+// checkClientVersion returns a BadRequestError when the client library making the call is not supported
+func (c *clientVersionChecker) checkClientVersion(ctx thrift.Context) error {
+	if c == nil || ctx == nil {
+		return nil
+	}
+	headers := ctx.Headers()
+	name := headers[ClientNameHeaderName]
+	version := headers[ClientVersionHeaderName]
+
+	r, ok := c.versions[name]
+	if !ok {
+		c.tagged(name, "").IncCounter(metrics.FrontendClientVersionScope, metrics.FrontendClientRequests)
+		return nil
+	}
+	parsed, err := parseClientVersion(version)
+	if err != nil || (r.min != nil && parsed.less(r.min)) || (r.max != nil && r.max.less(parsed)) {
+		metricsClient := c.tagged(name, unsupportedClientTagValue)
+		metricsClient.IncCounter(metrics.FrontendClientVersionScope, metrics.FrontendClientRequests)
+		metricsClient.IncCounter(metrics.FrontendClientVersionScope, metrics.FrontendClientVersionRejectedCounter)
+		return &gen.BadRequestError{Message: fmt.Sprintf(
+			"Version %q of client %v is not supported, supported versions are from %q to %q.",
+			version, name, r.config.MinVersion, r.config.MaxVersion)}
+	}
+	c.tagged(name, c.versionTag(parsed)).IncCounter(metrics.FrontendClientVersionScope, metrics.FrontendClientRequests)
+	return nil
+}
+
+// tagged returns the metrics client tagged with the client name, unconfigured clients are tagged as other
+func (c *clientVersionChecker) tagged(name, versionTag string) metrics.Client {
+	if name == "" {
+		name, versionTag = unknownClientTagValue, unknownClientTagValue
+	} else if _, ok := c.versions[name]; !ok {
+		name, versionTag = otherClientTagValue, otherClientTagValue
+	}
+	return c.metricsClient.Tagged(map[string]string{
+		metrics.ClientNameTagName:    name,
+		metrics.ClientVersionTagName: versionTag,
+	})
+}
+
+// versionTag buckets the version by major.minor, past maxClientVersionTags distinct buckets the version is other
+func (c *clientVersionChecker) versionTag(v clientVersion) string {
+	var minor int
+	if len(v) > 1 {
+		minor = v[1]
+	}
+	tag := fmt.Sprintf("%v.%v", v[0], minor)
+
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.versionTags[tag]; !ok {
+		if len(c.versionTags) >= maxClientVersionTags {
+			return otherClientTagValue
+		}
+		c.versionTags[tag] = struct{}{}
+	}
+	return tag
+}
+
+func parseClientVersion(version string) (clientVersion, error) {
+	if version == "" {
+		return nil, fmt.Errorf("version not set")
+	}
+	parts := strings.Split(version, ".")
+	parsed := make(clientVersion, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("version %q is not made of dotted numbers", version)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
+
+// less compares the versions number by number, missing trailing numbers are zeros
+func (v clientVersion) less(other clientVersion) bool {
+	for i := 0; i < len(v) || i < len(other); i++ {
+		var a, b int
+		if i < len(v) {
+			a = v[i]
+		}
+		if i < len(other) {
+			b = other[i]
+		}
+		if a != b {
+			return a < b
+		}
+	}
+	return false
+}
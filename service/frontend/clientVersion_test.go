@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+type clientVersionSuite struct {
+	suite.Suite
+	scope   tally.TestScope
+	checker *clientVersionChecker
+}
+
+func TestClientVersionSuite(t *testing.T) {
+	suite.Run(t, new(clientVersionSuite))
+}
+
+func (s *clientVersionSuite) SetupTest() {
+	s.scope = tally.NewTestScope("", nil)
+	var err error
+	s.checker, err = newClientVersionChecker(map[string]config.ClientVersions{
+		"go-client":   {MinVersion: "0.2", MaxVersion: "1.4.0"},
+		"java-client": {MinVersion: "1.0.0"},
+	}, metrics.NewClient(s.scope, metrics.Frontend))
+	s.NoError(err)
+}
+
+func (s *clientVersionSuite) TestCheckClientVersion() {
+	s.NoError(s.checkClientVersion("go-client", "0.2.0"))
+	s.NoError(s.checkClientVersion("go-client", "1.4"))
+	s.NoError(s.checkClientVersion("java-client", "12.0.1"))
+	s.NoError(s.checkClientVersion("other-client", "0.0.1"))
+	s.NoError(s.checkClientVersion("", ""))
+	s.NoError(s.checker.checkClientVersion(nil))
+
+	err := s.checkClientVersion("go-client", "0.1.9")
+	s.IsType(&gen.BadRequestError{}, err)
+	s.Contains(err.Error(), "from \"0.2\" to \"1.4.0\"")
+	s.IsType(&gen.BadRequestError{}, s.checkClientVersion("go-client", "1.4.0.1"))
+	s.IsType(&gen.BadRequestError{}, s.checkClientVersion("java-client", "0.9"))
+	s.IsType(&gen.BadRequestError{}, s.checkClientVersion("java-client", ""))
+	s.IsType(&gen.BadRequestError{}, s.checkClientVersion("java-client", "1.0.0-beta"))
+}
+
+func (s *clientVersionSuite) TestMetrics() {
+	s.checkClientVersion("go-client", "1.0.0")
+	s.checkClientVersion("go-client", "1.0.3")
+	s.checkClientVersion("go-client", "2.0.0")
+	s.checkClientVersion("java-client", "1.2")
+	s.checkClientVersion("my-client", "0.0.1")
+	s.checkClientVersion("", "")
+
+	counters := s.scope.Snapshot().Counters()
+	s.Equal(int64(2), counters["client.requests+client_name=go-client,client_version=1.0,operation=ClientVersion"].Value())
+	s.Equal(int64(1),
+		counters["client.requests+client_name=go-client,client_version=unsupported,operation=ClientVersion"].Value())
+	s.Equal(int64(1),
+		counters["client.version-rejected+client_name=go-client,client_version=unsupported,operation=ClientVersion"].Value())
+	s.Equal(int64(1), counters["client.requests+client_name=java-client,client_version=1.2,operation=ClientVersion"].Value())
+	s.Equal(int64(1), counters["client.requests+client_name=other,client_version=other,operation=ClientVersion"].Value())
+	s.Equal(int64(1), counters["client.requests+client_name=unknown,client_version=unknown,operation=ClientVersion"].Value())
+}
+
+func (s *clientVersionSuite) TestMetricsVersionTagsBounded() {
+	for i := 0; i < maxClientVersionTags+10; i++ {
+		s.NoError(s.checkClientVersion("java-client", fmt.Sprintf("1.%v.0", i)))
+	}
+
+	counters := s.scope.Snapshot().Counters()
+	s.Equal(int64(10), counters["client.requests+client_name=java-client,client_version=other,operation=ClientVersion"].Value())
+	s.Equal(int64(1), counters["client.requests+client_name=java-client,client_version=1.0,operation=ClientVersion"].Value())
+}
+
+func (s *clientVersionSuite) TestInvalidConfig() {
+	_, err := newClientVersionChecker(map[string]config.ClientVersions{"go-client": {MinVersion: "v1"}},
+		metrics.NewClient(s.scope, metrics.Frontend))
+	s.Error(err)
+}
+
+func (s *clientVersionSuite) checkClientVersion(name, version string) error {
+	headers := make(map[string]string)
+	if name != "" {
+		headers[ClientNameHeaderName] = name
+	}
+	if version != "" {
+		headers[ClientVersionHeaderName] = version
+	}
+	return s.checker.checkClientVersion(thrift.WithHeaders(context.Background(), headers))
+}
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/pborman/uuid"
 	"github.com/uber/cadence/.gen/go/cadence"
@@ -33,11 +34,17 @@ import (
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/errors"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/tracing"
 
 	"github.com/uber-common/bark"
+	"github.com/uber/tchannel-go"
 	"github.com/uber/tchannel-go/thrift"
 )
 
@@ -54,6 +61,13 @@ type (
 		matching           matching.Client
 		tokenSerializer    common.TaskTokenSerializer
 		hSerializerFactory persistence.HistorySerializerFactory
+		auditLogger        audit.Logger
+		historyResponses   *historyResponseCache
+		pageTokenSigner    *pageTokenSigner
+		versionChecker     *clientVersionChecker
+		headerPropagator   *headerPropagator
+		historyVerifier    *historyVerifier
+		payloads           *payloadOffloader
 		startWG            sync.WaitGroup
 		service.Service
 	}
@@ -63,13 +77,58 @@ type (
 		nextEventID      int64
 		persistenceToken []byte
 	}
+
+	// closedSinceToken is the position of a ListClosedWorkflowExecutionsSince caller in the close time ordered
+	// stream of closed executions
+	closedSinceToken struct {
+		CloseTime int64
+		// Runs closed exactly at CloseTime which were already returned
+		RunIDs []string
+	}
 )
 
 const (
 	defaultVisibilityMaxPageSize = 1000
 	defaultHistoryMaxPageSize    = 1000
+
+	// closedSinceSettleDelay keeps ListClosedWorkflowExecutionsSince behind the most recent closes, visibility records
+	// are written asynchronously and may land out of close time order
+	closedSinceSettleDelay = time.Minute
+
+	// maxDelayStartSeconds bounds how long the first decision of an execution can be delayed
+	maxDelayStartSeconds = 365 * 24 * 60 * 60
 )
 
+// Client features reported by DescribeCluster, clients use the features of requests and decisions only when the
+// cluster reports them
+const (
+	ClientFeatureQueryWorkflow          = "queryWorkflow"
+	ClientFeatureActivityCompletionByID = "activityCompletionByID"
+	ClientFeatureSubSecondTimers        = "subSecondTimers"
+	ClientFeatureDelayedStart           = "delayedStart"
+	ClientFeatureActivityPriority       = "activityPriority"
+	ClientFeatureActivitySessions       = "activitySessions"
+	ClientFeatureLocalActivityDispatch  = "localActivityDispatch"
+	ClientFeatureCompatibleBuildIDs     = "compatibleBuildIDs"
+	ClientFeatureCloseTimeFilter        = "closeTimeFilter"
+	ClientFeatureMinimalExecutionInfo   = "minimalExecutionInfo"
+	ClientFeaturePartialDecisionHistory = "partialDecisionHistory"
+)
+
+var supportedClientFeatures = []string{
+	ClientFeatureQueryWorkflow,
+	ClientFeatureActivityCompletionByID,
+	ClientFeatureSubSecondTimers,
+	ClientFeatureDelayedStart,
+	ClientFeatureActivityPriority,
+	ClientFeatureActivitySessions,
+	ClientFeatureLocalActivityDispatch,
+	ClientFeatureCompatibleBuildIDs,
+	ClientFeatureCloseTimeFilter,
+	ClientFeatureMinimalExecutionInfo,
+	ClientFeaturePartialDecisionHistory,
+}
+
 var (
 	errDomainNotSet         = &gen.BadRequestError{Message: "Domain not set on request."}
 	errTaskTokenNotSet      = &gen.BadRequestError{Message: "Task token not set on request."}
@@ -77,22 +136,50 @@ var (
 	errExecutionNotSet      = &gen.BadRequestError{Message: "Execution is not set on request."}
 	errWorkflowIDNotSet     = &gen.BadRequestError{Message: "WorkflowId is not set on request."}
 	errRunIDNotSet          = &gen.BadRequestError{Message: "RunId is not set on request."}
+	errActivityIDNotSet     = &gen.BadRequestError{Message: "ActivityId is not set on request."}
 	errInvalidRunID         = &gen.BadRequestError{Message: "Invalid RunId."}
 	errInvalidNextPageToken = &gen.BadRequestError{Message: "Invalid NextPageToken."}
+	errHistoryNotSet        = &gen.BadRequestError{Message: "History is not set on request."}
+
+	errInvalidMaxDecisionAttempts   = &gen.BadRequestError{Message: "MaxDecisionAttempts cannot be negative."}
+	errInvalidActivityTimeoutPolicy = &gen.BadRequestError{Message: "Activity timeouts cannot be negative."}
+	errInvalidMaxOpenExecutions     = &gen.BadRequestError{Message: "MaxOpenExecutions cannot be negative."}
+
+	errDomainNotRegistered = &gen.BadRequestError{Message: "Only registered domains can be drained."}
+	errDomainNotDraining   = &gen.BadRequestError{Message: "Domain is not draining."}
 )
 
 // NewWorkflowHandler creates a thrift handler for the cadence service
 func NewWorkflowHandler(
 	sVice service.Service, metadataMgr persistence.MetadataManager,
-	historyMgr persistence.HistoryManager, visibilityMgr persistence.VisibilityManager) (*WorkflowHandler, []thrift.TChanServer) {
+	historyMgr persistence.HistoryManager, visibilityMgr persistence.VisibilityManager,
+	auditLogger audit.Logger, pageTokenConfig config.PageToken,
+	clientVersions map[string]config.ClientVersions,
+	propagatedHeaders []string, blobClient blobstore.Client,
+	largePayloadsConfig config.LargePayloads) (*WorkflowHandler, []thrift.TChanServer) {
+	versionChecker, err := newClientVersionChecker(clientVersions, sVice.GetMetricsClient())
+	if err != nil {
+		sVice.GetLogger().Fatalf("invalid client versions config: %v", err)
+	}
+	pageTokenSigner, err := newPageTokenSigner(pageTokenConfig)
+	if err != nil {
+		sVice.GetLogger().Fatalf("invalid page token config: %v", err)
+	}
 	handler := &WorkflowHandler{
 		Service:            sVice,
 		metadataMgr:        metadataMgr,
 		historyMgr:         historyMgr,
 		visibitiltyMgr:     visibilityMgr,
-		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
+		tokenSerializer:    sVice.GetTaskTokenSerializer(),
 		hSerializerFactory: persistence.NewHistorySerializerFactory(),
 		domainCache:        cache.NewDomainCache(metadataMgr, sVice.GetLogger()),
+		auditLogger:        auditLogger,
+		historyResponses:   newHistoryResponseCache(),
+		pageTokenSigner:    pageTokenSigner,
+		versionChecker:     versionChecker,
+		headerPropagator:   newHeaderPropagator(propagatedHeaders),
+		historyVerifier:    newHistoryVerifier(persistence.NewHistorySerializerFactory()),
+		payloads:           newPayloadOffloader(blobClient, largePayloadsConfig),
 	}
 	// prevent us from trying to serve requests before handler's Start() is complete
 	handler.startWG.Add(1)
@@ -130,14 +217,23 @@ func (wh *WorkflowHandler) IsHealthy(ctx thrift.Context) (bool, error) {
 // entity within Cadence, used as a container for all resources like workflow executions, tasklists, etc.  Domain
 // acts as a sandbox and provides isolation for all resources within the domain.  All resources belongs to exactly one
 // domain.
-func (wh *WorkflowHandler) RegisterDomain(ctx thrift.Context, registerRequest *gen.RegisterDomainRequest) error {
+func (wh *WorkflowHandler) RegisterDomain(ctx thrift.Context, registerRequest *gen.RegisterDomainRequest) (retError error) {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		wh.auditLogger.Log(audit.OperationRegisterDomain, getCallerIdentity(ctx, ""), registerRequest.GetName(), "", "",
+			retError)
+	}()
+
 	if !registerRequest.IsSetName() || registerRequest.GetName() == "" {
 		return errDomainNotSet
 	}
 
-	response, err := wh.metadataMgr.CreateDomain(&persistence.CreateDomainRequest{
+	response, err := wh.metadataMgr.CreateDomain(ctx, &persistence.CreateDomainRequest{
 		Name:        registerRequest.GetName(),
 		Status:      persistence.DomainStatusRegistered,
 		OwnerEmail:  registerRequest.GetOwnerEmail(),
@@ -150,8 +246,7 @@ func (wh *WorkflowHandler) RegisterDomain(ctx thrift.Context, registerRequest *g
 		return wrapError(err)
 	}
 
-	// TODO: Log through logging framework.  We need to have good auditing of domain CRUD
-	wh.GetLogger().Debugf("Register domain succeeded for name: %v, Id: %v", registerRequest.GetName(), response.ID)
+	wh.getLogger(ctx).Debugf("Register domain succeeded for name: %v, Id: %v", registerRequest.GetName(), response.ID)
 	return nil
 }
 
@@ -160,36 +255,48 @@ func (wh *WorkflowHandler) DescribeDomain(ctx thrift.Context,
 	describeRequest *gen.DescribeDomainRequest) (*gen.DescribeDomainResponse, error) {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+
 	if !describeRequest.IsSetName() {
 		return nil, errDomainNotSet
 	}
 
-	resp, err := wh.metadataMgr.GetDomain(&persistence.GetDomainRequest{
-		Name: describeRequest.GetName(),
-	})
-
+	// Domains are looked up through the domain cache, which refreshes its entries every few seconds.  The updates made
+	// through this host invalidate the entries right away.
+	info, config, err := wh.domainCache.GetDomain(describeRequest.GetName())
 	if err != nil {
 		return nil, wrapError(err)
 	}
 
 	response := gen.NewDescribeDomainResponse()
-	response.DomainInfo, response.Configuration = createDomainResponse(resp.Info, resp.Config)
+	response.DomainInfo, response.Configuration = createDomainResponse(info, config)
 
 	return response, nil
 }
 
 // UpdateDomain is used to update the information and configuration for a registered domain.
 func (wh *WorkflowHandler) UpdateDomain(ctx thrift.Context,
-	updateRequest *gen.UpdateDomainRequest) (*gen.UpdateDomainResponse, error) {
+	updateRequest *gen.UpdateDomainRequest) (resp *gen.UpdateDomainResponse, retError error) {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		wh.auditLogger.Log(audit.OperationUpdateDomain, getCallerIdentity(ctx, ""), updateRequest.GetName(), "", "",
+			retError)
+	}()
+
 	if !updateRequest.IsSetName() {
 		return nil, errDomainNotSet
 	}
 
 	domainName := updateRequest.GetName()
 
-	getResponse, err0 := wh.metadataMgr.GetDomain(&persistence.GetDomainRequest{
+	getResponse, err0 := wh.metadataMgr.GetDomain(ctx, &persistence.GetDomainRequest{
 		Name: domainName,
 	})
 
@@ -218,12 +325,39 @@ func (wh *WorkflowHandler) UpdateDomain(ctx thrift.Context,
 		if updatedConfig.IsSetWorkflowExecutionRetentionPeriodInDays() {
 			config.Retention = updatedConfig.GetWorkflowExecutionRetentionPeriodInDays()
 		}
+		if updatedConfig.IsSetBadBinaryChecksums() {
+			config.BadBinaries = updatedConfig.GetBadBinaryChecksums()
+		}
+		if updatedConfig.IsSetMaxDecisionAttempts() {
+			if updatedConfig.GetMaxDecisionAttempts() < 0 {
+				return nil, errInvalidMaxDecisionAttempts
+			}
+			config.MaxDecisionAttempts = updatedConfig.GetMaxDecisionAttempts()
+		}
+		if updatedConfig.IsSetMaxOpenExecutions() {
+			if updatedConfig.GetMaxOpenExecutions() < 0 {
+				return nil, errInvalidMaxOpenExecutions
+			}
+			config.MaxOpenExecutions = updatedConfig.GetMaxOpenExecutions()
+		}
+		if updatedConfig.IsSetActivityTimeoutDefaults() {
+			if err := updateActivityTimeouts(&config.ActivityTimeoutDefaults,
+				updatedConfig.GetActivityTimeoutDefaults()); err != nil {
+				return nil, err
+			}
+		}
+		if updatedConfig.IsSetActivityTimeoutCaps() {
+			if err := updateActivityTimeouts(&config.ActivityTimeoutCaps, updatedConfig.GetActivityTimeoutCaps()); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	err := wh.metadataMgr.UpdateDomain(&persistence.UpdateDomainRequest{
+	err := wh.metadataMgr.UpdateDomain(ctx, &persistence.UpdateDomainRequest{
 		Info:   info,
 		Config: config,
 	})
+	wh.domainCache.InvalidateDomain(info.Name, info.ID)
 	if err != nil {
 		return nil, wrapError(err)
 	}
@@ -236,16 +370,26 @@ func (wh *WorkflowHandler) UpdateDomain(ctx thrift.Context,
 // DeprecateDomain us used to update status of a registered domain to DEPRECATED.  Once the domain is deprecated
 // it cannot be used to start new workflow executions.  Existing workflow executions will continue to run on
 // deprecated domains.
-func (wh *WorkflowHandler) DeprecateDomain(ctx thrift.Context, deprecateRequest *gen.DeprecateDomainRequest) error {
+func (wh *WorkflowHandler) DeprecateDomain(ctx thrift.Context,
+	deprecateRequest *gen.DeprecateDomainRequest) (retError error) {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		wh.auditLogger.Log(audit.OperationDeprecateDomain, getCallerIdentity(ctx, ""), deprecateRequest.GetName(), "", "",
+			retError)
+	}()
+
 	if !deprecateRequest.IsSetName() {
 		return errDomainNotSet
 	}
 
 	domainName := deprecateRequest.GetName()
 
-	getResponse, err0 := wh.metadataMgr.GetDomain(&persistence.GetDomainRequest{
+	getResponse, err0 := wh.metadataMgr.GetDomain(ctx, &persistence.GetDomainRequest{
 		Name: domainName,
 	})
 
@@ -257,10 +401,59 @@ func (wh *WorkflowHandler) DeprecateDomain(ctx thrift.Context, deprecateRequest
 	info.Status = persistence.DomainStatusDeprecated
 	config := getResponse.Config
 
-	return wh.metadataMgr.UpdateDomain(&persistence.UpdateDomainRequest{
+	err := wh.metadataMgr.UpdateDomain(ctx, &persistence.UpdateDomainRequest{
 		Info:   info,
 		Config: config,
 	})
+	wh.domainCache.InvalidateDomain(info.Name, info.ID)
+	return err
+}
+
+// DrainDomain is used to update status of a registered domain to DRAINING.  A draining domain rejects new workflow
+// executions while the existing ones run to completion.  Setting cancel on the request puts a draining domain back
+// to REGISTERED.
+func (wh *WorkflowHandler) DrainDomain(ctx thrift.Context, drainRequest *gen.DrainDomainRequest) (retError error) {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		wh.auditLogger.Log(audit.OperationDrainDomain, getCallerIdentity(ctx, ""), drainRequest.GetName(), "", "",
+			retError)
+	}()
+
+	if !drainRequest.IsSetName() {
+		return errDomainNotSet
+	}
+
+	getResponse, err := wh.metadataMgr.GetDomain(ctx, &persistence.GetDomainRequest{
+		Name: drainRequest.GetName(),
+	})
+	if err != nil {
+		return wrapError(err)
+	}
+
+	info := getResponse.Info
+	if drainRequest.GetCancel() {
+		if info.Status != persistence.DomainStatusDraining {
+			return errDomainNotDraining
+		}
+		info.Status = persistence.DomainStatusRegistered
+	} else {
+		if info.Status != persistence.DomainStatusRegistered && info.Status != persistence.DomainStatusDraining {
+			return errDomainNotRegistered
+		}
+		info.Status = persistence.DomainStatusDraining
+	}
+
+	err = wh.metadataMgr.UpdateDomain(ctx, &persistence.UpdateDomainRequest{
+		Info:   info,
+		Config: getResponse.Config,
+	})
+	wh.domainCache.InvalidateDomain(info.Name, info.ID)
+	return wrapError(err)
 }
 
 // PollForActivityTask - Poll for an activity task.
@@ -269,7 +462,11 @@ func (wh *WorkflowHandler) PollForActivityTask(
 	pollRequest *gen.PollForActivityTaskRequest) (*gen.PollForActivityTaskResponse, error) {
 	wh.startWG.Wait()
 
-	wh.Service.GetLogger().Debug("Received PollForActivityTask")
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+
+	wh.getLogger(ctx).Debug("Received PollForActivityTask")
 	if !pollRequest.IsSetDomain() {
 		return nil, errDomainNotSet
 	}
@@ -289,10 +486,23 @@ func (wh *WorkflowHandler) PollForActivityTask(
 		PollRequest: pollRequest,
 	})
 	if err != nil {
-		wh.Service.GetLogger().Errorf(
+		wh.getLogger(ctx).Errorf(
 			"PollForActivityTask failed. TaskList: %v, Error: %v", pollRequest.GetTaskList().GetName(), err)
+		return nil, wrapError(err)
 	}
-	return resp, wrapError(err)
+	if resp.PayloadReference != nil {
+		// Activities can be scheduled on the task lists of other domains, the payload belongs to the domain of the
+		// execution which scheduled it
+		taskToken, err := wh.tokenSerializer.Deserialize(resp.GetTaskToken())
+		if err != nil {
+			return nil, wrapError(err)
+		}
+		if err := wh.payloads.resolve(ctx, taskToken.DomainID, &resp.Input, &resp.PayloadReference); err != nil {
+			return nil, wrapError(err)
+		}
+	}
+	wh.headerPropagator.setResponseHeaders(ctx, resp.GetHeader())
+	return resp, nil
 }
 
 // PollForDecisionTask - Poll for a decision task.
@@ -301,7 +511,11 @@ func (wh *WorkflowHandler) PollForDecisionTask(
 	pollRequest *gen.PollForDecisionTaskRequest) (*gen.PollForDecisionTaskResponse, error) {
 	wh.startWG.Wait()
 
-	wh.Service.GetLogger().Debug("Received PollForDecisionTask")
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+
+	wh.getLogger(ctx).Debug("Received PollForDecisionTask")
 	if !pollRequest.IsSetDomain() {
 		return nil, errDomainNotSet
 	}
@@ -316,15 +530,15 @@ func (wh *WorkflowHandler) PollForDecisionTask(
 		return nil, wrapError(err)
 	}
 
-	wh.Service.GetLogger().Infof("Poll for decision domain name: %v", domainName)
-	wh.Service.GetLogger().Infof("Poll for decision request domainID: %v", info.ID)
+	wh.getLogger(ctx).Infof("Poll for decision domain name: %v", domainName)
+	wh.getLogger(ctx).Infof("Poll for decision request domainID: %v", info.ID)
 
 	matchingResp, err := wh.matching.PollForDecisionTask(ctx, &m.PollForDecisionTaskRequest{
 		DomainUUID:  common.StringPtr(info.ID),
 		PollRequest: pollRequest,
 	})
 	if err != nil {
-		wh.Service.GetLogger().Errorf(
+		wh.getLogger(ctx).Errorf(
 			"PollForDecisionTask failed. TaskList: %v, Error: %v", pollRequest.GetTaskList().GetName(), err)
 		return nil, wrapError(err)
 	}
@@ -332,10 +546,12 @@ func (wh *WorkflowHandler) PollForDecisionTask(
 	var history *gen.History
 	var persistenceToken []byte
 	var continuation []byte
+	firstEventID := common.FirstEventID
 	if matchingResp.IsSetWorkflowExecution() {
 		// Non-empty response. Get the history
-		history, persistenceToken, err = wh.getHistory(
-			info.ID, *matchingResp.GetWorkflowExecution(), matchingResp.GetStartedEventId()+1, defaultHistoryMaxPageSize, nil)
+		firstEventID = getDecisionHistoryFirstEventID(pollRequest.GetPartialHistory(), matchingResp.GetPreviousStartedEventId())
+		history, persistenceToken, err = wh.getHistory(ctx, info.ID, *matchingResp.GetWorkflowExecution(), firstEventID,
+			matchingResp.GetStartedEventId()+1, defaultHistoryMaxPageSize, nil)
 		if err != nil {
 			return nil, wrapError(err)
 		}
@@ -345,9 +561,14 @@ func (wh *WorkflowHandler) PollForDecisionTask(
 		if err != nil {
 			return nil, wrapError(err)
 		}
+		wh.headerPropagator.setResponseHeaders(ctx, startedEventHeader(history))
 	}
 
-	return createPollForDecisionTaskResponse(matchingResp, history, continuation), nil
+	resp := createPollForDecisionTaskResponse(matchingResp, history, continuation)
+	if firstEventID != common.FirstEventID {
+		resp.PartialHistory = common.BoolPtr(true)
+	}
+	return resp, nil
 }
 
 // RecordActivityTaskHeartbeat - Record Activity Task Heart beat.
@@ -356,7 +577,11 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(
 	heartbeatRequest *gen.RecordActivityTaskHeartbeatRequest) (*gen.RecordActivityTaskHeartbeatResponse, error) {
 	wh.startWG.Wait()
 
-	wh.Service.GetLogger().Debug("Received RecordActivityTaskHeartbeat")
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+
+	wh.getLogger(ctx).Debug("Received RecordActivityTaskHeartbeat")
 	if !heartbeatRequest.IsSetTaskToken() {
 		return nil, errTaskTokenNotSet
 	}
@@ -381,6 +606,10 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 	completeRequest *gen.RespondActivityTaskCompletedRequest) error {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+
 	if !completeRequest.IsSetTaskToken() {
 		return errTaskTokenNotSet
 	}
@@ -392,12 +621,17 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 		return errDomainNotSet
 	}
 
+	if err := wh.payloads.offload(ctx, taskToken.DomainID, &completeRequest.Result_,
+		&completeRequest.PayloadReference); err != nil {
+		return wrapError(err)
+	}
+
 	err = wh.history.RespondActivityTaskCompleted(ctx, &h.RespondActivityTaskCompletedRequest{
 		DomainUUID:      common.StringPtr(taskToken.DomainID),
 		CompleteRequest: completeRequest,
 	})
 	if err != nil {
-		logger := wh.getLoggerForTask(completeRequest.GetTaskToken())
+		logger := wh.getLoggerForTask(ctx, completeRequest.GetTaskToken())
 		logger.Errorf("RespondActivityTaskCompleted. Error: %v", err)
 	}
 	return wrapError(err)
@@ -409,6 +643,10 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(
 	failedRequest *gen.RespondActivityTaskFailedRequest) error {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+
 	if !failedRequest.IsSetTaskToken() {
 		return errTaskTokenNotSet
 	}
@@ -420,12 +658,17 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(
 		return errDomainNotSet
 	}
 
+	if err := wh.payloads.offload(ctx, taskToken.DomainID, &failedRequest.Details,
+		&failedRequest.PayloadReference); err != nil {
+		return wrapError(err)
+	}
+
 	err = wh.history.RespondActivityTaskFailed(ctx, &h.RespondActivityTaskFailedRequest{
 		DomainUUID:    common.StringPtr(taskToken.DomainID),
 		FailedRequest: failedRequest,
 	})
 	if err != nil {
-		logger := wh.getLoggerForTask(failedRequest.GetTaskToken())
+		logger := wh.getLoggerForTask(ctx, failedRequest.GetTaskToken())
 		logger.Errorf("RespondActivityTaskFailed. Error: %v", err)
 	}
 	return wrapError(err)
@@ -438,6 +681,10 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(
 	cancelRequest *gen.RespondActivityTaskCanceledRequest) error {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+
 	if !cancelRequest.IsSetTaskToken() {
 		return errTaskTokenNotSet
 	}
@@ -454,48 +701,219 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(
 		CancelRequest: cancelRequest,
 	})
 	if err != nil {
-		logger := wh.getLoggerForTask(cancelRequest.GetTaskToken())
+		logger := wh.getLoggerForTask(ctx, cancelRequest.GetTaskToken())
 		logger.Errorf("RespondActivityTaskCanceled. Error: %v", err)
 	}
 	return wrapError(err)
 
 }
 
+// RespondActivityTaskCompletedByID - response to an activity task completion, located by the activity ID
+func (wh *WorkflowHandler) RespondActivityTaskCompletedByID(
+	ctx thrift.Context,
+	completeRequest *gen.RespondActivityTaskCompletedByIDRequest) error {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+
+	taskToken, err := wh.createActivityTaskToken(completeRequest.GetDomain(), completeRequest.GetWorkflowID(),
+		completeRequest.GetRunID(), completeRequest.GetActivityID())
+	if err != nil {
+		return err
+	}
+	token, err := wh.tokenSerializer.Serialize(taskToken)
+	if err != nil {
+		return wrapError(err)
+	}
+	var payloadReference *gen.PayloadReference
+	if err := wh.payloads.offload(ctx, taskToken.DomainID, &completeRequest.Result_, &payloadReference); err != nil {
+		return wrapError(err)
+	}
+
+	err = wh.history.RespondActivityTaskCompleted(ctx, &h.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(taskToken.DomainID),
+		CompleteRequest: &gen.RespondActivityTaskCompletedRequest{
+			TaskToken:        token,
+			Result_:          completeRequest.Result_,
+			Identity:         completeRequest.Identity,
+			PayloadReference: payloadReference,
+		},
+	})
+	if err != nil {
+		wh.getLoggerForTask(ctx, token).Errorf("RespondActivityTaskCompletedByID. Error: %v", err)
+	}
+	return wrapError(err)
+}
+
+// RespondActivityTaskFailedByID - response to an activity task failure, located by the activity ID
+func (wh *WorkflowHandler) RespondActivityTaskFailedByID(
+	ctx thrift.Context,
+	failedRequest *gen.RespondActivityTaskFailedByIDRequest) error {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+
+	taskToken, err := wh.createActivityTaskToken(failedRequest.GetDomain(), failedRequest.GetWorkflowID(),
+		failedRequest.GetRunID(), failedRequest.GetActivityID())
+	if err != nil {
+		return err
+	}
+	token, err := wh.tokenSerializer.Serialize(taskToken)
+	if err != nil {
+		return wrapError(err)
+	}
+	var payloadReference *gen.PayloadReference
+	if err := wh.payloads.offload(ctx, taskToken.DomainID, &failedRequest.Details, &payloadReference); err != nil {
+		return wrapError(err)
+	}
+
+	err = wh.history.RespondActivityTaskFailed(ctx, &h.RespondActivityTaskFailedRequest{
+		DomainUUID: common.StringPtr(taskToken.DomainID),
+		FailedRequest: &gen.RespondActivityTaskFailedRequest{
+			TaskToken:        token,
+			Reason:           failedRequest.Reason,
+			Details:          failedRequest.Details,
+			Identity:         failedRequest.Identity,
+			PayloadReference: payloadReference,
+		},
+	})
+	if err != nil {
+		wh.getLoggerForTask(ctx, token).Errorf("RespondActivityTaskFailedByID. Error: %v", err)
+	}
+	return wrapError(err)
+}
+
+// RespondActivityTaskCanceledByID - called to cancel an activity task, located by the activity ID
+func (wh *WorkflowHandler) RespondActivityTaskCanceledByID(
+	ctx thrift.Context,
+	cancelRequest *gen.RespondActivityTaskCanceledByIDRequest) error {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+
+	taskToken, err := wh.createActivityTaskToken(cancelRequest.GetDomain(), cancelRequest.GetWorkflowID(),
+		cancelRequest.GetRunID(), cancelRequest.GetActivityID())
+	if err != nil {
+		return err
+	}
+	token, err := wh.tokenSerializer.Serialize(taskToken)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	err = wh.history.RespondActivityTaskCanceled(ctx, &h.RespondActivityTaskCanceledRequest{
+		DomainUUID: common.StringPtr(taskToken.DomainID),
+		CancelRequest: &gen.RespondActivityTaskCanceledRequest{
+			TaskToken: token,
+			Details:   cancelRequest.Details,
+			Identity:  cancelRequest.Identity,
+		},
+	})
+	if err != nil {
+		wh.getLoggerForTask(ctx, token).Errorf("RespondActivityTaskCanceledByID. Error: %v", err)
+	}
+	return wrapError(err)
+}
+
+// createActivityTaskToken makes up the task token of an activity located by its ID, for the activity ID based APIs.
+// History resolves the schedule ID of the activity from the activity ID.
+func (wh *WorkflowHandler) createActivityTaskToken(domainName, workflowID, runID,
+	activityID string) (*common.TaskToken, error) {
+	if domainName == "" {
+		return nil, errDomainNotSet
+	}
+	if workflowID == "" {
+		return nil, errWorkflowIDNotSet
+	}
+	if runID != "" && uuid.Parse(runID) == nil {
+		return nil, errInvalidRunID
+	}
+	if activityID == "" {
+		return nil, errActivityIDNotSet
+	}
+
+	info, _, err := wh.domainCache.GetDomain(domainName)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return &common.TaskToken{
+		DomainID:   info.ID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+		ScheduleID: common.EmptyEventID,
+		ActivityID: activityID,
+	}, nil
+}
+
 // RespondDecisionTaskCompleted - response to a decision task
 func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 	ctx thrift.Context,
-	completeRequest *gen.RespondDecisionTaskCompletedRequest) error {
+	completeRequest *gen.RespondDecisionTaskCompletedRequest) (*gen.RespondDecisionTaskCompletedResponse, error) {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+
 	if !completeRequest.IsSetTaskToken() {
-		return errTaskTokenNotSet
+		return nil, errTaskTokenNotSet
 	}
 	taskToken, err := wh.tokenSerializer.Deserialize(completeRequest.GetTaskToken())
 	if err != nil {
-		return wrapError(err)
+		return nil, wrapError(err)
 	}
 	if taskToken.DomainID == "" {
-		return errDomainNotSet
+		return nil, errDomainNotSet
+	}
+
+	if err := wh.payloads.offloadDecisions(ctx, taskToken.DomainID, completeRequest.Decisions); err != nil {
+		return nil, wrapError(err)
 	}
 
-	err = wh.history.RespondDecisionTaskCompleted(ctx, &h.RespondDecisionTaskCompletedRequest{
+	response, err := wh.history.RespondDecisionTaskCompleted(ctx, &h.RespondDecisionTaskCompletedRequest{
 		DomainUUID:      common.StringPtr(taskToken.DomainID),
 		CompleteRequest: completeRequest,
 	})
 	if err != nil {
-		logger := wh.getLoggerForTask(completeRequest.GetTaskToken())
+		logger := wh.getLoggerForTask(ctx, completeRequest.GetTaskToken())
 		logger.Errorf("RespondDecisionTaskCompleted. Error: %v", err)
+		return nil, wrapError(err)
 	}
-	return wrapError(err)
+	if err := wh.payloads.resolveActivityTasks(ctx, taskToken.DomainID, response.GetActivityTasks()); err != nil {
+		return nil, wrapError(err)
+	}
+	return response, nil
 }
 
 // StartWorkflowExecution - Creates a new workflow execution
 func (wh *WorkflowHandler) StartWorkflowExecution(
 	ctx thrift.Context,
-	startRequest *gen.StartWorkflowExecutionRequest) (*gen.StartWorkflowExecutionResponse, error) {
+	startRequest *gen.StartWorkflowExecutionRequest) (resp *gen.StartWorkflowExecutionResponse, retError error) {
 	wh.startWG.Wait()
 
-	wh.Service.GetLogger().Debugf("Received StartWorkflowExecution. WorkflowID: %v", startRequest.GetWorkflowId())
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+	tracing.TagExecution(ctx, startRequest.GetDomain(), startRequest.GetWorkflowId(), "")
+
+	defer func() {
+		// Rejected requests have no response
+		runID := ""
+		if resp != nil {
+			runID = resp.GetRunId()
+		}
+		wh.auditLogger.Log(audit.OperationStartWorkflowExecution, getCallerIdentity(ctx, startRequest.GetIdentity()),
+			startRequest.GetDomain(), startRequest.GetWorkflowId(), runID, retError)
+	}()
+
+	wh.getLogger(ctx).Debugf("Received StartWorkflowExecution. WorkflowID: %v", startRequest.GetWorkflowId())
 
 	if !startRequest.IsSetDomain() {
 		return nil, errDomainNotSet
@@ -521,21 +939,42 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, &gen.BadRequestError{Message: "A valid TaskStartToCloseTimeoutSeconds is not set on request."}
 	}
 
+	if startRequest.GetDelayStartSeconds() < 0 {
+		return nil, &gen.BadRequestError{Message: "DelayStartSeconds cannot be negative."}
+	}
+
+	if startRequest.GetDelayStartSeconds() > maxDelayStartSeconds {
+		return nil, &gen.BadRequestError{Message: "DelayStartSeconds cannot exceed a year."}
+	}
+
+	// The execution times out while it is delayed unless the delay is shorter than the execution timeout
+	if startRequest.GetDelayStartSeconds() >= startRequest.GetExecutionStartToCloseTimeoutSeconds() {
+		return nil, &gen.BadRequestError{Message: "DelayStartSeconds must be less than ExecutionStartToCloseTimeoutSeconds."}
+	}
+
 	domainName := startRequest.GetDomain()
-	wh.Service.GetLogger().Infof("Start workflow execution request domain: %v", domainName)
+	wh.getLogger(ctx).Infof("Start workflow execution request domain: %v", domainName)
 	info, _, err := wh.domainCache.GetDomain(domainName)
 	if err != nil {
 		return nil, wrapError(err)
 	}
 
-	wh.Service.GetLogger().Infof("Start workflow execution request domainID: %v", info.ID)
+	wh.getLogger(ctx).Infof("Start workflow execution request domainID: %v", info.ID)
+	if info.Status == persistence.DomainStatusDraining {
+		return nil, errors.NewDomainNotActiveError(domainName,
+			"Domain is draining, no new workflow executions can be started.")
+	}
+	wh.headerPropagator.injectStartHeader(ctx, startRequest)
+	if err := wh.payloads.offload(ctx, info.ID, &startRequest.Input, &startRequest.PayloadReference); err != nil {
+		return nil, wrapError(err)
+	}
 
-	resp, err := wh.history.StartWorkflowExecution(ctx, &h.StartWorkflowExecutionRequest{
+	resp, err = wh.history.StartWorkflowExecution(ctx, &h.StartWorkflowExecutionRequest{
 		DomainUUID:   common.StringPtr(info.ID),
 		StartRequest: startRequest,
 	})
 	if err != nil {
-		wh.Service.GetLogger().Errorf("StartWorkflowExecution failed. WorkflowID: %v. Error: %v", startRequest.GetWorkflowId(), err)
+		wh.getLogger(ctx).Errorf("StartWorkflowExecution failed. WorkflowID: %v. Error: %v", startRequest.GetWorkflowId(), err)
 	}
 	return resp, wrapError(err)
 }
@@ -546,6 +985,11 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 	getRequest *gen.GetWorkflowExecutionHistoryRequest) (*gen.GetWorkflowExecutionHistoryResponse, error) {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+	tagExecution(ctx, getRequest.GetDomain(), getRequest.Execution)
+
 	if !getRequest.IsSetDomain() {
 		return nil, errDomainNotSet
 	}
@@ -582,6 +1026,10 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		if err != nil {
 			return nil, errInvalidNextPageToken
 		}
+	} else if nextEventID, ok := wh.historyResponses.getNextEventID(info.ID, *getRequest.GetExecution()); ok {
+		// The execution is closed, so its next event ID does not change anymore
+		token.nextEventID = nextEventID
+		token.runID = getRequest.GetExecution().GetRunId()
 	} else {
 		response, err := wh.history.GetWorkflowExecutionNextEventID(ctx, &h.GetWorkflowExecutionNextEventIDRequest{
 			DomainUUID: common.StringPtr(info.ID),
@@ -598,8 +1046,14 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		WorkflowId: getRequest.GetExecution().WorkflowId,
 		RunId:      common.StringPtr(token.runID),
 	}
+	if cached := wh.historyResponses.get(info.ID, we, getRequest.GetMaximumPageSize(),
+		getRequest.GetNextPageToken()); cached != nil {
+		return cached, nil
+	}
+
 	history, persistenceToken, err :=
-		wh.getHistory(info.ID, we, token.nextEventID, getRequest.GetMaximumPageSize(), getRequest.GetNextPageToken())
+		wh.getHistory(ctx, info.ID, we, common.FirstEventID, token.nextEventID, getRequest.GetMaximumPageSize(),
+			getRequest.GetNextPageToken())
 	if err != nil {
 		return nil, wrapError(err)
 	}
@@ -609,93 +1063,413 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		return nil, wrapError(err)
 	}
 
-	return createGetWorkflowExecutionHistoryResponse(history, token.nextEventID, nextToken), nil
+	response := createGetWorkflowExecutionHistoryResponse(history, token.nextEventID, nextToken)
+	wh.historyResponses.put(info.ID, we, getRequest.GetMaximumPageSize(), getRequest.GetNextPageToken(),
+		token.nextEventID, response)
+	return response, nil
 }
 
 // SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
-// WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
+// WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.  It
+// returns the receipt of the signal, the original one when a signal with the same request ID was already recorded.
 func (wh *WorkflowHandler) SignalWorkflowExecution(ctx thrift.Context,
-	signalRequest *gen.SignalWorkflowExecutionRequest) error {
+	signalRequest *gen.SignalWorkflowExecutionRequest) (resp *gen.SignalWorkflowExecutionResponse, retError error) {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+	tagExecution(ctx, signalRequest.GetDomain(), signalRequest.WorkflowExecution)
+
+	defer func() {
+		wh.auditLogger.Log(audit.OperationSignalWorkflowExecution, getCallerIdentity(ctx, signalRequest.GetIdentity()),
+			signalRequest.GetDomain(), signalRequest.GetWorkflowExecution().GetWorkflowId(),
+			signalRequest.GetWorkflowExecution().GetRunId(), retError)
+	}()
+
 	if !signalRequest.IsSetDomain() {
-		return errDomainNotSet
+		return nil, errDomainNotSet
 	}
 
 	if !signalRequest.IsSetWorkflowExecution() {
-		return errExecutionNotSet
+		return nil, errExecutionNotSet
 	}
 
 	if !signalRequest.GetWorkflowExecution().IsSetWorkflowId() {
-		return errWorkflowIDNotSet
+		return nil, errWorkflowIDNotSet
 	}
 
 	if signalRequest.GetWorkflowExecution().IsSetRunId() &&
 		uuid.Parse(signalRequest.GetWorkflowExecution().GetRunId()) == nil {
-		return errInvalidRunID
+		return nil, errInvalidRunID
 	}
 
 	if !signalRequest.IsSetSignalName() {
-		return &gen.BadRequestError{Message: "SignalName is not set on request."}
+		return nil, &gen.BadRequestError{Message: "SignalName is not set on request."}
 	}
 
 	domainName := signalRequest.GetDomain()
 	info, _, err := wh.domainCache.GetDomain(domainName)
 	if err != nil {
-		return wrapError(err)
+		return nil, wrapError(err)
 	}
 
-	err = wh.history.SignalWorkflowExecution(ctx, &h.SignalWorkflowExecutionRequest{
+	if err := wh.payloads.offload(ctx, info.ID, &signalRequest.Input, &signalRequest.PayloadReference); err != nil {
+		return nil, wrapError(err)
+	}
+
+	resp, err = wh.history.SignalWorkflowExecution(ctx, &h.SignalWorkflowExecutionRequest{
 		DomainUUID:    common.StringPtr(info.ID),
 		SignalRequest: signalRequest,
 	})
+	if err != nil {
+		return nil, wrapError(err)
+	}
 
-	return wrapError(err)
+	return resp, nil
+}
+
+// GetSignalReceipt tells whether the signal sent with the request ID was recorded by the workflow execution, and
+// returns its receipt if it was.
+func (wh *WorkflowHandler) GetSignalReceipt(ctx thrift.Context,
+	receiptRequest *gen.GetSignalReceiptRequest) (*gen.GetSignalReceiptResponse, error) {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+	tagExecution(ctx, receiptRequest.GetDomain(), receiptRequest.WorkflowExecution)
+
+	if !receiptRequest.IsSetDomain() {
+		return nil, errDomainNotSet
+	}
+
+	if !receiptRequest.IsSetWorkflowExecution() {
+		return nil, errExecutionNotSet
+	}
+
+	if !receiptRequest.GetWorkflowExecution().IsSetWorkflowId() {
+		return nil, errWorkflowIDNotSet
+	}
+
+	if receiptRequest.GetWorkflowExecution().IsSetRunId() &&
+		uuid.Parse(receiptRequest.GetWorkflowExecution().GetRunId()) == nil {
+		return nil, errInvalidRunID
+	}
+
+	if receiptRequest.GetRequestId() == "" {
+		return nil, &gen.BadRequestError{Message: "RequestId is not set on request."}
+	}
+
+	domainName := receiptRequest.GetDomain()
+	info, _, err := wh.domainCache.GetDomain(domainName)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	resp, err := wh.history.GetSignalReceipt(ctx, &h.GetSignalReceiptRequest{
+		DomainUUID:     common.StringPtr(info.ID),
+		ReceiptRequest: receiptRequest,
+	})
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return resp, nil
+}
+
+// QueryWorkflow queries the current state of a running workflow execution.  The query is handed to the worker along
+// with the next decision task, or a query task which doesn't change the history, and the call blocks until the worker
+// answers it or the query times out.
+func (wh *WorkflowHandler) QueryWorkflow(ctx thrift.Context,
+	queryRequest *gen.QueryWorkflowRequest) (*gen.QueryWorkflowResponse, error) {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+	tagExecution(ctx, queryRequest.GetDomain(), queryRequest.Execution)
+
+	if !queryRequest.IsSetDomain() {
+		return nil, errDomainNotSet
+	}
+
+	if !queryRequest.IsSetExecution() {
+		return nil, errExecutionNotSet
+	}
+
+	if !queryRequest.GetExecution().IsSetWorkflowId() {
+		return nil, errWorkflowIDNotSet
+	}
+
+	if queryRequest.GetExecution().IsSetRunId() &&
+		uuid.Parse(queryRequest.GetExecution().GetRunId()) == nil {
+		return nil, errInvalidRunID
+	}
+
+	if !queryRequest.IsSetQuery() || !queryRequest.GetQuery().IsSetQueryType() {
+		return nil, &gen.BadRequestError{Message: "QueryType is not set on request."}
+	}
+
+	domainName := queryRequest.GetDomain()
+	info, _, err := wh.domainCache.GetDomain(domainName)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	resp, err := wh.history.QueryWorkflow(ctx, &h.QueryWorkflowRequest{
+		DomainUUID:   common.StringPtr(info.ID),
+		QueryRequest: queryRequest,
+	})
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return resp, nil
+}
+
+// DescribeWorkflowExecution returns information about the specified workflow execution, including the activities
+// still pending on it and the time by which they have to be completed.
+func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx thrift.Context,
+	request *gen.DescribeWorkflowExecutionRequest) (*gen.DescribeWorkflowExecutionResponse, error) {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+	tagExecution(ctx, request.GetDomain(), request.Execution)
+
+	if !request.IsSetDomain() {
+		return nil, errDomainNotSet
+	}
+
+	if !request.IsSetExecution() {
+		return nil, errExecutionNotSet
+	}
+
+	if !request.GetExecution().IsSetWorkflowId() {
+		return nil, errWorkflowIDNotSet
+	}
+
+	if request.GetExecution().IsSetRunId() && uuid.Parse(request.GetExecution().GetRunId()) == nil {
+		return nil, errInvalidRunID
+	}
+
+	domainName := request.GetDomain()
+	info, _, err := wh.domainCache.GetDomain(domainName)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	resp, err := wh.history.DescribeWorkflowExecution(ctx, &h.DescribeWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(info.ID),
+		Request:    request,
+	})
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return resp, nil
 }
 
-// TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
-// in the history and immediately terminating the execution instance.
+// TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminatedByOperator
+// event in the history and immediately terminating the execution instance.
 func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx thrift.Context,
-	terminateRequest *gen.TerminateWorkflowExecutionRequest) error {
+	terminateRequest *gen.TerminateWorkflowExecutionRequest) (resp *gen.TerminateWorkflowExecutionResponse,
+	retError error) {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+	tagExecution(ctx, terminateRequest.GetDomain(), terminateRequest.WorkflowExecution)
+
+	defer func() {
+		wh.auditLogger.Log(audit.OperationTerminateWorkflowExecution,
+			getCallerIdentity(ctx, terminateRequest.GetIdentity()), terminateRequest.GetDomain(),
+			terminateRequest.GetWorkflowExecution().GetWorkflowId(), terminateRequest.GetWorkflowExecution().GetRunId(),
+			retError)
+	}()
+
 	if !terminateRequest.IsSetDomain() {
-		return errDomainNotSet
+		return nil, errDomainNotSet
 	}
 
 	if !terminateRequest.IsSetWorkflowExecution() {
-		return errExecutionNotSet
+		return nil, errExecutionNotSet
 	}
 
 	if !terminateRequest.GetWorkflowExecution().IsSetWorkflowId() {
-		return errWorkflowIDNotSet
+		return nil, errWorkflowIDNotSet
 	}
 
 	if terminateRequest.GetWorkflowExecution().IsSetRunId() &&
 		uuid.Parse(terminateRequest.GetWorkflowExecution().GetRunId()) == nil {
-		return errInvalidRunID
+		return nil, errInvalidRunID
 	}
 
 	domainName := terminateRequest.GetDomain()
 	info, _, err := wh.domainCache.GetDomain(domainName)
 	if err != nil {
-		return wrapError(err)
+		return nil, wrapError(err)
 	}
 
-	err = wh.history.TerminateWorkflowExecution(ctx, &h.TerminateWorkflowExecutionRequest{
+	response, err := wh.history.TerminateWorkflowExecution(ctx, &h.TerminateWorkflowExecutionRequest{
 		DomainUUID:       common.StringPtr(info.ID),
 		TerminateRequest: terminateRequest,
 	})
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return response, nil
+}
+
+// DescribeCluster - returns the metadata of the cluster, SDKs and tools use it to negotiate capabilities
+func (wh *WorkflowHandler) DescribeCluster(ctx thrift.Context) (*gen.DescribeClusterResponse, error) {
+	return &gen.DescribeClusterResponse{
+		ServerVersion:           common.StringPtr(common.ServerVersion),
+		NumberOfHistoryShards:   common.Int32Ptr(int32(wh.GetNumberOfHistoryShards())),
+		SupportedClientFeatures: append([]string(nil), supportedClientFeatures...),
+		PersistenceStoreType:    common.StringPtr(persistence.StoreTypeCassandra),
+	}, nil
+}
+
+// RefreshWorkflowTasks - re-generates the transfer and timer tasks of a workflow execution
+func (wh *WorkflowHandler) RefreshWorkflowTasks(ctx thrift.Context,
+	refreshRequest *gen.RefreshWorkflowTasksRequest) (retError error) {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+	tagExecution(ctx, refreshRequest.GetDomain(), refreshRequest.WorkflowExecution)
+
+	defer func() {
+		wh.auditLogger.Log(audit.OperationRefreshWorkflowTasks,
+			getCallerIdentity(ctx, refreshRequest.GetIdentity()), refreshRequest.GetDomain(),
+			refreshRequest.GetWorkflowExecution().GetWorkflowId(), refreshRequest.GetWorkflowExecution().GetRunId(),
+			retError)
+	}()
+
+	if !refreshRequest.IsSetDomain() {
+		return errDomainNotSet
+	}
+
+	if !refreshRequest.IsSetWorkflowExecution() {
+		return errExecutionNotSet
+	}
+
+	if !refreshRequest.GetWorkflowExecution().IsSetWorkflowId() {
+		return errWorkflowIDNotSet
+	}
+
+	if refreshRequest.GetWorkflowExecution().IsSetRunId() &&
+		uuid.Parse(refreshRequest.GetWorkflowExecution().GetRunId()) == nil {
+		return errInvalidRunID
+	}
+
+	domainName := refreshRequest.GetDomain()
+	info, _, err := wh.domainCache.GetDomain(domainName)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	err = wh.history.RefreshWorkflowTasks(ctx, &h.RefreshWorkflowTasksRequest{
+		DomainUUID:     common.StringPtr(info.ID),
+		RefreshRequest: refreshRequest,
+	})
+	return wrapError(err)
+}
+
+// MigrateWorkflowExecution - moves a closed workflow execution to another domain
+func (wh *WorkflowHandler) MigrateWorkflowExecution(ctx thrift.Context,
+	migrateRequest *gen.MigrateWorkflowExecutionRequest) (retError error) {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+	tagExecution(ctx, migrateRequest.GetDomain(), migrateRequest.WorkflowExecution)
+
+	defer func() {
+		wh.auditLogger.Log(audit.OperationMigrateWorkflowExecution,
+			getCallerIdentity(ctx, migrateRequest.GetIdentity()), migrateRequest.GetDomain(),
+			migrateRequest.GetWorkflowExecution().GetWorkflowId(), migrateRequest.GetWorkflowExecution().GetRunId(),
+			retError)
+	}()
+
+	if !migrateRequest.IsSetDomain() || !migrateRequest.IsSetTargetDomain() {
+		return errDomainNotSet
+	}
+
+	if !migrateRequest.IsSetWorkflowExecution() {
+		return errExecutionNotSet
+	}
+
+	if !migrateRequest.GetWorkflowExecution().IsSetWorkflowId() {
+		return errWorkflowIDNotSet
+	}
+
+	// Closed executions are never the current run of their workflow, they can only be found by run ID
+	if !migrateRequest.GetWorkflowExecution().IsSetRunId() {
+		return errRunIDNotSet
+	}
+	if uuid.Parse(migrateRequest.GetWorkflowExecution().GetRunId()) == nil {
+		return errInvalidRunID
+	}
+
+	info, _, err := wh.domainCache.GetDomain(migrateRequest.GetDomain())
+	if err != nil {
+		return wrapError(err)
+	}
+	targetInfo, _, err := wh.domainCache.GetDomain(migrateRequest.GetTargetDomain())
+	if err != nil {
+		return wrapError(err)
+	}
 
+	err = wh.history.MigrateWorkflowExecution(ctx, &h.MigrateWorkflowExecutionRequest{
+		DomainUUID:       common.StringPtr(info.ID),
+		TargetDomainUUID: common.StringPtr(targetInfo.ID),
+		MigrateRequest:   migrateRequest,
+	})
 	return wrapError(err)
 }
 
+// VerifyHistory - runs structural validation over a serialized workflow history and reports every issue found
+func (wh *WorkflowHandler) VerifyHistory(ctx thrift.Context,
+	verifyRequest *gen.VerifyHistoryRequest) (*gen.VerifyHistoryResponse, error) {
+	wh.startWG.Wait()
+
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(verifyRequest.Batches) == 0 {
+		return nil, errHistoryNotSet
+	}
+
+	return wh.historyVerifier.verify(verifyRequest.Batches), nil
+}
+
 // RequestCancelWorkflowExecution - requests to cancel a workflow execution
 func (wh *WorkflowHandler) RequestCancelWorkflowExecution(
 	ctx thrift.Context,
-	cancelRequest *gen.RequestCancelWorkflowExecutionRequest) error {
+	cancelRequest *gen.RequestCancelWorkflowExecutionRequest) (retError error) {
 	wh.startWG.Wait()
 
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return err
+	}
+	tagExecution(ctx, cancelRequest.GetDomain(), cancelRequest.WorkflowExecution)
+
+	defer func() {
+		wh.auditLogger.Log(audit.OperationRequestCancelWorkflowExecution,
+			getCallerIdentity(ctx, cancelRequest.GetIdentity()), cancelRequest.GetDomain(),
+			cancelRequest.GetWorkflowExecution().GetWorkflowId(), cancelRequest.GetWorkflowExecution().GetRunId(),
+			retError)
+	}()
+
 	if !cancelRequest.IsSetDomain() {
 		return errDomainNotSet
 	}
@@ -733,6 +1507,9 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(
 // ListOpenWorkflowExecutions - retrieves info for open workflow executions in a domain
 func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx thrift.Context,
 	listRequest *gen.ListOpenWorkflowExecutionsRequest) (*gen.ListOpenWorkflowExecutionsResponse, error) {
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
 
 	if !listRequest.IsSetDomain() {
 		return nil, errDomainNotSet
@@ -766,6 +1543,12 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx thrift.Context,
 		listRequest.MaximumPageSize = common.Int32Ptr(defaultVisibilityMaxPageSize)
 	}
 
+	query := listOpenQuery(listRequest)
+	pageToken, err := wh.pageTokenSigner.verify(query, listRequest.GetNextPageToken())
+	if err != nil {
+		return nil, err
+	}
+
 	domainName := listRequest.GetDomain()
 	domainInfo, _, err := wh.domainCache.GetDomain(domainName)
 	if err != nil {
@@ -775,25 +1558,25 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx thrift.Context,
 	baseReq := persistence.ListWorkflowExecutionsRequest{
 		DomainUUID:        domainInfo.ID,
 		PageSize:          int(listRequest.GetMaximumPageSize()),
-		NextPageToken:     listRequest.GetNextPageToken(),
+		NextPageToken:     pageToken,
 		EarliestStartTime: listRequest.GetStartTimeFilter().GetEarliestTime(),
 		LatestStartTime:   listRequest.GetStartTimeFilter().GetLatestTime(),
 	}
 
 	var persistenceResp *persistence.ListWorkflowExecutionsResponse
 	if listRequest.IsSetExecutionFilter() {
-		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutionsByWorkflowID(
+		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutionsByWorkflowID(ctx,
 			&persistence.ListWorkflowExecutionsByWorkflowIDRequest{
 				ListWorkflowExecutionsRequest: baseReq,
 				WorkflowID:                    listRequest.ExecutionFilter.GetWorkflowId(),
 			})
 	} else if listRequest.IsSetTypeFilter() {
-		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutionsByType(&persistence.ListWorkflowExecutionsByTypeRequest{
+		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutionsByType(ctx, &persistence.ListWorkflowExecutionsByTypeRequest{
 			ListWorkflowExecutionsRequest: baseReq,
 			WorkflowTypeName:              listRequest.TypeFilter.GetName(),
 		})
 	} else {
-		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutions(&baseReq)
+		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutions(ctx, &baseReq)
 	}
 
 	if err != nil {
@@ -801,33 +1584,63 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx thrift.Context,
 	}
 
 	resp := gen.NewListOpenWorkflowExecutionsResponse()
-	resp.Executions = persistenceResp.Executions
-	resp.NextPageToken = persistenceResp.NextPageToken
+	resp.Executions = projectExecutionInfos(persistenceResp.Executions, listRequest.GetView())
+	resp.NextPageToken = wh.pageTokenSigner.sign(query, persistenceResp.NextPageToken)
 	return resp, nil
 }
 
 // ListClosedWorkflowExecutions - retrieves info for closed workflow executions in a domain
 func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx thrift.Context,
 	listRequest *gen.ListClosedWorkflowExecutionsRequest) (*gen.ListClosedWorkflowExecutionsResponse, error) {
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+
 	if !listRequest.IsSetDomain() {
 		return nil, errDomainNotSet
 	}
 
-	if !listRequest.IsSetStartTimeFilter() {
-		return nil, &gen.BadRequestError{
-			Message: "StartTimeFilter is required",
+	if listRequest.IsSetCloseTimeFilter() {
+		if listRequest.IsSetStartTimeFilter() {
+			return nil, &gen.BadRequestError{
+				Message: "Only one of StartTimeFilter or CloseTimeFilter is allowed",
+			}
 		}
-	}
 
-	if !listRequest.GetStartTimeFilter().IsSetEarliestTime() {
-		return nil, &gen.BadRequestError{
-			Message: "EarliestTime in StartTimeFilter is required",
+		if !listRequest.GetCloseTimeFilter().IsSetEarliestTime() {
+			return nil, &gen.BadRequestError{
+				Message: "EarliestTime in CloseTimeFilter is required",
+			}
 		}
-	}
 
-	if !listRequest.GetStartTimeFilter().IsSetLatestTime() {
-		return nil, &gen.BadRequestError{
-			Message: "LatestTime in StartTimeFilter is required",
+		if !listRequest.GetCloseTimeFilter().IsSetLatestTime() {
+			return nil, &gen.BadRequestError{
+				Message: "LatestTime in CloseTimeFilter is required",
+			}
+		}
+
+		if listRequest.IsSetExecutionFilter() || listRequest.IsSetTypeFilter() {
+			return nil, &gen.BadRequestError{
+				Message: "Only StatusFilter is allowed with CloseTimeFilter",
+			}
+		}
+	} else {
+		if !listRequest.IsSetStartTimeFilter() {
+			return nil, &gen.BadRequestError{
+				Message: "StartTimeFilter is required",
+			}
+		}
+
+		if !listRequest.GetStartTimeFilter().IsSetEarliestTime() {
+			return nil, &gen.BadRequestError{
+				Message: "EarliestTime in StartTimeFilter is required",
+			}
+		}
+
+		if !listRequest.GetStartTimeFilter().IsSetLatestTime() {
+			return nil, &gen.BadRequestError{
+				Message: "LatestTime in StartTimeFilter is required",
+			}
 		}
 	}
 
@@ -852,6 +1665,12 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx thrift.Context,
 		listRequest.MaximumPageSize = common.Int32Ptr(defaultVisibilityMaxPageSize)
 	}
 
+	query := listClosedQuery(listRequest)
+	pageToken, err := wh.pageTokenSigner.verify(query, listRequest.GetNextPageToken())
+	if err != nil {
+		return nil, err
+	}
+
 	domainName := listRequest.GetDomain()
 	domainInfo, _, err := wh.domainCache.GetDomain(domainName)
 	if err != nil {
@@ -861,30 +1680,40 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx thrift.Context,
 	baseReq := persistence.ListWorkflowExecutionsRequest{
 		DomainUUID:        domainInfo.ID,
 		PageSize:          int(listRequest.GetMaximumPageSize()),
-		NextPageToken:     listRequest.GetNextPageToken(),
+		NextPageToken:     pageToken,
 		EarliestStartTime: listRequest.GetStartTimeFilter().GetEarliestTime(),
 		LatestStartTime:   listRequest.GetStartTimeFilter().GetLatestTime(),
 	}
 
 	var persistenceResp *persistence.ListWorkflowExecutionsResponse
-	if listRequest.IsSetExecutionFilter() {
-		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByWorkflowID(
+	if listRequest.IsSetCloseTimeFilter() {
+		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByCloseTime(ctx,
+			&persistence.ListClosedWorkflowExecutionsByCloseTimeRequest{
+				DomainUUID:        domainInfo.ID,
+				EarliestCloseTime: listRequest.GetCloseTimeFilter().GetEarliestTime(),
+				LatestCloseTime:   listRequest.GetCloseTimeFilter().GetLatestTime(),
+				Status:            listRequest.StatusFilter,
+				PageSize:          int(listRequest.GetMaximumPageSize()),
+				NextPageToken:     pageToken,
+			})
+	} else if listRequest.IsSetExecutionFilter() {
+		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByWorkflowID(ctx,
 			&persistence.ListWorkflowExecutionsByWorkflowIDRequest{
 				ListWorkflowExecutionsRequest: baseReq,
 				WorkflowID:                    listRequest.ExecutionFilter.GetWorkflowId(),
 			})
 	} else if listRequest.IsSetTypeFilter() {
-		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByType(&persistence.ListWorkflowExecutionsByTypeRequest{
+		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByType(ctx, &persistence.ListWorkflowExecutionsByTypeRequest{
 			ListWorkflowExecutionsRequest: baseReq,
 			WorkflowTypeName:              listRequest.TypeFilter.GetName(),
 		})
 	} else if listRequest.IsSetStatusFilter() {
-		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByStatus(&persistence.ListClosedWorkflowExecutionsByStatusRequest{
+		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByStatus(ctx, &persistence.ListClosedWorkflowExecutionsByStatusRequest{
 			ListWorkflowExecutionsRequest: baseReq,
 			Status: listRequest.GetStatusFilter(),
 		})
 	} else {
-		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutions(&baseReq)
+		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutions(ctx, &baseReq)
 	}
 
 	if err != nil {
@@ -892,22 +1721,108 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx thrift.Context,
 	}
 
 	resp := gen.NewListClosedWorkflowExecutionsResponse()
-	resp.Executions = persistenceResp.Executions
-	resp.NextPageToken = persistenceResp.NextPageToken
+	resp.Executions = projectExecutionInfos(persistenceResp.Executions, listRequest.GetView())
+	resp.NextPageToken = wh.pageTokenSigner.sign(query, persistenceResp.NextPageToken)
+	return resp, nil
+}
+
+// ListClosedWorkflowExecutionsSince returns, in close time order, the executions closed since the position held by
+// the ClosedSinceToken
+func (wh *WorkflowHandler) ListClosedWorkflowExecutionsSince(ctx thrift.Context,
+	listRequest *gen.ListClosedWorkflowExecutionsSinceRequest) (*gen.ListClosedWorkflowExecutionsSinceResponse, error) {
+	if err := wh.versionChecker.checkClientVersion(ctx); err != nil {
+		return nil, err
+	}
+
+	if !listRequest.IsSetDomain() {
+		return nil, errDomainNotSet
+	}
+
+	if !listRequest.IsSetMaximumPageSize() || listRequest.GetMaximumPageSize() == 0 {
+		listRequest.MaximumPageSize = common.Int32Ptr(defaultVisibilityMaxPageSize)
+	}
+
+	token := &closedSinceToken{CloseTime: listRequest.GetEarliestCloseTime()}
+	if listRequest.IsSetClosedSinceToken() {
+		var err error
+		if token, err = deserializeClosedSinceToken(listRequest.GetClosedSinceToken()); err != nil {
+			return nil, &gen.BadRequestError{Message: "Invalid ClosedSinceToken."}
+		}
+	}
+
+	domainName := listRequest.GetDomain()
+	domainInfo, _, err := wh.domainCache.GetDomain(domainName)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	persistenceResp, err := wh.visibitiltyMgr.ListClosedWorkflowExecutionsSince(ctx,
+		&persistence.ListClosedWorkflowExecutionsSinceRequest{
+			DomainUUID:        domainInfo.ID,
+			EarliestCloseTime: token.CloseTime,
+			LatestCloseTime:   time.Now().Add(-closedSinceSettleDelay).UnixNano(),
+			ExcludedRunIDs:    token.RunIDs,
+			PageSize:          int(listRequest.GetMaximumPageSize()),
+		})
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	data, err := json.Marshal(advanceClosedSinceToken(token, persistenceResp.Executions))
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	resp := gen.NewListClosedWorkflowExecutionsSinceResponse()
+	resp.Executions = projectExecutionInfos(persistenceResp.Executions, listRequest.GetView())
+	resp.ClosedSinceToken = data
 	return resp, nil
 }
 
-func (wh *WorkflowHandler) getHistory(domainID string, execution gen.WorkflowExecution,
-	nextEventID int64, pageSize int32, nextPageToken []byte) (*gen.History, []byte, error) {
+// advanceClosedSinceToken moves the token past the executions, which are in close time order
+func advanceClosedSinceToken(token *closedSinceToken, executions []*gen.WorkflowExecutionInfo) *closedSinceToken {
+	for _, execution := range executions {
+		if execution.GetCloseTime() != token.CloseTime {
+			token = &closedSinceToken{CloseTime: execution.GetCloseTime()}
+		}
+		token.RunIDs = append(token.RunIDs, execution.Execution.GetRunId())
+	}
+	return token
+}
+
+func deserializeClosedSinceToken(data []byte) (*closedSinceToken, error) {
+	var token closedSinceToken
+	err := json.Unmarshal(data, &token)
+
+	return &token, err
+}
+
+// projectExecutionInfos strips the fields of the executions not requested by the view
+func projectExecutionInfos(executions []*gen.WorkflowExecutionInfo,
+	view gen.ExecutionInfoView) []*gen.WorkflowExecutionInfo {
+	if view != gen.ExecutionInfoView_MINIMAL {
+		return executions
+	}
+
+	result := make([]*gen.WorkflowExecutionInfo, 0, len(executions))
+	for _, execution := range executions {
+		result = append(result, &gen.WorkflowExecutionInfo{Execution: execution.Execution})
+	}
+	return result
+}
+
+func (wh *WorkflowHandler) getHistory(ctx thrift.Context, domainID string, execution gen.WorkflowExecution,
+	firstEventID, nextEventID int64, pageSize int32, nextPageToken []byte) (*gen.History, []byte, error) {
 
 	if nextPageToken == nil {
 		nextPageToken = []byte{}
 	}
 	historyEvents := []*gen.HistoryEvent{}
 
-	response, err := wh.historyMgr.GetWorkflowExecutionHistory(&persistence.GetWorkflowExecutionHistoryRequest{
+	response, err := wh.historyMgr.GetWorkflowExecutionHistory(ctx, &persistence.GetWorkflowExecutionHistoryRequest{
 		DomainID:      domainID,
 		Execution:     execution,
+		FirstEventID:  firstEventID,
 		NextEventID:   nextEventID,
 		PageSize:      int(pageSize),
 		NextPageToken: nextPageToken,
@@ -931,9 +1846,22 @@ func (wh *WorkflowHandler) getHistory(domainID string, execution gen.WorkflowExe
 
 	executionHistory := gen.NewHistory()
 	executionHistory.Events = historyEvents
+	if err := wh.payloads.resolveHistory(ctx, domainID, executionHistory); err != nil {
+		return nil, nil, err
+	}
 	return executionHistory, nextPageToken, nil
 }
 
+// getDecisionHistoryFirstEventID returns the first event of the history returned with a decision task. Pollers asking
+// for partial history have the workflow state up to the previous decision cached, so they only get the events since
+// the previous decision started.
+func getDecisionHistoryFirstEventID(partialHistory bool, previousStartedEventID int64) int64 {
+	if partialHistory && previousStartedEventID > common.FirstEventID {
+		return previousStartedEventID + 1
+	}
+	return common.FirstEventID
+}
+
 // sets the version and encoding types to defaults if they
 // are missing from persistence. This is purely for backwards
 // compatibility
@@ -946,8 +1874,33 @@ func setSerializedHistoryDefaults(history *persistence.SerializedHistoryEventBat
 	}
 }
 
-func (wh *WorkflowHandler) getLoggerForTask(taskToken []byte) bark.Logger {
-	logger := wh.Service.GetLogger()
+// getCallerIdentity returns the identity set on the request, falling back to the name of the calling service
+// tagExecution tags the span of the call with the workflow execution it is made for
+func tagExecution(ctx thrift.Context, domain string, execution *gen.WorkflowExecution) {
+	if execution == nil {
+		tracing.TagExecution(ctx, domain, "", "")
+		return
+	}
+	tracing.TagExecution(ctx, domain, execution.GetWorkflowId(), execution.GetRunId())
+}
+
+func getCallerIdentity(ctx thrift.Context, identity string) string {
+	if identity != "" {
+		return identity
+	}
+	if call := tchannel.CurrentCall(ctx); call != nil {
+		return call.CallerName()
+	}
+	return ""
+}
+
+// getLogger returns the service logger tagged with the id of the request served by ctx
+func (wh *WorkflowHandler) getLogger(ctx thrift.Context) bark.Logger {
+	return tracing.WithRequestID(wh.Service.GetLogger(), ctx)
+}
+
+func (wh *WorkflowHandler) getLoggerForTask(ctx thrift.Context, taskToken []byte) bark.Logger {
+	logger := wh.getLogger(ctx)
 	task, err := wh.tokenSerializer.Deserialize(taskToken)
 	if err == nil {
 		logger = logger.WithFields(bark.Fields{
@@ -960,27 +1913,7 @@ func (wh *WorkflowHandler) getLoggerForTask(taskToken []byte) bark.Logger {
 }
 
 func wrapError(err error) error {
-	if err != nil && shouldWrapInInternalServiceError(err) {
-		return &gen.InternalServiceError{Message: err.Error()}
-	}
-	return err
-}
-
-func shouldWrapInInternalServiceError(err error) bool {
-	switch err.(type) {
-	case *gen.InternalServiceError:
-		return false
-	case *gen.BadRequestError:
-		return false
-	case *gen.EntityNotExistsError:
-		return false
-	case *gen.WorkflowExecutionAlreadyStartedError:
-		return false
-	case *gen.DomainAlreadyExistsError:
-		return false
-	}
-
-	return true
+	return errors.ToThrift(err)
 }
 
 func getDomainStatus(info *persistence.DomainInfo) *gen.DomainStatus {
@@ -991,6 +1924,8 @@ func getDomainStatus(info *persistence.DomainInfo) *gen.DomainStatus {
 		return gen.DomainStatusPtr(gen.DomainStatus_DEPRECATED)
 	case persistence.DomainStatusDeleted:
 		return gen.DomainStatusPtr(gen.DomainStatus_DELETED)
+	case persistence.DomainStatusDraining:
+		return gen.DomainStatusPtr(gen.DomainStatus_DRAINING)
 	}
 
 	return nil
@@ -1008,10 +1943,45 @@ func createDomainResponse(info *persistence.DomainInfo, config *persistence.Doma
 	c := gen.NewDomainConfiguration()
 	c.EmitMetric = common.BoolPtr(config.EmitMetric)
 	c.WorkflowExecutionRetentionPeriodInDays = common.Int32Ptr(config.Retention)
+	c.BadBinaryChecksums = config.BadBinaries
+	c.MaxDecisionAttempts = common.Int32Ptr(config.MaxDecisionAttempts)
+	c.MaxOpenExecutions = common.Int32Ptr(config.MaxOpenExecutions)
+	c.ActivityTimeoutDefaults = createActivityTimeoutPolicy(config.ActivityTimeoutDefaults)
+	c.ActivityTimeoutCaps = createActivityTimeoutPolicy(config.ActivityTimeoutCaps)
 
 	return i, c
 }
 
+func createActivityTimeoutPolicy(timeouts persistence.ActivityTimeouts) *gen.ActivityTimeoutPolicy {
+	p := gen.NewActivityTimeoutPolicy()
+	p.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(timeouts.ScheduleToClose)
+	p.ScheduleToStartTimeoutSeconds = common.Int32Ptr(timeouts.ScheduleToStart)
+	p.StartToCloseTimeoutSeconds = common.Int32Ptr(timeouts.StartToClose)
+	p.HeartbeatTimeoutSeconds = common.Int32Ptr(timeouts.Heartbeat)
+	return p
+}
+
+// updateActivityTimeouts overwrites the timeouts which are set on the policy, leaving the others untouched
+func updateActivityTimeouts(timeouts *persistence.ActivityTimeouts, policy *gen.ActivityTimeoutPolicy) error {
+	if policy.GetScheduleToCloseTimeoutSeconds() < 0 || policy.GetScheduleToStartTimeoutSeconds() < 0 ||
+		policy.GetStartToCloseTimeoutSeconds() < 0 || policy.GetHeartbeatTimeoutSeconds() < 0 {
+		return errInvalidActivityTimeoutPolicy
+	}
+	if policy.IsSetScheduleToCloseTimeoutSeconds() {
+		timeouts.ScheduleToClose = policy.GetScheduleToCloseTimeoutSeconds()
+	}
+	if policy.IsSetScheduleToStartTimeoutSeconds() {
+		timeouts.ScheduleToStart = policy.GetScheduleToStartTimeoutSeconds()
+	}
+	if policy.IsSetStartToCloseTimeoutSeconds() {
+		timeouts.StartToClose = policy.GetStartToCloseTimeoutSeconds()
+	}
+	if policy.IsSetHeartbeatTimeoutSeconds() {
+		timeouts.Heartbeat = policy.GetHeartbeatTimeoutSeconds()
+	}
+	return nil
+}
+
 func createPollForDecisionTaskResponse(
 	matchingResponse *m.PollForDecisionTaskResponse, history *gen.History, nextPageToken []byte) *gen.PollForDecisionTaskResponse {
 	resp := gen.NewPollForDecisionTaskResponse()
@@ -1021,6 +1991,7 @@ func createPollForDecisionTaskResponse(
 		resp.WorkflowType = matchingResponse.WorkflowType
 		resp.PreviousStartedEventId = matchingResponse.PreviousStartedEventId
 		resp.StartedEventId = matchingResponse.StartedEventId
+		resp.Queries = matchingResponse.Queries
 	}
 	resp.History = history
 	resp.NextPageToken = nextPageToken
@@ -22,9 +22,23 @@ package frontend
 
 import (
 	"testing"
+	"time"
 
+	log "github.com/Sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/tchannel-go/thrift"
+	"golang.org/x/net/context"
 )
 
 type HandlerTestSuite struct {
@@ -45,3 +59,139 @@ func (s *HandlerTestSuite) TestHealthEndpoint() {
 	assert.NoError(s.T(), err, "Health check shouldn't return error")
 	assert.True(s.T(), healthy, "Health check needs to work")
 }
+
+func (s *HandlerTestSuite) TestProjectExecutionInfos() {
+	executions := []*gen.WorkflowExecutionInfo{
+		{
+			Execution: &gen.WorkflowExecution{
+				WorkflowId: common.StringPtr("wId"),
+				RunId:      common.StringPtr("rId"),
+			},
+			Type:      &gen.WorkflowType{Name: common.StringPtr("wType")},
+			StartTime: common.Int64Ptr(1),
+			CloseTime: common.Int64Ptr(2),
+		},
+	}
+
+	s.Equal(executions, projectExecutionInfos(executions, gen.ExecutionInfoView_FULL))
+
+	minimal := projectExecutionInfos(executions, gen.ExecutionInfoView_MINIMAL)
+	s.Equal(1, len(minimal))
+	s.Equal(executions[0].Execution, minimal[0].Execution)
+	s.Nil(minimal[0].Type)
+	s.Nil(minimal[0].StartTime)
+	s.Nil(minimal[0].CloseTime)
+}
+
+func (s *HandlerTestSuite) TestAdvanceClosedSinceToken() {
+	newExecution := func(runID string, closeTime int64) *gen.WorkflowExecutionInfo {
+		return &gen.WorkflowExecutionInfo{
+			Execution: &gen.WorkflowExecution{WorkflowId: common.StringPtr("wId"), RunId: common.StringPtr(runID)},
+			CloseTime: common.Int64Ptr(closeTime),
+		}
+	}
+
+	token := &closedSinceToken{CloseTime: 1, RunIDs: []string{"r0"}}
+	s.Equal(token, advanceClosedSinceToken(token, nil))
+
+	token = advanceClosedSinceToken(token, []*gen.WorkflowExecutionInfo{newExecution("r1", 1)})
+	s.Equal(&closedSinceToken{CloseTime: 1, RunIDs: []string{"r0", "r1"}}, token)
+
+	token = advanceClosedSinceToken(token, []*gen.WorkflowExecutionInfo{newExecution("r2", 2), newExecution("r3", 2)})
+	s.Equal(&closedSinceToken{CloseTime: 2, RunIDs: []string{"r2", "r3"}}, token)
+}
+
+func (s *HandlerTestSuite) TestGetDecisionHistoryFirstEventID() {
+	s.Equal(common.FirstEventID, getDecisionHistoryFirstEventID(false, 10))
+	s.Equal(common.FirstEventID, getDecisionHistoryFirstEventID(true, common.EmptyEventID))
+	s.Equal(int64(11), getDecisionHistoryFirstEventID(true, 10))
+}
+
+func (s *HandlerTestSuite) TestDescribeCluster() {
+	s.Handler.Service = service.New(&service.BootstrapParams{
+		Name:             common.FrontendServiceName,
+		Logger:           bark.NewLoggerFromLogrus(log.New()),
+		MetricScope:      tally.NewTestScope(common.FrontendServiceName, nil),
+		NumHistoryShards: 4,
+	})
+
+	resp, err := s.Handler.DescribeCluster(nil)
+	s.NoError(err)
+	s.Equal(common.ServerVersion, resp.GetServerVersion())
+	s.Equal(int32(4), resp.GetNumberOfHistoryShards())
+	s.Equal(persistence.StoreTypeCassandra, resp.GetPersistenceStoreType())
+	s.Contains(resp.GetSupportedClientFeatures(), ClientFeatureQueryWorkflow)
+	s.Equal(len(supportedClientFeatures), len(resp.GetSupportedClientFeatures()))
+}
+
+func (s *HandlerTestSuite) TestDescribeDomainAfterUpdate() {
+	logger := bark.NewLoggerFromLogrus(log.New())
+	info := persistence.DomainInfo{ID: "domain-id", Name: "domain", Status: persistence.DomainStatusRegistered}
+	config := persistence.DomainConfig{Retention: 1}
+	metadataMgr := &mocks.MetadataManager{}
+	metadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, request *persistence.GetDomainRequest) *persistence.GetDomainResponse {
+			storedInfo, storedConfig := info, config
+			return &persistence.GetDomainResponse{Info: &storedInfo, Config: &storedConfig}
+		}, nil)
+	metadataMgr.On("UpdateDomain", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		request := args.Get(1).(*persistence.UpdateDomainRequest)
+		info, config = *request.Info, *request.Config
+	})
+	handler := &WorkflowHandler{
+		metadataMgr: metadataMgr,
+		domainCache: cache.NewDomainCache(metadataMgr, logger),
+		auditLogger: audit.NewLogger(audit.NewNoopSink(), logger),
+	}
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+	describe := func() *gen.DescribeDomainResponse {
+		resp, err := handler.DescribeDomain(ctx, &gen.DescribeDomainRequest{Name: common.StringPtr("domain")})
+		s.NoError(err)
+		return resp
+	}
+	s.Equal(int32(1), describe().Configuration.GetWorkflowExecutionRetentionPeriodInDays())
+
+	_, err := handler.UpdateDomain(ctx, &gen.UpdateDomainRequest{
+		Name: common.StringPtr("domain"),
+		Configuration: &gen.DomainConfiguration{
+			WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(2),
+		},
+	})
+	s.NoError(err)
+	s.Equal(int32(2), describe().Configuration.GetWorkflowExecutionRetentionPeriodInDays())
+
+	s.NoError(handler.DrainDomain(ctx, &gen.DrainDomainRequest{Name: common.StringPtr("domain")}))
+	s.Equal(gen.DomainStatus_DRAINING, describe().DomainInfo.GetStatus())
+
+	s.NoError(handler.DeprecateDomain(ctx, &gen.DeprecateDomainRequest{Name: common.StringPtr("domain")}))
+	s.Equal(gen.DomainStatus_DEPRECATED, describe().DomainInfo.GetStatus())
+}
+
+func (s *HandlerTestSuite) TestStartWorkflowExecutionValidatesDelayStart() {
+	logger := bark.NewLoggerFromLogrus(log.New())
+	s.Handler.Service = service.New(&service.BootstrapParams{
+		Name:        common.FrontendServiceName,
+		Logger:      logger,
+		MetricScope: tally.NewTestScope(common.FrontendServiceName, nil),
+	})
+	s.Handler.auditLogger = audit.NewLogger(audit.NewNoopSink(), logger)
+
+	start := func(delaySeconds, timeoutSeconds int32) error {
+		ctx, cancel := thrift.NewContext(time.Minute)
+		defer cancel()
+		_, err := s.Handler.StartWorkflowExecution(ctx, &gen.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr("domain"),
+			WorkflowId:                          common.StringPtr("wid"),
+			WorkflowType:                        &gen.WorkflowType{Name: common.StringPtr("wType")},
+			TaskList:                            &gen.TaskList{Name: common.StringPtr("taskList")},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(timeoutSeconds),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(10),
+			DelayStartSeconds:                   common.Int32Ptr(delaySeconds),
+		})
+		return err
+	}
+	s.IsType(&gen.BadRequestError{}, start(-1, 100))
+	s.IsType(&gen.BadRequestError{}, start(100, 100))
+	s.IsType(&gen.BadRequestError{}, start(maxDelayStartSeconds+1, maxDelayStartSeconds+100))
+}
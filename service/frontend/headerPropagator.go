@@ -0,0 +1,98 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+type (
+	// headerPropagator carries the request headers configured for propagation, such as trace and tenant IDs, from
+	// the starter of a workflow to its workers.  They are copied into the header of the start request, which is
+	// recorded in the started event, and handed back as response headers to the workers polling the tasks of the
+	// workflow.  Workers also find them in the started event, and in the scheduled event of activities scheduled
+	// with the header.
+	headerPropagator struct {
+		headers []string
+	}
+)
+
+func newHeaderPropagator(headers []string) *headerPropagator {
+	return &headerPropagator{headers: headers}
+}
+
+// injectStartHeader adds the propagated request headers to the header of the start request, the fields already set
+// by the starter are kept
+func (p *headerPropagator) injectStartHeader(ctx thrift.Context, request *gen.StartWorkflowExecutionRequest) {
+	if p == nil || ctx == nil {
+		return
+	}
+	requestHeaders := ctx.Headers()
+	for _, name := range p.headers {
+		value, ok := requestHeaders[name]
+		if !ok {
+			continue
+		}
+		if request.Header == nil {
+			request.Header = &gen.Header{}
+		}
+		if request.Header.Fields == nil {
+			request.Header.Fields = make(map[string][]byte)
+		}
+		if _, ok := request.Header.Fields[name]; !ok {
+			request.Header.Fields[name] = []byte(value)
+		}
+	}
+}
+
+// setResponseHeaders sets the propagated fields of the header as response headers of the call
+func (p *headerPropagator) setResponseHeaders(ctx thrift.Context, header *gen.Header) {
+	if p == nil || ctx == nil || header == nil {
+		return
+	}
+	var responseHeaders map[string]string
+	for _, name := range p.headers {
+		value, ok := header.Fields[name]
+		if !ok {
+			continue
+		}
+		if responseHeaders == nil {
+			responseHeaders = make(map[string]string)
+			for k, v := range ctx.ResponseHeaders() {
+				responseHeaders[k] = v
+			}
+		}
+		responseHeaders[name] = string(value)
+	}
+	if responseHeaders != nil {
+		ctx.SetResponseHeaders(responseHeaders)
+	}
+}
+
+// startedEventHeader returns the header recorded in the started event when the history begins with it
+func startedEventHeader(history *gen.History) *gen.Header {
+	if history == nil || len(history.Events) == 0 ||
+		!history.Events[0].IsSetWorkflowExecutionStartedEventAttributes() {
+		return nil
+	}
+	return history.Events[0].GetWorkflowExecutionStartedEventAttributes().GetHeader()
+}
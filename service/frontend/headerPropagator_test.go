@@ -0,0 +1,79 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+type headerPropagatorSuite struct {
+	suite.Suite
+	propagator *headerPropagator
+}
+
+func TestHeaderPropagatorSuite(t *testing.T) {
+	suite.Run(t, new(headerPropagatorSuite))
+}
+
+func (s *headerPropagatorSuite) SetupTest() {
+	s.propagator = newHeaderPropagator([]string{"trace-id", "tenant-id"})
+}
+
+func (s *headerPropagatorSuite) TestInjectStartHeader() {
+	ctx := thrift.WithHeaders(context.Background(), map[string]string{
+		"trace-id":  "trace",
+		"tenant-id": "tenant",
+		"other":     "other",
+	})
+	request := &gen.StartWorkflowExecutionRequest{
+		Header: &gen.Header{Fields: map[string][]byte{"tenant-id": []byte("starter-tenant")}},
+	}
+	s.propagator.injectStartHeader(ctx, request)
+	s.Equal(map[string][]byte{
+		"trace-id":  []byte("trace"),
+		"tenant-id": []byte("starter-tenant"),
+	}, request.Header.Fields)
+
+	request = &gen.StartWorkflowExecutionRequest{}
+	s.propagator.injectStartHeader(thrift.WithHeaders(context.Background(), nil), request)
+	s.Nil(request.Header)
+}
+
+func (s *headerPropagatorSuite) TestSetResponseHeaders() {
+	ctx := thrift.WithHeaders(context.Background(), nil)
+	header := &gen.Header{Fields: map[string][]byte{"trace-id": []byte("trace"), "other": []byte("other")}}
+	s.propagator.setResponseHeaders(ctx, header)
+	s.Equal(map[string]string{"trace-id": "trace"}, ctx.ResponseHeaders())
+
+	history := &gen.History{Events: []*gen.HistoryEvent{{
+		EventType:                               gen.EventTypePtr(gen.EventType_WorkflowExecutionStarted),
+		WorkflowExecutionStartedEventAttributes: &gen.WorkflowExecutionStartedEventAttributes{Header: header},
+	}}}
+	s.Equal(header, startedEventHeader(history))
+	history.Events[0] = &gen.HistoryEvent{EventId: common.Int64Ptr(5)}
+	s.Nil(startedEventHeader(history))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"fmt"
+	"time"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+)
+
+const (
+	historyResponseCacheInitialSize = 256
+	historyResponseCacheMaxSize     = 4 * 1024
+	historyResponseCacheTTL         = time.Minute
+)
+
+type (
+	// historyResponseCache keeps the responses of GetWorkflowExecutionHistory for closed workflow executions for a
+	// short time, to avoid reading the same history from persistence over and over again when tools like dashboards
+	// keep on polling it.  The history of a closed execution never changes, so it is cached once the last page of
+	// its history shows the execution has closed.
+	historyResponseCache struct {
+		responses  cache.Cache // pages of history of closed executions, by request
+		closedRuns cache.Cache // next event ID of closed executions
+	}
+)
+
+func newHistoryResponseCache() *historyResponseCache {
+	opts := &cache.Options{}
+	opts.InitialCapacity = historyResponseCacheInitialSize
+	opts.TTL = historyResponseCacheTTL
+
+	return &historyResponseCache{
+		responses:  cache.New(historyResponseCacheMaxSize, opts),
+		closedRuns: cache.New(historyResponseCacheMaxSize, opts),
+	}
+}
+
+// getNextEventID returns the next event ID of the execution if it is known to be closed
+func (c *historyResponseCache) getNextEventID(domainID string, execution gen.WorkflowExecution) (int64, bool) {
+	nextEventID, ok := c.closedRuns.Get(getRunKey(domainID, execution)).(int64)
+	return nextEventID, ok
+}
+
+// get returns the cached response for a request of the history of a closed execution
+func (c *historyResponseCache) get(domainID string, execution gen.WorkflowExecution, pageSize int32,
+	nextPageToken []byte) *gen.GetWorkflowExecutionHistoryResponse {
+	key := getResponseKey(domainID, execution, pageSize, nextPageToken)
+	response, _ := c.responses.Get(key).(*gen.GetWorkflowExecutionHistoryResponse)
+	return response
+}
+
+// put caches the response for a request of the history of an execution, if the execution is closed
+func (c *historyResponseCache) put(domainID string, execution gen.WorkflowExecution, pageSize int32,
+	nextPageToken []byte, nextEventID int64, response *gen.GetWorkflowExecutionHistoryResponse) {
+	runKey := getRunKey(domainID, execution)
+	if c.closedRuns.Get(runKey) == nil {
+		events := response.GetHistory().GetEvents()
+		if len(response.NextPageToken) > 0 || len(events) == 0 ||
+			!common.IsWorkflowCloseEvent(events[len(events)-1].GetEventType()) {
+			return
+		}
+		c.closedRuns.Put(runKey, nextEventID)
+	}
+	c.responses.Put(getResponseKey(domainID, execution, pageSize, nextPageToken), response)
+}
+
+func getRunKey(domainID string, execution gen.WorkflowExecution) string {
+	return fmt.Sprintf("%v/%v/%v", domainID, execution.GetWorkflowId(), execution.GetRunId())
+}
+
+func getResponseKey(domainID string, execution gen.WorkflowExecution, pageSize int32, nextPageToken []byte) string {
+	return fmt.Sprintf("%v/%v/%v/%v/%x", domainID, execution.GetWorkflowId(), execution.GetRunId(), pageSize,
+		nextPageToken)
+}
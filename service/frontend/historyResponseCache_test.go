@@ -0,0 +1,91 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type historyResponseCacheSuite struct {
+	suite.Suite
+	cache     *historyResponseCache
+	domainID  string
+	execution gen.WorkflowExecution
+}
+
+func TestHistoryResponseCacheSuite(t *testing.T) {
+	suite.Run(t, new(historyResponseCacheSuite))
+}
+
+func (s *historyResponseCacheSuite) SetupTest() {
+	s.cache = newHistoryResponseCache()
+	s.domainID = "deadbeef-0123-4567-890a-bcdef0123456"
+	s.execution = gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("history-response-cache-test"),
+		RunId:      common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6"),
+	}
+}
+
+func (s *historyResponseCacheSuite) TestOpenExecutionNotCached() {
+	response := s.createResponse(nil, gen.EventType_WorkflowExecutionStarted, gen.EventType_DecisionTaskScheduled)
+	s.cache.put(s.domainID, s.execution, 100, nil, 3, response)
+
+	_, ok := s.cache.getNextEventID(s.domainID, s.execution)
+	s.False(ok)
+	s.Nil(s.cache.get(s.domainID, s.execution, 100, nil))
+}
+
+func (s *historyResponseCacheSuite) TestClosedExecutionCached() {
+	firstPage := s.createResponse([]byte("next"), gen.EventType_WorkflowExecutionStarted)
+	lastPage := s.createResponse(nil, gen.EventType_DecisionTaskScheduled, gen.EventType_WorkflowExecutionCompleted)
+
+	// the first page is not known to belong to a closed execution yet
+	s.cache.put(s.domainID, s.execution, 1, nil, 4, firstPage)
+	s.Nil(s.cache.get(s.domainID, s.execution, 1, nil))
+
+	s.cache.put(s.domainID, s.execution, 1, []byte("next"), 4, lastPage)
+	nextEventID, ok := s.cache.getNextEventID(s.domainID, s.execution)
+	s.True(ok)
+	s.Equal(int64(4), nextEventID)
+	s.Equal(lastPage, s.cache.get(s.domainID, s.execution, 1, []byte("next")))
+
+	// from now on all pages are cached
+	s.cache.put(s.domainID, s.execution, 1, nil, 4, firstPage)
+	s.Equal(firstPage, s.cache.get(s.domainID, s.execution, 1, nil))
+	s.Nil(s.cache.get(s.domainID, s.execution, 2, nil))
+}
+
+func (s *historyResponseCacheSuite) createResponse(nextPageToken []byte,
+	eventTypes ...gen.EventType) *gen.GetWorkflowExecutionHistoryResponse {
+	history := gen.NewHistory()
+	for i, eventType := range eventTypes {
+		history.Events = append(history.Events, &gen.HistoryEvent{
+			EventId:   common.Int64Ptr(int64(i + 1)),
+			EventType: common.EventTypePtr(eventType),
+		})
+	}
+	return createGetWorkflowExecutionHistoryResponse(history, int64(len(eventTypes)+1), nextPageToken)
+}
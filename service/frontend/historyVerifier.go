@@ -0,0 +1,428 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"fmt"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// historyVerifier runs structural validation over serialized workflow histories.  Histories corrupted by a bug or
+	// by a manual repair otherwise only surface as panics of the history engine while it replays them.
+	historyVerifier struct {
+		serializerFactory persistence.HistorySerializerFactory
+	}
+
+	// historyVerification is the state of a single verification: the events seen so far and the pairing of the
+	// initiated events with the events that started and closed them
+	historyVerification struct {
+		issues        []*gen.HistoryVerificationIssue
+		eventCount    int64
+		nextEventID   int64
+		lastTimestamp int64
+		closeEventID  int64
+		eventTypes    map[int64]gen.EventType
+		starts        map[int64]int64
+		closes        map[int64]int64
+	}
+)
+
+func newHistoryVerifier(serializerFactory persistence.HistorySerializerFactory) *historyVerifier {
+	return &historyVerifier{serializerFactory: serializerFactory}
+}
+
+// verify decodes the batches of the history and validates their events, every issue found is reported
+func (v *historyVerifier) verify(batches []*gen.SerializedHistoryBatch) *gen.VerifyHistoryResponse {
+	s := &historyVerification{
+		nextEventID: common.FirstEventID,
+		eventTypes:  make(map[int64]gen.EventType),
+		starts:      make(map[int64]int64),
+		closes:      make(map[int64]int64),
+	}
+
+	lastVersion := int32(0)
+	for i, batch := range batches {
+		if batch == nil {
+			s.report(0, "batch %v is empty", i)
+			continue
+		}
+		if batch.GetVersion() < lastVersion {
+			s.report(0, "batch %v has version %v, lower than the version %v of the previous batch",
+				i, batch.GetVersion(), lastVersion)
+		} else {
+			lastVersion = batch.GetVersion()
+		}
+
+		events, err := v.decode(batch)
+		if err != nil {
+			s.report(0, "batch %v cannot be decoded: %v", i, err)
+			continue
+		}
+		for _, event := range events {
+			s.verifyEvent(event)
+		}
+	}
+
+	if s.eventCount == 0 {
+		s.report(0, "history has no events")
+	}
+
+	return &gen.VerifyHistoryResponse{
+		Valid:      common.BoolPtr(len(s.issues) == 0),
+		EventCount: common.Int64Ptr(s.eventCount),
+		Issues:     s.issues,
+	}
+}
+
+func (v *historyVerifier) decode(batch *gen.SerializedHistoryBatch) ([]*gen.HistoryEvent, error) {
+	encodingType := persistence.DefaultEncodingType
+	if batch.IsSetEncodingType() {
+		encodingType = common.EncodingType(batch.GetEncodingType())
+	}
+	serializer, err := v.serializerFactory.Get(encodingType)
+	if err != nil {
+		return nil, err
+	}
+	history, err := serializer.Deserialize(
+		persistence.NewSerializedHistoryEventBatch(batch.Data, encodingType, int(batch.GetVersion())))
+	if err != nil {
+		return nil, err
+	}
+	return history.Events, nil
+}
+
+func (s *historyVerification) report(eventID int64, format string, args ...interface{}) {
+	issue := &gen.HistoryVerificationIssue{Message: common.StringPtr(fmt.Sprintf(format, args...))}
+	if eventID != 0 {
+		issue.EventId = common.Int64Ptr(eventID)
+	}
+	s.issues = append(s.issues, issue)
+}
+
+func (s *historyVerification) verifyEvent(event *gen.HistoryEvent) {
+	if event == nil {
+		s.report(0, "event %v is empty", s.nextEventID)
+		s.nextEventID++
+		return
+	}
+	s.eventCount++
+
+	eventID := event.GetEventId()
+	if eventID != s.nextEventID {
+		s.report(eventID, "event id %v is out of sequence, expected %v", eventID, s.nextEventID)
+	}
+	s.nextEventID = eventID + 1
+
+	if event.GetTimestamp() < s.lastTimestamp {
+		s.report(eventID, "timestamp %v is earlier than the timestamp %v of the previous event",
+			event.GetTimestamp(), s.lastTimestamp)
+	} else {
+		s.lastTimestamp = event.GetTimestamp()
+	}
+
+	if !event.IsSetEventType() {
+		s.report(eventID, "event type is not set")
+		return
+	}
+	eventType := event.GetEventType()
+	if s.eventCount == 1 && eventType != gen.EventType_WorkflowExecutionStarted {
+		s.report(eventID, "history starts with %v instead of %v", eventType, gen.EventType_WorkflowExecutionStarted)
+	}
+	if s.closeEventID != 0 {
+		s.report(eventID, "%v is recorded after the workflow execution was closed by event %v",
+			eventType, s.closeEventID)
+	}
+	if _, ok := s.eventTypes[eventID]; ok {
+		s.report(eventID, "event id %v is used by more than one event", eventID)
+	} else {
+		s.eventTypes[eventID] = eventType
+	}
+
+	s.verifyAttributes(event)
+}
+
+// verifyAttributes checks that the attributes of the event are set, and that the events they reference pair with it
+func (s *historyVerification) verifyAttributes(event *gen.HistoryEvent) {
+	eventID := event.GetEventId()
+	eventType := event.GetEventType()
+	missing := func() {
+		s.report(eventID, "attributes of %v are not set", eventType)
+	}
+
+	switch eventType {
+	case gen.EventType_WorkflowExecutionStarted:
+		if event.WorkflowExecutionStartedEventAttributes == nil {
+			missing()
+			break
+		}
+		if s.eventCount != 1 {
+			s.report(eventID, "%v is not the first event of the history", eventType)
+		}
+
+	case gen.EventType_WorkflowExecutionCompleted,
+		gen.EventType_WorkflowExecutionFailed,
+		gen.EventType_WorkflowExecutionTimedOut,
+		gen.EventType_WorkflowExecutionCanceled,
+		gen.EventType_WorkflowExecutionTerminated,
+		gen.EventType_WorkflowExecutionTerminatedByOperator,
+		gen.EventType_WorkflowExecutionContinuedAsNew:
+		if s.closeEventID == 0 {
+			s.closeEventID = eventID
+		}
+
+	case gen.EventType_DecisionTaskStarted:
+		attr := event.DecisionTaskStartedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.started(event, attr.GetScheduledEventId(), gen.EventType_DecisionTaskScheduled)
+
+	case gen.EventType_DecisionTaskCompleted:
+		attr := event.DecisionTaskCompletedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetScheduledEventId(), gen.EventType_DecisionTaskScheduled, attr.GetStartedEventId(), true)
+
+	case gen.EventType_DecisionTaskFailed:
+		attr := event.DecisionTaskFailedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetScheduledEventId(), gen.EventType_DecisionTaskScheduled, attr.GetStartedEventId(), true)
+
+	case gen.EventType_DecisionTaskTimedOut:
+		attr := event.DecisionTaskTimedOutEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetScheduledEventId(), gen.EventType_DecisionTaskScheduled, attr.GetStartedEventId(), false)
+
+	case gen.EventType_ActivityTaskStarted:
+		attr := event.ActivityTaskStartedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.started(event, attr.GetScheduledEventId(), gen.EventType_ActivityTaskScheduled)
+
+	case gen.EventType_ActivityTaskCompleted:
+		attr := event.ActivityTaskCompletedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetScheduledEventId(), gen.EventType_ActivityTaskScheduled, attr.GetStartedEventId(), true)
+
+	case gen.EventType_ActivityTaskFailed:
+		attr := event.ActivityTaskFailedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetScheduledEventId(), gen.EventType_ActivityTaskScheduled, attr.GetStartedEventId(), true)
+
+	case gen.EventType_ActivityTaskTimedOut:
+		attr := event.ActivityTaskTimedOutEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetScheduledEventId(), gen.EventType_ActivityTaskScheduled, attr.GetStartedEventId(), false)
+
+	case gen.EventType_ActivityTaskCanceled:
+		attr := event.ActivityTaskCanceledEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetScheduledEventId(), gen.EventType_ActivityTaskScheduled, attr.GetStartedEventId(), false)
+		if attr.IsSetLatestCancelRequestedEventId() {
+			s.references(event, attr.GetLatestCancelRequestedEventId(), gen.EventType_ActivityTaskCancelRequested)
+		}
+
+	case gen.EventType_TimerFired:
+		attr := event.TimerFiredEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetStartedEventId(), gen.EventType_TimerStarted, 0, false)
+
+	case gen.EventType_TimerCanceled:
+		attr := event.TimerCanceledEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetStartedEventId(), gen.EventType_TimerStarted, 0, false)
+
+	case gen.EventType_RequestCancelExternalWorkflowExecutionFailed:
+		attr := event.RequestCancelExternalWorkflowExecutionFailedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetInitiatedEventId(),
+			gen.EventType_RequestCancelExternalWorkflowExecutionInitiated, 0, false)
+
+	case gen.EventType_ExternalWorkflowExecutionCancelRequested:
+		attr := event.ExternalWorkflowExecutionCancelRequestedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetInitiatedEventId(),
+			gen.EventType_RequestCancelExternalWorkflowExecutionInitiated, 0, false)
+
+	case gen.EventType_StartChildWorkflowExecutionFailed:
+		attr := event.StartChildWorkflowExecutionFailedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetInitiatedEventId(), gen.EventType_StartChildWorkflowExecutionInitiated, 0, false)
+
+	case gen.EventType_ChildWorkflowExecutionStarted:
+		attr := event.ChildWorkflowExecutionStartedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.started(event, attr.GetInitiatedEventId(), gen.EventType_StartChildWorkflowExecutionInitiated)
+
+	case gen.EventType_ChildWorkflowExecutionCompleted:
+		attr := event.ChildWorkflowExecutionCompletedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetInitiatedEventId(), gen.EventType_StartChildWorkflowExecutionInitiated,
+			attr.GetStartedEventId(), true)
+
+	case gen.EventType_ChildWorkflowExecutionFailed:
+		attr := event.ChildWorkflowExecutionFailedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetInitiatedEventId(), gen.EventType_StartChildWorkflowExecutionInitiated,
+			attr.GetStartedEventId(), true)
+
+	case gen.EventType_ChildWorkflowExecutionCanceled:
+		attr := event.ChildWorkflowExecutionCanceledEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetInitiatedEventId(), gen.EventType_StartChildWorkflowExecutionInitiated,
+			attr.GetStartedEventId(), true)
+
+	case gen.EventType_ChildWorkflowExecutionTimedOut:
+		attr := event.ChildWorkflowExecutionTimedOutEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetInitiatedEventId(), gen.EventType_StartChildWorkflowExecutionInitiated,
+			attr.GetStartedEventId(), true)
+
+	case gen.EventType_ChildWorkflowExecutionTerminated:
+		attr := event.ChildWorkflowExecutionTerminatedEventAttributes
+		if attr == nil {
+			missing()
+			break
+		}
+		s.closed(event, attr.GetInitiatedEventId(), gen.EventType_StartChildWorkflowExecutionInitiated,
+			attr.GetStartedEventId(), true)
+	}
+}
+
+// references checks that the event references an event of the expected type recorded before it
+func (s *historyVerification) references(event *gen.HistoryEvent, referencedID int64,
+	expectedType gen.EventType) bool {
+	referencedType, ok := s.eventTypes[referencedID]
+	if !ok || referencedID >= event.GetEventId() {
+		s.report(event.GetEventId(), "%v references event %v which is not recorded before it",
+			event.GetEventType(), referencedID)
+		return false
+	}
+	if referencedType != expectedType {
+		s.report(event.GetEventId(), "%v references %v event %v, expected %v",
+			event.GetEventType(), referencedType, referencedID, expectedType)
+		return false
+	}
+	return true
+}
+
+// started pairs the event with the initiated event it started
+func (s *historyVerification) started(event *gen.HistoryEvent, initiatedID int64, initiatedType gen.EventType) {
+	if !s.references(event, initiatedID, initiatedType) {
+		return
+	}
+	if startedID, ok := s.starts[initiatedID]; ok {
+		s.report(event.GetEventId(), "%v event %v was already started by event %v",
+			initiatedType, initiatedID, startedID)
+		return
+	}
+	if closeID, ok := s.closes[initiatedID]; ok {
+		s.report(event.GetEventId(), "%v event %v was already closed by event %v",
+			initiatedType, initiatedID, closeID)
+		return
+	}
+	s.starts[initiatedID] = event.GetEventId()
+}
+
+// closed pairs the event with the initiated event it closed, and with the event which started it.  Closing events
+// which may be recorded before the start, such as timeouts and cancellations, only reference a started event when set.
+func (s *historyVerification) closed(event *gen.HistoryEvent, initiatedID int64, initiatedType gen.EventType,
+	startedID int64, startRequired bool) {
+	if !s.references(event, initiatedID, initiatedType) {
+		return
+	}
+	if closeID, ok := s.closes[initiatedID]; ok {
+		s.report(event.GetEventId(), "%v event %v was already closed by event %v",
+			initiatedType, initiatedID, closeID)
+		return
+	}
+	s.closes[initiatedID] = event.GetEventId()
+
+	recordedStartID, started := s.starts[initiatedID]
+	switch {
+	case startedID == 0 && startRequired:
+		s.report(event.GetEventId(), "%v does not reference the event which started %v event %v",
+			event.GetEventType(), initiatedType, initiatedID)
+	case startedID != 0 && !started:
+		s.report(event.GetEventId(), "%v references started event %v, but %v event %v was never started",
+			event.GetEventType(), startedID, initiatedType, initiatedID)
+	case startedID != 0 && startedID != recordedStartID:
+		s.report(event.GetEventId(), "%v references started event %v, but %v event %v was started by event %v",
+			event.GetEventType(), startedID, initiatedType, initiatedID, recordedStartID)
+	}
+}
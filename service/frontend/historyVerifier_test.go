@@ -0,0 +1,214 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type historyVerifierSuite struct {
+	suite.Suite
+	// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+	// not merely log an error
+	*require.Assertions
+	verifier *historyVerifier
+}
+
+func TestHistoryVerifierSuite(t *testing.T) {
+	suite.Run(t, new(historyVerifierSuite))
+}
+
+func (s *historyVerifierSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.verifier = newHistoryVerifier(persistence.NewHistorySerializerFactory())
+}
+
+func (s *historyVerifierSuite) TestValidHistory() {
+	response := s.verifier.verify([]*gen.SerializedHistoryBatch{
+		s.batch(1, s.workflowStarted(1), s.decisionScheduled(2)),
+		s.batch(1, s.decisionStarted(3, 2)),
+		s.batch(1, s.decisionCompleted(4, 2, 3), s.activityScheduled(5)),
+		s.batch(1, s.activityStarted(6, 5), s.activityCompleted(7, 5, 6), s.workflowCompleted(8)),
+	})
+	s.True(response.GetValid())
+	s.Equal(int64(8), response.GetEventCount())
+	s.Empty(response.Issues)
+}
+
+func (s *historyVerifierSuite) TestEventIDContinuity() {
+	response := s.verifier.verify([]*gen.SerializedHistoryBatch{
+		s.batch(1, s.workflowStarted(1), s.decisionScheduled(3)),
+	})
+	s.False(response.GetValid())
+	s.Equal([]string{"event id 3 is out of sequence, expected 2"}, s.messages(response))
+	s.Equal(int64(3), response.Issues[0].GetEventId())
+}
+
+func (s *historyVerifierSuite) TestVersionOrdering() {
+	response := s.verifier.verify([]*gen.SerializedHistoryBatch{
+		s.batch(1, s.workflowStarted(1)),
+		s.batch(0, s.decisionScheduled(2)),
+		s.batch(2, s.decisionStarted(3, 2)),
+	})
+	s.Equal([]string{
+		"batch 1 has version 0, lower than the version 1 of the previous batch",
+		"batch 2 cannot be decoded: history deserialization error: incompatible history version;required=2;maxSupported=1",
+	}, s.messages(response))
+	s.False(response.Issues[0].IsSetEventId())
+}
+
+func (s *historyVerifierSuite) TestUnmatchedPairs() {
+	response := s.verifier.verify([]*gen.SerializedHistoryBatch{
+		s.batch(1,
+			s.workflowStarted(1),
+			s.decisionScheduled(2),
+			s.activityStarted(3, 2),
+			s.decisionStarted(4, 2),
+			s.decisionStarted(5, 2),
+			s.activityCompleted(6, 9, 3),
+			s.activityScheduled(7),
+			s.activityCompleted(8, 7, 4),
+			s.decisionCompleted(9, 2, 0),
+		),
+	})
+	s.Equal([]string{
+		"ActivityTaskStarted references DecisionTaskScheduled event 2, expected ActivityTaskScheduled",
+		"DecisionTaskScheduled event 2 was already started by event 4",
+		"ActivityTaskCompleted references event 9 which is not recorded before it",
+		"ActivityTaskCompleted references started event 4, but ActivityTaskScheduled event 7 was never started",
+		"DecisionTaskCompleted does not reference the event which started DecisionTaskScheduled event 2",
+	}, s.messages(response))
+}
+
+func (s *historyVerifierSuite) TestStructure() {
+	response := s.verifier.verify([]*gen.SerializedHistoryBatch{
+		s.batch(1,
+			s.decisionScheduled(1),
+			s.event(2, gen.EventType_ActivityTaskStarted),
+			s.workflowCompleted(3),
+			s.decisionScheduled(4),
+		),
+		{Version: common.Int32Ptr(1), Data: []byte("not json")},
+	})
+	s.Equal(int64(4), response.GetEventCount())
+	messages := s.messages(response)
+	s.Len(messages, 4)
+	s.Equal("history starts with DecisionTaskScheduled instead of WorkflowExecutionStarted",
+		messages[0])
+	s.Equal("attributes of ActivityTaskStarted are not set", messages[1])
+	s.Equal("DecisionTaskScheduled is recorded after the workflow execution was closed by event 3",
+		messages[2])
+	s.Contains(messages[3], "batch 1 cannot be decoded")
+
+	response = s.verifier.verify([]*gen.SerializedHistoryBatch{nil})
+	s.Equal([]string{"batch 0 is empty", "history has no events"}, s.messages(response))
+}
+
+func (s *historyVerifierSuite) batch(version int32, events ...*gen.HistoryEvent) *gen.SerializedHistoryBatch {
+	data, err := json.Marshal(events)
+	s.NoError(err)
+	return &gen.SerializedHistoryBatch{
+		EncodingType: common.StringPtr(string(common.EncodingTypeJSON)),
+		Version:      common.Int32Ptr(version),
+		Data:         data,
+	}
+}
+
+func (s *historyVerifierSuite) messages(response *gen.VerifyHistoryResponse) []string {
+	var messages []string
+	for _, issue := range response.Issues {
+		messages = append(messages, issue.GetMessage())
+	}
+	return messages
+}
+
+func (s *historyVerifierSuite) event(eventID int64, eventType gen.EventType) *gen.HistoryEvent {
+	return &gen.HistoryEvent{
+		EventId:   common.Int64Ptr(eventID),
+		Timestamp: common.Int64Ptr(eventID),
+		EventType: common.EventTypePtr(eventType),
+	}
+}
+
+func (s *historyVerifierSuite) workflowStarted(eventID int64) *gen.HistoryEvent {
+	event := s.event(eventID, gen.EventType_WorkflowExecutionStarted)
+	event.WorkflowExecutionStartedEventAttributes = &gen.WorkflowExecutionStartedEventAttributes{}
+	return event
+}
+
+func (s *historyVerifierSuite) workflowCompleted(eventID int64) *gen.HistoryEvent {
+	event := s.event(eventID, gen.EventType_WorkflowExecutionCompleted)
+	event.WorkflowExecutionCompletedEventAttributes = &gen.WorkflowExecutionCompletedEventAttributes{}
+	return event
+}
+
+func (s *historyVerifierSuite) decisionScheduled(eventID int64) *gen.HistoryEvent {
+	event := s.event(eventID, gen.EventType_DecisionTaskScheduled)
+	event.DecisionTaskScheduledEventAttributes = &gen.DecisionTaskScheduledEventAttributes{}
+	return event
+}
+
+func (s *historyVerifierSuite) decisionStarted(eventID, scheduledID int64) *gen.HistoryEvent {
+	event := s.event(eventID, gen.EventType_DecisionTaskStarted)
+	event.DecisionTaskStartedEventAttributes = &gen.DecisionTaskStartedEventAttributes{
+		ScheduledEventId: common.Int64Ptr(scheduledID),
+	}
+	return event
+}
+
+func (s *historyVerifierSuite) decisionCompleted(eventID, scheduledID, startedID int64) *gen.HistoryEvent {
+	event := s.event(eventID, gen.EventType_DecisionTaskCompleted)
+	event.DecisionTaskCompletedEventAttributes = &gen.DecisionTaskCompletedEventAttributes{
+		ScheduledEventId: common.Int64Ptr(scheduledID),
+		StartedEventId:   common.Int64Ptr(startedID),
+	}
+	return event
+}
+
+func (s *historyVerifierSuite) activityScheduled(eventID int64) *gen.HistoryEvent {
+	event := s.event(eventID, gen.EventType_ActivityTaskScheduled)
+	event.ActivityTaskScheduledEventAttributes = &gen.ActivityTaskScheduledEventAttributes{}
+	return event
+}
+
+func (s *historyVerifierSuite) activityStarted(eventID, scheduledID int64) *gen.HistoryEvent {
+	event := s.event(eventID, gen.EventType_ActivityTaskStarted)
+	event.ActivityTaskStartedEventAttributes = &gen.ActivityTaskStartedEventAttributes{
+		ScheduledEventId: common.Int64Ptr(scheduledID),
+	}
+	return event
+}
+
+func (s *historyVerifierSuite) activityCompleted(eventID, scheduledID, startedID int64) *gen.HistoryEvent {
+	event := s.event(eventID, gen.EventType_ActivityTaskCompleted)
+	event.ActivityTaskCompletedEventAttributes = &gen.ActivityTaskCompletedEventAttributes{
+		ScheduledEventId: common.Int64Ptr(scheduledID),
+		StartedEventId:   common.Int64Ptr(startedID),
+	}
+	return event
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	// pageTokenVersion1 is the first version of the signed visibility page token format
+	pageTokenVersion1 byte = 1
+
+	// pageTokenHeaderSize is the size of the version and expiration time leading the token
+	pageTokenHeaderSize = 1 + 8
+
+	// pageTokenSignatureSize is the size of the HMAC-SHA256 signature trailing the token
+	pageTokenSignatureSize = sha256.Size
+
+	defaultPageTokenTTL = time.Hour
+)
+
+var (
+	errInvalidSignedPageToken = &gen.BadRequestError{Message: "NextPageToken is invalid or was issued for another query."}
+	errExpiredSignedPageToken = &gen.BadRequestError{Message: "NextPageToken has expired."}
+)
+
+type (
+	// pageTokenSigner wraps the page tokens of the visibility store in an envelope made of a version byte, the
+	// expiration time of the token, the store token and an HMAC-SHA256 signature.  The signature also covers the
+	// query the token was issued for, so it can't be used to page through the results of another query.
+	pageTokenSigner struct {
+		signingKey []byte
+		ttl        time.Duration
+		timeSource common.TimeSource
+	}
+)
+
+// newPageTokenSigner returns an error when no signing key is configured, page tokens could be forged otherwise
+func newPageTokenSigner(cfg config.PageToken) (*pageTokenSigner, error) {
+	if cfg.SigningKey == "" {
+		return nil, errors.New("pageToken.signingKey is not set")
+	}
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = defaultPageTokenTTL
+	}
+	return &pageTokenSigner{
+		signingKey: []byte(cfg.SigningKey),
+		ttl:        ttl,
+		timeSource: common.NewRealTimeSource(),
+	}, nil
+}
+
+// sign wraps the store token issued for the query.  The empty token marking the last page is returned as is.
+func (s *pageTokenSigner) sign(query string, token []byte) []byte {
+	if len(token) == 0 {
+		return token
+	}
+
+	data := make([]byte, pageTokenHeaderSize, pageTokenHeaderSize+len(token)+pageTokenSignatureSize)
+	data[0] = pageTokenVersion1
+	expiration := s.timeSource.Now().Add(s.ttl).UnixNano()
+	binary.BigEndian.PutUint64(data[1:pageTokenHeaderSize], uint64(expiration))
+	data = append(data, token...)
+	return append(data, s.signature(query, data)...)
+}
+
+// verify returns the store token wrapped in data, after checking it was issued for the query and has not expired
+func (s *pageTokenSigner) verify(query string, data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	if len(data) <= pageTokenHeaderSize+pageTokenSignatureSize || data[0] != pageTokenVersion1 {
+		return nil, errInvalidSignedPageToken
+	}
+
+	payload := data[:len(data)-pageTokenSignatureSize]
+	if !hmac.Equal(data[len(data)-pageTokenSignatureSize:], s.signature(query, payload)) {
+		return nil, errInvalidSignedPageToken
+	}
+	expiration := int64(binary.BigEndian.Uint64(payload[1:pageTokenHeaderSize]))
+	if s.timeSource.Now().UnixNano() > expiration {
+		return nil, errExpiredSignedPageToken
+	}
+	return payload[pageTokenHeaderSize:], nil
+}
+
+func (s *pageTokenSigner) signature(query string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(query))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// listOpenQuery identifies the query of the request, regardless of its paging and view
+func listOpenQuery(request *gen.ListOpenWorkflowExecutionsRequest) string {
+	query := *request
+	query.MaximumPageSize = nil
+	query.NextPageToken = nil
+	query.View = nil
+	data, _ := json.Marshal(&query)
+	return "ListOpenWorkflowExecutions" + string(data)
+}
+
+// listClosedQuery identifies the query of the request, regardless of its paging and view
+func listClosedQuery(request *gen.ListClosedWorkflowExecutionsRequest) string {
+	query := *request
+	query.MaximumPageSize = nil
+	query.NextPageToken = nil
+	query.View = nil
+	data, _ := json.Marshal(&query)
+	return "ListClosedWorkflowExecutions" + string(data)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	pageTokenSuite struct {
+		suite.Suite
+		timeSource *fixedTimeSource
+		signer     *pageTokenSigner
+	}
+
+	fixedTimeSource struct {
+		now time.Time
+	}
+)
+
+func (ts *fixedTimeSource) Now() time.Time {
+	return ts.now
+}
+
+func TestPageTokenSuite(t *testing.T) {
+	suite.Run(t, new(pageTokenSuite))
+}
+
+func (s *pageTokenSuite) SetupTest() {
+	s.timeSource = &fixedTimeSource{now: time.Unix(1500000000, 0)}
+	var err error
+	s.signer, err = newPageTokenSigner(config.PageToken{SigningKey: "test-key", TTL: time.Minute})
+	s.NoError(err)
+	s.signer.timeSource = s.timeSource
+}
+
+func (s *pageTokenSuite) TestRoundTrip() {
+	query := listOpenQuery(&gen.ListOpenWorkflowExecutionsRequest{Domain: common.StringPtr("domain")})
+	data := s.signer.sign(query, []byte("store-token"))
+	s.NotEqual([]byte("store-token"), data)
+
+	token, err := s.signer.verify(query, data)
+	s.Nil(err)
+	s.Equal([]byte("store-token"), token)
+}
+
+func (s *pageTokenSuite) TestEmptyToken() {
+	query := listOpenQuery(&gen.ListOpenWorkflowExecutionsRequest{Domain: common.StringPtr("domain")})
+	s.Empty(s.signer.sign(query, nil))
+
+	token, err := s.signer.verify(query, nil)
+	s.Nil(err)
+	s.Empty(token)
+}
+
+func (s *pageTokenSuite) TestTamperedToken() {
+	query := listOpenQuery(&gen.ListOpenWorkflowExecutionsRequest{Domain: common.StringPtr("domain")})
+	data := s.signer.sign(query, []byte("store-token"))
+
+	tampered := append([]byte{}, data...)
+	tampered[pageTokenHeaderSize] ^= 0xff
+	_, err := s.signer.verify(query, tampered)
+	s.Equal(errInvalidSignedPageToken, err)
+
+	_, err = s.signer.verify(query, []byte("store-token"))
+	s.Equal(errInvalidSignedPageToken, err)
+
+	forger, err := newPageTokenSigner(config.PageToken{SigningKey: "another-key"})
+	s.NoError(err)
+	forger.timeSource = s.timeSource
+	_, err = s.signer.verify(query, forger.sign(query, []byte("store-token")))
+	s.Equal(errInvalidSignedPageToken, err)
+}
+
+func (s *pageTokenSuite) TestTokenOfAnotherQuery() {
+	request := &gen.ListClosedWorkflowExecutionsRequest{
+		Domain:       common.StringPtr("domain"),
+		StatusFilter: gen.WorkflowExecutionCloseStatusPtr(gen.WorkflowExecutionCloseStatus_COMPLETED),
+	}
+	data := s.signer.sign(listClosedQuery(request), []byte("store-token"))
+
+	// paging and view don't change the query
+	request.MaximumPageSize = common.Int32Ptr(10)
+	request.NextPageToken = data
+	request.View = gen.ExecutionInfoViewPtr(gen.ExecutionInfoView_MINIMAL)
+	_, err := s.signer.verify(listClosedQuery(request), data)
+	s.Nil(err)
+
+	request.StatusFilter = gen.WorkflowExecutionCloseStatusPtr(gen.WorkflowExecutionCloseStatus_FAILED)
+	_, err = s.signer.verify(listClosedQuery(request), data)
+	s.Equal(errInvalidSignedPageToken, err)
+
+	openQuery := listOpenQuery(&gen.ListOpenWorkflowExecutionsRequest{Domain: common.StringPtr("domain")})
+	_, err = s.signer.verify(openQuery, data)
+	s.Equal(errInvalidSignedPageToken, err)
+}
+
+func (s *pageTokenSuite) TestExpiredToken() {
+	query := listOpenQuery(&gen.ListOpenWorkflowExecutionsRequest{Domain: common.StringPtr("domain")})
+	data := s.signer.sign(query, []byte("store-token"))
+
+	s.timeSource.now = s.timeSource.now.Add(time.Minute)
+	_, err := s.signer.verify(query, data)
+	s.Nil(err)
+
+	s.timeSource.now = s.timeSource.now.Add(time.Second)
+	_, err = s.signer.verify(query, data)
+	s.Equal(errExpiredSignedPageToken, err)
+}
+
+func (s *pageTokenSuite) TestSigningKeyRequired() {
+	_, err := newPageTokenSigner(config.PageToken{TTL: time.Minute})
+	s.Error(err)
+}
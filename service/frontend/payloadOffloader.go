@@ -0,0 +1,241 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"fmt"
+
+	"github.com/pborman/uuid"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/service/config"
+	"golang.org/x/net/context"
+)
+
+var errPayloadReferenceSet = &gen.BadRequestError{Message: "PayloadReference is set by the server only."}
+
+type (
+	// payloadOffloader keeps the payloads above the size threshold out of the history and mutable state of workflow
+	// executions.  Payloads are stored in the blob store when the frontend receives them, and history gets a
+	// reference to the blob in the PayloadReference field next to the emptied payload.  The references are resolved
+	// back to the payloads in the histories and activity tasks the frontend returns, so callers never see them.
+	// Blobs are keyed by the domain, only the references of the domain of a request are resolved.  Blobs of
+	// requests which fail after their payload is offloaded are not deleted.
+	payloadOffloader struct {
+		client    blobstore.Client
+		bucket    string
+		threshold int
+	}
+)
+
+// newPayloadOffloader returns nil when no blob store is configured.  Without a threshold payloads are not offloaded,
+// the ones offloaded before are still resolved.
+func newPayloadOffloader(client blobstore.Client, cfg config.LargePayloads) *payloadOffloader {
+	if client == nil {
+		return nil
+	}
+	return &payloadOffloader{
+		client:    client,
+		bucket:    cfg.Bucket,
+		threshold: cfg.Threshold,
+	}
+}
+
+// offload stores the payload in the blob store when it is above the threshold, and replaces it with the reference.
+// References set by callers are rejected.
+func (o *payloadOffloader) offload(ctx context.Context, domainID string, payload *[]byte,
+	reference **gen.PayloadReference) error {
+	if *reference != nil {
+		return errPayloadReferenceSet
+	}
+	if o == nil || o.threshold <= 0 || len(*payload) <= o.threshold {
+		return nil
+	}
+
+	key := blobstore.PayloadKey(domainID, uuid.New())
+	err := o.client.Put(ctx, o.bucket, key, &blobstore.Blob{
+		Body: *payload,
+		Tags: map[string]string{"domain": domainID},
+	})
+	if err != nil {
+		return &gen.InternalServiceError{Message: fmt.Sprintf("Failed to offload payload: %v", err)}
+	}
+	*reference = &gen.PayloadReference{
+		Key:      &key,
+		Checksum: common.StringPtr(blobstore.PayloadChecksum(*payload)),
+		Size:     common.Int32Ptr(int32(len(*payload))),
+	}
+	*payload = nil
+	return nil
+}
+
+// offloadDecisions offloads the payloads of the decisions which end up in history events
+func (o *payloadOffloader) offloadDecisions(ctx context.Context, domainID string, decisions []*gen.Decision) error {
+	for _, decision := range decisions {
+		payload := decisionPayload(decision)
+		if payload == nil {
+			if decision.PayloadReference != nil {
+				return errPayloadReferenceSet
+			}
+			continue
+		}
+		if err := o.offload(ctx, domainID, payload, &decision.PayloadReference); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve replaces the reference to an offloaded payload with the payload.  References to the blobs of other domains
+// are refused.
+func (o *payloadOffloader) resolve(ctx context.Context, domainID string, payload *[]byte,
+	reference **gen.PayloadReference) error {
+	if *reference == nil {
+		return nil
+	}
+	key := (*reference).GetKey()
+	if o == nil {
+		return &gen.InternalServiceError{
+			Message: fmt.Sprintf("Offloaded payload %v cannot be resolved without a blobstore", key),
+		}
+	}
+	if !blobstore.IsPayloadKeyOfDomain(key, domainID) {
+		return &gen.InternalServiceError{
+			Message: fmt.Sprintf("Offloaded payload %v does not belong to the domain", key),
+		}
+	}
+
+	blob, err := o.client.Get(ctx, o.bucket, key)
+	if err != nil {
+		return &gen.InternalServiceError{
+			Message: fmt.Sprintf("Failed to resolve offloaded payload %v: %v", key, err),
+		}
+	}
+	if len(blob.Body) != int((*reference).GetSize()) ||
+		blobstore.PayloadChecksum(blob.Body) != (*reference).GetChecksum() {
+		return &gen.InternalServiceError{
+			Message: fmt.Sprintf("Offloaded payload %v does not match its checksum", key),
+		}
+	}
+	*payload = blob.Body
+	*reference = nil
+	return nil
+}
+
+// resolveHistory resolves the offloaded payloads of the history events
+func (o *payloadOffloader) resolveHistory(ctx context.Context, domainID string, history *gen.History) error {
+	if history == nil {
+		return nil
+	}
+	for _, event := range history.Events {
+		if event.PayloadReference == nil {
+			continue
+		}
+		payload := eventPayload(event)
+		if payload == nil {
+			return &gen.InternalServiceError{
+				Message: fmt.Sprintf("Event %v has no payload to resolve", event.GetEventType()),
+			}
+		}
+		if err := o.resolve(ctx, domainID, payload, &event.PayloadReference); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveActivityTasks resolves the inputs of the activity tasks dispatched along with the response to a decision,
+// they are scheduled by the decisions offloaded before
+func (o *payloadOffloader) resolveActivityTasks(ctx context.Context, domainID string,
+	tasks []*gen.PollForActivityTaskResponse) error {
+	for _, task := range tasks {
+		if err := o.resolve(ctx, domainID, &task.Input, &task.PayloadReference); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decisionPayload returns the payload field of the decision which is offloaded, or nil for decisions without one
+func decisionPayload(decision *gen.Decision) *[]byte {
+	if attributes := decision.ScheduleActivityTaskDecisionAttributes; attributes != nil {
+		return &attributes.Input
+	}
+	if attributes := decision.CompleteWorkflowExecutionDecisionAttributes; attributes != nil {
+		return &attributes.Result_
+	}
+	if attributes := decision.FailWorkflowExecutionDecisionAttributes; attributes != nil {
+		return &attributes.Details
+	}
+	if attributes := decision.RecordMarkerDecisionAttributes; attributes != nil {
+		return &attributes.Details
+	}
+	if attributes := decision.ContinueAsNewWorkflowExecutionDecisionAttributes; attributes != nil {
+		return &attributes.Input
+	}
+	if attributes := decision.StartChildWorkflowExecutionDecisionAttributes; attributes != nil {
+		return &attributes.Input
+	}
+	return nil
+}
+
+// eventPayload returns the payload field of the history event which may be offloaded, the ones recorded from
+// offloaded request and decision payloads and the ones history copies them to
+func eventPayload(event *gen.HistoryEvent) *[]byte {
+	if attributes := event.WorkflowExecutionStartedEventAttributes; attributes != nil {
+		return &attributes.Input
+	}
+	if attributes := event.WorkflowExecutionCompletedEventAttributes; attributes != nil {
+		return &attributes.Result_
+	}
+	if attributes := event.WorkflowExecutionFailedEventAttributes; attributes != nil {
+		return &attributes.Details
+	}
+	if attributes := event.WorkflowExecutionContinuedAsNewEventAttributes; attributes != nil {
+		return &attributes.Input
+	}
+	if attributes := event.WorkflowExecutionSignaledEventAttributes; attributes != nil {
+		return &attributes.Input
+	}
+	if attributes := event.ActivityTaskScheduledEventAttributes; attributes != nil {
+		return &attributes.Input
+	}
+	if attributes := event.ActivityTaskCompletedEventAttributes; attributes != nil {
+		return &attributes.Result_
+	}
+	if attributes := event.ActivityTaskFailedEventAttributes; attributes != nil {
+		return &attributes.Details
+	}
+	if attributes := event.MarkerRecordedEventAttributes; attributes != nil {
+		return &attributes.Details
+	}
+	if attributes := event.StartChildWorkflowExecutionInitiatedEventAttributes; attributes != nil {
+		return &attributes.Input
+	}
+	if attributes := event.ChildWorkflowExecutionCompletedEventAttributes; attributes != nil {
+		return &attributes.Result_
+	}
+	if attributes := event.ChildWorkflowExecutionFailedEventAttributes; attributes != nil {
+		return &attributes.Details
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	h "github.com/uber/cadence/.gen/go/history"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+// localDispatchHistoryClient dispatches the activities scheduled by a decision along with the response to it
+type localDispatchHistoryClient struct {
+	history.Client
+	scheduledDecisions []*gen.Decision
+}
+
+func (c *localDispatchHistoryClient) RespondDecisionTaskCompleted(ctx thrift.Context,
+	request *h.RespondDecisionTaskCompletedRequest) (*gen.RespondDecisionTaskCompletedResponse, error) {
+	response := &gen.RespondDecisionTaskCompletedResponse{}
+	for _, decision := range request.CompleteRequest.Decisions {
+		c.scheduledDecisions = append(c.scheduledDecisions, decision)
+		response.ActivityTasks = append(response.ActivityTasks, &gen.PollForActivityTaskResponse{
+			ActivityId:       decision.ScheduleActivityTaskDecisionAttributes.ActivityId,
+			Input:            decision.ScheduleActivityTaskDecisionAttributes.Input,
+			PayloadReference: decision.PayloadReference,
+		})
+	}
+	return response, nil
+}
+
+type payloadOffloaderSuite struct {
+	suite.Suite
+	rootDir   string
+	client    blobstore.Client
+	offloader *payloadOffloader
+}
+
+func TestPayloadOffloaderSuite(t *testing.T) {
+	suite.Run(t, new(payloadOffloaderSuite))
+}
+
+func (s *payloadOffloaderSuite) SetupTest() {
+	var err error
+	s.rootDir, err = ioutil.TempDir("", "payloadOffloaderSuite")
+	s.NoError(err)
+	s.NoError(os.Mkdir(s.rootDir+"/payloads", 0700))
+	s.client, err = blobstore.NewFilesystemClient(s.rootDir)
+	s.NoError(err)
+	s.offloader = newPayloadOffloader(s.client, config.LargePayloads{Bucket: "payloads", Threshold: 8})
+}
+
+func (s *payloadOffloaderSuite) TearDownTest() {
+	os.RemoveAll(s.rootDir)
+}
+
+func (s *payloadOffloaderSuite) TestOffloadAndResolve() {
+	var reference *gen.PayloadReference
+	payload := []byte("small")
+	s.NoError(s.offloader.offload(context.Background(), "domain", &payload, &reference))
+	s.Equal([]byte("small"), payload)
+	s.Nil(reference)
+
+	large := bytes.Repeat([]byte("large"), 10)
+	payload = large
+	s.NoError(s.offloader.offload(context.Background(), "domain", &payload, &reference))
+	s.Nil(payload)
+	s.NotNil(reference)
+	s.True(strings.HasPrefix(reference.GetKey(), "domain/"))
+	s.Equal(blobstore.PayloadChecksum(large), reference.GetChecksum())
+	s.Equal(int32(len(large)), reference.GetSize())
+	blob, err := s.client.Get(context.Background(), "payloads", reference.GetKey())
+	s.NoError(err)
+	s.Equal(large, blob.Body)
+	s.Equal(map[string]string{"domain": "domain"}, blob.Tags)
+
+	// every offload gets a blob of its own
+	var other *gen.PayloadReference
+	otherPayload := large
+	s.NoError(s.offloader.offload(context.Background(), "domain", &otherPayload, &other))
+	s.NotEqual(reference.GetKey(), other.GetKey())
+
+	s.NoError(s.offloader.resolve(context.Background(), "domain", &payload, &reference))
+	s.Equal(large, payload)
+	s.Nil(reference)
+
+	// references set by callers are rejected
+	payload = large
+	reference = other
+	s.Equal(errPayloadReferenceSet, s.offloader.offload(context.Background(), "domain", &payload, &reference))
+	s.Equal(errPayloadReferenceSet, (*payloadOffloader)(nil).offload(context.Background(), "domain", &payload,
+		&reference))
+}
+
+func (s *payloadOffloaderSuite) TestResolveErrors() {
+	large := bytes.Repeat([]byte("large"), 10)
+	var offloaded *gen.PayloadReference
+	payload := large
+	s.NoError(s.offloader.offload(context.Background(), "domain", &payload, &offloaded))
+
+	// references are only resolved within the domain of their blob
+	reference := offloaded
+	s.IsType(&gen.InternalServiceError{}, s.offloader.resolve(context.Background(), "other", &payload, &reference))
+	reference = &gen.PayloadReference{
+		Key:      common.StringPtr("other/" + strings.TrimPrefix(offloaded.GetKey(), "domain/")),
+		Checksum: offloaded.Checksum,
+		Size:     offloaded.Size,
+	}
+	s.IsType(&gen.InternalServiceError{}, s.offloader.resolve(context.Background(), "domain", &payload, &reference))
+
+	s.NoError(s.client.Put(context.Background(), "payloads", offloaded.GetKey(),
+		&blobstore.Blob{Body: []byte("tampered")}))
+	reference = offloaded
+	s.IsType(&gen.InternalServiceError{}, s.offloader.resolve(context.Background(), "domain", &payload, &reference))
+
+	s.NoError(s.client.Delete(context.Background(), "payloads", offloaded.GetKey()))
+	reference = offloaded
+	s.IsType(&gen.InternalServiceError{}, s.offloader.resolve(context.Background(), "domain", &payload, &reference))
+	s.Nil(payload)
+}
+
+func (s *payloadOffloaderSuite) TestDisabled() {
+	large := bytes.Repeat([]byte("large"), 10)
+	var offloaded *gen.PayloadReference
+	payload := large
+	s.NoError(s.offloader.offload(context.Background(), "domain", &payload, &offloaded))
+
+	// without a threshold the payloads offloaded before are still resolved
+	offloader := newPayloadOffloader(s.client, config.LargePayloads{Bucket: "payloads"})
+	var reference *gen.PayloadReference
+	payload = large
+	s.NoError(offloader.offload(context.Background(), "domain", &payload, &reference))
+	s.Equal(large, payload)
+	s.Nil(reference)
+	payload = nil
+	reference = offloaded
+	s.NoError(offloader.resolve(context.Background(), "domain", &payload, &reference))
+	s.Equal(large, payload)
+
+	// without a blob store nothing is offloaded, and references cannot be resolved
+	offloader = newPayloadOffloader(nil, config.LargePayloads{Bucket: "payloads", Threshold: 8})
+	s.Nil(offloader)
+	payload = large
+	s.NoError(offloader.offload(context.Background(), "domain", &payload, &reference))
+	s.NoError(offloader.offloadDecisions(context.Background(), "domain", []*gen.Decision{{}}))
+	s.NoError(offloader.resolveHistory(context.Background(), "domain", &gen.History{}))
+	s.Equal(large, payload)
+	s.Nil(reference)
+	reference = offloaded
+	s.IsType(&gen.InternalServiceError{}, offloader.resolve(context.Background(), "domain", &payload, &reference))
+}
+
+func (s *payloadOffloaderSuite) TestDecisionsAndHistory() {
+	input := bytes.Repeat([]byte("input"), 10)
+	result := bytes.Repeat([]byte("result"), 10)
+	decisions := []*gen.Decision{
+		{
+			DecisionType: gen.DecisionTypePtr(gen.DecisionType_ScheduleActivityTask),
+			ScheduleActivityTaskDecisionAttributes: &gen.ScheduleActivityTaskDecisionAttributes{
+				ActivityId: common.StringPtr("activity"),
+				Input:      input,
+			},
+		},
+		{
+			DecisionType: gen.DecisionTypePtr(gen.DecisionType_CompleteWorkflowExecution),
+			CompleteWorkflowExecutionDecisionAttributes: &gen.CompleteWorkflowExecutionDecisionAttributes{
+				Result_: result,
+			},
+		},
+		{
+			DecisionType: gen.DecisionTypePtr(gen.DecisionType_RecordMarker),
+			RecordMarkerDecisionAttributes: &gen.RecordMarkerDecisionAttributes{
+				MarkerName: common.StringPtr("marker"),
+				Details:    []byte("small"),
+			},
+		},
+	}
+	s.NoError(s.offloader.offloadDecisions(context.Background(), "domain", decisions))
+	s.Nil(decisions[0].ScheduleActivityTaskDecisionAttributes.Input)
+	s.Nil(decisions[1].CompleteWorkflowExecutionDecisionAttributes.Result_)
+	s.NotNil(decisions[0].PayloadReference)
+	s.NotNil(decisions[1].PayloadReference)
+	s.Equal([]byte("small"), decisions[2].RecordMarkerDecisionAttributes.Details)
+	s.Nil(decisions[2].PayloadReference)
+
+	history := &gen.History{Events: []*gen.HistoryEvent{
+		{
+			EventType:                            gen.EventTypePtr(gen.EventType_ActivityTaskScheduled),
+			ActivityTaskScheduledEventAttributes: &gen.ActivityTaskScheduledEventAttributes{},
+			PayloadReference:                     decisions[0].PayloadReference,
+		},
+		{
+			EventType: gen.EventTypePtr(gen.EventType_WorkflowExecutionCompleted),
+			WorkflowExecutionCompletedEventAttributes: &gen.WorkflowExecutionCompletedEventAttributes{},
+			PayloadReference: decisions[1].PayloadReference,
+		},
+		{
+			EventType: gen.EventTypePtr(gen.EventType_MarkerRecorded),
+			MarkerRecordedEventAttributes: &gen.MarkerRecordedEventAttributes{
+				Details: []byte("small"),
+			},
+		},
+	}}
+	s.NoError(s.offloader.resolveHistory(context.Background(), "domain", history))
+	s.Equal(input, history.Events[0].ActivityTaskScheduledEventAttributes.Input)
+	s.Equal(result, history.Events[1].WorkflowExecutionCompletedEventAttributes.Result_)
+	s.Equal([]byte("small"), history.Events[2].MarkerRecordedEventAttributes.Details)
+	for _, event := range history.Events {
+		s.Nil(event.PayloadReference)
+	}
+
+	// decisions without a payload cannot carry a reference either
+	s.Equal(errPayloadReferenceSet, s.offloader.offloadDecisions(context.Background(), "domain", []*gen.Decision{{
+		DecisionType:                 gen.DecisionTypePtr(gen.DecisionType_StartTimer),
+		StartTimerDecisionAttributes: &gen.StartTimerDecisionAttributes{},
+		PayloadReference:             &gen.PayloadReference{Key: common.StringPtr("domain/timer")},
+	}}))
+}
+
+func (s *payloadOffloaderSuite) TestRespondDecisionTaskCompletedWithLocalDispatch() {
+	historyClient := &localDispatchHistoryClient{}
+	tokenSerializer := common.NewJSONTaskTokenSerializer()
+	handler := &WorkflowHandler{
+		history:         historyClient,
+		tokenSerializer: tokenSerializer,
+		payloads:        s.offloader,
+	}
+	taskToken, err := tokenSerializer.Serialize(&common.TaskToken{DomainID: "domain"})
+	s.NoError(err)
+
+	large := bytes.Repeat([]byte("large"), 10)
+	decision := func(activityID string, input []byte) *gen.Decision {
+		return &gen.Decision{
+			DecisionType: gen.DecisionTypePtr(gen.DecisionType_ScheduleActivityTask),
+			ScheduleActivityTaskDecisionAttributes: &gen.ScheduleActivityTaskDecisionAttributes{
+				ActivityId: common.StringPtr(activityID),
+				Input:      input,
+			},
+		}
+	}
+	ctx, cancel := thrift.NewContext(time.Minute)
+	defer cancel()
+	response, err := handler.RespondDecisionTaskCompleted(ctx, &gen.RespondDecisionTaskCompletedRequest{
+		TaskToken: taskToken,
+		Decisions: []*gen.Decision{decision("large", large), decision("small", []byte("small"))},
+	})
+	s.NoError(err)
+
+	// history records the reference, the activity tasks dispatched to the worker carry the payload
+	s.NotNil(historyClient.scheduledDecisions[0].PayloadReference)
+	s.Nil(historyClient.scheduledDecisions[1].PayloadReference)
+	s.Len(response.ActivityTasks, 2)
+	s.Equal(large, response.ActivityTasks[0].Input)
+	s.Nil(response.ActivityTasks[0].PayloadReference)
+	s.Equal([]byte("small"), response.ActivityTasks[1].Input)
+}
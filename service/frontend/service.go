@@ -22,7 +22,7 @@ package frontend
 
 import (
 	"github.com/uber/cadence/common"
-	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/audit"
 	"github.com/uber/cadence/common/service"
 )
 
@@ -50,37 +50,41 @@ func (s *Service) Start() {
 
 	base := service.New(p)
 
-	metadata, err := persistence.NewCassandraMetadataPersistence(p.CassandraConfig.Hosts,
-		p.CassandraConfig.Datacenter,
-		p.CassandraConfig.Keyspace,
-		p.Logger)
-
+	pFactory := base.GetPersistenceFactory()
+	metadata, err := pFactory.NewMetadataManager()
 	if err != nil {
 		log.Fatalf("failed to create metadata manager: %v", err)
 	}
-	metadata = persistence.NewMetadataPersistenceClient(metadata, base.GetMetricsClient())
-
-	visibility, err := persistence.NewCassandraVisibilityPersistence(p.CassandraConfig.Hosts,
-		p.CassandraConfig.Datacenter,
-		p.CassandraConfig.VisibilityKeyspace,
-		p.Logger)
 
+	visibility, err := pFactory.NewVisibilityManager()
 	if err != nil {
 		log.Fatalf("failed to create visiblity manager: %v", err)
 	}
 
-	history, err := persistence.NewCassandraHistoryPersistence(p.CassandraConfig.Hosts,
-		p.CassandraConfig.Datacenter,
-		p.CassandraConfig.Keyspace,
-		p.Logger)
-
+	history, err := pFactory.NewHistoryManager()
 	if err != nil {
-		log.Fatalf("Creating Cassandra history manager persistence failed: %v", err)
+		log.Fatalf("failed to create history manager: %v", err)
+	}
+
+	auditSink := audit.NewNoopSink()
+	if p.Audit.FilePath != "" {
+		auditSink, err = audit.NewFileSink(p.Audit.FilePath)
+		if err != nil {
+			log.Fatalf("failed to create audit log: %v", err)
+		}
 	}
+	auditLogger := audit.NewLogger(auditSink, p.Logger)
 
-	history = persistence.NewHistoryPersistenceClient(history, base.GetMetricsClient())
+	blobClient, err := p.Blobstore.NewClient()
+	if err != nil {
+		log.Fatalf("failed to create blobstore client: %v", err)
+	}
+	if blobClient == nil && p.LargePayloads.Threshold > 0 {
+		log.Fatalf("offloading large payloads requires a blobstore")
+	}
 
-	handler, tchanServers := NewWorkflowHandler(base, metadata, history, visibility)
+	handler, tchanServers := NewWorkflowHandler(base, metadata, history, visibility, auditLogger, p.PageToken,
+		p.ClientVersions, p.PropagatedHeaders, blobClient, p.LargePayloads)
 	handler.Start(tchanServers)
 
 	log.Infof("%v started", common.FrontendServiceName)
@@ -88,6 +92,7 @@ func (s *Service) Start() {
 	<-s.stopC
 
 	base.Stop()
+	auditLogger.Close()
 }
 
 // Stop stops the service
@@ -23,6 +23,7 @@ package history
 import "github.com/stretchr/testify/mock"
 import gohistory "github.com/uber/cadence/.gen/go/history"
 import "github.com/uber/cadence/.gen/go/shared"
+import "github.com/uber/tchannel-go/thrift"
 
 // MockHistoryEngine is used as mock implementation for HistoryEngine
 type MockHistoryEngine struct {
@@ -40,12 +41,12 @@ func (_m *MockHistoryEngine) Stop() {
 }
 
 // StartWorkflowExecution is mock implementation for StartWorkflowExecution of HistoryEngine
-func (_m *MockHistoryEngine) StartWorkflowExecution(request *gohistory.StartWorkflowExecutionRequest) (*shared.StartWorkflowExecutionResponse, error) {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) StartWorkflowExecution(ctx thrift.Context, request *gohistory.StartWorkflowExecutionRequest) (*shared.StartWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *shared.StartWorkflowExecutionResponse
-	if rf, ok := ret.Get(0).(func(*gohistory.StartWorkflowExecutionRequest) *shared.StartWorkflowExecutionResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.StartWorkflowExecutionRequest) *shared.StartWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*shared.StartWorkflowExecutionResponse)
@@ -53,8 +54,8 @@ func (_m *MockHistoryEngine) StartWorkflowExecution(request *gohistory.StartWork
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*gohistory.StartWorkflowExecutionRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.StartWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,12 +64,12 @@ func (_m *MockHistoryEngine) StartWorkflowExecution(request *gohistory.StartWork
 }
 
 // GetWorkflowExecutionNextEventID is mock implementation for GetWorkflowExecutionNextEventID of HistoryEngine
-func (_m *MockHistoryEngine) GetWorkflowExecutionNextEventID(request *gohistory.GetWorkflowExecutionNextEventIDRequest) (*gohistory.GetWorkflowExecutionNextEventIDResponse, error) {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) GetWorkflowExecutionNextEventID(ctx thrift.Context, request *gohistory.GetWorkflowExecutionNextEventIDRequest) (*gohistory.GetWorkflowExecutionNextEventIDResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *gohistory.GetWorkflowExecutionNextEventIDResponse
-	if rf, ok := ret.Get(0).(func(*gohistory.GetWorkflowExecutionNextEventIDRequest) *gohistory.GetWorkflowExecutionNextEventIDResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.GetWorkflowExecutionNextEventIDRequest) *gohistory.GetWorkflowExecutionNextEventIDResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gohistory.GetWorkflowExecutionNextEventIDResponse)
@@ -76,8 +77,8 @@ func (_m *MockHistoryEngine) GetWorkflowExecutionNextEventID(request *gohistory.
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*gohistory.GetWorkflowExecutionNextEventIDRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.GetWorkflowExecutionNextEventIDRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -86,12 +87,12 @@ func (_m *MockHistoryEngine) GetWorkflowExecutionNextEventID(request *gohistory.
 }
 
 // RecordDecisionTaskStarted is mock implementation for RecordDecisionTaskStarted of HistoryEngine
-func (_m *MockHistoryEngine) RecordDecisionTaskStarted(request *gohistory.RecordDecisionTaskStartedRequest) (*gohistory.RecordDecisionTaskStartedResponse, error) {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) RecordDecisionTaskStarted(ctx thrift.Context, request *gohistory.RecordDecisionTaskStartedRequest) (*gohistory.RecordDecisionTaskStartedResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *gohistory.RecordDecisionTaskStartedResponse
-	if rf, ok := ret.Get(0).(func(*gohistory.RecordDecisionTaskStartedRequest) *gohistory.RecordDecisionTaskStartedResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RecordDecisionTaskStartedRequest) *gohistory.RecordDecisionTaskStartedResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gohistory.RecordDecisionTaskStartedResponse)
@@ -99,8 +100,8 @@ func (_m *MockHistoryEngine) RecordDecisionTaskStarted(request *gohistory.Record
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*gohistory.RecordDecisionTaskStartedRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.RecordDecisionTaskStartedRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -109,12 +110,12 @@ func (_m *MockHistoryEngine) RecordDecisionTaskStarted(request *gohistory.Record
 }
 
 // RecordActivityTaskStarted is mock implementation for RecordActivityTaskStarted of HistoryEngine
-func (_m *MockHistoryEngine) RecordActivityTaskStarted(request *gohistory.RecordActivityTaskStartedRequest) (*gohistory.RecordActivityTaskStartedResponse, error) {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) RecordActivityTaskStarted(ctx thrift.Context, request *gohistory.RecordActivityTaskStartedRequest) (*gohistory.RecordActivityTaskStartedResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *gohistory.RecordActivityTaskStartedResponse
-	if rf, ok := ret.Get(0).(func(*gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gohistory.RecordActivityTaskStartedResponse)
@@ -122,8 +123,8 @@ func (_m *MockHistoryEngine) RecordActivityTaskStarted(request *gohistory.Record
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*gohistory.RecordActivityTaskStartedRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.RecordActivityTaskStartedRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -132,26 +133,35 @@ func (_m *MockHistoryEngine) RecordActivityTaskStarted(request *gohistory.Record
 }
 
 // RespondDecisionTaskCompleted is mock implementation for RespondDecisionTaskCompleted of HistoryEngine
-func (_m *MockHistoryEngine) RespondDecisionTaskCompleted(request *gohistory.RespondDecisionTaskCompletedRequest) error {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) RespondDecisionTaskCompleted(ctx thrift.Context, request *gohistory.RespondDecisionTaskCompletedRequest) (*shared.RespondDecisionTaskCompletedResponse, error) {
+	ret := _m.Called(ctx, request)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*gohistory.RespondDecisionTaskCompletedRequest) error); ok {
-		r0 = rf(request)
+	var r0 *shared.RespondDecisionTaskCompletedResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RespondDecisionTaskCompletedRequest) *shared.RespondDecisionTaskCompletedResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.RespondDecisionTaskCompletedResponse)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.RespondDecisionTaskCompletedRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // RespondActivityTaskCompleted is mock implementation for RespondActivityTaskCompleted of HistoryEngine
-func (_m *MockHistoryEngine) RespondActivityTaskCompleted(request *gohistory.RespondActivityTaskCompletedRequest) error {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) RespondActivityTaskCompleted(ctx thrift.Context, request *gohistory.RespondActivityTaskCompletedRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gohistory.RespondActivityTaskCompletedRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RespondActivityTaskCompletedRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -160,12 +170,12 @@ func (_m *MockHistoryEngine) RespondActivityTaskCompleted(request *gohistory.Res
 }
 
 // RespondActivityTaskFailed is mock implementation for RespondActivityTaskFailed of HistoryEngine
-func (_m *MockHistoryEngine) RespondActivityTaskFailed(request *gohistory.RespondActivityTaskFailedRequest) error {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) RespondActivityTaskFailed(ctx thrift.Context, request *gohistory.RespondActivityTaskFailedRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gohistory.RespondActivityTaskFailedRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RespondActivityTaskFailedRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -174,12 +184,12 @@ func (_m *MockHistoryEngine) RespondActivityTaskFailed(request *gohistory.Respon
 }
 
 // RespondActivityTaskCanceled is mock implementation for RespondActivityTaskCanceled of HistoryEngine
-func (_m *MockHistoryEngine) RespondActivityTaskCanceled(request *gohistory.RespondActivityTaskCanceledRequest) error {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) RespondActivityTaskCanceled(ctx thrift.Context, request *gohistory.RespondActivityTaskCanceledRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gohistory.RespondActivityTaskCanceledRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RespondActivityTaskCanceledRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -188,12 +198,12 @@ func (_m *MockHistoryEngine) RespondActivityTaskCanceled(request *gohistory.Resp
 }
 
 // RecordActivityTaskHeartbeat is mock implementation for RecordActivityTaskHeartbeat of HistoryEngine
-func (_m *MockHistoryEngine) RecordActivityTaskHeartbeat(request *gohistory.RecordActivityTaskHeartbeatRequest) (*shared.RecordActivityTaskHeartbeatResponse, error) {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) RecordActivityTaskHeartbeat(ctx thrift.Context, request *gohistory.RecordActivityTaskHeartbeatRequest) (*shared.RecordActivityTaskHeartbeatResponse, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *shared.RecordActivityTaskHeartbeatResponse
-	if rf, ok := ret.Get(0).(func(*gohistory.RecordActivityTaskHeartbeatRequest) *shared.RecordActivityTaskHeartbeatResponse); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RecordActivityTaskHeartbeatRequest) *shared.RecordActivityTaskHeartbeatResponse); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*shared.RecordActivityTaskHeartbeatResponse)
@@ -201,8 +211,8 @@ func (_m *MockHistoryEngine) RecordActivityTaskHeartbeat(request *gohistory.Reco
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*gohistory.RecordActivityTaskHeartbeatRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.RecordActivityTaskHeartbeatRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -211,12 +221,12 @@ func (_m *MockHistoryEngine) RecordActivityTaskHeartbeat(request *gohistory.Reco
 }
 
 // RequestCancelWorkflowExecution is mock implementation for RequestCancelWorkflowExecution of HistoryEngine
-func (_m *MockHistoryEngine) RequestCancelWorkflowExecution(request *gohistory.RequestCancelWorkflowExecutionRequest) error {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) RequestCancelWorkflowExecution(ctx thrift.Context, request *gohistory.RequestCancelWorkflowExecutionRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gohistory.RequestCancelWorkflowExecutionRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RequestCancelWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -225,12 +235,127 @@ func (_m *MockHistoryEngine) RequestCancelWorkflowExecution(request *gohistory.R
 }
 
 // SignalWorkflowExecution is mock implementation for SignalWorkflowExecution of HistoryEngine
-func (_m *MockHistoryEngine) SignalWorkflowExecution(request *gohistory.SignalWorkflowExecutionRequest) error {
-	ret := _m.Called(request)
+func (_m *MockHistoryEngine) SignalWorkflowExecution(ctx thrift.Context, request *gohistory.SignalWorkflowExecutionRequest) (*shared.SignalWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.SignalWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.SignalWorkflowExecutionRequest) *shared.SignalWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.SignalWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.SignalWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSignalReceipt is mock implementation for GetSignalReceipt of HistoryEngine
+func (_m *MockHistoryEngine) GetSignalReceipt(ctx thrift.Context, request *gohistory.GetSignalReceiptRequest) (*shared.GetSignalReceiptResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.GetSignalReceiptResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.GetSignalReceiptRequest) *shared.GetSignalReceiptResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetSignalReceiptResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.GetSignalReceiptRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryWorkflow is mock implementation for QueryWorkflow of HistoryEngine
+func (_m *MockHistoryEngine) QueryWorkflow(ctx thrift.Context, request *gohistory.QueryWorkflowRequest) (*shared.QueryWorkflowResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.QueryWorkflowResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.QueryWorkflowRequest) *shared.QueryWorkflowResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.QueryWorkflowResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.QueryWorkflowRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeWorkflowExecution is mock implementation for DescribeWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) DescribeWorkflowExecution(ctx thrift.Context, request *gohistory.DescribeWorkflowExecutionRequest) (*shared.DescribeWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.DescribeWorkflowExecutionRequest) *shared.DescribeWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.DescribeWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TerminateWorkflowExecution is mock implementation for TerminateWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) TerminateWorkflowExecution(ctx thrift.Context, request *gohistory.TerminateWorkflowExecutionRequest) (*shared.TerminateWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.TerminateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.TerminateWorkflowExecutionRequest) *shared.TerminateWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.TerminateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.TerminateWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleDecisionTask is mock implementation for ScheduleDecisionTask of HistoryEngine
+func (_m *MockHistoryEngine) ScheduleDecisionTask(ctx thrift.Context, request *gohistory.ScheduleDecisionTaskRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gohistory.SignalWorkflowExecutionRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.ScheduleDecisionTaskRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -238,13 +363,13 @@ func (_m *MockHistoryEngine) SignalWorkflowExecution(request *gohistory.SignalWo
 	return r0
 }
 
-// TerminateWorkflowExecution is mock implementation for TerminateWorkflowExecution of HistoryEngine
-func (_m *MockHistoryEngine) TerminateWorkflowExecution(request *gohistory.TerminateWorkflowExecutionRequest) error {
-	ret := _m.Called(request)
+// RecordChildExecutionCompleted is mock implementation for CompleteChildExecution of HistoryEngine
+func (_m *MockHistoryEngine) RecordChildExecutionCompleted(ctx thrift.Context, request *gohistory.RecordChildExecutionCompletedRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gohistory.TerminateWorkflowExecutionRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RecordChildExecutionCompletedRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -252,13 +377,13 @@ func (_m *MockHistoryEngine) TerminateWorkflowExecution(request *gohistory.Termi
 	return r0
 }
 
-// ScheduleDecisionTask is mock implementation for ScheduleDecisionTask of HistoryEngine
-func (_m *MockHistoryEngine) ScheduleDecisionTask(request *gohistory.ScheduleDecisionTaskRequest) error {
-	ret := _m.Called(request)
+// UpdateQueueProcessing is mock implementation for UpdateQueueProcessing of HistoryEngine
+func (_m *MockHistoryEngine) UpdateQueueProcessing(ctx thrift.Context, request *gohistory.UpdateQueueProcessingRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gohistory.ScheduleDecisionTaskRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.UpdateQueueProcessingRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -266,13 +391,36 @@ func (_m *MockHistoryEngine) ScheduleDecisionTask(request *gohistory.ScheduleDec
 	return r0
 }
 
-// RecordChildExecutionCompleted is mock implementation for CompleteChildExecution of HistoryEngine
-func (_m *MockHistoryEngine) RecordChildExecutionCompleted(request *gohistory.RecordChildExecutionCompletedRequest) error {
-	ret := _m.Called(request)
+// DescribeMutableState is mock implementation for DescribeMutableState of HistoryEngine
+func (_m *MockHistoryEngine) DescribeMutableState(ctx thrift.Context, request *gohistory.DescribeMutableStateRequest) (*gohistory.DescribeMutableStateResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *gohistory.DescribeMutableStateResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.DescribeMutableStateRequest) *gohistory.DescribeMutableStateResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gohistory.DescribeMutableStateResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.DescribeMutableStateRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshWorkflowTasks is mock implementation for RefreshWorkflowTasks of HistoryEngine
+func (_m *MockHistoryEngine) RefreshWorkflowTasks(ctx thrift.Context, request *gohistory.RefreshWorkflowTasksRequest) error {
+	ret := _m.Called(ctx, request)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gohistory.RecordChildExecutionCompletedRequest) error); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RefreshWorkflowTasksRequest) error); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -280,4 +428,41 @@ func (_m *MockHistoryEngine) RecordChildExecutionCompleted(request *gohistory.Re
 	return r0
 }
 
+// MigrateWorkflowExecution is mock implementation for MigrateWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) MigrateWorkflowExecution(ctx thrift.Context, request *gohistory.MigrateWorkflowExecutionRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.MigrateWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RedriveTransferDLQTasks is mock implementation for RedriveTransferDLQTasks of HistoryEngine
+func (_m *MockHistoryEngine) RedriveTransferDLQTasks(ctx thrift.Context, request *gohistory.RedriveTransferDLQTasksRequest) (*gohistory.RedriveTransferDLQTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *gohistory.RedriveTransferDLQTasksResponse
+	if rf, ok := ret.Get(0).(func(thrift.Context, *gohistory.RedriveTransferDLQTasksRequest) *gohistory.RedriveTransferDLQTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gohistory.RedriveTransferDLQTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(thrift.Context, *gohistory.RedriveTransferDLQTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 var _ Engine = (*MockHistoryEngine)(nil)
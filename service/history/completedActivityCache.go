@@ -0,0 +1,102 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"hash/fnv"
+	"strconv"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+)
+
+const (
+	completedActivityCacheInitialSize = 256
+)
+
+type (
+	// completedActivityCache remembers the activities recently completed through the shards of a host, along with a
+	// hash of their result.  A completion retried by a worker after a network timeout no longer finds its activity in
+	// the mutable state, it is acknowledged from the cache when it carries the same result instead of failing.
+	// Schedule IDs are never reused within a run, so entries need no invalidation.
+	completedActivityCache struct {
+		cache.Cache
+	}
+)
+
+// newCompletedActivityCache returns nil when the cache is disabled, all the methods of the cache are no-ops on nil
+func newCompletedActivityCache(config *Config) *completedActivityCache {
+	if config.CompletedActivityCacheSize <= 0 {
+		return nil
+	}
+
+	opts := &cache.Options{}
+	opts.InitialCapacity = completedActivityCacheInitialSize
+	opts.TTL = config.CompletedActivityCacheTTL
+
+	return &completedActivityCache{
+		Cache: cache.New(config.CompletedActivityCacheSize, opts),
+	}
+}
+
+// completedActivityKey identifies the activity by schedule ID, or by activity ID for tokens made up from it
+func completedActivityKey(domainID string, execution workflow.WorkflowExecution, scheduleID int64,
+	activityID string) string {
+	key := completedExecutionKey(domainID, execution)
+	if scheduleID != common.EmptyEventID {
+		return key + "/" + strconv.FormatInt(scheduleID, 10)
+	}
+	return key + "/id/" + activityID
+}
+
+func hashActivityResult(result []byte) uint64 {
+	hash := fnv.New64a()
+	hash.Write(result)
+	return hash.Sum64()
+}
+
+func (c *completedActivityCache) put(domainID string, execution workflow.WorkflowExecution, scheduleID int64,
+	activityID string, result []byte) {
+	if c == nil {
+		return
+	}
+
+	resultHash := hashActivityResult(result)
+	c.Put(completedActivityKey(domainID, execution, scheduleID, ""), resultHash)
+	c.Put(completedActivityKey(domainID, execution, common.EmptyEventID, activityID), resultHash)
+}
+
+// isDuplicate returns true when the completion of the activity the token refers to, with the same result, went
+// through already.  Tokens without a RunId target the current run of the workflow, which can't be answered from the
+// cache.
+func (c *completedActivityCache) isDuplicate(domainID string, token *common.TaskToken, result []byte) bool {
+	if c == nil || token.RunID == "" {
+		return false
+	}
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(token.WorkflowID),
+		RunId:      common.StringPtr(token.RunID),
+	}
+	resultHash, ok := c.Get(completedActivityKey(domainID, execution, token.ScheduleID, token.ActivityID)).(uint64)
+	return ok && resultHash == hashActivityResult(result)
+}
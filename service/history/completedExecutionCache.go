@@ -0,0 +1,89 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/cache"
+)
+
+const (
+	completedExecutionCacheInitialSize = 256
+)
+
+type (
+	// completedExecutionCache remembers the close status of the executions recently closed by the shards of a host.
+	// Retries of activity completions and signals racing the close of an execution are rejected from it, without
+	// loading the mutable state of the execution.  Closed executions never run again, so entries need no invalidation.
+	completedExecutionCache struct {
+		cache.Cache
+	}
+
+	completedExecution struct {
+		closeStatus int
+		closeTime   time.Time
+	}
+)
+
+// newCompletedExecutionCache returns nil when the cache is disabled, all the methods of the cache are no-ops on nil
+func newCompletedExecutionCache(config *Config) *completedExecutionCache {
+	if config.CompletedExecutionCacheSize <= 0 {
+		return nil
+	}
+
+	opts := &cache.Options{}
+	opts.InitialCapacity = completedExecutionCacheInitialSize
+	opts.TTL = config.CompletedExecutionCacheTTL
+
+	return &completedExecutionCache{
+		Cache: cache.New(config.CompletedExecutionCacheSize, opts),
+	}
+}
+
+func completedExecutionKey(domainID string, execution workflow.WorkflowExecution) string {
+	return domainID + "/" + execution.GetWorkflowId() + "/" + execution.GetRunId()
+}
+
+func (c *completedExecutionCache) put(domainID string, execution workflow.WorkflowExecution, closeStatus int,
+	closeTime time.Time) {
+	if c == nil {
+		return
+	}
+
+	c.Put(completedExecutionKey(domainID, execution), &completedExecution{
+		closeStatus: closeStatus,
+		closeTime:   closeTime,
+	})
+}
+
+// get returns the close status of the execution if it is known to be closed.  Requests without a RunId target the
+// current run of the workflow, which can't be answered from the cache.
+func (c *completedExecutionCache) get(domainID string, execution workflow.WorkflowExecution) (*completedExecution,
+	bool) {
+	if c == nil || execution.GetRunId() == "" {
+		return nil, false
+	}
+
+	closed, ok := c.Get(completedExecutionKey(domainID, execution)).(*completedExecution)
+	return closed, ok
+}
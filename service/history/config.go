@@ -0,0 +1,151 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"time"
+)
+
+// Config represents configuration for cadence-history service
+type Config struct {
+	// DecisionScheduleToStartTimeout is how long a decision task waits on its task list for a poller before it
+	// times out and is scheduled again.  Zero, the default, disables the timeout.
+	DecisionScheduleToStartTimeout time.Duration
+	// DecisionRetryInitialInterval is how long dispatch of a decision is delayed after the first failed or timed out
+	// attempt.  The delay doubles with every consecutive failure up to DecisionRetryMaxInterval.
+	DecisionRetryInitialInterval time.Duration
+	DecisionRetryMaxInterval     time.Duration
+	// TimerJitterWindow is the window over which the processing of timers sharing a fire time is spread once more
+	// than TimerJitterThreshold of them fire within the same second.  Zero disables the jitter.
+	TimerJitterWindow    time.Duration
+	TimerJitterThreshold int
+	// TimerMaxClockSkew is how far the clock of a history host is trusted to be behind the clock of the previous
+	// owner of a shard.  Timers up to the persisted timer ack level of the shard are due on the new owner as long as
+	// the ack level isn't more than TimerMaxClockSkew ahead of its clock.  Zero only trusts the clock of the host.
+	TimerMaxClockSkew time.Duration
+	// TransferMatchingRetryInitialInterval is how long a decision or activity transfer task is rescheduled for after
+	// it first failed to be added to matching, without holding a task worker.  The delay doubles with every
+	// consecutive failure up to TransferMatchingRetryMaxInterval.  Once TransferMatchingMaxAttempts attempts failed
+	// the task is parked in the dead letter queue of the shard, so the ack level moves past it, until the
+	// RedriveTransferDLQTasks admin API moves it back to the transfer queue.  Zero never parks it.
+	TransferMatchingRetryInitialInterval time.Duration
+	TransferMatchingRetryMaxInterval     time.Duration
+	TransferMatchingMaxAttempts          int
+	// TransferThrottleMaxRetries is how many times in a row a decision or activity transfer task is retried after
+	// matching throttled the shard, before it is rescheduled like a task which failed to be added to matching
+	TransferThrottleMaxRetries int
+	// TransferQueueProcessingPaused and TimerQueueProcessingPaused start the queue processors of every shard paused,
+	// until resumed through the UpdateQueueProcessing admin API.  The state set through the API is persisted on the
+	// shard and takes precedence
+	TransferQueueProcessingPaused bool
+	TimerQueueProcessingPaused    bool
+	// LoadSheddingTransferQueueDepth and LoadSheddingPersistenceLatency are the pending transfer task count and the
+	// average persistence latency above which a shard is considered overloaded.  Zero disables the check.  Activity
+	// heartbeats to an overloaded shard are rejected with ServiceBusyError, and once the load reaches
+	// LoadSheddingPollOverloadFactor times the thresholds task starts for polls are rejected as well.
+	LoadSheddingTransferQueueDepth int64
+	LoadSheddingPersistenceLatency time.Duration
+	LoadSheddingPollOverloadFactor float64
+	// MutableStateChecksumFailFast fails the load of a workflow execution whose mutable state doesn't match the
+	// checksum persisted with its last update, instead of only logging and counting the mismatch
+	MutableStateChecksumFailFast bool
+	// ExecutionScavengerInterval is the interval between scans of the shards owned by a host for orphaned mutable
+	// state, histories and tasks.  Zero disables the scans.  Garbage is only reported unless
+	// ExecutionScavengerDeleteGarbage is set.
+	ExecutionScavengerInterval      time.Duration
+	ExecutionScavengerDeleteGarbage bool
+	// StaleExecutionMonitorInterval is the interval between scans of the shards owned by a host for stale
+	// executions: running executions with a decision pending and no update for StaleDecisionTimeoutFactor times the
+	// decision timeout, and executions with a timer still pending StaleTimerThreshold after its fire time.  Zero
+	// disables the scans.
+	StaleExecutionMonitorInterval time.Duration
+	StaleDecisionTimeoutFactor    int
+	StaleTimerThreshold           time.Duration
+	// QueryTimeout is how long a query waits to be answered by the worker with the completion of a decision task or
+	// query task
+	QueryTimeout time.Duration
+	// MaxDecisionsPerCompletion is the most decisions a decision task completion may carry, completions with more
+	// fail the decision task.  Zero disables the limit.
+	MaxDecisionsPerCompletion int
+	// MaxPendingTimersPerExecution is the most user timers an execution may have pending, decisions starting more
+	// timers fail the decision task.  Zero disables the limit.
+	MaxPendingTimersPerExecution int
+	// ActivityTasksPerUpdate is the most activities scheduled by a decision whose transfer and timer tasks are written
+	// with the update completing the decision, so a decision scheduling many activities doesn't exceed the batch
+	// limits of the execution store.  The tasks of the other activities are written by continuation transfer tasks,
+	// ActivityTasksPerUpdate activities at a time.  Zero disables the limit.
+	ActivityTasksPerUpdate int
+	// HistoryEventsPerBatch is the most events appended to the history of an execution with a single write, the
+	// events of larger updates are split across writes.  Zero disables the limit.
+	HistoryEventsPerBatch int
+	// DomainTaskWeights are the weights, by domain name, of domains in the fair scheduling of the tasks of the
+	// transfer and timer queues of a shard.  A domain gets to process up to its weight of tasks in a row before the
+	// other domains with pending tasks, domains not listed have a weight of 1.  Only the tasks a queue processor
+	// has read ahead of its workers, up to 1000 of them, are scheduled fairly.
+	DomainTaskWeights map[string]int
+	// SignalBatchMaxSize is the most signals to a workflow execution applied together with a single update, when
+	// signals are received faster than the execution is updated.  Zero disables the limit.
+	SignalBatchMaxSize int
+	// CompletedExecutionCacheSize is the most recently closed executions whose close status a host remembers for
+	// CompletedExecutionCacheTTL, so requests against them are rejected without loading their mutable state.  Zero
+	// disables the cache.
+	CompletedExecutionCacheSize int
+	CompletedExecutionCacheTTL  time.Duration
+	// CompletedActivityCacheSize is the most recently completed activities a host remembers for
+	// CompletedActivityCacheTTL, so completions retried by workers after a timeout succeed without appending a second
+	// completion to the history.  Zero disables the cache.
+	CompletedActivityCacheSize int
+	CompletedActivityCacheTTL  time.Duration
+	// ActivityExternalCompletionTimeout is the longest a started activity without a heartbeat timeout waits for its
+	// completion, from the worker or from another process through the activity ID based APIs, before it times out.
+	// Longer start-to-close timeouts of such activities are cut down to it when they start.  Zero disables the bound.
+	ActivityExternalCompletionTimeout time.Duration
+}
+
+// NewConfig returns new service config with default values
+func NewConfig() *Config {
+	return &Config{
+		DecisionScheduleToStartTimeout:       0,
+		DecisionRetryInitialInterval:         time.Second,
+		DecisionRetryMaxInterval:             time.Minute,
+		TimerJitterWindow:                    0,
+		TimerJitterThreshold:                 1000,
+		TimerMaxClockSkew:                    5 * time.Second,
+		TransferMatchingRetryInitialInterval: 100 * time.Millisecond,
+		TransferMatchingRetryMaxInterval:     10 * time.Second,
+		TransferThrottleMaxRetries:           10,
+		LoadSheddingTransferQueueDepth:       10000,
+		LoadSheddingPersistenceLatency:       time.Second,
+		LoadSheddingPollOverloadFactor:       2,
+		QueryTimeout:                         10 * time.Second,
+		MaxDecisionsPerCompletion:            1000,
+		MaxPendingTimersPerExecution:         10000,
+		ActivityTasksPerUpdate:               100,
+		HistoryEventsPerBatch:                500,
+		StaleDecisionTimeoutFactor:           10,
+		StaleTimerThreshold:                  10 * time.Minute,
+		SignalBatchMaxSize:                   100,
+		CompletedExecutionCacheSize:          10000,
+		CompletedExecutionCacheTTL:           10 * time.Minute,
+		CompletedActivityCacheSize:           10000,
+		CompletedActivityCacheTTL:            10 * time.Minute,
+	}
+}
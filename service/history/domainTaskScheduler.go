@@ -0,0 +1,166 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+
+	"github.com/uber/cadence/common/cache"
+)
+
+const (
+	// domainTaskSchedulerCapacity is the most tasks a queue processor reads ahead of its workers, it bounds the
+	// window of tasks the scheduler can reorder
+	domainTaskSchedulerCapacity = 1000
+	defaultDomainTaskWeight     = 1
+)
+
+type (
+	// domainTaskScheduler hands the tasks of a queue processor to its workers in weighted round-robin order of their
+	// domains, so a domain with a large backlog of tasks can't monopolize the processing of a shard.  A domain with
+	// pending tasks dispatches up to its weight of tasks in a row before the next domain gets its turn, and the tasks
+	// of a domain are dispatched in the order they were submitted.
+	//
+	// The queue processors still read the tasks of a shard in task order, so the scheduling is only fair within the
+	// capacity tasks read ahead of the workers.  A task further than capacity tasks behind the backlog of a busy
+	// domain is submitted once enough of that backlog is dispatched to bring it into the window, until then the
+	// busy domain has the workers to itself.
+	domainTaskScheduler struct {
+		sync.Mutex
+		notEmpty *sync.Cond
+		notFull  *sync.Cond
+		weightFn func(domainID string) int
+		capacity int
+		size     int
+		queues   map[string][]interface{}
+		weights  map[string]int
+		ring     []string // domains with pending tasks, in the order they get their turn
+		credit   int      // tasks ring[0] may still dispatch in its current turn
+		closed   bool
+	}
+)
+
+func newDomainTaskScheduler(capacity int, weightFn func(domainID string) int) *domainTaskScheduler {
+	s := &domainTaskScheduler{
+		weightFn: weightFn,
+		capacity: capacity,
+		queues:   make(map[string][]interface{}),
+		weights:  make(map[string]int),
+	}
+	s.notEmpty = sync.NewCond(s)
+	s.notFull = sync.NewCond(s)
+	return s
+}
+
+// newDomainWeightFn returns the weights of domains configured by name in DomainTaskWeights
+func newDomainWeightFn(config *Config, domainCache cache.DomainCache) func(domainID string) int {
+	return func(domainID string) int {
+		if len(config.DomainTaskWeights) == 0 {
+			return defaultDomainTaskWeight
+		}
+		info, _, err := domainCache.GetDomainByID(domainID)
+		if err != nil {
+			return defaultDomainTaskWeight
+		}
+		if weight, ok := config.DomainTaskWeights[info.Name]; ok && weight > 0 {
+			return weight
+		}
+		return defaultDomainTaskWeight
+	}
+}
+
+// submit queues a task of the domain, blocking while the scheduler is full.  It returns false once the scheduler is
+// closed.
+func (s *domainTaskScheduler) submit(domainID string, task interface{}) bool {
+	// Resolved before taking the lock, the domain cache may have to go to persistence
+	weight := s.weightFn(domainID)
+
+	s.Lock()
+	defer s.Unlock()
+
+	for s.size >= s.capacity && !s.closed {
+		s.notFull.Wait()
+	}
+	if s.closed {
+		return false
+	}
+
+	queue, ok := s.queues[domainID]
+	if !ok {
+		s.ring = append(s.ring, domainID)
+		s.weights[domainID] = weight
+	}
+	s.queues[domainID] = append(queue, task)
+	s.size++
+	s.notEmpty.Signal()
+	return true
+}
+
+// next returns the next task to process, blocking while the scheduler is empty.  Tasks still queued when the
+// scheduler is closed are returned, it returns false once they are drained.
+func (s *domainTaskScheduler) next() (interface{}, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	for s.size == 0 {
+		if s.closed {
+			return nil, false
+		}
+		s.notEmpty.Wait()
+	}
+
+	domainID := s.ring[0]
+	if s.credit <= 0 {
+		s.credit = s.weights[domainID]
+	}
+	queue := s.queues[domainID]
+	task := queue[0]
+	queue[0] = nil
+	queue = queue[1:]
+	s.credit--
+	s.size--
+
+	if len(queue) == 0 {
+		delete(s.queues, domainID)
+		delete(s.weights, domainID)
+		s.ring = s.ring[1:]
+		s.credit = 0
+	} else {
+		s.queues[domainID] = queue
+		if s.credit == 0 {
+			// Turn is over, the domain goes to the back of the ring
+			s.ring = append(s.ring[1:], domainID)
+		}
+	}
+
+	s.notFull.Signal()
+	return task, true
+}
+
+// close stops the scheduler from accepting tasks and wakes up the blocked callers
+func (s *domainTaskScheduler) close() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.closed = true
+	s.notEmpty.Broadcast()
+	s.notFull.Broadcast()
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	domainTaskSchedulerSuite struct {
+		suite.Suite
+	}
+)
+
+func TestDomainTaskSchedulerSuite(t *testing.T) {
+	s := new(domainTaskSchedulerSuite)
+	suite.Run(t, s)
+}
+
+func (s *domainTaskSchedulerSuite) TestWeightedRoundRobin() {
+	weights := map[string]int{"busy": 2}
+	scheduler := newDomainTaskScheduler(100, func(domainID string) int {
+		if weight, ok := weights[domainID]; ok {
+			return weight
+		}
+		return defaultDomainTaskWeight
+	})
+
+	for i := 0; i < 5; i++ {
+		s.True(scheduler.submit("busy", "busy"))
+	}
+	s.True(scheduler.submit("quiet", "quiet"))
+	s.True(scheduler.submit("other", "other"))
+	s.True(scheduler.submit("other", "other"))
+
+	expected := []string{"busy", "busy", "quiet", "other", "busy", "busy", "other", "busy"}
+	for _, domain := range expected {
+		task, ok := scheduler.next()
+		s.True(ok)
+		s.Equal(domain, task)
+	}
+}
+
+func (s *domainTaskSchedulerSuite) TestTasksOfDomainInOrder() {
+	scheduler := newDomainTaskScheduler(100, func(string) int { return 3 })
+	for i := 0; i < 10; i++ {
+		s.True(scheduler.submit("domain", i))
+	}
+
+	for i := 0; i < 10; i++ {
+		task, ok := scheduler.next()
+		s.True(ok)
+		s.Equal(i, task)
+	}
+}
+
+func (s *domainTaskSchedulerSuite) TestClose() {
+	scheduler := newDomainTaskScheduler(100, func(string) int { return defaultDomainTaskWeight })
+	s.True(scheduler.submit("domain", 1))
+	scheduler.close()
+	s.False(scheduler.submit("domain", 2))
+
+	// Tasks queued before the scheduler was closed are drained
+	task, ok := scheduler.next()
+	s.True(ok)
+	s.Equal(1, task)
+	_, ok = scheduler.next()
+	s.False(ok)
+}
+
+func (s *domainTaskSchedulerSuite) TestSubmitBlocksWhileFull() {
+	scheduler := newDomainTaskScheduler(1, func(string) int { return defaultDomainTaskWeight })
+	s.True(scheduler.submit("domain", 1))
+
+	submitted := make(chan bool)
+	go func() {
+		submitted <- scheduler.submit("domain", 2)
+	}()
+
+	task, ok := scheduler.next()
+	s.True(ok)
+	s.Equal(1, task)
+	s.True(<-submitted)
+	task, ok = scheduler.next()
+	s.True(ok)
+	s.Equal(2, task)
+}
@@ -0,0 +1,412 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
+
+	h "github.com/uber/cadence/.gen/go/history"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	hc "github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	executionScavengerPageSize = 100
+)
+
+const (
+	executionScavengerStatusInitialized = iota
+	executionScavengerStatusStarted
+	executionScavengerStatusStopped
+)
+
+type (
+	// executionScavenger periodically scans the shards owned by this host for garbage: mutable state of running
+	// executions which are not the current run of their workflow, histories of runs which neither have mutable state
+	// nor were closed, and transfer and timer tasks of runs without mutable state.  Garbage is reported and, if
+	// ExecutionScavengerDeleteGarbage is set, deleted once two consecutive scans found it, so executions in the
+	// middle of being created or deleted are left alone.
+	executionScavenger struct {
+		controller         *shardController
+		historyMgr         persistence.HistoryManager
+		historyClient      hc.Client
+		numberOfShards     int
+		config             *Config
+		hSerializerFactory persistence.HistorySerializerFactory
+		status             int32
+		shutdownCh         chan struct{}
+		shutdownWG         sync.WaitGroup
+		logger             bark.Logger
+		metricsClient      metrics.Client
+
+		// suspects is the garbage found by the previous scan
+		suspects map[string]struct{}
+	}
+)
+
+func newExecutionScavenger(controller *shardController, historyMgr persistence.HistoryManager,
+	historyClient hc.Client, numberOfShards int, config *Config, logger bark.Logger,
+	metricsClient metrics.Client) *executionScavenger {
+	return &executionScavenger{
+		controller:         controller,
+		historyMgr:         historyMgr,
+		historyClient:      historyClient,
+		numberOfShards:     numberOfShards,
+		config:             config,
+		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+		status:             executionScavengerStatusInitialized,
+		shutdownCh:         make(chan struct{}),
+		logger:             logger.WithField(logging.TagWorkflowComponent, logging.TagValueExecutionScavenger),
+		metricsClient:      metricsClient,
+		suspects:           make(map[string]struct{}),
+	}
+}
+
+func (s *executionScavenger) Start() {
+	if !atomic.CompareAndSwapInt32(&s.status, executionScavengerStatusInitialized, executionScavengerStatusStarted) {
+		return
+	}
+	s.shutdownWG.Add(1)
+	go s.scavengeLoop()
+}
+
+func (s *executionScavenger) Stop() {
+	if !atomic.CompareAndSwapInt32(&s.status, executionScavengerStatusStarted, executionScavengerStatusStopped) {
+		return
+	}
+	close(s.shutdownCh)
+	s.shutdownWG.Wait()
+}
+
+func (s *executionScavenger) scavengeLoop() {
+	defer s.shutdownWG.Done()
+
+	ticker := time.NewTicker(s.config.ExecutionScavengerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-ticker.C:
+			s.scavenge()
+		}
+	}
+}
+
+func (s *executionScavenger) scavenge() {
+	found := make(map[string]struct{})
+	for shardID, shard := range s.controller.getShardContexts() {
+		if s.isStopped() {
+			return
+		}
+		s.scavengeShard(shardID, shard, found)
+	}
+	s.logger.Infof("Found %v garbage executions, histories and tasks", len(found))
+	s.suspects = found
+}
+
+func (s *executionScavenger) scavengeShard(shardID int, shard ShardContext, found map[string]struct{}) {
+	runIDs, ok := s.scavengeExecutions(shard, found)
+	if ok {
+		// tasks can only be checked against the complete list of executions of the shard
+		s.scavengeTransferTasks(shardID, shard, runIDs, found)
+		s.scavengeTimerTasks(shardID, shard, runIDs, found)
+	}
+	s.scavengeHistories(shardID, found)
+}
+
+// scavengeExecutions checks the mutable state of all executions of the shard.  Returns the run IDs of all
+// executions, and false if the shard could not be scanned completely.
+func (s *executionScavenger) scavengeExecutions(shard ShardContext,
+	found map[string]struct{}) (map[string]struct{}, bool) {
+	executionMgr := shard.GetExecutionManager()
+	runIDs := make(map[string]struct{})
+	var pageToken []byte
+	for {
+		response, err := executionMgr.ListExecutions(context.Background(), &persistence.ListExecutionsRequest{
+			PageSize:      executionScavengerPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			s.logger.WithField(logging.TagErr, err).Warn("Failed to list executions")
+			return nil, false
+		}
+
+		for _, info := range response.Executions {
+			if s.isStopped() {
+				return nil, false
+			}
+			runIDs[info.RunID] = struct{}{}
+			if !s.isOrphanedExecution(executionMgr, info) {
+				continue
+			}
+
+			key := fmt.Sprintf("execution/%v/%v/%v", info.DomainID, info.WorkflowID, info.RunID)
+			if s.collect(found, key, metrics.ExecutionScavengerOrphanedExecutionsCounter) {
+				s.delete(key, shard.DeleteWorkflowExecution(context.Background(),
+					&persistence.DeleteWorkflowExecutionRequest{ExecutionInfo: info}))
+			}
+		}
+
+		if len(response.NextPageToken) == 0 {
+			return runIDs, true
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+// isOrphanedExecution returns true if the execution is running but is not the current run of its workflow
+func (s *executionScavenger) isOrphanedExecution(executionMgr persistence.ExecutionManager,
+	info *persistence.WorkflowExecutionInfo) bool {
+	if info.State == persistence.WorkflowStateCompleted {
+		// the mutable state of closed executions is deleted by their delete history timer once retention expired
+		return false
+	}
+
+	response, err := executionMgr.GetCurrentExecution(context.Background(), &persistence.GetCurrentExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+	})
+	if err != nil {
+		_, ok := err.(*workflow.EntityNotExistsError)
+		return ok
+	}
+	return response.RunID != info.RunID
+}
+
+func (s *executionScavenger) scavengeTransferTasks(shardID int, shard ShardContext, runIDs map[string]struct{},
+	found map[string]struct{}) {
+	executionMgr := shard.GetExecutionManager()
+	readLevel := shard.GetTransferAckLevel()
+	maxReadLevel := shard.GetTransferMaxReadLevel()
+	for {
+		response, err := executionMgr.GetTransferTasks(context.Background(), &persistence.GetTransferTasksRequest{
+			ReadLevel:    readLevel,
+			MaxReadLevel: maxReadLevel,
+			BatchSize:    executionScavengerPageSize,
+		})
+		if err != nil {
+			s.logger.WithField(logging.TagErr, err).Warn("Failed to read transfer tasks")
+			return
+		}
+
+		for _, task := range response.Tasks {
+			readLevel = task.TaskID
+			if _, ok := runIDs[task.RunID]; ok {
+				continue
+			}
+
+			key := fmt.Sprintf("transfer/%v/%v", shardID, task.TaskID)
+			if s.collect(found, key, metrics.ExecutionScavengerOrphanedTasksCounter) {
+				err := shard.CompleteTransferTask(context.Background(),
+					&persistence.CompleteTransferTaskRequest{TaskID: task.TaskID})
+				if err == nil {
+					shard.TransferTaskProcessed(task.TaskID)
+				}
+				s.delete(key, err)
+			}
+		}
+
+		if len(response.Tasks) < executionScavengerPageSize || s.isStopped() {
+			return
+		}
+	}
+}
+
+func (s *executionScavenger) scavengeTimerTasks(shardID int, shard ShardContext, runIDs map[string]struct{},
+	found map[string]struct{}) {
+	for _, queue := range persistence.TimerQueues {
+		s.scavengeTimerQueue(shardID, shard, queue, runIDs, found)
+	}
+}
+
+func (s *executionScavenger) scavengeTimerQueue(shardID int, shard ShardContext, queue int,
+	runIDs map[string]struct{}, found map[string]struct{}) {
+	executionMgr := shard.GetExecutionManager()
+	minKey := int64(0)
+	for {
+		response, err := executionMgr.GetTimerIndexTasks(context.Background(), &persistence.GetTimerIndexTasksRequest{
+			Queue:     queue,
+			MinKey:    minKey,
+			MaxKey:    math.MaxInt64,
+			BatchSize: executionScavengerPageSize,
+		})
+		if err != nil {
+			s.logger.WithField(logging.TagErr, err).Warn("Failed to read timer tasks")
+			return
+		}
+
+		for _, task := range response.Timers {
+			minKey = task.TaskID + 1
+			if _, ok := runIDs[task.RunID]; ok {
+				continue
+			}
+
+			key := fmt.Sprintf("timer/%v/%v/%v", shardID, queue, task.TaskID)
+			if s.collect(found, key, metrics.ExecutionScavengerOrphanedTasksCounter) {
+				s.delete(key, shard.CompleteTimerTask(context.Background(),
+					&persistence.CompleteTimerTaskRequest{Queue: queue, TaskID: task.TaskID}))
+			}
+		}
+
+		if len(response.Timers) < executionScavengerPageSize || s.isStopped() {
+			return
+		}
+	}
+}
+
+// scavengeHistories checks the histories of the history partition matching the shard, so that every history is
+// checked exactly once across all shards
+func (s *executionScavenger) scavengeHistories(shardID int, found map[string]struct{}) {
+	var pageToken []byte
+	for {
+		response, err := s.historyMgr.ListWorkflowExecutionHistories(context.Background(),
+			&persistence.ListWorkflowExecutionHistoriesRequest{
+				Partition:     shardID,
+				NumPartitions: s.numberOfShards,
+				PageSize:      executionScavengerPageSize,
+				NextPageToken: pageToken,
+			})
+		if err != nil {
+			s.logger.WithField(logging.TagErr, err).Warn("Failed to list histories")
+			return
+		}
+
+		for _, history := range response.Histories {
+			if s.isStopped() {
+				return
+			}
+			if !s.isOrphanedHistory(history) {
+				continue
+			}
+
+			key := fmt.Sprintf("history/%v/%v/%v", history.DomainID, history.Execution.GetWorkflowId(),
+				history.Execution.GetRunId())
+			if s.collect(found, key, metrics.ExecutionScavengerOrphanedHistoriesCounter) {
+				s.delete(key, s.historyMgr.DeleteWorkflowExecutionHistory(context.Background(),
+					&persistence.DeleteWorkflowExecutionHistoryRequest{
+						DomainID:  history.DomainID,
+						Execution: history.Execution,
+					}))
+			}
+		}
+
+		if len(response.NextPageToken) == 0 {
+			return
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+// isOrphanedHistory returns true if the run of the history has no mutable state and was not closed.  The mutable
+// state is looked up through the history service, as the run can belong to a shard owned by another host.
+func (s *executionScavenger) isOrphanedHistory(history *persistence.WorkflowExecutionHistoryInfo) bool {
+	_, err := s.historyClient.GetWorkflowExecutionNextEventID(nil, &h.GetWorkflowExecutionNextEventIDRequest{
+		DomainUUID: &history.DomainID,
+		Execution:  &history.Execution,
+	})
+	if _, ok := err.(*workflow.EntityNotExistsError); !ok {
+		return false
+	}
+
+	lastEvent, err := s.getLastHistoryEvent(history)
+	if err != nil {
+		s.logger.WithField(logging.TagErr, err).Warnf("Failed to read history of run %v",
+			history.Execution.GetRunId())
+		return false
+	}
+	return lastEvent == nil || !common.IsWorkflowCloseEvent(lastEvent.GetEventType())
+}
+
+func (s *executionScavenger) getLastHistoryEvent(
+	history *persistence.WorkflowExecutionHistoryInfo) (*workflow.HistoryEvent, error) {
+	var lastBatch *persistence.SerializedHistoryEventBatch
+	var pageToken []byte
+	for {
+		response, err := s.historyMgr.GetWorkflowExecutionHistory(context.Background(),
+			&persistence.GetWorkflowExecutionHistoryRequest{
+				DomainID:      history.DomainID,
+				Execution:     history.Execution,
+				NextEventID:   math.MaxInt64,
+				PageSize:      executionScavengerPageSize,
+				NextPageToken: pageToken,
+			})
+		if err != nil {
+			return nil, err
+		}
+		if len(response.Events) > 0 {
+			lastBatch = &response.Events[len(response.Events)-1]
+		}
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+	if lastBatch == nil {
+		return nil, nil
+	}
+
+	serializer, err := s.hSerializerFactory.Get(lastBatch.EncodingType)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := serializer.Deserialize(lastBatch)
+	if err != nil {
+		return nil, err
+	}
+	if len(batch.Events) == 0 {
+		return nil, nil
+	}
+	return batch.Events[len(batch.Events)-1], nil
+}
+
+// collect records garbage found by the current scan, and returns true if it should be deleted
+func (s *executionScavenger) collect(found map[string]struct{}, key string, counter int) bool {
+	found[key] = struct{}{}
+	s.metricsClient.IncCounter(metrics.HistoryExecutionScavengerScope, counter)
+	_, suspect := s.suspects[key]
+	s.logger.Warnf("Found garbage %v, found by previous scan: %v", key, suspect)
+	return suspect && s.config.ExecutionScavengerDeleteGarbage
+}
+
+func (s *executionScavenger) delete(key string, err error) {
+	if err != nil {
+		s.logger.WithField(logging.TagErr, err).Warnf("Failed to delete garbage %v", key)
+		return
+	}
+	s.metricsClient.IncCounter(metrics.HistoryExecutionScavengerScope, metrics.ExecutionScavengerDeletedCounter)
+}
+
+func (s *executionScavenger) isStopped() bool {
+	return atomic.LoadInt32(&s.status) == executionScavengerStatusStopped
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	executionScavengerSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		logger            bark.Logger
+		config            *Config
+		mockExecutionMgr  *mocks.ExecutionManager
+		mockHistoryMgr    *mocks.HistoryManager
+		mockHistoryClient *mocks.HistoryClient
+		mockShard         *shardContextImpl
+		scavenger         *executionScavenger
+	}
+)
+
+func TestExecutionScavengerSuite(t *testing.T) {
+	s := new(executionScavengerSuite)
+	suite.Run(t, s)
+}
+
+func (s *executionScavengerSuite) SetupTest() {
+	s.logger = bark.NewLoggerFromLogrus(log.New())
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.config = NewConfig()
+	s.config.ExecutionScavengerInterval = time.Minute
+	s.mockExecutionMgr = &mocks.ExecutionManager{}
+	s.mockHistoryMgr = &mocks.HistoryManager{}
+	s.mockHistoryClient = &mocks.HistoryClient{}
+	s.mockShard = &shardContextImpl{
+		shardInfo:             &persistence.ShardInfo{ShardID: 0, RangeID: 1, TransferAckLevel: 0},
+		taskSequenceNumber:    1,
+		transferMaxReadLevel:  100,
+		executionManager:      s.mockExecutionMgr,
+		shardManager:          &mocks.ShardManager{},
+		rangeSize:             defaultRangeSize,
+		maxTaskSequenceNumber: 100000,
+		closeEvents:           newShardEventBus(),
+		logger:                s.logger,
+		metricsClient:         metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:            common.NewRealTimeSource(),
+	}
+	s.scavenger = newExecutionScavenger(nil, s.mockHistoryMgr, s.mockHistoryClient, 1, s.config, s.logger,
+		metrics.NewClient(tally.NoopScope, metrics.History))
+}
+
+func (s *executionScavengerSuite) TearDownTest() {
+	s.mockExecutionMgr.AssertExpectations(s.T())
+	s.mockHistoryMgr.AssertExpectations(s.T())
+	s.mockHistoryClient.AssertExpectations(s.T())
+}
+
+func (s *executionScavengerSuite) TestScavengeShard() {
+	s.config.ExecutionScavengerDeleteGarbage = true
+	domainID := "deadbeef-0123-4567-890a-bcdef0123460"
+	current := &persistence.WorkflowExecutionInfo{DomainID: domainID, WorkflowID: "wf-current",
+		RunID: "0d00698f-08e1-4d36-a3e2-3bf109f5d2d6", State: persistence.WorkflowStateRunning}
+	orphaned := &persistence.WorkflowExecutionInfo{DomainID: domainID, WorkflowID: "wf-orphaned",
+		RunID: "17f7a8c2-bf1e-43b7-9b55-4d7e1e3b7a3b", State: persistence.WorkflowStateRunning}
+	closed := &persistence.WorkflowExecutionInfo{DomainID: domainID, WorkflowID: "wf-closed",
+		RunID: "2b2e5d4a-7b6c-4b0e-9d3a-6e3f4c5d6e7f", State: persistence.WorkflowStateCompleted}
+
+	s.mockExecutionMgr.On("ListExecutions", mock.Anything, mock.Anything).Return(&persistence.ListExecutionsResponse{
+		Executions: []*persistence.WorkflowExecutionInfo{current, orphaned, closed},
+	}, nil).Times(2)
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything, &persistence.GetCurrentExecutionRequest{
+		DomainID: domainID, WorkflowID: current.WorkflowID,
+	}).Return(&persistence.GetCurrentExecutionResponse{RunID: current.RunID}, nil).Times(2)
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything, &persistence.GetCurrentExecutionRequest{
+		DomainID: domainID, WorkflowID: orphaned.WorkflowID,
+	}).Return(nil, &workflow.EntityNotExistsError{}).Times(2)
+
+	s.mockExecutionMgr.On("GetTransferTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTransferTasksResponse{Tasks: []*persistence.TransferTaskInfo{
+			{TaskID: 1, DomainID: domainID, WorkflowID: current.WorkflowID, RunID: current.RunID},
+			{TaskID: 2, DomainID: domainID, WorkflowID: "wf-deleted", RunID: "3c3f6e5b-8c7d-4c1f-8e4b-7f4a5d6e7f80"},
+		}}, nil).Times(2)
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, &persistence.GetTimerIndexTasksRequest{
+		Queue: persistence.TimerQueueActive, MaxKey: math.MaxInt64, BatchSize: executionScavengerPageSize,
+	}).Return(&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{
+		{TaskID: 3, DomainID: domainID, WorkflowID: closed.WorkflowID, RunID: closed.RunID},
+		{TaskID: 4, DomainID: domainID, WorkflowID: "wf-deleted", RunID: "3c3f6e5b-8c7d-4c1f-8e4b-7f4a5d6e7f80"},
+	}}, nil).Times(2)
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, &persistence.GetTimerIndexTasksRequest{
+		Queue: persistence.TimerQueueCron, MaxKey: math.MaxInt64, BatchSize: executionScavengerPageSize,
+	}).Return(&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{
+		{TaskID: 4, DomainID: domainID, WorkflowID: "wf-deleted", RunID: "3c3f6e5b-8c7d-4c1f-8e4b-7f4a5d6e7f80",
+			TaskType: persistence.TaskTypeFirstDecisionBackoff},
+	}}, nil).Times(2)
+
+	runningHistory := s.historyInfo(domainID, "wf-running", "4d4a7f6c-9d8e-4d2a-9f5c-8a5b6e7f8091")
+	closedHistory := s.historyInfo(domainID, "wf-done", "5e5b8a7d-ae9f-4e3b-a06d-9b6c7f8091a2")
+	s.mockHistoryMgr.On("ListWorkflowExecutionHistories", mock.Anything,
+		&persistence.ListWorkflowExecutionHistoriesRequest{Partition: 0, NumPartitions: 1,
+			PageSize: executionScavengerPageSize}).Return(&persistence.ListWorkflowExecutionHistoriesResponse{
+		Histories: []*persistence.WorkflowExecutionHistoryInfo{runningHistory, closedHistory},
+	}, nil).Times(2)
+	s.mockHistoryClient.On("GetWorkflowExecutionNextEventID", mock.Anything, mock.Anything).Return(
+		nil, &workflow.EntityNotExistsError{}).Times(4)
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything, &persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID: domainID, Execution: runningHistory.Execution, NextEventID: math.MaxInt64,
+		PageSize: executionScavengerPageSize,
+	}).Return(s.historyResponse(workflow.EventType_DecisionTaskScheduled), nil).Times(2)
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything, &persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID: domainID, Execution: closedHistory.Execution, NextEventID: math.MaxInt64,
+		PageSize: executionScavengerPageSize,
+	}).Return(s.historyResponse(workflow.EventType_WorkflowExecutionCompleted), nil).Times(2)
+
+	// garbage is only reported by the first scan
+	found := make(map[string]struct{})
+	s.scavenger.scavengeShard(0, s.mockShard, found)
+	s.Equal(5, len(found))
+	s.scavenger.suspects = found
+
+	// and deleted once the next scan finds it again
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", mock.Anything, &persistence.DeleteWorkflowExecutionRequest{
+		ExecutionInfo: orphaned,
+	}).Return(nil).Once()
+	s.mockExecutionMgr.On("CompleteTransferTask", mock.Anything, &persistence.CompleteTransferTaskRequest{
+		TaskID: 2,
+	}).Return(nil).Once()
+	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything, &persistence.CompleteTimerTaskRequest{
+		TaskID: 4,
+	}).Return(nil).Once()
+	// timer tasks of different queues can share a task ID
+	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything, &persistence.CompleteTimerTaskRequest{
+		Queue: persistence.TimerQueueCron, TaskID: 4,
+	}).Return(nil).Once()
+	s.mockHistoryMgr.On("DeleteWorkflowExecutionHistory", mock.Anything,
+		&persistence.DeleteWorkflowExecutionHistoryRequest{
+			DomainID:  domainID,
+			Execution: runningHistory.Execution,
+		}).Return(nil).Once()
+
+	found = make(map[string]struct{})
+	s.scavenger.scavengeShard(0, s.mockShard, found)
+	s.Equal(5, len(found))
+}
+
+func (s *executionScavengerSuite) TestScavengeShardReportOnly() {
+	domainID := "deadbeef-0123-4567-890a-bcdef0123460"
+	orphaned := &persistence.WorkflowExecutionInfo{DomainID: domainID, WorkflowID: "wf-orphaned",
+		RunID: "17f7a8c2-bf1e-43b7-9b55-4d7e1e3b7a3b", State: persistence.WorkflowStateCreated}
+
+	s.mockExecutionMgr.On("ListExecutions", mock.Anything, mock.Anything).Return(&persistence.ListExecutionsResponse{
+		Executions: []*persistence.WorkflowExecutionInfo{orphaned},
+	}, nil)
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything, mock.Anything).Return(
+		&persistence.GetCurrentExecutionResponse{RunID: "6f6c9b8e-bfa0-4f4c-b17e-ac7d8091a2b3"}, nil)
+	s.mockExecutionMgr.On("GetTransferTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTransferTasksResponse{}, nil)
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTimerIndexTasksResponse{}, nil)
+	s.mockHistoryMgr.On("ListWorkflowExecutionHistories", mock.Anything, mock.Anything).Return(
+		&persistence.ListWorkflowExecutionHistoriesResponse{}, nil)
+
+	for i := 0; i < 3; i++ {
+		found := make(map[string]struct{})
+		s.scavenger.scavengeShard(0, s.mockShard, found)
+		s.Equal(1, len(found))
+		s.scavenger.suspects = found
+	}
+	s.mockExecutionMgr.AssertNotCalled(s.T(), "DeleteWorkflowExecution", mock.Anything, mock.Anything)
+}
+
+func (s *executionScavengerSuite) historyInfo(domainID, workflowID,
+	runID string) *persistence.WorkflowExecutionHistoryInfo {
+	return &persistence.WorkflowExecutionHistoryInfo{
+		DomainID: domainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr(runID),
+		},
+	}
+}
+
+func (s *executionScavengerSuite) historyResponse(
+	eventType workflow.EventType) *persistence.GetWorkflowExecutionHistoryResponse {
+	serializer, err := persistence.NewHistorySerializerFactory().Get(common.EncodingTypeJSON)
+	s.Nil(err)
+	batch, err := serializer.Serialize(&persistence.HistoryEventBatch{
+		Version: persistence.GetDefaultHistoryVersion(),
+		Events: []*workflow.HistoryEvent{
+			{EventId: common.Int64Ptr(1), EventType: common.EventTypePtr(workflow.EventType_WorkflowExecutionStarted)},
+			{EventId: common.Int64Ptr(2), EventType: common.EventTypePtr(eventType)},
+		},
+	})
+	s.Nil(err)
+	return &persistence.GetWorkflowExecutionHistoryResponse{Events: []persistence.SerializedHistoryEventBatch{*batch}}
+}
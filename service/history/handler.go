@@ -23,17 +23,24 @@ package history
 import (
 	"fmt"
 	"log"
+	"runtime/debug"
 	"sync"
 
+	"github.com/uber-common/bark"
 	hist "github.com/uber/cadence/.gen/go/history"
 	gen "github.com/uber/cadence/.gen/go/shared"
 	hc "github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/errors"
+	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/tracing"
 	"github.com/uber/tchannel-go/thrift"
 )
 
@@ -50,8 +57,15 @@ type Handler struct {
 	hServiceResolver      membership.ServiceResolver
 	controller            *shardController
 	tokenSerializer       common.TaskTokenSerializer
+	loadShedder           *loadShedder
+	scavenger             *executionScavenger
+	staleMonitor          *staleExecutionMonitor
+	completedExecutions   *completedExecutionCache
+	completedActivities   *completedActivityCache
+	payloadBlobs          *payloadBlobs
 	startWG               sync.WaitGroup
 	metricsClient         metrics.Client
+	config                *Config
 	service.Service
 }
 
@@ -61,12 +75,15 @@ var _ EngineFactory = (*Handler)(nil)
 var (
 	errDomainNotSet            = &gen.BadRequestError{Message: "Domain not set on request."}
 	errWorkflowExecutionNotSet = &gen.BadRequestError{Message: "WorkflowExecution not set on request."}
+	errShardIDNotSet           = &gen.BadRequestError{Message: "A valid ShardId is not set on request."}
+	errQueueProcessingNotSet   = &gen.BadRequestError{Message: "QueueType and Paused must be set on request."}
 )
 
 // NewHandler creates a thrift handler for the history service
 func NewHandler(sVice service.Service, shardManager persistence.ShardManager, metadataMgr persistence.MetadataManager,
 	visibilityMgr persistence.VisibilityManager, historyMgr persistence.HistoryManager,
-	executionMgrFactory persistence.ExecutionManagerFactory, numberOfShards int) (*Handler, []thrift.TChanServer) {
+	executionMgrFactory persistence.ExecutionManagerFactory, blobClient blobstore.Client,
+	largePayloadsConfig config.LargePayloads, numberOfShards int, config *Config) (*Handler, []thrift.TChanServer) {
 	handler := &Handler{
 		Service:             sVice,
 		shardManager:        shardManager,
@@ -75,7 +92,12 @@ func NewHandler(sVice service.Service, shardManager persistence.ShardManager, me
 		visibilityMgr:       visibilityMgr,
 		executionMgrFactory: executionMgrFactory,
 		numberOfShards:      numberOfShards,
-		tokenSerializer:     common.NewJSONTaskTokenSerializer(),
+		tokenSerializer:     sVice.GetTaskTokenSerializer(),
+		loadShedder:         newLoadShedder(config),
+		completedExecutions: newCompletedExecutionCache(config),
+		completedActivities: newCompletedActivityCache(config),
+		payloadBlobs:        newPayloadBlobs(blobClient, largePayloadsConfig),
+		config:              config,
 	}
 	// prevent us from trying to serve requests before shard controller is started and ready
 	handler.startWG.Add(1)
@@ -106,19 +128,35 @@ func (h *Handler) Start(thriftService []thrift.TChanServer) error {
 		h.executionMgrFactory, h, h.GetLogger(), h.GetMetricsClient())
 	h.controller.Start()
 	h.metricsClient = h.GetMetricsClient()
+	if h.config.ExecutionScavengerInterval > 0 {
+		h.scavenger = newExecutionScavenger(h.controller, h.historyMgr, h.historyServiceClient, h.numberOfShards,
+			h.config, h.GetLogger(), h.metricsClient)
+		h.scavenger.Start()
+	}
+	if h.config.StaleExecutionMonitorInterval > 0 {
+		h.staleMonitor = newStaleExecutionMonitor(h.controller, h.config, h.GetLogger(), h.metricsClient)
+		h.staleMonitor.Start()
+	}
 	h.startWG.Done()
 	return nil
 }
 
 // Stop stops the handler
 func (h *Handler) Stop() {
+	if h.scavenger != nil {
+		h.scavenger.Stop()
+	}
+	if h.staleMonitor != nil {
+		h.staleMonitor.Stop()
+	}
 	h.controller.Stop()
 	h.Service.Stop()
 }
 
 // CreateEngine is implementation for HistoryEngineFactory used for creating the engine instance for shard
 func (h *Handler) CreateEngine(context ShardContext) Engine {
-	return NewEngineWithShardContext(context, h.metadataMgr, h.visibilityMgr, h.matchingServiceClient, h.historyServiceClient)
+	return NewEngineWithShardContext(context, h.metadataMgr, h.visibilityMgr, h.matchingServiceClient, h.historyServiceClient,
+		h.tokenSerializer, h.completedExecutions, h.completedActivities, h.payloadBlobs, h.config)
 }
 
 // IsHealthy - Health endpoint.
@@ -129,12 +167,13 @@ func (h *Handler) IsHealthy(ctx thrift.Context) (bool, error) {
 
 // RecordActivityTaskHeartbeat - Record Activity Task Heart beat.
 func (h *Handler) RecordActivityTaskHeartbeat(ctx thrift.Context,
-	wrappedRequest *hist.RecordActivityTaskHeartbeatRequest) (*gen.RecordActivityTaskHeartbeatResponse, error) {
+	wrappedRequest *hist.RecordActivityTaskHeartbeatRequest) (resp *gen.RecordActivityTaskHeartbeatResponse, retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryRecordActivityTaskHeartbeatScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryRecordActivityTaskHeartbeatScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRecordActivityTaskHeartbeatScope, &retError)
 
 	if !wrappedRequest.IsSetDomainUUID() {
 		return nil, errDomainNotSet
@@ -154,7 +193,12 @@ func (h *Handler) RecordActivityTaskHeartbeat(ctx thrift.Context,
 		return nil, err1
 	}
 
-	response, err2 := engine.RecordActivityTaskHeartbeat(wrappedRequest)
+	if h.shouldShedLoad(token.WorkflowID, priorityHeartbeat) {
+		h.updateErrorMetric(metrics.HistoryRecordActivityTaskHeartbeatScope, errShardOverloaded)
+		return nil, errShardOverloaded
+	}
+
+	response, err2 := engine.RecordActivityTaskHeartbeat(ctx, wrappedRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryRecordActivityTaskHeartbeatScope, h.convertError(err2))
 		return nil, h.convertError(err2)
@@ -165,12 +209,13 @@ func (h *Handler) RecordActivityTaskHeartbeat(ctx thrift.Context,
 
 // RecordActivityTaskStarted - Record Activity Task started.
 func (h *Handler) RecordActivityTaskStarted(ctx thrift.Context,
-	recordRequest *hist.RecordActivityTaskStartedRequest) (*hist.RecordActivityTaskStartedResponse, error) {
+	recordRequest *hist.RecordActivityTaskStartedRequest) (resp *hist.RecordActivityTaskStartedResponse, retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryRecordActivityTaskStartedScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryRecordActivityTaskStartedScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRecordActivityTaskStartedScope, &retError)
 
 	if !recordRequest.IsSetDomainUUID() {
 		return nil, errDomainNotSet
@@ -183,7 +228,12 @@ func (h *Handler) RecordActivityTaskStarted(ctx thrift.Context,
 		return nil, err1
 	}
 
-	response, err2 := engine.RecordActivityTaskStarted(recordRequest)
+	if h.shouldShedLoad(workflowExecution.GetWorkflowId(), priorityPoll) {
+		h.updateErrorMetric(metrics.HistoryRecordActivityTaskStartedScope, errShardOverloaded)
+		return nil, errShardOverloaded
+	}
+
+	response, err2 := engine.RecordActivityTaskStarted(ctx, recordRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryRecordActivityTaskStartedScope, h.convertError(err2))
 		return nil, h.convertError(err2)
@@ -194,15 +244,16 @@ func (h *Handler) RecordActivityTaskStarted(ctx thrift.Context,
 
 // RecordDecisionTaskStarted - Record Decision Task started.
 func (h *Handler) RecordDecisionTaskStarted(ctx thrift.Context,
-	recordRequest *hist.RecordDecisionTaskStartedRequest) (*hist.RecordDecisionTaskStartedResponse, error) {
+	recordRequest *hist.RecordDecisionTaskStartedRequest) (resp *hist.RecordDecisionTaskStartedResponse, retError error) {
 	h.startWG.Wait()
-	h.Service.GetLogger().Debugf("RecordDecisionTaskStarted. DomainID: %v, WorkflowID: %v, RunID: %v, ScheduleID: %v",
+	h.getLogger(ctx).Debugf("RecordDecisionTaskStarted. DomainID: %v, WorkflowID: %v, RunID: %v, ScheduleID: %v",
 		recordRequest.GetDomainUUID(), recordRequest.GetWorkflowExecution().GetWorkflowId(),
 		recordRequest.GetWorkflowExecution().GetRunId(), recordRequest.GetScheduleId())
 
 	h.metricsClient.IncCounter(metrics.HistoryRecordDecisionTaskStartedScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryRecordDecisionTaskStartedScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRecordDecisionTaskStartedScope, &retError)
 
 	if !recordRequest.IsSetDomainUUID() {
 		return nil, errDomainNotSet
@@ -211,7 +262,7 @@ func (h *Handler) RecordDecisionTaskStarted(ctx thrift.Context,
 	workflowExecution := recordRequest.GetWorkflowExecution()
 	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
 	if err1 != nil {
-		h.Service.GetLogger().Errorf("RecordDecisionTaskStarted failed. Error: %v. WorkflowID: %v, RunID: %v, ScheduleID: %v",
+		h.getLogger(ctx).Errorf("RecordDecisionTaskStarted failed. Error: %v. WorkflowID: %v, RunID: %v, ScheduleID: %v",
 			err1,
 			recordRequest.GetWorkflowExecution().GetWorkflowId(),
 			recordRequest.GetWorkflowExecution().GetRunId(),
@@ -220,7 +271,12 @@ func (h *Handler) RecordDecisionTaskStarted(ctx thrift.Context,
 		return nil, err1
 	}
 
-	response, err2 := engine.RecordDecisionTaskStarted(recordRequest)
+	if h.shouldShedLoad(workflowExecution.GetWorkflowId(), priorityPoll) {
+		h.updateErrorMetric(metrics.HistoryRecordDecisionTaskStartedScope, errShardOverloaded)
+		return nil, errShardOverloaded
+	}
+
+	response, err2 := engine.RecordDecisionTaskStarted(ctx, recordRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryRecordDecisionTaskStartedScope, h.convertError(err2))
 		return nil, h.convertError(err2)
@@ -231,12 +287,13 @@ func (h *Handler) RecordDecisionTaskStarted(ctx thrift.Context,
 
 // RespondActivityTaskCompleted - records completion of an activity task
 func (h *Handler) RespondActivityTaskCompleted(ctx thrift.Context,
-	wrappedRequest *hist.RespondActivityTaskCompletedRequest) error {
+	wrappedRequest *hist.RespondActivityTaskCompletedRequest) (retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryRespondActivityTaskCompletedScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryRespondActivityTaskCompletedScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRespondActivityTaskCompletedScope, &retError)
 
 	if !wrappedRequest.IsSetDomainUUID() {
 		return errDomainNotSet
@@ -256,7 +313,7 @@ func (h *Handler) RespondActivityTaskCompleted(ctx thrift.Context,
 		return err1
 	}
 
-	err2 := engine.RespondActivityTaskCompleted(wrappedRequest)
+	err2 := engine.RespondActivityTaskCompleted(ctx, wrappedRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryRespondActivityTaskCompletedScope, h.convertError(err2))
 		return h.convertError(err2)
@@ -267,12 +324,13 @@ func (h *Handler) RespondActivityTaskCompleted(ctx thrift.Context,
 
 // RespondActivityTaskFailed - records failure of an activity task
 func (h *Handler) RespondActivityTaskFailed(ctx thrift.Context,
-	wrappedRequest *hist.RespondActivityTaskFailedRequest) error {
+	wrappedRequest *hist.RespondActivityTaskFailedRequest) (retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryRespondActivityTaskFailedScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryRespondActivityTaskFailedScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRespondActivityTaskFailedScope, &retError)
 
 	if !wrappedRequest.IsSetDomainUUID() {
 		return errDomainNotSet
@@ -292,7 +350,7 @@ func (h *Handler) RespondActivityTaskFailed(ctx thrift.Context,
 		return err1
 	}
 
-	err2 := engine.RespondActivityTaskFailed(wrappedRequest)
+	err2 := engine.RespondActivityTaskFailed(ctx, wrappedRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryRespondActivityTaskFailedScope, h.convertError(err2))
 		return h.convertError(err2)
@@ -303,12 +361,13 @@ func (h *Handler) RespondActivityTaskFailed(ctx thrift.Context,
 
 // RespondActivityTaskCanceled - records failure of an activity task
 func (h *Handler) RespondActivityTaskCanceled(ctx thrift.Context,
-	wrappedRequest *hist.RespondActivityTaskCanceledRequest) error {
+	wrappedRequest *hist.RespondActivityTaskCanceledRequest) (retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryRespondActivityTaskCanceledScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryRespondActivityTaskCanceledScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRespondActivityTaskCanceledScope, &retError)
 
 	if !wrappedRequest.IsSetDomainUUID() {
 		return errDomainNotSet
@@ -328,7 +387,7 @@ func (h *Handler) RespondActivityTaskCanceled(ctx thrift.Context,
 		return err1
 	}
 
-	err2 := engine.RespondActivityTaskCanceled(wrappedRequest)
+	err2 := engine.RespondActivityTaskCanceled(ctx, wrappedRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryRespondActivityTaskCanceledScope, h.convertError(err2))
 		return h.convertError(err2)
@@ -339,15 +398,16 @@ func (h *Handler) RespondActivityTaskCanceled(ctx thrift.Context,
 
 // RespondDecisionTaskCompleted - records completion of a decision task
 func (h *Handler) RespondDecisionTaskCompleted(ctx thrift.Context,
-	wrappedRequest *hist.RespondDecisionTaskCompletedRequest) error {
+	wrappedRequest *hist.RespondDecisionTaskCompletedRequest) (resp *gen.RespondDecisionTaskCompletedResponse, retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRespondDecisionTaskCompletedScope, &retError)
 
 	if !wrappedRequest.IsSetDomainUUID() {
-		return errDomainNotSet
+		return nil, errDomainNotSet
 	}
 
 	completeRequest := wrappedRequest.GetCompleteRequest()
@@ -355,10 +415,10 @@ func (h *Handler) RespondDecisionTaskCompleted(ctx thrift.Context,
 	if err0 != nil {
 		err0 = &gen.BadRequestError{Message: fmt.Sprintf("Error deserializing task token. Error: %v", err0)}
 		h.updateErrorMetric(metrics.HistoryRespondDecisionTaskCompletedScope, err0)
-		return err0
+		return nil, err0
 	}
 
-	h.Service.GetLogger().Debugf("RespondDecisionTaskCompleted. DomainID: %v, WorkflowID: %v, RunID: %v, ScheduleID: %v",
+	h.getLogger(ctx).Debugf("RespondDecisionTaskCompleted. DomainID: %v, WorkflowID: %v, RunID: %v, ScheduleID: %v",
 		token.DomainID,
 		token.WorkflowID,
 		token.RunID,
@@ -367,26 +427,27 @@ func (h *Handler) RespondDecisionTaskCompleted(ctx thrift.Context,
 	engine, err1 := h.controller.GetEngine(token.WorkflowID)
 	if err1 != nil {
 		h.updateErrorMetric(metrics.HistoryRespondDecisionTaskCompletedScope, err1)
-		return err1
+		return nil, err1
 	}
 
-	err2 := engine.RespondDecisionTaskCompleted(wrappedRequest)
+	response, err2 := engine.RespondDecisionTaskCompleted(ctx, wrappedRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryRespondDecisionTaskCompletedScope, h.convertError(err2))
-		return h.convertError(err2)
+		return nil, h.convertError(err2)
 	}
 
-	return nil
+	return response, nil
 }
 
 // StartWorkflowExecution - creates a new workflow execution
 func (h *Handler) StartWorkflowExecution(ctx thrift.Context,
-	wrappedRequest *hist.StartWorkflowExecutionRequest) (*gen.StartWorkflowExecutionResponse, error) {
+	wrappedRequest *hist.StartWorkflowExecutionRequest) (resp *gen.StartWorkflowExecutionResponse, retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryStartWorkflowExecutionScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryStartWorkflowExecutionScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryStartWorkflowExecutionScope, &retError)
 
 	if !wrappedRequest.IsSetDomainUUID() {
 		return nil, errDomainNotSet
@@ -399,7 +460,7 @@ func (h *Handler) StartWorkflowExecution(ctx thrift.Context,
 		return nil, err1
 	}
 
-	response, err2 := engine.StartWorkflowExecution(wrappedRequest)
+	response, err2 := engine.StartWorkflowExecution(ctx, wrappedRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryStartWorkflowExecutionScope, h.convertError(err2))
 		return nil, h.convertError(err2)
@@ -410,12 +471,13 @@ func (h *Handler) StartWorkflowExecution(ctx thrift.Context,
 
 // GetWorkflowExecutionNextEventID - returns the id of the next event in the execution's history
 func (h *Handler) GetWorkflowExecutionNextEventID(ctx thrift.Context,
-	getRequest *hist.GetWorkflowExecutionNextEventIDRequest) (*hist.GetWorkflowExecutionNextEventIDResponse, error) {
+	getRequest *hist.GetWorkflowExecutionNextEventIDRequest) (resp *hist.GetWorkflowExecutionNextEventIDResponse, retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryGetWorkflowExecutionNextEventIDScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryGetWorkflowExecutionNextEventIDScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryGetWorkflowExecutionNextEventIDScope, &retError)
 
 	if !getRequest.IsSetDomainUUID() {
 		return nil, errDomainNotSet
@@ -428,7 +490,7 @@ func (h *Handler) GetWorkflowExecutionNextEventID(ctx thrift.Context,
 		return nil, err1
 	}
 
-	resp, err2 := engine.GetWorkflowExecutionNextEventID(getRequest)
+	resp, err2 := engine.GetWorkflowExecutionNextEventID(ctx, getRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryGetWorkflowExecutionNextEventIDScope, h.convertError(err2))
 		return nil, h.convertError(err2)
@@ -436,17 +498,77 @@ func (h *Handler) GetWorkflowExecutionNextEventID(ctx thrift.Context,
 	return resp, nil
 }
 
+// BatchGetWorkflowExecutionNextEventID - returns the id of the next event in the history of each of the executions,
+// failures are reported per execution
+func (h *Handler) BatchGetWorkflowExecutionNextEventID(ctx thrift.Context,
+	getRequest *hist.BatchGetWorkflowExecutionNextEventIDRequest) (resp *hist.BatchGetWorkflowExecutionNextEventIDResponse,
+	retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryBatchGetNextEventIDScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryBatchGetNextEventIDScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryBatchGetNextEventIDScope, &retError)
+
+	requests := getRequest.GetRequests()
+	for _, request := range requests {
+		if !request.IsSetDomainUUID() {
+			return nil, errDomainNotSet
+		}
+		if !request.IsSetExecution() {
+			return nil, errWorkflowExecutionNotSet
+		}
+	}
+
+	response := &hist.BatchGetWorkflowExecutionNextEventIDResponse{
+		Results: make([]*hist.GetWorkflowExecutionNextEventIDOutcome, 0, len(requests)),
+	}
+	for _, request := range requests {
+		var resp *hist.GetWorkflowExecutionNextEventIDResponse
+		engine, err := h.controller.GetEngine(request.GetExecution().GetWorkflowId())
+		if err == nil {
+			resp, err = engine.GetWorkflowExecutionNextEventID(ctx, request)
+		}
+		response.Results = append(response.Results, newNextEventIDOutcome(resp, h.convertError(err)))
+	}
+	return response, nil
+}
+
+// newNextEventIDOutcome reports the failure of an execution in the field of its type, other errors are reported as
+// InternalServiceError
+func newNextEventIDOutcome(response *hist.GetWorkflowExecutionNextEventIDResponse,
+	err error) *hist.GetWorkflowExecutionNextEventIDOutcome {
+	outcome := &hist.GetWorkflowExecutionNextEventIDOutcome{Response: response}
+	switch err := err.(type) {
+	case nil:
+	case *gen.EntityNotExistsError:
+		outcome.EntityNotExistError = err
+	case *hist.ShardOwnershipLostError:
+		outcome.ShardOwnershipLostError = err
+	case *gen.InternalServiceError:
+		outcome.InternalServiceError = err
+	case *gen.BadRequestError:
+		outcome.BadRequestError = err
+	case *gen.ServiceBusyError:
+		outcome.ServiceBusyError = err
+	default:
+		outcome.InternalServiceError = &gen.InternalServiceError{Message: err.Error()}
+	}
+	return outcome
+}
+
 // RequestCancelWorkflowExecution - requests cancellation of a workflow
 func (h *Handler) RequestCancelWorkflowExecution(ctx thrift.Context,
-	request *hist.RequestCancelWorkflowExecutionRequest) error {
+	request *hist.RequestCancelWorkflowExecutionRequest) (retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryRequestCancelWorkflowExecutionScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryRequestCancelWorkflowExecutionScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRequestCancelWorkflowExecutionScope, &retError)
 
 	cancelRequest := request.GetCancelRequest()
-	h.Service.GetLogger().Debugf("RequestCancelWorkflowExecution. DomainID: %v/%v, WorkflowID: %v, RunID: %v.",
+	h.getLogger(ctx).Debugf("RequestCancelWorkflowExecution. DomainID: %v/%v, WorkflowID: %v, RunID: %v.",
 		cancelRequest.GetDomain(),
 		request.GetDomainUUID(),
 		cancelRequest.GetWorkflowExecution().GetWorkflowId(),
@@ -458,7 +580,7 @@ func (h *Handler) RequestCancelWorkflowExecution(ctx thrift.Context,
 		return err1
 	}
 
-	err2 := engine.RequestCancelWorkflowExecution(request)
+	err2 := engine.RequestCancelWorkflowExecution(ctx, request)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryRequestCancelWorkflowExecutionScope, h.convertError(err2))
 		return h.convertError(err2)
@@ -468,17 +590,19 @@ func (h *Handler) RequestCancelWorkflowExecution(ctx thrift.Context,
 }
 
 // SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
-// WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
+// WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.  It
+// returns the receipt of the signal.
 func (h *Handler) SignalWorkflowExecution(ctx thrift.Context,
-	wrappedRequest *hist.SignalWorkflowExecutionRequest) error {
+	wrappedRequest *hist.SignalWorkflowExecutionRequest) (resp *gen.SignalWorkflowExecutionResponse, retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistorySignalWorkflowExecutionScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistorySignalWorkflowExecutionScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistorySignalWorkflowExecutionScope, &retError)
 
 	if !wrappedRequest.IsSetDomainUUID() {
-		return errDomainNotSet
+		return nil, errDomainNotSet
 	}
 
 	signalRequest := wrappedRequest.GetSignalRequest()
@@ -486,30 +610,124 @@ func (h *Handler) SignalWorkflowExecution(ctx thrift.Context,
 	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
 	if err1 != nil {
 		h.updateErrorMetric(metrics.HistorySignalWorkflowExecutionScope, err1)
-		return err1
+		return nil, err1
 	}
 
-	err2 := engine.SignalWorkflowExecution(wrappedRequest)
+	resp, err2 := engine.SignalWorkflowExecution(ctx, wrappedRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistorySignalWorkflowExecutionScope, h.convertError(err2))
-		return h.convertError(err2)
+		return nil, h.convertError(err2)
 	}
 
-	return nil
+	return resp, nil
+}
+
+// GetSignalReceipt tells whether the signal sent with the request ID was recorded by the workflow execution.
+func (h *Handler) GetSignalReceipt(ctx thrift.Context,
+	wrappedRequest *hist.GetSignalReceiptRequest) (resp *gen.GetSignalReceiptResponse, retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryGetSignalReceiptScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryGetSignalReceiptScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryGetSignalReceiptScope, &retError)
+
+	if !wrappedRequest.IsSetDomainUUID() {
+		return nil, errDomainNotSet
+	}
+
+	workflowExecution := wrappedRequest.GetReceiptRequest().GetWorkflowExecution()
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryGetSignalReceiptScope, err1)
+		return nil, err1
+	}
+
+	resp, err2 := engine.GetSignalReceipt(ctx, wrappedRequest)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryGetSignalReceiptScope, h.convertError(err2))
+		return nil, h.convertError(err2)
+	}
+
+	return resp, nil
+}
+
+// QueryWorkflow queues a query to a running workflow execution and waits for the worker to answer it with the
+// completion of the next decision task of the execution, or of a query task of its own.
+func (h *Handler) QueryWorkflow(ctx thrift.Context,
+	wrappedRequest *hist.QueryWorkflowRequest) (resp *gen.QueryWorkflowResponse, retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryQueryWorkflowScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryQueryWorkflowScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryQueryWorkflowScope, &retError)
+
+	if !wrappedRequest.IsSetDomainUUID() {
+		return nil, errDomainNotSet
+	}
+
+	queryRequest := wrappedRequest.GetQueryRequest()
+	workflowExecution := queryRequest.GetExecution()
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryQueryWorkflowScope, err1)
+		return nil, err1
+	}
+
+	resp, err2 := engine.QueryWorkflow(ctx, wrappedRequest)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryQueryWorkflowScope, h.convertError(err2))
+		return nil, h.convertError(err2)
+	}
+
+	return resp, nil
 }
 
-// TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
-// in the history and immediately terminating the execution instance.
+// DescribeWorkflowExecution returns information about the specified workflow execution, including the activities
+// still pending on it.
+func (h *Handler) DescribeWorkflowExecution(ctx thrift.Context,
+	wrappedRequest *hist.DescribeWorkflowExecutionRequest) (resp *gen.DescribeWorkflowExecutionResponse, retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryDescribeWorkflowExecutionScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryDescribeWorkflowExecutionScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryDescribeWorkflowExecutionScope, &retError)
+
+	if !wrappedRequest.IsSetDomainUUID() {
+		return nil, errDomainNotSet
+	}
+
+	workflowExecution := wrappedRequest.GetRequest().GetExecution()
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryDescribeWorkflowExecutionScope, err1)
+		return nil, err1
+	}
+
+	resp, err2 := engine.DescribeWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryDescribeWorkflowExecutionScope, h.convertError(err2))
+		return nil, h.convertError(err2)
+	}
+
+	return resp, nil
+}
+
+// TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminatedByOperator
+// event in the history and immediately terminating the execution instance.
 func (h *Handler) TerminateWorkflowExecution(ctx thrift.Context,
-	wrappedRequest *hist.TerminateWorkflowExecutionRequest) error {
+	wrappedRequest *hist.TerminateWorkflowExecutionRequest) (resp *gen.TerminateWorkflowExecutionResponse, retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryTerminateWorkflowExecutionScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryTerminateWorkflowExecutionScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryTerminateWorkflowExecutionScope, &retError)
 
 	if !wrappedRequest.IsSetDomainUUID() {
-		return errDomainNotSet
+		return nil, errDomainNotSet
 	}
 
 	terminateRequest := wrappedRequest.GetTerminateRequest()
@@ -517,28 +735,29 @@ func (h *Handler) TerminateWorkflowExecution(ctx thrift.Context,
 	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
 	if err1 != nil {
 		h.updateErrorMetric(metrics.HistoryTerminateWorkflowExecutionScope, err1)
-		return err1
+		return nil, err1
 	}
 
-	err2 := engine.TerminateWorkflowExecution(wrappedRequest)
+	response, err2 := engine.TerminateWorkflowExecution(ctx, wrappedRequest)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryTerminateWorkflowExecutionScope, h.convertError(err2))
-		return h.convertError(err2)
+		return nil, h.convertError(err2)
 	}
 
-	return nil
+	return response, nil
 }
 
 // ScheduleDecisionTask is used for creating a decision task for already started workflow execution.  This is mainly
 // used by transfer queue processor during the processing of StartChildWorkflowExecution task, where it first starts
 // child execution without creating the decision task and then calls this API after updating the mutable state of
 // parent execution.
-func (h *Handler) ScheduleDecisionTask(ctx thrift.Context, request *hist.ScheduleDecisionTaskRequest) error {
+func (h *Handler) ScheduleDecisionTask(ctx thrift.Context, request *hist.ScheduleDecisionTaskRequest) (retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryScheduleDecisionTaskScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryScheduleDecisionTaskScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryScheduleDecisionTaskScope, &retError)
 
 	if !request.IsSetDomainUUID() {
 		return errDomainNotSet
@@ -555,7 +774,7 @@ func (h *Handler) ScheduleDecisionTask(ctx thrift.Context, request *hist.Schedul
 		return err1
 	}
 
-	err2 := engine.ScheduleDecisionTask(request)
+	err2 := engine.ScheduleDecisionTask(ctx, request)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryScheduleDecisionTaskScope, h.convertError(err2))
 		return h.convertError(err2)
@@ -566,12 +785,14 @@ func (h *Handler) ScheduleDecisionTask(ctx thrift.Context, request *hist.Schedul
 
 // RecordChildExecutionCompleted is used for reporting the completion of child workflow execution to parent.
 // This is mainly called by transfer queue processor during the processing of DeleteExecution task.
-func (h *Handler) RecordChildExecutionCompleted(ctx thrift.Context, request *hist.RecordChildExecutionCompletedRequest) error {
+func (h *Handler) RecordChildExecutionCompleted(ctx thrift.Context,
+	request *hist.RecordChildExecutionCompletedRequest) (retError error) {
 	h.startWG.Wait()
 
 	h.metricsClient.IncCounter(metrics.HistoryRecordChildExecutionCompletedScope, metrics.CadenceRequests)
 	sw := h.metricsClient.StartTimer(metrics.HistoryRecordChildExecutionCompletedScope, metrics.CadenceLatency)
 	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRecordChildExecutionCompletedScope, &retError)
 
 	if !request.IsSetDomainUUID() {
 		return errDomainNotSet
@@ -588,7 +809,7 @@ func (h *Handler) RecordChildExecutionCompleted(ctx thrift.Context, request *his
 		return err1
 	}
 
-	err2 := engine.RecordChildExecutionCompleted(request)
+	err2 := engine.RecordChildExecutionCompleted(ctx, request)
 	if err2 != nil {
 		h.updateErrorMetric(metrics.HistoryRecordChildExecutionCompletedScope, h.convertError(err2))
 		return h.convertError(err2)
@@ -597,6 +818,186 @@ func (h *Handler) RecordChildExecutionCompleted(ctx thrift.Context, request *his
 	return nil
 }
 
+// UpdateQueueProcessing pauses or resumes the processing of the transfer or timer queue of a shard owned by this host.
+func (h *Handler) UpdateQueueProcessing(ctx thrift.Context,
+	request *hist.UpdateQueueProcessingRequest) (retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryUpdateQueueProcessingScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryUpdateQueueProcessingScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryUpdateQueueProcessingScope, &retError)
+
+	if !request.IsSetShardId() || request.GetShardId() < 0 || int(request.GetShardId()) >= h.numberOfShards {
+		return errShardIDNotSet
+	}
+
+	if !request.IsSetQueueType() || !request.IsSetPaused() {
+		return errQueueProcessingNotSet
+	}
+
+	engine, err1 := h.controller.getEngineForShard(int(request.GetShardId()))
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryUpdateQueueProcessingScope, err1)
+		return err1
+	}
+
+	err2 := engine.UpdateQueueProcessing(ctx, request)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryUpdateQueueProcessingScope, h.convertError(err2))
+		return h.convertError(err2)
+	}
+
+	return nil
+}
+
+// DescribeMutableState returns the mutable state of the specified workflow execution as JSON, for debugging.
+func (h *Handler) DescribeMutableState(ctx thrift.Context,
+	request *hist.DescribeMutableStateRequest) (resp *hist.DescribeMutableStateResponse, retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryDescribeMutableStateScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryDescribeMutableStateScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryDescribeMutableStateScope, &retError)
+
+	if !request.IsSetDomainUUID() {
+		return nil, errDomainNotSet
+	}
+
+	workflowExecution := request.GetExecution()
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryDescribeMutableStateScope, err1)
+		return nil, err1
+	}
+
+	resp, err2 := engine.DescribeMutableState(ctx, request)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryDescribeMutableStateScope, h.convertError(err2))
+		return nil, h.convertError(err2)
+	}
+
+	return resp, nil
+}
+
+// RefreshWorkflowTasks re-generates the transfer and timer tasks of the specified workflow execution.
+func (h *Handler) RefreshWorkflowTasks(ctx thrift.Context, request *hist.RefreshWorkflowTasksRequest) (retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryRefreshWorkflowTasksScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryRefreshWorkflowTasksScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRefreshWorkflowTasksScope, &retError)
+
+	if !request.IsSetDomainUUID() {
+		return errDomainNotSet
+	}
+
+	workflowExecution := request.GetRefreshRequest().GetWorkflowExecution()
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryRefreshWorkflowTasksScope, err1)
+		return err1
+	}
+
+	err2 := engine.RefreshWorkflowTasks(ctx, request)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryRefreshWorkflowTasksScope, h.convertError(err2))
+		return h.convertError(err2)
+	}
+
+	return nil
+}
+
+// MigrateWorkflowExecution moves the specified closed workflow execution to another domain.
+func (h *Handler) MigrateWorkflowExecution(ctx thrift.Context,
+	request *hist.MigrateWorkflowExecutionRequest) (retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryMigrateWorkflowExecutionScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryMigrateWorkflowExecutionScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryMigrateWorkflowExecutionScope, &retError)
+
+	if !request.IsSetDomainUUID() || !request.IsSetTargetDomainUUID() {
+		return errDomainNotSet
+	}
+
+	workflowExecution := request.GetMigrateRequest().GetWorkflowExecution()
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryMigrateWorkflowExecutionScope, err1)
+		return err1
+	}
+
+	err2 := engine.MigrateWorkflowExecution(ctx, request)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryMigrateWorkflowExecutionScope, h.convertError(err2))
+		return h.convertError(err2)
+	}
+
+	return nil
+}
+
+// ListStaleExecutions returns the stale executions found by the last scan of the specified shard.
+func (h *Handler) ListStaleExecutions(ctx thrift.Context,
+	request *hist.ListStaleExecutionsRequest) (resp *hist.ListStaleExecutionsResponse, retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryListStaleExecutionsScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryListStaleExecutionsScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryListStaleExecutionsScope, &retError)
+
+	if !request.IsSetShardId() || request.GetShardId() < 0 || int(request.GetShardId()) >= h.numberOfShards {
+		return nil, errShardIDNotSet
+	}
+
+	// Only the owner of the shard has scanned it
+	if _, err1 := h.controller.getEngineForShard(int(request.GetShardId())); err1 != nil {
+		h.updateErrorMetric(metrics.HistoryListStaleExecutionsScope, err1)
+		return nil, err1
+	}
+
+	response := &hist.ListStaleExecutionsResponse{Executions: []*hist.StaleExecution{}}
+	if h.staleMonitor != nil {
+		response.Executions = append(response.Executions,
+			h.staleMonitor.getStaleExecutions(int(request.GetShardId()))...)
+	}
+	return response, nil
+}
+
+// RedriveTransferDLQTasks moves the transfer tasks parked in the dead letter queue of the specified shard back to its
+// transfer queue.
+func (h *Handler) RedriveTransferDLQTasks(ctx thrift.Context,
+	request *hist.RedriveTransferDLQTasksRequest) (resp *hist.RedriveTransferDLQTasksResponse, retError error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryRedriveTransferDLQTasksScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryRedriveTransferDLQTasksScope, metrics.CadenceLatency)
+	defer sw.Stop()
+	defer h.recoverPanic(ctx, metrics.HistoryRedriveTransferDLQTasksScope, &retError)
+
+	if !request.IsSetShardId() || request.GetShardId() < 0 || int(request.GetShardId()) >= h.numberOfShards {
+		return nil, errShardIDNotSet
+	}
+
+	engine, err1 := h.controller.getEngineForShard(int(request.GetShardId()))
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryRedriveTransferDLQTasksScope, err1)
+		return nil, err1
+	}
+
+	response, err2 := engine.RedriveTransferDLQTasks(ctx, request)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryRedriveTransferDLQTasksScope, h.convertError(err2))
+		return nil, h.convertError(err2)
+	}
+
+	return response, nil
+}
+
 // convertError is a helper method to convert ShardOwnershipLostError from persistence layer returned by various
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
@@ -605,15 +1006,37 @@ func (h *Handler) convertError(err error) error {
 	case *persistence.ShardOwnershipLostError:
 		shardID := err.(*persistence.ShardOwnershipLostError).ShardID
 		info, err := h.hServiceResolver.Lookup(string(shardID))
-		if err != nil {
-			return createShardOwnershipLostError(h.GetHostInfo().GetAddress(), info.GetAddress())
+		if err == nil {
+			return errors.NewShardOwnershipLostError(h.GetHostInfo().GetAddress(), info.GetAddress())
 		}
-		return createShardOwnershipLostError(h.GetHostInfo().GetAddress(), "")
+		return errors.NewShardOwnershipLostError(h.GetHostInfo().GetAddress(), "")
 	}
 
 	return err
 }
 
+// shouldShedLoad returns true if a request of the given priority for the workflow has to be rejected because the shard
+// owning the workflow is overloaded
+func (h *Handler) shouldShedLoad(workflowID string, priority requestPriority) bool {
+	shard := h.controller.getShardContext(workflowID)
+	return shard != nil && h.loadShedder.shouldShed(shard, priority)
+}
+
+// getLogger returns the service logger tagged with the id of the request served by ctx
+func (h *Handler) getLogger(ctx thrift.Context) bark.Logger {
+	return tracing.WithRequestID(h.GetLogger(), ctx)
+}
+
+// recoverPanic converts a panic raised while serving a request into an InternalServiceError returned to the caller,
+// so a bug hit by a single request does not take down the whole host. It must be deferred directly by the handler.
+func (h *Handler) recoverPanic(ctx thrift.Context, scope int, retError *error) {
+	if p := recover(); p != nil {
+		logging.LogPanicRecoveredEvent(h.getLogger(ctx), p, debug.Stack())
+		h.metricsClient.IncCounter(scope, metrics.CadenceErrPanicCounter)
+		*retError = errors.NewInternalServiceError("Internal error: %v", p)
+	}
+}
+
 func (h *Handler) updateErrorMetric(scope int, err error) {
 	switch err.(type) {
 	case *hist.ShardOwnershipLostError:
@@ -624,15 +1047,11 @@ func (h *Handler) updateErrorMetric(scope int, err error) {
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrBadRequestCounter)
 	case *gen.EntityNotExistsError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrEntityNotExistsCounter)
+	case *gen.ServiceBusyError:
+		h.metricsClient.IncCounter(scope, metrics.CadenceErrServiceBusyCounter)
+	case *gen.LimitExceededError:
+		h.metricsClient.IncCounter(scope, metrics.CadenceErrLimitExceededCounter)
 	default:
 		h.metricsClient.IncCounter(scope, metrics.CadenceFailures)
 	}
 }
-
-func createShardOwnershipLostError(currentHost, ownerHost string) *hist.ShardOwnershipLostError {
-	shardLostErr := hist.NewShardOwnershipLostError()
-	shardLostErr.Message = common.StringPtr(fmt.Sprintf("Shard is not owned by host: %v", currentHost))
-	shardLostErr.Owner = common.StringPtr(ownerHost)
-
-	return shardLostErr
-}
@@ -32,12 +32,16 @@ import (
 const (
 	firstEventID int64 = 1
 	emptyEventID int64 = -23
+	// bufferedEventID is the event ID of events buffered while a decision task is in flight, they get their actual
+	// event ID once they are flushed to the history
+	bufferedEventID int64 = -123
 )
 
 type (
 	historyBuilder struct {
 		serializer persistence.HistorySerializer
 		history    []*workflow.HistoryEvent
+		buffered   []*workflow.HistoryEvent
 		msBuilder  *mutableStateBuilder
 		logger     bark.Logger
 	}
@@ -53,7 +57,16 @@ func newHistoryBuilder(msBuilder *mutableStateBuilder, logger bark.Logger) *hist
 }
 
 func (b *historyBuilder) Serialize() (*persistence.SerializedHistoryEventBatch, error) {
-	eventBatch := persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(), b.history)
+	return b.serialize(b.history)
+}
+
+// SerializeBuffered serializes the events buffered while the decision task is in flight
+func (b *historyBuilder) SerializeBuffered() (*persistence.SerializedHistoryEventBatch, error) {
+	return b.serialize(b.buffered)
+}
+
+func (b *historyBuilder) serialize(events []*workflow.HistoryEvent) (*persistence.SerializedHistoryEventBatch, error) {
+	eventBatch := persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(), events)
 	history, err := b.serializer.Serialize(eventBatch)
 	if err != nil {
 		return nil, err
@@ -90,8 +103,8 @@ func (b *historyBuilder) AddDecisionTaskCompletedEvent(scheduleEventID, startedE
 }
 
 func (b *historyBuilder) AddDecisionTaskTimedOutEvent(scheduleEventID int64,
-	startedEventID int64) *workflow.HistoryEvent {
-	event := b.newDecisionTaskTimedOutEvent(scheduleEventID, startedEventID)
+	startedEventID int64, timeoutType workflow.TimeoutType) *workflow.HistoryEvent {
+	event := b.newDecisionTaskTimedOutEvent(scheduleEventID, startedEventID, timeoutType)
 
 	return b.addEventToHistory(event)
 }
@@ -159,6 +172,13 @@ func (b *historyBuilder) AddWorkflowExecutionTerminatedEvent(
 	return b.addEventToHistory(event)
 }
 
+func (b *historyBuilder) AddWorkflowExecutionTerminatedBySystemEvent(reason string,
+	details []byte) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionTerminatedBySystemEvent(reason, details)
+
+	return b.addEventToHistory(event)
+}
+
 func (b *historyBuilder) AddContinuedAsNewEvent(decisionCompletedEventID int64, newRunID string,
 	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newWorkflowExecutionContinuedAsNewEvent(decisionCompletedEventID, newRunID, attributes)
@@ -172,6 +192,9 @@ func (b *historyBuilder) AddTimerStartedEvent(decisionCompletedEventID int64,
 	attributes := workflow.NewTimerStartedEventAttributes()
 	attributes.TimerId = common.StringPtr(request.GetTimerId())
 	attributes.StartToFireTimeoutSeconds = common.Int64Ptr(request.GetStartToFireTimeoutSeconds())
+	if request.IsSetStartToFireTimeoutMilliseconds() {
+		attributes.StartToFireTimeoutMilliseconds = common.Int64Ptr(request.GetStartToFireTimeoutMilliseconds())
+	}
 	attributes.DecisionTaskCompletedEventId = common.Int64Ptr(decisionCompletedEventID)
 
 	event := b.msBuilder.createNewHistoryEvent(workflow.EventType_TimerStarted)
@@ -376,6 +399,11 @@ func (b *historyBuilder) AddChildWorkflowExecutionTerminatedEvent(domain string,
 }
 
 func (b *historyBuilder) addEventToHistory(event *workflow.HistoryEvent) *workflow.HistoryEvent {
+	if event.GetEventId() == bufferedEventID {
+		b.buffered = append(b.buffered, event)
+		return event
+	}
+
 	b.history = append(b.history, event)
 	return event
 }
@@ -390,7 +418,12 @@ func (b *historyBuilder) newWorkflowExecutionStartedEvent(
 	attributes.ExecutionStartToCloseTimeoutSeconds = common.Int32Ptr(request.GetExecutionStartToCloseTimeoutSeconds())
 	attributes.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(request.GetTaskStartToCloseTimeoutSeconds())
 	attributes.Identity = common.StringPtr(request.GetIdentity())
+	if request.GetDelayStartSeconds() > 0 {
+		attributes.FirstDecisionTaskBackoffSeconds = common.Int32Ptr(request.GetDelayStartSeconds())
+	}
+	attributes.Header = request.Header
 	historyEvent.WorkflowExecutionStartedEventAttributes = attributes
+	historyEvent.PayloadReference = request.PayloadReference
 
 	return historyEvent
 }
@@ -402,6 +435,9 @@ func (b *historyBuilder) newDecisionTaskScheduledEvent(taskList string,
 	attributes.TaskList = workflow.NewTaskList()
 	attributes.TaskList.Name = common.StringPtr(taskList)
 	attributes.StartToCloseTimeoutSeconds = common.Int32Ptr(startToCloseTimeoutSeconds)
+	if attempt := b.msBuilder.executionInfo.DecisionAttempt; attempt > 0 {
+		attributes.Attempt = common.Int64Ptr(attempt)
+	}
 	historyEvent.DecisionTaskScheduledEventAttributes = attributes
 
 	return historyEvent
@@ -427,17 +463,24 @@ func (b *historyBuilder) newDecisionTaskCompletedEvent(scheduleEventID, startedE
 	attributes.ScheduledEventId = common.Int64Ptr(scheduleEventID)
 	attributes.StartedEventId = common.Int64Ptr(startedEventID)
 	attributes.Identity = common.StringPtr(request.GetIdentity())
+	if request.IsSetBinaryChecksum() {
+		attributes.BinaryChecksum = common.StringPtr(request.GetBinaryChecksum())
+	}
 	historyEvent.DecisionTaskCompletedEventAttributes = attributes
 
 	return historyEvent
 }
 
-func (b *historyBuilder) newDecisionTaskTimedOutEvent(scheduleEventID int64, startedEventID int64) *workflow.HistoryEvent {
+func (b *historyBuilder) newDecisionTaskTimedOutEvent(scheduleEventID int64, startedEventID int64,
+	timeoutType workflow.TimeoutType) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.createNewHistoryEvent(workflow.EventType_DecisionTaskTimedOut)
 	attributes := workflow.NewDecisionTaskTimedOutEventAttributes()
 	attributes.ScheduledEventId = common.Int64Ptr(scheduleEventID)
 	attributes.StartedEventId = common.Int64Ptr(startedEventID)
-	attributes.TimeoutType = workflow.TimeoutTypePtr(workflow.TimeoutType_START_TO_CLOSE)
+	attributes.TimeoutType = workflow.TimeoutTypePtr(timeoutType)
+	if attempt := b.msBuilder.executionInfo.DecisionAttempt; attempt > 0 {
+		attributes.Attempt = common.Int64Ptr(attempt)
+	}
 	historyEvent.DecisionTaskTimedOutEventAttributes = attributes
 
 	return historyEvent
@@ -451,6 +494,9 @@ func (b *historyBuilder) newDecisionTaskFailedEvent(scheduleEventID int64, start
 	attributes.StartedEventId = common.Int64Ptr(startedEventID)
 	attributes.Cause = workflow.DecisionTaskFailedCausePtr(cause)
 	attributes.Identity = common.StringPtr(request.GetIdentity())
+	if attempt := b.msBuilder.executionInfo.DecisionAttempt; attempt > 0 {
+		attributes.Attempt = common.Int64Ptr(attempt)
+	}
 	historyEvent.DecisionTaskFailedEventAttributes = attributes
 
 	return historyEvent
@@ -468,6 +514,13 @@ func (b *historyBuilder) newActivityTaskScheduledEvent(decisionTaskCompletedEven
 	attributes.ScheduleToStartTimeoutSeconds = common.Int32Ptr(scheduleAttributes.GetScheduleToStartTimeoutSeconds())
 	attributes.StartToCloseTimeoutSeconds = common.Int32Ptr(scheduleAttributes.GetStartToCloseTimeoutSeconds())
 	attributes.HeartbeatTimeoutSeconds = common.Int32Ptr(scheduleAttributes.GetHeartbeatTimeoutSeconds())
+	if scheduleAttributes.IsSetPriority() {
+		attributes.Priority = common.Int32Ptr(scheduleAttributes.GetPriority())
+	}
+	if scheduleAttributes.IsSetSessionId() {
+		attributes.SessionId = common.StringPtr(scheduleAttributes.GetSessionId())
+	}
+	attributes.Header = scheduleAttributes.Header
 	attributes.DecisionTaskCompletedEventId = common.Int64Ptr(decisionTaskCompletedEventID)
 	historyEvent.ActivityTaskScheduledEventAttributes = attributes
 
@@ -495,6 +548,7 @@ func (b *historyBuilder) newActivityTaskCompletedEvent(scheduleEventID, startedE
 	attributes.StartedEventId = common.Int64Ptr(startedEventID)
 	attributes.Identity = common.StringPtr(request.GetIdentity())
 	historyEvent.ActivityTaskCompletedEventAttributes = attributes
+	historyEvent.PayloadReference = request.PayloadReference
 
 	return historyEvent
 }
@@ -522,6 +576,7 @@ func (b *historyBuilder) newActivityTaskFailedEvent(scheduleEventID, startedEven
 	attributes.StartedEventId = common.Int64Ptr(startedEventID)
 	attributes.Identity = common.StringPtr(request.GetIdentity())
 	historyEvent.ActivityTaskFailedEventAttributes = attributes
+	historyEvent.PayloadReference = request.PayloadReference
 
 	return historyEvent
 }
@@ -556,18 +611,35 @@ func (b *historyBuilder) newWorkflowExecutionSignaledEvent(
 	attributes.SignalName = common.StringPtr(request.GetSignalName())
 	attributes.Input = request.GetInput()
 	attributes.Identity = common.StringPtr(request.GetIdentity())
+	attributes.RequestId = request.RequestId
 	historyEvent.WorkflowExecutionSignaledEventAttributes = attributes
+	historyEvent.PayloadReference = request.PayloadReference
 
 	return historyEvent
 }
 
 func (b *historyBuilder) newWorkflowExecutionTerminatedEvent(
 	request *workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent {
-	historyEvent := b.msBuilder.createNewHistoryEvent(workflow.EventType_WorkflowExecutionTerminated)
-	attributes := workflow.NewWorkflowExecutionTerminatedEventAttributes()
+	historyEvent := b.msBuilder.createNewHistoryEvent(workflow.EventType_WorkflowExecutionTerminatedByOperator)
+	attributes := workflow.NewWorkflowExecutionTerminatedByOperatorEventAttributes()
 	attributes.Reason = common.StringPtr(request.GetReason())
 	attributes.Details = request.GetDetails()
 	attributes.Identity = common.StringPtr(request.GetIdentity())
+	if request.IsSetChildPolicy() {
+		attributes.ChildPolicy = workflow.ChildPolicyPtr(request.GetChildPolicy())
+	}
+	historyEvent.WorkflowExecutionTerminatedByOperatorEventAttributes = attributes
+
+	return historyEvent
+}
+
+func (b *historyBuilder) newWorkflowExecutionTerminatedBySystemEvent(reason string,
+	details []byte) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.createNewHistoryEvent(workflow.EventType_WorkflowExecutionTerminated)
+	attributes := workflow.NewWorkflowExecutionTerminatedEventAttributes()
+	attributes.Reason = common.StringPtr(reason)
+	attributes.Details = details
+	attributes.Identity = common.StringPtr(historyServiceIdentity)
 	historyEvent.WorkflowExecutionTerminatedEventAttributes = attributes
 
 	return historyEvent
@@ -690,6 +762,7 @@ func (b *historyBuilder) newStartChildWorkflowExecutionInitiatedEvent(decisionTa
 	attributes.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(startAttributes.GetTaskStartToCloseTimeoutSeconds())
 	attributes.ChildPolicy = workflow.ChildPolicyPtr(startAttributes.GetChildPolicy())
 	attributes.Control = startAttributes.Control
+	attributes.Header = startAttributes.Header
 	attributes.DecisionTaskCompletedEventId = common.Int64Ptr(decisionTaskCompletedEventID)
 	historyEvent.StartChildWorkflowExecutionInitiatedEventAttributes = attributes
 
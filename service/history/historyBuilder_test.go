@@ -302,6 +302,172 @@ func (s *historyBuilderSuite) TestHistoryBuilderDecisionStartedFailures() {
 	s.Equal(emptyEventID, s.getPreviousDecisionStartedEventID())
 }
 
+func (s *historyBuilderSuite) TestHistoryBuilderWorkflowTerminatedByOperator() {
+	id := "dynamic-historybuilder-terminate-test-workflow-id"
+	rid := "dynamic-historybuilder-terminate-test-run-id"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(id),
+		RunId:      common.StringPtr(rid),
+	}
+	workflowStartedEvent := s.addWorkflowExecutionStartedEvent(we, "wfType", "tasklist", []byte("input"), 100, 50,
+		"identity")
+	s.validateWorkflowExecutionStartedEvent(workflowStartedEvent, "wfType", "tasklist", []byte("input"), 100, 50,
+		"identity")
+
+	terminatedEvent := s.msBuilder.AddWorkflowExecutionTerminatedEvent(&workflow.TerminateWorkflowExecutionRequest{
+		WorkflowExecution: &we,
+		Reason:            common.StringPtr("reason"),
+		Details:           []byte("details"),
+		Identity:          common.StringPtr("operator"),
+		ChildPolicy:       workflow.ChildPolicyPtr(workflow.ChildPolicy_ABANDON),
+	})
+	s.NotNil(terminatedEvent)
+	s.Equal(workflow.EventType_WorkflowExecutionTerminatedByOperator, terminatedEvent.GetEventType())
+	s.Equal(int64(2), terminatedEvent.GetEventId())
+	attributes := terminatedEvent.GetWorkflowExecutionTerminatedByOperatorEventAttributes()
+	s.NotNil(attributes)
+	s.Equal("reason", attributes.GetReason())
+	s.Equal([]byte("details"), attributes.GetDetails())
+	s.Equal("operator", attributes.GetIdentity())
+	s.Equal(workflow.ChildPolicy_ABANDON, attributes.GetChildPolicy())
+	s.Equal(persistence.WorkflowCloseStatusTerminated, s.msBuilder.executionInfo.CloseStatus)
+}
+
+func (s *historyBuilderSuite) TestHistoryBuilderHeaders() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("dynamic-historybuilder-header-test-workflow-id"),
+		RunId:      common.StringPtr("dynamic-historybuilder-header-test-run-id"),
+	}
+	header := &workflow.Header{Fields: map[string][]byte{"trace-id": []byte("trace")}}
+	workflowStartedEvent := s.msBuilder.AddWorkflowExecutionStartedEvent(s.domainID, we,
+		&workflow.StartWorkflowExecutionRequest{
+			WorkflowId:                          we.WorkflowId,
+			WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wfType")},
+			TaskList:                            &workflow.TaskList{Name: common.StringPtr("tasklist")},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(100),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(50),
+			Header:                              header,
+		})
+	s.Equal(header, workflowStartedEvent.GetWorkflowExecutionStartedEventAttributes().GetHeader())
+
+	_, di := s.addDecisionTaskScheduledEvent()
+	s.addDecisionTaskStartedEvent(di.ScheduleID, "tasklist", "identity")
+	decisionCompletedEvent := s.addDecisionTaskCompletedEvent(di.ScheduleID, di.ScheduleID+1, nil, "identity")
+
+	activityScheduledEvent, _ := s.msBuilder.AddActivityTaskScheduledEvent(decisionCompletedEvent.GetEventId(),
+		&workflow.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:   common.StringPtr("activity"),
+			ActivityType: &workflow.ActivityType{Name: common.StringPtr("activityType")},
+			TaskList:     &workflow.TaskList{Name: common.StringPtr("tasklist")},
+			Header:       header,
+		}, nil)
+	s.Equal(header, activityScheduledEvent.GetActivityTaskScheduledEventAttributes().GetHeader())
+
+	childInitiatedEvent, _ := s.msBuilder.AddStartChildWorkflowExecutionInitiatedEvent(
+		decisionCompletedEvent.GetEventId(), uuid.New(), &workflow.StartChildWorkflowExecutionDecisionAttributes{
+			Domain:       common.StringPtr(s.domainID),
+			WorkflowId:   common.StringPtr("child"),
+			WorkflowType: &workflow.WorkflowType{Name: common.StringPtr("childType")},
+			TaskList:     &workflow.TaskList{Name: common.StringPtr("tasklist")},
+			Header:       header,
+		}, nil)
+	s.Equal(header, childInitiatedEvent.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetHeader())
+}
+
+func (s *historyBuilderSuite) TestHistoryBuilderBufferedEvents() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("dynamic-historybuilder-buffered-test-workflow-id"),
+		RunId:      common.StringPtr("dynamic-historybuilder-buffered-test-run-id"),
+	}
+	s.addWorkflowExecutionStartedEvent(we, "wfType", "tasklist", []byte("input"), 100, 50, "identity")
+	_, di := s.addDecisionTaskScheduledEvent()
+	s.addDecisionTaskStartedEvent(di.ScheduleID, "tasklist", "identity")
+	s.Equal(int64(4), s.getNextEventID())
+
+	// Signals received while the decision is in flight don't get an event ID until it completes
+	signaledEvent := s.msBuilder.AddWorkflowExecutionSignaled(&workflow.SignalWorkflowExecutionRequest{
+		SignalName: common.StringPtr("signal1"),
+	})
+	s.Equal(bufferedEventID, signaledEvent.GetEventId())
+	s.Equal(int64(4), s.getNextEventID())
+	s.True(s.msBuilder.HasBufferedEvents())
+
+	// Buffered events persisted with an earlier update are flushed as well
+	serializedEvents, err := s.msBuilder.hBuilder.SerializeBuffered()
+	s.Nil(err)
+	s.msBuilder.CloseUpdateSession()
+	s.msBuilder.bufferedEvents = append(s.msBuilder.bufferedEvents, serializedEvents)
+	s.msBuilder.AddWorkflowExecutionSignaled(&workflow.SignalWorkflowExecutionRequest{
+		SignalName: common.StringPtr("signal2"),
+	})
+
+	failedEvent := s.msBuilder.AddDecisionTaskFailedEvent(di.ScheduleID, di.ScheduleID+1,
+		workflow.DecisionTaskFailedCause_UNHANDLED_DECISION, &workflow.RespondDecisionTaskCompletedRequest{})
+	s.NotNil(failedEvent)
+	s.Equal(int64(4), failedEvent.GetEventId())
+	s.Equal(int64(7), s.getNextEventID())
+	s.False(s.msBuilder.HasBufferedEvents())
+
+	history := s.msBuilder.hBuilder.history
+	s.Equal(3, len(history))
+	s.Equal(int64(5), history[1].GetEventId())
+	s.Equal("signal1", history[1].GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+	s.Equal(int64(6), history[2].GetEventId())
+	s.Equal("signal2", history[2].GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+	s.True(s.msBuilder.CloseUpdateSession().clearBufferedEvents)
+
+	// Once the decision is no longer in flight events get their event ID right away
+	signaledEvent = s.msBuilder.AddWorkflowExecutionSignaled(&workflow.SignalWorkflowExecutionRequest{
+		SignalName: common.StringPtr("signal3"),
+	})
+	s.Equal(int64(7), signaledEvent.GetEventId())
+}
+
+func (s *historyBuilderSuite) TestHistoryBuilderSignalReceiptsLimit() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("dynamic-historybuilder-receipts-test-workflow-id"),
+		RunId:      common.StringPtr("dynamic-historybuilder-receipts-test-run-id"),
+	}
+	s.addWorkflowExecutionStartedEvent(we, "wfType", "tasklist", []byte("input"), 100, 50, "identity")
+	signal := func() string {
+		requestID := uuid.New()
+		s.msBuilder.AddWorkflowExecutionSignaled(&workflow.SignalWorkflowExecutionRequest{
+			SignalName: common.StringPtr("signal"),
+			RequestId:  common.StringPtr(requestID),
+		})
+		return requestID
+	}
+
+	oldestRequestID := signal()
+	for i := 1; i < maxSignalReceipts; i++ {
+		signal()
+	}
+	s.msBuilder.CloseUpdateSession()
+	s.Equal(maxSignalReceipts, len(s.msBuilder.signalReceipts))
+
+	// The oldest receipt is evicted once the limit is reached
+	requestID := signal()
+	updates := s.msBuilder.CloseUpdateSession()
+	s.Equal(maxSignalReceipts, len(s.msBuilder.signalReceipts))
+	s.Equal([]string{oldestRequestID}, updates.deleteSignalReceipts)
+	s.Contains(updates.updateSignalReceipts, requestID)
+	_, ok := s.msBuilder.GetSignalReceipt(oldestRequestID)
+	s.False(ok)
+
+	// Receipts of buffered signals are kept until they are flushed
+	_, di := s.addDecisionTaskScheduledEvent()
+	s.addDecisionTaskStartedEvent(di.ScheduleID, "tasklist", "identity")
+	s.msBuilder.signalReceipts = map[string]int64{}
+	for i := 0; i < maxSignalReceipts; i++ {
+		signal()
+	}
+	s.msBuilder.CloseUpdateSession()
+	signal()
+	updates = s.msBuilder.CloseUpdateSession()
+	s.Equal(maxSignalReceipts+1, len(s.msBuilder.signalReceipts))
+	s.Empty(updates.deleteSignalReceipts)
+}
+
 func (s *historyBuilderSuite) getNextEventID() int64 {
 	return s.msBuilder.executionInfo.NextEventID
 }
@@ -362,7 +528,7 @@ func (s *historyBuilderSuite) addActivityTaskScheduledEvent(decisionCompletedID
 			ScheduleToCloseTimeoutSeconds: common.Int32Ptr(timeout),
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(queueTimeout),
 			HeartbeatTimeoutSeconds:       common.Int32Ptr(hearbeatTimeout),
-		})
+		}, nil)
 }
 
 func (s *historyBuilderSuite) addActivityTaskStartedEvent(scheduleID int64, taskList,
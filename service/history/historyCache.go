@@ -21,16 +21,20 @@
 package history
 
 import (
+	"sync/atomic"
 	"time"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/tracing"
 
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/common"
+	"golang.org/x/net/context"
 )
 
 const (
@@ -47,7 +51,15 @@ type (
 		shard            ShardContext
 		executionManager persistence.ExecutionManager
 		disabled         bool
-		logger           bark.Logger
+		// failOnChecksumMismatch is passed on to the workflow execution contexts created by the cache
+		failOnChecksumMismatch bool
+		// completedExecutions is passed on to the workflow execution contexts, which record the executions they close
+		completedExecutions *completedExecutionCache
+		// historyEventsPerBatch is passed on to the workflow execution contexts created by the cache
+		historyEventsPerBatch int
+		// bytes adds up the mutable state sizes of the cached workflow execution contexts
+		bytes  int64
+		logger bark.Logger
 	}
 )
 
@@ -63,17 +75,19 @@ func newHistoryCache(maxSize int, shard ShardContext, logger bark.Logger) *histo
 	opts.TTL = historyCacheTTL
 	opts.Pin = true
 
-	return &historyCache{
-		Cache:            cache.New(maxSize, opts),
+	c := &historyCache{
 		shard:            shard,
 		executionManager: shard.GetExecutionManager(),
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueHistoryCacheComponent,
 		}),
 	}
+	opts.RemovedFunc = c.onContextRemoved
+	c.Cache = cache.New(maxSize, opts)
+	return c
 }
 
-func (c *historyCache) getOrCreateWorkflowExecution(domainID string,
+func (c *historyCache) getOrCreateWorkflowExecution(ctx context.Context, domainID string,
 	execution workflow.WorkflowExecution) (*workflowExecutionContext, releaseWorkflowExecutionFunc, error) {
 	if execution.GetWorkflowId() == "" {
 		return nil, nil, &workflow.InternalServiceError{Message: "Can't load workflow execution.  WorkflowId not set."}
@@ -81,7 +95,7 @@ func (c *historyCache) getOrCreateWorkflowExecution(domainID string,
 
 	// RunID is not provided, lets try to retrieve the RunID for current active execution
 	if execution.GetRunId() == "" {
-		response, err := c.getCurrentExecutionWithRetry(&persistence.GetCurrentExecutionRequest{
+		response, err := c.getCurrentExecutionWithRetry(ctx, &persistence.GetCurrentExecutionRequest{
 			DomainID:   domainID,
 			WorkflowID: execution.GetWorkflowId(),
 		})
@@ -95,38 +109,76 @@ func (c *historyCache) getOrCreateWorkflowExecution(domainID string,
 
 	// Test hook for disabling the cache
 	if c.disabled {
-		return newWorkflowExecutionContext(domainID, execution, c.shard, c.executionManager, c.logger), func() {}, nil
+		context := newWorkflowExecutionContext(domainID, execution, c.shard, c.executionManager, c.logger)
+		context.failOnChecksumMismatch = c.failOnChecksumMismatch
+		context.completedExecutions = c.completedExecutions
+		context.historyEventsPerBatch = c.historyEventsPerBatch
+		return context, func() {}, nil
 	}
 
-	key := execution.GetRunId()
+	// Migrated executions keep their run ID, so it is only unique within a domain
+	key := domainID + "/" + execution.GetRunId()
 	context, cacheHit := c.Get(key).(*workflowExecutionContext)
 	if !cacheHit {
 		// Let's create the workflow execution context
 		context = newWorkflowExecutionContext(domainID, execution, c.shard, c.executionManager, c.logger)
+		context.failOnChecksumMismatch = c.failOnChecksumMismatch
+		context.completedExecutions = c.completedExecutions
+		context.historyEventsPerBatch = c.historyEventsPerBatch
+		context.cacheBytes = &c.bytes
 		elem, err := c.PutIfNotExist(key, context)
 		if err != nil {
 			return nil, nil, err
 		}
 		context = elem.(*workflowExecutionContext)
 	}
+	c.emitSizeMetrics()
 
 	// This will create a closure on every request.
 	// Consider revisiting this if it causes too much GC activity
 	releaseFunc := func() {
+		// A panic raised while the execution is locked can leave a partially applied update in the mutable state,
+		// which is dropped so the next request reloads it.  The release has to be deferred for recover to see it.
+		if p := recover(); p != nil {
+			context.clear()
+			context.Unlock()
+			c.Release(key)
+			panic(p)
+		}
 		context.Unlock()
 		c.Release(key)
 	}
 
+	span, _ := tracing.StartSpan(ctx, "HistoryCache.LockExecution")
 	context.Lock()
+	span.Finish()
 	return context, releaseFunc, nil
 }
 
-func (c *historyCache) getCurrentExecutionWithRetry(
+// onContextRemoved takes the mutable state of an evicted workflow execution context out of the bytes held by the cache
+func (c *historyCache) onContextRemoved(value interface{}) {
+	context := value.(*workflowExecutionContext)
+	atomic.AddInt64(&c.bytes, -atomic.SwapInt64(&context.stateSize, 0))
+}
+
+func (c *historyCache) emitSizeMetrics() {
+	entries := c.Size()
+	bytes := atomic.LoadInt64(&c.bytes)
+	metricsClient := c.shard.GetMetricsClient()
+	metricsClient.UpdateGauge(metrics.HistoryCacheScope, metrics.HistoryCacheEntriesGauge, float64(entries))
+	metricsClient.UpdateGauge(metrics.HistoryCacheScope, metrics.HistoryCacheBytesGauge, float64(bytes))
+	if entries > 0 {
+		metricsClient.UpdateGauge(metrics.HistoryCacheScope, metrics.HistoryCacheAverageStateSizeGauge,
+			float64(bytes)/float64(entries))
+	}
+}
+
+func (c *historyCache) getCurrentExecutionWithRetry(ctx context.Context,
 	request *persistence.GetCurrentExecutionRequest) (*persistence.GetCurrentExecutionResponse, error) {
 	var response *persistence.GetCurrentExecutionResponse
 	op := func() error {
 		var err error
-		response, err = c.executionManager.GetCurrentExecution(request)
+		response, err = c.executionManager.GetCurrentExecution(ctx, request)
 
 		return err
 	}
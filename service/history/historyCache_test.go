@@ -25,13 +25,16 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/pborman/uuid"
+	"golang.org/x/net/context"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
 )
@@ -60,19 +63,22 @@ func (s *historyCacheSuite) SetupTest() {
 	s.Assertions = require.New(s.T())
 	s.mockExecutionMgr = &mocks.ExecutionManager{}
 	s.mockShard = &shardContextImpl{
-		shardInfo:                 &persistence.ShardInfo{ShardID: 0, RangeID: 1, TransferAckLevel: 0},
-		transferSequenceNumber:    1,
-		executionManager:          s.mockExecutionMgr,
-		shardManager:              &mocks.ShardManager{},
-		rangeSize:                 defaultRangeSize,
-		maxTransferSequenceNumber: 100000,
-		closeCh:                   make(chan int, 100),
-		logger:                    s.logger,
+		shardInfo:             &persistence.ShardInfo{ShardID: 0, RangeID: 1, TransferAckLevel: 0},
+		taskSequenceNumber:    1,
+		executionManager:      s.mockExecutionMgr,
+		shardManager:          &mocks.ShardManager{},
+		rangeSize:             defaultRangeSize,
+		maxTaskSequenceNumber: 100000,
+		closeEvents:           newShardEventBus(),
+		logger:                s.logger,
+		metricsClient:         metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:            common.NewRealTimeSource(),
 	}
 	s.cache = newHistoryCache(historyCacheMaxSize, s.mockShard, s.logger)
 }
 
 func (s *historyCacheSuite) TestHistoryCachePinning() {
+	ctx := context.Background()
 	domain := "test_domain"
 	s.cache = newHistoryCache(2, s.mockShard, s.logger)
 	we := workflow.WorkflowExecution{
@@ -80,7 +86,7 @@ func (s *historyCacheSuite) TestHistoryCachePinning() {
 		RunId:      common.StringPtr(uuid.New()),
 	}
 
-	context, release, err := s.cache.getOrCreateWorkflowExecution(domain, we)
+	context, release, err := s.cache.getOrCreateWorkflowExecution(ctx, domain, we)
 	s.Nil(err)
 
 	we2 := workflow.WorkflowExecution{
@@ -89,19 +95,123 @@ func (s *historyCacheSuite) TestHistoryCachePinning() {
 	}
 
 	// Cache is full because context is pinned, should get an error now
-	_, _, err2 := s.cache.getOrCreateWorkflowExecution(domain, we2)
+	_, _, err2 := s.cache.getOrCreateWorkflowExecution(ctx, domain, we2)
 	s.NotNil(err2)
 
 	// Now release the context, this should unpin it.
 	release()
 
-	_, release2, err3 := s.cache.getOrCreateWorkflowExecution(domain, we2)
+	_, release2, err3 := s.cache.getOrCreateWorkflowExecution(ctx, domain, we2)
 	s.Nil(err3)
 	release2()
 
 	// Old context should be evicted.
-	newContext, release, err4 := s.cache.getOrCreateWorkflowExecution(domain, we)
+	newContext, release, err4 := s.cache.getOrCreateWorkflowExecution(ctx, domain, we)
 	s.Nil(err4)
 	s.False(context == newContext)
 	release()
 }
+
+func (s *historyCacheSuite) TestMutableStateChecksumVerification() {
+	ctx := context.Background()
+	domainID := "test_domain"
+	newState := func(we workflow.WorkflowExecution) *persistence.WorkflowMutableState {
+		return &persistence.WorkflowMutableState{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				DomainID:    domainID,
+				WorkflowID:  we.GetWorkflowId(),
+				RunID:       we.GetRunId(),
+				NextEventID: 5,
+			},
+			ActivitInfos: map[int64]*persistence.ActivityInfo{
+				3: {ScheduleID: 3, StartedID: emptyEventID, ActivityID: "activity"},
+			},
+			TimerInfos:          map[string]*persistence.TimerInfo{},
+			ChildExecutionInfos: map[int64]*persistence.ChildExecutionInfo{},
+		}
+	}
+	loadWithChecksum := func(we workflow.WorkflowExecution, checksum int64) error {
+		state := newState(we)
+		state.Checksum = checksum
+		s.mockExecutionMgr.On("GetWorkflowExecution", ctx, &persistence.GetWorkflowExecutionRequest{
+			DomainID:  domainID,
+			Execution: we,
+		}).Return(&persistence.GetWorkflowExecutionResponse{State: state}, nil).Once()
+
+		context, release, err := s.cache.getOrCreateWorkflowExecution(ctx, domainID, we)
+		s.Nil(err)
+		defer release()
+		_, err = context.loadWorkflowExecution(ctx)
+		context.clear()
+		return err
+	}
+
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wf-checksum-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	msBuilder := newMutableStateBuilder(s.logger)
+	msBuilder.Load(newState(we))
+	checksum := msBuilder.checksum()
+
+	s.cache.failOnChecksumMismatch = true
+	s.Nil(loadWithChecksum(we, checksum))
+	// Executions last updated without a checksum are not verified
+	s.Nil(loadWithChecksum(we, 0))
+	err := loadWithChecksum(we, checksum+1)
+	s.IsType(&workflow.InternalServiceError{}, err)
+
+	// Without fail fast the mismatch is only logged and counted
+	s.cache.failOnChecksumMismatch = false
+	we.RunId = common.StringPtr(uuid.New())
+	msBuilder = newMutableStateBuilder(s.logger)
+	msBuilder.Load(newState(we))
+	s.Nil(loadWithChecksum(we, msBuilder.checksum()+1))
+	s.mockExecutionMgr.AssertExpectations(s.T())
+}
+
+func (s *historyCacheSuite) TestHistoryCacheBytes() {
+	ctx := context.Background()
+	domainID := "test_domain"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wf-cache-bytes-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	state := &persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			DomainID:         domainID,
+			WorkflowID:       we.GetWorkflowId(),
+			RunID:            we.GetRunId(),
+			ExecutionContext: make([]byte, 1024),
+			NextEventID:      5,
+		},
+		ActivitInfos: map[int64]*persistence.ActivityInfo{
+			3: {ScheduleID: 3, StartedID: emptyEventID, ActivityID: "activity", Details: make([]byte, 2048)},
+		},
+		TimerInfos:          map[string]*persistence.TimerInfo{},
+		ChildExecutionInfos: map[int64]*persistence.ChildExecutionInfo{},
+	}
+	s.mockExecutionMgr.On("GetWorkflowExecution", ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID:  domainID,
+		Execution: we,
+	}).Return(&persistence.GetWorkflowExecutionResponse{State: state}, nil).Twice()
+
+	context, release, err := s.cache.getOrCreateWorkflowExecution(ctx, domainID, we)
+	s.Nil(err)
+	msBuilder, err := context.loadWorkflowExecution(ctx)
+	s.Nil(err)
+	size := msBuilder.size()
+	s.True(size > 3072)
+	s.Equal(int64(size), s.cache.bytes)
+
+	// Clearing the context drops its mutable state from the cache
+	context.clear()
+	s.Equal(int64(0), s.cache.bytes)
+
+	_, err = context.loadWorkflowExecution(ctx)
+	s.Nil(err)
+	release()
+	s.cache.onContextRemoved(context)
+	s.Equal(int64(0), s.cache.bytes)
+	s.mockExecutionMgr.AssertExpectations(s.T())
+}
@@ -21,20 +21,28 @@
 package history
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/pborman/uuid"
 	"github.com/uber-common/bark"
 	h "github.com/uber/cadence/.gen/go/history"
+	m "github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	hc "github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
+	cerrors "github.com/uber/cadence/common/errors"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/tracing"
+	"github.com/uber/tchannel-go/thrift"
+	"golang.org/x/net/context"
 )
 
 const (
@@ -42,6 +50,10 @@ const (
 	activityCancelationMsgActivityIDUnknown  = "ACTIVITY_ID_UNKNOWN"
 	activityCancelationMsgActivityNotStarted = "ACTIVITY_ID_NOT_STARTED"
 	timerCancelationMsgTimerIDUnknown        = "TIMER_ID_UNKNOWN"
+	stuckWorkflowTerminateReason             = "STUCK_DECISION"
+	historyServiceIdentity                   = "history-service"
+	refreshHistoryPageSize                   = 100
+	transferDLQRedriveBatchSize              = 100
 )
 
 type (
@@ -52,6 +64,8 @@ type (
 		executionManager   persistence.ExecutionManager
 		txProcessor        transferQueueProcessor
 		timerProcessor     timerQueueProcessor
+		historyClient      hc.Client
+		matchingClient     matching.Client
 		tokenSerializer    common.TaskTokenSerializer
 		hSerializerFactory persistence.HistorySerializerFactory
 		metricsReporter    metrics.Client
@@ -59,13 +73,41 @@ type (
 		domainCache        cache.DomainCache
 		metricsClient      metrics.Client
 		logger             bark.Logger
-	}
-
-	// shardContextWrapper wraps ShardContext to notify transferQueueProcessor on new tasks.
-	// TODO: use to notify timerQueueProcessor as well.
+		config             *Config
+		queryRegistry      *queryRegistry
+		signalBatcher      *signalBatcher
+		taskMetrics        *taskMetrics
+		// completedExecutions is shared by the engines of all the shards of the host
+		completedExecutions *completedExecutionCache
+		// completedActivities is shared by the engines of all the shards of the host
+		completedActivities *completedActivityCache
+		migrator            *workflowMigrator
+		payloadBlobs        *payloadBlobs
+		openExecutions      *openExecutionCounter
+	}
+
+	// shardContextWrapper wraps ShardContext to notify transferQueueProcessor and timerQueueProcessor on new tasks.
+	// It also creates the schedule to start timeout for every new decision task, and counts the open executions.
 	shardContextWrapper struct {
 		ShardContext
-		txProcessor transferQueueProcessor
+		txProcessor    transferQueueProcessor
+		timerProcessor timerQueueProcessor
+		tBuilder       *timerBuilder
+		openExecutions *openExecutionCounter
+		config         *Config
+	}
+
+	// mutableStateDump is the JSON document returned by DescribeMutableState
+	mutableStateDump struct {
+		ExecutionInfo        *persistence.WorkflowExecutionInfo
+		PendingDecision      *decisionInfo
+		ActivityInfos        map[int64]*persistence.ActivityInfo
+		TimerInfos           map[string]*persistence.TimerInfo
+		ChildExecutionInfos  map[int64]*persistence.ChildExecutionInfo
+		RequestCancelInfos   map[int64]*persistence.RequestCancelInfo
+		SignalReceipts       map[string]int64
+		TransferAckLevel     int64
+		TransferMaxReadLevel int64
 	}
 )
 
@@ -78,36 +120,66 @@ var (
 	ErrConflict = errors.New("Conditional update failed")
 	// ErrMaxAttemptsExceeded is exported temporarily for integration test
 	ErrMaxAttemptsExceeded = errors.New("Maximum attempts exceeded to update history")
+
+	// errSignalsAlreadyRecorded aborts the update of a signal batch made only of retries of recorded signals
+	errSignalsAlreadyRecorded = errors.New("Signals already recorded")
 )
 
 // NewEngineWithShardContext creates an instance of history engine
 func NewEngineWithShardContext(shard ShardContext, metadataMgr persistence.MetadataManager,
-	visibilityMgr persistence.VisibilityManager, matching matching.Client, historyClient hc.Client) Engine {
-	shardWrapper := &shardContextWrapper{ShardContext: shard}
-	shard = shardWrapper
+	visibilityMgr persistence.VisibilityManager, matching matching.Client, historyClient hc.Client,
+	tokenSerializer common.TaskTokenSerializer, completedExecutions *completedExecutionCache,
+	completedActivities *completedActivityCache, payloadBlobs *payloadBlobs, config *Config) Engine {
 	logger := shard.GetLogger()
+	openExecutions := newOpenExecutionCounter(metadataMgr, logger)
+	shardWrapper := &shardContextWrapper{ShardContext: shard, openExecutions: openExecutions, config: config}
+	shard = shardWrapper
 	executionManager := shard.GetExecutionManager()
 	historyManager := shard.GetHistoryManager()
 	historyCache := newHistoryCache(historyCacheMaxSize, shard, logger)
+	historyCache.failOnChecksumMismatch = config.MutableStateChecksumFailFast
+	historyCache.completedExecutions = completedExecutions
+	historyCache.historyEventsPerBatch = config.HistoryEventsPerBatch
 	domainCache := cache.NewDomainCache(metadataMgr, logger)
-	txProcessor := newTransferQueueProcessor(shard, visibilityMgr, matching, historyClient, historyCache, domainCache)
+	txProcessor := newTransferQueueProcessor(shard, visibilityMgr, matching, historyClient, historyCache, domainCache,
+		payloadBlobs, config)
 	historyEngImpl := &historyEngineImpl{
 		shard:              shard,
 		metadataMgr:        metadataMgr,
 		historyMgr:         historyManager,
 		executionManager:   executionManager,
 		txProcessor:        txProcessor,
-		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
+		historyClient:      historyClient,
+		matchingClient:     matching,
+		tokenSerializer:    tokenSerializer,
 		hSerializerFactory: persistence.NewHistorySerializerFactory(),
 		historyCache:       historyCache,
 		domainCache:        domainCache,
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueHistoryEngineComponent,
 		}),
-		metricsClient: shard.GetMetricsClient(),
+		metricsClient:       shard.GetMetricsClient(),
+		config:              config,
+		queryRegistry:       newQueryRegistry(),
+		signalBatcher:       newSignalBatcher(config.SignalBatchMaxSize),
+		taskMetrics:         newTaskMetrics(shard.GetMetricsClient()),
+		completedExecutions: completedExecutions,
+		completedActivities: completedActivities,
+		payloadBlobs:        payloadBlobs,
+		openExecutions:      openExecutions,
 	}
 	historyEngImpl.timerProcessor = newTimerQueueProcessor(historyEngImpl, executionManager, logger)
+	historyEngImpl.migrator = newWorkflowMigrator(shard, visibilityMgr, historyCache, domainCache, payloadBlobs,
+		historyEngImpl.logger)
+	if isQueuePaused(shard, h.QueueType_TRANSFER, config.TransferQueueProcessingPaused) {
+		txProcessor.Pause()
+	}
+	if isQueuePaused(shard, h.QueueType_TIMER, config.TimerQueueProcessingPaused) {
+		historyEngImpl.timerProcessor.Pause()
+	}
 	shardWrapper.txProcessor = txProcessor
+	shardWrapper.timerProcessor = historyEngImpl.timerProcessor
+	shardWrapper.tBuilder = newTimerBuilder(&shardSeqNumGenerator{context: shard}, shard.GetTimeSource(), logger)
 	return historyEngImpl
 }
 
@@ -132,8 +204,12 @@ func (e *historyEngineImpl) Stop() {
 }
 
 // StartWorkflowExecution starts a workflow execution
-func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflowExecutionRequest) (
+func (e *historyEngineImpl) StartWorkflowExecution(ctx thrift.Context, startRequest *h.StartWorkflowExecutionRequest) (
 	*workflow.StartWorkflowExecutionResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.StartWorkflowExecution")
+	defer span.Finish()
+	logger := tracing.WithRequestID(e.logger, ctx)
+
 	domainID := startRequest.GetDomainUUID()
 	request := startRequest.GetStartRequest()
 	executionID := request.GetWorkflowId()
@@ -145,6 +221,11 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 		RunId:      common.StringPtr(runID),
 	}
 
+	info, domainConfig, err := e.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return nil, err
+	}
+
 	var parentExecution *workflow.WorkflowExecution
 	initiatedID := emptyEventID
 	parentDomainID := ""
@@ -153,6 +234,23 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 		parentDomainID = parentInfo.GetDomainUUID()
 		parentExecution = parentInfo.GetExecution()
 		initiatedID = parentInfo.GetInitiatedId()
+	} else if info.Status == persistence.DomainStatusDraining {
+		// Child executions are started on behalf of executions which already run, so draining domains only reject
+		// the executions started by clients
+		return nil, cerrors.NewDomainNotActiveError(info.Name,
+			"Domain is draining, no new workflow executions can be started.")
+	}
+
+	// Executions continued as new replace their previous run, so the cap is enforced on the executions started by
+	// clients and parent executions
+	if err := e.openExecutions.checkLimit(ctx, domainID, domainConfig.MaxOpenExecutions); err != nil {
+		// The execution started by an earlier attempt of the request counts towards the cap
+		if runID, ok := e.getDuplicateStartRunID(ctx, domainID, executionID, request.GetRequestId()); ok {
+			e.metricsClient.IncCounter(metrics.HistoryStartWorkflowExecutionScope,
+				metrics.DeduplicatedStartWorkflowExecutionCounter)
+			return &workflow.StartWorkflowExecutionResponse{RunId: common.StringPtr(runID)}, nil
+		}
+		return nil, err
 	}
 
 	// Generate first decision task event.
@@ -163,7 +261,9 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 		return nil, &workflow.InternalServiceError{Message: "Failed to add workflow execution started event."}
 	}
 
-	var transferTasks []persistence.Task
+	// The open visibility record is written by the transfer queue, so it is retried until it succeeds
+	transferTasks := []persistence.Task{&persistence.RecordWorkflowStartedTask{}}
+	var timerTasks []persistence.Task
 	decisionScheduleID := emptyEventID
 	decisionStartID := emptyEventID
 	decisionTimeout := int32(0)
@@ -174,9 +274,22 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 			return nil, &workflow.InternalServiceError{Message: "Failed to add decision started event."}
 		}
 
-		transferTasks = []persistence.Task{&persistence.DecisionTask{
-			DomainID: domainID, TaskList: taskList, ScheduleID: di.ScheduleID,
-		}}
+		if request.GetDelayStartSeconds() > 0 {
+			// The first decision of a delayed execution is dispatched by a timer once the requested delay is over.
+			// Having it scheduled keeps signals and cancellation requests received during the delay from
+			// scheduling one early.
+			tBuilder := newTimerBuilder(&shardSeqNumGenerator{context: e.shard}, e.shard.GetTimeSource(), e.logger)
+			backoffTask, err := tBuilder.AddFirstDecisionBackoffTask(di.ScheduleID, request.GetDelayStartSeconds())
+			if err != nil {
+				return nil, err
+			}
+			timerTasks = []persistence.Task{backoffTask}
+			defer e.timerProcessor.NotifyNewTimer(backoffTask.GetTaskID())
+		} else {
+			transferTasks = append(transferTasks, &persistence.DecisionTask{
+				DomainID: domainID, TaskList: taskList, ScheduleID: di.ScheduleID,
+			})
+		}
 		decisionScheduleID = di.ScheduleID
 		decisionStartID = di.StartedID
 		decisionTimeout = di.DecisionTimeout
@@ -185,12 +298,12 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 	// Serialize the history
 	serializedHistory, serializedError := msBuilder.hBuilder.Serialize()
 	if serializedError != nil {
-		logging.LogHistorySerializationErrorEvent(e.logger, serializedError, fmt.Sprintf(
+		logging.LogHistorySerializationErrorEvent(logger, serializedError, fmt.Sprintf(
 			"HistoryEventBatch serialization error on start workflow.  WorkflowID: %v, RunID: %v", executionID, runID))
 		return nil, serializedError
 	}
 
-	err1 := e.shard.AppendHistoryEvents(&persistence.AppendHistoryEventsRequest{
+	err1 := e.shard.AppendHistoryEvents(ctx, &persistence.AppendHistoryEventsRequest{
 		DomainID:  domainID,
 		Execution: workflowExecution,
 		// It is ok to use 0 for TransactionID because RunID is unique so there are
@@ -203,7 +316,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 		return nil, err1
 	}
 
-	_, err := e.shard.CreateWorkflowExecution(&persistence.CreateWorkflowExecutionRequest{
+	_, err = e.shard.CreateWorkflowExecution(ctx, &persistence.CreateWorkflowExecutionRequest{
 		RequestID:                   request.GetRequestId(),
 		DomainID:                    domainID,
 		Execution:                   workflowExecution,
@@ -217,6 +330,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 		NextEventID:                 msBuilder.GetNextEventID(),
 		LastProcessedEvent:          emptyEventID,
 		TransferTasks:               transferTasks,
+		TimerTasks:                  timerTasks,
 		DecisionScheduleID:          decisionScheduleID,
 		DecisionStartedID:           decisionStartID,
 		DecisionStartToCloseTimeout: decisionTimeout,
@@ -230,12 +344,19 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 			// us to leak history events which are never cleaned up.  Cleaning up the events is absolutely safe here as they
 			// are always created for a unique run_id which is not visible beyond this call yet.
 			// TODO: Handle error on deletion of execution history
-			e.historyMgr.DeleteWorkflowExecutionHistory(&persistence.DeleteWorkflowExecutionHistoryRequest{
+			e.historyMgr.DeleteWorkflowExecutionHistory(ctx, &persistence.DeleteWorkflowExecutionHistoryRequest{
 				DomainID:  domainID,
 				Execution: workflowExecution,
 			})
 
+			if t.IsSetStartTime() {
+				// How long after the running execution was started callers still retry or race to start it
+				e.metricsClient.RecordTimer(metrics.HistoryStartWorkflowExecutionScope,
+					metrics.DuplicateStartWorkflowExecutionAge, time.Since(time.Unix(0, t.GetStartTime())))
+			}
 			if t.GetStartRequestId() == request.GetRequestId() {
+				e.metricsClient.IncCounter(metrics.HistoryStartWorkflowExecutionScope,
+					metrics.DeduplicatedStartWorkflowExecutionCounter)
 				return &workflow.StartWorkflowExecutionResponse{
 					RunId: t.RunId,
 				}, nil
@@ -245,13 +366,13 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 			// us to leak history events which are never cleaned up. Cleaning up the events is absolutely safe here as they
 			// are always created for a unique run_id which is not visible beyond this call yet.
 			// TODO: Handle error on deletion of execution history
-			e.historyMgr.DeleteWorkflowExecutionHistory(&persistence.DeleteWorkflowExecutionHistoryRequest{
+			e.historyMgr.DeleteWorkflowExecutionHistory(ctx, &persistence.DeleteWorkflowExecutionHistoryRequest{
 				DomainID:  domainID,
 				Execution: workflowExecution,
 			})
 		}
 
-		logging.LogPersistantStoreErrorEvent(e.logger, logging.TagValueStoreOperationCreateWorkflowExecution, err,
+		logging.LogPersistantStoreErrorEvent(logger, logging.TagValueStoreOperationCreateWorkflowExecution, err,
 			fmt.Sprintf("{WorkflowID: %v, RunID: %v}", executionID, runID))
 		return nil, err
 	}
@@ -261,22 +382,44 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 	}, nil
 }
 
+// getDuplicateStartRunID returns the run of the workflow started by an earlier attempt of the start request, as long
+// as it is running
+func (e *historyEngineImpl) getDuplicateStartRunID(ctx context.Context, domainID, workflowID,
+	requestID string) (string, bool) {
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID,
+		workflow.WorkflowExecution{WorkflowId: common.StringPtr(workflowID)})
+	if err != nil {
+		return "", false
+	}
+	defer release()
+
+	msBuilder, err := context.loadWorkflowExecution(ctx)
+	if err != nil || !msBuilder.isWorkflowExecutionRunning() ||
+		msBuilder.executionInfo.CreateRequestID != requestID {
+		return "", false
+	}
+	return msBuilder.executionInfo.RunID, true
+}
+
 // GetWorkflowExecutionNextEventID retrieves the nextEventId of the workflow execution history
 func (e *historyEngineImpl) GetWorkflowExecutionNextEventID(
-	request *h.GetWorkflowExecutionNextEventIDRequest) (*h.GetWorkflowExecutionNextEventIDResponse, error) {
+	ctx thrift.Context, request *h.GetWorkflowExecutionNextEventIDRequest) (*h.GetWorkflowExecutionNextEventIDResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.GetWorkflowExecutionNextEventID")
+	defer span.Finish()
+
 	domainID := request.GetDomainUUID()
 	execution := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr(request.GetExecution().GetWorkflowId()),
 		RunId:      common.StringPtr(request.GetExecution().GetRunId()),
 	}
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(domainID, execution)
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err0 != nil {
 		return nil, err0
 	}
 	defer release()
 
-	msBuilder, err1 := context.loadWorkflowExecution()
+	msBuilder, err1 := context.loadWorkflowExecution(ctx)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -289,9 +432,12 @@ func (e *historyEngineImpl) GetWorkflowExecutionNextEventID(
 }
 
 func (e *historyEngineImpl) RecordDecisionTaskStarted(
-	request *h.RecordDecisionTaskStartedRequest) (*h.RecordDecisionTaskStartedResponse, error) {
+	ctx thrift.Context, request *h.RecordDecisionTaskStartedRequest) (*h.RecordDecisionTaskStartedResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RecordDecisionTaskStarted")
+	defer span.Finish()
+
 	domainID := request.GetDomainUUID()
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(domainID, *request.WorkflowExecution)
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, *request.WorkflowExecution)
 	if err0 != nil {
 		return nil, err0
 	}
@@ -300,9 +446,13 @@ func (e *historyEngineImpl) RecordDecisionTaskStarted(
 	scheduleID := request.GetScheduleId()
 	requestID := request.GetRequestId()
 
+	if request.IsSetQueryId() {
+		return e.recordQueryTaskStarted(ctx, domainID, context, request.GetQueryId())
+	}
+
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err0 := context.loadWorkflowExecution()
+		msBuilder, err0 := context.loadWorkflowExecution(ctx)
 		if err0 != nil {
 			return nil, err0
 		}
@@ -315,6 +465,10 @@ Update_History_Loop:
 			continue Update_History_Loop
 		}
 
+		if err := e.validateTaskStart(msBuilder); err != nil {
+			return nil, err
+		}
+
 		// Check execution state to make sure task is in the list of outstanding tasks and it is not yet started.  If
 		// task is not outstanding than it is most probably a duplicate and complete the task.
 		di, isRunning := msBuilder.GetPendingDecision(scheduleID)
@@ -342,6 +496,7 @@ Update_History_Loop:
 			return nil, &workflow.EntityNotExistsError{Message: "Decision task already started."}
 		}
 
+		scheduledTime := msBuilder.executionInfo.DecisionScheduledTime
 		event := msBuilder.AddDecisionTaskStartedEvent(scheduleID, requestID, request.PollRequest)
 		if event == nil {
 			// Unable to add DecisionTaskStarted event to history
@@ -349,7 +504,10 @@ Update_History_Loop:
 		}
 
 		// Start a timer for the decision task.
-		timeOutTask := context.tBuilder.AddDecisionTimoutTask(scheduleID, di.DecisionTimeout)
+		timeOutTask, err := context.tBuilder.AddDecisionTimoutTask(scheduleID, di.DecisionTimeout)
+		if err != nil {
+			return nil, err
+		}
 		timerTasks := []persistence.Task{timeOutTask}
 		defer e.timerProcessor.NotifyNewTimer(timeOutTask.GetTaskID())
 
@@ -361,23 +519,54 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 		// the history and try the operation again.
-		if err3 := context.updateWorkflowExecution(nil, timerTasks, transactionID); err3 != nil {
+		if err3 := context.updateWorkflowExecution(ctx, nil, timerTasks, transactionID); err3 != nil {
 			if err3 == ErrConflict {
 				continue Update_History_Loop
 			}
 			return nil, err3
 		}
 
+		e.taskMetrics.recordLatency(metrics.HistoryRecordDecisionTaskStartedScope, metrics.TaskScheduleToStartLatency,
+			domainID, decisionTaskTypeName, scheduledTime)
 		return e.createRecordDecisionTaskStartedResponse(domainID, msBuilder, event.GetEventId()), nil
 	}
 
 	return nil, ErrMaxAttemptsExceeded
 }
 
+// recordQueryTaskStarted hands the query to the worker with the history of the execution up to its last event,
+// without recording anything.  Query tasks of queries already answered or delivered with a decision task are dropped.
+func (e *historyEngineImpl) recordQueryTaskStarted(ctx thrift.Context, domainID string,
+	context *workflowExecutionContext, queryID string) (*h.RecordDecisionTaskStartedResponse, error) {
+	msBuilder, err := context.loadWorkflowExecution(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.validateTaskStart(msBuilder); err != nil {
+		return nil, err
+	}
+	query := e.queryRegistry.deliverQuery(msBuilder.executionInfo.RunID, queryID)
+	if query == nil {
+		return nil, &workflow.EntityNotExistsError{Message: "Query not found."}
+	}
+
+	response := h.NewRecordDecisionTaskStartedResponse()
+	response.WorkflowType = msBuilder.getWorkflowType()
+	if msBuilder.previousDecisionStartedEvent() != emptyEventID {
+		response.PreviousStartedEventId = common.Int64Ptr(msBuilder.previousDecisionStartedEvent())
+	}
+	response.StartedEventId = common.Int64Ptr(msBuilder.GetNextEventID() - 1)
+	response.Queries = map[string]*workflow.WorkflowQuery{queryID: query}
+	return response, nil
+}
+
 func (e *historyEngineImpl) RecordActivityTaskStarted(
-	request *h.RecordActivityTaskStartedRequest) (*h.RecordActivityTaskStartedResponse, error) {
+	ctx thrift.Context, request *h.RecordActivityTaskStartedRequest) (*h.RecordActivityTaskStartedResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RecordActivityTaskStarted")
+	defer span.Finish()
+
 	domainID := request.GetDomainUUID()
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(domainID, *request.WorkflowExecution)
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, *request.WorkflowExecution)
 	if err0 != nil {
 		return nil, err0
 	}
@@ -388,7 +577,7 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(
 
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err0 := context.loadWorkflowExecution()
+		msBuilder, err0 := context.loadWorkflowExecution(ctx)
 		if err0 != nil {
 			return nil, err0
 		}
@@ -401,6 +590,10 @@ Update_History_Loop:
 			continue Update_History_Loop
 		}
 
+		if err := e.validateTaskStart(msBuilder); err != nil {
+			return nil, err
+		}
+
 		// Check execution state to make sure task is in the list of outstanding tasks and it is not yet started.  If
 		// task is not outstanding than it is most probably a duplicate and complete the task.
 		ai, isRunning := msBuilder.GetActivityInfo(scheduleID)
@@ -444,6 +637,7 @@ Update_History_Loop:
 			// Unable to add ActivityTaskStarted event to history
 			return nil, &workflow.InternalServiceError{Message: "Unable to add ActivityTaskStarted event to history."}
 		}
+		e.applyExternalCompletionTimeout(ai)
 
 		// Start a timer for the activity task.
 		timerTasks := []persistence.Task{}
@@ -471,7 +665,7 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 		// the history and try the operationi again.
-		if err3 := context.updateWorkflowExecution(nil, timerTasks, transactionID); err3 != nil {
+		if err3 := context.updateWorkflowExecution(ctx, nil, timerTasks, transactionID); err3 != nil {
 			if err3 == ErrConflict {
 				continue Update_History_Loop
 			}
@@ -479,6 +673,8 @@ Update_History_Loop:
 			return nil, err3
 		}
 
+		e.taskMetrics.recordLatency(metrics.HistoryRecordActivityTaskStartedScope, metrics.TaskScheduleToStartLatency,
+			domainID, activityTaskTypeName, time.Unix(0, scheduledEvent.GetTimestamp()))
 		response := h.NewRecordActivityTaskStartedResponse()
 		response.ScheduledEvent = scheduledEvent
 		response.StartedEvent = startedEvent
@@ -489,12 +685,26 @@ Update_History_Loop:
 }
 
 // RespondDecisionTaskCompleted completes a decision task
-func (e *historyEngineImpl) RespondDecisionTaskCompleted(req *h.RespondDecisionTaskCompletedRequest) error {
+func (e *historyEngineImpl) RespondDecisionTaskCompleted(ctx thrift.Context, req *h.RespondDecisionTaskCompletedRequest) (
+	*workflow.RespondDecisionTaskCompletedResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RespondDecisionTaskCompleted")
+	defer span.Finish()
+	logger := tracing.WithRequestID(e.logger, ctx)
+
 	domainID := req.GetDomainUUID()
 	request := req.GetCompleteRequest()
 	token, err0 := e.tokenSerializer.Deserialize(request.GetTaskToken())
 	if err0 != nil {
-		return &workflow.BadRequestError{Message: "Error deserializing task token."}
+		return nil, &workflow.BadRequestError{Message: "Error deserializing task token."}
+	}
+
+	// Query tasks only answer their query, the history is left as it is
+	if token.QueryID != "" {
+		if len(request.GetDecisions()) > 0 {
+			return nil, &workflow.BadRequestError{Message: "Decisions can't be completed with a query task."}
+		}
+		e.queryRegistry.answer(token.RunID, token.QueryID, request.GetQueryResults()[token.QueryID])
+		return &workflow.RespondDecisionTaskCompletedResponse{}, nil
 	}
 
 	workflowExecution := workflow.WorkflowExecution{
@@ -502,17 +712,17 @@ func (e *historyEngineImpl) RespondDecisionTaskCompleted(req *h.RespondDecisionT
 		RunId:      common.StringPtr(token.RunID),
 	}
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(domainID, workflowExecution)
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, workflowExecution)
 	if err0 != nil {
-		return err0
+		return nil, err0
 	}
 	defer release()
 
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
-			return err1
+			return nil, err1
 		}
 
 		scheduleID := token.ScheduleID
@@ -526,65 +736,140 @@ Update_History_Loop:
 
 		di, isRunning := msBuilder.GetPendingDecision(scheduleID)
 		if !msBuilder.isWorkflowExecutionRunning() || !isRunning || di.StartedID == emptyEventID {
-			return &workflow.EntityNotExistsError{Message: "Decision task not found."}
+			return nil, &workflow.EntityNotExistsError{Message: "Decision task not found."}
 		}
 
 		startedID := di.StartedID
+		startedTime := msBuilder.executionInfo.DecisionStartedTime
 		completedEvent := msBuilder.AddDecisionTaskCompletedEvent(scheduleID, startedID, request)
 		if completedEvent == nil {
-			return &workflow.InternalServiceError{Message: "Unable to add DecisionTaskCompleted event to history."}
+			return nil, &workflow.InternalServiceError{Message: "Unable to add DecisionTaskCompleted event to history."}
 		}
 
 		failDecision := false
 		var failCause workflow.DecisionTaskFailedCause
 		var err error
 		completedID := completedEvent.GetEventId()
-		hasUnhandledEvents := ((completedID - startedID) > 1)
+		hasUnhandledEvents := ((completedID - startedID) > 1) || msBuilder.HasBufferedEvents()
 		isComplete := false
 		transferTasks := []persistence.Task{}
 		timerTasks := []persistence.Task{}
 		var continueAsNewBuilder *mutableStateBuilder
+		var localActivityTasks []*workflow.PollForActivityTaskResponse
+		// activities dispatched through matching once ActivityTasksPerUpdate of them got their tasks with this update
+		// get theirs from a continuation task, starting with the one scheduled at continuationScheduleID
+		activityTaskCount := 0
+		continuationScheduleID := emptyEventID
+
+		decisions := request.Decisions
+		if request.IsSetBinaryChecksum() {
+			isBadBinary, err1 := e.isBadBinary(domainID, request.GetBinaryChecksum())
+			if err1 != nil {
+				return nil, err1
+			}
+			if isBadBinary {
+				// Decisions produced by a binary flagged as bad on the domain are never applied
+				failDecision = true
+				failCause = workflow.DecisionTaskFailedCause_BAD_BINARY
+				decisions = nil
+			}
+		}
+		if limit := e.config.MaxDecisionsPerCompletion; limit > 0 && len(decisions) > limit {
+			failDecision = true
+			failCause = workflow.DecisionTaskFailedCause_TOO_MANY_DECISIONS
+			err = &workflow.BadRequestError{
+				Message: fmt.Sprintf("Decision task completed with %v decisions, at most %v are allowed.",
+					len(decisions), limit)}
+			decisions = nil
+		}
+
 	Process_Decision_Loop:
-		for _, d := range request.Decisions {
+		for _, d := range decisions {
 			switch d.GetDecisionType() {
 			case workflow.DecisionType_ScheduleActivityTask:
 				targetDomainID := domainID
 				attributes := d.GetScheduleActivityTaskDecisionAttributes()
+				if err1 := e.applyActivityTimeoutPolicy(domainID, attributes); err1 != nil {
+					return nil, err1
+				}
+				if err = validateActivityScheduleAttributes(attributes); err != nil {
+					failDecision = true
+					failCause = workflow.DecisionTaskFailedCause_BAD_SCHEDULE_ACTIVITY_ATTRIBUTES
+					break Process_Decision_Loop
+				}
+				// Activity IDs identify the activity to the worker, an activity can't be scheduled while another with
+				// the same ID is pending
+				if _, isRunning := msBuilder.GetActivityByActivityID(attributes.GetActivityId()); isRunning {
+					err = &workflow.BadRequestError{
+						Message: fmt.Sprintf("Activity with ID %v is already pending.", attributes.GetActivityId())}
+					failDecision = true
+					failCause = workflow.DecisionTaskFailedCause_SCHEDULE_ACTIVITY_DUPLICATE_ID
+					break Process_Decision_Loop
+				}
+
 				// First check if we need to use a different target domain to schedule activity
 				if attributes.IsSetDomain() {
 					// TODO: Error handling for ActivitySchedule failed when domain lookup fails
 					info, _, err := e.domainCache.GetDomain(attributes.GetDomain())
 					if err != nil {
-						return &workflow.InternalServiceError{Message: "Unable to schedule activity across domain."}
+						return nil, &workflow.InternalServiceError{Message: "Unable to schedule activity across domain."}
 					}
 					targetDomainID = info.ID
 				}
 
-				if err = validateActivityScheduleAttributes(attributes); err != nil {
-					failDecision = true
-					failCause = workflow.DecisionTaskFailedCause_BAD_SCHEDULE_ACTIVITY_ATTRIBUTES
-					break Process_Decision_Loop
+				scheduleEvent, ai := msBuilder.AddActivityTaskScheduledEvent(completedID, attributes,
+					d.PayloadReference)
+				if scheduleEvent == nil {
+					return nil, &workflow.InternalServiceError{Message: "Unable to add ActivityTaskScheduled event to history."}
 				}
 
-				scheduleEvent, ai := msBuilder.AddActivityTaskScheduledEvent(completedID, attributes)
-				transferTasks = append(transferTasks, &persistence.ActivityTask{
-					DomainID:   targetDomainID,
-					TaskList:   attributes.GetTaskList().GetName(),
-					ScheduleID: scheduleEvent.GetEventId(),
-				})
+				isLocalDispatch := attributes.GetRequestLocalDispatch() && targetDomainID == domainID
+				if limit := e.config.ActivityTasksPerUpdate; !isLocalDispatch && limit > 0 && activityTaskCount >= limit {
+					if continuationScheduleID == emptyEventID {
+						continuationScheduleID = scheduleEvent.GetEventId()
+					}
+					// The activity info is written along with the scheduled event
+					ai.DispatchDeferred = true
+					continue Process_Decision_Loop
+				}
 
 				// Create activity timeouts.
-				Schedule2StartTimeoutTask := context.tBuilder.AddScheduleToStartActivityTimeout(ai)
-				timerTasks = append(timerTasks, Schedule2StartTimeoutTask)
-				defer e.timerProcessor.NotifyNewTimer(Schedule2StartTimeoutTask.GetTaskID())
-
 				Schedule2CloseTimeoutTask, err := context.tBuilder.AddScheduleToCloseActivityTimeout(ai)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				timerTasks = append(timerTasks, Schedule2CloseTimeoutTask)
 				defer e.timerProcessor.NotifyNewTimer(Schedule2CloseTimeoutTask.GetTaskID())
 
+				// The worker completing the decision asked to run the activity itself, start it right away instead
+				// of dispatching it through matching. Activities in other domains always go through matching.
+				if isLocalDispatch {
+					activityTask, startTimerTasks, err := e.startLocalActivityTask(context, msBuilder, domainID,
+						workflowExecution, scheduleEvent, ai, request.GetIdentity())
+					if err != nil {
+						return nil, err
+					}
+					for _, startTimerTask := range startTimerTasks {
+						timerTasks = append(timerTasks, startTimerTask)
+						defer e.timerProcessor.NotifyNewTimer(startTimerTask.GetTaskID())
+					}
+					localActivityTasks = append(localActivityTasks, activityTask)
+					continue Process_Decision_Loop
+				}
+
+				transferTasks = append(transferTasks, &persistence.ActivityTask{
+					DomainID:   targetDomainID,
+					TaskList:   attributes.GetTaskList().GetName(),
+					ScheduleID: scheduleEvent.GetEventId(),
+				})
+				Schedule2StartTimeoutTask, err := context.tBuilder.AddScheduleToStartActivityTimeout(ai)
+				if err != nil {
+					return nil, err
+				}
+				timerTasks = append(timerTasks, Schedule2StartTimeoutTask)
+				defer e.timerProcessor.NotifyNewTimer(Schedule2StartTimeoutTask.GetTaskID())
+				activityTaskCount++
+
 			case workflow.DecisionType_CompleteWorkflowExecution:
 				if hasUnhandledEvents {
 					failDecision = true
@@ -596,7 +881,7 @@ Update_History_Loop:
 				if isComplete {
 					e.metricsClient.AddCounter(metrics.HistoryMultipleCompletionDecisionsScope,
 						metrics.MultipleCompletionDecisionsCounter, 1)
-					logging.LogMultipleCompletionDecisionsEvent(e.logger, d.GetDecisionType())
+					logging.LogMultipleCompletionDecisionsEvent(logger, d.GetDecisionType())
 					continue Process_Decision_Loop
 				}
 				attributes := d.GetCompleteWorkflowExecutionDecisionAttributes()
@@ -605,7 +890,7 @@ Update_History_Loop:
 					failCause = workflow.DecisionTaskFailedCause_BAD_COMPLETE_WORKFLOW_EXECUTION_ATTRIBUTES
 					break Process_Decision_Loop
 				}
-				msBuilder.AddCompletedWorkflowEvent(completedID, attributes)
+				msBuilder.AddCompletedWorkflowEvent(completedID, attributes, d.PayloadReference)
 				isComplete = true
 			case workflow.DecisionType_FailWorkflowExecution:
 				if hasUnhandledEvents {
@@ -618,7 +903,7 @@ Update_History_Loop:
 				if isComplete {
 					e.metricsClient.AddCounter(metrics.HistoryMultipleCompletionDecisionsScope,
 						metrics.MultipleCompletionDecisionsCounter, 1)
-					logging.LogMultipleCompletionDecisionsEvent(e.logger, d.GetDecisionType())
+					logging.LogMultipleCompletionDecisionsEvent(logger, d.GetDecisionType())
 					continue Process_Decision_Loop
 				}
 				attributes := d.GetFailWorkflowExecutionDecisionAttributes()
@@ -627,7 +912,7 @@ Update_History_Loop:
 					failCause = workflow.DecisionTaskFailedCause_BAD_FAIL_WORKFLOW_EXECUTION_ATTRIBUTES
 					break Process_Decision_Loop
 				}
-				msBuilder.AddFailWorkflowEvent(completedID, attributes)
+				msBuilder.AddFailWorkflowEvent(completedID, attributes, d.PayloadReference)
 				isComplete = true
 			case workflow.DecisionType_CancelWorkflowExecution:
 				// If new events came while we are processing the decision, we would fail this and give a chance to client
@@ -642,7 +927,7 @@ Update_History_Loop:
 				if isComplete {
 					e.metricsClient.AddCounter(metrics.HistoryMultipleCompletionDecisionsScope,
 						metrics.MultipleCompletionDecisionsCounter, 1)
-					logging.LogMultipleCompletionDecisionsEvent(e.logger, d.GetDecisionType())
+					logging.LogMultipleCompletionDecisionsEvent(logger, d.GetDecisionType())
 					continue Process_Decision_Loop
 				}
 				attributes := d.GetCancelWorkflowExecutionDecisionAttributes()
@@ -661,8 +946,26 @@ Update_History_Loop:
 					failCause = workflow.DecisionTaskFailedCause_BAD_START_TIMER_ATTRIBUTES
 					break Process_Decision_Loop
 				}
+				if isRunning, _ := msBuilder.GetUserTimer(attributes.GetTimerId()); isRunning {
+					err = &workflow.BadRequestError{
+						Message: fmt.Sprintf("Timer with ID %v is already started.", attributes.GetTimerId())}
+					failDecision = true
+					failCause = workflow.DecisionTaskFailedCause_START_TIMER_DUPLICATE_ID
+					break Process_Decision_Loop
+				}
+				if limit := e.config.MaxPendingTimersPerExecution; limit > 0 && len(msBuilder.pendingTimerInfoIDs) >= limit {
+					err = &workflow.BadRequestError{
+						Message: fmt.Sprintf("Execution has %v pending timers, at most %v are allowed.",
+							len(msBuilder.pendingTimerInfoIDs), limit)}
+					failDecision = true
+					failCause = workflow.DecisionTaskFailedCause_TOO_MANY_PENDING_TIMERS
+					break Process_Decision_Loop
+				}
 				_, ti := msBuilder.AddTimerStartedEvent(completedID, attributes)
-				nextTimerTask := context.tBuilder.AddUserTimer(ti, msBuilder)
+				nextTimerTask, err := context.tBuilder.AddUserTimer(ti, msBuilder)
+				if err != nil {
+					return nil, err
+				}
 				if nextTimerTask != nil {
 					timerTasks = append(timerTasks, nextTimerTask)
 					defer e.timerProcessor.NotifyNewTimer(nextTimerTask.GetTaskID())
@@ -707,7 +1010,7 @@ Update_History_Loop:
 					failCause = workflow.DecisionTaskFailedCause_BAD_RECORD_MARKER_ATTRIBUTES
 					break Process_Decision_Loop
 				}
-				msBuilder.AddRecordMarkerEvent(completedID, attributes)
+				msBuilder.AddRecordMarkerEvent(completedID, attributes, d.PayloadReference)
 
 			case workflow.DecisionType_RequestCancelExternalWorkflowExecution:
 
@@ -720,15 +1023,16 @@ Update_History_Loop:
 
 				foreignInfo, _, err := e.domainCache.GetDomain(attributes.GetDomain())
 				if err != nil {
-					return &workflow.InternalServiceError{
+					return nil, &workflow.InternalServiceError{
 						Message: fmt.Sprintf("Unable to schedule activity across domain: %v.",
 							attributes.GetDomain())}
 				}
 
-				wfCancelReqEvent := msBuilder.AddRequestCancelExternalWorkflowExecutionInitiatedEvent(
-					completedID, attributes)
+				cancelRequestID := uuid.New()
+				wfCancelReqEvent, _ := msBuilder.AddRequestCancelExternalWorkflowExecutionInitiatedEvent(
+					completedID, cancelRequestID, attributes)
 				if wfCancelReqEvent == nil {
-					return &workflow.InternalServiceError{Message: "Unable to add external cancel workflow request."}
+					return nil, &workflow.InternalServiceError{Message: "Unable to add external cancel workflow request."}
 				}
 
 				transferTasks = append(transferTasks, &persistence.CancelExecutionTask{
@@ -749,7 +1053,7 @@ Update_History_Loop:
 				if isComplete {
 					e.metricsClient.AddCounter(metrics.HistoryMultipleCompletionDecisionsScope,
 						metrics.MultipleCompletionDecisionsCounter, 1)
-					logging.LogMultipleCompletionDecisionsEvent(e.logger, d.GetDecisionType())
+					logging.LogMultipleCompletionDecisionsEvent(logger, d.GetDecisionType())
 					continue Process_Decision_Loop
 				}
 				attributes := d.GetContinueAsNewWorkflowExecutionDecisionAttributes()
@@ -759,9 +1063,14 @@ Update_History_Loop:
 					break Process_Decision_Loop
 				}
 				runID := uuid.New()
-				_, newStateBuilder, err := msBuilder.AddContinueAsNewEvent(completedID, domainID, runID, attributes)
+				newRunPayloadReference, err := e.payloadBlobs.copy(ctx, d.PayloadReference, domainID, runID)
+				if err != nil {
+					return nil, err
+				}
+				_, newStateBuilder, err := msBuilder.AddContinueAsNewEvent(completedID, domainID, runID, attributes,
+					d.PayloadReference, newRunPayloadReference)
 				if err != nil {
-					return nil
+					return nil, nil
 				}
 				isComplete = true
 				continueAsNewBuilder = newStateBuilder
@@ -769,18 +1078,27 @@ Update_History_Loop:
 			case workflow.DecisionType_StartChildWorkflowExecution:
 				targetDomainID := domainID
 				attributes := d.GetStartChildWorkflowExecutionDecisionAttributes()
+				if err = validateStartChildExecutionAttributes(attributes); err != nil {
+					failDecision = true
+					failCause = workflow.DecisionTaskFailedCause_BAD_START_CHILD_EXECUTION_ATTRIBUTES
+					break Process_Decision_Loop
+				}
 				// First check if we need to use a different target domain to schedule child execution
 				if attributes.IsSetDomain() {
 					// TODO: Error handling for DecisionType_StartChildWorkflowExecution failed when domain lookup fails
 					info, _, err := e.domainCache.GetDomain(attributes.GetDomain())
 					if err != nil {
-						return &workflow.InternalServiceError{Message: "Unable to schedule child execution across domain."}
+						return nil, &workflow.InternalServiceError{Message: "Unable to schedule child execution across domain."}
 					}
 					targetDomainID = info.ID
 				}
 
 				requestID := uuid.New()
-				initiatedEvent, _ := msBuilder.AddStartChildWorkflowExecutionInitiatedEvent(completedID, requestID, attributes)
+				initiatedEvent, _ := msBuilder.AddStartChildWorkflowExecutionInitiatedEvent(completedID, requestID, attributes,
+					d.PayloadReference)
+				if initiatedEvent == nil {
+					return nil, &workflow.InternalServiceError{Message: "Unable to add StartChildWorkflowExecutionInitiated event to history."}
+				}
 				transferTasks = append(transferTasks, &persistence.StartChildExecutionTask{
 					TargetDomainID:   targetDomainID,
 					TargetWorkflowID: attributes.GetWorkflowId(),
@@ -788,31 +1106,57 @@ Update_History_Loop:
 				})
 
 			default:
-				return &workflow.BadRequestError{Message: fmt.Sprintf("Unknown decision type: %v", d.GetDecisionType())}
+				return nil, &workflow.BadRequestError{Message: fmt.Sprintf("Unknown decision type: %v", d.GetDecisionType())}
 			}
 		}
 
 		if failDecision {
-			e.logger.Info("failing the decision")
+			logger.Info("failing the decision")
 			e.metricsClient.AddCounter(metrics.RespondDecisionTaskCompletedScope, metrics.FailedDecisionsCounter, 1)
 			var err1 error
-			msBuilder, err1 = e.failDecision(context, scheduleID, startedID, failCause, request)
+			msBuilder, err1 = e.failDecision(ctx, context, scheduleID, startedID, failCause, request)
 			if err1 != nil {
-				return err1
+				return nil, err1
 			}
 			isComplete = false
 			hasUnhandledEvents = true
 			continueAsNewBuilder = nil
+			localActivityTasks = nil
+			continuationScheduleID = emptyEventID
+		}
+
+		if continuationScheduleID != emptyEventID {
+			transferTasks = append(transferTasks, &persistence.ActivityTaskContinuationTask{
+				ScheduleID: continuationScheduleID,
+			})
+		}
+
+		// Events which came in during this decision are flushed to the history after the events of its decisions
+		if err1 := msBuilder.FlushBufferedEvents(); err1 != nil {
+			return nil, err1
 		}
 
 		// Schedule another decision task if new events came in during this decision
 		if hasUnhandledEvents {
-			newDecisionEvent, _ := msBuilder.AddDecisionTaskScheduledEvent()
-			transferTasks = append(transferTasks, &persistence.DecisionTask{
-				DomainID:   domainID,
-				TaskList:   newDecisionEvent.GetDecisionTaskScheduledEventAttributes().GetTaskList().GetName(),
-				ScheduleID: newDecisionEvent.GetEventId(),
-			})
+			isStuck, err2 := e.terminateIfStuck(msBuilder, metrics.RespondDecisionTaskCompletedScope)
+			if err2 != nil {
+				return nil, err2
+			}
+			if isStuck {
+				isComplete = true
+			} else {
+				newDecisionEvent, di := msBuilder.AddDecisionTaskScheduledEvent()
+				decisionTransferTasks, decisionTimerTasks, err := createDecisionDispatchTasks(e.config,
+					context.tBuilder, domainID, newDecisionEvent, di)
+				if err != nil {
+					return nil, err
+				}
+				transferTasks = append(transferTasks, decisionTransferTasks...)
+				for _, backoffTask := range decisionTimerTasks {
+					timerTasks = append(timerTasks, backoffTask)
+					defer e.timerProcessor.NotifyNewTimer(backoffTask.GetTaskID())
+				}
+			}
 		}
 
 		if isComplete {
@@ -823,17 +1167,17 @@ Update_History_Loop:
 		// Generate a transaction ID for appending events to history
 		transactionID, err3 := e.shard.GetNextTransferTaskID()
 		if err3 != nil {
-			return err3
+			return nil, err3
 		}
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict then reload
 		// the history and try the operation again.
 		var updateErr error
 		if continueAsNewBuilder != nil {
-			updateErr = context.continueAsNewWorkflowExecution(request.GetExecutionContext(), continueAsNewBuilder,
+			updateErr = context.continueAsNewWorkflowExecution(ctx, request.GetExecutionContext(), continueAsNewBuilder,
 				transferTasks, transactionID)
 		} else {
-			updateErr = context.updateWorkflowExecutionWithContext(request.GetExecutionContext(), transferTasks, timerTasks,
+			updateErr = context.updateWorkflowExecutionWithContext(ctx, request.GetExecutionContext(), transferTasks, timerTasks,
 				transactionID)
 		}
 
@@ -842,17 +1186,84 @@ Update_History_Loop:
 				continue Update_History_Loop
 			}
 
-			return updateErr
+			return nil, updateErr
 		}
 
-		return err
+		e.queryRegistry.complete(token.RunID, request.GetQueryResults(), isComplete)
+		e.taskMetrics.recordLatency(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.TaskStartToCompleteLatency,
+			domainID, decisionTaskTypeName, startedTime)
+
+		if err != nil {
+			return nil, err
+		}
+		return &workflow.RespondDecisionTaskCompletedResponse{ActivityTasks: localActivityTasks}, nil
 	}
 
-	return ErrMaxAttemptsExceeded
+	return nil, ErrMaxAttemptsExceeded
+}
+
+// startLocalActivityTask starts an activity scheduled by a decision on the worker which completed the decision, and
+// returns the activity task to hand back to that worker along with the timers of the started activity.
+func (e *historyEngineImpl) startLocalActivityTask(context *workflowExecutionContext, msBuilder *mutableStateBuilder,
+	domainID string, execution workflow.WorkflowExecution, scheduledEvent *workflow.HistoryEvent, ai *persistence.ActivityInfo,
+	identity string) (*workflow.PollForActivityTaskResponse, []persistence.Task, error) {
+	attributes := scheduledEvent.GetActivityTaskScheduledEventAttributes()
+	scheduleID := scheduledEvent.GetEventId()
+	startedEvent := msBuilder.AddActivityTaskStartedEvent(ai, scheduleID, uuid.New(),
+		&workflow.PollForActivityTaskRequest{
+			TaskList: attributes.GetTaskList(),
+			Identity: common.StringPtr(identity),
+		})
+	if startedEvent == nil {
+		return nil, nil, &workflow.InternalServiceError{Message: "Unable to add ActivityTaskStarted event to history."}
+	}
+
+	timerTasks := []persistence.Task{}
+	start2CloseTimeoutTask, err := context.tBuilder.AddStartToCloseActivityTimeout(ai)
+	if err != nil {
+		return nil, nil, err
+	}
+	timerTasks = append(timerTasks, start2CloseTimeoutTask)
+	start2HeartBeatTimeoutTask, err := context.tBuilder.AddHeartBeatActivityTimeout(ai)
+	if err != nil {
+		return nil, nil, err
+	}
+	if start2HeartBeatTimeoutTask != nil {
+		timerTasks = append(timerTasks, start2HeartBeatTimeoutTask)
+	}
+
+	token, err := e.tokenSerializer.Serialize(&common.TaskToken{
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+		ScheduleID: scheduleID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := workflow.NewPollForActivityTaskResponse()
+	response.TaskToken = token
+	response.WorkflowExecution = &execution
+	response.ActivityId = attributes.ActivityId
+	response.ActivityType = attributes.GetActivityType()
+	response.Input = attributes.GetInput()
+	response.StartedEventId = common.Int64Ptr(startedEvent.GetEventId())
+	response.ScheduledTimestamp = common.Int64Ptr(scheduledEvent.GetTimestamp())
+	response.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(attributes.GetScheduleToCloseTimeoutSeconds())
+	response.StartedTimestamp = common.Int64Ptr(startedEvent.GetTimestamp())
+	response.StartToCloseTimeoutSeconds = common.Int32Ptr(attributes.GetStartToCloseTimeoutSeconds())
+	response.HeartbeatTimeoutSeconds = common.Int32Ptr(attributes.GetHeartbeatTimeoutSeconds())
+	response.Header = attributes.Header
+	response.PayloadReference = scheduledEvent.PayloadReference
+	return response, timerTasks, nil
 }
 
 // RespondActivityTaskCompleted completes an activity task.
-func (e *historyEngineImpl) RespondActivityTaskCompleted(req *h.RespondActivityTaskCompletedRequest) error {
+func (e *historyEngineImpl) RespondActivityTaskCompleted(ctx thrift.Context, req *h.RespondActivityTaskCompletedRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RespondActivityTaskCompleted")
+	defer span.Finish()
+
 	domainID := req.GetDomainUUID()
 	request := req.GetCompleteRequest()
 	token, err0 := e.tokenSerializer.Deserialize(request.GetTaskToken())
@@ -865,7 +1276,17 @@ func (e *historyEngineImpl) RespondActivityTaskCompleted(req *h.RespondActivityT
 		RunId:      common.StringPtr(token.RunID),
 	}
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(domainID, workflowExecution)
+	// Tokens made up from the activity ID are only checked once the activity isn't found, the ID may have been reused
+	// by an activity scheduled since
+	if token.ScheduleID != common.EmptyEventID && e.checkActivityCompleted(domainID, token, request.Result_) {
+		return nil
+	}
+
+	if err := e.checkCompleted(metrics.HistoryRespondActivityTaskCompletedScope, domainID, workflowExecution); err != nil {
+		return err
+	}
+
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, workflowExecution)
 	if err0 != nil {
 		return err0
 	}
@@ -873,12 +1294,18 @@ func (e *historyEngineImpl) RespondActivityTaskCompleted(req *h.RespondActivityT
 
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
 			return err1
 		}
 
-		scheduleID := token.ScheduleID
+		scheduleID, err2 := getActivityScheduleID(token, msBuilder)
+		if err2 != nil {
+			if e.checkActivityCompleted(domainID, token, request.Result_) {
+				return nil
+			}
+			return err2
+		}
 
 		// First check to see if cache needs to be refreshed as we could potentially have stale workflow execution in
 		// some extreme cassandra failure cases.
@@ -894,6 +1321,7 @@ Update_History_Loop:
 		}
 
 		startedID := ai.StartedID
+		startedTime := ai.StartedTime
 		if msBuilder.AddActivityTaskCompletedEvent(scheduleID, startedID, request) == nil {
 			// Unable to add ActivityTaskCompleted event to history
 			return &workflow.InternalServiceError{Message: "Unable to add ActivityTaskCompleted event to history."}
@@ -917,7 +1345,7 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 		// the history and try the operation again.
-		if err := context.updateWorkflowExecution(transferTasks, nil, transactionID); err != nil {
+		if err := context.updateWorkflowExecution(ctx, transferTasks, nil, transactionID); err != nil {
 			if err == ErrConflict {
 				continue Update_History_Loop
 			}
@@ -925,6 +1353,9 @@ Update_History_Loop:
 			return err
 		}
 
+		e.completedActivities.put(domainID, workflowExecution, scheduleID, ai.ActivityID, request.Result_)
+		e.taskMetrics.recordLatency(metrics.HistoryRespondActivityTaskCompletedScope, metrics.TaskStartToCompleteLatency, domainID,
+			activityTaskTypeName, startedTime)
 		return nil
 	}
 
@@ -932,7 +1363,10 @@ Update_History_Loop:
 }
 
 // RespondActivityTaskFailed completes an activity task failure.
-func (e *historyEngineImpl) RespondActivityTaskFailed(req *h.RespondActivityTaskFailedRequest) error {
+func (e *historyEngineImpl) RespondActivityTaskFailed(ctx thrift.Context, req *h.RespondActivityTaskFailedRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RespondActivityTaskFailed")
+	defer span.Finish()
+
 	domainID := req.GetDomainUUID()
 	request := req.GetFailedRequest()
 	token, err0 := e.tokenSerializer.Deserialize(request.GetTaskToken())
@@ -945,7 +1379,11 @@ func (e *historyEngineImpl) RespondActivityTaskFailed(req *h.RespondActivityTask
 		RunId:      common.StringPtr(token.RunID),
 	}
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(domainID, workflowExecution)
+	if err := e.checkCompleted(metrics.HistoryRespondActivityTaskFailedScope, domainID, workflowExecution); err != nil {
+		return err
+	}
+
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, workflowExecution)
 	if err0 != nil {
 		return err0
 	}
@@ -953,12 +1391,15 @@ func (e *historyEngineImpl) RespondActivityTaskFailed(req *h.RespondActivityTask
 
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
 			return err1
 		}
 
-		scheduleID := token.ScheduleID
+		scheduleID, err2 := getActivityScheduleID(token, msBuilder)
+		if err2 != nil {
+			return err2
+		}
 
 		// First check to see if cache needs to be refreshed as we could potentially have stale workflow execution in
 		// some extreme cassandra failure cases.
@@ -974,6 +1415,7 @@ Update_History_Loop:
 		}
 
 		startedID := ai.StartedID
+		startedTime := ai.StartedTime
 		if msBuilder.AddActivityTaskFailedEvent(scheduleID, startedID, request) == nil {
 			// Unable to add ActivityTaskFailed event to history
 			return &workflow.InternalServiceError{Message: "Unable to add ActivityTaskFailed event to history."}
@@ -997,7 +1439,7 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 		// the history and try the operation again.
-		if err := context.updateWorkflowExecution(transferTasks, nil, transactionID); err != nil {
+		if err := context.updateWorkflowExecution(ctx, transferTasks, nil, transactionID); err != nil {
 			if err == ErrConflict {
 				continue Update_History_Loop
 			}
@@ -1005,6 +1447,8 @@ Update_History_Loop:
 			return err
 		}
 
+		e.taskMetrics.recordLatency(metrics.HistoryRespondActivityTaskFailedScope, metrics.TaskStartToCompleteLatency, domainID,
+			activityTaskTypeName, startedTime)
 		return nil
 	}
 
@@ -1012,7 +1456,10 @@ Update_History_Loop:
 }
 
 // RespondActivityTaskCanceled completes an activity task failure.
-func (e *historyEngineImpl) RespondActivityTaskCanceled(req *h.RespondActivityTaskCanceledRequest) error {
+func (e *historyEngineImpl) RespondActivityTaskCanceled(ctx thrift.Context, req *h.RespondActivityTaskCanceledRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RespondActivityTaskCanceled")
+	defer span.Finish()
+
 	domainID := req.GetDomainUUID()
 	request := req.GetCancelRequest()
 	token, err0 := e.tokenSerializer.Deserialize(request.GetTaskToken())
@@ -1025,7 +1472,11 @@ func (e *historyEngineImpl) RespondActivityTaskCanceled(req *h.RespondActivityTa
 		RunId:      common.StringPtr(token.RunID),
 	}
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(domainID, workflowExecution)
+	if err := e.checkCompleted(metrics.HistoryRespondActivityTaskCanceledScope, domainID, workflowExecution); err != nil {
+		return err
+	}
+
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, workflowExecution)
 	if err0 != nil {
 		return err0
 	}
@@ -1033,12 +1484,15 @@ func (e *historyEngineImpl) RespondActivityTaskCanceled(req *h.RespondActivityTa
 
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
 			return err1
 		}
 
-		scheduleID := token.ScheduleID
+		scheduleID, err2 := getActivityScheduleID(token, msBuilder)
+		if err2 != nil {
+			return err2
+		}
 		// First check to see if cache needs to be refreshed as we could potentially have stale workflow execution in
 		// some extreme cassandra failure cases.
 		if scheduleID >= msBuilder.GetNextEventID() {
@@ -1054,6 +1508,7 @@ Update_History_Loop:
 			return &workflow.EntityNotExistsError{Message: "Activity task not found."}
 		}
 
+		startedTime := ai.StartedTime
 		if msBuilder.AddActivityTaskCanceledEvent(scheduleID, ai.StartedID, ai.CancelRequestID, request.GetDetails(),
 			request.GetIdentity()) == nil {
 			// Unable to add ActivityTaskCanceled event to history
@@ -1078,7 +1533,7 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 		// the history and try the operation again.
-		if err := context.updateWorkflowExecution(transferTasks, nil, transactionID); err != nil {
+		if err := context.updateWorkflowExecution(ctx, transferTasks, nil, transactionID); err != nil {
 			if err == ErrConflict {
 				continue Update_History_Loop
 			}
@@ -1086,6 +1541,8 @@ Update_History_Loop:
 			return err
 		}
 
+		e.taskMetrics.recordLatency(metrics.HistoryRespondActivityTaskCanceledScope, metrics.TaskStartToCompleteLatency, domainID,
+			activityTaskTypeName, startedTime)
 		return nil
 	}
 
@@ -1097,7 +1554,11 @@ Update_History_Loop:
 // - For reporting liveness of the activity.
 // - For reporting progress of the activity, this can be done even if the liveness is not configured.
 func (e *historyEngineImpl) RecordActivityTaskHeartbeat(
-	req *h.RecordActivityTaskHeartbeatRequest) (*workflow.RecordActivityTaskHeartbeatResponse, error) {
+	ctx thrift.Context, req *h.RecordActivityTaskHeartbeatRequest) (*workflow.RecordActivityTaskHeartbeatResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RecordActivityTaskHeartbeat")
+	defer span.Finish()
+	logger := tracing.WithRequestID(e.logger, ctx)
+
 	domainID := req.GetDomainUUID()
 	request := req.GetHeartbeatRequest()
 	token, err0 := e.tokenSerializer.Deserialize(request.GetTaskToken())
@@ -1110,7 +1571,11 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(
 		RunId:      common.StringPtr(token.RunID),
 	}
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(domainID, workflowExecution)
+	if err := e.checkCompleted(metrics.HistoryRecordActivityTaskHeartbeatScope, domainID, workflowExecution); err != nil {
+		return nil, err
+	}
+
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, workflowExecution)
 	if err0 != nil {
 		return nil, err0
 	}
@@ -1118,7 +1583,7 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(
 
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
 			return nil, err1
 		}
@@ -1134,14 +1599,14 @@ Update_History_Loop:
 
 		ai, isRunning := msBuilder.GetActivityInfo(scheduleID)
 		if !msBuilder.isWorkflowExecutionRunning() || !isRunning || ai.StartedID == emptyEventID {
-			e.logger.Debugf("Activity HeartBeat: scheduleEventID: %v, ActivityInfo: %+v, Exist: %v",
+			logger.Debugf("Activity HeartBeat: scheduleEventID: %v, ActivityInfo: %+v, Exist: %v",
 				scheduleID, ai, isRunning)
 			return nil, &workflow.EntityNotExistsError{Message: "Activity task not found."}
 		}
 
 		cancelRequested := ai.CancelRequested
 
-		e.logger.Debugf("Activity HeartBeat: scheduleEventID: %v, ActivityInfo: %+v, CancelRequested: %v",
+		logger.Debugf("Activity HeartBeat: scheduleEventID: %v, ActivityInfo: %+v, CancelRequested: %v",
 			scheduleID, ai, cancelRequested)
 
 		// Save progress and last HB reported time.
@@ -1155,7 +1620,7 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 		// the history and try the operation again.
-		if err := context.updateWorkflowExecution(nil, nil, transactionID); err != nil {
+		if err := context.updateWorkflowExecution(ctx, nil, nil, transactionID); err != nil {
 			if err == ErrConflict {
 				continue Update_History_Loop
 			}
@@ -1175,7 +1640,10 @@ Update_History_Loop:
 //	(2) if there are multiple calls if one request goes through then can we respond to the other ones with
 //       cancellation in progress instead of success.
 func (e *historyEngineImpl) RequestCancelWorkflowExecution(
-	req *h.RequestCancelWorkflowExecutionRequest) error {
+	ctx thrift.Context, req *h.RequestCancelWorkflowExecutionRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RequestCancelWorkflowExecution")
+	defer span.Finish()
+
 	domainID := req.GetDomainUUID()
 	request := req.GetCancelRequest()
 
@@ -1183,8 +1651,12 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(
 		WorkflowId: common.StringPtr(request.GetWorkflowExecution().GetWorkflowId()),
 		RunId:      common.StringPtr(request.GetWorkflowExecution().GetRunId()),
 	}
+	if err := e.checkCompleted(metrics.HistoryRequestCancelWorkflowExecutionScope, domainID,
+		workflowExecution); err != nil {
+		return err
+	}
 
-	return e.updateWorkflowExecution(domainID, workflowExecution, false, true,
+	return e.updateWorkflowExecution(ctx, domainID, workflowExecution, false, true,
 		func(msBuilder *mutableStateBuilder) error {
 			if !msBuilder.isWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
@@ -1198,63 +1670,391 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(
 		})
 }
 
-func (e *historyEngineImpl) SignalWorkflowExecution(signalRequest *h.SignalWorkflowExecutionRequest) error {
+func (e *historyEngineImpl) SignalWorkflowExecution(ctx thrift.Context,
+	signalRequest *h.SignalWorkflowExecutionRequest) (*workflow.SignalWorkflowExecutionResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.SignalWorkflowExecution")
+	defer span.Finish()
+
 	domainID := signalRequest.GetDomainUUID()
 	request := signalRequest.GetSignalRequest()
 	execution := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr(request.GetWorkflowExecution().GetWorkflowId()),
 		RunId:      common.StringPtr(request.GetWorkflowExecution().GetRunId()),
 	}
+	if err := e.checkCompleted(metrics.HistorySignalWorkflowExecutionScope, domainID, execution); err != nil {
+		return nil, err
+	}
+
+	// Signals received while the execution is updated for an earlier one are batched, the first signal of the batch
+	// applies all of them once it gets the lock of the execution and the others wait for the outcome
+	key := signalBatchKey(domainID, execution)
+	batch, isLeader := e.signalBatcher.add(key, request)
+	if !isLeader {
+		return batch.wait(ctx, request)
+	}
 
-	return e.updateWorkflowExecution(domainID, execution, false, true,
+	var signals []*workflow.SignalWorkflowExecutionRequest
+	var receipts map[*workflow.SignalWorkflowExecutionRequest]*workflow.SignalWorkflowExecutionResponse
+	var duplicates int
+	err := e.updateWorkflowExecution(ctx, domainID, execution, false, true,
 		func(msBuilder *mutableStateBuilder) error {
 			if !msBuilder.isWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
 			}
 
-			if msBuilder.AddWorkflowExecutionSignaled(request) == nil {
-				return &workflow.InternalServiceError{Message: "Unable to signal workflow execution."}
+			if signals == nil {
+				signals = e.signalBatcher.seal(key, batch)
+			}
+			receipts = make(map[*workflow.SignalWorkflowExecutionRequest]*workflow.SignalWorkflowExecutionResponse)
+			duplicates = 0
+			for _, signal := range signals {
+				// A retried signal gets the receipt of the one already recorded instead of a second event
+				if eventID, ok := msBuilder.GetSignalReceipt(signal.GetRequestId()); ok {
+					receipts[signal] = newSignalReceipt(msBuilder, eventID)
+					duplicates++
+					continue
+				}
+
+				event := msBuilder.AddWorkflowExecutionSignaled(signal)
+				if event == nil {
+					return &workflow.InternalServiceError{Message: "Unable to signal workflow execution."}
+				}
+				receipts[signal] = newSignalReceipt(msBuilder, event.GetEventId())
 			}
 
+			if duplicates == len(signals) {
+				return errSignalsAlreadyRecorded
+			}
 			return nil
 		})
+	if err == errSignalsAlreadyRecorded {
+		err = nil
+	}
+	if err == nil && duplicates > 0 {
+		e.metricsClient.AddCounter(metrics.HistorySignalWorkflowExecutionScope, metrics.DuplicateSignalsCounter,
+			int64(duplicates))
+	}
+	if len(signals) > 1 {
+		e.metricsClient.AddCounter(metrics.HistorySignalWorkflowExecutionScope, metrics.BatchedSignalsCounter,
+			int64(len(signals)))
+	}
+	e.signalBatcher.done(key, batch, receipts, err)
+
+	if err != nil {
+		return nil, err
+	}
+	return receipts[request], nil
 }
 
-func (e *historyEngineImpl) TerminateWorkflowExecution(terminateRequest *h.TerminateWorkflowExecutionRequest) error {
-	domainID := terminateRequest.GetDomainUUID()
-	request := terminateRequest.GetTerminateRequest()
+// GetSignalReceipt tells whether the execution recorded the signal sent with the request ID, and returns the ID of its
+// event once it is part of the history
+func (e *historyEngineImpl) GetSignalReceipt(ctx thrift.Context,
+	receiptRequest *h.GetSignalReceiptRequest) (*workflow.GetSignalReceiptResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.GetSignalReceipt")
+	defer span.Finish()
+
+	domainID := receiptRequest.GetDomainUUID()
+	request := receiptRequest.GetReceiptRequest()
 	execution := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr(request.GetWorkflowExecution().GetWorkflowId()),
 		RunId:      common.StringPtr(request.GetWorkflowExecution().GetRunId()),
 	}
 
-	return e.updateWorkflowExecution(domainID, execution, true, false,
-		func(msBuilder *mutableStateBuilder) error {
-			if !msBuilder.isWorkflowExecutionRunning() {
-				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
-			}
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err0 != nil {
+		return nil, err0
+	}
+	defer release()
 
-			if msBuilder.AddWorkflowExecutionTerminatedEvent(request) == nil {
-				return &workflow.InternalServiceError{Message: "Unable to terminate workflow execution."}
-			}
+	msBuilder, err1 := context.loadWorkflowExecution(ctx)
+	if err1 != nil {
+		return nil, err1
+	}
 
-			return nil
-		})
+	response := &workflow.GetSignalReceiptResponse{
+		Recorded: common.BoolPtr(false),
+		RunId:    common.StringPtr(msBuilder.executionInfo.RunID),
+	}
+	if eventID, ok := msBuilder.GetSignalReceipt(request.GetRequestId()); ok {
+		receipt := newSignalReceipt(msBuilder, eventID)
+		response.Recorded = common.BoolPtr(true)
+		response.EventId = receipt.EventId
+	}
+	return response, nil
 }
 
-// ScheduleDecisionTask schedules a decision if no outstanding decision found
-func (e *historyEngineImpl) ScheduleDecisionTask(scheduleRequest *h.ScheduleDecisionTaskRequest) error {
-	domainID := scheduleRequest.GetDomainUUID()
+// newSignalReceipt returns the receipt of a signal recorded with the event ID, which is left out while the signal is
+// buffered behind the decision in flight
+func newSignalReceipt(msBuilder *mutableStateBuilder, eventID int64) *workflow.SignalWorkflowExecutionResponse {
+	receipt := &workflow.SignalWorkflowExecutionResponse{
+		RunId: common.StringPtr(msBuilder.executionInfo.RunID),
+	}
+	if eventID != bufferedEventID {
+		receipt.EventId = common.Int64Ptr(eventID)
+	}
+	return receipt
+}
+
+// QueryWorkflow queues the query for the decision task of the execution waiting for a poller, or else hands it to the
+// worker with a query task, and waits for the worker to answer it
+func (e *historyEngineImpl) QueryWorkflow(ctx thrift.Context,
+	queryRequest *h.QueryWorkflowRequest) (*workflow.QueryWorkflowResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.QueryWorkflow")
+	defer span.Finish()
+	queryCtx, cancel := context.WithTimeout(ctx, e.config.QueryTimeout)
+	defer cancel()
+
+	domainID := queryRequest.GetDomainUUID()
+	request := queryRequest.GetQueryRequest()
 	execution := workflow.WorkflowExecution{
-		WorkflowId: common.StringPtr(scheduleRequest.GetWorkflowExecution().GetWorkflowId()),
-		RunId:      common.StringPtr(scheduleRequest.GetWorkflowExecution().GetRunId()),
+		WorkflowId: common.StringPtr(request.GetExecution().GetWorkflowId()),
+		RunId:      common.StringPtr(request.GetExecution().GetRunId()),
 	}
 
-	return e.updateWorkflowExecution(domainID, execution, false, true,
-		func(msBuilder *mutableStateBuilder) error {
-			if !msBuilder.isWorkflowExecutionRunning() {
-				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
-			}
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err0 != nil {
+		return nil, err0
+	}
+	msBuilder, err1 := context.loadWorkflowExecution(ctx)
+	if err1 != nil {
+		release()
+		return nil, err1
+	}
+	if !msBuilder.isWorkflowExecutionRunning() {
+		release()
+		return nil, &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
+	}
+
+	// Queries are registered while holding the execution, so a decision task starting concurrently either
+	// delivers the query or leaves it to the query task.  Queries don't wait for a decision task a worker started
+	// already, or schedule one, they get a query task which doesn't change the history instead.
+	execution.RunId = context.workflowExecution.RunId
+	query := e.queryRegistry.add(execution.GetRunId(), request.GetQuery())
+	defer e.queryRegistry.remove(execution.GetRunId(), query.id)
+	needsQueryTask := !msBuilder.HasPendingDecisionTask() || msBuilder.HasInFlightDecisionTask()
+	taskList := msBuilder.executionInfo.TaskList
+	release()
+
+	if needsQueryTask {
+		// returns once a worker polled for the query task
+		err := e.matchingClient.AddDecisionTask(thrift.Wrap(queryCtx), &m.AddDecisionTaskRequest{
+			DomainUUID: common.StringPtr(domainID),
+			Execution:  &execution,
+			TaskList:   &workflow.TaskList{Name: common.StringPtr(taskList)},
+			ScheduleId: common.Int64Ptr(emptyEventID),
+			QueryId:    common.StringPtr(query.id),
+		})
+		if err != nil && queryCtx.Err() == nil {
+			return nil, err
+		}
+	}
+
+	select {
+	case result := <-query.resultCh:
+		if result.GetResultType() == workflow.QueryResultType_FAILED {
+			return nil, &workflow.QueryFailedError{Message: result.GetErrorMessage()}
+		}
+		return &workflow.QueryWorkflowResponse{QueryAnswer: result.GetAnswer()}, nil
+	case <-queryCtx.Done():
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &workflow.QueryFailedError{Message: "Query timed out waiting for a worker to answer it."}
+	}
+}
+
+// DescribeWorkflowExecution returns the execution info and the activities pending on the execution, with the time by
+// which each of them has to be completed before it times out
+func (e *historyEngineImpl) DescribeWorkflowExecution(ctx thrift.Context,
+	describeRequest *h.DescribeWorkflowExecutionRequest) (*workflow.DescribeWorkflowExecutionResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.DescribeWorkflowExecution")
+	defer span.Finish()
+
+	domainID := describeRequest.GetDomainUUID()
+	request := describeRequest.GetRequest()
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(request.GetExecution().GetWorkflowId()),
+		RunId:      common.StringPtr(request.GetExecution().GetRunId()),
+	}
+
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err0 != nil {
+		return nil, err0
+	}
+	defer release()
+
+	msBuilder, err1 := context.loadWorkflowExecution(ctx)
+	if err1 != nil {
+		return nil, err1
+	}
+
+	executionInfo := msBuilder.executionInfo
+	result := &workflow.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{
+			Execution: &workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr(executionInfo.WorkflowID),
+				RunId:      common.StringPtr(executionInfo.RunID),
+			},
+			Type:      &workflow.WorkflowType{Name: common.StringPtr(executionInfo.WorkflowTypeName)},
+			StartTime: common.Int64Ptr(executionInfo.StartTimestamp.UnixNano()),
+		},
+		PendingActivities: []*workflow.PendingActivityInfo{},
+	}
+
+	scheduleIDs := make([]int64, 0, len(msBuilder.pendingActivityInfoIDs))
+	for scheduleID := range msBuilder.pendingActivityInfoIDs {
+		scheduleIDs = append(scheduleIDs, scheduleID)
+	}
+	sort.Slice(scheduleIDs, func(i, j int) bool { return scheduleIDs[i] < scheduleIDs[j] })
+	for _, scheduleID := range scheduleIDs {
+		pendingActivity, err := createPendingActivityInfo(msBuilder, msBuilder.pendingActivityInfoIDs[scheduleID])
+		if err != nil {
+			return nil, err
+		}
+		result.PendingActivities = append(result.PendingActivities, pendingActivity)
+	}
+
+	return result, nil
+}
+
+func (e *historyEngineImpl) TerminateWorkflowExecution(ctx thrift.Context,
+	terminateRequest *h.TerminateWorkflowExecutionRequest) (*workflow.TerminateWorkflowExecutionResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.TerminateWorkflowExecution")
+	defer span.Finish()
+
+	domainID := terminateRequest.GetDomainUUID()
+	request := terminateRequest.GetTerminateRequest()
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(request.GetWorkflowExecution().GetWorkflowId()),
+		RunId:      common.StringPtr(request.GetWorkflowExecution().GetRunId()),
+	}
+
+	var children []*workflow.ChildWorkflowExecutionStartedEventAttributes
+	err := e.updateWorkflowExecution(ctx, domainID, execution, true, false,
+		func(msBuilder *mutableStateBuilder) error {
+			if !msBuilder.isWorkflowExecutionRunning() {
+				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
+			}
+
+			// Snapshot the running children before the update, as the policy is applied to them after the parent
+			// is terminated.  Children which have not started yet are abandoned along with their initiated event.
+			children = nil
+			for initiatedID, ci := range msBuilder.pendingChildExecutionInfoIDs {
+				if ci.StartedID == emptyEventID {
+					continue
+				}
+				startedEvent, ok := msBuilder.GetChildExecutionStartedEvent(initiatedID)
+				if !ok {
+					return &workflow.InternalServiceError{Message: "Unable to load child execution started event."}
+				}
+				children = append(children, startedEvent.GetChildWorkflowExecutionStartedEventAttributes())
+			}
+
+			if msBuilder.AddWorkflowExecutionTerminatedEvent(request) == nil {
+				return &workflow.InternalServiceError{Message: "Unable to terminate workflow execution."}
+			}
+
+			// The run ID is resolved by the update when the request only carries a workflow ID
+			execution.RunId = common.StringPtr(msBuilder.executionInfo.RunID)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	affectedExecutions := []*workflow.WorkflowExecution{&execution}
+	childExecutions, err := e.applyChildPolicy(ctx, request, children)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow.TerminateWorkflowExecutionResponse{
+		AffectedExecutions: append(affectedExecutions, childExecutions...),
+	}, nil
+}
+
+// applyChildPolicy applies the child policy from the terminate request to the children which were running when their
+// parent got terminated.  It returns the executions which were terminated or had cancellation requested as a result.
+// Children are left alone unless the request sets a policy, the zero value of ChildPolicy is TERMINATE.
+func (e *historyEngineImpl) applyChildPolicy(ctx thrift.Context, request *workflow.TerminateWorkflowExecutionRequest,
+	children []*workflow.ChildWorkflowExecutionStartedEventAttributes) ([]*workflow.WorkflowExecution, error) {
+	if !request.IsSetChildPolicy() {
+		return nil, nil
+	}
+	policy := request.GetChildPolicy()
+	if policy != workflow.ChildPolicy_TERMINATE && policy != workflow.ChildPolicy_REQUEST_CANCEL {
+		return nil, nil
+	}
+
+	var affectedExecutions []*workflow.WorkflowExecution
+	for _, child := range children {
+		domainInfo, _, err := e.domainCache.GetDomain(child.GetDomain())
+		if err != nil {
+			return nil, err
+		}
+
+		childExecution := child.GetWorkflowExecution()
+		switch policy {
+		case workflow.ChildPolicy_TERMINATE:
+			resp, err := e.historyClient.TerminateWorkflowExecution(ctx, &h.TerminateWorkflowExecutionRequest{
+				DomainUUID: common.StringPtr(domainInfo.ID),
+				TerminateRequest: &workflow.TerminateWorkflowExecutionRequest{
+					Domain:            common.StringPtr(child.GetDomain()),
+					WorkflowExecution: childExecution,
+					Reason:            common.StringPtr(request.GetReason()),
+					Details:           request.GetDetails(),
+					Identity:          common.StringPtr(request.GetIdentity()),
+					ChildPolicy:       workflow.ChildPolicyPtr(policy),
+				},
+			})
+			if err != nil {
+				if _, ok := err.(*workflow.EntityNotExistsError); ok {
+					// Child already completed
+					continue
+				}
+				return nil, err
+			}
+			affectedExecutions = append(affectedExecutions, resp.GetAffectedExecutions()...)
+
+		case workflow.ChildPolicy_REQUEST_CANCEL:
+			err := e.historyClient.RequestCancelWorkflowExecution(ctx, &h.RequestCancelWorkflowExecutionRequest{
+				DomainUUID: common.StringPtr(domainInfo.ID),
+				CancelRequest: &workflow.RequestCancelWorkflowExecutionRequest{
+					Domain:            common.StringPtr(child.GetDomain()),
+					WorkflowExecution: childExecution,
+					Identity:          common.StringPtr(request.GetIdentity()),
+				},
+			})
+			if err != nil {
+				if _, ok := err.(*workflow.EntityNotExistsError); ok {
+					// Child already completed
+					continue
+				}
+				return nil, err
+			}
+			affectedExecutions = append(affectedExecutions, childExecution)
+		}
+	}
+
+	return affectedExecutions, nil
+}
+
+// ScheduleDecisionTask schedules a decision if no outstanding decision found
+func (e *historyEngineImpl) ScheduleDecisionTask(ctx thrift.Context, scheduleRequest *h.ScheduleDecisionTaskRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.ScheduleDecisionTask")
+	defer span.Finish()
+
+	domainID := scheduleRequest.GetDomainUUID()
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(scheduleRequest.GetWorkflowExecution().GetWorkflowId()),
+		RunId:      common.StringPtr(scheduleRequest.GetWorkflowExecution().GetRunId()),
+	}
+
+	return e.updateWorkflowExecution(ctx, domainID, execution, false, true,
+		func(msBuilder *mutableStateBuilder) error {
+			if !msBuilder.isWorkflowExecutionRunning() {
+				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
+			}
 
 			// Noop
 
@@ -1263,14 +2063,17 @@ func (e *historyEngineImpl) ScheduleDecisionTask(scheduleRequest *h.ScheduleDeci
 }
 
 // RecordChildExecutionCompleted records the completion of child execution into parent execution history
-func (e *historyEngineImpl) RecordChildExecutionCompleted(completionRequest *h.RecordChildExecutionCompletedRequest) error {
+func (e *historyEngineImpl) RecordChildExecutionCompleted(ctx thrift.Context, completionRequest *h.RecordChildExecutionCompletedRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RecordChildExecutionCompleted")
+	defer span.Finish()
+
 	domainID := completionRequest.GetDomainUUID()
 	execution := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr(completionRequest.GetWorkflowExecution().GetWorkflowId()),
 		RunId:      common.StringPtr(completionRequest.GetWorkflowExecution().GetRunId()),
 	}
 
-	return e.updateWorkflowExecution(domainID, execution, false, true,
+	return e.updateWorkflowExecution(ctx, domainID, execution, false, true,
 		func(msBuilder *mutableStateBuilder) error {
 			if !msBuilder.isWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
@@ -1289,27 +2092,418 @@ func (e *historyEngineImpl) RecordChildExecutionCompleted(completionRequest *h.R
 			switch completionEvent.GetEventType() {
 			case workflow.EventType_WorkflowExecutionCompleted:
 				attributes := completionEvent.GetWorkflowExecutionCompletedEventAttributes()
-				msBuilder.AddChildWorkflowExecutionCompletedEvent(initiatedID, completedExecution, attributes)
+				msBuilder.AddChildWorkflowExecutionCompletedEvent(initiatedID, completedExecution, attributes,
+					completionEvent.PayloadReference)
 			case workflow.EventType_WorkflowExecutionFailed:
 				attributes := completionEvent.GetWorkflowExecutionFailedEventAttributes()
-				msBuilder.AddChildWorkflowExecutionFailedEvent(initiatedID, completedExecution, attributes)
+				msBuilder.AddChildWorkflowExecutionFailedEvent(initiatedID, completedExecution, attributes,
+					completionEvent.PayloadReference)
 			case workflow.EventType_WorkflowExecutionCanceled:
 				attributes := completionEvent.GetWorkflowExecutionCanceledEventAttributes()
 				msBuilder.AddChildWorkflowExecutionCanceledEvent(initiatedID, completedExecution, attributes)
 			case workflow.EventType_WorkflowExecutionTerminated:
 				attributes := completionEvent.GetWorkflowExecutionTerminatedEventAttributes()
 				msBuilder.AddChildWorkflowExecutionTerminatedEvent(initiatedID, completedExecution, attributes)
+			case workflow.EventType_WorkflowExecutionTerminatedByOperator:
+				operatorAttributes := completionEvent.GetWorkflowExecutionTerminatedByOperatorEventAttributes()
+				attributes := &workflow.WorkflowExecutionTerminatedEventAttributes{
+					Reason:   common.StringPtr(operatorAttributes.GetReason()),
+					Details:  operatorAttributes.GetDetails(),
+					Identity: common.StringPtr(operatorAttributes.GetIdentity()),
+				}
+				msBuilder.AddChildWorkflowExecutionTerminatedEvent(initiatedID, completedExecution, attributes)
 			}
 
 			return nil
 		})
 }
 
-func (e *historyEngineImpl) updateWorkflowExecution(domainID string, execution workflow.WorkflowExecution,
+// UpdateQueueProcessing pauses or resumes the transfer or timer queue processor of the shard, and persists its
+// paused state on the shard
+func (e *historyEngineImpl) UpdateQueueProcessing(ctx thrift.Context, request *h.UpdateQueueProcessingRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.UpdateQueueProcessing")
+	defer span.Finish()
+
+	var processor interface {
+		Pause()
+		Resume()
+	}
+	switch request.GetQueueType() {
+	case h.QueueType_TRANSFER:
+		processor = e.txProcessor
+	case h.QueueType_TIMER:
+		processor = e.timerProcessor
+	default:
+		return &workflow.BadRequestError{Message: fmt.Sprintf("Unknown queue type: %v", request.GetQueueType())}
+	}
+
+	if err := e.shard.UpdateQueuePaused(request.GetQueueType().String(), request.GetPaused()); err != nil {
+		return err
+	}
+	if request.GetPaused() {
+		processor.Pause()
+	} else {
+		processor.Resume()
+	}
+	return nil
+}
+
+// isQueuePaused returns whether the queue processor of the shard starts paused, the state persisted through the
+// UpdateQueueProcessing admin API overriding the configured one
+func isQueuePaused(shard ShardContext, queueType h.QueueType, pausedByConfig bool) bool {
+	if paused, ok := shard.GetQueuePaused(queueType.String()); ok {
+		return paused
+	}
+	return pausedByConfig
+}
+
+// RedriveTransferDLQTasks moves the transfer tasks parked in the dead letter queue of the shard back to its transfer
+// queue, the oldest first, and notifies the transfer queue processor of them
+func (e *historyEngineImpl) RedriveTransferDLQTasks(ctx thrift.Context,
+	request *h.RedriveTransferDLQTasksRequest) (*h.RedriveTransferDLQTasksResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RedriveTransferDLQTasks")
+	defer span.Finish()
+
+	maxCount := int(request.GetMaximumTaskCount())
+	redriven := 0
+	defer func() {
+		if redriven > 0 {
+			e.txProcessor.NotifyNewTask()
+		}
+	}()
+
+	readLevel := int64(0)
+	for maxCount <= 0 || redriven < maxCount {
+		response, err := e.executionManager.GetTransferDLQTasks(ctx, &persistence.GetTransferDLQTasksRequest{
+			ReadLevel: readLevel,
+			BatchSize: transferDLQRedriveBatchSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, task := range response.Tasks {
+			if maxCount > 0 && redriven >= maxCount {
+				break
+			}
+			err := e.shard.RedriveTransferDLQTask(ctx, &persistence.RedriveTransferDLQTaskRequest{TaskInfo: task})
+			if err != nil {
+				return nil, err
+			}
+			redriven++
+			readLevel = task.TaskID
+		}
+
+		if len(response.Tasks) < transferDLQRedriveBatchSize {
+			break
+		}
+	}
+
+	e.logger.Infof("Redrove %v transfer tasks from the dead letter queue.", redriven)
+	return &h.RedriveTransferDLQTasksResponse{RedrivenTaskCount: common.Int32Ptr(int32(redriven))}, nil
+}
+
+// DescribeMutableState dumps the mutable state of the execution as JSON, along with the transfer queue ack levels of
+// the shard.  The execution is only read, never updated.
+func (e *historyEngineImpl) DescribeMutableState(ctx thrift.Context,
+	request *h.DescribeMutableStateRequest) (*h.DescribeMutableStateResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.DescribeMutableState")
+	defer span.Finish()
+
+	domainID := request.GetDomainUUID()
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(request.GetExecution().GetWorkflowId()),
+		RunId:      common.StringPtr(request.GetExecution().GetRunId()),
+	}
+
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err0 != nil {
+		return nil, err0
+	}
+	defer release()
+
+	msBuilder, err1 := context.loadWorkflowExecution(ctx)
+	if err1 != nil {
+		return nil, err1
+	}
+
+	dump := &mutableStateDump{
+		ExecutionInfo:        msBuilder.executionInfo,
+		ActivityInfos:        msBuilder.pendingActivityInfoIDs,
+		TimerInfos:           msBuilder.pendingTimerInfoIDs,
+		ChildExecutionInfos:  msBuilder.pendingChildExecutionInfoIDs,
+		RequestCancelInfos:   msBuilder.pendingRequestCancelInfoIDs,
+		SignalReceipts:       msBuilder.signalReceipts,
+		TransferAckLevel:     e.shard.GetTransferAckLevel(),
+		TransferMaxReadLevel: e.shard.GetTransferMaxReadLevel(),
+	}
+	if msBuilder.HasPendingDecisionTask() {
+		dump.PendingDecision, _ = msBuilder.GetPendingDecision(msBuilder.executionInfo.DecisionScheduleID)
+	}
+
+	// Marshalled while the execution is locked, so the dump is consistent
+	data, err2 := json.Marshal(dump)
+	if err2 != nil {
+		return nil, &workflow.InternalServiceError{Message: fmt.Sprintf("Unable to dump mutable state: %v", err2)}
+	}
+
+	return &h.DescribeMutableStateResponse{MutableStateInJson: common.StringPtr(string(data))}, nil
+}
+
+// RefreshWorkflowTasks re-generates the transfer and timer tasks of a running execution from its mutable state: the
+// pending decision, the pending activities along with their timeouts, the next user timer, the child executions
+// not started yet and the external cancellations not delivered yet.  Timeouts keep their original deadline.  Tasks
+// which were not lost are dropped as duplicates by the queue processors.  Executions have no timer for their own
+// execution timeout, so there is none to re-generate.
+func (e *historyEngineImpl) RefreshWorkflowTasks(ctx thrift.Context, request *h.RefreshWorkflowTasksRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.RefreshWorkflowTasks")
+	defer span.Finish()
+
+	domainID := request.GetDomainUUID()
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(request.GetRefreshRequest().GetWorkflowExecution().GetWorkflowId()),
+		RunId:      common.StringPtr(request.GetRefreshRequest().GetWorkflowExecution().GetRunId()),
+	}
+
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err0 != nil {
+		return err0
+	}
+	defer release()
+
+Update_History_Loop:
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
+		if err1 != nil {
+			return err1
+		}
+
+		if !msBuilder.isWorkflowExecutionRunning() {
+			return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
+		}
+
+		transferTasks, timerTasks, err2 := e.createRefreshTasks(ctx, domainID, context, msBuilder)
+		if err2 != nil {
+			return err2
+		}
+
+		// Generate a transaction ID for appending events to history
+		transactionID, err3 := e.shard.GetNextTransferTaskID()
+		if err3 != nil {
+			return err3
+		}
+
+		// No event is added, the update only writes the tasks, conditioned on the execution not having moved on
+		if err4 := context.updateWorkflowExecution(ctx, transferTasks, timerTasks, transactionID); err4 != nil {
+			if err4 == ErrConflict {
+				continue Update_History_Loop
+			}
+			return err4
+		}
+		return nil
+	}
+	return ErrMaxAttemptsExceeded
+}
+
+// MigrateWorkflowExecution moves a closed workflow execution, its history, mutable state and visibility record, to
+// another domain.  The execution keeps its workflow and run IDs.
+func (e *historyEngineImpl) MigrateWorkflowExecution(ctx thrift.Context,
+	request *h.MigrateWorkflowExecutionRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "HistoryEngine.MigrateWorkflowExecution")
+	defer span.Finish()
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(request.GetMigrateRequest().GetWorkflowExecution().GetWorkflowId()),
+		RunId:      common.StringPtr(request.GetMigrateRequest().GetWorkflowExecution().GetRunId()),
+	}
+	return e.migrator.migrate(ctx, request.GetDomainUUID(), request.GetTargetDomainUUID(), execution)
+}
+
+// createRefreshTasks creates the tasks a running execution is waiting on, given its mutable state
+func (e *historyEngineImpl) createRefreshTasks(ctx context.Context, domainID string,
+	context *workflowExecutionContext, msBuilder *mutableStateBuilder) ([]persistence.Task, []persistence.Task, error) {
+	var transferTasks []persistence.Task
+	var timerTasks []persistence.Task
+	// timerErr is the first error creating a timer task
+	var timerErr error
+	addTimerTask := func(task persistence.Task, err error) {
+		if err != nil {
+			if timerErr == nil {
+				timerErr = err
+			}
+			return
+		}
+		if task != nil {
+			timerTasks = append(timerTasks, task)
+		}
+	}
+
+	if msBuilder.HasPendingDecisionTask() {
+		di, _ := msBuilder.GetPendingDecision(msBuilder.executionInfo.DecisionScheduleID)
+		if di.StartedID == emptyEventID {
+			transferTasks = append(transferTasks, &persistence.DecisionTask{
+				DomainID:   domainID,
+				TaskList:   msBuilder.executionInfo.TaskList,
+				ScheduleID: di.ScheduleID,
+			})
+		} else {
+			addTimerTask(context.tBuilder.AddDecisionTimoutTask(di.ScheduleID, di.DecisionTimeout))
+		}
+	}
+
+	for scheduleID, ai := range msBuilder.pendingActivityInfoIDs {
+		scheduledEvent, ok := msBuilder.GetActivityScheduledEvent(scheduleID)
+		if !ok {
+			return nil, nil, &workflow.InternalServiceError{Message: "Unable to load activity scheduled event."}
+		}
+		scheduledTime := time.Unix(0, scheduledEvent.GetTimestamp())
+		addTimerTask(context.tBuilder.AddActivityTimeoutTask(scheduleID, workflow.TimeoutType_SCHEDULE_TO_CLOSE,
+			ai.ScheduleToCloseTimeout, &scheduledTime))
+
+		if ai.StartedID == emptyEventID {
+			attributes := scheduledEvent.GetActivityTaskScheduledEventAttributes()
+			targetDomainID := domainID
+			if attributes.IsSetDomain() {
+				info, _, err := e.domainCache.GetDomain(attributes.GetDomain())
+				if err != nil {
+					return nil, nil, err
+				}
+				targetDomainID = info.ID
+			}
+			transferTasks = append(transferTasks, &persistence.ActivityTask{
+				DomainID:   targetDomainID,
+				TaskList:   attributes.GetTaskList().GetName(),
+				ScheduleID: scheduleID,
+			})
+			addTimerTask(context.tBuilder.AddActivityTimeoutTask(scheduleID, workflow.TimeoutType_SCHEDULE_TO_START,
+				ai.ScheduleToStartTimeout, &scheduledTime))
+			continue
+		}
+
+		startedTime := ai.StartedTime
+		addTimerTask(context.tBuilder.AddActivityTimeoutTask(scheduleID, workflow.TimeoutType_START_TO_CLOSE,
+			ai.StartToCloseTimeout, &startedTime))
+		addTimerTask(context.tBuilder.AddHeartBeatActivityTimeout(ai))
+	}
+
+	// Only the first user timer has a task, the next one is created when it fires
+	for _, ti := range msBuilder.pendingTimerInfoIDs {
+		ti.TaskID = emptyTimerID
+		msBuilder.UpdateUserTimer(ti.TimerID, ti)
+	}
+	for _, ti := range msBuilder.pendingTimerInfoIDs {
+		addTimerTask(context.tBuilder.AddUserTimer(ti, msBuilder))
+		break
+	}
+
+	for initiatedID, ci := range msBuilder.pendingChildExecutionInfoIDs {
+		if ci.StartedID != emptyEventID {
+			continue
+		}
+		initiatedEvent, ok := msBuilder.GetChildExecutionInitiatedEvent(initiatedID)
+		if !ok {
+			return nil, nil, &workflow.InternalServiceError{Message: "Unable to load child execution initiated event."}
+		}
+		attributes := initiatedEvent.GetStartChildWorkflowExecutionInitiatedEventAttributes()
+		targetDomainID := domainID
+		if attributes.IsSetDomain() {
+			info, _, err := e.domainCache.GetDomain(attributes.GetDomain())
+			if err != nil {
+				return nil, nil, err
+			}
+			targetDomainID = info.ID
+		}
+		transferTasks = append(transferTasks, &persistence.StartChildExecutionTask{
+			TargetDomainID:   targetDomainID,
+			TargetWorkflowID: attributes.GetWorkflowId(),
+			InitiatedID:      initiatedID,
+		})
+	}
+
+	if len(msBuilder.pendingRequestCancelInfoIDs) > 0 {
+		cancelTasks, err := e.createRefreshCancelTasks(ctx, domainID, context.workflowExecution, msBuilder)
+		if err != nil {
+			return nil, nil, err
+		}
+		transferTasks = append(transferTasks, cancelTasks...)
+	}
+
+	if timerErr != nil {
+		return nil, nil, timerErr
+	}
+	return transferTasks, timerTasks, nil
+}
+
+// createRefreshCancelTasks creates the tasks of the external cancellations not delivered yet.  Mutable state only
+// keeps the initiated event ID of a cancellation, the target is read from the initiated event in history.
+func (e *historyEngineImpl) createRefreshCancelTasks(ctx context.Context, domainID string,
+	execution workflow.WorkflowExecution, msBuilder *mutableStateBuilder) ([]persistence.Task, error) {
+	var lastInitiatedID int64
+	for initiatedID := range msBuilder.pendingRequestCancelInfoIDs {
+		if initiatedID > lastInitiatedID {
+			lastInitiatedID = initiatedID
+		}
+	}
+
+	var cancelTasks []persistence.Task
+	request := &persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID:      domainID,
+		Execution:     execution,
+		NextEventID:   lastInitiatedID + 1,
+		PageSize:      refreshHistoryPageSize,
+		NextPageToken: []byte{},
+	}
+	for {
+		response, err := e.historyMgr.GetWorkflowExecutionHistory(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for i := range response.Events {
+			serializer, err := e.hSerializerFactory.Get(response.Events[i].EncodingType)
+			if err != nil {
+				return nil, err
+			}
+			batch, err := serializer.Deserialize(&response.Events[i])
+			if err != nil {
+				return nil, err
+			}
+			for _, event := range batch.Events {
+				if event.GetEventType() != workflow.EventType_RequestCancelExternalWorkflowExecutionInitiated {
+					continue
+				}
+				if _, ok := msBuilder.pendingRequestCancelInfoIDs[event.GetEventId()]; !ok {
+					continue
+				}
+				attributes := event.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes
+				targetInfo, _, err := e.domainCache.GetDomain(attributes.GetDomain())
+				if err != nil {
+					return nil, err
+				}
+				cancelTasks = append(cancelTasks, &persistence.CancelExecutionTask{
+					TargetDomainID:   targetInfo.ID,
+					TargetWorkflowID: attributes.GetWorkflowExecution().GetWorkflowId(),
+					TargetRunID:      attributes.GetWorkflowExecution().GetRunId(),
+					ScheduleID:       event.GetEventId(),
+				})
+			}
+		}
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+
+	if len(cancelTasks) != len(msBuilder.pendingRequestCancelInfoIDs) {
+		return nil, &workflow.InternalServiceError{Message: "Unable to load request cancel initiated event."}
+	}
+	return cancelTasks, nil
+}
+
+func (e *historyEngineImpl) updateWorkflowExecution(ctx context.Context, domainID string, execution workflow.WorkflowExecution,
 	createDeletionTask, createDecisionTask bool,
 	action func(builder *mutableStateBuilder) error) error {
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(domainID, execution)
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err0 != nil {
 		return err0
 	}
@@ -1317,7 +2511,7 @@ func (e *historyEngineImpl) updateWorkflowExecution(domainID string, execution w
 
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
 			return err1
 		}
@@ -1352,7 +2546,7 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict then reload
 		// the history and try the operation again.
-		if err := context.updateWorkflowExecution(transferTasks, nil, transactionID); err != nil {
+		if err := context.updateWorkflowExecution(ctx, transferTasks, nil, transactionID); err != nil {
 			if err == ErrConflict {
 				continue Update_History_Loop
 			}
@@ -1363,6 +2557,21 @@ Update_History_Loop:
 	return ErrMaxAttemptsExceeded
 }
 
+// validateTaskStart rejects starting a task matched to a zombie execution: the state cached by a shard stolen by another
+// host can't be trusted, and tasks of completed workflows are dropped by matching without retries or failure events.
+func (e *historyEngineImpl) validateTaskStart(msBuilder *mutableStateBuilder) error {
+	if e.shard.IsClosed() {
+		return &persistence.ShardOwnershipLostError{
+			ShardID: e.shard.GetShardID(),
+			Msg:     "Shard is closed, it was acquired by another host.",
+		}
+	}
+	if !msBuilder.isWorkflowExecutionRunning() {
+		return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
+	}
+	return nil
+}
+
 func (e *historyEngineImpl) createRecordDecisionTaskStartedResponse(domainID string, msBuilder *mutableStateBuilder,
 	startedEventID int64) *h.RecordDecisionTaskStartedResponse {
 	response := h.NewRecordDecisionTaskStartedResponse()
@@ -1371,6 +2580,7 @@ func (e *historyEngineImpl) createRecordDecisionTaskStartedResponse(domainID str
 		response.PreviousStartedEventId = common.Int64Ptr(msBuilder.previousDecisionStartedEvent())
 	}
 	response.StartedEventId = common.Int64Ptr(startedEventID)
+	response.Queries = e.queryRegistry.deliver(msBuilder.executionInfo.RunID)
 
 	return response
 }
@@ -1387,14 +2597,52 @@ func setSerializedHistoryDefaults(history *persistence.SerializedHistoryEventBat
 	}
 }
 
-func (e *historyEngineImpl) failDecision(context *workflowExecutionContext, scheduleID, startedID int64,
+func (e *historyEngineImpl) isBadBinary(domainID, binaryChecksum string) (bool, error) {
+	_, config, err := e.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, badBinary := range config.BadBinaries {
+		if badBinary == binaryChecksum {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// terminateIfStuck terminates the execution, instead of letting it schedule another attempt of its failing decision,
+// once the decision has failed the number of consecutive attempts allowed by the domain.  It returns whether the
+// execution got terminated.
+func (e *historyEngineImpl) terminateIfStuck(msBuilder *mutableStateBuilder, scope int) (bool, error) {
+	attempt := msBuilder.executionInfo.DecisionAttempt
+	if attempt == 0 {
+		return false, nil
+	}
+
+	_, config, err := e.domainCache.GetDomainByID(msBuilder.executionInfo.DomainID)
+	if err != nil {
+		return false, err
+	}
+	if config.MaxDecisionAttempts == 0 || attempt < int64(config.MaxDecisionAttempts) {
+		return false, nil
+	}
+
+	if msBuilder.AddWorkflowExecutionTerminatedStuckEvent(attempt) == nil {
+		return false, &workflow.InternalServiceError{Message: "Unable to terminate stuck workflow execution."}
+	}
+	e.metricsClient.IncCounter(scope, metrics.StuckExecutionsTerminatedCounter)
+	return true, nil
+}
+
+func (e *historyEngineImpl) failDecision(ctx context.Context, context *workflowExecutionContext, scheduleID, startedID int64,
 	cause workflow.DecisionTaskFailedCause, request *workflow.RespondDecisionTaskCompletedRequest) (*mutableStateBuilder,
 	error) {
 	// Clear any updates we have accumulated so far
 	context.clear()
 
 	// Reload workflow execution so we can apply the decision task failure event
-	msBuilder, err := context.loadWorkflowExecution()
+	msBuilder, err := context.loadWorkflowExecution(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1405,27 +2653,261 @@ func (e *historyEngineImpl) failDecision(context *workflowExecutionContext, sche
 	return msBuilder, nil
 }
 
-func (s *shardContextWrapper) UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) error {
-	err := s.ShardContext.UpdateWorkflowExecution(request)
-	if err == nil {
-		if len(request.TransferTasks) > 0 {
-			s.txProcessor.NotifyNewTask()
+// createPendingActivityInfo describes a pending activity.  Its expiration is the earliest of its schedule-to-close and,
+// once started, start-to-close deadlines, the latter bounded by ActivityExternalCompletionTimeout for activities which
+// don't heartbeat; a completion which hasn't arrived by then, from the worker or externally through the activity ID
+// based APIs, is lost and the activity times out.
+func createPendingActivityInfo(msBuilder *mutableStateBuilder,
+	ai *persistence.ActivityInfo) (*workflow.PendingActivityInfo, error) {
+	scheduledEvent, ok := msBuilder.GetActivityScheduledEvent(ai.ScheduleID)
+	if !ok {
+		return nil, &workflow.InternalServiceError{Message: "Unable to get activity schedule event."}
+	}
+
+	pendingActivity := &workflow.PendingActivityInfo{
+		ActivityID: common.StringPtr(ai.ActivityID),
+		State:      common.PendingActivityStatePtr(workflow.PendingActivityState_SCHEDULED),
+	}
+	expiration := common.AddSecondsToBaseTime(scheduledEvent.GetTimestamp(), int64(ai.ScheduleToCloseTimeout))
+	if ai.StartedID != emptyEventID {
+		pendingActivity.State = common.PendingActivityStatePtr(workflow.PendingActivityState_STARTED)
+		pendingActivity.LastStartedTimestamp = common.Int64Ptr(ai.StartedTime.UnixNano())
+		startedExpiration := common.AddSecondsToBaseTime(ai.StartedTime.UnixNano(), int64(ai.StartToCloseTimeout))
+		if startedExpiration < expiration {
+			expiration = startedExpiration
 		}
 	}
+	if ai.CancelRequested {
+		pendingActivity.State = common.PendingActivityStatePtr(workflow.PendingActivityState_CANCEL_REQUESTED)
+	}
+	if !ai.LastHeartBeatUpdatedTime.IsZero() {
+		pendingActivity.HeartbeatDetails = ai.Details
+		pendingActivity.LastHeartbeatTimestamp = common.Int64Ptr(ai.LastHeartBeatUpdatedTime.UnixNano())
+	}
+	pendingActivity.ExpirationTimestamp = common.Int64Ptr(expiration)
+
+	return pendingActivity, nil
+}
+
+// getActivityScheduleID returns the schedule ID of the activity a task token refers to.  Tokens made up by the
+// frontend for the activity ID based APIs carry the activity ID instead of the schedule ID.
+func getActivityScheduleID(token *common.TaskToken, msBuilder *mutableStateBuilder) (int64, error) {
+	if token.ScheduleID != common.EmptyEventID {
+		return token.ScheduleID, nil
+	}
+
+	ai, isRunning := msBuilder.GetActivityByActivityID(token.ActivityID)
+	if !isRunning {
+		return 0, &workflow.EntityNotExistsError{Message: "Activity task not found."}
+	}
+	return ai.ScheduleID, nil
+}
+
+// createDecisionDispatchTasks returns the tasks needed to dispatch a newly scheduled decision.  Decisions which are
+// retried after a failure or timeout are dispatched by a backoff timer instead of being sent to matching right away.
+func createDecisionDispatchTasks(config *Config, tBuilder *timerBuilder, domainID string,
+	newDecisionEvent *workflow.HistoryEvent, di *decisionInfo) (transferTasks, timerTasks []persistence.Task,
+	err error) {
+	if di.Attempt > 0 {
+		backoff := getDecisionRetryBackoff(config, di.Attempt)
+		backoffTask, err := tBuilder.AddDecisionRetryBackoffTask(di.ScheduleID, backoff)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, []persistence.Task{backoffTask}, nil
+	}
+
+	transferTasks = append(transferTasks, &persistence.DecisionTask{
+		DomainID:   domainID,
+		TaskList:   newDecisionEvent.GetDecisionTaskScheduledEventAttributes().GetTaskList().GetName(),
+		ScheduleID: newDecisionEvent.GetEventId(),
+	})
+	return
+}
+
+func getDecisionRetryBackoff(config *Config, attempt int64) time.Duration {
+	backoff := config.DecisionRetryInitialInterval
+	for i := int64(1); i < attempt && backoff < config.DecisionRetryMaxInterval; i++ {
+		backoff *= 2
+	}
+	if backoff > config.DecisionRetryMaxInterval {
+		backoff = config.DecisionRetryMaxInterval
+	}
+	return backoff
+}
+
+func (s *shardContextWrapper) UpdateWorkflowExecution(ctx context.Context, request *persistence.UpdateWorkflowExecutionRequest) error {
+	updateRequest, err := s.addDecisionScheduleToStartTimeouts(request)
+	if err != nil {
+		return err
+	}
+	err = s.ShardContext.UpdateWorkflowExecution(ctx, updateRequest)
+	if err == nil {
+		s.notifyNewTasks(updateRequest)
+		s.openExecutions.executionUpdated(ctx, updateRequest)
+	}
 	return err
 }
 
-func (s *shardContextWrapper) CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (
+// addDecisionScheduleToStartTimeouts returns a copy of the update request with the schedule to start timeouts of its
+// decision tasks added.  Requests are retried as is, so timeouts are added to a copy of the request
+func (s *shardContextWrapper) addDecisionScheduleToStartTimeouts(
+	request *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionRequest, error) {
+	updateRequest := *request
+	timeoutTasks, err := s.createDecisionScheduleToStartTimeoutTasks(request.TransferTasks)
+	if err != nil {
+		return nil, err
+	}
+	updateRequest.TimerTasks = appendTasks(request.TimerTasks, timeoutTasks)
+	if request.ContinueAsNew != nil {
+		startRequest := *request.ContinueAsNew
+		newRunTimeoutTasks, err := s.createDecisionScheduleToStartTimeoutTasks(startRequest.TransferTasks)
+		if err != nil {
+			return nil, err
+		}
+		startRequest.TimerTasks = appendTasks(startRequest.TimerTasks, newRunTimeoutTasks)
+		updateRequest.ContinueAsNew = &startRequest
+	}
+	return &updateRequest, nil
+}
+
+// notifyNewTasks notifies the queue processors of the tasks written by a successful update
+func (s *shardContextWrapper) notifyNewTasks(updateRequest *persistence.UpdateWorkflowExecutionRequest) {
+	if len(updateRequest.TransferTasks) > 0 {
+		s.txProcessor.NotifyNewTask()
+	}
+	s.notifyNewTimers(updateRequest.TimerTasks)
+	if updateRequest.ContinueAsNew != nil {
+		s.notifyNewTimers(updateRequest.ContinueAsNew.TimerTasks)
+	}
+}
+
+func (s *shardContextWrapper) CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (
 	*persistence.CreateWorkflowExecutionResponse, error) {
-	resp, err := s.ShardContext.CreateWorkflowExecution(request)
+	createRequest := *request
+	timeoutTasks, err := s.createDecisionScheduleToStartTimeoutTasks(request.TransferTasks)
+	if err != nil {
+		return nil, err
+	}
+	createRequest.TimerTasks = appendTasks(request.TimerTasks, timeoutTasks)
+
+	resp, err := s.ShardContext.CreateWorkflowExecution(ctx, &createRequest)
 	if err == nil {
 		if len(request.TransferTasks) > 0 {
 			s.txProcessor.NotifyNewTask()
 		}
+		s.notifyNewTimers(timeoutTasks)
+		s.openExecutions.executionCreated(ctx, request.DomainID)
 	}
 	return resp, err
 }
 
+// createDecisionScheduleToStartTimeoutTasks creates a schedule to start timeout for each decision task dispatched
+// to matching by the transfer tasks.
+func (s *shardContextWrapper) createDecisionScheduleToStartTimeoutTasks(
+	transferTasks []persistence.Task) ([]persistence.Task, error) {
+	scheduleToStartTimeout := int32(s.config.DecisionScheduleToStartTimeout / time.Second)
+	if scheduleToStartTimeout <= 0 {
+		return nil, nil
+	}
+
+	var timerTasks []persistence.Task
+	for _, task := range transferTasks {
+		if decisionTask, ok := task.(*persistence.DecisionTask); ok {
+			timeoutTask, err := s.tBuilder.AddScheduleToStartDecisionTimeoutTask(decisionTask.ScheduleID,
+				scheduleToStartTimeout)
+			if err != nil {
+				return nil, err
+			}
+			timerTasks = append(timerTasks, timeoutTask)
+		}
+	}
+	return timerTasks, nil
+}
+
+func (s *shardContextWrapper) notifyNewTimers(timerTasks []persistence.Task) {
+	for _, task := range timerTasks {
+		s.timerProcessor.NotifyNewTimer(task.GetTaskID())
+	}
+}
+
+func appendTasks(tasks []persistence.Task, newTasks []persistence.Task) []persistence.Task {
+	if len(newTasks) == 0 {
+		return tasks
+	}
+	result := make([]persistence.Task, 0, len(tasks)+len(newTasks))
+	result = append(result, tasks...)
+	return append(result, newTasks...)
+}
+
+// checkCompleted rejects requests against executions the host recently closed without loading their mutable state
+func (e *historyEngineImpl) checkCompleted(scope int, domainID string, execution workflow.WorkflowExecution) error {
+	if _, ok := e.completedExecutions.get(domainID, execution); ok {
+		e.metricsClient.IncCounter(scope, metrics.CompletedExecutionCacheHitCounter)
+		return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
+	}
+	return nil
+}
+
+// checkActivityCompleted returns true when the activity completion is a retry of one which went through already, so
+// it is acknowledged without appending a second completion to the history
+func (e *historyEngineImpl) checkActivityCompleted(domainID string, token *common.TaskToken, result []byte) bool {
+	if e.completedActivities.isDuplicate(domainID, token, result) {
+		e.metricsClient.IncCounter(metrics.HistoryRespondActivityTaskCompletedScope,
+			metrics.DuplicateActivityCompletionCounter)
+		return true
+	}
+	return false
+}
+
+// applyActivityTimeoutPolicy fills in the timeouts the decision left unset with the domain defaults and clamps them
+// to the domain caps, so activities can't be scheduled without a bound on how long they stay pending
+func (e *historyEngineImpl) applyActivityTimeoutPolicy(domainID string,
+	attributes *workflow.ScheduleActivityTaskDecisionAttributes) error {
+	if attributes == nil {
+		return nil
+	}
+
+	_, config, err := e.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return err
+	}
+
+	defaults := config.ActivityTimeoutDefaults
+	caps := config.ActivityTimeoutCaps
+	attributes.ScheduleToCloseTimeoutSeconds = applyTimeoutPolicy(attributes.ScheduleToCloseTimeoutSeconds,
+		defaults.ScheduleToClose, caps.ScheduleToClose)
+	attributes.ScheduleToStartTimeoutSeconds = applyTimeoutPolicy(attributes.ScheduleToStartTimeoutSeconds,
+		defaults.ScheduleToStart, caps.ScheduleToStart)
+	attributes.StartToCloseTimeoutSeconds = applyTimeoutPolicy(attributes.StartToCloseTimeoutSeconds,
+		defaults.StartToClose, caps.StartToClose)
+	attributes.HeartbeatTimeoutSeconds = applyTimeoutPolicy(attributes.HeartbeatTimeoutSeconds,
+		defaults.Heartbeat, caps.Heartbeat)
+	return nil
+}
+
+// applyExternalCompletionTimeout bounds how long a started activity which doesn't heartbeat waits for its completion,
+// which may be reported by another process through the activity ID based APIs.  Activities which heartbeat time out
+// on their heartbeat timeout once their completion is lost.
+func (e *historyEngineImpl) applyExternalCompletionTimeout(ai *persistence.ActivityInfo) {
+	timeout := int32(e.config.ActivityExternalCompletionTimeout / time.Second)
+	if timeout > 0 && ai.HeartbeatTimeout <= 0 && ai.StartToCloseTimeout > timeout {
+		ai.StartToCloseTimeout = timeout
+	}
+}
+
+// applyTimeoutPolicy returns the default when the timeout is unset and the cap when the timeout exceeds it, a zero
+// default or cap is not applied
+func applyTimeoutPolicy(timeout *int32, defaultValue, capValue int32) *int32 {
+	if (timeout == nil || *timeout == 0) && defaultValue > 0 {
+		timeout = common.Int32Ptr(defaultValue)
+	}
+	if timeout != nil && capValue > 0 && *timeout > capValue {
+		timeout = common.Int32Ptr(capValue)
+	}
+	return timeout
+}
+
 func validateActivityScheduleAttributes(attributes *workflow.ScheduleActivityTaskDecisionAttributes) error {
 	if attributes == nil {
 		return &workflow.BadRequestError{Message: "ScheduleActivityTaskDecisionAttributes is not set on decision."}
@@ -1466,6 +2948,12 @@ func validateTimerScheduleAttributes(attributes *workflow.StartTimerDecisionAttr
 	if !attributes.IsSetTimerId() || attributes.GetTimerId() == "" {
 		return &workflow.BadRequestError{Message: "TimerId is not set on decision."}
 	}
+	if attributes.IsSetStartToFireTimeoutMilliseconds() {
+		if attributes.GetStartToFireTimeoutMilliseconds() <= 0 {
+			return &workflow.BadRequestError{Message: "A valid StartToFireTimeoutMilliseconds is not set on decision."}
+		}
+		return nil
+	}
 	if !attributes.IsSetStartToFireTimeoutSeconds() || attributes.GetStartToFireTimeoutSeconds() <= 0 {
 		return &workflow.BadRequestError{Message: "A valid StartToFireTimeoutSeconds is not set on decision."}
 	}
@@ -1545,6 +3033,34 @@ func validateCancelExternalWorkflowExecutionAttributes(attributes *workflow.Requ
 	return nil
 }
 
+func validateStartChildExecutionAttributes(attributes *workflow.StartChildWorkflowExecutionDecisionAttributes) error {
+	if attributes == nil {
+		return &workflow.BadRequestError{Message: "StartChildWorkflowExecutionDecisionAttributes is not set on decision."}
+	}
+
+	if !attributes.IsSetWorkflowId() || attributes.GetWorkflowId() == "" {
+		return &workflow.BadRequestError{Message: "WorkflowId is not set on decision."}
+	}
+
+	if !attributes.IsSetWorkflowType() || !attributes.GetWorkflowType().IsSetName() || attributes.GetWorkflowType().GetName() == "" {
+		return &workflow.BadRequestError{Message: "WorkflowType is not set on decision."}
+	}
+
+	if attributes.IsSetTaskList() && attributes.GetTaskList().GetName() == "" {
+		return &workflow.BadRequestError{Message: "A valid TaskList is not set on decision."}
+	}
+
+	if attributes.IsSetExecutionStartToCloseTimeoutSeconds() && attributes.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
+		return &workflow.BadRequestError{Message: "A valid ExecutionStartToCloseTimeoutSeconds is not set on decision."}
+	}
+
+	if attributes.IsSetTaskStartToCloseTimeoutSeconds() && attributes.GetTaskStartToCloseTimeoutSeconds() <= 0 {
+		return &workflow.BadRequestError{Message: "A valid TaskStartToCloseTimeoutSeconds is not set on decision."}
+	}
+
+	return nil
+}
+
 func validateContinueAsNewWorkflowExecutionAttributes(attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) error {
 	if attributes == nil {
 		return &workflow.BadRequestError{Message: "ContinueAsNewWorkflowExecutionDecisionAttributes is not set on decision."}
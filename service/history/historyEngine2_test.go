@@ -25,17 +25,22 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/tchannel-go/thrift"
 
 	h "github.com/uber/cadence/.gen/go/history"
+	m "github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
 )
@@ -54,9 +59,10 @@ type (
 		mockExecutionMgr   *mocks.ExecutionManager
 		mockHistoryMgr     *mocks.HistoryManager
 		mockShardManager   *mocks.ShardManager
-		shardClosedCh      chan int
+		shardEvents        *shardEventBus
 		eventSerializer    historyEventSerializer
 		logger             bark.Logger
+		callContext        thrift.Context
 	}
 )
 
@@ -71,6 +77,7 @@ func (s *engine2Suite) SetupSuite() {
 	}
 
 	s.logger = bark.NewLoggerFromLogrus(log.New())
+	s.callContext = common.BackgroundThriftContext()
 }
 
 func (s *engine2Suite) TearDownSuite() {
@@ -88,36 +95,46 @@ func (s *engine2Suite) SetupTest() {
 	s.mockExecutionMgr = &mocks.ExecutionManager{}
 	s.mockHistoryMgr = &mocks.HistoryManager{}
 	s.mockShardManager = &mocks.ShardManager{}
-	s.shardClosedCh = make(chan int, 100)
+	s.shardEvents = newShardEventBus()
 	s.eventSerializer = newJSONHistoryEventSerializer()
 
 	mockShard := &shardContextImpl{
-		shardInfo:                 &persistence.ShardInfo{ShardID: shardID, RangeID: 1, TransferAckLevel: 0},
-		transferSequenceNumber:    1,
-		executionManager:          s.mockExecutionMgr,
-		historyMgr:                s.mockHistoryMgr,
-		shardManager:              s.mockShardManager,
-		rangeSize:                 defaultRangeSize,
-		maxTransferSequenceNumber: 100000,
-		closeCh:                   s.shardClosedCh,
-		logger:                    s.logger,
+		shardInfo:             &persistence.ShardInfo{ShardID: shardID, RangeID: 1, TransferAckLevel: 0},
+		taskSequenceNumber:    1,
+		executionManager:      s.mockExecutionMgr,
+		historyMgr:            s.mockHistoryMgr,
+		shardManager:          s.mockShardManager,
+		rangeSize:             defaultRangeSize,
+		maxTaskSequenceNumber: 100000,
+		closeEvents:           s.shardEvents,
+		logger:                s.logger,
+		metricsClient:         metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:            common.NewRealTimeSource(),
 	}
 
 	historyCache := newHistoryCache(historyCacheMaxSize, mockShard, s.logger)
 	domainCache := cache.NewDomainCache(s.mockMetadataMgr, s.logger)
-	txProcessor := newTransferQueueProcessor(mockShard, s.mockVisibilityMgr, s.mockMatchingClient, s.mockHistoryClient, historyCache, domainCache)
+	txProcessor := newTransferQueueProcessor(mockShard, s.mockVisibilityMgr, s.mockMatchingClient, s.mockHistoryClient, historyCache, domainCache, nil, NewConfig())
 	h := &historyEngineImpl{
 		shard:              mockShard,
 		executionManager:   s.mockExecutionMgr,
 		historyMgr:         s.mockHistoryMgr,
 		txProcessor:        txProcessor,
+		matchingClient:     s.mockMatchingClient,
 		historyCache:       historyCache,
 		domainCache:        domainCache,
 		logger:             s.logger,
+		metricsClient:      metrics.NewClient(tally.NoopScope, metrics.History),
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+		queryRegistry:      newQueryRegistry(),
+		signalBatcher:      newSignalBatcher(0),
+		taskMetrics:        newTaskMetrics(mockShard.GetMetricsClient()),
+		config:             NewConfig(),
+		openExecutions:     newOpenExecutionCounter(s.mockMetadataMgr, s.logger),
 	}
 	h.timerProcessor = newTimerQueueProcessor(h, s.mockExecutionMgr, s.logger)
+	h.migrator = newWorkflowMigrator(mockShard, s.mockVisibilityMgr, historyCache, domainCache, nil, s.logger)
 	s.historyEngine = h
 }
 
@@ -138,9 +155,9 @@ func (s *engine2Suite) TestRecordDecisionTaskStartedIfNoExecution() {
 	identity := "testIdentity"
 	tl := "testTaskList"
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
 
-	response, err := s.historyEngine.RecordDecisionTaskStarted(&h.RecordDecisionTaskStartedRequest{
+	response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext, &h.RecordDecisionTaskStartedRequest{
 		WorkflowExecution: workflowExecution,
 		ScheduleId:        common.Int64Ptr(2),
 		TaskId:            common.Int64Ptr(100),
@@ -166,9 +183,9 @@ func (s *engine2Suite) TestRecordDecisionTaskStartedIfGetExecutionFailed() {
 	identity := "testIdentity"
 	tl := "testTaskList"
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, errors.New("FAILED")).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil, errors.New("FAILED")).Once()
 
-	response, err := s.historyEngine.RecordDecisionTaskStarted(&h.RecordDecisionTaskStartedRequest{
+	response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext, &h.RecordDecisionTaskStartedRequest{
 		WorkflowExecution: workflowExecution,
 		ScheduleId:        common.Int64Ptr(2),
 		TaskId:            common.Int64Ptr(100),
@@ -197,9 +214,9 @@ func (s *engine2Suite) TestRecordDecisionTaskStartedIfTaskAlreadyStarted() {
 	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 
-	response, err := s.historyEngine.RecordDecisionTaskStarted(&h.RecordDecisionTaskStartedRequest{
+	response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext, &h.RecordDecisionTaskStartedRequest{
 		WorkflowExecution: &workflowExecution,
 		ScheduleId:        common.Int64Ptr(2),
 		TaskId:            common.Int64Ptr(100),
@@ -232,9 +249,9 @@ func (s *engine2Suite) TestRecordDecisionTaskStartedIfTaskAlreadyCompleted() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 
-	response, err := s.historyEngine.RecordDecisionTaskStarted(&h.RecordDecisionTaskStartedRequest{
+	response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext, &h.RecordDecisionTaskStartedRequest{
 		WorkflowExecution: &workflowExecution,
 		ScheduleId:        common.Int64Ptr(2),
 		TaskId:            common.Int64Ptr(100),
@@ -266,18 +283,18 @@ func (s *engine2Suite) TestRecordDecisionTaskStartedConflictOnUpdate() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
 
 	ms2 := createMutableState(msBuilder)
 	gwmsResponse2 := &persistence.GetWorkflowExecutionResponse{State: ms2}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse2, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse2, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	response, err := s.historyEngine.RecordDecisionTaskStarted(&h.RecordDecisionTaskStartedRequest{
+	response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext, &h.RecordDecisionTaskStartedRequest{
 		WorkflowExecution: &workflowExecution,
 		ScheduleId:        common.Int64Ptr(2),
 		TaskId:            common.Int64Ptr(100),
@@ -310,16 +327,16 @@ func (s *engine2Suite) TestRecordDecisionTaskRetrySameRequest() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
 
 	startedEventID := addDecisionTaskStartedEventWithRequestID(msBuilder, int64(2), requestID, tl, identity)
 	ms2 := createMutableState(msBuilder)
 	gwmsResponse2 := &persistence.GetWorkflowExecutionResponse{State: ms2}
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse2, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse2, nil).Once()
 
-	response, err := s.historyEngine.RecordDecisionTaskStarted(&h.RecordDecisionTaskStartedRequest{
+	response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext, &h.RecordDecisionTaskStartedRequest{
 		WorkflowExecution: &workflowExecution,
 		ScheduleId:        common.Int64Ptr(2),
 		TaskId:            common.Int64Ptr(100),
@@ -352,17 +369,17 @@ func (s *engine2Suite) TestRecordDecisionTaskRetryDifferentRequest() {
 	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, false)
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
 
 	// Add event.
 	addDecisionTaskStartedEventWithRequestID(msBuilder, int64(2), "some_other_req", tl, identity)
 	ms2 := createMutableState(msBuilder)
 	gwmsResponse2 := &persistence.GetWorkflowExecutionResponse{State: ms2}
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse2, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse2, nil).Once()
 
-	response, err := s.historyEngine.RecordDecisionTaskStarted(&h.RecordDecisionTaskStartedRequest{
+	response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext, &h.RecordDecisionTaskStartedRequest{
 		WorkflowExecution: &workflowExecution,
 		ScheduleId:        common.Int64Ptr(2),
 		TaskId:            common.Int64Ptr(100),
@@ -395,15 +412,15 @@ func (s *engine2Suite) TestRecordDecisionTaskStartedMaxAttemptsExceeded() {
 		ms := createMutableState(msBuilder)
 		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 	}
 
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Times(
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Times(
 		conditionalRetryCount)
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(
 		&persistence.ConditionFailedError{}).Times(conditionalRetryCount)
 
-	response, err := s.historyEngine.RecordDecisionTaskStarted(&h.RecordDecisionTaskStartedRequest{
+	response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext, &h.RecordDecisionTaskStartedRequest{
 		WorkflowExecution: &workflowExecution,
 		ScheduleId:        common.Int64Ptr(2),
 		TaskId:            common.Int64Ptr(100),
@@ -433,11 +450,11 @@ func (s *engine2Suite) TestRecordDecisionTaskSuccess() {
 	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, false)
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	response, err := s.historyEngine.RecordDecisionTaskStarted(&h.RecordDecisionTaskStartedRequest{
+	response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext, &h.RecordDecisionTaskStartedRequest{
 		WorkflowExecution: &workflowExecution,
 		ScheduleId:        common.Int64Ptr(2),
 		TaskId:            common.Int64Ptr(100),
@@ -466,9 +483,9 @@ func (s *engine2Suite) TestRecordActivityTaskStartedIfNoExecution() {
 	identity := "testIdentity"
 	tl := "testTaskList"
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
 
-	response, err := s.historyEngine.RecordActivityTaskStarted(&h.RecordActivityTaskStartedRequest{
+	response, err := s.historyEngine.RecordActivityTaskStarted(s.callContext, &h.RecordActivityTaskStartedRequest{
 		WorkflowExecution: workflowExecution,
 		ScheduleId:        common.Int64Ptr(5),
 		TaskId:            common.Int64Ptr(100),
@@ -509,11 +526,11 @@ func (s *engine2Suite) TestRecordActivityTaskStartedSuccess() {
 	ms1 := createMutableState(msBuilder)
 	gwmsResponse1 := &persistence.GetWorkflowExecutionResponse{State: ms1}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	response, err := s.historyEngine.RecordActivityTaskStarted(&h.RecordActivityTaskStartedRequest{
+	response, err := s.historyEngine.RecordActivityTaskStarted(s.callContext, &h.RecordActivityTaskStartedRequest{
 		WorkflowExecution: &workflowExecution,
 		ScheduleId:        common.Int64Ptr(5),
 		TaskId:            common.Int64Ptr(100),
@@ -532,6 +549,135 @@ func (s *engine2Suite) TestRecordActivityTaskStartedSuccess() {
 	s.Equal("reqId", response.GetStartedEvent().GetActivityTaskStartedEventAttributes().GetRequestId())
 }
 
+func (s *engine2Suite) TestRecordActivityTaskStartedExternalCompletionTimeout() {
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+	s.historyEngine.config.ActivityExternalCompletionTimeout = 30 * time.Second
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	for _, activityID := range []string{"activity1_id", "activity2_id"} {
+		heartbeatTimeout := int32(0)
+		if activityID == "activity2_id" {
+			heartbeatTimeout = 5
+		}
+		msBuilder.AddActivityTaskScheduledEvent(decisionCompletedEvent.GetEventId(),
+			&workflow.ScheduleActivityTaskDecisionAttributes{
+				ActivityId:                    common.StringPtr(activityID),
+				ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+				TaskList:                      &workflow.TaskList{Name: common.StringPtr(tl)},
+				ScheduleToCloseTimeoutSeconds: common.Int32Ptr(1000),
+				ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+				StartToCloseTimeoutSeconds:    common.Int32Ptr(100),
+				HeartbeatTimeoutSeconds:       common.Int32Ptr(heartbeatTimeout),
+			}, nil)
+	}
+
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &persistence.GetWorkflowExecutionResponse{State: ms1}
+	var updates []*persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Twice()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Run(
+		func(arguments mock.Arguments) {
+			updates = append(updates, arguments.Get(1).(*persistence.UpdateWorkflowExecutionRequest))
+		}).Twice()
+
+	// The activity without heartbeats waits at most ActivityExternalCompletionTimeout for its completion, the one
+	// with heartbeats keeps its start-to-close timeout
+	for _, scheduleID := range []int64{5, 6} {
+		_, err := s.historyEngine.RecordActivityTaskStarted(s.callContext, &h.RecordActivityTaskStartedRequest{
+			WorkflowExecution: &workflowExecution,
+			ScheduleId:        common.Int64Ptr(scheduleID),
+			TaskId:            common.Int64Ptr(100),
+			RequestId:         common.StringPtr("reqId"),
+			PollRequest: &workflow.PollForActivityTaskRequest{
+				TaskList: &workflow.TaskList{Name: common.StringPtr(tl)},
+				Identity: common.StringPtr(identity),
+			},
+		})
+		s.Nil(err)
+	}
+	s.Equal(2, len(updates))
+	s.Equal(int32(30), updates[0].UpsertActivityInfos[0].StartToCloseTimeout)
+	s.Equal(int32(100), updates[1].UpsertActivityInfos[0].StartToCloseTimeout)
+}
+
+func (s *engine2Suite) TestRecordActivityTaskStartedWorkflowCompleted() {
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), "activity1_id", "activity_type1", tl,
+		[]byte("input1"), 100, 10, 5)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.State = persistence.WorkflowStateCompleted
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+
+	response, err := s.historyEngine.RecordActivityTaskStarted(s.callContext, &h.RecordActivityTaskStartedRequest{
+		WorkflowExecution: &workflowExecution,
+		ScheduleId:        common.Int64Ptr(5),
+		TaskId:            common.Int64Ptr(100),
+		RequestId:         common.StringPtr("reqId"),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: &workflow.TaskList{
+				Name: common.StringPtr(tl),
+			},
+			Identity: common.StringPtr(identity),
+		},
+	})
+	s.Nil(response)
+	s.Equal(&workflow.EntityNotExistsError{Message: "Workflow execution already completed."}, err)
+}
+
+func (s *engine2Suite) TestRecordActivityTaskStartedShardClosed() {
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), "activity1_id", "activity_type1", tl,
+		[]byte("input1"), 100, 10, 5)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: createMutableState(msBuilder)}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	// the shard was stolen by another host after the execution was cached
+	s.historyEngine.shard.(*shardContextImpl).isClosed = true
+
+	response, err := s.historyEngine.RecordActivityTaskStarted(s.callContext, &h.RecordActivityTaskStartedRequest{
+		WorkflowExecution: &workflowExecution,
+		ScheduleId:        common.Int64Ptr(5),
+		TaskId:            common.Int64Ptr(100),
+		RequestId:         common.StringPtr("reqId"),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: &workflow.TaskList{
+				Name: common.StringPtr(tl),
+			},
+			Identity: common.StringPtr(identity),
+		},
+	})
+	s.Nil(response)
+	s.IsType(&persistence.ShardOwnershipLostError{}, err)
+}
+
 func (s *engine2Suite) TestRequestCancelWorkflowExecutionSuccess() {
 	domainID := "domainId"
 	workflowExecution := workflow.WorkflowExecution{
@@ -546,11 +692,11 @@ func (s *engine2Suite) TestRequestCancelWorkflowExecutionSuccess() {
 	ms1 := createMutableState(msBuilder)
 	gwmsResponse1 := &persistence.GetWorkflowExecutionResponse{State: ms1}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.historyEngine.RequestCancelWorkflowExecution(&h.RequestCancelWorkflowExecutionRequest{
+	err := s.historyEngine.RequestCancelWorkflowExecution(s.callContext, &h.RequestCancelWorkflowExecutionRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CancelRequest: &workflow.RequestCancelWorkflowExecutionRequest{
 			WorkflowExecution: &workflow.WorkflowExecution{
@@ -581,9 +727,9 @@ func (s *engine2Suite) TestRequestCancelWorkflowExecutionFail() {
 	ms1 := createMutableState(msBuilder)
 	gwmsResponse1 := &persistence.GetWorkflowExecutionResponse{State: ms1}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
 
-	err := s.historyEngine.RequestCancelWorkflowExecution(&h.RequestCancelWorkflowExecutionRequest{
+	err := s.historyEngine.RequestCancelWorkflowExecution(s.callContext, &h.RequestCancelWorkflowExecutionRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CancelRequest: &workflow.RequestCancelWorkflowExecutionRequest{
 			WorkflowExecution: &workflow.WorkflowExecution{
@@ -597,6 +743,322 @@ func (s *engine2Suite) TestRequestCancelWorkflowExecutionFail() {
 	s.IsType(&workflow.EntityNotExistsError{}, err)
 }
 
+func (s *engine2Suite) TestStartWorkflowExecutionWithDelay() {
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: "domainId"},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.CreateWorkflowExecutionRequest) bool {
+			// The first decision is scheduled but not dispatched until the backoff timer fires, the execution is
+			// still recorded as open
+			if len(request.TransferTasks) != 1 || len(request.TimerTasks) != 1 ||
+				request.DecisionScheduleID == emptyEventID || request.DecisionStartedID != emptyEventID {
+				return false
+			}
+			if _, ok := request.TransferTasks[0].(*persistence.RecordWorkflowStartedTask); !ok {
+				return false
+			}
+			backoffTask, ok := request.TimerTasks[0].(*persistence.FirstDecisionBackoffTask)
+			if !ok || backoffTask.EventID != request.DecisionScheduleID {
+				return false
+			}
+			expiryTime, _ := DeconstructTimerKey(SequenceID(backoffTask.TaskID))
+			return expiryTime > time.Now().Add(time.Hour).UnixNano()
+		})).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+
+	resp, err := s.historyEngine.StartWorkflowExecution(s.callContext, &h.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr("domainId"),
+		StartRequest: &workflow.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr("domain"),
+			WorkflowId:                          common.StringPtr("wId"),
+			WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wType")},
+			TaskList:                            &workflow.TaskList{Name: common.StringPtr("testTaskList")},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(10000),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			Identity:                            common.StringPtr("testIdentity"),
+			DelayStartSeconds:                   common.Int32Ptr(7200),
+		},
+	})
+	s.Nil(err)
+	s.NotEmpty(resp.GetRunId())
+}
+
+func (s *engine2Suite) TestStartWorkflowExecutionDomainDraining() {
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: "domainId", Status: persistence.DomainStatusDraining},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+
+	_, err := s.historyEngine.StartWorkflowExecution(s.callContext, &h.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr("domainId"),
+		StartRequest: &workflow.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr("domain"),
+			WorkflowId:                          common.StringPtr("wId"),
+			WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wType")},
+			TaskList:                            &workflow.TaskList{Name: common.StringPtr("testTaskList")},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(10000),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			Identity:                            common.StringPtr("testIdentity"),
+		},
+	})
+	s.IsType(&workflow.DomainNotActiveError{}, err)
+}
+
+func (s *engine2Suite) TestStartWorkflowExecutionOpenExecutionLimit() {
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: "domainId"},
+		Config: &persistence.DomainConfig{Retention: 1, MaxOpenExecutions: 2},
+	}, nil).Once()
+	s.mockMetadataMgr.On("GetOpenExecutionCount", mock.Anything, &persistence.GetOpenExecutionCountRequest{
+		DomainID: "domainId",
+	}).Return(&persistence.GetOpenExecutionCountResponse{Count: 2}, nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything, mock.Anything).Return(
+		nil, &workflow.EntityNotExistsError{}).Once()
+
+	// the cap applies to the children started by running executions as well
+	_, err := s.historyEngine.StartWorkflowExecution(s.callContext, &h.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr("domainId"),
+		StartRequest: &workflow.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr("domain"),
+			WorkflowId:                          common.StringPtr("wId"),
+			WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wType")},
+			TaskList:                            &workflow.TaskList{Name: common.StringPtr("testTaskList")},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(10000),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			Identity:                            common.StringPtr("testIdentity"),
+			RequestId:                           common.StringPtr("requestId"),
+		},
+		ParentExecutionInfo: &h.ParentExecutionInfo{
+			DomainUUID: common.StringPtr("domainId"),
+			Execution: &workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr("parentId"),
+				RunId:      common.StringPtr("1f7a0a8e-7b1e-4d4e-9e2a-4c1d6c9b2f3a"),
+			},
+			InitiatedId: common.Int64Ptr(5),
+		},
+	})
+	s.IsType(&workflow.LimitExceededError{}, err)
+}
+
+func (s *engine2Suite) TestStartWorkflowExecutionOpenExecutionLimitDuplicate() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("2c8cd9c2-3a3b-4a4c-9d8e-0f1a2b3c4d5e"),
+	}
+	msBuilder := s.createExecutionStartedState(we, "testTaskList", "testIdentity", false)
+	msBuilder.executionInfo.CreateRequestID = "requestId"
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: "domainId"},
+		Config: &persistence.DomainConfig{Retention: 1, MaxOpenExecutions: 2},
+	}, nil).Once()
+	s.mockMetadataMgr.On("GetOpenExecutionCount", mock.Anything, mock.Anything).Return(
+		&persistence.GetOpenExecutionCountResponse{Count: 2}, nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything, mock.Anything).Return(
+		&persistence.GetCurrentExecutionResponse{RunID: we.GetRunId()}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: createMutableState(msBuilder)}, nil).Once()
+
+	// the execution started by an earlier attempt of the request is returned instead of the cap
+	resp, err := s.historyEngine.StartWorkflowExecution(s.callContext, &h.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr("domainId"),
+		StartRequest: &workflow.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr("domain"),
+			WorkflowId:                          common.StringPtr("wId"),
+			WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wType")},
+			TaskList:                            &workflow.TaskList{Name: common.StringPtr("testTaskList")},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(10000),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			Identity:                            common.StringPtr("testIdentity"),
+			RequestId:                           common.StringPtr("requestId"),
+		},
+	})
+	s.Nil(err)
+	s.Equal(we.GetRunId(), resp.GetRunId())
+}
+
+func (s *engine2Suite) TestMigrateWorkflowExecutionRunning() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("2c8cd9c2-3a3b-4a4c-9d8e-0f1a2b3c4d5e"),
+	}
+	msBuilder := s.createExecutionStartedState(we, "testTaskList", "testIdentity", false)
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: "targetDomainId"},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: createMutableState(msBuilder)}, nil).Once()
+
+	err := s.historyEngine.MigrateWorkflowExecution(s.callContext, &h.MigrateWorkflowExecutionRequest{
+		DomainUUID:       common.StringPtr("domainId"),
+		TargetDomainUUID: common.StringPtr("targetDomainId"),
+		MigrateRequest:   &workflow.MigrateWorkflowExecutionRequest{WorkflowExecution: &we},
+	})
+	s.Equal(errMigrateRunningExecution, err)
+}
+
+func (s *engine2Suite) TestMigrateWorkflowExecution() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("2c8cd9c2-3a3b-4a4c-9d8e-0f1a2b3c4d5e"),
+	}
+	msBuilder := newMutableStateBuilder(s.logger)
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", nil, 100, 10, "testIdentity")
+	_, di := addDecisionTaskScheduledEvent(msBuilder)
+	startedEvent := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, "testTaskList", "testIdentity")
+	completedEvent := addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, startedEvent.GetEventId(), nil,
+		"testIdentity")
+	addCompleteWorkflowEvent(msBuilder, completedEvent.GetEventId(), nil)
+	serializedHistory, err := msBuilder.hBuilder.Serialize()
+	s.Nil(err)
+	startTime := time.Now().Add(-time.Hour)
+	closeTime := startTime.Add(time.Minute)
+	msBuilder.executionInfo.StartTimestamp = startTime
+	msBuilder.executionInfo.LastUpdatedTimestamp = time.Now()
+
+	isSource := func(domainID string) bool { return domainID == "domainId" }
+	isTarget := func(domainID string) bool { return domainID == "targetDomainId" }
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: "targetDomainId"},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.GetWorkflowExecutionRequest) bool { return isSource(request.DomainID) })).Return(
+		&persistence.GetWorkflowExecutionResponse{State: createMutableState(msBuilder)}, nil).Once()
+	s.mockVisibilityMgr.On("ListClosedWorkflowExecutionsByWorkflowID", mock.Anything, mock.Anything).Return(
+		&persistence.ListWorkflowExecutionsResponse{Executions: []*workflow.WorkflowExecutionInfo{{
+			Execution: &we,
+			StartTime: common.Int64Ptr(startTime.UnixNano()),
+			CloseTime: common.Int64Ptr(closeTime.UnixNano()),
+		}}}, nil).Once()
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything, mock.MatchedBy(
+		func(request *persistence.GetWorkflowExecutionHistoryRequest) bool { return isSource(request.DomainID) })).Return(
+		&persistence.GetWorkflowExecutionHistoryResponse{
+			Events: []persistence.SerializedHistoryEventBatch{*serializedHistory},
+		}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.MatchedBy(
+		func(request *persistence.AppendHistoryEventsRequest) bool {
+			return isTarget(request.DomainID) && request.FirstEventID == common.FirstEventID
+		})).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.GetWorkflowExecutionRequest) bool { return isTarget(request.DomainID) })).Return(
+		nil, &workflow.EntityNotExistsError{}).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.CreateWorkflowExecutionRequest) bool {
+			return isTarget(request.DomainID) && request.NextEventID == msBuilder.GetNextEventID()
+		})).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+			if !isTarget(request.ExecutionInfo.DomainID) || !request.CloseExecution || len(request.TimerTasks) != 1 {
+				return false
+			}
+			// the retention of the target domain starts from the original close time
+			expiryTime, _ := DeconstructTimerKey(SequenceID(request.TimerTasks[0].GetTaskID()))
+			return expiryTime == closeTime.Add(24*time.Hour).UnixNano()&TimerQueueTimeStampBitmask
+		})).Return(nil).Once()
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionClosed", mock.Anything, mock.MatchedBy(
+		func(request *persistence.RecordWorkflowExecutionClosedRequest) bool {
+			return isTarget(request.DomainUUID) && request.CloseTimestamp == closeTime.UnixNano() &&
+				request.Status == workflow.WorkflowExecutionCloseStatus_COMPLETED
+		})).Return(nil).Once()
+	s.mockVisibilityMgr.On("DeleteClosedWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.DeleteClosedWorkflowExecutionRequest) bool {
+			return isSource(request.DomainUUID) && request.CloseTimestamp == closeTime.UnixNano()
+		})).Return(nil).Once()
+	s.mockHistoryMgr.On("DeleteWorkflowExecutionHistory", mock.Anything, mock.MatchedBy(
+		func(request *persistence.DeleteWorkflowExecutionHistoryRequest) bool {
+			return isSource(request.DomainID)
+		})).
+		Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.DeleteWorkflowExecutionRequest) bool {
+			return isSource(request.ExecutionInfo.DomainID)
+		})).Return(nil).Once()
+
+	err = s.historyEngine.MigrateWorkflowExecution(s.callContext, &h.MigrateWorkflowExecutionRequest{
+		DomainUUID:       common.StringPtr("domainId"),
+		TargetDomainUUID: common.StringPtr("targetDomainId"),
+		MigrateRequest:   &workflow.MigrateWorkflowExecutionRequest{WorkflowExecution: &we},
+	})
+	s.Nil(err)
+}
+
+func (s *engine2Suite) TestQueryWorkflowWithQueryTask() {
+	domainID := "domainId"
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	addDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	// the history is only read, neither events nor a decision task are added for the query
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+
+	isQueryTask := func(request *m.AddDecisionTaskRequest) bool {
+		return request.GetQueryId() != "" && request.GetScheduleId() == emptyEventID &&
+			request.GetTaskList().GetName() == tl
+	}
+	s.mockMatchingClient.On("AddDecisionTask", mock.Anything, mock.MatchedBy(isQueryTask)).Run(
+		func(args mock.Arguments) {
+			// the worker polls for the query task and answers it
+			request := args.Get(1).(*m.AddDecisionTaskRequest)
+			response, err := s.historyEngine.RecordDecisionTaskStarted(s.callContext,
+				&h.RecordDecisionTaskStartedRequest{
+					DomainUUID:        common.StringPtr(domainID),
+					WorkflowExecution: &workflowExecution,
+					ScheduleId:        common.Int64Ptr(emptyEventID),
+					RequestId:         common.StringPtr("reqId"),
+					QueryId:           request.QueryId,
+				})
+			s.NoError(err)
+			s.Equal("wType", response.GetWorkflowType().GetName())
+			s.Equal(int64(3), response.GetPreviousStartedEventId())
+			s.Equal(int64(4), response.GetStartedEventId())
+			s.Equal("state", response.GetQueries()[request.GetQueryId()].GetQueryType())
+
+			taskToken, _ := json.Marshal(&common.TaskToken{
+				WorkflowID: "wId",
+				RunID:      "rId",
+				ScheduleID: emptyEventID,
+				QueryID:    request.GetQueryId(),
+			})
+			_, err = s.historyEngine.RespondDecisionTaskCompleted(s.callContext, &h.RespondDecisionTaskCompletedRequest{
+				DomainUUID: common.StringPtr(domainID),
+				CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+					TaskToken: taskToken,
+					QueryResults: map[string]*workflow.WorkflowQueryAnswer{
+						request.GetQueryId(): {
+							ResultType: common.QueryResultTypePtr(workflow.QueryResultType_ANSWERED),
+							Answer:     []byte("answer"),
+						},
+					},
+					Identity: common.StringPtr(identity),
+				},
+			})
+			s.NoError(err)
+		}).Return(nil).Once()
+
+	response, err := s.historyEngine.QueryWorkflow(s.callContext, &h.QueryWorkflowRequest{
+		DomainUUID: common.StringPtr(domainID),
+		QueryRequest: &workflow.QueryWorkflowRequest{
+			Execution: &workflowExecution,
+			Query:     &workflow.WorkflowQuery{QueryType: common.StringPtr("state")},
+		},
+	})
+	s.NoError(err)
+	s.Equal([]byte("answer"), response.GetQueryAnswer())
+	s.mockMatchingClient.AssertExpectations(s.T())
+}
+
 func (s *engine2Suite) createExecutionStartedState(we workflow.WorkflowExecution, tl, identity string,
 	startDecision bool) *mutableStateBuilder {
 	msBuilder := newMutableStateBuilder(s.logger)
@@ -619,6 +1081,41 @@ func (s *engine2Suite) printHistory(builder *mutableStateBuilder) string {
 	return history.String()
 }
 
+func (s *engine2Suite) TestRedriveTransferDLQTasks() {
+	task1 := &persistence.TransferTaskInfo{TaskID: 5, TaskType: persistence.TransferTaskTypeDecisionTask}
+	task2 := &persistence.TransferTaskInfo{TaskID: 7, TaskType: persistence.TransferTaskTypeActivityTask}
+	s.mockExecutionMgr.On("GetTransferDLQTasks", mock.Anything, &persistence.GetTransferDLQTasksRequest{
+		ReadLevel: 0,
+		BatchSize: transferDLQRedriveBatchSize,
+	}).Return(&persistence.GetTransferDLQTasksResponse{Tasks: []*persistence.TransferTaskInfo{task1, task2}}, nil)
+
+	var newTaskIDs []int64
+	s.mockExecutionMgr.On("RedriveTransferDLQTask", mock.Anything, mock.Anything).Return(nil).Run(
+		func(args mock.Arguments) {
+			request := args.Get(1).(*persistence.RedriveTransferDLQTaskRequest)
+			s.Equal(int64(1), request.RangeID)
+			newTaskIDs = append(newTaskIDs, request.NewTaskID)
+		})
+
+	// The maximum task count stops the redrive after the oldest task
+	response, err := s.historyEngine.RedriveTransferDLQTasks(s.callContext,
+		&h.RedriveTransferDLQTasksRequest{ShardId: common.Int32Ptr(0), MaximumTaskCount: common.Int32Ptr(1)})
+	s.Nil(err)
+	s.Equal(int32(1), response.GetRedrivenTaskCount())
+	s.mockExecutionMgr.AssertCalled(s.T(), "RedriveTransferDLQTask", mock.Anything,
+		&persistence.RedriveTransferDLQTaskRequest{TaskInfo: task1, NewTaskID: newTaskIDs[0], RangeID: 1})
+
+	response, err = s.historyEngine.RedriveTransferDLQTasks(s.callContext,
+		&h.RedriveTransferDLQTasksRequest{ShardId: common.Int32Ptr(0)})
+	s.Nil(err)
+	s.Equal(int32(2), response.GetRedrivenTaskCount())
+
+	// Redriven tasks get new IDs, in the order they were parked in, up to which the transfer queue is read
+	s.Equal(3, len(newTaskIDs))
+	s.True(newTaskIDs[0] < newTaskIDs[1] && newTaskIDs[1] < newTaskIDs[2])
+	s.Equal(newTaskIDs[2], s.historyEngine.shard.GetTransferMaxReadLevel())
+}
+
 func (s *engine2Suite) TestRespondDecisionTaskCompletedRecordMarkerDecision() {
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
@@ -651,11 +1148,11 @@ func (s *engine2Suite) TestRespondDecisionTaskCompletedRecordMarkerDecision() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.historyEngine.RespondDecisionTaskCompleted(&h.RespondDecisionTaskCompletedRequest{
+	_, err := s.historyEngine.RespondDecisionTaskCompleted(s.callContext, &h.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -673,7 +1170,7 @@ func (s *engine2Suite) TestRespondDecisionTaskCompletedRecordMarkerDecision() {
 }
 
 func (s *engine2Suite) getBuilder(domainID string, we workflow.WorkflowExecution) *mutableStateBuilder {
-	context, release, err := s.historyEngine.historyCache.getOrCreateWorkflowExecution(domainID, we)
+	context, release, err := s.historyEngine.historyCache.getOrCreateWorkflowExecution(s.callContext, domainID, we)
 	if err != nil {
 		return nil
 	}
@@ -24,6 +24,7 @@ import (
 	h "github.com/uber/cadence/.gen/go/history"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/tchannel-go/thrift"
 )
 
 type (
@@ -31,22 +32,36 @@ type (
 	Engine interface {
 		common.Daemon
 		// TODO: Convert workflow.WorkflowExecution to pointer all over the place
-		StartWorkflowExecution(request *h.StartWorkflowExecutionRequest) (*workflow.StartWorkflowExecutionResponse,
+		StartWorkflowExecution(ctx thrift.Context, request *h.StartWorkflowExecutionRequest) (*workflow.StartWorkflowExecutionResponse,
 			error)
 		GetWorkflowExecutionNextEventID(
-			request *h.GetWorkflowExecutionNextEventIDRequest) (*h.GetWorkflowExecutionNextEventIDResponse, error)
-		RecordDecisionTaskStarted(request *h.RecordDecisionTaskStartedRequest) (*h.RecordDecisionTaskStartedResponse, error)
-		RecordActivityTaskStarted(request *h.RecordActivityTaskStartedRequest) (*h.RecordActivityTaskStartedResponse, error)
-		RespondDecisionTaskCompleted(request *h.RespondDecisionTaskCompletedRequest) error
-		RespondActivityTaskCompleted(request *h.RespondActivityTaskCompletedRequest) error
-		RespondActivityTaskFailed(request *h.RespondActivityTaskFailedRequest) error
-		RespondActivityTaskCanceled(request *h.RespondActivityTaskCanceledRequest) error
-		RecordActivityTaskHeartbeat(request *h.RecordActivityTaskHeartbeatRequest) (*workflow.RecordActivityTaskHeartbeatResponse, error)
-		RequestCancelWorkflowExecution(request *h.RequestCancelWorkflowExecutionRequest) error
-		SignalWorkflowExecution(request *h.SignalWorkflowExecutionRequest) error
-		TerminateWorkflowExecution(request *h.TerminateWorkflowExecutionRequest) error
-		ScheduleDecisionTask(request *h.ScheduleDecisionTaskRequest) error
-		RecordChildExecutionCompleted(request *h.RecordChildExecutionCompletedRequest) error
+			ctx thrift.Context, request *h.GetWorkflowExecutionNextEventIDRequest) (*h.GetWorkflowExecutionNextEventIDResponse, error)
+		RecordDecisionTaskStarted(ctx thrift.Context, request *h.RecordDecisionTaskStartedRequest) (*h.RecordDecisionTaskStartedResponse, error)
+		RecordActivityTaskStarted(ctx thrift.Context, request *h.RecordActivityTaskStartedRequest) (*h.RecordActivityTaskStartedResponse, error)
+		RespondDecisionTaskCompleted(ctx thrift.Context, request *h.RespondDecisionTaskCompletedRequest) (
+			*workflow.RespondDecisionTaskCompletedResponse, error)
+		RespondActivityTaskCompleted(ctx thrift.Context, request *h.RespondActivityTaskCompletedRequest) error
+		RespondActivityTaskFailed(ctx thrift.Context, request *h.RespondActivityTaskFailedRequest) error
+		RespondActivityTaskCanceled(ctx thrift.Context, request *h.RespondActivityTaskCanceledRequest) error
+		RecordActivityTaskHeartbeat(ctx thrift.Context, request *h.RecordActivityTaskHeartbeatRequest) (*workflow.RecordActivityTaskHeartbeatResponse, error)
+		RequestCancelWorkflowExecution(ctx thrift.Context, request *h.RequestCancelWorkflowExecutionRequest) error
+		SignalWorkflowExecution(ctx thrift.Context, request *h.SignalWorkflowExecutionRequest) (
+			*workflow.SignalWorkflowExecutionResponse, error)
+		GetSignalReceipt(ctx thrift.Context, request *h.GetSignalReceiptRequest) (*workflow.GetSignalReceiptResponse, error)
+		QueryWorkflow(ctx thrift.Context, request *h.QueryWorkflowRequest) (*workflow.QueryWorkflowResponse, error)
+		DescribeWorkflowExecution(ctx thrift.Context,
+			request *h.DescribeWorkflowExecutionRequest) (*workflow.DescribeWorkflowExecutionResponse, error)
+		TerminateWorkflowExecution(ctx thrift.Context, request *h.TerminateWorkflowExecutionRequest) (
+			*workflow.TerminateWorkflowExecutionResponse, error)
+		ScheduleDecisionTask(ctx thrift.Context, request *h.ScheduleDecisionTaskRequest) error
+		RecordChildExecutionCompleted(ctx thrift.Context, request *h.RecordChildExecutionCompletedRequest) error
+		UpdateQueueProcessing(ctx thrift.Context, request *h.UpdateQueueProcessingRequest) error
+		DescribeMutableState(ctx thrift.Context, request *h.DescribeMutableStateRequest) (*h.DescribeMutableStateResponse,
+			error)
+		RefreshWorkflowTasks(ctx thrift.Context, request *h.RefreshWorkflowTasksRequest) error
+		MigrateWorkflowExecution(ctx thrift.Context, request *h.MigrateWorkflowExecutionRequest) error
+		RedriveTransferDLQTasks(ctx thrift.Context, request *h.RedriveTransferDLQTasksRequest) (
+			*h.RedriveTransferDLQTasksResponse, error)
 	}
 
 	// EngineFactory is used to create an instance of sharded history engine
@@ -62,10 +77,14 @@ type (
 	transferQueueProcessor interface {
 		common.Daemon
 		NotifyNewTask()
+		Pause()
+		Resume()
 	}
 
 	timerQueueProcessor interface {
 		common.Daemon
 		NotifyNewTimer(taskID int64)
+		Pause()
+		Resume()
 	}
 )
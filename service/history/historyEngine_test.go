@@ -23,7 +23,9 @@ package history
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"sync/atomic"
 	"testing"
 
 	log "github.com/Sirupsen/logrus"
@@ -32,6 +34,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	"github.com/uber/tchannel-go/thrift"
 
 	"github.com/uber-go/tally"
 	"github.com/uber/cadence/.gen/go/history"
@@ -57,9 +60,10 @@ type (
 		mockExecutionMgr   *mocks.ExecutionManager
 		mockHistoryMgr     *mocks.HistoryManager
 		mockShardManager   *mocks.ShardManager
-		shardClosedCh      chan int
+		shardEvents        *shardEventBus
 		eventSerializer    historyEventSerializer
 		logger             bark.Logger
+		callContext        thrift.Context
 	}
 )
 
@@ -74,6 +78,7 @@ func (s *engineSuite) SetupSuite() {
 	}
 
 	s.logger = bark.NewLoggerFromLogrus(log.New())
+	s.callContext = common.BackgroundThriftContext()
 }
 
 func (s *engineSuite) TearDownSuite() {
@@ -92,35 +97,42 @@ func (s *engineSuite) SetupTest() {
 	s.mockExecutionMgr = &mocks.ExecutionManager{}
 	s.mockHistoryMgr = &mocks.HistoryManager{}
 	s.mockShardManager = &mocks.ShardManager{}
-	s.shardClosedCh = make(chan int, 100)
+	s.shardEvents = newShardEventBus()
 	s.eventSerializer = newJSONHistoryEventSerializer()
 
 	mockShard := &shardContextImpl{
-		shardInfo:                 &persistence.ShardInfo{ShardID: shardID, RangeID: 1, TransferAckLevel: 0},
-		transferSequenceNumber:    1,
-		executionManager:          s.mockExecutionMgr,
-		historyMgr:                s.mockHistoryMgr,
-		shardManager:              s.mockShardManager,
-		rangeSize:                 defaultRangeSize,
-		maxTransferSequenceNumber: 100000,
-		closeCh:                   s.shardClosedCh,
-		logger:                    s.logger,
+		shardInfo:             &persistence.ShardInfo{ShardID: shardID, RangeID: 1, TransferAckLevel: 0},
+		taskSequenceNumber:    1,
+		executionManager:      s.mockExecutionMgr,
+		historyMgr:            s.mockHistoryMgr,
+		shardManager:          s.mockShardManager,
+		rangeSize:             defaultRangeSize,
+		maxTaskSequenceNumber: 100000,
+		closeEvents:           s.shardEvents,
+		logger:                s.logger,
+		metricsClient:         metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:            common.NewRealTimeSource(),
 	}
 
 	historyCache := newHistoryCache(historyCacheMaxSize, mockShard, s.logger)
 	domainCache := cache.NewDomainCache(s.mockMetadataMgr, s.logger)
-	txProcessor := newTransferQueueProcessor(mockShard, s.mockVisibilityMgr, s.mockMatchingClient, s.mockHistoryClient, historyCache, domainCache)
+	txProcessor := newTransferQueueProcessor(mockShard, s.mockVisibilityMgr, s.mockMatchingClient, s.mockHistoryClient, historyCache, domainCache, nil, NewConfig())
 	h := &historyEngineImpl{
 		shard:              mockShard,
 		executionManager:   s.mockExecutionMgr,
 		historyMgr:         s.mockHistoryMgr,
 		txProcessor:        txProcessor,
+		historyClient:      s.mockHistoryClient,
 		historyCache:       historyCache,
 		domainCache:        domainCache,
 		logger:             s.logger,
 		metricsClient:      metrics.NewClient(tally.NewTestScope("", nil), metrics.History),
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+		queryRegistry:      newQueryRegistry(),
+		signalBatcher:      newSignalBatcher(0),
+		taskMetrics:        newTaskMetrics(mockShard.GetMetricsClient()),
+		config:             NewConfig(),
 	}
 	h.timerProcessor = newTimerQueueProcessor(h, s.mockExecutionMgr, s.logger)
 	s.mockHistoryEngine = h
@@ -139,7 +151,7 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedInvalidToken() {
 	invalidToken, _ := json.Marshal("bad token")
 	identity := "testIdentity"
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        invalidToken,
@@ -162,9 +174,9 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedIfNoExecution() {
 	})
 	identity := "testIdentity"
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken: taskToken,
@@ -184,9 +196,9 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedIfGetExecutionFailed() {
 	})
 	identity := "testIdentity"
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, errors.New("FAILED")).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil, errors.New("FAILED")).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken: taskToken,
@@ -219,12 +231,12 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedUpdateExecutionFailed() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(errors.New("FAILED")).Once()
-	s.mockShardManager.On("UpdateShard", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(errors.New("FAILED")).Once()
+	s.mockShardManager.On("AllocateTaskIDs", mock.Anything, mock.Anything).Return(newAllocateTaskIDsResponse(2), nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken: taskToken,
@@ -258,9 +270,9 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedIfTaskCompleted() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken: taskToken,
@@ -292,9 +304,9 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedIfTaskNotStarted() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken: taskToken,
@@ -371,16 +383,20 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedConflictOnUpdate() {
 	ms2 := createMutableState(msBuilder)
 	gwmsResponse2 := &persistence.GetWorkflowExecutionResponse{State: ms2}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(
 		&persistence.ConditionFailedError{}).Once()
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse2, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse2, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -395,10 +411,11 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedConflictOnUpdate() {
 	s.Equal(context, ms2.ExecutionInfo.ExecutionContext)
 
 	executionBuilder := s.getBuilder(domainID, we)
-	activity3Attributes := s.getActivityScheduledEvent(executionBuilder, 14).GetActivityTaskScheduledEventAttributes()
+	// The completion of activity2 came in during the decision, it is flushed after the events of the decision
+	activity3Attributes := s.getActivityScheduledEvent(executionBuilder, 13).GetActivityTaskScheduledEventAttributes()
 	s.Equal(activity3ID, activity3Attributes.GetActivityId())
 	s.Equal(activity3Type, activity3Attributes.GetActivityType().GetName())
-	s.Equal(int64(13), activity3Attributes.GetDecisionTaskCompletedEventId())
+	s.Equal(int64(12), activity3Attributes.GetDecisionTaskCompletedEventId())
 	s.Equal(tl, activity3Attributes.GetTaskList().GetName())
 	s.Equal(activity3Input, activity3Attributes.GetInput())
 	s.Equal(int32(100), activity3Attributes.GetScheduleToCloseTimeoutSeconds())
@@ -446,17 +463,21 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedMaxAttemptsExceeded() {
 		},
 	}}
 
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
 	for i := 0; i < conditionalRetryCount; i++ {
 		ms := createMutableState(msBuilder)
 		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-		s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+		s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(
 			&persistence.ConditionFailedError{}).Once()
 	}
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -523,13 +544,17 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedCompleteWorkflowFailed() {
 	for i := 0; i < 2; i++ {
 		ms := createMutableState(msBuilder)
 		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 	}
 
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -603,13 +628,17 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedFailWorkflowFailed() {
 	for i := 0; i < 2; i++ {
 		ms := createMutableState(msBuilder)
 		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 	}
 
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -669,13 +698,17 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedBadDecisionAttributes() {
 	for i := 0; i < 2; i++ {
 		ms := createMutableState(msBuilder)
 		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 	}
 
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -688,7 +721,7 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedBadDecisionAttributes() {
 	s.IsType(&workflow.BadRequestError{}, err)
 }
 
-func (s *engineSuite) TestRespondDecisionTaskCompletedSingleActivityScheduledDecision() {
+func (s *engineSuite) TestRespondDecisionTaskCompletedFailedDecisionRetryBackoff() {
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
@@ -701,21 +734,18 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedSingleActivityScheduledDec
 		ScheduleID: 2,
 	})
 	identity := "testIdentity"
-	context := []byte("context")
-	input := []byte("input")
 
 	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
 	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
 	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
 	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
 
+	// Decision with missing activity id
 	decisions := []*workflow.Decision{{
 		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_ScheduleActivityTask),
 		ScheduleActivityTaskDecisionAttributes: &workflow.ScheduleActivityTaskDecisionAttributes{
-			ActivityId:   common.StringPtr("activity1"),
-			ActivityType: &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
-			TaskList:     &workflow.TaskList{Name: &tl},
-			Input:        input,
+			ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+			TaskList:                      &workflow.TaskList{Name: &tl},
 			ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
 			StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
@@ -723,57 +753,218 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedSingleActivityScheduledDec
 		},
 	}}
 
-	ms := createMutableState(msBuilder)
-	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	for i := 0; i < 2; i++ {
+		ms := createMutableState(msBuilder)
+		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			updateRequest = args.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+		})
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
-			TaskToken:        taskToken,
-			Decisions:        decisions,
-			ExecutionContext: context,
-			Identity:         &identity,
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
 		},
 	})
-	s.Nil(err, s.printHistory(msBuilder))
+	s.NotNil(err)
+	s.IsType(&workflow.BadRequestError{}, err)
 	executionBuilder := s.getBuilder(domainID, we)
-	s.Equal(int64(6), executionBuilder.executionInfo.NextEventID)
-	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
-	s.Equal(context, executionBuilder.executionInfo.ExecutionContext)
-	s.Equal(persistence.WorkflowStateRunning, executionBuilder.executionInfo.State)
-	s.False(executionBuilder.HasPendingDecisionTask())
+	s.Equal(int64(1), executionBuilder.executionInfo.DecisionAttempt)
+	s.True(executionBuilder.HasPendingDecisionTask())
+	di, ok := executionBuilder.GetPendingDecision(int64(5))
+	s.True(ok)
+	s.Equal(int64(1), di.Attempt)
 
-	activity1Attributes := s.getActivityScheduledEvent(executionBuilder, int64(5)).GetActivityTaskScheduledEventAttributes()
-	s.Equal("activity1", activity1Attributes.GetActivityId())
-	s.Equal("activity_type1", activity1Attributes.GetActivityType().GetName())
-	s.Equal(int64(4), activity1Attributes.GetDecisionTaskCompletedEventId())
-	s.Equal(tl, activity1Attributes.GetTaskList().GetName())
-	s.Equal(input, activity1Attributes.GetInput())
-	s.Equal(int32(100), activity1Attributes.GetScheduleToCloseTimeoutSeconds())
-	s.Equal(int32(10), activity1Attributes.GetScheduleToStartTimeoutSeconds())
-	s.Equal(int32(50), activity1Attributes.GetStartToCloseTimeoutSeconds())
-	s.Equal(int32(5), activity1Attributes.GetHeartbeatTimeoutSeconds())
+	// Retried decision is dispatched by a backoff timer instead of a transfer task
+	s.NotNil(updateRequest)
+	for _, task := range updateRequest.TransferTasks {
+		s.NotEqual(persistence.TransferTaskTypeDecisionTask, task.GetType())
+	}
+	s.Equal(1, len(updateRequest.TimerTasks))
+	backoffTask, ok := updateRequest.TimerTasks[0].(*persistence.DecisionRetryBackoffTask)
+	s.True(ok)
+	s.Equal(int64(5), backoffTask.EventID)
 }
 
-func (s *engineSuite) TestRespondDecisionTaskCompletedCompleteWorkflowSuccess() {
+func (s *engineSuite) TestRespondDecisionTaskCompletedDuplicateActivityID() {
+	tl := "testTaskList"
+	attributes := &workflow.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:                    common.StringPtr("activity1"),
+		ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+		TaskList:                      &workflow.TaskList{Name: &tl},
+		ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+		ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+		StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+		HeartbeatTimeoutSeconds:       common.Int32Ptr(5),
+	}
+	decisions := []*workflow.Decision{
+		{
+			DecisionType:                           workflow.DecisionTypePtr(workflow.DecisionType_ScheduleActivityTask),
+			ScheduleActivityTaskDecisionAttributes: attributes,
+		},
+		{
+			DecisionType:                           workflow.DecisionTypePtr(workflow.DecisionType_ScheduleActivityTask),
+			ScheduleActivityTaskDecisionAttributes: attributes,
+		},
+	}
+
+	cause, err := s.respondDecisionTaskCompletedExpectFailure(decisions, nil)
+	s.IsType(&workflow.BadRequestError{}, err)
+	s.Equal(workflow.DecisionTaskFailedCause_SCHEDULE_ACTIVITY_DUPLICATE_ID, cause)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedDuplicateTimerID() {
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_StartTimer),
+		StartTimerDecisionAttributes: &workflow.StartTimerDecisionAttributes{
+			TimerId:                   common.StringPtr("timer1"),
+			StartToFireTimeoutSeconds: common.Int64Ptr(10),
+		},
+	}}
+
+	cause, err := s.respondDecisionTaskCompletedExpectFailure(decisions, func(msBuilder *mutableStateBuilder) {
+		addTimerStartedEvent(msBuilder, emptyEventID, "timer1", 10)
+	})
+	s.IsType(&workflow.BadRequestError{}, err)
+	s.Equal(workflow.DecisionTaskFailedCause_START_TIMER_DUPLICATE_ID, cause)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedBadStartChildAttributes() {
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_StartChildWorkflowExecution),
+		StartChildWorkflowExecutionDecisionAttributes: &workflow.StartChildWorkflowExecutionDecisionAttributes{
+			WorkflowType: &workflow.WorkflowType{Name: common.StringPtr("child_type")},
+		},
+	}}
+
+	cause, err := s.respondDecisionTaskCompletedExpectFailure(decisions, nil)
+	s.IsType(&workflow.BadRequestError{}, err)
+	s.Equal(workflow.DecisionTaskFailedCause_BAD_START_CHILD_EXECUTION_ATTRIBUTES, cause)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedTooManyDecisions() {
+	s.mockHistoryEngine.config.MaxDecisionsPerCompletion = 2
+	var decisions []*workflow.Decision
+	for i := 0; i < 3; i++ {
+		decisions = append(decisions, &workflow.Decision{
+			DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_RecordMarker),
+			RecordMarkerDecisionAttributes: &workflow.RecordMarkerDecisionAttributes{
+				MarkerName: common.StringPtr("marker"),
+			},
+		})
+	}
+
+	cause, err := s.respondDecisionTaskCompletedExpectFailure(decisions, nil)
+	s.IsType(&workflow.BadRequestError{}, err)
+	s.Equal(workflow.DecisionTaskFailedCause_TOO_MANY_DECISIONS, cause)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedTooManyPendingTimers() {
+	s.mockHistoryEngine.config.MaxPendingTimersPerExecution = 2
+	var decisions []*workflow.Decision
+	for i := 0; i < 3; i++ {
+		decisions = append(decisions, &workflow.Decision{
+			DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_StartTimer),
+			StartTimerDecisionAttributes: &workflow.StartTimerDecisionAttributes{
+				TimerId:                   common.StringPtr(fmt.Sprintf("timer%v", i)),
+				StartToFireTimeoutSeconds: common.Int64Ptr(10),
+			},
+		})
+	}
+
+	cause, err := s.respondDecisionTaskCompletedExpectFailure(decisions, nil)
+	s.IsType(&workflow.BadRequestError{}, err)
+	s.Equal(workflow.DecisionTaskFailedCause_TOO_MANY_PENDING_TIMERS, cause)
+}
+
+// respondDecisionTaskCompletedExpectFailure completes a started decision task of a new execution with the decisions
+// and returns the cause of the DecisionTaskFailed event it recorded along with the error
+func (s *engineSuite) respondDecisionTaskCompletedExpectFailure(decisions []*workflow.Decision,
+	setup func(msBuilder *mutableStateBuilder)) (workflow.DecisionTaskFailedCause, error) {
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
 		RunId:      common.StringPtr("rId"),
 	}
 	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	if setup != nil {
+		setup(msBuilder)
+	}
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
 	taskToken, _ := json.Marshal(&common.TaskToken{
 		WorkflowID: we.GetWorkflowId(),
 		RunID:      we.GetRunId(),
+		ScheduleID: scheduleEvent.GetEventId(),
+	})
+
+	for i := 0; i < 2; i++ {
+		ms := createMutableState(msBuilder)
+		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	}
+	var appendRequest *persistence.AppendHistoryEventsRequest
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			appendRequest = args.Get(1).(*persistence.AppendHistoryEventsRequest)
+		})
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
+		},
+	})
+	s.NotNil(appendRequest)
+	serializer, _ := persistence.NewHistorySerializerFactory().Get(appendRequest.Events.EncodingType)
+	batch, err1 := serializer.Deserialize(appendRequest.Events)
+	s.Nil(err1)
+	for _, event := range batch.Events {
+		if event.GetEventType() == workflow.EventType_DecisionTaskFailed {
+			return event.GetDecisionTaskFailedEventAttributes().GetCause(), err
+		}
+	}
+	s.Fail("DecisionTaskFailed event not recorded")
+	return workflow.DecisionTaskFailedCause(-1), err
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedBadBinary() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      "rId",
 		ScheduleID: 2,
 	})
 	identity := "testIdentity"
-	context := []byte("context")
-	workflowResult := []byte("success")
+	binaryChecksum := "bad-binary-checksum"
 
 	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
 	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
@@ -783,36 +974,39 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedCompleteWorkflowSuccess()
 	decisions := []*workflow.Decision{{
 		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_CompleteWorkflowExecution),
 		CompleteWorkflowExecutionDecisionAttributes: &workflow.CompleteWorkflowExecutionDecisionAttributes{
-			Result_: workflowResult,
+			Result_: []byte("complete"),
 		},
 	}}
 
-	ms := createMutableState(msBuilder)
-	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
-
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	for i := 0; i < 2; i++ {
+		ms := createMutableState(msBuilder)
+		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	}
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1, BadBinaries: []string{binaryChecksum}},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
-			TaskToken:        taskToken,
-			Decisions:        decisions,
-			ExecutionContext: context,
-			Identity:         &identity,
+			TaskToken:      taskToken,
+			Decisions:      decisions,
+			Identity:       &identity,
+			BinaryChecksum: common.StringPtr(binaryChecksum),
 		},
 	})
 	s.Nil(err, s.printHistory(msBuilder))
 	executionBuilder := s.getBuilder(domainID, we)
-	s.Equal(int64(6), executionBuilder.executionInfo.NextEventID)
-	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
-	s.Equal(context, executionBuilder.executionInfo.ExecutionContext)
-	s.Equal(persistence.WorkflowStateCompleted, executionBuilder.executionInfo.State)
-	s.False(executionBuilder.HasPendingDecisionTask())
+	s.Equal(persistence.WorkflowStateRunning, executionBuilder.executionInfo.State)
+	s.True(executionBuilder.HasPendingDecisionTask())
+	s.Equal(int64(1), executionBuilder.executionInfo.DecisionAttempt)
 }
 
-func (s *engineSuite) TestRespondDecisionTaskCompletedFailWorkflowSuccess() {
+func (s *engineSuite) TestRespondDecisionTaskCompletedStuckDecision() {
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
@@ -820,14 +1014,12 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedFailWorkflowSuccess() {
 	}
 	tl := "testTaskList"
 	taskToken, _ := json.Marshal(&common.TaskToken{
-		WorkflowID: we.GetWorkflowId(),
-		RunID:      we.GetRunId(),
+		WorkflowID: "wId",
+		RunID:      "rId",
 		ScheduleID: 2,
 	})
 	identity := "testIdentity"
-	context := []byte("context")
-	details := []byte("fail workflow details")
-	reason := "fail workflow reason"
+	binaryChecksum := "bad-binary-checksum"
 
 	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
 	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
@@ -835,58 +1027,66 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedFailWorkflowSuccess() {
 	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
 
 	decisions := []*workflow.Decision{{
-		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_FailWorkflowExecution),
-		FailWorkflowExecutionDecisionAttributes: &workflow.FailWorkflowExecutionDecisionAttributes{
-			Reason:  &reason,
-			Details: details,
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_CompleteWorkflowExecution),
+		CompleteWorkflowExecutionDecisionAttributes: &workflow.CompleteWorkflowExecutionDecisionAttributes{
+			Result_: []byte("complete"),
 		},
 	}}
 
-	ms := createMutableState(msBuilder)
-	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	for i := 0; i < 2; i++ {
+		ms := createMutableState(msBuilder)
+		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	}
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info: &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1, BadBinaries: []string{binaryChecksum},
+			MaxDecisionAttempts: 1},
+	}, nil).Once()
+	var appendRequest *persistence.AppendHistoryEventsRequest
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			appendRequest = args.Get(1).(*persistence.AppendHistoryEventsRequest)
+		})
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
-			TaskToken:        taskToken,
-			Decisions:        decisions,
-			ExecutionContext: context,
-			Identity:         &identity,
+			TaskToken:      taskToken,
+			Decisions:      decisions,
+			Identity:       &identity,
+			BinaryChecksum: common.StringPtr(binaryChecksum),
 		},
 	})
 	s.Nil(err, s.printHistory(msBuilder))
 	executionBuilder := s.getBuilder(domainID, we)
-	s.Equal(int64(6), executionBuilder.executionInfo.NextEventID)
-	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
-	s.Equal(context, executionBuilder.executionInfo.ExecutionContext)
 	s.Equal(persistence.WorkflowStateCompleted, executionBuilder.executionInfo.State)
+	s.Equal(persistence.WorkflowCloseStatusTerminatedStuck, executionBuilder.executionInfo.CloseStatus)
 	s.False(executionBuilder.HasPendingDecisionTask())
-}
-
-func (s *engineSuite) TestRespondActivityTaskCompletedInvalidToken() {
-	domainID := "domainId"
-	invalidToken, _ := json.Marshal("bad token")
-	identity := "testIdentity"
 
-	err := s.mockHistoryEngine.RespondActivityTaskCompleted(&history.RespondActivityTaskCompletedRequest{
-		DomainUUID: common.StringPtr(domainID),
-		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
-			TaskToken: invalidToken,
-			Result_:   nil,
-			Identity:  &identity,
-		},
-	})
+	history, err := persistence.NewJSONHistorySerializer().Deserialize(appendRequest.Events)
+	s.Nil(err)
+	terminatedEvent := history.Events[len(history.Events)-1]
+	s.Equal(workflow.EventType_WorkflowExecutionTerminated, terminatedEvent.GetEventType())
+	s.Equal(stuckWorkflowTerminateReason, terminatedEvent.GetWorkflowExecutionTerminatedEventAttributes().GetReason())
+}
 
-	s.NotNil(err)
-	s.IsType(&workflow.BadRequestError{}, err)
+func (s *engineSuite) TestGetDecisionRetryBackoff() {
+	config := NewConfig()
+	s.Equal(config.DecisionRetryInitialInterval, getDecisionRetryBackoff(config, 1))
+	s.Equal(2*config.DecisionRetryInitialInterval, getDecisionRetryBackoff(config, 2))
+	s.Equal(4*config.DecisionRetryInitialInterval, getDecisionRetryBackoff(config, 3))
+	s.Equal(config.DecisionRetryMaxInterval, getDecisionRetryBackoff(config, 100))
 }
 
-func (s *engineSuite) TestRespondActivityTaskCompletedIfNoExecution() {
+func (s *engineSuite) TestSignalWorkflowExecutionBufferedWhileDecisionInFlight() {
 	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
 	taskToken, _ := json.Marshal(&common.TaskToken{
 		WorkflowID: "wId",
 		RunID:      "rId",
@@ -894,90 +1094,1025 @@ func (s *engineSuite) TestRespondActivityTaskCompletedIfNoExecution() {
 	})
 	identity := "testIdentity"
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Twice().Run(
+		func(args mock.Arguments) {
+			updateRequest = args.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+		})
 
-	err := s.mockHistoryEngine.RespondActivityTaskCompleted(&history.RespondActivityTaskCompletedRequest{
+	receipt, err := s.mockHistoryEngine.SignalWorkflowExecution(s.callContext, &history.SignalWorkflowExecutionRequest{
 		DomainUUID: common.StringPtr(domainID),
-		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
-			TaskToken: taskToken,
-			Identity:  &identity,
+		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
+			WorkflowExecution: &we,
+			SignalName:        common.StringPtr("signal"),
+			Identity:          common.StringPtr(identity),
+			RequestId:         common.StringPtr("signal-request"),
 		},
 	})
-	s.NotNil(err)
-	s.IsType(&workflow.EntityNotExistsError{}, err)
-}
-
-func (s *engineSuite) TestRespondActivityTaskCompletedIfGetExecutionFailed() {
-	domainID := "domainId"
-	taskToken, _ := json.Marshal(&common.TaskToken{
-		WorkflowID: "wId",
-		RunID:      "rId",
-		ScheduleID: 2,
-	})
-	identity := "testIdentity"
-
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, errors.New("FAILED")).Once()
+	s.Nil(err)
+	// The signal is kept with the mutable state instead of being appended to the history, it has no event ID yet
+	s.Equal("rId", receipt.GetRunId())
+	s.False(receipt.IsSetEventId())
+	s.Equal(map[string]int64{"signal-request": bufferedEventID}, updateRequest.UpsertSignalReceipts)
+	s.Equal(int64(4), updateRequest.ExecutionInfo.NextEventID)
+	s.NotNil(updateRequest.NewBufferedEvents)
+	buffered, err := persistence.NewJSONHistorySerializer().Deserialize(updateRequest.NewBufferedEvents)
+	s.Nil(err)
+	s.Equal(1, len(buffered.Events))
+	s.Equal(workflow.EventType_WorkflowExecutionSignaled, buffered.Events[0].GetEventType())
 
-	err := s.mockHistoryEngine.RespondActivityTaskCompleted(&history.RespondActivityTaskCompletedRequest{
+	var appendRequest *persistence.AppendHistoryEventsRequest
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			appendRequest = args.Get(1).(*persistence.AppendHistoryEventsRequest)
+		})
+	_, err = s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
-		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken: taskToken,
 			Identity:  &identity,
 		},
 	})
-	s.EqualError(err, "FAILED")
+	s.Nil(err, s.printHistory(msBuilder))
+	s.True(updateRequest.ClearBufferedEvents)
+	s.Nil(updateRequest.NewBufferedEvents)
+
+	// The signal is flushed after the completion of the decision, and schedules another one
+	history, err := persistence.NewJSONHistorySerializer().Deserialize(appendRequest.Events)
+	s.Nil(err)
+	s.Equal(3, len(history.Events))
+	s.Equal(workflow.EventType_DecisionTaskCompleted, history.Events[0].GetEventType())
+	s.Equal(int64(4), history.Events[0].GetEventId())
+	s.Equal(workflow.EventType_WorkflowExecutionSignaled, history.Events[1].GetEventType())
+	s.Equal(int64(5), history.Events[1].GetEventId())
+	s.Equal(workflow.EventType_DecisionTaskScheduled, history.Events[2].GetEventType())
+	s.Equal(int64(6), history.Events[2].GetEventId())
+	s.Equal(map[string]int64{"signal-request": 5}, updateRequest.UpsertSignalReceipts)
 }
 
-func (s *engineSuite) TestRespondActivityTaskCompletedUpdateExecutionFailed() {
+func (s *engineSuite) TestSignalWorkflowExecutionReceipts() {
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
 		RunId:      common.StringPtr("rId"),
 	}
-	tl := "testTaskList"
-	taskToken, _ := json.Marshal(&common.TaskToken{
-		WorkflowID: we.GetWorkflowId(),
-		RunID:      we.GetRunId(),
-		ScheduleID: 5,
-	})
 	identity := "testIdentity"
-	activityID := "activity1_id"
-	activityType := "activity_type1"
-	activityInput := []byte("input1")
-	activityResult := []byte("activity result")
 
 	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
-	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
-	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
-	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
-	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
-		decisionStartedEvent.GetEventId(), nil, identity)
-	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
-		activityType, tl, activityInput, 100, 10, 5)
-	addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 200, identity)
+	addDecisionTaskScheduledEvent(msBuilder)
 
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			updateRequest = args.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+		})
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(errors.New("FAILED")).Once()
-	s.mockShardManager.On("UpdateShard", mock.Anything).Return(nil).Once()
+	signalRequest := &history.SignalWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
+			WorkflowExecution: &we,
+			SignalName:        common.StringPtr("signal"),
+			Identity:          common.StringPtr(identity),
+			RequestId:         common.StringPtr("signal-request"),
+		},
+	}
+	receipt, err := s.mockHistoryEngine.SignalWorkflowExecution(s.callContext, signalRequest)
+	s.Nil(err)
+	s.Equal("rId", receipt.GetRunId())
+	s.Equal(int64(3), receipt.GetEventId())
+	s.Equal(map[string]int64{"signal-request": 3}, updateRequest.UpsertSignalReceipts)
 
-	err := s.mockHistoryEngine.RespondActivityTaskCompleted(&history.RespondActivityTaskCompletedRequest{
+	// The retry gets the receipt of the recorded signal without updating the execution
+	retried, err := s.mockHistoryEngine.SignalWorkflowExecution(s.callContext, signalRequest)
+	s.Nil(err)
+	s.Equal(receipt, retried)
+
+	resp, err := s.mockHistoryEngine.GetSignalReceipt(s.callContext, &history.GetSignalReceiptRequest{
 		DomainUUID: common.StringPtr(domainID),
-		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
-			TaskToken: taskToken,
-			Result_:   activityResult,
-			Identity:  &identity,
+		ReceiptRequest: &workflow.GetSignalReceiptRequest{
+			WorkflowExecution: &we,
+			RequestId:         common.StringPtr("signal-request"),
 		},
 	})
-	s.EqualError(err, "FAILED")
-}
+	s.Nil(err)
+	s.True(resp.GetRecorded())
+	s.Equal("rId", resp.GetRunId())
+	s.Equal(int64(3), resp.GetEventId())
 
-func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskCompleted() {
-	domainID := "domainId"
-	we := workflow.WorkflowExecution{
+	resp, err = s.mockHistoryEngine.GetSignalReceipt(s.callContext, &history.GetSignalReceiptRequest{
+		DomainUUID: common.StringPtr(domainID),
+		ReceiptRequest: &workflow.GetSignalReceiptRequest{
+			WorkflowExecution: &we,
+			RequestId:         common.StringPtr("other-request"),
+		},
+	})
+	s.Nil(err)
+	s.False(resp.GetRecorded())
+	s.False(resp.IsSetEventId())
+}
+
+func (s *engineSuite) TestSignalWorkflowExecutionAfterPanic() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 200, identity)
+	addDecisionTaskScheduledEvent(msBuilder)
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: createMutableState(msBuilder)}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			panic("append failed")
+		})
+	signalRequest := func(requestID string) *history.SignalWorkflowExecutionRequest {
+		return &history.SignalWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			SignalRequest: &workflow.SignalWorkflowExecutionRequest{
+				WorkflowExecution: &we,
+				SignalName:        common.StringPtr("signal"),
+				Identity:          common.StringPtr(identity),
+				RequestId:         common.StringPtr(requestID),
+			},
+		}
+	}
+	s.Panics(func() {
+		s.mockHistoryEngine.SignalWorkflowExecution(s.callContext, signalRequest("panicked-request"))
+	})
+
+	// The signal added by the panicked request is dropped along with the cached mutable state
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: createMutableState(msBuilder)}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			updateRequest = args.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+		})
+	receipt, err := s.mockHistoryEngine.SignalWorkflowExecution(s.callContext, signalRequest("signal-request"))
+	s.Nil(err)
+	s.Equal(int64(3), receipt.GetEventId())
+	s.Equal(map[string]int64{"signal-request": 3}, updateRequest.UpsertSignalReceipts)
+	s.Equal(int64(4), updateRequest.ExecutionInfo.NextEventID)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedSingleActivityScheduledDecision() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      "rId",
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+	context := []byte("context")
+	input := []byte("input")
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_ScheduleActivityTask),
+		ScheduleActivityTaskDecisionAttributes: &workflow.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:   common.StringPtr("activity1"),
+			ActivityType: &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+			TaskList:     &workflow.TaskList{Name: &tl},
+			Input:        input,
+			ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+			StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+			HeartbeatTimeoutSeconds:       common.Int32Ptr(5),
+		},
+	}}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken:        taskToken,
+			Decisions:        decisions,
+			ExecutionContext: context,
+			Identity:         &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(6), executionBuilder.executionInfo.NextEventID)
+	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
+	s.Equal(context, executionBuilder.executionInfo.ExecutionContext)
+	s.Equal(persistence.WorkflowStateRunning, executionBuilder.executionInfo.State)
+	s.False(executionBuilder.HasPendingDecisionTask())
+	s.Equal(int64(1), executionBuilder.executionInfo.DecisionCount)
+	s.Equal(int64(1), executionBuilder.executionInfo.ActivityCount)
+	s.Equal(int64(0), executionBuilder.executionInfo.TimerCount)
+
+	activity1Attributes := s.getActivityScheduledEvent(executionBuilder, int64(5)).GetActivityTaskScheduledEventAttributes()
+	s.Equal("activity1", activity1Attributes.GetActivityId())
+	s.Equal("activity_type1", activity1Attributes.GetActivityType().GetName())
+	s.Equal(int64(4), activity1Attributes.GetDecisionTaskCompletedEventId())
+	s.Equal(tl, activity1Attributes.GetTaskList().GetName())
+	s.Equal(input, activity1Attributes.GetInput())
+	s.Equal(int32(100), activity1Attributes.GetScheduleToCloseTimeoutSeconds())
+	s.Equal(int32(10), activity1Attributes.GetScheduleToStartTimeoutSeconds())
+	s.Equal(int32(50), activity1Attributes.GetStartToCloseTimeoutSeconds())
+	s.Equal(int32(5), activity1Attributes.GetHeartbeatTimeoutSeconds())
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedOffloadedActivityInput() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      "rId",
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+	reference := &workflow.PayloadReference{
+		Key:      common.StringPtr(domainID + "/offloaded"),
+		Checksum: common.StringPtr("checksum"),
+		Size:     common.Int32Ptr(1024),
+	}
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_ScheduleActivityTask),
+		ScheduleActivityTaskDecisionAttributes: &workflow.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:                    common.StringPtr("activity1"),
+			ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+			TaskList:                      &workflow.TaskList{Name: &tl},
+			ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+			StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+			HeartbeatTimeoutSeconds:       common.Int32Ptr(5),
+		},
+		PayloadReference: reference,
+	}}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+
+	// the reference is recorded in the scheduled event, which the activity task is created from
+	executionBuilder := s.getBuilder(domainID, we)
+	scheduledEvent := s.getActivityScheduledEvent(executionBuilder, int64(5))
+	s.Equal(reference, scheduledEvent.PayloadReference)
+	s.Nil(scheduledEvent.GetActivityTaskScheduledEventAttributes().GetInput())
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedActivityTaskContinuation() {
+	s.mockHistoryEngine.config.ActivityTasksPerUpdate = 2
+	s.mockHistoryEngine.historyCache.historyEventsPerBatch = 2
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      "rId",
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	var decisions []*workflow.Decision
+	for i := 0; i < 3; i++ {
+		decisions = append(decisions, &workflow.Decision{
+			DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_ScheduleActivityTask),
+			ScheduleActivityTaskDecisionAttributes: &workflow.ScheduleActivityTaskDecisionAttributes{
+				ActivityId:                    common.StringPtr(fmt.Sprintf("activity%v", i)),
+				ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+				TaskList:                      &workflow.TaskList{Name: &tl},
+				ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+				ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+				StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+				HeartbeatTimeoutSeconds:       common.Int32Ptr(5),
+			},
+		})
+	}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	var firstEventIDs []int64
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Twice().Run(
+		func(args mock.Arguments) {
+			firstEventIDs = append(firstEventIDs, args.Get(1).(*persistence.AppendHistoryEventsRequest).FirstEventID)
+		})
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			updateRequest = args.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+		})
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	// DecisionTaskCompleted and the three ActivityTaskScheduled events are appended two at a time
+	s.Equal([]int64{4, 6}, firstEventIDs)
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(8), executionBuilder.executionInfo.NextEventID)
+	s.Equal(3, len(executionBuilder.pendingActivityInfoIDs))
+	s.False(executionBuilder.pendingActivityInfoIDs[5].DispatchDeferred)
+	s.False(executionBuilder.pendingActivityInfoIDs[6].DispatchDeferred)
+	s.True(executionBuilder.pendingActivityInfoIDs[7].DispatchDeferred)
+
+	s.NotNil(updateRequest)
+	s.Equal(3, len(updateRequest.TransferTasks))
+	s.Equal(int64(5), updateRequest.TransferTasks[0].(*persistence.ActivityTask).ScheduleID)
+	s.Equal(int64(6), updateRequest.TransferTasks[1].(*persistence.ActivityTask).ScheduleID)
+	s.Equal(int64(7), updateRequest.TransferTasks[2].(*persistence.ActivityTaskContinuationTask).ScheduleID)
+	s.Equal(4, len(updateRequest.TimerTasks))
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedActivityTimeoutPolicy() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      "rId",
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_ScheduleActivityTask),
+		ScheduleActivityTaskDecisionAttributes: &workflow.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:                 common.StringPtr("activity1"),
+			ActivityType:               &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+			TaskList:                   &workflow.TaskList{Name: &tl},
+			StartToCloseTimeoutSeconds: common.Int32Ptr(3600),
+		},
+	}}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info: &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{
+			Retention: 1,
+			ActivityTimeoutDefaults: persistence.ActivityTimeouts{
+				ScheduleToClose: 1200,
+				ScheduleToStart: 60,
+				StartToClose:    300,
+				Heartbeat:       30,
+			},
+			ActivityTimeoutCaps: persistence.ActivityTimeouts{
+				ScheduleToClose: 600,
+				StartToClose:    600,
+			},
+		},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(1), executionBuilder.executionInfo.ActivityCount)
+
+	activity1Attributes := s.getActivityScheduledEvent(executionBuilder, int64(5)).GetActivityTaskScheduledEventAttributes()
+	s.Equal(int32(600), activity1Attributes.GetScheduleToCloseTimeoutSeconds())
+	s.Equal(int32(60), activity1Attributes.GetScheduleToStartTimeoutSeconds())
+	s.Equal(int32(600), activity1Attributes.GetStartToCloseTimeoutSeconds())
+	s.Equal(int32(30), activity1Attributes.GetHeartbeatTimeoutSeconds())
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedLocalActivityDispatch() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      "rId",
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+	input := []byte("input")
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_ScheduleActivityTask),
+		ScheduleActivityTaskDecisionAttributes: &workflow.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:                    common.StringPtr("activity1"),
+			ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+			TaskList:                      &workflow.TaskList{Name: &tl},
+			Input:                         input,
+			ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+			StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+			HeartbeatTimeoutSeconds:       common.Int32Ptr(5),
+			RequestLocalDispatch:          common.BoolPtr(true),
+		},
+	}}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			updateRequest = args.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+		})
+
+	response, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(7), executionBuilder.executionInfo.NextEventID)
+	ai, ok := executionBuilder.GetActivityInfo(int64(5))
+	s.True(ok)
+	s.Equal(int64(6), ai.StartedID)
+
+	// The activity is started right away and handed back to the worker instead of going through matching
+	s.NotNil(updateRequest)
+	for _, task := range updateRequest.TransferTasks {
+		s.NotEqual(persistence.TransferTaskTypeActivityTask, task.GetType())
+	}
+	s.Equal(1, len(response.ActivityTasks))
+	activityTask := response.ActivityTasks[0]
+	s.Equal("activity1", activityTask.GetActivityId())
+	s.Equal(input, activityTask.GetInput())
+	s.Equal(int64(6), activityTask.GetStartedEventId())
+	s.Equal(int32(50), activityTask.GetStartToCloseTimeoutSeconds())
+	activityToken, err := s.mockHistoryEngine.tokenSerializer.Deserialize(activityTask.GetTaskToken())
+	s.Nil(err)
+	s.Equal(domainID, activityToken.DomainID)
+	s.Equal(int64(5), activityToken.ScheduleID)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedCompleteWorkflowSuccess() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+	context := []byte("context")
+	workflowResult := []byte("success")
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_CompleteWorkflowExecution),
+		CompleteWorkflowExecutionDecisionAttributes: &workflow.CompleteWorkflowExecutionDecisionAttributes{
+			Result_: workflowResult,
+		},
+	}}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken:        taskToken,
+			Decisions:        decisions,
+			ExecutionContext: context,
+			Identity:         &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(6), executionBuilder.executionInfo.NextEventID)
+	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
+	s.Equal(context, executionBuilder.executionInfo.ExecutionContext)
+	s.Equal(persistence.WorkflowStateCompleted, executionBuilder.executionInfo.State)
+	s.False(executionBuilder.HasPendingDecisionTask())
+}
+
+func (s *engineSuite) TestCompletedExecutionCache() {
+	config := NewConfig()
+	completedExecutions := newCompletedExecutionCache(config)
+	s.mockHistoryEngine.completedExecutions = completedExecutions
+	s.mockHistoryEngine.historyCache.completedExecutions = completedExecutions
+
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_CompleteWorkflowExecution),
+		CompleteWorkflowExecutionDecisionAttributes: &workflow.CompleteWorkflowExecutionDecisionAttributes{
+			Result_: []byte("success"),
+		},
+	}}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+
+	closed, ok := completedExecutions.get(domainID, we)
+	s.True(ok)
+	s.Equal(persistence.WorkflowCloseStatusCompleted, closed.closeStatus)
+	s.False(closed.closeTime.IsZero())
+	_, ok = completedExecutions.get(domainID, workflow.WorkflowExecution{WorkflowId: we.WorkflowId})
+	s.False(ok)
+
+	// Requests against the closed execution are rejected without loading its mutable state, which is no longer cached
+	s.mockHistoryEngine.historyCache = newHistoryCache(historyCacheMaxSize, s.mockHistoryEngine.shard, s.logger)
+	_, err = s.mockHistoryEngine.SignalWorkflowExecution(s.callContext, &history.SignalWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
+			WorkflowExecution: &we,
+			SignalName:        common.StringPtr("signal"),
+		},
+	})
+	s.IsType(&workflow.EntityNotExistsError{}, err)
+
+	activityToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 5,
+	})
+	err = s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: activityToken,
+			Identity:  &identity,
+		},
+	})
+	s.IsType(&workflow.EntityNotExistsError{}, err)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedFailWorkflowSuccess() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+	context := []byte("context")
+	details := []byte("fail workflow details")
+	reason := "fail workflow reason"
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_FailWorkflowExecution),
+		FailWorkflowExecutionDecisionAttributes: &workflow.FailWorkflowExecutionDecisionAttributes{
+			Reason:  &reason,
+			Details: details,
+		},
+	}}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken:        taskToken,
+			Decisions:        decisions,
+			ExecutionContext: context,
+			Identity:         &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(6), executionBuilder.executionInfo.NextEventID)
+	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
+	s.Equal(context, executionBuilder.executionInfo.ExecutionContext)
+	s.Equal(persistence.WorkflowStateCompleted, executionBuilder.executionInfo.State)
+	s.False(executionBuilder.HasPendingDecisionTask())
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedInvalidToken() {
+	domainID := "domainId"
+	invalidToken, _ := json.Marshal("bad token")
+	identity := "testIdentity"
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: invalidToken,
+			Result_:   nil,
+			Identity:  &identity,
+		},
+	})
+
+	s.NotNil(err)
+	s.IsType(&workflow.BadRequestError{}, err)
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedIfNoExecution() {
+	domainID := "domainId"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      "rId",
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Identity:  &identity,
+		},
+	})
+	s.NotNil(err)
+	s.IsType(&workflow.EntityNotExistsError{}, err)
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedIfGetExecutionFailed() {
+	domainID := "domainId"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      "rId",
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil, errors.New("FAILED")).Once()
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Identity:  &identity,
+		},
+	})
+	s.EqualError(err, "FAILED")
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedUpdateExecutionFailed() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 5,
+	})
+	identity := "testIdentity"
+	activityID := "activity1_id"
+	activityType := "activity_type1"
+	activityInput := []byte("input1")
+	activityResult := []byte("activity result")
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
+		activityType, tl, activityInput, 100, 10, 5)
+	addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(errors.New("FAILED")).Once()
+	s.mockShardManager.On("AllocateTaskIDs", mock.Anything, mock.Anything).Return(newAllocateTaskIDsResponse(2), nil).Once()
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Result_:   activityResult,
+			Identity:  &identity,
+		},
+	})
+	s.EqualError(err, "FAILED")
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskCompleted() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 5,
+	})
+	identity := "testIdentity"
+	activityID := "activity1_id"
+	activityType := "activity_type1"
+	activityInput := []byte("input1")
+	activityResult := []byte("activity result")
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
+		activityType, tl, activityInput, 100, 10, 5)
+	activityStartedEvent := addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
+	addActivityTaskCompletedEvent(msBuilder, activityScheduledEvent.GetEventId(), activityStartedEvent.GetEventId(),
+		activityResult, identity)
+	addDecisionTaskScheduledEvent(msBuilder)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Result_:   activityResult,
+			Identity:  &identity,
+		},
+	})
+	s.NotNil(err)
+	s.IsType(&workflow.EntityNotExistsError{}, err)
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskNotStarted() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 5,
+	})
+	identity := "testIdentity"
+	activityID := "activity1_id"
+	activityType := "activity_type1"
+	activityInput := []byte("input1")
+	activityResult := []byte("activity result")
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
+		activityType, tl, activityInput, 100, 10, 5)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Result_:   activityResult,
+			Identity:  &identity,
+		},
+	})
+	s.NotNil(err)
+	s.IsType(&workflow.EntityNotExistsError{}, err)
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedConflictOnUpdate() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 5,
+	})
+	identity := "testIdentity"
+	activity1ID := "activity1"
+	activity1Type := "activity_type1"
+	activity1Input := []byte("input1")
+	activity1Result := []byte("activity1_result")
+	activity2ID := "activity2"
+	activity2Type := "activity_type2"
+	activity2Input := []byte("input2")
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 25, 200, identity)
+	decisionScheduledEvent1, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent1 := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent1.GetEventId(), tl, identity)
+	decisionCompletedEvent1 := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent1.GetEventId(),
+		decisionStartedEvent1.GetEventId(), nil, identity)
+	activity1ScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent1.GetEventId(), activity1ID,
+		activity1Type, tl, activity1Input, 100, 10, 5)
+	activity2ScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent1.GetEventId(), activity2ID,
+		activity2Type, tl, activity2Input, 100, 10, 5)
+	addActivityTaskStartedEvent(msBuilder, activity1ScheduledEvent.GetEventId(), tl, identity)
+	addActivityTaskStartedEvent(msBuilder, activity2ScheduledEvent.GetEventId(), tl, identity)
+
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &persistence.GetWorkflowExecutionResponse{State: ms1}
+
+	ms2 := createMutableState(msBuilder)
+	gwmsResponse2 := &persistence.GetWorkflowExecutionResponse{State: ms2}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse2, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Result_:   activity1Result,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(11), executionBuilder.executionInfo.NextEventID)
+	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
+	s.Equal(persistence.WorkflowStateRunning, executionBuilder.executionInfo.State)
+
+	s.True(executionBuilder.HasPendingDecisionTask())
+	di, ok := executionBuilder.GetPendingDecision(int64(10))
+	s.True(ok)
+	s.Equal(int32(200), di.DecisionTimeout)
+	s.Equal(int64(10), di.ScheduleID)
+	s.Equal(emptyEventID, di.StartedID)
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedMaxAttemptsExceeded() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
 		RunId:      common.StringPtr("rId"),
 	}
@@ -1001,17 +2136,64 @@ func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskCompleted() {
 		decisionStartedEvent.GetEventId(), nil, identity)
 	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
 		activityType, tl, activityInput, 100, 10, 5)
-	activityStartedEvent := addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
-	addActivityTaskCompletedEvent(msBuilder, activityScheduledEvent.GetEventId(), activityStartedEvent.GetEventId(),
-		activityResult, identity)
-	addDecisionTaskScheduledEvent(msBuilder)
+	addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
+
+	for i := 0; i < conditionalRetryCount; i++ {
+		ms := createMutableState(msBuilder)
+		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+		s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+	}
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Result_:   activityResult,
+			Identity:  &identity,
+		},
+	})
+	s.Equal(ErrMaxAttemptsExceeded, err)
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedSuccess() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 5,
+	})
+	identity := "testIdentity"
+	activityID := "activity1_id"
+	activityType := "activity_type1"
+	activityInput := []byte("input1")
+	activityResult := []byte("activity result")
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
+		activityType, tl, activityInput, 100, 10, 5)
+	addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
 
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskCompleted(&history.RespondActivityTaskCompletedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
 			TaskToken: taskToken,
@@ -1019,11 +2201,23 @@ func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskCompleted() {
 			Identity:  &identity,
 		},
 	})
-	s.NotNil(err)
-	s.IsType(&workflow.EntityNotExistsError{}, err)
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(9), executionBuilder.executionInfo.NextEventID)
+	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
+	s.Equal(persistence.WorkflowStateRunning, executionBuilder.executionInfo.State)
+
+	s.True(executionBuilder.HasPendingDecisionTask())
+	di, ok := executionBuilder.GetPendingDecision(int64(8))
+	s.True(ok)
+	s.Equal(int32(200), di.DecisionTimeout)
+	s.Equal(int64(8), di.ScheduleID)
+	s.Equal(emptyEventID, di.StartedID)
 }
 
-func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskNotStarted() {
+func (s *engineSuite) TestRespondActivityTaskCompletedDuplicate() {
+	s.mockHistoryEngine.completedActivities = newCompletedActivityCache(NewConfig())
+
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
@@ -1037,9 +2231,202 @@ func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskNotStarted() {
 	})
 	identity := "testIdentity"
 	activityID := "activity1_id"
+	activityResult := []byte("activity result")
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
+		"activity_type1", tl, []byte("input1"), 100, 10, 5)
+	addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	complete := func(taskToken []byte, result []byte) error {
+		return s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+			DomainUUID: common.StringPtr(domainID),
+			CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+				TaskToken: taskToken,
+				Result_:   result,
+				Identity:  &identity,
+			},
+		})
+	}
+	s.Nil(complete(taskToken, activityResult), s.printHistory(msBuilder))
+
+	// Retries of the completion succeed without another update of the execution, whether by schedule or activity ID
+	s.Nil(complete(taskToken, activityResult))
+	byIDToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: common.EmptyEventID,
+		ActivityID: activityID,
+	})
+	s.Nil(complete(byIDToken, activityResult))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(9), executionBuilder.executionInfo.NextEventID)
+
+	// A completion with another result is not a retry
+	err := complete(taskToken, []byte("other result"))
+	s.IsType(&workflow.EntityNotExistsError{}, err)
+	s.Equal(int64(9), executionBuilder.executionInfo.NextEventID)
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedByIDSuccess() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+	activityID := "activity1_id"
 	activityType := "activity_type1"
 	activityInput := []byte("input1")
 	activityResult := []byte("activity result")
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: common.EmptyEventID,
+		ActivityID: activityID,
+	})
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
+		activityType, tl, activityInput, 100, 10, 5)
+	addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Result_:   activityResult,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(9), executionBuilder.executionInfo.NextEventID)
+	_, isRunning := executionBuilder.GetActivityInfo(activityScheduledEvent.GetEventId())
+	s.False(isRunning)
+	s.True(executionBuilder.HasPendingDecisionTask())
+}
+
+func (s *engineSuite) TestRespondActivityTaskCompletedByIDNotFound() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: common.EmptyEventID,
+		ActivityID: "unknown_activity_id",
+	})
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	addDecisionTaskScheduledEvent(msBuilder)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+
+	err := s.mockHistoryEngine.RespondActivityTaskCompleted(s.callContext, &history.RespondActivityTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
+			TaskToken: taskToken,
+			Identity:  &identity,
+		},
+	})
+	s.NotNil(err)
+	s.IsType(&workflow.EntityNotExistsError{}, err)
+}
+
+func (s *engineSuite) TestDescribeWorkflowExecutionPendingActivities() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	activity1ScheduledEvent, activity1Info := addActivityTaskScheduledEvent(msBuilder,
+		decisionCompletedEvent.GetEventId(), "activity1_id", "activity_type1", tl, []byte("input1"), 100, 10, 5)
+	activity1Info.StartToCloseTimeout = 20
+	activity1StartedEvent := addActivityTaskStartedEvent(msBuilder, activity1ScheduledEvent.GetEventId(), tl, identity)
+	activity2ScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(),
+		"activity2_id", "activity_type2", tl, []byte("input2"), 100, 10, 5)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+
+	resp, err := s.mockHistoryEngine.DescribeWorkflowExecution(s.callContext, &history.DescribeWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Request: &workflow.DescribeWorkflowExecutionRequest{
+			Domain:    common.StringPtr("domain"),
+			Execution: &we,
+		},
+	})
+	s.Nil(err)
+	s.Equal("wType", resp.GetWorkflowExecutionInfo().GetType().GetName())
+	s.Equal(2, len(resp.GetPendingActivities()))
+
+	activity1 := resp.GetPendingActivities()[0]
+	s.Equal("activity1_id", activity1.GetActivityID())
+	s.Equal(workflow.PendingActivityState_STARTED, activity1.GetState())
+	s.Equal(activity1StartedEvent.GetTimestamp(), activity1.GetLastStartedTimestamp())
+	s.Equal(common.AddSecondsToBaseTime(activity1StartedEvent.GetTimestamp(), 20), activity1.GetExpirationTimestamp())
+	s.False(activity1.IsSetLastHeartbeatTimestamp())
+
+	activity2 := resp.GetPendingActivities()[1]
+	s.Equal("activity2_id", activity2.GetActivityID())
+	s.Equal(workflow.PendingActivityState_SCHEDULED, activity2.GetState())
+	s.False(activity2.IsSetLastStartedTimestamp())
+	s.Equal(common.AddSecondsToBaseTime(activity2ScheduledEvent.GetTimestamp(), 100), activity2.GetExpirationTimestamp())
+}
+
+func (s *engineSuite) TestDescribeMutableState() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
 
 	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
 	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
@@ -1047,113 +2434,103 @@ func (s *engineSuite) TestRespondActivityTaskCompletedIfTaskNotStarted() {
 	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
 	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
 		decisionStartedEvent.GetEventId(), nil, identity)
-	addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
-		activityType, tl, activityInput, 100, 10, 5)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(),
+		"activity1_id", "activity_type1", tl, []byte("input1"), 100, 10, 5)
+	addTimerStartedEvent(msBuilder, decisionCompletedEvent.GetEventId(), "timer1_id", 10)
+	decisionScheduledEvent2, _ := addDecisionTaskScheduledEvent(msBuilder)
 
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskCompleted(&history.RespondActivityTaskCompletedRequest{
+	resp, err := s.mockHistoryEngine.DescribeMutableState(s.callContext, &history.DescribeMutableStateRequest{
 		DomainUUID: common.StringPtr(domainID),
-		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
-			TaskToken: taskToken,
-			Result_:   activityResult,
-			Identity:  &identity,
-		},
+		Execution:  &we,
 	})
-	s.NotNil(err)
-	s.IsType(&workflow.EntityNotExistsError{}, err)
+	s.Nil(err)
+
+	dump := &mutableStateDump{}
+	s.Nil(json.Unmarshal([]byte(resp.GetMutableStateInJson()), dump))
+	s.Equal("wType", dump.ExecutionInfo.WorkflowTypeName)
+	s.Equal(decisionScheduledEvent2.GetEventId(), dump.PendingDecision.ScheduleID)
+	s.Equal(1, len(dump.ActivityInfos))
+	s.Equal("activity1_id", dump.ActivityInfos[activityScheduledEvent.GetEventId()].ActivityID)
+	s.Equal(1, len(dump.TimerInfos))
+	s.NotNil(dump.TimerInfos["timer1_id"])
+	s.Equal(0, len(dump.ChildExecutionInfos))
+	s.Equal(s.mockHistoryEngine.shard.GetTransferAckLevel(), dump.TransferAckLevel)
 }
 
-func (s *engineSuite) TestRespondActivityTaskCompletedConflictOnUpdate() {
+func (s *engineSuite) TestRefreshWorkflowTasks() {
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
 		RunId:      common.StringPtr("rId"),
 	}
 	tl := "testTaskList"
-	taskToken, _ := json.Marshal(&common.TaskToken{
-		WorkflowID: we.GetWorkflowId(),
-		RunID:      we.GetRunId(),
-		ScheduleID: 5,
-	})
 	identity := "testIdentity"
-	activity1ID := "activity1"
-	activity1Type := "activity_type1"
-	activity1Input := []byte("input1")
-	activity1Result := []byte("activity1_result")
-	activity2ID := "activity2"
-	activity2Type := "activity_type2"
-	activity2Input := []byte("input2")
 
 	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
-	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 25, 200, identity)
-	decisionScheduledEvent1, _ := addDecisionTaskScheduledEvent(msBuilder)
-	decisionStartedEvent1 := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent1.GetEventId(), tl, identity)
-	decisionCompletedEvent1 := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent1.GetEventId(),
-		decisionStartedEvent1.GetEventId(), nil, identity)
-	activity1ScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent1.GetEventId(), activity1ID,
-		activity1Type, tl, activity1Input, 100, 10, 5)
-	activity2ScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent1.GetEventId(), activity2ID,
-		activity2Type, tl, activity2Input, 100, 10, 5)
-	addActivityTaskStartedEvent(msBuilder, activity1ScheduledEvent.GetEventId(), tl, identity)
-	addActivityTaskStartedEvent(msBuilder, activity2ScheduledEvent.GetEventId(), tl, identity)
-
-	ms1 := createMutableState(msBuilder)
-	gwmsResponse1 := &persistence.GetWorkflowExecutionResponse{State: ms1}
-
-	ms2 := createMutableState(msBuilder)
-	gwmsResponse2 := &persistence.GetWorkflowExecutionResponse{State: ms2}
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(),
+		"activity1_id", "activity_type1", tl, []byte("input1"), 100, 10, 5)
+	addTimerStartedEvent(msBuilder, decisionCompletedEvent.GetEventId(), "timer1_id", 10)
+	decisionScheduledEvent2, _ := addDecisionTaskScheduledEvent(msBuilder)
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse2, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			updateRequest = args.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+		})
 
-	err := s.mockHistoryEngine.RespondActivityTaskCompleted(&history.RespondActivityTaskCompletedRequest{
+	err := s.mockHistoryEngine.RefreshWorkflowTasks(s.callContext, &history.RefreshWorkflowTasksRequest{
 		DomainUUID: common.StringPtr(domainID),
-		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
-			TaskToken: taskToken,
-			Result_:   activity1Result,
-			Identity:  &identity,
+		RefreshRequest: &workflow.RefreshWorkflowTasksRequest{
+			Domain:            common.StringPtr("domain"),
+			WorkflowExecution: &we,
+			Identity:          common.StringPtr(identity),
 		},
 	})
-	s.Nil(err, s.printHistory(msBuilder))
-	executionBuilder := s.getBuilder(domainID, we)
-	s.Equal(int64(11), executionBuilder.executionInfo.NextEventID)
-	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
-	s.Equal(persistence.WorkflowStateRunning, executionBuilder.executionInfo.State)
-
-	s.True(executionBuilder.HasPendingDecisionTask())
-	di, ok := executionBuilder.GetPendingDecision(int64(10))
-	s.True(ok)
-	s.Equal(int32(200), di.DecisionTimeout)
-	s.Equal(int64(10), di.ScheduleID)
-	s.Equal(emptyEventID, di.StartedID)
+	s.Nil(err)
+	s.NotNil(updateRequest)
+
+	s.Equal(2, len(updateRequest.TransferTasks))
+	decisionTask := updateRequest.TransferTasks[0].(*persistence.DecisionTask)
+	s.Equal(decisionScheduledEvent2.GetEventId(), decisionTask.ScheduleID)
+	s.Equal(tl, decisionTask.TaskList)
+	activityTask := updateRequest.TransferTasks[1].(*persistence.ActivityTask)
+	s.Equal(activityScheduledEvent.GetEventId(), activityTask.ScheduleID)
+	s.Equal(domainID, activityTask.DomainID)
+
+	timerTaskTypes := make(map[int]int)
+	for _, task := range updateRequest.TimerTasks {
+		timerTaskTypes[task.GetType()]++
+	}
+	// Schedule to close and schedule to start timeouts of the activity, and the user timer
+	s.Equal(3, len(updateRequest.TimerTasks))
+	s.Equal(2, timerTaskTypes[persistence.TaskTypeActivityTimeout])
+	s.Equal(1, timerTaskTypes[persistence.TaskTypeUserTimer])
+	_, ti := s.getBuilder(domainID, we).GetUserTimer("timer1_id")
+	s.NotEqual(int64(emptyTimerID), ti.TaskID)
 }
 
-func (s *engineSuite) TestRespondActivityTaskCompletedMaxAttemptsExceeded() {
+func (s *engineSuite) TestRefreshWorkflowTasksRequestCancel() {
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
 		RunId:      common.StringPtr("rId"),
 	}
 	tl := "testTaskList"
-	taskToken, _ := json.Marshal(&common.TaskToken{
-		WorkflowID: we.GetWorkflowId(),
-		RunID:      we.GetRunId(),
-		ScheduleID: 5,
-	})
 	identity := "testIdentity"
-	activityID := "activity1_id"
-	activityType := "activity_type1"
-	activityInput := []byte("input1")
-	activityResult := []byte("activity result")
 
 	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
 	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
@@ -1161,47 +2538,70 @@ func (s *engineSuite) TestRespondActivityTaskCompletedMaxAttemptsExceeded() {
 	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
 	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
 		decisionStartedEvent.GetEventId(), nil, identity)
-	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
-		activityType, tl, activityInput, 100, 10, 5)
-	addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
+	cancelInitiatedEvent, _ := msBuilder.AddRequestCancelExternalWorkflowExecutionInitiatedEvent(
+		decisionCompletedEvent.GetEventId(), uuid.New(), &workflow.RequestCancelExternalWorkflowExecutionDecisionAttributes{
+			Domain:     common.StringPtr("targetDomain"),
+			WorkflowId: common.StringPtr("targetWorkflowId"),
+			RunId:      common.StringPtr("targetRunId"),
+		})
 
-	for i := 0; i < conditionalRetryCount; i++ {
-		ms := createMutableState(msBuilder)
-		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	serializer, err := persistence.NewHistorySerializerFactory().Get(common.EncodingTypeJSON)
+	s.Nil(err)
+	serializedHistory, err := serializer.Serialize(&persistence.HistoryEventBatch{
+		Version: persistence.GetDefaultHistoryVersion(),
+		Events:  msBuilder.hBuilder.history,
+	})
+	s.Nil(err)
 
-		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-		s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
-	}
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything, mock.MatchedBy(
+		func(request *persistence.GetWorkflowExecutionHistoryRequest) bool {
+			return request.NextEventID == cancelInitiatedEvent.GetEventId()+1
+		})).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		Events: []persistence.SerializedHistoryEventBatch{*serializedHistory},
+	}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{Name: "targetDomain"}).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: "targetDomainId"},
+			Config: &persistence.DomainConfig{Retention: 1},
+		}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			updateRequest = args.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+		})
 
-	err := s.mockHistoryEngine.RespondActivityTaskCompleted(&history.RespondActivityTaskCompletedRequest{
+	err = s.mockHistoryEngine.RefreshWorkflowTasks(s.callContext, &history.RefreshWorkflowTasksRequest{
 		DomainUUID: common.StringPtr(domainID),
-		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
-			TaskToken: taskToken,
-			Result_:   activityResult,
-			Identity:  &identity,
+		RefreshRequest: &workflow.RefreshWorkflowTasksRequest{
+			Domain:            common.StringPtr("domain"),
+			WorkflowExecution: &we,
+			Identity:          common.StringPtr(identity),
 		},
 	})
-	s.Equal(ErrMaxAttemptsExceeded, err)
+	s.Nil(err)
+	s.NotNil(updateRequest)
+
+	// The cancellation not delivered yet gets its task again, with the target read from the initiated event
+	s.Equal(1, len(updateRequest.TransferTasks))
+	cancelTask := updateRequest.TransferTasks[0].(*persistence.CancelExecutionTask)
+	s.Equal(cancelInitiatedEvent.GetEventId(), cancelTask.ScheduleID)
+	s.Equal("targetDomainId", cancelTask.TargetDomainID)
+	s.Equal("targetWorkflowId", cancelTask.TargetWorkflowID)
+	s.Equal("targetRunId", cancelTask.TargetRunID)
 }
 
-func (s *engineSuite) TestRespondActivityTaskCompletedSuccess() {
+func (s *engineSuite) TestRefreshWorkflowTasksCompletedExecution() {
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
 		RunId:      common.StringPtr("rId"),
 	}
 	tl := "testTaskList"
-	taskToken, _ := json.Marshal(&common.TaskToken{
-		WorkflowID: we.GetWorkflowId(),
-		RunID:      we.GetRunId(),
-		ScheduleID: 5,
-	})
 	identity := "testIdentity"
-	activityID := "activity1_id"
-	activityType := "activity_type1"
-	activityInput := []byte("input1")
-	activityResult := []byte("activity result")
 
 	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
 	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
@@ -1209,37 +2609,21 @@ func (s *engineSuite) TestRespondActivityTaskCompletedSuccess() {
 	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
 	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
 		decisionStartedEvent.GetEventId(), nil, identity)
-	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), activityID,
-		activityType, tl, activityInput, 100, 10, 5)
-	addActivityTaskStartedEvent(msBuilder, activityScheduledEvent.GetEventId(), tl, identity)
+	addCompleteWorkflowEvent(msBuilder, decisionCompletedEvent.GetEventId(), nil)
 
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskCompleted(&history.RespondActivityTaskCompletedRequest{
+	err := s.mockHistoryEngine.RefreshWorkflowTasks(s.callContext, &history.RefreshWorkflowTasksRequest{
 		DomainUUID: common.StringPtr(domainID),
-		CompleteRequest: &workflow.RespondActivityTaskCompletedRequest{
-			TaskToken: taskToken,
-			Result_:   activityResult,
-			Identity:  &identity,
+		RefreshRequest: &workflow.RefreshWorkflowTasksRequest{
+			Domain:            common.StringPtr("domain"),
+			WorkflowExecution: &we,
 		},
 	})
-	s.Nil(err, s.printHistory(msBuilder))
-	executionBuilder := s.getBuilder(domainID, we)
-	s.Equal(int64(9), executionBuilder.executionInfo.NextEventID)
-	s.Equal(int64(3), executionBuilder.executionInfo.LastProcessedEvent)
-	s.Equal(persistence.WorkflowStateRunning, executionBuilder.executionInfo.State)
-
-	s.True(executionBuilder.HasPendingDecisionTask())
-	di, ok := executionBuilder.GetPendingDecision(int64(8))
-	s.True(ok)
-	s.Equal(int32(200), di.DecisionTimeout)
-	s.Equal(int64(8), di.ScheduleID)
-	s.Equal(emptyEventID, di.StartedID)
+	s.IsType(&workflow.EntityNotExistsError{}, err)
 }
 
 func (s *engineSuite) TestRespondActivityTaskFailedInvalidToken() {
@@ -1247,7 +2631,7 @@ func (s *engineSuite) TestRespondActivityTaskFailedInvalidToken() {
 	invalidToken, _ := json.Marshal("bad token")
 	identity := "testIdentity"
 
-	err := s.mockHistoryEngine.RespondActivityTaskFailed(&history.RespondActivityTaskFailedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskFailed(s.callContext, &history.RespondActivityTaskFailedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		FailedRequest: &workflow.RespondActivityTaskFailedRequest{
 			TaskToken: invalidToken,
@@ -1268,10 +2652,10 @@ func (s *engineSuite) TestRespondActivityTaskFailedIfNoExecution() {
 	})
 	identity := "testIdentity"
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil,
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil,
 		&workflow.EntityNotExistsError{}).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskFailed(&history.RespondActivityTaskFailedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskFailed(s.callContext, &history.RespondActivityTaskFailedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		FailedRequest: &workflow.RespondActivityTaskFailedRequest{
 			TaskToken: taskToken,
@@ -1291,10 +2675,10 @@ func (s *engineSuite) TestRespondActivityTaskFailedIfGetExecutionFailed() {
 	})
 	identity := "testIdentity"
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil,
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil,
 		errors.New("FAILED")).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskFailed(&history.RespondActivityTaskFailedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskFailed(s.callContext, &history.RespondActivityTaskFailedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		FailedRequest: &workflow.RespondActivityTaskFailedRequest{
 			TaskToken: taskToken,
@@ -1334,12 +2718,12 @@ func (s *engineSuite) TestRespondActivityTaskFailedUpdateExecutionFailed() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(errors.New("FAILED")).Once()
-	s.mockShardManager.On("UpdateShard", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(errors.New("FAILED")).Once()
+	s.mockShardManager.On("AllocateTaskIDs", mock.Anything, mock.Anything).Return(newAllocateTaskIDsResponse(2), nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskFailed(&history.RespondActivityTaskFailedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskFailed(s.callContext, &history.RespondActivityTaskFailedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		FailedRequest: &workflow.RespondActivityTaskFailedRequest{
 			TaskToken: taskToken,
@@ -1384,9 +2768,9 @@ func (s *engineSuite) TestRespondActivityTaskFailedIfTaskCompleted() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskFailed(&history.RespondActivityTaskFailedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskFailed(s.callContext, &history.RespondActivityTaskFailedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		FailedRequest: &workflow.RespondActivityTaskFailedRequest{
 			TaskToken: taskToken,
@@ -1428,9 +2812,9 @@ func (s *engineSuite) TestRespondActivityTaskFailedIfTaskNotStarted() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskFailed(&history.RespondActivityTaskFailedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskFailed(s.callContext, &history.RespondActivityTaskFailedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		FailedRequest: &workflow.RespondActivityTaskFailedRequest{
 			TaskToken: taskToken,
@@ -1487,15 +2871,15 @@ func (s *engineSuite) TestRespondActivityTaskFailedConflictOnUpdate() {
 	ms2 := createMutableState(msBuilder)
 	gwmsResponse2 := &persistence.GetWorkflowExecutionResponse{State: ms2}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse2, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse2, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskFailed(&history.RespondActivityTaskFailedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskFailed(s.callContext, &history.RespondActivityTaskFailedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		FailedRequest: &workflow.RespondActivityTaskFailedRequest{
 			TaskToken: taskToken,
@@ -1549,12 +2933,12 @@ func (s *engineSuite) TestRespondActivityTaskFailedMaxAttemptsExceeded() {
 		ms := createMutableState(msBuilder)
 		gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-		s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+		s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
 	}
 
-	err := s.mockHistoryEngine.RespondActivityTaskFailed(&history.RespondActivityTaskFailedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskFailed(s.callContext, &history.RespondActivityTaskFailedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		FailedRequest: &workflow.RespondActivityTaskFailedRequest{
 			TaskToken: taskToken,
@@ -1596,11 +2980,11 @@ func (s *engineSuite) TestRespondActivityTaskFailedSuccess() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskFailed(&history.RespondActivityTaskFailedRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskFailed(s.callContext, &history.RespondActivityTaskFailedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		FailedRequest: &workflow.RespondActivityTaskFailedRequest{
 			TaskToken: taskToken,
@@ -1653,12 +3037,12 @@ func (s *engineSuite) TestRecordActivityTaskHeartBeatSuccess_NoTimer() {
 	// No HeartBeat timer running.
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
 	detais := []byte("details")
 
-	_, err := s.mockHistoryEngine.RecordActivityTaskHeartbeat(&history.RecordActivityTaskHeartbeatRequest{
+	_, err := s.mockHistoryEngine.RecordActivityTaskHeartbeat(s.callContext, &history.RecordActivityTaskHeartbeatRequest{
 		DomainUUID: common.StringPtr(domainID),
 		HeartbeatRequest: &workflow.RecordActivityTaskHeartbeatRequest{
 			TaskToken: taskToken,
@@ -1700,12 +3084,12 @@ func (s *engineSuite) TestRecordActivityTaskHeartBeatSuccess_TimerRunning() {
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
 	// HeartBeat timer running.
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
 	detais := []byte("details")
 
-	_, err := s.mockHistoryEngine.RecordActivityTaskHeartbeat(&history.RecordActivityTaskHeartbeatRequest{
+	_, err := s.mockHistoryEngine.RecordActivityTaskHeartbeat(s.callContext, &history.RecordActivityTaskHeartbeatRequest{
 		DomainUUID: common.StringPtr(domainID),
 		HeartbeatRequest: &workflow.RecordActivityTaskHeartbeatRequest{
 			TaskToken: taskToken,
@@ -1750,9 +3134,9 @@ func (s *engineSuite) TestRespondActivityTaskCanceled_Scheduled() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskCanceled(&history.RespondActivityTaskCanceledRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskCanceled(s.callContext, &history.RespondActivityTaskCanceledRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CancelRequest: &workflow.RespondActivityTaskCanceledRequest{
 			TaskToken: taskToken,
@@ -1795,11 +3179,11 @@ func (s *engineSuite) TestRespondActivityTaskCanceled_Started() {
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondActivityTaskCanceled(&history.RespondActivityTaskCanceledRequest{
+	err := s.mockHistoryEngine.RespondActivityTaskCanceled(s.callContext, &history.RespondActivityTaskCanceledRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CancelRequest: &workflow.RespondActivityTaskCanceledRequest{
 			TaskToken: taskToken,
@@ -1851,11 +3235,11 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_NotSchedule
 	ms := createMutableState(msBuilder)
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -1910,11 +3294,11 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_Scheduled()
 		},
 	}}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -1971,11 +3355,11 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_NoHeartBeat
 		},
 	}}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -1993,7 +3377,7 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_NoHeartBeat
 	s.False(executionBuilder.HasPendingDecisionTask())
 
 	// Try recording activity heartbeat
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
 	activityTaskToken, _ := json.Marshal(&common.TaskToken{
 		WorkflowID: "wId",
@@ -2001,7 +3385,7 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_NoHeartBeat
 		ScheduleID: 5,
 	})
 
-	hbResponse, err := s.mockHistoryEngine.RecordActivityTaskHeartbeat(&history.RecordActivityTaskHeartbeatRequest{
+	hbResponse, err := s.mockHistoryEngine.RecordActivityTaskHeartbeat(s.callContext, &history.RecordActivityTaskHeartbeatRequest{
 		DomainUUID: common.StringPtr(domainID),
 		HeartbeatRequest: &workflow.RecordActivityTaskHeartbeatRequest{
 			TaskToken: activityTaskToken,
@@ -2014,10 +3398,10 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_NoHeartBeat
 	s.True(hbResponse.GetCancelRequested())
 
 	// Try cancelling the request.
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err = s.mockHistoryEngine.RespondActivityTaskCanceled(&history.RespondActivityTaskCanceledRequest{
+	err = s.mockHistoryEngine.RespondActivityTaskCanceled(s.callContext, &history.RespondActivityTaskCanceledRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CancelRequest: &workflow.RespondActivityTaskCanceledRequest{
 			TaskToken: activityTaskToken,
@@ -2073,11 +3457,11 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_Success() {
 		},
 	}}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -2095,7 +3479,7 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_Success() {
 	s.False(executionBuilder.HasPendingDecisionTask())
 
 	// Try recording activity heartbeat
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
 	activityTaskToken, _ := json.Marshal(&common.TaskToken{
 		WorkflowID: "wId",
@@ -2103,7 +3487,7 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_Success() {
 		ScheduleID: 5,
 	})
 
-	hbResponse, err := s.mockHistoryEngine.RecordActivityTaskHeartbeat(&history.RecordActivityTaskHeartbeatRequest{
+	hbResponse, err := s.mockHistoryEngine.RecordActivityTaskHeartbeat(s.callContext, &history.RecordActivityTaskHeartbeatRequest{
 		DomainUUID: common.StringPtr(domainID),
 		HeartbeatRequest: &workflow.RecordActivityTaskHeartbeatRequest{
 			TaskToken: activityTaskToken,
@@ -2116,10 +3500,10 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_Success() {
 	s.True(hbResponse.GetCancelRequested())
 
 	// Try cancelling the request.
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err = s.mockHistoryEngine.RespondActivityTaskCanceled(&history.RespondActivityTaskCanceledRequest{
+	err = s.mockHistoryEngine.RespondActivityTaskCanceled(s.callContext, &history.RespondActivityTaskCanceledRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CancelRequest: &workflow.RespondActivityTaskCanceledRequest{
 			TaskToken: activityTaskToken,
@@ -2136,6 +3520,79 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_Success() {
 	s.True(executionBuilder.HasPendingDecisionTask())
 }
 
+func (s *engineSuite) TestRequestCancelExternal_RespondDecisionTaskCompleted() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+	targetRunID := uuid.New()
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	scheduleEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, scheduleEvent.GetEventId(), tl, identity)
+
+	decisions := []*workflow.Decision{{
+		DecisionType: workflow.DecisionTypePtr(workflow.DecisionType_RequestCancelExternalWorkflowExecution),
+		RequestCancelExternalWorkflowExecutionDecisionAttributes: &workflow.RequestCancelExternalWorkflowExecutionDecisionAttributes{
+			Domain:     common.StringPtr("targetDomain"),
+			WorkflowId: common.StringPtr("targetWorkflowId"),
+			RunId:      common.StringPtr(targetRunID),
+		},
+	}}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: "targetDomainId"},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once().Run(
+		func(args mock.Arguments) {
+			updateRequest = args.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+		})
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+
+	// The initiated cancellation is persisted with mutable state so the transfer task can be re-driven from it
+	s.NotNil(updateRequest)
+	s.Equal(1, len(updateRequest.UpsertRequestCancelInfos))
+	initiatedID := updateRequest.UpsertRequestCancelInfos[0].InitiatedID
+	s.Equal(int64(5), initiatedID)
+	s.NotEmpty(updateRequest.UpsertRequestCancelInfos[0].CancelRequestID)
+	executionBuilder := s.getBuilder(domainID, we)
+	ri, isPending := executionBuilder.GetRequestCancelInfo(initiatedID)
+	s.True(isPending)
+	s.Equal(updateRequest.UpsertRequestCancelInfos[0].CancelRequestID, ri.CancelRequestID)
+
+	s.NotNil(executionBuilder.AddExternalWorkflowExecutionCancelRequested(initiatedID, "targetDomainId",
+		"targetWorkflowId", targetRunID))
+	_, isPending = executionBuilder.GetRequestCancelInfo(initiatedID)
+	s.False(isPending)
+	s.Nil(executionBuilder.AddExternalWorkflowExecutionCancelRequested(initiatedID, "targetDomainId",
+		"targetWorkflowId", targetRunID))
+}
+
 func (s *engineSuite) TestUserTimer_RespondDecisionTaskCompleted() {
 	domainID := "domainId"
 	we := workflow.WorkflowExecution{
@@ -2172,11 +3629,11 @@ func (s *engineSuite) TestUserTimer_RespondDecisionTaskCompleted() {
 		},
 	}}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -2225,11 +3682,11 @@ func (s *engineSuite) TestCancelTimer_RespondDecisionTaskCompleted_NoStartTimer(
 		},
 	}}
 
-	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 
-	err := s.mockHistoryEngine.RespondDecisionTaskCompleted(&history.RespondDecisionTaskCompletedRequest{
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(s.callContext, &history.RespondDecisionTaskCompletedRequest{
 		DomainUUID: common.StringPtr(domainID),
 		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
 			TaskToken:        taskToken,
@@ -2248,7 +3705,7 @@ func (s *engineSuite) TestCancelTimer_RespondDecisionTaskCompleted_NoStartTimer(
 }
 
 func (s *engineSuite) getBuilder(domainID string, we workflow.WorkflowExecution) *mutableStateBuilder {
-	context, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecution(domainID, we)
+	context, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecution(s.callContext, domainID, we)
 	if err != nil {
 		return nil
 	}
@@ -2300,6 +3757,181 @@ func (s *engineSuite) printHistory(builder *mutableStateBuilder) string {
 	return history.String()
 }
 
+func (s *engineSuite) TestTerminateWorkflowExecutionChildPolicyTerminate() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	childExecution := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("childWId"),
+		RunId:      common.StringPtr("childRId"),
+	}
+	grandChildExecution := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("grandChildWId"),
+		RunId:      common.StringPtr("grandChildRId"),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	initiatedEvent, _ := msBuilder.AddStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEvent.GetEventId(),
+		uuid.New(), &workflow.StartChildWorkflowExecutionDecisionAttributes{
+			Domain:       common.StringPtr("childDomain"),
+			WorkflowId:   common.StringPtr(childExecution.GetWorkflowId()),
+			WorkflowType: &workflow.WorkflowType{Name: common.StringPtr("childType")},
+			TaskList:     &workflow.TaskList{Name: common.StringPtr(tl)},
+		}, nil)
+	msBuilder.AddChildWorkflowExecutionStartedEvent("childDomain", childExecution,
+		&workflow.WorkflowType{Name: common.StringPtr("childType")}, initiatedEvent.GetEventId())
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: "childDomainId", Name: "childDomain"},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil).Once()
+	s.mockHistoryClient.On("TerminateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *history.TerminateWorkflowExecutionRequest) bool {
+			return request.GetDomainUUID() == "childDomainId" &&
+				request.GetTerminateRequest().GetWorkflowExecution().GetWorkflowId() == childExecution.GetWorkflowId() &&
+				request.GetTerminateRequest().GetChildPolicy() == workflow.ChildPolicy_TERMINATE
+		})).Return(&workflow.TerminateWorkflowExecutionResponse{
+		AffectedExecutions: []*workflow.WorkflowExecution{childExecution, grandChildExecution},
+	}, nil).Once()
+
+	resp, err := s.mockHistoryEngine.TerminateWorkflowExecution(s.callContext, &history.TerminateWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		TerminateRequest: &workflow.TerminateWorkflowExecutionRequest{
+			Domain:            common.StringPtr("domain"),
+			WorkflowExecution: &we,
+			Reason:            common.StringPtr("reason"),
+			Identity:          common.StringPtr(identity),
+			ChildPolicy:       workflow.ChildPolicyPtr(workflow.ChildPolicy_TERMINATE),
+		},
+	})
+	s.Nil(err)
+	s.Equal([]*workflow.WorkflowExecution{&we, childExecution, grandChildExecution}, resp.GetAffectedExecutions())
+	s.mockHistoryClient.AssertExpectations(s.T())
+
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(persistence.WorkflowStateCompleted, executionBuilder.executionInfo.State)
+	s.Equal(persistence.WorkflowCloseStatusTerminated, executionBuilder.executionInfo.CloseStatus)
+}
+
+func (s *engineSuite) TestTerminateWorkflowExecutionChildPolicyUnset() {
+	domainID := "domainId"
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr("rId"),
+	}
+	childExecution := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("childWId"),
+		RunId:      common.StringPtr("childRId"),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, decisionScheduledEvent.GetEventId(), tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	initiatedEvent, _ := msBuilder.AddStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEvent.GetEventId(),
+		uuid.New(), &workflow.StartChildWorkflowExecutionDecisionAttributes{
+			Domain:       common.StringPtr("childDomain"),
+			WorkflowId:   common.StringPtr(childExecution.GetWorkflowId()),
+			WorkflowType: &workflow.WorkflowType{Name: common.StringPtr("childType")},
+			TaskList:     &workflow.TaskList{Name: common.StringPtr(tl)},
+		}, nil)
+	msBuilder.AddChildWorkflowExecutionStartedEvent("childDomain", childExecution,
+		&workflow.WorkflowType{Name: common.StringPtr("childType")}, initiatedEvent.GetEventId())
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+
+	// Without a child policy only the execution itself is terminated
+	resp, err := s.mockHistoryEngine.TerminateWorkflowExecution(s.callContext, &history.TerminateWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		TerminateRequest: &workflow.TerminateWorkflowExecutionRequest{
+			Domain:            common.StringPtr("domain"),
+			WorkflowExecution: &we,
+			Reason:            common.StringPtr("reason"),
+			Identity:          common.StringPtr(identity),
+		},
+	})
+	s.Nil(err)
+	s.Equal([]*workflow.WorkflowExecution{&we}, resp.GetAffectedExecutions())
+	s.mockHistoryClient.AssertNotCalled(s.T(), "TerminateWorkflowExecution", mock.Anything, mock.Anything)
+	s.mockHistoryClient.AssertNotCalled(s.T(), "RequestCancelWorkflowExecution", mock.Anything, mock.Anything)
+
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(persistence.WorkflowStateCompleted, executionBuilder.executionInfo.State)
+	s.Equal(persistence.WorkflowCloseStatusTerminated, executionBuilder.executionInfo.CloseStatus)
+}
+
+func (s *engineSuite) TestUpdateQueueProcessing() {
+	txProcessor := s.mockHistoryEngine.txProcessor.(*transferQueueProcessorImpl)
+	timerProcessor := s.mockHistoryEngine.timerProcessor.(*timerQueueProcessors).queues[0]
+	s.mockShardManager.On("UpdateShard", mock.Anything, mock.Anything).Return(nil)
+
+	err := s.mockHistoryEngine.UpdateQueueProcessing(s.callContext, &history.UpdateQueueProcessingRequest{
+		ShardId:   common.Int32Ptr(1),
+		QueueType: history.QueueTypePtr(history.QueueType_TRANSFER),
+		Paused:    common.BoolPtr(true),
+	})
+	s.Nil(err)
+	s.Equal(int32(1), atomic.LoadInt32(&txProcessor.isPaused))
+	s.Equal(int32(0), atomic.LoadInt32(&timerProcessor.isPaused))
+
+	err = s.mockHistoryEngine.UpdateQueueProcessing(s.callContext, &history.UpdateQueueProcessingRequest{
+		ShardId:   common.Int32Ptr(1),
+		QueueType: history.QueueTypePtr(history.QueueType_TIMER),
+		Paused:    common.BoolPtr(true),
+	})
+	s.Nil(err)
+	s.Equal(int32(1), atomic.LoadInt32(&timerProcessor.isPaused))
+
+	err = s.mockHistoryEngine.UpdateQueueProcessing(s.callContext, &history.UpdateQueueProcessingRequest{
+		ShardId:   common.Int32Ptr(1),
+		QueueType: history.QueueTypePtr(history.QueueType_TRANSFER),
+		Paused:    common.BoolPtr(false),
+	})
+	s.Nil(err)
+	s.Equal(int32(0), atomic.LoadInt32(&txProcessor.isPaused))
+	s.Equal(int32(1), atomic.LoadInt32(&timerProcessor.isPaused))
+
+	shard := s.mockHistoryEngine.shard
+	s.True(isQueuePaused(shard, history.QueueType_TIMER, false))
+	s.False(isQueuePaused(shard, history.QueueType_TRANSFER, true))
+	paused, ok := shard.GetQueuePaused(history.QueueType_TIMER.String())
+	s.True(ok)
+	s.True(paused)
+
+	err = s.mockHistoryEngine.UpdateQueueProcessing(s.callContext, &history.UpdateQueueProcessingRequest{
+		ShardId:   common.Int32Ptr(1),
+		QueueType: history.QueueTypePtr(history.QueueType(10)),
+		Paused:    common.BoolPtr(false),
+	})
+	s.IsType(&workflow.BadRequestError{}, err)
+
+	s.mockShardManager.AssertNumberOfCalls(s.T(), "UpdateShard", 3)
+}
+
 func addWorkflowExecutionStartedEvent(builder *mutableStateBuilder, workflowExecution workflow.WorkflowExecution,
 	workflowType, taskList string, input []byte, executionStartToCloseTimeout, taskStartToCloseTimeout int32,
 	identity string) *workflow.HistoryEvent {
@@ -2356,7 +3988,7 @@ func addActivityTaskScheduledEvent(builder *mutableStateBuilder, decisionComplet
 		ScheduleToCloseTimeoutSeconds: common.Int32Ptr(timeout),
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(queueTimeout),
 		HeartbeatTimeoutSeconds:       common.Int32Ptr(hearbeatTimeout),
-	})
+	}, nil)
 }
 
 func addActivityTaskStartedEvent(builder *mutableStateBuilder, scheduleID int64,
@@ -2402,7 +4034,7 @@ func addCompleteWorkflowEvent(builder *mutableStateBuilder, decisionCompletedEve
 	result []byte) *workflow.HistoryEvent {
 	e := builder.AddCompletedWorkflowEvent(decisionCompletedEventID, &workflow.CompleteWorkflowExecutionDecisionAttributes{
 		Result_: result,
-	})
+	}, nil)
 
 	return e
 }
@@ -2417,10 +4049,48 @@ func createMutableState(builder *mutableStateBuilder) *persistence.WorkflowMutab
 	for id, info := range builder.pendingTimerInfoIDs {
 		timerInfos[id] = copyTimerInfo(info)
 	}
+	childInfos := make(map[int64]*persistence.ChildExecutionInfo)
+	for id, info := range builder.pendingChildExecutionInfoIDs {
+		childInfos[id] = copyChildInfo(info)
+	}
+	cancelInfos := make(map[int64]*persistence.RequestCancelInfo)
+	for id, info := range builder.pendingRequestCancelInfoIDs {
+		cancelInfos[id] = copyRequestCancelInfo(info)
+	}
+	bufferedEvents := append([]*persistence.SerializedHistoryEventBatch{}, builder.bufferedEvents...)
+	if len(builder.hBuilder.buffered) > 0 {
+		serializedEvents, _ := builder.hBuilder.SerializeBuffered()
+		bufferedEvents = append(bufferedEvents, serializedEvents)
+	}
+	signalReceipts := make(map[string]int64)
+	for requestID, eventID := range builder.signalReceipts {
+		signalReceipts[requestID] = eventID
+	}
 	return &persistence.WorkflowMutableState{
-		ExecutionInfo: info,
-		ActivitInfos:  activityInfos,
-		TimerInfos:    timerInfos,
+		ExecutionInfo:       info,
+		ActivitInfos:        activityInfos,
+		TimerInfos:          timerInfos,
+		ChildExecutionInfos: childInfos,
+		RequestCancelInfos:  cancelInfos,
+		BufferedEvents:      bufferedEvents,
+		SignalReceipts:      signalReceipts,
+	}
+}
+
+func copyRequestCancelInfo(sourceInfo *persistence.RequestCancelInfo) *persistence.RequestCancelInfo {
+	return &persistence.RequestCancelInfo{
+		InitiatedID:     sourceInfo.InitiatedID,
+		CancelRequestID: sourceInfo.CancelRequestID,
+	}
+}
+
+func copyChildInfo(sourceInfo *persistence.ChildExecutionInfo) *persistence.ChildExecutionInfo {
+	return &persistence.ChildExecutionInfo{
+		InitiatedID:     sourceInfo.InitiatedID,
+		InitiatedEvent:  sourceInfo.InitiatedEvent,
+		StartedID:       sourceInfo.StartedID,
+		StartedEvent:    sourceInfo.StartedEvent,
+		CreateRequestID: sourceInfo.CreateRequestID,
 	}
 }
 
@@ -2448,6 +4118,10 @@ func copyWorkflowExecutionInfo(sourceInfo *persistence.WorkflowExecutionInfo) *p
 		DecisionStartedID:    sourceInfo.DecisionStartedID,
 		DecisionRequestID:    sourceInfo.DecisionRequestID,
 		DecisionTimeout:      sourceInfo.DecisionTimeout,
+		SignalCount:          sourceInfo.SignalCount,
+		ActivityCount:        sourceInfo.ActivityCount,
+		TimerCount:           sourceInfo.TimerCount,
+		DecisionCount:        sourceInfo.DecisionCount,
 	}
 }
 
@@ -2457,6 +4131,7 @@ func copyActivityInfo(sourceInfo *persistence.ActivityInfo) *persistence.Activit
 		ScheduledEvent:         sourceInfo.ScheduledEvent,
 		StartedID:              sourceInfo.StartedID,
 		StartedEvent:           sourceInfo.StartedEvent,
+		StartedTime:            sourceInfo.StartedTime,
 		ActivityID:             sourceInfo.ActivityID,
 		RequestID:              sourceInfo.RequestID,
 		Details:                sourceInfo.Details,
@@ -2466,6 +4141,7 @@ func copyActivityInfo(sourceInfo *persistence.ActivityInfo) *persistence.Activit
 		HeartbeatTimeout:       sourceInfo.HeartbeatTimeout,
 		CancelRequested:        sourceInfo.CancelRequested,
 		CancelRequestID:        sourceInfo.CancelRequestID,
+		DispatchDeferred:       sourceInfo.DispatchDeferred,
 	}
 }
 
@@ -0,0 +1,81 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"math"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	// requestPriority orders the API calls which can be shed when a shard is overloaded, lowest priority first
+	requestPriority int
+
+	// loadShedder decides which requests to an overloaded shard are rejected with ServiceBusyError.  Rather than
+	// letting every operation degrade uniformly, activity heartbeats are shed first and task starts on behalf of
+	// pollers next, so that the capacity left goes to the calls which make progress on workflows.
+	loadShedder struct {
+		config *Config
+	}
+)
+
+const (
+	priorityHeartbeat requestPriority = iota
+	priorityPoll
+)
+
+var errShardOverloaded = &gen.ServiceBusyError{Message: "Shard is overloaded, retry later."}
+
+func newLoadShedder(config *Config) *loadShedder {
+	return &loadShedder{
+		config: config,
+	}
+}
+
+// shouldShed returns true if a request of the given priority to the shard has to be rejected
+func (l *loadShedder) shouldShed(shard ShardContext, priority requestPriority) bool {
+	load := l.getLoad(shard)
+	switch priority {
+	case priorityHeartbeat:
+		return load > 1
+	case priorityPoll:
+		return load > math.Max(l.config.LoadSheddingPollOverloadFactor, 1)
+	}
+
+	return false
+}
+
+// getLoad returns the load of the shard relative to the configured thresholds, a value above 1 means the shard is
+// overloaded
+func (l *loadShedder) getLoad(shard ShardContext) float64 {
+	load := 0.0
+	if l.config.LoadSheddingTransferQueueDepth > 0 {
+		load = math.Max(load,
+			float64(shard.GetTransferQueueDepth())/float64(l.config.LoadSheddingTransferQueueDepth))
+	}
+	if l.config.LoadSheddingPersistenceLatency > 0 {
+		load = math.Max(load,
+			float64(shard.GetPersistenceLatency())/float64(l.config.LoadSheddingPersistenceLatency))
+	}
+
+	return load
+}
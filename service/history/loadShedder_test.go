@@ -0,0 +1,103 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	loadShedderSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		config  *Config
+		shard   *shardContextImpl
+		shedder *loadShedder
+	}
+)
+
+func TestLoadShedderSuite(t *testing.T) {
+	s := new(loadShedderSuite)
+	suite.Run(t, s)
+}
+
+func (s *loadShedderSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.config = NewConfig()
+	s.config.LoadSheddingTransferQueueDepth = 100
+	s.config.LoadSheddingPersistenceLatency = 100 * time.Millisecond
+	s.config.LoadSheddingPollOverloadFactor = 2
+	s.shard = &shardContextImpl{}
+	s.shedder = newLoadShedder(s.config)
+}
+
+func (s *loadShedderSuite) TestShedByTransferQueueDepth() {
+	s.shard.transferQueueDepth = 100
+	s.False(s.shedder.shouldShed(s.shard, priorityHeartbeat))
+	s.False(s.shedder.shouldShed(s.shard, priorityPoll))
+
+	s.shard.transferQueueDepth = 150
+	s.True(s.shedder.shouldShed(s.shard, priorityHeartbeat))
+	s.False(s.shedder.shouldShed(s.shard, priorityPoll))
+
+	s.shard.transferQueueDepth = 250
+	s.True(s.shedder.shouldShed(s.shard, priorityHeartbeat))
+	s.True(s.shedder.shouldShed(s.shard, priorityPoll))
+}
+
+func (s *loadShedderSuite) TestTransferTaskProcessed() {
+	s.shard.firstTaskID = 1000
+	s.shard.transferQueueDepth = 2
+
+	// Tasks persisted by a previous owner were never counted
+	s.shard.TransferTaskProcessed(999)
+	s.Equal(int64(2), s.shard.GetTransferQueueDepth())
+
+	s.shard.TransferTaskProcessed(1000)
+	s.shard.TransferTaskProcessed(1001)
+	s.Equal(int64(0), s.shard.GetTransferQueueDepth())
+}
+
+func (s *loadShedderSuite) TestShedByPersistenceLatency() {
+	s.shard.persistenceLatency = int64(150 * time.Millisecond)
+	s.True(s.shedder.shouldShed(s.shard, priorityHeartbeat))
+	s.False(s.shedder.shouldShed(s.shard, priorityPoll))
+
+	s.shard.persistenceLatency = int64(250 * time.Millisecond)
+	s.True(s.shedder.shouldShed(s.shard, priorityPoll))
+
+	s.config.LoadSheddingPersistenceLatency = 0
+	s.False(s.shedder.shouldShed(s.shard, priorityHeartbeat))
+}
+
+func (s *loadShedderSuite) TestPersistenceLatencyMovingAverage() {
+	s.shard.persistenceLatency = int64(time.Second)
+	s.shard.recordPersistenceLatency(time.Now())
+	s.True(s.shard.GetPersistenceLatency() < time.Second)
+	s.True(s.shard.GetPersistenceLatency() > 800*time.Millisecond)
+}
@@ -21,13 +21,13 @@
 package history
 
 import (
-	"errors"
 	"fmt"
 	"time"
 
 	h "github.com/uber/cadence/.gen/go/history"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/errors"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/persistence"
 
@@ -37,6 +37,10 @@ import (
 
 const (
 	emptyUUID = "emptyUuid"
+
+	// maxSignalReceipts bounds the signal receipts kept by an execution, the oldest ones are evicted first.  A signal
+	// retried with the request ID of an evicted receipt is recorded again.
+	maxSignalReceipts = 1000
 )
 
 type (
@@ -54,6 +58,17 @@ type (
 		updateChildExecutionInfos    []*persistence.ChildExecutionInfo         // Modified ChildExecution Infos since last update
 		deleteChildExecutionInfo     *int64                                    // Deleted ChildExecution Info since last update
 
+		pendingRequestCancelInfoIDs map[int64]*persistence.RequestCancelInfo // Initiated Event ID -> RequestCancelInfo
+		updateRequestCancelInfos    []*persistence.RequestCancelInfo         // Modified RequestCancel Infos since last update
+		deleteRequestCancelInfo     *int64                                   // Deleted RequestCancel Info since last update
+
+		bufferedEvents      []*persistence.SerializedHistoryEventBatch // Events received while the decision was in flight.
+		clearBufferedEvents bool                                       // Buffered events flushed since last update.
+
+		signalReceipts       map[string]int64 // Signal Request ID -> Event ID of the signal, bufferedEventID until flushed.
+		updateSignalReceipts map[string]int64 // Signal receipts recorded since last update.
+		deleteSignalReceipts []string         // Signal receipts evicted since last update.
+
 		executionInfo   *persistence.WorkflowExecutionInfo // Workflow mutable state info.
 		continueAsNew   *persistence.CreateWorkflowExecutionRequest
 		hBuilder        *historyBuilder
@@ -69,6 +84,11 @@ type (
 		deleteTimerInfos          []string
 		updateChildExecutionInfos []*persistence.ChildExecutionInfo
 		deleteChildExecutionInfo  *int64
+		updateRequestCancelInfos  []*persistence.RequestCancelInfo
+		deleteRequestCancelInfo   *int64
+		clearBufferedEvents       bool
+		updateSignalReceipts      map[string]int64
+		deleteSignalReceipts      []string
 		continueAsNew             *persistence.CreateWorkflowExecutionRequest
 	}
 
@@ -78,6 +98,7 @@ type (
 		StartedID       int64
 		RequestID       string
 		DecisionTimeout int32
+		Attempt         int64
 	}
 )
 
@@ -91,6 +112,10 @@ func newMutableStateBuilder(logger bark.Logger) *mutableStateBuilder {
 		deleteTimerInfos:                []string{},
 		updateChildExecutionInfos:       []*persistence.ChildExecutionInfo{},
 		pendingChildExecutionInfoIDs:    make(map[int64]*persistence.ChildExecutionInfo),
+		updateRequestCancelInfos:        []*persistence.RequestCancelInfo{},
+		pendingRequestCancelInfoIDs:     make(map[int64]*persistence.RequestCancelInfo),
+		signalReceipts:                  make(map[string]int64),
+		updateSignalReceipts:            make(map[string]int64),
 		eventSerializer:                 newJSONHistoryEventSerializer(),
 		logger:                          logger,
 	}
@@ -109,6 +134,13 @@ func (e *mutableStateBuilder) Load(state *persistence.WorkflowMutableState) {
 	e.pendingActivityInfoIDs = state.ActivitInfos
 	e.pendingTimerInfoIDs = state.TimerInfos
 	e.pendingChildExecutionInfoIDs = state.ChildExecutionInfos
+	if state.RequestCancelInfos != nil {
+		e.pendingRequestCancelInfoIDs = state.RequestCancelInfos
+	}
+	e.bufferedEvents = state.BufferedEvents
+	if state.SignalReceipts != nil {
+		e.signalReceipts = state.SignalReceipts
+	}
 	e.executionInfo = state.ExecutionInfo
 	for _, ai := range state.ActivitInfos {
 		e.pendingActivityInfoByActivityID[ai.ActivityID] = ai.ScheduleID
@@ -124,6 +156,11 @@ func (e *mutableStateBuilder) CloseUpdateSession() *mutableStateSessionUpdates {
 		deleteTimerInfos:          e.deleteTimerInfos,
 		updateChildExecutionInfos: e.updateChildExecutionInfos,
 		deleteChildExecutionInfo:  e.deleteChildExecutionInfo,
+		updateRequestCancelInfos:  e.updateRequestCancelInfos,
+		deleteRequestCancelInfo:   e.deleteRequestCancelInfo,
+		clearBufferedEvents:       e.clearBufferedEvents,
+		updateSignalReceipts:      e.updateSignalReceipts,
+		deleteSignalReceipts:      e.deleteSignalReceipts,
 		continueAsNew:             e.continueAsNew,
 	}
 
@@ -135,6 +172,11 @@ func (e *mutableStateBuilder) CloseUpdateSession() *mutableStateSessionUpdates {
 	e.deleteTimerInfos = []string{}
 	e.updateChildExecutionInfos = []*persistence.ChildExecutionInfo{}
 	e.deleteChildExecutionInfo = nil
+	e.updateRequestCancelInfos = []*persistence.RequestCancelInfo{}
+	e.deleteRequestCancelInfo = nil
+	e.clearBufferedEvents = false
+	e.updateSignalReceipts = make(map[string]int64)
+	e.deleteSignalReceipts = nil
 	e.continueAsNew = nil
 
 	return updates
@@ -148,10 +190,67 @@ func (e *mutableStateBuilder) createNewHistoryEvent(eventType workflow.EventType
 	historyEvent.Timestamp = ts
 	historyEvent.EventType = workflow.EventTypePtr(eventType)
 
+	if e.HasInFlightDecisionTask() && isBufferedEventType(eventType) {
+		// Buffer the event until the decision in flight completes, so it doesn't land between the started and
+		// completed events of the decision
+		historyEvent.EventId = common.Int64Ptr(bufferedEventID)
+		return historyEvent
+	}
+
 	e.executionInfo.NextEventID++
 	return historyEvent
 }
 
+// isBufferedEventType tells whether events of the type are buffered while a decision task is in flight
+func isBufferedEventType(eventType workflow.EventType) bool {
+	switch eventType {
+	case workflow.EventType_WorkflowExecutionSignaled,
+		workflow.EventType_ActivityTaskCompleted,
+		workflow.EventType_ActivityTaskFailed,
+		workflow.EventType_ActivityTaskTimedOut,
+		workflow.EventType_ActivityTaskCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasBufferedEvents tells whether events were received while the decision was in flight
+func (e *mutableStateBuilder) HasBufferedEvents() bool {
+	return len(e.bufferedEvents) > 0 || len(e.hBuilder.buffered) > 0
+}
+
+// FlushBufferedEvents appends the events buffered while the decision was in flight to the history, they get their
+// event IDs in the order they were received
+func (e *mutableStateBuilder) FlushBufferedEvents() error {
+	var events []*workflow.HistoryEvent
+	for _, batch := range e.bufferedEvents {
+		history, err := e.hBuilder.serializer.Deserialize(batch)
+		if err != nil {
+			return err
+		}
+		events = append(events, history.Events...)
+	}
+	events = append(events, e.hBuilder.buffered...)
+
+	for _, event := range events {
+		event.EventId = common.Int64Ptr(e.executionInfo.NextEventID)
+		e.executionInfo.NextEventID++
+		e.hBuilder.history = append(e.hBuilder.history, event)
+
+		if event.GetEventType() == workflow.EventType_WorkflowExecutionSignaled {
+			e.recordSignalReceipt(event)
+		}
+	}
+
+	if len(e.bufferedEvents) > 0 {
+		e.clearBufferedEvents = true
+	}
+	e.bufferedEvents = nil
+	e.hBuilder.buffered = nil
+	return nil
+}
+
 func (e *mutableStateBuilder) getWorkflowType() *workflow.WorkflowType {
 	wType := workflow.NewWorkflowType()
 	wType.Name = common.StringPtr(e.executionInfo.WorkflowTypeName)
@@ -226,6 +325,12 @@ func (e *mutableStateBuilder) GetChildExecutionStartedEvent(initiatedEventID int
 	return e.getHistoryEvent(ci.StartedEvent)
 }
 
+// GetRequestCancelInfo gives details about a request cancellation that is currently in progress.
+func (e *mutableStateBuilder) GetRequestCancelInfo(initiatedEventID int64) (*persistence.RequestCancelInfo, bool) {
+	ri, ok := e.pendingRequestCancelInfoIDs[initiatedEventID]
+	return ri, ok
+}
+
 // GetCompletionEvent retrieves the workflow completion event from mutable state
 func (e *mutableStateBuilder) GetCompletionEvent() (*workflow.HistoryEvent, bool) {
 	serializedEvent := e.executionInfo.CompletionEvent
@@ -243,7 +348,7 @@ func (e *mutableStateBuilder) DeletePendingChildExecution(initiatedEventID int64
 		errorMsg := fmt.Sprintf("Unable to find child execution with initiated event id: %v in mutable state",
 			initiatedEventID)
 		logging.LogMutableStateInvalidAction(e.logger, errorMsg)
-		return errors.New(errorMsg)
+		return errors.NewInternalServiceError("%v", errorMsg)
 	}
 	delete(e.pendingChildExecutionInfoIDs, initiatedEventID)
 
@@ -251,6 +356,42 @@ func (e *mutableStateBuilder) DeletePendingChildExecution(initiatedEventID int64
 	return nil
 }
 
+// DeletePendingRequestCancel deletes details about a request cancellation once it was delivered or failed.
+func (e *mutableStateBuilder) DeletePendingRequestCancel(initiatedEventID int64) error {
+	_, ok := e.pendingRequestCancelInfoIDs[initiatedEventID]
+	if !ok {
+		errorMsg := fmt.Sprintf("Unable to find request cancellation with initiated event id: %v in mutable state",
+			initiatedEventID)
+		logging.LogMutableStateInvalidAction(e.logger, errorMsg)
+		return errors.NewInternalServiceError("%v", errorMsg)
+	}
+	delete(e.pendingRequestCancelInfoIDs, initiatedEventID)
+	// An upsert in the same update would race the delete
+	for i, ri := range e.updateRequestCancelInfos {
+		if ri.InitiatedID == initiatedEventID {
+			e.updateRequestCancelInfos = append(e.updateRequestCancelInfos[:i], e.updateRequestCancelInfos[i+1:]...)
+			break
+		}
+	}
+
+	e.deleteRequestCancelInfo = common.Int64Ptr(initiatedEventID)
+	return nil
+}
+
+// RestorePendingRequestCancel adds the request cancellation of an initiated event which has no info in mutable state,
+// because it was initiated before request cancellations were kept in mutable state.
+func (e *mutableStateBuilder) RestorePendingRequestCancel(initiatedEventID int64,
+	cancelRequestID string) *persistence.RequestCancelInfo {
+	ri := &persistence.RequestCancelInfo{
+		InitiatedID:     initiatedEventID,
+		CancelRequestID: cancelRequestID,
+	}
+
+	e.pendingRequestCancelInfoIDs[initiatedEventID] = ri
+	e.updateRequestCancelInfos = append(e.updateRequestCancelInfos, ri)
+	return ri
+}
+
 func (e *mutableStateBuilder) writeCompletionEventToMutableState(completionEvent *workflow.HistoryEvent) error {
 	// First check to see if this is a Child Workflow
 	if e.hasParentExecution() {
@@ -282,13 +423,20 @@ func (e *mutableStateBuilder) updateActivityProgress(ai *persistence.ActivityInf
 	e.updateActivityInfos = append(e.updateActivityInfos, ai)
 }
 
+// markActivityDispatched clears the deferred dispatch of an activity once an activity task continuation wrote its
+// tasks.
+func (e *mutableStateBuilder) markActivityDispatched(ai *persistence.ActivityInfo) {
+	ai.DispatchDeferred = false
+	e.updateActivityInfos = append(e.updateActivityInfos, ai)
+}
+
 // DeleteActivity deletes details about an activity.
 func (e *mutableStateBuilder) DeleteActivity(scheduleEventID int64) error {
 	a, ok := e.pendingActivityInfoIDs[scheduleEventID]
 	if !ok {
 		errorMsg := fmt.Sprintf("Unable to find activity with schedule event id: %v in mutable state", scheduleEventID)
 		logging.LogMutableStateInvalidAction(e.logger, errorMsg)
-		return errors.New(errorMsg)
+		return errors.NewInternalServiceError("%v", errorMsg)
 	}
 	delete(e.pendingActivityInfoIDs, scheduleEventID)
 
@@ -296,7 +444,7 @@ func (e *mutableStateBuilder) DeleteActivity(scheduleEventID int64) error {
 	if !ok {
 		errorMsg := fmt.Sprintf("Unable to find activity: %v in mutable state", a.ActivityID)
 		logging.LogMutableStateInvalidAction(e.logger, errorMsg)
-		return errors.New(errorMsg)
+		return errors.NewInternalServiceError("%v", errorMsg)
 	}
 	delete(e.pendingActivityInfoByActivityID, a.ActivityID)
 
@@ -322,7 +470,7 @@ func (e *mutableStateBuilder) DeleteUserTimer(timerID string) error {
 	if !ok {
 		errorMsg := fmt.Sprintf("Unable to find pending timer: %v", timerID)
 		logging.LogMutableStateInvalidAction(e.logger, errorMsg)
-		return errors.New(errorMsg)
+		return errors.NewInternalServiceError("%v", errorMsg)
 	}
 	delete(e.pendingTimerInfoIDs, timerID)
 
@@ -337,6 +485,7 @@ func (e *mutableStateBuilder) GetPendingDecision(scheduleEventID int64) (*decisi
 		StartedID:       e.executionInfo.DecisionStartedID,
 		RequestID:       e.executionInfo.DecisionRequestID,
 		DecisionTimeout: e.executionInfo.DecisionTimeout,
+		Attempt:         e.executionInfo.DecisionAttempt,
 	}
 	if scheduleEventID == di.ScheduleID {
 		return di, true
@@ -348,23 +497,32 @@ func (e *mutableStateBuilder) HasPendingDecisionTask() bool {
 	return e.executionInfo.DecisionScheduleID != emptyEventID
 }
 
+// HasInFlightDecisionTask tells whether a worker started the pending decision task
+func (e *mutableStateBuilder) HasInFlightDecisionTask() bool {
+	return e.executionInfo.DecisionStartedID != emptyEventID
+}
+
 // UpdateDecision updates a decision task.
 func (e *mutableStateBuilder) UpdateDecision(di *decisionInfo) {
 	e.executionInfo.DecisionScheduleID = di.ScheduleID
 	e.executionInfo.DecisionStartedID = di.StartedID
 	e.executionInfo.DecisionRequestID = di.RequestID
 	e.executionInfo.DecisionTimeout = di.DecisionTimeout
+	e.executionInfo.DecisionAttempt = di.Attempt
 }
 
-// DeleteDecision deletes a decision task.
+// DeleteDecision deletes a decision task.  The attempt count is kept so it carries over to the next decision.
 func (e *mutableStateBuilder) DeleteDecision() {
 	emptyDecisionInfo := &decisionInfo{
 		ScheduleID:      emptyEventID,
 		StartedID:       emptyEventID,
 		RequestID:       emptyUUID,
 		DecisionTimeout: 0,
+		Attempt:         e.executionInfo.DecisionAttempt,
 	}
 	e.UpdateDecision(emptyDecisionInfo)
+	e.executionInfo.DecisionScheduledTime = time.Time{}
+	e.executionInfo.DecisionStartedTime = time.Time{}
 }
 
 // GetNextEventID returns next event ID
@@ -387,7 +545,8 @@ func (e *mutableStateBuilder) getHistoryEvent(serializedEvent []byte) (*workflow
 
 func (e *mutableStateBuilder) AddWorkflowExecutionStartedEventForContinueAsNew(domainID string,
 	execution workflow.WorkflowExecution, previousExecutionState *mutableStateBuilder,
-	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
+	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes,
+	payloadReference *workflow.PayloadReference) *workflow.HistoryEvent {
 	taskList := previousExecutionState.executionInfo.TaskList
 	if attributes.IsSetTaskList() {
 		taskList = attributes.GetTaskList().GetName()
@@ -415,8 +574,10 @@ func (e *mutableStateBuilder) AddWorkflowExecutionStartedEventForContinueAsNew(d
 		WorkflowType:                        wType,
 		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(decisionTimeout),
 		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(attributes.GetExecutionStartToCloseTimeoutSeconds()),
-		Input:    attributes.GetInput(),
-		Identity: nil,
+		Input:            attributes.GetInput(),
+		Identity:         nil,
+		Header:           attributes.Header,
+		PayloadReference: payloadReference,
 	}
 
 	return e.AddWorkflowExecutionStartedEvent(domainID, execution, createRequest)
@@ -445,6 +606,7 @@ func (e *mutableStateBuilder) AddWorkflowExecutionStartedEvent(domainID string,
 	e.executionInfo.DecisionStartedID = emptyEventID
 	e.executionInfo.DecisionRequestID = emptyUUID
 	e.executionInfo.DecisionTimeout = 0
+	e.executionInfo.DecisionAttempt = 0
 
 	return e.hBuilder.AddWorkflowExecutionStartedEvent(request)
 }
@@ -465,8 +627,10 @@ func (e *mutableStateBuilder) AddDecisionTaskScheduledEvent() (*workflow.History
 		StartedID:       emptyEventID,
 		RequestID:       emptyUUID,
 		DecisionTimeout: startToCloseTimeoutSeconds,
+		Attempt:         e.executionInfo.DecisionAttempt,
 	}
 	e.UpdateDecision(di)
+	e.executionInfo.DecisionScheduledTime = time.Unix(0, newDecisionEvent.GetTimestamp())
 
 	return newDecisionEvent, di
 }
@@ -486,6 +650,7 @@ func (e *mutableStateBuilder) AddDecisionTaskStartedEvent(scheduleEventID int64,
 	// Update mutable decision state
 	e.executionInfo.DecisionStartedID = event.GetEventId()
 	e.executionInfo.DecisionRequestID = requestID
+	e.executionInfo.DecisionStartedTime = time.Unix(0, event.GetTimestamp())
 	e.executionInfo.State = persistence.WorkflowStateRunning
 
 	return event
@@ -504,12 +669,18 @@ func (e *mutableStateBuilder) AddDecisionTaskCompletedEvent(scheduleEventID, sta
 	event := e.hBuilder.AddDecisionTaskCompletedEvent(scheduleEventID, startedEventID, request)
 
 	e.executionInfo.LastProcessedEvent = startedEventID
+	e.executionInfo.DecisionCount++
 	e.DeleteDecision()
+	e.executionInfo.DecisionAttempt = 0
+	if request.IsSetBinaryChecksum() {
+		// Following decisions are only dispatched to workers compatible with this build
+		e.executionInfo.BuildID = request.GetBinaryChecksum()
+	}
 	return event
 }
 
 func (e *mutableStateBuilder) AddDecisionTaskTimedOutEvent(scheduleEventID int64,
-	startedEventID int64) *workflow.HistoryEvent {
+	startedEventID int64, timeoutType workflow.TimeoutType) *workflow.HistoryEvent {
 	hasPendingDecision := e.HasPendingDecisionTask()
 	pendingDecisionTask, ok := e.GetPendingDecision(scheduleEventID)
 	if !hasPendingDecision || !ok || pendingDecisionTask.StartedID != startedEventID {
@@ -519,9 +690,17 @@ func (e *mutableStateBuilder) AddDecisionTaskTimedOutEvent(scheduleEventID int64
 		return nil
 	}
 
-	event := e.hBuilder.AddDecisionTaskTimedOutEvent(scheduleEventID, startedEventID)
+	event := e.hBuilder.AddDecisionTaskTimedOutEvent(scheduleEventID, startedEventID, timeoutType)
 
 	e.DeleteDecision()
+	if timeoutType == workflow.TimeoutType_START_TO_CLOSE {
+		// Decision was picked up by a worker which failed to respond in time
+		e.executionInfo.DecisionAttempt++
+	}
+	if err := e.FlushBufferedEvents(); err != nil {
+		logging.LogHistorySerializationErrorEvent(e.logger, err, "Unable to flush buffered events.")
+		return nil
+	}
 	return event
 }
 
@@ -540,11 +719,17 @@ func (e *mutableStateBuilder) AddDecisionTaskFailedEvent(scheduleEventID int64,
 	event := e.hBuilder.AddDecisionTaskFailedEvent(scheduleEventID, startedEventID, cause, request)
 
 	e.DeleteDecision()
+	e.executionInfo.DecisionAttempt++
+	if err := e.FlushBufferedEvents(); err != nil {
+		logging.LogHistorySerializationErrorEvent(e.logger, err, "Unable to flush buffered events.")
+		return nil
+	}
 	return event
 }
 
 func (e *mutableStateBuilder) AddActivityTaskScheduledEvent(decisionCompletedEventID int64,
-	attributes *workflow.ScheduleActivityTaskDecisionAttributes) (*workflow.HistoryEvent, *persistence.ActivityInfo) {
+	attributes *workflow.ScheduleActivityTaskDecisionAttributes,
+	payloadReference *workflow.PayloadReference) (*workflow.HistoryEvent, *persistence.ActivityInfo) {
 	if ai, ok := e.GetActivityInfo(e.GetNextEventID()); ok {
 		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionActivityTaskScheduled, ai.ScheduleID, fmt.Sprintf(
 			"{Exist: %v, Value: %v}", ok, ai.StartedID))
@@ -552,6 +737,7 @@ func (e *mutableStateBuilder) AddActivityTaskScheduledEvent(decisionCompletedEve
 	}
 
 	event := e.hBuilder.AddActivityTaskScheduledEvent(decisionCompletedEventID, attributes)
+	event.PayloadReference = payloadReference
 
 	scheduleEvent, err := e.eventSerializer.Serialize(event)
 	if err != nil {
@@ -585,11 +771,14 @@ func (e *mutableStateBuilder) AddActivityTaskScheduledEvent(decisionCompletedEve
 		CancelRequested:          false,
 		CancelRequestID:          emptyEventID,
 		LastHeartBeatUpdatedTime: time.Time{},
+		Priority:                 attributes.GetPriority(),
+		SessionID:                attributes.GetSessionId(),
 	}
 
 	e.pendingActivityInfoIDs[scheduleEventID] = ai
 	e.pendingActivityInfoByActivityID[ai.ActivityID] = scheduleEventID
 	e.updateActivityInfos = append(e.updateActivityInfos, ai)
+	e.executionInfo.ActivityCount++
 
 	return event, ai
 }
@@ -605,6 +794,7 @@ func (e *mutableStateBuilder) AddActivityTaskStartedEvent(ai *persistence.Activi
 	event := e.hBuilder.AddActivityTaskStartedEvent(scheduleEventID, requestID, request)
 
 	ai.StartedID = event.GetEventId()
+	ai.StartedTime = time.Unix(0, event.GetTimestamp())
 	ai.RequestID = requestID
 	e.updateActivityInfos = append(e.updateActivityInfos, ai)
 
@@ -711,7 +901,8 @@ func (e *mutableStateBuilder) AddActivityTaskCanceledEvent(scheduleEventID, star
 }
 
 func (e *mutableStateBuilder) AddCompletedWorkflowEvent(decisionCompletedEventID int64,
-	attributes *workflow.CompleteWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
+	attributes *workflow.CompleteWorkflowExecutionDecisionAttributes,
+	payloadReference *workflow.PayloadReference) *workflow.HistoryEvent {
 	if e.executionInfo.State == persistence.WorkflowStateCompleted {
 		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionCompleteWorkflow, e.GetNextEventID(), fmt.Sprintf(
 			"{State: %v}", e.executionInfo.State))
@@ -720,13 +911,15 @@ func (e *mutableStateBuilder) AddCompletedWorkflowEvent(decisionCompletedEventID
 	e.executionInfo.State = persistence.WorkflowStateCompleted
 	e.executionInfo.CloseStatus = persistence.WorkflowCloseStatusCompleted
 	event := e.hBuilder.AddCompletedWorkflowEvent(decisionCompletedEventID, attributes)
+	event.PayloadReference = payloadReference
 	e.writeCompletionEventToMutableState(event)
 
 	return event
 }
 
 func (e *mutableStateBuilder) AddFailWorkflowEvent(decisionCompletedEventID int64,
-	attributes *workflow.FailWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
+	attributes *workflow.FailWorkflowExecutionDecisionAttributes,
+	payloadReference *workflow.PayloadReference) *workflow.HistoryEvent {
 	if e.executionInfo.State == persistence.WorkflowStateCompleted {
 		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionFailWorkflow, e.GetNextEventID(), fmt.Sprintf(
 			"{State: %v}", e.executionInfo.State))
@@ -735,6 +928,7 @@ func (e *mutableStateBuilder) AddFailWorkflowEvent(decisionCompletedEventID int6
 	e.executionInfo.State = persistence.WorkflowStateCompleted
 	e.executionInfo.CloseStatus = persistence.WorkflowCloseStatusFailed
 	event := e.hBuilder.AddFailWorkflowEvent(decisionCompletedEventID, attributes)
+	event.PayloadReference = payloadReference
 	e.writeCompletionEventToMutableState(event)
 
 	return event
@@ -761,21 +955,60 @@ func (e *mutableStateBuilder) AddWorkflowExecutionCanceledEvent(decisionTaskComp
 }
 
 func (e *mutableStateBuilder) AddRequestCancelExternalWorkflowExecutionInitiatedEvent(decisionCompletedEventID int64,
-	request *workflow.RequestCancelExternalWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
-	return e.hBuilder.AddRequestCancelExternalWorkflowExecutionInitiatedEvent(
+	cancelRequestID string, request *workflow.RequestCancelExternalWorkflowExecutionDecisionAttributes) (
+	*workflow.HistoryEvent, *persistence.RequestCancelInfo) {
+	event := e.hBuilder.AddRequestCancelExternalWorkflowExecutionInitiatedEvent(
 		decisionCompletedEventID, request)
+	if event == nil {
+		return nil, nil
+	}
+
+	initiatedEventID := event.GetEventId()
+	ri := &persistence.RequestCancelInfo{
+		InitiatedID:     initiatedEventID,
+		CancelRequestID: cancelRequestID,
+	}
+
+	e.pendingRequestCancelInfoIDs[initiatedEventID] = ri
+	e.updateRequestCancelInfos = append(e.updateRequestCancelInfos, ri)
+
+	return event, ri
 }
 
 func (e *mutableStateBuilder) AddRequestCancelExternalWorkflowExecutionFailedEvent(
 	decisionTaskCompletedEventID, initiatedEventID int64,
 	domain, workflowID, runID string, cause workflow.CancelExternalWorkflowExecutionFailedCause) *workflow.HistoryEvent {
-	return e.hBuilder.AddRequestCancelExternalWorkflowExecutionFailedEvent(
+	_, ok := e.GetRequestCancelInfo(initiatedEventID)
+	if !ok {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionRequestCancelExternalFailed,
+			e.GetNextEventID(), fmt.Sprintf("{InitiatedID: %v, Exist: %v}", initiatedEventID, ok))
+		return nil
+	}
+
+	event := e.hBuilder.AddRequestCancelExternalWorkflowExecutionFailedEvent(
 		decisionTaskCompletedEventID, initiatedEventID, domain, workflowID, runID, cause)
+	if err := e.DeletePendingRequestCancel(initiatedEventID); err != nil {
+		return nil
+	}
+
+	return event
 }
 
 func (e *mutableStateBuilder) AddExternalWorkflowExecutionCancelRequested(initiatedEventID int64,
 	domain, workflowID, runID string) *workflow.HistoryEvent {
-	return e.hBuilder.AddExternalWorkflowExecutionCancelRequested(initiatedEventID, domain, workflowID, runID)
+	_, ok := e.GetRequestCancelInfo(initiatedEventID)
+	if !ok {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionExternalCancelRequested,
+			e.GetNextEventID(), fmt.Sprintf("{InitiatedID: %v, Exist: %v}", initiatedEventID, ok))
+		return nil
+	}
+
+	event := e.hBuilder.AddExternalWorkflowExecutionCancelRequested(initiatedEventID, domain, workflowID, runID)
+	if err := e.DeletePendingRequestCancel(initiatedEventID); err != nil {
+		return nil
+	}
+
+	return event
 }
 
 func (e *mutableStateBuilder) AddTimerStartedEvent(decisionCompletedEventID int64,
@@ -790,7 +1023,7 @@ func (e *mutableStateBuilder) AddTimerStartedEvent(decisionCompletedEventID int6
 
 	event := e.hBuilder.AddTimerStartedEvent(decisionCompletedEventID, request)
 
-	fireTimeout := time.Duration(request.GetStartToFireTimeoutSeconds()) * time.Second
+	fireTimeout := getTimerFireTimeout(request)
 	// TODO: Time skew need to be taken in to account.
 	expiryTime := time.Now().Add(fireTimeout)
 	ti = &persistence.TimerInfo{
@@ -802,6 +1035,7 @@ func (e *mutableStateBuilder) AddTimerStartedEvent(decisionCompletedEventID int6
 
 	e.pendingTimerInfoIDs[timerID] = ti
 	e.updateTimerInfos = append(e.updateTimerInfos, ti)
+	e.executionInfo.TimerCount++
 
 	return event, ti
 }
@@ -850,9 +1084,12 @@ func (e *mutableStateBuilder) AddCancelTimerFailedEvent(decisionCompletedEventID
 }
 
 func (e *mutableStateBuilder) AddRecordMarkerEvent(decisionCompletedEventID int64,
-	attributes *workflow.RecordMarkerDecisionAttributes) *workflow.HistoryEvent {
+	attributes *workflow.RecordMarkerDecisionAttributes,
+	payloadReference *workflow.PayloadReference) *workflow.HistoryEvent {
 
-	return e.hBuilder.AddMarkerRecordedEvent(decisionCompletedEventID, attributes)
+	event := e.hBuilder.AddMarkerRecordedEvent(decisionCompletedEventID, attributes)
+	event.PayloadReference = payloadReference
+	return event
 }
 
 func (e *mutableStateBuilder) AddWorkflowExecutionTerminatedEvent(
@@ -863,6 +1100,11 @@ func (e *mutableStateBuilder) AddWorkflowExecutionTerminatedEvent(
 		return nil
 	}
 
+	// Events buffered while a decision is in flight precede the termination in the history
+	if err := e.FlushBufferedEvents(); err != nil {
+		logging.LogHistorySerializationErrorEvent(e.logger, err, "Unable to flush buffered events.")
+		return nil
+	}
 	e.executionInfo.State = persistence.WorkflowStateCompleted
 	e.executionInfo.CloseStatus = persistence.WorkflowCloseStatusTerminated
 	event := e.hBuilder.AddWorkflowExecutionTerminatedEvent(request)
@@ -871,6 +1113,24 @@ func (e *mutableStateBuilder) AddWorkflowExecutionTerminatedEvent(
 	return event
 }
 
+// AddWorkflowExecutionTerminatedStuckEvent terminates the execution on behalf of the stuck workflow policy of its
+// domain, it is closed with a status of its own to tell it apart from executions terminated by an operator
+func (e *mutableStateBuilder) AddWorkflowExecutionTerminatedStuckEvent(attempt int64) *workflow.HistoryEvent {
+	if e.executionInfo.State == persistence.WorkflowStateCompleted {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionWorkflowTerminated, e.GetNextEventID(), fmt.Sprintf(
+			"{State: %v}", e.executionInfo.State))
+		return nil
+	}
+
+	e.executionInfo.State = persistence.WorkflowStateCompleted
+	e.executionInfo.CloseStatus = persistence.WorkflowCloseStatusTerminatedStuck
+	event := e.hBuilder.AddWorkflowExecutionTerminatedBySystemEvent(stuckWorkflowTerminateReason,
+		[]byte(fmt.Sprintf("decision failed %v consecutive attempts", attempt)))
+	e.writeCompletionEventToMutableState(event)
+
+	return event
+}
+
 func (e *mutableStateBuilder) AddWorkflowExecutionSignaled(
 	request *workflow.SignalWorkflowExecutionRequest) *workflow.HistoryEvent {
 	if e.executionInfo.State == persistence.WorkflowStateCompleted {
@@ -879,12 +1139,63 @@ func (e *mutableStateBuilder) AddWorkflowExecutionSignaled(
 		return nil
 	}
 
-	return e.hBuilder.AddWorkflowExecutionSignaledEvent(request)
+	e.executionInfo.SignalCount++
+	event := e.hBuilder.AddWorkflowExecutionSignaledEvent(request)
+	e.recordSignalReceipt(event)
+	return event
 }
 
+// GetSignalReceipt returns the ID of the event which recorded the signal sent with the request ID, it is
+// bufferedEventID while the signal is buffered behind a decision in flight
+func (e *mutableStateBuilder) GetSignalReceipt(requestID string) (int64, bool) {
+	eventID, ok := e.signalReceipts[requestID]
+	return eventID, ok
+}
+
+func (e *mutableStateBuilder) recordSignalReceipt(event *workflow.HistoryEvent) {
+	requestID := event.GetWorkflowExecutionSignaledEventAttributes().GetRequestId()
+	if requestID == "" {
+		return
+	}
+
+	e.signalReceipts[requestID] = event.GetEventId()
+	e.updateSignalReceipts[requestID] = event.GetEventId()
+	if len(e.signalReceipts) > maxSignalReceipts {
+		e.evictOldestSignalReceipt()
+	}
+}
+
+// evictOldestSignalReceipt deletes the receipt with the lowest event ID.  Receipts of buffered signals are kept, their
+// event ID is only known once they are flushed.
+func (e *mutableStateBuilder) evictOldestSignalReceipt() {
+	oldestRequestID := ""
+	oldestEventID := int64(0)
+	for requestID, eventID := range e.signalReceipts {
+		if eventID == bufferedEventID {
+			continue
+		}
+		if oldestRequestID == "" || eventID < oldestEventID {
+			oldestRequestID, oldestEventID = requestID, eventID
+		}
+	}
+	if oldestRequestID == "" {
+		return
+	}
+
+	delete(e.signalReceipts, oldestRequestID)
+	if _, ok := e.updateSignalReceipts[oldestRequestID]; ok {
+		// Recorded and evicted within the same update
+		delete(e.updateSignalReceipts, oldestRequestID)
+		return
+	}
+	e.deleteSignalReceipts = append(e.deleteSignalReceipts, oldestRequestID)
+}
+
+// AddContinueAsNewEvent takes the reference to the offloaded input for the continued event, and the one to the copy
+// of the input for the started event of the new run, which keeps the input after the history of this run is deleted.
 func (e *mutableStateBuilder) AddContinueAsNewEvent(decisionCompletedEventID int64, domainID, newRunID string,
-	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) (*workflow.HistoryEvent, *mutableStateBuilder,
-	error) {
+	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes, payloadReference,
+	newRunPayloadReference *workflow.PayloadReference) (*workflow.HistoryEvent, *mutableStateBuilder, error) {
 	if e.hasPendingTasks() || e.HasPendingDecisionTask() {
 		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionContinueAsNew, e.GetNextEventID(), fmt.Sprintf(
 			"{OutStandingActivityTasks: %v, HasPendingDecision: %v}", len(e.pendingActivityInfoIDs),
@@ -900,7 +1211,7 @@ func (e *mutableStateBuilder) AddContinueAsNewEvent(decisionCompletedEventID int
 
 	newStateBuilder := newMutableStateBuilder(e.logger)
 	startedEvent := newStateBuilder.AddWorkflowExecutionStartedEventForContinueAsNew(domainID, newExecution, e,
-		attributes)
+		attributes, newRunPayloadReference)
 	if startedEvent == nil {
 		return nil, nil, &workflow.InternalServiceError{Message: "Failed to add workflow execution started event."}
 	}
@@ -935,7 +1246,7 @@ func (e *mutableStateBuilder) AddContinueAsNewEvent(decisionCompletedEventID int
 		ExecutionContext:     nil,
 		NextEventID:          newStateBuilder.GetNextEventID(),
 		LastProcessedEvent:   common.EmptyEventID,
-		TransferTasks: []persistence.Task{&persistence.DecisionTask{
+		TransferTasks: []persistence.Task{&persistence.RecordWorkflowStartedTask{}, &persistence.DecisionTask{
 			DomainID: domainID, TaskList: newStateBuilder.executionInfo.TaskList, ScheduleID: di.ScheduleID,
 		}},
 		DecisionScheduleID:          di.ScheduleID,
@@ -944,13 +1255,16 @@ func (e *mutableStateBuilder) AddContinueAsNewEvent(decisionCompletedEventID int
 		ContinueAsNew:               true,
 	}
 
-	return e.hBuilder.AddContinuedAsNewEvent(decisionCompletedEventID, newRunID, attributes), newStateBuilder, nil
+	event := e.hBuilder.AddContinuedAsNewEvent(decisionCompletedEventID, newRunID, attributes)
+	event.PayloadReference = payloadReference
+	return event, newStateBuilder, nil
 }
 
 func (e *mutableStateBuilder) AddStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEventID int64,
-	createRequestID string, attributes *workflow.StartChildWorkflowExecutionDecisionAttributes) (*workflow.HistoryEvent,
-	*persistence.ChildExecutionInfo) {
+	createRequestID string, attributes *workflow.StartChildWorkflowExecutionDecisionAttributes,
+	payloadReference *workflow.PayloadReference) (*workflow.HistoryEvent, *persistence.ChildExecutionInfo) {
 	event := e.hBuilder.AddStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEventID, attributes)
+	event.PayloadReference = payloadReference
 
 	initiatedEvent, err := e.eventSerializer.Serialize(event)
 	if err != nil {
@@ -1012,8 +1326,8 @@ func (e *mutableStateBuilder) AddStartChildWorkflowExecutionFailedEvent(initiate
 }
 
 func (e *mutableStateBuilder) AddChildWorkflowExecutionCompletedEvent(initiatedID int64,
-	childExecution *workflow.WorkflowExecution,
-	attributes *workflow.WorkflowExecutionCompletedEventAttributes) *workflow.HistoryEvent {
+	childExecution *workflow.WorkflowExecution, attributes *workflow.WorkflowExecutionCompletedEventAttributes,
+	payloadReference *workflow.PayloadReference) *workflow.HistoryEvent {
 	ci, ok := e.GetChildExecutionInfo(initiatedID)
 	if !ok || ci.StartedID == emptyEventID {
 		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionChildExecutionCompleted, e.GetNextEventID(), fmt.Sprintf(
@@ -1027,16 +1341,18 @@ func (e *mutableStateBuilder) AddChildWorkflowExecutionCompletedEvent(initiatedI
 	workflowType := startedEvent.GetChildWorkflowExecutionStartedEventAttributes().GetWorkflowType()
 
 	if err := e.DeletePendingChildExecution(initiatedID); err == nil {
-		return e.hBuilder.AddChildWorkflowExecutionCompletedEvent(domain, childExecution, workflowType, ci.InitiatedID,
+		event := e.hBuilder.AddChildWorkflowExecutionCompletedEvent(domain, childExecution, workflowType, ci.InitiatedID,
 			ci.StartedID, attributes)
+		event.PayloadReference = payloadReference
+		return event
 	}
 
 	return nil
 }
 
 func (e *mutableStateBuilder) AddChildWorkflowExecutionFailedEvent(initiatedID int64,
-	childExecution *workflow.WorkflowExecution,
-	attributes *workflow.WorkflowExecutionFailedEventAttributes) *workflow.HistoryEvent {
+	childExecution *workflow.WorkflowExecution, attributes *workflow.WorkflowExecutionFailedEventAttributes,
+	payloadReference *workflow.PayloadReference) *workflow.HistoryEvent {
 	ci, ok := e.GetChildExecutionInfo(initiatedID)
 	if !ok || ci.StartedID == emptyEventID {
 		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionChildExecutionFailed, e.GetNextEventID(), fmt.Sprintf(
@@ -1050,8 +1366,10 @@ func (e *mutableStateBuilder) AddChildWorkflowExecutionFailedEvent(initiatedID i
 	workflowType := startedEvent.GetChildWorkflowExecutionStartedEventAttributes().GetWorkflowType()
 
 	if err := e.DeletePendingChildExecution(initiatedID); err == nil {
-		return e.hBuilder.AddChildWorkflowExecutionFailedEvent(domain, childExecution, workflowType, ci.InitiatedID,
+		event := e.hBuilder.AddChildWorkflowExecutionFailedEvent(domain, childExecution, workflowType, ci.InitiatedID,
 			ci.StartedID, attributes)
+		event.PayloadReference = payloadReference
+		return event
 	}
 
 	return nil
@@ -0,0 +1,151 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"sort"
+)
+
+type (
+	checksumWriter struct {
+		h   hash.Hash64
+		buf [8]byte
+	}
+)
+
+// checksum returns a checksum over the parts of the mutable state which are persisted exactly as they are held in
+// memory.  Timestamps and serialized events are left out, since they don't survive a round trip through persistence
+// unchanged.  A mismatch between the checksum of a loaded execution and the one stored with its last update means the
+// cached and the persisted state diverged.
+func (e *mutableStateBuilder) checksum() int64 {
+	w := &checksumWriter{h: fnv.New64a()}
+
+	info := e.executionInfo
+	w.writeString(info.DomainID)
+	w.writeString(info.WorkflowID)
+	w.writeString(info.RunID)
+	w.writeInt(int64(info.State))
+	w.writeInt(int64(info.CloseStatus))
+	w.writeInt(info.NextEventID)
+	w.writeInt(info.LastProcessedEvent)
+	w.writeInt(info.DecisionScheduleID)
+	w.writeInt(info.DecisionStartedID)
+	w.writeString(info.DecisionRequestID)
+	w.writeInt(int64(info.DecisionTimeout))
+	w.writeInt(info.DecisionAttempt)
+
+	var scheduleIDs []int64
+	for id := range e.pendingActivityInfoIDs {
+		scheduleIDs = append(scheduleIDs, id)
+	}
+	sortInt64s(scheduleIDs)
+	w.writeInt(int64(len(scheduleIDs)))
+	for _, id := range scheduleIDs {
+		ai := e.pendingActivityInfoIDs[id]
+		w.writeInt(ai.ScheduleID)
+		w.writeInt(ai.StartedID)
+		w.writeString(ai.ActivityID)
+		w.writeString(ai.RequestID)
+		w.writeBool(ai.CancelRequested)
+		w.writeInt(ai.CancelRequestID)
+	}
+
+	var timerIDs []string
+	for id := range e.pendingTimerInfoIDs {
+		timerIDs = append(timerIDs, id)
+	}
+	sort.Strings(timerIDs)
+	w.writeInt(int64(len(timerIDs)))
+	for _, id := range timerIDs {
+		ti := e.pendingTimerInfoIDs[id]
+		w.writeString(ti.TimerID)
+		w.writeInt(ti.StartedID)
+		w.writeInt(ti.TaskID)
+	}
+
+	var initiatedIDs []int64
+	for id := range e.pendingChildExecutionInfoIDs {
+		initiatedIDs = append(initiatedIDs, id)
+	}
+	sortInt64s(initiatedIDs)
+	w.writeInt(int64(len(initiatedIDs)))
+	for _, id := range initiatedIDs {
+		ci := e.pendingChildExecutionInfoIDs[id]
+		w.writeInt(ci.InitiatedID)
+		w.writeInt(ci.StartedID)
+		w.writeString(ci.CreateRequestID)
+	}
+
+	// Only written when present so executions persisted before request cancellations were tracked still verify
+	if len(e.pendingRequestCancelInfoIDs) > 0 {
+		var cancelIDs []int64
+		for id := range e.pendingRequestCancelInfoIDs {
+			cancelIDs = append(cancelIDs, id)
+		}
+		sortInt64s(cancelIDs)
+		w.writeInt(int64(len(cancelIDs)))
+		for _, id := range cancelIDs {
+			ri := e.pendingRequestCancelInfoIDs[id]
+			w.writeInt(ri.InitiatedID)
+			w.writeString(ri.CancelRequestID)
+		}
+	}
+
+	if len(e.signalReceipts) > 0 {
+		var requestIDs []string
+		for id := range e.signalReceipts {
+			requestIDs = append(requestIDs, id)
+		}
+		sort.Strings(requestIDs)
+		w.writeInt(int64(len(requestIDs)))
+		for _, id := range requestIDs {
+			w.writeString(id)
+			w.writeInt(e.signalReceipts[id])
+		}
+	}
+
+	return int64(w.h.Sum64())
+}
+
+func (w *checksumWriter) writeInt(v int64) {
+	binary.BigEndian.PutUint64(w.buf[:], uint64(v))
+	w.h.Write(w.buf[:])
+}
+
+func (w *checksumWriter) writeBool(v bool) {
+	if v {
+		w.writeInt(1)
+	} else {
+		w.writeInt(0)
+	}
+}
+
+func (w *checksumWriter) writeString(v string) {
+	w.writeInt(int64(len(v)))
+	w.h.Write([]byte(v))
+}
+
+func sortInt64s(values []int64) {
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+}
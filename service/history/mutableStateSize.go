@@ -0,0 +1,54 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+// size returns an estimate of the bytes the mutable state takes up in persistence.  Only the variable length parts are
+// counted, as they are what grows an execution towards the size limits of the store: identifiers, payloads, serialized
+// events and the events buffered while a decision is in flight.
+func (e *mutableStateBuilder) size() int {
+	info := e.executionInfo
+	size := len(info.DomainID) + len(info.WorkflowID) + len(info.RunID) + len(info.ParentDomainID) +
+		len(info.ParentWorkflowID) + len(info.ParentRunID) + len(info.CompletionEvent) + len(info.TaskList) +
+		len(info.WorkflowTypeName) + len(info.ExecutionContext) + len(info.CreateRequestID) +
+		len(info.DecisionRequestID) + len(info.BuildID)
+
+	for _, ai := range e.pendingActivityInfoIDs {
+		size += len(ai.ScheduledEvent) + len(ai.StartedEvent) + len(ai.ActivityID) + len(ai.RequestID) +
+			len(ai.Details) + len(ai.SessionID)
+	}
+	for _, ti := range e.pendingTimerInfoIDs {
+		size += len(ti.TimerID)
+	}
+	for _, ci := range e.pendingChildExecutionInfoIDs {
+		size += len(ci.InitiatedEvent) + len(ci.StartedEvent) + len(ci.CreateRequestID)
+	}
+	for _, ri := range e.pendingRequestCancelInfoIDs {
+		size += len(ri.CancelRequestID)
+	}
+	for _, batch := range e.bufferedEvents {
+		size += len(batch.Data)
+	}
+	for requestID := range e.signalReceipts {
+		size += len(requestID)
+	}
+
+	return size
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
+
+	"github.com/uber/cadence/common/errors"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// openExecutionCounter keeps the count of the open executions of each domain, which caps the executions started
+	// in the domains setting MaxOpenExecutions.  The count is updated after the executions are created and closed, an
+	// update failing or timing out after the execution was written leaves it off by one.  Starts racing with each
+	// other may all pass the cap.
+	openExecutionCounter struct {
+		metadataMgr persistence.MetadataManager
+		logger      bark.Logger
+	}
+)
+
+func newOpenExecutionCounter(metadataMgr persistence.MetadataManager, logger bark.Logger) *openExecutionCounter {
+	return &openExecutionCounter{
+		metadataMgr: metadataMgr,
+		logger:      logger,
+	}
+}
+
+// checkLimit returns LimitExceededError when the domain already has maxOpenExecutions open executions, a limit of
+// zero or less disables the check
+func (c *openExecutionCounter) checkLimit(ctx context.Context, domainID string, maxOpenExecutions int32) error {
+	if maxOpenExecutions <= 0 {
+		return nil
+	}
+
+	response, err := c.metadataMgr.GetOpenExecutionCount(ctx, &persistence.GetOpenExecutionCountRequest{
+		DomainID: domainID,
+	})
+	if err != nil {
+		return err
+	}
+	if response.Count >= int64(maxOpenExecutions) {
+		return errors.NewLimitExceededError("Domain has reached its limit of %v open workflow executions.",
+			maxOpenExecutions)
+	}
+	return nil
+}
+
+// executionCreated counts the execution created in the domain
+func (c *openExecutionCounter) executionCreated(ctx context.Context, domainID string) {
+	c.update(ctx, domainID, 1)
+}
+
+// executionUpdated counts the executions closed and created by an update.  An execution continued as new is
+// replaced by its new run, which leaves the count as is.
+func (c *openExecutionCounter) executionUpdated(ctx context.Context, request *persistence.UpdateWorkflowExecutionRequest) {
+	delta := int64(0)
+	if request.CloseExecution {
+		delta--
+	}
+	if request.ContinueAsNew != nil {
+		delta++
+	}
+	if delta != 0 {
+		c.update(ctx, request.ExecutionInfo.DomainID, delta)
+	}
+}
+
+func (c *openExecutionCounter) update(ctx context.Context, domainID string, delta int64) {
+	err := c.metadataMgr.UpdateOpenExecutionCount(ctx, &persistence.UpdateOpenExecutionCountRequest{
+		DomainID: domainID,
+		Delta:    delta,
+	})
+	if err != nil {
+		c.logger.Warnf("Failed to add %v to the count of open executions of domain %v. Error: %v",
+			delta, domainID, err)
+	}
+}
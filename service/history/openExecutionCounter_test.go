@@ -0,0 +1,116 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	openExecutionCounterSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		mockMetadataMgr *mocks.MetadataManager
+		counter         *openExecutionCounter
+	}
+)
+
+func TestOpenExecutionCounterSuite(t *testing.T) {
+	s := new(openExecutionCounterSuite)
+	suite.Run(t, s)
+}
+
+func (s *openExecutionCounterSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *openExecutionCounterSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.counter = newOpenExecutionCounter(s.mockMetadataMgr, bark.NewLoggerFromLogrus(log.New()))
+}
+
+func (s *openExecutionCounterSuite) TearDownTest() {
+	s.mockMetadataMgr.AssertExpectations(s.T())
+}
+
+func (s *openExecutionCounterSuite) TestCheckLimit() {
+	s.mockMetadataMgr.On("GetOpenExecutionCount", mock.Anything, &persistence.GetOpenExecutionCountRequest{
+		DomainID: "domainId",
+	}).Return(&persistence.GetOpenExecutionCountResponse{Count: 2}, nil).Twice()
+
+	s.Nil(s.counter.checkLimit(context.Background(), "domainId", 3))
+	err := s.counter.checkLimit(context.Background(), "domainId", 2)
+	s.IsType(&workflow.LimitExceededError{}, err)
+
+	// no cap, no read
+	s.Nil(s.counter.checkLimit(context.Background(), "domainId", 0))
+}
+
+func (s *openExecutionCounterSuite) TestExecutionCreated() {
+	s.mockMetadataMgr.On("UpdateOpenExecutionCount", mock.Anything, &persistence.UpdateOpenExecutionCountRequest{
+		DomainID: "domainId",
+		Delta:    1,
+	}).Return(nil).Once()
+
+	s.counter.executionCreated(context.Background(), "domainId")
+}
+
+func (s *openExecutionCounterSuite) TestExecutionUpdated() {
+	info := &persistence.WorkflowExecutionInfo{DomainID: "domainId"}
+	s.mockMetadataMgr.On("UpdateOpenExecutionCount", mock.Anything, &persistence.UpdateOpenExecutionCountRequest{
+		DomainID: "domainId",
+		Delta:    -1,
+	}).Return(errors.New("counter update failed")).Once()
+
+	// the close is counted, a failed update is only logged
+	s.counter.executionUpdated(context.Background(), &persistence.UpdateWorkflowExecutionRequest{
+		ExecutionInfo:  info,
+		CloseExecution: true,
+	})
+	// the new run replaces the run continued as new
+	s.counter.executionUpdated(context.Background(), &persistence.UpdateWorkflowExecutionRequest{
+		ExecutionInfo:  info,
+		CloseExecution: true,
+		ContinueAsNew:  &persistence.CreateWorkflowExecutionRequest{DomainID: "domainId"},
+	})
+	s.counter.executionUpdated(context.Background(), &persistence.UpdateWorkflowExecutionRequest{
+		ExecutionInfo: info,
+	})
+}
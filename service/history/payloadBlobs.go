@@ -0,0 +1,152 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const payloadBlobsHistoryPageSize = 100
+
+type (
+	// payloadBlobs manages the blobs of the payloads the frontend offloaded, which the history events only hold
+	// references to.  A blob belongs to the history holding the reference to it, payloads history copies to the
+	// events of other executions get a copy of their blob, and the blobs go away with the history on retention.
+	payloadBlobs struct {
+		client             blobstore.Client
+		bucket             string
+		hSerializerFactory persistence.HistorySerializerFactory
+	}
+)
+
+// newPayloadBlobs returns nil when no blob store is configured
+func newPayloadBlobs(client blobstore.Client, cfg config.LargePayloads) *payloadBlobs {
+	if client == nil {
+		return nil
+	}
+	return &payloadBlobs{
+		client:             client,
+		bucket:             cfg.Bucket,
+		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+	}
+}
+
+// copy stores a copy of the offloaded payload for an event of an execution of the domain.  The ID has to be the same
+// for every attempt at recording the event, so a repeated attempt overwrites the copy of the one before.
+func (p *payloadBlobs) copy(ctx context.Context, reference *workflow.PayloadReference, domainID,
+	id string) (*workflow.PayloadReference, error) {
+	if reference == nil {
+		return nil, nil
+	}
+	if p == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Offloaded payload %v cannot be copied without a blobstore", reference.GetKey()),
+		}
+	}
+
+	blob, err := p.client.Get(ctx, p.bucket, reference.GetKey())
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to read offloaded payload %v: %v", reference.GetKey(), err),
+		}
+	}
+	key := blobstore.PayloadKey(domainID, id)
+	err = p.client.Put(ctx, p.bucket, key, &blobstore.Blob{
+		Body: blob.Body,
+		Tags: map[string]string{"domain": domainID},
+	})
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to copy offloaded payload %v: %v", reference.GetKey(), err),
+		}
+	}
+	return &workflow.PayloadReference{
+		Key:      common.StringPtr(key),
+		Checksum: reference.Checksum,
+		Size:     reference.Size,
+	}, nil
+}
+
+// copyToDomain copies the offloaded payload for an execution moved to another domain, the copy keeps the ID of the
+// blob within the domain
+func (p *payloadBlobs) copyToDomain(ctx context.Context, reference *workflow.PayloadReference, domainID,
+	targetDomainID string) (*workflow.PayloadReference, error) {
+	id := strings.TrimPrefix(reference.GetKey(), domainID+"/")
+	return p.copy(ctx, reference, targetDomainID, id)
+}
+
+// deleteHistory deletes the offloaded payloads the history of the execution holds references to, it has to be called
+// before the history is deleted.  Blobs deleted by a previous attempt are skipped.
+func (p *payloadBlobs) deleteHistory(ctx context.Context, historyMgr persistence.HistoryManager, domainID string,
+	execution workflow.WorkflowExecution) error {
+	if p == nil {
+		return nil
+	}
+
+	request := &persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID:      domainID,
+		Execution:     execution,
+		NextEventID:   math.MaxInt64,
+		PageSize:      payloadBlobsHistoryPageSize,
+		NextPageToken: []byte{},
+	}
+	for {
+		response, err := historyMgr.GetWorkflowExecutionHistory(ctx, request)
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); ok {
+				return nil
+			}
+			return err
+		}
+		for i := range response.Events {
+			serializer, err := p.hSerializerFactory.Get(response.Events[i].EncodingType)
+			if err != nil {
+				return err
+			}
+			batch, err := serializer.Deserialize(&response.Events[i])
+			if err != nil {
+				return err
+			}
+			for _, event := range batch.Events {
+				if event.PayloadReference == nil {
+					continue
+				}
+				if err := p.client.Delete(ctx, p.bucket, event.PayloadReference.GetKey()); err != nil {
+					return err
+				}
+			}
+		}
+		if len(response.NextPageToken) == 0 {
+			return nil
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	payloadBlobsSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		rootDir        string
+		client         blobstore.Client
+		mockHistoryMgr *mocks.HistoryManager
+		blobs          *payloadBlobs
+	}
+)
+
+func TestPayloadBlobsSuite(t *testing.T) {
+	s := new(payloadBlobsSuite)
+	suite.Run(t, s)
+}
+
+func (s *payloadBlobsSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	var err error
+	s.rootDir, err = ioutil.TempDir("", "payloadBlobsSuite")
+	s.NoError(err)
+	s.NoError(os.Mkdir(s.rootDir+"/payloads", 0700))
+	s.client, err = blobstore.NewFilesystemClient(s.rootDir)
+	s.NoError(err)
+	s.mockHistoryMgr = &mocks.HistoryManager{}
+	s.blobs = newPayloadBlobs(s.client, config.LargePayloads{Bucket: "payloads", Threshold: 8})
+}
+
+func (s *payloadBlobsSuite) TearDownTest() {
+	s.mockHistoryMgr.AssertExpectations(s.T())
+	os.RemoveAll(s.rootDir)
+}
+
+func (s *payloadBlobsSuite) putPayload(key string, payload []byte) *workflow.PayloadReference {
+	s.NoError(s.client.Put(context.Background(), "payloads", key, &blobstore.Blob{Body: payload}))
+	return &workflow.PayloadReference{
+		Key:      common.StringPtr(key),
+		Checksum: common.StringPtr(blobstore.PayloadChecksum(payload)),
+		Size:     common.Int32Ptr(int32(len(payload))),
+	}
+}
+
+func (s *payloadBlobsSuite) TestCopy() {
+	reference := s.putPayload("domain/offloaded", []byte("offloaded payload"))
+
+	copied, err := s.blobs.copy(context.Background(), reference, "child-domain", "request-id")
+	s.NoError(err)
+	s.Equal("child-domain/request-id", copied.GetKey())
+	s.Equal(reference.GetChecksum(), copied.GetChecksum())
+	s.Equal(reference.GetSize(), copied.GetSize())
+	blob, err := s.client.Get(context.Background(), "payloads", copied.GetKey())
+	s.NoError(err)
+	s.Equal([]byte("offloaded payload"), blob.Body)
+
+	// a repeated copy overwrites the one before
+	copied, err = s.blobs.copy(context.Background(), reference, "child-domain", "request-id")
+	s.NoError(err)
+	s.Equal("child-domain/request-id", copied.GetKey())
+
+	copied, err = s.blobs.copyToDomain(context.Background(), reference, "domain", "target-domain")
+	s.NoError(err)
+	s.Equal("target-domain/offloaded", copied.GetKey())
+
+	copied, err = s.blobs.copy(context.Background(), nil, "child-domain", "request-id")
+	s.NoError(err)
+	s.Nil(copied)
+
+	_, err = s.blobs.copy(context.Background(), &workflow.PayloadReference{Key: common.StringPtr("domain/missing")},
+		"child-domain", "request-id")
+	s.IsType(&workflow.InternalServiceError{}, err)
+
+	// without a blob store there are no references to copy
+	copied, err = (*payloadBlobs)(nil).copy(context.Background(), nil, "child-domain", "request-id")
+	s.NoError(err)
+	s.Nil(copied)
+	_, err = (*payloadBlobs)(nil).copy(context.Background(), reference, "child-domain", "request-id")
+	s.IsType(&workflow.InternalServiceError{}, err)
+}
+
+func (s *payloadBlobsSuite) TestDeleteHistory() {
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wid"),
+		RunId:      common.StringPtr("rid"),
+	}
+	started := s.putPayload("domain/started", []byte("started input"))
+	completed := s.putPayload("domain/completed", []byte("completed result"))
+	other := s.putPayload("domain/other", []byte("other input"))
+
+	serialize := func(events ...*workflow.HistoryEvent) persistence.SerializedHistoryEventBatch {
+		batch, err := persistence.NewJSONHistorySerializer().Serialize(&persistence.HistoryEventBatch{
+			Version: 1,
+			Events:  events,
+		})
+		s.NoError(err)
+		return *batch
+	}
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything, mock.MatchedBy(
+		func(request *persistence.GetWorkflowExecutionHistoryRequest) bool {
+			return len(request.NextPageToken) == 0
+		})).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		Events: []persistence.SerializedHistoryEventBatch{serialize(
+			&workflow.HistoryEvent{EventId: common.Int64Ptr(1), PayloadReference: started},
+			&workflow.HistoryEvent{EventId: common.Int64Ptr(2)},
+		)},
+		NextPageToken: []byte("next"),
+	}, nil).Once()
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything, mock.MatchedBy(
+		func(request *persistence.GetWorkflowExecutionHistoryRequest) bool {
+			return string(request.NextPageToken) == "next"
+		})).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		Events: []persistence.SerializedHistoryEventBatch{serialize(
+			&workflow.HistoryEvent{EventId: common.Int64Ptr(3), PayloadReference: completed},
+		)},
+	}, nil).Once()
+
+	s.NoError(s.blobs.deleteHistory(context.Background(), s.mockHistoryMgr, "domain", execution))
+	for _, reference := range []*workflow.PayloadReference{started, completed} {
+		exists, err := s.client.Exists(context.Background(), "payloads", reference.GetKey())
+		s.NoError(err)
+		s.False(exists)
+	}
+	exists, err := s.client.Exists(context.Background(), "payloads", other.GetKey())
+	s.NoError(err)
+	s.True(exists)
+
+	// a history deleted by a previous attempt has nothing left to delete
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything, mock.Anything).Return(nil,
+		&workflow.EntityNotExistsError{Message: "history not found"}).Once()
+	s.NoError(s.blobs.deleteHistory(context.Background(), s.mockHistoryMgr, "domain", execution))
+	s.NoError((*payloadBlobs)(nil).deleteHistory(context.Background(), s.mockHistoryMgr, "domain", execution))
+}
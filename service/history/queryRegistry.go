@@ -0,0 +1,171 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+
+	"github.com/pborman/uuid"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	// queryRegistry holds the queries to the workflow executions of a shard until the worker answers them.  Queries
+	// are not persisted, they are handed to the worker with the next decision task of the execution and answered
+	// when the decision task is completed.  Queries which can't wait for a decision task are handed to the worker with
+	// a query task of their own, which doesn't change the history.  Queries are lost when the shard moves, their
+	// callers time out.
+	queryRegistry struct {
+		sync.Mutex
+		queries map[string]map[string]*workflowQuery // by runID and queryID
+	}
+
+	workflowQuery struct {
+		id       string
+		query    *workflow.WorkflowQuery
+		resultCh chan *workflow.WorkflowQueryAnswer // buffered, the caller may have given up waiting
+		// set once the query was handed to the worker with a decision task or a query task
+		delivered bool
+		// set if the query was handed to the worker with a query task, it is not delivered with decision tasks then
+		queryTask bool
+	}
+)
+
+func newQueryRegistry() *queryRegistry {
+	return &queryRegistry{
+		queries: make(map[string]map[string]*workflowQuery),
+	}
+}
+
+// add queues the query until the next decision task of the execution or its query task starts
+func (r *queryRegistry) add(runID string, query *workflow.WorkflowQuery) *workflowQuery {
+	q := &workflowQuery{
+		id:       uuid.New(),
+		query:    query,
+		resultCh: make(chan *workflow.WorkflowQueryAnswer, 1),
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	queries, ok := r.queries[runID]
+	if !ok {
+		queries = make(map[string]*workflowQuery)
+		r.queries[runID] = queries
+	}
+	queries[q.id] = q
+	return q
+}
+
+func (r *queryRegistry) remove(runID string, queryID string) {
+	r.Lock()
+	defer r.Unlock()
+	r.removeLocked(runID, queryID)
+}
+
+func (r *queryRegistry) removeLocked(runID string, queryID string) {
+	if queries, ok := r.queries[runID]; ok {
+		delete(queries, queryID)
+		if len(queries) == 0 {
+			delete(r.queries, runID)
+		}
+	}
+}
+
+// deliver returns the queries of the execution to hand to the worker with a decision task.  Queries already
+// delivered with a decision task which failed or timed out are delivered again.
+func (r *queryRegistry) deliver(runID string) map[string]*workflow.WorkflowQuery {
+	r.Lock()
+	defer r.Unlock()
+	queries, ok := r.queries[runID]
+	if !ok {
+		return nil
+	}
+	result := make(map[string]*workflow.WorkflowQuery, len(queries))
+	for id, q := range queries {
+		if q.queryTask {
+			continue
+		}
+		q.delivered = true
+		result[id] = q.query
+	}
+	return result
+}
+
+// deliverQuery returns the query to hand to the worker with a query task, or nil if the query was answered or was
+// delivered already
+func (r *queryRegistry) deliverQuery(runID string, queryID string) *workflow.WorkflowQuery {
+	r.Lock()
+	defer r.Unlock()
+	q, ok := r.queries[runID][queryID]
+	if !ok || q.delivered {
+		return nil
+	}
+	q.delivered = true
+	q.queryTask = true
+	return q.query
+}
+
+// answer answers the query with the result of the completed query task, the query fails without a result
+func (r *queryRegistry) answer(runID string, queryID string, result *workflow.WorkflowQueryAnswer) {
+	r.Lock()
+	defer r.Unlock()
+	q, ok := r.queries[runID][queryID]
+	if !ok {
+		return
+	}
+	if result == nil {
+		result = &workflow.WorkflowQueryAnswer{
+			ResultType:   common.QueryResultTypePtr(workflow.QueryResultType_FAILED),
+			ErrorMessage: common.StringPtr("Query was not answered by the query task."),
+		}
+	}
+	q.resultCh <- result
+	r.removeLocked(runID, queryID)
+}
+
+// complete answers the queries with the results of the completed decision task.  Queries delivered with the decision
+// task which the worker didn't answer are failed, the others wait for the next decision task or their query task
+// unless the decision closed the execution.
+func (r *queryRegistry) complete(runID string, results map[string]*workflow.WorkflowQueryAnswer, executionClosed bool) {
+	r.Lock()
+	defer r.Unlock()
+	for id, q := range r.queries[runID] {
+		result, answered := results[id]
+		if !answered {
+			var message string
+			switch {
+			case q.delivered && !q.queryTask:
+				message = "Query was not answered by the decision task."
+			case executionClosed:
+				message = "Workflow execution already completed."
+			default:
+				continue
+			}
+			result = &workflow.WorkflowQueryAnswer{
+				ResultType:   common.QueryResultTypePtr(workflow.QueryResultType_FAILED),
+				ErrorMessage: common.StringPtr(message),
+			}
+		}
+		q.resultCh <- result
+		r.removeLocked(runID, id)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	queryRegistrySuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		registry *queryRegistry
+	}
+)
+
+func TestQueryRegistrySuite(t *testing.T) {
+	s := new(queryRegistrySuite)
+	suite.Run(t, s)
+}
+
+func (s *queryRegistrySuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.registry = newQueryRegistry()
+}
+
+func (s *queryRegistrySuite) TestDeliverAndAnswer() {
+	runID := "run"
+	query := s.registry.add(runID, &workflow.WorkflowQuery{QueryType: common.StringPtr("state")})
+
+	delivered := s.registry.deliver(runID)
+	s.Equal(1, len(delivered))
+	s.Equal("state", delivered[query.id].GetQueryType())
+
+	// A query queued while the decision task is running waits for the next one
+	pending := s.registry.add(runID, &workflow.WorkflowQuery{QueryType: common.StringPtr("pending")})
+
+	s.registry.complete(runID, map[string]*workflow.WorkflowQueryAnswer{
+		query.id: {
+			ResultType: common.QueryResultTypePtr(workflow.QueryResultType_ANSWERED),
+			Answer:     []byte("answer"),
+		},
+	}, false)
+	result := <-query.resultCh
+	s.Equal(workflow.QueryResultType_ANSWERED, result.GetResultType())
+	s.Equal([]byte("answer"), result.GetAnswer())
+	s.Equal(0, len(pending.resultCh))
+	s.Equal(1, len(s.registry.deliver(runID)))
+}
+
+func (s *queryRegistrySuite) TestQueryTask() {
+	runID := "run"
+	query := s.registry.add(runID, &workflow.WorkflowQuery{QueryType: common.StringPtr("state")})
+	s.Equal("state", s.registry.deliverQuery(runID, query.id).GetQueryType())
+	s.Nil(s.registry.deliverQuery(runID, query.id))
+
+	// A query handed to the worker with a query task is neither delivered nor failed by decision tasks
+	s.Empty(s.registry.deliver(runID))
+	s.registry.complete(runID, nil, false)
+	s.Equal(0, len(query.resultCh))
+
+	s.registry.answer(runID, query.id, nil)
+	result := <-query.resultCh
+	s.Equal(workflow.QueryResultType_FAILED, result.GetResultType())
+	s.Equal("Query was not answered by the query task.", result.GetErrorMessage())
+	s.Nil(s.registry.deliverQuery(runID, query.id))
+
+	// A query delivered with a decision task is not handed to the worker with a query task
+	query = s.registry.add(runID, &workflow.WorkflowQuery{QueryType: common.StringPtr("state")})
+	s.registry.deliver(runID)
+	s.Nil(s.registry.deliverQuery(runID, query.id))
+}
+
+func (s *queryRegistrySuite) TestUnansweredQueriesFail() {
+	runID := "run"
+	unanswered := s.registry.add(runID, &workflow.WorkflowQuery{QueryType: common.StringPtr("state")})
+	s.registry.deliver(runID)
+	undelivered := s.registry.add(runID, &workflow.WorkflowQuery{QueryType: common.StringPtr("state")})
+
+	s.registry.complete(runID, nil, true)
+	result := <-unanswered.resultCh
+	s.Equal(workflow.QueryResultType_FAILED, result.GetResultType())
+	s.Equal("Query was not answered by the decision task.", result.GetErrorMessage())
+	result = <-undelivered.resultCh
+	s.Equal(workflow.QueryResultType_FAILED, result.GetResultType())
+	s.Equal("Workflow execution already completed.", result.GetErrorMessage())
+	s.Nil(s.registry.deliver(runID))
+}
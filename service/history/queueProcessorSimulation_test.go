@@ -0,0 +1,634 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"golang.org/x/net/context"
+
+	m "github.com/uber/cadence/.gen/go/matching"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	simulationDomainID     = "c9a9a3c2-42f9-4a04-8ef1-6d2ba3d5a4a6"
+	simulationAwaitTimeout = 10 * time.Second
+)
+
+type (
+	// queueProcessorSimulationSuite drives the timer and transfer queue processors of a shard with a fake clock and
+	// an in-memory store, moving the shard between hosts with drifting clocks.  Nothing waits on real time passing,
+	// the simulation only waits for the processors to catch up with the tasks due.
+	queueProcessorSimulationSuite struct {
+		suite.Suite
+		*require.Assertions
+		logger bark.Logger
+		config *Config
+		store  *simExecutionStore
+		// matchingClient is shared by the owners of the shard
+		matchingClient *mocks.MatchingClient
+		// now is the true time of the simulation, the clocks of the shard owners are offset from it
+		now   time.Time
+		owner *simShardOwner
+	}
+
+	// simShardOwner is the history host currently owning the simulated shard
+	simShardOwner struct {
+		shard            *shardContextImpl
+		timeSource       *common.FakeTimeSource
+		timerProcessor   *timerQueueProcessorImpl
+		transferProcesor *transferQueueProcessorImpl
+	}
+
+	// simExecutionStore is an in-memory store of the timer and transfer tasks of a shard.  Workflow executions not
+	// added to the store are never found, so the processors complete their tasks without touching mutable state.
+	// Methods which aren't simulated panic on the embedded nil ExecutionManager.
+	simExecutionStore struct {
+		persistence.ExecutionManager
+
+		sync.Mutex
+		timeSource    common.TimeSource
+		timers        map[int64]*persistence.TimerTaskInfo
+		firedTimers   map[int64]time.Time // clock of the shard owner when the timer was completed
+		transferTasks map[int64]*persistence.TransferTaskInfo
+		dlqTasks      map[int64]*persistence.TransferTaskInfo
+		executions    map[string]*persistence.WorkflowMutableState // by run ID
+		loadedRuns    map[string]int                               // loads of workflow executions by processed tasks, by run ID
+	}
+)
+
+func TestQueueProcessorSimulationSuite(t *testing.T) {
+	suite.Run(t, new(queueProcessorSimulationSuite))
+}
+
+func (s *queueProcessorSimulationSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.logger = bark.NewLoggerFromLogrus(log.New())
+	s.config = NewConfig()
+	s.config.TimerMaxClockSkew = 5 * time.Second
+	s.now = time.Unix(1500000000, 0)
+	s.store = newSimExecutionStore()
+	s.matchingClient = &mocks.MatchingClient{}
+	s.owner = s.newShardOwner(&persistence.ShardInfo{ShardID: 1, RangeID: 1}, 0, 0)
+}
+
+func (s *queueProcessorSimulationSuite) TearDownTest() {
+	s.owner.stop()
+}
+
+func (s *queueProcessorSimulationSuite) TestTimersFollowFakeClock() {
+	rng := rand.New(rand.NewSource(1))
+	s.addTimers(rng, 2000, 10*time.Minute)
+
+	for i := 0; i < 130; i++ {
+		s.advance(5 * time.Second)
+		s.awaitDueTimers()
+	}
+	s.Equal(0, s.store.pendingTimers())
+}
+
+func (s *queueProcessorSimulationSuite) TestTimersAcrossShardMovements() {
+	rng := rand.New(rand.NewSource(2))
+	s.addTimers(rng, 2000, 10*time.Minute)
+
+	// Clocks of the hosts drift within the max clock skew, some shard movements are not graceful
+	offsets := []time.Duration{-3 * time.Second, 2 * time.Second, 0, -4 * time.Second, 4 * time.Second}
+	for i := 0; i < 130; i++ {
+		s.advance(time.Duration(1+rng.Intn(9)) * time.Second)
+		s.awaitDueTimers()
+		if i%10 == 9 {
+			s.moveShard(offsets[(i/10)%len(offsets)], rng.Intn(3) > 0)
+			s.awaitDueTimers()
+		}
+		if i == 60 {
+			// More timers, some of them already expired
+			s.addTimers(rng, 500, 5*time.Minute)
+		}
+	}
+	s.advance(10 * time.Minute)
+	s.awaitDueTimers()
+	s.Equal(0, s.store.pendingTimers())
+}
+
+func (s *queueProcessorSimulationSuite) TestTransferTasksAcrossShardMovements() {
+	rng := rand.New(rand.NewSource(3))
+	var runIDs []string
+	for i := 0; i < 10; i++ {
+		runIDs = append(runIDs, s.addTransferTasks(100)...)
+		s.awaitTransferTasks(runIDs)
+		s.moveShard(0, rng.Intn(2) > 0)
+	}
+
+	// Every task is processed at least once, and completed once the ack level moves past it
+	s.awaitTransferTasks(runIDs)
+	s.await(func() bool {
+		s.owner.transferProcesor.ackMgr.updateAckLevel()
+		return s.store.pendingTransferTasks() == 0
+	})
+	s.Equal(int64(len(runIDs)), s.owner.shard.GetTransferAckLevel())
+}
+
+func (s *queueProcessorSimulationSuite) TestTransferTaskParkedAfterMatchingFailures() {
+	s.owner.stop()
+	s.config.TransferMatchingRetryInitialInterval = time.Millisecond
+	s.config.TransferMatchingRetryMaxInterval = 4 * time.Millisecond
+	s.config.TransferMatchingMaxAttempts = 5
+	s.owner = s.newShardOwner(&persistence.ShardInfo{ShardID: 1, RangeID: 1}, 0, 0)
+
+	isTaskList := func(name string) interface{} {
+		return mock.MatchedBy(func(request *m.AddDecisionTaskRequest) bool {
+			return request.TaskList.GetName() == name
+		})
+	}
+	s.matchingClient.On("AddDecisionTask", mock.Anything, isTaskList("sim-broken")).
+		Return(errors.New("matching unavailable")).Times(5)
+	s.matchingClient.On("AddDecisionTask", mock.Anything, isTaskList("sim-tasklist")).Return(nil).Times(10)
+
+	// The task of the broken task list doesn't block the tasks after it
+	brokenTaskID := s.addDecisionTasks("sim-broken", 1)[0]
+	s.addDecisionTasks("sim-tasklist", 10)
+	s.await(func() bool {
+		s.owner.transferProcesor.ackMgr.updateAckLevel()
+		return s.store.pendingTransferTasks() == 0
+	})
+
+	s.matchingClient.AssertExpectations(s.T())
+	s.store.Lock()
+	defer s.store.Unlock()
+	s.Equal(1, len(s.store.dlqTasks))
+	s.Equal("sim-broken", s.store.dlqTasks[brokenTaskID].TaskList)
+}
+
+func (s *queueProcessorSimulationSuite) TestTransferTaskRescheduledOnMatchingFailures() {
+	s.owner.stop()
+	s.config.TransferMatchingRetryInitialInterval = time.Millisecond
+	s.config.TransferMatchingRetryMaxInterval = 4 * time.Millisecond
+	s.owner = s.newShardOwner(&persistence.ShardInfo{ShardID: 1, RangeID: 1}, 0, 0)
+
+	// The task is never parked without max attempts, it makes it to matching once matching recovers
+	s.matchingClient.On("AddDecisionTask", mock.Anything, mock.Anything).Return(errors.New("matching unavailable")).Times(8)
+	s.matchingClient.On("AddDecisionTask", mock.Anything, mock.Anything).Return(nil).Once()
+
+	s.addDecisionTasks("sim-tasklist", 1)
+	s.await(func() bool {
+		s.owner.transferProcesor.ackMgr.updateAckLevel()
+		return s.store.pendingTransferTasks() == 0
+	})
+
+	s.matchingClient.AssertExpectations(s.T())
+	processor := s.owner.transferProcesor
+	processor.matchingLock.Lock()
+	s.Equal(0, len(processor.matchingAttempts))
+	processor.matchingLock.Unlock()
+	s.store.Lock()
+	defer s.store.Unlock()
+	s.Equal(0, len(s.store.dlqTasks))
+}
+
+func (s *queueProcessorSimulationSuite) TestTransferTasksThrottledByMatching() {
+	isTaskList := func(name string) interface{} {
+		return mock.MatchedBy(func(request *m.AddDecisionTaskRequest) bool {
+			return request.TaskList.GetName() == name
+		})
+	}
+	var lock sync.Mutex
+	var addedAt time.Time
+	s.matchingClient.On("AddDecisionTask", mock.Anything, isTaskList("sim-busy")).
+		Return(&workflow.ServiceBusyError{Message: "Task list overloaded.", RetryAfterMillis: common.Int64Ptr(200)}).Once()
+	s.matchingClient.On("AddDecisionTask", mock.Anything, isTaskList("sim-busy")).Return(nil).Once()
+	s.matchingClient.On("AddDecisionTask", mock.Anything, isTaskList("sim-tasklist")).Return(nil).Once().
+		Run(func(args mock.Arguments) {
+			lock.Lock()
+			defer lock.Unlock()
+			addedAt = time.Now()
+		})
+
+	processor := s.owner.transferProcesor
+	s.addDecisionTasks("sim-busy", 1)
+	s.await(func() bool { return atomic.LoadInt64(&processor.throttledUntil) > 0 })
+	throttledUntil := time.Unix(0, atomic.LoadInt64(&processor.throttledUntil))
+
+	// Tasks notified while the shard is throttled are read once the retry-after hint elapsed
+	s.addDecisionTasks("sim-tasklist", 1)
+	s.await(func() bool {
+		processor.ackMgr.updateAckLevel()
+		return s.store.pendingTransferTasks() == 0
+	})
+
+	s.matchingClient.AssertExpectations(s.T())
+	lock.Lock()
+	defer lock.Unlock()
+	s.False(addedAt.Before(throttledUntil))
+}
+
+func (s *queueProcessorSimulationSuite) TestTransferTaskRescheduledWhenThrottled() {
+	s.owner.stop()
+	s.config.TransferThrottleMaxRetries = 3
+	s.config.TransferMatchingRetryInitialInterval = time.Millisecond
+	s.config.TransferMatchingMaxAttempts = 1
+	s.owner = s.newShardOwner(&persistence.ShardInfo{ShardID: 1, RangeID: 1}, 0, 0)
+
+	// A task which keeps being throttled is handed over to the retries of tasks failing to be added to matching
+	s.matchingClient.On("AddDecisionTask", mock.Anything, mock.Anything).
+		Return(&workflow.ServiceBusyError{Message: "Task list overloaded.", RetryAfterMillis: common.Int64Ptr(1)}).Times(3)
+
+	taskID := s.addDecisionTasks("sim-busy", 1)[0]
+	s.await(func() bool {
+		s.owner.transferProcesor.ackMgr.updateAckLevel()
+		return s.store.pendingTransferTasks() == 0
+	})
+
+	s.matchingClient.AssertExpectations(s.T())
+	s.store.Lock()
+	defer s.store.Unlock()
+	s.Equal(1, len(s.store.dlqTasks))
+	s.Equal("sim-busy", s.store.dlqTasks[taskID].TaskList)
+}
+
+// newShardOwner starts the queue processors of the shard on a host whose clock is offset from the true time
+func (s *queueProcessorSimulationSuite) newShardOwner(shardInfo *persistence.ShardInfo, offset time.Duration,
+	transferMaxReadLevel int64) *simShardOwner {
+	shardManager := &mocks.ShardManager{}
+	shardManager.On("UpdateShard", mock.Anything, mock.Anything).Return(nil)
+	timeSource := common.NewFakeTimeSource(s.now.Add(offset))
+	shard := &shardContextImpl{
+		shardID:               shardInfo.ShardID,
+		shardInfo:             shardInfo,
+		taskSequenceNumber:    transferMaxReadLevel + 1,
+		maxTaskSequenceNumber: 1 << 20,
+		transferMaxReadLevel:  transferMaxReadLevel,
+		executionManager:      s.store,
+		shardManager:          shardManager,
+		historyMgr:            &mocks.HistoryManager{},
+		rangeSize:             defaultRangeSize,
+		closeEvents:           newShardEventBus(),
+		logger:                s.logger,
+		metricsClient:         metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:            timeSource,
+	}
+	s.store.setTimeSource(timeSource)
+
+	historyCache := newHistoryCache(historyCacheMaxSize, shard, s.logger)
+	domainCache := cache.NewDomainCache(&mocks.MetadataManager{}, s.logger)
+	transferProcessor := newTransferQueueProcessor(shard, &mocks.VisibilityManager{}, s.matchingClient,
+		&mocks.HistoryClient{}, historyCache, domainCache, nil, s.config).(*transferQueueProcessorImpl)
+	// The read rate limit is not what is simulated
+	transferProcessor.rateLimiter = common.NewTokenBucket(1000000, common.NewRealTimeSource())
+	engine := &historyEngineImpl{
+		shard:              shard,
+		executionManager:   s.store,
+		txProcessor:        transferProcessor,
+		historyCache:       historyCache,
+		domainCache:        domainCache,
+		logger:             s.logger,
+		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
+		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+		queryRegistry:      newQueryRegistry(),
+		signalBatcher:      newSignalBatcher(0),
+		taskMetrics:        newTaskMetrics(shard.GetMetricsClient()),
+		config:             s.config,
+		metricsClient:      shard.GetMetricsClient(),
+	}
+	timerProcessor := newTimerQueue(engine, s.store, activeTimerQueue, s.logger)
+	engine.timerProcessor = timerProcessor
+
+	owner := &simShardOwner{
+		shard:            shard,
+		timeSource:       timeSource,
+		timerProcessor:   timerProcessor,
+		transferProcesor: transferProcessor,
+	}
+	timerProcessor.Start()
+	transferProcessor.Start()
+	return owner
+}
+
+// moveShard moves the shard to a host whose clock is offset from the true time.  The ack levels of the queues are
+// only persisted by the previous owner when the movement is graceful.
+func (s *queueProcessorSimulationSuite) moveShard(offset time.Duration, graceful bool) {
+	previous := s.owner
+	if graceful {
+		previous.timerProcessor.updateAckLevel()
+		previous.transferProcesor.ackMgr.updateAckLevel()
+	}
+	previous.stop()
+
+	previous.shard.RLock()
+	shardInfo := copyShardInfo(previous.shard.shardInfo)
+	transferMaxReadLevel := previous.shard.transferMaxReadLevel
+	previous.shard.RUnlock()
+	s.owner = s.newShardOwner(shardInfo, offset, transferMaxReadLevel)
+}
+
+// advance moves the true time and the clock of the shard owner forward
+func (s *queueProcessorSimulationSuite) advance(d time.Duration) {
+	s.now = s.now.Add(d)
+	s.owner.timeSource.Advance(d)
+	s.owner.timerProcessor.NotifyNewTimer(int64(MaxTimerKey))
+}
+
+func (s *queueProcessorSimulationSuite) addTimers(rng *rand.Rand, count int, within time.Duration) {
+	seqNum, err := s.owner.shard.GetTimerSequenceNumber()
+	s.NoError(err)
+	for i := 0; i < count; i++ {
+		expiry := s.owner.timeSource.Now().Add(time.Duration(rng.Int63n(int64(within))) - within/10)
+		key := ConstructTimerKey(expiry.UnixNano(), seqNum+int64(i))
+		s.store.addTimer(&persistence.TimerTaskInfo{
+			DomainID:   simulationDomainID,
+			WorkflowID: "sim-timer",
+			RunID:      uuid.New(),
+			TaskID:     int64(key),
+			TaskType:   persistence.TaskTypeUserTimer,
+		})
+		s.owner.timerProcessor.NotifyNewTimer(int64(key))
+	}
+}
+
+// awaitDueTimers waits for the timers due on the clock of the shard owner to be completed, and checks no timer was
+// completed before it was due or more than the max clock skew ahead of the true time
+func (s *queueProcessorSimulationSuite) awaitDueTimers() {
+	now := s.owner.timeSource.Now()
+	dueTime := s.owner.timerProcessor.dueTime(now.UnixNano())
+	s.True(dueTime <= s.now.Add(s.config.TimerMaxClockSkew).UnixNano())
+	s.await(func() bool { return s.store.firstPendingTimer() > dueTime })
+
+	s.store.Lock()
+	defer s.store.Unlock()
+	for key, firedAt := range s.store.firedTimers {
+		expiry, _ := DeconstructTimerKey(SequenceID(key))
+		if firedAt.Equal(now) {
+			s.True(expiry <= dueTime, "Timer %v fired before it was due", SequenceID(key))
+		}
+	}
+}
+
+func (s *queueProcessorSimulationSuite) addTransferTasks(count int) []string {
+	var runIDs []string
+	var maxTaskID int64
+	for i := 0; i < count; i++ {
+		taskID, err := s.owner.shard.GetNextTransferTaskID()
+		s.NoError(err)
+		runID := uuid.New()
+		runIDs = append(runIDs, runID)
+		s.store.addTransferTask(&persistence.TransferTaskInfo{
+			DomainID:       simulationDomainID,
+			WorkflowID:     "sim-transfer",
+			RunID:          runID,
+			TaskID:         taskID,
+			TargetDomainID: simulationDomainID,
+			TaskList:       "sim-tasklist",
+			TaskType:       persistence.TransferTaskTypeActivityTask,
+			ScheduleID:     int64(i),
+		})
+		maxTaskID = taskID
+	}
+
+	s.owner.shard.Lock()
+	s.owner.shard.updateMaxReadLevelLocked(maxTaskID)
+	s.owner.shard.Unlock()
+	s.owner.transferProcesor.NotifyNewTask()
+	return runIDs
+}
+
+// addDecisionTasks adds decision transfer tasks for new workflow executions on the task list
+func (s *queueProcessorSimulationSuite) addDecisionTasks(taskList string, count int) []int64 {
+	var taskIDs []int64
+	for i := 0; i < count; i++ {
+		taskID, err := s.owner.shard.GetNextTransferTaskID()
+		s.NoError(err)
+		runID := uuid.New()
+		s.store.addExecution(&persistence.WorkflowExecutionInfo{
+			DomainID:    simulationDomainID,
+			WorkflowID:  "sim-decision",
+			RunID:       runID,
+			TaskList:    taskList,
+			NextEventID: 3,
+		})
+		s.store.addTransferTask(&persistence.TransferTaskInfo{
+			DomainID:       simulationDomainID,
+			WorkflowID:     "sim-decision",
+			RunID:          runID,
+			TaskID:         taskID,
+			TargetDomainID: simulationDomainID,
+			TaskList:       taskList,
+			TaskType:       persistence.TransferTaskTypeDecisionTask,
+			ScheduleID:     2,
+		})
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	s.owner.shard.Lock()
+	s.owner.shard.updateMaxReadLevelLocked(taskIDs[len(taskIDs)-1])
+	s.owner.shard.Unlock()
+	s.owner.transferProcesor.NotifyNewTask()
+	return taskIDs
+}
+
+// awaitTransferTasks waits for the transfer tasks of the runs to be processed at least once
+func (s *queueProcessorSimulationSuite) awaitTransferTasks(runIDs []string) {
+	s.await(func() bool {
+		s.store.Lock()
+		defer s.store.Unlock()
+		for _, runID := range runIDs {
+			if s.store.loadedRuns[runID] == 0 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// await polls the condition until the processors catch up with it
+func (s *queueProcessorSimulationSuite) await(condition func() bool) {
+	deadline := time.Now().Add(simulationAwaitTimeout)
+	for !condition() {
+		s.True(time.Now().Before(deadline), "Timed out waiting for the queue processors")
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (o *simShardOwner) stop() {
+	o.timerProcessor.Stop()
+	o.transferProcesor.Stop()
+}
+
+func newSimExecutionStore() *simExecutionStore {
+	return &simExecutionStore{
+		timers:        make(map[int64]*persistence.TimerTaskInfo),
+		firedTimers:   make(map[int64]time.Time),
+		transferTasks: make(map[int64]*persistence.TransferTaskInfo),
+		dlqTasks:      make(map[int64]*persistence.TransferTaskInfo),
+		executions:    make(map[string]*persistence.WorkflowMutableState),
+		loadedRuns:    make(map[string]int),
+	}
+}
+
+func (m *simExecutionStore) setTimeSource(timeSource common.TimeSource) {
+	m.Lock()
+	defer m.Unlock()
+	m.timeSource = timeSource
+}
+
+func (m *simExecutionStore) addTimer(timer *persistence.TimerTaskInfo) {
+	m.Lock()
+	defer m.Unlock()
+	m.timers[timer.TaskID] = timer
+}
+
+func (m *simExecutionStore) addTransferTask(task *persistence.TransferTaskInfo) {
+	m.Lock()
+	defer m.Unlock()
+	m.transferTasks[task.TaskID] = task
+}
+
+func (m *simExecutionStore) addExecution(info *persistence.WorkflowExecutionInfo) {
+	m.Lock()
+	defer m.Unlock()
+	m.executions[info.RunID] = &persistence.WorkflowMutableState{ExecutionInfo: info}
+}
+
+func (m *simExecutionStore) pendingTimers() int {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.timers)
+}
+
+func (m *simExecutionStore) pendingTransferTasks() int {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.transferTasks)
+}
+
+// firstPendingTimer returns the expiry time of the first timer not completed yet
+func (m *simExecutionStore) firstPendingTimer() int64 {
+	m.Lock()
+	defer m.Unlock()
+	first := int64(MaxTimerKey)
+	for key := range m.timers {
+		if key < first {
+			first = key
+		}
+	}
+	expiry, _ := DeconstructTimerKey(SequenceID(first))
+	return expiry
+}
+
+func (m *simExecutionStore) GetWorkflowExecution(ctx context.Context,
+	request *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.loadedRuns[request.Execution.GetRunId()]++
+	if state, ok := m.executions[request.Execution.GetRunId()]; ok {
+		return &persistence.GetWorkflowExecutionResponse{State: state}, nil
+	}
+	return nil, &workflow.EntityNotExistsError{Message: "Workflow execution not found."}
+}
+
+func (m *simExecutionStore) GetTimerIndexTasks(ctx context.Context,
+	request *persistence.GetTimerIndexTasksRequest) (*persistence.GetTimerIndexTasksResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	response := &persistence.GetTimerIndexTasksResponse{}
+	if len(request.TaskIDs) > 0 {
+		for _, taskID := range request.TaskIDs {
+			if timer, ok := m.timers[taskID]; ok && persistence.GetTimerQueue(timer.TaskType) == request.Queue {
+				response.Timers = append(response.Timers, timer)
+			}
+		}
+		return response, nil
+	}
+
+	var keys []int64
+	for key, timer := range m.timers {
+		if key >= request.MinKey && key < request.MaxKey && persistence.GetTimerQueue(timer.TaskType) == request.Queue {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	for i := 0; i < len(keys) && i < request.BatchSize; i++ {
+		response.Timers = append(response.Timers, m.timers[keys[i]])
+	}
+	return response, nil
+}
+
+func (m *simExecutionStore) CompleteTimerTask(ctx context.Context,
+	request *persistence.CompleteTimerTaskRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.timers[request.TaskID]; ok {
+		delete(m.timers, request.TaskID)
+		m.firedTimers[request.TaskID] = m.timeSource.Now()
+	}
+	return nil
+}
+
+func (m *simExecutionStore) GetTransferTasks(ctx context.Context,
+	request *persistence.GetTransferTasksRequest) (*persistence.GetTransferTasksResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	var taskIDs []int64
+	for taskID := range m.transferTasks {
+		if taskID > request.ReadLevel && taskID <= request.MaxReadLevel {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Slice(taskIDs, func(i, j int) bool { return taskIDs[i] < taskIDs[j] })
+	response := &persistence.GetTransferTasksResponse{}
+	for i := 0; i < len(taskIDs) && i < request.BatchSize; i++ {
+		response.Tasks = append(response.Tasks, m.transferTasks[taskIDs[i]])
+	}
+	return response, nil
+}
+
+func (m *simExecutionStore) CompleteTransferTask(ctx context.Context,
+	request *persistence.CompleteTransferTaskRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.transferTasks, request.TaskID)
+	return nil
+}
+
+func (m *simExecutionStore) PutTransferDLQTask(ctx context.Context,
+	request *persistence.PutTransferDLQTaskRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	m.dlqTasks[request.TaskInfo.TaskID] = request.TaskInfo
+	return nil
+}
@@ -21,6 +21,8 @@
 package history
 
 import (
+	"golang.org/x/net/context"
+
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
@@ -54,20 +56,20 @@ func (s *Service) Start() {
 
 	s.metricsClient = base.GetMetricsClient()
 
-	shardMgr, err := persistence.NewCassandraShardPersistence(p.CassandraConfig.Hosts,
-		p.CassandraConfig.Datacenter,
-		p.CassandraConfig.Keyspace,
-		p.Logger)
-
+	pFactory := base.GetPersistenceFactory()
+	shardMgr, err := pFactory.NewShardManager()
 	if err != nil {
 		log.Fatalf("failed to create shard manager: %v", err)
 	}
-	shardMgr = persistence.NewShardPersistenceClient(shardMgr, base.GetMetricsClient())
+
+	if err := verifyNumHistoryShards(shardMgr, p.NumHistoryShards); err != nil {
+		log.Fatalf("invalid number of history shards: %v", err)
+	}
 
 	// Hack to create shards for bootstrap purposes
 	// TODO: properly pre-create all shards before deployment.
-	for shardID := 0; shardID < p.CassandraConfig.NumHistoryShards; shardID++ {
-		shardMgr.CreateShard(&persistence.CreateShardRequest{
+	for shardID := 0; shardID < p.NumHistoryShards; shardID++ {
+		shardMgr.CreateShard(context.Background(), &persistence.CreateShardRequest{
 			ShardInfo: &persistence.ShardInfo{
 				ShardID:          shardID,
 				RangeID:          0,
@@ -75,44 +77,36 @@ func (s *Service) Start() {
 			}})
 	}
 
-	metadata, err := persistence.NewCassandraMetadataPersistence(p.CassandraConfig.Hosts,
-		p.CassandraConfig.Datacenter,
-		p.CassandraConfig.Keyspace,
-		p.Logger)
-
+	metadata, err := pFactory.NewMetadataManager()
 	if err != nil {
 		log.Fatalf("failed to create metadata manager: %v", err)
 	}
-	metadata = persistence.NewMetadataPersistenceClient(metadata, base.GetMetricsClient())
-
-	visibility, err := persistence.NewCassandraVisibilityPersistence(p.CassandraConfig.Hosts,
-		p.CassandraConfig.Datacenter,
-		p.CassandraConfig.VisibilityKeyspace,
-		p.Logger)
 
+	visibility, err := pFactory.NewVisibilityManager()
 	if err != nil {
 		log.Fatalf("failed to create visiblity manager: %v", err)
 	}
 
-	history, err := persistence.NewCassandraHistoryPersistence(p.CassandraConfig.Hosts,
-		p.CassandraConfig.Datacenter,
-		p.CassandraConfig.Keyspace,
-		p.Logger)
-
+	history, err := pFactory.NewHistoryManager()
 	if err != nil {
-		log.Fatalf("Creating Cassandra history manager persistence failed: %v", err)
+		log.Fatalf("failed to create history manager: %v", err)
 	}
 
-	history = persistence.NewHistoryPersistenceClient(history, base.GetMetricsClient())
-	execMgrFactory := NewExecutionManagerFactory(&p.CassandraConfig, p.Logger, base.GetMetricsClient())
+	blobClient, err := p.Blobstore.NewClient()
+	if err != nil {
+		log.Fatalf("failed to create blobstore client: %v", err)
+	}
 
 	handler, tchanServers := NewHandler(base,
 		shardMgr,
 		metadata,
 		visibility,
 		history,
-		execMgrFactory,
-		p.CassandraConfig.NumHistoryShards)
+		pFactory,
+		blobClient,
+		p.LargePayloads,
+		p.NumHistoryShards,
+		NewConfig())
 
 	handler.Start(tchanServers)
 
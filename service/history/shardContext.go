@@ -22,24 +22,35 @@ package history
 
 import (
 	"fmt"
+	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 )
 
 const (
 	defaultRangeSize = 20 // 20 bits for sequencer, 2^20 sequence number for any range
+	// timerRangeIDBits is the number of bits of timer sequence numbers taken from the range ID of the shard, the
+	// others number the timers created within the range, which gives each range 2^16 timers
+	timerRangeIDBits = 4
+	timerRangeSize   = TimerQueueSeqNumBits - timerRangeIDBits
+	// persistenceLatencySmoothing is the weight of the current average persistence latency against a new sample
+	persistenceLatencySmoothing = 8
 )
 
 type (
 	// ShardContext represents a history engine shard
 	ShardContext interface {
+		GetShardID() int
 		GetExecutionManager() persistence.ExecutionManager
 		GetHistoryManager() persistence.HistoryManager
 		GetNextTransferTaskID() (int64, error)
@@ -47,38 +58,63 @@ type (
 		GetTransferMaxReadLevel() int64
 		GetTransferAckLevel() int64
 		UpdateAckLevel(ackLevel int64) error
-		GetTimerSequenceNumber() int64
-		CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (
+		GetTimerAckLevel(queue string) int64
+		UpdateTimerAckLevel(queue string, ackLevel int64) error
+		GetQueuePaused(queueType string) (paused bool, ok bool)
+		UpdateQueuePaused(queueType string, paused bool) error
+		GetTimerSequenceNumber() (int64, error)
+		GetTransferQueueDepth() int64
+		TransferTaskProcessed(taskID int64)
+		GetPersistenceLatency() time.Duration
+		GetTimeSource() common.TimeSource
+		CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (
 			*persistence.CreateWorkflowExecutionResponse, error)
-		UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) error
-		AppendHistoryEvents(request *persistence.AppendHistoryEventsRequest) error
+		UpdateWorkflowExecution(ctx context.Context, request *persistence.UpdateWorkflowExecutionRequest) error
+		AppendHistoryEvents(ctx context.Context, request *persistence.AppendHistoryEventsRequest) error
+		DeleteWorkflowExecution(ctx context.Context, request *persistence.DeleteWorkflowExecutionRequest) error
+		CompleteTransferTask(ctx context.Context, request *persistence.CompleteTransferTaskRequest) error
+		PutTransferDLQTask(ctx context.Context, request *persistence.PutTransferDLQTaskRequest) error
+		RedriveTransferDLQTask(ctx context.Context, request *persistence.RedriveTransferDLQTaskRequest) error
+		CompleteTimerTask(ctx context.Context, request *persistence.CompleteTimerTaskRequest) error
 		GetLogger() bark.Logger
 		GetMetricsClient() metrics.Client
+		IsClosed() bool
 	}
 
 	shardContextImpl struct {
-		shardID             int
-		rangeID             int64
-		shardManager        persistence.ShardManager
-		historyMgr          persistence.HistoryManager
-		executionManager    persistence.ExecutionManager
-		timerSequenceNumber int64
-		rangeSize           uint
-		closeCh             chan<- int
-		isClosed            bool
-		logger              bark.Logger
-		metricsClient       metrics.Client
+		shardID            int
+		rangeID            int64
+		shardManager       persistence.ShardManager
+		historyMgr         persistence.HistoryManager
+		executionManager   persistence.ExecutionManager
+		transferQueueDepth int64 // transfer tasks persisted by this host and not yet processed
+		// first task ID allocated to this host, the transfer tasks below it were persisted by the previous owners
+		firstTaskID        int64
+		persistenceLatency int64 // moving average of persistence write latency, in nanoseconds
+		rangeSize          uint
+		closeEvents        *shardEventBus
+		isClosed           bool
+		logger             bark.Logger
+		metricsClient      metrics.Client
+		timeSource         common.TimeSource
 
 		sync.RWMutex
-		shardInfo                 *persistence.ShardInfo
-		transferSequenceNumber    int64
-		maxTransferSequenceNumber int64
-		transferMaxReadLevel      int64
+		shardInfo *persistence.ShardInfo
+		// taskSequenceNumber is the next task ID of the range allocated to this host, up to maxTaskSequenceNumber
+		taskSequenceNumber    int64
+		maxTaskSequenceNumber int64
+		transferMaxReadLevel  int64
+		// timerSequenceNumber numbers the timers created within the range allocated to this host
+		timerSequenceNumber int64
 	}
 )
 
 var _ ShardContext = (*shardContextImpl)(nil)
 
+func (s *shardContextImpl) GetShardID() int {
+	return s.shardID
+}
+
 func (s *shardContextImpl) GetExecutionManager() persistence.ExecutionManager {
 	return s.executionManager
 }
@@ -91,14 +127,14 @@ func (s *shardContextImpl) GetNextTransferTaskID() (int64, error) {
 	s.Lock()
 	defer s.Unlock()
 
-	return s.getNextTransferTaskIDLocked()
+	return s.getNextTaskIDLocked()
 }
 
 func (s *shardContextImpl) GetTransferSequenceNumber() int64 {
 	s.RLock()
 	defer s.RUnlock()
 
-	return s.transferSequenceNumber - 1
+	return s.taskSequenceNumber - 1
 }
 
 func (s *shardContextImpl) GetTransferAckLevel() int64 {
@@ -118,10 +154,62 @@ func (s *shardContextImpl) UpdateAckLevel(ackLevel int64) error {
 	s.Lock()
 	defer s.Unlock()
 	s.shardInfo.TransferAckLevel = ackLevel
+	return s.updateShardInfoLocked()
+}
+
+// GetTimerAckLevel returns the ack level of the named timer queue.  The active queue keeps the ack level the shard
+// had before timers were split into queues.
+func (s *shardContextImpl) GetTimerAckLevel(queue string) int64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	if queue == activeTimerQueueName {
+		return s.shardInfo.TimerAckLevel
+	}
+	return s.shardInfo.TimerAckLevels[queue]
+}
+
+func (s *shardContextImpl) UpdateTimerAckLevel(queue string, ackLevel int64) error {
+	s.Lock()
+	defer s.Unlock()
+	if queue == activeTimerQueueName {
+		s.shardInfo.TimerAckLevel = ackLevel
+	} else {
+		if s.shardInfo.TimerAckLevels == nil {
+			s.shardInfo.TimerAckLevels = make(map[string]int64)
+		}
+		s.shardInfo.TimerAckLevels[queue] = ackLevel
+	}
+	return s.updateShardInfoLocked()
+}
+
+// GetQueuePaused returns the paused state of the queue processor persisted on the shard, ok being false when it
+// was never set through the admin API
+func (s *shardContextImpl) GetQueuePaused(queueType string) (bool, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	paused, ok := s.shardInfo.QueuePaused[queueType]
+	return paused, ok
+}
+
+// UpdateQueuePaused persists the paused state of the queue processor on the shard, so the next owner of the shard
+// starts it in the same state
+func (s *shardContextImpl) UpdateQueuePaused(queueType string, paused bool) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.shardInfo.QueuePaused == nil {
+		s.shardInfo.QueuePaused = make(map[string]bool)
+	}
+	s.shardInfo.QueuePaused[queueType] = paused
+	return s.updateShardInfoLocked()
+}
+
+func (s *shardContextImpl) updateShardInfoLocked() error {
 	s.shardInfo.StolenSinceRenew = 0
 	updatedShardInfo := copyShardInfo(s.shardInfo)
 
-	err := s.shardManager.UpdateShard(&persistence.UpdateShardRequest{
+	err := s.shardManager.UpdateShard(context.Background(), &persistence.UpdateShardRequest{
 		ShardInfo:       updatedShardInfo,
 		PreviousRangeID: s.shardInfo.RangeID,
 	})
@@ -129,18 +217,55 @@ func (s *shardContextImpl) UpdateAckLevel(ackLevel int64) error {
 	if err != nil {
 		// Shard is stolen, trigger history engine shutdown
 		if _, ok := err.(*persistence.ShardOwnershipLostError); ok {
-			s.closeShard()
+			s.closeShard(shardCloseReasonLeaseLost, err)
 		}
 	}
 
 	return err
 }
 
-func (s *shardContextImpl) GetTimerSequenceNumber() int64 {
-	return atomic.AddInt64(&s.timerSequenceNumber, 1)
+func (s *shardContextImpl) GetTimeSource() common.TimeSource {
+	return s.timeSource
+}
+
+// GetTimerSequenceNumber returns the sequence number of a new timer.  Only the low TimerQueueSeqNumBits of sequence
+// numbers make it into timer keys, so the range ID is kept in the top timerRangeIDBits of them and the timers created
+// within the range are numbered in the others.  A new range is allocated once they are all used.  Timers written by
+// the owners of the last 2^timerRangeIDBits ranges of the shard thus never share a key, an older timer is only
+// overwritten by a new one with exactly the same expiry and number.
+func (s *shardContextImpl) GetTimerSequenceNumber() (int64, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.timerSequenceNumber >= 1<<timerRangeSize {
+		s.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.TimerSequenceExhaustedCounter)
+		if err := s.renewRangeLocked(false); err != nil {
+			return -1, err
+		}
+	}
+
+	seqNum := (s.rangeID<<timerRangeSize | s.timerSequenceNumber) & TimerQueueSeqNumBitmask
+	s.timerSequenceNumber++
+	return seqNum, nil
+}
+
+func (s *shardContextImpl) GetTransferQueueDepth() int64 {
+	return atomic.LoadInt64(&s.transferQueueDepth)
+}
+
+// TransferTaskProcessed takes a processed transfer task off the queue depth.  Tasks persisted by the previous owners
+// of the shard were never counted, so they are left out.
+func (s *shardContextImpl) TransferTaskProcessed(taskID int64) {
+	if taskID >= s.firstTaskID {
+		atomic.AddInt64(&s.transferQueueDepth, -1)
+	}
 }
 
-func (s *shardContextImpl) CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (
+func (s *shardContextImpl) GetPersistenceLatency() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.persistenceLatency))
+}
+
+func (s *shardContextImpl) CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (
 	*persistence.CreateWorkflowExecutionResponse, error) {
 	s.Lock()
 	defer s.Unlock()
@@ -150,7 +275,7 @@ func (s *shardContextImpl) CreateWorkflowExecution(request *persistence.CreateWo
 	// Must be done under the shard lock to ensure transfer tasks are written to persistence in increasing
 	// ID order
 	for _, task := range request.TransferTasks {
-		id, err := s.getNextTransferTaskIDLocked()
+		id, err := s.getNextTaskIDLocked()
 		if err != nil {
 			return nil, err
 		}
@@ -164,7 +289,9 @@ Create_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
-		response, err := s.executionManager.CreateWorkflowExecution(request)
+		startTime := time.Now()
+		response, err := s.executionManager.CreateWorkflowExecution(ctx, request)
+		s.recordPersistenceLatency(startTime)
 		if err != nil {
 			switch err.(type) {
 			case *persistence.ShardOwnershipLostError:
@@ -175,7 +302,7 @@ Create_Loop:
 						continue Create_Loop
 					} else {
 						// Shard is stolen, trigger shutdown of history engine
-						s.closeShard()
+						s.closeShard(shardCloseReasonLeaseLost, err)
 					}
 				}
 			case *shared.WorkflowExecutionAlreadyStartedError:
@@ -190,19 +317,23 @@ Create_Loop:
 					if err1 != nil {
 						// At this point we have no choice but to unload the shard, so that it
 						// gets a new RangeID when it's reloaded.
-						s.closeShard()
+						s.closeShard(shardCloseReasonError, err1)
 					}
 				}
 			}
 		}
 
+		if err == nil {
+			atomic.AddInt64(&s.transferQueueDepth, int64(len(request.TransferTasks)))
+		}
+
 		return response, err
 	}
 
 	return nil, ErrMaxAttemptsExceeded
 }
 
-func (s *shardContextImpl) UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) error {
+func (s *shardContextImpl) UpdateWorkflowExecution(ctx context.Context, request *persistence.UpdateWorkflowExecutionRequest) error {
 	s.Lock()
 	defer s.Unlock()
 
@@ -211,7 +342,7 @@ func (s *shardContextImpl) UpdateWorkflowExecution(request *persistence.UpdateWo
 	// Must be done under the shard lock to ensure transfer tasks are written to persistence in increasing
 	// ID order
 	for _, task := range request.TransferTasks {
-		id, err := s.getNextTransferTaskIDLocked()
+		id, err := s.getNextTaskIDLocked()
 		if err != nil {
 			return err
 		}
@@ -222,7 +353,7 @@ func (s *shardContextImpl) UpdateWorkflowExecution(request *persistence.UpdateWo
 
 	if request.ContinueAsNew != nil {
 		for _, task := range request.ContinueAsNew.TransferTasks {
-			id, err := s.getNextTransferTaskIDLocked()
+			id, err := s.getNextTaskIDLocked()
 			if err != nil {
 				return err
 			}
@@ -237,7 +368,9 @@ Update_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
-		err := s.executionManager.UpdateWorkflowExecution(request)
+		startTime := time.Now()
+		err := s.executionManager.UpdateWorkflowExecution(ctx, request)
+		s.recordPersistenceLatency(startTime)
 		if err != nil {
 			switch err.(type) {
 			case *persistence.ShardOwnershipLostError:
@@ -248,7 +381,7 @@ Update_Loop:
 						continue Update_Loop
 					} else {
 						// Shard is stolen, trigger shutdown of history engine
-						s.closeShard()
+						s.closeShard(shardCloseReasonLeaseLost, err)
 					}
 				}
 			case *persistence.ConditionFailedError:
@@ -263,34 +396,153 @@ Update_Loop:
 					if err1 != nil {
 						// At this point we have no choice but to unload the shard, so that it
 						// gets a new RangeID when it's reloaded.
-						s.closeShard()
+						s.closeShard(shardCloseReasonError, err1)
 					}
 				}
 			}
 		}
 
+		if err == nil {
+			transferTaskCount := len(request.TransferTasks)
+			if request.ContinueAsNew != nil {
+				transferTaskCount += len(request.ContinueAsNew.TransferTasks)
+			}
+			atomic.AddInt64(&s.transferQueueDepth, int64(transferTaskCount))
+		}
+
 		return err
 	}
 
 	return ErrMaxAttemptsExceeded
 }
 
-func (s *shardContextImpl) AppendHistoryEvents(request *persistence.AppendHistoryEventsRequest) error {
+func (s *shardContextImpl) AppendHistoryEvents(ctx context.Context, request *persistence.AppendHistoryEventsRequest) error {
 	// No need to lock context here, as we can write concurrently to append history events
 	currentRangeID := atomic.LoadInt64(&s.rangeID)
 	request.RangeID = currentRangeID
-	err0 := s.historyMgr.AppendHistoryEvents(request)
+	startTime := time.Now()
+	err0 := s.historyMgr.AppendHistoryEvents(ctx, request)
+	s.recordPersistenceLatency(startTime)
 	if err0 != nil {
 		if _, ok := err0.(*persistence.ConditionFailedError); ok {
 			// Inserting a new event failed, lets try to overwrite the tail
 			request.Overwrite = true
-			return s.historyMgr.AppendHistoryEvents(request)
+			return s.historyMgr.AppendHistoryEvents(ctx, request)
 		}
 	}
 
 	return err0
 }
 
+func (s *shardContextImpl) DeleteWorkflowExecution(ctx context.Context,
+	request *persistence.DeleteWorkflowExecutionRequest) error {
+	return s.executeWithRangeID(func(rangeID int64) error {
+		request.RangeID = rangeID
+		return s.executionManager.DeleteWorkflowExecution(ctx, request)
+	})
+}
+
+// CompleteTransferTask deletes a processed transfer task.  Completions are not fenced on the rangeID, which would make
+// each of them a conditional write on the shard: tasks are never rewritten, so a host which lost the shard can only
+// delete a task it processed itself, which the new owner would process again otherwise.
+func (s *shardContextImpl) CompleteTransferTask(ctx context.Context,
+	request *persistence.CompleteTransferTaskRequest) error {
+	if s.IsClosed() {
+		return s.closedShardError()
+	}
+	return s.executionManager.CompleteTransferTask(ctx, request)
+}
+
+func (s *shardContextImpl) PutTransferDLQTask(ctx context.Context,
+	request *persistence.PutTransferDLQTaskRequest) error {
+	return s.executeWithRangeID(func(rangeID int64) error {
+		request.RangeID = rangeID
+		return s.executionManager.PutTransferDLQTask(ctx, request)
+	})
+}
+
+// RedriveTransferDLQTask moves a parked transfer task back to the transfer queue.  Its new task ID is assigned under
+// the shard lock, like the IDs of the transfer tasks of workflow updates, so transfer tasks are persisted in ID order.
+func (s *shardContextImpl) RedriveTransferDLQTask(ctx context.Context,
+	request *persistence.RedriveTransferDLQTaskRequest) error {
+	s.Lock()
+	defer s.Unlock()
+
+	id, err := s.getNextTaskIDLocked()
+	if err != nil {
+		return err
+	}
+	request.NewTaskID = id
+	defer s.updateMaxReadLevelLocked(id)
+
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		currentRangeID := s.getRangeID()
+		request.RangeID = currentRangeID
+		startTime := time.Now()
+		err := s.executionManager.RedriveTransferDLQTask(ctx, request)
+		s.recordPersistenceLatency(startTime)
+		switch err.(type) {
+		case nil:
+			atomic.AddInt64(&s.transferQueueDepth, 1)
+		case *persistence.ShardOwnershipLostError:
+			// RangeID might have been renewed by the same host while this update was in flight
+			if currentRangeID != s.getRangeID() {
+				continue
+			}
+			// Shard is stolen, trigger shutdown of history engine
+			s.closeShard(shardCloseReasonLeaseLost, err)
+		default:
+			// The task may still be written, renew the RangeID like for workflow updates so the outcome is known
+			if err1 := s.renewRangeLocked(false); err1 != nil {
+				s.closeShard(shardCloseReasonError, err1)
+			}
+		}
+		return err
+	}
+
+	return ErrMaxAttemptsExceeded
+}
+
+// CompleteTimerTask deletes a fired timer task, unfenced like CompleteTransferTask
+func (s *shardContextImpl) CompleteTimerTask(ctx context.Context, request *persistence.CompleteTimerTaskRequest) error {
+	if s.IsClosed() {
+		return s.closedShardError()
+	}
+	return s.executionManager.CompleteTimerTask(ctx, request)
+}
+
+func (s *shardContextImpl) closedShardError() error {
+	return &persistence.ShardOwnershipLostError{
+		ShardID: s.shardID,
+		Msg:     "Shard is closed, it was acquired by another host.",
+	}
+}
+
+// executeWithRangeID runs a write fenced on the current rangeID of the shard.  The shard lock is not held during the
+// write, so a rangeID renewed by this host while the write was in flight is retried, while a stolen shard is closed.
+func (s *shardContextImpl) executeWithRangeID(op func(rangeID int64) error) error {
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		currentRangeID := atomic.LoadInt64(&s.rangeID)
+		startTime := time.Now()
+		err := op(currentRangeID)
+		s.recordPersistenceLatency(startTime)
+		if _, ok := err.(*persistence.ShardOwnershipLostError); ok {
+			s.Lock()
+			if currentRangeID != s.getRangeID() {
+				s.Unlock()
+				continue
+			}
+			// Shard is stolen, trigger shutdown of history engine
+			s.closeShard(shardCloseReasonLeaseLost, err)
+			s.Unlock()
+		}
+
+		return err
+	}
+
+	return ErrMaxAttemptsExceeded
+}
+
 func (s *shardContextImpl) GetLogger() bark.Logger {
 	return s.logger
 }
@@ -299,11 +551,31 @@ func (s *shardContextImpl) GetMetricsClient() metrics.Client {
 	return s.metricsClient
 }
 
+// IsClosed returns true once the shard was stolen by another host, the state cached for it can't be trusted anymore
+func (s *shardContextImpl) IsClosed() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.isClosed
+}
+
+// recordPersistenceLatency folds the latency of a persistence write started at startTime into the moving average
+// reported by GetPersistenceLatency
+func (s *shardContextImpl) recordPersistenceLatency(startTime time.Time) {
+	latency := int64(time.Since(startTime))
+	for {
+		average := atomic.LoadInt64(&s.persistenceLatency)
+		updated := average + (latency-average)/persistenceLatencySmoothing
+		if atomic.CompareAndSwapInt64(&s.persistenceLatency, average, updated) {
+			return
+		}
+	}
+}
+
 func (s *shardContextImpl) getRangeID() int64 {
 	return s.shardInfo.RangeID
 }
 
-func (s *shardContextImpl) closeShard() {
+func (s *shardContextImpl) closeShard(reason shardCloseReason, err error) {
 	if s.isClosed {
 		return
 	}
@@ -314,61 +586,66 @@ func (s *shardContextImpl) closeShard() {
 	s.shardInfo.RangeID = -1
 	atomic.StoreInt64(&s.rangeID, s.shardInfo.RangeID)
 
-	if s.closeCh != nil {
-		// This is the channel passed in by shard controller to monitor if a shard needs to be unloaded
+	if s.closeEvents != nil {
+		// This is the event bus passed in by shard controller to monitor if a shard needs to be unloaded
 		// It will trigger the HistoryEngine unload and removal of engine from shard controller
-		s.closeCh <- s.shardID
+		s.closeEvents.publish(&shardClosedEvent{shardID: s.shardID, reason: reason, err: err})
 	}
 }
 
-func (s *shardContextImpl) getNextTransferTaskIDLocked() (int64, error) {
+func (s *shardContextImpl) getNextTaskIDLocked() (int64, error) {
 	if err := s.updateRangeIfNeededLocked(); err != nil {
 		return -1, err
 	}
 
-	taskID := s.transferSequenceNumber
-	s.transferSequenceNumber++
+	taskID := s.taskSequenceNumber
+	s.taskSequenceNumber++
 
 	return taskID, nil
 }
 
 func (s *shardContextImpl) updateRangeIfNeededLocked() error {
-	if s.transferSequenceNumber < s.maxTransferSequenceNumber {
+	if s.taskSequenceNumber < s.maxTaskSequenceNumber {
 		return nil
 	}
 
+	s.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardRangeExhaustedCounter)
 	return s.renewRangeLocked(false)
 }
 
+// renewRangeLocked allocates the next range of task IDs of the shard.  Allocating a range bumps the range ID of the
+// shard, which fences off writes of any previous owner still holding the shard.
 func (s *shardContextImpl) renewRangeLocked(isStealing bool) error {
 	updatedShardInfo := copyShardInfo(s.shardInfo)
-	updatedShardInfo.RangeID++
 	if isStealing {
 		updatedShardInfo.StolenSinceRenew++
 	}
 
-	err := s.shardManager.UpdateShard(&persistence.UpdateShardRequest{
-		ShardInfo:       updatedShardInfo,
-		PreviousRangeID: s.shardInfo.RangeID})
+	response, err := s.shardManager.AllocateTaskIDs(context.Background(), &persistence.AllocateTaskIDsRequest{
+		ShardInfo:     updatedShardInfo,
+		RangeSizeBits: s.rangeSize,
+	})
 	if err != nil {
 		logging.LogPersistantStoreErrorEvent(s.logger, logging.TagValueStoreOperationUpdateShard, err,
 			fmt.Sprintf("{RangeID: %v}", s.shardInfo.RangeID))
 		// Shard is stolen, trigger history engine shutdown
 		if _, ok := err.(*persistence.ShardOwnershipLostError); ok {
-			s.closeShard()
+			s.closeShard(shardCloseReasonLeaseLost, err)
 		}
 		return err
 	}
 
 	// Range is successfully updated in cassandra now update shard context to reflect new range
-	s.transferSequenceNumber = updatedShardInfo.RangeID << s.rangeSize
-	s.maxTransferSequenceNumber = (updatedShardInfo.RangeID + 1) << s.rangeSize
-	s.transferMaxReadLevel = s.transferSequenceNumber - 1
+	updatedShardInfo.RangeID = response.RangeID
+	s.taskSequenceNumber = response.MinTaskID
+	s.maxTaskSequenceNumber = response.MaxTaskID
+	s.transferMaxReadLevel = s.taskSequenceNumber - 1
+	s.timerSequenceNumber = 0
 	atomic.StoreInt64(&s.rangeID, updatedShardInfo.RangeID)
 	s.shardInfo = updatedShardInfo
 
-	logging.LogShardRangeUpdatedEvent(s.logger, s.shardInfo.ShardID, s.shardInfo.RangeID, s.transferSequenceNumber,
-		s.maxTransferSequenceNumber)
+	logging.LogShardRangeUpdatedEvent(s.logger, s.shardInfo.ShardID, s.shardInfo.RangeID, s.taskSequenceNumber,
+		s.maxTaskSequenceNumber)
 
 	return nil
 }
@@ -382,9 +659,9 @@ func (s *shardContextImpl) updateMaxReadLevelLocked(rl int64) {
 
 // TODO: This method has too many parameters.  Clean it up.  Maybe create a struct to pass in as parameter.
 func acquireShard(shardID int, shardManager persistence.ShardManager, historyMgr persistence.HistoryManager,
-	executionMgr persistence.ExecutionManager, owner string, closeCh chan<- int, logger bark.Logger,
+	executionMgr persistence.ExecutionManager, owner string, closeEvents *shardEventBus, logger bark.Logger,
 	reporter metrics.Client) (ShardContext, error) {
-	response, err0 := shardManager.GetShard(&persistence.GetShardRequest{ShardID: shardID})
+	response, err0 := shardManager.GetShard(context.Background(), &persistence.GetShardRequest{ShardID: shardID})
 	if err0 != nil {
 		return nil, err0
 	}
@@ -399,13 +676,14 @@ func acquireShard(shardID int, shardManager persistence.ShardManager, historyMgr
 		executionManager: executionMgr,
 		shardInfo:        updatedShardInfo,
 		rangeSize:        defaultRangeSize,
-		closeCh:          closeCh,
+		closeEvents:      closeEvents,
+		timeSource:       common.NewRealTimeSource(),
 	}
 	context.logger = logger.WithFields(bark.Fields{
 		logging.TagHistoryShardID: shardID,
 	})
 	tags := map[string]string{
-		metrics.ShardTagName: string(shardID),
+		metrics.ShardTagName: strconv.Itoa(shardID),
 	}
 	context.metricsClient = reporter.Tagged(tags)
 
@@ -413,6 +691,7 @@ func acquireShard(shardID int, shardManager persistence.ShardManager, historyMgr
 	if err1 != nil {
 		return nil, err1
 	}
+	context.firstTaskID = context.taskSequenceNumber
 
 	return context, nil
 }
@@ -424,6 +703,19 @@ func copyShardInfo(shardInfo *persistence.ShardInfo) *persistence.ShardInfo {
 		RangeID:          shardInfo.RangeID,
 		StolenSinceRenew: shardInfo.StolenSinceRenew,
 		TransferAckLevel: atomic.LoadInt64(&shardInfo.TransferAckLevel),
+		TimerAckLevel:    atomic.LoadInt64(&shardInfo.TimerAckLevel),
+	}
+	if shardInfo.TimerAckLevels != nil {
+		shardInfoCopy.TimerAckLevels = make(map[string]int64, len(shardInfo.TimerAckLevels))
+		for queue, ackLevel := range shardInfo.TimerAckLevels {
+			shardInfoCopy.TimerAckLevels[queue] = ackLevel
+		}
+	}
+	if shardInfo.QueuePaused != nil {
+		shardInfoCopy.QueuePaused = make(map[string]bool, len(shardInfo.QueuePaused))
+		for queueType, paused := range shardInfo.QueuePaused {
+			shardInfoCopy.QueuePaused[queueType] = paused
+		}
 	}
 
 	return shardInfoCopy
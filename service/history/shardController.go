@@ -27,8 +27,11 @@ import (
 	"time"
 
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 
+	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/errors"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
@@ -51,7 +54,7 @@ type (
 		historyMgr          persistence.HistoryManager
 		executionMgrFactory persistence.ExecutionManagerFactory
 		engineFactory       EngineFactory
-		shardClosedCh       chan int
+		shardEvents         *shardEventBus
 		isStarted           int32
 		isStopped           int32
 		shutdownWG          sync.WaitGroup
@@ -95,7 +98,7 @@ func newShardController(numberOfShards int, host *membership.HostInfo, resolver
 		executionMgrFactory: executionMgrFactory,
 		engineFactory:       factory,
 		historyShards:       make(map[int]*historyShardsItem),
-		shardClosedCh:       make(chan int, numberOfShards),
+		shardEvents:         newShardEventBus(),
 		shutdownCh:          make(chan struct{}),
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueShardController,
@@ -170,7 +173,34 @@ func (c *shardController) getEngineForShard(shardID int) (Engine, error) {
 		return nil, err
 	}
 
-	return item.getOrCreateEngine(c.shardClosedCh)
+	return item.getOrCreateEngine(c.shardEvents)
+}
+
+// getShardContext returns the context of the shard owning the workflow, or nil if the shard is not loaded on this host
+func (c *shardController) getShardContext(workflowID string) ShardContext {
+	shardID := common.WorkflowIDToHistoryShard(workflowID, c.numberOfShards)
+	c.RLock()
+	item, ok := c.historyShards[shardID]
+	c.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	return item.getContext()
+}
+
+// getShardContexts returns the contexts of all shards loaded on this host, by shard ID
+func (c *shardController) getShardContexts() map[int]ShardContext {
+	c.RLock()
+	defer c.RUnlock()
+
+	contexts := make(map[int]ShardContext)
+	for shardID, item := range c.historyShards {
+		if context := item.getContext(); context != nil {
+			contexts[shardID] = context
+		}
+	}
+	return contexts
 }
 
 func (c *shardController) removeEngineForShard(shardID int) {
@@ -196,7 +226,7 @@ func (c *shardController) getOrCreateHistoryShardItem(shardID int) (*historyShar
 	}
 
 	if c.isStopping {
-		return nil, fmt.Errorf("shardController for host '%v' shutting down", c.host.Identity())
+		return nil, errors.NewServiceBusyError("shardController for host '%v' shutting down", c.host.Identity())
 	}
 	info, err := c.hServiceResolver.Lookup(string(shardID))
 	if err != nil {
@@ -211,7 +241,7 @@ func (c *shardController) getOrCreateHistoryShardItem(shardID int) (*historyShar
 		return shardItem, nil
 	}
 
-	return nil, createShardOwnershipLostError(c.host.Identity(), info.GetAddress())
+	return nil, errors.NewShardOwnershipLostError(c.host.Identity(), info.GetAddress())
 }
 
 func (c *shardController) removeHistoryShardItem(shardID int) (*historyShardsItem, error) {
@@ -220,7 +250,7 @@ func (c *shardController) removeHistoryShardItem(shardID int) (*historyShardsIte
 
 	item, ok := c.historyShards[shardID]
 	if !ok {
-		return nil, fmt.Errorf("No item found to remove for shard: %v", shardID)
+		return nil, errors.NewInternalServiceError("No item found to remove for shard: %v", shardID)
 	}
 
 	delete(c.historyShards, shardID)
@@ -244,6 +274,8 @@ func (c *shardController) shardManagementPump() {
 			for _, item := range c.historyShards {
 				item.stopEngine()
 			}
+			c.metricsClient.AddCounter(metrics.HistoryShardControllerScope, metrics.ShardClosedShutdownCounter,
+				int64(len(c.historyShards)))
 			c.historyShards = nil
 			return
 		case <-acquireTicker.C:
@@ -252,32 +284,54 @@ func (c *shardController) shardManagementPump() {
 			logging.LogRingMembershipChangedEvent(c.logger, c.host.Identity(), len(changedEvent.HostsAdded),
 				len(changedEvent.HostsRemoved), len(changedEvent.HostsUpdated))
 			c.acquireShards()
-		case shardID := <-c.shardClosedCh:
-			logging.LogShardClosedEvent(c.logger, c.host.Identity(), shardID)
-			c.removeEngineForShard(shardID)
+		case <-c.shardEvents.notifications():
+			for _, event := range c.shardEvents.drain() {
+				c.handleShardClosed(event)
+			}
 		}
 	}
 }
 
+func (c *shardController) handleShardClosed(event *shardClosedEvent) {
+	logging.LogShardClosedEvent(c.logger, c.host.Identity(), event.shardID, event.reason.String(), event.err)
+	c.removeEngineForShard(event.shardID)
+
+	switch event.reason {
+	case shardCloseReasonLeaseLost:
+		// The shard is owned by another host now, it is acquired again once the ring says so
+		c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardClosedLeaseLostCounter)
+	case shardCloseReasonError:
+		// The host may still own the shard, reacquiring it right away renews its range instead of leaving it
+		// unloaded until the next acquire interval
+		c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardClosedErrorCounter)
+		c.acquireShard(event.shardID)
+	case shardCloseReasonShutdown:
+		c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardClosedShutdownCounter)
+	}
+}
+
 func (c *shardController) acquireShards() {
-AcquireLoop:
 	for shardID := 0; shardID < c.numberOfShards; shardID++ {
-		info, err := c.hServiceResolver.Lookup(string(shardID))
-		if err != nil {
-			logging.LogOperationFailedEvent(c.logger, fmt.Sprintf("Error looking up host for shardID: %v", shardID), err)
-			continue AcquireLoop
-		}
+		c.acquireShard(shardID)
+	}
+}
 
-		if info.Identity() == c.host.Identity() {
-			_, err1 := c.getEngineForShard(shardID)
-			if err1 != nil {
-				logging.LogOperationFailedEvent(c.logger, fmt.Sprintf("Unable to create history shard engine: %v", shardID),
-					err1)
-				continue AcquireLoop
-			}
-		} else {
-			c.removeEngineForShard(shardID)
+// acquireShard loads the engine of the shard if the host owns it, and unloads it otherwise
+func (c *shardController) acquireShard(shardID int) {
+	info, err := c.hServiceResolver.Lookup(string(shardID))
+	if err != nil {
+		logging.LogOperationFailedEvent(c.logger, fmt.Sprintf("Error looking up host for shardID: %v", shardID), err)
+		return
+	}
+
+	if info.Identity() == c.host.Identity() {
+		_, err1 := c.getEngineForShard(shardID)
+		if err1 != nil {
+			logging.LogOperationFailedEvent(c.logger, fmt.Sprintf("Unable to create history shard engine: %v", shardID),
+				err1)
 		}
+	} else {
+		c.removeEngineForShard(shardID)
 	}
 }
 
@@ -288,7 +342,14 @@ func (i *historyShardsItem) getEngine() Engine {
 	return i.engine
 }
 
-func (i *historyShardsItem) getOrCreateEngine(shardClosedCh chan<- int) (Engine, error) {
+func (i *historyShardsItem) getContext() ShardContext {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.context
+}
+
+func (i *historyShardsItem) getOrCreateEngine(shardEvents *shardEventBus) (Engine, error) {
 	i.RLock()
 	if i.engine != nil {
 		defer i.RUnlock()
@@ -310,12 +371,14 @@ func (i *historyShardsItem) getOrCreateEngine(shardClosedCh chan<- int) (Engine,
 		return nil, err
 	}
 
-	context, err := acquireShard(i.shardID, i.shardMgr, i.historyMgr, executionMgr, i.host.Identity(), shardClosedCh,
+	context, err := acquireShard(i.shardID, i.shardMgr, i.historyMgr, executionMgr, i.host.Identity(), shardEvents,
 		i.logger, i.metricsClient)
 	if err != nil {
+		executionMgr.Close()
 		return nil, err
 	}
 
+	i.context = context
 	i.engine = i.engineFactory.CreateEngine(context)
 	i.engine.Start()
 
@@ -330,10 +393,46 @@ func (i *historyShardsItem) stopEngine() {
 
 	if i.engine != nil {
 		i.engine.Stop()
+		// The execution manager is only used by the engine of the shard
+		i.context.GetExecutionManager().Close()
 		i.engine = nil
+		i.context = nil
 	}
 }
 
+// verifyNumHistoryShards fails if the shards in persistence were created for a different number of history shards,
+// as workflow executions would then be looked up in the wrong shards.  A new cluster has no shards yet.
+func verifyNumHistoryShards(shardMgr persistence.ShardManager, numberOfShards int) error {
+	shardExists := func(shardID int) (bool, error) {
+		_, err := shardMgr.GetShard(context.Background(), &persistence.GetShardRequest{ShardID: shardID})
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); ok {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	if exists, err := shardExists(0); err != nil || !exists {
+		return err
+	}
+	if exists, err := shardExists(numberOfShards - 1); err != nil {
+		return err
+	} else if !exists {
+		return fmt.Errorf("cluster has less than %v history shards, reshard executions before changing "+
+			"numHistoryShards", numberOfShards)
+	}
+	if exists, err := shardExists(numberOfShards); err != nil {
+		return err
+	} else if exists {
+		return fmt.Errorf("cluster has more than %v history shards, reshard executions before changing "+
+			"numHistoryShards", numberOfShards)
+	}
+
+	return nil
+}
+
 func isShardOwnershiptLostError(err error) bool {
 	switch err.(type) {
 	case *persistence.ShardOwnershipLostError:
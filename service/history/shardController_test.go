@@ -35,10 +35,14 @@ import (
 	"time"
 
 	"github.com/uber-go/tally"
+	h "github.com/uber/cadence/.gen/go/history"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
 	mmocks "github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
+	"golang.org/x/net/context"
 )
 
 type (
@@ -95,7 +99,7 @@ func (s *shardControllerSuite) TestAcquireShardSuccess() {
 			mockEngine.On("Start").Return().Once()
 			s.mockServiceResolver.On("Lookup", string(shardID)).Return(s.hostInfo, nil).Twice()
 			s.mockEngineFactory.On("CreateEngine", mock.Anything).Return(mockEngine).Once()
-			s.mockShardManager.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).Return(
+			s.mockShardManager.On("GetShard", mock.Anything, &persistence.GetShardRequest{ShardID: shardID}).Return(
 				&persistence.GetShardResponse{
 					ShardInfo: &persistence.ShardInfo{
 						ShardID: shardID,
@@ -103,16 +107,16 @@ func (s *shardControllerSuite) TestAcquireShardSuccess() {
 						RangeID: 5,
 					},
 				}, nil).Once()
-			s.mockShardManager.On("UpdateShard", &persistence.UpdateShardRequest{
+			s.mockShardManager.On("AllocateTaskIDs", mock.Anything, &persistence.AllocateTaskIDsRequest{
 				ShardInfo: &persistence.ShardInfo{
 					ShardID:          shardID,
 					Owner:            s.hostInfo.Identity(),
-					RangeID:          6,
+					RangeID:          5,
 					StolenSinceRenew: 1,
 					TransferAckLevel: 0,
 				},
-				PreviousRangeID: 5,
-			}).Return(nil).Once()
+				RangeSizeBits: defaultRangeSize,
+			}).Return(newAllocateTaskIDsResponse(6), nil).Once()
 		} else {
 			ownerHost := fmt.Sprintf("test-acquire-shard-host-%v", hostID)
 			s.mockServiceResolver.On("Lookup", string(shardID)).Return(membership.NewHostInfo(ownerHost, nil), nil).Once()
@@ -152,7 +156,7 @@ func (s *shardControllerSuite) TestAcquireShardRenewSuccess() {
 		mockEngine.On("Start").Return().Once()
 		s.mockServiceResolver.On("Lookup", string(shardID)).Return(s.hostInfo, nil).Twice()
 		s.mockEngineFactory.On("CreateEngine", mock.Anything).Return(mockEngine).Once()
-		s.mockShardManager.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).Return(
+		s.mockShardManager.On("GetShard", mock.Anything, &persistence.GetShardRequest{ShardID: shardID}).Return(
 			&persistence.GetShardResponse{
 				ShardInfo: &persistence.ShardInfo{
 					ShardID: shardID,
@@ -160,16 +164,16 @@ func (s *shardControllerSuite) TestAcquireShardRenewSuccess() {
 					RangeID: 5,
 				},
 			}, nil).Once()
-		s.mockShardManager.On("UpdateShard", &persistence.UpdateShardRequest{
+		s.mockShardManager.On("AllocateTaskIDs", mock.Anything, &persistence.AllocateTaskIDsRequest{
 			ShardInfo: &persistence.ShardInfo{
 				ShardID:          shardID,
 				Owner:            s.hostInfo.Identity(),
-				RangeID:          6,
+				RangeID:          5,
 				StolenSinceRenew: 1,
 				TransferAckLevel: 0,
 			},
-			PreviousRangeID: 5,
-		}).Return(nil).Once()
+			RangeSizeBits: defaultRangeSize,
+		}).Return(newAllocateTaskIDsResponse(6), nil).Once()
 	}
 
 	s.controller.acquireShards()
@@ -194,7 +198,7 @@ func (s *shardControllerSuite) TestAcquireShardRenewLookupFailed() {
 		mockEngine.On("Start").Return().Once()
 		s.mockServiceResolver.On("Lookup", string(shardID)).Return(s.hostInfo, nil).Twice()
 		s.mockEngineFactory.On("CreateEngine", mock.Anything).Return(mockEngine).Once()
-		s.mockShardManager.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).Return(
+		s.mockShardManager.On("GetShard", mock.Anything, &persistence.GetShardRequest{ShardID: shardID}).Return(
 			&persistence.GetShardResponse{
 				ShardInfo: &persistence.ShardInfo{
 					ShardID: shardID,
@@ -202,16 +206,16 @@ func (s *shardControllerSuite) TestAcquireShardRenewLookupFailed() {
 					RangeID: 5,
 				},
 			}, nil).Once()
-		s.mockShardManager.On("UpdateShard", &persistence.UpdateShardRequest{
+		s.mockShardManager.On("AllocateTaskIDs", mock.Anything, &persistence.AllocateTaskIDsRequest{
 			ShardInfo: &persistence.ShardInfo{
 				ShardID:          shardID,
 				Owner:            s.hostInfo.Identity(),
-				RangeID:          6,
+				RangeID:          5,
 				StolenSinceRenew: 1,
 				TransferAckLevel: 0,
 			},
-			PreviousRangeID: 5,
-		}).Return(nil).Once()
+			RangeSizeBits: defaultRangeSize,
+		}).Return(newAllocateTaskIDsResponse(6), nil).Once()
 	}
 
 	s.controller.acquireShards()
@@ -262,7 +266,7 @@ func (s *shardControllerSuite) TestHistoryEngineClosed() {
 		mockEngine := historyEngines[shardID]
 		mockEngine.On("Stop").Return().Once()
 		s.mockServiceResolver.On("Lookup", string(shardID)).Return(differentHostInfo, nil)
-		s.controller.shardClosedCh <- shardID
+		s.controller.shardEvents.publish(&shardClosedEvent{shardID: shardID, reason: shardCloseReasonLeaseLost})
 	}
 
 	for w := 0; w < 10; w++ {
@@ -396,10 +400,11 @@ func (s *shardControllerSuite) TestShardControllerClosed() {
 	s.controller = newShardController(numShards, s.hostInfo, s.mockServiceResolver, s.mockShardManager, s.mockHistoryMgr,
 		s.mockExecutionMgrFactory, s.mockEngineFactory, s.logger, s.metricsClient)
 	historyEngines := make(map[int]*MockHistoryEngine)
+	executionMgrs := make(map[int]*mmocks.ExecutionManager)
 	for shardID := 0; shardID < numShards; shardID++ {
 		mockEngine := &MockHistoryEngine{}
 		historyEngines[shardID] = mockEngine
-		s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
+		executionMgrs[shardID] = s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
 	}
 
 	s.mockServiceResolver.On("AddListener", shardControllerMembershipUpdateListenerName,
@@ -435,16 +440,63 @@ func (s *shardControllerSuite) TestShardControllerClosed() {
 	}
 	s.controller.Stop()
 	workerWG.Wait()
+	// the execution managers of the unloaded shards are closed
+	for shardID := 0; shardID < numShards; shardID++ {
+		executionMgrs[shardID].AssertCalled(s.T(), "Close")
+	}
+}
+
+func (s *shardControllerSuite) TestVerifyNumHistoryShards() {
+	notExists := &workflow.EntityNotExistsError{}
+	mockShard := func(mockShardManager *mmocks.ShardManager, shardID int, exists bool) {
+		call := mockShardManager.On("GetShard", mock.Anything, &persistence.GetShardRequest{ShardID: shardID})
+		if exists {
+			call.Return(&persistence.GetShardResponse{ShardInfo: &persistence.ShardInfo{ShardID: shardID}}, nil)
+		} else {
+			call.Return(nil, notExists)
+		}
+	}
+
+	// New cluster
+	mockShardManager := &mmocks.ShardManager{}
+	mockShard(mockShardManager, 0, false)
+	s.Nil(verifyNumHistoryShards(mockShardManager, 4))
+
+	// Matching shard count
+	mockShardManager = &mmocks.ShardManager{}
+	mockShard(mockShardManager, 0, true)
+	mockShard(mockShardManager, 3, true)
+	mockShard(mockShardManager, 4, false)
+	s.Nil(verifyNumHistoryShards(mockShardManager, 4))
+
+	// Shard count increased without resharding
+	mockShardManager = &mmocks.ShardManager{}
+	mockShard(mockShardManager, 0, true)
+	mockShard(mockShardManager, 7, false)
+	s.NotNil(verifyNumHistoryShards(mockShardManager, 8))
+
+	// Shard count decreased without resharding
+	mockShardManager = &mmocks.ShardManager{}
+	mockShard(mockShardManager, 0, true)
+	mockShard(mockShardManager, 1, true)
+	mockShard(mockShardManager, 2, true)
+	s.NotNil(verifyNumHistoryShards(mockShardManager, 2))
+
+	// Persistence errors are not mistaken for missing shards
+	mockShardManager = &mmocks.ShardManager{}
+	mockShardManager.On("GetShard", mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+	s.NotNil(verifyNumHistoryShards(mockShardManager, 4))
 }
 
 func (s *shardControllerSuite) setupMocksForAcquireShard(shardID int, mockEngine *MockHistoryEngine, currentRangeID,
-	newRangeID int64) {
+	newRangeID int64) *mmocks.ExecutionManager {
 	mockExecutionMgr := &mmocks.ExecutionManager{}
+	mockExecutionMgr.On("Close").Return()
 	s.mockExecutionMgrFactory.On("CreateExecutionManager", shardID).Return(mockExecutionMgr, nil).Once()
 	mockEngine.On("Start").Return().Once()
 	s.mockServiceResolver.On("Lookup", string(shardID)).Return(s.hostInfo, nil).Twice()
 	s.mockEngineFactory.On("CreateEngine", mock.Anything).Return(mockEngine).Once()
-	s.mockShardManager.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).Return(
+	s.mockShardManager.On("GetShard", mock.Anything, &persistence.GetShardRequest{ShardID: shardID}).Return(
 		&persistence.GetShardResponse{
 			ShardInfo: &persistence.ShardInfo{
 				ShardID: shardID,
@@ -452,14 +504,180 @@ func (s *shardControllerSuite) setupMocksForAcquireShard(shardID int, mockEngine
 				RangeID: currentRangeID,
 			},
 		}, nil).Once()
-	s.mockShardManager.On("UpdateShard", &persistence.UpdateShardRequest{
+	s.mockShardManager.On("AllocateTaskIDs", mock.Anything, &persistence.AllocateTaskIDsRequest{
 		ShardInfo: &persistence.ShardInfo{
 			ShardID:          shardID,
 			Owner:            s.hostInfo.Identity(),
-			RangeID:          newRangeID,
+			RangeID:          currentRangeID,
 			StolenSinceRenew: 1,
 			TransferAckLevel: 0,
 		},
-		PreviousRangeID: currentRangeID,
-	}).Return(nil).Once()
+		RangeSizeBits: defaultRangeSize,
+	}).Return(newAllocateTaskIDsResponse(newRangeID), nil).Once()
+	return mockExecutionMgr
+}
+
+// newAllocateTaskIDsResponse returns the range of task IDs allocated with the given range ID of a shard
+func newAllocateTaskIDsResponse(rangeID int64) *persistence.AllocateTaskIDsResponse {
+	return &persistence.AllocateTaskIDsResponse{
+		RangeID:   rangeID,
+		MinTaskID: rangeID << defaultRangeSize,
+		MaxTaskID: (rangeID + 1) << defaultRangeSize,
+	}
+}
+
+func (s *shardControllerSuite) TestTimerSequenceNumber() {
+	shardID := 2
+	s.mockShardManager.On("GetShard", mock.Anything, &persistence.GetShardRequest{ShardID: shardID}).Return(
+		&persistence.GetShardResponse{
+			ShardInfo: &persistence.ShardInfo{ShardID: shardID, Owner: s.hostInfo.Identity(), RangeID: 5},
+		}, nil).Once()
+	s.mockShardManager.On("AllocateTaskIDs", mock.Anything, mock.Anything).Return(
+		newAllocateTaskIDsResponse(6), nil).Once()
+	scope := tally.NewTestScope("", nil)
+	shard, err := acquireShard(shardID, s.mockShardManager, s.mockHistoryMgr, &mmocks.ExecutionManager{},
+		s.hostInfo.Identity(), newShardEventBus(), s.logger, metrics.NewClient(scope, metrics.History))
+	s.NoError(err)
+	context := shard.(*shardContextImpl)
+
+	seqNum, err := context.GetTimerSequenceNumber()
+	s.NoError(err)
+	s.Equal(int64(6)<<timerRangeSize, seqNum)
+	seqNum, err = context.GetTimerSequenceNumber()
+	s.NoError(err)
+	s.Equal(int64(6)<<timerRangeSize+1, seqNum)
+
+	// Once the timers of the range are all numbered a new range is allocated, timers of the next range get
+	// different keys
+	context.timerSequenceNumber = 1 << timerRangeSize
+	s.mockShardManager.On("AllocateTaskIDs", mock.Anything, mock.Anything).Return(
+		newAllocateTaskIDsResponse(7), nil).Once()
+	seqNum, err = context.GetTimerSequenceNumber()
+	s.NoError(err)
+	s.Equal(int64(7)<<timerRangeSize, seqNum)
+	s.NotEqual(ConstructTimerKey(0, int64(6)<<timerRangeSize), ConstructTimerKey(0, seqNum))
+	counters := scope.Snapshot().Counters()
+	s.Equal(int64(1), counters["timer-sequence-exhausted+operation=ShardController,shard=2"].Value())
+	s.Equal(int64(0), counters["shard-range-exhausted+operation=ShardController,shard=2"].Value())
+
+	// Failures to allocate a range are returned, the shard stays open
+	context.timerSequenceNumber = 1 << timerRangeSize
+	s.mockShardManager.On("AllocateTaskIDs", mock.Anything, mock.Anything).Return(
+		nil, errors.New("timeout")).Once()
+	_, err = context.GetTimerSequenceNumber()
+	s.Error(err)
+	s.False(context.IsClosed())
+}
+
+func (s *shardControllerSuite) TestCompleteTaskAfterShardClosed() {
+	shardID := 3
+	s.mockShardManager.On("GetShard", mock.Anything, &persistence.GetShardRequest{ShardID: shardID}).Return(
+		&persistence.GetShardResponse{
+			ShardInfo: &persistence.ShardInfo{ShardID: shardID, Owner: s.hostInfo.Identity(), RangeID: 5},
+		}, nil).Once()
+	s.mockShardManager.On("AllocateTaskIDs", mock.Anything, mock.Anything).Return(
+		newAllocateTaskIDsResponse(6), nil).Once()
+	mockExecutionMgr := &mmocks.ExecutionManager{}
+	shard, err := acquireShard(shardID, s.mockShardManager, s.mockHistoryMgr, mockExecutionMgr,
+		s.hostInfo.Identity(), newShardEventBus(), s.logger, s.metricsClient)
+	s.NoError(err)
+	shardContext := shard.(*shardContextImpl)
+
+	// Completions are plain deletes while the shard is owned
+	transferRequest := &persistence.CompleteTransferTaskRequest{TaskID: 10}
+	timerRequest := &persistence.CompleteTimerTaskRequest{TaskID: 11}
+	mockExecutionMgr.On("CompleteTransferTask", mock.Anything, transferRequest).Return(nil).Once()
+	mockExecutionMgr.On("CompleteTimerTask", mock.Anything, timerRequest).Return(nil).Once()
+	s.NoError(shardContext.CompleteTransferTask(context.Background(), transferRequest))
+	s.NoError(shardContext.CompleteTimerTask(context.Background(), timerRequest))
+
+	// and are refused without reaching persistence once it is lost
+	shardContext.Lock()
+	shardContext.closeShard(shardCloseReasonLeaseLost, nil)
+	shardContext.Unlock()
+	err = shardContext.CompleteTransferTask(context.Background(), transferRequest)
+	s.IsType(&persistence.ShardOwnershipLostError{}, err)
+	err = shardContext.CompleteTimerTask(context.Background(), timerRequest)
+	s.IsType(&persistence.ShardOwnershipLostError{}, err)
+	mockExecutionMgr.AssertExpectations(s.T())
+}
+
+func (s *shardControllerSuite) TestHandlerShedsLoad() {
+	mockEngine := &MockHistoryEngine{}
+	s.setupMocksForAcquireShard(0, mockEngine, 5, 6)
+	config := NewConfig()
+	config.LoadSheddingTransferQueueDepth = 100
+	config.LoadSheddingPollOverloadFactor = 2
+	handler := &Handler{
+		controller:    s.controller,
+		loadShedder:   newLoadShedder(config),
+		metricsClient: s.metricsClient,
+		config:        config,
+	}
+	request := &h.RecordActivityTaskStartedRequest{
+		DomainUUID: common.StringPtr("domain"),
+		WorkflowExecution: &workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr("workflow"),
+			RunId:      common.StringPtr("run"),
+		},
+	}
+	s.controller.acquireShards()
+	_, err := handler.controller.GetEngine("workflow")
+	s.NoError(err)
+	shard := handler.controller.getShardContext("workflow").(*shardContextImpl)
+
+	// polls are shed once the load reaches the overload factor, without reaching the engine
+	shard.transferQueueDepth = 250
+	_, err = handler.RecordActivityTaskStarted(nil, request)
+	s.Equal(errShardOverloaded, err)
+	mockEngine.AssertNotCalled(s.T(), "RecordActivityTaskStarted", mock.Anything, mock.Anything)
+
+	shard.transferQueueDepth = 150
+	response := &h.RecordActivityTaskStartedResponse{}
+	mockEngine.On("RecordActivityTaskStarted", mock.Anything, request).Return(response, nil).Once()
+	resp, err := handler.RecordActivityTaskStarted(nil, request)
+	s.NoError(err)
+	s.Equal(response, resp)
+	mockEngine.AssertExpectations(s.T())
+}
+
+func (s *shardControllerSuite) TestHandlerBatchGetNextEventID() {
+	mockEngine := &MockHistoryEngine{}
+	s.setupMocksForAcquireShard(0, mockEngine, 5, 6)
+	s.controller.acquireShards()
+	handler := &Handler{
+		controller:    s.controller,
+		metricsClient: s.metricsClient,
+	}
+
+	errs := []error{
+		nil,
+		&workflow.EntityNotExistsError{Message: "Workflow execution not found."},
+		&workflow.ServiceBusyError{Message: "Shard overloaded."},
+		&workflow.BadRequestError{Message: "Invalid WorkflowID."},
+	}
+	request := &h.BatchGetWorkflowExecutionNextEventIDRequest{}
+	for i, err := range errs {
+		getRequest := &h.GetWorkflowExecutionNextEventIDRequest{
+			DomainUUID: common.StringPtr("domain"),
+			Execution:  &workflow.WorkflowExecution{WorkflowId: common.StringPtr(fmt.Sprintf("workflow%v", i))},
+		}
+		request.Requests = append(request.Requests, getRequest)
+		var response *h.GetWorkflowExecutionNextEventIDResponse
+		if err == nil {
+			response = &h.GetWorkflowExecutionNextEventIDResponse{EventId: common.Int64Ptr(42)}
+		}
+		mockEngine.On("GetWorkflowExecutionNextEventID", mock.Anything, getRequest).Return(response, err).Once()
+	}
+
+	// failures are reported per execution with their original type
+	response, err := handler.BatchGetWorkflowExecutionNextEventID(nil, request)
+	s.NoError(err)
+	results := response.GetResults()
+	s.Len(results, len(errs))
+	s.EqualValues(42, results[0].GetResponse().GetEventId())
+	s.Equal(errs[1], results[1].GetEntityNotExistError())
+	s.Equal(errs[2], results[2].GetServiceBusyError())
+	s.Equal(errs[3], results[3].GetBadRequestError())
+	mockEngine.AssertExpectations(s.T())
 }
@@ -0,0 +1,110 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+)
+
+const (
+	// shardCloseReasonLeaseLost is used when another host took over the range of the shard
+	shardCloseReasonLeaseLost shardCloseReason = iota
+	// shardCloseReasonError is used when the shard can no longer be used on this host, like when renewing its range
+	// failed, but the host may still own it
+	shardCloseReasonError
+	// shardCloseReasonShutdown is used for the shards unloaded as the host shuts down
+	shardCloseReasonShutdown
+)
+
+type (
+	shardCloseReason int
+
+	// shardClosedEvent notifies the shard controller that a shard closed and its engine has to be unloaded
+	shardClosedEvent struct {
+		shardID int
+		reason  shardCloseReason
+		err     error
+	}
+
+	// shardEventBus carries the lifecycle events of the shards of a host to the shard controller.  Publishing never
+	// blocks nor drops an event, and at most one event is pending per shard: a shard closing again before the
+	// controller got to it only replaces the reason of the pending event.
+	shardEventBus struct {
+		sync.Mutex
+		pending  map[int]*shardClosedEvent
+		order    []int
+		notifyCh chan struct{}
+	}
+)
+
+func newShardEventBus() *shardEventBus {
+	return &shardEventBus{
+		pending:  make(map[int]*shardClosedEvent),
+		notifyCh: make(chan struct{}, 1),
+	}
+}
+
+// publish queues the event for the controller, and wakes it up if it is waiting for events
+func (b *shardEventBus) publish(event *shardClosedEvent) {
+	b.Lock()
+	if _, ok := b.pending[event.shardID]; !ok {
+		b.order = append(b.order, event.shardID)
+	}
+	b.pending[event.shardID] = event
+	b.Unlock()
+
+	select {
+	case b.notifyCh <- struct{}{}:
+	default:
+		// the controller was already notified and picks the event up with the ones before it
+	}
+}
+
+// notifications returns the channel signaled when events are pending
+func (b *shardEventBus) notifications() <-chan struct{} {
+	return b.notifyCh
+}
+
+// drain returns the pending events in the order the shards were first closed
+func (b *shardEventBus) drain() []*shardClosedEvent {
+	b.Lock()
+	defer b.Unlock()
+
+	events := make([]*shardClosedEvent, 0, len(b.order))
+	for _, shardID := range b.order {
+		events = append(events, b.pending[shardID])
+		delete(b.pending, shardID)
+	}
+	b.order = nil
+	return events
+}
+
+func (r shardCloseReason) String() string {
+	switch r {
+	case shardCloseReasonLeaseLost:
+		return "LeaseLost"
+	case shardCloseReasonError:
+		return "Error"
+	case shardCloseReasonShutdown:
+		return "Shutdown"
+	}
+	return "Unknown"
+}
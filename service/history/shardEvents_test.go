@@ -0,0 +1,56 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardEventBus(t *testing.T) {
+	bus := newShardEventBus()
+	require.Empty(t, bus.drain())
+
+	// Publishing more events than shards never blocks, and a shard closing again replaces its pending event
+	renewErr := errors.New("renew range failed")
+	for i := 0; i < 100; i++ {
+		bus.publish(&shardClosedEvent{shardID: 2, reason: shardCloseReasonLeaseLost})
+		bus.publish(&shardClosedEvent{shardID: 1, reason: shardCloseReasonLeaseLost})
+	}
+	bus.publish(&shardClosedEvent{shardID: 2, reason: shardCloseReasonError, err: renewErr})
+
+	select {
+	case <-bus.notifications():
+	default:
+		require.Fail(t, "expected a notification for the pending events")
+	}
+
+	events := bus.drain()
+	require.Len(t, events, 2)
+	require.Equal(t, 2, events[0].shardID)
+	require.Equal(t, shardCloseReasonError, events[0].reason)
+	require.Equal(t, renewErr, events[0].err)
+	require.Equal(t, 1, events[1].shardID)
+	require.Equal(t, shardCloseReasonLeaseLost, events[1].reason)
+	require.Empty(t, bus.drain())
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"golang.org/x/net/context"
+)
+
+type (
+	// signalBatcher batches the signals sent concurrently to a workflow execution.  The first signal of a batch
+	// applies the whole batch, with a single history append and at most one new decision task, once it holds the
+	// lock of the execution; the signals received while it waited for the lock join its batch and wait for its
+	// outcome.  Executions receiving signals at a high rate are updated once per batch instead of once per signal.
+	signalBatcher struct {
+		sync.Mutex
+		maxBatchSize int
+		batches      map[string]*signalBatch // open batches by domainID, workflowID and runID
+	}
+
+	signalBatch struct {
+		requests []*workflow.SignalWorkflowExecutionRequest
+		doneCh   chan struct{}
+		receipts map[*workflow.SignalWorkflowExecutionRequest]*workflow.SignalWorkflowExecutionResponse
+		err      error
+	}
+)
+
+func newSignalBatcher(maxBatchSize int) *signalBatcher {
+	return &signalBatcher{
+		maxBatchSize: maxBatchSize,
+		batches:      make(map[string]*signalBatch),
+	}
+}
+
+func signalBatchKey(domainID string, execution workflow.WorkflowExecution) string {
+	return domainID + "/" + execution.GetWorkflowId() + "/" + execution.GetRunId()
+}
+
+// add adds the signal to the open batch of the execution, or opens a new batch when there is none or it is full.
+// The caller which opened the batch is returned as its leader, it must apply the batch and call done.
+func (b *signalBatcher) add(key string, request *workflow.SignalWorkflowExecutionRequest) (*signalBatch, bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	if batch, ok := b.batches[key]; ok && (b.maxBatchSize <= 0 || len(batch.requests) < b.maxBatchSize) {
+		batch.requests = append(batch.requests, request)
+		return batch, false
+	}
+
+	batch := &signalBatch{
+		requests: []*workflow.SignalWorkflowExecutionRequest{request},
+		doneCh:   make(chan struct{}),
+	}
+	b.batches[key] = batch
+	return batch, true
+}
+
+// seal closes the batch to new signals and returns them, signals received afterwards open a new batch
+func (b *signalBatcher) seal(key string, batch *signalBatch) []*workflow.SignalWorkflowExecutionRequest {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.batches[key] == batch {
+		delete(b.batches, key)
+	}
+	return batch.requests
+}
+
+// done seals the batch, in case its leader failed before applying it, and releases the signals waiting on it with
+// the outcome of the update and the receipts of the signals it recorded
+func (b *signalBatcher) done(key string, batch *signalBatch,
+	receipts map[*workflow.SignalWorkflowExecutionRequest]*workflow.SignalWorkflowExecutionResponse, err error) {
+	b.seal(key, batch)
+	batch.receipts = receipts
+	batch.err = err
+	close(batch.doneCh)
+}
+
+// wait blocks until the leader of the batch applied it, or the context of the caller is done, and returns the receipt
+// of the request
+func (s *signalBatch) wait(ctx context.Context,
+	request *workflow.SignalWorkflowExecutionRequest) (*workflow.SignalWorkflowExecutionResponse, error) {
+	select {
+	case <-s.doneCh:
+		if s.err != nil {
+			return nil, s.err
+		}
+		return s.receipts[request], nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
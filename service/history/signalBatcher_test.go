@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"golang.org/x/net/context"
+)
+
+type (
+	signalBatcherSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		batcher *signalBatcher
+	}
+)
+
+func TestSignalBatcherSuite(t *testing.T) {
+	s := new(signalBatcherSuite)
+	suite.Run(t, s)
+}
+
+func (s *signalBatcherSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.batcher = newSignalBatcher(2)
+}
+
+func (s *signalBatcherSuite) TestBatchSignals() {
+	key := signalBatchKey("domain", workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wid"),
+		RunId:      common.StringPtr("rid"),
+	})
+	batch, isLeader := s.batcher.add(key, s.newSignal("s1"))
+	s.True(isLeader)
+	signal2 := s.newSignal("s2")
+	joined, isLeader := s.batcher.add(key, signal2)
+	s.False(isLeader)
+	s.Equal(batch, joined)
+
+	// A full batch opens a new one
+	next, isLeader := s.batcher.add(key, s.newSignal("s3"))
+	s.True(isLeader)
+	s.NotEqual(batch, next)
+
+	signals := s.batcher.seal(key, next)
+	s.Equal(1, len(signals))
+	s.Equal("s3", signals[0].GetSignalName())
+
+	// Signals received after the batch is sealed open a new one
+	_, isLeader = s.batcher.add(key, s.newSignal("s4"))
+	s.True(isLeader)
+
+	signals = s.batcher.seal(key, batch)
+	s.Equal(2, len(signals))
+	s.Equal("s1", signals[0].GetSignalName())
+	s.Equal("s2", signals[1].GetSignalName())
+
+	err := &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
+	s.batcher.done(key, batch, nil, err)
+	_, err1 := joined.wait(context.Background(), signal2)
+	s.Equal(err, err1)
+}
+
+func (s *signalBatcherSuite) TestWaitReceipt() {
+	signal1 := s.newSignal("s1")
+	batch, _ := s.batcher.add("key", signal1)
+	signal2 := s.newSignal("s2")
+	s.batcher.add("key", signal2)
+
+	receipt := &workflow.SignalWorkflowExecutionResponse{EventId: common.Int64Ptr(7)}
+	s.batcher.done("key", batch, map[*workflow.SignalWorkflowExecutionRequest]*workflow.SignalWorkflowExecutionResponse{
+		signal1: {EventId: common.Int64Ptr(6)},
+		signal2: receipt,
+	}, nil)
+	resp, err := batch.wait(context.Background(), signal2)
+	s.Nil(err)
+	s.Equal(receipt, resp)
+}
+
+func (s *signalBatcherSuite) TestWaitCanceled() {
+	signal := s.newSignal("s1")
+	batch, _ := s.batcher.add("key", signal)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := batch.wait(ctx, signal)
+	s.Equal(context.Canceled, err)
+}
+
+func (s *signalBatcherSuite) newSignal(name string) *workflow.SignalWorkflowExecutionRequest {
+	return &workflow.SignalWorkflowExecutionRequest{SignalName: common.StringPtr(name)}
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
+
+	h "github.com/uber/cadence/.gen/go/history"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	staleExecutionMonitorPageSize = 100
+)
+
+const (
+	staleExecutionMonitorStatusInitialized = iota
+	staleExecutionMonitorStatusStarted
+	staleExecutionMonitorStatusStopped
+)
+
+type (
+	// staleExecutionMonitor periodically scans the shards owned by this host for stale executions: running
+	// executions whose pending decision has not moved for StaleDecisionTimeoutFactor times its timeout, and
+	// executions with timers still pending StaleTimerThreshold after their fire time.  Both are symptoms of a stuck
+	// shard or of lost tasks.  Stale executions are counted, logged and kept until the next scan of their shard for
+	// the ListStaleExecutions admin API.
+	staleExecutionMonitor struct {
+		controller    *shardController
+		config        *Config
+		status        int32
+		shutdownCh    chan struct{}
+		shutdownWG    sync.WaitGroup
+		logger        bark.Logger
+		metricsClient metrics.Client
+		timeSource    common.TimeSource
+
+		sync.RWMutex
+		// staleExecutions are the stale executions found by the last scan, by shard ID
+		staleExecutions map[int][]*h.StaleExecution
+	}
+)
+
+func newStaleExecutionMonitor(controller *shardController, config *Config, logger bark.Logger,
+	metricsClient metrics.Client) *staleExecutionMonitor {
+	return &staleExecutionMonitor{
+		controller:      controller,
+		config:          config,
+		status:          staleExecutionMonitorStatusInitialized,
+		shutdownCh:      make(chan struct{}),
+		logger:          logger.WithField(logging.TagWorkflowComponent, logging.TagValueStaleExecutionMonitor),
+		metricsClient:   metricsClient,
+		timeSource:      common.NewRealTimeSource(),
+		staleExecutions: make(map[int][]*h.StaleExecution),
+	}
+}
+
+func (m *staleExecutionMonitor) Start() {
+	if !atomic.CompareAndSwapInt32(&m.status, staleExecutionMonitorStatusInitialized,
+		staleExecutionMonitorStatusStarted) {
+		return
+	}
+	m.shutdownWG.Add(1)
+	go m.monitorLoop()
+}
+
+func (m *staleExecutionMonitor) Stop() {
+	if !atomic.CompareAndSwapInt32(&m.status, staleExecutionMonitorStatusStarted,
+		staleExecutionMonitorStatusStopped) {
+		return
+	}
+	close(m.shutdownCh)
+	m.shutdownWG.Wait()
+}
+
+// getStaleExecutions returns the stale executions found by the last scan of the shard
+func (m *staleExecutionMonitor) getStaleExecutions(shardID int) []*h.StaleExecution {
+	m.RLock()
+	defer m.RUnlock()
+	return m.staleExecutions[shardID]
+}
+
+func (m *staleExecutionMonitor) monitorLoop() {
+	defer m.shutdownWG.Done()
+
+	ticker := time.NewTicker(m.config.StaleExecutionMonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-ticker.C:
+			m.scan()
+		}
+	}
+}
+
+func (m *staleExecutionMonitor) scan() {
+	staleExecutions := make(map[int][]*h.StaleExecution)
+	count := 0
+	for shardID, shard := range m.controller.getShardContexts() {
+		if m.isStopped() {
+			return
+		}
+		staleExecutions[shardID] = m.scanShard(shardID, shard)
+		count += len(staleExecutions[shardID])
+	}
+
+	m.Lock()
+	m.staleExecutions = staleExecutions
+	m.Unlock()
+
+	m.metricsClient.UpdateGauge(metrics.HistoryStaleExecutionMonitorScope, metrics.StaleExecutionsGauge, float64(count))
+	m.logger.Infof("Found %v stale executions", count)
+}
+
+// scanShard returns the stale executions of the shard
+func (m *staleExecutionMonitor) scanShard(shardID int, shard ShardContext) []*h.StaleExecution {
+	now := m.timeSource.Now()
+	staleExecutions := m.scanPendingDecisions(shardID, shard, now)
+	return append(staleExecutions, m.scanOverdueTimers(shardID, shard, now)...)
+}
+
+// scanPendingDecisions finds the running executions of the shard which have a decision pending and have not been
+// updated for StaleDecisionTimeoutFactor times the timeout of the decision
+func (m *staleExecutionMonitor) scanPendingDecisions(shardID int, shard ShardContext,
+	now time.Time) []*h.StaleExecution {
+	var staleExecutions []*h.StaleExecution
+	var pageToken []byte
+	for {
+		response, err := shard.GetExecutionManager().ListExecutions(context.Background(),
+			&persistence.ListExecutionsRequest{
+				PageSize:      staleExecutionMonitorPageSize,
+				NextPageToken: pageToken,
+			})
+		if err != nil {
+			m.logger.WithField(logging.TagErr, err).Warn("Failed to list executions")
+			return staleExecutions
+		}
+
+		for _, info := range response.Executions {
+			if !m.isDecisionStale(info, now) {
+				continue
+			}
+			staleExecutions = append(staleExecutions, m.newStaleExecution(shardID, info.DomainID, info.WorkflowID,
+				info.RunID, h.StaleExecutionReason_DECISION_PENDING, info.LastUpdatedTimestamp))
+			m.metricsClient.IncCounter(metrics.HistoryStaleExecutionMonitorScope, metrics.StaleDecisionsCounter)
+		}
+
+		if len(response.NextPageToken) == 0 || m.isStopped() {
+			return staleExecutions
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+func (m *staleExecutionMonitor) isDecisionStale(info *persistence.WorkflowExecutionInfo, now time.Time) bool {
+	if info.State == persistence.WorkflowStateCompleted || info.DecisionScheduleID == emptyEventID {
+		return false
+	}
+
+	timeout := info.DecisionTimeout
+	if timeout <= 0 {
+		timeout = info.DecisionTimeoutValue
+	}
+	if timeout <= 0 || m.config.StaleDecisionTimeoutFactor <= 0 {
+		return false
+	}
+	staleAfter := time.Duration(m.config.StaleDecisionTimeoutFactor) * time.Duration(timeout) * time.Second
+	return now.Sub(info.LastUpdatedTimestamp) > staleAfter
+}
+
+// scanOverdueTimers finds the executions of the shard with timers still pending StaleTimerThreshold after their fire
+// time.  An execution is reported once, for the first overdue timer found in the timer queues of the shard.
+func (m *staleExecutionMonitor) scanOverdueTimers(shardID int, shard ShardContext,
+	now time.Time) []*h.StaleExecution {
+	var staleExecutions []*h.StaleExecution
+	reported := make(map[string]struct{})
+	maxKey := int64(ConstructTimerKey(now.Add(-m.config.StaleTimerThreshold).UnixNano(), 0))
+	for _, queue := range persistence.TimerQueues {
+		minKey := int64(0)
+	TimerLoop:
+		for {
+			response, err := shard.GetExecutionManager().GetTimerIndexTasks(context.Background(),
+				&persistence.GetTimerIndexTasksRequest{
+					Queue:     queue,
+					MinKey:    minKey,
+					MaxKey:    maxKey,
+					BatchSize: staleExecutionMonitorPageSize,
+				})
+			if err != nil {
+				m.logger.WithField(logging.TagErr, err).Warn("Failed to read timer tasks")
+				return staleExecutions
+			}
+
+			for _, task := range response.Timers {
+				minKey = task.TaskID + 1
+				if _, ok := reported[task.RunID]; ok {
+					continue
+				}
+				reported[task.RunID] = struct{}{}
+
+				expiryTime, _ := DeconstructTimerKey(SequenceID(task.TaskID))
+				staleExecutions = append(staleExecutions, m.newStaleExecution(shardID, task.DomainID, task.WorkflowID,
+					task.RunID, h.StaleExecutionReason_TIMER_OVERDUE, time.Unix(0, expiryTime)))
+				m.metricsClient.IncCounter(metrics.HistoryStaleExecutionMonitorScope, metrics.StaleTimersCounter)
+			}
+
+			if m.isStopped() {
+				return staleExecutions
+			}
+			if len(response.Timers) < staleExecutionMonitorPageSize {
+				break TimerLoop
+			}
+		}
+	}
+	return staleExecutions
+}
+
+func (m *staleExecutionMonitor) newStaleExecution(shardID int, domainID, workflowID, runID string,
+	reason h.StaleExecutionReason, staleSince time.Time) *h.StaleExecution {
+	m.logger.Warnf("Found stale execution {DomainID: %v, WorkflowID: %v, RunID: %v, Reason: %v, Since: %v}",
+		domainID, workflowID, runID, reason, staleSince)
+	return &h.StaleExecution{
+		ShardId:    common.Int32Ptr(int32(shardID)),
+		DomainUUID: common.StringPtr(domainID),
+		Execution: &workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr(runID),
+		},
+		Reason:              h.StaleExecutionReasonPtr(reason),
+		StaleSinceTimestamp: common.Int64Ptr(staleSince.UnixNano()),
+	}
+}
+
+func (m *staleExecutionMonitor) isStopped() bool {
+	return atomic.LoadInt32(&m.status) == staleExecutionMonitorStatusStopped
+}
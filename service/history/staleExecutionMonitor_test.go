@@ -0,0 +1,168 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	staleExecutionMonitorSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		logger           bark.Logger
+		config           *Config
+		now              time.Time
+		mockExecutionMgr *mocks.ExecutionManager
+		mockShard        *shardContextImpl
+		monitor          *staleExecutionMonitor
+	}
+)
+
+func TestStaleExecutionMonitorSuite(t *testing.T) {
+	s := new(staleExecutionMonitorSuite)
+	suite.Run(t, s)
+}
+
+func (s *staleExecutionMonitorSuite) SetupTest() {
+	s.logger = bark.NewLoggerFromLogrus(log.New())
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.config = NewConfig()
+	s.config.StaleExecutionMonitorInterval = time.Minute
+	s.now = time.Unix(1500000000, 0)
+	s.mockExecutionMgr = &mocks.ExecutionManager{}
+	s.mockShard = &shardContextImpl{
+		shardInfo:             &persistence.ShardInfo{ShardID: 3, RangeID: 1, TransferAckLevel: 0},
+		taskSequenceNumber:    1,
+		transferMaxReadLevel:  100,
+		executionManager:      s.mockExecutionMgr,
+		shardManager:          &mocks.ShardManager{},
+		rangeSize:             defaultRangeSize,
+		maxTaskSequenceNumber: 100000,
+		closeEvents:           newShardEventBus(),
+		logger:                s.logger,
+		metricsClient:         metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:            common.NewRealTimeSource(),
+	}
+	s.monitor = newStaleExecutionMonitor(nil, s.config, s.logger, metrics.NewClient(tally.NoopScope, metrics.History))
+	s.monitor.timeSource = common.NewFakeTimeSource(s.now)
+}
+
+func (s *staleExecutionMonitorSuite) TearDownTest() {
+	s.mockExecutionMgr.AssertExpectations(s.T())
+}
+
+func (s *staleExecutionMonitorSuite) TestScanShard() {
+	domainID := "deadbeef-0123-4567-890a-bcdef0123460"
+	stuck := &persistence.WorkflowExecutionInfo{DomainID: domainID, WorkflowID: "wf-stuck",
+		RunID: "0d00698f-08e1-4d36-a3e2-3bf109f5d2d6", State: persistence.WorkflowStateRunning,
+		DecisionScheduleID: 5, DecisionStartedID: emptyEventID, DecisionTimeout: 10,
+		LastUpdatedTimestamp: s.now.Add(-101 * time.Second)}
+	recent := &persistence.WorkflowExecutionInfo{DomainID: domainID, WorkflowID: "wf-recent",
+		RunID: "17f7a8c2-bf1e-43b7-9b55-4d7e1e3b7a3b", State: persistence.WorkflowStateRunning,
+		DecisionScheduleID: 5, DecisionStartedID: 6, DecisionTimeout: 10,
+		LastUpdatedTimestamp: s.now.Add(-99 * time.Second)}
+	idle := &persistence.WorkflowExecutionInfo{DomainID: domainID, WorkflowID: "wf-idle",
+		RunID: "2b2e5d4a-7b6c-4b0e-9d3a-6e3f4c5d6e7f", State: persistence.WorkflowStateRunning,
+		DecisionScheduleID: emptyEventID, DecisionTimeoutValue: 10, LastUpdatedTimestamp: s.now.Add(-time.Hour)}
+	closed := &persistence.WorkflowExecutionInfo{DomainID: domainID, WorkflowID: "wf-closed",
+		RunID: "3c3f6e5b-8c7d-4c1f-8e4b-7f4a5d6e7f80", State: persistence.WorkflowStateCompleted,
+		DecisionScheduleID: 5, DecisionTimeout: 10, LastUpdatedTimestamp: s.now.Add(-time.Hour)}
+
+	s.mockExecutionMgr.On("ListExecutions", mock.Anything, &persistence.ListExecutionsRequest{
+		PageSize: staleExecutionMonitorPageSize,
+	}).Return(&persistence.ListExecutionsResponse{
+		Executions:    []*persistence.WorkflowExecutionInfo{stuck, recent},
+		NextPageToken: []byte("next"),
+	}, nil).Once()
+	s.mockExecutionMgr.On("ListExecutions", mock.Anything, &persistence.ListExecutionsRequest{
+		PageSize:      staleExecutionMonitorPageSize,
+		NextPageToken: []byte("next"),
+	}).Return(&persistence.ListExecutionsResponse{
+		Executions: []*persistence.WorkflowExecutionInfo{idle, closed},
+	}, nil).Once()
+
+	overdue := s.now.Add(-time.Hour)
+	maxKey := int64(ConstructTimerKey(s.now.Add(-s.config.StaleTimerThreshold).UnixNano(), 0))
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, &persistence.GetTimerIndexTasksRequest{
+		Queue:     persistence.TimerQueueCron,
+		MinKey:    0,
+		MaxKey:    maxKey,
+		BatchSize: staleExecutionMonitorPageSize,
+	}).Return(&persistence.GetTimerIndexTasksResponse{}, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, &persistence.GetTimerIndexTasksRequest{
+		Queue:     persistence.TimerQueueActive,
+		MinKey:    0,
+		MaxKey:    maxKey,
+		BatchSize: staleExecutionMonitorPageSize,
+	}).Return(&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{
+		{TaskID: int64(ConstructTimerKey(overdue.UnixNano(), 1)), DomainID: domainID, WorkflowID: idle.WorkflowID,
+			RunID: idle.RunID},
+		{TaskID: int64(ConstructTimerKey(overdue.UnixNano(), 2)), DomainID: domainID, WorkflowID: idle.WorkflowID,
+			RunID: idle.RunID},
+	}}, nil).Once()
+
+	staleExecutions := s.monitor.scanShard(3, s.mockShard)
+	s.Equal(2, len(staleExecutions))
+
+	s.Equal(int32(3), staleExecutions[0].GetShardId())
+	s.Equal(domainID, staleExecutions[0].GetDomainUUID())
+	s.Equal(stuck.WorkflowID, staleExecutions[0].GetExecution().GetWorkflowId())
+	s.Equal(stuck.RunID, staleExecutions[0].GetExecution().GetRunId())
+	s.Equal(h.StaleExecutionReason_DECISION_PENDING, staleExecutions[0].GetReason())
+	s.Equal(stuck.LastUpdatedTimestamp.UnixNano(), staleExecutions[0].GetStaleSinceTimestamp())
+
+	s.Equal(idle.RunID, staleExecutions[1].GetExecution().GetRunId())
+	s.Equal(h.StaleExecutionReason_TIMER_OVERDUE, staleExecutions[1].GetReason())
+	// timer keys only keep the fire time to the precision left by the sequence number
+	expiryTime, _ := DeconstructTimerKey(ConstructTimerKey(overdue.UnixNano(), 1))
+	s.Equal(expiryTime, staleExecutions[1].GetStaleSinceTimestamp())
+}
+
+func (s *staleExecutionMonitorSuite) TestDecisionTimeoutFallsBackToWorkflowDecisionTimeout() {
+	info := &persistence.WorkflowExecutionInfo{State: persistence.WorkflowStateRunning, DecisionScheduleID: 2,
+		DecisionTimeoutValue: 5, LastUpdatedTimestamp: s.now.Add(-51 * time.Second)}
+	s.True(s.monitor.isDecisionStale(info, s.now))
+
+	info.LastUpdatedTimestamp = s.now.Add(-49 * time.Second)
+	s.False(s.monitor.isDecisionStale(info, s.now))
+
+	s.config.StaleDecisionTimeoutFactor = 0
+	info.LastUpdatedTimestamp = s.now.Add(-time.Hour)
+	s.False(s.monitor.isDecisionStale(info, s.now))
+}
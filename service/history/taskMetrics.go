@@ -0,0 +1,110 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// taskMetrics emits task latencies tagged with the domain and task type so that the end-to-end
+	// latency of a workflow can be broken down by the component adding the delay
+	taskMetrics struct {
+		sync.RWMutex
+		metricsClient metrics.Client
+		clients       map[taskMetricsKey]metrics.Client
+	}
+
+	taskMetricsKey struct {
+		domainID string
+		taskType string
+	}
+)
+
+const (
+	decisionTaskTypeName = "DecisionTask"
+	activityTaskTypeName = "ActivityTask"
+)
+
+func newTaskMetrics(metricsClient metrics.Client) *taskMetrics {
+	return &taskMetrics{
+		metricsClient: metricsClient,
+		clients:       make(map[taskMetricsKey]metrics.Client),
+	}
+}
+
+// recordLatency records the time elapsed since the given time, it is a no-op when the time is unknown
+func (m *taskMetrics) recordLatency(scope, timer int, domainID, taskType string, since time.Time) {
+	if since.IsZero() {
+		return
+	}
+	latency := time.Now().Sub(since)
+	if latency < 0 {
+		latency = 0
+	}
+	m.client(domainID, taskType).RecordTimer(scope, timer, latency)
+}
+
+// client returns the metrics client tagged with the domain and task type
+func (m *taskMetrics) client(domainID, taskType string) metrics.Client {
+	key := taskMetricsKey{domainID: domainID, taskType: taskType}
+	m.RLock()
+	client, ok := m.clients[key]
+	m.RUnlock()
+	if ok {
+		return client
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	if client, ok = m.clients[key]; !ok {
+		client = m.metricsClient.Tagged(map[string]string{
+			metrics.DomainIDTagName: domainID,
+			metrics.TaskTypeTagName: taskType,
+		})
+		m.clients[key] = client
+	}
+	return client
+}
+
+func getTransferTaskType(taskType int) string {
+	switch taskType {
+	case persistence.TransferTaskTypeDecisionTask:
+		return decisionTaskTypeName
+	case persistence.TransferTaskTypeActivityTask:
+		return activityTaskTypeName
+	case persistence.TransferTaskTypeDeleteExecution:
+		return "DeleteExecution"
+	case persistence.TransferTaskTypeCancelExecution:
+		return "CancelExecution"
+	case persistence.TransferTaskTypeStartChildExecution:
+		return "StartChildExecution"
+	case persistence.TransferTaskTypeRecordWorkflowStarted:
+		return "RecordWorkflowStarted"
+	case persistence.TransferTaskTypeActivityTaskContinuation:
+		return "ActivityTaskContinuation"
+	}
+	return "UnKnown"
+}
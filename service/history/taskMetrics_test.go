@@ -0,0 +1,76 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	taskMetricsSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		scope       tally.TestScope
+		taskMetrics *taskMetrics
+	}
+)
+
+func TestTaskMetricsSuite(t *testing.T) {
+	s := new(taskMetricsSuite)
+	suite.Run(t, s)
+}
+
+func (s *taskMetricsSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	s.scope = tally.NewTestScope("", nil)
+	s.taskMetrics = newTaskMetrics(metrics.NewClient(s.scope, metrics.History))
+}
+
+func (s *taskMetricsSuite) TestRecordLatency() {
+	s.taskMetrics.recordLatency(metrics.HistoryProcessTransferTasksScope, metrics.TransferTaskQueueLatency, "domain",
+		getTransferTaskType(persistence.TransferTaskTypeActivityTask), time.Now().Add(-time.Second))
+	s.taskMetrics.recordLatency(metrics.HistoryProcessTransferTasksScope, metrics.TransferTaskQueueLatency, "domain",
+		getTransferTaskType(persistence.TransferTaskTypeActivityTask), time.Now())
+	s.Equal(1, len(s.taskMetrics.clients))
+
+	latency, ok := s.scope.Snapshot().Timers()["transfer-task-queue-latency+domain_id=domain,operation=ProcessTransferTask,task_type=ActivityTask"]
+	s.True(ok)
+	s.Equal(2, len(latency.Values()))
+	s.True(latency.Values()[0] >= time.Second)
+}
+
+func (s *taskMetricsSuite) TestRecordLatencyUnknownStartTime() {
+	s.taskMetrics.recordLatency(metrics.HistoryRecordDecisionTaskStartedScope, metrics.TaskScheduleToStartLatency,
+		"domain", decisionTaskTypeName, time.Time{})
+	s.Empty(s.taskMetrics.clients)
+	_, ok := s.scope.Snapshot().Timers()["task-schedule-to-start-latency+domain_id=domain,operation=RecordDecisionTaskStarted,task_type=DecisionTask"]
+	s.False(ok)
+}
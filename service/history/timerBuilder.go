@@ -36,7 +36,7 @@ import (
 
 // Timer constansts
 const (
-	TimerQueueSeqNumBits                  = 26 // For timer-queues, use 38 bits of (expiry) timestamp, 26 bits of seqnum
+	TimerQueueSeqNumBits                  = 20 // For timer-queues, use 43 bits of (expiry) timestamp (~1ms resolution), 20 bits of seqnum
 	TimerQueueSeqNumBitmask               = (int64(1) << TimerQueueSeqNumBits) - 1
 	TimerQueueTimeStampBitmask            = math.MaxInt64 &^ TimerQueueSeqNumBitmask
 	SeqNumMax                             = math.MaxInt64 & TimerQueueSeqNumBitmask // The max allowed seqnum (subject to mode-specific bitmask)
@@ -65,6 +65,7 @@ type (
 		logger            bark.Logger
 		seqNumGen         SequenceNumberGenerator // The real sequence number generator
 		localSeqNumGen    SequenceNumberGenerator // This one used to order in-memory list.
+		timeSource        common.TimeSource       // Base time of timers not relative to a given time.
 	}
 
 	// SequenceID - Visibility timer stamp + Sequence Number.
@@ -72,7 +73,7 @@ type (
 
 	// SequenceNumberGenerator - Generates next sequence number.
 	SequenceNumberGenerator interface {
-		NextSeq() int64
+		NextSeq() (int64, error)
 	}
 
 	localSeqNumGenerator struct {
@@ -118,22 +119,24 @@ func (td *timerDetails) String() string {
 	return fmt.Sprintf("timerDetails: [%s expiry=%s]", td.SequenceID, time.Unix(0, int64(td.SequenceID)))
 }
 
-func (s *shardSeqNumGenerator) NextSeq() int64 {
+func (s *shardSeqNumGenerator) NextSeq() (int64, error) {
 	return s.context.GetTimerSequenceNumber()
 }
 
-func (l *localSeqNumGenerator) NextSeq() int64 {
-	return atomic.AddInt64(&l.counter, 1)
+func (l *localSeqNumGenerator) NextSeq() (int64, error) {
+	return atomic.AddInt64(&l.counter, 1), nil
 }
 
 // newTimerBuilder creates a timer builder.
-func newTimerBuilder(seqNumGen SequenceNumberGenerator, logger bark.Logger) *timerBuilder {
+func newTimerBuilder(seqNumGen SequenceNumberGenerator, timeSource common.TimeSource,
+	logger bark.Logger) *timerBuilder {
 	return &timerBuilder{
 		timers:            timers{},
 		pendingUserTimers: make(map[SequenceID]*persistence.TimerInfo),
 		logger:            logger.WithField(logging.TagWorkflowComponent, "timer"),
 		seqNumGen:         seqNumGen,
-		localSeqNumGen:    &localSeqNumGenerator{counter: 1}}
+		localSeqNumGen:    &localSeqNumGenerator{counter: 1},
+		timeSource:        timeSource}
 }
 
 // AllTimers - Get all timers.
@@ -149,26 +152,45 @@ func (tb *timerBuilder) UserTimer(taskID SequenceID) (bool, *persistence.TimerIn
 
 // AddDecisionTimeoutTask - Add a decision timeout task.
 func (tb *timerBuilder) AddDecisionTimoutTask(scheduleID int64,
-	startToCloseTimeout int32) *persistence.DecisionTimeoutTask {
-	timeOutTask := tb.createDecisionTimeoutTask(startToCloseTimeout, scheduleID)
+	startToCloseTimeout int32) (*persistence.DecisionTimeoutTask, error) {
+	timeOutTask, err := tb.createDecisionTimeoutTask(startToCloseTimeout, w.TimeoutType_START_TO_CLOSE, scheduleID)
+	if err != nil {
+		return nil, err
+	}
 	tb.logger.Debugf("Adding Decision Timeout: SequenceID: %v, EventID: %v",
 		SequenceID(timeOutTask.TaskID), timeOutTask.EventID)
-	return timeOutTask
+	return timeOutTask, nil
+}
+
+// AddScheduleToStartDecisionTimeoutTask - Add a timeout task for a decision task waiting on its task list for a poller.
+func (tb *timerBuilder) AddScheduleToStartDecisionTimeoutTask(scheduleID int64,
+	scheduleToStartTimeout int32) (*persistence.DecisionTimeoutTask, error) {
+	if scheduleToStartTimeout <= 0 {
+		return nil, nil
+	}
+
+	timeOutTask, err := tb.createDecisionTimeoutTask(scheduleToStartTimeout, w.TimeoutType_SCHEDULE_TO_START, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	tb.logger.Debugf("Adding Decision ScheduleToStart Timeout: SequenceID: %v, EventID: %v",
+		SequenceID(timeOutTask.TaskID), timeOutTask.EventID)
+	return timeOutTask, nil
 }
 
 func (tb *timerBuilder) AddScheduleToStartActivityTimeout(
-	ai *persistence.ActivityInfo) *persistence.ActivityTimeoutTask {
+	ai *persistence.ActivityInfo) (*persistence.ActivityTimeoutTask, error) {
 	return tb.AddActivityTimeoutTask(ai.ScheduleID, w.TimeoutType_SCHEDULE_TO_START, ai.ScheduleToStartTimeout, nil)
 }
 
 func (tb *timerBuilder) AddScheduleToCloseActivityTimeout(
 	ai *persistence.ActivityInfo) (*persistence.ActivityTimeoutTask, error) {
-	return tb.AddActivityTimeoutTask(ai.ScheduleID, w.TimeoutType_SCHEDULE_TO_CLOSE, ai.ScheduleToCloseTimeout, nil), nil
+	return tb.AddActivityTimeoutTask(ai.ScheduleID, w.TimeoutType_SCHEDULE_TO_CLOSE, ai.ScheduleToCloseTimeout, nil)
 }
 
 func (tb *timerBuilder) AddStartToCloseActivityTimeout(ai *persistence.ActivityInfo) (*persistence.ActivityTimeoutTask,
 	error) {
-	return tb.AddActivityTimeoutTask(ai.ScheduleID, w.TimeoutType_START_TO_CLOSE, ai.StartToCloseTimeout, nil), nil
+	return tb.AddActivityTimeoutTask(ai.ScheduleID, w.TimeoutType_START_TO_CLOSE, ai.StartToCloseTimeout, nil)
 }
 
 func (tb *timerBuilder) AddHeartBeatActivityTimeout(ai *persistence.ActivityInfo) (*persistence.ActivityTimeoutTask,
@@ -176,32 +198,84 @@ func (tb *timerBuilder) AddHeartBeatActivityTimeout(ai *persistence.ActivityInfo
 	// We want to create the timer starting from the last heart beat time stamp but
 	// avoid creating timers before the current timer frame.
 	targetTime := common.AddSecondsToBaseTime(ai.LastHeartBeatUpdatedTime.UnixNano(), int64(ai.HeartbeatTimeout))
-	if targetTime > time.Now().UnixNano() {
-		return tb.AddActivityTimeoutTask(ai.ScheduleID, w.TimeoutType_HEARTBEAT, ai.HeartbeatTimeout, &ai.LastHeartBeatUpdatedTime), nil
+	if targetTime > tb.timeSource.Now().UnixNano() {
+		return tb.AddActivityTimeoutTask(ai.ScheduleID, w.TimeoutType_HEARTBEAT, ai.HeartbeatTimeout, &ai.LastHeartBeatUpdatedTime)
 	}
-	return tb.AddActivityTimeoutTask(ai.ScheduleID, w.TimeoutType_HEARTBEAT, ai.HeartbeatTimeout, nil), nil
+	return tb.AddActivityTimeoutTask(ai.ScheduleID, w.TimeoutType_HEARTBEAT, ai.HeartbeatTimeout, nil)
 }
 
 // AddActivityTimeoutTask - Adds an activity timeout task.
 func (tb *timerBuilder) AddActivityTimeoutTask(scheduleID int64,
-	timeoutType w.TimeoutType, fireTimeout int32, baseTime *time.Time) *persistence.ActivityTimeoutTask {
+	timeoutType w.TimeoutType, fireTimeout int32, baseTime *time.Time) (*persistence.ActivityTimeoutTask, error) {
 	if fireTimeout <= 0 {
-		return nil
+		return nil, nil
 	}
 
-	timeOutTask := tb.createActivityTimeoutTask(fireTimeout, timeoutType, scheduleID, baseTime)
+	timeOutTask, err := tb.createActivityTimeoutTask(fireTimeout, timeoutType, scheduleID, baseTime)
+	if err != nil {
+		return nil, err
+	}
 	tb.logger.Debugf("Adding Activity Timeout: SequenceID: %v, TimeoutType: %v, EventID: %v",
 		SequenceID(timeOutTask.TaskID), timeoutType.String(), timeOutTask.EventID)
-	return timeOutTask
+	return timeOutTask, nil
+}
+
+// AddFirstDecisionBackoffTask - Adds a timer task dispatching the first decision of a delayed workflow execution.
+func (tb *timerBuilder) AddFirstDecisionBackoffTask(scheduleID int64,
+	backoffSeconds int32) (*persistence.FirstDecisionBackoffTask, error) {
+	expiryTime := common.AddSecondsToBaseTime(tb.timeSource.Now().UnixNano(), int64(backoffSeconds))
+	seqID, err := tb.newTimerKey(expiryTime)
+	if err != nil {
+		return nil, err
+	}
+	tb.logger.Debugf("Adding First Decision Backoff: SequenceID: %v, Backoff: %v", seqID, backoffSeconds)
+	return &persistence.FirstDecisionBackoffTask{
+		TaskID:  int64(seqID),
+		EventID: scheduleID,
+	}, nil
+}
+
+// AddDecisionRetryBackoffTask - Adds a timer task dispatching a decision which is retried after failures.
+func (tb *timerBuilder) AddDecisionRetryBackoffTask(scheduleID int64,
+	backoff time.Duration) (*persistence.DecisionRetryBackoffTask, error) {
+	expiryTime := tb.timeSource.Now().Add(backoff).UnixNano()
+	seqID, err := tb.newTimerKey(expiryTime)
+	if err != nil {
+		return nil, err
+	}
+	tb.logger.Debugf("Adding Decision Retry Backoff: SequenceID: %v, EventID: %v, Backoff: %v", seqID, scheduleID,
+		backoff)
+	return &persistence.DecisionRetryBackoffTask{
+		TaskID:  int64(seqID),
+		EventID: scheduleID,
+	}, nil
+}
+
+// AddDeleteHistoryEventTask - Adds a timer task deleting a closed workflow execution once its retention expired.
+func (tb *timerBuilder) AddDeleteHistoryEventTask(closeTime time.Time,
+	retention time.Duration) (*persistence.DeleteHistoryEventTask, error) {
+	expiryTime := closeTime.Add(retention).UnixNano()
+	seqID, err := tb.newTimerKey(expiryTime)
+	if err != nil {
+		return nil, err
+	}
+	tb.logger.Debugf("Adding Delete History Event: SequenceID: %v, Retention: %v", seqID, retention)
+	return &persistence.DeleteHistoryEventTask{
+		TaskID: int64(seqID),
+	}, nil
 }
 
 // AddUserTimer - Adds an user timeout request.
-func (tb *timerBuilder) AddUserTimer(ti *persistence.TimerInfo, msBuilder *mutableStateBuilder) persistence.Task {
+func (tb *timerBuilder) AddUserTimer(ti *persistence.TimerInfo, msBuilder *mutableStateBuilder) (persistence.Task,
+	error) {
 	tb.logger.Debugf("Adding User Timeout: %s", ti.TimerID)
 
 	// TODO: This is broken.  We need to comeup with a better way to implement this
 	tb.LoadUserTimers(msBuilder)
-	timerTask := tb.firstTimer()
+	timerTask, err := tb.firstTimer()
+	if err != nil {
+		return nil, err
+	}
 	if timerTask != nil {
 		// Update the task ID tracking the corresponding timer task.
 		ti := tb.pendingUserTimers[tb.timers[0].SequenceID]
@@ -210,7 +284,7 @@ func (tb *timerBuilder) AddUserTimer(ti *persistence.TimerInfo, msBuilder *mutab
 		msBuilder.UpdateUserTimer(ti.TimerID, ti)
 	}
 
-	return timerTask
+	return timerTask, nil
 }
 
 // LoadUserTimers - Load all user timers from mutable state.
@@ -225,6 +299,14 @@ func (tb *timerBuilder) LoadUserTimers(msBuilder *mutableStateBuilder) {
 	}
 }
 
+// getTimerFireTimeout - Returns the fire timeout of a user timer, preferring the millisecond timeout when set.
+func getTimerFireTimeout(attributes *w.StartTimerDecisionAttributes) time.Duration {
+	if attributes.IsSetStartToFireTimeoutMilliseconds() {
+		return time.Duration(attributes.GetStartToFireTimeoutMilliseconds()) * time.Millisecond
+	}
+	return time.Duration(attributes.GetStartToFireTimeoutSeconds()) * time.Second
+}
+
 // IsTimerExpired - Whether a timer is expired w.r.t reference time.
 func (tb *timerBuilder) IsTimerExpired(td *timerDetails, referenceTime int64) bool {
 	expiry, _ := DeconstructTimerKey(td.SequenceID)
@@ -232,42 +314,62 @@ func (tb *timerBuilder) IsTimerExpired(td *timerDetails, referenceTime int64) bo
 }
 
 // createDecisionTimeoutTask - Creates a decision timeout task.
-func (tb *timerBuilder) createDecisionTimeoutTask(fireTimeOut int32, eventID int64) *persistence.DecisionTimeoutTask {
-	expiryTime := common.AddSecondsToBaseTime(time.Now().UnixNano(), int64(fireTimeOut))
-	seqID := ConstructTimerKey(expiryTime, tb.seqNumGen.NextSeq())
-	return &persistence.DecisionTimeoutTask{
-		TaskID:  int64(seqID),
-		EventID: eventID,
+func (tb *timerBuilder) createDecisionTimeoutTask(fireTimeOut int32, timeoutType w.TimeoutType,
+	eventID int64) (*persistence.DecisionTimeoutTask, error) {
+	expiryTime := common.AddSecondsToBaseTime(tb.timeSource.Now().UnixNano(), int64(fireTimeOut))
+	seqID, err := tb.newTimerKey(expiryTime)
+	if err != nil {
+		return nil, err
 	}
+	return &persistence.DecisionTimeoutTask{
+		TaskID:      int64(seqID),
+		TimeoutType: int(timeoutType),
+		EventID:     eventID,
+	}, nil
 }
 
 // createActivityTimeoutTask - Creates a activity timeout task.
 func (tb *timerBuilder) createActivityTimeoutTask(fireTimeOut int32, timeoutType w.TimeoutType,
-	eventID int64, baseTime *time.Time) *persistence.ActivityTimeoutTask {
+	eventID int64, baseTime *time.Time) (*persistence.ActivityTimeoutTask, error) {
 	var expiryTime int64
 	if baseTime != nil {
 		expiryTime = common.AddSecondsToBaseTime(baseTime.UnixNano(), int64(fireTimeOut))
 	} else {
-		expiryTime = common.AddSecondsToBaseTime(time.Now().UnixNano(), int64(fireTimeOut))
+		expiryTime = common.AddSecondsToBaseTime(tb.timeSource.Now().UnixNano(), int64(fireTimeOut))
 	}
 
-	seqID := ConstructTimerKey(expiryTime, tb.seqNumGen.NextSeq())
+	seqID, err := tb.newTimerKey(expiryTime)
+	if err != nil {
+		return nil, err
+	}
 	return &persistence.ActivityTimeoutTask{
 		TaskID:      int64(seqID),
 		TimeoutType: int(timeoutType),
 		EventID:     eventID,
-	}
+	}, nil
 }
 
 // createUserTimerTask - Creates a user timer task.
-func (tb *timerBuilder) createUserTimerTask(expiryTime int64, startedEventID int64) *persistence.UserTimerTask {
-	seqID := ConstructTimerKey(expiryTime, tb.seqNumGen.NextSeq())
+func (tb *timerBuilder) createUserTimerTask(expiryTime int64, startedEventID int64) (*persistence.UserTimerTask, error) {
+	seqID, err := tb.newTimerKey(expiryTime)
+	if err != nil {
+		return nil, err
+	}
 	t := &persistence.UserTimerTask{
 		TaskID:  int64(seqID),
 		EventID: startedEventID,
 	}
 	tb.logger.Debugf("createUserTimerTask: %v", t)
-	return t
+	return t, nil
+}
+
+// newTimerKey returns the key of a new timer expiring at expiryTime, with a sequence number of the shard
+func (tb *timerBuilder) newTimerKey(expiryTime int64) (SequenceID, error) {
+	seqNum, err := tb.seqNumGen.NextSeq()
+	if err != nil {
+		return 0, err
+	}
+	return ConstructTimerKey(expiryTime, seqNum), nil
 }
 
 func (tb *timerBuilder) loadUserTimer(expires int64, task *persistence.UserTimerTask, taskCreated bool) (*timerDetails, bool) {
@@ -275,7 +377,7 @@ func (tb *timerBuilder) loadUserTimer(expires int64, task *persistence.UserTimer
 }
 
 func (tb *timerBuilder) createTimer(expires int64, task *persistence.UserTimerTask, taskCreated bool) (*timerDetails, bool) {
-	seqNum := tb.localSeqNumGen.NextSeq()
+	seqNum, _ := tb.localSeqNumGen.NextSeq() // local sequence numbers are never exhausted
 	timer := &timerDetails{
 		SequenceID:  ConstructTimerKey(expires, seqNum),
 		TimerTask:   task,
@@ -296,14 +398,14 @@ func (tb *timerBuilder) insertTimer(td *timerDetails) bool {
 	return i == 0 // This is the first timer in the list.
 }
 
-func (tb *timerBuilder) firstTimer() persistence.Task {
+func (tb *timerBuilder) firstTimer() (persistence.Task, error) {
 	if len(tb.timers) > 0 && !tb.timers[0].TaskCreated {
 		return tb.createNewTask(tb.timers[0])
 	}
-	return nil
+	return nil, nil
 }
 
-func (tb *timerBuilder) createNewTask(td *timerDetails) persistence.Task {
+func (tb *timerBuilder) createNewTask(td *timerDetails) (persistence.Task, error) {
 	task := td.TimerTask
 
 	// Allocate real sequence number
@@ -315,5 +417,5 @@ func (tb *timerBuilder) createNewTask(td *timerDetails) persistence.Task {
 		userTimerTask := task.(*persistence.UserTimerTask)
 		return tb.createUserTimerTask(expiry, userTimerTask.EventID)
 	}
-	return nil
+	return nil, nil
 }
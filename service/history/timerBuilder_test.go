@@ -58,11 +58,11 @@ func (s *timerBuilderProcessorSuite) SetupSuite() {
 	logger := log.New()
 	//logger.Level = log.DebugLevel
 	s.logger = bark.NewLoggerFromLogrus(logger)
-	s.tb = newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	s.tb = newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 }
 
 func (s *timerBuilderProcessorSuite) TestTimerBuilderSingleUserTimer() {
-	tb := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tb := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 
 	// Add one timer.
 	msb := newMutableStateBuilder(s.logger)
@@ -74,7 +74,8 @@ func (s *timerBuilderProcessorSuite) TestTimerBuilderSingleUserTimer() {
 		TimerId:                   common.StringPtr("tid1"),
 		StartToFireTimeoutSeconds: common.Int64Ptr(1),
 	})
-	t1 := tb.AddUserTimer(ti1, msb)
+	t1, err := tb.AddUserTimer(ti1, msb)
+	s.NoError(err)
 	s.NotNil(t1)
 	s.True(t1.GetTaskID() > 0)
 	s.Equal(int64(201), t1.(*persistence.UserTimerTask).EventID)
@@ -89,8 +90,45 @@ func (s *timerBuilderProcessorSuite) TestTimerBuilderSingleUserTimer() {
 	s.False(ti.ExpiryTime.IsZero())
 }
 
+func (s *timerBuilderProcessorSuite) TestTimerBuilderMillisecondUserTimer() {
+	tb := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
+
+	msb := newMutableStateBuilder(s.logger)
+	msb.Load(&persistence.WorkflowMutableState{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{NextEventID: int64(201)},
+		TimerInfos:    make(map[string]*persistence.TimerInfo),
+	})
+	before := time.Now()
+	event, ti1 := msb.AddTimerStartedEvent(int64(3), &workflow.StartTimerDecisionAttributes{
+		TimerId:                        common.StringPtr("tid1"),
+		StartToFireTimeoutSeconds:      common.Int64Ptr(1),
+		StartToFireTimeoutMilliseconds: common.Int64Ptr(100),
+	})
+	s.Equal(int64(100), event.GetTimerStartedEventAttributes().GetStartToFireTimeoutMilliseconds())
+	s.False(ti1.ExpiryTime.Before(before.Add(100 * time.Millisecond)))
+	s.True(ti1.ExpiryTime.Before(before.Add(time.Second)))
+
+	t1, err := tb.AddUserTimer(ti1, msb)
+	s.NoError(err)
+	s.NotNil(t1)
+	expiry, _ := DeconstructTimerKey(SequenceID(t1.GetTaskID()))
+	s.True(ti1.ExpiryTime.UnixNano()-expiry < int64(2*time.Millisecond))
+	s.True(ti1.ExpiryTime.UnixNano() >= expiry)
+}
+
+func (s *timerBuilderProcessorSuite) TestTimerKeyResolution() {
+	now := time.Now()
+	key1 := ConstructTimerKey(now.UnixNano(), SeqNumMax)
+	key2 := ConstructTimerKey(now.Add(2*time.Millisecond).UnixNano(), 0)
+	s.True(key1 < key2)
+
+	expiry, seqNum := DeconstructTimerKey(key1)
+	s.Equal(SeqNumMax, seqNum)
+	s.True(now.UnixNano()-expiry < int64(time.Millisecond+time.Microsecond*100))
+}
+
 func (s *timerBuilderProcessorSuite) TestTimerBuilderMulitpleUserTimer() {
-	tb := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tb := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 
 	// Add two timers. (before and after)
 	tp := &persistence.TimerInfo{TimerID: "tid1", StartedID: 201, TaskID: 101, ExpiryTime: time.Now().Add(10 * time.Second)}
@@ -104,7 +142,8 @@ func (s *timerBuilderProcessorSuite) TestTimerBuilderMulitpleUserTimer() {
 		TimerId:                   common.StringPtr("tid-before"),
 		StartToFireTimeoutSeconds: common.Int64Ptr(1),
 	})
-	t1 := tb.AddUserTimer(ti1, msb)
+	t1, err := tb.AddUserTimer(ti1, msb)
+	s.NoError(err)
 	s.NotNil(t1)
 	s.True(t1.GetTaskID() > 0)
 
@@ -118,7 +157,8 @@ func (s *timerBuilderProcessorSuite) TestTimerBuilderMulitpleUserTimer() {
 		TimerId:                   common.StringPtr("tid-after"),
 		StartToFireTimeoutSeconds: common.Int64Ptr(15),
 	})
-	t1 = tb.AddUserTimer(ti2, msb)
+	t1, err = tb.AddUserTimer(ti2, msb)
+	s.NoError(err)
 	s.Nil(t1) // we don't get any timer since there is one in progress.
 
 	// -- timer wth out a timer task.
@@ -133,7 +173,8 @@ func (s *timerBuilderProcessorSuite) TestTimerBuilderMulitpleUserTimer() {
 		TimerId:                   common.StringPtr("tid-after"),
 		StartToFireTimeoutSeconds: common.Int64Ptr(15),
 	})
-	t1 = tb.AddUserTimer(ti3, msb)
+	t1, err = tb.AddUserTimer(ti3, msb)
+	s.NoError(err)
 	s.NotNil(t1)
 	s.Equal(int64(201), t1.(*persistence.UserTimerTask).EventID)
 	s.Equal(t1.GetTaskID(), t1.(*persistence.UserTimerTask).TaskID)
@@ -146,7 +187,7 @@ func (s *timerBuilderProcessorSuite) TestTimerBuilderMulitpleUserTimer() {
 }
 
 func (s *timerBuilderProcessorSuite) TestTimerBuilderDuplicateTimerID() {
-	tb := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tb := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 	tp := &persistence.TimerInfo{TimerID: "tid-exist", StartedID: 201, TaskID: 101, ExpiryTime: time.Now().Add(10 * time.Second)}
 	timerInfos := map[string]*persistence.TimerInfo{"tid-exist": tp}
 	msb := newMutableStateBuilder(s.logger)
@@ -158,10 +199,49 @@ func (s *timerBuilderProcessorSuite) TestTimerBuilderDuplicateTimerID() {
 		ExpiryTime: time.Now().Add(time.Second),
 		StartedID:  int64(202),
 	}
-	t1 := tb.AddUserTimer(ti1, msb)
+	t1, err := tb.AddUserTimer(ti1, msb)
+	s.NoError(err)
 	s.Nil(t1)
 }
 
+func (s *timerBuilderProcessorSuite) TestTimerBuilderDecisionTimeouts() {
+	tb := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
+
+	t1, err := tb.AddDecisionTimoutTask(int64(2), int32(10))
+	s.NoError(err)
+	s.NotNil(t1)
+	s.Equal(int64(2), t1.EventID)
+	s.Equal(int(workflow.TimeoutType_START_TO_CLOSE), t1.TimeoutType)
+
+	t2, err := tb.AddScheduleToStartDecisionTimeoutTask(int64(5), int32(10))
+	s.NoError(err)
+	s.NotNil(t2)
+	s.Equal(int64(5), t2.EventID)
+	s.Equal(int(workflow.TimeoutType_SCHEDULE_TO_START), t2.TimeoutType)
+	expiryTime, _ := DeconstructTimerKey(SequenceID(t2.TaskID))
+	s.True(expiryTime > time.Now().Add(9*time.Second).UnixNano())
+
+	t2, err = tb.AddScheduleToStartDecisionTimeoutTask(int64(5), int32(0))
+	s.NoError(err)
+	s.Nil(t2)
+}
+
+func (s *timerBuilderProcessorSuite) TestTimerBuilderBackoffTimeSource() {
+	timeSource := common.NewFakeTimeSource(time.Unix(1500000000, 0))
+	tb := newTimerBuilder(&localSeqNumGenerator{counter: 1}, timeSource, s.logger)
+
+	t1, err := tb.AddFirstDecisionBackoffTask(int64(1), int32(30))
+	s.NoError(err)
+	expiryTime, _ := DeconstructTimerKey(SequenceID(t1.TaskID))
+	s.Equal(ConstructTimerKey(timeSource.Now().Add(30*time.Second).UnixNano(), 0), ConstructTimerKey(expiryTime, 0))
+
+	timeSource.Advance(time.Minute)
+	t2, err := tb.AddDecisionRetryBackoffTask(int64(2), 5*time.Second)
+	s.NoError(err)
+	expiryTime, _ = DeconstructTimerKey(SequenceID(t2.TaskID))
+	s.Equal(ConstructTimerKey(timeSource.Now().Add(5*time.Second).UnixNano(), 0), ConstructTimerKey(expiryTime, 0))
+}
+
 func (s *timerBuilderProcessorSuite) TestDecodeHistory() {
 	historyString := "5b7b226576656e744964223a312c2274696d657374616d70223a313438383332353631383735333431373433312c226576656e7454797065223a22576f726b666c6f77457865637574696f6e53746172746564222c22776f726b666c6f77457865637574696f6e537461727465644576656e7441747472696275746573223a7b22776f726b666c6f7754797065223a7b226e616d65223a22696e7465726174696f6e2d73657175656e7469616c2d757365722d74696d6572732d746573742d74797065227d2c227461736b4c697374223a7b226e616d65223a22696e7465726174696f6e2d73657175656e7469616c2d757365722d74696d6572732d746573742d7461736b6c697374227d2c22657865637574696f6e5374617274546f436c6f736554696d656f75745365636f6e6473223a3130302c227461736b5374617274546f436c6f736554696d656f75745365636f6e6473223a312c226964656e74697479223a22776f726b657231227d7d2c7b226576656e744964223a322c2274696d657374616d70223a313438383332353631383735333435333137312c226576656e7454797065223a224465636973696f6e5461736b5363686564756c6564222c226465636973696f6e5461736b5363686564756c65644576656e7441747472696275746573223a7b227461736b4c697374223a7b226e616d65223a22696e7465726174696f6e2d73657175656e7469616c2d757365722d74696d6572732d746573742d7461736b6c697374227d2c227374617274546f436c6f736554696d656f75745365636f6e6473223a317d7d2c7b226576656e744964223a332c2274696d657374616d70223a313438383332353632333938383637373536302c226576656e7454797065223a224465636973696f6e5461736b53746172746564222c226465636973696f6e5461736b537461727465644576656e7441747472696275746573223a7b227363686564756c65644576656e744964223a322c226964656e74697479223a22776f726b657231222c22726571756573744964223a2235383364326164652d663363332d343862322d383366352d323936636238393931646433227d7d2c7b226576656e744964223a342c2274696d657374616d70223a313438383332353632333939373138303336362c226576656e7454797065223a224465636973696f6e5461736b436f6d706c65746564222c226465636973696f6e5461736b436f6d706c657465644576656e7441747472696275746573223a7b22657865637574696f6e436f6e74657874223a224d513d3d222c227363686564756c65644576656e744964223a322c22737461727465644576656e744964223a332c226964656e74697479223a22776f726b657231227d7d2c7b226576656e744964223a352c2274696d657374616d70223a313438383332353632333939373138343436332c226576656e7454797065223a2254696d657253746172746564222c2274696d6572537461727465644576656e7441747472696275746573223a7b2274696d65724964223a2274696d65722d69642d31222c227374617274546f4669726554696d656f75745365636f6e6473223a312c226465636973696f6e5461736b436f6d706c657465644576656e744964223a347d7d2c7b226576656e744964223a362c2274696d657374616d70223a313438383332353632343939363835383639382c226576656e7454797065223a2254696d65724669726564222c2274696d657246697265644576656e7441747472696275746573223a7b2274696d65724964223a2274696d65722d69642d31222c22737461727465644576656e744964223a357d7d2c7b226576656e744964223a372c2274696d657374616d70223a313438383332353632343939363837333438302c226576656e7454797065223a224465636973696f6e5461736b5363686564756c6564222c226465636973696f6e5461736b5363686564756c65644576656e7441747472696275746573223a7b227461736b4c697374223a7b226e616d65223a22696e7465726174696f6e2d73657175656e7469616c2d757365722d74696d6572732d746573742d7461736b6c697374227d2c227374617274546f436c6f736554696d656f75745365636f6e6473223a317d7d2c7b226576656e744964223a382c2274696d657374616d70223a313438383332353632353238313139373232312c226576656e7454797065223a224465636973696f6e5461736b53746172746564222c226465636973696f6e5461736b537461727465644576656e7441747472696275746573223a7b227363686564756c65644576656e744964223a372c226964656e74697479223a22776f726b657231222c22726571756573744964223a2233646361663661642d663639382d343436342d386363612d333366663431353838393363227d7d2c7b226576656e744964223a392c2274696d657374616d70223a313438383332353632353238343137353337372c226576656e7454797065223a224465636973696f6e5461736b436f6d706c65746564222c226465636973696f6e5461736b436f6d706c657465644576656e7441747472696275746573223a7b22657865637574696f6e436f6e74657874223a224d673d3d222c227363686564756c65644576656e744964223a372c22737461727465644576656e744964223a382c226964656e74697479223a22776f726b657231227d7d2c7b226576656e744964223a31302c2274696d657374616d70223a313438383332353632353238343137373732342c226576656e7454797065223a2254696d657253746172746564222c2274696d6572537461727465644576656e7441747472696275746573223a7b2274696d65724964223a2274696d65722d69642d32222c227374617274546f4669726554696d656f75745365636f6e6473223a312c226465636973696f6e5461736b436f6d706c657465644576656e744964223a397d7d5d"
 	data, err := hex.DecodeString(historyString)
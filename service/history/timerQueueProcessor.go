@@ -21,67 +21,94 @@
 package history
 
 import (
-	"errors"
 	"fmt"
 	"math"
+	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/errors"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 )
 
 const (
 	timerTaskBatchSize          = 10
 	processTimerTaskWorkerCount = 5
 	updateFailureRetryCount     = 5
+
+	timerProcessorUpdateAckInterval = 10 * time.Second
 )
 
 var (
-	errTimerTaskNotFound          = errors.New("Timer task not found")
-	errFailedToAddTimeoutEvent    = errors.New("Failed to add timeout event")
-	errFailedToAddTimerFiredEvent = errors.New("Failed to add timer fired event")
+	errTimerTaskNotFound          = errors.NewEntityNotExistsError("Timer task not found")
+	errFailedToAddTimeoutEvent    = errors.NewInternalServiceError("Failed to add timeout event")
+	errFailedToAddTimerFiredEvent = errors.NewInternalServiceError("Failed to add timer fired event")
 )
 
 type (
 	timerQueueProcessorImpl struct {
+		queue             timerQueueDefinition
 		historyService    *historyEngineImpl
+		shard             ShardContext
 		cache             *historyCache
 		executionManager  persistence.ExecutionManager
 		isStarted         int32
+		isPaused          int32
 		isStopped         int32
 		shutdownWG        sync.WaitGroup
 		shutdownCh        chan struct{}
 		newTimerCh        chan struct{}
 		logger            bark.Logger
+		metricsClient     metrics.Client
+		taskMetrics       *taskMetrics
+		config            *Config
 		timerFiredCount   uint64
 		lock              sync.Mutex // Used to synchronize pending timers.
 		minPendingTimerID SequenceID // Track the minimum timer ID in memory.
 	}
 
+	// timerDispatcher hands expired timers to the task workers.  Once more than TimerJitterThreshold timers fire
+	// within the same second the rest of them are delayed by a random duration within TimerJitterWindow, to avoid
+	// mass expirations (e.g. cron schedules at midnight) hitting persistence and matching all at once.
+	timerDispatcher struct {
+		sync.RWMutex
+		tasksCh       chan SequenceID
+		shutdownCh    <-chan struct{}
+		config        *Config
+		metricsClient metrics.Client
+		closed        bool
+		burstFireTime int64 // fire time, in seconds, of the timers counted by burstCount
+		burstCount    int
+	}
+
 	timeGate struct {
 		tNext, tNow, tEnd int64       // time (in 'UnixNano' units) for next, (last) now and end
 		timer             *time.Timer // timer used to wake us up when the next message is ready to deliver
 		gateC             chan struct{}
 		closeC            chan struct{}
+		timeSource        common.TimeSource
 	}
 )
 
-func newTimeGate() *timeGate {
-	tNow := time.Now()
+func newTimeGate(timeSource common.TimeSource) *timeGate {
+	tNow := timeSource.Now()
 
 	// setup timeGate with timer set to fire at the 'end of time'
 	t := &timeGate{
-		tNow:   tNow.UnixNano(),
-		tEnd:   math.MaxInt64,
-		gateC:  make(chan struct{}),
-		closeC: make(chan struct{}),
-		timer:  time.NewTimer(time.Unix(0, math.MaxInt64).Sub(tNow)),
+		tNow:       tNow.UnixNano(),
+		tEnd:       math.MaxInt64,
+		gateC:      make(chan struct{}),
+		closeC:     make(chan struct{}),
+		timer:      time.NewTimer(time.Unix(0, math.MaxInt64).Sub(tNow)),
+		timeSource: timeSource,
 	}
 
 	// "Cast" chan Time to chan struct{}.
@@ -113,7 +140,7 @@ func (t *timeGate) setEoxReached() {
 func (t *timeGate) beforeSleep() <-chan struct{} {
 	if t.engaged() && t.tNext != t.tEnd {
 		// reset timer to fire when the next message should be made 'visible'
-		tNow := time.Now()
+		tNow := t.timeSource.Now()
 		t.tNow = tNow.UnixNano()
 		t.timer.Reset(time.Unix(0, t.tNext).Sub(tNow))
 	}
@@ -121,13 +148,15 @@ func (t *timeGate) beforeSleep() <-chan struct{} {
 }
 
 func (t *timeGate) engaged() bool {
-	t.tNow = time.Now().UnixNano()
+	t.tNow = t.timeSource.Now().UnixNano()
 	return t.tNext > t.tNow
 }
 
-func (t *timeGate) setNext(nextKey SequenceID) {
+// setNext sets the gate to open once the next timer is due, shift is how far the due time of timers is ahead of the
+// clock of this host
+func (t *timeGate) setNext(nextKey SequenceID, shift int64) {
 	expiryTime, _ := DeconstructTimerKey(nextKey)
-	t.tNext = expiryTime
+	t.tNext = expiryTime - shift
 }
 
 func (t *timeGate) close() {
@@ -138,10 +167,13 @@ func (t *timeGate) String() string {
 	return fmt.Sprintf("timeGate [engaged=%v eox=%v tNext=%x tNow=%x]", t.engaged(), t.tNext == t.tEnd, t.tNext, t.tNow)
 }
 
-func newTimerQueueProcessor(historyService *historyEngineImpl, executionManager persistence.ExecutionManager,
-	logger bark.Logger) timerQueueProcessor {
+// newTimerQueue creates the processor of one of the timer queues of the shard
+func newTimerQueue(historyService *historyEngineImpl, executionManager persistence.ExecutionManager,
+	queue timerQueueDefinition, logger bark.Logger) *timerQueueProcessorImpl {
 	return &timerQueueProcessorImpl{
+		queue:             queue,
 		historyService:    historyService,
+		shard:             historyService.shard,
 		cache:             historyService.historyCache,
 		executionManager:  executionManager,
 		shutdownCh:        make(chan struct{}),
@@ -149,7 +181,11 @@ func newTimerQueueProcessor(historyService *historyEngineImpl, executionManager
 		minPendingTimerID: MaxTimerKey,
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueTimerQueueComponent,
+			logging.TagTimerQueue:        queue.name,
 		}),
+		metricsClient: historyService.shard.GetMetricsClient(),
+		taskMetrics:   newTaskMetrics(historyService.shard.GetMetricsClient()),
+		config:        historyService.config,
 	}
 }
 
@@ -158,8 +194,9 @@ func (t *timerQueueProcessorImpl) Start() {
 		return
 	}
 
-	t.shutdownWG.Add(1)
+	t.shutdownWG.Add(2)
 	go t.processorPump(processTimerTaskWorkerCount)
+	go t.ackLevelPump()
 
 	t.logger.Info("Timer queue processor started.")
 }
@@ -199,15 +236,44 @@ func (t *timerQueueProcessorImpl) NotifyNewTimer(taskID int64) {
 	}
 }
 
+// Pause stops firing expired timers.  Timers already handed to the task workers are still processed.
+func (t *timerQueueProcessorImpl) Pause() {
+	if atomic.CompareAndSwapInt32(&t.isPaused, 0, 1) {
+		t.logger.Info("Timer queue processor paused.")
+	}
+}
+
+// Resume restarts firing expired timers after Pause
+func (t *timerQueueProcessorImpl) Resume() {
+	if atomic.CompareAndSwapInt32(&t.isPaused, 1, 0) {
+		t.logger.Info("Timer queue processor resumed.")
+		t.NotifyNewTimer(int64(MaxTimerKey))
+	}
+}
+
+func (t *timerQueueProcessorImpl) isPausedNow() bool {
+	return atomic.LoadInt32(&t.isPaused) == 1
+}
+
 func (t *timerQueueProcessorImpl) processorPump(taskWorkerCount int) {
 	defer t.shutdownWG.Done()
 
-	// Workers to process timer tasks that are expired.
+	// Workers to read the timer tasks that are expired, and to process them in fair order of their domains.
 	tasksCh := make(chan SequenceID, timerTaskBatchSize)
-	var workerWG sync.WaitGroup
+	scheduler := newDomainTaskScheduler(domainTaskSchedulerCapacity,
+		newDomainWeightFn(t.config, t.historyService.domainCache))
+	var readerWG, workerWG sync.WaitGroup
 	for i := 0; i < taskWorkerCount; i++ {
+		readerWG.Add(1)
+		go t.readTaskWorker(tasksCh, scheduler, &readerWG)
 		workerWG.Add(1)
-		go t.processTaskWorker(tasksCh, &workerWG)
+		go t.processTaskWorker(scheduler, &workerWG)
+	}
+	dispatcher := &timerDispatcher{
+		tasksCh:       tasksCh,
+		shutdownCh:    t.shutdownCh,
+		config:        t.config,
+		metricsClient: t.metricsClient,
 	}
 
 RetryProcessor:
@@ -215,13 +281,15 @@ RetryProcessor:
 		select {
 		case <-t.shutdownCh:
 			t.logger.Info("Timer queue processor pump shutting down.")
-			close(tasksCh)
-			if success := common.AwaitWaitGroup(&workerWG, 10*time.Second); !success {
+			dispatcher.close()
+			success := common.AwaitWaitGroup(&readerWG, 10*time.Second)
+			scheduler.close()
+			if success = success && common.AwaitWaitGroup(&workerWG, 10*time.Second); !success {
 				t.logger.Warn("Timer queue processor timed out on worker shutdown.")
 			}
 			break RetryProcessor
 		default:
-			err := t.internalProcessor(tasksCh)
+			err := t.internalProcessor(dispatcher)
 			if err != nil {
 				t.logger.Error("processor pump failed with error: ", err)
 			}
@@ -230,17 +298,17 @@ RetryProcessor:
 	t.logger.Info("Timer processor exiting.")
 }
 
-func (t *timerQueueProcessorImpl) internalProcessor(tasksCh chan<- SequenceID) error {
+func (t *timerQueueProcessorImpl) internalProcessor(dispatcher *timerDispatcher) error {
 	nextKey, err := t.getInitialSeed()
 	if err != nil {
 		return err
 	}
 
-	gate := newTimeGate()
+	gate := newTimeGate(t.shard.GetTimeSource())
 	defer gate.close()
 
 	if nextKey != MaxTimerKey {
-		gate.setNext(nextKey)
+		t.setGateNext(gate, nextKey)
 	}
 
 	t.logger.Infof("InitialSeed Key: %s", nextKey)
@@ -248,7 +316,7 @@ func (t *timerQueueProcessorImpl) internalProcessor(tasksCh chan<- SequenceID) e
 	for {
 		isWokeByNewTimer := false
 
-		if nextKey == MaxTimerKey || gate.engaged() {
+		if nextKey == MaxTimerKey || gate.engaged() || t.isPausedNow() {
 			gateC := gate.beforeSleep()
 
 			// Wait until one of four things occurs:
@@ -288,9 +356,9 @@ func (t *timerQueueProcessorImpl) internalProcessor(tasksCh chan<- SequenceID) e
 		}
 
 		pendingNextKeysList := []SequenceID{}
-		for nextKey != MaxTimerKey && t.isProcessNow(nextKey) {
+		for nextKey != MaxTimerKey && !t.isPausedNow() && t.isProcessNow(nextKey) {
 			// We have a timer to fire.
-			tasksCh <- nextKey
+			dispatcher.dispatch(nextKey)
 
 			// Get next key.
 			if len(pendingNextKeysList) == 0 {
@@ -307,12 +375,53 @@ func (t *timerQueueProcessorImpl) internalProcessor(tasksCh chan<- SequenceID) e
 			t.logger.Debugf("GetNextKey: %s", nextKey)
 
 			if nextKey != MaxTimerKey {
-				gate.setNext(nextKey)
+				t.setGateNext(gate, nextKey)
+				t.emitTimerAheadOfNow(nextKey)
 			}
 		}
 	}
 }
 
+func (d *timerDispatcher) dispatch(key SequenceID) {
+	expiryTime, _ := DeconstructTimerKey(key)
+	fireTime := expiryTime / int64(time.Second)
+	if fireTime != d.burstFireTime {
+		d.burstFireTime = fireTime
+		d.burstCount = 0
+	}
+	d.burstCount++
+
+	window := d.config.TimerJitterWindow
+	if window <= 0 || d.burstCount <= d.config.TimerJitterThreshold {
+		d.tasksCh <- key
+		return
+	}
+
+	d.metricsClient.IncCounter(metrics.HistoryProcessTimerTasksScope, metrics.TimerTasksJitteredCounter)
+	time.AfterFunc(time.Duration(rand.Int63n(int64(window))), func() {
+		d.RLock()
+		defer d.RUnlock()
+
+		if d.closed {
+			// Timer task is picked up again from persistence once the shard is reloaded
+			return
+		}
+		select {
+		case d.tasksCh <- key:
+		case <-d.shutdownCh:
+		}
+	})
+}
+
+// close closes the channel to the task workers, dropping timers whose jitter has not elapsed yet
+func (d *timerDispatcher) close() {
+	d.Lock()
+	defer d.Unlock()
+
+	d.closed = true
+	close(d.tasksCh)
+}
+
 func (t *timerQueueProcessorImpl) getInitialSeed() (SequenceID, error) {
 	keys, err := t.getNextKey(MinTimerKey, MaxTimerKey)
 	if err != nil {
@@ -323,7 +432,83 @@ func (t *timerQueueProcessorImpl) getInitialSeed() (SequenceID, error) {
 
 func (t *timerQueueProcessorImpl) isProcessNow(key SequenceID) bool {
 	expiryTime, _ := DeconstructTimerKey(key)
-	return expiryTime <= time.Now().UnixNano()
+	return expiryTime <= t.dueTime(t.shard.GetTimeSource().Now().UnixNano())
+}
+
+func (t *timerQueueProcessorImpl) setGateNext(gate *timeGate, nextKey SequenceID) {
+	now := t.shard.GetTimeSource().Now().UnixNano()
+	gate.setNext(nextKey, t.dueTime(now)-now)
+}
+
+// dueTime returns the fire time up to which timers are due at the given time of this host.  Timers up to the timer
+// ack level of the shard stay due when this host's clock is behind the one of the previous owner of the shard, but
+// the ack level is trusted only up to TimerMaxClockSkew ahead, so a previous owner whose clock ran ahead doesn't get
+// the timers fired early.
+func (t *timerQueueProcessorImpl) dueTime(now int64) int64 {
+	dueTime := t.shard.GetTimerAckLevel(t.queue.name)
+	if maxDueTime := now + int64(t.config.TimerMaxClockSkew); dueTime > maxDueTime {
+		dueTime = maxDueTime
+	}
+	if dueTime < now {
+		dueTime = now
+	}
+	return dueTime
+}
+
+func (t *timerQueueProcessorImpl) ackLevelPump() {
+	defer t.shutdownWG.Done()
+
+	updateAckTicker := time.NewTicker(timerProcessorUpdateAckInterval)
+	defer updateAckTicker.Stop()
+	for {
+		select {
+		case <-t.shutdownCh:
+			return
+		case <-updateAckTicker.C:
+			t.updateAckLevel()
+		}
+	}
+}
+
+// updateAckLevel moves the ack level of the timer queue up to the current due time, or up to the fire time of the
+// first timer of the queue not processed yet.  Processed timers are deleted, so that's the first timer of the queue
+// left in persistence.
+func (t *timerQueueProcessorImpl) updateAckLevel() {
+	ackLevel := t.dueTime(t.shard.GetTimeSource().Now().UnixNano())
+	tasks, err := t.getTimerTasks(MinTimerKey, MaxTimerKey, 1)
+	if err != nil {
+		t.logger.Warnf("Failed to read first timer task to update timer ack level: %v", err)
+		return
+	}
+	if len(tasks) > 0 {
+		if expiryTime, _ := DeconstructTimerKey(SequenceID(tasks[0].TaskID)); expiryTime < ackLevel {
+			ackLevel = expiryTime
+		}
+	}
+
+	if ackLevel <= t.shard.GetTimerAckLevel(t.queue.name) {
+		return
+	}
+	if err := t.shard.UpdateTimerAckLevel(t.queue.name, ackLevel); err != nil {
+		t.logger.Warnf("Failed to update timer ack level: %v", err)
+	}
+}
+
+// emitTimerAheadOfNow reports how far the next pending timer is ahead of the current time on this host.
+func (t *timerQueueProcessorImpl) emitTimerAheadOfNow(key SequenceID) {
+	expiryTime, _ := DeconstructTimerKey(key)
+	ahead := time.Duration(expiryTime - t.shard.GetTimeSource().Now().UnixNano())
+	t.metricsClient.UpdateGauge(metrics.HistoryProcessTimerTasksScope, metrics.TimerAheadOfNowGauge,
+		float64(ahead/time.Millisecond))
+}
+
+// emitTimerFired reports a processed timer along with the delay between its expiry time and its processing,
+// tagged with the domain and type of the timer.
+func (t *timerQueueProcessorImpl) emitTimerFired(key SequenceID, timerTask *persistence.TimerTaskInfo) {
+	expiryTime, _ := DeconstructTimerKey(key)
+	t.metricsClient.IncCounter(metrics.HistoryProcessTimerTasksScope, metrics.TimerTasksProcessedCounter)
+	t.taskMetrics.recordLatency(metrics.HistoryProcessTimerTasksScope, metrics.TimerTaskFireLatency,
+		timerTask.DomainID, t.getTimerTaskType(timerTask.TaskType), time.Unix(0, expiryTime))
 }
 
 func (t *timerQueueProcessorImpl) getNextKey(minKey SequenceID, maxKey SequenceID) ([]SequenceID, error) {
@@ -343,17 +528,39 @@ func (t *timerQueueProcessorImpl) getNextKey(minKey SequenceID, maxKey SequenceI
 
 func (t *timerQueueProcessorImpl) getTimerTasks(minKey SequenceID, maxKey SequenceID, batchSize int) ([]*persistence.TimerTaskInfo, error) {
 	request := &persistence.GetTimerIndexTasksRequest{
+		Queue:     t.queue.timerQueue,
 		MinKey:    int64(minKey),
 		MaxKey:    int64(maxKey),
 		BatchSize: batchSize}
-	response, err := t.executionManager.GetTimerIndexTasks(request)
+	response, err := t.executionManager.GetTimerIndexTasks(context.Background(), request)
 	if err != nil {
 		return nil, err
 	}
 	return response.Timers, nil
 }
 
-func (t *timerQueueProcessorImpl) processTaskWorker(tasksCh <-chan SequenceID, workerWG *sync.WaitGroup) {
+// getTimerTasksByID reads the timer tasks with the given keys in a single round trip.  Only the dispatched timers
+// are read this way, the range reads of getNextKey still page through timerTaskBatchSize keys at a time.
+func (t *timerQueueProcessorImpl) getTimerTasksByID(keys []SequenceID) (map[SequenceID]*persistence.TimerTaskInfo,
+	error) {
+	request := &persistence.GetTimerIndexTasksRequest{Queue: t.queue.timerQueue}
+	for _, key := range keys {
+		request.TaskIDs = append(request.TaskIDs, int64(key))
+	}
+	response, err := t.executionManager.GetTimerIndexTasks(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(map[SequenceID]*persistence.TimerTaskInfo, len(response.Timers))
+	for _, task := range response.Timers {
+		tasks[SequenceID(task.TaskID)] = task
+	}
+	return tasks, nil
+}
+
+// readTaskWorker reads the timer tasks dispatched to the workers and submits them to the scheduler
+func (t *timerQueueProcessorImpl) readTaskWorker(tasksCh <-chan SequenceID, scheduler *domainTaskScheduler,
+	workerWG *sync.WaitGroup) {
 	defer workerWG.Done()
 	for {
 		select {
@@ -362,48 +569,101 @@ func (t *timerQueueProcessorImpl) processTaskWorker(tasksCh <-chan SequenceID, w
 				return
 			}
 
-			var err error
-
-		UpdateFailureLoop:
-			for attempt := 1; attempt <= updateFailureRetryCount; attempt++ {
-				err = t.processTimerTask(key)
-				if err != nil && err != errTimerTaskNotFound {
-					// We will retry until we don't find the timer task any more.
-					t.logger.Infof("Failed to process timer with SequenceID: %s with error: %v", key, err)
-					backoff := time.Duration(attempt * 100)
-					time.Sleep(backoff * time.Millisecond)
-				} else {
-					// Completed processing the timer task.
-					break UpdateFailureLoop
+			// Dispatched timers are read back by ID in batches, to save round trips when many timers fire at once
+			keys := []SequenceID{key}
+			batched := map[SequenceID]bool{key: true}
+		BatchLoop:
+			for len(keys) < timerTaskBatchSize {
+				select {
+				case key, ok := <-tasksCh:
+					if !ok {
+						break BatchLoop
+					}
+					// The same timer can be dispatched more than once
+					if !batched[key] {
+						keys = append(keys, key)
+						batched[key] = true
+					}
+				default:
+					break BatchLoop
 				}
 			}
 
-			if err != nil && err != errTimerTaskNotFound {
-				// We need to retry for this timer task ID
-				t.NotifyNewTimer(int64(key))
+			tasks, err := t.getTimerTasksByID(keys)
+			for _, key := range keys {
+				if err != nil {
+					// The timer task is read again with the retries of its processing
+					t.processTimerTaskWithRetry(key, nil)
+					continue
+				}
+				task := tasks[key]
+				if task == nil {
+					t.logger.Infof("Unable to find timer task - SequenceID: %s", key)
+					continue
+				}
+				if !scheduler.submit(task.DomainID, task) {
+					// Timer task is picked up again from persistence once the shard is reloaded
+					return
+				}
 			}
 		}
 	}
 }
 
-func (t *timerQueueProcessorImpl) processTimerTask(key SequenceID) error {
-	t.logger.Debugf("Processing timer with SequenceID: %s", key)
+// processTaskWorker processes the timer tasks handed out by the scheduler
+func (t *timerQueueProcessorImpl) processTaskWorker(scheduler *domainTaskScheduler, workerWG *sync.WaitGroup) {
+	defer workerWG.Done()
+	for {
+		task, ok := scheduler.next()
+		if !ok {
+			return
+		}
 
-	tasks, err := t.getTimerTasks(key, key+1, 1)
-	if err != nil {
-		return err
+		timerTask := task.(*persistence.TimerTaskInfo)
+		t.processTimerTaskWithRetry(SequenceID(timerTask.TaskID), timerTask)
 	}
+}
 
-	if len(tasks) != 1 {
-		t.logger.Infof("Unable to find exact task for - SequenceID: %d, found task count: %d", key, len(tasks))
-		return errTimerTaskNotFound
+func (t *timerQueueProcessorImpl) processTimerTaskWithRetry(key SequenceID, task *persistence.TimerTaskInfo) {
+	var err error
+
+UpdateFailureLoop:
+	for attempt := 1; attempt <= updateFailureRetryCount; attempt++ {
+		err = t.processTimerTask(key, task)
+		if err != nil && err != errTimerTaskNotFound {
+			// We will retry until we don't find the timer task any more.
+			t.logger.Infof("Failed to process timer with SequenceID: %s with error: %v", key, err)
+			backoff := time.Duration(attempt * 100)
+			time.Sleep(backoff * time.Millisecond)
+			task = nil
+		} else {
+			// Completed processing the timer task.
+			break UpdateFailureLoop
+		}
 	}
 
-	timerTask := tasks[0]
+	if err != nil && err != errTimerTaskNotFound {
+		// We need to retry for this timer task ID
+		t.NotifyNewTimer(int64(key))
+	}
+}
+
+// processTimerTask processes the timer task with the given key.  The timer task is read from persistence unless
+// it is passed in.
+func (t *timerQueueProcessorImpl) processTimerTask(key SequenceID, timerTask *persistence.TimerTaskInfo) error {
+	ctx := context.Background()
+	t.logger.Debugf("Processing timer with SequenceID: %s", key)
 
-	if timerTask.TaskID != int64(key) {
-		t.logger.Infof("The key didn't match - SequenceID: %d, found task: %v", key, timerTask)
-		return errTimerTaskNotFound
+	if timerTask == nil {
+		tasks, err := t.getTimerTasksByID([]SequenceID{key})
+		if err != nil {
+			return err
+		}
+
+		if timerTask = tasks[key]; timerTask == nil {
+			t.logger.Infof("Unable to find timer task - SequenceID: %s", key)
+			return errTimerTaskNotFound
+		}
 	}
 
 	t.logger.Debugf("Processing found timer: %s, for WorkflowID: %v, RunID: %v, Type: %v, TimeoutTupe: %v, EventID: %v",
@@ -416,19 +676,26 @@ func (t *timerQueueProcessorImpl) processTimerTask(key SequenceID) error {
 		RunId:      common.StringPtr(timerTask.RunID),
 	}
 
-	context, release, err0 := t.cache.getOrCreateWorkflowExecution(domainID, workflowExecution)
+	context, release, err0 := t.cache.getOrCreateWorkflowExecution(ctx, domainID, workflowExecution)
 	if err0 != nil {
 		return err0
 	}
 	defer release()
 
+	var err error
 	switch timerTask.TaskType {
 	case persistence.TaskTypeUserTimer:
-		err = t.processExpiredUserTimer(context, timerTask)
+		err = t.processExpiredUserTimer(ctx, context, timerTask)
 	case persistence.TaskTypeActivityTimeout:
-		err = t.processActivityTimeout(context, timerTask)
+		err = t.processActivityTimeout(ctx, context, timerTask)
 	case persistence.TaskTypeDecisionTimeout:
-		err = t.processDecisionTimeout(context, timerTask)
+		err = t.processDecisionTimeout(ctx, context, timerTask)
+	case persistence.TaskTypeFirstDecisionBackoff:
+		err = t.processFirstDecisionBackoff(ctx, context, timerTask)
+	case persistence.TaskTypeDecisionRetryBackoff:
+		err = t.processDecisionRetryBackoff(ctx, context, timerTask)
+	case persistence.TaskTypeDeleteHistoryEvent:
+		err = t.processDeleteHistoryEvent(ctx, context, timerTask)
 	}
 
 	if err != nil {
@@ -442,7 +709,9 @@ func (t *timerQueueProcessorImpl) processTimerTask(key SequenceID) error {
 	if err == nil {
 		// Tracking only successful ones.
 		atomic.AddUint64(&t.timerFiredCount, 1)
-		err := t.executionManager.CompleteTimerTask(&persistence.CompleteTimerTaskRequest{TaskID: timerTask.TaskID})
+		t.emitTimerFired(key, timerTask)
+		err := t.historyService.shard.CompleteTimerTask(ctx, &persistence.CompleteTimerTaskRequest{
+			Queue: t.queue.timerQueue, TaskID: timerTask.TaskID})
 		if err != nil {
 			t.logger.Warnf("Processor unable to complete timer task '%v': %v", timerTask.TaskID, err)
 		}
@@ -452,18 +721,19 @@ func (t *timerQueueProcessorImpl) processTimerTask(key SequenceID) error {
 	return err
 }
 
-func (t *timerQueueProcessorImpl) processExpiredUserTimer(
+func (t *timerQueueProcessorImpl) processExpiredUserTimer(ctx context.Context,
 	context *workflowExecutionContext, task *persistence.TimerTaskInfo) error {
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
 			return err1
 		}
 
 		if !msBuilder.isWorkflowExecutionRunning() {
 			// Workflow is completed.
-			err := t.executionManager.CompleteTimerTask(&persistence.CompleteTimerTaskRequest{TaskID: task.TaskID})
+			err := t.historyService.shard.CompleteTimerTask(ctx, &persistence.CompleteTimerTaskRequest{
+				Queue: t.queue.timerQueue, TaskID: task.TaskID})
 			if err != nil {
 				t.logger.Warnf("Processor unable to complete user timer task '%v': %v", task.TaskID, err)
 			}
@@ -483,7 +753,7 @@ Update_History_Loop:
 			hasTimer, ti := context.tBuilder.UserTimer(td.SequenceID)
 			if !hasTimer {
 				t.logger.Debugf("Failed to find in memory user timer for: %s", td.SequenceID)
-				return fmt.Errorf("failed to find user timer")
+				return errors.NewInternalServiceError("Failed to find user timer: %v", td.SequenceID)
 			}
 
 			if isExpired := context.tBuilder.IsTimerExpired(td, timerTaskExpiryTime); isExpired {
@@ -496,7 +766,10 @@ Update_History_Loop:
 			} else {
 				// See if we have next timer in list to be created.
 				if !td.TaskCreated {
-					nextTask := context.tBuilder.createNewTask(td)
+					nextTask, err := context.tBuilder.createNewTask(td)
+					if err != nil {
+						return err
+					}
 					timerTasks = []persistence.Task{nextTask}
 
 					// Update the task ID tracking the corresponding timer task.
@@ -514,7 +787,7 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 		// the history and try the operation again.
-		err := t.updateWorkflowExecution(context, msBuilder, scheduleNewDecision, timerTasks, clearTimerTask)
+		err := t.updateWorkflowExecution(ctx, context, msBuilder, scheduleNewDecision, timerTasks, clearTimerTask)
 		if err != nil {
 			if err == ErrConflict {
 				continue Update_History_Loop
@@ -525,11 +798,11 @@ Update_History_Loop:
 	return ErrMaxAttemptsExceeded
 }
 
-func (t *timerQueueProcessorImpl) processActivityTimeout(
+func (t *timerQueueProcessorImpl) processActivityTimeout(ctx context.Context,
 	context *workflowExecutionContext, timerTask *persistence.TimerTaskInfo) error {
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
 			return err1
 		}
@@ -624,7 +897,7 @@ Update_History_Loop:
 		if updateHistory {
 			// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 			// the history and try the operation again.
-			err := t.updateWorkflowExecution(context, msBuilder, scheduleNewDecision, timerTasks, clearTimerTask)
+			err := t.updateWorkflowExecution(ctx, context, msBuilder, scheduleNewDecision, timerTasks, clearTimerTask)
 			if err != nil {
 				if err == ErrConflict {
 					continue Update_History_Loop
@@ -639,11 +912,11 @@ Update_History_Loop:
 	return ErrMaxAttemptsExceeded
 }
 
-func (t *timerQueueProcessorImpl) processDecisionTimeout(
+func (t *timerQueueProcessorImpl) processDecisionTimeout(ctx context.Context,
 	context *workflowExecutionContext, task *persistence.TimerTaskInfo) error {
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
 			return err1
 		}
@@ -663,20 +936,37 @@ Update_History_Loop:
 
 		di, isRunning := msBuilder.GetPendingDecision(scheduleID)
 		if isRunning && msBuilder.isWorkflowExecutionRunning() {
-			// Add a decision task timeout event.
-			timeoutEvent := msBuilder.AddDecisionTaskTimedOutEvent(scheduleID, di.StartedID)
-			if timeoutEvent == nil {
-				// Unable to add DecisionTaskTimedout event to history
-				return &workflow.InternalServiceError{Message: "Unable to add DecisionTaskTimedout event to history."}
-			}
+			timeoutType := workflow.TimeoutType(task.TimeoutType)
+			switch timeoutType {
+			case workflow.TimeoutType_START_TO_CLOSE:
+				// Add a decision task timeout event.
+				timeoutEvent := msBuilder.AddDecisionTaskTimedOutEvent(scheduleID, di.StartedID, timeoutType)
+				if timeoutEvent == nil {
+					// Unable to add DecisionTaskTimedout event to history
+					return &workflow.InternalServiceError{Message: "Unable to add DecisionTaskTimedout event to history."}
+				}
 
-			scheduleNewDecision = true
+				scheduleNewDecision = true
+
+			case workflow.TimeoutType_SCHEDULE_TO_START:
+				// Decision task is still waiting for a poller.  Time it out and schedule a new one so it is
+				// dispatched to matching again.
+				if di.StartedID == emptyEventID {
+					timeoutEvent := msBuilder.AddDecisionTaskTimedOutEvent(scheduleID, di.StartedID, timeoutType)
+					if timeoutEvent == nil {
+						// Unable to add DecisionTaskTimedout event to history
+						return &workflow.InternalServiceError{Message: "Unable to add DecisionTaskTimedout event to history."}
+					}
+
+					scheduleNewDecision = true
+				}
+			}
 		}
 
 		if scheduleNewDecision {
 			// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 			// the history and try the operation again.
-			err := t.updateWorkflowExecution(context, msBuilder, scheduleNewDecision, nil, clearTimerTask)
+			err := t.updateWorkflowExecution(ctx, context, msBuilder, scheduleNewDecision, nil, clearTimerTask)
 			if err != nil {
 				if err == ErrConflict {
 					continue Update_History_Loop
@@ -691,27 +981,189 @@ Update_History_Loop:
 	return ErrMaxAttemptsExceeded
 }
 
-func (t *timerQueueProcessorImpl) updateWorkflowExecution(context *workflowExecutionContext,
+func (t *timerQueueProcessorImpl) processFirstDecisionBackoff(ctx context.Context,
+	context *workflowExecutionContext, task *persistence.TimerTaskInfo) error {
+Update_History_Loop:
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
+		if err1 != nil {
+			return err1
+		}
+
+		scheduleID := task.EventID
+
+		// First check to see if cache needs to be refreshed as we could potentially have stale workflow execution in
+		// some extreme cassandra failure cases.
+		if scheduleID >= msBuilder.GetNextEventID() {
+			// Reload workflow execution history
+			context.clear()
+			continue Update_History_Loop
+		}
+
+		if !msBuilder.isWorkflowExecutionRunning() {
+			return nil
+		}
+
+		clearTimerTask := &persistence.FirstDecisionBackoffTask{TaskID: task.TaskID}
+		var err error
+		di, isPending := msBuilder.GetPendingDecision(scheduleID)
+		if isPending {
+			if di.StartedID != emptyEventID {
+				// Decision is no longer waiting to be dispatched
+				return nil
+			}
+			transferTasks := []persistence.Task{&persistence.DecisionTask{
+				DomainID:   msBuilder.executionInfo.DomainID,
+				TaskList:   msBuilder.executionInfo.TaskList,
+				ScheduleID: scheduleID,
+			}}
+			// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than
+			// reload the history and try the operation again.
+			err = t.updateWorkflowExecutionWithTasks(ctx, context, transferTasks, nil, clearTimerTask)
+		} else {
+			// Timers created before the first decision was scheduled at start point at the started event.  A signal
+			// or a cancellation request received during the backoff could have scheduled the decision already.
+			if msBuilder.HasPendingDecisionTask() || msBuilder.executionInfo.LastProcessedEvent != emptyEventID {
+				return nil
+			}
+			err = t.updateWorkflowExecution(ctx, context, msBuilder, true, nil, clearTimerTask)
+		}
+		if err != nil {
+			if err == ErrConflict {
+				continue Update_History_Loop
+			}
+		}
+		return err
+	}
+	return ErrMaxAttemptsExceeded
+}
+
+func (t *timerQueueProcessorImpl) processDecisionRetryBackoff(ctx context.Context,
+	context *workflowExecutionContext, task *persistence.TimerTaskInfo) error {
+Update_History_Loop:
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
+		if err1 != nil {
+			return err1
+		}
+
+		scheduleID := task.EventID
+
+		// First check to see if cache needs to be refreshed as we could potentially have stale workflow execution in
+		// some extreme cassandra failure cases.
+		if scheduleID >= msBuilder.GetNextEventID() {
+			// Reload workflow execution history
+			context.clear()
+			continue Update_History_Loop
+		}
+
+		di, isPending := msBuilder.GetPendingDecision(scheduleID)
+		if !isPending || di.StartedID != emptyEventID || !msBuilder.isWorkflowExecutionRunning() {
+			// Decision is no longer waiting to be dispatched
+			return nil
+		}
+
+		transferTasks := []persistence.Task{&persistence.DecisionTask{
+			DomainID:   msBuilder.executionInfo.DomainID,
+			TaskList:   msBuilder.executionInfo.TaskList,
+			ScheduleID: scheduleID,
+		}}
+		clearTimerTask := &persistence.DecisionRetryBackoffTask{TaskID: task.TaskID}
+
+		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
+		// the history and try the operation again.
+		err := t.updateWorkflowExecutionWithTasks(ctx, context, transferTasks, nil, clearTimerTask)
+		if err != nil {
+			if err == ErrConflict {
+				continue Update_History_Loop
+			}
+		}
+		return err
+	}
+	return ErrMaxAttemptsExceeded
+}
+
+func (t *timerQueueProcessorImpl) processDeleteHistoryEvent(ctx context.Context,
+	context *workflowExecutionContext, task *persistence.TimerTaskInfo) error {
+	msBuilder, err := context.loadWorkflowExecution(ctx)
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); !ok {
+			return err
+		}
+		// Mutable state is already deleted, but the history could still be around from a previous attempt.
+		msBuilder = nil
+	}
+	if msBuilder != nil && msBuilder.isWorkflowExecutionRunning() {
+		return nil
+	}
+
+	// The offloaded payloads go along with the history, which holds the references to them
+	err = t.historyService.payloadBlobs.deleteHistory(ctx, t.historyService.historyMgr, task.DomainID,
+		context.workflowExecution)
+	if err != nil {
+		return err
+	}
+	err = t.historyService.historyMgr.DeleteWorkflowExecutionHistory(ctx,
+		&persistence.DeleteWorkflowExecutionHistoryRequest{
+			DomainID:  task.DomainID,
+			Execution: context.workflowExecution,
+		})
+	if err != nil {
+		return err
+	}
+
+	if msBuilder != nil {
+		if err := context.deleteWorkflowExecution(ctx); err != nil {
+			return err
+		}
+		// Drop the cached mutable state, so the execution is no longer found
+		context.clear()
+	}
+	return nil
+}
+
+func (t *timerQueueProcessorImpl) updateWorkflowExecution(ctx context.Context, context *workflowExecutionContext,
 	msBuilder *mutableStateBuilder, scheduleNewDecision bool, timerTasks []persistence.Task,
 	clearTimerTask persistence.Task) error {
 	var transferTasks []persistence.Task
+	if scheduleNewDecision {
+		isStuck, err := t.historyService.terminateIfStuck(msBuilder, metrics.HistoryProcessTimerTasksScope)
+		if err != nil {
+			return err
+		}
+		if isStuck {
+			scheduleNewDecision = false
+			transferTasks = append(transferTasks, &persistence.DeleteExecutionTask{})
+		}
+	}
 	if scheduleNewDecision {
 		// Schedule a new decision.
-		newDecisionEvent, _ := msBuilder.AddDecisionTaskScheduledEvent()
-		transferTasks = []persistence.Task{&persistence.DecisionTask{
-			DomainID:   msBuilder.executionInfo.DomainID,
-			TaskList:   newDecisionEvent.GetDecisionTaskScheduledEventAttributes().GetTaskList().GetName(),
-			ScheduleID: newDecisionEvent.GetEventId(),
-		}}
+		newDecisionEvent, di := msBuilder.AddDecisionTaskScheduledEvent()
+		decisionTransferTasks, backoffTasks, err := createDecisionDispatchTasks(t.historyService.config, context.tBuilder,
+			msBuilder.executionInfo.DomainID, newDecisionEvent, di)
+		if err != nil {
+			return err
+		}
+		transferTasks = decisionTransferTasks
+		for _, backoffTask := range backoffTasks {
+			timerTasks = append(timerTasks, backoffTask)
+			defer t.NotifyNewTimer(backoffTask.GetTaskID())
+		}
 	}
 
+	return t.updateWorkflowExecutionWithTasks(ctx, context, transferTasks, timerTasks, clearTimerTask)
+}
+
+func (t *timerQueueProcessorImpl) updateWorkflowExecutionWithTasks(ctx context.Context,
+	context *workflowExecutionContext, transferTasks []persistence.Task, timerTasks []persistence.Task,
+	clearTimerTask persistence.Task) error {
 	// Generate a transaction ID for appending events to history
 	transactionID, err1 := t.historyService.shard.GetNextTransferTaskID()
 	if err1 != nil {
 		return err1
 	}
 
-	err := context.updateWorkflowExecutionWithDeleteTask(transferTasks, timerTasks, clearTimerTask, transactionID)
+	err := context.updateWorkflowExecutionWithDeleteTask(ctx, transferTasks, timerTasks, clearTimerTask, transactionID)
 	if err != nil {
 		if isShardOwnershiptLostError(err) {
 			// Shard is stolen.  Stop timer processing to reduce duplicates
@@ -729,6 +1181,12 @@ func (t *timerQueueProcessorImpl) getTimerTaskType(taskType int) string {
 		return "ActivityTimeout"
 	case persistence.TaskTypeDecisionTimeout:
 		return "DecisionTimeout"
+	case persistence.TaskTypeFirstDecisionBackoff:
+		return "FirstDecisionBackoff"
+	case persistence.TaskTypeDecisionRetryBackoff:
+		return "DecisionRetryBackoff"
+	case persistence.TaskTypeDeleteHistoryEvent:
+		return "DeleteHistoryEvent"
 	}
 	return "UnKnown"
 }
@@ -23,10 +23,13 @@ package history
 import (
 	"errors"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
 
@@ -35,14 +38,16 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
 	workflow "github.com/uber/cadence/.gen/go/shared"
+	"golang.org/x/net/context"
 )
 
 type (
 	timerQueueProcessor2Suite struct {
 		suite.Suite
 		mockShardManager *mocks.ShardManager
-		shardClosedCh    chan int
+		shardEvents      *shardEventBus
 		logger           bark.Logger
 
 		mockHistoryEngine  *historyEngineImpl
@@ -77,32 +82,41 @@ func (s *timerQueueProcessor2Suite) SetupTest() {
 	s.mockShardManager = &mocks.ShardManager{}
 	s.mockHistoryMgr = &mocks.HistoryManager{}
 	s.mockVisibilityMgr = &mocks.VisibilityManager{}
-	s.shardClosedCh = make(chan int, 100)
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.shardEvents = newShardEventBus()
 
 	mockShard := &shardContextImpl{
-		shardInfo:                 &persistence.ShardInfo{ShardID: shardID, RangeID: 1, TransferAckLevel: 0},
-		transferSequenceNumber:    1,
-		executionManager:          s.mockExecutionMgr,
-		shardManager:              s.mockShardManager,
-		historyMgr:                s.mockHistoryMgr,
-		rangeSize:                 defaultRangeSize,
-		maxTransferSequenceNumber: 100000,
-		closeCh:                   s.shardClosedCh,
-		logger:                    s.logger,
+		shardInfo:             &persistence.ShardInfo{ShardID: shardID, RangeID: 1, TransferAckLevel: 0},
+		taskSequenceNumber:    1,
+		executionManager:      s.mockExecutionMgr,
+		shardManager:          s.mockShardManager,
+		historyMgr:            s.mockHistoryMgr,
+		rangeSize:             defaultRangeSize,
+		maxTaskSequenceNumber: 100000,
+		closeEvents:           s.shardEvents,
+		logger:                s.logger,
+		metricsClient:         metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:            common.NewRealTimeSource(),
 	}
 
 	historyCache := newHistoryCache(historyCacheMaxSize, mockShard, s.logger)
 	domainCache := cache.NewDomainCache(s.mockMetadataMgr, s.logger)
-	txProcessor := newTransferQueueProcessor(mockShard, s.mockVisibilityMgr, s.mockMatchingClient, &mocks.HistoryClient{}, historyCache, domainCache)
+	txProcessor := newTransferQueueProcessor(mockShard, s.mockVisibilityMgr, s.mockMatchingClient, &mocks.HistoryClient{}, historyCache, domainCache, nil, NewConfig())
 	h := &historyEngineImpl{
 		shard:              mockShard,
 		historyMgr:         s.mockHistoryMgr,
 		executionManager:   s.mockExecutionMgr,
 		txProcessor:        txProcessor,
 		historyCache:       historyCache,
+		domainCache:        domainCache,
 		logger:             s.logger,
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+		queryRegistry:      newQueryRegistry(),
+		signalBatcher:      newSignalBatcher(0),
+		taskMetrics:        newTaskMetrics(mockShard.GetMetricsClient()),
+		config:             NewConfig(),
+		metricsClient:      metrics.NewClient(tally.NoopScope, metrics.History),
 	}
 	h.timerProcessor = newTimerQueueProcessor(h, s.mockExecutionMgr, s.logger)
 	s.mockHistoryEngine = h
@@ -141,33 +155,321 @@ func (s *timerQueueProcessor2Suite) TestTimerUpdateTimesOut() {
 		EventID: decisionScheduledEvent.GetEventId()}
 	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
 
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(timerIndexResponse, nil).Once() // initial
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once() // initial
 
 	for i := 0; i < 2; i++ {
-		s.mockExecutionMgr.On("GetTimerIndexTasks",
-			&persistence.GetTimerIndexTasksRequest{MinKey: 100, MaxKey: 101, BatchSize: 1}).Return(timerIndexResponse, nil).Once()
+		s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything,
+			&persistence.GetTimerIndexTasksRequest{TaskIDs: []int64{100}}).Return(timerIndexResponse, nil).Once()
 
 		ms := createMutableState(builder)
 		wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
-		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(wfResponse, nil).Once()
+		s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
 	}
 
-	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
 		&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}, nil)
 
-	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything, mock.Anything).Return(nil).Once()
 
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(errors.New("FAILED")).Once()
-	s.mockShardManager.On("UpdateShard", mock.Anything).Return(nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1},
+	}, nil)
 
-	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Run(func(arguments mock.Arguments) {
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(errors.New("FAILED")).Once()
+	s.mockShardManager.On("AllocateTaskIDs", mock.Anything, mock.Anything).Return(newAllocateTaskIDsResponse(2), nil).Once()
+
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Run(func(arguments mock.Arguments) {
+		// Done.
+		waitCh <- struct{}{}
+	}).Once()
+
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	processor.NotifyNewTimer(taskID)
+
+	// Start timer Processor.
+	processor.Start()
+	<-waitCh
+	processor.Stop()
+}
+
+func (s *timerQueueProcessor2Suite) TestDecisionTimeoutTerminatesStuckWorkflow() {
+	domainID := "5bb49df8-71bc-4c63-b57f-05f2a508e7b5"
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("decision-timeout-stuck-test"),
+		RunId: common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6")}
+
+	taskList := "decision-timeout-stuck"
+
+	builder := newMutableStateBuilder(s.logger)
+	builder.AddWorkflowExecutionStartedEvent(domainID, we, &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                   &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                       common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		TaskStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+	})
+
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(builder)
+	addDecisionTaskStartedEvent(builder, decisionScheduledEvent.GetEventId(), taskList, uuid.New())
+
+	waitCh := make(chan struct{})
+
+	taskID := int64(100)
+	timerTask := &persistence.TimerTaskInfo{WorkflowID: "wid", RunID: "rid", TaskID: taskID,
+		TaskType: persistence.TaskTypeDecisionTimeout, TimeoutType: int(workflow.TimeoutType_START_TO_CLOSE),
+		EventID: decisionScheduledEvent.GetEventId()}
+	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
+
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything,
+		&persistence.GetTimerIndexTasksRequest{TaskIDs: []int64{100}}).Return(timerIndexResponse, nil).Once()
+	ms := createMutableState(builder)
+	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Return(&persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: domainID},
+		Config: &persistence.DomainConfig{Retention: 1, MaxDecisionAttempts: 1},
+	}, nil)
+
+	var updateRequest *persistence.UpdateWorkflowExecutionRequest
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Run(
+		func(arguments mock.Arguments) {
+			updateRequest = arguments.Get(1).(*persistence.UpdateWorkflowExecutionRequest)
+			waitCh <- struct{}{}
+		}).Once()
+	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything, mock.Anything).Return(nil).Once()
+
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	processor.NotifyNewTimer(taskID)
+
+	processor.Start()
+	<-waitCh
+	processor.Stop()
+
+	s.Equal(persistence.WorkflowStateCompleted, updateRequest.ExecutionInfo.State)
+	s.Equal(persistence.WorkflowCloseStatusTerminatedStuck, updateRequest.ExecutionInfo.CloseStatus)
+	s.Equal(1, len(updateRequest.TransferTasks))
+	s.IsType(&persistence.DeleteExecutionTask{}, updateRequest.TransferTasks[0])
+}
+
+func (s *timerQueueProcessor2Suite) TestDecisionScheduleToStartTimeout() {
+	domainID := "5bb49df8-71bc-4c63-b57f-05f2a508e7b5"
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("decision-schedule-to-start-timeout-test"),
+		RunId: common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6")}
+
+	taskList := "decision-schedule-to-start-timeout"
+
+	builder := newMutableStateBuilder(s.logger)
+	builder.AddWorkflowExecutionStartedEvent(domainID, we, &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                   &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                       common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		TaskStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+	})
+
+	// Decision task is never picked up by a poller
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(builder)
+
+	waitCh := make(chan struct{})
+
+	taskID := int64(100)
+	timerTask := &persistence.TimerTaskInfo{WorkflowID: "wid", RunID: "rid", TaskID: taskID,
+		TaskType: persistence.TaskTypeDecisionTimeout, TimeoutType: int(workflow.TimeoutType_SCHEDULE_TO_START),
+		EventID: decisionScheduledEvent.GetEventId()}
+	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
+
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once() // initial
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything,
+		&persistence.GetTimerIndexTasksRequest{TaskIDs: []int64{100}}).Return(timerIndexResponse, nil).Once()
+	ms := createMutableState(builder)
+	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}, nil)
+
+	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+			// The timed out decision is replaced by a new decision dispatched to matching again
+			if len(request.TransferTasks) != 1 || request.DeleteTimerTask.GetTaskID() != taskID {
+				return false
+			}
+			decisionTask, ok := request.TransferTasks[0].(*persistence.DecisionTask)
+			return ok && decisionTask.ScheduleID > decisionScheduledEvent.GetEventId() &&
+				decisionTask.ScheduleID == request.ExecutionInfo.DecisionScheduleID &&
+				request.ExecutionInfo.DecisionStartedID == emptyEventID
+		})).Return(nil).Run(func(arguments mock.Arguments) {
+		// Done.
+		waitCh <- struct{}{}
+	}).Once()
+
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	processor.NotifyNewTimer(taskID)
+
+	// Start timer Processor.
+	processor.Start()
+	<-waitCh
+	processor.Stop()
+}
+
+func (s *timerQueueProcessor2Suite) TestShardWrapperAddsDecisionScheduleToStartTimeout() {
+	shard := s.mockHistoryEngine.shard
+	shardWrapper := &shardContextWrapper{
+		ShardContext:   shard,
+		txProcessor:    s.mockHistoryEngine.txProcessor,
+		timerProcessor: s.mockHistoryEngine.timerProcessor,
+		tBuilder:       newTimerBuilder(&shardSeqNumGenerator{context: shard}, shard.GetTimeSource(), s.logger),
+		config:         &Config{DecisionScheduleToStartTimeout: time.Minute},
+	}
+
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+			if len(request.TimerTasks) != 2 {
+				return false
+			}
+			timeoutTask, ok := request.TimerTasks[1].(*persistence.DecisionTimeoutTask)
+			return ok && timeoutTask.EventID == 5 &&
+				timeoutTask.TimeoutType == int(workflow.TimeoutType_SCHEDULE_TO_START)
+		})).Return(nil).Once()
+
+	userTimerTask := &persistence.UserTimerTask{TaskID: 1, EventID: 3}
+	request := &persistence.UpdateWorkflowExecutionRequest{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{},
+		TransferTasks: []persistence.Task{&persistence.DecisionTask{ScheduleID: 5}},
+		TimerTasks:    []persistence.Task{userTimerTask},
+	}
+	err := shardWrapper.UpdateWorkflowExecution(context.Background(), request)
+	s.Nil(err)
+	// The request itself is not modified, so retries don't add the timeout again
+	s.Equal([]persistence.Task{userTimerTask}, request.TimerTasks)
+
+	// Schedule to start timeouts can be disabled
+	shardWrapper.config = &Config{}
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+			return len(request.TimerTasks) == 0
+		})).Return(nil).Once()
+	err = shardWrapper.UpdateWorkflowExecution(context.Background(), &persistence.UpdateWorkflowExecutionRequest{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{},
+		TransferTasks: []persistence.Task{&persistence.DecisionTask{ScheduleID: 7}},
+	})
+	s.Nil(err)
+}
+
+func (s *timerQueueProcessor2Suite) TestFirstDecisionBackoff() {
+	domainID := "5bb49df8-71bc-4c63-b57f-05f2a508e7b5"
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("first-decision-backoff-test"),
+		RunId: common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6")}
+
+	taskList := "first-decision-backoff"
+
+	builder := newMutableStateBuilder(s.logger)
+	startedEvent := builder.AddWorkflowExecutionStartedEvent(domainID, we, &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                   &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                       common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		TaskStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+		DelayStartSeconds:              common.Int32Ptr(1),
+	})
+	s.Equal(int32(1), startedEvent.GetWorkflowExecutionStartedEventAttributes().GetFirstDecisionTaskBackoffSeconds())
+
+	waitCh := make(chan struct{})
+
+	// Timers created before the first decision was scheduled at start point at the started event
+	taskID := int64(100)
+	timerTask := &persistence.TimerTaskInfo{WorkflowID: "wid", RunID: "rid", TaskID: taskID,
+		TaskType: persistence.TaskTypeFirstDecisionBackoff, EventID: startedEvent.GetEventId()}
+	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
+
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once() // initial
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything,
+		&persistence.GetTimerIndexTasksRequest{Queue: persistence.TimerQueueCron, TaskIDs: []int64{100}}).Return(timerIndexResponse, nil).Once()
+	ms := createMutableState(builder)
+	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}, nil)
+
+	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything, &persistence.CompleteTimerTaskRequest{
+		Queue: persistence.TimerQueueCron, TaskID: taskID}).Return(nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+			if len(request.TransferTasks) != 1 || request.DeleteTimerTask.GetTaskID() != taskID {
+				return false
+			}
+			decisionTask, ok := request.TransferTasks[0].(*persistence.DecisionTask)
+			return ok && decisionTask.TaskList == taskList &&
+				decisionTask.ScheduleID == request.ExecutionInfo.DecisionScheduleID
+		})).Return(nil).Run(func(arguments mock.Arguments) {
+		// Done.
+		waitCh <- struct{}{}
+	}).Once()
+
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, cronTimerQueue, s.logger)
+	processor.NotifyNewTimer(taskID)
+
+	// Start timer Processor.
+	processor.Start()
+	<-waitCh
+	processor.Stop()
+}
+
+func (s *timerQueueProcessor2Suite) TestFirstDecisionBackoffDispatchesScheduledDecision() {
+	domainID := "5bb49df8-71bc-4c63-b57f-05f2a508e7b5"
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("first-decision-backoff-scheduled-test"),
+		RunId: common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6")}
+
+	taskList := "first-decision-backoff-scheduled"
+
+	builder := newMutableStateBuilder(s.logger)
+	builder.AddWorkflowExecutionStartedEvent(domainID, we, &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                   &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                       common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		TaskStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+		DelayStartSeconds:              common.Int32Ptr(1),
+	})
+	// The first decision is scheduled at start, a signal received during the delay does not schedule another
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(builder)
+	builder.AddWorkflowExecutionSignaled(&workflow.SignalWorkflowExecutionRequest{
+		SignalName: common.StringPtr("signal"),
+	})
+
+	waitCh := make(chan struct{})
+
+	taskID := int64(100)
+	timerTask := &persistence.TimerTaskInfo{WorkflowID: "wid", RunID: "rid", TaskID: taskID,
+		TaskType: persistence.TaskTypeFirstDecisionBackoff, EventID: decisionScheduledEvent.GetEventId()}
+	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
+
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once() // initial
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything,
+		&persistence.GetTimerIndexTasksRequest{Queue: persistence.TimerQueueCron, TaskIDs: []int64{100}}).Return(timerIndexResponse, nil).Once()
+	ms := createMutableState(builder)
+	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}, nil)
+
+	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything, &persistence.CompleteTimerTaskRequest{
+		Queue: persistence.TimerQueueCron, TaskID: taskID}).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+			// The already scheduled decision is dispatched to matching without any new events
+			if len(request.TransferTasks) != 1 || request.DeleteTimerTask.GetTaskID() != taskID {
+				return false
+			}
+			decisionTask, ok := request.TransferTasks[0].(*persistence.DecisionTask)
+			return ok && decisionTask.TaskList == taskList &&
+				decisionTask.ScheduleID == decisionScheduledEvent.GetEventId()
+		})).Return(nil).Run(func(arguments mock.Arguments) {
 		// Done.
 		waitCh <- struct{}{}
 	}).Once()
 
-	processor := newTimerQueueProcessor(s.mockHistoryEngine, s.mockExecutionMgr, s.logger).(*timerQueueProcessorImpl)
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, cronTimerQueue, s.logger)
 	processor.NotifyNewTimer(taskID)
 
 	// Start timer Processor.
@@ -175,3 +477,300 @@ func (s *timerQueueProcessor2Suite) TestTimerUpdateTimesOut() {
 	<-waitCh
 	processor.Stop()
 }
+
+func (s *timerQueueProcessor2Suite) TestDecisionRetryBackoff() {
+	domainID := "5bb49df8-71bc-4c63-b57f-05f2a508e7b5"
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("decision-retry-backoff-test"),
+		RunId: common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6")}
+
+	taskList := "decision-retry-backoff"
+
+	builder := newMutableStateBuilder(s.logger)
+	builder.AddWorkflowExecutionStartedEvent(domainID, we, &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                   &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                       common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		TaskStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+	})
+
+	// Decision is scheduled after a previous attempt failed
+	builder.executionInfo.DecisionAttempt = 1
+	decisionScheduledEvent, di := addDecisionTaskScheduledEvent(builder)
+	s.Equal(int64(1), di.Attempt)
+	s.Equal(int64(1), decisionScheduledEvent.GetDecisionTaskScheduledEventAttributes().GetAttempt())
+
+	waitCh := make(chan struct{})
+
+	taskID := int64(100)
+	timerTask := &persistence.TimerTaskInfo{WorkflowID: "wid", RunID: "rid", TaskID: taskID,
+		TaskType: persistence.TaskTypeDecisionRetryBackoff, EventID: decisionScheduledEvent.GetEventId()}
+	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
+
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once() // initial
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything,
+		&persistence.GetTimerIndexTasksRequest{TaskIDs: []int64{100}}).Return(timerIndexResponse, nil).Once()
+	ms := createMutableState(builder)
+	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}, nil)
+
+	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+			// The already scheduled decision is dispatched to matching without any new events
+			if len(request.TransferTasks) != 1 || request.DeleteTimerTask.GetTaskID() != taskID {
+				return false
+			}
+			decisionTask, ok := request.TransferTasks[0].(*persistence.DecisionTask)
+			return ok && decisionTask.TaskList == taskList &&
+				decisionTask.ScheduleID == decisionScheduledEvent.GetEventId()
+		})).Return(nil).Run(func(arguments mock.Arguments) {
+		// Done.
+		waitCh <- struct{}{}
+	}).Once()
+
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	processor.NotifyNewTimer(taskID)
+
+	// Start timer Processor.
+	processor.Start()
+	<-waitCh
+	processor.Stop()
+}
+
+func (s *timerQueueProcessor2Suite) TestDeleteHistoryEvent() {
+	domainID := "5bb49df8-71bc-4c63-b57f-05f2a508e7b5"
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("delete-history-event-test"),
+		RunId: common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6")}
+
+	taskList := "delete-history-event"
+	identity := "delete-history-event-test"
+
+	builder := newMutableStateBuilder(s.logger)
+	builder.AddWorkflowExecutionStartedEvent(domainID, we, &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                   &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                       common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		TaskStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+	})
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(builder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(builder, decisionScheduledEvent.GetEventId(), taskList, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(builder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, identity)
+	addCompleteWorkflowEvent(builder, decisionCompletedEvent.GetEventId(), []byte("result"))
+	s.Equal(persistence.WorkflowStateCompleted, builder.executionInfo.State)
+
+	waitCh := make(chan struct{})
+
+	taskID := int64(100)
+	timerTask := &persistence.TimerTaskInfo{DomainID: domainID, WorkflowID: we.GetWorkflowId(), RunID: we.GetRunId(),
+		TaskID: taskID, TaskType: persistence.TaskTypeDeleteHistoryEvent}
+	timerIndexResponse := &persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{timerTask}}
+
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(timerIndexResponse, nil).Once() // initial
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything,
+		&persistence.GetTimerIndexTasksRequest{TaskIDs: []int64{100}}).Return(timerIndexResponse, nil).Once()
+	ms := createMutableState(builder)
+	wfResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(wfResponse, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, mock.Anything).Return(
+		&persistence.GetTimerIndexTasksResponse{Timers: []*persistence.TimerTaskInfo{}}, nil)
+
+	s.mockHistoryMgr.On("DeleteWorkflowExecutionHistory", mock.Anything, &persistence.DeleteWorkflowExecutionHistoryRequest{
+		DomainID:  domainID,
+		Execution: we,
+	}).Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *persistence.DeleteWorkflowExecutionRequest) bool {
+			return request.ExecutionInfo.RunID == we.GetRunId()
+		})).Return(nil).Once()
+	s.mockExecutionMgr.On("CompleteTimerTask", mock.Anything, &persistence.CompleteTimerTaskRequest{TaskID: taskID}).
+		Return(nil).Run(func(arguments mock.Arguments) {
+		// Done.
+		waitCh <- struct{}{}
+	}).Once()
+
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	processor.NotifyNewTimer(taskID)
+
+	// Start timer Processor.
+	processor.Start()
+	<-waitCh
+	processor.Stop()
+}
+
+func (s *timerQueueProcessor2Suite) TestTimerTasksReadInBatch() {
+	tasksCh := make(chan SequenceID, timerTaskBatchSize)
+	tasksCh <- SequenceID(100)
+	tasksCh <- SequenceID(101)
+	tasksCh <- SequenceID(100)
+	close(tasksCh)
+
+	// Both timers got deleted before they fired
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything,
+		&persistence.GetTimerIndexTasksRequest{TaskIDs: []int64{100, 101}}).Return(
+		&persistence.GetTimerIndexTasksResponse{}, nil).Once()
+
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	scheduler := newDomainTaskScheduler(domainTaskSchedulerCapacity, func(string) int { return defaultDomainTaskWeight })
+	var workerWG sync.WaitGroup
+	workerWG.Add(1)
+	processor.readTaskWorker(tasksCh, scheduler, &workerWG)
+}
+
+func (s *timerQueueProcessor2Suite) TestTimerMetrics() {
+	scope := tally.NewTestScope("", nil)
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	processor.metricsClient = metrics.NewClient(scope, metrics.History)
+	processor.taskMetrics = newTaskMetrics(processor.metricsClient)
+
+	expiry := time.Now().Add(-time.Second)
+	processor.emitTimerFired(ConstructTimerKey(expiry.UnixNano(), 1),
+		&persistence.TimerTaskInfo{DomainID: "domain", TaskType: persistence.TaskTypeUserTimer})
+	processor.emitTimerAheadOfNow(ConstructTimerKey(time.Now().Add(time.Minute).UnixNano(), 2))
+
+	snapshot := scope.Snapshot()
+	counter, ok := snapshot.Counters()["timer-tasks-processed+operation=ProcessTimerTask"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+
+	latency, ok := snapshot.Timers()["timer-fire-latency+domain_id=domain,operation=ProcessTimerTask,task_type=UserTimer"]
+	s.True(ok)
+	s.True(latency.Values()[0] >= time.Second)
+
+	gauge, ok := snapshot.Gauges()["timer-ahead-of-now-ms+operation=ProcessTimerTask"]
+	s.True(ok)
+	s.True(gauge.Value() > 0)
+}
+
+func (s *timerQueueProcessor2Suite) TestTimerDispatcherJitter() {
+	config := NewConfig()
+	config.TimerJitterWindow = 50 * time.Millisecond
+	config.TimerJitterThreshold = 2
+	shutdownCh := make(chan struct{})
+	dispatcher := &timerDispatcher{
+		tasksCh:       make(chan SequenceID, 10),
+		shutdownCh:    shutdownCh,
+		config:        config,
+		metricsClient: metrics.NewClient(tally.NoopScope, metrics.History),
+	}
+
+	fireTime := time.Now().Truncate(time.Second)
+	for seqNum := int64(1); seqNum <= 5; seqNum++ {
+		dispatcher.dispatch(ConstructTimerKey(fireTime.UnixNano(), seqNum))
+	}
+	// Timers firing in the next second start a new burst
+	dispatcher.dispatch(ConstructTimerKey(fireTime.Add(time.Second).UnixNano(), 6))
+	s.Equal(3, len(dispatcher.tasksCh))
+
+	dispatched := map[SequenceID]bool{}
+	for i := 0; i < 6; i++ {
+		select {
+		case key := <-dispatcher.tasksCh:
+			dispatched[key] = true
+		case <-time.After(time.Second):
+			s.Fail("Timed out waiting for jittered timers")
+		}
+	}
+	s.Equal(6, len(dispatched))
+
+	// Jittered timers still pending on shutdown are dropped rather than sent on the closed channel
+	for seqNum := int64(7); seqNum <= 10; seqNum++ {
+		dispatcher.dispatch(ConstructTimerKey(fireTime.Add(time.Second).UnixNano(), seqNum))
+	}
+	close(shutdownCh)
+	dispatcher.close()
+	time.Sleep(2 * config.TimerJitterWindow)
+}
+
+func (s *timerQueueProcessor2Suite) TestTimerDueTimeClockSkew() {
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	processor.config.TimerMaxClockSkew = 5 * time.Second
+	shardInfo := processor.shard.(*shardContextImpl).shardInfo
+	now := time.Now().UnixNano()
+
+	// Clock of this host is ahead of the previous owner of the shard
+	shardInfo.TimerAckLevel = now - int64(time.Minute)
+	s.Equal(now, processor.dueTime(now))
+
+	// Clock of this host is behind, timers the previous owner already considered due stay due
+	shardInfo.TimerAckLevel = now + int64(2*time.Second)
+	s.Equal(now+int64(2*time.Second), processor.dueTime(now))
+	s.True(processor.isProcessNow(ConstructTimerKey(now+int64(time.Second), 1)))
+
+	// Ack level too far ahead of this host's clock is only trusted up to the max clock skew
+	shardInfo.TimerAckLevel = now + int64(time.Hour)
+	s.Equal(now+int64(5*time.Second), processor.dueTime(now))
+	s.False(processor.isProcessNow(ConstructTimerKey(now+int64(time.Minute), 1)))
+}
+
+func (s *timerQueueProcessor2Suite) TestTimerUpdateAckLevel() {
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	pendingKey := ConstructTimerKey(time.Now().Add(-time.Minute).UnixNano(), 1)
+	pendingExpiry, _ := DeconstructTimerKey(pendingKey)
+
+	// Ack level stops at the first timer not processed yet
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, &persistence.GetTimerIndexTasksRequest{
+		MinKey: int64(MinTimerKey), MaxKey: int64(MaxTimerKey), BatchSize: 1}).Return(
+		&persistence.GetTimerIndexTasksResponse{
+			Timers: []*persistence.TimerTaskInfo{{TaskID: int64(pendingKey)}},
+		}, nil).Twice()
+	s.mockShardManager.On("UpdateShard", mock.Anything, mock.MatchedBy(func(request *persistence.UpdateShardRequest) bool {
+		return request.ShardInfo.TimerAckLevel == pendingExpiry
+	})).Return(nil).Once()
+	processor.updateAckLevel()
+	s.Equal(pendingExpiry, processor.shard.GetTimerAckLevel(activeTimerQueueName))
+
+	// Ack level isn't persisted again until it moves
+	processor.updateAckLevel()
+}
+
+func (s *timerQueueProcessor2Suite) TestTimerDueOnShardTimeSource() {
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, activeTimerQueue, s.logger)
+	timeSource := common.NewFakeTimeSource(time.Now())
+	processor.shard.(*shardContextImpl).timeSource = timeSource
+	key := ConstructTimerKey(timeSource.Now().Add(time.Minute).UnixNano(), 1)
+
+	s.False(processor.isProcessNow(key))
+	timeSource.Advance(time.Minute)
+	s.True(processor.isProcessNow(key))
+
+	// The gate holds off until the clock of the shard reaches the next timer
+	gate := newTimeGate(timeSource)
+	defer gate.close()
+	processor.setGateNext(gate, ConstructTimerKey(timeSource.Now().Add(time.Second).UnixNano(), 2))
+	s.True(gate.engaged())
+	timeSource.Advance(time.Second)
+	s.False(gate.engaged())
+}
+
+func (s *timerQueueProcessor2Suite) TestTimerQueueReadsItsOwnTimers() {
+	processor := newTimerQueue(s.mockHistoryEngine, s.mockExecutionMgr, cronTimerQueue, s.logger)
+	backoffKey := ConstructTimerKey(time.Now().Add(-time.Minute).UnixNano(), 1)
+	backoffExpiry, _ := DeconstructTimerKey(backoffKey)
+
+	// The timers of the active queue are never read by the cron queue, however many are pending
+	backoffTimers := &persistence.GetTimerIndexTasksResponse{
+		Timers: []*persistence.TimerTaskInfo{
+			{TaskID: int64(backoffKey), TaskType: persistence.TaskTypeFirstDecisionBackoff},
+		},
+	}
+	for _, batchSize := range []int{timerTaskBatchSize, 1} {
+		s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything, &persistence.GetTimerIndexTasksRequest{
+			Queue: persistence.TimerQueueCron, MinKey: int64(MinTimerKey), MaxKey: int64(MaxTimerKey),
+			BatchSize: batchSize}).Return(backoffTimers, nil).Once()
+	}
+
+	keys, err := processor.getNextKey(MinTimerKey, MaxTimerKey)
+	s.Nil(err)
+	s.Equal([]SequenceID{backoffKey}, keys)
+
+	// The ack level of the queue is kept apart from the one of the active queue
+	s.mockShardManager.On("UpdateShard", mock.Anything, mock.MatchedBy(func(request *persistence.UpdateShardRequest) bool {
+		return request.ShardInfo.TimerAckLevels[cronTimerQueueName] == backoffExpiry &&
+			request.ShardInfo.TimerAckLevel == 0
+	})).Return(nil).Once()
+	processor.updateAckLevel()
+	s.Equal(backoffExpiry, processor.shard.GetTimerAckLevel(cronTimerQueueName))
+	s.Equal(int64(0), processor.shard.GetTimerAckLevel(activeTimerQueueName))
+	s.mockExecutionMgr.AssertExpectations(s.T())
+}
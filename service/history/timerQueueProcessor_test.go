@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
 
@@ -33,8 +34,10 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/cache"
+	"golang.org/x/net/context"
 )
 
 type (
@@ -43,7 +46,7 @@ type (
 		persistence.TestBase
 		engineImpl       *historyEngineImpl
 		mockShardManager *mocks.ShardManager
-		shardClosedCh    chan int
+		shardEvents      *shardEventBus
 		logger           bark.Logger
 
 		mockMetadataMgr   *mocks.MetadataManager
@@ -70,26 +73,28 @@ func (s *timerQueueProcessorSuite) SetupSuite() {
 	shardID := 0
 	s.mockShardManager = &mocks.ShardManager{}
 	s.mockMetadataMgr = &mocks.MetadataManager{}
-	resp, err := s.ShardMgr.GetShard(&persistence.GetShardRequest{ShardID: shardID})
+	resp, err := s.ShardMgr.GetShard(context.Background(), &persistence.GetShardRequest{ShardID: shardID})
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	shard := &shardContextImpl{
-		shardInfo:                 resp.ShardInfo,
-		transferSequenceNumber:    1,
-		executionManager:          s.WorkflowMgr,
-		shardManager:              s.mockShardManager,
-		historyMgr:                s.HistoryMgr,
-		rangeSize:                 defaultRangeSize,
-		maxTransferSequenceNumber: 100000,
-		closeCh:                   s.shardClosedCh,
-		logger:                    s.logger,
+		shardInfo:             resp.ShardInfo,
+		taskSequenceNumber:    1,
+		executionManager:      s.WorkflowMgr,
+		shardManager:          s.mockShardManager,
+		historyMgr:            s.HistoryMgr,
+		rangeSize:             defaultRangeSize,
+		maxTaskSequenceNumber: 100000,
+		closeEvents:           s.shardEvents,
+		logger:                s.logger,
+		metricsClient:         metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:            common.NewRealTimeSource(),
 	}
 	historyCache := newHistoryCache(historyCacheMaxSize, shard, s.logger)
 	historyCache.disabled = true
 	domainCache := cache.NewDomainCache(s.mockMetadataMgr, s.logger)
-	txProcessor := newTransferQueueProcessor(shard, s.mockVisibilityMgr, &mocks.MatchingClient{}, &mocks.HistoryClient{}, historyCache, domainCache)
+	txProcessor := newTransferQueueProcessor(shard, s.mockVisibilityMgr, &mocks.MatchingClient{}, &mocks.HistoryClient{}, historyCache, domainCache, nil, NewConfig())
 	s.engineImpl = &historyEngineImpl{
 		shard:              shard,
 		historyMgr:         s.HistoryMgr,
@@ -99,6 +104,10 @@ func (s *timerQueueProcessorSuite) SetupSuite() {
 		logger:             s.logger,
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+		queryRegistry:      newQueryRegistry(),
+		signalBatcher:      newSignalBatcher(0),
+		taskMetrics:        newTaskMetrics(shard.GetMetricsClient()),
+		config:             NewConfig(),
 	}
 }
 
@@ -135,7 +144,7 @@ func (s *timerQueueProcessorSuite) createExecutionWithTimers(domainID string, we
 	timerTasks := []persistence.Task{}
 	timerInfos := []*persistence.TimerInfo{}
 	decisionCompletedID := int64(4)
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.engineImpl.shard.GetTimeSource(), s.logger)
 	for _, timeOut := range timeOuts {
 		_, ti := builder.AddTimerStartedEvent(decisionCompletedID,
 			&workflow.StartTimerDecisionAttributes{
@@ -144,7 +153,9 @@ func (s *timerQueueProcessorSuite) createExecutionWithTimers(domainID string, we
 			})
 
 		timerInfos = append(timerInfos, ti)
-		if t := tBuilder.AddUserTimer(ti, builder); t != nil {
+		t, err := tBuilder.AddUserTimer(ti, builder)
+		s.NoError(err)
+		if t != nil {
 			timerTasks = append(timerTasks, t)
 		}
 	}
@@ -167,7 +178,8 @@ func (s *timerQueueProcessorSuite) addDecisionTimer(domainID string, we workflow
 	scheduledEvent, _ := addDecisionTaskScheduledEvent(builder)
 	addDecisionTaskStartedEvent(builder, scheduledEvent.GetEventId(), state.ExecutionInfo.TaskList, "identity")
 
-	timeOutTask := tb.AddDecisionTimoutTask(scheduledEvent.GetEventId(), 1)
+	timeOutTask, err := tb.AddDecisionTimoutTask(scheduledEvent.GetEventId(), 1)
+	s.NoError(err)
 	timerTasks := []persistence.Task{timeOutTask}
 
 	err2 := s.UpdateWorkflowExecution(state.ExecutionInfo, nil, nil, condition, timerTasks, nil, nil, nil, nil, nil)
@@ -185,7 +197,8 @@ func (s *timerQueueProcessorSuite) addUserTimer(domainID string, we workflow.Wor
 	// create a user timer
 	_, ti := builder.AddTimerStartedEvent(emptyEventID,
 		&workflow.StartTimerDecisionAttributes{TimerId: common.StringPtr(timerID), StartToFireTimeoutSeconds: common.Int64Ptr(1)})
-	t := tb.AddUserTimer(ti, builder)
+	t, err := tb.AddUserTimer(ti, builder)
+	s.NoError(err)
 	s.NotNil(t)
 	timerTasks := []persistence.Task{t}
 
@@ -204,7 +217,7 @@ func (s *timerQueueProcessorSuite) addHeartBeatTimer(domainID string,
 	ase, ai := builder.AddActivityTaskScheduledEvent(emptyEventID,
 		&workflow.ScheduleActivityTaskDecisionAttributes{
 			HeartbeatTimeoutSeconds: common.Int32Ptr(1),
-		})
+		}, nil)
 	builder.AddActivityTaskStartedEvent(ai, ase.GetEventId(), uuid.New(), &workflow.PollForActivityTaskRequest{})
 
 	// create a heart beat timeout
@@ -242,7 +255,7 @@ func (s *timerQueueProcessorSuite) TestSingleTimerTask() {
 	s.NotEmpty(timerInfo, "Expected non empty timers list")
 	s.Equal(1, len(timerInfo))
 
-	processor := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	processor := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	processor.Start()
 
 	for {
@@ -267,6 +280,13 @@ func (s *timerQueueProcessorSuite) TestManyTimerTasks() {
 
 	taskList := "multiple-timer-queue"
 	identity := "testIdentity"
+
+	// The timers fire as the clock of the shard moves past them, without waiting for them
+	shard := s.engineImpl.shard.(*shardContextImpl)
+	timeSource := common.NewFakeTimeSource(time.Now())
+	shard.timeSource = timeSource
+	defer func() { shard.timeSource = common.NewRealTimeSource() }()
+
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, identity, []int32{1, 2, 3})
 
 	timerInfo, err := s.GetTimerIndexTasks(int64(MinTimerKey), int64(MaxTimerKey))
@@ -274,19 +294,14 @@ func (s *timerQueueProcessorSuite) TestManyTimerTasks() {
 	s.NotEmpty(timerInfo, "Expected non empty timers list")
 	s.Equal(1, len(timerInfo))
 
-	processor := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	processor := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	processor.Start()
 
-	for {
-		timerInfo, err := s.GetTimerIndexTasks(int64(MinTimerKey), int64(MaxTimerKey))
-		s.logger.Infof("TestManyTimerTasks: GetTimerIndexTasks: Response Count: %d \n", len(timerInfo))
-		s.Nil(err, "No error expected.")
-		if len(timerInfo) == 0 {
-			processor.Stop()
-			break
-		}
-		time.Sleep(1000 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		timeSource.Advance(time.Second)
+		processor.NotifyNewTimer(int64(MaxTimerKey))
 	}
+	s.waitForTimerTasksToProcess(processor)
 
 	timerInfo, err = s.GetTimerIndexTasks(int64(MinTimerKey), int64(MaxTimerKey))
 	s.Nil(err, "No error expected.")
@@ -309,11 +324,18 @@ func (s *timerQueueProcessorSuite) TestTimerTaskAfterProcessorStart() {
 	s.Nil(err, "No error expected.")
 	s.Empty(timerInfo, "Expected empty timers list")
 
-	processor := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	// The decision timer fires once the clock of the shard moves past it, without waiting for it
+	shard := s.engineImpl.shard.(*shardContextImpl)
+	timeSource := common.NewFakeTimeSource(time.Now())
+	shard.timeSource = timeSource
+	defer func() { shard.timeSource = common.NewRealTimeSource() }()
+
+	processor := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	processor.Start()
 
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, timeSource, s.logger)
 	timeOutTask := s.addDecisionTimer(domainID, workflowExecution, tBuilder)
+	timeSource.Advance(2 * time.Second)
 	processor.NotifyNewTimer(timeOutTask.GetTaskID())
 
 	s.waitForTimerTasksToProcess(processor)
@@ -334,7 +356,7 @@ func (s *timerQueueProcessorSuite) waitForTimerTasksToProcess(p timerQueueProces
 			p.Stop()
 			break
 		}
-		time.Sleep(1000 * time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
 	}
 }
 
@@ -386,7 +408,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToStart_WithOutS
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// TimeoutType_SCHEDULE_TO_START - Without Start
-	processor := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	processor := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	processor.Start()
 
 	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
@@ -398,10 +420,11 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToStart_WithOutS
 	activityScheduledEvent, ai := builder.AddActivityTaskScheduledEvent(emptyEventID,
 		&workflow.ScheduleActivityTaskDecisionAttributes{
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
-		})
+		}, nil)
 
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
-	t := tBuilder.AddScheduleToStartActivityTimeout(ai)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
+	t, err := tBuilder.AddScheduleToStartActivityTimeout(ai)
+	s.NoError(err)
 	s.NotNil(t)
 	timerTasks := []persistence.Task{t}
 
@@ -424,7 +447,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToStart_WithStar
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// TimeoutType_SCHEDULE_TO_START - With Start
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
 	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
@@ -436,12 +459,13 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToStart_WithStar
 	ase, ai := builder.AddActivityTaskScheduledEvent(emptyEventID,
 		&workflow.ScheduleActivityTaskDecisionAttributes{
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
-		})
+		}, nil)
 	builder.AddActivityTaskStartedEvent(ai, ase.GetEventId(), uuid.New(), &workflow.PollForActivityTaskRequest{})
 
 	// create a schedule to start timeout
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
-	t := tBuilder.AddScheduleToStartActivityTimeout(ai)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
+	t, err := tBuilder.AddScheduleToStartActivityTimeout(ai)
+	s.NoError(err)
 	s.NotNil(t)
 	timerTasks := []persistence.Task{t}
 
@@ -464,7 +488,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskStartToClose_WithStart()
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// TimeoutType_START_TO_CLOSE - Just start.
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
 	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
@@ -476,11 +500,11 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskStartToClose_WithStart()
 	ase, ai := builder.AddActivityTaskScheduledEvent(emptyEventID,
 		&workflow.ScheduleActivityTaskDecisionAttributes{
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
-		})
+		}, nil)
 	builder.AddActivityTaskStartedEvent(ai, ase.GetEventId(), uuid.New(), &workflow.PollForActivityTaskRequest{})
 
 	// create a start to close timeout
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 	t, err := tBuilder.AddStartToCloseActivityTimeout(ai)
 	s.NoError(err)
 	s.NotNil(t)
@@ -504,7 +528,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskStartToClose_CompletedAc
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// TimeoutType_START_TO_CLOSE - Start and Completed activity.
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
 	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
@@ -516,7 +540,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskStartToClose_CompletedAc
 	ase, ai := builder.AddActivityTaskScheduledEvent(emptyEventID,
 		&workflow.ScheduleActivityTaskDecisionAttributes{
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
-		})
+		}, nil)
 	aste := builder.AddActivityTaskStartedEvent(ai, ase.GetEventId(), uuid.New(), &workflow.PollForActivityTaskRequest{})
 	builder.AddActivityTaskCompletedEvent(ase.GetEventId(), aste.GetEventId(), &workflow.RespondActivityTaskCompletedRequest{
 		Identity: common.StringPtr("test-id"),
@@ -524,7 +548,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskStartToClose_CompletedAc
 	})
 
 	// create a start to close timeout
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 	t, err := tBuilder.AddStartToCloseActivityTimeout(ai)
 	s.NoError(err)
 	s.NotNil(t)
@@ -548,7 +572,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToClose_JustSche
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// TimeoutType_SCHEDULE_TO_CLOSE - Just Scheduled.
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
 	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
@@ -560,10 +584,10 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToClose_JustSche
 	ase, ai := builder.AddActivityTaskScheduledEvent(emptyEventID,
 		&workflow.ScheduleActivityTaskDecisionAttributes{
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
-		})
+		}, nil)
 
 	// create a schedule to close timeout
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 	t, err := tBuilder.AddScheduleToCloseActivityTimeout(ai)
 	s.NoError(err)
 	s.NotNil(t)
@@ -587,7 +611,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToClose_Started(
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// TimeoutType_SCHEDULE_TO_CLOSE - Scheduled and started.
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
 	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
@@ -599,11 +623,11 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToClose_Started(
 	ase, ai := builder.AddActivityTaskScheduledEvent(emptyEventID,
 		&workflow.ScheduleActivityTaskDecisionAttributes{
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
-		})
+		}, nil)
 	builder.AddActivityTaskStartedEvent(ai, ase.GetEventId(), uuid.New(), &workflow.PollForActivityTaskRequest{})
 
 	// create a schedule to close timeout
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 	t, err := tBuilder.AddScheduleToCloseActivityTimeout(ai)
 	s.NoError(err)
 	s.NotNil(t)
@@ -627,7 +651,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToClose_Complete
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// TimeoutType_SCHEDULE_TO_CLOSE - Scheduled, started, completed.
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
 	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
@@ -639,7 +663,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToClose_Complete
 	ase, ai := builder.AddActivityTaskScheduledEvent(emptyEventID,
 		&workflow.ScheduleActivityTaskDecisionAttributes{
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
-		})
+		}, nil)
 	aste := builder.AddActivityTaskStartedEvent(ai, ase.GetEventId(), uuid.New(), &workflow.PollForActivityTaskRequest{})
 	builder.AddActivityTaskCompletedEvent(ase.GetEventId(), aste.GetEventId(), &workflow.RespondActivityTaskCompletedRequest{
 		Identity: common.StringPtr("test-id"),
@@ -647,7 +671,7 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskScheduleToClose_Complete
 	})
 
 	// create a schedule to close timeout
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 	t, err := tBuilder.AddScheduleToCloseActivityTimeout(ai)
 	s.NoError(err)
 	s.NotNil(t)
@@ -672,10 +696,10 @@ func (s *timerQueueProcessorSuite) TestTimerActivityTaskHeartBeat_JustStarted()
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// TimeoutType_HEARTBEAT - Scheduled, started.
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 	ase, t := s.addHeartBeatTimer(domainID, workflowExecution, tBuilder)
 
 	p.NotifyNewTimer(t.GetTaskID())
@@ -694,10 +718,10 @@ func (s *timerQueueProcessorSuite) TestTimerUserTimers() {
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// Single timer.
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 	timerID := "tid1"
 	t := s.addUserTimer(domainID, workflowExecution, timerID, tBuilder)
 	p.NotifyNewTimer(t.GetTaskID())
@@ -716,7 +740,7 @@ func (s *timerQueueProcessorSuite) TestTimerUserTimersSameExpiry() {
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
 	// Two timers.
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
 	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
@@ -731,13 +755,15 @@ func (s *timerQueueProcessorSuite) TestTimerUserTimersSameExpiry() {
 	_, ti2 := builder.AddTimerStartedEvent(emptyEventID,
 		&workflow.StartTimerDecisionAttributes{TimerId: common.StringPtr("tid2"), StartToFireTimeoutSeconds: common.Int64Ptr(1)})
 
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 	timerTasks := []persistence.Task{}
-	t1 := tBuilder.AddUserTimer(ti, builder)
+	t1, err := tBuilder.AddUserTimer(ti, builder)
+	s.NoError(err)
 	if t1 != nil {
 		timerTasks = append(timerTasks, t1)
 	}
-	t2 := tBuilder.AddUserTimer(ti2, builder)
+	t2, err := tBuilder.AddUserTimer(ti2, builder)
+	s.NoError(err)
 	if t2 != nil {
 		timerTasks = append(timerTasks, t2)
 	}
@@ -761,10 +787,10 @@ func (s *timerQueueProcessorSuite) TestTimersOnClosedWorkflow() {
 	taskList := "closed-workflow-queue"
 	s.createExecutionWithTimers(domainID, workflowExecution, taskList, "identity", []int32{})
 
-	p := newTimerQueueProcessor(s.engineImpl, s.WorkflowMgr, s.logger).(*timerQueueProcessorImpl)
+	p := newTimerQueue(s.engineImpl, s.WorkflowMgr, activeTimerQueue, s.logger)
 	p.Start()
 
-	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, s.logger)
+	tBuilder := newTimerBuilder(&localSeqNumGenerator{counter: 1}, common.NewRealTimeSource(), s.logger)
 
 	// Start of one of each timers each
 	dt := s.addDecisionTimer(domainID, workflowExecution, tBuilder)
@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	// activeTimerQueueName is the queue of the timers of running executions, like timeouts and user timers
+	activeTimerQueueName = "active"
+	// cronTimerQueueName is the queue of the timers starting the first decision of executions started with a delay
+	cronTimerQueueName = "cron"
+)
+
+type (
+	// timerQueueDefinition names a logical timer queue of a shard.  Each queue reads and fires only the timer tasks
+	// kept in its own partition of the timer tasks of the shard, and keeps an ack level of its own, so a backlog of
+	// timers in one queue doesn't hold up the others.
+	timerQueueDefinition struct {
+		name string
+		// timerQueue is the persistence timer queue holding the timer tasks of the queue
+		timerQueue int
+	}
+
+	// timerQueueProcessors runs the timer queues of a shard
+	timerQueueProcessors struct {
+		queues []*timerQueueProcessorImpl
+	}
+)
+
+var (
+	activeTimerQueue = timerQueueDefinition{name: activeTimerQueueName, timerQueue: persistence.TimerQueueActive}
+	cronTimerQueue   = timerQueueDefinition{name: cronTimerQueueName, timerQueue: persistence.TimerQueueCron}
+
+	// timerQueueDefinitions are the timer queues run for each shard, see persistence.GetTimerQueue for the timers
+	// fired by each
+	timerQueueDefinitions = []timerQueueDefinition{activeTimerQueue, cronTimerQueue}
+)
+
+func newTimerQueueProcessor(historyService *historyEngineImpl, executionManager persistence.ExecutionManager,
+	logger bark.Logger) timerQueueProcessor {
+	processors := &timerQueueProcessors{}
+	for _, queue := range timerQueueDefinitions {
+		processors.queues = append(processors.queues, newTimerQueue(historyService, executionManager, queue, logger))
+	}
+	return processors
+}
+
+func (p *timerQueueProcessors) Start() {
+	for _, queue := range p.queues {
+		queue.Start()
+	}
+}
+
+func (p *timerQueueProcessors) Stop() {
+	for _, queue := range p.queues {
+		queue.Stop()
+	}
+}
+
+// NotifyNewTimer notifies all queues, as only the task ID of the new timer is known.  The timer is only found in the
+// partition of its own queue.
+func (p *timerQueueProcessors) NotifyNewTimer(taskID int64) {
+	for _, queue := range p.queues {
+		queue.NotifyNewTimer(taskID)
+	}
+}
+
+func (p *timerQueueProcessors) Pause() {
+	for _, queue := range p.queues {
+		queue.Pause()
+	}
+}
+
+func (p *timerQueueProcessors) Resume() {
+	for _, queue := range p.queues {
+		queue.Resume()
+	}
+}
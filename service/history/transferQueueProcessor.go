@@ -21,11 +21,15 @@
 package history
 
 import (
+	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/pborman/uuid"
 	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
 
 	"github.com/uber/cadence/.gen/go/history"
 	m "github.com/uber/cadence/.gen/go/matching"
@@ -33,6 +37,7 @@ import (
 	hc "github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
@@ -45,26 +50,41 @@ const (
 	transferProcessorMaxPollInterval   = 10 * time.Second
 	transferProcessorUpdateAckInterval = 10 * time.Second
 	taskWorkerCount                    = 10
+	transferCancelHistoryPageSize      = 100
 )
 
 type (
 	transferQueueProcessorImpl struct {
-		shard             ShardContext
-		ackMgr            *ackManager
-		executionManager  persistence.ExecutionManager
-		visibilityManager persistence.VisibilityManager
-		matchingClient    matching.Client
-		historyClient     hc.Client
-		cache             *historyCache
-		domainCache       cache.DomainCache
-		rateLimiter       common.TokenBucket // Read rate limiter
-		appendCh          chan struct{}
-		isStarted         int32
-		isStopped         int32
-		shutdownWG        sync.WaitGroup
-		shutdownCh        chan struct{}
-		logger            bark.Logger
-		metricsClient     metrics.Client
+		shard              ShardContext
+		ackMgr             *ackManager
+		executionManager   persistence.ExecutionManager
+		visibilityManager  persistence.VisibilityManager
+		matchingClient     matching.Client
+		historyClient      hc.Client
+		cache              *historyCache
+		domainCache        cache.DomainCache
+		payloadBlobs       *payloadBlobs
+		hSerializerFactory persistence.HistorySerializerFactory
+		config             *Config
+		rateLimiter        common.TokenBucket // Read rate limiter
+		appendCh           chan struct{}
+		isStarted          int32
+		isStopped          int32
+		isPaused           int32
+		throttledUntil     int64 // UnixNano until which matching asked the shard to stop sending tasks
+		rescheduleCh       chan *persistence.TransferTaskInfo
+		matchingLock       sync.Mutex
+		matchingAttempts   map[int64]int // failed attempts to add a task to matching, by task ID
+		shutdownWG         sync.WaitGroup
+		shutdownCh         chan struct{}
+		logger             bark.Logger
+		metricsClient      metrics.Client
+		taskMetrics        *taskMetrics
+	}
+
+	// matchingTaskError is the failure to add a decision or activity task to matching, other than back pressure
+	matchingTaskError struct {
+		cause error
 	}
 
 	// ackManager is created by transferQueueProcessor to keep track of the transfer queue ackLevel for the shard.
@@ -86,24 +106,31 @@ type (
 )
 
 func newTransferQueueProcessor(shard ShardContext, visibilityMgr persistence.VisibilityManager, matching matching.Client,
-	historyClient hc.Client, cache *historyCache, domainCache cache.DomainCache) transferQueueProcessor {
+	historyClient hc.Client, cache *historyCache, domainCache cache.DomainCache, payloadBlobs *payloadBlobs,
+	config *Config) transferQueueProcessor {
 	executionManager := shard.GetExecutionManager()
 	logger := shard.GetLogger()
 	processor := &transferQueueProcessorImpl{
-		shard:             shard,
-		executionManager:  executionManager,
-		matchingClient:    matching,
-		historyClient:     historyClient,
-		visibilityManager: visibilityMgr,
-		cache:             cache,
-		domainCache:       domainCache,
-		rateLimiter:       common.NewTokenBucket(transferProcessorMaxPollRPS, common.NewRealTimeSource()),
-		appendCh:          make(chan struct{}, 1),
-		shutdownCh:        make(chan struct{}),
+		shard:              shard,
+		executionManager:   executionManager,
+		matchingClient:     matching,
+		historyClient:      historyClient,
+		visibilityManager:  visibilityMgr,
+		cache:              cache,
+		domainCache:        domainCache,
+		payloadBlobs:       payloadBlobs,
+		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+		config:             config,
+		rateLimiter:        common.NewTokenBucket(transferProcessorMaxPollRPS, common.NewRealTimeSource()),
+		appendCh:           make(chan struct{}, 1),
+		rescheduleCh:       make(chan *persistence.TransferTaskInfo),
+		matchingAttempts:   make(map[int64]int),
+		shutdownCh:         make(chan struct{}),
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueTransferQueueComponent,
 		}),
 		metricsClient: shard.GetMetricsClient(),
+		taskMetrics:   newTaskMetrics(shard.GetMetricsClient()),
 	}
 	processor.ackMgr = newAckManager(processor, shard, executionManager, logger)
 
@@ -164,12 +191,12 @@ func (t *transferQueueProcessorImpl) NotifyNewTask() {
 
 func (t *transferQueueProcessorImpl) processorPump() {
 	defer t.shutdownWG.Done()
-	tasksCh := make(chan *persistence.TransferTaskInfo, transferTaskBatchSize)
+	scheduler := newDomainTaskScheduler(domainTaskSchedulerCapacity, newDomainWeightFn(t.config, t.domainCache))
 
 	var workerWG sync.WaitGroup
 	for i := 0; i < taskWorkerCount; i++ {
 		workerWG.Add(1)
-		go t.taskWorker(tasksCh, &workerWG)
+		go t.taskWorker(scheduler, &workerWG)
 	}
 
 	pollTimer := time.NewTimer(transferProcessorMaxPollInterval)
@@ -181,9 +208,11 @@ processorPumpLoop:
 		case <-t.shutdownCh:
 			break processorPumpLoop
 		case <-t.appendCh:
-			t.processTransferTasks(tasksCh)
+			t.processTransferTasks(scheduler)
+		case task := <-t.rescheduleCh:
+			scheduler.submit(task.DomainID, task)
 		case <-pollTimer.C:
-			t.processTransferTasks(tasksCh)
+			t.processTransferTasks(scheduler)
 			pollTimer = time.NewTimer(transferProcessorMaxPollInterval)
 		case <-updateAckTimer.C:
 			t.ackMgr.updateAckLevel()
@@ -192,8 +221,8 @@ processorPumpLoop:
 	}
 
 	t.logger.Info("Transfer queue processor pump shutting down.")
-	// This is the only pump which writes to tasksCh, so it is safe to close channel here
-	close(tasksCh)
+	// This is the only pump which submits tasks, so it is safe to close the scheduler here
+	scheduler.close()
 	if success := common.AwaitWaitGroup(&workerWG, 10*time.Second); !success {
 		t.logger.Warn("Transfer queue processor timed out on worker shutdown.")
 	}
@@ -201,13 +230,37 @@ processorPumpLoop:
 	pollTimer.Stop()
 }
 
-func (t *transferQueueProcessorImpl) processTransferTasks(tasksCh chan<- *persistence.TransferTaskInfo) {
+// Pause stops the dispatch of transfer tasks to the task workers.  Tasks already dispatched are still processed.
+func (t *transferQueueProcessorImpl) Pause() {
+	if atomic.CompareAndSwapInt32(&t.isPaused, 0, 1) {
+		t.logger.Info("Transfer queue processor paused.")
+	}
+}
+
+// Resume restarts the dispatch of transfer tasks after Pause
+func (t *transferQueueProcessorImpl) Resume() {
+	if atomic.CompareAndSwapInt32(&t.isPaused, 1, 0) {
+		t.logger.Info("Transfer queue processor resumed.")
+		t.NotifyNewTask()
+	}
+}
+
+func (t *transferQueueProcessorImpl) processTransferTasks(scheduler *domainTaskScheduler) {
+	if atomic.LoadInt32(&t.isPaused) == 1 {
+		return
+	}
 
 	if !t.rateLimiter.Consume(1, transferProcessorMaxPollInterval) {
 		t.NotifyNewTask() // re-enqueue the event
 		return
 	}
 
+	if wait := time.Duration(atomic.LoadInt64(&t.throttledUntil) - time.Now().UnixNano()); wait > 0 {
+		// matching is saturated, don't dispatch more tasks until the retry-after hint elapses
+		time.AfterFunc(wait, t.NotifyNewTask)
+		return
+	}
+
 	tasks, err := t.ackMgr.readTransferTasks()
 
 	if err != nil {
@@ -221,7 +274,9 @@ func (t *transferQueueProcessorImpl) processTransferTasks(tasksCh chan<- *persis
 	}
 
 	for _, tsk := range tasks {
-		tasksCh <- tsk
+		if !scheduler.submit(tsk.DomainID, tsk) {
+			return
+		}
 	}
 
 	if len(tasks) == transferTaskBatchSize {
@@ -232,23 +287,25 @@ func (t *transferQueueProcessorImpl) processTransferTasks(tasksCh chan<- *persis
 	return
 }
 
-func (t *transferQueueProcessorImpl) taskWorker(tasksCh <-chan *persistence.TransferTaskInfo, workerWG *sync.WaitGroup) {
+func (t *transferQueueProcessorImpl) taskWorker(scheduler *domainTaskScheduler, workerWG *sync.WaitGroup) {
 	defer workerWG.Done()
 	for {
-		select {
-		case task, ok := <-tasksCh:
-			if !ok {
-				return
-			}
-
-			t.processTransferTask(task)
+		task, ok := scheduler.next()
+		if !ok {
+			return
 		}
+
+		t.processTransferTask(task.(*persistence.TransferTaskInfo))
 	}
 }
 
 func (t *transferQueueProcessorImpl) processTransferTask(task *persistence.TransferTaskInfo) {
 	t.logger.Debugf("Processing transfer task: %v, type: %v", task.TaskID, task.TaskType)
 	t.metricsClient.AddCounter(metrics.HistoryProcessTransferTasksScope, metrics.TransferTasksProcessedCounter, 1)
+	t.taskMetrics.recordLatency(metrics.HistoryProcessTransferTasksScope, metrics.TransferTaskQueueLatency,
+		task.DomainID, getTransferTaskType(task.TaskType), task.VisibilityTimestamp)
+	ctx := context.Background()
+	throttleCount := 0
 ProcessRetryLoop:
 	for retryCount := 1; retryCount <= 100; retryCount++ {
 		select {
@@ -258,24 +315,51 @@ ProcessRetryLoop:
 			var err error
 			switch task.TaskType {
 			case persistence.TransferTaskTypeActivityTask:
-				err = t.processActivityTask(task)
+				err = t.processActivityTask(ctx, task)
 			case persistence.TransferTaskTypeDecisionTask:
-				err = t.processDecisionTask(task)
+				err = t.processDecisionTask(ctx, task)
 			case persistence.TransferTaskTypeDeleteExecution:
-				err = t.processDeleteExecution(task)
+				err = t.processDeleteExecution(ctx, task)
 			case persistence.TransferTaskTypeCancelExecution:
-				err = t.processCancelExecution(task)
+				err = t.processCancelExecution(ctx, task)
 			case persistence.TransferTaskTypeStartChildExecution:
-				err = t.processStartChildExecution(task)
+				err = t.processStartChildExecution(ctx, task)
+			case persistence.TransferTaskTypeRecordWorkflowStarted:
+				err = t.processRecordWorkflowStarted(ctx, task)
+			case persistence.TransferTaskTypeActivityTaskContinuation:
+				err = t.processActivityTaskContinuation(ctx, task)
 			}
 
 			if err != nil {
+				if matchingErr, ok := err.(*matchingTaskError); ok {
+					t.rescheduleMatchingTask(ctx, task, matchingErr.cause)
+					return
+				}
+
+				if retryAfter, ok := getThrottleRetryAfter(err); ok {
+					// back pressure is not a task failure, it doesn't count against the retries
+					retryCount--
+					t.throttle(retryAfter)
+					throttleCount++
+					if throttleCount >= t.config.TransferThrottleMaxRetries {
+						// matching stays saturated, free the worker instead of retrying forever
+						t.rescheduleMatchingTask(ctx, task, err)
+						return
+					}
+					select {
+					case <-t.shutdownCh:
+					case <-time.After(retryAfter):
+					}
+					continue ProcessRetryLoop
+				}
+
 				t.logger.WithField("error", err).Warn("Processor failed to create task")
 				backoff := time.Duration(retryCount * 100)
 				time.Sleep(backoff * time.Millisecond)
 				continue ProcessRetryLoop
 			}
 
+			t.clearMatchingAttempts(task.TaskID)
 			t.ackMgr.completeTask(task.TaskID)
 			return
 		}
@@ -285,7 +369,69 @@ ProcessRetryLoop:
 	t.logger.Fatalf("Retry count exceeded for transfer taskID: %v", task.TaskID)
 }
 
-func (t *transferQueueProcessorImpl) processActivityTask(task *persistence.TransferTaskInfo) error {
+// rescheduleMatchingTask reschedules a decision or activity task which failed to be added to matching after a backoff,
+// freeing the task worker for the tasks of other task lists meanwhile.  The task is parked in the dead letter queue of
+// the shard once it failed TransferMatchingMaxAttempts times, so the ack level isn't blocked on it.
+func (t *transferQueueProcessorImpl) rescheduleMatchingTask(ctx context.Context, task *persistence.TransferTaskInfo,
+	err error) {
+	t.metricsClient.IncCounter(metrics.HistoryProcessTransferTasksScope, metrics.TransferTasksMatchingFailedCounter)
+	t.matchingLock.Lock()
+	t.matchingAttempts[task.TaskID]++
+	attempt := t.matchingAttempts[task.TaskID]
+	t.matchingLock.Unlock()
+
+	if maxAttempts := t.config.TransferMatchingMaxAttempts; maxAttempts > 0 && attempt >= maxAttempts {
+		err := t.shard.PutTransferDLQTask(ctx, &persistence.PutTransferDLQTaskRequest{TaskInfo: task})
+		if err == nil {
+			t.logger.Warnf("Parked transfer task %v for task list '%v' after %v failed attempts to add it to matching.",
+				task.TaskID, task.TaskList, attempt)
+			t.metricsClient.IncCounter(metrics.HistoryProcessTransferTasksScope, metrics.TransferTasksParkedCounter)
+			t.clearMatchingAttempts(task.TaskID)
+			t.ackMgr.completeTask(task.TaskID)
+			return
+		}
+		t.logger.WithField("error", err).Warnf("Processor unable to park transfer task %v.", task.TaskID)
+	}
+
+	backoff := t.matchingRetryBackoff(attempt)
+	t.logger.WithField("error", err).Warnf("Failed to add transfer task %v to matching, rescheduled in %v.",
+		task.TaskID, backoff)
+	time.AfterFunc(backoff, func() {
+		select {
+		case t.rescheduleCh <- task:
+		case <-t.shutdownCh:
+		}
+	})
+}
+
+// matchingRetryBackoff returns how long a task is rescheduled for after its attempt failed to add it to matching
+func (t *transferQueueProcessorImpl) matchingRetryBackoff(attempt int) time.Duration {
+	backoff := t.config.TransferMatchingRetryInitialInterval
+	for i := 1; i < attempt && backoff < t.config.TransferMatchingRetryMaxInterval; i++ {
+		backoff *= 2
+	}
+	return minDuration(backoff, t.config.TransferMatchingRetryMaxInterval)
+}
+
+func (t *transferQueueProcessorImpl) clearMatchingAttempts(taskID int64) {
+	t.matchingLock.Lock()
+	delete(t.matchingAttempts, taskID)
+	t.matchingLock.Unlock()
+}
+
+// throttle stops the dispatch of transfer tasks of the shard for the duration
+func (t *transferQueueProcessorImpl) throttle(duration time.Duration) {
+	t.metricsClient.IncCounter(metrics.HistoryProcessTransferTasksScope, metrics.TransferTasksThrottledCounter)
+	until := time.Now().Add(duration).UnixNano()
+	for {
+		current := atomic.LoadInt64(&t.throttledUntil)
+		if current >= until || atomic.CompareAndSwapInt64(&t.throttledUntil, current, until) {
+			return
+		}
+	}
+}
+
+func (t *transferQueueProcessorImpl) processActivityTask(ctx context.Context, task *persistence.TransferTaskInfo) error {
 	var err error
 	domainID := task.DomainID
 	targetDomainID := task.TargetDomainID
@@ -295,14 +441,16 @@ func (t *transferQueueProcessorImpl) processActivityTask(task *persistence.Trans
 		Name: &task.TaskList,
 	}
 
-	context, release, err := t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err != nil {
 		return err
 	}
 
 	var mb *mutableStateBuilder
-	mb, err = context.loadWorkflowExecution()
+	mb, err = context.loadWorkflowExecution(ctx)
 	timeout := int32(0)
+	priority := int32(0)
+	sessionID := ""
 	if err != nil {
 		release()
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
@@ -314,75 +462,221 @@ func (t *transferQueueProcessorImpl) processActivityTask(task *persistence.Trans
 
 	if ai, found := mb.GetActivityInfo(task.ScheduleID); found {
 		timeout = ai.ScheduleToStartTimeout
+		priority = ai.Priority
+		sessionID = ai.SessionID
 	} else {
 		logging.LogDuplicateTransferTaskEvent(t.logger, persistence.TransferTaskTypeActivityTask, task.TaskID, task.ScheduleID)
 	}
 	release()
 
 	if timeout != 0 {
-		err = t.matchingClient.AddActivityTask(nil, &m.AddActivityTaskRequest{
+		addRequest := &m.AddActivityTaskRequest{
 			DomainUUID:                    common.StringPtr(targetDomainID),
 			SourceDomainUUID:              common.StringPtr(domainID),
 			Execution:                     &execution,
 			TaskList:                      taskList,
 			ScheduleId:                    &task.ScheduleID,
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(timeout),
+		}
+		if priority != 0 {
+			addRequest.Priority = common.Int32Ptr(priority)
+		}
+		if sessionID != "" {
+			addRequest.SessionId = common.StringPtr(sessionID)
+		}
+		err = t.matchingClient.AddActivityTask(nil, addRequest)
+	}
+	return newMatchingTaskError(err)
+}
+
+// processActivityTaskContinuation writes the transfer and timer tasks of the next activities, starting with the one
+// scheduled at the schedule ID of the task, scheduled by a decision whose activities did not all get their tasks with
+// the update completing the decision.  Another continuation task is written with them while activities remain.
+func (t *transferQueueProcessorImpl) processActivityTaskContinuation(ctx context.Context,
+	task *persistence.TransferTaskInfo) error {
+	execution := workflow.WorkflowExecution{WorkflowId: common.StringPtr(task.WorkflowID),
+		RunId: common.StringPtr(task.RunID)}
+
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, task.DomainID, execution)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+Update_History_Loop:
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		msBuilder, err := context.loadWorkflowExecution(ctx)
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); ok {
+				return nil
+			}
+			return err
+		}
+		if !msBuilder.isWorkflowExecutionRunning() {
+			return nil
+		}
+
+		transferTasks, timerTasks, err := t.createContinuedActivityTasks(context, msBuilder, task.DomainID,
+			task.ScheduleID)
+		if err != nil {
+			return err
+		}
+		if len(transferTasks) == 0 {
+			return nil
+		}
+
+		transactionID, err := t.shard.GetNextTransferTaskID()
+		if err != nil {
+			return err
+		}
+		if err := context.updateWorkflowExecution(ctx, transferTasks, timerTasks, transactionID); err != nil {
+			if err == ErrConflict {
+				continue Update_History_Loop
+			}
+			return err
+		}
+		return nil
+	}
+
+	return ErrMaxAttemptsExceeded
+}
+
+// createContinuedActivityTasks returns the transfer and timer tasks of up to ActivityTasksPerUpdate activities
+// scheduled from scheduleID on whose dispatch was deferred, along with the continuation task for the rest.  The
+// activities are no longer marked as deferred once they get their tasks, so activities scheduled by later decisions
+// and a continuation task processed again are left alone.
+func (t *transferQueueProcessorImpl) createContinuedActivityTasks(context *workflowExecutionContext,
+	msBuilder *mutableStateBuilder, domainID string, scheduleID int64) ([]persistence.Task, []persistence.Task, error) {
+	var scheduleIDs []int64
+	for id, ai := range msBuilder.pendingActivityInfoIDs {
+		if id >= scheduleID && ai.DispatchDeferred && ai.StartedID == emptyEventID {
+			scheduleIDs = append(scheduleIDs, id)
+		}
+	}
+	sort.Slice(scheduleIDs, func(i, j int) bool { return scheduleIDs[i] < scheduleIDs[j] })
+
+	var transferTasks, timerTasks []persistence.Task
+	for i, id := range scheduleIDs {
+		if limit := t.config.ActivityTasksPerUpdate; limit > 0 && i >= limit {
+			transferTasks = append(transferTasks, &persistence.ActivityTaskContinuationTask{ScheduleID: id})
+			break
+		}
+
+		ai := msBuilder.pendingActivityInfoIDs[id]
+		scheduledEvent, ok := msBuilder.GetActivityScheduledEvent(id)
+		if !ok {
+			return nil, nil, &workflow.InternalServiceError{Message: "Unable to get activity schedule event."}
+		}
+		attributes := scheduledEvent.GetActivityTaskScheduledEventAttributes()
+		targetDomainID := domainID
+		if attributes.IsSetDomain() {
+			info, _, err := t.domainCache.GetDomain(attributes.GetDomain())
+			if err != nil {
+				return nil, nil, err
+			}
+			targetDomainID = info.ID
+		}
+
+		transferTasks = append(transferTasks, &persistence.ActivityTask{
+			DomainID:   targetDomainID,
+			TaskList:   attributes.GetTaskList().GetName(),
+			ScheduleID: id,
 		})
+		msBuilder.markActivityDispatched(ai)
+		// Timeouts run from the time the activity was scheduled, not from the time its tasks are written
+		scheduledTime := time.Unix(0, scheduledEvent.GetTimestamp())
+		for _, timeout := range []struct {
+			timeoutType workflow.TimeoutType
+			seconds     int32
+		}{
+			{workflow.TimeoutType_SCHEDULE_TO_CLOSE, ai.ScheduleToCloseTimeout},
+			{workflow.TimeoutType_SCHEDULE_TO_START, ai.ScheduleToStartTimeout},
+		} {
+			timeoutTask, err := context.tBuilder.AddActivityTimeoutTask(id, timeout.timeoutType, timeout.seconds,
+				&scheduledTime)
+			if err != nil {
+				return nil, nil, err
+			}
+			if timeoutTask != nil {
+				timerTasks = append(timerTasks, timeoutTask)
+			}
+		}
 	}
-	return err
+	return transferTasks, timerTasks, nil
 }
 
-func (t *transferQueueProcessorImpl) processDecisionTask(task *persistence.TransferTaskInfo) error {
-	var err error
+func (t *transferQueueProcessorImpl) processDecisionTask(ctx context.Context, task *persistence.TransferTaskInfo) error {
 	domainID := task.DomainID
 	execution := workflow.WorkflowExecution{WorkflowId: common.StringPtr(task.WorkflowID),
 		RunId: common.StringPtr(task.RunID)}
 
-	if task.ScheduleID == firstEventID+1 {
-		err = t.recordWorkflowExecutionStarted(execution, task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err != nil {
+		return err
 	}
 
+	mb, err := context.loadWorkflowExecution(ctx)
 	if err != nil {
+		release()
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
 			// this could happen if this is a duplicate processing of the task, and the execution has already completed.
 			return nil
 		}
 		return err
 	}
+	buildID := mb.executionInfo.BuildID
+	release()
 
 	taskList := &workflow.TaskList{
 		Name: &task.TaskList,
 	}
-	err = t.matchingClient.AddDecisionTask(nil, &m.AddDecisionTaskRequest{
+	addRequest := &m.AddDecisionTaskRequest{
 		DomainUUID: common.StringPtr(domainID),
 		Execution:  &execution,
 		TaskList:   taskList,
 		ScheduleId: &task.ScheduleID,
-	})
+	}
+	if buildID != "" {
+		addRequest.BuildId = common.StringPtr(buildID)
+	}
+	err = t.matchingClient.AddDecisionTask(nil, addRequest)
+
+	return newMatchingTaskError(err)
+}
 
+func (t *transferQueueProcessorImpl) processRecordWorkflowStarted(ctx context.Context,
+	task *persistence.TransferTaskInfo) error {
+	execution := workflow.WorkflowExecution{WorkflowId: common.StringPtr(task.WorkflowID),
+		RunId: common.StringPtr(task.RunID)}
+
+	err := t.recordWorkflowExecutionStarted(ctx, execution, task)
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			// this could happen if this is a duplicate processing of the task, and the execution has already completed.
+			return nil
+		}
+	}
 	return err
 }
 
-func (t *transferQueueProcessorImpl) processDeleteExecution(task *persistence.TransferTaskInfo) error {
+func (t *transferQueueProcessorImpl) processDeleteExecution(ctx context.Context, task *persistence.TransferTaskInfo) error {
 	var err error
 	domainID := task.DomainID
 	execution := workflow.WorkflowExecution{WorkflowId: common.StringPtr(task.WorkflowID),
 		RunId: common.StringPtr(task.RunID)}
 
-	context, release, err := t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	defer release()
 	if err != nil {
 		return err
 	}
 
-	// TODO: We need to keep completed executions for auditing purpose.  Need a design for keeping them around
-	// for visibility purpose.
 	var mb *mutableStateBuilder
-	mb, err = context.loadWorkflowExecution()
+	mb, err = context.loadWorkflowExecution(ctx)
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
-			// this could happen if this is a duplicate processing of the task, but the mutable state was
-			// already deleted on a previous attempt to process the task.
+			// this could happen if this is a duplicate processing of the task, but the retention of the execution
+			// already expired and its mutable state was deleted.
 			return nil
 		}
 		return err
@@ -391,7 +685,17 @@ func (t *transferQueueProcessorImpl) processDeleteExecution(task *persistence.Tr
 	// Communicate the result to parent execution if this is Child Workflow execution
 	if mb.hasParentExecution() && mb.executionInfo.CloseStatus != persistence.WorkflowCloseStatusContinuedAsNew {
 		completionEvent, _ := mb.GetCompletionEvent()
-		err = t.historyClient.RecordChildExecutionCompleted(nil, &history.RecordChildExecutionCompletedRequest{
+		if completionEvent != nil && completionEvent.PayloadReference != nil {
+			// The parent gets a copy of the offloaded result, it outlives the history of the child
+			parentEvent := *completionEvent
+			parentEvent.PayloadReference, err = t.payloadBlobs.copy(ctx, completionEvent.PayloadReference,
+				mb.executionInfo.ParentDomainID, task.RunID)
+			if err != nil {
+				return err
+			}
+			completionEvent = &parentEvent
+		}
+		recordRequest := &history.RecordChildExecutionCompletedRequest{
 			DomainUUID: common.StringPtr(mb.executionInfo.ParentDomainID),
 			WorkflowExecution: &workflow.WorkflowExecution{
 				WorkflowId: common.StringPtr(mb.executionInfo.ParentWorkflowID),
@@ -403,6 +707,9 @@ func (t *transferQueueProcessorImpl) processDeleteExecution(task *persistence.Tr
 				RunId:      common.StringPtr(task.RunID),
 			},
 			CompletionEvent: completionEvent,
+		}
+		err = callHistoryServiceWithRetry(func() error {
+			return t.historyClient.RecordChildExecutionCompleted(nil, recordRequest)
 		})
 
 		// Check to see if the error is non-transient, in which case reset the error and continue with processing
@@ -428,25 +735,36 @@ func (t *transferQueueProcessorImpl) processDeleteExecution(task *persistence.Tr
 		retentionSeconds = int64(domainConfig.Retention) * 24 * 60 * 60
 	}
 
-	err = t.visibilityManager.RecordWorkflowExecutionClosed(&persistence.RecordWorkflowExecutionClosedRequest{
+	err = t.visibilityManager.RecordWorkflowExecutionClosed(ctx, &persistence.RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       task.DomainID,
 		Execution:        execution,
 		WorkflowTypeName: mb.executionInfo.WorkflowTypeName,
 		StartTimestamp:   mb.executionInfo.StartTimestamp.UnixNano(),
 		CloseTimestamp:   mb.executionInfo.LastUpdatedTimestamp.UnixNano(),
 		Status:           getWorkflowExecutionCloseStatus(mb.executionInfo.CloseStatus),
+		CloseReason:      getWorkflowExecutionCloseReason(mb),
 		RetentionSeconds: retentionSeconds,
 	})
 	if err != nil {
 		return err
 	}
 
-	err = context.deleteWorkflowExecution()
+	// Keep the mutable state and history of the closed execution until the retention of the domain expires
+	deleteTask, err := context.tBuilder.AddDeleteHistoryEventTask(mb.executionInfo.LastUpdatedTimestamp,
+		time.Duration(retentionSeconds)*time.Second)
+	if err != nil {
+		return err
+	}
+	transactionID, err := t.shard.GetNextTransferTaskID()
+	if err != nil {
+		return err
+	}
+	err = context.updateWorkflowExecution(ctx, nil, []persistence.Task{deleteTask}, transactionID)
 
 	return err
 }
 
-func (t *transferQueueProcessorImpl) processCancelExecution(task *persistence.TransferTaskInfo) error {
+func (t *transferQueueProcessorImpl) processCancelExecution(ctx context.Context, task *persistence.TransferTaskInfo) error {
 	var err error
 	domainID := task.DomainID
 	targetDomainID := task.TargetDomainID
@@ -455,13 +773,14 @@ func (t *transferQueueProcessorImpl) processCancelExecution(task *persistence.Tr
 
 	var context *workflowExecutionContext
 	var release releaseWorkflowExecutionFunc
-	context, release, err = t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	context, release, err = t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	defer release()
 	if err != nil {
 		return err
 	}
 	// Load workflow execution.
-	_, err = context.loadWorkflowExecution()
+	var msBuilder *mutableStateBuilder
+	msBuilder, err = context.loadWorkflowExecution(ctx)
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
 			// this could happen if this is a duplicate processing of the task, and the execution has already completed.
@@ -470,6 +789,23 @@ func (t *transferQueueProcessorImpl) processCancelExecution(task *persistence.Tr
 		return err
 	}
 
+	// The request cancellation is removed from mutable state once its outcome is recorded, so a task processed
+	// again after it was delivered doesn't record the outcome twice
+	if _, isPending := msBuilder.GetRequestCancelInfo(task.ScheduleID); !isPending {
+		// Request cancellations initiated before they were kept in mutable state have no info, the history tells
+		// whether their outcome is recorded already
+		isRecorded, err := t.isRequestCancelOutcomeRecorded(ctx, task)
+		if err != nil {
+			return err
+		}
+		if isRecorded {
+			logging.LogDuplicateTransferTaskEvent(t.logger, persistence.TransferTaskTypeCancelExecution, task.TaskID,
+				task.ScheduleID)
+			return nil
+		}
+		msBuilder.RestorePendingRequestCancel(task.ScheduleID, uuid.New())
+	}
+
 	cancelRequest := &history.RequestCancelWorkflowExecutionRequest{
 		DomainUUID: common.StringPtr(targetDomainID),
 		CancelRequest: &workflow.RequestCancelWorkflowExecutionRequest{
@@ -477,7 +813,7 @@ func (t *transferQueueProcessorImpl) processCancelExecution(task *persistence.Tr
 				WorkflowId: common.StringPtr(task.TargetWorkflowID),
 				RunId:      common.StringPtr(task.TargetRunID),
 			},
-			Identity: common.StringPtr("history-service"),
+			Identity: common.StringPtr(historyServiceIdentity),
 		},
 		ExternalInitiatedEventId: common.Int64Ptr(task.ScheduleID),
 		ExternalWorkflowExecution: &workflow.WorkflowExecution{
@@ -486,7 +822,7 @@ func (t *transferQueueProcessorImpl) processCancelExecution(task *persistence.Tr
 		},
 	}
 
-	err = context.requestExternalCancelWorkflowExecutionWithRetry(
+	err = context.requestExternalCancelWorkflowExecutionWithRetry(ctx,
 		t.historyClient,
 		cancelRequest,
 		task.ScheduleID)
@@ -494,7 +830,61 @@ func (t *transferQueueProcessorImpl) processCancelExecution(task *persistence.Tr
 	return err
 }
 
-func (t *transferQueueProcessorImpl) processStartChildExecution(task *persistence.TransferTaskInfo) error {
+// isRequestCancelOutcomeRecorded checks the history of the execution for the outcome of the request cancellation of
+// the task, it is only needed for tasks which have no request cancellation in mutable state.
+func (t *transferQueueProcessorImpl) isRequestCancelOutcomeRecorded(ctx context.Context,
+	task *persistence.TransferTaskInfo) (bool, error) {
+	request := &persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID: task.DomainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(task.WorkflowID),
+			RunId:      common.StringPtr(task.RunID),
+		},
+		FirstEventID:  task.ScheduleID + 1,
+		NextEventID:   math.MaxInt64,
+		PageSize:      transferCancelHistoryPageSize,
+		NextPageToken: []byte{},
+	}
+	for {
+		response, err := t.shard.GetHistoryManager().GetWorkflowExecutionHistory(ctx, request)
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); ok {
+				return false, nil
+			}
+			return false, err
+		}
+		for i := range response.Events {
+			serializer, err := t.hSerializerFactory.Get(response.Events[i].EncodingType)
+			if err != nil {
+				return false, err
+			}
+			batch, err := serializer.Deserialize(&response.Events[i])
+			if err != nil {
+				return false, err
+			}
+			for _, event := range batch.Events {
+				switch event.GetEventType() {
+				case workflow.EventType_ExternalWorkflowExecutionCancelRequested:
+					if event.ExternalWorkflowExecutionCancelRequestedEventAttributes.GetInitiatedEventId() ==
+						task.ScheduleID {
+						return true, nil
+					}
+				case workflow.EventType_RequestCancelExternalWorkflowExecutionFailed:
+					if event.RequestCancelExternalWorkflowExecutionFailedEventAttributes.GetInitiatedEventId() ==
+						task.ScheduleID {
+						return true, nil
+					}
+				}
+			}
+		}
+		if len(response.NextPageToken) == 0 {
+			return false, nil
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+func (t *transferQueueProcessorImpl) processStartChildExecution(ctx context.Context, task *persistence.TransferTaskInfo) error {
 	var err error
 	domainID := task.DomainID
 	targetDomainID := task.TargetDomainID
@@ -503,7 +893,7 @@ func (t *transferQueueProcessorImpl) processStartChildExecution(task *persistenc
 
 	var context *workflowExecutionContext
 	var release releaseWorkflowExecutionFunc
-	context, release, err = t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	context, release, err = t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	defer release()
 	if err != nil {
 		return err
@@ -511,7 +901,7 @@ func (t *transferQueueProcessorImpl) processStartChildExecution(task *persistenc
 
 	// First step is to load workflow execution so we can retrieve the initiated event
 	var msBuilder *mutableStateBuilder
-	msBuilder, err = context.loadWorkflowExecution()
+	msBuilder, err = context.loadWorkflowExecution(ctx)
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
 			// this could happen if this is a duplicate processing of the task, and the execution has already completed.
@@ -527,6 +917,13 @@ func (t *transferQueueProcessorImpl) processStartChildExecution(task *persistenc
 		attributes := initiatedEvent.GetStartChildWorkflowExecutionInitiatedEventAttributes()
 		if ok && ci.StartedID == emptyEventID {
 			// Found pending child execution and it is not marked as started
+			// The child gets a copy of the offloaded input, it outlives the history of the parent
+			var payloadReference *workflow.PayloadReference
+			payloadReference, err = t.payloadBlobs.copy(ctx, initiatedEvent.PayloadReference, targetDomainID,
+				ci.CreateRequestID)
+			if err != nil {
+				return err
+			}
 			// Let's try and start the child execution
 			startRequest := &history.StartWorkflowExecutionRequest{
 				DomainUUID: common.StringPtr(targetDomainID),
@@ -535,10 +932,12 @@ func (t *transferQueueProcessorImpl) processStartChildExecution(task *persistenc
 					WorkflowType: attributes.GetWorkflowType(),
 					TaskList:     attributes.GetTaskList(),
 					Input:        attributes.GetInput(),
+					Header:       attributes.Header,
 					ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(attributes.GetExecutionStartToCloseTimeoutSeconds()),
 					TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(attributes.GetTaskStartToCloseTimeoutSeconds()),
 					// Use the same request ID to dedupe StartWorkflowExecution calls
-					RequestId: common.StringPtr(ci.CreateRequestID),
+					RequestId:        common.StringPtr(ci.CreateRequestID),
+					PayloadReference: payloadReference,
 				},
 				ParentExecutionInfo: &history.ParentExecutionInfo{
 					DomainUUID: common.StringPtr(domainID),
@@ -551,7 +950,15 @@ func (t *transferQueueProcessorImpl) processStartChildExecution(task *persistenc
 			}
 
 			var startResponse *workflow.StartWorkflowExecutionResponse
-			startResponse, err = t.historyClient.StartWorkflowExecution(nil, startRequest)
+			err = callHistoryServiceWithRetry(func() error {
+				var startErr error
+				startResponse, startErr = t.historyClient.StartWorkflowExecution(nil, startRequest)
+				return startErr
+			})
+			if duplicateResponse, ok := getDuplicateStartResponse(err, ci.CreateRequestID); ok {
+				// An earlier attempt started the child, e.g. before the call timed out
+				startResponse, err = duplicateResponse, nil
+			}
 			if err != nil {
 				t.logger.Debugf("Failed to start child workflow execution. Error: %v", err)
 
@@ -559,7 +966,11 @@ func (t *transferQueueProcessorImpl) processStartChildExecution(task *persistenc
 				// event and complete transfer task by setting the err = nil
 				switch err.(type) {
 				case *workflow.WorkflowExecutionAlreadyStartedError:
-					err = t.recordStartChildExecutionFailed(task, context, attributes)
+					err = t.recordStartChildExecutionFailed(ctx, task, context, attributes,
+						workflow.ChildWorkflowExecutionFailedCause_WORKFLOW_ALREADY_RUNNING)
+				case *workflow.LimitExceededError:
+					err = t.recordStartChildExecutionFailed(ctx, task, context, attributes,
+						workflow.ChildWorkflowExecutionFailedCause_OPEN_EXECUTION_LIMIT_EXCEEDED)
 				}
 				return err
 			}
@@ -568,46 +979,52 @@ func (t *transferQueueProcessorImpl) processStartChildExecution(task *persistenc
 				attributes.GetWorkflowId(), startResponse.GetRunId())
 
 			// Child execution is successfully started, record ChildExecutionStartedEvent in parent execution
-			err = t.recordChildExecutionStarted(task, context, attributes, startResponse.GetRunId())
+			err = t.recordChildExecutionStarted(ctx, task, context, attributes, startResponse.GetRunId())
 
 			if err != nil {
 				return err
 			}
 			// Finally create first decision task for Child execution so it is really started
-			err = t.historyClient.ScheduleDecisionTask(nil, &history.ScheduleDecisionTaskRequest{
-				DomainUUID: common.StringPtr(targetDomainID),
-				WorkflowExecution: &workflow.WorkflowExecution{
-					WorkflowId: common.StringPtr(task.TargetWorkflowID),
-					RunId:      common.StringPtr(startResponse.GetRunId()),
-				},
+			err = t.scheduleChildDecisionTask(targetDomainID, &workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr(task.TargetWorkflowID),
+				RunId:      common.StringPtr(startResponse.GetRunId()),
 			})
 		} else {
 			// ChildExecution already started, just create DecisionTask and complete transfer task
 			startedEvent, _ := msBuilder.GetChildExecutionStartedEvent(initiatedEventID)
 			startedAttributes := startedEvent.GetChildWorkflowExecutionStartedEventAttributes()
-			err = t.historyClient.ScheduleDecisionTask(nil, &history.ScheduleDecisionTaskRequest{
-				DomainUUID:        common.StringPtr(targetDomainID),
-				WorkflowExecution: startedAttributes.GetWorkflowExecution(),
-			})
+			err = t.scheduleChildDecisionTask(targetDomainID, startedAttributes.GetWorkflowExecution())
 		}
 	}
 
 	return err
 }
 
-func (t *transferQueueProcessorImpl) recordWorkflowExecutionStarted(
+func (t *transferQueueProcessorImpl) scheduleChildDecisionTask(targetDomainID string,
+	childExecution *workflow.WorkflowExecution) error {
+	request := &history.ScheduleDecisionTaskRequest{
+		DomainUUID:        common.StringPtr(targetDomainID),
+		WorkflowExecution: childExecution,
+	}
+
+	return callHistoryServiceWithRetry(func() error {
+		return t.historyClient.ScheduleDecisionTask(nil, request)
+	})
+}
+
+func (t *transferQueueProcessorImpl) recordWorkflowExecutionStarted(ctx context.Context,
 	execution workflow.WorkflowExecution, task *persistence.TransferTaskInfo) error {
-	context, release, err := t.cache.getOrCreateWorkflowExecution(task.DomainID, execution)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, task.DomainID, execution)
 	if err != nil {
 		return err
 	}
 	defer release()
-	mb, err := context.loadWorkflowExecution()
+	mb, err := context.loadWorkflowExecution(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = t.visibilityManager.RecordWorkflowExecutionStarted(&persistence.RecordWorkflowExecutionStartedRequest{
+	err = t.visibilityManager.RecordWorkflowExecutionStarted(ctx, &persistence.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       task.DomainID,
 		Execution:        execution,
 		WorkflowTypeName: mb.executionInfo.WorkflowTypeName,
@@ -617,11 +1034,11 @@ func (t *transferQueueProcessorImpl) recordWorkflowExecutionStarted(
 	return err
 }
 
-func (t *transferQueueProcessorImpl) recordChildExecutionStarted(task *persistence.TransferTaskInfo,
+func (t *transferQueueProcessorImpl) recordChildExecutionStarted(ctx context.Context, task *persistence.TransferTaskInfo,
 	context *workflowExecutionContext, initiatedAttributes *workflow.StartChildWorkflowExecutionInitiatedEventAttributes,
 	runID string) error {
 
-	return t.updateWorkflowExecution(task.DomainID, context, true,
+	return t.updateWorkflowExecution(ctx, task.DomainID, context, true,
 		func(msBuilder *mutableStateBuilder) error {
 			if !msBuilder.isWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
@@ -644,11 +1061,12 @@ func (t *transferQueueProcessorImpl) recordChildExecutionStarted(task *persisten
 		})
 }
 
-func (t *transferQueueProcessorImpl) recordStartChildExecutionFailed(task *persistence.TransferTaskInfo,
+func (t *transferQueueProcessorImpl) recordStartChildExecutionFailed(ctx context.Context, task *persistence.TransferTaskInfo,
 	context *workflowExecutionContext,
-	initiatedAttributes *workflow.StartChildWorkflowExecutionInitiatedEventAttributes) error {
+	initiatedAttributes *workflow.StartChildWorkflowExecutionInitiatedEventAttributes,
+	cause workflow.ChildWorkflowExecutionFailedCause) error {
 
-	return t.updateWorkflowExecution(task.DomainID, context, true,
+	return t.updateWorkflowExecution(ctx, task.DomainID, context, true,
 		func(msBuilder *mutableStateBuilder) error {
 			if !msBuilder.isWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
@@ -660,18 +1078,17 @@ func (t *transferQueueProcessorImpl) recordStartChildExecutionFailed(task *persi
 				return &workflow.EntityNotExistsError{Message: "Pending child execution not found."}
 			}
 
-			msBuilder.AddStartChildWorkflowExecutionFailedEvent(initiatedEventID,
-				workflow.ChildWorkflowExecutionFailedCause_WORKFLOW_ALREADY_RUNNING, initiatedAttributes)
+			msBuilder.AddStartChildWorkflowExecutionFailedEvent(initiatedEventID, cause, initiatedAttributes)
 
 			return nil
 		})
 }
 
-func (t *transferQueueProcessorImpl) updateWorkflowExecution(domainID string, context *workflowExecutionContext,
+func (t *transferQueueProcessorImpl) updateWorkflowExecution(ctx context.Context, domainID string, context *workflowExecutionContext,
 	createDecisionTask bool, action func(builder *mutableStateBuilder) error) error {
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
-		msBuilder, err1 := context.loadWorkflowExecution()
+		msBuilder, err1 := context.loadWorkflowExecution(ctx)
 		if err1 != nil {
 			return err1
 		}
@@ -701,7 +1118,7 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict then reload
 		// the history and try the operation again.
-		if err := context.updateWorkflowExecution(transferTasks, nil, transactionID); err != nil {
+		if err := context.updateWorkflowExecution(ctx, transferTasks, nil, transactionID); err != nil {
 			if err == ErrConflict {
 				continue Update_History_Loop
 			}
@@ -718,7 +1135,7 @@ func (a *ackManager) readTransferTasks() ([]*persistence.TransferTaskInfo, error
 	a.RLock()
 	rLevel := a.readLevel
 	a.RUnlock()
-	response, err := a.executionMgr.GetTransferTasks(&persistence.GetTransferTasksRequest{
+	response, err := a.executionMgr.GetTransferTasks(context.Background(), &persistence.GetTransferTasksRequest{
 		ReadLevel:    rLevel,
 		MaxReadLevel: a.shard.GetTransferMaxReadLevel(),
 		BatchSize:    transferTaskBatchSize,
@@ -750,10 +1167,15 @@ func (a *ackManager) readTransferTasks() ([]*persistence.TransferTaskInfo, error
 
 func (a *ackManager) completeTask(taskID int64) {
 	a.Lock()
-	if _, ok := a.outstandingTasks[taskID]; ok {
+	acked, ok := a.outstandingTasks[taskID]
+	if ok {
 		a.outstandingTasks[taskID] = true
 	}
 	a.Unlock()
+
+	if ok && !acked {
+		a.shard.TransferTaskProcessed(taskID)
+	}
 }
 
 func (a *ackManager) updateAckLevel() {
@@ -763,7 +1185,7 @@ MoveAckLevelLoop:
 	for current := a.ackLevel + 1; current <= a.readLevel; current++ {
 		if acked, ok := a.outstandingTasks[current]; ok {
 			if acked {
-				err := a.executionMgr.CompleteTransferTask(&persistence.CompleteTransferTaskRequest{TaskID: current})
+				err := a.shard.CompleteTransferTask(context.Background(), &persistence.CompleteTransferTaskRequest{TaskID: current})
 
 				if err != nil {
 					a.logger.Warnf("Processor unable to complete transfer task '%v': %v", current, err)
@@ -780,6 +1202,11 @@ MoveAckLevelLoop:
 	}
 	a.Unlock()
 
+	// Report how far the ack level trails the last task ID allocated for the shard
+	lag := a.shard.GetTransferMaxReadLevel() - updatedAckLevel
+	a.shard.GetMetricsClient().UpdateGauge(metrics.HistoryProcessTransferTasksScope,
+		metrics.TransferTaskAckLevelLagGauge, float64(lag))
+
 	// Always update ackLevel to detect if the shared is stolen
 	if err := a.shard.UpdateAckLevel(updatedAckLevel); err != nil {
 		logging.LogOperationFailedEvent(a.logger, "Error updating ack level for shard", err)
@@ -787,6 +1214,58 @@ MoveAckLevelLoop:
 
 }
 
+// callHistoryServiceWithRetry delivers a cross-shard request to the history service owning the target execution.
+// The request is made while processing the transfer task persisted along with the source update, so it only needs
+// to be retried until it either succeeds or fails with a non-transient error.  Failing that, the transfer task is
+// redelivered later on.  Requests must be idempotent on the target, or their callers must recognize the errors of
+// a request which already went through, as an attempt can succeed on the target without its response making it back.
+func callHistoryServiceWithRetry(op func() error) error {
+	return backoff.Retry(op, historyServiceOperationRetryPolicy, isHistoryServiceTransientError)
+}
+
+// getDuplicateStartResponse returns the response to a start request which failed because the execution was already
+// started by an earlier attempt of the same request
+func getDuplicateStartResponse(err error, requestID string) (*workflow.StartWorkflowExecutionResponse, bool) {
+	if alreadyStarted, ok := err.(*workflow.WorkflowExecutionAlreadyStartedError); ok &&
+		alreadyStarted.IsSetStartRequestId() && alreadyStarted.GetStartRequestId() == requestID {
+		return &workflow.StartWorkflowExecutionResponse{RunId: alreadyStarted.RunId}, true
+	}
+	return nil, false
+}
+
+// getThrottleRetryAfter returns how long to back off when the error is a throttle carrying a retry-after hint
+func getThrottleRetryAfter(err error) (time.Duration, bool) {
+	if busy, ok := err.(*workflow.ServiceBusyError); ok && busy.RetryAfterMillis != nil {
+		return time.Duration(busy.GetRetryAfterMillis()) * time.Millisecond, true
+	}
+	return 0, false
+}
+
+// newMatchingTaskError wraps the failure to add a task to matching for the task to be rescheduled.  Back pressure from
+// matching is returned as is, it throttles the whole shard instead.
+func newMatchingTaskError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := getThrottleRetryAfter(err); ok {
+		return err
+	}
+	return &matchingTaskError{cause: err}
+}
+
+func (e *matchingTaskError) Error() string {
+	return e.cause.Error()
+}
+
+func isHistoryServiceTransientError(err error) bool {
+	switch err.(type) {
+	case *history.ShardOwnershipLostError, *workflow.ServiceBusyError:
+		return true
+	}
+
+	return common.IsServiceHostFailureError(err)
+}
+
 func minDuration(x, y time.Duration) time.Duration {
 	if x < y {
 		return x
@@ -809,7 +1288,29 @@ func getWorkflowExecutionCloseStatus(status int) workflow.WorkflowExecutionClose
 		return workflow.WorkflowExecutionCloseStatus_CONTINUED_AS_NEW
 	case persistence.WorkflowCloseStatusTimedOut:
 		return workflow.WorkflowExecutionCloseStatus_TIMED_OUT
+	case persistence.WorkflowCloseStatusTerminatedStuck:
+		return workflow.WorkflowExecutionCloseStatus_TERMINATED_STUCK
 	default:
 		panic("Invalid value for enum WorkflowExecutionCloseStatus")
 	}
 }
+
+// getWorkflowExecutionCloseReason returns the reason recorded on the completion event for terminated and failed
+// executions, or an empty string for all other close statuses.
+func getWorkflowExecutionCloseReason(mb *mutableStateBuilder) string {
+	completionEvent, ok := mb.GetCompletionEvent()
+	if !ok {
+		return ""
+	}
+
+	switch completionEvent.GetEventType() {
+	case workflow.EventType_WorkflowExecutionTerminated:
+		return completionEvent.WorkflowExecutionTerminatedEventAttributes.GetReason()
+	case workflow.EventType_WorkflowExecutionTerminatedByOperator:
+		return completionEvent.WorkflowExecutionTerminatedByOperatorEventAttributes.GetReason()
+	case workflow.EventType_WorkflowExecutionFailed:
+		return completionEvent.WorkflowExecutionFailedEventAttributes.GetReason()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"golang.org/x/net/context"
+)
+
+type (
+	transferQueueProcessor2Suite struct {
+		suite.Suite
+		mockShardManager *mocks.ShardManager
+		logger           bark.Logger
+
+		processor          *transferQueueProcessorImpl
+		mockMatchingClient *mocks.MatchingClient
+		mockMetadataMgr    *mocks.MetadataManager
+		mockVisibilityMgr  *mocks.VisibilityManager
+		mockExecutionMgr   *mocks.ExecutionManager
+		mockHistoryMgr     *mocks.HistoryManager
+	}
+)
+
+func TestTransferQueueProcessor2Suite(t *testing.T) {
+	s := new(transferQueueProcessor2Suite)
+	suite.Run(t, s)
+}
+
+func (s *transferQueueProcessor2Suite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+
+	log2 := log.New()
+	log2.Level = log.DebugLevel
+	s.logger = bark.NewLoggerFromLogrus(log2)
+}
+
+func (s *transferQueueProcessor2Suite) SetupTest() {
+	shardID := 0
+	s.mockMatchingClient = &mocks.MatchingClient{}
+	s.mockExecutionMgr = &mocks.ExecutionManager{}
+	s.mockShardManager = &mocks.ShardManager{}
+	s.mockHistoryMgr = &mocks.HistoryManager{}
+	s.mockVisibilityMgr = &mocks.VisibilityManager{}
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+
+	mockShard := &shardContextImpl{
+		shardInfo:             &persistence.ShardInfo{ShardID: shardID, RangeID: 1, TransferAckLevel: 0},
+		taskSequenceNumber:    1,
+		executionManager:      s.mockExecutionMgr,
+		shardManager:          s.mockShardManager,
+		historyMgr:            s.mockHistoryMgr,
+		rangeSize:             defaultRangeSize,
+		maxTaskSequenceNumber: 100000,
+		closeEvents:           newShardEventBus(),
+		logger:                s.logger,
+		metricsClient:         metrics.NewClient(tally.NoopScope, metrics.History),
+		timeSource:            common.NewRealTimeSource(),
+	}
+
+	config := NewConfig()
+	config.ActivityTasksPerUpdate = 2
+	historyCache := newHistoryCache(historyCacheMaxSize, mockShard, s.logger)
+	domainCache := cache.NewDomainCache(s.mockMetadataMgr, s.logger)
+	s.processor = newTransferQueueProcessor(mockShard, s.mockVisibilityMgr, s.mockMatchingClient,
+		&mocks.HistoryClient{}, historyCache, domainCache, nil, config).(*transferQueueProcessorImpl)
+}
+
+func (s *transferQueueProcessor2Suite) TearDownTest() {
+	s.mockShardManager.AssertExpectations(s.T())
+	s.mockMatchingClient.AssertExpectations(s.T())
+	s.mockExecutionMgr.AssertExpectations(s.T())
+	s.mockHistoryMgr.AssertExpectations(s.T())
+	s.mockVisibilityMgr.AssertExpectations(s.T())
+}
+
+func (s *transferQueueProcessor2Suite) TestProcessActivityTaskContinuation() {
+	domainID := "9f1b2c3d-4e5f-4a6b-8c7d-0e1f2a3b4c5d"
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("activity-continuation-test"),
+		RunId: common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6")}
+	taskList := "activity-continuation-queue"
+
+	builder := newMutableStateBuilder(s.logger)
+	builder.AddWorkflowExecutionStartedEvent(domainID, we, &workflow.StartWorkflowExecutionRequest{
+		WorkflowType:                   &workflow.WorkflowType{Name: common.StringPtr("wType")},
+		TaskList:                       common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+		TaskStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+	})
+	decisionScheduledEvent, _ := addDecisionTaskScheduledEvent(builder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(builder, decisionScheduledEvent.GetEventId(), taskList,
+		uuid.New())
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(builder, decisionScheduledEvent.GetEventId(),
+		decisionStartedEvent.GetEventId(), nil, "identity")
+
+	// The decision dispatched its first activity and deferred the three others
+	var scheduleIDs []int64
+	for i := 0; i < 4; i++ {
+		scheduledEvent, ai := addActivityTaskScheduledEvent(builder, decisionCompletedEvent.GetEventId(),
+			fmt.Sprintf("activity%v", i), "activity_type", taskList, nil, 100, 10, 5)
+		ai.DispatchDeferred = i > 0
+		scheduleIDs = append(scheduleIDs, scheduledEvent.GetEventId())
+	}
+	// An activity scheduled by a later decision got its tasks with that decision
+	addActivityTaskScheduledEvent(builder, decisionCompletedEvent.GetEventId(), "activity4", "activity_type",
+		taskList, nil, 100, 10, 5)
+
+	ms := createMutableState(builder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: ms}, nil).Once()
+	var updateRequests []*persistence.UpdateWorkflowExecutionRequest
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Twice().Run(
+		func(args mock.Arguments) {
+			updateRequests = append(updateRequests, args.Get(1).(*persistence.UpdateWorkflowExecutionRequest))
+		})
+
+	task := &persistence.TransferTaskInfo{
+		DomainID:   domainID,
+		WorkflowID: we.GetWorkflowId(),
+		RunID:      we.GetRunId(),
+		TaskID:     100,
+		TaskType:   persistence.TransferTaskTypeActivityTaskContinuation,
+		ScheduleID: scheduleIDs[1],
+	}
+	err := s.processor.processActivityTaskContinuation(context.Background(), task)
+	s.Nil(err)
+	s.Equal(1, len(updateRequests))
+	transferTasks := updateRequests[0].TransferTasks
+	s.Equal(3, len(transferTasks))
+	s.Equal(scheduleIDs[1], transferTasks[0].(*persistence.ActivityTask).ScheduleID)
+	s.Equal(scheduleIDs[2], transferTasks[1].(*persistence.ActivityTask).ScheduleID)
+	s.Equal(scheduleIDs[3], transferTasks[2].(*persistence.ActivityTaskContinuationTask).ScheduleID)
+	s.Equal(4, len(updateRequests[0].TimerTasks))
+	s.Equal(2, len(updateRequests[0].UpsertActivityInfos))
+	for _, ai := range updateRequests[0].UpsertActivityInfos {
+		s.False(ai.DispatchDeferred)
+	}
+
+	// The last deferred activity gets its tasks from the continuation, the activity of the later decision is left
+	// alone
+	task.ScheduleID = scheduleIDs[3]
+	err = s.processor.processActivityTaskContinuation(context.Background(), task)
+	s.Nil(err)
+	s.Equal(2, len(updateRequests))
+	transferTasks = updateRequests[1].TransferTasks
+	s.Equal(1, len(transferTasks))
+	s.Equal(scheduleIDs[3], transferTasks[0].(*persistence.ActivityTask).ScheduleID)
+	s.Equal(2, len(updateRequests[1].TimerTasks))
+
+	// Processing a continuation task again writes no tasks for activities which already got theirs
+	err = s.processor.processActivityTaskContinuation(context.Background(), task)
+	s.Nil(err)
+	task.ScheduleID = scheduleIDs[1]
+	err = s.processor.processActivityTaskContinuation(context.Background(), task)
+	s.Nil(err)
+	s.Equal(2, len(updateRequests))
+}
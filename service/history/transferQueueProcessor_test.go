@@ -25,10 +25,12 @@ import (
 	"testing"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/uber-common/bark"
+	h "github.com/uber/cadence/.gen/go/history"
 	m "github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
@@ -71,7 +73,7 @@ func (s *transferQueueProcessorSuite) SetupSuite() {
 	s.mockMetadataMgr = &mocks.MetadataManager{}
 	historyCache := newHistoryCache(historyCacheMaxSize, s.ShardContext, s.logger)
 	domainCache := cache.NewDomainCache(s.mockMetadataMgr, s.logger)
-	s.processor = newTransferQueueProcessor(s.ShardContext, s.mockVisibilityMgr, s.mockMatching, s.mockHistoryClient, historyCache, domainCache).(*transferQueueProcessorImpl)
+	s.processor = newTransferQueueProcessor(s.ShardContext, s.mockVisibilityMgr, s.mockMatching, s.mockHistoryClient, historyCache, domainCache, nil, NewConfig()).(*transferQueueProcessorImpl)
 }
 
 func (s *transferQueueProcessorSuite) TearDownSuite() {
@@ -92,16 +94,14 @@ func (s *transferQueueProcessorSuite) TestSingleDecisionTask() {
 	s.Nil(err0, "No error expected.")
 	s.NotEmpty(task0, "Expected non empty task identifier.")
 
-	tasksCh := make(chan *persistence.TransferTaskInfo, 10)
-	s.processor.processTransferTasks(tasksCh)
+	scheduler := newDomainTaskScheduler(10, func(string) int { return defaultDomainTaskWeight })
+	s.processor.processTransferTasks(scheduler)
+	tasksCh := drainDomainTaskScheduler(scheduler)
 workerPump:
 	for {
 		select {
 		case task := <-tasksCh:
 			s.mockMatching.On("AddDecisionTask", mock.Anything, createAddRequestFromTask(task, 0)).Once().Return(nil)
-			if task.ScheduleID == firstEventID+1 {
-				s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything).Once().Return(nil)
-			}
 			s.processor.processTransferTask(task)
 		default:
 			break workerPump
@@ -146,8 +146,9 @@ func (s *transferQueueProcessorSuite) TestManyTransferTasks() {
 	err1 := s.UpdateWorkflowExecutionWithTransferTasks(updatedInfo, int64(2), transferTasks, builder.updateActivityInfos)
 	s.Nil(err1)
 
-	tasksCh := make(chan *persistence.TransferTaskInfo, 10)
-	s.processor.processTransferTasks(tasksCh)
+	scheduler := newDomainTaskScheduler(10, func(string) int { return defaultDomainTaskWeight })
+	s.processor.processTransferTasks(scheduler)
+	tasksCh := drainDomainTaskScheduler(scheduler)
 workerPump:
 	for {
 		select {
@@ -193,24 +194,22 @@ func (s *transferQueueProcessorSuite) TestDeleteExecutionTransferTasks() {
 	newExecution := workflow.WorkflowExecution{WorkflowId: common.StringPtr("delete-execution-transfertasks-test"),
 		RunId: common.StringPtr("d3ac892e-9fc1-4def-84fa-bfc44b9128cc")}
 
-	tasksCh := make(chan *persistence.TransferTaskInfo, 10)
-	s.processor.processTransferTasks(tasksCh)
+	scheduler := newDomainTaskScheduler(10, func(string) int { return defaultDomainTaskWeight })
+	s.processor.processTransferTasks(scheduler)
+	tasksCh := drainDomainTaskScheduler(scheduler)
 workerPump:
 	for {
 		select {
 		case task := <-tasksCh:
 			if task.TaskType == persistence.TransferTaskTypeDecisionTask {
 				s.mockMatching.On("AddDecisionTask", mock.Anything, createAddRequestFromTask(task, 0)).Once().Return(nil)
-				if task.ScheduleID == firstEventID+1 {
-					s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything).Once().Return(nil)
-				}
 			} else if task.TaskType == persistence.TransferTaskTypeDeleteExecution {
-				s.mockMetadataMgr.On("GetDomain", mock.Anything).Once().Return(&persistence.GetDomainResponse{
+				s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Once().Return(&persistence.GetDomainResponse{
 					Config: &persistence.DomainConfig{
 						Retention: 1,
 					},
 				}, nil)
-				s.mockVisibilityMgr.On("RecordWorkflowExecutionClosed", mock.Anything).Once().Return(nil)
+				s.mockVisibilityMgr.On("RecordWorkflowExecutionClosed", mock.Anything, mock.Anything).Once().Return(nil)
 			}
 			s.processor.processTransferTask(task)
 		default:
@@ -253,20 +252,18 @@ func (s *transferQueueProcessorSuite) TestDeleteExecutionTransferTasksDomainNotE
 	newExecution := workflow.WorkflowExecution{WorkflowId: common.StringPtr("delete-execution-transfertasks-test"),
 		RunId: common.StringPtr("d3ac892e-9fc1-4def-84fa-bfc44b9128cc")}
 
-	tasksCh := make(chan *persistence.TransferTaskInfo, 10)
-	s.processor.processTransferTasks(tasksCh)
+	scheduler := newDomainTaskScheduler(10, func(string) int { return defaultDomainTaskWeight })
+	s.processor.processTransferTasks(scheduler)
+	tasksCh := drainDomainTaskScheduler(scheduler)
 workerPump:
 	for {
 		select {
 		case task := <-tasksCh:
 			if task.TaskType == persistence.TransferTaskTypeDecisionTask {
 				s.mockMatching.On("AddDecisionTask", mock.Anything, createAddRequestFromTask(task, 0)).Once().Return(nil)
-				if task.ScheduleID == firstEventID+1 {
-					s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything).Once().Return(nil)
-				}
 			} else if task.TaskType == persistence.TransferTaskTypeDeleteExecution {
-				s.mockMetadataMgr.On("GetDomain", mock.Anything).Once().Return(nil, &workflow.EntityNotExistsError{})
-				s.mockVisibilityMgr.On("RecordWorkflowExecutionClosed", mock.Anything).Once().Return(nil)
+				s.mockMetadataMgr.On("GetDomain", mock.Anything, mock.Anything).Once().Return(nil, &workflow.EntityNotExistsError{})
+				s.mockVisibilityMgr.On("RecordWorkflowExecutionClosed", mock.Anything, mock.Anything).Once().Return(nil)
 			}
 			s.processor.processTransferTask(task)
 		default:
@@ -306,9 +303,13 @@ func (s *transferQueueProcessorSuite) TestCancelRemoteExecutionTransferTasks() {
 	updatedInfo := copyWorkflowExecutionInfo(builder.executionInfo)
 	err1 := s.UpdateWorkflowExecutionWithTransferTasks(updatedInfo, int64(3), transferTasks, nil)
 	s.Nil(err1, "No error expected.")
+	err2 := s.UpsertRequestCancelState(updatedInfo, updatedInfo.NextEventID,
+		[]*persistence.RequestCancelInfo{{InitiatedID: 1, CancelRequestID: uuid.New()}})
+	s.Nil(err2, "No error expected.")
 
-	tasksCh := make(chan *persistence.TransferTaskInfo, 10)
-	s.processor.processTransferTasks(tasksCh)
+	scheduler := newDomainTaskScheduler(10, func(string) int { return defaultDomainTaskWeight })
+	s.processor.processTransferTasks(scheduler)
+	tasksCh := drainDomainTaskScheduler(scheduler)
 workerPump:
 	for {
 		select {
@@ -316,9 +317,6 @@ workerPump:
 			s.logger.Infof("Processing transfer task type: %v", task.TaskType)
 			if task.TaskType == persistence.TransferTaskTypeDecisionTask {
 				s.mockMatching.On("AddDecisionTask", mock.Anything, createAddRequestFromTask(task, 0)).Once().Return(nil)
-				if task.ScheduleID == firstEventID+1 {
-					s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything).Once().Return(nil)
-				}
 			} else if task.TaskType == persistence.TransferTaskTypeCancelExecution {
 				s.mockHistoryClient.On("RequestCancelWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
 			}
@@ -331,6 +329,63 @@ workerPump:
 	s.mockMatching.AssertExpectations(s.T())
 	s.mockVisibilityMgr.AssertExpectations(s.T())
 	s.mockHistoryClient.AssertExpectations(s.T())
+	state, err3 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err3, "No error expected.")
+	s.Equal(0, len(state.RequestCancelInfos))
+}
+
+func (s *transferQueueProcessorSuite) TestCancelRemoteExecutionTransferTask_InitiatedBeforeUpgrade() {
+	domainID := "f5f1ece7-000d-495d-81c3-918ac29006ed"
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("cancel-transfer-upgrade-test"),
+		RunId:      common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6")}
+	taskList := "cancel-transfer-upgrade-queue"
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, taskList, "wType", 10, nil, 3, 0, 2, nil)
+	s.Nil(err0, "No error expected.")
+	s.NotEmpty(task0, "Expected non empty task identifier.")
+
+	builder := newMutableStateBuilder(s.logger)
+	info, _ := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	builder.Load(info)
+	addDecisionTaskStartedEvent(builder, int64(2), taskList, "identity")
+
+	// No request cancellation in mutable state, and no outcome in the history
+	transferTasks := []persistence.Task{&persistence.CancelExecutionTask{
+		TaskID:           s.GetNextSequenceNumber(),
+		TargetDomainID:   "f2bfaab6-7e8b-4fac-9a62-17da8d37becb",
+		TargetWorkflowID: "target-workflow_id",
+		TargetRunID:      "0d00698f-08e1-4d36-a3e2-3bf109f5d2d6",
+		ScheduleID:       1,
+	}}
+	updatedInfo := copyWorkflowExecutionInfo(builder.executionInfo)
+	err1 := s.UpdateWorkflowExecutionWithTransferTasks(updatedInfo, int64(3), transferTasks, nil)
+	s.Nil(err1, "No error expected.")
+
+	scheduler := newDomainTaskScheduler(10, func(string) int { return defaultDomainTaskWeight })
+	s.processor.processTransferTasks(scheduler)
+	tasksCh := drainDomainTaskScheduler(scheduler)
+workerPump:
+	for {
+		select {
+		case task := <-tasksCh:
+			s.logger.Infof("Processing transfer task type: %v", task.TaskType)
+			if task.TaskType == persistence.TransferTaskTypeDecisionTask {
+				s.mockMatching.On("AddDecisionTask", mock.Anything, createAddRequestFromTask(task, 0)).Once().Return(nil)
+			} else if task.TaskType == persistence.TransferTaskTypeCancelExecution {
+				s.mockHistoryClient.On("RequestCancelWorkflowExecution", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+			s.processor.processTransferTask(task)
+		default:
+			break workerPump
+		}
+	}
+
+	s.mockMatching.AssertExpectations(s.T())
+	s.mockVisibilityMgr.AssertExpectations(s.T())
+	s.mockHistoryClient.AssertExpectations(s.T())
+	state, err2 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err2, "No error expected.")
+	s.Equal(0, len(state.RequestCancelInfos))
 }
 
 func (s *transferQueueProcessorSuite) TestCancelRemoteExecutionTransferTask_RequestFail() {
@@ -358,9 +413,13 @@ func (s *transferQueueProcessorSuite) TestCancelRemoteExecutionTransferTask_Requ
 	updatedInfo := copyWorkflowExecutionInfo(builder.executionInfo)
 	err1 := s.UpdateWorkflowExecutionWithTransferTasks(updatedInfo, int64(3), transferTasks, nil)
 	s.Nil(err1, "No error expected.")
+	err2 := s.UpsertRequestCancelState(updatedInfo, updatedInfo.NextEventID,
+		[]*persistence.RequestCancelInfo{{InitiatedID: 1, CancelRequestID: uuid.New()}})
+	s.Nil(err2, "No error expected.")
 
-	tasksCh := make(chan *persistence.TransferTaskInfo, 10)
-	s.processor.processTransferTasks(tasksCh)
+	scheduler := newDomainTaskScheduler(10, func(string) int { return defaultDomainTaskWeight })
+	s.processor.processTransferTasks(scheduler)
+	tasksCh := drainDomainTaskScheduler(scheduler)
 workerPump:
 	for {
 		select {
@@ -368,9 +427,6 @@ workerPump:
 			s.logger.Infof("Processing transfer task type: %v", task.TaskType)
 			if task.TaskType == persistence.TransferTaskTypeDecisionTask {
 				s.mockMatching.On("AddDecisionTask", mock.Anything, createAddRequestFromTask(task, 0)).Once().Return(nil)
-				if task.ScheduleID == firstEventID+1 {
-					s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything).Once().Return(nil)
-				}
 			} else if task.TaskType == persistence.TransferTaskTypeCancelExecution {
 				s.mockHistoryClient.On("RequestCancelWorkflowExecution", mock.Anything, mock.Anything).
 					Return(&workflow.EntityNotExistsError{}).Once()
@@ -384,6 +440,9 @@ workerPump:
 	s.mockMatching.AssertExpectations(s.T())
 	s.mockVisibilityMgr.AssertExpectations(s.T())
 	s.mockHistoryClient.AssertExpectations(s.T())
+	state, err3 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err3, "No error expected.")
+	s.Equal(0, len(state.RequestCancelInfos))
 }
 
 func (s *transferQueueProcessorSuite) TestCompleteTaskAfterExecutionDeleted() {
@@ -395,14 +454,24 @@ func (s *transferQueueProcessorSuite) TestCompleteTaskAfterExecutionDeleted() {
 	s.Nil(err0, "No error expected.")
 	s.NotEmpty(task0, "Expected non empty task identifier.")
 
-	tasksCh := make(chan *persistence.TransferTaskInfo, 10)
-	s.processor.processTransferTasks(tasksCh)
+	state, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	transferTasks := []persistence.Task{&persistence.RecordWorkflowStartedTask{TaskID: s.GetNextSequenceNumber()}}
+	updatedInfo := copyWorkflowExecutionInfo(state.ExecutionInfo)
+	err2 := s.UpdateWorkflowExecutionWithTransferTasks(updatedInfo, int64(3), transferTasks, nil)
+	s.Nil(err2, "No error expected.")
+
+	scheduler := newDomainTaskScheduler(10, func(string) int { return defaultDomainTaskWeight })
+	s.processor.processTransferTasks(scheduler)
+	tasksCh := drainDomainTaskScheduler(scheduler)
 workerPump:
 	for {
 		select {
 		case task := <-tasksCh:
-			if task.ScheduleID == firstEventID+1 {
-				s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything).Once().Return(&workflow.EntityNotExistsError{})
+			if task.TaskType == persistence.TransferTaskTypeRecordWorkflowStarted {
+				s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything, mock.Anything).Once().Return(&workflow.EntityNotExistsError{})
+			} else {
+				s.mockMatching.On("AddDecisionTask", mock.Anything, createAddRequestFromTask(task, 0)).Once().Return(nil)
 			}
 			s.processor.processTransferTask(task)
 		default:
@@ -410,6 +479,7 @@ workerPump:
 		}
 	}
 
+	s.mockMatching.AssertExpectations(s.T())
 	s.mockVisibilityMgr.AssertExpectations(s.T())
 }
 
@@ -450,3 +520,54 @@ func containsID(list []int64, scheduleID int64) bool {
 
 	return false
 }
+
+func (s *transferQueueProcessorSuite) TestCallHistoryServiceWithRetry() {
+	attempts := 0
+	err := callHistoryServiceWithRetry(func() error {
+		attempts++
+		if attempts < 3 {
+			return &h.ShardOwnershipLostError{Message: common.StringPtr("shard moved")}
+		}
+		return nil
+	})
+	s.Nil(err)
+	s.Equal(3, attempts)
+
+	attempts = 0
+	err = callHistoryServiceWithRetry(func() error {
+		attempts++
+		return &workflow.EntityNotExistsError{Message: "target not found"}
+	})
+	s.IsType(&workflow.EntityNotExistsError{}, err)
+	s.Equal(1, attempts)
+}
+
+func (s *transferQueueProcessorSuite) TestGetDuplicateStartResponse() {
+	alreadyStarted := &workflow.WorkflowExecutionAlreadyStartedError{
+		StartRequestId: common.StringPtr("request"),
+		RunId:          common.StringPtr("run"),
+	}
+	response, ok := getDuplicateStartResponse(alreadyStarted, "request")
+	s.True(ok)
+	s.Equal("run", response.GetRunId())
+
+	_, ok = getDuplicateStartResponse(alreadyStarted, "other request")
+	s.False(ok)
+	_, ok = getDuplicateStartResponse(&workflow.WorkflowExecutionAlreadyStartedError{}, "")
+	s.False(ok)
+	_, ok = getDuplicateStartResponse(nil, "request")
+	s.False(ok)
+}
+
+// drainDomainTaskScheduler closes the scheduler and returns its pending transfer tasks in a channel
+func drainDomainTaskScheduler(scheduler *domainTaskScheduler) <-chan *persistence.TransferTaskInfo {
+	scheduler.close()
+	tasksCh := make(chan *persistence.TransferTaskInfo, scheduler.size)
+	for {
+		task, ok := scheduler.next()
+		if !ok {
+			return tasksCh
+		}
+		tasksCh <- task.(*persistence.TransferTaskInfo)
+	}
+}
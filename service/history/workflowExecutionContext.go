@@ -23,17 +23,19 @@ package history
 import (
 	"fmt"
 	"sync"
-	"time"
+	"sync/atomic"
 
 	"github.com/uber/cadence/.gen/go/history"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 
 	"github.com/uber-common/bark"
 	hc "github.com/uber/cadence/client/history"
+	"golang.org/x/net/context"
 )
 
 type (
@@ -49,11 +51,21 @@ type (
 		tBuilder        *timerBuilder
 		updateCondition int64
 		deleteTimerTask persistence.Task
+
+		failOnChecksumMismatch bool
+		completedExecutions    *completedExecutionCache
+		historyEventsPerBatch  int
+		// stateSize is the size of the loaded mutable state, added up across the contexts held by the history cache
+		stateSize  int64
+		cacheBytes *int64
+		// metricsClient is tagged with the domain of the execution, created on first use
+		metricsClient metrics.Client
 	}
 )
 
 var (
-	persistenceOperationRetryPolicy = common.CreatePersistanceRetryPolicy()
+	persistenceOperationRetryPolicy    = common.CreatePersistanceRetryPolicy()
+	historyServiceOperationRetryPolicy = common.CreateHistoryServiceRetryPolicy()
 )
 
 func newWorkflowExecutionContext(domainID string, execution workflow.WorkflowExecution, shard ShardContext,
@@ -62,7 +74,7 @@ func newWorkflowExecutionContext(domainID string, execution workflow.WorkflowExe
 		logging.TagWorkflowExecutionID: execution.GetWorkflowId(),
 		logging.TagWorkflowRunID:       execution.GetRunId(),
 	})
-	tBuilder := newTimerBuilder(&shardSeqNumGenerator{context: shard}, lg)
+	tBuilder := newTimerBuilder(&shardSeqNumGenerator{context: shard}, shard.GetTimeSource(), lg)
 
 	return &workflowExecutionContext{
 		domainID:          domainID,
@@ -74,12 +86,12 @@ func newWorkflowExecutionContext(domainID string, execution workflow.WorkflowExe
 	}
 }
 
-func (c *workflowExecutionContext) loadWorkflowExecution() (*mutableStateBuilder, error) {
+func (c *workflowExecutionContext) loadWorkflowExecution(ctx context.Context) (*mutableStateBuilder, error) {
 	if c.msBuilder != nil {
 		return c.msBuilder, nil
 	}
 
-	response, err := c.getWorkflowExecutionWithRetry(&persistence.GetWorkflowExecutionRequest{
+	response, err := c.getWorkflowExecutionWithRetry(ctx, &persistence.GetWorkflowExecutionRequest{
 		DomainID:  c.domainID,
 		Execution: c.workflowExecution,
 	})
@@ -92,29 +104,57 @@ func (c *workflowExecutionContext) loadWorkflowExecution() (*mutableStateBuilder
 	if response != nil && response.State != nil {
 		state := response.State
 		msBuilder.Load(state)
+		if err := c.verifyChecksum(msBuilder, state.Checksum); err != nil {
+			return nil, err
+		}
 		info := state.ExecutionInfo
 		c.updateCondition = info.NextEventID
 	}
 
 	c.msBuilder = msBuilder
+	c.updateStateSize(msBuilder.size())
 	return msBuilder, nil
 }
 
-func (c *workflowExecutionContext) updateWorkflowExecutionWithContext(context []byte, transferTasks []persistence.Task,
-	timerTasks []persistence.Task, transactionID int64) error {
-	c.msBuilder.executionInfo.ExecutionContext = context
+// verifyChecksum compares the checksum of the loaded mutable state against the one persisted with the last update of
+// the execution.  Executions last updated without a checksum are not verified.
+func (c *workflowExecutionContext) verifyChecksum(msBuilder *mutableStateBuilder, expected int64) error {
+	if expected == 0 {
+		return nil
+	}
+
+	actual := msBuilder.checksum()
+	if actual == expected {
+		return nil
+	}
 
-	return c.updateWorkflowExecution(transferTasks, timerTasks, transactionID)
+	logging.LogMutableStateChecksumMismatchEvent(c.logger, expected, actual)
+	c.shard.GetMetricsClient().IncCounter(metrics.HistoryLoadMutableStateScope,
+		metrics.MutableStateChecksumMismatchCounter)
+	if c.failOnChecksumMismatch {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Mutable state checksum mismatch.  WorkflowId: %v, RunId: %v",
+				c.workflowExecution.GetWorkflowId(), c.workflowExecution.GetRunId()),
+		}
+	}
+	return nil
 }
 
-func (c *workflowExecutionContext) updateWorkflowExecutionWithDeleteTask(transferTasks []persistence.Task,
+func (c *workflowExecutionContext) updateWorkflowExecutionWithContext(ctx context.Context, executionContext []byte,
+	transferTasks []persistence.Task, timerTasks []persistence.Task, transactionID int64) error {
+	c.msBuilder.executionInfo.ExecutionContext = executionContext
+
+	return c.updateWorkflowExecution(ctx, transferTasks, timerTasks, transactionID)
+}
+
+func (c *workflowExecutionContext) updateWorkflowExecutionWithDeleteTask(ctx context.Context, transferTasks []persistence.Task,
 	timerTasks []persistence.Task, deleteTimerTask persistence.Task, transactionID int64) error {
 	c.deleteTimerTask = deleteTimerTask
 
-	return c.updateWorkflowExecution(transferTasks, timerTasks, transactionID)
+	return c.updateWorkflowExecution(ctx, transferTasks, timerTasks, transactionID)
 }
 
-func (c *workflowExecutionContext) updateWorkflowExecution(transferTasks []persistence.Task,
+func (c *workflowExecutionContext) updateWorkflowExecution(ctx context.Context, transferTasks []persistence.Task,
 	timerTasks []persistence.Task, transactionID int64) error {
 	// Take a snapshot of all updates we have accumulated for this execution
 	updates := c.msBuilder.CloseUpdateSession()
@@ -122,43 +162,59 @@ func (c *workflowExecutionContext) updateWorkflowExecution(transferTasks []persi
 	builder := updates.newEventsBuilder
 	if builder.history != nil && len(builder.history) > 0 {
 		// Some operations only update the mutable state. For example RecordActivityTaskHeartbeat.
-		firstEvent := builder.history[0]
-		serializedHistory, err := builder.Serialize()
-		if err != nil {
-			logging.LogHistorySerializationErrorEvent(c.logger, err, "Unable to serialize execution history for update.")
-			return err
-		}
-
-		if err0 := c.shard.AppendHistoryEvents(&persistence.AppendHistoryEventsRequest{
-			DomainID:      c.domainID,
-			Execution:     c.workflowExecution,
-			TransactionID: transactionID,
-			FirstEventID:  firstEvent.GetEventId(),
-			Events:        serializedHistory,
-		}); err0 != nil {
-			// Clear all cached state in case of error
-			c.clear()
-
-			switch err0.(type) {
-			case *persistence.ConditionFailedError:
-				return ErrConflict
+		batches := splitHistoryEvents(builder.history, c.historyEventsPerBatch)
+		for _, batch := range batches {
+			serializedHistory, err := builder.serialize(batch)
+			if err != nil {
+				logging.LogHistorySerializationErrorEvent(c.logger, err, "Unable to serialize execution history for update.")
+				return err
 			}
 
-			logging.LogPersistantStoreErrorEvent(c.logger, logging.TagValueStoreOperationUpdateWorkflowExecution, err0,
-				fmt.Sprintf("{updateCondition: %v}", c.updateCondition))
-			return err0
+			c.domainMetricsClient().RecordHistogramValue(metrics.HistoryExecutionStatsScope,
+				metrics.HistoryBlobSizeHistogram, float64(len(serializedHistory.Data)))
+			// The events are appended ahead of the update, they are beyond the next event ID of the persisted mutable
+			// state until the update goes through, and overwritten when the update is retried
+			if err0 := c.shard.AppendHistoryEvents(ctx, &persistence.AppendHistoryEventsRequest{
+				DomainID:      c.domainID,
+				Execution:     c.workflowExecution,
+				TransactionID: transactionID,
+				FirstEventID:  batch[0].GetEventId(),
+				Events:        serializedHistory,
+			}); err0 != nil {
+				// Clear all cached state in case of error
+				c.clear()
+
+				switch err0.(type) {
+				case *persistence.ConditionFailedError:
+					return ErrConflict
+				}
+
+				logging.LogPersistantStoreErrorEvent(c.logger, logging.TagValueStoreOperationUpdateWorkflowExecution,
+					err0, fmt.Sprintf("{updateCondition: %v}", c.updateCondition))
+				return err0
+			}
 		}
+	}
 
+	var newBufferedEvents *persistence.SerializedHistoryEventBatch
+	if len(builder.buffered) > 0 {
+		// Events received while the decision is in flight are kept with the mutable state until it completes
+		serializedEvents, err := builder.SerializeBuffered()
+		if err != nil {
+			logging.LogHistorySerializationErrorEvent(c.logger, err, "Unable to serialize buffered events for update.")
+			return err
+		}
+		newBufferedEvents = serializedEvents
 	}
 
 	continueAsNew := updates.continueAsNew
 	deleteExecution := false
-	if c.msBuilder.executionInfo.State == persistence.WorkflowStateCompleted {
-		// Workflow execution completed as part of this transaction.
+	if c.msBuilder.executionInfo.State == persistence.WorkflowStateCompleted && len(builder.history) > 0 {
+		// Workflow execution completed as part of this transaction, as closed executions get no new events.
 		// Also transactionally delete workflow execution representing current run for the execution
 		deleteExecution = true
 	}
-	if err1 := c.updateWorkflowExecutionWithRetry(&persistence.UpdateWorkflowExecutionRequest{
+	if err1 := c.updateWorkflowExecutionWithRetry(ctx, &persistence.UpdateWorkflowExecutionRequest{
 		ExecutionInfo:             c.msBuilder.executionInfo,
 		TransferTasks:             transferTasks,
 		TimerTasks:                timerTasks,
@@ -170,8 +226,15 @@ func (c *workflowExecutionContext) updateWorkflowExecution(transferTasks []persi
 		DeleteTimerInfos:          updates.deleteTimerInfos,
 		UpsertChildExecutionInfos: updates.updateChildExecutionInfos,
 		DeleteChildExecutionInfo:  updates.deleteChildExecutionInfo,
+		UpsertRequestCancelInfos:  updates.updateRequestCancelInfos,
+		DeleteRequestCancelInfo:   updates.deleteRequestCancelInfo,
+		NewBufferedEvents:         newBufferedEvents,
+		ClearBufferedEvents:       updates.clearBufferedEvents,
+		UpsertSignalReceipts:      updates.updateSignalReceipts,
+		DeleteSignalReceipts:      updates.deleteSignalReceipts,
 		ContinueAsNew:             continueAsNew,
 		CloseExecution:            deleteExecution,
+		Checksum:                  c.msBuilder.checksum(),
 	}); err1 != nil {
 		// Clear all cached state in case of error
 		c.clear()
@@ -188,11 +251,68 @@ func (c *workflowExecutionContext) updateWorkflowExecution(transferTasks []persi
 
 	// Update went through so update the condition for new updates
 	c.updateCondition = c.msBuilder.GetNextEventID()
-	c.msBuilder.executionInfo.LastUpdatedTimestamp = time.Now()
+	if newBufferedEvents != nil {
+		c.msBuilder.bufferedEvents = append(c.msBuilder.bufferedEvents, newBufferedEvents)
+	}
+	c.msBuilder.executionInfo.LastUpdatedTimestamp = c.shard.GetTimeSource().Now()
+	stateSize := c.msBuilder.size()
+	c.updateStateSize(stateSize)
+	c.domainMetricsClient().RecordHistogramValue(metrics.HistoryExecutionStatsScope, metrics.MutableStateSizeHistogram,
+		float64(stateSize))
+	if deleteExecution {
+		c.emitExecutionStats()
+		c.completedExecutions.put(c.domainID, c.workflowExecution, c.msBuilder.executionInfo.CloseStatus,
+			c.msBuilder.executionInfo.LastUpdatedTimestamp)
+	}
 	return nil
 }
 
-func (c *workflowExecutionContext) continueAsNewWorkflowExecution(context []byte, newStateBuilder *mutableStateBuilder,
+// splitHistoryEvents splits the events of an update into the batches appended to the history with one write each, of
+// up to batchSize events.  Zero keeps all the events in one batch.
+func splitHistoryEvents(events []*workflow.HistoryEvent, batchSize int) [][]*workflow.HistoryEvent {
+	if batchSize <= 0 {
+		return [][]*workflow.HistoryEvent{events}
+	}
+
+	var batches [][]*workflow.HistoryEvent
+	for len(events) > batchSize {
+		batches = append(batches, events[:batchSize])
+		events = events[batchSize:]
+	}
+	return append(batches, events)
+}
+
+// emitExecutionStats reports the counters kept in the mutable state of an execution once it is closed, so the usage
+// of domains can be metered
+func (c *workflowExecutionContext) emitExecutionStats() {
+	info := c.msBuilder.executionInfo
+	metricsClient := c.domainMetricsClient()
+	metricsClient.IncCounter(metrics.HistoryExecutionStatsScope, metrics.ExecutionsClosedCounter)
+	metricsClient.AddCounter(metrics.HistoryExecutionStatsScope, metrics.ExecutionEventsCounter, info.NextEventID-1)
+	metricsClient.AddCounter(metrics.HistoryExecutionStatsScope, metrics.ExecutionSignalsCounter, info.SignalCount)
+	metricsClient.AddCounter(metrics.HistoryExecutionStatsScope, metrics.ExecutionActivitiesCounter, info.ActivityCount)
+	metricsClient.AddCounter(metrics.HistoryExecutionStatsScope, metrics.ExecutionTimersCounter, info.TimerCount)
+	metricsClient.AddCounter(metrics.HistoryExecutionStatsScope, metrics.ExecutionDecisionsCounter, info.DecisionCount)
+}
+
+func (c *workflowExecutionContext) domainMetricsClient() metrics.Client {
+	if c.metricsClient == nil {
+		c.metricsClient = c.shard.GetMetricsClient().Tagged(map[string]string{metrics.DomainIDTagName: c.domainID})
+	}
+	return c.metricsClient
+}
+
+// updateStateSize records the size of the mutable state held by the context, and adjusts the total bytes of the
+// history cache the context belongs to
+func (c *workflowExecutionContext) updateStateSize(size int) {
+	prev := atomic.SwapInt64(&c.stateSize, int64(size))
+	if c.cacheBytes != nil {
+		atomic.AddInt64(c.cacheBytes, int64(size)-prev)
+	}
+}
+
+func (c *workflowExecutionContext) continueAsNewWorkflowExecution(ctx context.Context, executionContext []byte,
+	newStateBuilder *mutableStateBuilder,
 	transferTasks []persistence.Task, transactionID int64) error {
 
 	domainID := newStateBuilder.executionInfo.DomainID
@@ -211,7 +331,7 @@ func (c *workflowExecutionContext) continueAsNewWorkflowExecution(context []byte
 		return serializedError
 	}
 
-	err1 := c.shard.AppendHistoryEvents(&persistence.AppendHistoryEventsRequest{
+	err1 := c.shard.AppendHistoryEvents(ctx, &persistence.AppendHistoryEventsRequest{
 		DomainID:  domainID,
 		Execution: newExecution,
 		// It is ok to use 0 for TransactionID because RunID is unique so there are
@@ -224,7 +344,7 @@ func (c *workflowExecutionContext) continueAsNewWorkflowExecution(context []byte
 		return err1
 	}
 
-	err2 := c.updateWorkflowExecutionWithContext(context, transferTasks, nil, transactionID)
+	err2 := c.updateWorkflowExecutionWithContext(ctx, executionContext, transferTasks, nil, transactionID)
 
 	if err2 != nil {
 		// TODO: Delete new execution if update fails due to conflict or shard being lost
@@ -233,8 +353,8 @@ func (c *workflowExecutionContext) continueAsNewWorkflowExecution(context []byte
 	return err2
 }
 
-func (c *workflowExecutionContext) deleteWorkflowExecution() error {
-	err := c.deleteWorkflowExecutionWithRetry(&persistence.DeleteWorkflowExecutionRequest{
+func (c *workflowExecutionContext) deleteWorkflowExecution(ctx context.Context) error {
+	err := c.deleteWorkflowExecutionWithRetry(ctx, &persistence.DeleteWorkflowExecutionRequest{
 		ExecutionInfo: c.msBuilder.executionInfo,
 	})
 	if err != nil {
@@ -247,12 +367,12 @@ func (c *workflowExecutionContext) deleteWorkflowExecution() error {
 	return err
 }
 
-func (c *workflowExecutionContext) getWorkflowExecutionWithRetry(
+func (c *workflowExecutionContext) getWorkflowExecutionWithRetry(ctx context.Context,
 	request *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
 	var response *persistence.GetWorkflowExecutionResponse
 	op := func() error {
 		var err error
-		response, err = c.executionManager.GetWorkflowExecution(request)
+		response, err = c.executionManager.GetWorkflowExecution(ctx, request)
 
 		return err
 	}
@@ -265,19 +385,19 @@ func (c *workflowExecutionContext) getWorkflowExecutionWithRetry(
 	return response, nil
 }
 
-func (c *workflowExecutionContext) updateWorkflowExecutionWithRetry(
+func (c *workflowExecutionContext) updateWorkflowExecutionWithRetry(ctx context.Context,
 	request *persistence.UpdateWorkflowExecutionRequest) error {
 	op := func() error {
-		return c.shard.UpdateWorkflowExecution(request)
+		return c.shard.UpdateWorkflowExecution(ctx, request)
 	}
 
 	return backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
 }
 
-func (c *workflowExecutionContext) deleteWorkflowExecutionWithRetry(
+func (c *workflowExecutionContext) deleteWorkflowExecutionWithRetry(ctx context.Context,
 	request *persistence.DeleteWorkflowExecutionRequest) error {
 	op := func() error {
-		return c.executionManager.DeleteWorkflowExecution(request)
+		return c.shard.DeleteWorkflowExecution(ctx, request)
 	}
 
 	return backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
@@ -287,20 +407,15 @@ func (c *workflowExecutionContext) deleteWorkflowExecutionWithRetry(
 //   https://github.com/uber/cadence/issues/145
 //  (1) On the target workflow we can generate more than one cancel request if we end up retrying because of intermittent
 //	errors. We might want to have deduping logic internally to avoid that.
-//  (2) For single cancel transfer task we can generate more than one ExternalWorkflowExecutionCancelRequested event in the
-//	history if we fail to delete transfer task and retry again. We need some logic to look back at the event
-//      state in mutable state when we are processing this transfer task.
-//      This means for one single ExternalWorkflowExecutionCancelInitiated we can see a
-//      ExternalWorkflowExecutionCancelRequested and RequestCancelExternalWorkflowExecutionFailedEvent.
-func (c *workflowExecutionContext) requestExternalCancelWorkflowExecutionWithRetry(
+// The outcome of the request is recorded at most once, as recording it removes the pending request cancellation from
+// mutable state.
+func (c *workflowExecutionContext) requestExternalCancelWorkflowExecutionWithRetry(ctx context.Context,
 	historyClient hc.Client,
 	request *history.RequestCancelWorkflowExecutionRequest,
 	initiatedEventID int64) error {
-	op := func() error {
+	err := callHistoryServiceWithRetry(func() error {
 		return historyClient.RequestCancelWorkflowExecution(nil, request)
-	}
-
-	err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
+	})
 	if err == nil {
 		// We succeeded in request to cancel workflow.
 		if c.msBuilder.AddExternalWorkflowExecutionCancelRequested(
@@ -317,7 +432,7 @@ func (c *workflowExecutionContext) requestExternalCancelWorkflowExecutionWithRet
 		if err != nil {
 			return err
 		}
-		return c.updateWorkflowExecution(nil, nil, transactionID)
+		return c.updateWorkflowExecution(ctx, nil, nil, transactionID)
 
 	} else if err != nil && common.IsServiceNonRetryableError(err) {
 		// We failed in request to cancel workflow.
@@ -337,12 +452,13 @@ func (c *workflowExecutionContext) requestExternalCancelWorkflowExecutionWithRet
 		if err != nil {
 			return err
 		}
-		return c.updateWorkflowExecution(nil, nil, transactionID)
+		return c.updateWorkflowExecution(ctx, nil, nil, transactionID)
 	}
 	return err
 }
 
 func (c *workflowExecutionContext) clear() {
 	c.msBuilder = nil
-	c.tBuilder = newTimerBuilder(&shardSeqNumGenerator{context: c.shard}, c.logger)
+	c.updateStateSize(0)
+	c.tBuilder = newTimerBuilder(&shardSeqNumGenerator{context: c.shard}, c.shard.GetTimeSource(), c.logger)
 }
@@ -0,0 +1,346 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"time"
+
+	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	workflowMigratorHistoryPageSize    = 100
+	workflowMigratorVisibilityPageSize = 100
+)
+
+var (
+	errMigrateSameDomain       = &workflow.BadRequestError{Message: "Target domain is the domain of the execution."}
+	errMigrateRunningExecution = &workflow.BadRequestError{Message: "Only closed workflow executions can be migrated."}
+)
+
+type (
+	// workflowMigrator moves closed workflow executions of a shard from one domain to another.  The history and the
+	// mutable state of the execution are copied under the ID of the target domain, the execution is recorded as
+	// closed in the visibility store of the target domain and gets the retention of the target domain, then the
+	// copies kept by the source domain are deleted.  The run ID of the execution is kept.
+	//
+	// Every step can be repeated, so a migration which failed half way is completed by migrating the execution again.
+	//
+	// Only closed executions are moved, one at a time and within the cluster.  Running executions would have to be
+	// reset to be rebuilt in the target domain, and moves to another cluster need the history to be replicated
+	// between clusters, neither of which exists yet.  Both are left to follow-ups, along with a worker migrating the
+	// closed executions of a domain in bulk; until then a domain is migrated by listing its closed executions and
+	// migrating each of them.
+	workflowMigrator struct {
+		shard              ShardContext
+		historyMgr         persistence.HistoryManager
+		visibilityMgr      persistence.VisibilityManager
+		historyCache       *historyCache
+		domainCache        cache.DomainCache
+		hSerializerFactory persistence.HistorySerializerFactory
+		payloadBlobs       *payloadBlobs
+		logger             bark.Logger
+	}
+)
+
+func newWorkflowMigrator(shard ShardContext, visibilityMgr persistence.VisibilityManager,
+	historyCache *historyCache, domainCache cache.DomainCache, payloadBlobs *payloadBlobs,
+	logger bark.Logger) *workflowMigrator {
+	return &workflowMigrator{
+		shard:              shard,
+		historyMgr:         shard.GetHistoryManager(),
+		visibilityMgr:      visibilityMgr,
+		historyCache:       historyCache,
+		domainCache:        domainCache,
+		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+		payloadBlobs:       payloadBlobs,
+		logger:             logger,
+	}
+}
+
+// migrate moves the closed execution from the source to the target domain
+func (m *workflowMigrator) migrate(ctx context.Context, domainID, targetDomainID string,
+	execution workflow.WorkflowExecution) error {
+	if domainID == targetDomainID {
+		return errMigrateSameDomain
+	}
+	_, targetConfig, err := m.domainCache.GetDomainByID(targetDomainID)
+	if err != nil {
+		return err
+	}
+
+	sourceContext, sourceRelease, err := m.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err != nil {
+		return err
+	}
+	defer sourceRelease()
+	msBuilder, err := sourceContext.loadWorkflowExecution(ctx)
+	if err != nil {
+		return err
+	}
+	if msBuilder.isWorkflowExecutionRunning() {
+		return errMigrateRunningExecution
+	}
+
+	targetContext, targetRelease, err := m.historyCache.getOrCreateWorkflowExecution(ctx, targetDomainID, execution)
+	if err != nil {
+		return err
+	}
+	defer targetRelease()
+	// The copy is written without going through the context of the target
+	defer targetContext.clear()
+
+	logger := m.logger.WithFields(bark.Fields{
+		logging.TagWorkflowExecutionID: execution.GetWorkflowId(),
+		logging.TagWorkflowRunID:       execution.GetRunId(),
+	})
+	logger.Infof("Migrating workflow execution from domain %v to domain %v.", domainID, targetDomainID)
+
+	closeRecord, err := m.getCloseRecord(ctx, domainID, msBuilder.executionInfo)
+	if err != nil {
+		return err
+	}
+	closeTime := msBuilder.executionInfo.LastUpdatedTimestamp
+	if closeRecord != nil {
+		closeTime = time.Unix(0, closeRecord.GetCloseTime())
+	}
+
+	if err := m.copyHistory(ctx, domainID, targetDomainID, execution, msBuilder.GetNextEventID()); err != nil {
+		return err
+	}
+
+	_, err = targetContext.loadWorkflowExecution(ctx)
+	if _, ok := err.(*workflow.EntityNotExistsError); ok {
+		retention := time.Duration(targetConfig.Retention) * 24 * time.Hour
+		err = m.copyMutableState(ctx, targetDomainID, msBuilder, closeTime, retention)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = m.visibilityMgr.RecordWorkflowExecutionClosed(ctx, &persistence.RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       targetDomainID,
+		Execution:        execution,
+		WorkflowTypeName: msBuilder.executionInfo.WorkflowTypeName,
+		StartTimestamp:   msBuilder.executionInfo.StartTimestamp.UnixNano(),
+		CloseTimestamp:   closeTime.UnixNano(),
+		Status:           getWorkflowExecutionCloseStatus(msBuilder.executionInfo.CloseStatus),
+		CloseReason:      getWorkflowExecutionCloseReason(msBuilder),
+		RetentionSeconds: int64(targetConfig.Retention) * 24 * 60 * 60,
+	})
+	if err != nil {
+		return err
+	}
+	if closeRecord != nil {
+		err = m.visibilityMgr.DeleteClosedWorkflowExecution(ctx, &persistence.DeleteClosedWorkflowExecutionRequest{
+			DomainUUID:     domainID,
+			Execution:      execution,
+			StartTimestamp: closeRecord.GetStartTime(),
+			CloseTimestamp: closeRecord.GetCloseTime(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// The mutable state goes last, it is what a repeated migration starts from
+	err = m.payloadBlobs.deleteHistory(ctx, m.historyMgr, domainID, execution)
+	if err != nil {
+		return err
+	}
+	err = m.historyMgr.DeleteWorkflowExecutionHistory(ctx, &persistence.DeleteWorkflowExecutionHistoryRequest{
+		DomainID:  domainID,
+		Execution: execution,
+	})
+	if err != nil {
+		return err
+	}
+	if err := sourceContext.deleteWorkflowExecution(ctx); err != nil {
+		return err
+	}
+	sourceContext.clear()
+
+	logger.Infof("Migrated workflow execution from domain %v to domain %v.", domainID, targetDomainID)
+	return nil
+}
+
+// getCloseRecord returns the visibility record of the closed execution, or nil if it was not recorded yet.  The
+// close time of the record is not kept by the mutable state, which is updated again after the record is written.
+func (m *workflowMigrator) getCloseRecord(ctx context.Context, domainID string,
+	executionInfo *persistence.WorkflowExecutionInfo) (*workflow.WorkflowExecutionInfo, error) {
+	startTime := executionInfo.StartTimestamp.UnixNano()
+	request := &persistence.ListWorkflowExecutionsByWorkflowIDRequest{
+		ListWorkflowExecutionsRequest: persistence.ListWorkflowExecutionsRequest{
+			DomainUUID:        domainID,
+			EarliestStartTime: startTime,
+			LatestStartTime:   startTime,
+			PageSize:          workflowMigratorVisibilityPageSize,
+		},
+		WorkflowID: executionInfo.WorkflowID,
+	}
+	for {
+		response, err := m.visibilityMgr.ListClosedWorkflowExecutionsByWorkflowID(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range response.Executions {
+			if record.GetExecution().GetRunId() == executionInfo.RunID {
+				return record, nil
+			}
+		}
+		if len(response.NextPageToken) == 0 {
+			return nil, nil
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+// copyHistory copies the history batches of the execution to the target domain, batches copied by a previous
+// attempt are overwritten with the same events
+func (m *workflowMigrator) copyHistory(ctx context.Context, domainID, targetDomainID string,
+	execution workflow.WorkflowExecution, nextEventID int64) error {
+	request := &persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID:      domainID,
+		Execution:     execution,
+		NextEventID:   nextEventID,
+		PageSize:      workflowMigratorHistoryPageSize,
+		NextPageToken: []byte{},
+	}
+	for {
+		response, err := m.historyMgr.GetWorkflowExecutionHistory(ctx, request)
+		if err != nil {
+			return err
+		}
+		for i := range response.Events {
+			firstEventID, batch, err := m.copyBatchPayloads(ctx, domainID, targetDomainID, &response.Events[i])
+			if err != nil {
+				return err
+			}
+			err = m.shard.AppendHistoryEvents(ctx, &persistence.AppendHistoryEventsRequest{
+				DomainID:     targetDomainID,
+				Execution:    execution,
+				FirstEventID: firstEventID,
+				Events:       batch,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(response.NextPageToken) == 0 {
+			return nil
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+// copyBatchPayloads returns the ID of the first event of the history batch, along with the batch to write to the
+// target domain.  The offloaded payloads of the batch are copied to the target domain, which the frontend resolves
+// them in, the blobs of the source domain are deleted with its history.
+func (m *workflowMigrator) copyBatchPayloads(ctx context.Context, domainID, targetDomainID string,
+	batch *persistence.SerializedHistoryEventBatch) (int64, *persistence.SerializedHistoryEventBatch, error) {
+	serializer, err := m.hSerializerFactory.Get(batch.EncodingType)
+	if err != nil {
+		return 0, nil, err
+	}
+	history, err := serializer.Deserialize(batch)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(history.Events) == 0 {
+		return 0, nil, &workflow.InternalServiceError{Message: "Empty history batch."}
+	}
+
+	copied := false
+	for _, event := range history.Events {
+		if event.PayloadReference == nil {
+			continue
+		}
+		event.PayloadReference, err = m.payloadBlobs.copyToDomain(ctx, event.PayloadReference, domainID,
+			targetDomainID)
+		if err != nil {
+			return 0, nil, err
+		}
+		copied = true
+	}
+	if copied {
+		if batch, err = serializer.Serialize(history); err != nil {
+			return 0, nil, err
+		}
+	}
+	return history.Events[0].GetEventId(), batch, nil
+}
+
+// copyMutableState creates the closed execution in the target domain, along with the timer deleting it once the
+// retention of the target domain expired
+func (m *workflowMigrator) copyMutableState(ctx context.Context, targetDomainID string,
+	msBuilder *mutableStateBuilder, closeTime time.Time, retention time.Duration) error {
+	info := *msBuilder.executionInfo
+	info.DomainID = targetDomainID
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(info.WorkflowID),
+		RunId:      common.StringPtr(info.RunID),
+	}
+
+	// The execution is created running, as the current run of its workflow in the target domain, then closed
+	_, err := m.shard.CreateWorkflowExecution(ctx, &persistence.CreateWorkflowExecutionRequest{
+		RequestID:            info.CreateRequestID,
+		DomainID:             targetDomainID,
+		Execution:            execution,
+		TaskList:             info.TaskList,
+		WorkflowTypeName:     info.WorkflowTypeName,
+		DecisionTimeoutValue: info.DecisionTimeoutValue,
+		ExecutionContext:     info.ExecutionContext,
+		NextEventID:          info.NextEventID,
+		LastProcessedEvent:   info.LastProcessedEvent,
+		DecisionScheduleID:   emptyEventID,
+		DecisionStartedID:    emptyEventID,
+	})
+	if alreadyStarted, ok := err.(*workflow.WorkflowExecutionAlreadyStartedError); ok &&
+		alreadyStarted.GetRunId() == info.RunID {
+		// Created by a previous attempt which failed to close it
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	closedState := newMutableStateBuilder(m.logger)
+	closedState.executionInfo = &info
+	tBuilder := newTimerBuilder(&shardSeqNumGenerator{context: m.shard}, m.shard.GetTimeSource(), m.logger)
+	deleteTask, err := tBuilder.AddDeleteHistoryEventTask(closeTime, retention)
+	if err != nil {
+		return err
+	}
+	return m.shard.UpdateWorkflowExecution(ctx, &persistence.UpdateWorkflowExecutionRequest{
+		ExecutionInfo:  &info,
+		TimerTasks:     []persistence.Task{deleteTask},
+		Condition:      info.NextEventID,
+		CloseExecution: true,
+		Checksum:       closedState.checksum(),
+	})
+}
@@ -23,10 +23,13 @@ package matching
 import "github.com/uber-common/bark"
 
 // Used to convert out of order acks into ackLevel movement.
+// Tasks of a task list are dispatched to pollers in parallel and completed in any order. The ack level
+// only moves over a prefix of completed tasks, so the persisted ack level never skips a task in flight.
 type ackManager struct {
 	logger bark.Logger
 
 	outstandingTasks map[int64]bool // key->TaskID, value->(true for acked, false->for non acked)
+	taskIDs          []int64        // TaskIDs of outstandingTasks in increasing order
 	readLevel        int64          // Maximum TaskID inserted into outstandingTasks
 	ackLevel         int64          // Maximum TaskID below which all tasks are acked
 }
@@ -42,6 +45,7 @@ func (m *ackManager) addTask(taskID int64) {
 		m.logger.Fatalf("Already present in outstanding tasks: taskID=%v", taskID)
 	}
 	m.outstandingTasks[taskID] = false // true is for acked
+	m.taskIDs = append(m.taskIDs, taskID)
 }
 
 func newAckManager(logger bark.Logger) ackManager {
@@ -56,8 +60,20 @@ func (m *ackManager) getAckLevel() int64 {
 	return m.ackLevel
 }
 
+// Returns true if the task was added and is not below the ack level yet
+func (m *ackManager) hasTask(taskID int64) bool {
+	_, ok := m.outstandingTasks[taskID]
+	return ok
+}
+
+// Returns the number of tasks read but not acked yet
+func (m *ackManager) getOutstandingCount() int {
+	return len(m.taskIDs)
+}
+
 // Moves ack level to the new level if it is higher than the current one.
 // Also updates the read level it is lower than the ackLevel.
+// Tasks below the new ack level are not tracked anymore.
 func (m *ackManager) setAckLevel(ackLevel int64) {
 	if ackLevel > m.ackLevel {
 		m.ackLevel = ackLevel
@@ -65,22 +81,21 @@ func (m *ackManager) setAckLevel(ackLevel int64) {
 	if ackLevel > m.readLevel {
 		m.readLevel = ackLevel
 	}
+	for len(m.taskIDs) > 0 && m.taskIDs[0] <= m.ackLevel {
+		delete(m.outstandingTasks, m.taskIDs[0])
+		m.taskIDs = m.taskIDs[1:]
+	}
 }
 
+// Marks the task as acked and moves the ack level over all the acked tasks at the front of outstanding tasks.
 func (m *ackManager) completeTask(taskID int64) (ackLevel int64) {
 	if _, ok := m.outstandingTasks[taskID]; ok {
 		m.outstandingTasks[taskID] = true
 	}
-	// Update ackLevel
-	for current := m.ackLevel + 1; current <= m.readLevel; current++ {
-		if acked, ok := m.outstandingTasks[current]; ok {
-			if acked {
-				m.ackLevel = current
-				delete(m.outstandingTasks, current)
-			} else {
-				return m.ackLevel
-			}
-		}
+	for len(m.taskIDs) > 0 && m.outstandingTasks[m.taskIDs[0]] {
+		m.ackLevel = m.taskIDs[0]
+		delete(m.outstandingTasks, m.taskIDs[0])
+		m.taskIDs = m.taskIDs[1:]
 	}
 	return m.ackLevel
 }
@@ -0,0 +1,78 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"time"
+)
+
+// Config represents configuration for cadence-matching service
+type Config struct {
+	// LongPollExpirationInterval is how long a poll waits for a task before an empty response is returned
+	LongPollExpirationInterval time.Duration
+	// TaskListIdleTimeout is how long a task list without pollers and tasks stays loaded
+	TaskListIdleTimeout time.Duration
+	// TaskListScavengerInterval is the interval between sweeps deleting expired task lists
+	TaskListScavengerInterval time.Duration
+	// TaskListExpiry is how long an unused task list is kept in persistence
+	TaskListExpiry time.Duration
+	// TaskListWarmUpWindow is how recently a task list must have been used to be loaded ahead of its first
+	// request, when the membership changes and the hash ring assigns it to the host. Disabled when 0.
+	TaskListWarmUpWindow time.Duration
+	// HighPriorityDispatchWeight is how many buffered high priority tasks are offered to pollers first
+	// before normal priority tasks get a chance again
+	HighPriorityDispatchWeight int32
+	// MaxDeferredTasks is how many tasks loaded from persistence a task list holds in memory, because no poller
+	// could take them so far.  The task list stops reading tasks from persistence while the limit is reached.
+	MaxDeferredTasks int
+	// SessionHeartbeatTimeout is how long a poller owning activity sessions can go without polling before
+	// its sessions fail over to other pollers
+	SessionHeartbeatTimeout time.Duration
+	// SessionIdleTimeout is how long a session stays bound to its poller after its last task was dispatched, the
+	// next task of a session idle for longer goes to any poller
+	SessionIdleTimeout time.Duration
+	// PollerRetention is how long a poller which stopped polling is kept in the poller registry persisted with
+	// its task list
+	PollerRetention time.Duration
+	// AsyncTaskWriteTaskLists are the names of the task lists whose added tasks are acknowledged once recorded in
+	// a local write log under AsyncTaskWriteLogDir, they are written to persistence asynchronously in batches.
+	// This trades the durability of tasks on host loss for the throughput of adding tasks.
+	AsyncTaskWriteTaskLists map[string]bool
+	// AsyncTaskWriteLogDir is the directory of the write logs of AsyncTaskWriteTaskLists, async writes are
+	// disabled when empty
+	AsyncTaskWriteLogDir string
+}
+
+// NewConfig returns new service config with default values
+func NewConfig() *Config {
+	return &Config{
+		LongPollExpirationInterval: time.Minute,
+		TaskListIdleTimeout:        5 * time.Minute,
+		TaskListScavengerInterval:  time.Hour,
+		TaskListExpiry:             7 * 24 * time.Hour,
+		TaskListWarmUpWindow:       10 * time.Minute,
+		HighPriorityDispatchWeight: 4,
+		MaxDeferredTasks:           1000,
+		SessionHeartbeatTimeout:    time.Minute,
+		SessionIdleTimeout:         10 * time.Minute,
+		PollerRetention:            24 * time.Hour,
+	}
+}
@@ -21,12 +21,19 @@
 package matching
 
 import (
+	"runtime/debug"
 	"sync"
 
+	"github.com/uber-common/bark"
 	m "github.com/uber/cadence/.gen/go/matching"
 	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/errors"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/tracing"
 	"github.com/uber/tchannel-go/thrift"
 )
 
@@ -35,16 +42,19 @@ var _ m.TChanMatchingService = (*Handler)(nil)
 // Handler - Thrift handler inteface for history service
 type Handler struct {
 	taskPersistence persistence.TaskManager
+	config          *Config
 	engine          Engine
 	startWG         sync.WaitGroup
 	service.Service
 }
 
 // NewHandler creates a thrift handler for the history service
-func NewHandler(taskPersistence persistence.TaskManager, sVice service.Service) (*Handler, []thrift.TChanServer) {
+func NewHandler(taskPersistence persistence.TaskManager, config *Config,
+	sVice service.Service) (*Handler, []thrift.TChanServer) {
 	handler := &Handler{
 		Service:         sVice,
 		taskPersistence: taskPersistence,
+		config:          config,
 	}
 	// prevent us from trying to serve requests before matching engine is started and ready
 	handler.startWG.Add(1)
@@ -58,13 +68,26 @@ func (h *Handler) Start(thriftService []thrift.TChanServer) error {
 	if err != nil {
 		return err
 	}
-	h.engine = NewEngine(h.taskPersistence, history, h.Service.GetLogger())
+	matching, err := h.Service.GetClientFactory().NewMatchingClient()
+	if err != nil {
+		return err
+	}
+	resolver, err := h.Service.GetMembershipMonitor().GetResolver(common.MatchingServiceName)
+	if err != nil {
+		return err
+	}
+	h.engine = NewEngine(h.taskPersistence, history, matching, resolver, h.Service.GetHostInfo(),
+		h.Service.GetTaskTokenSerializer(), h.config, h.Service.GetLogger(), h.Service.GetMetricsClient())
+	h.engine.Start()
 	h.startWG.Done()
 	return nil
 }
 
 // Stop stops the handler
 func (h *Handler) Stop() {
+	if h.engine != nil {
+		h.engine.Stop()
+	}
 	h.Service.Stop()
 }
 
@@ -75,36 +98,55 @@ func (h *Handler) IsHealthy(ctx thrift.Context) (bool, error) {
 }
 
 // AddActivityTask - adds an activity task.
-func (h *Handler) AddActivityTask(ctx thrift.Context, addRequest *m.AddActivityTaskRequest) error {
-	h.Service.GetLogger().Debug("Engine Received AddActivityTask")
+func (h *Handler) AddActivityTask(ctx thrift.Context, addRequest *m.AddActivityTaskRequest) (retError error) {
+	h.getLogger(ctx).Debug("Engine Received AddActivityTask")
 	h.startWG.Wait()
-	return h.engine.AddActivityTask(addRequest)
+	defer h.recoverPanic(ctx, metrics.MatchingAddActivityTaskScope, &retError)
+	return h.engine.AddActivityTask(ctx, addRequest)
 }
 
 // AddDecisionTask - adds a decision task.
-func (h *Handler) AddDecisionTask(ctx thrift.Context, addRequest *m.AddDecisionTaskRequest) error {
-	h.Service.GetLogger().Debug("Engine Received AddDecisionTask")
+func (h *Handler) AddDecisionTask(ctx thrift.Context, addRequest *m.AddDecisionTaskRequest) (retError error) {
+	h.getLogger(ctx).Debug("Engine Received AddDecisionTask")
 	h.startWG.Wait()
-	return h.engine.AddDecisionTask(addRequest)
+	defer h.recoverPanic(ctx, metrics.MatchingAddDecisionTaskScope, &retError)
+	return h.engine.AddDecisionTask(ctx, addRequest)
 }
 
 // PollForActivityTask - long poll for an activity task.
 func (h *Handler) PollForActivityTask(ctx thrift.Context,
-	pollRequest *m.PollForActivityTaskRequest) (*gen.PollForActivityTaskResponse, error) {
-	h.Service.GetLogger().Debug("Engine Received PollForActivityTask")
+	pollRequest *m.PollForActivityTaskRequest) (resp *gen.PollForActivityTaskResponse, retError error) {
+	h.getLogger(ctx).Debug("Engine Received PollForActivityTask")
 	h.startWG.Wait()
+	defer h.recoverPanic(ctx, metrics.MatchingPollForActivityTaskScope, &retError)
 	response, error := h.engine.PollForActivityTask(ctx, pollRequest)
-	h.Service.GetLogger().Debug("Engine returned from PollForActivityTask")
+	h.getLogger(ctx).Debug("Engine returned from PollForActivityTask")
 	return response, error
 
 }
 
 // PollForDecisionTask - long poll for a decision task.
 func (h *Handler) PollForDecisionTask(ctx thrift.Context,
-	pollRequest *m.PollForDecisionTaskRequest) (*m.PollForDecisionTaskResponse, error) {
-	h.Service.GetLogger().Debug("Engine Received PollForDecisionTask")
+	pollRequest *m.PollForDecisionTaskRequest) (resp *m.PollForDecisionTaskResponse, retError error) {
+	h.getLogger(ctx).Debug("Engine Received PollForDecisionTask")
 	h.startWG.Wait()
+	defer h.recoverPanic(ctx, metrics.MatchingPollForDecisionTaskScope, &retError)
 	response, error := h.engine.PollForDecisionTask(ctx, pollRequest)
-	h.Service.GetLogger().Debug("Engine returned from PollForDecisionTask")
+	h.getLogger(ctx).Debug("Engine returned from PollForDecisionTask")
 	return response, error
 }
+
+// getLogger returns the service logger tagged with the id of the request served by ctx
+func (h *Handler) getLogger(ctx thrift.Context) bark.Logger {
+	return tracing.WithRequestID(h.GetLogger(), ctx)
+}
+
+// recoverPanic turns a panic in a matching API call into an InternalServiceError for the caller instead of
+// crashing the matching host
+func (h *Handler) recoverPanic(ctx thrift.Context, scope int, retError *error) {
+	if p := recover(); p != nil {
+		logging.LogPanicRecoveredEvent(h.getLogger(ctx), p, debug.Stack())
+		h.GetMetricsClient().IncCounter(scope, metrics.CadenceErrPanicCounter)
+		*retError = errors.NewInternalServiceError("Internal error: %v", p)
+	}
+}
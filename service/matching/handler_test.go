@@ -0,0 +1,58 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+
+	m "github.com/uber/cadence/.gen/go/matching"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+)
+
+// nilEngine panics with a nil pointer dereference on every call
+type nilEngine struct {
+	Engine
+}
+
+func TestHandlerRecoversFromPanic(t *testing.T) {
+	sVice := service.New(&service.BootstrapParams{
+		Name:               common.MatchingServiceName,
+		Logger:             bark.NewLoggerFromLogrus(log.New()),
+		MetricScope:        tally.NoopScope,
+		PersistenceFactory: struct{ persistence.Factory }{},
+	})
+	handler := &Handler{Service: sVice, engine: &nilEngine{}}
+
+	err := handler.AddActivityTask(nil, &m.AddActivityTaskRequest{})
+	assert.IsType(t, &workflow.InternalServiceError{}, err)
+
+	resp, err := handler.PollForDecisionTask(nil, &m.PollForDecisionTaskRequest{})
+	assert.Nil(t, resp)
+	assert.IsType(t, &workflow.InternalServiceError{}, err)
+}
@@ -22,7 +22,9 @@ package matching
 
 import (
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pborman/uuid"
@@ -34,10 +36,14 @@ import (
 	m "github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/tracing"
 	"github.com/uber/tchannel-go/thrift"
 )
 
@@ -47,12 +53,24 @@ import (
 type matchingEngineImpl struct {
 	taskManager                persistence.TaskManager
 	historyService             history.Client
+	matchingClient             matching.Client // hands off the write logs of task lists taken over by other hosts
 	tokenSerializer            common.TaskTokenSerializer
 	rangeSize                  int64
+	config                     *Config
 	logger                     bark.Logger
+	metricsClient              metrics.Client
 	longPollExpirationInterval time.Duration
-	taskListsLock              sync.RWMutex                   // locks mutation of taskLists
+	leaseHandoffInterval       time.Duration
+	taskListsLock              sync.RWMutex                   // locks mutation of taskLists and leaseLostTaskLists
 	taskLists                  map[taskListID]taskListManager // Convert to LRU cache
+	// Task lists which lost their lease to another host, with the time the lease was lost.
+	// They are not leased again before leaseHandoffInterval passed, so pollers and tasks that
+	// still arrive here don't steal the task list back from its new owner.
+	leaseLostTaskLists map[taskListID]time.Time
+	scavenger          *taskListScavenger
+	warmer             *taskListWarmer
+	stopped            int32
+	shutdownCh         chan struct{} // Closed on Stop to return the polls in flight
 }
 
 type taskListID struct {
@@ -62,9 +80,9 @@ type taskListID struct {
 }
 
 const (
-	defaultLongPollExpirationInterval = time.Minute
-	emptyGetRetryInitialInterval      = 100 * time.Millisecond
-	emptyGetRetryMaxInterval          = 1 * time.Second
+	defaultLeaseHandoffInterval  = 10 * time.Second
+	emptyGetRetryInitialInterval = 100 * time.Millisecond
+	emptyGetRetryMaxInterval     = 1 * time.Second
 )
 
 var (
@@ -78,15 +96,12 @@ var (
 	// ErrNoTasks is exported temporarily for integration test
 	ErrNoTasks    = errors.New("No tasks")
 	errPumpClosed = errors.New("Task list pump closed its channel")
+	// errTaskListLeaseLost is returned when the task list is owned by another host, callers retry
+	errTaskListLeaseLost = &workflow.ServiceBusyError{Message: "Task list is owned by another host"}
 )
 
 func (t *taskListID) String() string {
-	var r string
-	if t.taskType == persistence.TaskListTypeActivity {
-		r += "activity"
-	} else {
-		r += "decision"
-	}
+	r := taskTypeName(t.taskType)
 	r += " task list \""
 	r += t.taskListName
 	r += "\""
@@ -96,25 +111,58 @@ func (t *taskListID) String() string {
 var _ Engine = (*matchingEngineImpl)(nil) // Asserts that interface is indeed implemented
 
 // NewEngine creates an instance of matching engine
-func NewEngine(taskManager persistence.TaskManager, historyService history.Client, logger bark.Logger) Engine {
-	return &matchingEngineImpl{
+func NewEngine(taskManager persistence.TaskManager, historyService history.Client, matchingClient matching.Client,
+	resolver membership.ServiceResolver, host *membership.HostInfo, tokenSerializer common.TaskTokenSerializer,
+	config *Config, logger bark.Logger, metricsClient metrics.Client) Engine {
+	e := &matchingEngineImpl{
 		taskManager:                taskManager,
 		historyService:             historyService,
-		tokenSerializer:            common.NewJSONTaskTokenSerializer(),
+		matchingClient:             matchingClient,
+		tokenSerializer:            tokenSerializer,
 		taskLists:                  make(map[taskListID]taskListManager),
+		leaseLostTaskLists:         make(map[taskListID]time.Time),
 		rangeSize:                  defaultRangeSize,
-		longPollExpirationInterval: defaultLongPollExpirationInterval,
+		config:                     config,
+		metricsClient:              metricsClient,
+		longPollExpirationInterval: config.LongPollExpirationInterval,
+		leaseHandoffInterval:       defaultLeaseHandoffInterval,
+		shutdownCh:                 make(chan struct{}),
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueMatchingEngineComponent,
 		}),
 	}
+	e.scavenger = newTaskListScavenger(e)
+	if config.TaskListWarmUpWindow > 0 {
+		e.warmer = newTaskListWarmer(e, resolver, host)
+	}
+	return e
 }
 
 func (e *matchingEngineImpl) Start() {
-	// As task lists are initialized lazily nothing is done on startup at this point.
+	// Task lists are initialized lazily, or ahead of their first request by the warmer on membership changes.
+	if e.scavenger != nil {
+		e.scavenger.Start()
+	}
+	if e.warmer != nil {
+		e.warmer.Start()
+	}
+	if e.leaseHandoffInterval > 0 {
+		go e.sweepLeaseLostTaskListsLoop()
+	}
 }
 
 func (e *matchingEngineImpl) Stop() {
+	if !atomic.CompareAndSwapInt32(&e.stopped, 0, 1) {
+		return
+	}
+	// Return the polls in flight right away, so pollers reconnect to other hosts
+	close(e.shutdownCh)
+	if e.scavenger != nil {
+		e.scavenger.Stop()
+	}
+	if e.warmer != nil {
+		e.warmer.Stop()
+	}
 	// Executes Stop() on each task list outside of lock
 	for _, l := range e.getTaskLists(math.MaxInt32) {
 		l.Stop()
@@ -160,6 +208,13 @@ func (e *matchingEngineImpl) getTaskListManager(taskList *taskListID) (taskListM
 		e.taskListsLock.Unlock()
 		return result, nil
 	}
+	if lostTime, ok := e.leaseLostTaskLists[*taskList]; ok {
+		if time.Since(lostTime) < e.leaseHandoffInterval {
+			e.taskListsLock.Unlock()
+			return nil, errTaskListLeaseLost
+		}
+		delete(e.leaseLostTaskLists, *taskList)
+	}
 	e.taskLists[*taskList] = mgr
 	e.taskListsLock.Unlock()
 
@@ -171,101 +226,189 @@ func (e *matchingEngineImpl) getTaskListManager(taskList *taskListID) (taskListM
 	return mgr, nil
 }
 
-func (e *matchingEngineImpl) removeTaskListManager(id *taskListID) {
+func (e *matchingEngineImpl) isTaskListLoaded(id *taskListID) bool {
+	e.taskListsLock.RLock()
+	defer e.taskListsLock.RUnlock()
+	_, ok := e.taskLists[*id]
+	return ok
+}
+
+// Removes the task list manager unless it was already replaced by a new one
+func (e *matchingEngineImpl) removeTaskListManager(tlMgr *taskListManagerImpl) {
+	e.taskListsLock.Lock()
+	defer e.taskListsLock.Unlock()
+	e.removeTaskListManagerLocked(tlMgr)
+}
+
+// Removes the task list manager which lost its lease and holds the task list back from being
+// leased again for leaseHandoffInterval
+func (e *matchingEngineImpl) removeLeaseLostTaskListManager(tlMgr *taskListManagerImpl) {
+	e.taskListsLock.Lock()
+	defer e.taskListsLock.Unlock()
+	e.removeTaskListManagerLocked(tlMgr)
+	if e.leaseHandoffInterval > 0 {
+		e.leaseLostTaskLists[*tlMgr.taskListID] = time.Now()
+	}
+}
+
+// sweepLeaseLostTaskListsLoop periodically forgets the task lists whose lease handoff is over. Task lists which are
+// not requested again after losing their lease would otherwise stay in leaseLostTaskLists until the host restarts.
+func (e *matchingEngineImpl) sweepLeaseLostTaskListsLoop() {
+	ticker := time.NewTicker(e.leaseHandoffInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.shutdownCh:
+			return
+		case <-ticker.C:
+			e.sweepLeaseLostTaskLists()
+		}
+	}
+}
+
+func (e *matchingEngineImpl) sweepLeaseLostTaskLists() {
 	e.taskListsLock.Lock()
 	defer e.taskListsLock.Unlock()
-	delete(e.taskLists, *id)
+	for id, lostTime := range e.leaseLostTaskLists {
+		if time.Since(lostTime) >= e.leaseHandoffInterval {
+			delete(e.leaseLostTaskLists, id)
+		}
+	}
+}
+
+func (e *matchingEngineImpl) removeTaskListManagerLocked(tlMgr *taskListManagerImpl) {
+	if current, ok := e.taskLists[*tlMgr.taskListID]; ok && current == tlMgr {
+		delete(e.taskLists, *tlMgr.taskListID)
+	}
 }
 
 // AddDecisionTask either delivers task directly to waiting poller or save it into task list persistence.
-func (e *matchingEngineImpl) AddDecisionTask(addRequest *m.AddDecisionTaskRequest) error {
+func (e *matchingEngineImpl) AddDecisionTask(ctx thrift.Context, addRequest *m.AddDecisionTaskRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "MatchingEngine.AddDecisionTask")
+	defer span.Finish()
+
 	domainID := addRequest.GetDomainUUID()
 	taskListName := addRequest.GetTaskList().GetName()
-	e.logger.Debugf("Received AddDecisionTask for taskList=%v, WorkflowID=%v, RunID=%v",
+	tracing.WithRequestID(e.logger, ctx).Debugf("Received AddDecisionTask for taskList=%v, WorkflowID=%v, RunID=%v",
 		addRequest.TaskList.Name, addRequest.Execution.WorkflowId, addRequest.Execution.RunId)
 	taskList := newTaskListID(domainID, taskListName, persistence.TaskListTypeDecision)
-	tlMgr, err := e.getTaskListManager(taskList)
-	if err != nil {
-		return err
-	}
 	taskInfo := &persistence.TaskInfo{
 		DomainID:   domainID,
 		RunID:      addRequest.GetExecution().GetRunId(),
 		WorkflowID: addRequest.GetExecution().GetWorkflowId(),
 		ScheduleID: addRequest.GetScheduleId(),
+		BuildID:    addRequest.GetBuildId(),
+		QueryID:    addRequest.GetQueryId(),
 	}
-	return tlMgr.AddTask(addRequest.GetExecution(), taskInfo)
+	if taskInfo.QueryID != "" {
+		tlMgr, err := e.getTaskListManager(taskList)
+		if err != nil {
+			return err
+		}
+		return tlMgr.DispatchQueryTask(ctx, taskInfo)
+	}
+	return e.addTask(ctx, taskList, addRequest.GetExecution(), taskInfo)
 }
 
 // AddActivityTask either delivers task directly to waiting poller or save it into task list persistence.
-func (e *matchingEngineImpl) AddActivityTask(addRequest *m.AddActivityTaskRequest) error {
+func (e *matchingEngineImpl) AddActivityTask(ctx thrift.Context, addRequest *m.AddActivityTaskRequest) error {
+	span, ctx := tracing.StartThriftSpan(ctx, "MatchingEngine.AddActivityTask")
+	defer span.Finish()
+
 	domainID := addRequest.GetDomainUUID()
 	sourceDomainID := addRequest.GetSourceDomainUUID()
 	taskListName := addRequest.GetTaskList().GetName()
-	e.logger.Debugf("Received AddActivityTask for taskList=%v WorkflowID=%v, RunID=%v",
+	tracing.WithRequestID(e.logger, ctx).Debugf("Received AddActivityTask for taskList=%v WorkflowID=%v, RunID=%v",
 		taskListName, addRequest.Execution.WorkflowId, addRequest.Execution.RunId)
 	taskList := newTaskListID(domainID, taskListName, persistence.TaskListTypeActivity)
-	tlMgr, err := e.getTaskListManager(taskList)
-	if err != nil {
-		return err
-	}
 	taskInfo := &persistence.TaskInfo{
 		DomainID:               sourceDomainID,
 		RunID:                  addRequest.GetExecution().GetRunId(),
 		WorkflowID:             addRequest.GetExecution().GetWorkflowId(),
 		ScheduleID:             addRequest.GetScheduleId(),
 		ScheduleToStartTimeout: addRequest.GetScheduleToStartTimeoutSeconds(),
+		Priority:               addRequest.GetPriority(),
+		SessionID:              addRequest.GetSessionId(),
 	}
-	return tlMgr.AddTask(addRequest.GetExecution(), taskInfo)
+	return e.addTask(ctx, taskList, addRequest.GetExecution(), taskInfo)
+}
+
+// handOffTask adds a task acknowledged by this host without being written to persistence to the task list
+// through the host owning the task list now
+func (e *matchingEngineImpl) handOffTask(ctx thrift.Context, taskList *taskListID, entry *taskWriteLogEntry) error {
+	if e.matchingClient == nil {
+		return errors.New("no matching client to hand off tasks through")
+	}
+	info := entry.TaskInfo
+	switch taskList.taskType {
+	case persistence.TaskListTypeDecision:
+		return e.matchingClient.AddDecisionTask(ctx, &m.AddDecisionTaskRequest{
+			DomainUUID: common.StringPtr(taskList.domainID),
+			Execution:  entry.Execution,
+			TaskList:   &workflow.TaskList{Name: common.StringPtr(taskList.taskListName)},
+			ScheduleId: common.Int64Ptr(info.ScheduleID),
+			BuildId:    common.StringPtr(info.BuildID),
+		})
+	case persistence.TaskListTypeActivity:
+		return e.matchingClient.AddActivityTask(ctx, &m.AddActivityTaskRequest{
+			DomainUUID:                    common.StringPtr(taskList.domainID),
+			SourceDomainUUID:              common.StringPtr(info.DomainID),
+			Execution:                     entry.Execution,
+			TaskList:                      &workflow.TaskList{Name: common.StringPtr(taskList.taskListName)},
+			ScheduleId:                    common.Int64Ptr(info.ScheduleID),
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(info.ScheduleToStartTimeout),
+			Priority:                      common.Int32Ptr(info.Priority),
+			SessionId:                     common.StringPtr(info.SessionID),
+		})
+	}
+	return fmt.Errorf("tasks of %v task lists can't be handed off", taskTypeName(taskList.taskType))
+}
+
+// addTask either delivers a task of any type directly to a waiting poller or saves it into task list persistence.
+func (e *matchingEngineImpl) addTask(ctx thrift.Context, taskList *taskListID, execution *workflow.WorkflowExecution,
+	taskInfo *persistence.TaskInfo) error {
+	tlMgr, err := e.getTaskListManager(taskList)
+	if err != nil {
+		return err
+	}
+	return tlMgr.AddTask(ctx, execution, taskInfo)
 }
 
 // PollForDecisionTask tries to get the decision task using exponential backoff.
 func (e *matchingEngineImpl) PollForDecisionTask(ctx thrift.Context, req *m.PollForDecisionTaskRequest) (
 	*m.PollForDecisionTaskResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "MatchingEngine.PollForDecisionTask")
+	defer span.Finish()
+
 	domainID := req.GetDomainUUID()
 	request := req.GetPollRequest()
 	taskListName := request.GetTaskList().GetName()
-	e.logger.Debugf("Received PollForDecisionTask for taskList=%v", taskListName)
-pollLoop:
-	for {
-		err := common.IsValidContext(ctx)
-		if err != nil {
-			return nil, err
-		}
-
-		taskList := newTaskListID(domainID, taskListName, persistence.TaskListTypeDecision)
-		tCtx, err := e.getTask(ctx, taskList)
-		if err != nil {
-			// TODO: Is empty poll the best reply for errPumpClosed?
-			if err == ErrNoTasks || err == errPumpClosed {
-				return emptyPollForDecisionTaskResponse, nil
+	tracing.WithRequestID(e.logger, ctx).Debugf("Received PollForDecisionTask for taskList=%v", taskListName)
+	taskList := newTaskListID(domainID, taskListName, persistence.TaskListTypeDecision)
+	poller := &pollerInfo{identity: request.GetIdentity(), compatibleBuildIDs: request.GetCompatibleBuildIds()}
+	tCtx, resp, err := e.pollTask(ctx, taskList, poller,
+		func(ctx thrift.Context, tCtx *taskContext, requestID string) (interface{}, error) {
+			startRequest := &h.RecordDecisionTaskStartedRequest{
+				DomainUUID:        common.StringPtr(domainID),
+				WorkflowExecution: &tCtx.workflowExecution,
+				ScheduleId:        &tCtx.info.ScheduleID,
+				TaskId:            &tCtx.info.TaskID,
+				RequestId:         common.StringPtr(requestID),
+				PollRequest:       request,
 			}
-			return nil, err
-		}
-
-		// Generate a unique requestId for this task which will be used for all retries
-		requestID := uuid.New()
-		resp, err := tCtx.RecordDecisionTaskStartedWithRetry(&h.RecordDecisionTaskStartedRequest{
-			DomainUUID:        common.StringPtr(domainID),
-			WorkflowExecution: &tCtx.workflowExecution,
-			ScheduleId:        &tCtx.info.ScheduleID,
-			TaskId:            &tCtx.info.TaskID,
-			RequestId:         common.StringPtr(requestID),
-			PollRequest:       request,
-		})
-		if err != nil {
-			if _, ok := err.(*workflow.EntityNotExistsError); ok {
-				e.logger.Debugf("Duplicated decision task taskList=%v, taskID=",
-					taskListName, tCtx.info.TaskID)
-				tCtx.completeTask(nil)
-				continue pollLoop
+			if tCtx.info.QueryID != "" {
+				startRequest.QueryId = common.StringPtr(tCtx.info.QueryID)
 			}
-			tCtx.completeTask(err)
-			continue pollLoop
-		}
-		tCtx.completeTask(nil)
-		return e.createPollForDecisionTaskResponse(tCtx, resp), nil
+			return tCtx.RecordDecisionTaskStartedWithRetry(ctx, startRequest)
+		})
+	if err == ErrNoTasks {
+		return emptyPollForDecisionTaskResponse, nil
+	}
+	if err != nil {
+		return nil, err
 	}
+	return e.createPollForDecisionTaskResponse(tCtx, resp.(*h.RecordDecisionTaskStartedResponse)), nil
 }
 
 // pollForActivityTaskOperation takes one task from the task manager, update workflow execution history, mark task as
@@ -273,70 +416,106 @@ pollLoop:
 // error. Timeouts handled by the timer queue.
 func (e *matchingEngineImpl) PollForActivityTask(ctx thrift.Context, req *m.PollForActivityTaskRequest) (
 	*workflow.PollForActivityTaskResponse, error) {
+	span, ctx := tracing.StartThriftSpan(ctx, "MatchingEngine.PollForActivityTask")
+	defer span.Finish()
+
 	domainID := req.GetDomainUUID()
 	request := req.GetPollRequest()
 	taskListName := request.GetTaskList().GetName()
-	e.logger.Debugf("Received PollForActivityTask for taskList=%v", taskListName)
+	tracing.WithRequestID(e.logger, ctx).Debugf("Received PollForActivityTask for taskList=%v", taskListName)
+	taskList := newTaskListID(domainID, taskListName, persistence.TaskListTypeActivity)
+	tCtx, resp, err := e.pollTask(ctx, taskList, &pollerInfo{identity: request.GetIdentity()},
+		func(ctx thrift.Context, tCtx *taskContext, requestID string) (interface{}, error) {
+			return tCtx.RecordActivityTaskStartedWithRetry(ctx, &h.RecordActivityTaskStartedRequest{
+				DomainUUID:        common.StringPtr(domainID),
+				WorkflowExecution: &tCtx.workflowExecution,
+				ScheduleId:        &tCtx.info.ScheduleID,
+				TaskId:            &tCtx.info.TaskID,
+				RequestId:         common.StringPtr(requestID),
+				PollRequest:       request,
+			})
+		})
+	if err == ErrNoTasks {
+		return emptyPollForActivityTaskResponse, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e.createPollForActivityTaskResponse(tCtx, resp.(*h.RecordActivityTaskStartedResponse)), nil
+}
+
+// pollTask is the poll loop shared by all task types. It takes tasks from the task list until startTask records one
+// as started and returns it along with the result of startTask. Tasks which are no longer pending at their owner are
+// dropped. Returns ErrNoTasks if the poll ends without a task.
+func (e *matchingEngineImpl) pollTask(ctx thrift.Context, taskList *taskListID, poller *pollerInfo,
+	startTask startTaskFunc) (*taskContext, interface{}, error) {
 pollLoop:
 	for {
 		err := common.IsValidContext(ctx)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		taskList := newTaskListID(domainID, taskListName, persistence.TaskListTypeActivity)
-		tCtx, err := e.getTask(ctx, taskList)
+		tCtx, err := e.getTask(ctx, taskList, poller)
 		if err != nil {
 			// TODO: Is empty poll the best reply for errPumpClosed?
-			if err == ErrNoTasks || err == errPumpClosed {
-				return emptyPollForActivityTaskResponse, nil
+			if err == ErrNoTasks || err == errPumpClosed || err == errTaskListLeaseLost {
+				return nil, nil, ErrNoTasks
 			}
-			return nil, err
+			return nil, nil, err
 		}
+
 		// Generate a unique requestId for this task which will be used for all retries
 		requestID := uuid.New()
-		resp, err := tCtx.RecordActivityTaskStartedWithRetry(&h.RecordActivityTaskStartedRequest{
-			DomainUUID:        common.StringPtr(domainID),
-			WorkflowExecution: &tCtx.workflowExecution,
-			ScheduleId:        &tCtx.info.ScheduleID,
-			TaskId:            &tCtx.info.TaskID,
-			RequestId:         common.StringPtr(requestID),
-			PollRequest:       request,
-		})
+		resp, err := startTask(ctx, tCtx, requestID)
 		if err != nil {
-			if _, ok := err.(*workflow.EntityNotExistsError); ok {
-				e.logger.Debugf("Duplicated activity task taskList=%v, taskID=%v",
-					taskListName, tCtx.info.TaskID)
+			if isTaskNotPendingError(err) {
+				e.logger.Debugf("Dropping %v task taskList=%v, taskID=%v, err=%v",
+					taskTypeName(taskList.taskType), taskList.taskListName, tCtx.info.TaskID, err)
 				tCtx.completeTask(nil)
-				continue pollLoop // Duplicated or cancelled task
+				continue pollLoop // Duplicated, cancelled or timed out task
+			}
+			if isServiceBusyError(err) {
+				// The owner of the task is shedding load, push back on the poller instead of moving on
+				// to the next task
+				tCtx.holdTask(err)
+				return nil, nil, err
 			}
 			tCtx.completeTask(err)
 			continue pollLoop
 		}
 		tCtx.completeTask(nil)
-		return e.createPollForActivityTaskResponse(tCtx, resp), nil
+		return tCtx, resp, nil
 	}
 }
 
 // Loads a task from persistence and wraps it in a task context
-func (e *matchingEngineImpl) getTask(ctx thrift.Context, taskList *taskListID) (*taskContext, error) {
+func (e *matchingEngineImpl) getTask(ctx thrift.Context, taskList *taskListID, poller *pollerInfo) (
+	*taskContext, error) {
+	if atomic.LoadInt32(&e.stopped) == 1 {
+		return nil, ErrNoTasks
+	}
 	tlMgr, err := e.getTaskListManager(taskList)
+	if err == errTaskListLeaseLost {
+		// The task list was just taken over by another host. Hold the poll until pollers are
+		// routed to the new owner instead of taking the lease back.
+		return nil, e.waitForLeaseHandoff(ctx)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return tlMgr.GetTaskContext(ctx)
+	return tlMgr.GetTaskContext(ctx, poller)
 }
 
-func (e *matchingEngineImpl) unloadTaskList(id *taskListID) {
-	e.taskListsLock.Lock()
-	tlMgr, ok := e.taskLists[*id]
-	if ok {
-		delete(e.taskLists, *id)
-	}
-	e.taskListsLock.Unlock()
-	if ok {
-		tlMgr.Stop()
+func (e *matchingEngineImpl) waitForLeaseHandoff(ctx thrift.Context) error {
+	timer := time.NewTimer(e.leaseHandoffInterval)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	case <-e.shutdownCh:
 	}
+	return ErrNoTasks
 }
 
 // Populate the decision task response based on context and scheduled/started events.
@@ -351,6 +530,7 @@ func (e *matchingEngineImpl) createPollForDecisionTaskResponse(context *taskCont
 		WorkflowID: task.WorkflowID,
 		RunID:      task.RunID,
 		ScheduleID: task.ScheduleID,
+		QueryID:    task.QueryID,
 	}
 	response.TaskToken, _ = e.tokenSerializer.Serialize(token)
 	response.WorkflowType = historyResponse.GetWorkflowType()
@@ -358,6 +538,7 @@ func (e *matchingEngineImpl) createPollForDecisionTaskResponse(context *taskCont
 		response.PreviousStartedEventId = historyResponse.PreviousStartedEventId
 	}
 	response.StartedEventId = historyResponse.StartedEventId
+	response.Queries = historyResponse.Queries
 
 	return response
 }
@@ -388,6 +569,8 @@ func (e *matchingEngineImpl) createPollForActivityTaskResponse(context *taskCont
 	response.StartedTimestamp = common.Int64Ptr(startedEvent.GetTimestamp())
 	response.StartToCloseTimeoutSeconds = common.Int32Ptr(attributes.GetStartToCloseTimeoutSeconds())
 	response.HeartbeatTimeoutSeconds = common.Int32Ptr(attributes.GetHeartbeatTimeoutSeconds())
+	response.Header = attributes.Header
+	response.PayloadReference = scheduledEvent.PayloadReference
 
 	token := &common.TaskToken{
 		DomainID:   task.DomainID,
@@ -424,6 +607,20 @@ func isLongPollRetryableError(err error) bool {
 	return false
 }
 
+// isTaskNotPendingError returns true if history rejected a task because its scheduled event is not pending
+// anymore, e.g. the task is a duplicate or the workflow or activity is already completed or timed out.  History
+// reports all of these with EntityNotExistsError.  Such tasks can never be started, so they are completed without
+// handing them to a poller.  Other rejections do not tell whether the task is still pending, so the task is kept.
+func isTaskNotPendingError(err error) bool {
+	_, ok := err.(*workflow.EntityNotExistsError)
+	return ok
+}
+
+func isServiceBusyError(err error) bool {
+	_, ok := err.(*workflow.ServiceBusyError)
+	return ok
+}
+
 func workflowExecutionPtr(execution workflow.WorkflowExecution) *workflow.WorkflowExecution {
 	return &execution
 }
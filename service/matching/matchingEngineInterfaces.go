@@ -23,14 +23,16 @@ package matching
 import (
 	m "github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	"github.com/uber/tchannel-go/thrift"
 )
 
 type (
 	// Engine exposes interfaces for clients to poll for activity and decision tasks.
 	Engine interface {
-		AddDecisionTask(addRequest *m.AddDecisionTaskRequest) error
-		AddActivityTask(addRequest *m.AddActivityTaskRequest) error
+		common.Daemon
+		AddDecisionTask(ctx thrift.Context, addRequest *m.AddDecisionTaskRequest) error
+		AddActivityTask(ctx thrift.Context, addRequest *m.AddActivityTaskRequest) error
 		PollForDecisionTask(ctx thrift.Context, request *m.PollForDecisionTaskRequest) (*m.PollForDecisionTaskResponse, error)
 		PollForActivityTask(ctx thrift.Context, request *m.PollForActivityTaskRequest) (*workflow.PollForActivityTaskResponse, error)
 	}
@@ -23,9 +23,12 @@ package matching
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -35,11 +38,15 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"golang.org/x/net/context"
 
 	gohistory "github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/tchannel-go/thrift"
@@ -109,9 +116,12 @@ func (s *matchingEngineSuite) newMatchingEngine(rangeSize int64) *matchingEngine
 		historyService:             s.historyClient,
 		taskLists:                  make(map[taskListID]taskListManager),
 		logger:                     s.logger,
+		metricsClient:              metrics.NewClient(tally.NoopScope, metrics.Matching),
 		tokenSerializer:            common.NewJSONTaskTokenSerializer(),
 		longPollExpirationInterval: 100 * time.Second, //time.Millisecond,
 		rangeSize:                  rangeSize,
+		config:                     NewConfig(),
+		shutdownCh:                 make(chan struct{}),
 	}
 }
 
@@ -167,6 +177,45 @@ func (s *matchingEngineSuite) TestAckManager() {
 	s.EqualValues(t4, m.getReadLevel())
 }
 
+func (s *matchingEngineSuite) TestAckManagerOutOfOrder() {
+	m := newAckManager(s.logger)
+	m.setAckLevel(100)
+
+	const taskCount = 100
+	var taskIDs []int64
+	for i := int64(1); i <= taskCount; i++ {
+		taskID := 100 + i*1000 // task IDs are sparse across ranges
+		taskIDs = append(taskIDs, taskID)
+		m.addTask(taskID)
+	}
+	s.Equal(taskCount, m.getOutstandingCount())
+
+	// complete the second half first, the ack level must not move over the first half
+	for _, taskID := range taskIDs[taskCount/2:] {
+		s.EqualValues(100, m.completeTask(taskID))
+	}
+	s.Equal(taskCount, m.getOutstandingCount())
+
+	// complete the first half in reverse order
+	for i := taskCount/2 - 1; i > 0; i-- {
+		s.EqualValues(100, m.completeTask(taskIDs[i]))
+	}
+	s.EqualValues(taskIDs[taskCount-1], m.completeTask(taskIDs[0]))
+	s.EqualValues(taskIDs[taskCount-1], m.getReadLevel())
+	s.Equal(0, m.getOutstandingCount())
+
+	// completing a task twice or an unknown task doesn't move the ack level
+	s.EqualValues(taskIDs[taskCount-1], m.completeTask(taskIDs[0]))
+	s.EqualValues(taskIDs[taskCount-1], m.completeTask(taskIDs[taskCount-1]+1))
+
+	// tasks below a new ack level are dropped
+	m.addTask(taskIDs[taskCount-1] + 1)
+	m.addTask(taskIDs[taskCount-1] + 2)
+	m.setAckLevel(taskIDs[taskCount-1] + 1)
+	s.Equal(1, m.getOutstandingCount())
+	s.EqualValues(taskIDs[taskCount-1]+2, m.completeTask(taskIDs[taskCount-1]+2))
+}
+
 func (s *matchingEngineSuite) TestPollForActivityTasksEmptyResult() {
 	s.PollForTasksEmptyResultTest(persistence.TaskListTypeActivity)
 }
@@ -246,7 +295,7 @@ func (s *matchingEngineSuite) AddTasksTest(taskType int) {
 				ScheduleId:       &scheduleID,
 				TaskList:         taskList}
 
-			err = s.matchingEngine.AddActivityTask(&addRequest)
+			err = s.matchingEngine.AddActivityTask(s.callContext, &addRequest)
 		} else {
 			addRequest := matching.AddDecisionTaskRequest{
 				DomainUUID: common.StringPtr(domainID),
@@ -254,7 +303,7 @@ func (s *matchingEngineSuite) AddTasksTest(taskType int) {
 				ScheduleId: &scheduleID,
 				TaskList:   taskList}
 
-			err = s.matchingEngine.AddDecisionTask(&addRequest)
+			err = s.matchingEngine.AddDecisionTask(s.callContext, &addRequest)
 		}
 		s.NoError(err)
 	}
@@ -266,6 +315,128 @@ func (s *matchingEngineSuite) AddTasksTest(taskType int) {
 
 }
 
+func (s *matchingEngineSuite) TestAsyncTaskWrite() {
+	dir, err := ioutil.TempDir("", "matching")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := newTaskListID(domainID, tl, persistence.TaskListTypeActivity)
+	s.matchingEngine.config.AsyncTaskWriteTaskLists = map[string]bool{tl: true}
+	s.matchingEngine.config.AsyncTaskWriteLogDir = dir
+
+	runID := "run1"
+	workflowID := "workflow1"
+	execution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	// a task acknowledged by the previous owner but never written
+	writeLog, entries, err := newTaskWriteLog(dir, tlID)
+	s.NoError(err)
+	s.Empty(entries)
+	s.NoError(writeLog.append(&execution, &persistence.TaskInfo{
+		DomainID: domainID, WorkflowID: workflowID, RunID: runID, ScheduleID: 1000}))
+	writeLog.close()
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+	const taskCount = 20
+	for i := int64(0); i < taskCount; i++ {
+		scheduleID := i * 3
+		addRequest := matching.AddActivityTaskRequest{
+			SourceDomainUUID: common.StringPtr(domainID),
+			DomainUUID:       common.StringPtr(domainID),
+			Execution:        &execution,
+			ScheduleId:       &scheduleID,
+			TaskList:         taskList}
+		s.NoError(s.matchingEngine.AddActivityTask(s.callContext, &addRequest))
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for s.taskManager.getTaskCount(tlID) < taskCount+1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.EqualValues(taskCount+1, s.taskManager.getTaskCount(tlID))
+
+	// all logged tasks are written, the log is truncated
+	files, err := ioutil.ReadDir(dir)
+	s.NoError(err)
+	s.Len(files, 1)
+	s.EqualValues(0, files[0].Size())
+}
+
+func (s *matchingEngineSuite) TestTaskWriteLogKeepsFailedEntries() {
+	dir, err := ioutil.TempDir("", "matching")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+
+	tlID := newTaskListID("domainId", "makeToast", persistence.TaskListTypeActivity)
+	runID := "run1"
+	workflowID := "workflow1"
+	execution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	writeLog, _, err := newTaskWriteLog(dir, tlID)
+	s.NoError(err)
+	var tasks []*persistence.TaskInfo
+	for i := int64(0); i < 3; i++ {
+		task := &persistence.TaskInfo{DomainID: tlID.domainID, WorkflowID: workflowID, RunID: runID, ScheduleID: i}
+		tasks = append(tasks, task)
+		s.NoError(writeLog.append(&execution, task))
+	}
+	s.NoError(writeLog.failed([]*taskWriteLogEntry{{Execution: &execution, TaskInfo: tasks[1]}}))
+	s.NoError(writeLog.flushed(2))
+
+	// the log is still truncated once the following entries are written
+	s.NoError(writeLog.append(&execution, &persistence.TaskInfo{
+		DomainID: tlID.domainID, WorkflowID: workflowID, RunID: runID, ScheduleID: 3}))
+	s.NoError(writeLog.flushed(1))
+	writeLog.close()
+
+	// only the failed entry is written again by the next owner
+	writeLog, entries, err := newTaskWriteLog(dir, tlID)
+	s.NoError(err)
+	defer writeLog.close()
+	s.Len(entries, 1)
+	s.EqualValues(1, entries[0].TaskInfo.ScheduleID)
+}
+
+func (s *matchingEngineSuite) TestAddThenPollCustomTaskType() {
+	const customTaskType = 100
+	taskList := newTaskListID("domainId", "makeToast", customTaskType)
+	s.Equal("type 100 task list \"makeToast\"", taskList.String())
+
+	runID := "run1"
+	workflowID := "workflow1"
+	execution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+	for i := int64(0); i < 2; i++ {
+		taskInfo := &persistence.TaskInfo{
+			DomainID:   taskList.domainID,
+			WorkflowID: workflowID,
+			RunID:      runID,
+			ScheduleID: i,
+			Payload:    []byte(fmt.Sprintf("payload%v", i)),
+		}
+		s.NoError(s.matchingEngine.addTask(s.callContext, taskList, &execution, taskInfo))
+	}
+	s.EqualValues(2, s.taskManager.getTaskCount(taskList))
+
+	var started []int64
+	startTask := func(ctx thrift.Context, tCtx *taskContext, requestID string) (interface{}, error) {
+		started = append(started, tCtx.info.ScheduleID)
+		if tCtx.info.ScheduleID == 0 {
+			return nil, &workflow.EntityNotExistsError{Message: "task not pending"}
+		}
+		return string(tCtx.info.Payload), nil
+	}
+	tCtx, resp, err := s.matchingEngine.pollTask(s.callContext, taskList, &pollerInfo{identity: "identity"}, startTask)
+	s.NoError(err)
+	s.EqualValues(1, tCtx.info.ScheduleID)
+	s.Equal("payload1", resp)
+	// the first task was no longer pending and dropped
+	s.Equal([]int64{0, 1}, started)
+	s.EqualValues(0, s.taskManager.getTaskCount(taskList))
+}
+
 func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 	s.matchingEngine.longPollExpirationInterval = 10 * time.Millisecond
 
@@ -296,7 +467,7 @@ func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 			ScheduleId:       &scheduleID,
 			TaskList:         taskList}
 
-		err := s.matchingEngine.AddActivityTask(&addRequest)
+		err := s.matchingEngine.AddActivityTask(s.callContext, &addRequest)
 		s.NoError(err)
 	}
 	s.EqualValues(taskCount, s.taskManager.getTaskCount(tlID))
@@ -310,7 +481,7 @@ func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 	identity := "nobody"
 
 	// History service is using mock
-	s.historyClient.On("RecordActivityTaskStarted", nil,
+	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
 		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
 		func(ctx thrift.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
 			s.logger.Debug("Mock Received RecordActivityTaskStartedRequest")
@@ -406,7 +577,7 @@ func (s *matchingEngineSuite) TestSyncMatchActivities() {
 	identity := "nobody"
 
 	// History service is using mock
-	s.historyClient.On("RecordActivityTaskStarted", nil,
+	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
 		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
 		func(ctx thrift.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
 			s.logger.Debug("Mock Received RecordActivityTaskStartedRequest")
@@ -448,7 +619,7 @@ func (s *matchingEngineSuite) TestSyncMatchActivities() {
 			Execution:        &workflowExecution,
 			ScheduleId:       &scheduleID,
 			TaskList:         taskList}
-		err := s.matchingEngine.AddActivityTask(&addRequest)
+		err := s.matchingEngine.AddActivityTask(s.callContext, &addRequest)
 		s.NoError(err)
 
 		wg.Wait()
@@ -520,7 +691,7 @@ func (s *matchingEngineSuite) TestConcurrentPublishConsumeActivities() {
 					ScheduleId:       &scheduleID,
 					TaskList:         taskList}
 
-				err := s.matchingEngine.AddActivityTask(&addRequest)
+				err := s.matchingEngine.AddActivityTask(s.callContext, &addRequest)
 				if err != nil {
 					s.logger.Infof("Failure in AddActivityTask: %v", err)
 					i--
@@ -539,7 +710,7 @@ func (s *matchingEngineSuite) TestConcurrentPublishConsumeActivities() {
 	identity := "nobody"
 
 	// History service is using mock
-	s.historyClient.On("RecordActivityTaskStarted", nil,
+	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
 		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
 		func(ctx thrift.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
 			s.logger.Debug("Mock Received RecordActivityTaskStartedRequest")
@@ -643,7 +814,7 @@ func (s *matchingEngineSuite) TestConcurrentPublishConsumeDecisions() {
 					ScheduleId: &scheduleID,
 					TaskList:   taskList}
 
-				err := s.matchingEngine.AddDecisionTask(&addRequest)
+				err := s.matchingEngine.AddDecisionTask(s.callContext, &addRequest)
 				if err != nil {
 					panic(err)
 				}
@@ -657,7 +828,7 @@ func (s *matchingEngineSuite) TestConcurrentPublishConsumeDecisions() {
 	identity := "nobody"
 
 	// History service is using mock
-	s.historyClient.On("RecordDecisionTaskStarted", nil,
+	s.historyClient.On("RecordDecisionTaskStarted", mock.Anything,
 		mock.AnythingOfType("*history.RecordDecisionTaskStartedRequest")).Return(
 		func(ctx thrift.Context, taskRequest *gohistory.RecordDecisionTaskStartedRequest) *gohistory.RecordDecisionTaskStartedResponse {
 			s.logger.Debug("Mock Received RecordDecisionTaskStartedRequest")
@@ -753,6 +924,55 @@ func (s *matchingEngineSuite) TestPollWithExpiredContext() {
 
 }
 
+func (s *matchingEngineSuite) TestPollReturnsOnStop() {
+	identity := "nobody"
+	domainID := "domainId"
+	tl := "makeToast"
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+
+	engine := s.newMatchingEngine(defaultRangeSize)
+	engine.Start()
+
+	type pollResult struct {
+		resp *workflow.PollForActivityTaskResponse
+		err  error
+	}
+	resultCh := make(chan pollResult, 1)
+	go func() {
+		resp, err := engine.PollForActivityTask(s.callContext, &matching.PollForActivityTaskRequest{
+			DomainUUID: common.StringPtr(domainID),
+			PollRequest: &workflow.PollForActivityTaskRequest{
+				TaskList: taskList,
+				Identity: &identity},
+		})
+		resultCh <- pollResult{resp: resp, err: err}
+	}()
+
+	// let the poll start waiting for a task
+	time.Sleep(100 * time.Millisecond)
+	engine.Stop()
+
+	select {
+	case result := <-resultCh:
+		s.Nil(result.err)
+		s.Equal(emptyPollForActivityTaskResponse, result.resp)
+	case <-time.After(5 * time.Second):
+		s.Fail("poll did not return on stop")
+	}
+
+	// polls arriving after stop return right away
+	resp, err := engine.PollForActivityTask(s.callContext, &matching.PollForActivityTaskRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: taskList,
+			Identity: &identity},
+	})
+	s.Nil(err)
+	s.Equal(emptyPollForActivityTaskResponse, resp)
+}
+
 func (s *matchingEngineSuite) TestMultipleEnginesActivitiesRangeStealing() {
 	runID := "run1"
 	workflowID := "workflow1"
@@ -793,9 +1013,9 @@ func (s *matchingEngineSuite) TestMultipleEnginesActivitiesRangeStealing() {
 					ScheduleId:       &scheduleID,
 					TaskList:         taskList}
 
-				err := engine.AddActivityTask(&addRequest)
+				err := engine.AddActivityTask(s.callContext, &addRequest)
 				if err != nil {
-					if _, ok := err.(*persistence.ConditionFailedError); ok {
+					if err == errTaskListLeaseLost {
 						i-- // retry adding
 					} else {
 						panic(fmt.Sprintf("errType=%T, err=%v", err, err))
@@ -943,9 +1163,9 @@ func (s *matchingEngineSuite) TestMultipleEnginesDecisionsRangeStealing() {
 					ScheduleId: &scheduleID,
 					TaskList:   taskList}
 
-				err := engine.AddDecisionTask(&addRequest)
+				err := engine.AddDecisionTask(s.callContext, &addRequest)
 				if err != nil {
-					if _, ok := err.(*persistence.ConditionFailedError); ok {
+					if err == errTaskListLeaseLost {
 						i-- // retry adding
 					} else {
 						panic(fmt.Sprintf("errType=%T, err=%v", err, err))
@@ -963,7 +1183,7 @@ func (s *matchingEngineSuite) TestMultipleEnginesDecisionsRangeStealing() {
 	startedTasks := make(map[int64]bool)
 
 	// History service is using mock
-	s.historyClient.On("RecordDecisionTaskStarted", nil,
+	s.historyClient.On("RecordDecisionTaskStarted", mock.Anything,
 		mock.AnythingOfType("*history.RecordDecisionTaskStartedRequest")).Return(
 		func(ctx thrift.Context, taskRequest *gohistory.RecordDecisionTaskStartedRequest) *gohistory.RecordDecisionTaskStartedResponse {
 			if _, ok := startedTasks[*taskRequest.TaskId]; ok {
@@ -1064,16 +1284,16 @@ func (s *matchingEngineSuite) TestAddTaskAfterStartFailure() {
 		ScheduleId:       &scheduleID,
 		TaskList:         taskList}
 
-	err := s.matchingEngine.AddActivityTask(&addRequest)
+	err := s.matchingEngine.AddActivityTask(s.callContext, &addRequest)
 	s.NoError(err)
 	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
 
-	ctx, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID)
+	ctx, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID, nil)
 	s.NoError(err)
 
 	ctx.completeTask(errors.New("test error"))
 	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
-	ctx2, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID)
+	ctx2, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID, nil)
 	s.NoError(err)
 
 	s.NotEqual(ctx.info.TaskID, ctx2.info.TaskID)
@@ -1085,6 +1305,753 @@ func (s *matchingEngineSuite) TestAddTaskAfterStartFailure() {
 	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
 }
 
+func (s *matchingEngineSuite) TestPollDropsTasksNotPending() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+
+	// scheduleID 0: activity already timed out, 1: workflow is gone, 2: still pending but rejected once
+	for scheduleID := int64(0); scheduleID < 3; scheduleID++ {
+		addRequest := matching.AddActivityTaskRequest{
+			SourceDomainUUID: common.StringPtr(domainID),
+			DomainUUID:       common.StringPtr(domainID),
+			Execution:        &workflowExecution,
+			ScheduleId:       common.Int64Ptr(scheduleID),
+			TaskList:         taskList}
+
+		err := s.matchingEngine.AddActivityTask(s.callContext, &addRequest)
+		s.NoError(err)
+	}
+	s.EqualValues(3, s.taskManager.getTaskCount(tlID))
+
+	activityID := "activityId1"
+	identity := "nobody"
+	var recordCalls, badRequests int32
+	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
+		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
+		func(ctx thrift.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
+			if taskRequest.GetScheduleId() < 2 {
+				return nil
+			}
+			return &gohistory.RecordActivityTaskStartedResponse{
+				ScheduledEvent: newActivityTaskScheduledEvent(taskRequest.GetScheduleId(), 0,
+					&workflow.ScheduleActivityTaskDecisionAttributes{
+						ActivityId:   &activityID,
+						TaskList:     &workflow.TaskList{Name: taskList.Name},
+						ActivityType: &workflow.ActivityType{Name: common.StringPtr("activity1")},
+					}),
+				StartedEvent: newActivityTaskStartedEvent(123456, 0, &workflow.PollForActivityTaskRequest{
+					TaskList: &workflow.TaskList{Name: taskList.Name},
+					Identity: &identity,
+				})}
+		},
+		func(ctx thrift.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) error {
+			atomic.AddInt32(&recordCalls, 1)
+			switch taskRequest.GetScheduleId() {
+			case 0:
+				return &workflow.EntityNotExistsError{Message: "Activity task not found."}
+			case 1:
+				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
+			}
+			if atomic.AddInt32(&badRequests, 1) == 1 {
+				return &workflow.BadRequestError{Message: "Invalid WorkflowID."}
+			}
+			return nil
+		})
+
+	result, err := s.matchingEngine.PollForActivityTask(s.callContext, &matching.PollForActivityTaskRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: taskList,
+			Identity: &identity},
+	})
+	s.NoError(err)
+	s.EqualValues(activityID, result.GetActivityId())
+	// dead tasks are neither retried nor written back to the task list, other rejected tasks are retried
+	s.EqualValues(4, atomic.LoadInt32(&recordCalls))
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+}
+
+func (s *matchingEngineSuite) TestPollHoldsTasksOfOverloadedOwner() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+
+	addRequest := matching.AddActivityTaskRequest{
+		SourceDomainUUID: common.StringPtr(domainID),
+		DomainUUID:       common.StringPtr(domainID),
+		Execution:        &workflowExecution,
+		ScheduleId:       common.Int64Ptr(0),
+		TaskList:         taskList}
+	err := s.matchingEngine.AddActivityTask(s.callContext, &addRequest)
+	s.NoError(err)
+	s.EqualValues(1, s.taskManager.getCreateTaskCount(tlID))
+
+	activityID := "activityId1"
+	identity := "nobody"
+	var recordCalls int32
+	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
+		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
+		func(ctx thrift.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
+			return &gohistory.RecordActivityTaskStartedResponse{
+				ScheduledEvent: newActivityTaskScheduledEvent(taskRequest.GetScheduleId(), 0,
+					&workflow.ScheduleActivityTaskDecisionAttributes{
+						ActivityId:   &activityID,
+						TaskList:     &workflow.TaskList{Name: taskList.Name},
+						ActivityType: &workflow.ActivityType{Name: common.StringPtr("activity1")},
+					}),
+				StartedEvent: newActivityTaskStartedEvent(123456, 0, &workflow.PollForActivityTaskRequest{
+					TaskList: &workflow.TaskList{Name: taskList.Name},
+					Identity: &identity,
+				})}
+		},
+		func(ctx thrift.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) error {
+			if atomic.AddInt32(&recordCalls, 1) == 1 {
+				return &workflow.ServiceBusyError{Message: "Shard overloaded.", RetryAfterMillis: common.Int64Ptr(50)}
+			}
+			return nil
+		})
+
+	pollRequest := &matching.PollForActivityTaskRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: taskList,
+			Identity: &identity},
+	}
+	_, err = s.matchingEngine.PollForActivityTask(s.callContext, pollRequest)
+	s.IsType(&workflow.ServiceBusyError{}, err)
+	// the task is neither completed nor written back while the owner is overloaded
+	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
+	s.EqualValues(1, s.taskManager.getCreateTaskCount(tlID))
+
+	start := time.Now()
+	result, err := s.matchingEngine.PollForActivityTask(s.callContext, pollRequest)
+	s.NoError(err)
+	s.EqualValues(activityID, result.GetActivityId())
+	s.True(time.Since(start) >= 40*time.Millisecond)
+	s.EqualValues(2, atomic.LoadInt32(&recordCalls))
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+	s.EqualValues(1, s.taskManager.getCreateTaskCount(tlID))
+}
+
+func (s *matchingEngineSuite) TestSweepLeaseLostTaskLists() {
+	engine := s.newMatchingEngine(defaultRangeSize)
+	engine.leaseHandoffInterval = time.Minute
+	expired := taskListID{domainID: "domainId", taskListName: "expired", taskType: persistence.TaskListTypeActivity}
+	recent := taskListID{domainID: "domainId", taskListName: "recent", taskType: persistence.TaskListTypeActivity}
+	engine.leaseLostTaskLists = map[taskListID]time.Time{
+		expired: time.Now().Add(-2 * time.Minute),
+		recent:  time.Now(),
+	}
+
+	// Only the task lists still within their lease handoff are kept
+	engine.sweepLeaseLostTaskLists()
+	s.Equal(1, len(engine.leaseLostTaskLists))
+	_, ok := engine.leaseLostTaskLists[recent]
+	s.True(ok)
+}
+
+func (s *matchingEngineSuite) TestTaskListLeaseHandoff() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+
+	scheduleID := int64(0)
+	addRequest := matching.AddActivityTaskRequest{
+		SourceDomainUUID: common.StringPtr(domainID),
+		DomainUUID:       common.StringPtr(domainID),
+		Execution:        &workflowExecution,
+		ScheduleId:       &scheduleID,
+		TaskList:         taskList}
+
+	oldOwner := s.newMatchingEngine(defaultRangeSize)
+	oldOwner.leaseLostTaskLists = make(map[taskListID]time.Time)
+	oldOwner.leaseHandoffInterval = time.Minute
+	s.NoError(oldOwner.AddActivityTask(s.callContext, &addRequest))
+	s.EqualValues(1, s.taskManager.getTaskListManager(tlID).rangeID)
+
+	// another host takes over the task list
+	newOwner := s.newMatchingEngine(defaultRangeSize)
+	s.NoError(newOwner.AddActivityTask(s.callContext, &addRequest))
+	s.EqualValues(2, s.taskManager.getTaskListManager(tlID).rangeID)
+
+	// the old owner unloads the task list and doesn't lease it back
+	err := oldOwner.AddActivityTask(s.callContext, &addRequest)
+	s.Equal(errTaskListLeaseLost, err)
+	err = oldOwner.AddActivityTask(s.callContext, &addRequest)
+	s.Equal(errTaskListLeaseLost, err)
+	s.EqualValues(2, s.taskManager.getTaskListManager(tlID).rangeID)
+	s.Equal(0, len(oldOwner.getTaskLists(10)))
+
+	identity := "nobody"
+	ctx, cancel := thrift.NewContext(100 * time.Millisecond)
+	defer cancel()
+	resp, err := oldOwner.PollForActivityTask(ctx, &matching.PollForActivityTaskRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: taskList,
+			Identity: &identity},
+	})
+	s.NoError(err)
+	s.Equal(emptyPollForActivityTaskResponse, resp)
+	s.EqualValues(2, s.taskManager.getTaskListManager(tlID).rangeID)
+
+	oldOwner.Stop()
+	newOwner.Stop()
+	s.EqualValues(2, s.taskManager.getTaskCount(tlID))
+}
+
+func (s *matchingEngineSuite) TestTaskWriteLogHandoff() {
+	dir, err := ioutil.TempDir("", "matching")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+	addRequest := func(scheduleID int64) *matching.AddActivityTaskRequest {
+		return &matching.AddActivityTaskRequest{
+			SourceDomainUUID: common.StringPtr(domainID),
+			DomainUUID:       common.StringPtr(domainID),
+			Execution:        &workflowExecution,
+			ScheduleId:       common.Int64Ptr(scheduleID),
+			TaskList:         taskList}
+	}
+
+	matchingClient := &mocks.MatchingClient{}
+	oldOwner := s.newMatchingEngine(defaultRangeSize)
+	oldOwner.matchingClient = matchingClient
+	oldOwner.leaseLostTaskLists = make(map[taskListID]time.Time)
+	oldOwner.leaseHandoffInterval = time.Minute
+	oldOwner.config.AsyncTaskWriteTaskLists = map[string]bool{tl: true}
+	oldOwner.config.AsyncTaskWriteLogDir = dir
+	s.NoError(oldOwner.AddActivityTask(s.callContext, addRequest(0)))
+	deadline := time.Now().Add(5 * time.Second)
+	for s.taskManager.getTaskCount(tlID) < 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
+
+	// a task acknowledged by the old owner is not written yet when another host takes over the task list
+	tlMgr, err := oldOwner.getTaskListManager(tlID)
+	s.NoError(err)
+	s.NoError(tlMgr.(*taskListManagerImpl).taskWriter.writeLog.append(&workflowExecution, &persistence.TaskInfo{
+		DomainID: domainID, WorkflowID: workflowID, RunID: runID, ScheduleID: 6}))
+	newOwner := s.newMatchingEngine(defaultRangeSize)
+	s.NoError(newOwner.AddActivityTask(s.callContext, addRequest(3)))
+
+	// the task is handed off to the new owner once the old owner unloads the task list
+	handedOff := make(chan struct{})
+	matchingClient.On("AddActivityTask", mock.Anything, mock.MatchedBy(
+		func(request *matching.AddActivityTaskRequest) bool {
+			return request.GetScheduleId() == 6 && request.GetTaskList().GetName() == tl &&
+				request.GetDomainUUID() == domainID && request.GetSourceDomainUUID() == domainID
+		})).Return(nil).Run(func(arguments mock.Arguments) { close(handedOff) }).Once()
+	tlMgr.(*taskListManagerImpl).unload(true)
+	select {
+	case <-handedOff:
+	case <-time.After(5 * time.Second):
+		s.Fail("task was not handed off")
+	}
+
+	// the handed off task is dropped from the write log
+	deadline = time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		files, err := ioutil.ReadDir(dir)
+		s.NoError(err)
+		if len(files) == 1 && files[0].Size() == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	writeLog, entries, err := newTaskWriteLog(dir, tlID)
+	s.NoError(err)
+	writeLog.close()
+	s.Empty(entries)
+	matchingClient.AssertExpectations(s.T())
+
+	oldOwner.Stop()
+	newOwner.Stop()
+}
+
+func (s *matchingEngineSuite) TestIdleTaskList() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+	s.matchingEngine.config.TaskListIdleTimeout = 0
+
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID)
+	s.NoError(err)
+	tlMgrImpl := tlMgr.(*taskListManagerImpl)
+	s.True(tlMgrImpl.isIdle())
+
+	scheduleID := int64(0)
+	err = tlMgr.AddTask(s.callContext, &workflowExecution, &persistence.TaskInfo{
+		DomainID:   domainID,
+		RunID:      runID,
+		WorkflowID: workflowID,
+		ScheduleID: scheduleID,
+	})
+	s.NoError(err)
+
+	ctx, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID, nil)
+	s.NoError(err)
+	s.False(tlMgrImpl.isIdle()) // the task is not acked yet
+
+	ctx.completeTask(nil)
+	s.True(tlMgrImpl.isIdle())
+
+	s.matchingEngine.config.TaskListIdleTimeout = time.Minute
+	s.False(tlMgrImpl.isIdle())
+}
+
+func (s *matchingEngineSuite) TestDecisionTaskBuildCompatibility() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeDecision}
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+
+	for scheduleID := int64(0); scheduleID < 2; scheduleID++ {
+		addRequest := matching.AddDecisionTaskRequest{
+			DomainUUID: common.StringPtr(domainID),
+			Execution:  &workflowExecution,
+			ScheduleId: common.Int64Ptr(scheduleID),
+			TaskList:   taskList,
+			BuildId:    common.StringPtr("build1"),
+		}
+		s.NoError(s.matchingEngine.AddDecisionTask(s.callContext, &addRequest))
+	}
+	s.EqualValues(2, s.taskManager.getTaskCount(tlID))
+
+	// a poller which is not compatible with the build doesn't get the tasks
+	ctx, cancel := thrift.NewContext(100 * time.Millisecond)
+	defer cancel()
+	_, err := s.matchingEngine.getTask(ctx, tlID, &pollerInfo{compatibleBuildIDs: []string{"build2"}})
+	s.Equal(ErrNoTasks, err)
+
+	tCtx, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID,
+		&pollerInfo{compatibleBuildIDs: []string{"build2", "build1"}})
+	s.NoError(err)
+	s.Equal("build1", tCtx.info.BuildID)
+	tCtx.completeTask(nil)
+
+	// pollers which don't declare compatible builds get all the tasks
+	tCtx, err = s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID, nil)
+	s.NoError(err)
+	s.Equal("build1", tCtx.info.BuildID)
+	tCtx.completeTask(nil)
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+}
+
+func (s *matchingEngineSuite) TestDeferredTasksLimit() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeDecision}
+	s.matchingEngine.config.MaxDeferredTasks = 2
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+
+	addTask := func(scheduleID int64, buildID string) {
+		addRequest := matching.AddDecisionTaskRequest{
+			DomainUUID: common.StringPtr(domainID),
+			Execution:  &workflowExecution,
+			ScheduleId: common.Int64Ptr(scheduleID),
+			TaskList:   taskList,
+			BuildId:    common.StringPtr(buildID),
+		}
+		s.NoError(s.matchingEngine.AddDecisionTask(s.callContext, &addRequest))
+	}
+	addTask(0, "build1")
+	addTask(1, "build1")
+
+	// the tasks of build1 are held in memory, they fill up the deferred tasks
+	ctx, cancel := thrift.NewContext(100 * time.Millisecond)
+	defer cancel()
+	_, err := s.matchingEngine.getTask(ctx, tlID, &pollerInfo{compatibleBuildIDs: []string{"build2"}})
+	s.Equal(ErrNoTasks, err)
+
+	// tasks are no longer read from persistence
+	addTask(2, "build2")
+	ctx, cancel = thrift.NewContext(100 * time.Millisecond)
+	defer cancel()
+	_, err = s.matchingEngine.getTask(ctx, tlID, &pollerInfo{compatibleBuildIDs: []string{"build2"}})
+	s.Equal(ErrNoTasks, err)
+	s.EqualValues(3, s.taskManager.getTaskCount(tlID))
+
+	// until a deferred task is taken
+	tCtx, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID,
+		&pollerInfo{compatibleBuildIDs: []string{"build1"}})
+	s.NoError(err)
+	s.Equal("build1", tCtx.info.BuildID)
+	tCtx.completeTask(nil)
+
+	tCtx, err = s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID,
+		&pollerInfo{compatibleBuildIDs: []string{"build2"}})
+	s.NoError(err)
+	s.Equal("build2", tCtx.info.BuildID)
+	tCtx.completeTask(nil)
+	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
+}
+
+func (s *matchingEngineSuite) TestActivityTaskPriority() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+	s.matchingEngine.config.HighPriorityDispatchWeight = 2
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+
+	// scheduleID 0-2 are backfill tasks, 3-5 are added later with a high priority
+	for scheduleID := int64(0); scheduleID < 6; scheduleID++ {
+		addRequest := matching.AddActivityTaskRequest{
+			SourceDomainUUID:              common.StringPtr(domainID),
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &workflowExecution,
+			ScheduleId:                    common.Int64Ptr(scheduleID),
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
+		}
+		if scheduleID >= 3 {
+			addRequest.Priority = common.Int32Ptr(1)
+		}
+		s.NoError(s.matchingEngine.AddActivityTask(s.callContext, &addRequest))
+	}
+	s.EqualValues(6, s.taskManager.getTaskCount(tlID))
+
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID)
+	s.NoError(err)
+	tlMgrImpl := tlMgr.(*taskListManagerImpl)
+	// wait for the pump to load all the tasks, so they are dispatched from the buffers
+	for i := 0; i < 100 && len(tlMgrImpl.taskBuffer)+len(tlMgrImpl.highPriorityTaskBuffer) < 6; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var scheduleIDs []int64
+	for i := 0; i < 6; i++ {
+		tCtx, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID, nil)
+		s.NoError(err)
+		scheduleIDs = append(scheduleIDs, tCtx.info.ScheduleID)
+		tCtx.completeTask(nil)
+	}
+	// high priority tasks are offered first, until the dispatch weight is reached
+	s.Equal([]int64{3, 4}, scheduleIDs[:2])
+	sort.Slice(scheduleIDs, func(i, j int) bool { return scheduleIDs[i] < scheduleIDs[j] })
+	s.Equal([]int64{0, 1, 2, 3, 4, 5}, scheduleIDs)
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+}
+
+func (s *matchingEngineSuite) TestActivityTaskPriorityBehindBacklog() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+
+	addTask := func(scheduleID int64, priority int32) {
+		addRequest := matching.AddActivityTaskRequest{
+			SourceDomainUUID:              common.StringPtr(domainID),
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &workflowExecution,
+			ScheduleId:                    common.Int64Ptr(scheduleID),
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+			Priority:                      common.Int32Ptr(priority),
+		}
+		s.NoError(s.matchingEngine.AddActivityTask(s.callContext, &addRequest))
+	}
+
+	// a backlog of several batches, the pump blocks on the full buffer in the middle of one of them
+	backlog := int64(3 * getTasksBatchSize)
+	for scheduleID := int64(0); scheduleID < backlog; scheduleID++ {
+		addTask(scheduleID, 0)
+	}
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID)
+	s.NoError(err)
+	tlMgrImpl := tlMgr.(*taskListManagerImpl)
+	for i := 0; i < 100 && len(tlMgrImpl.taskBuffer) < taskBufferSize; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.Equal(taskBufferSize, len(tlMgrImpl.taskBuffer))
+
+	addTask(backlog, 1)
+	// the high priority task is read once the batch being buffered is, ahead of the rest of the backlog
+	polls := 0
+	for ; polls < int(backlog); polls++ {
+		tCtx, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID, nil)
+		s.NoError(err)
+		tCtx.completeTask(nil)
+		if tCtx.info.ScheduleID == backlog {
+			break
+		}
+	}
+	s.True(polls <= taskBufferSize+getTasksBatchSize, "high priority task dispatched after %v polls", polls)
+}
+
+func (s *matchingEngineSuite) TestActivityTaskSessionAffinity() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+
+	for scheduleID := int64(0); scheduleID < 3; scheduleID++ {
+		addRequest := matching.AddActivityTaskRequest{
+			SourceDomainUUID:              common.StringPtr(domainID),
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &workflowExecution,
+			ScheduleId:                    common.Int64Ptr(scheduleID),
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
+			SessionId:                     common.StringPtr("session1"),
+		}
+		s.NoError(s.matchingEngine.AddActivityTask(s.callContext, &addRequest))
+	}
+	s.EqualValues(3, s.taskManager.getTaskCount(tlID))
+
+	worker1 := &pollerInfo{identity: "worker1"}
+	worker2 := &pollerInfo{identity: "worker2"}
+
+	// the first poller taking a task of the session becomes its owner
+	tCtx, err := s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID, worker1)
+	s.NoError(err)
+	s.Equal("session1", tCtx.info.SessionID)
+	tCtx.completeTask(nil)
+
+	ctx, cancel := thrift.NewContext(100 * time.Millisecond)
+	defer cancel()
+	_, err = s.matchingEngine.getTask(ctx, tlID, worker2)
+	s.Equal(ErrNoTasks, err)
+
+	tCtx, err = s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID, worker1)
+	s.NoError(err)
+	tCtx.completeTask(nil)
+
+	// the session fails over once its owner stops polling
+	s.matchingEngine.config.SessionHeartbeatTimeout = 0
+	tCtx, err = s.matchingEngine.getTask(common.BackgroundThriftContext(), tlID, worker2)
+	s.NoError(err)
+	s.Equal("session1", tCtx.info.SessionID)
+	tCtx.completeTask(nil)
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+
+	// the session is forgotten once no task of it was dispatched for SessionIdleTimeout
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID)
+	s.NoError(err)
+	tlMgrImpl := tlMgr.(*taskListManagerImpl)
+	s.matchingEngine.config.SessionHeartbeatTimeout = time.Minute
+	tlMgrImpl.releaseLapsedSessions()
+	s.Equal(1, len(tlMgrImpl.sessionOwners))
+	s.matchingEngine.config.SessionIdleTimeout = 0
+	tlMgrImpl.releaseLapsedSessions()
+	s.Empty(tlMgrImpl.sessionOwners)
+}
+
+func (s *matchingEngineSuite) TestQueryTask() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeDecision}
+
+	taskList := workflow.NewTaskList()
+	taskList.Name = &tl
+	addRequest := &matching.AddDecisionTaskRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  &workflowExecution,
+		ScheduleId: common.Int64Ptr(common.EmptyEventID),
+		TaskList:   taskList,
+		QueryId:    common.StringPtr("query1"),
+	}
+
+	// query tasks wait for a poller and are never persisted
+	ctx, cancel := thrift.NewContext(100 * time.Millisecond)
+	defer cancel()
+	s.Equal(context.DeadlineExceeded, s.matchingEngine.AddDecisionTask(ctx, addRequest))
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+
+	workflowType := &workflow.WorkflowType{Name: common.StringPtr("workflowType1")}
+	queries := map[string]*workflow.WorkflowQuery{"query1": {QueryType: common.StringPtr("state")}}
+	isQueryTask := func(request *gohistory.RecordDecisionTaskStartedRequest) bool {
+		return request.GetQueryId() == "query1"
+	}
+	s.historyClient.On("RecordDecisionTaskStarted", mock.Anything, mock.MatchedBy(isQueryTask)).Return(
+		&gohistory.RecordDecisionTaskStartedResponse{
+			StartedEventId: common.Int64Ptr(4),
+			WorkflowType:   workflowType,
+			Queries:        queries,
+		}, nil).Once()
+
+	addErr := make(chan error, 1)
+	go func() {
+		addErr <- s.matchingEngine.AddDecisionTask(s.callContext, addRequest)
+	}()
+	result, err := s.matchingEngine.PollForDecisionTask(s.callContext, &matching.PollForDecisionTaskRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PollRequest: &workflow.PollForDecisionTaskRequest{
+			TaskList: taskList,
+			Identity: common.StringPtr("nobody"),
+		},
+	})
+	s.NoError(err)
+	s.NoError(<-addErr)
+	s.Equal(queries, result.Queries)
+	token, err := s.matchingEngine.tokenSerializer.Deserialize(result.TaskToken)
+	s.NoError(err)
+	s.Equal("query1", token.QueryID)
+	s.Equal(common.EmptyEventID, token.ScheduleID)
+	s.EqualValues(0, s.taskManager.getCreateTaskCount(tlID))
+}
+
+func (s *matchingEngineSuite) TestScavengeExpiredTaskLists() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	expiredID := &taskListID{domainID: domainID, taskListName: "expired", taskType: persistence.TaskListTypeActivity}
+	pendingID := &taskListID{domainID: domainID, taskListName: "pending", taskType: persistence.TaskListTypeActivity}
+	activeID := &taskListID{domainID: domainID, taskListName: "active", taskType: persistence.TaskListTypeActivity}
+
+	for _, id := range []*taskListID{expiredID, pendingID, activeID} {
+		tlMgr, err := s.matchingEngine.getTaskListManager(id)
+		s.NoError(err)
+		if id == pendingID {
+			err = tlMgr.AddTask(s.callContext, &workflowExecution, &persistence.TaskInfo{
+				DomainID:   domainID,
+				RunID:      runID,
+				WorkflowID: workflowID,
+				ScheduleID: 0,
+			})
+			s.NoError(err)
+		}
+		if id != activeID {
+			tlMgr.Stop()
+		}
+		s.taskManager.getTaskListManager(id).lastUpdated = time.Now().Add(-2 * s.matchingEngine.config.TaskListExpiry)
+	}
+
+	newTaskListScavenger(s.matchingEngine).scavenge()
+
+	s.taskManager.Lock()
+	defer s.taskManager.Unlock()
+	s.NotContains(s.taskManager.taskLists, *expiredID)
+	s.Contains(s.taskManager.taskLists, *pendingID)
+	s.Contains(s.taskManager.taskLists, *activeID)
+}
+
+func (s *matchingEngineSuite) TestPollerRegistry() {
+	id := &taskListID{domainID: "domainId", taskListName: "pollers", taskType: persistence.TaskListTypeActivity}
+	tlMgr, err := s.matchingEngine.getTaskListManager(id)
+	s.NoError(err)
+	tlMgrImpl := tlMgr.(*taskListManagerImpl)
+	tlMgrImpl.pollStarted("worker1")
+	tlMgrImpl.pollCompleted("worker1")
+	tlMgrImpl.pollStarted("worker2")
+	tlMgrImpl.Lock()
+	tlMgrImpl.pollerRegistry["lapsed"] = time.Now().Add(-2 * s.matchingEngine.config.PollerRetention)
+	tlMgrImpl.Unlock()
+	tlMgr.Stop()
+
+	pollers := s.taskManager.getTaskListManager(id).pollers
+	s.Equal(2, len(pollers))
+	s.Contains(pollers, "worker1")
+	s.Contains(pollers, "worker2")
+
+	// the registry is loaded again by the next owner of the task list
+	s.matchingEngine.removeTaskListManager(tlMgrImpl)
+	tlMgr, err = s.matchingEngine.getTaskListManager(id)
+	s.NoError(err)
+	tlMgrImpl = tlMgr.(*taskListManagerImpl)
+	tlMgrImpl.Lock()
+	defer tlMgrImpl.Unlock()
+	s.Equal(pollers, tlMgrImpl.pollerRegistry)
+}
+
+func (s *matchingEngineSuite) TestWarmUpOwnedTaskLists() {
+	domainID := "domainId"
+	ownedID := &taskListID{domainID: domainID, taskListName: "owned", taskType: persistence.TaskListTypeDecision}
+	unusedID := &taskListID{domainID: domainID, taskListName: "unused", taskType: persistence.TaskListTypeDecision}
+	otherID := &taskListID{domainID: domainID, taskListName: "other", taskType: persistence.TaskListTypeDecision}
+	for _, id := range []*taskListID{ownedID, unusedID, otherID} {
+		s.taskManager.getTaskListManager(id).lastUpdated = time.Now()
+	}
+	s.taskManager.getTaskListManager(unusedID).lastUpdated = time.Now().Add(
+		-2 * s.matchingEngine.config.TaskListWarmUpWindow)
+
+	self := membership.NewHostInfo("self", nil)
+	resolver := &mocks.ServiceResolver{}
+	resolver.On("Lookup", "owned").Return(self, nil)
+	resolver.On("Lookup", "unused").Return(self, nil)
+	resolver.On("Lookup", "other").Return(membership.NewHostInfo("other", nil), nil)
+
+	newTaskListWarmer(s.matchingEngine, resolver, self).warmUp()
+
+	s.True(s.matchingEngine.isTaskListLoaded(ownedID))
+	s.False(s.matchingEngine.isTaskListLoaded(unusedID))
+	s.False(s.matchingEngine.isTaskListLoaded(otherID))
+	s.EqualValues(1, s.taskManager.getTaskListManager(ownedID).rangeID)
+}
+
 func newActivityTaskScheduledEvent(eventID int64, decisionTaskCompletedEventID int64,
 	scheduleAttributes *workflow.ScheduleActivityTaskDecisionAttributes) *workflow.HistoryEvent {
 	historyEvent := newHistoryEvent(eventID, workflow.EventType_ActivityTaskScheduled)
@@ -1150,10 +2117,13 @@ func (m *testTaskManager) getTaskListManager(id *taskListID) *testTaskListManage
 
 type testTaskListManager struct {
 	sync.Mutex
-	rangeID         int64
-	ackLevel        int64
-	createTaskCount int
-	tasks           *treemap.Map
+	rangeID              int64
+	ackLevel             int64
+	highPriorityAckLevel int64
+	lastUpdated          time.Time
+	pollers              map[string]time.Time
+	createTaskCount      int
+	tasks                *treemap.Map
 }
 
 func Int64Comparator(a, b interface{}) int {
@@ -1174,26 +2144,29 @@ func newTestTaskListManager() *testTaskListManager {
 }
 
 // LeaseTaskList provides a mock function with given fields: request
-func (m *testTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error) {
+func (m *testTaskManager) LeaseTaskList(ctx context.Context, request *persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error) {
 	tlm := m.getTaskListManager(newTaskListID(request.DomainID, request.TaskList, request.TaskType))
 	tlm.Lock()
 	defer tlm.Unlock()
 	tlm.rangeID++
+	tlm.lastUpdated = time.Now()
 	m.logger.Debugf("LeaseTaskList rangeID=%v", tlm.rangeID)
 
 	return &persistence.LeaseTaskListResponse{
 		TaskListInfo: &persistence.TaskListInfo{
-			AckLevel: tlm.ackLevel,
-			DomainID: request.DomainID,
-			Name:     request.TaskList,
-			TaskType: request.TaskType,
-			RangeID:  tlm.rangeID,
+			AckLevel:             tlm.ackLevel,
+			DomainID:             request.DomainID,
+			Name:                 request.TaskList,
+			TaskType:             request.TaskType,
+			RangeID:              tlm.rangeID,
+			Pollers:              tlm.pollers,
+			HighPriorityAckLevel: tlm.highPriorityAckLevel,
 		},
 	}, nil
 }
 
 // UpdateTaskList provides a mock function with given fields: request
-func (m *testTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequest) (*persistence.UpdateTaskListResponse, error) {
+func (m *testTaskManager) UpdateTaskList(ctx context.Context, request *persistence.UpdateTaskListRequest) (*persistence.UpdateTaskListResponse, error) {
 	m.logger.Debugf("UpdateTaskList taskListInfo=%v, ackLevel=%v", request.TaskListInfo, request.TaskListInfo.AckLevel)
 
 	tli := request.TaskListInfo
@@ -1207,11 +2180,58 @@ func (m *testTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequ
 		}
 	}
 	tlm.ackLevel = tli.AckLevel
+	tlm.highPriorityAckLevel = tli.HighPriorityAckLevel
+	tlm.pollers = tli.Pollers
+	tlm.lastUpdated = time.Now()
 	return &persistence.UpdateTaskListResponse{}, nil
 }
 
+// ListTaskLists provides a mock function with given fields: request
+func (m *testTaskManager) ListTaskLists(ctx context.Context, request *persistence.ListTaskListsRequest) (*persistence.ListTaskListsResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	response := &persistence.ListTaskListsResponse{}
+	for id, tlm := range m.taskLists {
+		if len(request.DomainID) > 0 && request.DomainID != id.domainID {
+			continue
+		}
+		tlm.Lock()
+		response.TaskLists = append(response.TaskLists, &persistence.TaskListInfo{
+			DomainID:    id.domainID,
+			Name:        id.taskListName,
+			TaskType:    id.taskType,
+			RangeID:     tlm.rangeID,
+			AckLevel:    tlm.ackLevel,
+			LastUpdated: tlm.lastUpdated,
+			Pollers:     tlm.pollers,
+		})
+		tlm.Unlock()
+	}
+	return response, nil
+}
+
+// DeleteTaskList provides a mock function with given fields: request
+func (m *testTaskManager) DeleteTaskList(ctx context.Context, request *persistence.DeleteTaskListRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	id := newTaskListID(request.DomainID, request.TaskList, request.TaskType)
+	tlm, ok := m.taskLists[*id]
+	if !ok {
+		return nil
+	}
+	tlm.Lock()
+	defer tlm.Unlock()
+	if tlm.rangeID != request.RangeID {
+		return &persistence.ConditionFailedError{
+			Msg: fmt.Sprintf("Failed to delete task list: name=%v, type=%v", request.TaskList, request.TaskType),
+		}
+	}
+	delete(m.taskLists, *id)
+	return nil
+}
+
 // CompleteTask provides a mock function with given fields: request
-func (m *testTaskManager) CompleteTask(request *persistence.CompleteTaskRequest) error {
+func (m *testTaskManager) CompleteTask(ctx context.Context, request *persistence.CompleteTaskRequest) error {
 	m.logger.Debugf("CompleteTask taskID=%v, ackLevel=%v", request.TaskID, request.TaskList.AckLevel)
 	if request.TaskID <= 0 {
 		panic(fmt.Errorf("Invalid taskID=%v", request.TaskID))
@@ -1228,7 +2248,7 @@ func (m *testTaskManager) CompleteTask(request *persistence.CompleteTaskRequest)
 }
 
 // CreateTask provides a mock function with given fields: request
-func (m *testTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*persistence.CreateTasksResponse, error) {
+func (m *testTaskManager) CreateTasks(ctx context.Context, request *persistence.CreateTasksRequest) (*persistence.CreateTasksResponse, error) {
 	domainID := request.DomainID
 	taskList := request.TaskList
 	taskType := request.TaskListType
@@ -1268,6 +2288,10 @@ func (m *testTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (
 			ScheduleID: scheduleID,
 			TaskID:     task.TaskID,
 			WorkflowID: *task.Execution.WorkflowId,
+			BuildID:    task.Data.BuildID,
+			Priority:   task.Data.Priority,
+			SessionID:  task.Data.SessionID,
+			Payload:    task.Data.Payload,
 		})
 		tlm.createTaskCount++
 	}
@@ -1276,7 +2300,7 @@ func (m *testTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (
 }
 
 // GetTasks provides a mock function with given fields: request
-func (m *testTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persistence.GetTasksResponse, error) {
+func (m *testTaskManager) GetTasks(ctx context.Context, request *persistence.GetTasksRequest) (*persistence.GetTasksResponse, error) {
 	m.logger.Debugf("testTaskManager.GetTasks readLevel=%v, maxReadLevel=%v", request.ReadLevel, request.MaxReadLevel)
 
 	tlm := m.getTaskListManager(newTaskListID(request.DomainID, request.TaskList, request.TaskType))
@@ -1299,7 +2323,14 @@ func (m *testTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persi
 		if taskID > request.MaxReadLevel {
 			break
 		}
-		tasks = append(tasks, it.Value().(*persistence.TaskInfo))
+		task := it.Value().(*persistence.TaskInfo)
+		if request.HighPriority != (task.Priority > 0) {
+			continue
+		}
+		tasks = append(tasks, task)
+		if request.BatchSize > 0 && len(tasks) == request.BatchSize {
+			break
+		}
 	}
 	return &persistence.GetTasksResponse{
 		Tasks: tasks,
@@ -22,7 +22,6 @@ package matching
 
 import (
 	"github.com/uber/cadence/common"
-	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
 )
 
@@ -50,18 +49,12 @@ func (s *Service) Start() {
 
 	base := service.New(p)
 
-	taskPersistence, err := persistence.NewCassandraTaskPersistence(p.CassandraConfig.Hosts,
-		p.CassandraConfig.Datacenter,
-		p.CassandraConfig.Keyspace,
-		base.GetLogger())
-
+	taskPersistence, err := base.GetPersistenceFactory().NewTaskManager()
 	if err != nil {
 		log.Fatalf("failed to create task persistence: %v", err)
 	}
 
-	taskPersistence = persistence.NewTaskPersistenceClient(taskPersistence, base.GetMetricsClient())
-
-	handler, tchanServers := NewHandler(taskPersistence, base)
+	handler, tchanServers := NewHandler(taskPersistence, NewConfig(), base)
 	handler.Start(tchanServers)
 
 	log.Infof("%v started", common.MatchingServiceName)
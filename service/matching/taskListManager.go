@@ -22,6 +22,7 @@ package matching
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,6 +33,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/tchannel-go/thrift"
 	"golang.org/x/net/context"
@@ -43,6 +45,8 @@ const (
 	// To perform one db operation if there are no pollers
 	taskBufferSize    = getTasksBatchSize - 1
 	updateAckInterval = 10 * time.Second
+	// How long a task is held back when the owner of its workflow sheds load without a retry-after hint
+	serviceBusyTaskDelay = time.Second
 
 	done time.Duration = -1
 )
@@ -50,26 +54,34 @@ const (
 type taskListManager interface {
 	Start() error
 	Stop()
-	AddTask(execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo) error
-	GetTaskContext(ctx thrift.Context) (*taskContext, error)
+	AddTask(ctx context.Context, execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo) error
+	DispatchQueryTask(ctx context.Context, taskInfo *persistence.TaskInfo) error
+	GetTaskContext(ctx thrift.Context, poller *pollerInfo) (*taskContext, error)
 	String() string
 }
 
 func newTaskListManager(e *matchingEngineImpl, taskList *taskListID) taskListManager {
 	tlMgr := &taskListManagerImpl{
-		engine:     e,
-		taskBuffer: make(chan *persistence.TaskInfo, taskBufferSize),
-		notifyCh:   make(chan struct{}, 1),
-		shutdownCh: make(chan struct{}),
-		taskListID: taskList,
+		engine:                 e,
+		taskBuffer:             make(chan *persistence.TaskInfo, taskBufferSize),
+		highPriorityTaskBuffer: make(chan *persistence.TaskInfo, taskBufferSize),
+		notifyCh:               make(chan struct{}, 1),
+		shutdownCh:             make(chan struct{}),
+		taskListID:             taskList,
 		logger: e.logger.WithFields(bark.Fields{
 			logging.TagTaskListType: taskList.taskType,
 			logging.TagTaskListName: taskList.taskListName,
 		}),
-		taskAckManager: newAckManager(e.logger),
-		syncMatch:      make(chan *getTaskResult),
+		taskAckManager:         newAckManager(e.logger),
+		highPriorityAckManager: newAckManager(e.logger),
+		syncMatch:              make(chan *getTaskResult),
+		deferredTasksChanged:   make(chan struct{}),
+		sessionOwners:          make(map[string]*sessionOwner),
+		pollers:                make(map[string]*pollerState),
+		pollerRegistry:         make(map[string]time.Time),
 	}
 	tlMgr.taskWriter = newTaskWriter(tlMgr, tlMgr.shutdownCh)
+	tlMgr.updateLastActivityTime()
 	return tlMgr
 }
 
@@ -88,6 +100,10 @@ type taskListManagerImpl struct {
 	engine     *matchingEngineImpl
 	taskWriter *taskWriter
 	taskBuffer chan *persistence.TaskInfo // tasks loaded from persistence
+	// Tasks with a positive priority loaded from persistence, offered to pollers ahead of taskBuffer
+	highPriorityTaskBuffer chan *persistence.TaskInfo
+	// Number of high priority tasks dispatched from the buffers since the last normal priority one
+	highPriorityDispatchCount int32
 	// Sync channel used to perform sync matching.
 	// It must to be unbuffered. addTask publishes to it asynchronously and expects publish to succeed
 	// only if there is waiting poll that consumes from it.
@@ -95,12 +111,48 @@ type taskListManagerImpl struct {
 	notifyCh   chan struct{} // Used as signal to notify pump of new tasks
 	shutdownCh chan struct{} // Delivers stop to the pump that populates taskBuffer
 	stopped    int32
+	leaseLost  int32 // Set when another host took over the task list
+	// Number of polls waiting for a task and time of the last poll or added task, used to unload idle task lists
+	outstandingPolls int32
+	lastActivityTime int64
 
 	sync.Mutex
 	taskAckManager          ackManager // tracks ackLevel for delivered messages
+	highPriorityAckManager  ackManager // tracks ackLevel for delivered messages stored with a positive priority
 	rangeID                 int64      // Current range of the task list. Starts from 1.
 	taskSequenceNumber      int64      // Sequence number of the next task. Starts from 1.
 	nextRangeSequenceNumber int64      // Current range boundary
+	// Tasks loaded from persistence which no poller could take so far, because they are pinned to a build or
+	// to a session owned by another poller. deferredTasksChanged is closed and replaced whenever a task is
+	// added or a session is released, to wake up waiting pollers. They hold back the ack level, so the pump
+	// stops reading tasks while there are MaxDeferredTasks of them and the rest stay in persistence.
+	deferredTasks        []*persistence.TaskInfo
+	deferredTasksChanged chan struct{}
+	// Poller identity each activity session is bound to, and the pollers seen on the task list
+	sessionOwners map[string]*sessionOwner
+	pollers       map[string]*pollerState
+	// Time of the last poll of each poller seen on the task list within PollerRetention, persisted with the
+	// ack level so it survives the task list moving to another host
+	pollerRegistry map[string]time.Time
+}
+
+// pollerInfo describes the poller asking for a task
+type pollerInfo struct {
+	identity           string
+	compatibleBuildIDs []string
+}
+
+// sessionOwner is the poller an activity session is bound to, and the time the last task of the session was
+// dispatched, used to forget the sessions which ended
+type sessionOwner struct {
+	identity     string
+	lastDispatch time.Time
+}
+
+// pollerState is used to detect that a poller owning sessions went away
+type pollerState struct {
+	outstandingPolls int
+	lastSeen         time.Time
 }
 
 // getTaskResult contains task info and optional channel to notify createTask caller
@@ -130,23 +182,67 @@ func (c *taskListManagerImpl) Start() error {
 }
 
 // Stops pump that fills up taskBuffer from persistence.
+// The ack level is persisted first, so the next owner of the task list starts from it.
 func (c *taskListManagerImpl) Stop() {
+	if atomic.LoadInt32(&c.stopped) == 1 {
+		return
+	}
+	if err := c.persistAckLevel(); err != nil {
+		logging.LogPersistantStoreErrorEvent(c.logger, logging.TagValueStoreOperationUpdateTaskList, err,
+			fmt.Sprintf("{taskType: %v, taskList: %v}",
+				c.taskListID.taskType, c.taskListID.taskListName))
+	}
+	c.unload(false)
+}
+
+// Stops the task list without persisting the ack level, either because persistence failed or because
+// another host leased the task list. In the later case pollers are drained with errTaskListLeaseLost and
+// the tasks loaded in memory are released, the new owner reads them again from its persisted ack level.
+// Safe to call while holding the task list lock.
+func (c *taskListManagerImpl) unload(leaseLost bool) {
+	if leaseLost {
+		atomic.StoreInt32(&c.leaseLost, 1)
+	}
 	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
 		return
 	}
-	c.logger.Infof("Unloaded %v", c.taskListID)
 	close(c.shutdownCh)
-	c.engine.removeTaskListManager(c.taskListID)
+	if leaseLost {
+		c.logger.Infof("Unloaded %v, lease was taken over by another host", c.taskListID)
+		c.engine.removeLeaseLostTaskListManager(c)
+		return
+	}
+	c.logger.Infof("Unloaded %v", c.taskListID)
+	c.engine.removeTaskListManager(c)
 }
 
-func (c *taskListManagerImpl) AddTask(execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo) error {
+func (c *taskListManagerImpl) isLeaseLost() bool {
+	return atomic.LoadInt32(&c.leaseLost) == 1
+}
+
+// Releases the tasks buffered for pollers, must be called after the pump closed taskBuffer
+func (c *taskListManagerImpl) releaseTasks() {
+	for range c.taskBuffer {
+	}
+	for range c.highPriorityTaskBuffer {
+	}
+	c.Lock()
+	c.taskAckManager = newAckManager(c.logger)
+	c.highPriorityAckManager = newAckManager(c.logger)
+	c.deferredTasks = nil
+	c.Unlock()
+}
+
+func (c *taskListManagerImpl) AddTask(ctx context.Context, execution *s.WorkflowExecution,
+	taskInfo *persistence.TaskInfo) error {
+	c.updateLastActivityTime()
 	_, err := c.executeWithRetry(func(rangeID int64) (interface{}, error) {
-		r, err := c.trySyncMatch(taskInfo)
+		r, err := c.trySyncMatch(ctx, taskInfo)
 		if err != nil || r != nil {
 			return r, err
 		}
 
-		r, err = c.taskWriter.appendTask(execution, taskInfo, rangeID)
+		r, err = c.taskWriter.appendTask(ctx, execution, taskInfo, rangeID)
 		return r, err
 	})
 	if err == nil {
@@ -155,9 +251,34 @@ func (c *taskListManagerImpl) AddTask(execution *s.WorkflowExecution, taskInfo *
 	return err
 }
 
+// DispatchQueryTask hands a query task to a poller, blocking until one takes it. Query tasks are not persisted, the
+// query fails once the caller gives up waiting.
+func (c *taskListManagerImpl) DispatchQueryTask(ctx context.Context, taskInfo *persistence.TaskInfo) error {
+	c.updateLastActivityTime()
+	for {
+		request := &getTaskResult{task: taskInfo, C: make(chan *syncMatchResponse, 1)}
+		select {
+		case c.syncMatch <- request:
+			select {
+			case r := <-request.C:
+				if r.response == nil && r.err == nil {
+					continue // the poller can't take it, wait for another one
+				}
+				return r.err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case <-c.shutdownCh:
+			return errPumpClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // Loads a task from DB or from sync match and wraps it in a task context
-func (c *taskListManagerImpl) GetTaskContext(ctx thrift.Context) (*taskContext, error) {
-	result, err := c.getTask(ctx)
+func (c *taskListManagerImpl) GetTaskContext(ctx thrift.Context, poller *pollerInfo) (*taskContext, error) {
+	result, err := c.getTask(ctx, poller)
 	if err != nil {
 		return nil, err
 	}
@@ -193,15 +314,17 @@ func (c *taskListManagerImpl) persistAckLevel() error {
 	c.Lock()
 	updateTaskListRequest := &persistence.UpdateTaskListRequest{
 		TaskListInfo: &persistence.TaskListInfo{
-			DomainID: c.taskListID.domainID,
-			Name:     c.taskListID.taskListName,
-			TaskType: c.taskListID.taskType,
-			AckLevel: c.taskAckManager.getAckLevel(),
-			RangeID:  c.rangeID,
+			DomainID:             c.taskListID.domainID,
+			Name:                 c.taskListID.taskListName,
+			TaskType:             c.taskListID.taskType,
+			AckLevel:             c.taskAckManager.getAckLevel(),
+			RangeID:              c.rangeID,
+			Pollers:              c.getPollerRegistryLocked(),
+			HighPriorityAckLevel: c.highPriorityAckManager.getAckLevel(),
 		},
 	}
 	c.Unlock()
-	_, err := c.engine.taskManager.UpdateTaskList(updateTaskListRequest)
+	_, err := c.engine.taskManager.UpdateTaskList(context.Background(), updateTaskListRequest)
 	return err
 }
 
@@ -233,52 +356,296 @@ func (c *taskListManagerImpl) getAckLevel() (ackLevel int64) {
 }
 
 // completeTaskPoll should be called after task poll is done even if append has failed.
-// There is no correspondent initiateTaskPoll as append is initiated in getTasksPump
-func (c *taskListManagerImpl) completeTaskPoll(taskID int64) (ackLevel int64) {
+// There is no correspondent initiateTaskPoll as append is initiated in getTasksPump.
+// Returns true if the task was read from the high priority tasks.
+func (c *taskListManagerImpl) completeTaskPoll(taskID int64) (highPriority bool) {
 	c.Lock()
 	defer c.Unlock()
-	ackLevel = c.taskAckManager.completeTask(taskID)
-	return
+	if c.highPriorityAckManager.hasTask(taskID) {
+		c.highPriorityAckManager.completeTask(taskID)
+		return true
+	}
+	c.taskAckManager.completeTask(taskID)
+	return false
 }
 
-// Loads task from taskBuffer (which is populated from persistence) or from sync match to add task call
-func (c *taskListManagerImpl) getTask(ctx thrift.Context) (*getTaskResult, error) {
+// Loads task from taskBuffer (which is populated from persistence) or from sync match to add task call.
+// Tasks pinned to a build the poller is not compatible with, or to a session owned by another poller,
+// are left for other pollers.
+func (c *taskListManagerImpl) getTask(ctx thrift.Context, poller *pollerInfo) (*getTaskResult, error) {
+	if poller == nil {
+		poller = &pollerInfo{}
+	}
+	atomic.AddInt32(&c.outstandingPolls, 1)
+	c.pollStarted(poller.identity)
+	defer func() {
+		atomic.AddInt32(&c.outstandingPolls, -1)
+		c.pollCompleted(poller.identity)
+		c.updateLastActivityTime()
+	}()
+
 	timer := time.NewTimer(c.engine.longPollExpirationInterval)
 	defer timer.Stop()
-	select {
-	case task, ok := <-c.taskBuffer:
-		if !ok { // Task list getTasks pump is shutdown
-			return nil, errPumpClosed
+	for {
+		task, deferredTasksChanged := c.takeDeferredTask(poller)
+		if task != nil {
+			return &getTaskResult{task: task}, nil
 		}
-		return &getTaskResult{task: task}, nil
-	case resultFromSyncMatch := <-c.syncMatch:
-		return resultFromSyncMatch, nil
-	case <-timer.C:
-		return nil, ErrNoTasks
-	case <-ctx.Done():
-		err := ctx.Err()
-		if err == context.DeadlineExceeded {
-			err = ErrNoTasks
+
+		if c.preferHighPriorityTasks() {
+			select {
+			case task, ok := <-c.highPriorityTaskBuffer:
+				result, err := c.getBufferedTask(task, ok, poller)
+				if result == nil && err == nil {
+					continue
+				}
+				return result, err
+			default:
+			}
 		}
-		return nil, err
+
+		select {
+		case task, ok := <-c.highPriorityTaskBuffer:
+			result, err := c.getBufferedTask(task, ok, poller)
+			if result == nil && err == nil {
+				continue
+			}
+			return result, err
+		case task, ok := <-c.taskBuffer:
+			result, err := c.getBufferedTask(task, ok, poller)
+			if result == nil && err == nil {
+				continue
+			}
+			return result, err
+		case resultFromSyncMatch := <-c.syncMatch:
+			if !c.canDispatch(resultFromSyncMatch.task, poller) {
+				// Empty response makes the add task call persist the task instead
+				resultFromSyncMatch.C <- &syncMatchResponse{}
+				continue
+			}
+			return resultFromSyncMatch, nil
+		case <-deferredTasksChanged:
+			continue
+		case <-timer.C:
+			return nil, ErrNoTasks
+		case <-c.engine.shutdownCh:
+			return nil, ErrNoTasks
+		case <-ctx.Done():
+			err := ctx.Err()
+			if err == context.DeadlineExceeded {
+				err = ErrNoTasks
+			}
+			return nil, err
+		}
+	}
+}
+
+// Wraps a task received from one of the task buffers. Returns nil result without error if the task was put aside
+// for a poller compatible with its build or owning its session.
+func (c *taskListManagerImpl) getBufferedTask(task *persistence.TaskInfo, ok bool,
+	poller *pollerInfo) (*getTaskResult, error) {
+	if c.isLeaseLost() { // the task belongs to the new owner of the task list
+		return nil, errTaskListLeaseLost
+	}
+	if !ok { // Task list getTasks pump is shutdown
+		return nil, errPumpClosed
+	}
+	if !c.canDispatch(task, poller) {
+		c.deferTask(task)
+		return nil, nil
+	}
+	if task.Priority > 0 {
+		atomic.AddInt32(&c.highPriorityDispatchCount, 1)
+	} else {
+		atomic.StoreInt32(&c.highPriorityDispatchCount, 0)
+	}
+	return &getTaskResult{task: task}, nil
+}
+
+// High priority tasks are offered first, unless HighPriorityDispatchWeight of them were dispatched in a row.
+// Then both buffers get an equal chance, so normal priority tasks are not starved.
+func (c *taskListManagerImpl) preferHighPriorityTasks() bool {
+	return atomic.LoadInt32(&c.highPriorityDispatchCount) < c.engine.config.HighPriorityDispatchWeight
+}
+
+// Removes and returns the first deferred task the poller can take, along with the channel which is
+// closed once more tasks are deferred or a session is released.
+func (c *taskListManagerImpl) takeDeferredTask(poller *pollerInfo) (*persistence.TaskInfo, <-chan struct{}) {
+	c.Lock()
+	defer c.Unlock()
+	for i, task := range c.deferredTasks {
+		if c.canDispatchLocked(task, poller) {
+			if len(c.deferredTasks) >= c.engine.config.MaxDeferredTasks {
+				c.signalNewTask() // resume the pump
+			}
+			c.deferredTasks = append(c.deferredTasks[:i], c.deferredTasks[i+1:]...)
+			return task, c.deferredTasksChanged
+		}
+	}
+	return nil, c.deferredTasksChanged
+}
+
+func (c *taskListManagerImpl) deferTask(task *persistence.TaskInfo) {
+	c.engine.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.DeferredTasksCounter)
+	c.Lock()
+	defer c.Unlock()
+	c.deferredTasks = append(c.deferredTasks, task)
+	c.notifyDeferredTasksChangedLocked()
+}
+
+// Tasks already buffered can still be deferred once the limit is reached, so there are at most
+// MaxDeferredTasks plus the size of the buffers of them.
+func (c *taskListManagerImpl) isDeferredTasksLimitReached() bool {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.deferredTasks) >= c.engine.config.MaxDeferredTasks
+}
+
+func (c *taskListManagerImpl) notifyDeferredTasksChangedLocked() {
+	close(c.deferredTasksChanged)
+	c.deferredTasksChanged = make(chan struct{})
+}
+
+func (c *taskListManagerImpl) canDispatch(task *persistence.TaskInfo, poller *pollerInfo) bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.canDispatchLocked(task, poller)
+}
+
+// Returns true if the poller can take the task. The first poller taking a task of a session becomes the session
+// owner, it gets all the following tasks of the session until it stops polling for SessionHeartbeatTimeout.
+func (c *taskListManagerImpl) canDispatchLocked(task *persistence.TaskInfo, poller *pollerInfo) bool {
+	if !isCompatibleBuild(task, poller.compatibleBuildIDs) {
+		return false
+	}
+	if task.SessionID == "" {
+		return true
+	}
+	owner, ok := c.sessionOwners[task.SessionID]
+	if ok && owner.identity != poller.identity && c.isPollerAliveLocked(owner.identity) {
+		return false
+	}
+	if ok && owner.identity != poller.identity {
+		c.logger.Infof("Session %v failed over from poller %v to %v", task.SessionID, owner.identity,
+			poller.identity)
+	}
+	c.sessionOwners[task.SessionID] = &sessionOwner{identity: poller.identity, lastDispatch: time.Now()}
+	return true
+}
+
+func (c *taskListManagerImpl) pollStarted(identity string) {
+	c.Lock()
+	defer c.Unlock()
+	state, ok := c.pollers[identity]
+	if !ok {
+		state = &pollerState{}
+		c.pollers[identity] = state
+	}
+	state.outstandingPolls++
+	state.lastSeen = time.Now()
+	c.recordPollLocked(identity, state.lastSeen)
+}
+
+func (c *taskListManagerImpl) pollCompleted(identity string) {
+	c.Lock()
+	defer c.Unlock()
+	if state, ok := c.pollers[identity]; ok {
+		state.outstandingPolls--
+		state.lastSeen = time.Now()
+		c.recordPollLocked(identity, state.lastSeen)
+	}
+}
+
+func (c *taskListManagerImpl) recordPollLocked(identity string, pollTime time.Time) {
+	if identity == "" {
+		return
+	}
+	if lastPoll, ok := c.pollerRegistry[identity]; !ok || lastPoll.Before(pollTime) {
+		c.pollerRegistry[identity] = pollTime
 	}
 }
 
-// Returns a batch of tasks from persistence starting form current read level.
-func (c *taskListManagerImpl) getTaskBatch() ([]*persistence.TaskInfo, error) {
+// Returns a copy of the poller registry after dropping the pollers not seen within PollerRetention
+func (c *taskListManagerImpl) getPollerRegistryLocked() map[string]time.Time {
+	pollers := make(map[string]time.Time, len(c.pollerRegistry))
+	for identity, lastPoll := range c.pollerRegistry {
+		if time.Since(lastPoll) > c.engine.config.PollerRetention {
+			delete(c.pollerRegistry, identity)
+			continue
+		}
+		pollers[identity] = lastPoll
+	}
+	return pollers
+}
+
+func (c *taskListManagerImpl) isPollerAliveLocked(identity string) bool {
+	state, ok := c.pollers[identity]
+	if !ok {
+		return false
+	}
+	return state.outstandingPolls > 0 ||
+		time.Since(state.lastSeen) < c.engine.config.SessionHeartbeatTimeout
+}
+
+// Forgets the pollers which stopped polling and releases their sessions, waking up pollers so deferred tasks of
+// those sessions fail over to them. Sessions without a task dispatched for SessionIdleTimeout are considered
+// ended and forgotten as well, even if their owner is still polling.
+func (c *taskListManagerImpl) releaseLapsedSessions() {
+	c.Lock()
+	defer c.Unlock()
+	released := false
+	for sessionID, owner := range c.sessionOwners {
+		if !c.isPollerAliveLocked(owner.identity) ||
+			time.Since(owner.lastDispatch) >= c.engine.config.SessionIdleTimeout {
+			delete(c.sessionOwners, sessionID)
+			released = true
+		}
+	}
+	for identity := range c.pollers {
+		if !c.isPollerAliveLocked(identity) {
+			delete(c.pollers, identity)
+		}
+	}
+	if released {
+		c.notifyDeferredTasksChangedLocked()
+	}
+}
+
+// isCompatibleBuild returns true if a poller declaring compatibleBuildIDs can process the task.
+// Pollers which don't declare any build get all the tasks.
+func isCompatibleBuild(task *persistence.TaskInfo, compatibleBuildIDs []string) bool {
+	if task.BuildID == "" || len(compatibleBuildIDs) == 0 {
+		return true
+	}
+	for _, buildID := range compatibleBuildIDs {
+		if buildID == task.BuildID {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns a batch of tasks from persistence starting form current read level of the high priority or the
+// other tasks.
+func (c *taskListManagerImpl) getTaskBatch(highPriority bool) ([]*persistence.TaskInfo, error) {
 	response, err := c.executeWithRetry(func(rangeID int64) (interface{}, error) {
 		c.Lock()
+		readLevel := c.taskAckManager.getReadLevel()
+		if highPriority {
+			readLevel = c.highPriorityAckManager.getReadLevel()
+		}
 		request := &persistence.GetTasksRequest{
 			DomainID:     c.taskListID.domainID,
 			TaskList:     c.taskListID.taskListName,
 			TaskType:     c.taskListID.taskType,
 			BatchSize:    getTasksBatchSize,
 			RangeID:      rangeID,
-			ReadLevel:    c.taskAckManager.getReadLevel(),
+			ReadLevel:    readLevel,
 			MaxReadLevel: c.taskWriter.GetMaxReadLevel(),
+			HighPriority: highPriority,
 		}
 		c.Unlock()
-		return c.engine.taskManager.GetTasks(request)
+		return c.engine.taskManager.GetTasks(context.Background(), request)
 	})
 	if err != nil {
 		return nil, err
@@ -300,7 +667,7 @@ func (c *taskListManagerImpl) updateRangeIfNeededLocked(e *matchingEngineImpl) e
 	}
 	var resp *persistence.LeaseTaskListResponse
 	op := func() (err error) {
-		resp, err = e.taskManager.LeaseTaskList(&persistence.LeaseTaskListRequest{
+		resp, err = e.taskManager.LeaseTaskList(context.Background(), &persistence.LeaseTaskListRequest{
 			DomainID: c.taskListID.domainID,
 			TaskList: c.taskListID.taskListName,
 			TaskType: c.taskListID.taskType,
@@ -310,13 +677,21 @@ func (c *taskListManagerImpl) updateRangeIfNeededLocked(e *matchingEngineImpl) e
 	err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
 
 	if err != nil {
-		c.engine.unloadTaskList(c.taskListID)
+		if _, ok := err.(*persistence.ConditionFailedError); ok {
+			c.unload(true)
+			return errTaskListLeaseLost
+		}
+		c.unload(false)
 		return err
 	}
 
 	tli := resp.TaskListInfo
 	c.rangeID = tli.RangeID // Starts from 1
 	c.taskAckManager.setAckLevel(tli.AckLevel)
+	c.highPriorityAckManager.setAckLevel(tli.HighPriorityAckLevel)
+	for identity, lastPoll := range tli.Pollers {
+		c.recordPollLocked(identity, lastPoll)
+	}
 	c.taskSequenceNumber = (tli.RangeID-1)*e.rangeSize + 1
 
 	c.nextRangeSequenceNumber = (tli.RangeID)*e.rangeSize + 1
@@ -325,22 +700,44 @@ func (c *taskListManagerImpl) updateRangeIfNeededLocked(e *matchingEngineImpl) e
 	return nil
 }
 
+func (c *taskListManagerImpl) updateLastActivityTime() {
+	atomic.StoreInt64(&c.lastActivityTime, time.Now().UnixNano())
+}
+
+// Returns true if the task list had no pollers and no tasks for the idle timeout.
+// Must be called by the pump after it read all tasks from persistence.
+func (c *taskListManagerImpl) isIdle() bool {
+	if atomic.LoadInt32(&c.outstandingPolls) > 0 {
+		return false
+	}
+	lastActivityTime := time.Unix(0, atomic.LoadInt64(&c.lastActivityTime))
+	if time.Since(lastActivityTime) < c.engine.config.TaskListIdleTimeout {
+		return false
+	}
+	if len(c.taskBuffer) > 0 || len(c.highPriorityTaskBuffer) > 0 {
+		return false
+	}
+	c.Lock()
+	defer c.Unlock()
+	// all tasks read from persistence are acked
+	return c.taskAckManager.getAckLevel() == c.taskAckManager.getReadLevel() &&
+		c.highPriorityAckManager.getAckLevel() == c.highPriorityAckManager.getReadLevel()
+}
+
 func (c *taskListManagerImpl) String() string {
 	c.Lock()
 	defer c.Unlock()
 
-	var r string
-	if c.taskListID.taskType == persistence.TaskListTypeActivity {
-		r += "Activity"
-	} else {
-		r += "Decision"
-	}
+	r := strings.Title(taskTypeName(c.taskListID.taskType))
 	r += " task list " + c.taskListID.taskListName + "\n"
 	r += fmt.Sprintf("RangeID=%v\n", c.rangeID)
 	r += fmt.Sprintf("TaskSequenceNumber=%v\n", c.taskSequenceNumber)
 	r += fmt.Sprintf("NextRangeSequenceNumber=%v\n", c.nextRangeSequenceNumber)
 	r += fmt.Sprintf("AckLevel=%v\n", c.taskAckManager.ackLevel)
 	r += fmt.Sprintf("MaxReadLevel=%v\n", c.taskAckManager.getReadLevel())
+	r += fmt.Sprintf("HighPriorityAckLevel=%v\n", c.highPriorityAckManager.ackLevel)
+	r += fmt.Sprintf("HighPriorityMaxReadLevel=%v\n", c.highPriorityAckManager.getReadLevel())
+	r += fmt.Sprintf("Pollers=%v\n", c.pollerRegistry)
 
 	return r
 }
@@ -349,23 +746,37 @@ func (c *taskListManagerImpl) String() string {
 // When this method returns non nil response without error it is guaranteed that the task is started
 // and sent to a poller. So it not necessary to persist it.
 // Returns (nil, nil) if there is no waiting poller which indicates that task has to be persisted.
-func (c *taskListManagerImpl) trySyncMatch(task *persistence.TaskInfo) (*persistence.CreateTasksResponse, error) {
+func (c *taskListManagerImpl) trySyncMatch(ctx context.Context,
+	task *persistence.TaskInfo) (*persistence.CreateTasksResponse, error) {
 	// Request from the point of view of Add(Activity|Decision)Task operation.
 	// But it is getTask result from the point of view of a poll operation.
 	request := &getTaskResult{task: task, C: make(chan *syncMatchResponse, 1)}
 	select {
 	case c.syncMatch <- request: // poller goroutine picked up the task
-		r := <-request.C
-		return r.response, r.err
+		select {
+		case r := <-request.C:
+			return r.response, r.err
+		case <-ctx.Done():
+			// the poller owns the task now, response channel is buffered so it never blocks on us
+			return nil, ctx.Err()
+		}
 	default: // no poller waiting for tasks
 		return nil, nil
 	}
 }
 
 func (c *taskListManagerImpl) getTasksPump() {
-	defer close(c.taskBuffer)
+	defer func() {
+		close(c.taskBuffer)
+		close(c.highPriorityTaskBuffer)
+		if c.isLeaseLost() {
+			c.releaseTasks()
+		}
+	}()
 
 	updateAckTimer := time.NewTimer(updateAckInterval)
+	// true if the last batch read from persistence was empty
+	tasksDrained := false
 
 getTasksPumpLoop:
 	for {
@@ -374,7 +785,24 @@ getTasksPumpLoop:
 			break getTasksPumpLoop
 		case <-c.notifyCh:
 			{
-				tasks, err := c.getTaskBatch()
+				if c.isDeferredTasksLimitReached() {
+					// signaled again once a poller takes one of them
+					c.engine.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope,
+						metrics.DeferredTasksLimitReachedCounter)
+					continue getTasksPumpLoop
+				}
+				// High priority tasks are stored apart and read first, so they are not stuck behind a backlog of
+				// other tasks. Once the pump blocks on a full taskBuffer a high priority task waits at most for
+				// the tasks of the batch being buffered.
+				highPriority := true
+				tasks, err := c.getTaskBatch(highPriority)
+				if err == nil && len(tasks) == 0 {
+					highPriority = false
+					tasks, err = c.getTaskBatch(highPriority)
+				}
+				if err == errTaskListLeaseLost {
+					break getTasksPumpLoop
+				}
 				if err != nil {
 					logging.LogPersistantStoreErrorEvent(c.logger, logging.TagValueStoreOperationGetTasks, err,
 						fmt.Sprintf("{taskType: %v, taskList: %v}",
@@ -383,14 +811,24 @@ getTasksPumpLoop:
 					// TODO: Should we ever stop retrying on db errors?
 					continue getTasksPumpLoop
 				}
+				tasksDrained = len(tasks) == 0
 				c.Lock()
 				for _, t := range tasks {
-					c.taskAckManager.addTask(t.TaskID)
+					if highPriority {
+						c.highPriorityAckManager.addTask(t.TaskID)
+					} else {
+						c.taskAckManager.addTask(t.TaskID)
+					}
 				}
 				c.Unlock()
 				for _, t := range tasks {
+					// tasks stored before high priority tasks were stored apart are read with the others
+					buffer := c.taskBuffer
+					if t.Priority > 0 {
+						buffer = c.highPriorityTaskBuffer
+					}
 					select {
-					case c.taskBuffer <- t:
+					case buffer <- t:
 					case <-c.shutdownCh:
 						break getTasksPumpLoop
 					}
@@ -412,6 +850,12 @@ getTasksPumpLoop:
 							c.taskListID.taskType, c.taskListID.taskListName))
 					// keep going as saving ack is not critical
 				}
+				if tasksDrained && c.isIdle() {
+					c.logger.Infof("Unloading idle %v", c.taskListID)
+					c.Stop()
+					break getTasksPumpLoop
+				}
+				c.releaseLapsedSessions()
 				c.signalNewTask() // periodically signal pump to check persistence for tasks
 				updateAckTimer = time.NewTimer(updateAckInterval)
 			}
@@ -421,7 +865,8 @@ getTasksPumpLoop:
 	updateAckTimer.Stop()
 }
 
-// Retry operation on transient error and on rangeID change. On rangeID update by another process calls c.Stop().
+// Retry operation on transient error and on rangeID change. On rangeID update by another process unloads
+// the task list and returns errTaskListLeaseLost.
 func (c *taskListManagerImpl) executeWithRetry(
 	operation func(rangeID int64) (interface{}, error)) (result interface{}, err error) {
 
@@ -454,7 +899,8 @@ func (c *taskListManagerImpl) executeWithRetry(
 
 	if _, ok := err.(*persistence.ConditionFailedError); ok {
 		c.logger.Debugf("Stopping task list due to persistence condition failure. Err: %v", err)
-		c.Stop()
+		c.unload(true)
+		err = errTaskListLeaseLost
 	}
 	return
 }
@@ -468,37 +914,59 @@ func (c *taskListManagerImpl) signalNewTask() {
 }
 
 func (c *taskContext) RecordDecisionTaskStartedWithRetry(
-	request *h.RecordDecisionTaskStartedRequest) (resp *h.RecordDecisionTaskStartedResponse, err error) {
+	ctx thrift.Context, request *h.RecordDecisionTaskStartedRequest) (resp *h.RecordDecisionTaskStartedResponse,
+	err error) {
 	op := func() error {
 		var err error
-		resp, err = c.tlMgr.engine.historyService.RecordDecisionTaskStarted(nil, request)
+		resp, err = c.tlMgr.engine.historyService.RecordDecisionTaskStarted(ctx, request)
 		return err
 	}
 	err = backoff.Retry(op, historyServiceOperationRetryPolicy, func(err error) bool {
-		if _, ok := err.(*s.EntityNotExistsError); !ok {
-			return true
+		if common.IsValidContext(ctx) != nil {
+			return false
 		}
-		return false
+		return !isTaskNotPendingError(err) && !isServiceBusyError(err)
 	})
 	return
 }
 
 func (c *taskContext) RecordActivityTaskStartedWithRetry(
-	request *h.RecordActivityTaskStartedRequest) (resp *h.RecordActivityTaskStartedResponse, err error) {
+	ctx thrift.Context, request *h.RecordActivityTaskStartedRequest) (resp *h.RecordActivityTaskStartedResponse,
+	err error) {
 	op := func() error {
 		var err error
-		resp, err = c.tlMgr.engine.historyService.RecordActivityTaskStarted(nil, request)
+		resp, err = c.tlMgr.engine.historyService.RecordActivityTaskStarted(ctx, request)
 		return err
 	}
 	err = backoff.Retry(op, historyServiceOperationRetryPolicy, func(err error) bool {
-		if _, ok := err.(*s.EntityNotExistsError); !ok {
-			return true
+		if common.IsValidContext(ctx) != nil {
+			return false
 		}
-		return false
+		return !isTaskNotPendingError(err) && !isServiceBusyError(err)
 	})
 	return
 }
 
+// holdTask is used instead of completeTask when the owner of the workflow is shedding load.  A task loaded from
+// persistence is kept in memory, still outstanding for the ack level, and offered to pollers again after a delay.
+// Rewriting it would add to the load, and offering it right away would hit the owner again.  The addTask goroutine
+// of a task received from it directly is notified about the error, as for any other failure.
+func (c *taskContext) holdTask(err error) {
+	if c.syncResponseCh != nil {
+		c.completeTask(err)
+		return
+	}
+	delay := serviceBusyTaskDelay
+	if busy, ok := err.(*s.ServiceBusyError); ok && busy.GetRetryAfterMillis() > 0 {
+		delay = time.Duration(busy.GetRetryAfterMillis()) * time.Millisecond
+	}
+	tlMgr := c.tlMgr
+	task := c.info
+	time.AfterFunc(delay, func() {
+		tlMgr.deferTask(task)
+	})
+}
+
 // If poll received task from addTask directly the addTask goroutine is notified about start task result.
 // If poll received task from persistence then task is deleted from it if no error was reported.
 func (c *taskContext) completeTask(err error) {
@@ -520,9 +988,11 @@ func (c *taskContext) completeTask(err error) {
 		// This will allow subsequent tasks to make progress, and hopefully by the time this task is picked-up
 		// again the underlying reason for failing to start will be resolved.
 		// Note that RecordTaskStarted only fails after retrying for a long time, so a single task will not be
-		// re-written to persistence frequently.
+		// re-written to persistence frequently.  Tasks rejected by an overloaded owner are held back in memory by
+		// holdTask instead.
 		_, err = tlMgr.executeWithRetry(func(rangeID int64) (interface{}, error) {
-			return tlMgr.taskWriter.appendTask(&c.workflowExecution, c.info, rangeID)
+			// not bound to the poll request, the task must not be lost if the poller goes away
+			return tlMgr.taskWriter.appendTask(context.Background(), &c.workflowExecution, c.info, rangeID)
 		})
 
 		if err != nil {
@@ -538,17 +1008,18 @@ func (c *taskContext) completeTask(err error) {
 		tlMgr.signalNewTask()
 	}
 
-	tlMgr.completeTaskPoll(c.info.TaskID)
+	highPriority := tlMgr.completeTaskPoll(c.info.TaskID)
 
 	// TODO: use range deletes to complete all tasks below ack level instead of completing
 	// tasks one by one.
-	err2 := tlMgr.engine.taskManager.CompleteTask(&persistence.CompleteTaskRequest{
+	err2 := tlMgr.engine.taskManager.CompleteTask(context.Background(), &persistence.CompleteTaskRequest{
 		TaskList: &persistence.TaskListInfo{
 			DomainID: tlMgr.taskListID.domainID,
 			Name:     tlMgr.taskListID.taskListName,
 			TaskType: tlMgr.taskListID.taskType,
 		},
-		TaskID: c.info.TaskID,
+		TaskID:       c.info.TaskID,
+		HighPriority: highPriority,
 	})
 
 	if err2 != nil {
@@ -558,8 +1029,10 @@ func (c *taskContext) completeTask(err error) {
 	}
 }
 
+// createServiceBusyError throttles callers appending to a saturated task writer, with a hint on when to retry
 func createServiceBusyError() *s.ServiceBusyError {
 	err := s.NewServiceBusyError()
 	err.Message = "Too many outstanding appends to the TaskList"
+	err.RetryAfterMillis = common.Int64Ptr(int64(appendThrottleRetryAfter / time.Millisecond))
 	return err
 }
@@ -0,0 +1,177 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
+
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	taskListScavengerPageSize = 100
+)
+
+const (
+	taskListScavengerStatusInitialized = iota
+	taskListScavengerStatusStarted
+	taskListScavengerStatusStopped
+)
+
+type (
+	// taskListScavenger periodically deletes the task lists which were not used for
+	// TaskListExpiry and have no tasks left
+	taskListScavenger struct {
+		engine     *matchingEngineImpl
+		status     int32
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+		logger     bark.Logger
+	}
+)
+
+func newTaskListScavenger(e *matchingEngineImpl) *taskListScavenger {
+	return &taskListScavenger{
+		engine:     e,
+		status:     taskListScavengerStatusInitialized,
+		shutdownCh: make(chan struct{}),
+		logger:     e.logger.WithField(logging.TagWorkflowComponent, logging.TagValueTaskListScavenger),
+	}
+}
+
+func (s *taskListScavenger) Start() {
+	if !atomic.CompareAndSwapInt32(&s.status, taskListScavengerStatusInitialized, taskListScavengerStatusStarted) {
+		return
+	}
+	s.shutdownWG.Add(1)
+	go s.scavengeLoop()
+}
+
+func (s *taskListScavenger) Stop() {
+	if !atomic.CompareAndSwapInt32(&s.status, taskListScavengerStatusStarted, taskListScavengerStatusStopped) {
+		return
+	}
+	close(s.shutdownCh)
+	s.shutdownWG.Wait()
+}
+
+func (s *taskListScavenger) scavengeLoop() {
+	defer s.shutdownWG.Done()
+
+	ticker := time.NewTicker(s.engine.config.TaskListScavengerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-ticker.C:
+			s.scavenge()
+		}
+	}
+}
+
+func (s *taskListScavenger) scavenge() {
+	var deleted int
+	var pageToken []byte
+	for {
+		response, err := s.engine.taskManager.ListTaskLists(context.Background(), &persistence.ListTaskListsRequest{
+			PageSize:      taskListScavengerPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			s.logger.WithField(logging.TagErr, err).Warn("Failed to list task lists")
+			return
+		}
+
+		for _, tli := range response.TaskLists {
+			select {
+			case <-s.shutdownCh:
+				return
+			default:
+			}
+			if s.deleteIfExpired(tli) {
+				deleted++
+			}
+		}
+
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+	s.logger.Infof("Deleted %v expired task lists", deleted)
+}
+
+// deleteIfExpired deletes the task list if it was not used for TaskListExpiry and has no tasks left.
+// Returns true if the task list was deleted.
+func (s *taskListScavenger) deleteIfExpired(tli *persistence.TaskListInfo) bool {
+	// task lists written before the last update time was tracked are kept until they are used again
+	if tli.LastUpdated.IsZero() || time.Since(tli.LastUpdated) < s.engine.config.TaskListExpiry {
+		return false
+	}
+	if s.engine.isTaskListLoaded(newTaskListID(tli.DomainID, tli.Name, tli.TaskType)) {
+		return false
+	}
+
+	// tasks without a schedule to start timeout never expire, keep the task list until they are dispatched
+	for _, highPriority := range []bool{false, true} {
+		readLevel := tli.AckLevel
+		if highPriority {
+			readLevel = tli.HighPriorityAckLevel
+		}
+		response, err := s.engine.taskManager.GetTasks(context.Background(), &persistence.GetTasksRequest{
+			DomainID:     tli.DomainID,
+			TaskList:     tli.Name,
+			TaskType:     tli.TaskType,
+			ReadLevel:    readLevel,
+			MaxReadLevel: math.MaxInt64,
+			BatchSize:    1,
+			RangeID:      tli.RangeID,
+			HighPriority: highPriority,
+		})
+		if err != nil || len(response.Tasks) > 0 {
+			return false
+		}
+	}
+
+	err := s.engine.taskManager.DeleteTaskList(context.Background(), &persistence.DeleteTaskListRequest{
+		DomainID: tli.DomainID,
+		TaskList: tli.Name,
+		TaskType: tli.TaskType,
+		RangeID:  tli.RangeID,
+	})
+	if err != nil {
+		// a condition failure means the task list was leased again in the meantime
+		if _, ok := err.(*persistence.ConditionFailedError); !ok {
+			s.logger.WithField(logging.TagErr, err).Warnf("Failed to delete expired task list %v", tli.Name)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"golang.org/x/net/context"
+
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	taskListWarmerMembershipUpdateListenerName = "taskListWarmer"
+	taskListWarmerPageSize                     = 100
+)
+
+const (
+	taskListWarmerStatusInitialized = iota
+	taskListWarmerStatusStarted
+	taskListWarmerStatusStopped
+)
+
+type (
+	// taskListWarmer loads the recently used task lists which the hash ring assigns to this host whenever
+	// the membership changes, so their leases are acquired and ack levels read in the background instead of
+	// by the first poll or task arriving at the new owner
+	taskListWarmer struct {
+		engine             *matchingEngineImpl
+		resolver           membership.ServiceResolver
+		host               *membership.HostInfo
+		status             int32
+		membershipUpdateCh chan *membership.ChangedEvent
+		shutdownCh         chan struct{}
+		shutdownWG         sync.WaitGroup
+		logger             bark.Logger
+	}
+)
+
+func newTaskListWarmer(e *matchingEngineImpl, resolver membership.ServiceResolver,
+	host *membership.HostInfo) *taskListWarmer {
+	return &taskListWarmer{
+		engine:             e,
+		resolver:           resolver,
+		host:               host,
+		status:             taskListWarmerStatusInitialized,
+		membershipUpdateCh: make(chan *membership.ChangedEvent, 10),
+		shutdownCh:         make(chan struct{}),
+		logger:             e.logger.WithField(logging.TagWorkflowComponent, logging.TagValueTaskListWarmer),
+	}
+}
+
+func (w *taskListWarmer) Start() {
+	if !atomic.CompareAndSwapInt32(&w.status, taskListWarmerStatusInitialized, taskListWarmerStatusStarted) {
+		return
+	}
+	if err := w.resolver.AddListener(taskListWarmerMembershipUpdateListenerName, w.membershipUpdateCh); err != nil {
+		w.logger.WithField(logging.TagErr, err).Warn("Failed to add membership update listener")
+	}
+	w.shutdownWG.Add(1)
+	go w.warmUpLoop()
+}
+
+func (w *taskListWarmer) Stop() {
+	if !atomic.CompareAndSwapInt32(&w.status, taskListWarmerStatusStarted, taskListWarmerStatusStopped) {
+		return
+	}
+	if err := w.resolver.RemoveListener(taskListWarmerMembershipUpdateListenerName); err != nil {
+		w.logger.WithField(logging.TagErr, err).Warn("Failed to remove membership update listener")
+	}
+	close(w.shutdownCh)
+	w.shutdownWG.Wait()
+}
+
+func (w *taskListWarmer) warmUpLoop() {
+	defer w.shutdownWG.Done()
+
+	w.warmUp()
+	for {
+		select {
+		case <-w.shutdownCh:
+			return
+		case <-w.membershipUpdateCh:
+			w.warmUp()
+		}
+	}
+}
+
+func (w *taskListWarmer) warmUp() {
+	var loaded int
+	var pageToken []byte
+	for {
+		response, err := w.engine.taskManager.ListTaskLists(context.Background(), &persistence.ListTaskListsRequest{
+			PageSize:      taskListWarmerPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			w.logger.WithField(logging.TagErr, err).Warn("Failed to list task lists")
+			return
+		}
+
+		for _, tli := range response.TaskLists {
+			select {
+			case <-w.shutdownCh:
+				return
+			default:
+			}
+			if w.loadIfOwned(tli) {
+				loaded++
+			}
+		}
+
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+	w.logger.Infof("Loaded %v task lists ahead of their first request", loaded)
+}
+
+// loadIfOwned loads the task list if it was used within TaskListWarmUpWindow, is owned by this host and not
+// loaded yet. Returns true if the task list was loaded.
+func (w *taskListWarmer) loadIfOwned(tli *persistence.TaskListInfo) bool {
+	if tli.LastUpdated.IsZero() || time.Since(tli.LastUpdated) > w.engine.config.TaskListWarmUpWindow {
+		return false
+	}
+	id := newTaskListID(tli.DomainID, tli.Name, tli.TaskType)
+	if w.engine.isTaskListLoaded(id) {
+		return false
+	}
+	// matching hosts are looked up by task list name, see the matching client
+	owner, err := w.resolver.Lookup(tli.Name)
+	if err != nil || owner.Identity() != w.host.Identity() {
+		return false
+	}
+	if _, err := w.engine.getTaskListManager(id); err != nil {
+		if err != errTaskListLeaseLost {
+			w.logger.WithField(logging.TagErr, err).Warnf("Failed to load %v", id)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"fmt"
+
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/tchannel-go/thrift"
+)
+
+type (
+	// startTaskFunc records a task handed out to a poller as started at the owner of the task, like the history
+	// service for decision and activity tasks, and returns the data the poll response is created from.
+	// Task types which carry more than the scheduled event find their data in the TaskInfo.Payload envelope.
+	startTaskFunc func(ctx thrift.Context, tCtx *taskContext, requestID string) (interface{}, error)
+)
+
+// Task lists hold the tasks of a single type, persistence.TaskListType* enumerates the types. A new type of task only
+// needs a name here, it is added with addTask and handed out by pollTask with its own startTaskFunc.
+var taskTypeNames = map[int]string{
+	persistence.TaskListTypeDecision: "decision",
+	persistence.TaskListTypeActivity: "activity",
+}
+
+func taskTypeName(taskType int) string {
+	if name, ok := taskTypeNames[taskType]; ok {
+		return name
+	}
+	return fmt.Sprintf("type %v", taskType)
+}
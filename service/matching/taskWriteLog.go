@@ -0,0 +1,172 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	taskWriteLogEntry struct {
+		Execution *s.WorkflowExecution
+		TaskInfo  *persistence.TaskInfo
+	}
+
+	// taskWriteLog is a local append only file holding the tasks of a task list which were acknowledged
+	// before being written to persistence. The file is truncated once all of them are written or failed,
+	// keeping only the failed ones. Entries left in the file are handed off to the new owner when another
+	// host takes over the task list, or written again when the task list is loaded here next, so tasks are
+	// written at least once.
+	taskWriteLog struct {
+		sync.Mutex
+		file          *os.File
+		pending       int                  // number of entries not yet written to persistence
+		failedEntries []*taskWriteLogEntry // entries which failed to be written, kept for the next load
+	}
+)
+
+// newTaskWriteLog opens the write log of the task list and returns the entries left from the previous owner
+func newTaskWriteLog(dir string, id *taskListID) (*taskWriteLog, []*taskWriteLogEntry, error) {
+	name := fmt.Sprintf("%v_%v_%v.log", url.QueryEscape(id.domainID), id.taskType, url.QueryEscape(id.taskListName))
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, err := readTaskWriteLog(file)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return &taskWriteLog{file: file, pending: len(entries)}, entries, nil
+}
+
+func readTaskWriteLog(file *os.File) ([]*taskWriteLogEntry, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	var entries []*taskWriteLogEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := &taskWriteLogEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			// a partially written last line, the task was not acknowledged
+			break
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// append durably records the task, it must be written to persistence and reported through flushed or failed
+// afterwards
+func (l *taskWriteLog) append(execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo) error {
+	l.Lock()
+	defer l.Unlock()
+	if err := l.writeLocked(&taskWriteLogEntry{Execution: execution, TaskInfo: taskInfo}); err != nil {
+		return err
+	}
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+	l.pending++
+	return nil
+}
+
+// flushed is called after count entries were written to persistence
+func (l *taskWriteLog) flushed(count int) error {
+	l.Lock()
+	defer l.Unlock()
+	l.pending -= count
+	return l.truncateLocked()
+}
+
+// failed is called with the entries which could not be written to persistence
+func (l *taskWriteLog) failed(entries []*taskWriteLogEntry) error {
+	l.Lock()
+	defer l.Unlock()
+	l.pending -= len(entries)
+	l.failedEntries = append(l.failedEntries, entries...)
+	return l.truncateLocked()
+}
+
+// entries returns the entries left in the log, which may include entries of batches already written to
+// persistence while others were pending
+func (l *taskWriteLog) entries() ([]*taskWriteLogEntry, error) {
+	l.Lock()
+	defer l.Unlock()
+	return readTaskWriteLog(l.file)
+}
+
+// reset rewrites the log with only the given entries, once the others were handed off to the new owner
+func (l *taskWriteLog) reset(entries []*taskWriteLogEntry) error {
+	l.Lock()
+	defer l.Unlock()
+	l.pending = 0
+	l.failedEntries = entries
+	return l.truncateLocked()
+}
+
+// truncateLocked rewrites the log with only the failed entries once no entry is pending
+func (l *taskWriteLog) truncateLocked() error {
+	if l.pending > 0 {
+		return nil
+	}
+	l.pending = 0
+	if err := l.file.Truncate(0); err != nil {
+		return err
+	}
+	if len(l.failedEntries) == 0 {
+		return nil
+	}
+	for _, entry := range l.failedEntries {
+		if err := l.writeLocked(entry); err != nil {
+			return err
+		}
+	}
+	return l.file.Sync()
+}
+
+func (l *taskWriteLog) writeLocked(entry *taskWriteLogEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = l.file.Write(append(data, '\n'))
+	return err
+}
+
+func (l *taskWriteLog) close() {
+	l.Lock()
+	defer l.Unlock()
+	l.file.Close()
+}
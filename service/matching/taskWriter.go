@@ -22,17 +22,25 @@ package matching
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/uber-common/bark"
 	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/tchannel-go/thrift"
+	"golang.org/x/net/context"
 )
 
 const (
 	outstandingTaskAppendsThreshold = 250
 	maxTaskBatchSize                = 100
+	// appendThrottleRetryAfter is how long callers are asked to back off once outstandingTaskAppendsThreshold is hit
+	appendThrottleRetryAfter = 500 * time.Millisecond
 )
 
 type (
@@ -57,11 +65,16 @@ type (
 		maxReadLevel int64
 		shutdownCh   chan struct{}
 		logger       bark.Logger
+		// Set when appended tasks are acknowledged once recorded in the local write log, before being
+		// written to persistence. asyncLock orders the log appends with the requests sent to appendCh.
+		writeLog   *taskWriteLog
+		asyncLock  sync.Mutex
+		logEntries []*taskWriteLogEntry // left in the write log by the previous owner
 	}
 )
 
 func newTaskWriter(tlMgr *taskListManagerImpl, shutdownCh chan struct{}) *taskWriter {
-	return &taskWriter{
+	w := &taskWriter{
 		tlMgr:       tlMgr,
 		taskListID:  tlMgr.taskListID,
 		taskManager: tlMgr.engine.taskManager,
@@ -69,16 +82,36 @@ func newTaskWriter(tlMgr *taskListManagerImpl, shutdownCh chan struct{}) *taskWr
 		appendCh:    make(chan *writeTaskRequest, outstandingTaskAppendsThreshold),
 		logger:      tlMgr.logger,
 	}
+
+	config := tlMgr.engine.config
+	if config.AsyncTaskWriteLogDir != "" && config.AsyncTaskWriteTaskLists[w.taskListID.taskListName] {
+		writeLog, entries, err := newTaskWriteLog(config.AsyncTaskWriteLogDir, w.taskListID)
+		if err != nil {
+			w.logger.WithField(logging.TagErr, err).Warn("Failed to open task write log, writing tasks synchronously")
+		} else {
+			w.writeLog = writeLog
+			w.logEntries = entries
+		}
+	}
+	return w
 }
 
 func (w *taskWriter) Start() {
 	w.maxReadLevel = w.tlMgr.getTaskSequenceNumber() - 1
 	go w.taskWriterLoop()
+	if len(w.logEntries) > 0 {
+		go w.replayWriteLog()
+	}
 }
 
-func (w *taskWriter) appendTask(execution *s.WorkflowExecution,
+func (w *taskWriter) appendTask(ctx context.Context, execution *s.WorkflowExecution,
 	taskInfo *persistence.TaskInfo, rangeID int64) (*persistence.CreateTasksResponse, error) {
-	ch := make(chan *writeTaskResponse)
+	if w.writeLog != nil {
+		return w.appendTaskAsync(execution, taskInfo, rangeID)
+	}
+
+	// buffered so that the writer never blocks on a caller which gave up waiting
+	ch := make(chan *writeTaskResponse, 1)
 	req := &writeTaskRequest{
 		execution:  execution,
 		taskInfo:   taskInfo,
@@ -88,19 +121,67 @@ func (w *taskWriter) appendTask(execution *s.WorkflowExecution,
 
 	select {
 	case w.appendCh <- req:
-		r := <-ch
-		return r.persistenceResponse, r.err
+		select {
+		case r := <-ch:
+			return r.persistenceResponse, r.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	default: // channel is full, throttle
 		return nil, createServiceBusyError()
 	}
 }
 
+// appendTaskAsync returns as soon as the task is recorded in the write log, the task is written to
+// persistence with the next batch.
+func (w *taskWriter) appendTaskAsync(execution *s.WorkflowExecution,
+	taskInfo *persistence.TaskInfo, rangeID int64) (*persistence.CreateTasksResponse, error) {
+	w.asyncLock.Lock()
+	defer w.asyncLock.Unlock()
+	if len(w.appendCh) >= cap(w.appendCh) { // throttle
+		return nil, createServiceBusyError()
+	}
+	if err := w.writeLog.append(execution, taskInfo); err != nil {
+		return nil, err
+	}
+
+	// only senders holding asyncLock add to appendCh, so this does not block
+	w.appendCh <- &writeTaskRequest{
+		execution:  execution,
+		taskInfo:   taskInfo,
+		rangeID:    rangeID,
+		responseCh: make(chan *writeTaskResponse, 1),
+	}
+	return &persistence.CreateTasksResponse{}, nil
+}
+
+// replayWriteLog writes the tasks acknowledged by the previous owner of the write log to persistence
+func (w *taskWriter) replayWriteLog() {
+	w.logger.Infof("Replaying %v tasks from the task write log", len(w.logEntries))
+	for _, entry := range w.logEntries {
+		req := &writeTaskRequest{
+			execution:  entry.Execution,
+			taskInfo:   entry.TaskInfo,
+			responseCh: make(chan *writeTaskResponse, 1),
+		}
+		select {
+		case w.appendCh <- req:
+		case <-w.shutdownCh:
+			return
+		}
+	}
+	w.logEntries = nil
+}
+
 func (w *taskWriter) GetMaxReadLevel() int64 {
 	return atomic.LoadInt64(&w.maxReadLevel)
 }
 
 func (w *taskWriter) taskWriterLoop() {
 	defer close(w.appendCh)
+	if w.writeLog != nil {
+		defer w.closeWriteLog()
+	}
 
 writerLoop:
 	for {
@@ -110,51 +191,14 @@ writerLoop:
 				// read a batch of requests from the channel
 				reqs := []*writeTaskRequest{request}
 				reqs = w.getWriteBatch(reqs)
-				batchSize := len(reqs)
 
-				maxReadLevel := int64(0)
-
-				taskIDs, err := w.tlMgr.newTaskIDs(batchSize)
-				if err != nil {
-					w.sendWriteResponse(reqs, err, nil)
-					continue writerLoop
-				}
-
-				tasks := []*persistence.CreateTaskInfo{}
-				rangeID := int64(0)
-				for i, req := range reqs {
-					tasks = append(tasks, &persistence.CreateTaskInfo{
-						TaskID:    taskIDs[i],
-						Execution: *req.execution,
-						Data:      req.taskInfo,
-					})
-					if req.rangeID > rangeID {
-						rangeID = req.rangeID // use the maximum rangeID provided for the write operation
-					}
-					maxReadLevel = taskIDs[i]
-				}
-
-				r, err := w.taskManager.CreateTasks(&persistence.CreateTasksRequest{
-					DomainID:     w.taskListID.domainID,
-					TaskList:     w.taskListID.taskListName,
-					TaskListType: w.taskListID.taskType,
-					Tasks:        tasks,
-					// Note that newTaskID could increment range, so rangeID parameter
-					// might be out of sync. This is OK as caller can just retry.
-					RangeID: rangeID,
-				})
-
-				if err != nil {
-					logging.LogPersistantStoreErrorEvent(w.logger, logging.TagValueStoreOperationCreateTask, err,
-						fmt.Sprintf("{taskID: [%v, %v], taskType: %v, taskList: %v}",
-							taskIDs[0], taskIDs[batchSize-1], w.taskListID.taskType, w.taskListID.taskListName))
-				}
-
-				// Update the maxReadLevel after the writes are completed.
-				if maxReadLevel > 0 {
-					atomic.StoreInt64(&w.maxReadLevel, maxReadLevel)
+				var r *persistence.CreateTasksResponse
+				var err error
+				if w.writeLog != nil {
+					r, err = w.writeLoggedBatch(reqs)
+				} else {
+					r, err = w.writeBatch(reqs)
 				}
-
 				w.sendWriteResponse(reqs, err, r)
 			}
 		case <-w.shutdownCh:
@@ -163,6 +207,127 @@ writerLoop:
 	}
 }
 
+func (w *taskWriter) writeBatch(reqs []*writeTaskRequest) (*persistence.CreateTasksResponse, error) {
+	batchSize := len(reqs)
+	maxReadLevel := int64(0)
+
+	taskIDs, err := w.tlMgr.newTaskIDs(batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []*persistence.CreateTaskInfo{}
+	rangeID := int64(0)
+	for i, req := range reqs {
+		tasks = append(tasks, &persistence.CreateTaskInfo{
+			TaskID:    taskIDs[i],
+			Execution: *req.execution,
+			Data:      req.taskInfo,
+		})
+		if req.rangeID > rangeID {
+			rangeID = req.rangeID // use the maximum rangeID provided for the write operation
+		}
+		maxReadLevel = taskIDs[i]
+	}
+	if w.writeLog != nil {
+		// nobody waits to retry logged tasks, use the range the task IDs were allocated from
+		rangeID = w.tlMgr.getRangeID()
+	}
+
+	r, err := w.taskManager.CreateTasks(context.Background(), &persistence.CreateTasksRequest{
+		DomainID:     w.taskListID.domainID,
+		TaskList:     w.taskListID.taskListName,
+		TaskListType: w.taskListID.taskType,
+		Tasks:        tasks,
+		// Note that newTaskID could increment range, so rangeID parameter
+		// might be out of sync. This is OK as caller can just retry.
+		RangeID: rangeID,
+	})
+
+	if err != nil {
+		logging.LogPersistantStoreErrorEvent(w.logger, logging.TagValueStoreOperationCreateTask, err,
+			fmt.Sprintf("{taskID: [%v, %v], taskType: %v, taskList: %v}",
+				taskIDs[0], taskIDs[batchSize-1], w.taskListID.taskType, w.taskListID.taskListName))
+	}
+
+	// Update the maxReadLevel after the writes are completed.
+	if maxReadLevel > 0 {
+		atomic.StoreInt64(&w.maxReadLevel, maxReadLevel)
+	}
+
+	return r, err
+}
+
+// writeLoggedBatch writes tasks already acknowledged to their callers, retrying transient failures.
+// Tasks which still fail are left in the write log until the task list is loaded again.
+func (w *taskWriter) writeLoggedBatch(reqs []*writeTaskRequest) (r *persistence.CreateTasksResponse, err error) {
+	op := func() error {
+		r, err = w.writeBatch(reqs)
+		return err
+	}
+	err = backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
+	if err != nil {
+		entries := make([]*taskWriteLogEntry, 0, len(reqs))
+		for _, req := range reqs {
+			entries = append(entries, &taskWriteLogEntry{Execution: req.execution, TaskInfo: req.taskInfo})
+		}
+		if err := w.writeLog.failed(entries); err != nil {
+			w.logger.WithField(logging.TagErr, err).Warn("Failed to truncate task write log")
+		}
+		if _, ok := err.(*persistence.ConditionFailedError); ok {
+			w.tlMgr.unload(true)
+		}
+		return
+	}
+
+	if err := w.writeLog.flushed(len(reqs)); err != nil {
+		w.logger.WithField(logging.TagErr, err).Warn("Failed to truncate task write log")
+	}
+	// AddTask signaled before the tasks were readable
+	w.tlMgr.signalNewTask()
+	return
+}
+
+// closeWriteLog closes the write log once the writer stopped. When another host took over the task list the
+// tasks left in the log are handed off to it, instead of waiting for the task list to be loaded here again.
+// Holding asyncLock keeps new tasks from being logged meanwhile.
+func (w *taskWriter) closeWriteLog() {
+	w.asyncLock.Lock()
+	defer w.asyncLock.Unlock()
+	if w.tlMgr.isLeaseLost() {
+		w.handOffWriteLog()
+	}
+	w.writeLog.close()
+}
+
+// handOffWriteLog adds the tasks left in the write log to the task list through its new owner, the tasks which
+// fail to be handed off are kept in the log. The handoff is bounded by the lease handoff interval of the engine,
+// after which the task list may be leased by this host again and replay the log itself.
+func (w *taskWriter) handOffWriteLog() {
+	entries, err := w.writeLog.entries()
+	if err != nil {
+		w.logger.WithField(logging.TagErr, err).Warn("Failed to read task write log")
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	ctx, cancel := thrift.NewContext(w.tlMgr.engine.leaseHandoffInterval)
+	defer cancel()
+	var kept []*taskWriteLogEntry
+	for _, entry := range entries {
+		if err := w.tlMgr.engine.handOffTask(ctx, w.taskListID, entry); err != nil {
+			kept = append(kept, entry)
+		}
+	}
+	w.logger.Infof("Handed off %v of %v tasks of the task write log to the new owner of the task list",
+		len(entries)-len(kept), len(entries))
+	if err := w.writeLog.reset(kept); err != nil {
+		w.logger.WithField(logging.TagErr, err).Warn("Failed to truncate task write log")
+	}
+}
+
 func (w *taskWriter) getWriteBatch(reqs []*writeTaskRequest) []*writeTaskRequest {
 readLoop:
 	for i := 0; i < maxTaskBatchSize; i++ {
@@ -128,7 +128,7 @@ func (s *UpdateSchemaTestSuite) TestDryrun() {
 	ver, err := client.ReadSchemaVersion()
 	s.Nil(err)
 	// update the version to the latest
-	s.Equal(0, cmpVersion(ver, "0.1"))
+	s.Equal(0, cmpVersion(ver, "0.26"))
 
 	dropAllTablesTypes(client)
 }